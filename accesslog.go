@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+// openAccessLog returns a logger writing JSON access log records to path, or
+// to stderr if path is "-". The returned function closes the file.
+func openAccessLog(path string) (*slog.Logger, func() error, error) {
+	if path == "-" {
+		return slog.New(slog.NewJSONHandler(os.Stderr, nil)), func() error { return nil }, nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open access log: %w", err)
+	}
+	return newAccessLogger(f), f.Close, nil
+}
+
+func newAccessLogger(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, nil))
+}
+
+// accessLogHandler logs a record for every request once it has been served:
+// the method and path, the RPC for Connect, gRPC and gRPC-Web requests, the
+// peer, the HTTP status (and the gRPC status when it's sent as a trailer), the
+// response size and how long it took. Streams are logged when they end.
+func accessLogHandler(h http.Handler, logger *slog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		lw := &accessLogResponseWriter{ResponseWriter: w}
+		defer func() {
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+			}
+			if rpc := rpcName(r.URL.Path); rpc != "" {
+				attrs = append(attrs, slog.String("rpc", rpc))
+			}
+			attrs = append(attrs, slog.String("peer", r.RemoteAddr))
+			if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+				attrs = append(attrs, slog.String("forwarded_for", forwarded))
+			}
+			attrs = append(attrs, slog.Int("status", lw.statusCode()))
+			if code, ok := lw.grpcStatus(); ok {
+				attrs = append(attrs, slog.String("grpc_status", code))
+			}
+			attrs = append(attrs,
+				slog.Int64("bytes", lw.bytes),
+				slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			)
+			if ua := r.Header.Get("User-Agent"); ua != "" {
+				attrs = append(attrs, slog.String("user_agent", ua))
+			}
+			logger.LogAttrs(r.Context(), slog.LevelInfo, "access", attrs...)
+		}()
+		h.ServeHTTP(lw, r)
+	})
+}
+
+// rpcName returns the procedure of an RPC path, like
+// mitmflow.v1.Service/DeleteFlows, or "" for other paths.
+func rpcName(path string) string {
+	service, method, ok := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if !ok || method == "" || strings.Contains(method, "/") || !strings.Contains(service, ".") {
+		return ""
+	}
+	return service + "/" + method
+}
+
+// accessLogResponseWriter records the status and size of a response.
+type accessLogResponseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *accessLogResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *accessLogResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.bytes += int64(n)
+	return n, err
+}
+
+func (w *accessLogResponseWriter) Flush() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	http.NewResponseController(w.ResponseWriter).Flush() //nolint:errcheck
+}
+
+func (w *accessLogResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *accessLogResponseWriter) statusCode() int {
+	if w.status == 0 {
+		// Nothing was written, which net/http answers with a 200.
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// grpcStatus returns the status of a gRPC response from its trailers, named
+// like Connect's error codes.
+func (w *accessLogResponseWriter) grpcStatus() (string, bool) {
+	value := w.Header().Get("Grpc-Status")
+	if value == "" {
+		value = w.Header().Get(http.TrailerPrefix + "Grpc-Status")
+	}
+	if value == "" {
+		return "", false
+	}
+	n, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return value, true
+	}
+	if n == 0 {
+		return "ok", true
+	}
+	return connect.Code(n).String(), true
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+func TestAccessLogHandler(t *testing.T) {
+	server, _ := newTestServer(t)
+	mux := http.NewServeMux()
+	mux.Handle(mitmflowv1.NewServiceHandler(server))
+	mux.HandleFunc("/grpc", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(http.TrailerPrefix+"Grpc-Status", "5")
+	})
+
+	var buf bytes.Buffer
+	handler := accessLogHandler(mux, newAccessLogger(&buf))
+	serve := func(method, path, body string) map[string]any {
+		buf.Reset()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", "203.0.113.7")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		record := map[string]any{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		return record
+	}
+
+	record := serve(http.MethodPost, "/mitmflow.v1.Service/GetServerInfo", "{}")
+	assert.Equal(t, "access", record["msg"])
+	assert.Equal(t, "POST", record["method"])
+	assert.Equal(t, "mitmflow.v1.Service/GetServerInfo", record["rpc"])
+	assert.Equal(t, "192.0.2.1:1234", record["peer"])
+	assert.Equal(t, "203.0.113.7", record["forwarded_for"])
+	assert.EqualValues(t, 200, record["status"])
+	assert.Greater(t, record["bytes"], float64(0))
+	assert.Contains(t, record, "latency_ms")
+
+	record = serve(http.MethodPost, "/mitmflow.v1.Service/DeleteFlows", `{"flowIds": 1}`)
+	assert.EqualValues(t, 400, record["status"])
+
+	record = serve(http.MethodGet, "/assets/index.js", "")
+	assert.NotContains(t, record, "rpc")
+	assert.EqualValues(t, 404, record["status"])
+
+	record = serve(http.MethodPost, "/grpc", "")
+	assert.Equal(t, "not_found", record["grpc_status"])
+}
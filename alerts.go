@@ -0,0 +1,397 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+const (
+	// alertNotifyTimeout bounds how long sending one alert to a notifier
+	// takes.
+	alertNotifyTimeout = 10 * time.Second
+	// maxAlertURLLength keeps long URLs from flooding chat messages.
+	maxAlertURLLength = 300
+	// maxDiscordMessageLength is the most characters Discord accepts in a
+	// message.
+	maxDiscordMessageLength = 2000
+)
+
+// alertRule trips when count flows matching expr arrive within window,
+// counted separately per server host or client when by is set. A rule with
+// a count of 1 trips for every matching flow.
+type alertRule struct {
+	name   string
+	expr   string
+	match  flowPredicate
+	count  int
+	window time.Duration
+	// by is "", "host" or "client".
+	by string
+}
+
+// parseAlertRule parses a rule such as
+// "name=api-5xx,count=11,window=60s,by=host,expr=~c 5xx". expr comes last and
+// takes the rest of the rule, so it may contain commas. count defaults to 1,
+// window to a minute and name to expr.
+func parseAlertRule(spec string) (alertRule, error) {
+	rule := alertRule{count: 1, window: time.Minute}
+	rest := spec
+	for rest != "" {
+		var field string
+		if strings.HasPrefix(rest, "expr=") {
+			field, rest = rest, ""
+		} else {
+			field, rest, _ = strings.Cut(rest, ",")
+		}
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return alertRule{}, fmt.Errorf("invalid alert rule %q: expected KEY=VALUE, got %q", spec, field)
+		}
+		var err error
+		switch key {
+		case "name":
+			rule.name = value
+		case "expr":
+			rule.expr = value
+		case "count":
+			rule.count, err = strconv.Atoi(value)
+			if err == nil && rule.count < 1 {
+				err = fmt.Errorf("count must be at least 1")
+			}
+		case "window":
+			rule.window, err = time.ParseDuration(value)
+			if err == nil && rule.window <= 0 {
+				err = fmt.Errorf("window must be positive")
+			}
+		case "by":
+			if value != "host" && value != "client" {
+				err = fmt.Errorf("by must be host or client")
+			}
+			rule.by = value
+		default:
+			err = fmt.Errorf("unknown key %q", key)
+		}
+		if err != nil {
+			return alertRule{}, fmt.Errorf("invalid alert rule %q: %w", spec, err)
+		}
+	}
+	if rule.expr == "" {
+		return alertRule{}, fmt.Errorf("invalid alert rule %q: expr is required", spec)
+	}
+	match, err := parseFilterExpr(rule.expr)
+	if err != nil {
+		return alertRule{}, fmt.Errorf("invalid alert rule %q: %w", spec, err)
+	}
+	rule.match = match
+	if rule.name == "" {
+		rule.name = rule.expr
+	}
+	return rule, nil
+}
+
+// alert is sent to notifiers when a rule trips.
+type alert struct {
+	Rule string `json:"rule"`
+	Expr string `json:"expr"`
+	// Group is the server host or client the flows were counted for, if the
+	// rule counts them separately.
+	Group   string    `json:"group,omitempty"`
+	Count   int       `json:"count"`
+	Window  string    `json:"window"`
+	At      time.Time `json:"at"`
+	FlowIDs []string  `json:"flow_ids"`
+	// Flow is the flow that tripped the rule.
+	Flow alertFlow `json:"flow"`
+}
+
+// alertFlow summarizes a flow in an alert.
+type alertFlow struct {
+	ID       string `json:"id"`
+	Protocol string `json:"protocol,omitempty"`
+	Host     string `json:"host,omitempty"`
+	Method   string `json:"method,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Status   int32  `json:"status,omitempty"`
+	// Link opens the flow in the UI. It needs -public-url.
+	Link string `json:"link,omitempty"`
+}
+
+func newAlertFlow(flow *mitmflowv1.Flow, publicURL string) alertFlow {
+	f := alertFlow{
+		ID:       GetFlowID(flow),
+		Protocol: flowProtocol(flow),
+		Host:     flowServerHost(flow),
+	}
+	if h := flow.GetHttpFlow(); h != nil {
+		f.Method = h.GetRequest().GetMethod()
+		f.URL = h.GetRequest().GetUrl()
+		f.Status = h.GetResponse().GetStatusCode()
+	}
+	if publicURL != "" {
+		f.Link = strings.TrimSuffix(publicURL, "/") + "/?flow=" + url.QueryEscape(f.ID)
+	}
+	return f
+}
+
+// describe is a one line description of the flow, like
+// "GET https://example.com/ → 503".
+func (f alertFlow) describe() string {
+	switch {
+	case f.Method == "":
+		return strings.TrimSpace(f.Protocol + " " + f.Host)
+	case f.Status == 0:
+		return f.Method + " " + truncateString(f.URL, maxAlertURLLength)
+	}
+	return fmt.Sprintf("%s %s → %d", f.Method, truncateString(f.URL, maxAlertURLLength), f.Status)
+}
+
+func (a alert) String() string {
+	return fmt.Sprintf("alert %q: %s", a.Rule, a.summary())
+}
+
+// summary says what tripped the alert, e.g. "3 flows matched ~c 5xx for
+// api.example.com within 1m0s".
+func (a alert) summary() string {
+	where := ""
+	if a.Group != "" {
+		where = " for " + a.Group
+	}
+	if a.Count == 1 {
+		return fmt.Sprintf("flow %s matched %s%s", a.FlowIDs[0], a.Expr, where)
+	}
+	return fmt.Sprintf("%d flows matched %s%s within %s", a.Count, a.Expr, where, a.Window)
+}
+
+// alertNotifier delivers alerts somewhere.
+type alertNotifier interface {
+	notify(ctx context.Context, a alert) error
+}
+
+// logNotifier writes alerts to the log.
+type logNotifier struct{}
+
+func (logNotifier) notify(_ context.Context, a alert) error {
+	log.Print(a)
+	return nil
+}
+
+// webhookNotifier posts alerts as JSON to a URL, either as they are or as
+// the message a chat service expects.
+type webhookNotifier struct {
+	url     string
+	client  *http.Client
+	payload func(alert) any
+}
+
+// parseAlertNotifier parses an -alert-webhook: a URL that alerts are posted
+// to as JSON, or slack=URL or discord=URL for an incoming webhook of Slack or
+// Discord.
+func parseAlertNotifier(spec string) (*webhookNotifier, error) {
+	payload := func(a alert) any { return a }
+	if service, rest, ok := strings.Cut(spec, "="); ok && !strings.Contains(service, ":") {
+		switch service {
+		case "slack":
+			payload = slackMessage
+		case "discord":
+			payload = discordMessage
+		default:
+			return nil, fmt.Errorf("invalid alert webhook %q: unknown service %q, expected slack or discord", spec, service)
+		}
+		spec = rest
+	}
+	u, err := url.Parse(spec)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid alert webhook %q: expected an http or https URL", spec)
+	}
+	return &webhookNotifier{url: spec, client: &http.Client{Timeout: alertNotifyTimeout}, payload: payload}, nil
+}
+
+// slackMessage formats an alert for a Slack incoming webhook, in Slack's
+// mrkdwn.
+func slackMessage(a alert) any {
+	text := fmt.Sprintf("*%s*: %s\n`%s`", slackEscape(a.Rule), slackEscape(a.summary()), slackEscape(a.Flow.describe()))
+	if a.Flow.Link != "" {
+		text += fmt.Sprintf(" <%s|Open in mitmflow>", a.Flow.Link)
+	}
+	return map[string]string{"text": text}
+}
+
+// slackEscape escapes the characters Slack treats as control characters.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// discordMessage formats an alert for a Discord webhook, in markdown. Mentions
+// are disabled so an expression or URL can't ping anyone.
+func discordMessage(a alert) any {
+	content := fmt.Sprintf("**%s**: %s\n`%s`", a.Rule, a.summary(), strings.ReplaceAll(a.Flow.describe(), "`", "'"))
+	if a.Flow.Link != "" {
+		content += fmt.Sprintf(" [Open in mitmflow](<%s>)", a.Flow.Link)
+	}
+	return map[string]any{
+		"content":          truncateString(content, maxDiscordMessageLength),
+		"allowed_mentions": map[string]any{"parse": []string{}},
+	}
+}
+
+func (n *webhookNotifier) notify(ctx context.Context, a alert) error {
+	body, err := json.Marshal(n.payload(a))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook %s returned %s", n.url, resp.Status)
+	}
+	return nil
+}
+
+type alertKey struct {
+	rule  int
+	group string
+}
+
+type alertMatch struct {
+	flowID string
+	at     time.Time
+}
+
+// alertGroup is what a rule counted for one host or client: the matches
+// since it last tripped and every flow that matched within the window, so
+// a flow that is updated isn't counted twice.
+type alertGroup struct {
+	pending []alertMatch
+	recent  map[string]time.Time
+}
+
+// alerter checks ingested flows against alert rules and notifies when they
+// trip. After a rule trips for a group its count starts over.
+type alerter struct {
+	rules     []alertRule
+	notifiers []alertNotifier
+	// publicURL is where the UI is served, for links to flows.
+	publicURL string
+
+	mu     sync.Mutex
+	groups map[alertKey]*alertGroup
+}
+
+func newAlerter() *alerter {
+	return &alerter{groups: make(map[alertKey]*alertGroup)}
+}
+
+func (a *alerter) enabled() bool {
+	return len(a.rules) > 0
+}
+
+// observe counts a flow towards the rules it matches and returns the alerts
+// that tripped.
+func (a *alerter) observe(flow *mitmflowv1.Flow, now time.Time) []alert {
+	if !a.enabled() {
+		return nil
+	}
+	id := GetFlowID(flow)
+	at := flowStartTime(flow, now)
+	var alerts []alert
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i, rule := range a.rules {
+		if !rule.match(flow) {
+			continue
+		}
+		var group string
+		switch rule.by {
+		case "host":
+			group = flowServerHost(flow)
+		case "client":
+			group = flowClientHost(flow)
+		}
+		key := alertKey{rule: i, group: group}
+		g, ok := a.groups[key]
+		if !ok {
+			g = &alertGroup{recent: make(map[string]time.Time)}
+			a.groups[key] = g
+		}
+		g.prune(at.Add(-rule.window))
+		if _, seen := g.recent[id]; seen {
+			continue
+		}
+		g.recent[id] = at
+		g.pending = append(g.pending, alertMatch{flowID: id, at: at})
+		if len(g.pending) < rule.count {
+			continue
+		}
+		ids := make([]string, len(g.pending))
+		for j, m := range g.pending {
+			ids[j] = m.flowID
+		}
+		g.pending = nil
+		alerts = append(alerts, alert{
+			Rule:    rule.name,
+			Expr:    rule.expr,
+			Group:   group,
+			Count:   rule.count,
+			Window:  rule.window.String(),
+			At:      at,
+			FlowIDs: ids,
+			Flow:    newAlertFlow(flow, a.publicURL),
+		})
+	}
+	return alerts
+}
+
+// prune forgets matches from before cutoff.
+func (g *alertGroup) prune(cutoff time.Time) {
+	i := 0
+	for i < len(g.pending) && g.pending[i].at.Before(cutoff) {
+		i++
+	}
+	g.pending = g.pending[i:]
+	for id, at := range g.recent {
+		if at.Before(cutoff) {
+			delete(g.recent, id)
+		}
+	}
+}
+
+// notify sends alerts to every notifier, logging the ones that fail.
+func (a *alerter) notify(ctx context.Context, alerts []alert) {
+	for _, al := range alerts {
+		for _, n := range a.notifiers {
+			ctx, cancel := context.WithTimeout(ctx, alertNotifyTimeout)
+			if err := n.notify(ctx, al); err != nil {
+				log.Printf("failed to send alert %q: %v", al.Rule, err)
+			}
+			cancel()
+		}
+	}
+}
+
+// truncateString cuts s down to at most n runes, marking the cut with "…".
+func truncateString(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestParseAlertRule(t *testing.T) {
+	rule, err := parseAlertRule("name=api-5xx,count=11,window=30s,by=host,expr=~c 5xx | ~u a,b")
+	require.NoError(t, err)
+	assert.Equal(t, "api-5xx", rule.name)
+	assert.Equal(t, "~c 5xx | ~u a,b", rule.expr)
+	assert.Equal(t, 11, rule.count)
+	assert.Equal(t, 30*time.Second, rule.window)
+	assert.Equal(t, "host", rule.by)
+
+	rule, err = parseAlertRule("expr=~e")
+	require.NoError(t, err)
+	assert.Equal(t, "~e", rule.name)
+	assert.Equal(t, 1, rule.count)
+
+	for _, spec := range []string{"", "count=2", "count=0,expr=~e", "by=path,expr=~e", "expr=~bogus", "window=1m"} {
+		_, err := parseAlertRule(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestAlerter(t *testing.T) {
+	rule, err := parseAlertRule("name=5xx,count=3,window=60s,by=host,expr=~c 5xx")
+	require.NoError(t, err)
+	a := newAlerter()
+	a.rules = []alertRule{rule}
+	a.publicURL = "https://mitmflow.example.com/ui/"
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	n := 0
+	flow := func(host string, status int32, at time.Duration) *mitmflowv1.Flow {
+		n++
+		return mitmflowv1.Flow_builder{
+			HttpFlow: mitmproxyv1.HTTPFlow_builder{
+				Id:             proto.String(fmt.Sprint(n)),
+				TimestampStart: timestamppb.New(start.Add(at)),
+				Request:        mitmproxyv1.Request_builder{Url: proto.String("https://" + host + "/")}.Build(),
+				Response:       mitmproxyv1.Response_builder{StatusCode: proto.Int32(status)}.Build(),
+			}.Build(),
+		}.Build()
+	}
+
+	assert.Empty(t, a.observe(flow("api.example.com", 500, 0), start))
+	assert.Empty(t, a.observe(flow("api.example.com", 200, time.Second), start))
+	assert.Empty(t, a.observe(flow("cdn.example.com", 503, 2*time.Second), start))
+	repeated := flow("api.example.com", 502, 3*time.Second)
+	assert.Empty(t, a.observe(repeated, start))
+	assert.Empty(t, a.observe(repeated, start), "an updated flow isn't counted twice")
+	// The first 5xx has left the window.
+	assert.Empty(t, a.observe(flow("api.example.com", 500, 61*time.Second), start))
+
+	alerts := a.observe(flow("api.example.com", 504, 62*time.Second), start)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "5xx", alerts[0].Rule)
+	assert.Equal(t, "api.example.com", alerts[0].Group)
+	assert.Equal(t, []string{"4", "5", "6"}, alerts[0].FlowIDs)
+	assert.Equal(t, `alert "5xx": 3 flows matched ~c 5xx for api.example.com within 1m0s`, alerts[0].String())
+	assert.Equal(t, alertFlow{
+		ID:     "6",
+		Host:   "api.example.com",
+		URL:    "https://api.example.com/",
+		Status: 504,
+		Link:   "https://mitmflow.example.com/ui/?flow=6",
+	}, alerts[0].Flow)
+
+	assert.Empty(t, a.observe(flow("api.example.com", 500, 63*time.Second), start), "the count starts over")
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	received := make(chan alert, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var a alert
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&a))
+		received <- a
+	}))
+	defer srv.Close()
+
+	sent := alert{Rule: "errors", Expr: "~e", Count: 1, Window: "1m0s", FlowIDs: []string{"f1"}}
+	notifier, err := parseAlertNotifier(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, notifier.notify(context.Background(), sent))
+	got := <-received
+	assert.Equal(t, sent.FlowIDs, got.FlowIDs)
+	assert.Equal(t, sent.Rule, got.Rule)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	notifier, err = parseAlertNotifier(failing.URL)
+	require.NoError(t, err)
+	assert.Error(t, notifier.notify(context.Background(), sent))
+
+	for _, spec := range []string{"ftp://example.com", "teams=https://example.com/hook", "slack=", "not a url"} {
+		_, err := parseAlertNotifier(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestChatNotifiers(t *testing.T) {
+	received := make(chan map[string]any, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		received <- msg
+	}))
+	defer srv.Close()
+
+	a := alert{
+		Rule:    "checkout <errors>",
+		Expr:    "~c 5xx",
+		Group:   "api.example.com",
+		Count:   11,
+		Window:  "1m0s",
+		FlowIDs: []string{"f1"},
+		Flow: alertFlow{
+			ID:     "f1",
+			Method: "POST",
+			URL:    "https://api.example.com/checkout",
+			Status: 503,
+			Link:   "https://mitmflow.example.com/?flow=f1",
+		},
+	}
+
+	slack, err := parseAlertNotifier("slack=" + srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, slack.notify(context.Background(), a))
+	assert.Equal(t, map[string]any{
+		"text": "*checkout &lt;errors&gt;*: 11 flows matched ~c 5xx for api.example.com within 1m0s\n" +
+			"`POST https://api.example.com/checkout → 503` <https://mitmflow.example.com/?flow=f1|Open in mitmflow>",
+	}, <-received)
+
+	discord, err := parseAlertNotifier("discord=" + srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, discord.notify(context.Background(), a))
+	assert.Equal(t, map[string]any{
+		"content": "**checkout <errors>**: 11 flows matched ~c 5xx for api.example.com within 1m0s\n" +
+			"`POST https://api.example.com/checkout → 503` [Open in mitmflow](<https://mitmflow.example.com/?flow=f1>)",
+		"allowed_mentions": map[string]any{"parse": []any{}},
+	}, <-received)
+}
@@ -0,0 +1,653 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// amqpHeaderPrefix starts the protocol header a client opens an AMQP
+// connection with. The four bytes after it pick the version: 0 0 9 1 for
+// AMQP 0-9-1, and 0 1 0 0 or, to start with SASL, 3 1 0 0 for AMQP 1.0.
+var amqpHeaderPrefix = []byte("AMQP")
+
+const (
+	// amqpFrameEnd ends every AMQP 0-9-1 frame.
+	amqpFrameEnd = 0xce
+	// maxAMQPFrameSize bounds the frames that are buffered until complete.
+	maxAMQPFrameSize = 16 << 20
+	// maxAMQPDepth bounds how deeply AMQP 1.0 values may nest.
+	maxAMQPDepth = 32
+	// maxAMQPElements bounds the elements of an AMQP 1.0 list, map or array.
+	maxAMQPElements = 10000
+)
+
+var errShortAMQPFrame = errors.New("amqp frame is cut short")
+
+func isAMQPHeader(first []byte) bool {
+	return len(first) >= 8 && bytes.HasPrefix(first, amqpHeaderPrefix)
+}
+
+// amqpDecoder renders AMQP 0-9-1 and 1.0 frames. The version comes from the
+// protocol header the client sends, so a capture that missed it is taken
+// for 0-9-1.
+type amqpDecoder struct {
+	v1 bool
+}
+
+func (d *amqpDecoder) decode(data []byte, _ bool) ([]string, []byte, error) {
+	var frames []string
+	for len(data) > 0 {
+		if bytes.HasPrefix(data, amqpHeaderPrefix) || bytes.HasPrefix(amqpHeaderPrefix, data) {
+			if len(data) < 8 {
+				return frames, data, nil
+			}
+			frame, err := d.header(data[:8])
+			if err != nil {
+				return nil, nil, err
+			}
+			frames = append(frames, frame)
+			data = data[8:]
+			continue
+		}
+		var frame string
+		var n int
+		var err error
+		if d.v1 {
+			frame, n, err = amqp1Frame(data)
+		} else {
+			frame, n, err = amqp091Frame(data)
+		}
+		if errors.Is(err, errShortAMQPFrame) {
+			return frames, data, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		frames = append(frames, frame)
+		data = data[n:]
+	}
+	return frames, nil, nil
+}
+
+func (d *amqpDecoder) header(h []byte) (string, error) {
+	switch {
+	case h[4] == 0 && h[5] == 0 && h[6] == 9 && h[7] == 1:
+		d.v1 = false
+		return "AMQP 0-9-1", nil
+	case h[4] == 0 && h[5] == 1:
+		d.v1 = true
+		return "AMQP 1.0", nil
+	case h[4] == 3 && h[5] == 1:
+		d.v1 = true
+		return "AMQP 1.0 (SASL)", nil
+	case h[4] == 2 && h[5] == 1:
+		return "", errors.New("amqp connection upgrades to TLS")
+	}
+	return "", fmt.Errorf("unknown amqp protocol header % x", h[4:])
+}
+
+// amqpReader reads the fields of an AMQP frame.
+type amqpReader struct {
+	wireReader
+	depth int
+}
+
+func (r *amqpReader) shortstr() string {
+	return string(r.take(int(r.u8())))
+}
+
+func (r *amqpReader) longstr() []byte {
+	return r.take(int(r.u32()))
+}
+
+// amqp091Frame renders the AMQP 0-9-1 frame at the start of data and returns
+// its size.
+func amqp091Frame(data []byte) (string, int, error) {
+	if len(data) < 7 {
+		return "", 0, errShortAMQPFrame
+	}
+	typ := data[0]
+	channel := binary.BigEndian.Uint16(data[1:])
+	size := binary.BigEndian.Uint32(data[3:])
+	if size > maxAMQPFrameSize {
+		return "", 0, fmt.Errorf("amqp frame of %d bytes is too large", size)
+	}
+	end := 7 + int(size)
+	if end >= len(data) {
+		return "", 0, errShortAMQPFrame
+	}
+	if data[end] != amqpFrameEnd {
+		return "", 0, errors.New("amqp frame is missing its frame end")
+	}
+	r := &amqpReader{wireReader: wireReader{data: data[7:end]}}
+	var frame string
+	switch typ {
+	case 1:
+		frame = amqp091MethodFrame(r, channel)
+	case 2:
+		frame = amqp091ContentHeader(r, channel)
+	case 3:
+		frame = fmt.Sprintf("body (channel %d): %s\n%s", channel, countOf(int(size), "byte"), streamPayload(r.data))
+	case 8:
+		frame = "heartbeat"
+	default:
+		return "", 0, fmt.Errorf("unknown amqp frame type %d", typ)
+	}
+	if r.err != nil {
+		return "", 0, fmt.Errorf("amqp frame: %w", r.err)
+	}
+	return frame, end + 1, nil
+}
+
+// amqp091Method names an AMQP 0-9-1 method and its arguments, in order.
+// Arguments without a name are reserved and not rendered.
+type amqp091Method struct {
+	name string
+	args []amqp091Arg
+}
+
+type amqp091Arg struct {
+	// kind is o for octet, s for short, l for long, L for long long, b for a
+	// bit, S for a short string, T for a long string and F for a table.
+	kind byte
+	name string
+}
+
+// amqpArgs builds arguments from their kind followed by their name, e.g.
+// "Squeue".
+func amqpArgs(spec ...string) []amqp091Arg {
+	args := make([]amqp091Arg, len(spec))
+	for i, s := range spec {
+		args[i] = amqp091Arg{kind: s[0], name: s[1:]}
+	}
+	return args
+}
+
+var amqp091Methods = map[[2]uint16]amqp091Method{
+	{10, 10}:  {"connection.start", amqpArgs("oversion-major", "oversion-minor", "F", "Tmechanisms", "Tlocales")},
+	{10, 11}:  {"connection.start-ok", amqpArgs("F", "Smechanism", "Tresponse", "Slocale")},
+	{10, 20}:  {"connection.secure", amqpArgs("T")},
+	{10, 21}:  {"connection.secure-ok", amqpArgs("Tresponse")},
+	{10, 30}:  {"connection.tune", amqpArgs("schannel-max", "lframe-max", "sheartbeat")},
+	{10, 31}:  {"connection.tune-ok", amqpArgs("schannel-max", "lframe-max", "sheartbeat")},
+	{10, 40}:  {"connection.open", amqpArgs("Svirtual-host", "S", "b")},
+	{10, 41}:  {"connection.open-ok", amqpArgs("S")},
+	{10, 50}:  {"connection.close", amqpArgs("sreply-code", "Sreply-text", "sclass-id", "smethod-id")},
+	{10, 51}:  {"connection.close-ok", nil},
+	{10, 60}:  {"connection.blocked", amqpArgs("Sreason")},
+	{10, 61}:  {"connection.unblocked", nil},
+	{20, 10}:  {"channel.open", amqpArgs("S")},
+	{20, 11}:  {"channel.open-ok", amqpArgs("T")},
+	{20, 20}:  {"channel.flow", amqpArgs("bactive")},
+	{20, 21}:  {"channel.flow-ok", amqpArgs("bactive")},
+	{20, 40}:  {"channel.close", amqpArgs("sreply-code", "Sreply-text", "sclass-id", "smethod-id")},
+	{20, 41}:  {"channel.close-ok", nil},
+	{40, 10}:  {"exchange.declare", amqpArgs("s", "Sexchange", "Stype", "bpassive", "bdurable", "bauto-delete", "binternal", "bno-wait", "F")},
+	{40, 11}:  {"exchange.declare-ok", nil},
+	{40, 20}:  {"exchange.delete", amqpArgs("s", "Sexchange", "bif-unused", "bno-wait")},
+	{40, 21}:  {"exchange.delete-ok", nil},
+	{40, 30}:  {"exchange.bind", amqpArgs("s", "Sdestination", "Ssource", "Srouting-key", "bno-wait", "F")},
+	{40, 31}:  {"exchange.bind-ok", nil},
+	{40, 40}:  {"exchange.unbind", amqpArgs("s", "Sdestination", "Ssource", "Srouting-key", "bno-wait", "F")},
+	{40, 51}:  {"exchange.unbind-ok", nil},
+	{50, 10}:  {"queue.declare", amqpArgs("s", "Squeue", "bpassive", "bdurable", "bexclusive", "bauto-delete", "bno-wait", "F")},
+	{50, 11}:  {"queue.declare-ok", amqpArgs("Squeue", "lmessage-count", "lconsumer-count")},
+	{50, 20}:  {"queue.bind", amqpArgs("s", "Squeue", "Sexchange", "Srouting-key", "bno-wait", "F")},
+	{50, 21}:  {"queue.bind-ok", nil},
+	{50, 30}:  {"queue.purge", amqpArgs("s", "Squeue", "bno-wait")},
+	{50, 31}:  {"queue.purge-ok", amqpArgs("lmessage-count")},
+	{50, 40}:  {"queue.delete", amqpArgs("s", "Squeue", "bif-unused", "bif-empty", "bno-wait")},
+	{50, 41}:  {"queue.delete-ok", amqpArgs("lmessage-count")},
+	{50, 50}:  {"queue.unbind", amqpArgs("s", "Squeue", "Sexchange", "Srouting-key", "F")},
+	{50, 51}:  {"queue.unbind-ok", nil},
+	{60, 10}:  {"basic.qos", amqpArgs("lprefetch-size", "sprefetch-count", "bglobal")},
+	{60, 11}:  {"basic.qos-ok", nil},
+	{60, 20}:  {"basic.consume", amqpArgs("s", "Squeue", "Sconsumer-tag", "bno-local", "bno-ack", "bexclusive", "bno-wait", "F")},
+	{60, 21}:  {"basic.consume-ok", amqpArgs("Sconsumer-tag")},
+	{60, 30}:  {"basic.cancel", amqpArgs("Sconsumer-tag", "bno-wait")},
+	{60, 31}:  {"basic.cancel-ok", amqpArgs("Sconsumer-tag")},
+	{60, 40}:  {"basic.publish", amqpArgs("s", "Sexchange", "Srouting-key", "bmandatory", "bimmediate")},
+	{60, 50}:  {"basic.return", amqpArgs("sreply-code", "Sreply-text", "Sexchange", "Srouting-key")},
+	{60, 60}:  {"basic.deliver", amqpArgs("Sconsumer-tag", "Ldelivery-tag", "bredelivered", "Sexchange", "Srouting-key")},
+	{60, 70}:  {"basic.get", amqpArgs("s", "Squeue", "bno-ack")},
+	{60, 71}:  {"basic.get-ok", amqpArgs("Ldelivery-tag", "bredelivered", "Sexchange", "Srouting-key", "lmessage-count")},
+	{60, 72}:  {"basic.get-empty", amqpArgs("S")},
+	{60, 80}:  {"basic.ack", amqpArgs("Ldelivery-tag", "bmultiple")},
+	{60, 90}:  {"basic.reject", amqpArgs("Ldelivery-tag", "brequeue")},
+	{60, 100}: {"basic.recover-async", amqpArgs("brequeue")},
+	{60, 110}: {"basic.recover", amqpArgs("brequeue")},
+	{60, 111}: {"basic.recover-ok", nil},
+	{60, 120}: {"basic.nack", amqpArgs("Ldelivery-tag", "bmultiple", "brequeue")},
+	{85, 10}:  {"confirm.select", amqpArgs("bno-wait")},
+	{85, 11}:  {"confirm.select-ok", nil},
+	{90, 10}:  {"tx.select", nil},
+	{90, 11}:  {"tx.select-ok", nil},
+	{90, 20}:  {"tx.commit", nil},
+	{90, 21}:  {"tx.commit-ok", nil},
+	{90, 30}:  {"tx.rollback", nil},
+	{90, 31}:  {"tx.rollback-ok", nil},
+}
+
+func amqp091MethodFrame(r *amqpReader, channel uint16) string {
+	class, id := r.u16(), r.u16()
+	method, ok := amqp091Methods[[2]uint16{class, id}]
+	if !ok {
+		return fmt.Sprintf("method %d.%d (channel %d)", class, id, channel)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (channel %d)", method.name, channel)
+	// Consecutive bits are packed into octets, lowest bit first.
+	var bits byte
+	bit := 8
+	for _, arg := range method.args {
+		if arg.kind != 'b' {
+			bit = 8
+		}
+		var value string
+		switch arg.kind {
+		case 'o':
+			value = fmt.Sprint(r.u8())
+		case 's':
+			value = fmt.Sprint(r.u16())
+		case 'l':
+			value = fmt.Sprint(r.u32())
+		case 'L':
+			value = fmt.Sprint(r.u64())
+		case 'b':
+			if bit == 8 {
+				bits, bit = r.u8(), 0
+			}
+			value = fmt.Sprint(bits&(1<<bit) != 0)
+			bit++
+		case 'S':
+			value = fmt.Sprintf("%q", r.shortstr())
+		case 'T':
+			value = fmt.Sprintf("%q", r.longstr())
+		case 'F':
+			r.longstr()
+		}
+		switch {
+		case arg.name == "" || arg.kind == 'F':
+		case arg.name == "response":
+			b.WriteString("\n  response: (redacted)")
+		default:
+			fmt.Fprintf(&b, "\n  %s: %s", arg.name, value)
+		}
+	}
+	return b.String()
+}
+
+// amqp091Properties are the basic class properties of a content header, in
+// the order of their flag bits from the highest.
+var amqp091Properties = amqpArgs(
+	"Scontent-type", "Scontent-encoding", "Fheaders", "odelivery-mode", "opriority",
+	"Scorrelation-id", "Sreply-to", "Sexpiration", "Smessage-id", "Ltimestamp",
+	"Stype", "Suser-id", "Sapp-id", "S",
+)
+
+func amqp091ContentHeader(r *amqpReader, channel uint16) string {
+	r.u16() // class
+	r.u16() // weight
+	size := r.u64()
+	flags := r.u16()
+	var b strings.Builder
+	fmt.Fprintf(&b, "content header (channel %d): %s", channel, countOf(int(size), "byte"))
+	for i, prop := range amqp091Properties {
+		if flags&(1<<(15-i)) == 0 {
+			continue
+		}
+		var value string
+		switch prop.kind {
+		case 'S':
+			value = r.shortstr()
+		case 'o':
+			value = fmt.Sprint(r.u8())
+		case 'L':
+			value = time.Unix(int64(r.u64()), 0).UTC().Format(time.RFC3339)
+		case 'F':
+			r.longstr()
+			continue
+		}
+		if prop.name != "" {
+			fmt.Fprintf(&b, "\n  %s: %s", prop.name, value)
+		}
+	}
+	return b.String()
+}
+
+// amqp1Performatives name the fields of the AMQP 1.0 performatives and SASL
+// frames, by descriptor code.
+var amqp1Performatives = map[uint64]struct {
+	name   string
+	fields []string
+}{
+	0x10: {"open", []string{"container-id", "hostname", "max-frame-size", "channel-max", "idle-time-out", "outgoing-locales", "incoming-locales", "offered-capabilities", "desired-capabilities", "properties"}},
+	0x11: {"begin", []string{"remote-channel", "next-outgoing-id", "incoming-window", "outgoing-window", "handle-max", "offered-capabilities", "desired-capabilities", "properties"}},
+	0x12: {"attach", []string{"name", "handle", "role", "snd-settle-mode", "rcv-settle-mode", "source", "target", "unsettled", "incomplete-unsettled", "initial-delivery-count", "max-message-size", "offered-capabilities", "desired-capabilities", "properties"}},
+	0x13: {"flow", []string{"next-incoming-id", "incoming-window", "next-outgoing-id", "outgoing-window", "handle", "delivery-count", "link-credit", "available", "drain", "echo", "properties"}},
+	0x14: {"transfer", []string{"handle", "delivery-id", "delivery-tag", "message-format", "settled", "more", "rcv-settle-mode", "state", "resume", "aborted", "batchable"}},
+	0x15: {"disposition", []string{"role", "first", "last", "settled", "state", "batchable"}},
+	0x16: {"detach", []string{"handle", "closed", "error"}},
+	0x17: {"end", []string{"error"}},
+	0x18: {"close", []string{"error"}},
+	0x40: {"sasl-mechanisms", []string{"mechanisms"}},
+	0x41: {"sasl-init", []string{"mechanism", "initial-response", "hostname"}},
+	0x42: {"sasl-challenge", []string{"challenge"}},
+	0x43: {"sasl-response", []string{"response"}},
+	0x44: {"sasl-outcome", []string{"code", "additional-data"}},
+}
+
+// amqp1Sections name the sections of an AMQP 1.0 message, and the fields of
+// its properties.
+var (
+	amqp1Sections = map[uint64]string{
+		0x70: "header", 0x71: "delivery-annotations", 0x72: "message-annotations", 0x73: "properties",
+		0x74: "application-properties", 0x75: "data", 0x76: "amqp-sequence", 0x77: "amqp-value", 0x78: "footer",
+	}
+	amqp1MessageProperties = []string{
+		"message-id", "user-id", "to", "subject", "reply-to", "correlation-id", "content-type",
+		"content-encoding", "absolute-expiry-time", "creation-time", "group-id", "group-sequence", "reply-to-group-id",
+	}
+)
+
+// amqp1Frame renders the AMQP 1.0 frame at the start of data and returns its
+// size.
+func amqp1Frame(data []byte) (string, int, error) {
+	if len(data) < 8 {
+		return "", 0, errShortAMQPFrame
+	}
+	size := binary.BigEndian.Uint32(data)
+	doff := int(data[4]) * 4
+	channel := binary.BigEndian.Uint16(data[6:])
+	if size > maxAMQPFrameSize || doff < 8 || int(size) < doff {
+		return "", 0, fmt.Errorf("invalid amqp frame of %d bytes", size)
+	}
+	if int(size) > len(data) {
+		return "", 0, errShortAMQPFrame
+	}
+	body := data[doff:size]
+	if len(body) == 0 {
+		return "heartbeat", int(size), nil
+	}
+	r := &amqpReader{wireReader: wireReader{data: body}}
+	performative, ok := r.value().(amqpDescribed)
+	if r.err != nil {
+		return "", 0, fmt.Errorf("amqp frame: %w", r.err)
+	}
+	code, _ := performative.descriptor.(uint64)
+	p, known := amqp1Performatives[code]
+	if !ok || !known {
+		return "", 0, fmt.Errorf("unknown amqp performative %v", performative.descriptor)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (channel %d)", p.name, channel)
+	fields, _ := performative.value.([]any)
+	for i, v := range fields {
+		if v == nil || i >= len(p.fields) {
+			continue
+		}
+		switch name := p.fields[i]; name {
+		case "initial-response", "response":
+			fmt.Fprintf(&b, "\n  %s: (redacted)", name)
+		case "role":
+			role := "sender"
+			if v == true {
+				role = "receiver"
+			}
+			fmt.Fprintf(&b, "\n  role: %s", role)
+		default:
+			fmt.Fprintf(&b, "\n  %s: %s", name, amqp1Render(v))
+		}
+	}
+	if code == 0x14 && len(r.data) > 0 {
+		b.WriteString(amqp1Message(r))
+	}
+	return b.String(), int(size), nil
+}
+
+// amqp1Message renders the sections of a message sent with a transfer.
+func amqp1Message(r *amqpReader) string {
+	var b strings.Builder
+	for len(r.data) > 0 {
+		rest := r.data
+		section, ok := r.value().(amqpDescribed)
+		code, _ := section.descriptor.(uint64)
+		name, known := amqp1Sections[code]
+		if r.err != nil || !ok || !known {
+			b.WriteString("\n" + strings.TrimSuffix(hexdumpFrame(rest), "\n"))
+			break
+		}
+		switch code {
+		case 0x73:
+			fields, _ := section.value.([]any)
+			for i, v := range fields {
+				if v != nil && i < len(amqp1MessageProperties) {
+					fmt.Fprintf(&b, "\n  %s: %s", amqp1MessageProperties[i], amqp1Render(v))
+				}
+			}
+		case 0x74:
+			fmt.Fprintf(&b, "\n  %s: %s", name, amqp1Render(section.value))
+		case 0x75, 0x77:
+			switch v := section.value.(type) {
+			case []byte:
+				b.WriteString("\n" + streamPayload(v))
+			case string:
+				b.WriteString("\n" + streamPayload([]byte(v)))
+			default:
+				b.WriteString("\n" + amqp1Render(v))
+			}
+		case 0x76:
+			b.WriteString("\n" + amqp1Render(section.value))
+		}
+	}
+	return b.String()
+}
+
+type (
+	amqpSymbol    string
+	amqpDescribed struct{ descriptor, value any }
+	amqpMapEntry  struct{ key, value any }
+)
+
+// amqp1Descriptors name the described types that are rendered by name.
+var amqp1Descriptors = map[uint64]string{
+	0x1d: "error", 0x23: "received", 0x24: "accepted", 0x25: "rejected",
+	0x26: "released", 0x27: "modified", 0x28: "source", 0x29: "target",
+}
+
+// amqp1Render renders a decoded AMQP 1.0 value on one line.
+func amqp1Render(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return fmt.Sprintf("%q", v)
+	case amqpSymbol:
+		return string(v)
+	case []byte:
+		if !isBinary(v) {
+			return fmt.Sprintf("%q", v)
+		}
+		return "0x" + hex.EncodeToString(v)
+	case []any:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = amqp1Render(e)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case []amqpMapEntry:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = amqp1Render(e.key) + ": " + amqp1Render(e.value)
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	case amqpDescribed:
+		code, _ := v.descriptor.(uint64)
+		name, ok := amqp1Descriptors[code]
+		if sym, isSym := v.descriptor.(amqpSymbol); isSym {
+			name, ok = strings.TrimSuffix(strings.TrimPrefix(string(sym), "amqp:"), ":list"), true
+		}
+		fields, _ := v.value.([]any)
+		switch {
+		case !ok:
+			return amqp1Render(v.value)
+		case (name == "source" || name == "target") && len(fields) > 0:
+			// The address of a terminus is its first field.
+			return amqp1Render(fields[0])
+		case len(fields) == 0:
+			return name
+		}
+		return name + " " + amqp1Render(v.value)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
+
+// value decodes the next AMQP 1.0 value.
+func (r *amqpReader) value() any {
+	return r.typed(r.u8())
+}
+
+func (r *amqpReader) typed(code byte) any {
+	if r.err != nil {
+		return nil
+	}
+	switch code {
+	case 0x00:
+		if r.depth >= maxAMQPDepth {
+			r.err = errors.New("amqp value nests too deeply")
+			return nil
+		}
+		r.depth++
+		defer func() { r.depth-- }()
+		return amqpDescribed{descriptor: r.value(), value: r.value()}
+	case 0x40:
+		return nil
+	case 0x41:
+		return true
+	case 0x42:
+		return false
+	case 0x56:
+		return r.u8() != 0
+	case 0x43, 0x44:
+		return uint64(0)
+	case 0x50, 0x52, 0x53:
+		return uint64(r.u8())
+	case 0x51, 0x54, 0x55:
+		return int64(int8(r.u8()))
+	case 0x60:
+		return uint64(r.u16())
+	case 0x61:
+		return int64(int16(r.u16()))
+	case 0x70:
+		return uint64(r.u32())
+	case 0x71:
+		return int64(int32(r.u32()))
+	case 0x72:
+		return float64(math.Float32frombits(r.u32()))
+	case 0x73:
+		return string(rune(r.u32()))
+	case 0x80:
+		return r.u64()
+	case 0x81:
+		return int64(r.u64())
+	case 0x82:
+		return math.Float64frombits(r.u64())
+	case 0x83:
+		return time.UnixMilli(int64(r.u64()))
+	case 0x74:
+		return r.take(4)
+	case 0x84:
+		return r.take(8)
+	case 0x94:
+		return r.take(16)
+	case 0x98:
+		u := r.take(16)
+		if u == nil {
+			return nil
+		}
+		return fmt.Sprintf("%x-%x-%x-%x-%x", u[:4], u[4:6], u[6:8], u[8:10], u[10:])
+	case 0xa0:
+		return r.take(int(r.u8()))
+	case 0xa1:
+		return string(r.take(int(r.u8())))
+	case 0xa3:
+		return amqpSymbol(r.take(int(r.u8())))
+	case 0xb0:
+		return r.longstr()
+	case 0xb1:
+		return string(r.longstr())
+	case 0xb3:
+		return amqpSymbol(r.longstr())
+	case 0x45:
+		return []any{}
+	case 0xc0, 0xc1, 0xe0:
+		size := int(r.u8())
+		return r.compound(code, r.take(size))
+	case 0xd0, 0xd1, 0xf0:
+		size := int(r.u32())
+		return r.compound(code, r.take(size))
+	}
+	r.err = fmt.Errorf("unknown amqp type 0x%02x", code)
+	return nil
+}
+
+// compound decodes the elements of a list, map or array.
+func (r *amqpReader) compound(code byte, data []byte) any {
+	if r.err != nil {
+		return nil
+	}
+	if r.depth >= maxAMQPDepth {
+		r.err = errors.New("amqp value nests too deeply")
+		return nil
+	}
+	sub := &amqpReader{wireReader: wireReader{data: data}, depth: r.depth + 1}
+	var count int
+	if code < 0xd0 {
+		count = int(sub.u8())
+	} else {
+		count = int(sub.u32())
+	}
+	if count > maxAMQPElements {
+		r.err = fmt.Errorf("amqp value has %d elements", count)
+		return nil
+	}
+	var v any
+	switch code {
+	case 0xc0, 0xd0:
+		list := make([]any, 0, count)
+		for range count {
+			list = append(list, sub.value())
+		}
+		v = list
+	case 0xc1, 0xd1:
+		m := make([]amqpMapEntry, 0, count/2)
+		for range count / 2 {
+			m = append(m, amqpMapEntry{key: sub.value(), value: sub.value()})
+		}
+		v = m
+	default:
+		elem := sub.u8()
+		if elem == 0x00 {
+			r.err = errors.New("described amqp arrays are not supported")
+			return nil
+		}
+		list := make([]any, 0, count)
+		for range count {
+			list = append(list, sub.typed(elem))
+		}
+		v = list
+	}
+	if sub.err != nil {
+		r.err = sub.err
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func amqp091TestFrame(typ byte, channel uint16, payload ...byte) []byte {
+	b := []byte{typ, byte(channel >> 8), byte(channel)}
+	b = binary.BigEndian.AppendUint32(b, uint32(len(payload)))
+	return append(append(b, payload...), amqpFrameEnd)
+}
+
+func shortstr(s string) []byte {
+	return append([]byte{byte(len(s))}, s...)
+}
+
+func TestAMQP091(t *testing.T) {
+	var publish []byte
+	publish = append(publish, 0, 60, 0, 40, 0, 0)
+	publish = append(publish, shortstr("orders")...)
+	publish = append(publish, shortstr("order.created")...)
+	publish = append(publish, 0x01)
+
+	header := []byte{0, 60, 0, 0, 0, 0, 0, 0, 0, 0, 0, 9, 0x80, 0}
+	header = append(header, shortstr("application/json")...)
+
+	client := append([]byte("AMQP\x00\x00\x09\x01"), amqp091TestFrame(1, 1, publish...)...)
+	client = append(client, amqp091TestFrame(2, 1, header...)...)
+	client = append(client, amqp091TestFrame(3, 1, []byte(`{"id":7}`)...)...)
+
+	flow := mitmproxyv1.TCPFlow_builder{
+		Server: mitmproxyv1.ServerConn_builder{AddressPort: proto.Uint32(15672)}.Build(),
+		Messages: []*mitmproxyv1.TCPMessage{
+			mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(true), Content: client[:20]}.Build(),
+			mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(true), Content: client[20:]}.Build(),
+		},
+	}.Build()
+	details := tcpMessageDetails(flow, false).GetMessages()
+	require.Len(t, details, 2)
+	assert.Equal(t, []string{"AMQP 0-9-1"}, details[0].GetTextualFrames())
+	assert.Equal(t, "application/amqp", details[1].GetEffectiveContentType())
+	assert.Equal(t, []string{
+		"basic.publish (channel 1)\n  exchange: \"orders\"\n  routing-key: \"order.created\"\n  mandatory: true\n  immediate: false\n\n" +
+			"content header (channel 1): 9 bytes\n  content-type: application/json\n\n" +
+			"body (channel 1): 8 bytes\n{\n  \"id\": 7\n}",
+	}, details[1].GetTextualFrames())
+}
+
+func amqp1TestFrame(typ byte, body ...byte) []byte {
+	b := binary.BigEndian.AppendUint32(nil, uint32(8+len(body)))
+	return append(append(b, 2, typ, 0, 0), body...)
+}
+
+func TestAMQP1(t *testing.T) {
+	dec := amqpDecoder{}
+	// sasl-init with mechanism PLAIN and a response.
+	saslInit := amqp1TestFrame(1, 0x00, 0x53, 0x41, 0xc0, 0x0c, 0x02, 0xa3, 0x05, 'P', 'L', 'A', 'I', 'N', 0xa0, 0x02, 'h', 'i')
+	// attach of a sender link named "l" to the queue "q".
+	attach := amqp1TestFrame(0, 0x00, 0x53, 0x12, 0xc0, 0x12, 0x07,
+		0xa1, 0x01, 'l', 0x43, 0x42, 0x40, 0x40, 0x40,
+		0x00, 0x53, 0x29, 0xc0, 0x04, 0x01, 0xa1, 0x01, 'q')
+	// transfer with a subject and a data section.
+	transfer := amqp1TestFrame(0, 0x00, 0x53, 0x14, 0xc0, 0x02, 0x01, 0x43,
+		0x00, 0x53, 0x73, 0xc0, 0x07, 0x04, 0x40, 0x40, 0x40, 0xa1, 0x01, 's',
+		0x00, 0x53, 0x75, 0xa0, 0x02, 'o', 'k')
+
+	data := append([]byte("AMQP\x03\x01\x00\x00"), saslInit...)
+	data = append(data, "AMQP\x00\x01\x00\x00"...)
+	data = append(data, attach...)
+	data = append(data, transfer...)
+	data = append(data, amqp1TestFrame(0)...)
+	frames, rest, err := dec.decode(data, true)
+	require.NoError(t, err)
+	assert.Empty(t, rest)
+	assert.Equal(t, []string{
+		"AMQP 1.0 (SASL)",
+		"sasl-init (channel 0)\n  mechanism: PLAIN\n  initial-response: (redacted)",
+		"AMQP 1.0",
+		"attach (channel 0)\n  name: \"l\"\n  handle: 0\n  role: sender\n  target: \"q\"",
+		"transfer (channel 0)\n  handle: 0\n  subject: \"s\"\nok",
+		"heartbeat",
+	}, frames)
+}
+
+func TestAMQPRejectsOtherTraffic(t *testing.T) {
+	dec := amqpDecoder{}
+	_, _, err := dec.decode([]byte("GET / HTTP/1.1\r\n\r\n"), true)
+	assert.Error(t, err)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"maps"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// annotationUser returns whose pins and notes a request reads and writes:
+// the authenticated user's, or "" for the shared ones when the server doesn't
+// require authentication.
+func annotationUser(ctx context.Context) string {
+	p, ok := principalFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return p.name
+}
+
+// pinnedByAnyone reports whether the team or any user has pinned a flow.
+func pinnedByAnyone(flow *mitmflowv1.Flow) bool {
+	if flow.GetPinned() {
+		return true
+	}
+	for _, a := range flow.GetUserAnnotations() {
+		if a.GetPinned() {
+			return true
+		}
+	}
+	return false
+}
+
+// viewAs returns a flow as user sees it: pinned if either they or the team
+// pinned it, with their note in place of the shared one if they wrote one, and
+// without anyone's own annotations. The flow is returned as is when there's
+// nothing to merge.
+func viewAs(flow *mitmflowv1.Flow, user string) *mitmflowv1.Flow {
+	if len(flow.GetUserAnnotations()) == 0 {
+		return flow
+	}
+	view := proto.Clone(flow).(*mitmflowv1.Flow)
+	view.SetUserAnnotations(nil)
+	if a, ok := flow.GetUserAnnotations()[user]; ok && user != "" {
+		if a.GetPinned() {
+			view.SetPinned(true)
+		}
+		if a.GetNote() != "" {
+			view.SetNote(a.GetNote())
+		}
+	}
+	return view
+}
+
+// annotate sets user's pin and note on flow when pinned and note are non-nil,
+// dropping their annotation once it's empty.
+func annotate(flow *mitmflowv1.Flow, user string, pinned *bool, note *string) {
+	// Readers may be walking the map, so it's replaced rather than changed.
+	annotations := maps.Clone(flow.GetUserAnnotations())
+	if annotations == nil {
+		annotations = make(map[string]*mitmflowv1.Annotation, 1)
+	}
+	a := &mitmflowv1.Annotation{}
+	if existing, ok := annotations[user]; ok {
+		a = proto.Clone(existing).(*mitmflowv1.Annotation)
+	}
+	if pinned != nil {
+		a.SetPinned(*pinned)
+	}
+	if note != nil {
+		a.SetNote(*note)
+	}
+	if a.GetPinned() || a.GetNote() != "" {
+		annotations[user] = a
+	} else {
+		delete(annotations, user)
+	}
+	flow.SetUserAnnotations(annotations)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUserAnnotations(t *testing.T) {
+	server, storage := newTestServer(t)
+	require.NoError(t, storage.SaveFlow(createFlow("a", time.Now())))
+	ada := context.WithValue(context.Background(), principalKey{}, principal{name: "ada", role: roleEditor})
+	bob := context.WithValue(context.Background(), principalKey{}, principal{name: "bob", role: roleEditor})
+
+	update := func(ctx context.Context, req *mitmflowv1.UpdateFlowRequest) *mitmflowv1.FlowSummary {
+		req.SetFlowId("a")
+		res, err := server.UpdateFlow(ctx, connect.NewRequest(req))
+		require.NoError(t, err)
+		return res.Msg.GetFlow()
+	}
+	get := func(ctx context.Context) *mitmflowv1.Flow {
+		res, err := server.GetFlow(ctx, connect.NewRequest(mitmflowv1.GetFlowRequest_builder{FlowId: proto.String("a")}.Build()))
+		require.NoError(t, err)
+		return res.Msg.GetFlow()
+	}
+	pinnedFilter := mitmflowv1.FlowFilter_builder{Pinned: proto.Bool(true)}.Build()
+	pinned := func(user string) bool {
+		stored, ok := storage.GetFlow("a")
+		require.True(t, ok)
+		return matchFlow(viewAs(stored, user), pinnedFilter)
+	}
+
+	summary := update(ada, mitmflowv1.UpdateFlowRequest_builder{
+		Pinned: proto.Bool(true),
+		Note:   proto.String("ada's lead"),
+	}.Build())
+	assert.True(t, summary.GetPinned())
+	assert.Equal(t, "ada's lead", summary.GetNote())
+
+	// Bob doesn't see Ada's pin or note.
+	flow := get(bob)
+	assert.False(t, flow.GetPinned())
+	assert.Empty(t, flow.GetNote())
+	assert.Empty(t, flow.GetUserAnnotations())
+	assert.True(t, pinned("ada"))
+	assert.False(t, pinned("bob"))
+
+	// A shared note is seen by everyone without one of their own.
+	update(bob, mitmflowv1.UpdateFlowRequest_builder{
+		Note:   proto.String("team note"),
+		Shared: proto.Bool(true),
+	}.Build())
+	assert.Equal(t, "team note", get(bob).GetNote())
+	assert.Equal(t, "ada's lead", get(ada).GetNote())
+	assert.Equal(t, "team note", get(context.Background()).GetNote())
+
+	// Unpinning only clears Ada's own pin, which still kept the flow.
+	stored, ok := storage.GetFlow("a")
+	require.True(t, ok)
+	assert.True(t, storage.retained(stored))
+	update(ada, mitmflowv1.UpdateFlowRequest_builder{
+		Pinned: proto.Bool(false),
+		Note:   proto.String(""),
+	}.Build())
+	stored, ok = storage.GetFlow("a")
+	require.True(t, ok)
+	assert.Empty(t, stored.GetUserAnnotations())
+	assert.False(t, storage.retained(stored))
+	assert.Equal(t, "team note", get(ada).GetNote())
+}
@@ -0,0 +1,431 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// anonymizationKeyFile holds the HMAC key in the data directory, so the same
+// host gets the same pseudonym in every export.
+const anonymizationKeyFile = "anonymize.key"
+
+// loadOrCreateKey reads an HMAC key from filename, creating it with a random
+// key the first time.
+func loadOrCreateKey(filename string) ([]byte, error) {
+	key, err := os.ReadFile(filename)
+	if err == nil {
+		if len(key) < sha256.Size {
+			return nil, fmt.Errorf("key %s is too short", filename)
+		}
+		return key, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	key = make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomic(filename, key, 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// anonymizer pseudonymizes the hostnames, IP addresses, cookies and
+// credentials of flows with an HMAC, so the same value always maps to the same
+// pseudonym and flows stay linkable without revealing what they talked to.
+// Bodies and WebSocket messages are left as they are.
+type anonymizer struct {
+	key []byte
+}
+
+func newAnonymizer(key []byte) *anonymizer {
+	return &anonymizer{key: key}
+}
+
+func (a *anonymizer) sum(kind, value string) []byte {
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(kind))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
+
+// token replaces a secret value with a pseudonym of the same kind.
+func (a *anonymizer) token(kind, value string) string {
+	if value == "" {
+		return ""
+	}
+	return hex.EncodeToString(a.sum(kind, value))[:16]
+}
+
+// flowAnonymizer anonymizes one flow and remembers the hosts it replaced, so
+// they can also be replaced in free text like error messages.
+type flowAnonymizer struct {
+	*anonymizer
+	replaced map[string]string
+}
+
+// anonymizeFlow returns an anonymized copy of flow.
+func (a *anonymizer) anonymizeFlow(flow *mitmflowv1.Flow) *mitmflowv1.Flow {
+	flow = proto.Clone(flow).(*mitmflowv1.Flow)
+	fa := &flowAnonymizer{anonymizer: a, replaced: make(map[string]string)}
+	switch {
+	case flow.GetHttpFlow() != nil:
+		h := flow.GetHttpFlow()
+		fa.client(h.GetClient())
+		fa.server(h.GetServer())
+		if req := h.GetRequest(); req != nil {
+			req.SetUrl(fa.url(req.GetUrl()))
+			if req.HasPrettyUrl() {
+				req.SetPrettyUrl(fa.url(req.GetPrettyUrl()))
+			}
+			req.SetHeaders(fa.headers(req.GetHeaders()))
+			req.SetTrailers(fa.headers(req.GetTrailers()))
+		}
+		if res := h.GetResponse(); res != nil {
+			res.SetHeaders(fa.headers(res.GetHeaders()))
+			res.SetTrailers(fa.headers(res.GetTrailers()))
+		}
+		if extra := flow.GetHttpFlowExtra(); extra != nil {
+			if extra.GetServerHostname() != "" {
+				extra.SetServerHostname(fa.host(extra.GetServerHostname()))
+			}
+			if cors := extra.GetCors(); cors != nil {
+				cors.SetOrigin(fa.url(cors.GetOrigin()))
+			}
+		}
+	case flow.GetDnsFlow() != nil:
+		d := flow.GetDnsFlow()
+		fa.client(d.GetClient())
+		fa.server(d.GetServer())
+		fa.dnsMessage(d.GetRequest())
+		fa.dnsMessage(d.GetResponse())
+	case flow.GetTcpFlow() != nil:
+		t := flow.GetTcpFlow()
+		fa.client(t.GetClient())
+		fa.server(t.GetServer())
+	case flow.GetUdpFlow() != nil:
+		u := flow.GetUdpFlow()
+		fa.client(u.GetClient())
+		fa.server(u.GetServer())
+	}
+	if extra := flow.GetStreamFlowExtra(); extra != nil && extra.GetServerHostname() != "" {
+		extra.SetServerHostname(fa.host(extra.GetServerHostname()))
+	}
+	fa.errors(flow)
+	return flow
+}
+
+// errors replaces the anonymized hosts in the error messages of a flow and
+// its connections, once every host of the flow is known.
+func (fa *flowAnonymizer) errors(flow *mitmflowv1.Flow) {
+	type errorField interface {
+		HasError() bool
+		GetError() string
+		SetError(string)
+	}
+	var fields []errorField
+	switch {
+	case flow.GetHttpFlow() != nil:
+		h := flow.GetHttpFlow()
+		fields = []errorField{h, h.GetClient(), h.GetServer()}
+	case flow.GetDnsFlow() != nil:
+		d := flow.GetDnsFlow()
+		fields = []errorField{d, d.GetClient(), d.GetServer()}
+	case flow.GetTcpFlow() != nil:
+		t := flow.GetTcpFlow()
+		fields = []errorField{t, t.GetClient(), t.GetServer()}
+	case flow.GetUdpFlow() != nil:
+		u := flow.GetUdpFlow()
+		fields = []errorField{u, u.GetClient(), u.GetServer()}
+	}
+	for _, f := range fields {
+		if f.HasError() {
+			f.SetError(fa.text(f.GetError()))
+		}
+	}
+}
+
+// host returns the pseudonym of a hostname or IP address. Each label of a
+// name is replaced by a hash of the name from that label on, so subdomains of
+// one domain still share a parent; the top-level domain is kept.
+func (fa *flowAnonymizer) host(name string) string {
+	if name == "" {
+		return ""
+	}
+	if pseudonym, ok := fa.replaced[name]; ok {
+		return pseudonym
+	}
+	var pseudonym string
+	if addr, err := netip.ParseAddr(strings.Trim(name, "[]")); err == nil {
+		pseudonym = fa.ip(addr)
+		if strings.HasPrefix(name, "[") {
+			pseudonym = "[" + pseudonym + "]"
+		}
+	} else {
+		labels := strings.Split(strings.TrimSuffix(strings.ToLower(name), "."), ".")
+		last := len(labels)
+		if last > 1 {
+			last--
+		}
+		for i := 0; i < last; i++ {
+			labels[i] = "h" + hex.EncodeToString(fa.sum("host", strings.Join(labels[i:], ".")))[:8]
+		}
+		pseudonym = strings.Join(labels, ".")
+	}
+	fa.replaced[name] = pseudonym
+	return pseudonym
+}
+
+// ip maps IPv4 addresses into 10.0.0.0/8 and IPv6 addresses into fd00::/8.
+// Loopback and unspecified addresses reveal nothing and are kept.
+func (fa *flowAnonymizer) ip(addr netip.Addr) string {
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsUnspecified() {
+		return addr.String()
+	}
+	sum := fa.sum("ip", addr.String())
+	if addr.Is4() {
+		return netip.AddrFrom4([4]byte{10, sum[0], sum[1], sum[2]}).String()
+	}
+	var b [16]byte
+	b[0] = 0xfd
+	copy(b[1:], sum)
+	return netip.AddrFrom16(b).String()
+}
+
+// hostPort anonymizes a Host header style value.
+func (fa *flowAnonymizer) hostPort(value string) string {
+	if host, port, err := net.SplitHostPort(value); err == nil {
+		return net.JoinHostPort(strings.Trim(fa.host(host), "[]"), port)
+	}
+	return fa.host(value)
+}
+
+// url anonymizes the host and user info of a URL and the values of its secret
+// query parameters, leaving the rest as it was written.
+func (fa *flowAnonymizer) url(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	u.Host = fa.hostPort(u.Host)
+	if u.User != nil {
+		user := fa.token("user", u.User.Username())
+		if password, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(user, fa.token("password", password))
+		} else {
+			u.User = url.User(user)
+		}
+	}
+	if u.RawQuery != "" {
+		params := strings.Split(u.RawQuery, "&")
+		for i, param := range params {
+			name, value, hasValue := strings.Cut(param, "=")
+			if unescaped, err := url.QueryUnescape(name); err == nil && hasValue && isSecretName(unescaped) {
+				params[i] = name + "=" + fa.token("param", value)
+			}
+		}
+		u.RawQuery = strings.Join(params, "&")
+	}
+	return u.String()
+}
+
+func (fa *flowAnonymizer) headers(headers map[string]string) map[string]string {
+	if len(headers) == 0 {
+		return headers
+	}
+	res := make(map[string]string, len(headers))
+	for k, v := range headers {
+		switch name := strings.ToLower(k); name {
+		case "host", ":authority", "x-forwarded-host":
+			v = fa.hostPort(v)
+		case "origin", "referer", "location", "content-location":
+			v = fa.url(v)
+		case "x-forwarded-for", "x-real-ip":
+			addrs := strings.Split(v, ",")
+			for i, addr := range addrs {
+				addrs[i] = fa.host(strings.TrimSpace(addr))
+			}
+			v = strings.Join(addrs, ", ")
+		case "authorization", "proxy-authorization":
+			scheme, credentials, ok := strings.Cut(v, " ")
+			if ok {
+				v = scheme + " " + fa.token("credentials", credentials)
+			} else {
+				v = fa.token("credentials", v)
+			}
+		case "cookie":
+			pairs := strings.Split(v, ";")
+			for i, pair := range pairs {
+				name, value, _ := strings.Cut(pair, "=")
+				pairs[i] = strings.TrimSpace(name) + "=" + fa.token("cookie", value)
+			}
+			v = strings.Join(pairs, "; ")
+		case "set-cookie":
+			var lines []string
+			for _, line := range splitSetCookie(v) {
+				attrs := strings.Split(line, ";")
+				name, value, _ := strings.Cut(attrs[0], "=")
+				attrs[0] = strings.TrimSpace(name) + "=" + fa.token("cookie", value)
+				for i, attr := range attrs[1:] {
+					if key, domain, ok := strings.Cut(attr, "="); ok && strings.EqualFold(strings.TrimSpace(key), "domain") {
+						domain = strings.TrimPrefix(strings.TrimSpace(domain), ".")
+						attrs[i+1] = key + "=" + fa.host(domain)
+					}
+				}
+				lines = append(lines, strings.Join(attrs, ";"))
+			}
+			v = strings.Join(lines, "\n")
+		default:
+			if isSecretName(name) {
+				v = fa.token("header", v)
+			}
+		}
+		res[k] = v
+	}
+	return res
+}
+
+// client anonymizes a client connection. Certificates name hosts in ways that
+// can't be rewritten, so they are dropped.
+func (fa *flowAnonymizer) client(c *mitmproxyv1.ClientConn) {
+	if c == nil {
+		return
+	}
+	if c.HasPeernameHost() {
+		c.SetPeernameHost(fa.host(c.GetPeernameHost()))
+	}
+	if c.HasSocknameHost() {
+		c.SetSocknameHost(fa.host(c.GetSocknameHost()))
+	}
+	if c.HasSni() {
+		c.SetSni(fa.host(c.GetSni()))
+	}
+	c.SetCertificateList(nil)
+	c.ClearMitmcert()
+	if c.HasProxyMode() {
+		// Modes like upstream:http://proxy:8080 name a host.
+		mode, _, _ := strings.Cut(c.GetProxyMode(), ":")
+		c.SetProxyMode(mode)
+	}
+}
+
+func (fa *flowAnonymizer) server(s *mitmproxyv1.ServerConn) {
+	if s == nil {
+		return
+	}
+	if s.HasPeernameHost() {
+		s.SetPeernameHost(fa.host(s.GetPeernameHost()))
+	}
+	if s.HasSocknameHost() {
+		s.SetSocknameHost(fa.host(s.GetSocknameHost()))
+	}
+	if s.HasAddressHost() {
+		s.SetAddressHost(fa.host(s.GetAddressHost()))
+	}
+	if s.HasSni() {
+		s.SetSni(fa.host(s.GetSni()))
+	}
+	s.SetCertificateList(nil)
+	if via := s.GetVia(); via != nil {
+		via.SetHost(fa.host(via.GetHost()))
+	}
+}
+
+// dnsMessage anonymizes the names in a DNS message and the addresses and
+// names its records point to. The packed message is dropped.
+func (fa *flowAnonymizer) dnsMessage(m *mitmproxyv1.DNSMessage) {
+	if m == nil {
+		return
+	}
+	m.ClearPacked()
+	for _, q := range m.GetQuestions() {
+		q.SetName(fa.host(q.GetName()))
+	}
+	for _, records := range [][]*mitmproxyv1.DNSResourceRecord{m.GetAnswers(), m.GetAuthorities(), m.GetAdditionals()} {
+		for _, rr := range records {
+			rr.SetName(fa.host(rr.GetName()))
+			rr.SetData(fa.dnsRecordData(rr))
+		}
+	}
+}
+
+// dnsRecordData anonymizes the address of an A or AAAA record, keeping it
+// packed or as text like mitmproxy sent it, and the name a CNAME, NS or PTR
+// record sent as text points to. Other data is dropped.
+func (fa *flowAnonymizer) dnsRecordData(rr *mitmproxyv1.DNSResourceRecord) []byte {
+	data := rr.GetData()
+	switch rr.GetType() {
+	case "A", "AAAA":
+		ip := dnsRecordIP(rr)
+		if ip == "" {
+			return nil
+		}
+		addr, err := netip.ParseAddr(fa.host(ip))
+		if err != nil {
+			return nil
+		}
+		switch len(data) {
+		case net.IPv4len:
+			b := addr.As4()
+			return b[:]
+		case net.IPv6len:
+			b := addr.As16()
+			return b[:]
+		}
+		return []byte(addr.String())
+	case "CNAME", "NS", "PTR":
+		name := string(data)
+		if !utf8.ValidString(name) || strings.IndexFunc(name, unicode.IsControl) >= 0 {
+			return nil
+		}
+		return []byte(fa.host(name))
+	}
+	return nil
+}
+
+// text replaces the hosts anonymized so far wherever they appear in s,
+// longest first so a name isn't replaced piecemeal.
+func (fa *flowAnonymizer) text(s string) string {
+	if s == "" || len(fa.replaced) == 0 {
+		return s
+	}
+	names := make([]string, 0, len(fa.replaced))
+	for name := range fa.replaced {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	pairs := make([]string, 0, 2*len(names))
+	for _, name := range names {
+		pairs = append(pairs, name, fa.replaced[name])
+	}
+	return strings.NewReplacer(pairs...).Replace(s)
+}
+
+// anonymizeFlows returns anonymized copies of flows.
+func (a *anonymizer) anonymizeFlows(flows []*mitmflowv1.Flow) []*mitmflowv1.Flow {
+	res := make([]*mitmflowv1.Flow, len(flows))
+	for i, flow := range flows {
+		res[i] = a.anonymizeFlow(flow)
+	}
+	return res
+}
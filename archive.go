@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// archiveDateLayout names the per-day directories inside the archive.
+const archiveDateLayout = "2006-01-02"
+
+// WithArchiveDir makes prune move evicted flows into dir instead of deleting
+// them. Archived flows are always compressed, have their blobs inlined, and
+// are partitioned into one directory per day of their start time.
+func WithArchiveDir(dir string) StorageOption {
+	return func(s *FlowStorage) {
+		s.archiveDir = dir
+	}
+}
+
+// archiveFlows moves the files of pruned flows into the archive. It runs on
+// the persist worker, after any pending writes of the same flows.
+func (s *FlowStorage) archiveFlows(ids []string) {
+	for _, id := range ids {
+		if err := s.archiveFlow(id); err != nil {
+			log.Printf("failed to archive flow %s: %v", id, err)
+			continue
+		}
+	}
+}
+
+func (s *FlowStorage) archiveFlow(id string) error {
+	flow, err := s.backend.Read(id)
+	if err != nil {
+		return err
+	}
+	if err := s.loadBlobs(context.Background(), flow); err != nil {
+		return err
+	}
+	data, err := proto.Marshal(flow)
+	if err != nil {
+		return err
+	}
+
+	day := time.Unix(0, GetFlowStartTime(flow)).UTC().Format(archiveDateLayout)
+	dir := filepath.Join(s.archiveDir, day)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(dir, id+".bin"), s.archiveCodec.encode(data), 0644); err != nil {
+		return err
+	}
+	return s.backend.Remove(id)
+}
+
+func readFlowFile(filename string, codec *flowCodec) (*mitmflowv1.Flow, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	data, err = codec.decode(data)
+	if err != nil {
+		return nil, err
+	}
+	flow := &mitmflowv1.Flow{}
+	if err := proto.Unmarshal(data, flow); err != nil {
+		return nil, err
+	}
+	return flow, nil
+}
+
+// WalkArchive calls fn for each archived flow, newest day first and newest
+// flow first within a day, until fn returns false.
+func (s *FlowStorage) WalkArchive(ctx context.Context, fn func(*mitmflowv1.Flow) bool) error {
+	if s.archiveDir == "" {
+		return nil
+	}
+	days, err := os.ReadDir(s.archiveDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	slices.Reverse(days)
+
+	for _, day := range days {
+		if !day.IsDir() {
+			continue
+		}
+		if _, err := time.Parse(archiveDateLayout, day.Name()); err != nil {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(s.archiveDir, day.Name()))
+		if err != nil {
+			return err
+		}
+
+		var flows []*mitmflowv1.Flow
+		for _, entry := range entries {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if entry.IsDir() || filepath.Ext(entry.Name()) != ".bin" {
+				continue
+			}
+			flow, err := readFlowFile(filepath.Join(s.archiveDir, day.Name(), entry.Name()), s.archiveCodec)
+			if err != nil {
+				log.Printf("failed to read archived flow %s: %v", entry.Name(), err)
+				continue
+			}
+			flows = append(flows, flow)
+		}
+		sort.Slice(flows, func(i, j int) bool {
+			return GetFlowStartTime(flows[i]) > GetFlowStartTime(flows[j])
+		})
+		for _, flow := range flows {
+			if !fn(flow) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// RestoreArchived moves archived flows back into the store and returns them.
+// IDs that aren't in the archive are skipped.
+func (s *FlowStorage) RestoreArchived(ids []string, pin bool) ([]*mitmflowv1.Flow, error) {
+	if s.archiveDir == "" {
+		return nil, fmt.Errorf("archiving is not enabled")
+	}
+
+	var restored []*mitmflowv1.Flow
+	for _, id := range ids {
+		if !validFlowID(id) {
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(s.archiveDir, "*", id+".bin"))
+		if err != nil {
+			return restored, err
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		flow, err := readFlowFile(matches[0], s.archiveCodec)
+		if err != nil {
+			return restored, fmt.Errorf("failed to read archived flow %s: %w", id, err)
+		}
+		if pin {
+			flow.SetPinned(true)
+		}
+		// The archived copy is removed first: saving may prune the flow
+		// straight back into the archive.
+		if err := os.Remove(matches[0]); err != nil {
+			return restored, err
+		}
+		if err := s.SaveFlow(flow); err != nil {
+			if data, merr := proto.Marshal(flow); merr == nil {
+				writeFileAtomic(matches[0], s.archiveCodec.encode(data), 0644) //nolint:errcheck
+			}
+			return restored, err
+		}
+		restored = append(restored, flow)
+	}
+	return restored, nil
+}
+
+// validFlowID reports whether id is safe to use as a file name.
+func validFlowID(id string) bool {
+	return id != "" && filepath.IsLocal(id) && filepath.Base(id) == id && !strings.ContainsAny(id, `*?[\`)
+}
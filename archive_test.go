@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+func TestFlowStorage_ArchiveOnPrune(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_archive_test")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(tmpDir)) })
+	archiveDir := filepath.Join(tmpDir, "archive")
+
+	storage, err := NewFlowStorage(tmpDir, 2, WithCompression(false), WithArchiveDir(archiveDir))
+	require.NoError(t, err)
+	defer storage.Close()
+
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, id := range []string{"flow-1", "flow-2", "flow-3"} {
+		require.NoError(t, storage.SaveFlow(createFlow(id, start.Add(time.Duration(i)*time.Second))))
+	}
+
+	archived := filepath.Join(archiveDir, "2024-03-01", "flow-1.bin")
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(archived)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.NoFileExists(t, filepath.Join(tmpDir, "flow-1.bin"))
+
+	// Archived files are compressed even though live flows aren't.
+	data, err := os.ReadFile(archived)
+	require.NoError(t, err)
+	assert.Equal(t, zstdMagic, data[:4])
+
+	var found []string
+	require.NoError(t, storage.WalkArchive(context.Background(), func(flow *mitmflowv1.Flow) bool {
+		found = append(found, GetFlowID(flow))
+		return true
+	}))
+	assert.Equal(t, []string{"flow-1"}, found)
+
+	restored, err := storage.RestoreArchived([]string{"flow-1", "missing", "../flow-2"}, true)
+	require.NoError(t, err)
+	require.Len(t, restored, 1)
+	flow, ok := storage.GetFlow("flow-1")
+	require.True(t, ok)
+	assert.True(t, flow.GetPinned())
+	assert.NoFileExists(t, archived)
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultAuditEventLimit = 100
+
+// auditLog appends AuditEvents to a file, one JSON object per line. The file
+// is only ever appended to, and each event is synced before the change it
+// records is reported as done.
+type auditLog struct {
+	mu   sync.Mutex
+	f    *os.File
+	path string
+}
+
+func openAuditLog(path string) (*auditLog, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &auditLog{f: f, path: path}, nil
+}
+
+// WithAuditLog records deletes, pins, notes and exports in audit.
+func WithAuditLog(audit *auditLog) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.auditLog = audit
+	}
+}
+
+func (l *auditLog) append(event *mitmflowv1.AuditEvent) error {
+	data, err := protojson.Marshal(event)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.f.Write(data); err != nil {
+		return err
+	}
+	return l.f.Sync()
+}
+
+// read returns the newest events matching req, newest first.
+func (l *auditLog) read(req *mitmflowv1.ListAuditEventsRequest) ([]*mitmflowv1.AuditEvent, error) {
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultAuditEventLimit
+	}
+	f, err := os.Open(l.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck
+
+	var events []*mitmflowv1.AuditEvent
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 1 {
+			event := &mitmflowv1.AuditEvent{}
+			if uerr := protojson.Unmarshal(line, event); uerr != nil {
+				log.Printf("skipping unreadable audit event: %v", uerr)
+			} else if auditEventMatches(event, req) {
+				events = append(events, event)
+				if len(events) > limit {
+					events = events[1:]
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	slices.Reverse(events)
+	return events, nil
+}
+
+func auditEventMatches(event *mitmflowv1.AuditEvent, req *mitmflowv1.ListAuditEventsRequest) bool {
+	if len(req.GetActions()) > 0 && !slices.Contains(req.GetActions(), event.GetAction()) {
+		return false
+	}
+	if req.GetActor() != "" && event.GetActor() != req.GetActor() {
+		return false
+	}
+	if req.HasSince() && event.GetTime().AsTime().Before(req.GetSince().AsTime()) {
+		return false
+	}
+	return true
+}
+
+func (l *auditLog) Close() error {
+	return l.f.Close()
+}
+
+// audit records a change to, or export of, flows by the client making the
+// request. Every flow was affected when ids is nil and count isn't zero.
+func (s *MITMFlowServer) audit(ctx context.Context, peer connect.Peer, action mitmflowv1.AuditAction, ids []string, count int, detail string) {
+	if s.auditLog == nil {
+		return
+	}
+	actor, _ := principalFromContext(ctx)
+	event := mitmflowv1.AuditEvent_builder{
+		Time:    timestamppb.New(time.Now()),
+		Actor:   proto.String(actor.name),
+		Peer:    proto.String(peer.Addr),
+		Action:  action.Enum(),
+		FlowIds: ids,
+		Count:   proto.Int64(int64(count)),
+		Detail:  proto.String(detail),
+	}.Build()
+	if err := s.auditLog.append(event); err != nil {
+		log.Printf("failed to write audit event: %v", err)
+	}
+}
+
+func flowIDs(flows []*mitmflowv1.Flow) []string {
+	var out []string
+	for _, f := range flows {
+		out = append(out, GetFlowID(f))
+	}
+	return out
+}
+
+// formatMetadata describes a metadata update as "key=value" pairs sorted by
+// key. Removed keys have no value.
+func formatMetadata(metadata map[string]string) string {
+	keys := slices.Sorted(maps.Keys(metadata))
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + metadata[k]
+	}
+	return strings.Join(pairs, " ")
+}
+
+func (s *MITMFlowServer) ListAuditEvents(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.ListAuditEventsRequest],
+) (*connect.Response[mitmflowv1.ListAuditEventsResponse], error) {
+	if s.auditLog == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("the audit log is disabled; start the server with -audit-log"))
+	}
+	events, err := s.auditLog.read(req.Msg)
+	if err != nil {
+		log.Printf("failed to read audit log: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(mitmflowv1.ListAuditEventsResponse_builder{
+		Events: events,
+	}.Build()), nil
+}
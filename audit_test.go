@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestAuditLog(t *testing.T) {
+	server, storage := newTestServer(t)
+	_, err := server.ListAuditEvents(context.Background(), connect.NewRequest(&mitmflowv1.ListAuditEventsRequest{}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	tmpDir, err := os.MkdirTemp("", "mitmflow_audit_test")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(tmpDir)) })
+	audit, err := openAuditLog(filepath.Join(tmpDir, "audit.jsonl"))
+	require.NoError(t, err)
+	t.Cleanup(func() { audit.Close() }) //nolint:errcheck
+	server.auditLog = audit
+
+	require.NoError(t, storage.SaveFlow(createFlow("a", time.Now())))
+	require.NoError(t, storage.SaveFlow(createFlow("b", time.Now())))
+	ada := context.WithValue(context.Background(), principalKey{}, principal{name: "ada", role: roleEditor})
+	ci := context.WithValue(context.Background(), principalKey{}, principal{name: "ci", role: roleEditor})
+
+	_, err = server.UpdateFlow(ada, connect.NewRequest(mitmflowv1.UpdateFlowRequest_builder{
+		FlowId: proto.String("a"),
+		Pinned: proto.Bool(true),
+		Note:   proto.String("login bug"),
+	}.Build()))
+	require.NoError(t, err)
+	_, err = server.ExportFlows(ci, connect.NewRequest(mitmflowv1.ExportFlowsRequest_builder{
+		FlowIds: []string{"a", "b"},
+		Format:  mitmflowv1.ExportFormat_EXPORT_FORMAT_HAR.Enum(),
+	}.Build()))
+	require.NoError(t, err)
+	_, err = server.DeleteFlows(ada, connect.NewRequest(mitmflowv1.DeleteFlowsRequest_builder{
+		FlowIds: []string{"b"},
+	}.Build()))
+	require.NoError(t, err)
+
+	list := func(req *mitmflowv1.ListAuditEventsRequest) []*mitmflowv1.AuditEvent {
+		res, err := server.ListAuditEvents(context.Background(), connect.NewRequest(req))
+		require.NoError(t, err)
+		return res.Msg.GetEvents()
+	}
+
+	events := list(&mitmflowv1.ListAuditEventsRequest{})
+	require.Len(t, events, 4)
+	var actions []mitmflowv1.AuditAction
+	for _, event := range events {
+		actions = append(actions, event.GetAction())
+	}
+	assert.Equal(t, []mitmflowv1.AuditAction{
+		mitmflowv1.AuditAction_AUDIT_ACTION_DELETE,
+		mitmflowv1.AuditAction_AUDIT_ACTION_EXPORT,
+		mitmflowv1.AuditAction_AUDIT_ACTION_NOTE,
+		mitmflowv1.AuditAction_AUDIT_ACTION_PIN,
+	}, actions, "newest first")
+	assert.Equal(t, "ada", events[0].GetActor())
+	assert.Equal(t, []string{"b"}, events[0].GetFlowIds())
+	assert.Equal(t, "EXPORT_FORMAT_HAR", events[1].GetDetail())
+	assert.Equal(t, int64(2), events[1].GetCount())
+	assert.Equal(t, "login bug", events[2].GetDetail())
+
+	events = list(mitmflowv1.ListAuditEventsRequest_builder{Actor: proto.String("ada"), Limit: proto.Int32(2)}.Build())
+	require.Len(t, events, 2)
+	assert.Equal(t, mitmflowv1.AuditAction_AUDIT_ACTION_DELETE, events[0].GetAction())
+	assert.Equal(t, mitmflowv1.AuditAction_AUDIT_ACTION_NOTE, events[1].GetAction())
+
+	events = list(mitmflowv1.ListAuditEventsRequest_builder{
+		Actions: []mitmflowv1.AuditAction{mitmflowv1.AuditAction_AUDIT_ACTION_EXPORT},
+	}.Build())
+	require.Len(t, events, 1)
+	assert.Equal(t, "ci", events[0].GetActor())
+}
+
+func TestAuditLog_RulesBaselineAndImports(t *testing.T) {
+	server, storage := newTestServer(t)
+	tmpDir, err := os.MkdirTemp("", "mitmflow_audit_test")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(tmpDir)) })
+	audit, err := openAuditLog(filepath.Join(tmpDir, "audit.jsonl"))
+	require.NoError(t, err)
+	t.Cleanup(func() { audit.Close() }) //nolint:errcheck
+	server.auditLog = audit
+
+	require.NoError(t, storage.SaveFlow(createFlow("a", time.Now())))
+	admin := context.WithValue(context.Background(), principalKey{}, principal{name: "root", role: roleAdmin})
+	ada := context.WithValue(context.Background(), principalKey{}, principal{name: "ada", role: roleEditor})
+
+	_, err = server.SetProxyRules(admin, connect.NewRequest(mitmflowv1.SetProxyRulesRequest_builder{
+		Rules: mitmflowv1.ProxyRules_builder{
+			Overrides: []*mitmflowv1.OverrideRule{
+				mitmflowv1.OverrideRule_builder{Name: proto.String("teapot"), StatusCode: proto.Int32(418)}.Build(),
+			},
+		}.Build(),
+	}.Build()))
+	require.NoError(t, err)
+	_, err = server.SetBaseline(ada, connect.NewRequest(mitmflowv1.SetBaselineRequest_builder{
+		Session: mitmflowv1.SessionSelector_builder{Filter: proto.String("~meta ^build=1$")}.Build(),
+	}.Build()))
+	require.NoError(t, err)
+	_, err = server.SetBaseline(ada, connect.NewRequest(&mitmflowv1.SetBaselineRequest{}))
+	require.NoError(t, err)
+	data, err := proto.Marshal(mitmflowv1.FlowSet_builder{
+		Flows: []*mitmflowv1.Flow{createFlow("b", time.Now()), createFlow("c", time.Now())},
+	}.Build())
+	require.NoError(t, err)
+	_, err = server.ImportFlows(ada, connect.NewRequest(mitmflowv1.ImportFlowsRequest_builder{Data: data}.Build()))
+	require.NoError(t, err)
+
+	res, err := server.ListAuditEvents(context.Background(), connect.NewRequest(&mitmflowv1.ListAuditEventsRequest{}))
+	require.NoError(t, err)
+	events := res.Msg.GetEvents()
+	require.Len(t, events, 4)
+
+	imported := events[0]
+	assert.Equal(t, mitmflowv1.AuditAction_AUDIT_ACTION_IMPORT, imported.GetAction())
+	assert.Equal(t, "ada", imported.GetActor())
+	assert.Equal(t, []string{"b", "c"}, imported.GetFlowIds())
+	assert.Equal(t, int64(2), imported.GetCount())
+
+	cleared := events[1]
+	assert.Equal(t, mitmflowv1.AuditAction_AUDIT_ACTION_SET_BASELINE, cleared.GetAction())
+	assert.Equal(t, "cleared", cleared.GetDetail())
+	assert.Zero(t, cleared.GetCount())
+
+	baseline := events[2]
+	assert.Equal(t, mitmflowv1.AuditAction_AUDIT_ACTION_SET_BASELINE, baseline.GetAction())
+	assert.Equal(t, "ada", baseline.GetActor())
+	assert.Equal(t, "~meta ^build=1$", baseline.GetDetail())
+
+	rules := events[3]
+	assert.Equal(t, mitmflowv1.AuditAction_AUDIT_ACTION_SET_PROXY_RULES, rules.GetAction())
+	assert.Equal(t, "root", rules.GetActor())
+	assert.Equal(t, "1 overrides and 0 throttles", rules.GetDetail())
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"connectrpc.com/connect"
+	"connectrpc.com/grpcreflect"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxygrpcv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+)
+
+// role is what an authenticated client is allowed to do. Each role can do
+// everything the roles before it can.
+type role int
+
+const (
+	// roleViewer can list, stream and export flows.
+	roleViewer role = iota + 1
+	// roleEditor can also change flows: pin, note, delete, import and ingest
+	// them.
+	roleEditor
+	// roleAdmin can also manage the server: backups, baselines, the proxy
+	// rules and anything else not assigned a role below.
+	roleAdmin
+)
+
+func (r role) String() string {
+	switch r {
+	case roleViewer:
+		return "viewer"
+	case roleEditor:
+		return "editor"
+	case roleAdmin:
+		return "admin"
+	}
+	return fmt.Sprintf("role(%d)", int(r))
+}
+
+func parseRole(s string) (role, error) {
+	switch strings.ToLower(s) {
+	case "viewer":
+		return roleViewer, nil
+	case "editor":
+		return roleEditor, nil
+	case "admin":
+		return roleAdmin, nil
+	}
+	return 0, fmt.Errorf("unknown role %q, expected viewer, editor or admin", s)
+}
+
+// The procedures of server reflection, which lists the services to anyone
+// who may call them.
+const (
+	reflectV1Procedure      = "/" + grpcreflect.ReflectV1ServiceName + "/ServerReflectionInfo"
+	reflectV1AlphaProcedure = "/" + grpcreflect.ReflectV1AlphaServiceName + "/ServerReflectionInfo"
+)
+
+// rpcRoles is the role each RPC requires. RPCs that aren't listed require
+// roleAdmin, so new RPCs are locked down until they're given a role.
+var rpcRoles = map[string]role{
+	mitmflowv1.ServiceGetFlowsProcedure:          roleViewer,
+	mitmflowv1.ServiceStreamFlowsProcedure:       roleViewer,
+	mitmflowv1.ServiceExportFlowsProcedure:       roleViewer,
+	mitmflowv1.ServiceGetFlowProcedure:           roleViewer,
+	mitmflowv1.ServiceGetFlowBodyProcedure:       roleViewer,
+	mitmflowv1.ServiceGetFlowFramesProcedure:     roleViewer,
+	mitmflowv1.ServiceSearchArchiveProcedure:     roleViewer,
+	mitmflowv1.ServiceGetServerInfoProcedure:     roleViewer,
+	mitmflowv1.ServiceGetCookieTimelineProcedure: roleViewer,
+	mitmflowv1.ServiceGetRedirectChainProcedure:  roleViewer,
+	mitmflowv1.ServiceCreateShareBundleProcedure: roleViewer,
+	mitmflowv1.ServiceCompareSessionsProcedure:   roleViewer,
+	mitmflowv1.ServiceDiffWithPreviousProcedure:  roleViewer,
+	mitmflowv1.ServiceTopFlowsProcedure:          roleViewer,
+	mitmflowv1.ServiceGetBandwidthStatsProcedure: roleViewer,
+	mitmflowv1.ServiceGetProxyRulesProcedure:     roleViewer,
+	mitmflowv1.ServiceWatchProxyRulesProcedure:   roleViewer,
+	reflectV1Procedure:                           roleViewer,
+	reflectV1AlphaProcedure:                      roleViewer,
+
+	mitmflowv1.ServiceUpdateFlowProcedure:           roleEditor,
+	mitmflowv1.ServiceDeleteFlowsProcedure:          roleEditor,
+	mitmflowv1.ServiceImportFlowsProcedure:          roleEditor,
+	mitmflowv1.ServiceRestoreArchivedFlowsProcedure: roleEditor,
+	mitmflowv1.ServiceRestoreFlowsProcedure:         roleEditor,
+	mitmflowv1.ServiceSendRequestProcedure:          roleEditor,
+	mitmflowv1.ServiceUploadFlowBodyProcedure:       roleEditor,
+	mitmproxygrpcv1.ServiceExportFlowProcedure:      roleEditor,
+
+	mitmflowv1.ServiceCreateBackupProcedure:    roleAdmin,
+	mitmflowv1.ServiceRestoreBackupProcedure:   roleAdmin,
+	mitmflowv1.ServiceSetBaselineProcedure:     roleAdmin,
+	mitmflowv1.ServiceSetProxyRulesProcedure:   roleAdmin,
+	mitmflowv1.ServiceListSubscribersProcedure: roleAdmin,
+	mitmflowv1.ServiceListAuditEventsProcedure: roleAdmin,
+}
+
+func requiredRole(procedure string) role {
+	if r, ok := rpcRoles[procedure]; ok {
+		return r
+	}
+	return roleAdmin
+}
+
+// principal is an authenticated client.
+type principal struct {
+	// name identifies the client in logs, defaulting to its role.
+	name string
+	role role
+	// private makes the flows the client captures, imports or sends private
+	// to it.
+	private bool
+}
+
+type principalKey struct{}
+
+// principalFromContext returns the client making a request, if the server
+// requires authentication.
+func principalFromContext(ctx context.Context) (principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(principal)
+	return p, ok
+}
+
+// authenticator authenticates clients by bearer token, or by the session
+// cookie of a user signed in to the UI with OIDC, and checks that their role
+// allows the RPC they're calling.
+type authenticator struct {
+	tokens map[string]principal
+	// oidc is nil unless users can sign in with OpenID Connect.
+	oidc *oidcAuth
+}
+
+// loadTokenFile reads tokens from a file with one "ROLE TOKEN [NAME [private]]"
+// per line. Blank lines and lines starting with # are ignored.
+func loadTokenFile(filename string) (map[string]principal, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open token file: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	tokens := make(map[string]principal)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) < 2 || len(fields) > 4 {
+			return nil, fmt.Errorf("%s:%d: expected ROLE TOKEN [NAME [private]]", filename, line)
+		}
+		r, err := parseRole(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", filename, line, err)
+		}
+		p := principal{name: r.String(), role: r}
+		if len(fields) >= 3 {
+			p.name = fields[2]
+		}
+		if len(fields) == 4 {
+			if fields[3] != "private" {
+				return nil, fmt.Errorf("%s:%d: unknown option %q, expected private", filename, line, fields[3])
+			}
+			p.private = true
+		}
+		tokens[fields[1]] = p
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%s has no tokens", filename)
+	}
+	return tokens, nil
+}
+
+// authenticate returns the client a token belongs to.
+func (a *authenticator) authenticate(token string) (principal, bool) {
+	if token == "" {
+		return principal{}, false
+	}
+	// Compare against every token so the time taken doesn't reveal how
+	// much of a token was right.
+	var found principal
+	var ok bool
+	for t, p := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			found, ok = p, true
+		}
+	}
+	return found, ok
+}
+
+// authorize checks the bearer token or session cookie in header against the
+// role required.
+func (a *authenticator) authorize(header http.Header, required role) (principal, error) {
+	var p principal
+	if token, ok := strings.CutPrefix(header.Get("Authorization"), "Bearer "); ok {
+		if p, ok = a.authenticate(strings.TrimSpace(token)); !ok {
+			return principal{}, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid token"))
+		}
+	} else if session, ok := a.oidc.session(header); ok {
+		p = session
+	} else {
+		return principal{}, connect.NewError(connect.CodeUnauthenticated, errors.New("missing bearer token"))
+	}
+	if p.role < required {
+		return principal{}, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("%s requires the %s role", p.name, required))
+	}
+	return p, nil
+}
+
+// authorizeRequest authorizes a plain HTTP request, like the WebSocket
+// endpoint's, which browsers can't add headers to, so the token may also be
+// given as the access_token query parameter.
+func (a *authenticator) authorizeRequest(r *http.Request, required role) (principal, error) {
+	header := r.Header
+	if token := r.URL.Query().Get("access_token"); token != "" && header.Get("Authorization") == "" {
+		header = header.Clone()
+		header.Set("Authorization", "Bearer "+token)
+	}
+	return a.authorize(header, required)
+}
+
+func (a *authenticator) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		p, err := a.authorize(req.Header(), requiredRole(req.Spec().Procedure))
+		if err != nil {
+			return nil, err
+		}
+		return next(context.WithValue(ctx, principalKey{}, p), req)
+	}
+}
+
+func (a *authenticator) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (a *authenticator) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		p, err := a.authorize(conn.RequestHeader(), requiredRole(conn.Spec().Procedure))
+		if err != nil {
+			return err
+		}
+		return next(context.WithValue(ctx, principalKey{}, p), conn)
+	}
+}
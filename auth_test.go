@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"connectrpc.com/grpcreflect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestLoadTokenFile(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_auth_test")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(tmpDir)) })
+
+	filename := filepath.Join(tmpDir, "tokens")
+	require.NoError(t, os.WriteFile(filename, []byte("# dashboards\nviewer v-token\n\nEditor e-token ci\neditor p-token ada private\n"), 0600))
+	tokens, err := loadTokenFile(filename)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]principal{
+		"v-token": {name: "viewer", role: roleViewer},
+		"e-token": {name: "ci", role: roleEditor},
+		"p-token": {name: "ada", role: roleEditor, private: true},
+	}, tokens)
+
+	require.NoError(t, os.WriteFile(filename, []byte("owner o-token\n"), 0600))
+	_, err = loadTokenFile(filename)
+	assert.ErrorContains(t, err, ":1: unknown role")
+
+	require.NoError(t, os.WriteFile(filename, []byte("editor p-token ada secret\n"), 0600))
+	_, err = loadTokenFile(filename)
+	assert.ErrorContains(t, err, ":1: unknown option")
+}
+
+func TestRPCRoles(t *testing.T) {
+	methods := mitmflowv1.File_mitmflow_v1_mitmflow_proto.Services().ByName("Service").Methods()
+	for i := range methods.Len() {
+		procedure := fmt.Sprintf("/%s/%s", methods.Get(i).Parent().FullName(), methods.Get(i).Name())
+		assert.Contains(t, rpcRoles, procedure, "every RPC should be given a role")
+	}
+}
+
+func TestTokenAuth(t *testing.T) {
+	server, storage := newTestServer(t)
+	require.NoError(t, storage.SaveFlow(createFlow("a", time.Now())))
+	auth := &authenticator{tokens: map[string]principal{
+		"v-token": {name: "dashboard", role: roleViewer},
+		"e-token": {name: "ci", role: roleEditor},
+	}}
+	mux := http.NewServeMux()
+	mux.Handle(mitmflowv1.NewServiceHandler(server, connect.WithInterceptors(auth)))
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	client := func(token string) mitmflowv1.ServiceClient {
+		var opts []connect.ClientOption
+		if token != "" {
+			opts = append(opts, connect.WithInterceptors(bearerToken(token)))
+		}
+		return mitmflowv1.NewServiceClient(ts.Client(), ts.URL, opts...)
+	}
+	deleteFlow := func(token string) error {
+		_, err := client(token).DeleteFlows(context.Background(), connect.NewRequest(mitmflowv1.DeleteFlowsRequest_builder{
+			FlowIds: []string{"a"},
+		}.Build()))
+		return err
+	}
+	getFlows := func(token string) error {
+		stream, err := client(token).GetFlows(context.Background(), connect.NewRequest(mitmflowv1.GetFlowsRequest_builder{
+			Limit: proto.Int32(10),
+		}.Build()))
+		if err != nil {
+			return err
+		}
+		for stream.Receive() {
+		}
+		return stream.Err()
+	}
+
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(getFlows("")))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(getFlows("wrong")))
+	assert.NoError(t, getFlows("v-token"))
+
+	err := deleteFlow("v-token")
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	assert.ErrorContains(t, err, "dashboard requires the editor role")
+	_, ok := storage.GetFlow("a")
+	assert.True(t, ok)
+
+	assert.NoError(t, deleteFlow("e-token"))
+	_, ok = storage.GetFlow("a")
+	assert.False(t, ok)
+
+	_, err = client("e-token").ListSubscribers(context.Background(), connect.NewRequest(&mitmflowv1.ListSubscribersRequest{}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), "listing subscribers needs the admin role")
+	_, err = client("e-token").SetProxyRules(context.Background(), connect.NewRequest(&mitmflowv1.SetProxyRulesRequest{}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), "setting the proxy rules needs the admin role")
+}
+
+func TestReflection(t *testing.T) {
+	server, _ := newTestServer(t)
+	auth := &authenticator{tokens: map[string]principal{
+		"v-token": {name: "dashboard", role: roleViewer},
+	}}
+	// Reflection streams both ways, which takes HTTP/2.
+	ts := httptest.NewUnstartedServer(newServeMux(server, auth, nil))
+	ts.EnableHTTP2 = true
+	ts.StartTLS()
+	defer ts.Close()
+
+	listServices := func(token string) ([]protoreflect.FullName, error) {
+		var opts []grpcreflect.ClientStreamOption
+		if token != "" {
+			opts = append(opts, grpcreflect.WithRequestHeaders(http.Header{"Authorization": {"Bearer " + token}}))
+		}
+		stream := grpcreflect.NewClient(ts.Client(), ts.URL).NewStream(context.Background(), opts...)
+		defer stream.Close() //nolint:errcheck
+		return stream.ListServices()
+	}
+
+	_, err := listServices("")
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	services, err := listServices("v-token")
+	require.NoError(t, err)
+	assert.Contains(t, services, protoreflect.FullName(mitmflowv1.ServiceName))
+	assert.Contains(t, services, protoreflect.FullName(mitmproxyv1.ServiceName))
+}
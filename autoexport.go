@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+// autoExportRule writes each capture session in one format to a directory or
+// to s3://bucket/prefix.
+type autoExportRule struct {
+	format   mitmflowv1.ExportFormat
+	location string
+	s3       *s3.Client
+	bucket   string
+	prefix   string
+}
+
+// parseAutoExportRule parses a rule of the form FORMAT=LOCATION, e.g.
+// har=/tmp/captures or jsonl=s3://bucket/ci.
+func parseAutoExportRule(ctx context.Context, spec string) (autoExportRule, error) {
+	formatName, location, ok := strings.Cut(spec, "=")
+	if !ok || location == "" {
+		return autoExportRule{}, fmt.Errorf("invalid auto-export rule %q, expected FORMAT=LOCATION", spec)
+	}
+	format, err := parseFormat(formatName)
+	if err != nil {
+		return autoExportRule{}, err
+	}
+	rule := autoExportRule{format: format, location: location}
+	if rest, ok := strings.CutPrefix(location, "s3://"); ok {
+		bucket, prefix, _ := strings.Cut(rest, "/")
+		if bucket == "" {
+			return autoExportRule{}, fmt.Errorf("invalid s3 location: %s", location)
+		}
+		cfg, err := config.LoadDefaultConfig(ctx)
+		if err != nil {
+			return autoExportRule{}, fmt.Errorf("failed to load aws config: %w", err)
+		}
+		rule.s3 = s3.NewFromConfig(cfg)
+		rule.bucket = bucket
+		rule.prefix = strings.Trim(prefix, "/")
+	}
+	return rule, nil
+}
+
+func (r autoExportRule) write(ctx context.Context, name string, data []byte) error {
+	if r.s3 != nil {
+		_, err := r.s3.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(r.bucket),
+			Key:    aws.String(path.Join(r.prefix, name)),
+			Body:   bytes.NewReader(data),
+		})
+		return err
+	}
+	if err := os.MkdirAll(r.location, 0755); err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(r.location, name), data, 0644)
+}
+
+// autoExporter tracks capture sessions and exports them by its rules. A
+// session starts when mitmproxy connects and ends once no mitmproxy instance
+// is connected anymore. Sessions are exported when they end and, with an
+// interval, periodically while they last; each export of a session replaces
+// the previous one.
+type autoExporter struct {
+	storage  *FlowStorage
+	rules    []autoExportRule
+	interval time.Duration
+
+	// exportMu keeps a periodic export from overwriting the final one.
+	exportMu sync.Mutex
+	mu       sync.Mutex
+	streams  int
+	start    time.Time
+	ids      map[string]struct{}
+}
+
+func newAutoExporter() *autoExporter {
+	return &autoExporter{}
+}
+
+func (e *autoExporter) enabled() bool {
+	return len(e.rules) > 0
+}
+
+// begin records that mitmproxy connected.
+func (e *autoExporter) begin() {
+	if !e.enabled() {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.streams == 0 {
+		e.start = time.Now()
+		e.ids = make(map[string]struct{})
+	}
+	e.streams++
+}
+
+// add records a flow received in the current session.
+func (e *autoExporter) add(id string) {
+	if !e.enabled() {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.ids != nil {
+		e.ids[id] = struct{}{}
+	}
+}
+
+// end records that mitmproxy disconnected and exports the session if it was
+// the last one connected.
+func (e *autoExporter) end(ctx context.Context) {
+	if !e.enabled() {
+		return
+	}
+	e.exportMu.Lock()
+	defer e.exportMu.Unlock()
+	e.mu.Lock()
+	e.streams--
+	if e.streams > 0 {
+		e.mu.Unlock()
+		return
+	}
+	start, ids := e.start, e.ids
+	e.ids = nil
+	e.mu.Unlock()
+	e.export(ctx, start, ids)
+}
+
+// run exports the current session every interval until ctx is done.
+func (e *autoExporter) run(ctx context.Context) {
+	if !e.enabled() || e.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.exportCurrent(ctx)
+		}
+	}
+}
+
+func (e *autoExporter) exportCurrent(ctx context.Context) {
+	e.exportMu.Lock()
+	defer e.exportMu.Unlock()
+	e.mu.Lock()
+	if e.streams == 0 {
+		e.mu.Unlock()
+		return
+	}
+	start, ids := e.start, make(map[string]struct{}, len(e.ids))
+	for id := range e.ids {
+		ids[id] = struct{}{}
+	}
+	e.mu.Unlock()
+	e.export(ctx, start, ids)
+}
+
+func (e *autoExporter) export(ctx context.Context, start time.Time, ids map[string]struct{}) {
+	var flows []*mitmflowv1.Flow
+	for id := range ids {
+		flow, ok := e.storage.GetFlow(id)
+		if !ok {
+			// Pruned or deleted while the session was running.
+			continue
+		}
+		flow, err := e.storage.HydrateFlow(ctx, flow)
+		if err != nil {
+			log.Printf("auto-export: failed to load flow %s: %v", id, err)
+			continue
+		}
+		flows = append(flows, flow)
+	}
+	sort.Slice(flows, func(i, j int) bool {
+		return GetFlowStartTime(flows[i]) < GetFlowStartTime(flows[j])
+	})
+
+	base := "session-" + start.UTC().Format("20060102T150405Z")
+	for _, rule := range e.rules {
+		data, filename, err := encodeFlows(flows, rule.format)
+		if err != nil {
+			log.Printf("auto-export: failed to encode session: %v", err)
+			continue
+		}
+		name := base + filepath.Ext(filename)
+		if err := rule.write(ctx, name, data); err != nil {
+			log.Printf("auto-export: failed to write %s to %s: %v", name, rule.location, err)
+			continue
+		}
+		log.Printf("auto-export: wrote %d flows to %s", len(flows), path.Join(rule.location, name))
+	}
+}
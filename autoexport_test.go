@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestParseAutoExportRule(t *testing.T) {
+	rule, err := parseAutoExportRule(context.Background(), "jsonl=/tmp/captures")
+	require.NoError(t, err)
+	assert.Equal(t, mitmflowv1.ExportFormat_EXPORT_FORMAT_JSONL, rule.format)
+	assert.Equal(t, "/tmp/captures", rule.location)
+	assert.Nil(t, rule.s3)
+
+	for _, spec := range []string{"/tmp/captures", "har=", "xml=/tmp/captures", "har=s3://"} {
+		_, err := parseAutoExportRule(context.Background(), spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestAutoExport(t *testing.T) {
+	out := t.TempDir()
+	server, storage := newTestServer(t, WithAutoExport(0,
+		autoExportRule{format: mitmflowv1.ExportFormat_EXPORT_FORMAT_HAR, location: out},
+		autoExportRule{format: mitmflowv1.ExportFormat_EXPORT_FORMAT_JSONL, location: out},
+	))
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	save := func(id string, offset time.Duration) {
+		require.NoError(t, storage.SaveFlow(mitmflowv1.Flow_builder{
+			HttpFlow: mitmproxyv1.HTTPFlow_builder{
+				Id:             proto.String(id),
+				TimestampStart: timestamppb.New(start.Add(offset)),
+				Request: mitmproxyv1.Request_builder{
+					Method: proto.String("GET"),
+					Url:    proto.String("https://example.com/" + id),
+				}.Build(),
+			}.Build(),
+		}.Build()))
+	}
+	save("before", 0)
+
+	e := server.autoExport
+	ctx := context.Background()
+	e.begin()
+	e.begin()
+	save("b", 2*time.Second)
+	e.add("b")
+	save("a", time.Second)
+	e.add("a")
+	e.add("pruned")
+	e.end(ctx)
+	entries, _ := os.ReadDir(out)
+	assert.Empty(t, entries, "the session lasts while any mitmproxy is connected")
+
+	e.exportCurrent(ctx)
+	entries, err := os.ReadDir(out)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	save("c", 3*time.Second)
+	e.add("c")
+	e.end(ctx)
+	name := "session-" + e.start.UTC().Format("20060102T150405Z")
+	entries, err = os.ReadDir(out)
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "the final export replaces the periodic one")
+	assert.Equal(t, name+".har", entries[0].Name())
+	assert.Equal(t, name+".jsonl", entries[1].Name())
+
+	data, err := os.ReadFile(filepath.Join(out, name+".jsonl"))
+	require.NoError(t, err)
+	var ids []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		flow := &mitmflowv1.Flow{}
+		require.NoError(t, protojson.Unmarshal(scanner.Bytes(), flow))
+		ids = append(ids, GetFlowID(flow))
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+
+	// Flows outside of a session are ignored.
+	e.add("before")
+	assert.Nil(t, e.ids)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// A backup is a gzipped tarball holding a manifest plus one serialized Flow
+// per entry under flows/. Flows are hydrated before they are written, so a
+// backup doesn't depend on the blob store of the instance that created it.
+const (
+	backupManifestName = "manifest.json"
+	backupFlowsDir     = "flows/"
+	backupVersion      = 1
+)
+
+type backupManifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	FlowCount int       `json:"flow_count"`
+}
+
+// WriteBackup writes every stored flow, including pins and notes, to w and
+// returns the number of flows written.
+func (s *FlowStorage) WriteBackup(ctx context.Context, w io.Writer) (int, error) {
+	// The flows are collected up front, as the manifest that starts the
+	// backup records how many there are.
+	flows := s.GetFlows()
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+
+	manifest, err := json.Marshal(backupManifest{
+		Version:   backupVersion,
+		CreatedAt: now.UTC(),
+		FlowCount: len(flows),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := writeTarFile(tw, backupManifestName, manifest, now); err != nil {
+		return 0, err
+	}
+
+	for i, flow := range flows {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+		flow, err := s.HydrateFlow(ctx, flow)
+		if err != nil {
+			return i, err
+		}
+		data, err := proto.Marshal(flow)
+		if err != nil {
+			return i, fmt.Errorf("failed to marshal flow: %w", err)
+		}
+		if err := writeTarFile(tw, backupFlowsDir+GetFlowID(flow)+".binpb", data, now); err != nil {
+			return i, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return len(flows), err
+	}
+	return len(flows), gz.Close()
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// ReadBackup reads a backup written by WriteBackup and calls fn for each flow.
+func ReadBackup(r io.Reader, fn func(*mitmflowv1.Flow) error) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("invalid backup: %w", err)
+	}
+	defer gz.Close() //nolint:errcheck
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid backup: %w", err)
+		}
+
+		switch {
+		case hdr.Name == backupManifestName:
+			var manifest backupManifest
+			if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+				return fmt.Errorf("invalid backup manifest: %w", err)
+			}
+			if manifest.Version > backupVersion {
+				return fmt.Errorf("unsupported backup version %d", manifest.Version)
+			}
+		case strings.HasPrefix(hdr.Name, backupFlowsDir) && path.Ext(hdr.Name) == ".binpb":
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", hdr.Name, err)
+			}
+			flow := &mitmflowv1.Flow{}
+			if err := proto.Unmarshal(data, flow); err != nil {
+				return fmt.Errorf("failed to unmarshal %s: %w", hdr.Name, err)
+			}
+			if err := fn(flow); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestBackupRestore(t *testing.T) {
+	srcDir, err := os.MkdirTemp("", "mitmflow_backup_src")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(srcDir)) })
+	src, err := NewFlowStorage(srcDir, 100)
+	require.NoError(t, err)
+	defer src.Close()
+
+	now := time.Now()
+	for i, id := range []string{"flow-1", "flow-2", "flow-3"} {
+		flow := createFlow(id, now.Add(time.Duration(i)*time.Second))
+		if id == "flow-2" {
+			flow.SetPinned(true)
+			flow.SetNote("important")
+		}
+		require.NoError(t, src.SaveFlow(flow))
+	}
+
+	var buf bytes.Buffer
+	count, err := src.WriteBackup(context.Background(), &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	dstDir, err := os.MkdirTemp("", "mitmflow_backup_dst")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(dstDir)) })
+	dst, err := NewFlowStorage(dstDir, 100)
+	require.NoError(t, err)
+	defer dst.Close()
+
+	// Existing flows are dropped when restoring with replace.
+	require.NoError(t, dst.SaveFlow(createFlow("stale", now)))
+
+	server, err := NewMITMFlowServer(dst, nil, WithBackupDir(dstDir))
+	require.NoError(t, err)
+	audit, err := openAuditLog(filepath.Join(dstDir, "audit.jsonl"))
+	require.NoError(t, err)
+	t.Cleanup(func() { audit.Close() }) //nolint:errcheck
+	server.auditLog = audit
+
+	// A corrupt backup leaves the flows alone, even with replace.
+	_, err = server.RestoreBackup(context.Background(), connect.NewRequest(mitmflowv1.RestoreBackupRequest_builder{
+		Data:    buf.Bytes()[:buf.Len()/2],
+		Replace: proto.Bool(true),
+	}.Build()))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	assert.Equal(t, []string{"stale"}, flowIDs(dst.GetFlows()))
+
+	resp, err := server.RestoreBackup(context.Background(), connect.NewRequest(mitmflowv1.RestoreBackupRequest_builder{
+		Data:    buf.Bytes(),
+		Replace: proto.Bool(true),
+	}.Build()))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.Msg.GetCount())
+
+	flows := dst.GetFlows()
+	require.Len(t, flows, 3)
+	assert.Equal(t, []string{"flow-1", "flow-2", "flow-3"}, flowIDs(flows))
+	assert.True(t, flows[1].GetPinned())
+	assert.Equal(t, "important", flows[1].GetNote())
+
+	events, err := server.ListAuditEvents(context.Background(), connect.NewRequest(&mitmflowv1.ListAuditEventsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, events.Msg.GetEvents(), 2)
+	restore, wipe := events.Msg.GetEvents()[0], events.Msg.GetEvents()[1]
+	assert.Equal(t, mitmflowv1.AuditAction_AUDIT_ACTION_RESTORE, restore.GetAction())
+	assert.Equal(t, []string{"flow-1", "flow-2", "flow-3"}, restore.GetFlowIds())
+	assert.Equal(t, mitmflowv1.AuditAction_AUDIT_ACTION_DELETE_ALL, wipe.GetAction())
+	assert.Equal(t, int64(1), wipe.GetCount())
+
+	_, err = server.RestoreBackup(context.Background(), connect.NewRequest(mitmflowv1.RestoreBackupRequest_builder{
+		Path: proto.String("../outside.tar.gz"),
+	}.Build()))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"net/url"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultBandwidthBucket = time.Minute
+	defaultBandwidthLimit  = 50
+)
+
+// GetBandwidthStats adds up the totals of the visible flows matching a
+// filter per server host and per client.
+func (s *MITMFlowServer) GetBandwidthStats(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.GetBandwidthStatsRequest],
+) (*connect.Response[mitmflowv1.GetBandwidthStatsResponse], error) {
+	match, err := parseFilterExpr(req.Msg.GetFilterExpr())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	bucket := defaultBandwidthBucket
+	if n := req.Msg.GetBucketSeconds(); n > 0 {
+		bucket = time.Duration(n) * time.Second
+	}
+	limit := int(req.Msg.GetLimit())
+	if limit <= 0 {
+		limit = defaultBandwidthLimit
+	}
+	var start, end int64
+	if req.Msg.HasStartTime() {
+		start = req.Msg.GetStartTime().AsTime().UnixNano()
+	}
+	if req.Msg.HasEndTime() {
+		end = req.Msg.GetEndTime().AsTime().UnixNano()
+	}
+
+	user := annotationUser(ctx)
+	visible := visibleTo(ctx)
+	filter := req.Msg.GetFilter()
+	hosts := make(map[string]*bandwidthUsage)
+	clients := make(map[string]*bandwidthUsage)
+	total := newBandwidthUsage("")
+	s.storage.Walk(func(flow *mitmflowv1.Flow) bool {
+		at := GetFlowStartTime(flow)
+		if (start != 0 && at < start) || (end != 0 && at > end) {
+			return true
+		}
+		flow = viewAs(flow, user)
+		if !visible(flow) || !matchFlow(flow, filter) || !match(flow) {
+			return true
+		}
+		totals := flowTotals(flow)
+		from := time.Unix(0, at).Truncate(bucket)
+		for _, usage := range []*bandwidthUsage{
+			bandwidthUsageOf(hosts, flowServerHost(flow)),
+			bandwidthUsageOf(clients, flowClientHost(flow)),
+			total,
+		} {
+			usage.add(from, totals)
+		}
+		return true
+	})
+
+	return connect.NewResponse(mitmflowv1.GetBandwidthStatsResponse_builder{
+		Hosts:   busiestBandwidthUsages(hosts, limit),
+		Clients: busiestBandwidthUsages(clients, limit),
+		Total:   total.build(),
+	}.Build()), nil
+}
+
+// flowServerHost returns the host a flow's bandwidth is accounted to: the
+// request's host for HTTP, otherwise the server's hostname when known, or
+// its address.
+func flowServerHost(flow *mitmflowv1.Flow) string {
+	if f := flow.GetHttpFlow(); f != nil {
+		if u, err := url.Parse(f.GetRequest().GetUrl()); err == nil && u.Hostname() != "" {
+			return u.Hostname()
+		}
+	}
+	if extra := flowHostnameExtra(flow); extra != nil && extra.GetServerHostname() != "" {
+		return extra.GetServerHostname()
+	}
+	switch {
+	case flow.GetHttpFlow() != nil:
+		return cmp.Or(flow.GetHttpFlow().GetServer().GetSni(), flow.GetHttpFlow().GetServer().GetAddressHost())
+	case flow.GetTcpFlow() != nil:
+		return cmp.Or(flow.GetTcpFlow().GetServer().GetSni(), flow.GetTcpFlow().GetServer().GetAddressHost())
+	case flow.GetUdpFlow() != nil:
+		return flow.GetUdpFlow().GetServer().GetAddressHost()
+	case flow.GetDnsFlow() != nil:
+		return flow.GetDnsFlow().GetServer().GetAddressHost()
+	}
+	return ""
+}
+
+// flowClientHost returns the IP of a flow's client.
+func flowClientHost(flow *mitmflowv1.Flow) string {
+	switch {
+	case flow.GetHttpFlow() != nil:
+		return flow.GetHttpFlow().GetClient().GetPeernameHost()
+	case flow.GetTcpFlow() != nil:
+		return flow.GetTcpFlow().GetClient().GetPeernameHost()
+	case flow.GetUdpFlow() != nil:
+		return flow.GetUdpFlow().GetClient().GetPeernameHost()
+	case flow.GetDnsFlow() != nil:
+		return flow.GetDnsFlow().GetClient().GetPeernameHost()
+	}
+	return ""
+}
+
+type bandwidthCount struct {
+	sent, received, flows int64
+}
+
+// bandwidthUsage adds up the totals of flows, overall and per time bucket.
+type bandwidthUsage struct {
+	key string
+	bandwidthCount
+	buckets map[time.Time]*bandwidthCount
+}
+
+func newBandwidthUsage(key string) *bandwidthUsage {
+	return &bandwidthUsage{key: key, buckets: make(map[time.Time]*bandwidthCount)}
+}
+
+func bandwidthUsageOf(usages map[string]*bandwidthUsage, key string) *bandwidthUsage {
+	usage, ok := usages[key]
+	if !ok {
+		usage = newBandwidthUsage(key)
+		usages[key] = usage
+	}
+	return usage
+}
+
+func (u *bandwidthUsage) add(bucket time.Time, totals *mitmflowv1.FlowTotals) {
+	count, ok := u.buckets[bucket]
+	if !ok {
+		count = &bandwidthCount{}
+		u.buckets[bucket] = count
+	}
+	for _, c := range []*bandwidthCount{&u.bandwidthCount, count} {
+		c.sent += totals.GetRequestBytes()
+		c.received += totals.GetResponseBytes()
+		c.flows++
+	}
+}
+
+func (u *bandwidthUsage) build() *mitmflowv1.BandwidthUsage {
+	starts := slices.SortedFunc(maps.Keys(u.buckets), time.Time.Compare)
+	buckets := make([]*mitmflowv1.BandwidthBucket, len(starts))
+	for i, start := range starts {
+		count := u.buckets[start]
+		buckets[i] = mitmflowv1.BandwidthBucket_builder{
+			Start:         timestamppb.New(start),
+			BytesSent:     proto.Int64(count.sent),
+			BytesReceived: proto.Int64(count.received),
+			Flows:         proto.Int64(count.flows),
+		}.Build()
+	}
+	return mitmflowv1.BandwidthUsage_builder{
+		Key:           proto.String(u.key),
+		BytesSent:     proto.Int64(u.sent),
+		BytesReceived: proto.Int64(u.received),
+		Flows:         proto.Int64(u.flows),
+		Buckets:       buckets,
+	}.Build()
+}
+
+// busiestBandwidthUsages returns the limit usages with the most bytes sent
+// and received together.
+func busiestBandwidthUsages(usages map[string]*bandwidthUsage, limit int) []*mitmflowv1.BandwidthUsage {
+	sorted := slices.SortedFunc(maps.Values(usages), func(a, b *bandwidthUsage) int {
+		return cmp.Or(cmp.Compare(b.sent+b.received, a.sent+a.received), cmp.Compare(a.key, b.key))
+	})
+	out := make([]*mitmflowv1.BandwidthUsage, 0, min(limit, len(sorted)))
+	for _, usage := range sorted[:min(limit, len(sorted))] {
+		out = append(out, usage.build())
+	}
+	return out
+}
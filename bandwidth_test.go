@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetBandwidthStats(t *testing.T) {
+	server, storage := newTestServer(t)
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	save := func(flow *mitmflowv1.Flow) {
+		server.preprocessFlow(flow)
+		require.NoError(t, storage.SaveFlow(flow))
+	}
+	http := func(id, client, url string, at time.Duration, bodySize int) *mitmflowv1.Flow {
+		return mitmflowv1.Flow_builder{
+			HttpFlow: mitmproxyv1.HTTPFlow_builder{
+				Id:             proto.String(id),
+				TimestampStart: timestamppb.New(start.Add(at)),
+				Client:         mitmproxyv1.ClientConn_builder{PeernameHost: proto.String(client)}.Build(),
+				Request: mitmproxyv1.Request_builder{
+					Method: proto.String("GET"),
+					Url:    proto.String(url),
+				}.Build(),
+				Response: mitmproxyv1.Response_builder{
+					StatusCode: proto.Int32(200),
+					Content:    []byte(strings.Repeat("x", bodySize)),
+				}.Build(),
+			}.Build(),
+		}.Build()
+	}
+	save(http("a", "10.0.0.2", "https://video.example.com/1", 0, 5000))
+	save(http("b", "10.0.0.2", "https://video.example.com/2", 90*time.Second, 3000))
+	save(http("c", "10.0.0.3", "https://api.example.com/", 30*time.Second, 100))
+	save(mitmflowv1.Flow_builder{
+		TcpFlow: mitmproxyv1.TCPFlow_builder{
+			Id:             proto.String("d"),
+			TimestampStart: timestamppb.New(start.Add(time.Minute)),
+			Client:         mitmproxyv1.ClientConn_builder{PeernameHost: proto.String("10.0.0.3")}.Build(),
+			Server:         mitmproxyv1.ServerConn_builder{AddressHost: proto.String("192.0.2.1"), AddressPort: proto.Uint32(4000)}.Build(),
+			Messages: []*mitmproxyv1.TCPMessage{
+				mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(true), Content: []byte("ping")}.Build(),
+			},
+		}.Build(),
+	}.Build())
+
+	res, err := server.GetBandwidthStats(context.Background(), connect.NewRequest(&mitmflowv1.GetBandwidthStatsRequest{}))
+	require.NoError(t, err)
+	hosts := res.Msg.GetHosts()
+	require.Len(t, hosts, 3)
+	assert.Equal(t, "video.example.com", hosts[0].GetKey())
+	assert.Equal(t, int64(2), hosts[0].GetFlows())
+	assert.Greater(t, hosts[0].GetBytesReceived(), int64(8000))
+	require.Len(t, hosts[0].GetBuckets(), 2)
+	assert.Equal(t, start, hosts[0].GetBuckets()[0].GetStart().AsTime())
+	assert.Equal(t, start.Add(time.Minute), hosts[0].GetBuckets()[1].GetStart().AsTime())
+	assert.Equal(t, "192.0.2.1", hosts[2].GetKey())
+	assert.Equal(t, int64(4), hosts[2].GetBytesSent())
+
+	clients := res.Msg.GetClients()
+	require.Len(t, clients, 2)
+	assert.Equal(t, "10.0.0.2", clients[0].GetKey())
+	assert.Equal(t, int64(2), clients[1].GetFlows())
+	assert.Equal(t, int64(4), res.Msg.GetTotal().GetFlows())
+	assert.Equal(t, hosts[0].GetBytesReceived()+hosts[1].GetBytesReceived()+hosts[2].GetBytesReceived(), res.Msg.GetTotal().GetBytesReceived())
+
+	res, err = server.GetBandwidthStats(context.Background(), connect.NewRequest(mitmflowv1.GetBandwidthStatsRequest_builder{
+		FilterExpr:    proto.String("~http"),
+		StartTime:     timestamppb.New(start.Add(time.Second)),
+		BucketSeconds: proto.Int32(3600),
+		Limit:         proto.Int32(1),
+	}.Build()))
+	require.NoError(t, err)
+	require.Len(t, res.Msg.GetHosts(), 1)
+	assert.Equal(t, "video.example.com", res.Msg.GetHosts()[0].GetKey())
+	assert.Equal(t, int64(1), res.Msg.GetHosts()[0].GetFlows())
+	assert.Len(t, res.Msg.GetHosts()[0].GetBuckets(), 1)
+	assert.Equal(t, int64(2), res.Msg.GetTotal().GetFlows())
+}
@@ -0,0 +1,453 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"connectrpc.com/connect"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// volatileHeaders differ from one response to the next without anything
+// having changed, so they aren't compared with the baseline.
+var volatileHeaders = map[string]bool{
+	"age":                           true,
+	"alt-svc":                       true,
+	"cf-ray":                        true,
+	"connection":                    true,
+	"content-encoding":              true,
+	"content-length":                true,
+	"date":                          true,
+	"etag":                          true,
+	"expires":                       true,
+	"keep-alive":                    true,
+	"last-modified":                 true,
+	"nel":                           true,
+	"report-to":                     true,
+	"server":                        true,
+	"server-timing":                 true,
+	"set-cookie":                    true,
+	"traceparent":                   true,
+	"transfer-encoding":             true,
+	"via":                           true,
+	"x-amz-cf-id":                   true,
+	"x-amz-cf-pop":                  true,
+	"x-amzn-requestid":              true,
+	"x-amzn-trace-id":               true,
+	"x-cache":                       true,
+	"x-correlation-id":              true,
+	"x-envoy-upstream-service-time": true,
+	"x-request-id":                  true,
+	"x-response-time":               true,
+	"x-runtime":                     true,
+	"x-served-by":                   true,
+	"x-timer":                       true,
+	"x-trace-id":                    true,
+}
+
+// baselineEntry is what a baseline response is compared by.
+type baselineEntry struct {
+	flowID  string
+	status  int32
+	headers map[string]string
+	// shape maps the paths of a JSON body to their types. It is nil when the
+	// body isn't JSON.
+	shape map[string]string
+}
+
+// baseline holds the responses of a recorded session, by method and path,
+// for later sessions to be compared with. It is kept in filename, when set,
+// so it survives restarts.
+type baseline struct {
+	mu       sync.RWMutex
+	filename string
+	flows    []*mitmflowv1.Flow
+	entries  map[string]baselineEntry
+}
+
+func newBaseline() *baseline {
+	return &baseline{}
+}
+
+// load reads the baseline saved by an earlier run, if any.
+func (b *baseline) load() error {
+	if b == nil || b.filename == "" {
+		return nil
+	}
+	data, err := os.ReadFile(b.filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	set := &mitmflowv1.FlowSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		return fmt.Errorf("invalid baseline %s: %w", b.filename, err)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.setLocked(set.GetFlows())
+	return nil
+}
+
+// set replaces the baseline with flows, which must be hydrated and in start
+// time order. The latest response for a method and path wins. An empty set
+// clears the baseline.
+func (b *baseline) set(flows []*mitmflowv1.Flow) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.setLocked(flows)
+	if b.filename != "" {
+		if len(b.flows) == 0 {
+			if err := os.Remove(b.filename); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return 0, err
+			}
+		} else {
+			data, err := proto.Marshal(mitmflowv1.FlowSet_builder{Flows: b.flows}.Build())
+			if err != nil {
+				return 0, err
+			}
+			if err := writeFileAtomic(b.filename, data, 0644); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(b.entries), nil
+}
+
+func (b *baseline) setLocked(flows []*mitmflowv1.Flow) {
+	latest := make(map[string]*mitmflowv1.Flow)
+	for _, flow := range flows {
+		if key, ok := baselineKey(flow); ok {
+			latest[key] = flow
+		}
+	}
+	b.flows = make([]*mitmflowv1.Flow, 0, len(latest))
+	b.entries = make(map[string]baselineEntry, len(latest))
+	for key, flow := range latest {
+		b.flows = append(b.flows, flow)
+		res := flow.GetHttpFlow().GetResponse()
+		headers := make(map[string]string)
+		for k, v := range res.GetHeaders() {
+			headers[strings.ToLower(k)] = v
+		}
+		b.entries[key] = baselineEntry{
+			flowID:  GetFlowID(flow),
+			status:  res.GetStatusCode(),
+			headers: headers,
+			shape:   responseJSONShape(flow),
+		}
+	}
+	sort.Slice(b.flows, func(i, j int) bool {
+		return GetFlowStartTime(b.flows[i]) < GetFlowStartTime(b.flows[j])
+	})
+}
+
+func (b *baseline) empty() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.entries) == 0
+}
+
+// compare returns how the response of an HTTP flow regressed from the
+// baseline response for the same method and path. It returns nil when the
+// flow has no response or the baseline has nothing to compare it with.
+func (b *baseline) compare(flow *mitmflowv1.Flow) *mitmflowv1.BaselineComparison {
+	if b == nil {
+		return nil
+	}
+	key, ok := baselineKey(flow)
+	if !ok {
+		return nil
+	}
+	b.mu.RLock()
+	entry, ok := b.entries[key]
+	b.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	h := flow.GetHttpFlow()
+	method, path, _ := strings.Cut(key, " ")
+	result := mitmflowv1.BaselineComparison_builder{
+		FlowId:         proto.String(GetFlowID(flow)),
+		BaselineFlowId: proto.String(entry.flowID),
+		Method:         proto.String(method),
+		Path:           proto.String(path),
+	}.Build()
+
+	var diffs []*mitmflowv1.BaselineDifference
+	diff := func(kind mitmflowv1.BaselineDifferenceKind, field, baseline, actual string) {
+		diffs = append(diffs, mitmflowv1.BaselineDifference_builder{
+			Kind:     kind.Enum(),
+			Field:    proto.String(field),
+			Baseline: proto.String(baseline),
+			Actual:   proto.String(actual),
+		}.Build())
+	}
+
+	if status := h.GetResponse().GetStatusCode(); status != entry.status {
+		diff(mitmflowv1.BaselineDifferenceKind_BASELINE_DIFFERENCE_KIND_STATUS, "status",
+			strconv.Itoa(int(entry.status)), strconv.Itoa(int(status)))
+	}
+
+	headers := make(map[string]string)
+	for k, v := range h.GetResponse().GetHeaders() {
+		headers[strings.ToLower(k)] = v
+	}
+	names := make([]string, 0, len(entry.headers))
+	for name := range entry.headers {
+		if !volatileHeaders[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if actual := headers[name]; actual != entry.headers[name] {
+			diff(mitmflowv1.BaselineDifferenceKind_BASELINE_DIFFERENCE_KIND_HEADER, name, entry.headers[name], actual)
+		}
+	}
+
+	if entry.shape != nil {
+		shape := responseJSONShape(flow)
+		if shape == nil {
+			diff(mitmflowv1.BaselineDifferenceKind_BASELINE_DIFFERENCE_KIND_BODY, "$", entry.shape["$"], "")
+		} else {
+			for _, field := range compareJSONShapes(entry.shape, shape) {
+				diff(mitmflowv1.BaselineDifferenceKind_BASELINE_DIFFERENCE_KIND_BODY, field, entry.shape[field], shape[field])
+			}
+		}
+	}
+	result.SetDifferences(diffs)
+	return result
+}
+
+// baselineKey is the method and path that flows are matched to the baseline
+// by. The host and query are left out, so a session recorded against one
+// environment can be the baseline for another.
+func baselineKey(flow *mitmflowv1.Flow) (string, bool) {
+	if !flow.GetHttpFlow().HasResponse() {
+		return "", false
+	}
+	return requestKey(flow)
+}
+
+// requestKey is the method and path of an HTTP flow's request, like "GET
+// /users".
+func requestKey(flow *mitmflowv1.Flow) (string, bool) {
+	h := flow.GetHttpFlow()
+	if h == nil {
+		return "", false
+	}
+	u, err := url.Parse(h.GetRequest().GetUrl())
+	if err != nil {
+		return "", false
+	}
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	return strings.ToUpper(h.GetRequest().GetMethod()) + " " + path, true
+}
+
+// responseJSONShape returns the paths and types of the fields of a JSON
+// response body, or nil when the body isn't JSON.
+func responseJSONShape(flow *mitmflowv1.Flow) map[string]string {
+	res := flow.GetHttpFlow().GetResponse()
+	mediaType, _, _ := mime.ParseMediaType(getHeaderValue(res.GetHeaders(), "Content-Type"))
+	if !isJSONMediaType(mediaType) {
+		return nil
+	}
+	content := res.GetContent()
+	if decoded, ok := decodeContentEncoding(content, getHeaderValue(res.GetHeaders(), "Content-Encoding")); ok {
+		content = decoded
+	}
+	var v any
+	if err := json.Unmarshal(content, &v); err != nil {
+		return nil
+	}
+	shape := make(map[string]string)
+	addJSONShape(shape, "$", v)
+	return shape
+}
+
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// addJSONShape records the type of v at path and of everything inside it.
+// Array elements share a path ending in "[]"; the first element of a type
+// decides the shape of the others.
+func addJSONShape(shape map[string]string, path string, v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		shape[path] = "object"
+		for k, item := range v {
+			addJSONShape(shape, path+"."+k, item)
+		}
+	case []any:
+		shape[path] = "array"
+		for _, item := range v {
+			if _, seen := shape[path+"[]"]; !seen {
+				addJSONShape(shape, path+"[]", item)
+			}
+		}
+	case string:
+		shape[path] = "string"
+	case float64:
+		shape[path] = "number"
+	case bool:
+		shape[path] = "boolean"
+	case nil:
+		shape[path] = "null"
+	}
+}
+
+// compareJSONShapes returns the paths of the baseline that are missing from
+// actual or changed type, sorted. Fields that were null in the baseline may
+// hold anything, and fields inside arrays that are empty in actual can't be
+// compared.
+func compareJSONShapes(baseline, actual map[string]string) []string {
+	var fields []string
+	for path, typ := range baseline {
+		if typ == "null" || actual[path] == typ {
+			continue
+		}
+		if _, ok := actual[path]; !ok && inEmptyArray(actual, path) {
+			continue
+		}
+		fields = append(fields, path)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func inEmptyArray(shape map[string]string, path string) bool {
+	for i := strings.Index(path, "[]"); i >= 0; {
+		if shape[path[:i]] == "array" {
+			if _, ok := shape[path[:i+2]]; !ok {
+				return true
+			}
+		}
+		next := strings.Index(path[i+2:], "[]")
+		if next < 0 {
+			break
+		}
+		i += 2 + next
+	}
+	return false
+}
+
+// sessionFlows returns the hydrated HTTP flows with responses that sel
+// picks, in start time order.
+func (s *MITMFlowServer) sessionFlows(ctx context.Context, sel *mitmflowv1.SessionSelector) ([]*mitmflowv1.Flow, error) {
+	match, err := parseFilterExpr(sel.GetFilter())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	var start, end int64
+	if sel.HasStartTime() {
+		start = sel.GetStartTime().AsTime().UnixNano()
+	}
+	if sel.HasEndTime() {
+		end = sel.GetEndTime().AsTime().UnixNano()
+	}
+
+	visible := visibleTo(ctx)
+	var flows []*mitmflowv1.Flow
+	s.storage.Walk(func(flow *mitmflowv1.Flow) bool {
+		if !flow.GetHttpFlow().HasResponse() || !visible(flow) {
+			return true
+		}
+		at := GetFlowStartTime(flow)
+		if (start != 0 && at < start) || (end != 0 && at > end) || !match(flow) {
+			return true
+		}
+		flows = append(flows, flow)
+		return true
+	})
+	for i, flow := range flows {
+		if flows[i], err = s.storage.HydrateFlow(ctx, flow); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	return flows, nil
+}
+
+// SetBaseline records the responses of a session as the baseline that new
+// flows and CompareSessions compare with.
+func (s *MITMFlowServer) SetBaseline(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.SetBaselineRequest],
+) (*connect.Response[mitmflowv1.SetBaselineResponse], error) {
+	var flows []*mitmflowv1.Flow
+	if req.Msg.HasSession() {
+		var err error
+		if flows, err = s.sessionFlows(ctx, req.Msg.GetSession()); err != nil {
+			return nil, err
+		}
+	}
+	count, err := s.baseline.set(flows)
+	if err != nil {
+		log.Printf("Failed to save baseline: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	log.Printf("Baseline set from %d flows with %d distinct requests", len(flows), count)
+	detail := "cleared"
+	if req.Msg.HasSession() {
+		detail = req.Msg.GetSession().GetFilter()
+	}
+	s.audit(ctx, req.Peer(), mitmflowv1.AuditAction_AUDIT_ACTION_SET_BASELINE, flowIDs(flows), len(flows), detail)
+	return connect.NewResponse(mitmflowv1.SetBaselineResponse_builder{
+		Count: proto.Int64(int64(count)),
+	}.Build()), nil
+}
+
+// CompareSessions compares the responses of a session with the baseline and
+// reports the flows that regressed.
+func (s *MITMFlowServer) CompareSessions(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.CompareSessionsRequest],
+) (*connect.Response[mitmflowv1.CompareSessionsResponse], error) {
+	if s.baseline.empty() {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("no baseline is set"))
+	}
+	flows, err := s.sessionFlows(ctx, req.Msg.GetSession())
+	if err != nil {
+		return nil, err
+	}
+
+	var regressions []*mitmflowv1.BaselineComparison
+	var matched, unmatched int64
+	for _, flow := range flows {
+		comparison := s.baseline.compare(flow)
+		if comparison == nil {
+			unmatched++
+			continue
+		}
+		matched++
+		if len(comparison.GetDifferences()) > 0 {
+			regressions = append(regressions, comparison)
+		}
+	}
+	return connect.NewResponse(mitmflowv1.CompareSessionsResponse_builder{
+		Regressions:    regressions,
+		MatchedCount:   proto.Int64(matched),
+		UnmatchedCount: proto.Int64(unmatched),
+	}.Build()), nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCompareSessions(t *testing.T) {
+	baselineFile := filepath.Join(t.TempDir(), "baseline.binpb")
+	server, storage := newTestServer(t, WithBaselineFile(baselineFile))
+
+	jsonHeaders := func(extra ...string) map[string]string {
+		h := map[string]string{"Content-Type": "application/json", "Cache-Control": "no-store", "Date": "Wed, 01 May 2024 12:00:00 GMT"}
+		for i := 0; i+1 < len(extra); i += 2 {
+			h[extra[i]] = extra[i+1]
+		}
+		return h
+	}
+	build := func(n int) flowOption {
+		return withMetadata(map[string]string{"build": strconv.Itoa(n)})
+	}
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, flow := range []*mitmflowv1.Flow{
+		createHTTPFlow("b-user", "https://staging.example.com/api/user?id=1", start, build(1), withResponseHeaders(jsonHeaders()),
+			withResponseBody(`{"id":1,"name":"gopher","email":null,"roles":[{"name":"admin"}],"tags":[{"name":"x"}]}`)),
+		createHTTPFlow("b-items", "https://staging.example.com/api/items", start.Add(time.Second), build(1),
+			withResponseHeaders(jsonHeaders()), withResponseBody(`[1,2]`)),
+		createHTTPFlow("b-home", "https://staging.example.com/", start.Add(2*time.Second), build(1),
+			withResponseHeaders(map[string]string{"Content-Type": "text/html"}), withResponseBody("<html></html>")),
+	} {
+		require.NoError(t, storage.SaveFlow(flow))
+	}
+
+	session := mitmflowv1.SessionSelector_builder{Filter: proto.String("~meta ^build=1$")}.Build()
+	resp, err := server.SetBaseline(context.Background(), connect.NewRequest(mitmflowv1.SetBaselineRequest_builder{
+		Session: session,
+	}.Build()))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.Msg.GetCount())
+	require.FileExists(t, baselineFile)
+
+	for _, flow := range []*mitmflowv1.Flow{
+		// The email became a string (fine, it was null), the roles lost their
+		// name, the id became a string and tags is empty.
+		createHTTPFlow("c-user", "https://prod.example.com/api/user?id=2", start.Add(time.Hour), build(2),
+			withResponseHeaders(jsonHeaders("Date", "Thu, 02 May 2024 12:00:00 GMT")),
+			withResponseBody(`{"id":"1","name":"gopher","email":"g@example.com","roles":[{}],"tags":[]}`)),
+		createHTTPFlow("c-items", "https://prod.example.com/api/items", start.Add(time.Hour), build(2), withStatus(500),
+			withResponseHeaders(map[string]string{"Content-Type": "text/plain"}), withResponseBody("oops")),
+		createHTTPFlow("c-home", "https://prod.example.com/", start.Add(time.Hour), build(2),
+			withResponseHeaders(map[string]string{"Content-Type": "text/html"}), withResponseBody("<html>new</html>")),
+		createHTTPFlow("c-new", "https://prod.example.com/api/new", start.Add(time.Hour), build(2),
+			withResponseHeaders(jsonHeaders()), withResponseBody(`{}`)),
+	} {
+		require.NoError(t, storage.SaveFlow(flow))
+	}
+
+	// A restarted server picks up the saved baseline.
+	server, err = NewMITMFlowServer(storage, nil, WithBaselineFile(baselineFile))
+	require.NoError(t, err)
+	compare, err := server.CompareSessions(context.Background(), connect.NewRequest(mitmflowv1.CompareSessionsRequest_builder{
+		Session: mitmflowv1.SessionSelector_builder{Filter: proto.String("~meta ^build=2$")}.Build(),
+	}.Build()))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), compare.Msg.GetMatchedCount())
+	assert.Equal(t, int64(1), compare.Msg.GetUnmatchedCount())
+
+	type difference struct {
+		kind                    mitmflowv1.BaselineDifferenceKind
+		field, baseline, actual string
+	}
+	got := map[string][]difference{}
+	for _, c := range compare.Msg.GetRegressions() {
+		for _, d := range c.GetDifferences() {
+			got[c.GetFlowId()] = append(got[c.GetFlowId()], difference{d.GetKind(), d.GetField(), d.GetBaseline(), d.GetActual()})
+		}
+	}
+	const (
+		status = mitmflowv1.BaselineDifferenceKind_BASELINE_DIFFERENCE_KIND_STATUS
+		header = mitmflowv1.BaselineDifferenceKind_BASELINE_DIFFERENCE_KIND_HEADER
+		body   = mitmflowv1.BaselineDifferenceKind_BASELINE_DIFFERENCE_KIND_BODY
+	)
+	assert.Equal(t, map[string][]difference{
+		"c-user": {
+			{body, "$.id", "number", "string"},
+			{body, "$.roles[].name", "string", ""},
+		},
+		"c-items": {
+			{status, "status", "200", "500"},
+			{header, "cache-control", "no-store", ""},
+			{header, "content-type", "application/json", "text/plain"},
+			{body, "$", "array", ""},
+		},
+	}, got)
+	assert.Equal(t, "b-items", compare.Msg.GetRegressions()[1].GetBaselineFlowId())
+
+	// New flows are compared as they arrive.
+	flow := createHTTPFlow("live", "https://prod.example.com/api/items", start.Add(2*time.Hour), build(3), withStatus(404),
+		withResponseHeaders(jsonHeaders()), withResponseBody(`[]`))
+	server.preprocessFlow(flow)
+	assert.Equal(t, "b-items", flow.GetHttpFlowExtra().GetBaseline().GetBaselineFlowId())
+	assert.Len(t, flow.GetHttpFlowExtra().GetBaseline().GetDifferences(), 1)
+
+	_, err = server.SetBaseline(context.Background(), connect.NewRequest(&mitmflowv1.SetBaselineRequest{}))
+	require.NoError(t, err)
+	assert.NoFileExists(t, baselineFile)
+	_, err = server.CompareSessions(context.Background(), connect.NewRequest(mitmflowv1.CompareSessionsRequest_builder{
+		Session: session,
+	}.Build()))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+}
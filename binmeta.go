@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/base64"
+	"sort"
+	"strings"
+
+	"github.com/protocolbuffers/protoscope"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// decodeBinaryHeader decodes the value of a header or trailer whose name
+// ends in -bin. gRPC sends those base64 encoded, with or without padding,
+// and joins repeated values with commas. mitmproxy passes them on as they
+// were sent, but values that aren't base64, like ones a client sent raw,
+// are taken as the bytes themselves.
+func decodeBinaryHeader(value string) []byte {
+	var out []byte
+	for _, part := range strings.Split(value, ",") {
+		decoded, ok := decodeBase64(strings.TrimSpace(part))
+		if !ok {
+			return []byte(value)
+		}
+		out = append(out, decoded...)
+	}
+	return out
+}
+
+func decodeBase64(s string) ([]byte, bool) {
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if decoded, err := enc.DecodeString(s); err == nil {
+			return decoded, true
+		}
+	}
+	return nil, false
+}
+
+// binaryMetadata decodes the -bin headers and trailers of a message, sorted
+// by key with the headers first.
+func binaryMetadata(headers, trailers map[string]string) []*mitmflowv1.BinaryMetadata {
+	var out []*mitmflowv1.BinaryMetadata
+	for _, fields := range []struct {
+		values  map[string]string
+		trailer bool
+	}{{headers, false}, {trailers, true}} {
+		start := len(out)
+		for k, v := range fields.values {
+			key := strings.ToLower(k)
+			if !strings.HasSuffix(key, "-bin") {
+				continue
+			}
+			value := decodeBinaryHeader(v)
+			out = append(out, mitmflowv1.BinaryMetadata_builder{
+				Key:     proto.String(key),
+				Trailer: proto.Bool(fields.trailer),
+				Value:   value,
+				Text:    proto.String(binaryMetadataText(key, value)),
+			}.Build())
+		}
+		added := out[start:]
+		sort.Slice(added, func(i, j int) bool { return added[i].GetKey() < added[j].GetKey() })
+	}
+	return out
+}
+
+// binaryMetadataText renders a decoded -bin value readably.
+func binaryMetadataText(key string, value []byte) string {
+	if key == "grpc-status-details-bin" {
+		if text := formatErrorDetails(value); text != nil {
+			return *text
+		}
+	}
+	if isBinary(value) && looksLikeProtobuf(value) {
+		return protoscope.Write(value, protoscope.WriterOptions{})
+	}
+	if !isBinary(value) {
+		return string(value)
+	}
+	return hexdumpFrame(value)
+}
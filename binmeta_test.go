@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	statuspb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodeBinaryHeader(t *testing.T) {
+	value := []byte{0x08, 0x05, 0xff}
+	assert.Equal(t, value, decodeBinaryHeader(base64.StdEncoding.EncodeToString(value)))
+	assert.Equal(t, value, decodeBinaryHeader(base64.RawStdEncoding.EncodeToString(value)), "padding is optional")
+	assert.Equal(t, []byte("ab"), decodeBinaryHeader("YQ, Yg=="), "repeated values are joined")
+	assert.Equal(t, []byte("raw value!"), decodeBinaryHeader("raw value!"), "values that aren't base64 are taken as is")
+}
+
+func TestBinaryMetadata(t *testing.T) {
+	status, err := proto.Marshal(&statuspb.Status{Code: 5, Message: "no such user"})
+	require.NoError(t, err)
+	entries := binaryMetadata(
+		map[string]string{"Trace-Bin": base64.RawStdEncoding.EncodeToString([]byte{0x08, 0x96, 0x01}), "content-type": "application/grpc"},
+		map[string]string{"grpc-status-details-bin": base64.RawStdEncoding.EncodeToString(status), "x-blob-bin": "AAEC"},
+	)
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "trace-bin", entries[0].GetKey())
+	assert.False(t, entries[0].GetTrailer())
+	assert.Equal(t, []byte{0x08, 0x96, 0x01}, entries[0].GetValue())
+	assert.Equal(t, "1: 150\n", entries[0].GetText())
+
+	assert.Equal(t, "grpc-status-details-bin", entries[1].GetKey())
+	assert.True(t, entries[1].GetTrailer())
+	// protojson randomizes its whitespace, so it isn't matched exactly.
+	assert.Regexp(t, `"message":\s+"no such user"`, entries[1].GetText())
+
+	assert.Equal(t, "x-blob-bin", entries[2].GetKey())
+	assert.Equal(t, hexdumpFrame([]byte{0, 1, 2}), entries[2].GetText())
+}
+
+func TestParseErrorDetailsSources(t *testing.T) {
+	status, err := proto.Marshal(&statuspb.Status{Code: 5, Message: "no such user"})
+	require.NoError(t, err)
+	details := base64.RawStdEncoding.EncodeToString(status)
+
+	frames, err := parseGrpcFrames(nil, map[string]string{"Grpc-Status-Details-Bin": details}, nil)
+	require.NoError(t, err)
+	assert.Contains(t, frames[len(frames)-1], "no such user", "unpadded details in a trailer with any casing")
+
+	trailer := "grpc-status: 5\r\ngrpc-status-details-bin: " + details + "\r\n"
+	body := append([]byte{0x80, 0, 0, 0, byte(len(trailer))}, trailer...)
+	frames, err = parseGrpcWebFrames(body, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, frames, 2)
+	assert.Contains(t, frames[1], "no such user", "details in the trailer frame of a gRPC-Web body")
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// ErrBlobNotFound is returned by BlobStore.Get when no blob exists for a key.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore stores large message bodies outside of the flow records. Blobs
+// are content-addressed: the key is the hex encoded SHA-256 of the data, so
+// identical bodies are only stored once.
+type BlobStore interface {
+	// Put stores data under key. Storing an existing key is a no-op.
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns the data stored under key or ErrBlobNotFound.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete removes the blob stored under key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// NewBlobStore creates a blob store from a location. Locations of the form
+// s3://bucket/prefix use S3 (configured through the usual AWS environment
+// variables and shared config), anything else is treated as a local directory.
+func NewBlobStore(ctx context.Context, location string) (BlobStore, error) {
+	if rest, ok := strings.CutPrefix(location, "s3://"); ok {
+		bucket, prefix, _ := strings.Cut(rest, "/")
+		if bucket == "" {
+			return nil, fmt.Errorf("invalid s3 location: %s", location)
+		}
+		cfg, err := config.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load aws config: %w", err)
+		}
+		return &s3BlobStore{
+			client: s3.NewFromConfig(cfg),
+			bucket: bucket,
+			prefix: strings.Trim(prefix, "/"),
+		}, nil
+	}
+	return NewLocalBlobStore(location)
+}
+
+func blobKey(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func validBlobKey(key string) bool {
+	if len(key) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(key)
+	return err == nil
+}
+
+type localBlobStore struct {
+	dir string
+}
+
+// NewLocalBlobStore creates a blob store that keeps blobs in dir, fanned out
+// into subdirectories by the first two characters of the key.
+func NewLocalBlobStore(dir string) (BlobStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return &localBlobStore{dir: dir}, nil
+}
+
+func (b *localBlobStore) path(key string) (string, error) {
+	if !validBlobKey(key) {
+		return "", fmt.Errorf("invalid blob key: %q", key)
+	}
+	return filepath.Join(b.dir, key[:2], key), nil
+}
+
+func (b *localBlobStore) Put(_ context.Context, key string, data []byte) error {
+	p, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(p); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	return writeFileAtomic(p, data, 0644)
+}
+
+func (b *localBlobStore) Get(_ context.Context, key string) ([]byte, error) {
+	p, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	return data, err
+}
+
+func (b *localBlobStore) Delete(_ context.Context, key string) error {
+	p, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+type s3BlobStore struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+func (b *s3BlobStore) objectKey(key string) (string, error) {
+	if !validBlobKey(key) {
+		return "", fmt.Errorf("invalid blob key: %q", key)
+	}
+	return path.Join(b.prefix, key[:2], key), nil
+}
+
+func (b *s3BlobStore) Put(ctx context.Context, key string, data []byte) error {
+	objectKey, err := b.objectKey(key)
+	if err != nil {
+		return err
+	}
+	_, err = b.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(objectKey),
+		Body:   bytes.NewReader(data),
+	})
+	return err
+}
+
+func (b *s3BlobStore) Get(ctx context.Context, key string) ([]byte, error) {
+	objectKey, err := b.objectKey(key)
+	if err != nil {
+		return nil, err
+	}
+	out, err := b.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(objectKey),
+	})
+	if err != nil {
+		var noSuchKey *s3types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ErrBlobNotFound
+		}
+		return nil, err
+	}
+	defer out.Body.Close() //nolint:errcheck
+	return io.ReadAll(out.Body)
+}
+
+func (b *s3BlobStore) Delete(ctx context.Context, key string) error {
+	objectKey, err := b.objectKey(key)
+	if err != nil {
+		return err
+	}
+	_, err = b.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(objectKey),
+	})
+	return err
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+// CharlesSession is one entry of a Charles JSON session (.chlsj). Charles'
+// native .chls files are an undocumented binary format; the JSON format holds
+// the same information and opens the same way.
+type CharlesSession struct {
+	Status          string           `json:"status"`
+	Method          string           `json:"method"`
+	ProtocolVersion string           `json:"protocolVersion"`
+	Scheme          string           `json:"scheme"`
+	Host            string           `json:"host"`
+	ActualPort      int              `json:"actualPort"`
+	Path            string           `json:"path"`
+	Query           *string          `json:"query"`
+	Tunnel          bool             `json:"tunnel"`
+	RemoteAddress   string           `json:"remoteAddress,omitempty"`
+	ClientAddress   string           `json:"clientAddress,omitempty"`
+	ClientPort      int              `json:"clientPort,omitempty"`
+	Notes           string           `json:"notes,omitempty"`
+	ErrorMessage    string           `json:"errorMessage,omitempty"`
+	Times           CharlesTimes     `json:"times"`
+	Durations       CharlesDurations `json:"durations"`
+	TotalSize       int              `json:"totalSize"`
+	Request         CharlesMessage   `json:"request"`
+	Response        *CharlesMessage  `json:"response,omitempty"`
+}
+
+type CharlesTimes struct {
+	Start           string `json:"start,omitempty"`
+	RequestBegin    string `json:"requestBegin,omitempty"`
+	RequestComplete string `json:"requestComplete,omitempty"`
+	ResponseBegin   string `json:"responseBegin,omitempty"`
+	End             string `json:"end,omitempty"`
+}
+
+// CharlesDurations are in milliseconds; nil means unknown.
+type CharlesDurations struct {
+	Total    *int64 `json:"total"`
+	DNS      *int64 `json:"dns"`
+	Connect  *int64 `json:"connect"`
+	SSL      *int64 `json:"ssl"`
+	Request  *int64 `json:"request"`
+	Response *int64 `json:"response"`
+	Latency  *int64 `json:"latency"`
+}
+
+type CharlesMessage struct {
+	Status          int           `json:"status,omitempty"`
+	Sizes           CharlesSizes  `json:"sizes"`
+	MimeType        string        `json:"mimeType,omitempty"`
+	Charset         string        `json:"charset,omitempty"`
+	ContentEncoding string        `json:"contentEncoding,omitempty"`
+	Header          CharlesHeader `json:"header"`
+	Body            *CharlesBody  `json:"body,omitempty"`
+}
+
+type CharlesSizes struct {
+	Headers int `json:"headers"`
+	Body    int `json:"body"`
+}
+
+type CharlesHeader struct {
+	FirstLine string               `json:"firstLine"`
+	Headers   []CharlesHeaderField `json:"headers"`
+}
+
+type CharlesHeaderField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// CharlesBody holds either text or, for binary content, base64 in Encoded.
+type CharlesBody struct {
+	Text    string `json:"text,omitempty"`
+	Encoded string `json:"encoded,omitempty"`
+}
+
+// GenerateCharles writes HTTP flows as a Charles JSON session.
+func GenerateCharles(flows []*mitmflowv1.Flow) ([]byte, error) {
+	sessions := []CharlesSession{}
+	for _, flow := range flows {
+		if flow.GetHttpFlow() == nil {
+			continue
+		}
+		sessions = append(sessions, createCharlesSession(flow))
+	}
+	return json.MarshalIndent(sessions, "", "  ")
+}
+
+func createCharlesSession(flow *mitmflowv1.Flow) CharlesSession {
+	h := flow.GetHttpFlow()
+	req, res := h.GetRequest(), h.GetResponse()
+	server := h.GetServer()
+
+	session := CharlesSession{
+		Status:          "COMPLETE",
+		Method:          req.GetMethod(),
+		ProtocolVersion: req.GetHttpVersion(),
+		RemoteAddress:   server.GetPeernameHost(),
+		ClientAddress:   h.GetClient().GetPeernameHost(),
+		ClientPort:      int(h.GetClient().GetPeernamePort()),
+		Notes:           flow.GetNote(),
+		ErrorMessage:    h.GetError(),
+	}
+	if u, err := url.Parse(getPrettyURL(req)); err == nil {
+		session.Scheme = u.Scheme
+		session.Host = u.Hostname()
+		session.Path = u.EscapedPath()
+		if u.RawQuery != "" {
+			session.Query = &u.RawQuery
+		}
+		session.ActualPort, _ = strconv.Atoi(u.Port())
+		if session.ActualPort == 0 {
+			session.ActualPort = 80
+			if u.Scheme == "https" {
+				session.ActualPort = 443
+			}
+		}
+	}
+	if res == nil || h.GetError() != "" {
+		session.Status = "FAILED"
+	}
+
+	reqStart := getFlowTime(req.GetTimestampStart())
+	reqEnd := getFlowTime(req.GetTimestampEnd())
+	resStart := getFlowTime(res.GetTimestampStart())
+	resEnd := getFlowTime(res.GetTimestampEnd())
+	connStart := getFlowTime(server.GetTimestampStart())
+	tcpSetup := getFlowTime(server.GetTimestampTcpSetup())
+	tlsSetup := getFlowTime(server.GetTimestampTlsSetup())
+
+	start := reqStart
+	if !connStart.IsZero() && !reqStart.IsZero() && !connStart.Before(reqStart) {
+		// The connection was opened for this request, so it counts toward
+		// the session's durations.
+		session.Durations.Connect = charlesDuration(connStart, tcpSetup)
+		session.Durations.SSL = charlesDuration(tcpSetup, tlsSetup)
+	}
+	end := resEnd
+	if end.IsZero() {
+		end = reqEnd
+	}
+	session.Times = CharlesTimes{
+		Start:           charlesTime(start),
+		RequestBegin:    charlesTime(reqStart),
+		RequestComplete: charlesTime(reqEnd),
+		ResponseBegin:   charlesTime(resStart),
+		End:             charlesTime(end),
+	}
+	session.Durations.Total = charlesDuration(start, end)
+	session.Durations.Request = charlesDuration(reqStart, reqEnd)
+	session.Durations.Response = charlesDuration(resStart, resEnd)
+	session.Durations.Latency = charlesDuration(reqEnd, resStart)
+
+	firstLine := fmt.Sprintf("%s %s %s", req.GetMethod(), getPrettyURL(req), req.GetHttpVersion())
+	session.Request = createCharlesMessage(firstLine, req.GetHeaders(), req.GetContent())
+	session.TotalSize = session.Request.Sizes.Headers + session.Request.Sizes.Body
+	if res != nil {
+		firstLine := fmt.Sprintf("%s %d %s", res.GetHttpVersion(), res.GetStatusCode(), res.GetReason())
+		msg := createCharlesMessage(firstLine, res.GetHeaders(), res.GetContent())
+		msg.Status = int(res.GetStatusCode())
+		session.Response = &msg
+		session.TotalSize += msg.Sizes.Headers + msg.Sizes.Body
+	}
+	return session
+}
+
+func createCharlesMessage(firstLine string, headers map[string]string, content []byte) CharlesMessage {
+	msg := CharlesMessage{
+		Sizes:           CharlesSizes{Body: len(content)},
+		ContentEncoding: getHeaderValue(headers, "Content-Encoding"),
+		Header: CharlesHeader{
+			FirstLine: firstLine,
+			Headers:   []CharlesHeaderField{},
+		},
+	}
+	if mediaType, params, err := mime.ParseMediaType(getHeaderValue(headers, "Content-Type")); err == nil {
+		msg.MimeType = mediaType
+		msg.Charset = params["charset"]
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	msg.Sizes.Headers = len(firstLine) + 2
+	for _, name := range names {
+		msg.Header.Headers = append(msg.Header.Headers, CharlesHeaderField{Name: name, Value: headers[name]})
+		msg.Sizes.Headers += len(name) + len(headers[name]) + 4
+	}
+	msg.Sizes.Headers += 2
+
+	if len(content) > 0 {
+		if isBinary(content) || msg.ContentEncoding != "" {
+			msg.Body = &CharlesBody{Encoded: base64.StdEncoding.EncodeToString(content)}
+		} else {
+			msg.Body = &CharlesBody{Text: string(content)}
+		}
+	}
+	return msg
+}
+
+func charlesTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02T15:04:05.000-07:00")
+}
+
+func charlesDuration(from, to time.Time) *int64 {
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return nil
+	}
+	ms := to.Sub(from).Milliseconds()
+	return &ms
+}
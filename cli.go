@@ -0,0 +1,613 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// subcommands maps the first command line argument to its implementation.
+// Running mitmflow without a subcommand starts the server.
+var subcommands = map[string]func(args []string) error{
+	"serve":  func(args []string) error { runServe(args); return nil },
+	"export": runExport,
+	"import": runImport,
+	"tail":   runTail,
+}
+
+const defaultServerURL = "http://127.0.0.1:50051"
+
+// flowSource is where the export, import and tail subcommands read flows
+// from: a running server when -server is set, otherwise a data directory.
+type flowSource struct {
+	dataDir  string
+	server   string
+	zstdDict string
+}
+
+func addSourceFlags(fs *flag.FlagSet, defaultServer string) *flowSource {
+	src := &flowSource{}
+	fs.StringVar(&src.dataDir, "data-dir", "", "Read flows from this data directory instead of a server")
+	fs.StringVar(&src.server, "server", defaultServer, "URL of a running mitmflow server, sent the token in $"+tokenEnv+" if it requires one")
+	fs.StringVar(&src.zstdDict, "zstd-dict", "", "Path to the zstd dictionary the data directory was written with")
+	return src
+}
+
+// tokenEnv names the environment variable holding the bearer token sent to
+// servers started with -token-file.
+const tokenEnv = "MITMFLOW_TOKEN"
+
+func (src *flowSource) client() mitmflowv1.ServiceClient {
+	var opts []connect.ClientOption
+	if token := os.Getenv(tokenEnv); token != "" {
+		opts = append(opts, connect.WithInterceptors(bearerToken(token)))
+	}
+	return mitmflowv1.NewServiceClient(http.DefaultClient, strings.TrimSuffix(src.server, "/"), opts...)
+}
+
+// bearerToken adds an Authorization header to every request.
+type bearerToken string
+
+func (t bearerToken) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		req.Header().Set("Authorization", "Bearer "+string(t))
+		return next(ctx, req)
+	}
+}
+
+func (t bearerToken) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		conn.RequestHeader().Set("Authorization", "Bearer "+string(t))
+		return conn
+	}
+}
+
+func (t bearerToken) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// openStorage opens the data directory without pruning, with opts added.
+// Offloaded bodies are read from the default blob directory when it exists.
+func (src *flowSource) openStorage(opts ...StorageOption) (*FlowStorage, error) {
+	if src.zstdDict != "" {
+		dict, err := os.ReadFile(src.zstdDict)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read zstd dictionary: %w", err)
+		}
+		opts = append(opts, WithZstdDictionary(dict))
+	}
+	if blobDir := filepath.Join(src.dataDir, "blobs"); dirExists(blobDir) {
+		blobs, err := NewLocalBlobStore(blobDir)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithBlobStore(blobs, 0))
+	}
+	return NewFlowStorage(src.dataDir, math.MaxInt32, opts...)
+}
+
+// flows returns every flow matching the filter expression, oldest first, with
+// bodies loaded. A server applies the filter itself.
+func (src *flowSource) flows(ctx context.Context, filterExpr string) ([]*mitmflowv1.Flow, error) {
+	if src.dataDir == "" {
+		format := mitmflowv1.ExportFormat_EXPORT_FORMAT_PROTO
+		resp, err := src.client().ExportFlows(ctx, connect.NewRequest(mitmflowv1.ExportFlowsRequest_builder{
+			Format:     &format,
+			Filter:     &mitmflowv1.FlowFilter{},
+			FilterExpr: proto.String(filterExpr),
+		}.Build()))
+		if err != nil {
+			return nil, err
+		}
+		set := &mitmflowv1.FlowSet{}
+		if err := proto.Unmarshal(resp.Msg.GetData(), set); err != nil {
+			return nil, err
+		}
+		return set.GetFlows(), nil
+	}
+
+	pred, err := parseFilterExpr(filterExpr)
+	if err != nil {
+		return nil, err
+	}
+	// A server may be running on the data directory, so it is only read.
+	storage, err := src.openStorage(WithReadOnly())
+	if err != nil {
+		return nil, err
+	}
+	defer storage.Close()
+	var matched []*mitmflowv1.Flow
+	for _, flow := range storage.GetFlows() {
+		flow, err := storage.HydrateFlow(ctx, flow)
+		if err != nil {
+			return nil, err
+		}
+		if pred(flow) {
+			matched = append(matched, flow)
+		}
+	}
+	return matched, nil
+}
+
+// anonymize returns anonymized copies of flows. Pseudonyms come from the key
+// of the data directory or server the flows were read from, so they match the
+// ones in earlier exports from the same place.
+func (src *flowSource) anonymize(ctx context.Context, flows []*mitmflowv1.Flow) ([]*mitmflowv1.Flow, error) {
+	if src.dataDir != "" {
+		key, err := loadOrCreateKey(filepath.Join(src.dataDir, anonymizationKeyFile))
+		if err != nil {
+			return nil, err
+		}
+		return newAnonymizer(key).anonymizeFlows(flows), nil
+	}
+	if len(flows) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, len(flows))
+	for i, flow := range flows {
+		ids[i] = GetFlowID(flow)
+	}
+	format := mitmflowv1.ExportFormat_EXPORT_FORMAT_PROTO
+	resp, err := src.client().ExportFlows(ctx, connect.NewRequest(mitmflowv1.ExportFlowsRequest_builder{
+		FlowIds:   ids,
+		Format:    &format,
+		Anonymize: proto.Bool(true),
+	}.Build()))
+	if err != nil {
+		return nil, err
+	}
+	set := &mitmflowv1.FlowSet{}
+	if err := proto.Unmarshal(resp.Msg.GetData(), set); err != nil {
+		return nil, err
+	}
+	return set.GetFlows(), nil
+}
+
+func parseFormat(name string) (mitmflowv1.ExportFormat, error) {
+	switch strings.ToLower(name) {
+	case "har":
+		return mitmflowv1.ExportFormat_EXPORT_FORMAT_HAR, nil
+	case "json":
+		return mitmflowv1.ExportFormat_EXPORT_FORMAT_JSON, nil
+	case "jsonl":
+		return mitmflowv1.ExportFormat_EXPORT_FORMAT_JSONL, nil
+	case "proto", "binpb":
+		return mitmflowv1.ExportFormat_EXPORT_FORMAT_PROTO, nil
+	case "saz":
+		return mitmflowv1.ExportFormat_EXPORT_FORMAT_SAZ, nil
+	case "charles", "chlsj":
+		return mitmflowv1.ExportFormat_EXPORT_FORMAT_CHARLES, nil
+	case "grpc-frames":
+		return mitmflowv1.ExportFormat_EXPORT_FORMAT_GRPC_FRAMES, nil
+	case "grpcurl":
+		return mitmflowv1.ExportFormat_EXPORT_FORMAT_GRPCURL, nil
+	case "buf-curl":
+		return mitmflowv1.ExportFormat_EXPORT_FORMAT_BUF_CURL, nil
+	case "csv":
+		return mitmflowv1.ExportFormat_EXPORT_FORMAT_CSV, nil
+	case "markdown", "md":
+		return mitmflowv1.ExportFormat_EXPORT_FORMAT_MARKDOWN, nil
+	}
+	return 0, fmt.Errorf("unknown format %q, expected har, json, jsonl, proto, saz, charles, grpc-frames, grpcurl, buf-curl, csv or markdown", name)
+}
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	src := addSourceFlags(fs, defaultServerURL)
+	formatName := fs.String("format", "har", "Output format: har, json, jsonl, proto, saz, charles, grpc-frames, grpcurl, buf-curl, csv or markdown")
+	filterExpr := fs.String("filter", "", "Only export flows matching this filter expression, e.g. '~u /api & ~c 200'")
+	output := fs.String("o", "-", "File to write to, or - for stdout")
+	anonymize := fs.Bool("anonymize", false, "Replace hostnames, IP addresses, cookies and credentials with stable pseudonyms")
+	shiftTimestamps := fs.Bool("shift-timestamps", false, "Shift all timestamps so the capture starts at -epoch, keeping relative timing")
+	epochFlag := fs.String("epoch", "", "RFC 3339 time the capture starts at with -shift-timestamps (default the Unix epoch)")
+	fs.Parse(args) //nolint:errcheck
+
+	format, err := parseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+	if _, err := parseFilterExpr(*filterExpr); err != nil {
+		return err
+	}
+	epoch := time.Unix(0, 0)
+	if *epochFlag != "" {
+		if epoch, err = time.Parse(time.RFC3339Nano, *epochFlag); err != nil {
+			return fmt.Errorf("invalid -epoch: %w", err)
+		}
+	}
+
+	ctx := context.Background()
+	flows, err := src.flows(ctx, *filterExpr)
+	if err != nil {
+		return err
+	}
+	if *anonymize {
+		if flows, err = src.anonymize(ctx, flows); err != nil {
+			return err
+		}
+	}
+	if *shiftTimestamps {
+		flows = shiftFlows(flows, epoch)
+	}
+	data, _, err := encodeFlows(flows, format)
+	if err != nil {
+		return err
+	}
+
+	if *output == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(*output, data, 0644)
+}
+
+// runImport loads flow bundles (as written by export -format proto), share
+// bundles and backups (as written by CreateBackup) into a server or data
+// directory.
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	src := addSourceFlags(fs, defaultServerURL)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: mitmflow import [flags] FILE...\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args) //nolint:errcheck
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("no files to import")
+	}
+
+	ctx := context.Background()
+	var storage *FlowStorage
+	if src.dataDir != "" {
+		var err error
+		if storage, err = src.openStorage(); err != nil {
+			return err
+		}
+		defer storage.Close()
+	}
+
+	for _, name := range fs.Args() {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		isBackup := bytes.HasPrefix(data, []byte{0x1f, 0x8b})
+
+		var count int64
+		switch {
+		case storage != nil && isBackup:
+			err = ReadBackup(bytes.NewReader(data), func(flow *mitmflowv1.Flow) error {
+				if err := storage.SaveFlow(flow); err != nil {
+					return err
+				}
+				count++
+				return nil
+			})
+		case storage != nil:
+			var set *mitmflowv1.FlowSet
+			if set, err = decodeFlowSet(data); err == nil {
+				for _, flow := range set.GetFlows() {
+					if err = storage.SaveFlow(flow); err != nil {
+						break
+					}
+					count++
+				}
+			}
+		case isBackup:
+			var resp *connect.Response[mitmflowv1.RestoreBackupResponse]
+			resp, err = src.client().RestoreBackup(ctx, connect.NewRequest(mitmflowv1.RestoreBackupRequest_builder{
+				Data: data,
+			}.Build()))
+			if err == nil {
+				count = resp.Msg.GetCount()
+			}
+		default:
+			var resp *connect.Response[mitmflowv1.ImportFlowsResponse]
+			resp, err = src.client().ImportFlows(ctx, connect.NewRequest(mitmflowv1.ImportFlowsRequest_builder{
+				Data: data,
+			}.Build()))
+			if err == nil {
+				count = resp.Msg.GetCount()
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to import %s: %w", name, err)
+		}
+		fmt.Fprintf(os.Stderr, "Imported %d flows from %s\n", count, name)
+	}
+	return nil
+}
+
+// runTail prints the most recent flows and then, when reading from a
+// server, follows new flows as they complete.
+func runTail(args []string) error {
+	fs := flag.NewFlagSet("tail", flag.ExitOnError)
+	src := addSourceFlags(fs, defaultServerURL)
+	filterExpr := fs.String("filter", "", "Only show flows matching this filter expression, e.g. '~c 500'")
+	lines := fs.Int("n", 10, "Number of existing flows to show before following")
+	output := fs.String("output", "text", "Output format: text or json (one FlowSummary per line)")
+	colorMode := fs.String("color", "auto", "Colorize text output: auto, always or never")
+	fs.Parse(args) //nolint:errcheck
+
+	pred, err := parseFilterExpr(*filterExpr)
+	if err != nil {
+		return err
+	}
+	p := &tailPrinter{}
+	switch *output {
+	case "text":
+	case "json":
+		p.json = true
+	default:
+		return fmt.Errorf("unknown output %q, expected text or json", *output)
+	}
+	switch *colorMode {
+	case "auto":
+		p.color = colorSupported(os.Stdout)
+	case "always":
+		p.color = true
+	case "never":
+	default:
+		return fmt.Errorf("unknown color mode %q, expected auto, always or never", *colorMode)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush() //nolint:errcheck
+	p.out = out
+
+	if src.dataDir != "" {
+		flows, err := src.flows(ctx, *filterExpr)
+		if err != nil {
+			return err
+		}
+		for _, flow := range flows[max(len(flows)-*lines, 0):] {
+			if err := p.print(convertToSummary(flow)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	client := src.client()
+	// matches fetches the full flow when a filter needs more than the
+	// summary carries.
+	matches := func(id string) (bool, error) {
+		if *filterExpr == "" {
+			return true, nil
+		}
+		resp, err := client.GetFlow(ctx, connect.NewRequest(mitmflowv1.GetFlowRequest_builder{
+			FlowId: proto.String(id),
+		}.Build()))
+		if err != nil {
+			return false, err
+		}
+		return pred(resp.Msg.GetFlow()), nil
+	}
+
+	if *lines > 0 {
+		recent, err := client.GetFlows(ctx, connect.NewRequest(mitmflowv1.GetFlowsRequest_builder{
+			Limit: proto.Int32(math.MaxInt32),
+		}.Build()))
+		if err != nil {
+			return err
+		}
+		var backlog []*mitmflowv1.FlowSummary
+		for recent.Receive() && len(backlog) < *lines {
+			summary := recent.Msg().GetFlow()
+			ok, err := matches(summary.GetId())
+			if err != nil {
+				return err
+			}
+			if ok {
+				backlog = append(backlog, summary)
+			}
+		}
+		recent.Close() //nolint:errcheck
+		for i := len(backlog) - 1; i >= 0; i-- {
+			if err := p.print(backlog[i]); err != nil {
+				return err
+			}
+		}
+		if err := out.Flush(); err != nil {
+			return err
+		}
+	}
+
+	stream, err := client.StreamFlows(ctx, connect.NewRequest(&mitmflowv1.StreamFlowsRequest{}))
+	if err != nil {
+		return err
+	}
+	defer stream.Close() //nolint:errcheck
+
+	// Flows are streamed again on every update; only print each one once it
+	// has completed.
+	printed := make(map[string]bool)
+	for stream.Receive() {
+		if !stream.Msg().HasFlow() {
+			continue // keepalive
+		}
+		summary := stream.Msg().GetFlow()
+		if printed[summary.GetId()] || !flowCompleted(summary) {
+			continue
+		}
+		ok, err := matches(summary.GetId())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if len(printed) > 10000 {
+			clear(printed)
+		}
+		printed[summary.GetId()] = true
+		if err := p.print(summary); err != nil {
+			return err
+		}
+		// Flush per flow so output piped into grep or jq isn't held back.
+		if err := out.Flush(); err != nil {
+			return err
+		}
+	}
+	if err := stream.Err(); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+// flowCompleted reports whether a streamed flow is worth printing: HTTP flows
+// once they have a response, everything else straight away.
+func flowCompleted(summary *mitmflowv1.FlowSummary) bool {
+	if summary.HasHttp() {
+		return summary.GetHttp().GetStatusCode() != 0
+	}
+	return true
+}
+
+// tailPrinter writes flows for the tail subcommand, either as one-line
+// summaries or as JSON lines.
+type tailPrinter struct {
+	out   io.Writer
+	json  bool
+	color bool
+}
+
+func (p *tailPrinter) print(summary *mitmflowv1.FlowSummary) error {
+	if p.json {
+		data, err := protojson.Marshal(summary)
+		if err != nil {
+			return err
+		}
+		// protojson output is not guaranteed to be compact.
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, data); err != nil {
+			return err
+		}
+		buf.WriteByte('\n')
+		_, err = p.out.Write(buf.Bytes())
+		return err
+	}
+	_, err := fmt.Fprintln(p.out, formatFlowLine(summary, p.color))
+	return err
+}
+
+// colorSupported reports whether f is a terminal that should get colored
+// output. NO_COLOR (https://no-color.org) always turns color off.
+func colorSupported(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiDim    = "\x1b[2m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiCyan   = "\x1b[36m"
+)
+
+// statusColor mirrors the status colors used in the flow table.
+func statusColor(code int32) string {
+	switch {
+	case code >= 500:
+		return ansiRed
+	case code >= 400:
+		return ansiYellow
+	case code >= 300:
+		return ansiCyan
+	case code >= 200:
+		return ansiGreen
+	}
+	return ""
+}
+
+// formatFlowLine renders a one-line summary of a flow: method, URL, status,
+// duration and response size for HTTP flows.
+func formatFlowLine(summary *mitmflowv1.FlowSummary, color bool) string {
+	paint := func(code, s string) string {
+		if !color || code == "" || s == "" {
+			return s
+		}
+		return code + s + ansiReset
+	}
+
+	ts := paint(ansiDim, summary.GetTimestampStart().AsTime().Local().Format(time.TimeOnly))
+	switch {
+	case summary.HasHttp():
+		h := summary.GetHttp()
+		status := "---"
+		if h.GetStatusCode() != 0 {
+			status = strconv.Itoa(int(h.GetStatusCode()))
+		}
+		return fmt.Sprintf("%s %s %s %s %s %s", ts,
+			paint(ansiBold, fmt.Sprintf("%-7s", h.GetMethod())),
+			h.GetUrl(),
+			paint(statusColor(h.GetStatusCode()), status),
+			paint(ansiDim, fmt.Sprintf("%dms", h.GetDurationMs())),
+			paint(ansiDim, formatBytes(h.GetResponseContentLength())))
+	case summary.HasDns():
+		d := summary.GetDns()
+		return strings.TrimRight(fmt.Sprintf("%s %s %s %s", ts, paint(ansiBold, "DNS    "), d.GetQuestionName(), paint(ansiRed, d.GetError())), " ")
+	case summary.HasTcp():
+		t := summary.GetTcp()
+		return strings.TrimRight(fmt.Sprintf("%s %s %s:%d %s", ts, paint(ansiBold, "TCP    "), t.GetServerAddressHost(), t.GetServerAddressPort(), paint(ansiRed, t.GetError())), " ")
+	case summary.HasUdp():
+		u := summary.GetUdp()
+		return strings.TrimRight(fmt.Sprintf("%s %s %s:%d %s", ts, paint(ansiBold, "UDP    "), u.GetServerAddressHost(), u.GetServerAddressPort(), paint(ansiRed, u.GetError())), " ")
+	}
+	return ts + " " + summary.GetId()
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func dirExists(dir string) bool {
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
+
+// exitOnError prints err for the named subcommand and exits.
+func exitOnError(name string, err error) {
+	if err == nil {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "mitmflow %s: %v\n", name, err)
+	os.Exit(1)
+}
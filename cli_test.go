@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestTailPrinter(t *testing.T) {
+	summary := mitmflowv1.FlowSummary_builder{
+		Id:             proto.String("flow-1"),
+		TimestampStart: timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		Http: mitmflowv1.HttpFlowSummary_builder{
+			Method:                proto.String("GET"),
+			Url:                   proto.String("https://example.com/api"),
+			StatusCode:            proto.Int32(503),
+			DurationMs:            proto.Int64(42),
+			ResponseContentLength: proto.Int64(2048),
+		}.Build(),
+	}.Build()
+
+	var buf bytes.Buffer
+	p := &tailPrinter{out: &buf}
+	require.NoError(t, p.print(summary))
+	line := buf.String()
+	assert.Contains(t, line, "GET     https://example.com/api 503 42ms 2.0KB\n")
+	assert.NotContains(t, line, "\x1b[")
+
+	buf.Reset()
+	p.color = true
+	require.NoError(t, p.print(summary))
+	assert.Contains(t, buf.String(), ansiRed+"503"+ansiReset)
+
+	buf.Reset()
+	p.json = true
+	require.NoError(t, p.print(summary))
+	require.NoError(t, p.print(summary))
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &decoded))
+	assert.Equal(t, "flow-1", decoded["id"])
+	assert.Equal(t, float64(503), decoded["http"].(map[string]any)["statusCode"])
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// zstdMagic is the frame header every zstd frame starts with. Serialized Flow
+// messages never start with these bytes, which lets uncompressed files written
+// by older versions be read alongside compressed ones.
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// flowCodec compresses flow records before they are written to disk and
+// transparently decompresses them when they are read back.
+type flowCodec struct {
+	compress bool
+	enc      *zstd.Encoder
+	dec      *zstd.Decoder
+}
+
+// newFlowCodec creates a codec. When compress is false records are written
+// as-is but compressed records can still be read. dict is an optional zstd
+// dictionary (as produced by `zstd --train`) used for both directions.
+func newFlowCodec(compress bool, dict []byte) (*flowCodec, error) {
+	encOpts := []zstd.EOption{zstd.WithEncoderLevel(zstd.SpeedDefault)}
+	decOpts := []zstd.DOption{}
+	if len(dict) > 0 {
+		encOpts = append(encOpts, zstd.WithEncoderDict(dict))
+		decOpts = append(decOpts, zstd.WithDecoderDicts(dict))
+	}
+
+	enc, err := zstd.NewWriter(nil, encOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+	}
+	dec, err := zstd.NewReader(nil, decOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+	}
+	return &flowCodec{compress: compress, enc: enc, dec: dec}, nil
+}
+
+func (c *flowCodec) encode(data []byte) []byte {
+	if !c.compress {
+		return data
+	}
+	return c.enc.EncodeAll(data, make([]byte, 0, len(data)/2))
+}
+
+func (c *flowCodec) decode(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, zstdMagic) {
+		return data, nil
+	}
+	return c.dec.DecodeAll(data, nil)
+}
+
+func (c *flowCodec) Close() {
+	c.enc.Close() //nolint:errcheck
+	c.dec.Close()
+}
+
+// maxDecodedBodySize bounds how far a Content-Encoding is expanded, so a
+// compression bomb can't exhaust memory during export.
+const maxDecodedBodySize = 64 << 20
+
+// decodeContentEncoding undoes an HTTP Content-Encoding, applying the listed
+// codings in reverse. It returns false when there is nothing to decode, an
+// encoding is unsupported, or the body doesn't decode, which is also the case
+// when mitmproxy already sent the decoded body.
+func decodeContentEncoding(content []byte, encoding string) ([]byte, bool) {
+	codings := strings.Split(strings.ToLower(encoding), ",")
+	decoded := false
+	for i := len(codings) - 1; i >= 0; i-- {
+		var r io.Reader
+		switch coding := strings.TrimSpace(codings[i]); coding {
+		case "", "identity":
+			continue
+		case "gzip", "x-gzip":
+			gr, err := gzip.NewReader(bytes.NewReader(content))
+			if err != nil {
+				return nil, false
+			}
+			r = gr
+		case "deflate":
+			// Servers disagree on whether deflate means zlib or raw deflate.
+			if zr, err := zlib.NewReader(bytes.NewReader(content)); err == nil {
+				r = zr
+			} else {
+				r = flate.NewReader(bytes.NewReader(content))
+			}
+		case "br":
+			r = brotli.NewReader(bytes.NewReader(content))
+		case "zstd":
+			zr, err := zstd.NewReader(bytes.NewReader(content))
+			if err != nil {
+				return nil, false
+			}
+			defer zr.Close()
+			r = zr
+		default:
+			return nil, false
+		}
+		out, err := io.ReadAll(io.LimitReader(r, maxDecodedBodySize+1))
+		if err != nil || len(out) > maxDecodedBodySize {
+			return nil, false
+		}
+		content = out
+		decoded = true
+	}
+	return content, decoded
+}
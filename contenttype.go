@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+// contentTypeSource says which content type of a message wins: the declared
+// Content-Type header or the one sniffed from the body.
+type contentTypeSource int
+
+const (
+	preferSniffed contentTypeSource = iota
+	preferDeclared
+)
+
+func parseContentTypeSource(s string) (contentTypeSource, error) {
+	switch s {
+	case "sniff":
+		return preferSniffed, nil
+	case "header":
+		return preferDeclared, nil
+	}
+	return 0, fmt.Errorf("unknown content type priority %q, want sniff or header", s)
+}
+
+// parseContentTypeRule parses a -content-type-rule flag: a media type, or a
+// type/* wildcard, and the source that wins for it, e.g.
+// application/vnd.api+json=header.
+func parseContentTypeRule(s string) (string, contentTypeSource, error) {
+	mediaType, priority, ok := strings.Cut(s, "=")
+	if !ok || mediaType == "" {
+		return "", 0, fmt.Errorf("invalid content type rule %q, want TYPE=sniff or TYPE=header", s)
+	}
+	source, err := parseContentTypeSource(priority)
+	if err != nil {
+		return "", 0, err
+	}
+	return strings.ToLower(strings.TrimSpace(mediaType)), source, nil
+}
+
+// defaultContentTypeRules trust the header for framed binary formats, whose
+// payloads mimetype would otherwise mistake for the type of what's inside
+// them, like JSON in a gRPC frame.
+var defaultContentTypeRules = map[string]contentTypeSource{
+	"application/grpc":                preferDeclared,
+	"application/grpc+proto":          preferDeclared,
+	"application/grpc+json":           preferDeclared,
+	"application/grpc-web":            preferDeclared,
+	"application/grpc-web+proto":      preferDeclared,
+	"application/grpc-web+json":       preferDeclared,
+	"application/grpc-web-text":       preferDeclared,
+	"application/connect+proto":       preferDeclared,
+	"application/connect+json":        preferDeclared,
+	"application/proto":               preferDeclared,
+	"application/protobuf":            preferDeclared,
+	"application/x-protobuf":          preferDeclared,
+	"application/dns-message":         preferDeclared,
+	"application/vnd.google.protobuf": preferDeclared,
+}
+
+// contentTypePolicy decides the effective content type of messages.
+type contentTypePolicy struct {
+	// fallback applies to declared types no rule covers.
+	fallback contentTypeSource
+	// rules are keyed by declared media type, or by type/* for all of a
+	// top-level type. An exact match wins over a wildcard.
+	rules map[string]contentTypeSource
+}
+
+func newContentTypePolicy() *contentTypePolicy {
+	rules := make(map[string]contentTypeSource, len(defaultContentTypeRules))
+	for k, v := range defaultContentTypeRules {
+		rules[k] = v
+	}
+	return &contentTypePolicy{fallback: preferSniffed, rules: rules}
+}
+
+// source returns which content type wins for a declared Content-Type.
+func (p *contentTypePolicy) source(declared string) contentTypeSource {
+	mediaType, _, err := mime.ParseMediaType(declared)
+	if err != nil {
+		mediaType, _, _ = strings.Cut(declared, ";")
+		mediaType = strings.TrimSpace(mediaType)
+	}
+	if source, ok := p.rules[mediaType]; ok {
+		return source
+	}
+	if kind, _, ok := strings.Cut(mediaType, "/"); ok {
+		if source, ok := p.rules[kind+"/*"]; ok {
+			return source
+		}
+	}
+	return p.fallback
+}
+
+// resolve records the declared and detected content types of a message and
+// picks the effective one. The sniffed type wins when nothing was declared or
+// the rules prefer sniffing, unless all it says is text/plain or
+// application/octet-stream.
+func (p *contentTypePolicy) resolve(headers map[string]string, content []byte, details *mitmflowv1.MessageDetails) {
+	declared, hasDeclared := getContentType(headers)
+	details.SetDeclaredContentType(declared)
+	effective := declared
+
+	if ct := mimetype.Detect(content); ct != nil {
+		detected := ct.String()
+		details.SetDetectedContentType(detected)
+		generic := ct.Is("text/plain") || ct.Is("application/octet-stream")
+		if !generic && (!hasDeclared || p.source(declared) == preferSniffed) {
+			effective = detected
+		}
+	}
+	if effective != "" {
+		details.SetEffectiveContentType(effective)
+	}
+}
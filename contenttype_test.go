@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+func TestContentTypePolicy(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00")
+	resolve := func(p *contentTypePolicy, declared string, body []byte) *mitmflowv1.MessageDetails {
+		headers := map[string]string{}
+		if declared != "" {
+			headers["Content-Type"] = declared
+		}
+		details := &mitmflowv1.MessageDetails{}
+		p.resolve(headers, body, details)
+		return details
+	}
+
+	p := newContentTypePolicy()
+	details := resolve(p, "application/connect+json", []byte(`{"id":1}`))
+	assert.Equal(t, "application/connect+json", details.GetDeclaredContentType())
+	assert.Equal(t, "application/json", details.GetDetectedContentType())
+	assert.Equal(t, "application/connect+json", details.GetEffectiveContentType())
+
+	assert.Equal(t, "image/png", resolve(p, "application/octet-stream", png).GetEffectiveContentType())
+	assert.Equal(t, "application/json", resolve(p, "", []byte(`{"id":1}`)).GetEffectiveContentType())
+	assert.Equal(t, "application/x-www-form-urlencoded", resolve(p, "application/x-www-form-urlencoded", []byte("a=1&b=2")).GetEffectiveContentType())
+
+	details = resolve(p, "", []byte("hello"))
+	assert.False(t, details.HasEffectiveContentType())
+	assert.Equal(t, "text/plain; charset=utf-8", details.GetDetectedContentType())
+
+	p.rules["image/*"] = preferDeclared
+	assert.Equal(t, "image/jpeg", resolve(p, "image/jpeg", png).GetEffectiveContentType())
+	assert.Equal(t, "image/png", resolve(p, "application/octet-stream", png).GetEffectiveContentType())
+
+	p.fallback = preferDeclared
+	assert.Equal(t, "application/octet-stream", resolve(p, "application/octet-stream", png).GetEffectiveContentType())
+	assert.Equal(t, "image/png", resolve(p, "", png).GetEffectiveContentType())
+}
+
+func TestParseContentTypeRule(t *testing.T) {
+	mediaType, source, err := parseContentTypeRule("Image/*=header")
+	require.NoError(t, err)
+	assert.Equal(t, "image/*", mediaType)
+	assert.Equal(t, preferDeclared, source)
+
+	_, _, err = parseContentTypeRule("image/*")
+	assert.Error(t, err)
+	_, _, err = parseContentTypeRule("image/*=body")
+	assert.Error(t, err)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetCookieTimeline lists every stored flow that set, sent or deleted a
+// cookie, so a session can be followed without reading headers flow by flow.
+func (s *MITMFlowServer) GetCookieTimeline(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.GetCookieTimelineRequest],
+) (*connect.Response[mitmflowv1.GetCookieTimelineResponse], error) {
+	visible := visibleTo(ctx)
+	var flows []*mitmflowv1.Flow
+	s.storage.Walk(func(flow *mitmflowv1.Flow) bool {
+		if visible(flow) {
+			flows = append(flows, flow)
+		}
+		return true
+	})
+	events := cookieTimeline(flows, req.Msg.GetName(), strings.ToLower(strings.TrimPrefix(req.Msg.GetDomain(), ".")))
+	return connect.NewResponse(mitmflowv1.GetCookieTimelineResponse_builder{Events: events}.Build()), nil
+}
+
+func cookieTimeline(flows []*mitmflowv1.Flow, name, domain string) []*mitmflowv1.CookieEvent {
+	var events []*mitmflowv1.CookieEvent
+	for _, flow := range flows {
+		h := flow.GetHttpFlow()
+		if h == nil {
+			continue
+		}
+		u, err := url.Parse(h.GetRequest().GetUrl())
+		if err != nil {
+			continue
+		}
+		host := strings.ToLower(u.Hostname())
+		flowTime := time.Unix(0, GetFlowStartTime(flow))
+
+		if domainMatches(host, domain) {
+			if cookies, err := http.ParseCookie(getHeaderValue(h.GetRequest().GetHeaders(), "Cookie")); err == nil {
+				for _, c := range cookies {
+					if c.Name != name {
+						continue
+					}
+					events = append(events, mitmflowv1.CookieEvent_builder{
+						Type:      mitmflowv1.CookieEventType_COOKIE_EVENT_TYPE_SENT.Enum(),
+						FlowId:    proto.String(GetFlowID(flow)),
+						Timestamp: timestamppb.New(messageTime(h.GetRequest().GetTimestampStart(), flowTime)),
+						Host:      proto.String(host),
+						Value:     proto.String(c.Value),
+					}.Build())
+				}
+			}
+		}
+
+		if !h.HasResponse() {
+			continue
+		}
+		setAt := messageTime(h.GetResponse().GetTimestampStart(), flowTime)
+		for _, line := range splitSetCookie(getHeaderValue(h.GetResponse().GetHeaders(), "Set-Cookie")) {
+			c, err := http.ParseSetCookie(line)
+			if err != nil || c.Name != name {
+				continue
+			}
+			cookieDomain := strings.ToLower(strings.TrimPrefix(c.Domain, "."))
+			if !domainMatches(host, domain) && (cookieDomain == "" || !domainMatches(cookieDomain, domain)) {
+				continue
+			}
+			events = append(events, setCookieEvent(flow, host, setAt, c))
+		}
+	}
+
+	slices.SortStableFunc(events, func(a, b *mitmflowv1.CookieEvent) int {
+		return a.GetTimestamp().AsTime().Compare(b.GetTimestamp().AsTime())
+	})
+	var prev, prevSet *mitmflowv1.CookieEvent
+	for _, e := range events {
+		e.SetValueChanged(prev != nil && e.GetValue() != prev.GetValue())
+		if e.GetType() == mitmflowv1.CookieEventType_COOKIE_EVENT_TYPE_SET {
+			e.SetExpiryChanged(prevSet != nil && !proto.Equal(e.GetExpires(), prevSet.GetExpires()))
+			prevSet = e
+		}
+		prev = e
+	}
+	return events
+}
+
+// setCookieEvent describes a Set-Cookie header. Cookies that already expired,
+// the usual way to delete one, are DELETED events.
+func setCookieEvent(flow *mitmflowv1.Flow, host string, at time.Time, c *http.Cookie) *mitmflowv1.CookieEvent {
+	event := mitmflowv1.CookieEvent_builder{
+		Type:      mitmflowv1.CookieEventType_COOKIE_EVENT_TYPE_SET.Enum(),
+		FlowId:    proto.String(GetFlowID(flow)),
+		Timestamp: timestamppb.New(at),
+		Host:      proto.String(host),
+		Value:     proto.String(c.Value),
+		Domain:    proto.String(c.Domain),
+		Path:      proto.String(c.Path),
+		Secure:    proto.Bool(c.Secure),
+		HttpOnly:  proto.Bool(c.HttpOnly),
+		SameSite:  proto.String(sameSiteName(c.SameSite)),
+	}.Build()
+
+	var expires time.Time
+	switch {
+	case c.MaxAge < 0:
+		expires = at
+	case c.MaxAge > 0:
+		expires = at.Add(time.Duration(c.MaxAge) * time.Second)
+	case !c.Expires.IsZero():
+		expires = c.Expires
+	}
+	if !expires.IsZero() {
+		event.SetExpires(timestamppb.New(expires))
+		if !expires.After(at) {
+			event.SetType(mitmflowv1.CookieEventType_COOKIE_EVENT_TYPE_DELETED)
+		}
+	}
+	return event
+}
+
+func sameSiteName(mode http.SameSite) string {
+	switch mode {
+	case http.SameSiteLaxMode:
+		return "Lax"
+	case http.SameSiteStrictMode:
+		return "Strict"
+	case http.SameSiteNoneMode:
+		return "None"
+	}
+	return ""
+}
+
+// domainMatches reports whether host is domain or one of its subdomains. An
+// empty domain matches every host.
+func domainMatches(host, domain string) bool {
+	return domain == "" || host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// messageTime is when a request or response started, or fallback when
+// mitmproxy didn't record it.
+func messageTime(ts *timestamppb.Timestamp, fallback time.Time) time.Time {
+	if ts == nil {
+		return fallback
+	}
+	return ts.AsTime()
+}
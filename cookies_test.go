@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetCookieTimeline(t *testing.T) {
+	dir, err := os.MkdirTemp("", "mitmflow_cookies")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(dir)) })
+	storage, err := NewFlowStorage(dir, 100)
+	require.NoError(t, err)
+	defer storage.Close()
+	server, err := NewMITMFlowServer(storage, nil)
+	require.NoError(t, err)
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, flow := range []*mitmflowv1.Flow{
+		createHTTPFlow("login", "https://auth.example.com/login", start, withResponseHeaders(map[string]string{
+			"set-cookie": "sid=one; Domain=example.com; Path=/; Max-Age=3600; Secure; HttpOnly; SameSite=Lax",
+		})),
+		createHTTPFlow("api", "https://api.example.com/me", start.Add(time.Second),
+			withRequestHeaders(map[string]string{"cookie": "theme=dark; sid=one"})),
+		createHTTPFlow("refresh", "https://auth.example.com/refresh", start.Add(time.Minute),
+			withRequestHeaders(map[string]string{"cookie": "sid=one"}), withResponseHeaders(map[string]string{
+				"set-cookie": "sid=two; Domain=example.com; Path=/; Max-Age=7200; Secure; HttpOnly; SameSite=Lax",
+			})),
+		createHTTPFlow("other", "https://other.test/", start.Add(2*time.Minute),
+			withRequestHeaders(map[string]string{"cookie": "sid=elsewhere"})),
+		createHTTPFlow("logout", "https://auth.example.com/logout", start.Add(time.Hour),
+			withRequestHeaders(map[string]string{"cookie": "sid=two"}), withResponseHeaders(map[string]string{
+				"set-cookie": "sid=; Domain=example.com; Path=/; Expires=Thu, 01 Jan 1970 00:00:00 GMT",
+			})),
+	} {
+		// Cookies are set when the response arrives, after the request.
+		httpFlow := flow.GetHttpFlow()
+		httpFlow.GetResponse().SetTimestampStart(timestamppb.New(httpFlow.GetTimestampStart().AsTime().Add(100 * time.Millisecond)))
+		require.NoError(t, storage.SaveFlow(flow))
+	}
+
+	resp, err := server.GetCookieTimeline(context.Background(), connect.NewRequest(mitmflowv1.GetCookieTimelineRequest_builder{
+		Name:   proto.String("sid"),
+		Domain: proto.String("example.com"),
+	}.Build()))
+	require.NoError(t, err)
+
+	type event struct {
+		flowID       string
+		kind         mitmflowv1.CookieEventType
+		value        string
+		valueChanged bool
+	}
+	var got []event
+	for _, e := range resp.Msg.GetEvents() {
+		got = append(got, event{e.GetFlowId(), e.GetType(), e.GetValue(), e.GetValueChanged()})
+	}
+	assert.Equal(t, []event{
+		{"login", mitmflowv1.CookieEventType_COOKIE_EVENT_TYPE_SET, "one", false},
+		{"api", mitmflowv1.CookieEventType_COOKIE_EVENT_TYPE_SENT, "one", false},
+		{"refresh", mitmflowv1.CookieEventType_COOKIE_EVENT_TYPE_SENT, "one", false},
+		{"refresh", mitmflowv1.CookieEventType_COOKIE_EVENT_TYPE_SET, "two", true},
+		{"logout", mitmflowv1.CookieEventType_COOKIE_EVENT_TYPE_SENT, "two", false},
+		{"logout", mitmflowv1.CookieEventType_COOKIE_EVENT_TYPE_DELETED, "", true},
+	}, got)
+
+	events := resp.Msg.GetEvents()
+	login, refresh := events[0], events[3]
+	assert.Equal(t, "auth.example.com", login.GetHost())
+	assert.Equal(t, "example.com", login.GetDomain())
+	assert.True(t, login.GetSecure())
+	assert.True(t, login.GetHttpOnly())
+	assert.Equal(t, "Lax", login.GetSameSite())
+	assert.Equal(t, start.Add(100*time.Millisecond+time.Hour), login.GetExpires().AsTime())
+	assert.False(t, login.GetExpiryChanged())
+	assert.True(t, refresh.GetExpiryChanged())
+
+	// Without a domain, cookies of the same name on other sites are included.
+	resp, err = server.GetCookieTimeline(context.Background(), connect.NewRequest(mitmflowv1.GetCookieTimelineRequest_builder{
+		Name: proto.String("sid"),
+	}.Build()))
+	require.NoError(t, err)
+	assert.Len(t, resp.Msg.GetEvents(), 7)
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// corsDefaultMaxAge is how long after a preflight an actual request is
+	// paired with it when the preflight didn't allow caching for longer.
+	corsDefaultMaxAge = 5 * time.Second
+	// corsMaxMaxAge caps Access-Control-Max-Age the way Chromium does.
+	corsMaxMaxAge = 2 * time.Hour
+)
+
+type corsKey struct {
+	origin, method, url string
+}
+
+type corsPreflight struct {
+	flowID string
+	at     time.Time
+	maxAge time.Duration
+}
+
+// corsTracker pairs CORS preflights with the actual requests that follow
+// them.
+type corsTracker struct {
+	mu         sync.Mutex
+	preflights map[corsKey]corsPreflight
+}
+
+func newCORSTracker() *corsTracker {
+	return &corsTracker{preflights: make(map[corsKey]corsPreflight)}
+}
+
+// check returns the CORS check of an HTTP flow, or nil when it isn't a
+// cross-origin request. Preflights are remembered once they have a response,
+// so the actual request that follows can be linked to them.
+func (c *corsTracker) check(flow *mitmflowv1.Flow) *mitmflowv1.CorsCheck {
+	h := flow.GetHttpFlow()
+	req := h.GetRequest()
+	origin := getHeaderValue(req.GetHeaders(), "Origin")
+	if origin == "" || isSameOrigin(origin, req.GetUrl()) {
+		return nil
+	}
+	result := mitmflowv1.CorsCheck_builder{Origin: proto.String(origin)}.Build()
+	at := flowStartTime(flow, time.Now())
+
+	requestedMethod := getHeaderValue(req.GetHeaders(), "Access-Control-Request-Method")
+	if strings.EqualFold(req.GetMethod(), "OPTIONS") && requestedMethod != "" {
+		result.SetPreflight(true)
+		if !h.HasResponse() {
+			return result
+		}
+		result.SetProblems(checkCORSPreflight(flow))
+		c.recordPreflight(corsKey{origin, strings.ToUpper(requestedMethod), req.GetUrl()}, corsPreflight{
+			flowID: GetFlowID(flow),
+			at:     at,
+			maxAge: corsPreflightMaxAge(getHeaderValue(h.GetResponse().GetHeaders(), "Access-Control-Max-Age")),
+		})
+		return result
+	}
+
+	if p, ok := c.preflight(corsKey{origin, strings.ToUpper(req.GetMethod()), req.GetUrl()}, at); ok {
+		result.SetPreflightFlowId(p.flowID)
+	}
+	if h.HasResponse() {
+		credentials := getHeaderValue(req.GetHeaders(), "Cookie") != ""
+		result.SetProblems(checkCORSAllowOrigin(h.GetResponse().GetHeaders(), origin, credentials))
+	}
+	return result
+}
+
+func (c *corsTracker) recordPreflight(key corsKey, p corsPreflight) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, old := range c.preflights {
+		if p.at.Sub(old.at) > corsMaxMaxAge {
+			delete(c.preflights, k)
+		}
+	}
+	c.preflights[key] = p
+}
+
+// preflight returns the preflight whose result a request made at the given
+// time would have used.
+func (c *corsTracker) preflight(key corsKey, at time.Time) (corsPreflight, bool) {
+	if c == nil {
+		return corsPreflight{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.preflights[key]
+	if !ok || at.Before(p.at) || at.Sub(p.at) > max(p.maxAge, corsDefaultMaxAge) {
+		return corsPreflight{}, false
+	}
+	return p, true
+}
+
+func corsPreflightMaxAge(header string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(header))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return min(time.Duration(seconds)*time.Second, corsMaxMaxAge)
+}
+
+// checkCORSPreflight checks a preflight response against the method and
+// headers its request asked for.
+func checkCORSPreflight(flow *mitmflowv1.Flow) []string {
+	req, resp := flow.GetHttpFlow().GetRequest(), flow.GetHttpFlow().GetResponse()
+	headers := resp.GetHeaders()
+	var problems []string
+	if code := resp.GetStatusCode(); code < 200 || code > 299 {
+		problems = append(problems, fmt.Sprintf("preflight returned status %d", code))
+	}
+	// Browsers never send cookies with a preflight, so whether the actual
+	// request has credentials is only known from what the server allows.
+	credentials := strings.TrimSpace(getHeaderValue(headers, "Access-Control-Allow-Credentials")) == "true"
+	problems = append(problems, checkCORSAllowOrigin(headers, getHeaderValue(req.GetHeaders(), "Origin"), credentials)...)
+
+	method := strings.ToUpper(strings.TrimSpace(getHeaderValue(req.GetHeaders(), "Access-Control-Request-Method")))
+	allowedMethods := corsList(getHeaderValue(headers, "Access-Control-Allow-Methods"))
+	if method != "GET" && method != "HEAD" && method != "POST" &&
+		!allowedMethods[method] && !(allowedMethods["*"] && !credentials) {
+		problems = append(problems, fmt.Sprintf("method %s isn't in Access-Control-Allow-Methods", method))
+	}
+
+	allowedHeaders := corsList(getHeaderValue(headers, "Access-Control-Allow-Headers"))
+	var missing []string
+	for _, name := range strings.Split(getHeaderValue(req.GetHeaders(), "Access-Control-Request-Headers"), ",") {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if name == "" || allowedHeaders[name] {
+			continue
+		}
+		// A wildcard doesn't cover Authorization.
+		if allowedHeaders["*"] && !credentials && name != "AUTHORIZATION" {
+			continue
+		}
+		missing = append(missing, strings.ToLower(name))
+	}
+	if len(missing) > 0 {
+		problems = append(problems, fmt.Sprintf("headers %s aren't in Access-Control-Allow-Headers", strings.Join(missing, ", ")))
+	}
+	return problems
+}
+
+// checkCORSAllowOrigin checks that a response lets origin read it.
+func checkCORSAllowOrigin(headers map[string]string, origin string, credentials bool) []string {
+	var problems []string
+	switch allowOrigin := strings.TrimSpace(getHeaderValue(headers, "Access-Control-Allow-Origin")); {
+	case allowOrigin == "":
+		problems = append(problems, "Access-Control-Allow-Origin is missing")
+	case allowOrigin == "*":
+		if credentials {
+			problems = append(problems, "Access-Control-Allow-Origin can't be * for requests with credentials")
+		}
+	case allowOrigin != origin:
+		problems = append(problems, fmt.Sprintf("Access-Control-Allow-Origin %s doesn't match origin %s", allowOrigin, origin))
+	}
+	if credentials && strings.TrimSpace(getHeaderValue(headers, "Access-Control-Allow-Credentials")) != "true" {
+		problems = append(problems, "the request has credentials but Access-Control-Allow-Credentials isn't true")
+	}
+	return problems
+}
+
+// corsList parses a comma separated Access-Control-Allow-* header into an
+// uppercase set.
+func corsList(header string) map[string]bool {
+	set := map[string]bool{}
+	for _, v := range strings.Split(header, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			set[strings.ToUpper(v)] = true
+		}
+	}
+	return set
+}
+
+// isSameOrigin reports whether rawURL belongs to origin, e.g.
+// "https://example.com" and "https://example.com:443/path".
+func isSameOrigin(origin, rawURL string) bool {
+	o, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(o.Scheme, u.Scheme) && strings.EqualFold(o.Hostname(), u.Hostname()) &&
+		originPort(o) == originPort(u)
+}
+
+func originPort(u *url.URL) string {
+	if port := u.Port(); port != "" {
+		return port
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "https", "wss":
+		return "443"
+	case "http", "ws":
+		return "80"
+	}
+	return ""
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var corsTestStart = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestCORS_PreflightAndActualRequest(t *testing.T) {
+	server, err := NewMITMFlowServer(nil, nil)
+	require.NoError(t, err)
+	const apiURL = "https://api.example.com/v1/items"
+
+	preflight := createHTTPFlow("preflight", apiURL, corsTestStart, withMethod("OPTIONS"), withStatus(204), withRequestHeaders(map[string]string{
+		"origin":                         "https://app.example.com",
+		"access-control-request-method":  "PUT",
+		"access-control-request-headers": "content-type, x-request-id",
+	}), withResponseHeaders(map[string]string{
+		"access-control-allow-origin":  "https://app.example.com",
+		"access-control-allow-methods": "GET, PUT",
+		"access-control-allow-headers": "Content-Type",
+		"access-control-max-age":       "600",
+	}))
+	server.preprocessFlow(preflight)
+	check := preflight.GetHttpFlowExtra().GetCors()
+	assert.True(t, check.GetPreflight())
+	assert.Equal(t, "https://app.example.com", check.GetOrigin())
+	assert.Equal(t, []string{"headers x-request-id aren't in Access-Control-Allow-Headers"}, check.GetProblems())
+
+	actual := createHTTPFlow("actual", apiURL, corsTestStart.Add(time.Minute), withMethod("PUT"), withRequestHeaders(map[string]string{
+		"origin": "https://app.example.com",
+		"cookie": "sid=abc",
+	}), withResponseHeaders(map[string]string{
+		"access-control-allow-origin": "https://app.example.com",
+	}))
+	server.preprocessFlow(actual)
+	check = actual.GetHttpFlowExtra().GetCors()
+	assert.False(t, check.GetPreflight())
+	assert.Equal(t, "preflight", check.GetPreflightFlowId())
+	assert.Equal(t, []string{"the request has credentials but Access-Control-Allow-Credentials isn't true"}, check.GetProblems())
+
+	// After Access-Control-Max-Age the browser would have sent a new preflight.
+	later := createHTTPFlow("later", apiURL, corsTestStart.Add(time.Hour), withMethod("PUT"), withRequestHeaders(map[string]string{
+		"origin": "https://app.example.com",
+	}), withResponseHeaders(map[string]string{
+		"access-control-allow-origin": "*",
+	}))
+	server.preprocessFlow(later)
+	assert.Empty(t, later.GetHttpFlowExtra().GetCors().GetPreflightFlowId())
+	assert.Empty(t, later.GetHttpFlowExtra().GetCors().GetProblems())
+
+	pred, err := parseFilterExpr("~cors")
+	require.NoError(t, err)
+	assert.True(t, pred(preflight))
+	assert.True(t, pred(actual))
+	assert.False(t, pred(later))
+}
+
+func TestCORS_Problems(t *testing.T) {
+	s := &MITMFlowServer{}
+	for _, tc := range []struct {
+		name        string
+		method      string
+		reqHeaders  map[string]string
+		respHeaders map[string]string
+		want        []string
+	}{
+		{
+			name:       "missing allow origin",
+			method:     "GET",
+			reqHeaders: map[string]string{"origin": "https://app.example.com"},
+			want:       []string{"Access-Control-Allow-Origin is missing"},
+		},
+		{
+			name:        "wrong origin",
+			method:      "GET",
+			reqHeaders:  map[string]string{"origin": "https://app.example.com"},
+			respHeaders: map[string]string{"access-control-allow-origin": "https://other.example.com"},
+			want:        []string{"Access-Control-Allow-Origin https://other.example.com doesn't match origin https://app.example.com"},
+		},
+		{
+			name:       "wildcard with credentials",
+			method:     "GET",
+			reqHeaders: map[string]string{"origin": "https://app.example.com", "cookie": "sid=abc"},
+			respHeaders: map[string]string{
+				"access-control-allow-origin":      "*",
+				"access-control-allow-credentials": "true",
+			},
+			want: []string{"Access-Control-Allow-Origin can't be * for requests with credentials"},
+		},
+		{
+			name:   "preflight method and headers",
+			method: "OPTIONS",
+			reqHeaders: map[string]string{
+				"origin":                         "https://app.example.com",
+				"access-control-request-method":  "DELETE",
+				"access-control-request-headers": "authorization",
+			},
+			respHeaders: map[string]string{
+				"access-control-allow-origin":  "https://app.example.com",
+				"access-control-allow-headers": "*",
+			},
+			want: []string{
+				"method DELETE isn't in Access-Control-Allow-Methods",
+				"headers authorization aren't in Access-Control-Allow-Headers",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			flow := createHTTPFlow(tc.name, "https://api.example.com/", corsTestStart,
+				withMethod(tc.method), withRequestHeaders(tc.reqHeaders), withResponseHeaders(tc.respHeaders))
+			s.preprocessFlow(flow)
+			require.NotNil(t, flow.GetHttpFlowExtra().GetCors())
+			assert.Equal(t, tc.want, flow.GetHttpFlowExtra().GetCors().GetProblems())
+		})
+	}
+
+	t.Run("same origin", func(t *testing.T) {
+		flow := createHTTPFlow("same", "https://app.example.com:443/form", corsTestStart,
+			withMethod("POST"), withRequestHeaders(map[string]string{"origin": "https://app.example.com"}))
+		s.preprocessFlow(flow)
+		assert.Nil(t, flow.GetHttpFlowExtra().GetCors())
+	})
+}
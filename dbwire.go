@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// maxDBRows bounds how many rows of a result set are rendered. The rest
+	// are only counted.
+	maxDBRows = 100
+	// maxDBValueLen bounds how much of a single value is rendered.
+	maxDBValueLen = 200
+)
+
+// sqlLiterals matches the string and number literals of a query, along with
+// the character before a number so $1 placeholders and names like t1 keep
+// their digits.
+var sqlLiterals = regexp.MustCompile(`'(?:[^']|'')*'|(^|[^\w$.])\d+(?:\.\d+)?`)
+
+// redactSQL replaces the literals of a query with ?.
+func redactSQL(query string) string {
+	return sqlLiterals.ReplaceAllStringFunc(query, func(m string) string {
+		if m[0] == '\'' {
+			return "?"
+		}
+		if c := m[0]; c < '0' || c > '9' {
+			return string(c) + "?"
+		}
+		return "?"
+	})
+}
+
+// dbQuery renders the text of a query, redacted when redact is set.
+func dbQuery(query string, redact bool) string {
+	query = strings.TrimSpace(strings.TrimRight(query, "\x00"))
+	if redact {
+		return redactSQL(query)
+	}
+	return query
+}
+
+// dbValue renders a parameter or column value. Values that aren't text are
+// rendered as hex.
+func dbValue(v []byte, null, redact bool) string {
+	switch {
+	case null:
+		return "NULL"
+	case redact:
+		return "?"
+	case isBinary(v):
+		if len(v) > maxDBValueLen/2 {
+			return "0x" + hex.EncodeToString(v[:maxDBValueLen/2]) + "..."
+		}
+		return "0x" + hex.EncodeToString(v)
+	case len(v) > maxDBValueLen:
+		return fmt.Sprintf("%q...", v[:maxDBValueLen])
+	}
+	return fmt.Sprintf("%q", v)
+}
+
+// dbResultSet collects the rows of a result set as they're decoded.
+type dbResultSet struct {
+	columns []string
+	rows    int
+}
+
+// header renders the columns of the result set.
+func (rs *dbResultSet) header() string {
+	return fmt.Sprintf("%s: %s", countOf(len(rs.columns), "column"), strings.Join(rs.columns, ", "))
+}
+
+// row renders a row, or nothing once maxDBRows rows were rendered.
+func (rs *dbResultSet) row(values []string) (string, bool) {
+	rs.rows++
+	if rs.rows > maxDBRows {
+		return "", false
+	}
+	return "row " + fmt.Sprint(rs.rows) + ": " + strings.Join(values, ", "), true
+}
+
+// done renders the number of rows the result set had.
+func (rs *dbResultSet) done() string {
+	return countOf(rs.rows, "row")
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+// decodeLimits bound the work preprocessing does on bodies, so a single
+// pathological body, like a 200MB protobuf response, can't stall ingestion.
+// The zero value sets no limits.
+type decodeLimits struct {
+	// maxProtobufBytes is the size of the largest body decoded as
+	// protobuf, with protoscope or a descriptor, or 0 for no limit.
+	maxProtobufBytes int
+	// skipTypes are the media types, or type/* wildcards, of bodies that
+	// aren't decoded at all.
+	skipTypes []string
+	// maxFramesBytes caps the combined size of the textual frames of a
+	// message, or 0 for no limit.
+	maxFramesBytes int
+}
+
+// skipsDecoding reports whether a message's effective or declared content
+// type is one that isn't decoded.
+func (l decodeLimits) skipsDecoding(details *mitmflowv1.MessageDetails) bool {
+	return slices.ContainsFunc(l.skipTypes, func(pattern string) bool {
+		return matchMediaType(pattern, details.GetEffectiveContentType()) ||
+			matchMediaType(pattern, details.GetDeclaredContentType())
+	})
+}
+
+// protobufTooLarge reports whether a body is too large to decode as
+// protobuf.
+func (l decodeLimits) protobufTooLarge(size int) bool {
+	return l.maxProtobufBytes > 0 && size > l.maxProtobufBytes
+}
+
+// protobufTooLargeFrame stands in for the frames of a protobuf body that
+// was too large to decode.
+func (l decodeLimits) protobufTooLargeFrame(size int) []string {
+	return []string{fmt.Sprintf("Protobuf body not decoded: %d bytes is over the limit of %d bytes", size, l.maxProtobufBytes)}
+}
+
+// isProtobufContentType reports whether a Content-Type carries protobuf
+// messages, framed or not.
+func isProtobufContentType(contentType string) bool {
+	for _, t := range []string{
+		"application/proto",
+		"application/protobuf",
+		"application/x-protobuf",
+		"application/connect+proto",
+		"application/grpc",
+	} {
+		if strings.Contains(contentType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// capFrames cuts the textual frames of a message down to maxFramesBytes in
+// total. The frame crossing the limit is truncated, the ones after it are
+// dropped, and a last frame says how much was left out.
+func (l decodeLimits) capFrames(details *mitmflowv1.MessageDetails) {
+	if l.maxFramesBytes <= 0 {
+		return
+	}
+	frames := details.GetTextualFrames()
+	left := l.maxFramesBytes
+	for i, frame := range frames {
+		if len(frame) <= left {
+			left -= len(frame)
+			continue
+		}
+		cut := left
+		for cut > 0 && !utf8.RuneStart(frame[cut]) {
+			cut--
+		}
+		dropped := len(frame) - cut
+		for _, rest := range frames[i+1:] {
+			dropped += len(rest)
+		}
+		capped := slices.Clone(frames[:i])
+		if cut > 0 {
+			capped = append(capped, frame[:cut])
+		}
+		capped = append(capped, fmt.Sprintf("... %d more bytes of frames over the limit of %d bytes", dropped, l.maxFramesBytes))
+		details.SetTextualFrames(capped)
+		return
+	}
+}
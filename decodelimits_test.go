@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCapFrames(t *testing.T) {
+	limits := decodeLimits{maxFramesBytes: 10}
+	details := mitmflowv1.MessageDetails_builder{TextualFrames: []string{"abcd", "efgh", "ijkl", "mnop"}}.Build()
+	limits.capFrames(details)
+	assert.Equal(t, []string{"abcd", "efgh", "ij", "... 6 more bytes of frames over the limit of 10 bytes"}, details.GetTextualFrames())
+
+	details = mitmflowv1.MessageDetails_builder{TextualFrames: []string{"12345678", "ééé"}}.Build()
+	limits.capFrames(details)
+	assert.Equal(t, []string{"12345678", "é", "... 4 more bytes of frames over the limit of 10 bytes"}, details.GetTextualFrames(), "frames are cut at a rune")
+
+	details = mitmflowv1.MessageDetails_builder{TextualFrames: []string{"abcd", "efgh"}}.Build()
+	limits.capFrames(details)
+	assert.Equal(t, []string{"abcd", "efgh"}, details.GetTextualFrames())
+
+	decodeLimits{}.capFrames(details)
+	assert.Equal(t, []string{"abcd", "efgh"}, details.GetTextualFrames())
+}
+
+func TestDecodeLimits(t *testing.T) {
+	server, err := NewMITMFlowServer(nil, nil, WithDecodeLimits(decodeLimits{
+		maxProtobufBytes: 64,
+		skipTypes:        []string{"application/json", "image/*"},
+	}))
+	require.NoError(t, err)
+
+	var message []byte
+	for i := range 20 {
+		message = protowire.AppendTag(message, protowire.Number(i+1), protowire.VarintType)
+		message = protowire.AppendVarint(message, 300)
+	}
+	require.Greater(t, len(message), 64)
+	flow := func(contentType string, content []byte) *mitmflowv1.Flow {
+		return mitmflowv1.Flow_builder{
+			HttpFlow: mitmproxyv1.HTTPFlow_builder{
+				Id:      proto.String("f1"),
+				Request: mitmproxyv1.Request_builder{Method: proto.String("GET"), Url: proto.String("https://example.com/")}.Build(),
+				Response: mitmproxyv1.Response_builder{
+					StatusCode: proto.Int32(200),
+					Headers:    map[string]string{"Content-Type": contentType},
+					Content:    content,
+				}.Build(),
+			}.Build(),
+		}.Build()
+	}
+
+	f := flow("application/x-protobuf", message)
+	server.preprocessFlow(f)
+	assert.Equal(t, []string{"Protobuf body not decoded: 65 bytes is over the limit of 64 bytes"}, f.GetHttpFlowExtra().GetResponse().GetTextualFrames())
+
+	f = flow("application/x-protobuf", message[:6])
+	server.preprocessFlow(f)
+	assert.NotContains(t, f.GetHttpFlowExtra().GetResponse().GetTextualFrames()[0], "not decoded")
+
+	f = flow("application/json", []byte(`{"a": [1, 2, 3]}`))
+	server.preprocessFlow(f)
+	assert.Empty(t, f.GetHttpFlowExtra().GetResponse().GetTextualFrames())
+	assert.Equal(t, "application/json", f.GetHttpFlowExtra().GetResponse().GetEffectiveContentType())
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+	"time"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+const (
+	// decoderHookTimeout bounds how long a hook may take to decode one
+	// body.
+	decoderHookTimeout = 5 * time.Second
+	// maxDecoderHookOutput caps how much decoded text is read from a hook.
+	maxDecoderHookOutput = 16 << 20
+)
+
+// decoderHook decodes bodies of a content type with an external command,
+// which gets the body on stdin, or an HTTP endpoint, which gets it POSTed.
+// Like plugins, hooks answer with text, split into frames at NUL bytes.
+type decoderHook struct {
+	contentType string
+	command     []string
+	url         string
+	client      *http.Client
+}
+
+// parseDecoderHook parses a -decoder-hook flag: a media type, or a type/*
+// wildcard, and the command or http(s) URL that decodes it, e.g.
+// application/x-acme=acme-decode --text or
+// application/x-acme=http://localhost:9000/decode. Commands are split at
+// spaces and run without a shell.
+func parseDecoderHook(spec string) (decoderHook, error) {
+	contentType, target, ok := strings.Cut(spec, "=")
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	target = strings.TrimSpace(target)
+	if !ok || contentType == "" || target == "" {
+		return decoderHook{}, fmt.Errorf("invalid decoder hook %q, want TYPE=COMMAND or TYPE=URL", spec)
+	}
+	hook := decoderHook{contentType: contentType}
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		if _, err := url.Parse(target); err != nil {
+			return decoderHook{}, fmt.Errorf("invalid decoder hook URL %q: %w", target, err)
+		}
+		hook.url = target
+		hook.client = &http.Client{Timeout: decoderHookTimeout}
+		return hook, nil
+	}
+	hook.command = strings.Fields(target)
+	return hook, nil
+}
+
+func (h decoderHook) String() string {
+	if h.url != "" {
+		return h.url
+	}
+	return strings.Join(h.command, " ")
+}
+
+// decode runs the hook on a body of the given content type.
+func (h decoderHook) decode(ctx context.Context, contentType string, content []byte) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, decoderHookTimeout)
+	defer cancel()
+	if h.url != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", contentType)
+		resp, err := h.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode/100 != 2 {
+			return nil, fmt.Errorf("%s answered %s", h.url, resp.Status)
+		}
+		out, err := io.ReadAll(io.LimitReader(resp.Body, maxDecoderHookOutput))
+		if err != nil {
+			return nil, err
+		}
+		return splitFrames(out), nil
+	}
+
+	cmd := exec.CommandContext(ctx, h.command[0], h.command[1:]...)
+	cmd.Stdin = bytes.NewReader(content)
+	cmd.Env = append(cmd.Environ(), "CONTENT_TYPE="+contentType)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &limitedWriter{w: &stdout, n: maxDecoderHookOutput}
+	cmd.Stderr = &limitedWriter{w: &stderr, n: 4096}
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return splitFrames(stdout.Bytes()), nil
+}
+
+// limitedWriter keeps the first n bytes written to it and drops the rest,
+// so a chatty command can't exhaust memory.
+type limitedWriter struct {
+	w io.Writer
+	n int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.n > 0 {
+		keep := p[:min(len(p), l.n)]
+		if _, err := l.w.Write(keep); err != nil {
+			return 0, err
+		}
+		l.n -= len(keep)
+	}
+	return len(p), nil
+}
+
+// decoderHooks are the hooks given with -decoder-hook, in order.
+type decoderHooks []decoderHook
+
+// setFrames decodes a body with the first hook for its effective, or else
+// its declared, content type.
+func (hooks decoderHooks) setFrames(content []byte, details *mitmflowv1.MessageDetails) {
+	if len(hooks) == 0 || len(content) == 0 {
+		return
+	}
+	for _, contentType := range []string{details.GetEffectiveContentType(), details.GetDeclaredContentType()} {
+		for _, hook := range hooks {
+			if !matchMediaType(hook.contentType, contentType) {
+				continue
+			}
+			frames, err := hook.decode(context.Background(), contentType, content)
+			if err != nil {
+				log.Printf("decoder hook %s failed: %v", hook, err)
+				return
+			}
+			if len(frames) > 0 {
+				details.SetTextualFrames(frames)
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseDecoderHook(t *testing.T) {
+	hook, err := parseDecoderHook("Application/X-Acme=tr a-z A-Z")
+	require.NoError(t, err)
+	assert.Equal(t, "application/x-acme", hook.contentType)
+	assert.Equal(t, []string{"tr", "a-z", "A-Z"}, hook.command)
+
+	hook, err = parseDecoderHook("application/*=http://localhost:9000/decode")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:9000/decode", hook.url)
+
+	for _, spec := range []string{"", "application/x-acme", "=tr", "application/x-acme="} {
+		_, err := parseDecoderHook(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestDecoderHooks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/vnd.widget", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		_, _ = io.WriteString(w, "widget\x00"+string(body))
+	}))
+	defer srv.Close()
+
+	var hooks []decoderHook
+	for _, spec := range []string{"application/x-acme=tr a-z A-Z", "application/vnd.widget=" + srv.URL} {
+		hook, err := parseDecoderHook(spec)
+		require.NoError(t, err)
+		hooks = append(hooks, hook)
+	}
+	server, err := NewMITMFlowServer(nil, nil, WithDecoderHooks(hooks...))
+	require.NoError(t, err)
+
+	flow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Id: proto.String("f1"),
+			Request: mitmproxyv1.Request_builder{
+				Method:  proto.String("POST"),
+				Url:     proto.String("https://example.com/"),
+				Headers: map[string]string{"Content-Type": "application/x-acme"},
+				Content: []byte("hello\x00world"),
+			}.Build(),
+			Response: mitmproxyv1.Response_builder{
+				StatusCode: proto.Int32(200),
+				Headers:    map[string]string{"Content-Type": "application/vnd.widget"},
+				Content:    []byte("\x01\x02gear"),
+			}.Build(),
+		}.Build(),
+	}.Build()
+	server.preprocessFlow(flow)
+	assert.Equal(t, []string{"HELLO", "WORLD"}, flow.GetHttpFlowExtra().GetRequest().GetTextualFrames())
+	assert.Equal(t, []string{"widget", "\x01\x02gear"}, flow.GetHttpFlowExtra().GetResponse().GetTextualFrames())
+}
+
+func TestDecoderHookErrors(t *testing.T) {
+	hook := decoderHook{contentType: "application/x-acme", command: []string{"sh", "-c", "echo broken >&2; exit 3"}}
+	_, err := hook.decode(context.Background(), "application/x-acme", []byte("x"))
+	assert.ErrorContains(t, err, "broken")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	hook, err = parseDecoderHook("application/x-acme=" + srv.URL)
+	require.NoError(t, err)
+	_, err = hook.decode(context.Background(), "application/x-acme", []byte("x"))
+	assert.ErrorContains(t, err, "500")
+
+	// A failing hook leaves the frames of the built-in decoders alone.
+	details := mitmflowv1.MessageDetails_builder{
+		DeclaredContentType: proto.String("application/x-acme"),
+		TextualFrames:       []string{"hexdump"},
+	}.Build()
+	decoderHooks{hook}.setFrames([]byte("x"), details)
+	assert.Equal(t, []string{"hexdump"}, details.GetTextualFrames())
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"mime"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/connect"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxBodyDifferences caps how many JSON fields are reported for each body,
+// so that comparing two large, unrelated documents stays readable.
+const maxBodyDifferences = 1000
+
+// DiffWithPrevious compares an HTTP flow with the latest visible flow that
+// started before it with the same method and path.
+func (s *MITMFlowServer) DiffWithPrevious(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.DiffWithPreviousRequest],
+) (*connect.Response[mitmflowv1.DiffWithPreviousResponse], error) {
+	id := req.Msg.GetFlowId()
+	flow, ok := s.visibleFlow(ctx, id)
+	if !ok || flow.GetHttpFlow() == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("http flow not found: %s", id))
+	}
+	key, ok := requestKey(flow)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("flow %s has no request URL", id))
+	}
+
+	start := GetFlowStartTime(flow)
+	visible := visibleTo(ctx)
+	var prev *mitmflowv1.Flow
+	s.storage.ReverseWalk(func(f *mitmflowv1.Flow) bool {
+		if GetFlowStartTime(f) >= start || !visible(f) {
+			return true
+		}
+		if k, ok := requestKey(f); ok && k == key {
+			prev = f
+			return false
+		}
+		return true
+	})
+	if prev == nil {
+		return connect.NewResponse(&mitmflowv1.DiffWithPreviousResponse{}), nil
+	}
+
+	flow, err := s.storage.HydrateFlow(ctx, flow)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	prev, err = s.storage.HydrateFlow(ctx, prev)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	before, after := prev.GetHttpFlow(), flow.GetHttpFlow()
+
+	var request []*mitmflowv1.FlowDifference
+	request = append(request, queryDifferences(before.GetRequest().GetUrl(), after.GetRequest().GetUrl())...)
+	request = append(request, headerDifferences(before.GetRequest().GetHeaders(), after.GetRequest().GetHeaders())...)
+	request = append(request, bodyDifferences(before.GetRequest(), after.GetRequest())...)
+
+	var response []*mitmflowv1.FlowDifference
+	if before.HasResponse() || after.HasResponse() {
+		var prevStatus, status string
+		if before.HasResponse() {
+			prevStatus = strconv.Itoa(int(before.GetResponse().GetStatusCode()))
+		}
+		if after.HasResponse() {
+			status = strconv.Itoa(int(after.GetResponse().GetStatusCode()))
+		}
+		if prevStatus != status {
+			response = append(response, flowDifference(mitmflowv1.FlowDifferenceKind_FLOW_DIFFERENCE_KIND_STATUS, "", prevStatus, status))
+		}
+	}
+	response = append(response, headerDifferences(before.GetResponse().GetHeaders(), after.GetResponse().GetHeaders())...)
+	response = append(response, bodyDifferences(before.GetResponse(), after.GetResponse())...)
+
+	return connect.NewResponse(mitmflowv1.DiffWithPreviousResponse_builder{
+		Previous: s.summarize(viewAs(prev, annotationUser(ctx))),
+		Request:  request,
+		Response: response,
+	}.Build()), nil
+}
+
+func flowDifference(kind mitmflowv1.FlowDifferenceKind, field, previous, current string) *mitmflowv1.FlowDifference {
+	return mitmflowv1.FlowDifference_builder{
+		Kind:     kind.Enum(),
+		Field:    proto.String(field),
+		Previous: proto.String(previous),
+		Current:  proto.String(current),
+	}.Build()
+}
+
+// diffValues reports the keys whose values differ between two maps, sorted.
+func diffValues(kind mitmflowv1.FlowDifferenceKind, previous, current map[string]string, limit int) []*mitmflowv1.FlowDifference {
+	keys := make(map[string]bool, len(previous)+len(current))
+	for k := range previous {
+		keys[k] = true
+	}
+	for k := range current {
+		keys[k] = true
+	}
+	var diffs []*mitmflowv1.FlowDifference
+	for _, k := range slices.Sorted(maps.Keys(keys)) {
+		p, pok := previous[k]
+		c, cok := current[k]
+		if p == c && pok == cok {
+			continue
+		}
+		if limit > 0 && len(diffs) == limit {
+			break
+		}
+		diffs = append(diffs, flowDifference(kind, k, p, c))
+	}
+	return diffs
+}
+
+func queryDifferences(previous, current string) []*mitmflowv1.FlowDifference {
+	return diffValues(mitmflowv1.FlowDifferenceKind_FLOW_DIFFERENCE_KIND_QUERY, queryValues(previous), queryValues(current), 0)
+}
+
+func queryValues(rawURL string) map[string]string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+	values := make(map[string]string)
+	for k, v := range u.Query() {
+		values[k] = strings.Join(v, ", ")
+	}
+	return values
+}
+
+// headerDifferences compares headers by lowercased name, leaving out the
+// ones that differ every time, like Date.
+func headerDifferences(previous, current map[string]string) []*mitmflowv1.FlowDifference {
+	normalize := func(headers map[string]string) map[string]string {
+		out := make(map[string]string, len(headers))
+		for k, v := range headers {
+			if name := strings.ToLower(k); !volatileHeaders[name] {
+				out[name] = v
+			}
+		}
+		return out
+	}
+	return diffValues(mitmflowv1.FlowDifferenceKind_FLOW_DIFFERENCE_KIND_HEADER, normalize(previous), normalize(current), 0)
+}
+
+// message is a request or response.
+type message interface {
+	GetHeaders() map[string]string
+	GetContent() []byte
+}
+
+// bodyDifferences compares two JSON bodies field by field, and any other
+// bodies by their size and hash.
+func bodyDifferences(previous, current message) []*mitmflowv1.FlowDifference {
+	prevBody, currentBody := decodedBody(previous), decodedBody(current)
+	prevFields, prevJSON := jsonFields(previous, prevBody)
+	currentFields, currentJSON := jsonFields(current, currentBody)
+	if prevJSON && currentJSON {
+		return diffValues(mitmflowv1.FlowDifferenceKind_FLOW_DIFFERENCE_KIND_BODY, prevFields, currentFields, maxBodyDifferences)
+	}
+	if string(prevBody) == string(currentBody) {
+		return nil
+	}
+	return []*mitmflowv1.FlowDifference{
+		flowDifference(mitmflowv1.FlowDifferenceKind_FLOW_DIFFERENCE_KIND_BODY, "", describeBody(prevBody), describeBody(currentBody)),
+	}
+}
+
+func decodedBody(m message) []byte {
+	content := m.GetContent()
+	if decoded, ok := decodeContentEncoding(content, getHeaderValue(m.GetHeaders(), "Content-Encoding")); ok {
+		return decoded
+	}
+	return content
+}
+
+func describeBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(body)
+	return fmt.Sprintf("%d bytes, sha256 %s", len(body), hex.EncodeToString(sum[:]))
+}
+
+// jsonFields flattens a JSON body into the paths of its values, like
+// "$.items[0].id", each mapped to the value as JSON. Empty objects and
+// arrays are values too.
+func jsonFields(m message, body []byte) (map[string]string, bool) {
+	mediaType, _, _ := mime.ParseMediaType(getHeaderValue(m.GetHeaders(), "Content-Type"))
+	if !isJSONMediaType(mediaType) {
+		return nil, false
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, false
+	}
+	fields := make(map[string]string)
+	addJSONFields(fields, "$", v)
+	return fields, true
+}
+
+func addJSONFields(fields map[string]string, path string, v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			fields[path] = "{}"
+		}
+		for k, child := range v {
+			addJSONFields(fields, path+"."+k, child)
+		}
+	case []any:
+		if len(v) == 0 {
+			fields[path] = "[]"
+		}
+		for i, child := range v {
+			addJSONFields(fields, fmt.Sprintf("%s[%d]", path, i), child)
+		}
+	default:
+		data, _ := json.Marshal(v)
+		fields[path] = string(data)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDiffWithPrevious(t *testing.T) {
+	server, storage := newTestServer(t)
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	save := func(id, url string, at time.Duration, status int32, headers map[string]string, body string) {
+		require.NoError(t, storage.SaveFlow(mitmflowv1.Flow_builder{
+			HttpFlow: mitmproxyv1.HTTPFlow_builder{
+				Id:             proto.String(id),
+				TimestampStart: timestamppb.New(start.Add(at)),
+				Request: mitmproxyv1.Request_builder{
+					Method:  proto.String("GET"),
+					Url:     proto.String(url),
+					Headers: map[string]string{"Accept": "application/json"},
+				}.Build(),
+				Response: mitmproxyv1.Response_builder{
+					StatusCode: proto.Int32(status),
+					Headers:    headers,
+					Content:    []byte(body),
+				}.Build(),
+			}.Build(),
+		}.Build()))
+	}
+	jsonHeaders := func(extra ...string) map[string]string {
+		headers := map[string]string{"Content-Type": "application/json", "Date": start.String()}
+		for i := 0; i < len(extra); i += 2 {
+			headers[extra[i]] = extra[i+1]
+		}
+		return headers
+	}
+	save("passing", "https://api.example.com/orders?page=1", 0, 200, jsonHeaders(), `{"items":[{"id":1,"status":"ok"}]}`)
+	save("other", "https://api.example.com/users", time.Second, 200, jsonHeaders(), `{}`)
+	save("failing", "https://api.example.com/orders?page=2", 2*time.Second, 500, jsonHeaders("Retry-After", "5"), `{"items":[{"id":1,"status":"failed"}],"error":"db"}`)
+
+	diff := func(id string) *mitmflowv1.DiffWithPreviousResponse {
+		res, err := server.DiffWithPrevious(context.Background(), connect.NewRequest(mitmflowv1.DiffWithPreviousRequest_builder{
+			FlowId: proto.String(id),
+		}.Build()))
+		require.NoError(t, err)
+		return res.Msg
+	}
+	type difference struct {
+		kind     mitmflowv1.FlowDifferenceKind
+		field    string
+		previous string
+		current  string
+	}
+	differences := func(diffs []*mitmflowv1.FlowDifference) []difference {
+		var out []difference
+		for _, d := range diffs {
+			out = append(out, difference{d.GetKind(), d.GetField(), d.GetPrevious(), d.GetCurrent()})
+		}
+		return out
+	}
+
+	res := diff("failing")
+	assert.Equal(t, "passing", res.GetPrevious().GetId())
+	assert.Equal(t, []difference{
+		{mitmflowv1.FlowDifferenceKind_FLOW_DIFFERENCE_KIND_QUERY, "page", "1", "2"},
+	}, differences(res.GetRequest()))
+	assert.Equal(t, []difference{
+		{mitmflowv1.FlowDifferenceKind_FLOW_DIFFERENCE_KIND_STATUS, "", "200", "500"},
+		{mitmflowv1.FlowDifferenceKind_FLOW_DIFFERENCE_KIND_HEADER, "retry-after", "", "5"},
+		{mitmflowv1.FlowDifferenceKind_FLOW_DIFFERENCE_KIND_BODY, "$.error", "", `"db"`},
+		{mitmflowv1.FlowDifferenceKind_FLOW_DIFFERENCE_KIND_BODY, "$.items[0].status", `"ok"`, `"failed"`},
+	}, differences(res.GetResponse()))
+
+	res = diff("passing")
+	assert.False(t, res.HasPrevious())
+	assert.Empty(t, res.GetResponse())
+
+	_, err := server.DiffWithPrevious(context.Background(), connect.NewRequest(mitmflowv1.DiffWithPreviousRequest_builder{
+		FlowId: proto.String("missing"),
+	}.Build()))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestBodyDifferences_NotJSON(t *testing.T) {
+	prev := mitmproxyv1.Response_builder{Content: []byte("hello")}.Build()
+	current := mitmproxyv1.Response_builder{Content: []byte("hello!")}.Build()
+	diffs := bodyDifferences(prev, current)
+	require.Len(t, diffs, 1)
+	assert.Equal(t, "5 bytes, sha256 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", diffs[0].GetPrevious())
+	assert.Contains(t, diffs[0].GetCurrent(), "6 bytes, sha256 ")
+	assert.Empty(t, bodyDifferences(prev, prev))
+}
@@ -1,36 +1,315 @@
 package main
 
 import (
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"strconv"
+	"strings"
 
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
 )
 
-// DnsPacket is a simplified struct for JSON marshaling of DNS data.
+// DnsPacket is the JSON rendering of a DNS message, with names, types and
+// record data in their presentation format.
 type DnsPacket struct {
-	Questions   []layers.DNSQuestion       `json:"questions"`
-	Answers     []layers.DNSResourceRecord `json:"answers"`
-	Authorities []layers.DNSResourceRecord `json:"authorities"`
-	Additionals []layers.DNSResourceRecord `json:"additionals"`
+	ID          uint16         `json:"id"`
+	Opcode      string         `json:"opcode"`
+	Rcode       string         `json:"rcode"`
+	Flags       []string       `json:"flags"`
+	Questions   []DnsQuestion  `json:"questions"`
+	Answers     []DnsRecord    `json:"answers,omitempty"`
+	Authorities []DnsRecord    `json:"authorities,omitempty"`
+	Additionals []DnsRecord    `json:"additionals,omitempty"`
+	EDNS        *DnsEDNS       `json:"edns,omitempty"`
+	TTL         *DnsTTLSummary `json:"ttl,omitempty"`
+}
+
+type DnsQuestion struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Class string `json:"class"`
+}
+
+type DnsRecord struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Class string `json:"class"`
+	TTL   uint32 `json:"ttl"`
+	Data  string `json:"data"`
+}
+
+// DnsEDNS is the EDNS0 OPT pseudo-record of a message.
+type DnsEDNS struct {
+	Version uint8  `json:"version"`
+	UDPSize uint16 `json:"udpSize"`
+	// DNSSECOK is the DO bit, asking for DNSSEC records.
+	DNSSECOK bool            `json:"dnssecOk"`
+	Options  []DnsEDNSOption `json:"options,omitempty"`
+}
+
+type DnsEDNSOption struct {
+	Code string `json:"code"`
+	Data string `json:"data"`
+}
+
+// DnsTTLSummary rolls up the TTLs of the answers, which decide how long
+// they're cached.
+type DnsTTLSummary struct {
+	Min uint32 `json:"min"`
+	Max uint32 `json:"max"`
+}
+
+var dnsTypeNames = map[layers.DNSType]string{
+	43: "DS", 46: "RRSIG", 47: "NSEC", 48: "DNSKEY", 50: "NSEC3", 52: "TLSA",
+	64: "SVCB", 65: "HTTPS", 99: "SPF", 255: "ANY", 257: "CAA",
+}
+
+var dnsRcodeNames = []string{
+	"NOERROR", "FORMERR", "SERVFAIL", "NXDOMAIN", "NOTIMP", "REFUSED",
+	"YXDOMAIN", "YXRRSET", "NXRRSET", "NOTAUTH", "NOTZONE",
+}
+
+var dnsOpcodeNames = []string{"QUERY", "IQUERY", "STATUS", "", "NOTIFY", "UPDATE"}
+
+var dnsOptionNames = map[layers.DNSOptionCode]string{
+	3: "NSID", 8: "CLIENT-SUBNET", 9: "EXPIRE", 10: "COOKIE", 11: "TCP-KEEPALIVE",
+	12: "PADDING", 15: "EXTENDED-ERROR",
+}
+
+func dnsTypeName(t layers.DNSType) string {
+	if name, ok := dnsTypeNames[t]; ok {
+		return name
+	}
+	if name := t.String(); name != "Unknown" {
+		return name
+	}
+	return fmt.Sprintf("TYPE%d", t)
+}
+
+func dnsClassName(c layers.DNSClass) string {
+	switch c {
+	case layers.DNSClassIN:
+		return "IN"
+	case layers.DNSClassCH:
+		return "CH"
+	case layers.DNSClassAny:
+		return "ANY"
+	}
+	return fmt.Sprintf("CLASS%d", c)
+}
+
+func dnsRcodeName(code int) string {
+	if code < len(dnsRcodeNames) {
+		return dnsRcodeNames[code]
+	}
+	return fmt.Sprintf("RCODE%d", code)
+}
+
+func dnsName(name []byte) string {
+	if len(name) == 0 {
+		return "."
+	}
+	return string(name) + "."
 }
 
 func parseDnsPacket(content []byte) (string, error) {
 	packet := gopacket.NewPacket(content, layers.LayerTypeDNS, gopacket.Default)
-	if dnsLayer := packet.Layer(layers.LayerTypeDNS); dnsLayer != nil {
-		dns, _ := dnsLayer.(*layers.DNS)
-		dnsPacket := DnsPacket{
-			Questions:   dns.Questions,
-			Answers:     dns.Answers,
-			Authorities: dns.Authorities,
-			Additionals: dns.Additionals,
-		}
-		jsonBytes, err := json.MarshalIndent(dnsPacket, "", "  ")
+	dnsLayer := packet.Layer(layers.LayerTypeDNS)
+	if dnsLayer == nil {
+		return "", fmt.Errorf("not a valid DNS packet")
+	}
+	dns, _ := dnsLayer.(*layers.DNS)
+	dnsPacket := DnsPacket{
+		ID:     dns.ID,
+		Opcode: fmt.Sprintf("OPCODE%d", dns.OpCode),
+		Flags:  []string{},
+	}
+	if int(dns.OpCode) < len(dnsOpcodeNames) && dnsOpcodeNames[dns.OpCode] != "" {
+		dnsPacket.Opcode = dnsOpcodeNames[dns.OpCode]
+	}
+	for _, f := range []struct {
+		set  bool
+		name string
+	}{{dns.QR, "qr"}, {dns.AA, "aa"}, {dns.TC, "tc"}, {dns.RD, "rd"}, {dns.RA, "ra"}} {
+		if f.set {
+			dnsPacket.Flags = append(dnsPacket.Flags, f.name)
+		}
+	}
+	for _, q := range dns.Questions {
+		dnsPacket.Questions = append(dnsPacket.Questions, DnsQuestion{
+			Name:  dnsName(q.Name),
+			Type:  dnsTypeName(q.Type),
+			Class: dnsClassName(q.Class),
+		})
+	}
+	dnsPacket.Answers = dnsRecords(dns.Answers)
+	dnsPacket.Authorities = dnsRecords(dns.Authorities)
+	rcode := int(dns.ResponseCode)
+	for _, rr := range dns.Additionals {
+		if rr.Type == layers.DNSTypeOPT {
+			dnsPacket.EDNS = dnsEDNS(rr)
+			// The OPT record carries the upper eight bits of the rcode.
+			rcode |= int(rr.TTL>>24) << 4
+			continue
+		}
+		dnsPacket.Additionals = append(dnsPacket.Additionals, dnsRecord(rr))
+	}
+	dnsPacket.Rcode = dnsRcodeName(rcode)
+	for i, rr := range dns.Answers {
+		if i == 0 {
+			dnsPacket.TTL = &DnsTTLSummary{Min: rr.TTL, Max: rr.TTL}
+		}
+		dnsPacket.TTL.Min = min(dnsPacket.TTL.Min, rr.TTL)
+		dnsPacket.TTL.Max = max(dnsPacket.TTL.Max, rr.TTL)
+	}
+	jsonBytes, err := json.MarshalIndent(dnsPacket, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(jsonBytes), nil
+}
+
+func dnsRecords(rrs []layers.DNSResourceRecord) []DnsRecord {
+	var records []DnsRecord
+	for _, rr := range rrs {
+		records = append(records, dnsRecord(rr))
+	}
+	return records
+}
+
+func dnsRecord(rr layers.DNSResourceRecord) DnsRecord {
+	return DnsRecord{
+		Name:  dnsName(rr.Name),
+		Type:  dnsTypeName(rr.Type),
+		Class: dnsClassName(rr.Class),
+		TTL:   rr.TTL,
+		Data:  dnsRecordData(rr),
+	}
+}
+
+// dnsRecordData renders the data of a record in its presentation format,
+// like a zone file would.
+func dnsRecordData(rr layers.DNSResourceRecord) string {
+	switch rr.Type {
+	case layers.DNSTypeA, layers.DNSTypeAAAA:
+		return rr.IP.String()
+	case layers.DNSTypeNS:
+		return dnsName(rr.NS)
+	case layers.DNSTypeCNAME:
+		return dnsName(rr.CNAME)
+	case layers.DNSTypePTR:
+		return dnsName(rr.PTR)
+	case layers.DNSTypeMX:
+		return fmt.Sprintf("%d %s", rr.MX.Preference, dnsName(rr.MX.Name))
+	case layers.DNSTypeSRV:
+		return fmt.Sprintf("%d %d %d %s", rr.SRV.Priority, rr.SRV.Weight, rr.SRV.Port, dnsName(rr.SRV.Name))
+	case layers.DNSTypeSOA:
+		soa := rr.SOA
+		return fmt.Sprintf("%s %s %d %d %d %d %d", dnsName(soa.MName), dnsName(soa.RName), soa.Serial, soa.Refresh, soa.Retry, soa.Expire, soa.Minimum)
+	case layers.DNSTypeTXT:
+		txts := make([]string, len(rr.TXTs))
+		for i, txt := range rr.TXTs {
+			txts[i] = strconv.Quote(string(txt))
+		}
+		return strings.Join(txts, " ")
+	case layers.DNSTypeURI:
+		return fmt.Sprintf("%d %d %q", rr.URI.Priority, rr.URI.Weight, rr.URI.Target)
+	case 257: // CAA
+		if len(rr.Data) >= 2 && len(rr.Data) >= 2+int(rr.Data[1]) {
+			tag := rr.Data[2 : 2+rr.Data[1]]
+			return fmt.Sprintf("%d %s %q", rr.Data[0], tag, rr.Data[2+len(tag):])
+		}
+	}
+	// RFC 3597's generic format for types without a presentation format.
+	return fmt.Sprintf("\\# %d %x", len(rr.Data), rr.Data)
+}
+
+func dnsEDNS(rr layers.DNSResourceRecord) *DnsEDNS {
+	edns := &DnsEDNS{
+		Version:  uint8(rr.TTL >> 16),
+		UDPSize:  uint16(rr.Class),
+		DNSSECOK: rr.TTL&0x8000 != 0,
+	}
+	for _, opt := range rr.OPT {
+		name, ok := dnsOptionNames[opt.Code]
+		if !ok {
+			name = fmt.Sprintf("OPTION%d", opt.Code)
+		}
+		edns.Options = append(edns.Options, DnsEDNSOption{Code: name, Data: dnsOptionData(opt)})
+	}
+	return edns
+}
+
+func dnsOptionData(opt layers.DNSOPT) string {
+	data := opt.Data
+	switch opt.Code {
+	case 8: // Client subnet: family, source and scope prefix, address.
+		if len(data) >= 4 {
+			ip := make(net.IP, 16)
+			if binary.BigEndian.Uint16(data) == 1 {
+				ip = make(net.IP, 4)
+			}
+			copy(ip, data[4:])
+			return fmt.Sprintf("%s/%d scope %d", ip, data[2], data[3])
+		}
+	case 12:
+		return countOf(len(data), "byte")
+	case 15: // Extended DNS error: info code and extra text.
+		if len(data) >= 2 {
+			text := fmt.Sprint(binary.BigEndian.Uint16(data))
+			if len(data) > 2 {
+				text += " " + strconv.Quote(string(data[2:]))
+			}
+			return text
+		}
+	case 3:
+		if !isBinary(data) {
+			return string(data)
+		}
+	}
+	return hex.EncodeToString(data)
+}
+
+// isDNSOverTCP recognizes the first message of a DNS over TCP or TLS
+// connection: a query prefixed with its length.
+func isDNSOverTCP(first []byte) bool {
+	if len(first) < 14 || int(binary.BigEndian.Uint16(first)) > len(first)-2 {
+		return false
+	}
+	msg := first[2 : 2+binary.BigEndian.Uint16(first)]
+	// A query has the QR bit clear and asks a single question.
+	if msg[2]&0x80 != 0 || binary.BigEndian.Uint16(msg[4:]) != 1 {
+		return false
+	}
+	_, err := parseDnsPacket(msg)
+	return err == nil
+}
+
+// dnsStreamDecoder renders the DNS messages of a TCP or TLS connection, each
+// prefixed with its length.
+type dnsStreamDecoder struct{}
+
+func (dnsStreamDecoder) decode(data []byte, _ bool) ([]string, []byte, error) {
+	var frames []string
+	for len(data) >= 2 {
+		n := int(binary.BigEndian.Uint16(data))
+		if len(data) < 2+n {
+			break
+		}
+		if n == 0 {
+			return nil, nil, errors.New("empty dns message")
+		}
+		frame, err := parseDnsPacket(data[2 : 2+n])
 		if err != nil {
-			return "", err
+			return nil, nil, err
 		}
-		return string(jsonBytes), nil
+		frames = append(frames, frame)
+		data = data[2+n:]
 	}
-	return "", fmt.Errorf("not a valid DNS packet")
+	return frames, data, nil
 }
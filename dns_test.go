@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func serializeDNS(t *testing.T, dns *layers.DNS) []byte {
+	t.Helper()
+	buf := gopacket.NewSerializeBuffer()
+	require.NoError(t, dns.SerializeTo(buf, gopacket.SerializeOptions{FixLengths: true}))
+	return buf.Bytes()
+}
+
+func testDNSQuery(t *testing.T) []byte {
+	return serializeDNS(t, &layers.DNS{
+		ID: 7, RD: true,
+		Questions: []layers.DNSQuestion{{Name: []byte("example.com"), Type: layers.DNSTypeA, Class: layers.DNSClassIN}},
+	})
+}
+
+func testDNSResponse(t *testing.T) []byte {
+	return serializeDNS(t, &layers.DNS{
+		ID: 7, QR: true, RD: true, RA: true,
+		Questions: []layers.DNSQuestion{{Name: []byte("example.com"), Type: layers.DNSTypeA, Class: layers.DNSClassIN}},
+		Answers: []layers.DNSResourceRecord{{
+			Name: []byte("example.com"), Type: layers.DNSTypeA, Class: layers.DNSClassIN,
+			TTL: 300, IP: net.IPv4(93, 184, 216, 34).To4(),
+		}},
+	})
+}
+
+func lengthPrefixed(msgs ...[]byte) []byte {
+	var b []byte
+	for _, m := range msgs {
+		b = binary.BigEndian.AppendUint16(b, uint16(len(m)))
+		b = append(b, m...)
+	}
+	return b
+}
+
+func TestDNSOverTCP(t *testing.T) {
+	query := lengthPrefixed(testDNSQuery(t))
+	response := lengthPrefixed(testDNSResponse(t))
+	assert.True(t, isDNSOverTCP(query))
+	assert.False(t, isDNSOverTCP(response))
+	assert.False(t, isDNSOverTCP([]byte("GET / HTTP/1.1\r\n\r\n")))
+
+	flow := mitmproxyv1.TCPFlow_builder{
+		// DoT after mitmproxy terminated its TLS, on an unusual port.
+		Server: mitmproxyv1.ServerConn_builder{AddressPort: proto.Uint32(8853)}.Build(),
+		Messages: []*mitmproxyv1.TCPMessage{
+			mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(true), Content: query}.Build(),
+			mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(false), Content: response[:10]}.Build(),
+			mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(false), Content: response[10:]}.Build(),
+		},
+	}.Build()
+	details := tcpMessageDetails(flow, false).GetMessages()
+	require.Len(t, details, 3)
+	assert.Equal(t, "application/dns-message", details[0].GetEffectiveContentType())
+	queryFrame, err := parseDnsPacket(testDNSQuery(t))
+	require.NoError(t, err)
+	assert.Equal(t, []string{queryFrame}, details[0].GetTextualFrames())
+	assert.Empty(t, details[1].GetTextualFrames())
+	responseFrame, err := parseDnsPacket(testDNSResponse(t))
+	require.NoError(t, err)
+	assert.Equal(t, []string{responseFrame}, details[2].GetTextualFrames())
+}
+
+func TestParseDnsPacket(t *testing.T) {
+	response := serializeDNS(t, &layers.DNS{
+		ID: 7, QR: true, RD: true, RA: true, ResponseCode: layers.DNSResponseCodeNXDomain,
+		Questions: []layers.DNSQuestion{{Name: []byte("example.com"), Type: layers.DNSTypeTXT, Class: layers.DNSClassIN}},
+		Answers: []layers.DNSResourceRecord{
+			{Name: []byte("example.com"), Type: layers.DNSTypeTXT, Class: layers.DNSClassIN, TTL: 3600, TXTs: [][]byte{[]byte("v=spf1 -all")}},
+			{Name: []byte("_sip._tcp.example.com"), Type: layers.DNSTypeSRV, Class: layers.DNSClassIN, TTL: 300,
+				SRV: layers.DNSSRV{Priority: 10, Weight: 5, Port: 5060, Name: []byte("sip.example.com")}},
+		},
+		Additionals: []layers.DNSResourceRecord{{
+			Type: layers.DNSTypeOPT, Class: 1232, TTL: 0x8000,
+			OPT: []layers.DNSOPT{
+				{Code: 8, Data: []byte{0, 1, 24, 0, 192, 0, 2}},
+				{Code: 15, Data: append([]byte{0, 18}, "blocked"...)},
+			},
+		}},
+	})
+	rendered, err := parseDnsPacket(response)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": 7, "opcode": "QUERY", "rcode": "NXDOMAIN", "flags": ["qr", "rd", "ra"],
+		"questions": [{"name": "example.com.", "type": "TXT", "class": "IN"}],
+		"answers": [
+			{"name": "example.com.", "type": "TXT", "class": "IN", "ttl": 3600, "data": "\"v=spf1 -all\""},
+			{"name": "_sip._tcp.example.com.", "type": "SRV", "class": "IN", "ttl": 300, "data": "10 5 5060 sip.example.com."}
+		],
+		"edns": {"version": 0, "udpSize": 1232, "dnssecOk": true, "options": [
+			{"code": "CLIENT-SUBNET", "data": "192.0.2.0/24 scope 0"},
+			{"code": "EXTENDED-ERROR", "data": "18 \"blocked\""}
+		]},
+		"ttl": {"min": 300, "max": 3600}
+	}`, rendered)
+}
+
+func TestDNSRecordData(t *testing.T) {
+	caa := layers.DNSResourceRecord{Type: 257, Data: append([]byte{0, 5}, "issueletsencrypt.org"...)}
+	assert.Equal(t, `0 issue "letsencrypt.org"`, dnsRecordData(caa))
+	assert.Equal(t, "CAA", dnsTypeName(caa.Type))
+	unknown := layers.DNSResourceRecord{Type: 4711, Data: []byte{1, 2}}
+	assert.Equal(t, `\# 2 0102`, dnsRecordData(unknown))
+	assert.Equal(t, "TYPE4711", dnsTypeName(unknown.Type))
+}
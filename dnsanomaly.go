@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// nxdomainBurst is how many NXDOMAIN answers to one client within
+	// nxdomainBurstWindow make a burst.
+	nxdomainBurst       = 10
+	nxdomainBurstWindow = time.Minute
+	// dnsLongLabel and dnsLongName are well below the 63 and 253 characters
+	// DNS allows, but far above what people pick for names.
+	dnsLongLabel = 50
+	dnsLongName  = 180
+	// A label needs at least dnsEntropyMinLength characters with at least
+	// dnsEntropyBits bits of entropy per character to look random. Even long
+	// runs of words stay below that, while base32 and base64 data, which
+	// tunnels encode into names, don't.
+	dnsEntropyMinLength = 20
+	dnsEntropyBits      = 4.0
+)
+
+// usualQTypes are the query types ordinary clients ask for.
+var usualQTypes = map[string]bool{
+	"A": true, "AAAA": true, "CNAME": true, "MX": true, "NS": true, "PTR": true,
+	"SOA": true, "SRV": true, "TXT": true, "HTTPS": true, "SVCB": true, "CAA": true,
+	"DS": true, "DNSKEY": true, "NAPTR": true,
+}
+
+type nxdomainAnswer struct {
+	flowID string
+	at     time.Time
+}
+
+// dnsAnomalyDetector flags DNS flows that look like tunneling, exfiltration
+// or a client churning through names that don't exist.
+type dnsAnomalyDetector struct {
+	mu sync.Mutex
+	// nxdomains holds the recent NXDOMAIN answers to each client, oldest
+	// first.
+	nxdomains map[string][]nxdomainAnswer
+}
+
+func newDNSAnomalyDetector() *dnsAnomalyDetector {
+	return &dnsAnomalyDetector{nxdomains: make(map[string][]nxdomainAnswer)}
+}
+
+// check returns the anomalies of a DNS flow. A flow is flagged for an
+// NXDOMAIN burst when its answer is the one that makes the burst, or comes
+// while it lasts.
+func (d *dnsAnomalyDetector) check(flow *mitmflowv1.Flow) []*mitmflowv1.DnsAnomaly {
+	dns := flow.GetDnsFlow()
+	var anomalies []*mitmflowv1.DnsAnomaly
+	add := func(kind mitmflowv1.DnsAnomalyKind, format string, args ...any) {
+		anomalies = append(anomalies, mitmflowv1.DnsAnomaly_builder{
+			Kind:   kind.Enum(),
+			Detail: proto.String(fmt.Sprintf(format, args...)),
+		}.Build())
+	}
+	for _, q := range dns.GetRequest().GetQuestions() {
+		name := strings.TrimSuffix(q.GetName(), ".")
+		if !usualQTypes[strings.ToUpper(q.GetType())] {
+			add(mitmflowv1.DnsAnomalyKind_DNS_ANOMALY_KIND_UNUSUAL_QTYPE, "%s query for %s", q.GetType(), name)
+		}
+		if len(name) > dnsLongName {
+			add(mitmflowv1.DnsAnomalyKind_DNS_ANOMALY_KIND_LONG_LABEL, "name of %d characters", len(name))
+		} else if label := longestLabel(name); len(label) > dnsLongLabel {
+			add(mitmflowv1.DnsAnomalyKind_DNS_ANOMALY_KIND_LONG_LABEL, "label of %d characters", len(label))
+		}
+		if label, bits := randomLabel(name); label != "" {
+			add(mitmflowv1.DnsAnomalyKind_DNS_ANOMALY_KIND_HIGH_ENTROPY_NAME, "label %q has %.1f bits of entropy per character", label, bits)
+		}
+	}
+	if n := d.recordNXDOMAIN(flow); n >= nxdomainBurst {
+		add(mitmflowv1.DnsAnomalyKind_DNS_ANOMALY_KIND_NXDOMAIN_BURST, "%d NXDOMAIN answers to %s within %s", n, dns.GetClient().GetPeernameHost(), nxdomainBurstWindow)
+	}
+	return anomalies
+}
+
+// recordNXDOMAIN remembers a flow answered with NXDOMAIN and returns how many
+// such answers its client got within the window before it. mitmproxy sends
+// live flows again as they progress, so each flow is only counted once.
+func (d *dnsAnomalyDetector) recordNXDOMAIN(flow *mitmflowv1.Flow) int {
+	// mitmproxy doesn't send the response code separately, so it's read
+	// from the packed message header.
+	packed := flow.GetDnsFlow().GetResponse().GetPacked()
+	if d == nil || len(packed) < 4 || packed[3]&0x0f != 3 {
+		return 0
+	}
+	client := flow.GetDnsFlow().GetClient().GetPeernameHost()
+	id := GetFlowID(flow)
+	at := flowStartTime(flow, time.Now())
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	answers := d.nxdomains[client]
+	i := 0
+	for i < len(answers) && at.Sub(answers[i].at) > nxdomainBurstWindow {
+		i++
+	}
+	answers = answers[i:]
+	counted := false
+	n := 0
+	for _, a := range answers {
+		if a.flowID == id {
+			counted = true
+		}
+		if !a.at.After(at) {
+			n++
+		}
+	}
+	if !counted {
+		answers = append(answers, nxdomainAnswer{flowID: id, at: at})
+		n++
+	}
+	if len(answers) == 0 {
+		delete(d.nxdomains, client)
+	} else {
+		d.nxdomains[client] = answers
+	}
+	return n
+}
+
+func longestLabel(name string) string {
+	var longest string
+	for _, label := range strings.Split(name, ".") {
+		if len(label) > len(longest) {
+			longest = label
+		}
+	}
+	return longest
+}
+
+// randomLabel returns the most random looking label of a name below its
+// registered domain, approximated as the last two labels, with its entropy
+// per character. It returns "" when no label looks random.
+func randomLabel(name string) (string, float64) {
+	labels := strings.Split(name, ".")
+	if len(labels) <= 2 {
+		return "", 0
+	}
+	var found string
+	var most float64
+	for _, label := range labels[:len(labels)-2] {
+		if len(label) < dnsEntropyMinLength {
+			continue
+		}
+		if bits := shannonEntropy(strings.ToLower(label)); bits >= dnsEntropyBits && bits > most {
+			found, most = label, bits
+		}
+	}
+	return found, most
+}
+
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	n := 0
+	for _, r := range s {
+		counts[r]++
+		n++
+	}
+	var bits float64
+	for _, c := range counts {
+		p := float64(c) / float64(n)
+		bits -= p * math.Log2(p)
+	}
+	return bits
+}
+
+// matchDnsAnomalies reports whether a flow was flagged with any of kinds.
+func matchDnsAnomalies(flow *mitmflowv1.Flow, kinds []mitmflowv1.DnsAnomalyKind) bool {
+	for _, a := range flow.GetDnsFlowExtra().GetAnomalies() {
+		for _, kind := range kinds {
+			if a.GetKind() == kind {
+				return true
+			}
+		}
+	}
+	return false
+}
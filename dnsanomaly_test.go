@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func dnsQueryFlow(id, name, qtype string, at time.Time, nxdomain bool) *mitmflowv1.Flow {
+	// Only the header of the packed response matters: its rcode.
+	packed := []byte{0, 1, 0x81, 0x80}
+	if nxdomain {
+		packed[3] = 0x83
+	}
+	return mitmflowv1.Flow_builder{
+		DnsFlow: mitmproxyv1.DNSFlow_builder{
+			Id:             proto.String(id),
+			TimestampStart: timestamppb.New(at),
+			Client:         mitmproxyv1.ClientConn_builder{PeernameHost: proto.String("10.0.0.5")}.Build(),
+			Request: mitmproxyv1.DNSMessage_builder{
+				Questions: []*mitmproxyv1.DNSQuestion{
+					mitmproxyv1.DNSQuestion_builder{Name: proto.String(name), Type: proto.String(qtype)}.Build(),
+				},
+			}.Build(),
+			Response: mitmproxyv1.DNSMessage_builder{Packed: packed}.Build(),
+		}.Build(),
+	}.Build()
+}
+
+func anomalyKinds(anomalies []*mitmflowv1.DnsAnomaly) []mitmflowv1.DnsAnomalyKind {
+	var kinds []mitmflowv1.DnsAnomalyKind
+	for _, a := range anomalies {
+		kinds = append(kinds, a.GetKind())
+	}
+	return kinds
+}
+
+func TestDNSAnomalies(t *testing.T) {
+	d := newDNSAnomalyDetector()
+	start := hostnameTestStart
+
+	assert.Empty(t, d.check(dnsQueryFlow("plain", "accountsettings.example.com.", "A", start, false)))
+
+	tunnel := d.check(dnsQueryFlow("tunnel", "mzxw6ytboi4dsnrzgq2tmnzwge3dqmjzgiztgnbvgy3tmojw.t.example.com.", "NULL", start, false))
+	assert.Equal(t, []mitmflowv1.DnsAnomalyKind{
+		mitmflowv1.DnsAnomalyKind_DNS_ANOMALY_KIND_UNUSUAL_QTYPE,
+		mitmflowv1.DnsAnomalyKind_DNS_ANOMALY_KIND_HIGH_ENTROPY_NAME,
+	}, anomalyKinds(tunnel))
+	assert.Equal(t, "NULL query for mzxw6ytboi4dsnrzgq2tmnzwge3dqmjzgiztgnbvgy3tmojw.t.example.com", tunnel[0].GetDetail())
+
+	long := d.check(dnsQueryFlow("long", "this-is-a-very-long-label-that-goes-on-and-on-and-on.example.com.", "A", start, false))
+	require.Len(t, long, 1)
+	assert.Equal(t, "label of 52 characters", long[0].GetDetail())
+}
+
+func TestDNSAnomalies_NXDOMAINBurst(t *testing.T) {
+	d := newDNSAnomalyDetector()
+	for i := range nxdomainBurst - 1 {
+		flow := dnsQueryFlow(fmt.Sprint(i), fmt.Sprintf("host%d.example.com.", i), "A", hostnameTestStart.Add(time.Duration(i)*time.Second), true)
+		assert.Empty(t, d.check(flow))
+		// mitmproxy sends live flows again; they aren't counted twice.
+		assert.Empty(t, d.check(flow))
+	}
+	burst := d.check(dnsQueryFlow("last", "last.example.com.", "A", hostnameTestStart.Add(30*time.Second), true))
+	require.Len(t, burst, 1)
+	assert.Equal(t, "10 NXDOMAIN answers to 10.0.0.5 within 1m0s", burst[0].GetDetail())
+
+	// Once the window has passed, the earlier answers are forgotten.
+	assert.Empty(t, d.check(dnsQueryFlow("later", "later.example.com.", "A", hostnameTestStart.Add(2*time.Minute), true)))
+}
+
+func TestFilterDNSAnomalies(t *testing.T) {
+	server, err := NewMITMFlowServer(nil, nil)
+	require.NoError(t, err)
+	flow := dnsQueryFlow("any", "example.com.", "ANY", hostnameTestStart, false)
+	server.preprocessFlow(flow)
+
+	filter := mitmflowv1.FlowFilter_builder{
+		DnsAnomaly: []mitmflowv1.DnsAnomalyKind{mitmflowv1.DnsAnomalyKind_DNS_ANOMALY_KIND_UNUSUAL_QTYPE},
+	}.Build()
+	assert.True(t, matchFlow(flow, filter))
+	filter.SetDnsAnomaly([]mitmflowv1.DnsAnomalyKind{mitmflowv1.DnsAnomalyKind_DNS_ANOMALY_KIND_NXDOMAIN_BURST})
+	assert.False(t, matchFlow(flow, filter))
+
+	pred, err := parseFilterExpr("~dnsa unusual_qtype")
+	require.NoError(t, err)
+	assert.True(t, pred(flow))
+	assert.False(t, pred(dnsQueryFlow("a", "example.com.", "A", hostnameTestStart, false)))
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"crypto/sha256"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -30,6 +32,26 @@ func matchFlow(flow *mitmflowv1.Flow, filter *mitmflowv1.FlowFilter) bool {
 		return false
 	}
 
+	// Server Country Filter
+	if len(filter.GetServerCountries()) > 0 {
+		geo := flowServerGeo(flow)
+		if !slices.ContainsFunc(filter.GetServerCountries(), func(cc string) bool {
+			return strings.EqualFold(cc, geo.GetCountryCode())
+		}) {
+			return false
+		}
+	}
+
+	// DNS Anomaly Filter
+	if len(filter.GetDnsAnomaly()) > 0 && !matchDnsAnomalies(flow, filter.GetDnsAnomaly()) {
+		return false
+	}
+
+	// Protocol Filter
+	if len(filter.GetProtocols()) > 0 && !matchProtocols(flow, filter.GetProtocols()) {
+		return false
+	}
+
 	// Text Filter
 	if filterText := strings.ToLower(filter.GetFilterText()); filterText != "" {
 		if !matchText(flow, filterText) {
@@ -132,7 +154,7 @@ func matchText(flow *mitmflowv1.Flow, filterText string) bool {
 	var clientIp, serverIp string
 	var note = flow.GetNote()
 
-	// 1. Common Metadata Check (Note, IP)
+	// 1. Common Metadata Check (Note, IP, key/value metadata)
 	if f := flow.GetHttpFlow(); f != nil {
 		clientIp = f.GetClient().GetPeernameHost()
 		serverIp = f.GetServer().GetAddressHost()
@@ -152,6 +174,11 @@ func matchText(flow *mitmflowv1.Flow, filterText string) bool {
 		containsFold(note, filterText) {
 		return true
 	}
+	for k, v := range flow.GetMetadata() {
+		if containsFold(k, filterText) || containsFold(v, filterText) {
+			return true
+		}
+	}
 
 	// 2. Flow Specific Text Check
 	if f := flow.GetHttpFlow(); f != nil {
@@ -193,6 +220,14 @@ func matchHttpFlowText(flow *mitmflowv1.Flow, f *mitmproxygrpcv1.HTTPFlow, filte
 		if containsFold(sni, filterText) {
 			return true
 		}
+		// A pasted body hash finds the flows with that exact body.
+		if len(filterText) == sha256.Size*2 {
+			extra := flow.GetHttpFlowExtra()
+			if strings.EqualFold(extra.GetRequest().GetSha256(), filterText) ||
+				strings.EqualFold(extra.GetResponse().GetSha256(), filterText) {
+				return true
+			}
+		}
 	} else {
 		// Fallback for multi-token search (e.g. "GET 200")
 		// Use strings.Builder to minimize allocations
@@ -218,6 +253,12 @@ func matchHttpFlowText(flow *mitmflowv1.Flow, f *mitmproxygrpcv1.HTTPFlow, filte
 	if matchHeaders(f.GetResponse().GetHeaders(), filterText) {
 		return true
 	}
+	if matchHeaders(f.GetRequest().GetTrailers(), filterText) {
+		return true
+	}
+	if matchHeaders(f.GetResponse().GetTrailers(), filterText) {
+		return true
+	}
 
 	// Body check
 	// Check textual frames
@@ -387,6 +428,40 @@ func matchHttpFlow(flow *mitmflowv1.Flow, f *mitmproxygrpcv1.HTTPFlow, filter *m
 		}
 	}
 
+	// Client Families
+	if len(httpFilter.GetClientFamilies()) > 0 {
+		if !matchClientFamily(flow.GetHttpFlowExtra().GetUserAgent(), httpFilter.GetClientFamilies()) {
+			return false
+		}
+	}
+
+	// Security Findings
+	if minSeverity := httpFilter.GetMinSecuritySeverity(); minSeverity != mitmflowv1.FindingSeverity_FINDING_SEVERITY_UNSPECIFIED {
+		if maxFindingSeverity(flow) < minSeverity {
+			return false
+		}
+	}
+
+	// gRPC Statuses
+	if len(httpFilter.GetGrpcStatuses()) > 0 && !matchGrpcStatus(flow, httpFilter.GetGrpcStatuses()) {
+		return false
+	}
+
+	// Body Hash
+	if hash := httpFilter.GetBodySha256(); hash != "" {
+		extra := flow.GetHttpFlowExtra()
+		if !strings.EqualFold(extra.GetRequest().GetSha256(), hash) && !strings.EqualFold(extra.GetResponse().GetSha256(), hash) {
+			return false
+		}
+	}
+
+	// JSON Body Queries
+	for _, q := range httpFilter.GetBodyQueries() {
+		if !matchBodyQuery(flow, q) {
+			return false
+		}
+	}
+
 	// Content Types
 	if len(httpFilter.GetContentTypes()) > 0 {
 		reqCt := flow.GetHttpFlowExtra().GetRequest().GetEffectiveContentType()
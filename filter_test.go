@@ -1,6 +1,7 @@
 package main
 
 import (
+	"strings"
 	"testing"
 
 	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
@@ -76,6 +77,7 @@ func TestMatchFlow_Text(t *testing.T) {
 		}.Build(),
 		Response: mitmproxygrpcv1.Response_builder{
 			StatusCode: proto.Int32(200),
+			Trailers:   map[string]string{"grpc-message": "backend unavailable"},
 		}.Build(),
 		Client: mitmproxygrpcv1.ClientConn_builder{
 			Sni: proto.String("example.com"),
@@ -97,6 +99,7 @@ func TestMatchFlow_Text(t *testing.T) {
 		{"path", true},
 		{"json", true}, // Header value
 		{"User-Agent", true}, // Header key
+		{"unavailable", true}, // Trailer value
 		{"GET 200", true}, // Multi token
 		{"path GET", true},
 		{"http://example.com/some/path GET 200 example.com", true},
@@ -115,3 +118,39 @@ func TestMatchFlow_Text(t *testing.T) {
 		}
 	}
 }
+
+func TestMatchFlow_BodySha256(t *testing.T) {
+	hash := blobKey([]byte("payload"))
+	flow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxygrpcv1.HTTPFlow_builder{
+			Request: mitmproxygrpcv1.Request_builder{
+				Url:    proto.String("http://example.com/download"),
+				Method: proto.String("GET"),
+			}.Build(),
+		}.Build(),
+		HttpFlowExtra: mitmflowv1.HTTPFlowExtra_builder{
+			Response: mitmflowv1.MessageDetails_builder{Sha256: proto.String(hash)}.Build(),
+		}.Build(),
+	}.Build()
+
+	cases := []struct {
+		name   string
+		filter *mitmflowv1.FlowFilter
+		want   bool
+	}{
+		{"exact", mitmflowv1.FlowFilter_builder{
+			Http: mitmflowv1.HttpFilter_builder{BodySha256: proto.String(strings.ToUpper(hash))}.Build(),
+		}.Build(), true},
+		{"other", mitmflowv1.FlowFilter_builder{
+			Http: mitmflowv1.HttpFilter_builder{BodySha256: proto.String(blobKey([]byte("other")))}.Build(),
+		}.Build(), false},
+		{"search text", mitmflowv1.FlowFilter_builder{FilterText: proto.String(hash)}.Build(), true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := matchFlow(flow, tc.filter); got != tc.want {
+				t.Errorf("matchFlow() = %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
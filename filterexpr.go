@@ -0,0 +1,441 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// flowPredicate reports whether a flow matches a filter expression.
+type flowPredicate func(*mitmflowv1.Flow) bool
+
+// parseFilterExpr compiles a mitmproxy style filter expression such as
+// `~u /api & !~c 200` into a predicate. Supported operators:
+//
+//	~u regex   request URL          ~d regex   domain
+//	~m regex   request method       ~c code    response status code or class, e.g. 5xx
+//	~t regex   content type         ~h regex   request or response header
+//	~hq regex  request header       ~hs regex  response header
+//	~b regex   body                 ~bq regex  request body
+//	~bs regex  response body        ~comment regex  note
+//	~meta regex  metadata as key=value, e.g. ~meta ^build=12
+//	~hash regex  SHA-256 of the request or response body, in hex
+//	~q         request, no response ~s         has response
+//	~e         has error            ~marked    pinned
+//	~http ~tcp ~udp ~dns ~websocket flow type
+//	~geo CC|ASN server country code or ASN, e.g. ~geo DE, ~geo AS13335
+//	~sec level  security header finding at least this severe: info, low, medium
+//	~cors       cross-origin request or preflight that CORS would block
+//	~dnsa regex DNS anomaly, by kind or detail, e.g. ~dnsa nxdomain_burst
+//	~grpc regex gRPC status name or message, e.g. ~grpc unavailable
+//	~proto regex  protocol label, e.g. ~proto ^grpc, ~proto tcp-redis
+//	~jp path[=value]  JSON request or response body where a JSONPath selects
+//	            something, or a value equal to value, e.g. ~jp $.items[*].status=failed
+//	!  not     &  and               |  or      ( ) grouping
+//
+// Expressions next to each other are combined with and. A bare word matches
+// the same places as the search box in the UI. Regexes are case-insensitive.
+func parseFilterExpr(expr string) (flowPredicate, error) {
+	tokens, err := tokenizeFilterExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return func(*mitmflowv1.Flow) bool { return true }, nil
+	}
+	p := &filterParser{tokens: tokens}
+	pred, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in filter expression", p.tokens[p.pos].text)
+	}
+	return pred, nil
+}
+
+type filterToken struct {
+	text string
+	// quoted tokens are never treated as operators.
+	quoted bool
+}
+
+func tokenizeFilterExpr(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune("()!&|", r):
+			tokens = append(tokens, filterToken{text: string(r)})
+			i++
+		case r == '"' || r == '\'':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) && runes[j+1] == r {
+					j++
+				}
+				sb.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated quote in filter expression")
+			}
+			tokens = append(tokens, filterToken{text: sb.String(), quoted: true})
+			i = j + 1
+		default:
+			j := i
+			for j < len(runes) && !unicode.IsSpace(runes[j]) && !strings.ContainsRune("()", runes[j]) {
+				j++
+			}
+			tokens = append(tokens, filterToken{text: string(runes[i:j])})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() (filterToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return filterToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *filterParser) isOp(op string) bool {
+	tok, ok := p.peek()
+	return ok && !tok.quoted && tok.text == op
+}
+
+func (p *filterParser) parseOr() (flowPredicate, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("|") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(f *mitmflowv1.Flow) bool { return l(f) || right(f) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (flowPredicate, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if p.isOp("&") {
+			p.pos++
+		} else if _, ok := p.peek(); !ok || p.isOp("|") || p.isOp(")") {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(f *mitmflowv1.Flow) bool { return l(f) && right(f) }
+	}
+}
+
+func (p *filterParser) parseNot() (flowPredicate, error) {
+	if p.isOp("!") {
+		p.pos++
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return func(f *mitmflowv1.Flow) bool { return !inner(f) }, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (flowPredicate, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of filter expression")
+	}
+	p.pos++
+
+	if !tok.quoted {
+		switch {
+		case tok.text == "(":
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if !p.isOp(")") {
+				return nil, fmt.Errorf("missing ) in filter expression")
+			}
+			p.pos++
+			return inner, nil
+		case tok.text == ")" || tok.text == "&" || tok.text == "|":
+			return nil, fmt.Errorf("unexpected %q in filter expression", tok.text)
+		case strings.HasPrefix(tok.text, "~"):
+			return p.parseOperator(tok.text[1:])
+		}
+	}
+
+	text := tok.text
+	return func(f *mitmflowv1.Flow) bool { return matchText(f, text) }, nil
+}
+
+func (p *filterParser) parseOperator(name string) (flowPredicate, error) {
+	switch name {
+	case "q":
+		return func(f *mitmflowv1.Flow) bool {
+			h := f.GetHttpFlow()
+			return h != nil && h.HasRequest() && !h.HasResponse()
+		}, nil
+	case "s":
+		return func(f *mitmflowv1.Flow) bool { return f.GetHttpFlow().HasResponse() }, nil
+	case "e":
+		return func(f *mitmflowv1.Flow) bool { return flowError(f) != "" }, nil
+	case "marked":
+		return func(f *mitmflowv1.Flow) bool { return f.GetPinned() }, nil
+	case "http", "tcp", "udp", "dns":
+		return func(f *mitmflowv1.Flow) bool { return GetFlowType(f) == name }, nil
+	case "websocket":
+		return func(f *mitmflowv1.Flow) bool { return f.GetHttpFlow().GetIsWebsocket() }, nil
+	case "cors":
+		return func(f *mitmflowv1.Flow) bool { return len(f.GetHttpFlowExtra().GetCors().GetProblems()) > 0 }, nil
+	case "c":
+		arg, err := p.argument(name)
+		if err != nil {
+			return nil, err
+		}
+		if len(arg) == 3 && arg[0] >= '1' && arg[0] <= '5' && strings.EqualFold(arg[1:], "xx") {
+			class := int(arg[0] - '0')
+			return func(f *mitmflowv1.Flow) bool {
+				h := f.GetHttpFlow()
+				return h.HasResponse() && int(h.GetResponse().GetStatusCode())/100 == class
+			}, nil
+		}
+		code, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("~c expects a status code or class like 5xx, got %q", arg)
+		}
+		return func(f *mitmflowv1.Flow) bool {
+			h := f.GetHttpFlow()
+			return h.HasResponse() && int(h.GetResponse().GetStatusCode()) == code
+		}, nil
+	case "geo":
+		arg, err := p.argument(name)
+		if err != nil {
+			return nil, err
+		}
+		return func(f *mitmflowv1.Flow) bool { return matchGeo(flowServerGeo(f), arg) }, nil
+	case "sec":
+		arg, err := p.argument(name)
+		if err != nil {
+			return nil, err
+		}
+		severity, ok := mitmflowv1.FindingSeverity_value["FINDING_SEVERITY_"+strings.ToUpper(arg)]
+		if !ok || severity == 0 {
+			return nil, fmt.Errorf("~sec expects info, low or medium, got %q", arg)
+		}
+		return func(f *mitmflowv1.Flow) bool {
+			return maxFindingSeverity(f) >= mitmflowv1.FindingSeverity(severity)
+		}, nil
+	case "jp":
+		arg, err := p.argument(name)
+		if err != nil {
+			return nil, err
+		}
+		path, value, hasValue := strings.Cut(arg, "=")
+		if _, err := compileJSONPath(path); err != nil {
+			return nil, err
+		}
+		queries := make([]*mitmflowv1.BodyQuery, 2)
+		for i := range queries {
+			queries[i] = mitmflowv1.BodyQuery_builder{Path: proto.String(path), Request: proto.Bool(i == 1)}.Build()
+			if hasValue {
+				queries[i].SetEquals(value)
+			}
+		}
+		return func(f *mitmflowv1.Flow) bool {
+			return f.GetHttpFlow() != nil && (matchBodyQuery(f, queries[0]) || matchBodyQuery(f, queries[1]))
+		}, nil
+	}
+
+	var match func(f *mitmflowv1.Flow, re *regexp.Regexp) bool
+	switch name {
+	case "u":
+		match = func(f *mitmflowv1.Flow, re *regexp.Regexp) bool {
+			return re.MatchString(f.GetHttpFlow().GetRequest().GetUrl())
+		}
+	case "d":
+		match = func(f *mitmflowv1.Flow, re *regexp.Regexp) bool {
+			for _, d := range flowDomains(f) {
+				if re.MatchString(d) {
+					return true
+				}
+			}
+			return false
+		}
+	case "m":
+		match = func(f *mitmflowv1.Flow, re *regexp.Regexp) bool {
+			return re.MatchString(f.GetHttpFlow().GetRequest().GetMethod())
+		}
+	case "t":
+		match = func(f *mitmflowv1.Flow, re *regexp.Regexp) bool {
+			extra := f.GetHttpFlowExtra()
+			return re.MatchString(extra.GetRequest().GetEffectiveContentType()) ||
+				re.MatchString(extra.GetResponse().GetEffectiveContentType())
+		}
+	case "h", "hq", "hs":
+		match = func(f *mitmflowv1.Flow, re *regexp.Regexp) bool {
+			h := f.GetHttpFlow()
+			return (name != "hs" && matchHeaderRegexp(h.GetRequest().GetHeaders(), re)) ||
+				(name != "hq" && matchHeaderRegexp(h.GetResponse().GetHeaders(), re))
+		}
+	case "b", "bq", "bs":
+		match = func(f *mitmflowv1.Flow, re *regexp.Regexp) bool {
+			if h := f.GetHttpFlow(); h != nil {
+				return (name != "bs" && re.Match(h.GetRequest().GetContent())) ||
+					(name != "bq" && re.Match(h.GetResponse().GetContent()))
+			}
+			for _, m := range f.GetTcpFlow().GetMessages() {
+				if (name == "b" || m.GetFromClient() == (name == "bq")) && re.Match(m.GetContent()) {
+					return true
+				}
+			}
+			for _, m := range f.GetUdpFlow().GetMessages() {
+				if (name == "b" || m.GetFromClient() == (name == "bq")) && re.Match(m.GetContent()) {
+					return true
+				}
+			}
+			return false
+		}
+	case "comment":
+		match = func(f *mitmflowv1.Flow, re *regexp.Regexp) bool {
+			return re.MatchString(f.GetNote())
+		}
+	case "hash":
+		match = func(f *mitmflowv1.Flow, re *regexp.Regexp) bool {
+			extra := f.GetHttpFlowExtra()
+			return re.MatchString(extra.GetRequest().GetSha256()) || re.MatchString(extra.GetResponse().GetSha256())
+		}
+	case "grpc":
+		match = func(f *mitmflowv1.Flow, re *regexp.Regexp) bool {
+			status := f.GetHttpFlowExtra().GetResponse().GetGrpcStatus()
+			return status != nil && (re.MatchString(status.GetName()) || re.MatchString(status.GetMessage()))
+		}
+	case "proto":
+		match = func(f *mitmflowv1.Flow, re *regexp.Regexp) bool {
+			return re.MatchString(flowProtocol(f))
+		}
+	case "dnsa":
+		match = func(f *mitmflowv1.Flow, re *regexp.Regexp) bool {
+			for _, a := range f.GetDnsFlowExtra().GetAnomalies() {
+				kind := strings.TrimPrefix(a.GetKind().String(), "DNS_ANOMALY_KIND_")
+				if re.MatchString(kind) || re.MatchString(a.GetDetail()) {
+					return true
+				}
+			}
+			return false
+		}
+	case "meta":
+		match = func(f *mitmflowv1.Flow, re *regexp.Regexp) bool {
+			for k, v := range f.GetMetadata() {
+				if re.MatchString(k + "=" + v) {
+					return true
+				}
+			}
+			return false
+		}
+	default:
+		return nil, fmt.Errorf("unknown filter operator ~%s", name)
+	}
+
+	arg, err := p.argument(name)
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile("(?i)" + arg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex for ~%s: %w", name, err)
+	}
+	return func(f *mitmflowv1.Flow) bool { return match(f, re) }, nil
+}
+
+func (p *filterParser) argument(name string) (string, error) {
+	tok, ok := p.peek()
+	if !ok || (!tok.quoted && strings.ContainsAny(tok.text, "()!&|") && len(tok.text) == 1) {
+		return "", fmt.Errorf("~%s expects an argument", name)
+	}
+	p.pos++
+	return tok.text, nil
+}
+
+func matchHeaderRegexp(headers map[string]string, re *regexp.Regexp) bool {
+	var buf bytes.Buffer
+	for k, v := range headers {
+		buf.Reset()
+		buf.WriteString(k)
+		buf.WriteString(": ")
+		buf.WriteString(v)
+		if re.Match(buf.Bytes()) {
+			return true
+		}
+	}
+	return false
+}
+
+// flowDomains returns the host names a flow talked to.
+func flowDomains(flow *mitmflowv1.Flow) []string {
+	var domains []string
+	switch {
+	case flow.GetHttpFlow() != nil:
+		h := flow.GetHttpFlow()
+		domains = append(domains, h.GetServer().GetAddressHost(), h.GetServer().GetSni(),
+			flow.GetHttpFlowExtra().GetServerHostname())
+		if u, err := url.Parse(h.GetRequest().GetUrl()); err == nil {
+			domains = append(domains, u.Hostname())
+		}
+	case flow.GetDnsFlow() != nil:
+		for _, q := range flow.GetDnsFlow().GetRequest().GetQuestions() {
+			domains = append(domains, q.GetName())
+		}
+	case flow.GetTcpFlow() != nil:
+		domains = append(domains, flow.GetTcpFlow().GetServer().GetAddressHost(), flow.GetTcpFlow().GetServer().GetSni(),
+			flow.GetStreamFlowExtra().GetServerHostname())
+	case flow.GetUdpFlow() != nil:
+		domains = append(domains, flow.GetUdpFlow().GetServer().GetAddressHost(), flow.GetStreamFlowExtra().GetServerHostname())
+	}
+	return domains
+}
+
+func flowError(flow *mitmflowv1.Flow) string {
+	switch {
+	case flow.GetHttpFlow() != nil:
+		return flow.GetHttpFlow().GetError()
+	case flow.GetDnsFlow() != nil:
+		return flow.GetDnsFlow().GetError()
+	case flow.GetTcpFlow() != nil:
+		return flow.GetTcpFlow().GetError()
+	case flow.GetUdpFlow() != nil:
+		return flow.GetUdpFlow().GetError()
+	}
+	return ""
+}
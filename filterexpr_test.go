@@ -0,0 +1,79 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxygrpcv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseFilterExpr(t *testing.T) {
+	flow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxygrpcv1.HTTPFlow_builder{
+			Request: mitmproxygrpcv1.Request_builder{
+				Url:     proto.String("https://api.example.com/api/users?id=1"),
+				Method:  proto.String("POST"),
+				Headers: map[string]string{"Authorization": "Bearer abc"},
+				Content: []byte(`{"name":"gopher"}`),
+			}.Build(),
+			Response: mitmproxygrpcv1.Response_builder{
+				StatusCode: proto.Int32(500),
+				Content:    []byte("internal error"),
+			}.Build(),
+		}.Build(),
+		HttpFlowExtra: mitmflowv1.HTTPFlowExtra_builder{
+			Response: mitmflowv1.MessageDetails_builder{
+				EffectiveContentType: proto.String("text/plain"),
+			}.Build(),
+		}.Build(),
+		Note:     proto.String("flaky endpoint"),
+		Metadata: map[string]string{"build": "1234"},
+	}.Build()
+
+	tests := []struct {
+		expr  string
+		match bool
+	}{
+		{"", true},
+		{"~u /api", true},
+		{"~u /other", false},
+		{"~c 500", true},
+		{"~c 5xx", true},
+		{"~c 2XX", false},
+		{"!~c 500", false},
+		{"~m post & ~d example.com", true},
+		{"~m get | ~c 500", true},
+		{"~m get | ~c 200", false},
+		{"~u /api ~c 200", false},
+		{"~hq authorization", true},
+		{"~hs authorization", false},
+		{"~bq gopher", true},
+		{"~bs gopher", false},
+		{"~t text/plain", true},
+		{"~http !~tcp", true},
+		{"~s & !~q", true},
+		{"~comment 'flaky endpoint'", true},
+		{"~meta ^build=12", true},
+		{"~meta ^ticket=", false},
+		{"!(~c 500 | ~c 502)", false},
+		{"users", true},
+		{"missing", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			pred, err := parseFilterExpr(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.match, pred(flow))
+		})
+	}
+}
+
+func TestParseFilterExpr_Errors(t *testing.T) {
+	for _, expr := range []string{"~c", "~c abc", "~nope x", "(~c 500", "~u [", "& ~c 500", "'unterminated"} {
+		_, err := parseFilterExpr(expr)
+		assert.Error(t, err, expr)
+	}
+}
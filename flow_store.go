@@ -3,6 +3,7 @@ package main
 import (
 	"sort"
 	"sync"
+	"sync/atomic"
 
 	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
 )
@@ -11,22 +12,27 @@ import (
 type Store interface {
 	// Upsert adds or updates a flow in the store.
 	Upsert(flow *mitmflowv1.Flow)
+	// UpsertBatch adds or updates many flows at once, sorting a single time
+	// afterwards. It is much cheaper than repeated Upserts of unordered flows.
+	UpsertBatch(flows []*mitmflowv1.Flow)
 	// Get retrieves a flow by its ID.
 	Get(id string) (*mitmflowv1.Flow, bool)
-	// List returns all flows in the store, sorted by start time.
-	List() []*mitmflowv1.Flow
 	// Delete removes flows with the given IDs and returns the IDs of the flows that were actually removed.
 	Delete(ids ...string) []string
-	// DeleteAllUnpinned removes all unpinned flows and returns their IDs.
+	// DeleteAllUnpinned removes all flows that neither the team nor any user
+	// has pinned and returns their IDs.
 	DeleteAllUnpinned() []string
-	// Prune removes the oldest unpinned flows if the store size exceeds maxSize.
-	// It returns the IDs of the removed flows.
-	Prune(maxSize int) []string
+	// Prune removes the oldest flows that keep doesn't retain while the store
+	// size exceeds maxSize, as well as any of them that started before
+	// minStart (Unix nanoseconds, 0 for no limit). It returns the IDs of the
+	// removed flows.
+	Prune(maxSize int, minStart int64, keep func(*mitmflowv1.Flow) bool) []string
 	// Len returns the number of flows in the store.
 	Len() int
 	// Walk iterates over all flows in the store, sorted by start time (oldest first).
 	// It calls the given function for each flow.
 	// If the function returns false, iteration stops.
+	// Walks see the flows as they were when the walk started.
 	Walk(func(*mitmflowv1.Flow) bool)
 	// ReverseWalk iterates over all flows in the store, sorted by start time (newest first).
 	// It calls the given function for each flow.
@@ -34,18 +40,33 @@ type Store interface {
 	ReverseWalk(func(*mitmflowv1.Flow) bool)
 }
 
+// memoryStore keeps flows in a map for lookups plus a sorted slice for ordered
+// iteration. The sorted slice is copy-on-write: writers build a new slice and
+// swap it in atomically, so readers can walk a snapshot without copying or
+// holding the lock.
 type memoryStore struct {
 	mu          sync.RWMutex
 	flows       map[string]*mitmflowv1.Flow
-	sortedFlows []*mitmflowv1.Flow
+	sortedFlows atomic.Pointer[[]*mitmflowv1.Flow]
 }
 
 // NewMemoryStore creates a new in-memory flow store.
 func NewMemoryStore() Store {
-	return &memoryStore{
-		flows:       make(map[string]*mitmflowv1.Flow),
-		sortedFlows: make([]*mitmflowv1.Flow, 0),
+	s := &memoryStore{
+		flows: make(map[string]*mitmflowv1.Flow),
 	}
+	s.setSorted(make([]*mitmflowv1.Flow, 0))
+	return s
+}
+
+// sorted returns the current snapshot of flows sorted by start time. The
+// returned slice must not be modified.
+func (s *memoryStore) sorted() []*mitmflowv1.Flow {
+	return *s.sortedFlows.Load()
+}
+
+func (s *memoryStore) setSorted(flows []*mitmflowv1.Flow) {
+	s.sortedFlows.Store(&flows)
 }
 
 func (s *memoryStore) Upsert(flow *mitmflowv1.Flow) {
@@ -57,13 +78,29 @@ func (s *memoryStore) Upsert(flow *mitmflowv1.Flow) {
 		return
 	}
 
-	isUpdate := false
-	if _, ok := s.flows[id]; ok {
-		isUpdate = true
+	_, isUpdate := s.flows[id]
+	s.flows[id] = flow
+	s.setSorted(upsertSorted(s.sorted(), flow, isUpdate))
+}
+
+func (s *memoryStore) UpsertBatch(flows []*mitmflowv1.Flow) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, flow := range flows {
+		if id := GetFlowID(flow); id != "" {
+			s.flows[id] = flow
+		}
 	}
 
-	s.flows[id] = flow
-	s.updateSortedFlows(flow, isUpdate)
+	sortedFlows := make([]*mitmflowv1.Flow, 0, len(s.flows))
+	for _, flow := range s.flows {
+		sortedFlows = append(sortedFlows, flow)
+	}
+	sort.Slice(sortedFlows, func(i, j int) bool {
+		return GetFlowStartTime(sortedFlows[i]) < GetFlowStartTime(sortedFlows[j])
+	})
+	s.setSorted(sortedFlows)
 }
 
 func (s *memoryStore) Get(id string) (*mitmflowv1.Flow, bool) {
@@ -74,15 +111,6 @@ func (s *memoryStore) Get(id string) (*mitmflowv1.Flow, bool) {
 	return flow, ok
 }
 
-func (s *memoryStore) List() []*mitmflowv1.Flow {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	flows := make([]*mitmflowv1.Flow, len(s.sortedFlows))
-	copy(flows, s.sortedFlows)
-	return flows
-}
-
 func (s *memoryStore) Delete(ids ...string) []string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -99,7 +127,7 @@ func (s *memoryStore) Delete(ids ...string) []string {
 	}
 
 	if len(deleted) > 0 {
-		s.rebuildSortedFlows(toDelete)
+		s.setSorted(withoutFlows(s.sorted(), toDelete))
 	}
 
 	return deleted
@@ -113,7 +141,7 @@ func (s *memoryStore) DeleteAllUnpinned() []string {
 	toDelete := make(map[string]bool)
 
 	for id, flow := range s.flows {
-		if !flow.GetPinned() {
+		if !pinnedByAnyone(flow) {
 			delete(s.flows, id)
 			toDelete[id] = true
 			deleted = append(deleted, id)
@@ -121,47 +149,36 @@ func (s *memoryStore) DeleteAllUnpinned() []string {
 	}
 
 	if len(deleted) > 0 {
-		s.rebuildSortedFlows(toDelete)
+		s.setSorted(withoutFlows(s.sorted(), toDelete))
 	}
 
 	return deleted
 }
 
-func (s *memoryStore) Prune(maxSize int) []string {
+func (s *memoryStore) Prune(maxSize int, minStart int64, keep func(*mitmflowv1.Flow) bool) []string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if len(s.flows) <= maxSize {
+	sorted := s.sorted()
+	if len(s.flows) <= maxSize && (len(sorted) == 0 || GetFlowStartTime(sorted[0]) >= minStart) {
 		return nil
 	}
 
 	toRemove := len(s.flows) - maxSize
-	removedCount := 0
+	kept := make([]*mitmflowv1.Flow, 0, min(len(sorted), maxSize))
 	var deleted []string
 
-	// Filter in-place to avoid allocating a new slice
-	newLen := 0
-	for i, f := range s.sortedFlows {
-		if removedCount < toRemove && !f.GetPinned() {
+	for _, f := range sorted {
+		if (len(deleted) < toRemove || GetFlowStartTime(f) < minStart) && !keep(f) {
 			id := GetFlowID(f)
 			delete(s.flows, id)
 			deleted = append(deleted, id)
-			removedCount++
 			continue
 		}
-
-		if newLen != i {
-			s.sortedFlows[newLen] = f
-		}
-		newLen++
-	}
-
-	// Nil out the remaining elements to avoid memory leaks
-	for i := newLen; i < len(s.sortedFlows); i++ {
-		s.sortedFlows[i] = nil
+		kept = append(kept, f)
 	}
 
-	s.sortedFlows = s.sortedFlows[:newLen]
+	s.setSorted(kept)
 	return deleted
 }
 
@@ -172,10 +189,7 @@ func (s *memoryStore) Len() int {
 }
 
 func (s *memoryStore) Walk(fn func(*mitmflowv1.Flow) bool) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	for _, f := range s.sortedFlows {
+	for _, f := range s.sorted() {
 		if !fn(f) {
 			break
 		}
@@ -183,60 +197,50 @@ func (s *memoryStore) Walk(fn func(*mitmflowv1.Flow) bool) {
 }
 
 func (s *memoryStore) ReverseWalk(fn func(*mitmflowv1.Flow) bool) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	for i := len(s.sortedFlows) - 1; i >= 0; i-- {
-		if !fn(s.sortedFlows[i]) {
+	sorted := s.sorted()
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if !fn(sorted[i]) {
 			break
 		}
 	}
 }
 
-func (s *memoryStore) updateSortedFlows(flow *mitmflowv1.Flow, isUpdate bool) {
+// upsertSorted returns a copy of sorted with flow inserted in start time
+// order, replacing the previous version of the flow when isUpdate is set.
+// Appending a flow newer than every other flow reuses the backing array: the
+// slot past the end is invisible to existing snapshots, so they are unaffected.
+func upsertSorted(sorted []*mitmflowv1.Flow, flow *mitmflowv1.Flow, isUpdate bool) []*mitmflowv1.Flow {
 	if isUpdate {
-		id := GetFlowID(flow)
-		s.removeFromSortedFlows(id)
+		sorted = withoutFlows(sorted, map[string]bool{GetFlowID(flow): true})
 	}
-	s.insertIntoSortedFlows(flow)
-}
 
-func (s *memoryStore) removeFromSortedFlows(id string) {
-	for i, f := range s.sortedFlows {
-		if GetFlowID(f) == id {
-			s.sortedFlows = append(s.sortedFlows[:i], s.sortedFlows[i+1:]...)
-			return
-		}
-	}
-}
-
-func (s *memoryStore) insertIntoSortedFlows(flow *mitmflowv1.Flow) {
 	newTime := GetFlowStartTime(flow)
 	// Optimization: check last
-	if len(s.sortedFlows) == 0 || newTime >= GetFlowStartTime(s.sortedFlows[len(s.sortedFlows)-1]) {
-		s.sortedFlows = append(s.sortedFlows, flow)
-		return
+	if len(sorted) == 0 || newTime >= GetFlowStartTime(sorted[len(sorted)-1]) {
+		return append(sorted, flow)
 	}
 
 	// Binary search
-	index := sort.Search(len(s.sortedFlows), func(i int) bool {
-		return GetFlowStartTime(s.sortedFlows[i]) >= newTime
+	index := sort.Search(len(sorted), func(i int) bool {
+		return GetFlowStartTime(sorted[i]) >= newTime
 	})
 
-	s.sortedFlows = append(s.sortedFlows, nil)           // Extend capacity
-	copy(s.sortedFlows[index+1:], s.sortedFlows[index:]) // Shift
-	s.sortedFlows[index] = flow
+	result := make([]*mitmflowv1.Flow, 0, len(sorted)+1)
+	result = append(result, sorted[:index]...)
+	result = append(result, flow)
+	return append(result, sorted[index:]...)
 }
 
-func (s *memoryStore) rebuildSortedFlows(toDelete map[string]bool) {
-	newSortedFlows := make([]*mitmflowv1.Flow, 0, len(s.sortedFlows)-len(toDelete))
-	for _, f := range s.sortedFlows {
-		id := GetFlowID(f)
-		if !toDelete[id] {
-			newSortedFlows = append(newSortedFlows, f)
+// withoutFlows returns a new slice holding the flows of sorted whose IDs are
+// not in toDelete.
+func withoutFlows(sorted []*mitmflowv1.Flow, toDelete map[string]bool) []*mitmflowv1.Flow {
+	result := make([]*mitmflowv1.Flow, 0, max(len(sorted)-len(toDelete), 0))
+	for _, f := range sorted {
+		if !toDelete[GetFlowID(f)] {
+			result = append(result, f)
 		}
 	}
-	s.sortedFlows = newSortedFlows
+	return result
 }
 
 // GetFlowID returns the ID of the flow.
@@ -259,6 +263,21 @@ func GetFlowID(flow *mitmflowv1.Flow) string {
 	return ""
 }
 
+// GetFlowType returns the kind of flow: "http", "dns", "tcp" or "udp".
+func GetFlowType(flow *mitmflowv1.Flow) string {
+	switch {
+	case flow.GetHttpFlow() != nil:
+		return "http"
+	case flow.GetDnsFlow() != nil:
+		return "dns"
+	case flow.GetTcpFlow() != nil:
+		return "tcp"
+	case flow.GetUdpFlow() != nil:
+		return "udp"
+	}
+	return ""
+}
+
 // GetFlowStartTime returns the start timestamp of the flow in nanoseconds.
 func GetFlowStartTime(flow *mitmflowv1.Flow) int64 {
 	if f := flow.GetHttpFlow(); f != nil {
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+var storeImplementations = map[string]func() Store{
+	"memory":  NewMemoryStore,
+	"sharded": NewShardedStore,
+}
+
+// listFlows collects the flows of a store, oldest first.
+func listFlows(store Store) []*mitmflowv1.Flow {
+	var flows []*mitmflowv1.Flow
+	store.Walk(func(flow *mitmflowv1.Flow) bool {
+		flows = append(flows, flow)
+		return true
+	})
+	return flows
+}
+
+func TestStore_WalkSnapshot(t *testing.T) {
+	for name, newStore := range storeImplementations {
+		t.Run(name, func(t *testing.T) {
+			store := newStore()
+			now := time.Now()
+			store.Upsert(createFlow("flow-2", now.Add(2*time.Second)))
+			store.Upsert(createFlow("flow-3", now.Add(3*time.Second)))
+
+			// Changes made during a walk don't affect it.
+			var walked []*mitmflowv1.Flow
+			store.Walk(func(flow *mitmflowv1.Flow) bool {
+				if len(walked) == 0 {
+					store.Upsert(createFlow("flow-4", now.Add(4*time.Second)))
+					store.Upsert(createFlow("flow-1", now.Add(1*time.Second)))
+					store.Delete("flow-3")
+					store.Prune(2, 0, (*mitmflowv1.Flow).GetPinned)
+				}
+				walked = append(walked, flow)
+				return true
+			})
+
+			assert.Equal(t, []string{"flow-2", "flow-3"}, flowIDs(walked))
+			assert.Equal(t, []string{"flow-2", "flow-4"}, flowIDs(listFlows(store)))
+			assert.Equal(t, 2, store.Len())
+		})
+	}
+}
+
+func TestStore_Upsert(t *testing.T) {
+	for name, newStore := range storeImplementations {
+		t.Run(name, func(t *testing.T) {
+			store := newStore()
+			now := time.Now()
+			store.UpsertBatch([]*mitmflowv1.Flow{
+				createFlow("flow-3", now.Add(3*time.Second)),
+				createFlow("flow-1", now.Add(1*time.Second)),
+				createFlow("flow-2", now.Add(2*time.Second)),
+				createFlow("flow-1", now.Add(5*time.Second)),
+			})
+			assert.Equal(t, []string{"flow-2", "flow-3", "flow-1"}, flowIDs(listFlows(store)))
+
+			// Updating in place keeps the position; a new start time moves it.
+			updated := createFlow("flow-2", now.Add(2*time.Second))
+			updated.SetNote("updated")
+			store.Upsert(updated)
+			store.Upsert(createFlow("flow-3", now))
+			assert.Equal(t, []string{"flow-3", "flow-2", "flow-1"}, flowIDs(listFlows(store)))
+			assert.Equal(t, 3, store.Len())
+
+			got, ok := store.Get("flow-2")
+			assert.True(t, ok)
+			assert.Equal(t, "updated", got.GetNote())
+
+			pinned := createFlow("flow-1", now.Add(5*time.Second))
+			pinned.SetPinned(true)
+			store.Upsert(pinned)
+			assert.ElementsMatch(t, []string{"flow-2", "flow-3"}, store.DeleteAllUnpinned())
+			assert.Equal(t, []string{"flow-1"}, flowIDs(listFlows(store)))
+			assert.Equal(t, 1, store.Len())
+		})
+	}
+}
+
+// BenchmarkStore_Concurrent mixes ingest of new flows, updates of existing
+// flows (responses arriving), lookups, and listing, as happens when the UI is
+// open against a busy proxy.
+func BenchmarkStore_Concurrent(b *testing.B) {
+	for name, newStore := range storeImplementations {
+		b.Run(name, func(b *testing.B) {
+			store := newStore()
+			start := time.Now()
+			const preload = 10000
+			for i := range preload {
+				store.Upsert(createFlow(fmt.Sprintf("flow-%d", i), start.Add(time.Duration(i)*time.Millisecond)))
+			}
+			var next atomic.Int64
+			next.Store(preload)
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					i++
+					switch i % 4 {
+					case 0:
+						n := next.Add(1)
+						store.Upsert(createFlow(fmt.Sprintf("flow-%d", n), start.Add(time.Duration(n)*time.Millisecond)))
+					case 1:
+						n := next.Load() - int64(i%100)
+						store.Upsert(createFlow(fmt.Sprintf("flow-%d", n), start.Add(time.Duration(n)*time.Millisecond)))
+					case 2:
+						store.Get(fmt.Sprintf("flow-%d", i%preload))
+					case 3:
+						count := 0
+						store.ReverseWalk(func(*mitmflowv1.Flow) bool {
+							count++
+							return count < 100
+						})
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"mime"
+	"net/url"
+	"strings"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxFormFields bounds how many fields of a form body are decoded.
+const maxFormFields = 1000
+
+func isFormMediaType(headers map[string]string) bool {
+	mediaType, _, _ := mime.ParseMediaType(getHeaderValue(headers, "Content-Type"))
+	return mediaType == "application/x-www-form-urlencoded"
+}
+
+// setFormFields decodes an application/x-www-form-urlencoded body into
+// details.
+func setFormFields(content []byte, headers map[string]string, details *mitmflowv1.MessageDetails) {
+	if len(content) == 0 || !isFormMediaType(headers) {
+		return
+	}
+	if decoded, ok := decodeContentEncoding(content, getHeaderValue(headers, "Content-Encoding")); ok {
+		content = decoded
+	}
+	details.SetFormFields(parseFormFields(string(content)))
+}
+
+// parseFormFields splits a form body into its fields in order, unlike
+// url.ParseQuery. A name or value that isn't validly escaped is kept as it
+// was sent rather than dropping the field.
+func parseFormFields(body string) []*mitmflowv1.FormField {
+	var fields []*mitmflowv1.FormField
+	for pair := range strings.SplitSeq(strings.TrimSpace(body), "&") {
+		if pair == "" {
+			continue
+		}
+		if len(fields) == maxFormFields {
+			break
+		}
+		name, value, _ := strings.Cut(pair, "=")
+		fields = append(fields, mitmflowv1.FormField_builder{
+			Name:  proto.String(unescapeFormValue(name)),
+			Value: proto.String(unescapeFormValue(value)),
+		}.Build())
+	}
+	return fields
+}
+
+func unescapeFormValue(s string) string {
+	if unescaped, err := url.QueryUnescape(s); err == nil {
+		return unescaped
+	}
+	return s
+}
+
+// harFormParams decodes a form body into HAR postData params. body must
+// already be decoded from its Content-Encoding.
+func harFormParams(headers map[string]string, body []byte) []HARPostDataParam {
+	if !isFormMediaType(headers) {
+		return nil
+	}
+	fields := parseFormFields(string(body))
+	if len(fields) == 0 {
+		return nil
+	}
+	params := make([]HARPostDataParam, len(fields))
+	for i, f := range fields {
+		params[i] = HARPostDataParam{Name: f.GetName(), Value: f.GetValue()}
+	}
+	return params
+}
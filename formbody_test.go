@@ -0,0 +1,58 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSetFormFields(t *testing.T) {
+	type field struct{ name, value string }
+	fields := func(details *mitmflowv1.MessageDetails) []field {
+		var out []field
+		for _, f := range details.GetFormFields() {
+			out = append(out, field{f.GetName(), f.GetValue()})
+		}
+		return out
+	}
+
+	details := &mitmflowv1.MessageDetails{}
+	setFormFields([]byte("user=ann+lee&tag=a&tag=b%26c&empty=&flag&&bad=%zz\n"),
+		map[string]string{"Content-Type": "application/x-www-form-urlencoded; charset=UTF-8"}, details)
+	assert.Equal(t, []field{
+		{"user", "ann lee"},
+		{"tag", "a"},
+		{"tag", "b&c"},
+		{"empty", ""},
+		{"flag", ""},
+		{"bad", "%zz"},
+	}, fields(details))
+
+	details = &mitmflowv1.MessageDetails{}
+	setFormFields([]byte("user=ann"), map[string]string{"Content-Type": "text/plain"}, details)
+	assert.Empty(t, details.GetFormFields())
+}
+
+func TestGenerateHAR_FormParams(t *testing.T) {
+	flow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Request: mitmproxyv1.Request_builder{
+				Method:  proto.String("POST"),
+				Url:     proto.String("https://example.com/login"),
+				Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+				Content: []byte("user=ann%40example.com&remember=on"),
+			}.Build(),
+			Response: mitmproxyv1.Response_builder{StatusCode: proto.Int32(302)}.Build(),
+		}.Build(),
+	}.Build()
+
+	entry := generateHAREntry(t, flow)
+	assert.Equal(t, "user=ann%40example.com&remember=on", entry.Request.PostData.Text)
+	assert.Equal(t, []HARPostDataParam{
+		{Name: "user", Value: "ann@example.com"},
+		{Name: "remember", Value: "on"},
+	}, entry.Request.PostData.Params)
+}
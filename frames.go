@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// framePageSize is how many textual frames of a request or response GetFlow
+// returns, and GetFlowFrames returns by default. Streaming RPCs can have
+// thousands, which the UI pages through instead of receiving at once.
+const framePageSize = 100
+
+// GetFlowFrames returns a page of the textual frames of a flow's request or
+// response.
+func (s *MITMFlowServer) GetFlowFrames(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.GetFlowFramesRequest],
+) (*connect.Response[mitmflowv1.GetFlowFramesResponse], error) {
+	id := req.Msg.GetFlowId()
+	flow, ok := s.visibleFlow(ctx, id)
+	if !ok || flow.GetHttpFlow() == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("http flow not found: %s", id))
+	}
+	details := flow.GetHttpFlowExtra().GetRequest()
+	if req.Msg.GetResponse() {
+		details = flow.GetHttpFlowExtra().GetResponse()
+	}
+	limit := int(req.Msg.GetLimit())
+	if limit <= 0 {
+		limit = framePageSize
+	}
+
+	frames := details.GetTextualFrames()
+	start := min(int(req.Msg.GetOffset()), len(frames))
+	end := min(start+limit, len(frames))
+	var timestamps []int64
+	if ts := details.GetFrameTimestampsNs(); start < len(ts) {
+		timestamps = ts[start:min(end, len(ts))]
+	}
+	return connect.NewResponse(mitmflowv1.GetFlowFramesResponse_builder{
+		Frames:            frames[start:end],
+		FrameTimestampsNs: timestamps,
+		Total:             proto.Int32(int32(len(frames))),
+	}.Build()), nil
+}
+
+// pageFrames returns the flow with the frames of its request and response
+// cut down to their first page, recording how many there are. The flow is
+// copied when it has to be cut, so stored flows are never modified.
+func pageFrames(flow *mitmflowv1.Flow) *mitmflowv1.Flow {
+	extra := flow.GetHttpFlowExtra()
+	if len(extra.GetRequest().GetTextualFrames()) <= framePageSize && len(extra.GetResponse().GetTextualFrames()) <= framePageSize {
+		return flow
+	}
+	flow = proto.CloneOf(flow)
+	for _, details := range []*mitmflowv1.MessageDetails{flow.GetHttpFlowExtra().GetRequest(), flow.GetHttpFlowExtra().GetResponse()} {
+		frames := details.GetTextualFrames()
+		if len(frames) <= framePageSize {
+			continue
+		}
+		details.SetFrameCount(int32(len(frames)))
+		details.SetTextualFrames(frames[:framePageSize])
+		if ts := details.GetFrameTimestampsNs(); len(ts) > framePageSize {
+			details.SetFrameTimestampsNs(ts[:framePageSize])
+		}
+	}
+	return flow
+}
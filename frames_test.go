@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetFlowFrames(t *testing.T) {
+	server, storage := newTestServer(t)
+	frames := make([]string, 250)
+	timestamps := make([]int64, 250)
+	for i := range frames {
+		frames[i] = fmt.Sprintf("message %d", i)
+		timestamps[i] = int64(i)
+	}
+	require.NoError(t, storage.SaveFlow(mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Id:       proto.String("f1"),
+			Request:  mitmproxyv1.Request_builder{Method: proto.String("POST"), Url: proto.String("https://example.com/svc/Watch")}.Build(),
+			Response: mitmproxyv1.Response_builder{StatusCode: proto.Int32(200)}.Build(),
+		}.Build(),
+		HttpFlowExtra: mitmflowv1.HTTPFlowExtra_builder{
+			Request:  mitmflowv1.MessageDetails_builder{TextualFrames: []string{"watch"}}.Build(),
+			Response: mitmflowv1.MessageDetails_builder{TextualFrames: frames, FrameTimestampsNs: timestamps}.Build(),
+		}.Build(),
+	}.Build()))
+
+	res, err := server.GetFlow(context.Background(), connect.NewRequest(mitmflowv1.GetFlowRequest_builder{FlowId: proto.String("f1")}.Build()))
+	require.NoError(t, err)
+	response := res.Msg.GetFlow().GetHttpFlowExtra().GetResponse()
+	assert.Len(t, response.GetTextualFrames(), framePageSize)
+	assert.Len(t, response.GetFrameTimestampsNs(), framePageSize)
+	assert.Equal(t, int32(250), response.GetFrameCount())
+	assert.Equal(t, []string{"watch"}, res.Msg.GetFlow().GetHttpFlowExtra().GetRequest().GetTextualFrames())
+	assert.Zero(t, res.Msg.GetFlow().GetHttpFlowExtra().GetRequest().GetFrameCount())
+	stored, _ := storage.GetFlow("f1")
+	assert.Len(t, stored.GetHttpFlowExtra().GetResponse().GetTextualFrames(), 250, "the stored flow keeps every frame")
+
+	page, err := server.GetFlowFrames(context.Background(), connect.NewRequest(mitmflowv1.GetFlowFramesRequest_builder{
+		FlowId:   proto.String("f1"),
+		Response: proto.Bool(true),
+		Offset:   proto.Int32(200),
+	}.Build()))
+	require.NoError(t, err)
+	assert.Len(t, page.Msg.GetFrames(), 50)
+	assert.Equal(t, "message 200", page.Msg.GetFrames()[0])
+	assert.Equal(t, int64(249), page.Msg.GetFrameTimestampsNs()[49])
+	assert.Equal(t, int32(250), page.Msg.GetTotal())
+
+	page, err = server.GetFlowFrames(context.Background(), connect.NewRequest(mitmflowv1.GetFlowFramesRequest_builder{
+		FlowId: proto.String("f1"),
+		Offset: proto.Int32(5),
+		Limit:  proto.Int32(10),
+	}.Build()))
+	require.NoError(t, err)
+	assert.Empty(t, page.Msg.GetFrames())
+	assert.Equal(t, int32(1), page.Msg.GetTotal())
+
+	_, err = server.GetFlowFrames(context.Background(), connect.NewRequest(mitmflowv1.GetFlowFramesRequest_builder{FlowId: proto.String("missing")}.Build()))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
@@ -44,6 +44,60 @@ const (
 	ServiceExportFlowsProcedure = "/mitmflow.v1.Service/ExportFlows"
 	// ServiceGetFlowProcedure is the fully-qualified name of the Service's GetFlow RPC.
 	ServiceGetFlowProcedure = "/mitmflow.v1.Service/GetFlow"
+	// ServiceGetFlowBodyProcedure is the fully-qualified name of the Service's GetFlowBody RPC.
+	ServiceGetFlowBodyProcedure = "/mitmflow.v1.Service/GetFlowBody"
+	// ServiceGetFlowFramesProcedure is the fully-qualified name of the Service's GetFlowFrames RPC.
+	ServiceGetFlowFramesProcedure = "/mitmflow.v1.Service/GetFlowFrames"
+	// ServiceImportFlowsProcedure is the fully-qualified name of the Service's ImportFlows RPC.
+	ServiceImportFlowsProcedure = "/mitmflow.v1.Service/ImportFlows"
+	// ServiceCreateBackupProcedure is the fully-qualified name of the Service's CreateBackup RPC.
+	ServiceCreateBackupProcedure = "/mitmflow.v1.Service/CreateBackup"
+	// ServiceRestoreBackupProcedure is the fully-qualified name of the Service's RestoreBackup RPC.
+	ServiceRestoreBackupProcedure = "/mitmflow.v1.Service/RestoreBackup"
+	// ServiceSearchArchiveProcedure is the fully-qualified name of the Service's SearchArchive RPC.
+	ServiceSearchArchiveProcedure = "/mitmflow.v1.Service/SearchArchive"
+	// ServiceRestoreArchivedFlowsProcedure is the fully-qualified name of the Service's
+	// RestoreArchivedFlows RPC.
+	ServiceRestoreArchivedFlowsProcedure = "/mitmflow.v1.Service/RestoreArchivedFlows"
+	// ServiceRestoreFlowsProcedure is the fully-qualified name of the Service's RestoreFlows RPC.
+	ServiceRestoreFlowsProcedure = "/mitmflow.v1.Service/RestoreFlows"
+	// ServiceGetServerInfoProcedure is the fully-qualified name of the Service's GetServerInfo RPC.
+	ServiceGetServerInfoProcedure = "/mitmflow.v1.Service/GetServerInfo"
+	// ServiceListSubscribersProcedure is the fully-qualified name of the Service's ListSubscribers RPC.
+	ServiceListSubscribersProcedure = "/mitmflow.v1.Service/ListSubscribers"
+	// ServiceListAuditEventsProcedure is the fully-qualified name of the Service's ListAuditEvents RPC.
+	ServiceListAuditEventsProcedure = "/mitmflow.v1.Service/ListAuditEvents"
+	// ServiceSendRequestProcedure is the fully-qualified name of the Service's SendRequest RPC.
+	ServiceSendRequestProcedure = "/mitmflow.v1.Service/SendRequest"
+	// ServiceGetCookieTimelineProcedure is the fully-qualified name of the Service's GetCookieTimeline
+	// RPC.
+	ServiceGetCookieTimelineProcedure = "/mitmflow.v1.Service/GetCookieTimeline"
+	// ServiceGetRedirectChainProcedure is the fully-qualified name of the Service's GetRedirectChain
+	// RPC.
+	ServiceGetRedirectChainProcedure = "/mitmflow.v1.Service/GetRedirectChain"
+	// ServiceCreateShareBundleProcedure is the fully-qualified name of the Service's CreateShareBundle
+	// RPC.
+	ServiceCreateShareBundleProcedure = "/mitmflow.v1.Service/CreateShareBundle"
+	// ServiceSetBaselineProcedure is the fully-qualified name of the Service's SetBaseline RPC.
+	ServiceSetBaselineProcedure = "/mitmflow.v1.Service/SetBaseline"
+	// ServiceCompareSessionsProcedure is the fully-qualified name of the Service's CompareSessions RPC.
+	ServiceCompareSessionsProcedure = "/mitmflow.v1.Service/CompareSessions"
+	// ServiceUploadFlowBodyProcedure is the fully-qualified name of the Service's UploadFlowBody RPC.
+	ServiceUploadFlowBodyProcedure = "/mitmflow.v1.Service/UploadFlowBody"
+	// ServiceDiffWithPreviousProcedure is the fully-qualified name of the Service's DiffWithPrevious
+	// RPC.
+	ServiceDiffWithPreviousProcedure = "/mitmflow.v1.Service/DiffWithPrevious"
+	// ServiceTopFlowsProcedure is the fully-qualified name of the Service's TopFlows RPC.
+	ServiceTopFlowsProcedure = "/mitmflow.v1.Service/TopFlows"
+	// ServiceGetBandwidthStatsProcedure is the fully-qualified name of the Service's GetBandwidthStats
+	// RPC.
+	ServiceGetBandwidthStatsProcedure = "/mitmflow.v1.Service/GetBandwidthStats"
+	// ServiceGetProxyRulesProcedure is the fully-qualified name of the Service's GetProxyRules RPC.
+	ServiceGetProxyRulesProcedure = "/mitmflow.v1.Service/GetProxyRules"
+	// ServiceSetProxyRulesProcedure is the fully-qualified name of the Service's SetProxyRules RPC.
+	ServiceSetProxyRulesProcedure = "/mitmflow.v1.Service/SetProxyRules"
+	// ServiceWatchProxyRulesProcedure is the fully-qualified name of the Service's WatchProxyRules RPC.
+	ServiceWatchProxyRulesProcedure = "/mitmflow.v1.Service/WatchProxyRules"
 )
 
 // ServiceClient is a client for the mitmflow.v1.Service service.
@@ -54,6 +108,54 @@ type ServiceClient interface {
 	DeleteFlows(context.Context, *connect.Request[DeleteFlowsRequest]) (*connect.Response[DeleteFlowsResponse], error)
 	ExportFlows(context.Context, *connect.Request[ExportFlowsRequest]) (*connect.Response[ExportFlowsResponse], error)
 	GetFlow(context.Context, *connect.Request[GetFlowRequest]) (*connect.Response[GetFlowResponse], error)
+	GetFlowBody(context.Context, *connect.Request[GetFlowBodyRequest]) (*connect.Response[GetFlowBodyResponse], error)
+	// Pages through the textual frames of a request or response, for
+	// streaming bodies with more frames than GetFlow returns.
+	GetFlowFrames(context.Context, *connect.Request[GetFlowFramesRequest]) (*connect.Response[GetFlowFramesResponse], error)
+	ImportFlows(context.Context, *connect.Request[ImportFlowsRequest]) (*connect.Response[ImportFlowsResponse], error)
+	CreateBackup(context.Context, *connect.Request[CreateBackupRequest]) (*connect.ServerStreamForClient[CreateBackupResponse], error)
+	RestoreBackup(context.Context, *connect.Request[RestoreBackupRequest]) (*connect.Response[RestoreBackupResponse], error)
+	SearchArchive(context.Context, *connect.Request[SearchArchiveRequest]) (*connect.ServerStreamForClient[SearchArchiveResponse], error)
+	RestoreArchivedFlows(context.Context, *connect.Request[RestoreArchivedFlowsRequest]) (*connect.Response[RestoreArchivedFlowsResponse], error)
+	RestoreFlows(context.Context, *connect.Request[RestoreFlowsRequest]) (*connect.Response[RestoreFlowsResponse], error)
+	GetServerInfo(context.Context, *connect.Request[GetServerInfoRequest]) (*connect.Response[GetServerInfoResponse], error)
+	// ListSubscribers describes the clients currently streaming flows, for
+	// diagnosing why one of them is missing flows.
+	ListSubscribers(context.Context, *connect.Request[ListSubscribersRequest]) (*connect.Response[ListSubscribersResponse], error)
+	// ListAuditEvents returns the audit log of changes to and exports of
+	// flows, newest first. It requires the server to be started with -audit-log.
+	ListAuditEvents(context.Context, *connect.Request[ListAuditEventsRequest]) (*connect.Response[ListAuditEventsResponse], error)
+	SendRequest(context.Context, *connect.Request[SendRequestRequest]) (*connect.Response[SendRequestResponse], error)
+	GetCookieTimeline(context.Context, *connect.Request[GetCookieTimelineRequest]) (*connect.Response[GetCookieTimelineResponse], error)
+	GetRedirectChain(context.Context, *connect.Request[GetRedirectChainRequest]) (*connect.Response[GetRedirectChainResponse], error)
+	CreateShareBundle(context.Context, *connect.Request[CreateShareBundleRequest]) (*connect.Response[CreateShareBundleResponse], error)
+	SetBaseline(context.Context, *connect.Request[SetBaselineRequest]) (*connect.Response[SetBaselineResponse], error)
+	CompareSessions(context.Context, *connect.Request[CompareSessionsRequest]) (*connect.Response[CompareSessionsResponse], error)
+	// UploadFlowBody lets mitmproxy send bodies too large for a single
+	// ExportFlowRequest in chunks. The flow is exported first without the body
+	// (or with it truncated), then the chunks of each body are sent in order;
+	// a body is complete when the stream moves on to another one or ends.
+	UploadFlowBody(context.Context) *connect.ClientStreamForClient[UploadFlowBodyRequest, UploadFlowBodyResponse]
+	// DiffWithPrevious compares an HTTP flow with the latest one before it with
+	// the same method and path, e.g. to see what changed between a passing and
+	// a failing call.
+	DiffWithPrevious(context.Context, *connect.Request[DiffWithPreviousRequest]) (*connect.Response[DiffWithPreviousResponse], error)
+	// TopFlows returns the slowest or largest flows matching a filter, worst
+	// first, to start a performance hunt from the worst offenders.
+	TopFlows(context.Context, *connect.Request[TopFlowsRequest]) (*connect.Response[TopFlowsResponse], error)
+	// GetBandwidthStats adds up the bytes flows sent and received per server
+	// host and per client over time, e.g. to find what is eating a data plan.
+	GetBandwidthStats(context.Context, *connect.Request[GetBandwidthStatsRequest]) (*connect.Response[GetBandwidthStatsResponse], error)
+	// GetProxyRules returns the rules mitmproxy is asked to apply to the
+	// traffic it proxies, like serving a local response instead of the
+	// server's or slowing flows down.
+	GetProxyRules(context.Context, *connect.Request[GetProxyRulesRequest]) (*connect.Response[GetProxyRulesResponse], error)
+	// SetProxyRules replaces the proxy rules and relays them to every watcher.
+	SetProxyRules(context.Context, *connect.Request[SetProxyRulesRequest]) (*connect.Response[SetProxyRulesResponse], error)
+	// WatchProxyRules is the control channel of the mitmproxy addon. It sends
+	// the current rules right away and again whenever they change; the addon
+	// applies the latest rules it got.
+	WatchProxyRules(context.Context, *connect.Request[WatchProxyRulesRequest]) (*connect.ServerStreamForClient[WatchProxyRulesResponse], error)
 }
 
 // NewServiceClient constructs a client for the mitmflow.v1.Service service. By default, it uses the
@@ -103,17 +205,185 @@ func NewServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...con
 			connect.WithSchema(serviceMethods.ByName("GetFlow")),
 			connect.WithClientOptions(opts...),
 		),
+		getFlowBody: connect.NewClient[GetFlowBodyRequest, GetFlowBodyResponse](
+			httpClient,
+			baseURL+ServiceGetFlowBodyProcedure,
+			connect.WithSchema(serviceMethods.ByName("GetFlowBody")),
+			connect.WithClientOptions(opts...),
+		),
+		getFlowFrames: connect.NewClient[GetFlowFramesRequest, GetFlowFramesResponse](
+			httpClient,
+			baseURL+ServiceGetFlowFramesProcedure,
+			connect.WithSchema(serviceMethods.ByName("GetFlowFrames")),
+			connect.WithClientOptions(opts...),
+		),
+		importFlows: connect.NewClient[ImportFlowsRequest, ImportFlowsResponse](
+			httpClient,
+			baseURL+ServiceImportFlowsProcedure,
+			connect.WithSchema(serviceMethods.ByName("ImportFlows")),
+			connect.WithClientOptions(opts...),
+		),
+		createBackup: connect.NewClient[CreateBackupRequest, CreateBackupResponse](
+			httpClient,
+			baseURL+ServiceCreateBackupProcedure,
+			connect.WithSchema(serviceMethods.ByName("CreateBackup")),
+			connect.WithClientOptions(opts...),
+		),
+		restoreBackup: connect.NewClient[RestoreBackupRequest, RestoreBackupResponse](
+			httpClient,
+			baseURL+ServiceRestoreBackupProcedure,
+			connect.WithSchema(serviceMethods.ByName("RestoreBackup")),
+			connect.WithClientOptions(opts...),
+		),
+		searchArchive: connect.NewClient[SearchArchiveRequest, SearchArchiveResponse](
+			httpClient,
+			baseURL+ServiceSearchArchiveProcedure,
+			connect.WithSchema(serviceMethods.ByName("SearchArchive")),
+			connect.WithClientOptions(opts...),
+		),
+		restoreArchivedFlows: connect.NewClient[RestoreArchivedFlowsRequest, RestoreArchivedFlowsResponse](
+			httpClient,
+			baseURL+ServiceRestoreArchivedFlowsProcedure,
+			connect.WithSchema(serviceMethods.ByName("RestoreArchivedFlows")),
+			connect.WithClientOptions(opts...),
+		),
+		restoreFlows: connect.NewClient[RestoreFlowsRequest, RestoreFlowsResponse](
+			httpClient,
+			baseURL+ServiceRestoreFlowsProcedure,
+			connect.WithSchema(serviceMethods.ByName("RestoreFlows")),
+			connect.WithClientOptions(opts...),
+		),
+		getServerInfo: connect.NewClient[GetServerInfoRequest, GetServerInfoResponse](
+			httpClient,
+			baseURL+ServiceGetServerInfoProcedure,
+			connect.WithSchema(serviceMethods.ByName("GetServerInfo")),
+			connect.WithClientOptions(opts...),
+		),
+		listSubscribers: connect.NewClient[ListSubscribersRequest, ListSubscribersResponse](
+			httpClient,
+			baseURL+ServiceListSubscribersProcedure,
+			connect.WithSchema(serviceMethods.ByName("ListSubscribers")),
+			connect.WithClientOptions(opts...),
+		),
+		listAuditEvents: connect.NewClient[ListAuditEventsRequest, ListAuditEventsResponse](
+			httpClient,
+			baseURL+ServiceListAuditEventsProcedure,
+			connect.WithSchema(serviceMethods.ByName("ListAuditEvents")),
+			connect.WithClientOptions(opts...),
+		),
+		sendRequest: connect.NewClient[SendRequestRequest, SendRequestResponse](
+			httpClient,
+			baseURL+ServiceSendRequestProcedure,
+			connect.WithSchema(serviceMethods.ByName("SendRequest")),
+			connect.WithClientOptions(opts...),
+		),
+		getCookieTimeline: connect.NewClient[GetCookieTimelineRequest, GetCookieTimelineResponse](
+			httpClient,
+			baseURL+ServiceGetCookieTimelineProcedure,
+			connect.WithSchema(serviceMethods.ByName("GetCookieTimeline")),
+			connect.WithClientOptions(opts...),
+		),
+		getRedirectChain: connect.NewClient[GetRedirectChainRequest, GetRedirectChainResponse](
+			httpClient,
+			baseURL+ServiceGetRedirectChainProcedure,
+			connect.WithSchema(serviceMethods.ByName("GetRedirectChain")),
+			connect.WithClientOptions(opts...),
+		),
+		createShareBundle: connect.NewClient[CreateShareBundleRequest, CreateShareBundleResponse](
+			httpClient,
+			baseURL+ServiceCreateShareBundleProcedure,
+			connect.WithSchema(serviceMethods.ByName("CreateShareBundle")),
+			connect.WithClientOptions(opts...),
+		),
+		setBaseline: connect.NewClient[SetBaselineRequest, SetBaselineResponse](
+			httpClient,
+			baseURL+ServiceSetBaselineProcedure,
+			connect.WithSchema(serviceMethods.ByName("SetBaseline")),
+			connect.WithClientOptions(opts...),
+		),
+		compareSessions: connect.NewClient[CompareSessionsRequest, CompareSessionsResponse](
+			httpClient,
+			baseURL+ServiceCompareSessionsProcedure,
+			connect.WithSchema(serviceMethods.ByName("CompareSessions")),
+			connect.WithClientOptions(opts...),
+		),
+		uploadFlowBody: connect.NewClient[UploadFlowBodyRequest, UploadFlowBodyResponse](
+			httpClient,
+			baseURL+ServiceUploadFlowBodyProcedure,
+			connect.WithSchema(serviceMethods.ByName("UploadFlowBody")),
+			connect.WithClientOptions(opts...),
+		),
+		diffWithPrevious: connect.NewClient[DiffWithPreviousRequest, DiffWithPreviousResponse](
+			httpClient,
+			baseURL+ServiceDiffWithPreviousProcedure,
+			connect.WithSchema(serviceMethods.ByName("DiffWithPrevious")),
+			connect.WithClientOptions(opts...),
+		),
+		topFlows: connect.NewClient[TopFlowsRequest, TopFlowsResponse](
+			httpClient,
+			baseURL+ServiceTopFlowsProcedure,
+			connect.WithSchema(serviceMethods.ByName("TopFlows")),
+			connect.WithClientOptions(opts...),
+		),
+		getBandwidthStats: connect.NewClient[GetBandwidthStatsRequest, GetBandwidthStatsResponse](
+			httpClient,
+			baseURL+ServiceGetBandwidthStatsProcedure,
+			connect.WithSchema(serviceMethods.ByName("GetBandwidthStats")),
+			connect.WithClientOptions(opts...),
+		),
+		getProxyRules: connect.NewClient[GetProxyRulesRequest, GetProxyRulesResponse](
+			httpClient,
+			baseURL+ServiceGetProxyRulesProcedure,
+			connect.WithSchema(serviceMethods.ByName("GetProxyRules")),
+			connect.WithClientOptions(opts...),
+		),
+		setProxyRules: connect.NewClient[SetProxyRulesRequest, SetProxyRulesResponse](
+			httpClient,
+			baseURL+ServiceSetProxyRulesProcedure,
+			connect.WithSchema(serviceMethods.ByName("SetProxyRules")),
+			connect.WithClientOptions(opts...),
+		),
+		watchProxyRules: connect.NewClient[WatchProxyRulesRequest, WatchProxyRulesResponse](
+			httpClient,
+			baseURL+ServiceWatchProxyRulesProcedure,
+			connect.WithSchema(serviceMethods.ByName("WatchProxyRules")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // serviceClient implements ServiceClient.
 type serviceClient struct {
-	getFlows    *connect.Client[GetFlowsRequest, GetFlowsResponse]
-	streamFlows *connect.Client[StreamFlowsRequest, StreamFlowsResponse]
-	updateFlow  *connect.Client[UpdateFlowRequest, UpdateFlowResponse]
-	deleteFlows *connect.Client[DeleteFlowsRequest, DeleteFlowsResponse]
-	exportFlows *connect.Client[ExportFlowsRequest, ExportFlowsResponse]
-	getFlow     *connect.Client[GetFlowRequest, GetFlowResponse]
+	getFlows             *connect.Client[GetFlowsRequest, GetFlowsResponse]
+	streamFlows          *connect.Client[StreamFlowsRequest, StreamFlowsResponse]
+	updateFlow           *connect.Client[UpdateFlowRequest, UpdateFlowResponse]
+	deleteFlows          *connect.Client[DeleteFlowsRequest, DeleteFlowsResponse]
+	exportFlows          *connect.Client[ExportFlowsRequest, ExportFlowsResponse]
+	getFlow              *connect.Client[GetFlowRequest, GetFlowResponse]
+	getFlowBody          *connect.Client[GetFlowBodyRequest, GetFlowBodyResponse]
+	getFlowFrames        *connect.Client[GetFlowFramesRequest, GetFlowFramesResponse]
+	importFlows          *connect.Client[ImportFlowsRequest, ImportFlowsResponse]
+	createBackup         *connect.Client[CreateBackupRequest, CreateBackupResponse]
+	restoreBackup        *connect.Client[RestoreBackupRequest, RestoreBackupResponse]
+	searchArchive        *connect.Client[SearchArchiveRequest, SearchArchiveResponse]
+	restoreArchivedFlows *connect.Client[RestoreArchivedFlowsRequest, RestoreArchivedFlowsResponse]
+	restoreFlows         *connect.Client[RestoreFlowsRequest, RestoreFlowsResponse]
+	getServerInfo        *connect.Client[GetServerInfoRequest, GetServerInfoResponse]
+	listSubscribers      *connect.Client[ListSubscribersRequest, ListSubscribersResponse]
+	listAuditEvents      *connect.Client[ListAuditEventsRequest, ListAuditEventsResponse]
+	sendRequest          *connect.Client[SendRequestRequest, SendRequestResponse]
+	getCookieTimeline    *connect.Client[GetCookieTimelineRequest, GetCookieTimelineResponse]
+	getRedirectChain     *connect.Client[GetRedirectChainRequest, GetRedirectChainResponse]
+	createShareBundle    *connect.Client[CreateShareBundleRequest, CreateShareBundleResponse]
+	setBaseline          *connect.Client[SetBaselineRequest, SetBaselineResponse]
+	compareSessions      *connect.Client[CompareSessionsRequest, CompareSessionsResponse]
+	uploadFlowBody       *connect.Client[UploadFlowBodyRequest, UploadFlowBodyResponse]
+	diffWithPrevious     *connect.Client[DiffWithPreviousRequest, DiffWithPreviousResponse]
+	topFlows             *connect.Client[TopFlowsRequest, TopFlowsResponse]
+	getBandwidthStats    *connect.Client[GetBandwidthStatsRequest, GetBandwidthStatsResponse]
+	getProxyRules        *connect.Client[GetProxyRulesRequest, GetProxyRulesResponse]
+	setProxyRules        *connect.Client[SetProxyRulesRequest, SetProxyRulesResponse]
+	watchProxyRules      *connect.Client[WatchProxyRulesRequest, WatchProxyRulesResponse]
 }
 
 // GetFlows calls mitmflow.v1.Service.GetFlows.
@@ -146,6 +416,126 @@ func (c *serviceClient) GetFlow(ctx context.Context, req *connect.Request[GetFlo
 	return c.getFlow.CallUnary(ctx, req)
 }
 
+// GetFlowBody calls mitmflow.v1.Service.GetFlowBody.
+func (c *serviceClient) GetFlowBody(ctx context.Context, req *connect.Request[GetFlowBodyRequest]) (*connect.Response[GetFlowBodyResponse], error) {
+	return c.getFlowBody.CallUnary(ctx, req)
+}
+
+// GetFlowFrames calls mitmflow.v1.Service.GetFlowFrames.
+func (c *serviceClient) GetFlowFrames(ctx context.Context, req *connect.Request[GetFlowFramesRequest]) (*connect.Response[GetFlowFramesResponse], error) {
+	return c.getFlowFrames.CallUnary(ctx, req)
+}
+
+// ImportFlows calls mitmflow.v1.Service.ImportFlows.
+func (c *serviceClient) ImportFlows(ctx context.Context, req *connect.Request[ImportFlowsRequest]) (*connect.Response[ImportFlowsResponse], error) {
+	return c.importFlows.CallUnary(ctx, req)
+}
+
+// CreateBackup calls mitmflow.v1.Service.CreateBackup.
+func (c *serviceClient) CreateBackup(ctx context.Context, req *connect.Request[CreateBackupRequest]) (*connect.ServerStreamForClient[CreateBackupResponse], error) {
+	return c.createBackup.CallServerStream(ctx, req)
+}
+
+// RestoreBackup calls mitmflow.v1.Service.RestoreBackup.
+func (c *serviceClient) RestoreBackup(ctx context.Context, req *connect.Request[RestoreBackupRequest]) (*connect.Response[RestoreBackupResponse], error) {
+	return c.restoreBackup.CallUnary(ctx, req)
+}
+
+// SearchArchive calls mitmflow.v1.Service.SearchArchive.
+func (c *serviceClient) SearchArchive(ctx context.Context, req *connect.Request[SearchArchiveRequest]) (*connect.ServerStreamForClient[SearchArchiveResponse], error) {
+	return c.searchArchive.CallServerStream(ctx, req)
+}
+
+// RestoreArchivedFlows calls mitmflow.v1.Service.RestoreArchivedFlows.
+func (c *serviceClient) RestoreArchivedFlows(ctx context.Context, req *connect.Request[RestoreArchivedFlowsRequest]) (*connect.Response[RestoreArchivedFlowsResponse], error) {
+	return c.restoreArchivedFlows.CallUnary(ctx, req)
+}
+
+// RestoreFlows calls mitmflow.v1.Service.RestoreFlows.
+func (c *serviceClient) RestoreFlows(ctx context.Context, req *connect.Request[RestoreFlowsRequest]) (*connect.Response[RestoreFlowsResponse], error) {
+	return c.restoreFlows.CallUnary(ctx, req)
+}
+
+// GetServerInfo calls mitmflow.v1.Service.GetServerInfo.
+func (c *serviceClient) GetServerInfo(ctx context.Context, req *connect.Request[GetServerInfoRequest]) (*connect.Response[GetServerInfoResponse], error) {
+	return c.getServerInfo.CallUnary(ctx, req)
+}
+
+// ListSubscribers calls mitmflow.v1.Service.ListSubscribers.
+func (c *serviceClient) ListSubscribers(ctx context.Context, req *connect.Request[ListSubscribersRequest]) (*connect.Response[ListSubscribersResponse], error) {
+	return c.listSubscribers.CallUnary(ctx, req)
+}
+
+// ListAuditEvents calls mitmflow.v1.Service.ListAuditEvents.
+func (c *serviceClient) ListAuditEvents(ctx context.Context, req *connect.Request[ListAuditEventsRequest]) (*connect.Response[ListAuditEventsResponse], error) {
+	return c.listAuditEvents.CallUnary(ctx, req)
+}
+
+// SendRequest calls mitmflow.v1.Service.SendRequest.
+func (c *serviceClient) SendRequest(ctx context.Context, req *connect.Request[SendRequestRequest]) (*connect.Response[SendRequestResponse], error) {
+	return c.sendRequest.CallUnary(ctx, req)
+}
+
+// GetCookieTimeline calls mitmflow.v1.Service.GetCookieTimeline.
+func (c *serviceClient) GetCookieTimeline(ctx context.Context, req *connect.Request[GetCookieTimelineRequest]) (*connect.Response[GetCookieTimelineResponse], error) {
+	return c.getCookieTimeline.CallUnary(ctx, req)
+}
+
+// GetRedirectChain calls mitmflow.v1.Service.GetRedirectChain.
+func (c *serviceClient) GetRedirectChain(ctx context.Context, req *connect.Request[GetRedirectChainRequest]) (*connect.Response[GetRedirectChainResponse], error) {
+	return c.getRedirectChain.CallUnary(ctx, req)
+}
+
+// CreateShareBundle calls mitmflow.v1.Service.CreateShareBundle.
+func (c *serviceClient) CreateShareBundle(ctx context.Context, req *connect.Request[CreateShareBundleRequest]) (*connect.Response[CreateShareBundleResponse], error) {
+	return c.createShareBundle.CallUnary(ctx, req)
+}
+
+// SetBaseline calls mitmflow.v1.Service.SetBaseline.
+func (c *serviceClient) SetBaseline(ctx context.Context, req *connect.Request[SetBaselineRequest]) (*connect.Response[SetBaselineResponse], error) {
+	return c.setBaseline.CallUnary(ctx, req)
+}
+
+// CompareSessions calls mitmflow.v1.Service.CompareSessions.
+func (c *serviceClient) CompareSessions(ctx context.Context, req *connect.Request[CompareSessionsRequest]) (*connect.Response[CompareSessionsResponse], error) {
+	return c.compareSessions.CallUnary(ctx, req)
+}
+
+// UploadFlowBody calls mitmflow.v1.Service.UploadFlowBody.
+func (c *serviceClient) UploadFlowBody(ctx context.Context) *connect.ClientStreamForClient[UploadFlowBodyRequest, UploadFlowBodyResponse] {
+	return c.uploadFlowBody.CallClientStream(ctx)
+}
+
+// DiffWithPrevious calls mitmflow.v1.Service.DiffWithPrevious.
+func (c *serviceClient) DiffWithPrevious(ctx context.Context, req *connect.Request[DiffWithPreviousRequest]) (*connect.Response[DiffWithPreviousResponse], error) {
+	return c.diffWithPrevious.CallUnary(ctx, req)
+}
+
+// TopFlows calls mitmflow.v1.Service.TopFlows.
+func (c *serviceClient) TopFlows(ctx context.Context, req *connect.Request[TopFlowsRequest]) (*connect.Response[TopFlowsResponse], error) {
+	return c.topFlows.CallUnary(ctx, req)
+}
+
+// GetBandwidthStats calls mitmflow.v1.Service.GetBandwidthStats.
+func (c *serviceClient) GetBandwidthStats(ctx context.Context, req *connect.Request[GetBandwidthStatsRequest]) (*connect.Response[GetBandwidthStatsResponse], error) {
+	return c.getBandwidthStats.CallUnary(ctx, req)
+}
+
+// GetProxyRules calls mitmflow.v1.Service.GetProxyRules.
+func (c *serviceClient) GetProxyRules(ctx context.Context, req *connect.Request[GetProxyRulesRequest]) (*connect.Response[GetProxyRulesResponse], error) {
+	return c.getProxyRules.CallUnary(ctx, req)
+}
+
+// SetProxyRules calls mitmflow.v1.Service.SetProxyRules.
+func (c *serviceClient) SetProxyRules(ctx context.Context, req *connect.Request[SetProxyRulesRequest]) (*connect.Response[SetProxyRulesResponse], error) {
+	return c.setProxyRules.CallUnary(ctx, req)
+}
+
+// WatchProxyRules calls mitmflow.v1.Service.WatchProxyRules.
+func (c *serviceClient) WatchProxyRules(ctx context.Context, req *connect.Request[WatchProxyRulesRequest]) (*connect.ServerStreamForClient[WatchProxyRulesResponse], error) {
+	return c.watchProxyRules.CallServerStream(ctx, req)
+}
+
 // ServiceHandler is an implementation of the mitmflow.v1.Service service.
 type ServiceHandler interface {
 	GetFlows(context.Context, *connect.Request[GetFlowsRequest], *connect.ServerStream[GetFlowsResponse]) error
@@ -154,6 +544,54 @@ type ServiceHandler interface {
 	DeleteFlows(context.Context, *connect.Request[DeleteFlowsRequest]) (*connect.Response[DeleteFlowsResponse], error)
 	ExportFlows(context.Context, *connect.Request[ExportFlowsRequest]) (*connect.Response[ExportFlowsResponse], error)
 	GetFlow(context.Context, *connect.Request[GetFlowRequest]) (*connect.Response[GetFlowResponse], error)
+	GetFlowBody(context.Context, *connect.Request[GetFlowBodyRequest]) (*connect.Response[GetFlowBodyResponse], error)
+	// Pages through the textual frames of a request or response, for
+	// streaming bodies with more frames than GetFlow returns.
+	GetFlowFrames(context.Context, *connect.Request[GetFlowFramesRequest]) (*connect.Response[GetFlowFramesResponse], error)
+	ImportFlows(context.Context, *connect.Request[ImportFlowsRequest]) (*connect.Response[ImportFlowsResponse], error)
+	CreateBackup(context.Context, *connect.Request[CreateBackupRequest], *connect.ServerStream[CreateBackupResponse]) error
+	RestoreBackup(context.Context, *connect.Request[RestoreBackupRequest]) (*connect.Response[RestoreBackupResponse], error)
+	SearchArchive(context.Context, *connect.Request[SearchArchiveRequest], *connect.ServerStream[SearchArchiveResponse]) error
+	RestoreArchivedFlows(context.Context, *connect.Request[RestoreArchivedFlowsRequest]) (*connect.Response[RestoreArchivedFlowsResponse], error)
+	RestoreFlows(context.Context, *connect.Request[RestoreFlowsRequest]) (*connect.Response[RestoreFlowsResponse], error)
+	GetServerInfo(context.Context, *connect.Request[GetServerInfoRequest]) (*connect.Response[GetServerInfoResponse], error)
+	// ListSubscribers describes the clients currently streaming flows, for
+	// diagnosing why one of them is missing flows.
+	ListSubscribers(context.Context, *connect.Request[ListSubscribersRequest]) (*connect.Response[ListSubscribersResponse], error)
+	// ListAuditEvents returns the audit log of changes to and exports of
+	// flows, newest first. It requires the server to be started with -audit-log.
+	ListAuditEvents(context.Context, *connect.Request[ListAuditEventsRequest]) (*connect.Response[ListAuditEventsResponse], error)
+	SendRequest(context.Context, *connect.Request[SendRequestRequest]) (*connect.Response[SendRequestResponse], error)
+	GetCookieTimeline(context.Context, *connect.Request[GetCookieTimelineRequest]) (*connect.Response[GetCookieTimelineResponse], error)
+	GetRedirectChain(context.Context, *connect.Request[GetRedirectChainRequest]) (*connect.Response[GetRedirectChainResponse], error)
+	CreateShareBundle(context.Context, *connect.Request[CreateShareBundleRequest]) (*connect.Response[CreateShareBundleResponse], error)
+	SetBaseline(context.Context, *connect.Request[SetBaselineRequest]) (*connect.Response[SetBaselineResponse], error)
+	CompareSessions(context.Context, *connect.Request[CompareSessionsRequest]) (*connect.Response[CompareSessionsResponse], error)
+	// UploadFlowBody lets mitmproxy send bodies too large for a single
+	// ExportFlowRequest in chunks. The flow is exported first without the body
+	// (or with it truncated), then the chunks of each body are sent in order;
+	// a body is complete when the stream moves on to another one or ends.
+	UploadFlowBody(context.Context, *connect.ClientStream[UploadFlowBodyRequest]) (*connect.Response[UploadFlowBodyResponse], error)
+	// DiffWithPrevious compares an HTTP flow with the latest one before it with
+	// the same method and path, e.g. to see what changed between a passing and
+	// a failing call.
+	DiffWithPrevious(context.Context, *connect.Request[DiffWithPreviousRequest]) (*connect.Response[DiffWithPreviousResponse], error)
+	// TopFlows returns the slowest or largest flows matching a filter, worst
+	// first, to start a performance hunt from the worst offenders.
+	TopFlows(context.Context, *connect.Request[TopFlowsRequest]) (*connect.Response[TopFlowsResponse], error)
+	// GetBandwidthStats adds up the bytes flows sent and received per server
+	// host and per client over time, e.g. to find what is eating a data plan.
+	GetBandwidthStats(context.Context, *connect.Request[GetBandwidthStatsRequest]) (*connect.Response[GetBandwidthStatsResponse], error)
+	// GetProxyRules returns the rules mitmproxy is asked to apply to the
+	// traffic it proxies, like serving a local response instead of the
+	// server's or slowing flows down.
+	GetProxyRules(context.Context, *connect.Request[GetProxyRulesRequest]) (*connect.Response[GetProxyRulesResponse], error)
+	// SetProxyRules replaces the proxy rules and relays them to every watcher.
+	SetProxyRules(context.Context, *connect.Request[SetProxyRulesRequest]) (*connect.Response[SetProxyRulesResponse], error)
+	// WatchProxyRules is the control channel of the mitmproxy addon. It sends
+	// the current rules right away and again whenever they change; the addon
+	// applies the latest rules it got.
+	WatchProxyRules(context.Context, *connect.Request[WatchProxyRulesRequest], *connect.ServerStream[WatchProxyRulesResponse]) error
 }
 
 // NewServiceHandler builds an HTTP handler from the service implementation. It returns the path on
@@ -199,6 +637,150 @@ func NewServiceHandler(svc ServiceHandler, opts ...connect.HandlerOption) (strin
 		connect.WithSchema(serviceMethods.ByName("GetFlow")),
 		connect.WithHandlerOptions(opts...),
 	)
+	serviceGetFlowBodyHandler := connect.NewUnaryHandler(
+		ServiceGetFlowBodyProcedure,
+		svc.GetFlowBody,
+		connect.WithSchema(serviceMethods.ByName("GetFlowBody")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceGetFlowFramesHandler := connect.NewUnaryHandler(
+		ServiceGetFlowFramesProcedure,
+		svc.GetFlowFrames,
+		connect.WithSchema(serviceMethods.ByName("GetFlowFrames")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceImportFlowsHandler := connect.NewUnaryHandler(
+		ServiceImportFlowsProcedure,
+		svc.ImportFlows,
+		connect.WithSchema(serviceMethods.ByName("ImportFlows")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceCreateBackupHandler := connect.NewServerStreamHandler(
+		ServiceCreateBackupProcedure,
+		svc.CreateBackup,
+		connect.WithSchema(serviceMethods.ByName("CreateBackup")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceRestoreBackupHandler := connect.NewUnaryHandler(
+		ServiceRestoreBackupProcedure,
+		svc.RestoreBackup,
+		connect.WithSchema(serviceMethods.ByName("RestoreBackup")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceSearchArchiveHandler := connect.NewServerStreamHandler(
+		ServiceSearchArchiveProcedure,
+		svc.SearchArchive,
+		connect.WithSchema(serviceMethods.ByName("SearchArchive")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceRestoreArchivedFlowsHandler := connect.NewUnaryHandler(
+		ServiceRestoreArchivedFlowsProcedure,
+		svc.RestoreArchivedFlows,
+		connect.WithSchema(serviceMethods.ByName("RestoreArchivedFlows")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceRestoreFlowsHandler := connect.NewUnaryHandler(
+		ServiceRestoreFlowsProcedure,
+		svc.RestoreFlows,
+		connect.WithSchema(serviceMethods.ByName("RestoreFlows")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceGetServerInfoHandler := connect.NewUnaryHandler(
+		ServiceGetServerInfoProcedure,
+		svc.GetServerInfo,
+		connect.WithSchema(serviceMethods.ByName("GetServerInfo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceListSubscribersHandler := connect.NewUnaryHandler(
+		ServiceListSubscribersProcedure,
+		svc.ListSubscribers,
+		connect.WithSchema(serviceMethods.ByName("ListSubscribers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceListAuditEventsHandler := connect.NewUnaryHandler(
+		ServiceListAuditEventsProcedure,
+		svc.ListAuditEvents,
+		connect.WithSchema(serviceMethods.ByName("ListAuditEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceSendRequestHandler := connect.NewUnaryHandler(
+		ServiceSendRequestProcedure,
+		svc.SendRequest,
+		connect.WithSchema(serviceMethods.ByName("SendRequest")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceGetCookieTimelineHandler := connect.NewUnaryHandler(
+		ServiceGetCookieTimelineProcedure,
+		svc.GetCookieTimeline,
+		connect.WithSchema(serviceMethods.ByName("GetCookieTimeline")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceGetRedirectChainHandler := connect.NewUnaryHandler(
+		ServiceGetRedirectChainProcedure,
+		svc.GetRedirectChain,
+		connect.WithSchema(serviceMethods.ByName("GetRedirectChain")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceCreateShareBundleHandler := connect.NewUnaryHandler(
+		ServiceCreateShareBundleProcedure,
+		svc.CreateShareBundle,
+		connect.WithSchema(serviceMethods.ByName("CreateShareBundle")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceSetBaselineHandler := connect.NewUnaryHandler(
+		ServiceSetBaselineProcedure,
+		svc.SetBaseline,
+		connect.WithSchema(serviceMethods.ByName("SetBaseline")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceCompareSessionsHandler := connect.NewUnaryHandler(
+		ServiceCompareSessionsProcedure,
+		svc.CompareSessions,
+		connect.WithSchema(serviceMethods.ByName("CompareSessions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceUploadFlowBodyHandler := connect.NewClientStreamHandler(
+		ServiceUploadFlowBodyProcedure,
+		svc.UploadFlowBody,
+		connect.WithSchema(serviceMethods.ByName("UploadFlowBody")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceDiffWithPreviousHandler := connect.NewUnaryHandler(
+		ServiceDiffWithPreviousProcedure,
+		svc.DiffWithPrevious,
+		connect.WithSchema(serviceMethods.ByName("DiffWithPrevious")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceTopFlowsHandler := connect.NewUnaryHandler(
+		ServiceTopFlowsProcedure,
+		svc.TopFlows,
+		connect.WithSchema(serviceMethods.ByName("TopFlows")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceGetBandwidthStatsHandler := connect.NewUnaryHandler(
+		ServiceGetBandwidthStatsProcedure,
+		svc.GetBandwidthStats,
+		connect.WithSchema(serviceMethods.ByName("GetBandwidthStats")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceGetProxyRulesHandler := connect.NewUnaryHandler(
+		ServiceGetProxyRulesProcedure,
+		svc.GetProxyRules,
+		connect.WithSchema(serviceMethods.ByName("GetProxyRules")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceSetProxyRulesHandler := connect.NewUnaryHandler(
+		ServiceSetProxyRulesProcedure,
+		svc.SetProxyRules,
+		connect.WithSchema(serviceMethods.ByName("SetProxyRules")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceWatchProxyRulesHandler := connect.NewServerStreamHandler(
+		ServiceWatchProxyRulesProcedure,
+		svc.WatchProxyRules,
+		connect.WithSchema(serviceMethods.ByName("WatchProxyRules")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/mitmflow.v1.Service/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ServiceGetFlowsProcedure:
@@ -213,6 +795,54 @@ func NewServiceHandler(svc ServiceHandler, opts ...connect.HandlerOption) (strin
 			serviceExportFlowsHandler.ServeHTTP(w, r)
 		case ServiceGetFlowProcedure:
 			serviceGetFlowHandler.ServeHTTP(w, r)
+		case ServiceGetFlowBodyProcedure:
+			serviceGetFlowBodyHandler.ServeHTTP(w, r)
+		case ServiceGetFlowFramesProcedure:
+			serviceGetFlowFramesHandler.ServeHTTP(w, r)
+		case ServiceImportFlowsProcedure:
+			serviceImportFlowsHandler.ServeHTTP(w, r)
+		case ServiceCreateBackupProcedure:
+			serviceCreateBackupHandler.ServeHTTP(w, r)
+		case ServiceRestoreBackupProcedure:
+			serviceRestoreBackupHandler.ServeHTTP(w, r)
+		case ServiceSearchArchiveProcedure:
+			serviceSearchArchiveHandler.ServeHTTP(w, r)
+		case ServiceRestoreArchivedFlowsProcedure:
+			serviceRestoreArchivedFlowsHandler.ServeHTTP(w, r)
+		case ServiceRestoreFlowsProcedure:
+			serviceRestoreFlowsHandler.ServeHTTP(w, r)
+		case ServiceGetServerInfoProcedure:
+			serviceGetServerInfoHandler.ServeHTTP(w, r)
+		case ServiceListSubscribersProcedure:
+			serviceListSubscribersHandler.ServeHTTP(w, r)
+		case ServiceListAuditEventsProcedure:
+			serviceListAuditEventsHandler.ServeHTTP(w, r)
+		case ServiceSendRequestProcedure:
+			serviceSendRequestHandler.ServeHTTP(w, r)
+		case ServiceGetCookieTimelineProcedure:
+			serviceGetCookieTimelineHandler.ServeHTTP(w, r)
+		case ServiceGetRedirectChainProcedure:
+			serviceGetRedirectChainHandler.ServeHTTP(w, r)
+		case ServiceCreateShareBundleProcedure:
+			serviceCreateShareBundleHandler.ServeHTTP(w, r)
+		case ServiceSetBaselineProcedure:
+			serviceSetBaselineHandler.ServeHTTP(w, r)
+		case ServiceCompareSessionsProcedure:
+			serviceCompareSessionsHandler.ServeHTTP(w, r)
+		case ServiceUploadFlowBodyProcedure:
+			serviceUploadFlowBodyHandler.ServeHTTP(w, r)
+		case ServiceDiffWithPreviousProcedure:
+			serviceDiffWithPreviousHandler.ServeHTTP(w, r)
+		case ServiceTopFlowsProcedure:
+			serviceTopFlowsHandler.ServeHTTP(w, r)
+		case ServiceGetBandwidthStatsProcedure:
+			serviceGetBandwidthStatsHandler.ServeHTTP(w, r)
+		case ServiceGetProxyRulesProcedure:
+			serviceGetProxyRulesHandler.ServeHTTP(w, r)
+		case ServiceSetProxyRulesProcedure:
+			serviceSetProxyRulesHandler.ServeHTTP(w, r)
+		case ServiceWatchProxyRulesProcedure:
+			serviceWatchProxyRulesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -245,3 +875,99 @@ func (UnimplementedServiceHandler) ExportFlows(context.Context, *connect.Request
 func (UnimplementedServiceHandler) GetFlow(context.Context, *connect.Request[GetFlowRequest]) (*connect.Response[GetFlowResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.GetFlow is not implemented"))
 }
+
+func (UnimplementedServiceHandler) GetFlowBody(context.Context, *connect.Request[GetFlowBodyRequest]) (*connect.Response[GetFlowBodyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.GetFlowBody is not implemented"))
+}
+
+func (UnimplementedServiceHandler) GetFlowFrames(context.Context, *connect.Request[GetFlowFramesRequest]) (*connect.Response[GetFlowFramesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.GetFlowFrames is not implemented"))
+}
+
+func (UnimplementedServiceHandler) ImportFlows(context.Context, *connect.Request[ImportFlowsRequest]) (*connect.Response[ImportFlowsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.ImportFlows is not implemented"))
+}
+
+func (UnimplementedServiceHandler) CreateBackup(context.Context, *connect.Request[CreateBackupRequest], *connect.ServerStream[CreateBackupResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.CreateBackup is not implemented"))
+}
+
+func (UnimplementedServiceHandler) RestoreBackup(context.Context, *connect.Request[RestoreBackupRequest]) (*connect.Response[RestoreBackupResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.RestoreBackup is not implemented"))
+}
+
+func (UnimplementedServiceHandler) SearchArchive(context.Context, *connect.Request[SearchArchiveRequest], *connect.ServerStream[SearchArchiveResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.SearchArchive is not implemented"))
+}
+
+func (UnimplementedServiceHandler) RestoreArchivedFlows(context.Context, *connect.Request[RestoreArchivedFlowsRequest]) (*connect.Response[RestoreArchivedFlowsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.RestoreArchivedFlows is not implemented"))
+}
+
+func (UnimplementedServiceHandler) RestoreFlows(context.Context, *connect.Request[RestoreFlowsRequest]) (*connect.Response[RestoreFlowsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.RestoreFlows is not implemented"))
+}
+
+func (UnimplementedServiceHandler) GetServerInfo(context.Context, *connect.Request[GetServerInfoRequest]) (*connect.Response[GetServerInfoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.GetServerInfo is not implemented"))
+}
+
+func (UnimplementedServiceHandler) ListSubscribers(context.Context, *connect.Request[ListSubscribersRequest]) (*connect.Response[ListSubscribersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.ListSubscribers is not implemented"))
+}
+
+func (UnimplementedServiceHandler) ListAuditEvents(context.Context, *connect.Request[ListAuditEventsRequest]) (*connect.Response[ListAuditEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.ListAuditEvents is not implemented"))
+}
+
+func (UnimplementedServiceHandler) SendRequest(context.Context, *connect.Request[SendRequestRequest]) (*connect.Response[SendRequestResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.SendRequest is not implemented"))
+}
+
+func (UnimplementedServiceHandler) GetCookieTimeline(context.Context, *connect.Request[GetCookieTimelineRequest]) (*connect.Response[GetCookieTimelineResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.GetCookieTimeline is not implemented"))
+}
+
+func (UnimplementedServiceHandler) GetRedirectChain(context.Context, *connect.Request[GetRedirectChainRequest]) (*connect.Response[GetRedirectChainResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.GetRedirectChain is not implemented"))
+}
+
+func (UnimplementedServiceHandler) CreateShareBundle(context.Context, *connect.Request[CreateShareBundleRequest]) (*connect.Response[CreateShareBundleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.CreateShareBundle is not implemented"))
+}
+
+func (UnimplementedServiceHandler) SetBaseline(context.Context, *connect.Request[SetBaselineRequest]) (*connect.Response[SetBaselineResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.SetBaseline is not implemented"))
+}
+
+func (UnimplementedServiceHandler) CompareSessions(context.Context, *connect.Request[CompareSessionsRequest]) (*connect.Response[CompareSessionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.CompareSessions is not implemented"))
+}
+
+func (UnimplementedServiceHandler) UploadFlowBody(context.Context, *connect.ClientStream[UploadFlowBodyRequest]) (*connect.Response[UploadFlowBodyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.UploadFlowBody is not implemented"))
+}
+
+func (UnimplementedServiceHandler) DiffWithPrevious(context.Context, *connect.Request[DiffWithPreviousRequest]) (*connect.Response[DiffWithPreviousResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.DiffWithPrevious is not implemented"))
+}
+
+func (UnimplementedServiceHandler) TopFlows(context.Context, *connect.Request[TopFlowsRequest]) (*connect.Response[TopFlowsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.TopFlows is not implemented"))
+}
+
+func (UnimplementedServiceHandler) GetBandwidthStats(context.Context, *connect.Request[GetBandwidthStatsRequest]) (*connect.Response[GetBandwidthStatsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.GetBandwidthStats is not implemented"))
+}
+
+func (UnimplementedServiceHandler) GetProxyRules(context.Context, *connect.Request[GetProxyRulesRequest]) (*connect.Response[GetProxyRulesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.GetProxyRules is not implemented"))
+}
+
+func (UnimplementedServiceHandler) SetProxyRules(context.Context, *connect.Request[SetProxyRulesRequest]) (*connect.Response[SetProxyRulesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.SetProxyRules is not implemented"))
+}
+
+func (UnimplementedServiceHandler) WatchProxyRules(context.Context, *connect.Request[WatchProxyRulesRequest], *connect.ServerStream[WatchProxyRulesResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("mitmflow.v1.Service.WatchProxyRules is not implemented"))
+}
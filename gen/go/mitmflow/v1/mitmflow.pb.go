@@ -29,19 +29,58 @@ const (
 	ExportFormat_EXPORT_FORMAT_UNSPECIFIED ExportFormat = 0
 	ExportFormat_EXPORT_FORMAT_HAR         ExportFormat = 1
 	ExportFormat_EXPORT_FORMAT_JSON        ExportFormat = 2
+	// A serialized FlowSet, which can be loaded back with ImportFlows.
+	ExportFormat_EXPORT_FORMAT_PROTO ExportFormat = 3
+	// A Fiddler session archive.
+	ExportFormat_EXPORT_FORMAT_SAZ ExportFormat = 4
+	// A Charles JSON session (.chlsj), which Charles opens like a .chls file.
+	ExportFormat_EXPORT_FORMAT_CHARLES ExportFormat = 5
+	// A zip with each gRPC, gRPC-Web or Connect message as a .bin file of the
+	// serialized message, plus a .json file when the message could be decoded.
+	ExportFormat_EXPORT_FORMAT_GRPC_FRAMES ExportFormat = 6
+	// grpcurl commands replaying the requests of gRPC flows that were decoded
+	// with a schema.
+	ExportFormat_EXPORT_FORMAT_GRPCURL ExportFormat = 7
+	// Like EXPORT_FORMAT_GRPCURL, but for buf curl, which also speaks gRPC-Web
+	// and Connect.
+	ExportFormat_EXPORT_FORMAT_BUF_CURL ExportFormat = 8
+	// One flow per line in the protobuf JSON mapping.
+	ExportFormat_EXPORT_FORMAT_JSONL ExportFormat = 9
+	// A summary table with the time, method, URL, status, duration, response
+	// size and content type of each flow, as CSV or as a Markdown table.
+	ExportFormat_EXPORT_FORMAT_CSV      ExportFormat = 10
+	ExportFormat_EXPORT_FORMAT_MARKDOWN ExportFormat = 11
 )
 
 // Enum value maps for ExportFormat.
 var (
 	ExportFormat_name = map[int32]string{
-		0: "EXPORT_FORMAT_UNSPECIFIED",
-		1: "EXPORT_FORMAT_HAR",
-		2: "EXPORT_FORMAT_JSON",
+		0:  "EXPORT_FORMAT_UNSPECIFIED",
+		1:  "EXPORT_FORMAT_HAR",
+		2:  "EXPORT_FORMAT_JSON",
+		3:  "EXPORT_FORMAT_PROTO",
+		4:  "EXPORT_FORMAT_SAZ",
+		5:  "EXPORT_FORMAT_CHARLES",
+		6:  "EXPORT_FORMAT_GRPC_FRAMES",
+		7:  "EXPORT_FORMAT_GRPCURL",
+		8:  "EXPORT_FORMAT_BUF_CURL",
+		9:  "EXPORT_FORMAT_JSONL",
+		10: "EXPORT_FORMAT_CSV",
+		11: "EXPORT_FORMAT_MARKDOWN",
 	}
 	ExportFormat_value = map[string]int32{
 		"EXPORT_FORMAT_UNSPECIFIED": 0,
 		"EXPORT_FORMAT_HAR":         1,
 		"EXPORT_FORMAT_JSON":        2,
+		"EXPORT_FORMAT_PROTO":       3,
+		"EXPORT_FORMAT_SAZ":         4,
+		"EXPORT_FORMAT_CHARLES":     5,
+		"EXPORT_FORMAT_GRPC_FRAMES": 6,
+		"EXPORT_FORMAT_GRPCURL":     7,
+		"EXPORT_FORMAT_BUF_CURL":    8,
+		"EXPORT_FORMAT_JSONL":       9,
+		"EXPORT_FORMAT_CSV":         10,
+		"EXPORT_FORMAT_MARKDOWN":    11,
 	}
 )
 
@@ -67,19 +106,492 @@ func (x ExportFormat) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
+type BaselineDifferenceKind int32
+
+const (
+	BaselineDifferenceKind_BASELINE_DIFFERENCE_KIND_UNSPECIFIED BaselineDifferenceKind = 0
+	BaselineDifferenceKind_BASELINE_DIFFERENCE_KIND_STATUS      BaselineDifferenceKind = 1
+	BaselineDifferenceKind_BASELINE_DIFFERENCE_KIND_HEADER      BaselineDifferenceKind = 2
+	BaselineDifferenceKind_BASELINE_DIFFERENCE_KIND_BODY        BaselineDifferenceKind = 3
+)
+
+// Enum value maps for BaselineDifferenceKind.
+var (
+	BaselineDifferenceKind_name = map[int32]string{
+		0: "BASELINE_DIFFERENCE_KIND_UNSPECIFIED",
+		1: "BASELINE_DIFFERENCE_KIND_STATUS",
+		2: "BASELINE_DIFFERENCE_KIND_HEADER",
+		3: "BASELINE_DIFFERENCE_KIND_BODY",
+	}
+	BaselineDifferenceKind_value = map[string]int32{
+		"BASELINE_DIFFERENCE_KIND_UNSPECIFIED": 0,
+		"BASELINE_DIFFERENCE_KIND_STATUS":      1,
+		"BASELINE_DIFFERENCE_KIND_HEADER":      2,
+		"BASELINE_DIFFERENCE_KIND_BODY":        3,
+	}
+)
+
+func (x BaselineDifferenceKind) Enum() *BaselineDifferenceKind {
+	p := new(BaselineDifferenceKind)
+	*p = x
+	return p
+}
+
+func (x BaselineDifferenceKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BaselineDifferenceKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_mitmflow_v1_mitmflow_proto_enumTypes[1].Descriptor()
+}
+
+func (BaselineDifferenceKind) Type() protoreflect.EnumType {
+	return &file_mitmflow_v1_mitmflow_proto_enumTypes[1]
+}
+
+func (x BaselineDifferenceKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+type AuditAction int32
+
+const (
+	AuditAction_AUDIT_ACTION_UNSPECIFIED AuditAction = 0
+	AuditAction_AUDIT_ACTION_DELETE      AuditAction = 1
+	// Every flow was deleted at once.
+	AuditAction_AUDIT_ACTION_DELETE_ALL      AuditAction = 2
+	AuditAction_AUDIT_ACTION_PIN             AuditAction = 3
+	AuditAction_AUDIT_ACTION_UNPIN           AuditAction = 4
+	AuditAction_AUDIT_ACTION_NOTE            AuditAction = 5
+	AuditAction_AUDIT_ACTION_UPDATE_METADATA AuditAction = 6
+	// Flows were exported with ExportFlows, shared with CreateShareBundle or
+	// backed up with CreateBackup. The detail says which.
+	AuditAction_AUDIT_ACTION_EXPORT  AuditAction = 7
+	AuditAction_AUDIT_ACTION_RESTORE AuditAction = 8
+	// Flows were added with ImportFlows.
+	AuditAction_AUDIT_ACTION_IMPORT AuditAction = 9
+	// The proxy rules were replaced. The detail counts the overrides and
+	// throttles.
+	AuditAction_AUDIT_ACTION_SET_PROXY_RULES AuditAction = 10
+	// The baseline was set from a session, whose filter is the detail, or
+	// cleared, when the detail is "cleared".
+	AuditAction_AUDIT_ACTION_SET_BASELINE AuditAction = 11
+)
+
+// Enum value maps for AuditAction.
+var (
+	AuditAction_name = map[int32]string{
+		0:  "AUDIT_ACTION_UNSPECIFIED",
+		1:  "AUDIT_ACTION_DELETE",
+		2:  "AUDIT_ACTION_DELETE_ALL",
+		3:  "AUDIT_ACTION_PIN",
+		4:  "AUDIT_ACTION_UNPIN",
+		5:  "AUDIT_ACTION_NOTE",
+		6:  "AUDIT_ACTION_UPDATE_METADATA",
+		7:  "AUDIT_ACTION_EXPORT",
+		8:  "AUDIT_ACTION_RESTORE",
+		9:  "AUDIT_ACTION_IMPORT",
+		10: "AUDIT_ACTION_SET_PROXY_RULES",
+		11: "AUDIT_ACTION_SET_BASELINE",
+	}
+	AuditAction_value = map[string]int32{
+		"AUDIT_ACTION_UNSPECIFIED":     0,
+		"AUDIT_ACTION_DELETE":          1,
+		"AUDIT_ACTION_DELETE_ALL":      2,
+		"AUDIT_ACTION_PIN":             3,
+		"AUDIT_ACTION_UNPIN":           4,
+		"AUDIT_ACTION_NOTE":            5,
+		"AUDIT_ACTION_UPDATE_METADATA": 6,
+		"AUDIT_ACTION_EXPORT":          7,
+		"AUDIT_ACTION_RESTORE":         8,
+		"AUDIT_ACTION_IMPORT":          9,
+		"AUDIT_ACTION_SET_PROXY_RULES": 10,
+		"AUDIT_ACTION_SET_BASELINE":    11,
+	}
+)
+
+func (x AuditAction) Enum() *AuditAction {
+	p := new(AuditAction)
+	*p = x
+	return p
+}
+
+func (x AuditAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuditAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_mitmflow_v1_mitmflow_proto_enumTypes[2].Descriptor()
+}
+
+func (AuditAction) Type() protoreflect.EnumType {
+	return &file_mitmflow_v1_mitmflow_proto_enumTypes[2]
+}
+
+func (x AuditAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+type CookieEventType int32
+
+const (
+	CookieEventType_COOKIE_EVENT_TYPE_UNSPECIFIED CookieEventType = 0
+	// A response set the cookie.
+	CookieEventType_COOKIE_EVENT_TYPE_SET CookieEventType = 1
+	// A request sent the cookie.
+	CookieEventType_COOKIE_EVENT_TYPE_SENT CookieEventType = 2
+	// A response expired the cookie.
+	CookieEventType_COOKIE_EVENT_TYPE_DELETED CookieEventType = 3
+)
+
+// Enum value maps for CookieEventType.
+var (
+	CookieEventType_name = map[int32]string{
+		0: "COOKIE_EVENT_TYPE_UNSPECIFIED",
+		1: "COOKIE_EVENT_TYPE_SET",
+		2: "COOKIE_EVENT_TYPE_SENT",
+		3: "COOKIE_EVENT_TYPE_DELETED",
+	}
+	CookieEventType_value = map[string]int32{
+		"COOKIE_EVENT_TYPE_UNSPECIFIED": 0,
+		"COOKIE_EVENT_TYPE_SET":         1,
+		"COOKIE_EVENT_TYPE_SENT":        2,
+		"COOKIE_EVENT_TYPE_DELETED":     3,
+	}
+)
+
+func (x CookieEventType) Enum() *CookieEventType {
+	p := new(CookieEventType)
+	*p = x
+	return p
+}
+
+func (x CookieEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CookieEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_mitmflow_v1_mitmflow_proto_enumTypes[3].Descriptor()
+}
+
+func (CookieEventType) Type() protoreflect.EnumType {
+	return &file_mitmflow_v1_mitmflow_proto_enumTypes[3]
+}
+
+func (x CookieEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+type TopFlowsOrder int32
+
+const (
+	TopFlowsOrder_TOP_FLOWS_ORDER_UNSPECIFIED TopFlowsOrder = 0
+	// By duration.
+	TopFlowsOrder_TOP_FLOWS_ORDER_SLOWEST TopFlowsOrder = 1
+	// By the bytes sent and received together.
+	TopFlowsOrder_TOP_FLOWS_ORDER_LARGEST TopFlowsOrder = 2
+)
+
+// Enum value maps for TopFlowsOrder.
+var (
+	TopFlowsOrder_name = map[int32]string{
+		0: "TOP_FLOWS_ORDER_UNSPECIFIED",
+		1: "TOP_FLOWS_ORDER_SLOWEST",
+		2: "TOP_FLOWS_ORDER_LARGEST",
+	}
+	TopFlowsOrder_value = map[string]int32{
+		"TOP_FLOWS_ORDER_UNSPECIFIED": 0,
+		"TOP_FLOWS_ORDER_SLOWEST":     1,
+		"TOP_FLOWS_ORDER_LARGEST":     2,
+	}
+)
+
+func (x TopFlowsOrder) Enum() *TopFlowsOrder {
+	p := new(TopFlowsOrder)
+	*p = x
+	return p
+}
+
+func (x TopFlowsOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TopFlowsOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_mitmflow_v1_mitmflow_proto_enumTypes[4].Descriptor()
+}
+
+func (TopFlowsOrder) Type() protoreflect.EnumType {
+	return &file_mitmflow_v1_mitmflow_proto_enumTypes[4]
+}
+
+func (x TopFlowsOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+type FlowDifferenceKind int32
+
+const (
+	FlowDifferenceKind_FLOW_DIFFERENCE_KIND_UNSPECIFIED FlowDifferenceKind = 0
+	FlowDifferenceKind_FLOW_DIFFERENCE_KIND_STATUS      FlowDifferenceKind = 1
+	FlowDifferenceKind_FLOW_DIFFERENCE_KIND_QUERY       FlowDifferenceKind = 2
+	FlowDifferenceKind_FLOW_DIFFERENCE_KIND_HEADER      FlowDifferenceKind = 3
+	FlowDifferenceKind_FLOW_DIFFERENCE_KIND_BODY        FlowDifferenceKind = 4
+)
+
+// Enum value maps for FlowDifferenceKind.
+var (
+	FlowDifferenceKind_name = map[int32]string{
+		0: "FLOW_DIFFERENCE_KIND_UNSPECIFIED",
+		1: "FLOW_DIFFERENCE_KIND_STATUS",
+		2: "FLOW_DIFFERENCE_KIND_QUERY",
+		3: "FLOW_DIFFERENCE_KIND_HEADER",
+		4: "FLOW_DIFFERENCE_KIND_BODY",
+	}
+	FlowDifferenceKind_value = map[string]int32{
+		"FLOW_DIFFERENCE_KIND_UNSPECIFIED": 0,
+		"FLOW_DIFFERENCE_KIND_STATUS":      1,
+		"FLOW_DIFFERENCE_KIND_QUERY":       2,
+		"FLOW_DIFFERENCE_KIND_HEADER":      3,
+		"FLOW_DIFFERENCE_KIND_BODY":        4,
+	}
+)
+
+func (x FlowDifferenceKind) Enum() *FlowDifferenceKind {
+	p := new(FlowDifferenceKind)
+	*p = x
+	return p
+}
+
+func (x FlowDifferenceKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FlowDifferenceKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_mitmflow_v1_mitmflow_proto_enumTypes[5].Descriptor()
+}
+
+func (FlowDifferenceKind) Type() protoreflect.EnumType {
+	return &file_mitmflow_v1_mitmflow_proto_enumTypes[5]
+}
+
+func (x FlowDifferenceKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+type FindingSeverity int32
+
+const (
+	FindingSeverity_FINDING_SEVERITY_UNSPECIFIED FindingSeverity = 0
+	FindingSeverity_FINDING_SEVERITY_INFO        FindingSeverity = 1
+	FindingSeverity_FINDING_SEVERITY_LOW         FindingSeverity = 2
+	FindingSeverity_FINDING_SEVERITY_MEDIUM      FindingSeverity = 3
+)
+
+// Enum value maps for FindingSeverity.
+var (
+	FindingSeverity_name = map[int32]string{
+		0: "FINDING_SEVERITY_UNSPECIFIED",
+		1: "FINDING_SEVERITY_INFO",
+		2: "FINDING_SEVERITY_LOW",
+		3: "FINDING_SEVERITY_MEDIUM",
+	}
+	FindingSeverity_value = map[string]int32{
+		"FINDING_SEVERITY_UNSPECIFIED": 0,
+		"FINDING_SEVERITY_INFO":        1,
+		"FINDING_SEVERITY_LOW":         2,
+		"FINDING_SEVERITY_MEDIUM":      3,
+	}
+)
+
+func (x FindingSeverity) Enum() *FindingSeverity {
+	p := new(FindingSeverity)
+	*p = x
+	return p
+}
+
+func (x FindingSeverity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FindingSeverity) Descriptor() protoreflect.EnumDescriptor {
+	return file_mitmflow_v1_mitmflow_proto_enumTypes[6].Descriptor()
+}
+
+func (FindingSeverity) Type() protoreflect.EnumType {
+	return &file_mitmflow_v1_mitmflow_proto_enumTypes[6]
+}
+
+func (x FindingSeverity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+type DeviceType int32
+
+const (
+	DeviceType_DEVICE_TYPE_UNSPECIFIED DeviceType = 0
+	DeviceType_DEVICE_TYPE_DESKTOP     DeviceType = 1
+	DeviceType_DEVICE_TYPE_MOBILE      DeviceType = 2
+	DeviceType_DEVICE_TYPE_TABLET      DeviceType = 3
+	DeviceType_DEVICE_TYPE_BOT         DeviceType = 4
+)
+
+// Enum value maps for DeviceType.
+var (
+	DeviceType_name = map[int32]string{
+		0: "DEVICE_TYPE_UNSPECIFIED",
+		1: "DEVICE_TYPE_DESKTOP",
+		2: "DEVICE_TYPE_MOBILE",
+		3: "DEVICE_TYPE_TABLET",
+		4: "DEVICE_TYPE_BOT",
+	}
+	DeviceType_value = map[string]int32{
+		"DEVICE_TYPE_UNSPECIFIED": 0,
+		"DEVICE_TYPE_DESKTOP":     1,
+		"DEVICE_TYPE_MOBILE":      2,
+		"DEVICE_TYPE_TABLET":      3,
+		"DEVICE_TYPE_BOT":         4,
+	}
+)
+
+func (x DeviceType) Enum() *DeviceType {
+	p := new(DeviceType)
+	*p = x
+	return p
+}
+
+func (x DeviceType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeviceType) Descriptor() protoreflect.EnumDescriptor {
+	return file_mitmflow_v1_mitmflow_proto_enumTypes[7].Descriptor()
+}
+
+func (DeviceType) Type() protoreflect.EnumType {
+	return &file_mitmflow_v1_mitmflow_proto_enumTypes[7]
+}
+
+func (x DeviceType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+type DnsAnomalyKind int32
+
+const (
+	DnsAnomalyKind_DNS_ANOMALY_KIND_UNSPECIFIED DnsAnomalyKind = 0
+	// Many NXDOMAIN answers to the same client in a short time, as from a
+	// domain generation algorithm or a scan.
+	DnsAnomalyKind_DNS_ANOMALY_KIND_NXDOMAIN_BURST DnsAnomalyKind = 1
+	// A label or name close to the limits DNS allows.
+	DnsAnomalyKind_DNS_ANOMALY_KIND_LONG_LABEL DnsAnomalyKind = 2
+	// A label that looks random, like data encoded into a name to tunnel it.
+	DnsAnomalyKind_DNS_ANOMALY_KIND_HIGH_ENTROPY_NAME DnsAnomalyKind = 3
+	// A query type that ordinary clients rarely ask for, like NULL or ANY.
+	DnsAnomalyKind_DNS_ANOMALY_KIND_UNUSUAL_QTYPE DnsAnomalyKind = 4
+)
+
+// Enum value maps for DnsAnomalyKind.
+var (
+	DnsAnomalyKind_name = map[int32]string{
+		0: "DNS_ANOMALY_KIND_UNSPECIFIED",
+		1: "DNS_ANOMALY_KIND_NXDOMAIN_BURST",
+		2: "DNS_ANOMALY_KIND_LONG_LABEL",
+		3: "DNS_ANOMALY_KIND_HIGH_ENTROPY_NAME",
+		4: "DNS_ANOMALY_KIND_UNUSUAL_QTYPE",
+	}
+	DnsAnomalyKind_value = map[string]int32{
+		"DNS_ANOMALY_KIND_UNSPECIFIED":       0,
+		"DNS_ANOMALY_KIND_NXDOMAIN_BURST":    1,
+		"DNS_ANOMALY_KIND_LONG_LABEL":        2,
+		"DNS_ANOMALY_KIND_HIGH_ENTROPY_NAME": 3,
+		"DNS_ANOMALY_KIND_UNUSUAL_QTYPE":     4,
+	}
+)
+
+func (x DnsAnomalyKind) Enum() *DnsAnomalyKind {
+	p := new(DnsAnomalyKind)
+	*p = x
+	return p
+}
+
+func (x DnsAnomalyKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DnsAnomalyKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_mitmflow_v1_mitmflow_proto_enumTypes[8].Descriptor()
+}
+
+func (DnsAnomalyKind) Type() protoreflect.EnumType {
+	return &file_mitmflow_v1_mitmflow_proto_enumTypes[8]
+}
+
+func (x DnsAnomalyKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+type HostnameSource int32
+
+const (
+	HostnameSource_HOSTNAME_SOURCE_UNSPECIFIED HostnameSource = 0
+	// An answer for the IP in a DNS flow captured shortly before the flow.
+	HostnameSource_HOSTNAME_SOURCE_DNS_FLOW HostnameSource = 1
+	// A reverse DNS (PTR) lookup of the IP.
+	HostnameSource_HOSTNAME_SOURCE_REVERSE_DNS HostnameSource = 2
+)
+
+// Enum value maps for HostnameSource.
+var (
+	HostnameSource_name = map[int32]string{
+		0: "HOSTNAME_SOURCE_UNSPECIFIED",
+		1: "HOSTNAME_SOURCE_DNS_FLOW",
+		2: "HOSTNAME_SOURCE_REVERSE_DNS",
+	}
+	HostnameSource_value = map[string]int32{
+		"HOSTNAME_SOURCE_UNSPECIFIED": 0,
+		"HOSTNAME_SOURCE_DNS_FLOW":    1,
+		"HOSTNAME_SOURCE_REVERSE_DNS": 2,
+	}
+)
+
+func (x HostnameSource) Enum() *HostnameSource {
+	p := new(HostnameSource)
+	*p = x
+	return p
+}
+
+func (x HostnameSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HostnameSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_mitmflow_v1_mitmflow_proto_enumTypes[9].Descriptor()
+}
+
+func (HostnameSource) Type() protoreflect.EnumType {
+	return &file_mitmflow_v1_mitmflow_proto_enumTypes[9]
+}
+
+func (x HostnameSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
 type FlowFilter struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_FilterText  *string                `protobuf:"bytes,1,opt,name=filter_text,json=filterText"`
-	xxx_hidden_Pinned      bool                   `protobuf:"varint,2,opt,name=pinned"`
-	xxx_hidden_HasNote     bool                   `protobuf:"varint,3,opt,name=has_note,json=hasNote"`
-	xxx_hidden_FlowTypes   []string               `protobuf:"bytes,4,rep,name=flow_types,json=flowTypes"`
-	xxx_hidden_ClientIps   []string               `protobuf:"bytes,5,rep,name=client_ips,json=clientIps"`
-	xxx_hidden_Http        *HttpFilter            `protobuf:"bytes,6,opt,name=http"`
-	xxx_hidden_FlowIds     []string               `protobuf:"bytes,7,rep,name=flow_ids,json=flowIds"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_FilterText      *string                `protobuf:"bytes,1,opt,name=filter_text,json=filterText"`
+	xxx_hidden_Pinned          bool                   `protobuf:"varint,2,opt,name=pinned"`
+	xxx_hidden_HasNote         bool                   `protobuf:"varint,3,opt,name=has_note,json=hasNote"`
+	xxx_hidden_FlowTypes       []string               `protobuf:"bytes,4,rep,name=flow_types,json=flowTypes"`
+	xxx_hidden_ClientIps       []string               `protobuf:"bytes,5,rep,name=client_ips,json=clientIps"`
+	xxx_hidden_Http            *HttpFilter            `protobuf:"bytes,6,opt,name=http"`
+	xxx_hidden_FlowIds         []string               `protobuf:"bytes,7,rep,name=flow_ids,json=flowIds"`
+	xxx_hidden_ServerCountries []string               `protobuf:"bytes,8,rep,name=server_countries,json=serverCountries"`
+	xxx_hidden_DnsAnomaly      []DnsAnomalyKind       `protobuf:"varint,9,rep,packed,name=dns_anomaly,json=dnsAnomaly,enum=mitmflow.v1.DnsAnomalyKind"`
+	xxx_hidden_Protocols       []string               `protobuf:"bytes,10,rep,name=protocols"`
+	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
+	XXX_presence               [1]uint32
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
 }
 
 func (x *FlowFilter) Reset() {
@@ -159,19 +671,40 @@ func (x *FlowFilter) GetFlowIds() []string {
 	return nil
 }
 
+func (x *FlowFilter) GetServerCountries() []string {
+	if x != nil {
+		return x.xxx_hidden_ServerCountries
+	}
+	return nil
+}
+
+func (x *FlowFilter) GetDnsAnomaly() []DnsAnomalyKind {
+	if x != nil {
+		return x.xxx_hidden_DnsAnomaly
+	}
+	return nil
+}
+
+func (x *FlowFilter) GetProtocols() []string {
+	if x != nil {
+		return x.xxx_hidden_Protocols
+	}
+	return nil
+}
+
 func (x *FlowFilter) SetFilterText(v string) {
 	x.xxx_hidden_FilterText = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 10)
 }
 
 func (x *FlowFilter) SetPinned(v bool) {
 	x.xxx_hidden_Pinned = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 10)
 }
 
 func (x *FlowFilter) SetHasNote(v bool) {
 	x.xxx_hidden_HasNote = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 10)
 }
 
 func (x *FlowFilter) SetFlowTypes(v []string) {
@@ -190,6 +723,18 @@ func (x *FlowFilter) SetFlowIds(v []string) {
 	x.xxx_hidden_FlowIds = v
 }
 
+func (x *FlowFilter) SetServerCountries(v []string) {
+	x.xxx_hidden_ServerCountries = v
+}
+
+func (x *FlowFilter) SetDnsAnomaly(v []DnsAnomalyKind) {
+	x.xxx_hidden_DnsAnomaly = v
+}
+
+func (x *FlowFilter) SetProtocols(v []string) {
+	x.xxx_hidden_Protocols = v
+}
+
 func (x *FlowFilter) HasFilterText() bool {
 	if x == nil {
 		return false
@@ -247,6 +792,13 @@ type FlowFilter_builder struct {
 	ClientIps  []string
 	Http       *HttpFilter
 	FlowIds    []string
+	// ISO 3166-1 country codes of the server, e.g. "DE". Needs a GeoIP database.
+	ServerCountries []string
+	// Only DNS flows flagged with any of these anomalies.
+	DnsAnomaly []DnsAnomalyKind
+	// Only flows with any of these protocol labels, e.g. "grpc" or "tcp-redis".
+	// See HTTPFlowExtra.protocol.
+	Protocols []string
 }
 
 func (b0 FlowFilter_builder) Build() *FlowFilter {
@@ -254,31 +806,41 @@ func (b0 FlowFilter_builder) Build() *FlowFilter {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.FilterText != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 10)
 		x.xxx_hidden_FilterText = b.FilterText
 	}
 	if b.Pinned != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 10)
 		x.xxx_hidden_Pinned = *b.Pinned
 	}
 	if b.HasNote != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 10)
 		x.xxx_hidden_HasNote = *b.HasNote
 	}
 	x.xxx_hidden_FlowTypes = b.FlowTypes
 	x.xxx_hidden_ClientIps = b.ClientIps
 	x.xxx_hidden_Http = b.Http
 	x.xxx_hidden_FlowIds = b.FlowIds
+	x.xxx_hidden_ServerCountries = b.ServerCountries
+	x.xxx_hidden_DnsAnomaly = b.DnsAnomaly
+	x.xxx_hidden_Protocols = b.Protocols
 	return m0
 }
 
 type HttpFilter struct {
-	state                   protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Methods      []string               `protobuf:"bytes,1,rep,name=methods"`
-	xxx_hidden_ContentTypes []string               `protobuf:"bytes,2,rep,name=content_types,json=contentTypes"`
-	xxx_hidden_StatusCodes  []string               `protobuf:"bytes,3,rep,name=status_codes,json=statusCodes"`
-	unknownFields           protoimpl.UnknownFields
-	sizeCache               protoimpl.SizeCache
+	state                          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Methods             []string               `protobuf:"bytes,1,rep,name=methods"`
+	xxx_hidden_ContentTypes        []string               `protobuf:"bytes,2,rep,name=content_types,json=contentTypes"`
+	xxx_hidden_StatusCodes         []string               `protobuf:"bytes,3,rep,name=status_codes,json=statusCodes"`
+	xxx_hidden_ClientFamilies      []string               `protobuf:"bytes,4,rep,name=client_families,json=clientFamilies"`
+	xxx_hidden_MinSecuritySeverity FindingSeverity        `protobuf:"varint,5,opt,name=min_security_severity,json=minSecuritySeverity,enum=mitmflow.v1.FindingSeverity"`
+	xxx_hidden_BodySha256          *string                `protobuf:"bytes,6,opt,name=body_sha256,json=bodySha256"`
+	xxx_hidden_BodyQueries         *[]*BodyQuery          `protobuf:"bytes,7,rep,name=body_queries,json=bodyQueries"`
+	xxx_hidden_GrpcStatuses        []string               `protobuf:"bytes,8,rep,name=grpc_statuses,json=grpcStatuses"`
+	XXX_raceDetectHookData         protoimpl.RaceDetectHookData
+	XXX_presence                   [1]uint32
+	unknownFields                  protoimpl.UnknownFields
+	sizeCache                      protoimpl.SizeCache
 }
 
 func (x *HttpFilter) Reset() {
@@ -327,18 +889,106 @@ func (x *HttpFilter) GetStatusCodes() []string {
 	return nil
 }
 
-func (x *HttpFilter) SetMethods(v []string) {
-	x.xxx_hidden_Methods = v
+func (x *HttpFilter) GetClientFamilies() []string {
+	if x != nil {
+		return x.xxx_hidden_ClientFamilies
+	}
+	return nil
 }
 
-func (x *HttpFilter) SetContentTypes(v []string) {
-	x.xxx_hidden_ContentTypes = v
+func (x *HttpFilter) GetMinSecuritySeverity() FindingSeverity {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 4) {
+			return x.xxx_hidden_MinSecuritySeverity
+		}
+	}
+	return FindingSeverity_FINDING_SEVERITY_UNSPECIFIED
+}
+
+func (x *HttpFilter) GetBodySha256() string {
+	if x != nil {
+		if x.xxx_hidden_BodySha256 != nil {
+			return *x.xxx_hidden_BodySha256
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *HttpFilter) GetBodyQueries() []*BodyQuery {
+	if x != nil {
+		if x.xxx_hidden_BodyQueries != nil {
+			return *x.xxx_hidden_BodyQueries
+		}
+	}
+	return nil
+}
+
+func (x *HttpFilter) GetGrpcStatuses() []string {
+	if x != nil {
+		return x.xxx_hidden_GrpcStatuses
+	}
+	return nil
+}
+
+func (x *HttpFilter) SetMethods(v []string) {
+	x.xxx_hidden_Methods = v
+}
+
+func (x *HttpFilter) SetContentTypes(v []string) {
+	x.xxx_hidden_ContentTypes = v
 }
 
 func (x *HttpFilter) SetStatusCodes(v []string) {
 	x.xxx_hidden_StatusCodes = v
 }
 
+func (x *HttpFilter) SetClientFamilies(v []string) {
+	x.xxx_hidden_ClientFamilies = v
+}
+
+func (x *HttpFilter) SetMinSecuritySeverity(v FindingSeverity) {
+	x.xxx_hidden_MinSecuritySeverity = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 8)
+}
+
+func (x *HttpFilter) SetBodySha256(v string) {
+	x.xxx_hidden_BodySha256 = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 8)
+}
+
+func (x *HttpFilter) SetBodyQueries(v []*BodyQuery) {
+	x.xxx_hidden_BodyQueries = &v
+}
+
+func (x *HttpFilter) SetGrpcStatuses(v []string) {
+	x.xxx_hidden_GrpcStatuses = v
+}
+
+func (x *HttpFilter) HasMinSecuritySeverity() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *HttpFilter) HasBodySha256() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *HttpFilter) ClearMinSecuritySeverity() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_MinSecuritySeverity = FindingSeverity_FINDING_SEVERITY_UNSPECIFIED
+}
+
+func (x *HttpFilter) ClearBodySha256() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_BodySha256 = nil
+}
+
 type HttpFilter_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -347,6 +997,18 @@ type HttpFilter_builder struct {
 	ContentTypes []string
 	// e.g. "200", "4xx", "200-299"
 	StatusCodes []string
+	// Browser, OS or device type parsed from the User-Agent, e.g. "Chrome",
+	// "iOS", "mobile". Matched case-insensitively.
+	ClientFamilies []string
+	// Only flows with a security finding at least this severe.
+	MinSecuritySeverity *FindingSeverity
+	// Only flows whose request or response body has this SHA-256, in hex.
+	BodySha256 *string
+	// Only flows with JSON bodies that every query matches.
+	BodyQueries []*BodyQuery
+	// Only gRPC responses with one of these statuses, by name, e.g.
+	// "UNAVAILABLE". Matched case-insensitively.
+	GrpcStatuses []string
 }
 
 func (b0 HttpFilter_builder) Build() *HttpFilter {
@@ -356,6 +1018,165 @@ func (b0 HttpFilter_builder) Build() *HttpFilter {
 	x.xxx_hidden_Methods = b.Methods
 	x.xxx_hidden_ContentTypes = b.ContentTypes
 	x.xxx_hidden_StatusCodes = b.StatusCodes
+	x.xxx_hidden_ClientFamilies = b.ClientFamilies
+	if b.MinSecuritySeverity != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 8)
+		x.xxx_hidden_MinSecuritySeverity = *b.MinSecuritySeverity
+	}
+	if b.BodySha256 != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 8)
+		x.xxx_hidden_BodySha256 = b.BodySha256
+	}
+	x.xxx_hidden_BodyQueries = &b.BodyQueries
+	x.xxx_hidden_GrpcStatuses = b.GrpcStatuses
+	return m0
+}
+
+// BodyQuery matches a JSON body by the values a JSONPath expression selects,
+// e.g. "$.items[*].status" equal to "failed". Paths support .name, ['name'],
+// [index] (negative counts from the end), [*], .* and ..name.
+type BodyQuery struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Path        *string                `protobuf:"bytes,1,opt,name=path"`
+	xxx_hidden_Equals      *string                `protobuf:"bytes,2,opt,name=equals"`
+	xxx_hidden_Request     bool                   `protobuf:"varint,3,opt,name=request"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *BodyQuery) Reset() {
+	*x = BodyQuery{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BodyQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BodyQuery) ProtoMessage() {}
+
+func (x *BodyQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BodyQuery) GetPath() string {
+	if x != nil {
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BodyQuery) GetEquals() string {
+	if x != nil {
+		if x.xxx_hidden_Equals != nil {
+			return *x.xxx_hidden_Equals
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BodyQuery) GetRequest() bool {
+	if x != nil {
+		return x.xxx_hidden_Request
+	}
+	return false
+}
+
+func (x *BodyQuery) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *BodyQuery) SetEquals(v string) {
+	x.xxx_hidden_Equals = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *BodyQuery) SetRequest(v bool) {
+	x.xxx_hidden_Request = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *BodyQuery) HasPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *BodyQuery) HasEquals() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *BodyQuery) HasRequest() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *BodyQuery) ClearPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Path = nil
+}
+
+func (x *BodyQuery) ClearEquals() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Equals = nil
+}
+
+func (x *BodyQuery) ClearRequest() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Request = false
+}
+
+type BodyQuery_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Path *string
+	// Matches when any selected value equals this: strings as is, numbers
+	// numerically and anything else as JSON, e.g. "true" or "null". Without it,
+	// the path only has to select something.
+	Equals *string
+	// Query the request body instead of the response body.
+	Request *bool
+}
+
+func (b0 BodyQuery_builder) Build() *BodyQuery {
+	m0 := &BodyQuery{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Path = b.Path
+	}
+	if b.Equals != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Equals = b.Equals
+	}
+	if b.Request != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Request = *b.Request
+	}
 	return m0
 }
 
@@ -370,7 +1191,7 @@ type GetFlowRequest struct {
 
 func (x *GetFlowRequest) Reset() {
 	*x = GetFlowRequest{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[2]
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -382,7 +1203,7 @@ func (x *GetFlowRequest) String() string {
 func (*GetFlowRequest) ProtoMessage() {}
 
 func (x *GetFlowRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[2]
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -446,7 +1267,7 @@ type GetFlowResponse struct {
 
 func (x *GetFlowResponse) Reset() {
 	*x = GetFlowResponse{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[3]
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -458,7 +1279,7 @@ func (x *GetFlowResponse) String() string {
 func (*GetFlowResponse) ProtoMessage() {}
 
 func (x *GetFlowResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[3]
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -505,31 +1326,31 @@ func (b0 GetFlowResponse_builder) Build() *GetFlowResponse {
 	return m0
 }
 
-type GetFlowsRequest struct {
+type GetFlowBodyRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Filter      *FlowFilter            `protobuf:"bytes,1,opt,name=filter"`
-	xxx_hidden_Limit       int32                  `protobuf:"varint,2,opt,name=limit"`
+	xxx_hidden_FlowId      *string                `protobuf:"bytes,1,opt,name=flow_id,json=flowId"`
+	xxx_hidden_Response    bool                   `protobuf:"varint,2,opt,name=response"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *GetFlowsRequest) Reset() {
-	*x = GetFlowsRequest{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[4]
+func (x *GetFlowBodyRequest) Reset() {
+	*x = GetFlowBodyRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetFlowsRequest) String() string {
+func (x *GetFlowBodyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFlowsRequest) ProtoMessage() {}
+func (*GetFlowBodyRequest) ProtoMessage() {}
 
-func (x *GetFlowsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[4]
+func (x *GetFlowBodyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -540,93 +1361,105 @@ func (x *GetFlowsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *GetFlowsRequest) GetFilter() *FlowFilter {
+func (x *GetFlowBodyRequest) GetFlowId() string {
 	if x != nil {
-		return x.xxx_hidden_Filter
+		if x.xxx_hidden_FlowId != nil {
+			return *x.xxx_hidden_FlowId
+		}
+		return ""
 	}
-	return nil
+	return ""
 }
 
-func (x *GetFlowsRequest) GetLimit() int32 {
+func (x *GetFlowBodyRequest) GetResponse() bool {
 	if x != nil {
-		return x.xxx_hidden_Limit
+		return x.xxx_hidden_Response
 	}
-	return 0
+	return false
 }
 
-func (x *GetFlowsRequest) SetFilter(v *FlowFilter) {
-	x.xxx_hidden_Filter = v
+func (x *GetFlowBodyRequest) SetFlowId(v string) {
+	x.xxx_hidden_FlowId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
 }
 
-func (x *GetFlowsRequest) SetLimit(v int32) {
-	x.xxx_hidden_Limit = v
+func (x *GetFlowBodyRequest) SetResponse(v bool) {
+	x.xxx_hidden_Response = v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
 }
 
-func (x *GetFlowsRequest) HasFilter() bool {
+func (x *GetFlowBodyRequest) HasFlowId() bool {
 	if x == nil {
 		return false
 	}
-	return x.xxx_hidden_Filter != nil
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *GetFlowsRequest) HasLimit() bool {
+func (x *GetFlowBodyRequest) HasResponse() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *GetFlowsRequest) ClearFilter() {
-	x.xxx_hidden_Filter = nil
+func (x *GetFlowBodyRequest) ClearFlowId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_FlowId = nil
 }
 
-func (x *GetFlowsRequest) ClearLimit() {
+func (x *GetFlowBodyRequest) ClearResponse() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_Limit = 0
+	x.xxx_hidden_Response = false
 }
 
-type GetFlowsRequest_builder struct {
+type GetFlowBodyRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Filter *FlowFilter
-	Limit  *int32
+	FlowId *string
+	// Return the response body instead of the request body.
+	Response *bool
 }
 
-func (b0 GetFlowsRequest_builder) Build() *GetFlowsRequest {
-	m0 := &GetFlowsRequest{}
+func (b0 GetFlowBodyRequest_builder) Build() *GetFlowBodyRequest {
+	m0 := &GetFlowBodyRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Filter = b.Filter
-	if b.Limit != nil {
+	if b.FlowId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_FlowId = b.FlowId
+	}
+	if b.Response != nil {
 		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
-		x.xxx_hidden_Limit = *b.Limit
+		x.xxx_hidden_Response = *b.Response
 	}
 	return m0
 }
 
-type GetFlowsResponse struct {
-	state           protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Flow *FlowSummary           `protobuf:"bytes,1,opt,name=flow"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type GetFlowBodyResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Content     []byte                 `protobuf:"bytes,1,opt,name=content"`
+	xxx_hidden_ContentType *string                `protobuf:"bytes,2,opt,name=content_type,json=contentType"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *GetFlowsResponse) Reset() {
-	*x = GetFlowsResponse{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[5]
+func (x *GetFlowBodyResponse) Reset() {
+	*x = GetFlowBodyResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetFlowsResponse) String() string {
+func (x *GetFlowBodyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFlowsResponse) ProtoMessage() {}
+func (*GetFlowBodyResponse) ProtoMessage() {}
 
-func (x *GetFlowsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[5]
+func (x *GetFlowBodyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -637,67 +1470,109 @@ func (x *GetFlowsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *GetFlowsResponse) GetFlow() *FlowSummary {
+func (x *GetFlowBodyResponse) GetContent() []byte {
 	if x != nil {
-		return x.xxx_hidden_Flow
+		return x.xxx_hidden_Content
 	}
 	return nil
 }
 
-func (x *GetFlowsResponse) SetFlow(v *FlowSummary) {
-	x.xxx_hidden_Flow = v
+func (x *GetFlowBodyResponse) GetContentType() string {
+	if x != nil {
+		if x.xxx_hidden_ContentType != nil {
+			return *x.xxx_hidden_ContentType
+		}
+		return ""
+	}
+	return ""
 }
 
-func (x *GetFlowsResponse) HasFlow() bool {
-	if x == nil {
-		return false
+func (x *GetFlowBodyResponse) SetContent(v []byte) {
+	if v == nil {
+		v = []byte{}
 	}
-	return x.xxx_hidden_Flow != nil
+	x.xxx_hidden_Content = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
 }
 
-func (x *GetFlowsResponse) ClearFlow() {
-	x.xxx_hidden_Flow = nil
+func (x *GetFlowBodyResponse) SetContentType(v string) {
+	x.xxx_hidden_ContentType = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
 }
 
-type GetFlowsResponse_builder struct {
+func (x *GetFlowBodyResponse) HasContent() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *GetFlowBodyResponse) HasContentType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *GetFlowBodyResponse) ClearContent() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Content = nil
+}
+
+func (x *GetFlowBodyResponse) ClearContentType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_ContentType = nil
+}
+
+type GetFlowBodyResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Flow *FlowSummary
+	Content     []byte
+	ContentType *string
 }
 
-func (b0 GetFlowsResponse_builder) Build() *GetFlowsResponse {
-	m0 := &GetFlowsResponse{}
+func (b0 GetFlowBodyResponse_builder) Build() *GetFlowBodyResponse {
+	m0 := &GetFlowBodyResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Flow = b.Flow
+	if b.Content != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Content = b.Content
+	}
+	if b.ContentType != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_ContentType = b.ContentType
+	}
 	return m0
 }
 
-type StreamFlowsRequest struct {
-	state                       protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_SinceTimestampNs int64                  `protobuf:"varint,1,opt,name=since_timestamp_ns,json=sinceTimestampNs"`
-	xxx_hidden_Filter           *FlowFilter            `protobuf:"bytes,2,opt,name=filter"`
-	XXX_raceDetectHookData      protoimpl.RaceDetectHookData
-	XXX_presence                [1]uint32
-	unknownFields               protoimpl.UnknownFields
-	sizeCache                   protoimpl.SizeCache
+type GetFlowFramesRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_FlowId      *string                `protobuf:"bytes,1,opt,name=flow_id,json=flowId"`
+	xxx_hidden_Response    bool                   `protobuf:"varint,2,opt,name=response"`
+	xxx_hidden_Offset      int32                  `protobuf:"varint,3,opt,name=offset"`
+	xxx_hidden_Limit       int32                  `protobuf:"varint,4,opt,name=limit"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *StreamFlowsRequest) Reset() {
-	*x = StreamFlowsRequest{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[6]
+func (x *GetFlowFramesRequest) Reset() {
+	*x = GetFlowFramesRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamFlowsRequest) String() string {
+func (x *GetFlowFramesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamFlowsRequest) ProtoMessage() {}
+func (*GetFlowFramesRequest) ProtoMessage() {}
 
-func (x *StreamFlowsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[6]
+func (x *GetFlowFramesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -708,93 +1583,166 @@ func (x *StreamFlowsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *StreamFlowsRequest) GetSinceTimestampNs() int64 {
+func (x *GetFlowFramesRequest) GetFlowId() string {
 	if x != nil {
-		return x.xxx_hidden_SinceTimestampNs
+		if x.xxx_hidden_FlowId != nil {
+			return *x.xxx_hidden_FlowId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetFlowFramesRequest) GetResponse() bool {
+	if x != nil {
+		return x.xxx_hidden_Response
+	}
+	return false
+}
+
+func (x *GetFlowFramesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.xxx_hidden_Offset
 	}
 	return 0
 }
 
-func (x *StreamFlowsRequest) GetFilter() *FlowFilter {
+func (x *GetFlowFramesRequest) GetLimit() int32 {
 	if x != nil {
-		return x.xxx_hidden_Filter
+		return x.xxx_hidden_Limit
 	}
-	return nil
+	return 0
 }
 
-func (x *StreamFlowsRequest) SetSinceTimestampNs(v int64) {
-	x.xxx_hidden_SinceTimestampNs = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+func (x *GetFlowFramesRequest) SetFlowId(v string) {
+	x.xxx_hidden_FlowId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
 }
 
-func (x *StreamFlowsRequest) SetFilter(v *FlowFilter) {
-	x.xxx_hidden_Filter = v
+func (x *GetFlowFramesRequest) SetResponse(v bool) {
+	x.xxx_hidden_Response = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
 }
 
-func (x *StreamFlowsRequest) HasSinceTimestampNs() bool {
+func (x *GetFlowFramesRequest) SetOffset(v int32) {
+	x.xxx_hidden_Offset = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *GetFlowFramesRequest) SetLimit(v int32) {
+	x.xxx_hidden_Limit = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *GetFlowFramesRequest) HasFlowId() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *StreamFlowsRequest) HasFilter() bool {
+func (x *GetFlowFramesRequest) HasResponse() bool {
 	if x == nil {
 		return false
 	}
-	return x.xxx_hidden_Filter != nil
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *StreamFlowsRequest) ClearSinceTimestampNs() {
+func (x *GetFlowFramesRequest) HasOffset() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *GetFlowFramesRequest) HasLimit() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *GetFlowFramesRequest) ClearFlowId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_SinceTimestampNs = 0
+	x.xxx_hidden_FlowId = nil
 }
 
-func (x *StreamFlowsRequest) ClearFilter() {
-	x.xxx_hidden_Filter = nil
+func (x *GetFlowFramesRequest) ClearResponse() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Response = false
 }
 
-type StreamFlowsRequest_builder struct {
+func (x *GetFlowFramesRequest) ClearOffset() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Offset = 0
+}
+
+func (x *GetFlowFramesRequest) ClearLimit() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Limit = 0
+}
+
+type GetFlowFramesRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	SinceTimestampNs *int64
-	Filter           *FlowFilter
+	FlowId *string
+	// Page through the response's frames instead of the request's.
+	Response *bool
+	// The index of the first frame to return.
+	Offset *int32
+	// How many frames to return. Defaults to 100.
+	Limit *int32
 }
 
-func (b0 StreamFlowsRequest_builder) Build() *StreamFlowsRequest {
-	m0 := &StreamFlowsRequest{}
+func (b0 GetFlowFramesRequest_builder) Build() *GetFlowFramesRequest {
+	m0 := &GetFlowFramesRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.SinceTimestampNs != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
-		x.xxx_hidden_SinceTimestampNs = *b.SinceTimestampNs
+	if b.FlowId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_FlowId = b.FlowId
+	}
+	if b.Response != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Response = *b.Response
+	}
+	if b.Offset != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Offset = *b.Offset
+	}
+	if b.Limit != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Limit = *b.Limit
 	}
-	x.xxx_hidden_Filter = b.Filter
 	return m0
 }
 
-type StreamFlowsResponse struct {
-	state               protoimpl.MessageState         `protogen:"opaque.v1"`
-	xxx_hidden_Response isStreamFlowsResponse_Response `protobuf_oneof:"response"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+type GetFlowFramesResponse struct {
+	state                        protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Frames            []string               `protobuf:"bytes,1,rep,name=frames"`
+	xxx_hidden_FrameTimestampsNs []int64                `protobuf:"varint,2,rep,packed,name=frame_timestamps_ns,json=frameTimestampsNs"`
+	xxx_hidden_Total             int32                  `protobuf:"varint,3,opt,name=total"`
+	XXX_raceDetectHookData       protoimpl.RaceDetectHookData
+	XXX_presence                 [1]uint32
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
 }
 
-func (x *StreamFlowsResponse) Reset() {
-	*x = StreamFlowsResponse{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[7]
+func (x *GetFlowFramesResponse) Reset() {
+	*x = GetFlowFramesResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamFlowsResponse) String() string {
+func (x *GetFlowFramesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamFlowsResponse) ProtoMessage() {}
+func (*GetFlowFramesResponse) ProtoMessage() {}
 
-func (x *StreamFlowsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[7]
+func (x *GetFlowFramesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -805,127 +1753,101 @@ func (x *StreamFlowsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *StreamFlowsResponse) GetFlow() *FlowSummary {
+func (x *GetFlowFramesResponse) GetFrames() []string {
 	if x != nil {
-		if x, ok := x.xxx_hidden_Response.(*streamFlowsResponse_Flow); ok {
-			return x.Flow
-		}
+		return x.xxx_hidden_Frames
 	}
 	return nil
 }
 
-func (x *StreamFlowsResponse) SetFlow(v *FlowSummary) {
-	if v == nil {
-		x.xxx_hidden_Response = nil
-		return
+func (x *GetFlowFramesResponse) GetFrameTimestampsNs() []int64 {
+	if x != nil {
+		return x.xxx_hidden_FrameTimestampsNs
 	}
-	x.xxx_hidden_Response = &streamFlowsResponse_Flow{v}
+	return nil
 }
 
-func (x *StreamFlowsResponse) HasResponse() bool {
-	if x == nil {
-		return false
+func (x *GetFlowFramesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.xxx_hidden_Total
 	}
-	return x.xxx_hidden_Response != nil
+	return 0
 }
 
-func (x *StreamFlowsResponse) HasFlow() bool {
-	if x == nil {
-		return false
-	}
-	_, ok := x.xxx_hidden_Response.(*streamFlowsResponse_Flow)
-	return ok
+func (x *GetFlowFramesResponse) SetFrames(v []string) {
+	x.xxx_hidden_Frames = v
 }
 
-func (x *StreamFlowsResponse) ClearResponse() {
-	x.xxx_hidden_Response = nil
+func (x *GetFlowFramesResponse) SetFrameTimestampsNs(v []int64) {
+	x.xxx_hidden_FrameTimestampsNs = v
 }
 
-func (x *StreamFlowsResponse) ClearFlow() {
-	if _, ok := x.xxx_hidden_Response.(*streamFlowsResponse_Flow); ok {
-		x.xxx_hidden_Response = nil
-	}
+func (x *GetFlowFramesResponse) SetTotal(v int32) {
+	x.xxx_hidden_Total = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
 }
 
-const StreamFlowsResponse_Response_not_set_case case_StreamFlowsResponse_Response = 0
-const StreamFlowsResponse_Flow_case case_StreamFlowsResponse_Response = 1
-
-func (x *StreamFlowsResponse) WhichResponse() case_StreamFlowsResponse_Response {
+func (x *GetFlowFramesResponse) HasTotal() bool {
 	if x == nil {
-		return StreamFlowsResponse_Response_not_set_case
-	}
-	switch x.xxx_hidden_Response.(type) {
-	case *streamFlowsResponse_Flow:
-		return StreamFlowsResponse_Flow_case
-	default:
-		return StreamFlowsResponse_Response_not_set_case
+		return false
 	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
 }
 
-type StreamFlowsResponse_builder struct {
+func (x *GetFlowFramesResponse) ClearTotal() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Total = 0
+}
+
+type GetFlowFramesResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	// Fields of oneof xxx_hidden_Response:
-	Flow *FlowSummary
-	// -- end of xxx_hidden_Response
+	Frames []string
+	// When each of frames was received, like
+	// MessageDetails.frame_timestamps_ns.
+	FrameTimestampsNs []int64
+	// The number of frames the message has in all.
+	Total *int32
 }
 
-func (b0 StreamFlowsResponse_builder) Build() *StreamFlowsResponse {
-	m0 := &StreamFlowsResponse{}
+func (b0 GetFlowFramesResponse_builder) Build() *GetFlowFramesResponse {
+	m0 := &GetFlowFramesResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.Flow != nil {
-		x.xxx_hidden_Response = &streamFlowsResponse_Flow{b.Flow}
+	x.xxx_hidden_Frames = b.Frames
+	x.xxx_hidden_FrameTimestampsNs = b.FrameTimestampsNs
+	if b.Total != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Total = *b.Total
 	}
 	return m0
 }
 
-type case_StreamFlowsResponse_Response protoreflect.FieldNumber
-
-func (x case_StreamFlowsResponse_Response) String() string {
-	md := file_mitmflow_v1_mitmflow_proto_msgTypes[7].Descriptor()
-	if x == 0 {
-		return "not set"
-	}
-	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
-}
-
-type isStreamFlowsResponse_Response interface {
-	isStreamFlowsResponse_Response()
-}
-
-type streamFlowsResponse_Flow struct {
-	Flow *FlowSummary `protobuf:"bytes,1,opt,name=flow,oneof"`
-}
-
-func (*streamFlowsResponse_Flow) isStreamFlowsResponse_Response() {}
-
-type UpdateFlowRequest struct {
+type GetFlowsRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_FlowId      *string                `protobuf:"bytes,1,opt,name=flow_id,json=flowId"`
-	xxx_hidden_Pinned      bool                   `protobuf:"varint,2,opt,name=pinned"`
-	xxx_hidden_Note        *string                `protobuf:"bytes,3,opt,name=note"`
+	xxx_hidden_Filter      *FlowFilter            `protobuf:"bytes,1,opt,name=filter"`
+	xxx_hidden_Limit       int32                  `protobuf:"varint,2,opt,name=limit"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *UpdateFlowRequest) Reset() {
-	*x = UpdateFlowRequest{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[8]
+func (x *GetFlowsRequest) Reset() {
+	*x = GetFlowsRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateFlowRequest) String() string {
+func (x *GetFlowsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateFlowRequest) ProtoMessage() {}
+func (*GetFlowsRequest) ProtoMessage() {}
 
-func (x *UpdateFlowRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[8]
+func (x *GetFlowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -936,133 +1858,96 @@ func (x *UpdateFlowRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *UpdateFlowRequest) GetFlowId() string {
+func (x *GetFlowsRequest) GetFilter() *FlowFilter {
 	if x != nil {
-		if x.xxx_hidden_FlowId != nil {
-			return *x.xxx_hidden_FlowId
-		}
-		return ""
+		return x.xxx_hidden_Filter
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateFlowRequest) GetPinned() bool {
+func (x *GetFlowsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.xxx_hidden_Pinned
-	}
-	return false
-}
-
-func (x *UpdateFlowRequest) GetNote() string {
-	if x != nil {
-		if x.xxx_hidden_Note != nil {
-			return *x.xxx_hidden_Note
-		}
-		return ""
+		return x.xxx_hidden_Limit
 	}
-	return ""
-}
-
-func (x *UpdateFlowRequest) SetFlowId(v string) {
-	x.xxx_hidden_FlowId = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+	return 0
 }
 
-func (x *UpdateFlowRequest) SetPinned(v bool) {
-	x.xxx_hidden_Pinned = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+func (x *GetFlowsRequest) SetFilter(v *FlowFilter) {
+	x.xxx_hidden_Filter = v
 }
 
-func (x *UpdateFlowRequest) SetNote(v string) {
-	x.xxx_hidden_Note = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+func (x *GetFlowsRequest) SetLimit(v int32) {
+	x.xxx_hidden_Limit = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
 }
 
-func (x *UpdateFlowRequest) HasFlowId() bool {
+func (x *GetFlowsRequest) HasFilter() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+	return x.xxx_hidden_Filter != nil
 }
 
-func (x *UpdateFlowRequest) HasPinned() bool {
+func (x *GetFlowsRequest) HasLimit() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *UpdateFlowRequest) HasNote() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
-}
-
-func (x *UpdateFlowRequest) ClearFlowId() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_FlowId = nil
+func (x *GetFlowsRequest) ClearFilter() {
+	x.xxx_hidden_Filter = nil
 }
 
-func (x *UpdateFlowRequest) ClearPinned() {
+func (x *GetFlowsRequest) ClearLimit() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_Pinned = false
-}
-
-func (x *UpdateFlowRequest) ClearNote() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
-	x.xxx_hidden_Note = nil
+	x.xxx_hidden_Limit = 0
 }
 
-type UpdateFlowRequest_builder struct {
+type GetFlowsRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	FlowId *string
-	Pinned *bool
-	Note   *string
+	Filter *FlowFilter
+	Limit  *int32
 }
 
-func (b0 UpdateFlowRequest_builder) Build() *UpdateFlowRequest {
-	m0 := &UpdateFlowRequest{}
+func (b0 GetFlowsRequest_builder) Build() *GetFlowsRequest {
+	m0 := &GetFlowsRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.FlowId != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
-		x.xxx_hidden_FlowId = b.FlowId
-	}
-	if b.Pinned != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
-		x.xxx_hidden_Pinned = *b.Pinned
-	}
-	if b.Note != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
-		x.xxx_hidden_Note = b.Note
+	x.xxx_hidden_Filter = b.Filter
+	if b.Limit != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Limit = *b.Limit
 	}
 	return m0
 }
 
-type UpdateFlowResponse struct {
-	state           protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Flow *FlowSummary           `protobuf:"bytes,1,opt,name=flow"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type GetFlowsResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Flow        *FlowSummary           `protobuf:"bytes,1,opt,name=flow"`
+	xxx_hidden_Sequence    uint64                 `protobuf:"varint,2,opt,name=sequence"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *UpdateFlowResponse) Reset() {
-	*x = UpdateFlowResponse{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[9]
+func (x *GetFlowsResponse) Reset() {
+	*x = GetFlowsResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateFlowResponse) String() string {
+func (x *GetFlowsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateFlowResponse) ProtoMessage() {}
+func (*GetFlowsResponse) ProtoMessage() {}
 
-func (x *UpdateFlowResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[9]
+func (x *GetFlowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1073,67 +1958,99 @@ func (x *UpdateFlowResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *UpdateFlowResponse) GetFlow() *FlowSummary {
+func (x *GetFlowsResponse) GetFlow() *FlowSummary {
 	if x != nil {
 		return x.xxx_hidden_Flow
 	}
 	return nil
 }
 
-func (x *UpdateFlowResponse) SetFlow(v *FlowSummary) {
+func (x *GetFlowsResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.xxx_hidden_Sequence
+	}
+	return 0
+}
+
+func (x *GetFlowsResponse) SetFlow(v *FlowSummary) {
 	x.xxx_hidden_Flow = v
 }
 
-func (x *UpdateFlowResponse) HasFlow() bool {
+func (x *GetFlowsResponse) SetSequence(v uint64) {
+	x.xxx_hidden_Sequence = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *GetFlowsResponse) HasFlow() bool {
 	if x == nil {
 		return false
 	}
 	return x.xxx_hidden_Flow != nil
 }
 
-func (x *UpdateFlowResponse) ClearFlow() {
+func (x *GetFlowsResponse) HasSequence() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *GetFlowsResponse) ClearFlow() {
 	x.xxx_hidden_Flow = nil
 }
 
-type UpdateFlowResponse_builder struct {
+func (x *GetFlowsResponse) ClearSequence() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Sequence = 0
+}
+
+type GetFlowsResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	Flow *FlowSummary
+	// The change sequence when the listing started. Streaming with it as
+	// since_sequence returns only flows changed afterwards.
+	Sequence *uint64
 }
 
-func (b0 UpdateFlowResponse_builder) Build() *UpdateFlowResponse {
-	m0 := &UpdateFlowResponse{}
+func (b0 GetFlowsResponse_builder) Build() *GetFlowsResponse {
+	m0 := &GetFlowsResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
 	x.xxx_hidden_Flow = b.Flow
+	if b.Sequence != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Sequence = *b.Sequence
+	}
 	return m0
 }
 
-type DeleteFlowsRequest struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_FlowIds     []string               `protobuf:"bytes,1,rep,name=flow_ids,json=flowIds"`
-	xxx_hidden_All         bool                   `protobuf:"varint,2,opt,name=all"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+type StreamFlowsRequest struct {
+	state                       protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_SinceTimestampNs int64                  `protobuf:"varint,1,opt,name=since_timestamp_ns,json=sinceTimestampNs"`
+	xxx_hidden_Filter           *FlowFilter            `protobuf:"bytes,2,opt,name=filter"`
+	xxx_hidden_SinceSequence    uint64                 `protobuf:"varint,3,opt,name=since_sequence,json=sinceSequence"`
+	XXX_raceDetectHookData      protoimpl.RaceDetectHookData
+	XXX_presence                [1]uint32
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
 }
 
-func (x *DeleteFlowsRequest) Reset() {
-	*x = DeleteFlowsRequest{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[10]
+func (x *StreamFlowsRequest) Reset() {
+	*x = StreamFlowsRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteFlowsRequest) String() string {
+func (x *StreamFlowsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteFlowsRequest) ProtoMessage() {}
+func (*StreamFlowsRequest) ProtoMessage() {}
 
-func (x *DeleteFlowsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[10]
+func (x *StreamFlowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1144,86 +2061,128 @@ func (x *DeleteFlowsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *DeleteFlowsRequest) GetFlowIds() []string {
+func (x *StreamFlowsRequest) GetSinceTimestampNs() int64 {
 	if x != nil {
-		return x.xxx_hidden_FlowIds
+		return x.xxx_hidden_SinceTimestampNs
+	}
+	return 0
+}
+
+func (x *StreamFlowsRequest) GetFilter() *FlowFilter {
+	if x != nil {
+		return x.xxx_hidden_Filter
 	}
 	return nil
 }
 
-func (x *DeleteFlowsRequest) GetAll() bool {
+func (x *StreamFlowsRequest) GetSinceSequence() uint64 {
 	if x != nil {
-		return x.xxx_hidden_All
+		return x.xxx_hidden_SinceSequence
 	}
-	return false
+	return 0
 }
 
-func (x *DeleteFlowsRequest) SetFlowIds(v []string) {
-	x.xxx_hidden_FlowIds = v
+func (x *StreamFlowsRequest) SetSinceTimestampNs(v int64) {
+	x.xxx_hidden_SinceTimestampNs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
 }
 
-func (x *DeleteFlowsRequest) SetAll(v bool) {
-	x.xxx_hidden_All = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+func (x *StreamFlowsRequest) SetFilter(v *FlowFilter) {
+	x.xxx_hidden_Filter = v
 }
 
-func (x *DeleteFlowsRequest) HasAll() bool {
+func (x *StreamFlowsRequest) SetSinceSequence(v uint64) {
+	x.xxx_hidden_SinceSequence = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *StreamFlowsRequest) HasSinceTimestampNs() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
-}
-
-func (x *DeleteFlowsRequest) ClearAll() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_All = false
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-type DeleteFlowsRequest_builder struct {
-	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
-
-	FlowIds []string
-	All     *bool
+func (x *StreamFlowsRequest) HasFilter() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Filter != nil
 }
 
-func (b0 DeleteFlowsRequest_builder) Build() *DeleteFlowsRequest {
-	m0 := &DeleteFlowsRequest{}
-	b, x := &b0, m0
-	_, _ = b, x
-	x.xxx_hidden_FlowIds = b.FlowIds
-	if b.All != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
-		x.xxx_hidden_All = *b.All
+func (x *StreamFlowsRequest) HasSinceSequence() bool {
+	if x == nil {
+		return false
 	}
-	return m0
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
 }
 
-type DeleteFlowsResponse struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Count       int64                  `protobuf:"varint,1,opt,name=count"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+func (x *StreamFlowsRequest) ClearSinceTimestampNs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_SinceTimestampNs = 0
 }
 
-func (x *DeleteFlowsResponse) Reset() {
-	*x = DeleteFlowsResponse{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[11]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *StreamFlowsRequest) ClearFilter() {
+	x.xxx_hidden_Filter = nil
 }
 
-func (x *DeleteFlowsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *StreamFlowsRequest) ClearSinceSequence() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_SinceSequence = 0
 }
 
-func (*DeleteFlowsResponse) ProtoMessage() {}
+type StreamFlowsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-func (x *DeleteFlowsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[11]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	SinceTimestampNs *int64
+	Filter           *FlowFilter
+	// Send flows changed after this change sequence before streaming live
+	// flows. Takes precedence over since_timestamp_ns. Sequences carry over
+	// server restarts; flows kept from before a restart count as changed at
+	// the last sequence of the previous run.
+	SinceSequence *uint64
+}
+
+func (b0 StreamFlowsRequest_builder) Build() *StreamFlowsRequest {
+	m0 := &StreamFlowsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.SinceTimestampNs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_SinceTimestampNs = *b.SinceTimestampNs
+	}
+	x.xxx_hidden_Filter = b.Filter
+	if b.SinceSequence != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_SinceSequence = *b.SinceSequence
+	}
+	return m0
+}
+
+type StreamFlowsResponse struct {
+	state               protoimpl.MessageState         `protogen:"opaque.v1"`
+	xxx_hidden_Response isStreamFlowsResponse_Response `protobuf_oneof:"response"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *StreamFlowsResponse) Reset() {
+	*x = StreamFlowsResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamFlowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamFlowsResponse) ProtoMessage() {}
+
+func (x *StreamFlowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
@@ -1232,72 +2191,171 @@ func (x *DeleteFlowsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *DeleteFlowsResponse) GetCount() int64 {
+func (x *StreamFlowsResponse) GetFlow() *FlowSummary {
 	if x != nil {
-		return x.xxx_hidden_Count
+		if x, ok := x.xxx_hidden_Response.(*streamFlowsResponse_Flow); ok {
+			return x.Flow
+		}
 	}
-	return 0
+	return nil
 }
 
-func (x *DeleteFlowsResponse) SetCount(v int64) {
-	x.xxx_hidden_Count = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+func (x *StreamFlowsResponse) GetKeepalive() *Keepalive {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Response.(*streamFlowsResponse_Keepalive); ok {
+			return x.Keepalive
+		}
+	}
+	return nil
 }
 
-func (x *DeleteFlowsResponse) HasCount() bool {
+func (x *StreamFlowsResponse) SetFlow(v *FlowSummary) {
+	if v == nil {
+		x.xxx_hidden_Response = nil
+		return
+	}
+	x.xxx_hidden_Response = &streamFlowsResponse_Flow{v}
+}
+
+func (x *StreamFlowsResponse) SetKeepalive(v *Keepalive) {
+	if v == nil {
+		x.xxx_hidden_Response = nil
+		return
+	}
+	x.xxx_hidden_Response = &streamFlowsResponse_Keepalive{v}
+}
+
+func (x *StreamFlowsResponse) HasResponse() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+	return x.xxx_hidden_Response != nil
 }
 
-func (x *DeleteFlowsResponse) ClearCount() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_Count = 0
+func (x *StreamFlowsResponse) HasFlow() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Response.(*streamFlowsResponse_Flow)
+	return ok
 }
 
-type DeleteFlowsResponse_builder struct {
+func (x *StreamFlowsResponse) HasKeepalive() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Response.(*streamFlowsResponse_Keepalive)
+	return ok
+}
+
+func (x *StreamFlowsResponse) ClearResponse() {
+	x.xxx_hidden_Response = nil
+}
+
+func (x *StreamFlowsResponse) ClearFlow() {
+	if _, ok := x.xxx_hidden_Response.(*streamFlowsResponse_Flow); ok {
+		x.xxx_hidden_Response = nil
+	}
+}
+
+func (x *StreamFlowsResponse) ClearKeepalive() {
+	if _, ok := x.xxx_hidden_Response.(*streamFlowsResponse_Keepalive); ok {
+		x.xxx_hidden_Response = nil
+	}
+}
+
+const StreamFlowsResponse_Response_not_set_case case_StreamFlowsResponse_Response = 0
+const StreamFlowsResponse_Flow_case case_StreamFlowsResponse_Response = 1
+const StreamFlowsResponse_Keepalive_case case_StreamFlowsResponse_Response = 2
+
+func (x *StreamFlowsResponse) WhichResponse() case_StreamFlowsResponse_Response {
+	if x == nil {
+		return StreamFlowsResponse_Response_not_set_case
+	}
+	switch x.xxx_hidden_Response.(type) {
+	case *streamFlowsResponse_Flow:
+		return StreamFlowsResponse_Flow_case
+	case *streamFlowsResponse_Keepalive:
+		return StreamFlowsResponse_Keepalive_case
+	default:
+		return StreamFlowsResponse_Response_not_set_case
+	}
+}
+
+type StreamFlowsResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Count *int64
+	// Fields of oneof xxx_hidden_Response:
+	Flow      *FlowSummary
+	Keepalive *Keepalive
+	// -- end of xxx_hidden_Response
 }
 
-func (b0 DeleteFlowsResponse_builder) Build() *DeleteFlowsResponse {
-	m0 := &DeleteFlowsResponse{}
+func (b0 StreamFlowsResponse_builder) Build() *StreamFlowsResponse {
+	m0 := &StreamFlowsResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.Count != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
-		x.xxx_hidden_Count = *b.Count
+	if b.Flow != nil {
+		x.xxx_hidden_Response = &streamFlowsResponse_Flow{b.Flow}
+	}
+	if b.Keepalive != nil {
+		x.xxx_hidden_Response = &streamFlowsResponse_Keepalive{b.Keepalive}
 	}
 	return m0
 }
 
-type ExportFlowsRequest struct {
+type case_StreamFlowsResponse_Response protoreflect.FieldNumber
+
+func (x case_StreamFlowsResponse_Response) String() string {
+	md := file_mitmflow_v1_mitmflow_proto_msgTypes[12].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isStreamFlowsResponse_Response interface {
+	isStreamFlowsResponse_Response()
+}
+
+type streamFlowsResponse_Flow struct {
+	Flow *FlowSummary `protobuf:"bytes,1,opt,name=flow,oneof"`
+}
+
+type streamFlowsResponse_Keepalive struct {
+	Keepalive *Keepalive `protobuf:"bytes,2,opt,name=keepalive,oneof"`
+}
+
+func (*streamFlowsResponse_Flow) isStreamFlowsResponse_Response() {}
+
+func (*streamFlowsResponse_Keepalive) isStreamFlowsResponse_Response() {}
+
+// Keepalive is sent on an otherwise idle flow stream so intermediaries don't
+// close the connection, and so clients notice when the server goes away.
+type Keepalive struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_FlowIds     []string               `protobuf:"bytes,1,rep,name=flow_ids,json=flowIds"`
-	xxx_hidden_Format      ExportFormat           `protobuf:"varint,2,opt,name=format,enum=mitmflow.v1.ExportFormat"`
+	xxx_hidden_IntervalMs  int64                  `protobuf:"varint,1,opt,name=interval_ms,json=intervalMs"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *ExportFlowsRequest) Reset() {
-	*x = ExportFlowsRequest{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[12]
+func (x *Keepalive) Reset() {
+	*x = Keepalive{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExportFlowsRequest) String() string {
+func (x *Keepalive) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExportFlowsRequest) ProtoMessage() {}
+func (*Keepalive) ProtoMessage() {}
 
-func (x *ExportFlowsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[12]
+func (x *Keepalive) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1308,87 +2366,78 @@ func (x *ExportFlowsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *ExportFlowsRequest) GetFlowIds() []string {
-	if x != nil {
-		return x.xxx_hidden_FlowIds
-	}
-	return nil
-}
-
-func (x *ExportFlowsRequest) GetFormat() ExportFormat {
+func (x *Keepalive) GetIntervalMs() int64 {
 	if x != nil {
-		if protoimpl.X.Present(&(x.XXX_presence[0]), 1) {
-			return x.xxx_hidden_Format
-		}
+		return x.xxx_hidden_IntervalMs
 	}
-	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
-}
-
-func (x *ExportFlowsRequest) SetFlowIds(v []string) {
-	x.xxx_hidden_FlowIds = v
+	return 0
 }
 
-func (x *ExportFlowsRequest) SetFormat(v ExportFormat) {
-	x.xxx_hidden_Format = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+func (x *Keepalive) SetIntervalMs(v int64) {
+	x.xxx_hidden_IntervalMs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
 }
 
-func (x *ExportFlowsRequest) HasFormat() bool {
+func (x *Keepalive) HasIntervalMs() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *ExportFlowsRequest) ClearFormat() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_Format = ExportFormat_EXPORT_FORMAT_UNSPECIFIED
+func (x *Keepalive) ClearIntervalMs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_IntervalMs = 0
 }
 
-type ExportFlowsRequest_builder struct {
+type Keepalive_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	FlowIds []string
-	Format  *ExportFormat
+	// How often keepalives are sent. The first one is sent as soon as the
+	// stream starts, so a client knows how long to wait before giving up on it.
+	IntervalMs *int64
 }
 
-func (b0 ExportFlowsRequest_builder) Build() *ExportFlowsRequest {
-	m0 := &ExportFlowsRequest{}
+func (b0 Keepalive_builder) Build() *Keepalive {
+	m0 := &Keepalive{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_FlowIds = b.FlowIds
-	if b.Format != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
-		x.xxx_hidden_Format = *b.Format
+	if b.IntervalMs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_IntervalMs = *b.IntervalMs
 	}
 	return m0
 }
 
-type ExportFlowsResponse struct {
+type UpdateFlowRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Data        []byte                 `protobuf:"bytes,1,opt,name=data"`
-	xxx_hidden_Filename    *string                `protobuf:"bytes,2,opt,name=filename"`
+	xxx_hidden_FlowId      *string                `protobuf:"bytes,1,opt,name=flow_id,json=flowId"`
+	xxx_hidden_Pinned      bool                   `protobuf:"varint,2,opt,name=pinned"`
+	xxx_hidden_Note        *string                `protobuf:"bytes,3,opt,name=note"`
+	xxx_hidden_Metadata    map[string]string      `protobuf:"bytes,4,rep,name=metadata" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_Shared      bool                   `protobuf:"varint,5,opt,name=shared"`
+	xxx_hidden_Private     bool                   `protobuf:"varint,6,opt,name=private"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *ExportFlowsResponse) Reset() {
-	*x = ExportFlowsResponse{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[13]
+func (x *UpdateFlowRequest) Reset() {
+	*x = UpdateFlowRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExportFlowsResponse) String() string {
+func (x *UpdateFlowRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExportFlowsResponse) ProtoMessage() {}
+func (*UpdateFlowRequest) ProtoMessage() {}
 
-func (x *ExportFlowsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[13]
+func (x *UpdateFlowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1399,111 +2448,210 @@ func (x *ExportFlowsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *ExportFlowsResponse) GetData() []byte {
+func (x *UpdateFlowRequest) GetFlowId() string {
 	if x != nil {
-		return x.xxx_hidden_Data
+		if x.xxx_hidden_FlowId != nil {
+			return *x.xxx_hidden_FlowId
+		}
+		return ""
 	}
-	return nil
+	return ""
 }
 
-func (x *ExportFlowsResponse) GetFilename() string {
+func (x *UpdateFlowRequest) GetPinned() bool {
 	if x != nil {
-		if x.xxx_hidden_Filename != nil {
-			return *x.xxx_hidden_Filename
+		return x.xxx_hidden_Pinned
+	}
+	return false
+}
+
+func (x *UpdateFlowRequest) GetNote() string {
+	if x != nil {
+		if x.xxx_hidden_Note != nil {
+			return *x.xxx_hidden_Note
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *ExportFlowsResponse) SetData(v []byte) {
-	if v == nil {
-		v = []byte{}
+func (x *UpdateFlowRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Metadata
 	}
-	x.xxx_hidden_Data = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+	return nil
 }
 
-func (x *ExportFlowsResponse) SetFilename(v string) {
-	x.xxx_hidden_Filename = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+func (x *UpdateFlowRequest) GetShared() bool {
+	if x != nil {
+		return x.xxx_hidden_Shared
+	}
+	return false
 }
 
-func (x *ExportFlowsResponse) HasData() bool {
+func (x *UpdateFlowRequest) GetPrivate() bool {
+	if x != nil {
+		return x.xxx_hidden_Private
+	}
+	return false
+}
+
+func (x *UpdateFlowRequest) SetFlowId(v string) {
+	x.xxx_hidden_FlowId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+}
+
+func (x *UpdateFlowRequest) SetPinned(v bool) {
+	x.xxx_hidden_Pinned = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+}
+
+func (x *UpdateFlowRequest) SetNote(v string) {
+	x.xxx_hidden_Note = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
+}
+
+func (x *UpdateFlowRequest) SetMetadata(v map[string]string) {
+	x.xxx_hidden_Metadata = v
+}
+
+func (x *UpdateFlowRequest) SetShared(v bool) {
+	x.xxx_hidden_Shared = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *UpdateFlowRequest) SetPrivate(v bool) {
+	x.xxx_hidden_Private = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
+func (x *UpdateFlowRequest) HasFlowId() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *ExportFlowsResponse) HasFilename() bool {
+func (x *UpdateFlowRequest) HasPinned() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *ExportFlowsResponse) ClearData() {
+func (x *UpdateFlowRequest) HasNote() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *UpdateFlowRequest) HasShared() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *UpdateFlowRequest) HasPrivate() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *UpdateFlowRequest) ClearFlowId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_Data = nil
+	x.xxx_hidden_FlowId = nil
 }
 
-func (x *ExportFlowsResponse) ClearFilename() {
+func (x *UpdateFlowRequest) ClearPinned() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_Filename = nil
+	x.xxx_hidden_Pinned = false
 }
 
-type ExportFlowsResponse_builder struct {
+func (x *UpdateFlowRequest) ClearNote() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Note = nil
+}
+
+func (x *UpdateFlowRequest) ClearShared() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Shared = false
+}
+
+func (x *UpdateFlowRequest) ClearPrivate() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Private = false
+}
+
+type UpdateFlowRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Data     []byte
-	Filename *string
+	FlowId *string
+	Pinned *bool
+	Note   *string
+	// Metadata to merge into the flow's. Keys with an empty value are removed.
+	Metadata map[string]string
+	// Set the shared pin and note, seen by every user, instead of the caller's
+	// own. Ignored when the server doesn't require authentication, where
+	// everything is shared.
+	Shared *bool
+	// Hide the flow from everyone but its owner and admins. Only they can
+	// change it.
+	Private *bool
 }
 
-func (b0 ExportFlowsResponse_builder) Build() *ExportFlowsResponse {
-	m0 := &ExportFlowsResponse{}
+func (b0 UpdateFlowRequest_builder) Build() *UpdateFlowRequest {
+	m0 := &UpdateFlowRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.Data != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
-		x.xxx_hidden_Data = b.Data
+	if b.FlowId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		x.xxx_hidden_FlowId = b.FlowId
 	}
-	if b.Filename != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
-		x.xxx_hidden_Filename = b.Filename
+	if b.Pinned != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		x.xxx_hidden_Pinned = *b.Pinned
+	}
+	if b.Note != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
+		x.xxx_hidden_Note = b.Note
+	}
+	x.xxx_hidden_Metadata = b.Metadata
+	if b.Shared != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_Shared = *b.Shared
+	}
+	if b.Private != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_Private = *b.Private
 	}
 	return m0
 }
 
-type FlowSummary struct {
-	state                     protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Id             *string                `protobuf:"bytes,1,opt,name=id"`
-	xxx_hidden_Type           *string                `protobuf:"bytes,2,opt,name=type"`
-	xxx_hidden_TimestampStart *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp_start,json=timestampStart"`
-	xxx_hidden_Pinned         bool                   `protobuf:"varint,4,opt,name=pinned"`
-	xxx_hidden_Note           *string                `protobuf:"bytes,5,opt,name=note"`
-	xxx_hidden_Summary        isFlowSummary_Summary  `protobuf_oneof:"summary"`
-	XXX_raceDetectHookData    protoimpl.RaceDetectHookData
-	XXX_presence              [1]uint32
-	unknownFields             protoimpl.UnknownFields
-	sizeCache                 protoimpl.SizeCache
+type UpdateFlowResponse struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Flow *FlowSummary           `protobuf:"bytes,1,opt,name=flow"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *FlowSummary) Reset() {
-	*x = FlowSummary{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[14]
+func (x *UpdateFlowResponse) Reset() {
+	*x = UpdateFlowResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FlowSummary) String() string {
+func (x *UpdateFlowResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FlowSummary) ProtoMessage() {}
+func (*UpdateFlowResponse) ProtoMessage() {}
 
-func (x *FlowSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[14]
+func (x *UpdateFlowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1514,297 +2662,7538 @@ func (x *FlowSummary) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *FlowSummary) GetId() string {
+func (x *UpdateFlowResponse) GetFlow() *FlowSummary {
 	if x != nil {
-		if x.xxx_hidden_Id != nil {
-			return *x.xxx_hidden_Id
-		}
-		return ""
+		return x.xxx_hidden_Flow
 	}
-	return ""
+	return nil
 }
 
-func (x *FlowSummary) GetType() string {
-	if x != nil {
-		if x.xxx_hidden_Type != nil {
-			return *x.xxx_hidden_Type
-		}
-		return ""
-	}
-	return ""
+func (x *UpdateFlowResponse) SetFlow(v *FlowSummary) {
+	x.xxx_hidden_Flow = v
 }
 
-func (x *FlowSummary) GetTimestampStart() *timestamppb.Timestamp {
-	if x != nil {
-		return x.xxx_hidden_TimestampStart
+func (x *UpdateFlowResponse) HasFlow() bool {
+	if x == nil {
+		return false
 	}
-	return nil
+	return x.xxx_hidden_Flow != nil
 }
 
-func (x *FlowSummary) GetPinned() bool {
-	if x != nil {
-		return x.xxx_hidden_Pinned
-	}
-	return false
+func (x *UpdateFlowResponse) ClearFlow() {
+	x.xxx_hidden_Flow = nil
 }
 
-func (x *FlowSummary) GetNote() string {
-	if x != nil {
-		if x.xxx_hidden_Note != nil {
-			return *x.xxx_hidden_Note
-		}
-		return ""
-	}
-	return ""
+type UpdateFlowResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Flow *FlowSummary
 }
 
-func (x *FlowSummary) GetHttp() *HttpFlowSummary {
-	if x != nil {
-		if x, ok := x.xxx_hidden_Summary.(*flowSummary_Http); ok {
-			return x.Http
-		}
-	}
-	return nil
+func (b0 UpdateFlowResponse_builder) Build() *UpdateFlowResponse {
+	m0 := &UpdateFlowResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Flow = b.Flow
+	return m0
 }
 
-func (x *FlowSummary) GetDns() *DnsFlowSummary {
-	if x != nil {
-		if x, ok := x.xxx_hidden_Summary.(*flowSummary_Dns); ok {
-			return x.Dns
-		}
-	}
-	return nil
+type DeleteFlowsRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_FlowIds     []string               `protobuf:"bytes,1,rep,name=flow_ids,json=flowIds"`
+	xxx_hidden_All         bool                   `protobuf:"varint,2,opt,name=all"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *FlowSummary) GetTcp() *TcpFlowSummary {
+func (x *DeleteFlowsRequest) Reset() {
+	*x = DeleteFlowsRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFlowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFlowsRequest) ProtoMessage() {}
+
+func (x *DeleteFlowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[16]
 	if x != nil {
-		if x, ok := x.xxx_hidden_Summary.(*flowSummary_Tcp); ok {
-			return x.Tcp
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *FlowSummary) GetUdp() *UdpFlowSummary {
+func (x *DeleteFlowsRequest) GetFlowIds() []string {
 	if x != nil {
-		if x, ok := x.xxx_hidden_Summary.(*flowSummary_Udp); ok {
-			return x.Udp
-		}
+		return x.xxx_hidden_FlowIds
 	}
 	return nil
 }
 
-func (x *FlowSummary) SetId(v string) {
-	x.xxx_hidden_Id = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+func (x *DeleteFlowsRequest) GetAll() bool {
+	if x != nil {
+		return x.xxx_hidden_All
+	}
+	return false
 }
 
-func (x *FlowSummary) SetType(v string) {
-	x.xxx_hidden_Type = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+func (x *DeleteFlowsRequest) SetFlowIds(v []string) {
+	x.xxx_hidden_FlowIds = v
 }
 
-func (x *FlowSummary) SetTimestampStart(v *timestamppb.Timestamp) {
-	x.xxx_hidden_TimestampStart = v
+func (x *DeleteFlowsRequest) SetAll(v bool) {
+	x.xxx_hidden_All = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
 }
 
-func (x *FlowSummary) SetPinned(v bool) {
-	x.xxx_hidden_Pinned = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
+func (x *DeleteFlowsRequest) HasAll() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *FlowSummary) SetNote(v string) {
-	x.xxx_hidden_Note = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+func (x *DeleteFlowsRequest) ClearAll() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_All = false
 }
 
-func (x *FlowSummary) SetHttp(v *HttpFlowSummary) {
-	if v == nil {
-		x.xxx_hidden_Summary = nil
-		return
-	}
-	x.xxx_hidden_Summary = &flowSummary_Http{v}
+type DeleteFlowsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	FlowIds []string
+	All     *bool
 }
 
-func (x *FlowSummary) SetDns(v *DnsFlowSummary) {
-	if v == nil {
-		x.xxx_hidden_Summary = nil
-		return
+func (b0 DeleteFlowsRequest_builder) Build() *DeleteFlowsRequest {
+	m0 := &DeleteFlowsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_FlowIds = b.FlowIds
+	if b.All != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_All = *b.All
 	}
-	x.xxx_hidden_Summary = &flowSummary_Dns{v}
+	return m0
 }
 
-func (x *FlowSummary) SetTcp(v *TcpFlowSummary) {
-	if v == nil {
-		x.xxx_hidden_Summary = nil
-		return
-	}
-	x.xxx_hidden_Summary = &flowSummary_Tcp{v}
+type DeleteFlowsResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Count       int64                  `protobuf:"varint,1,opt,name=count"`
+	xxx_hidden_FlowIds     []string               `protobuf:"bytes,2,rep,name=flow_ids,json=flowIds"`
+	xxx_hidden_Restorable  bool                   `protobuf:"varint,3,opt,name=restorable"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *FlowSummary) SetUdp(v *UdpFlowSummary) {
-	if v == nil {
-		x.xxx_hidden_Summary = nil
-		return
-	}
-	x.xxx_hidden_Summary = &flowSummary_Udp{v}
+func (x *DeleteFlowsResponse) Reset() {
+	*x = DeleteFlowsResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *FlowSummary) HasId() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+func (x *DeleteFlowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *FlowSummary) HasType() bool {
-	if x == nil {
-		return false
+func (*DeleteFlowsResponse) ProtoMessage() {}
+
+func (x *DeleteFlowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+	return mi.MessageOf(x)
 }
 
-func (x *FlowSummary) HasTimestampStart() bool {
-	if x == nil {
-		return false
+func (x *DeleteFlowsResponse) GetCount() int64 {
+	if x != nil {
+		return x.xxx_hidden_Count
 	}
-	return x.xxx_hidden_TimestampStart != nil
+	return 0
 }
 
-func (x *FlowSummary) HasPinned() bool {
-	if x == nil {
-		return false
+func (x *DeleteFlowsResponse) GetFlowIds() []string {
+	if x != nil {
+		return x.xxx_hidden_FlowIds
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+	return nil
 }
 
-func (x *FlowSummary) HasNote() bool {
-	if x == nil {
-		return false
+func (x *DeleteFlowsResponse) GetRestorable() bool {
+	if x != nil {
+		return x.xxx_hidden_Restorable
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+	return false
 }
 
-func (x *FlowSummary) HasSummary() bool {
-	if x == nil {
-		return false
-	}
-	return x.xxx_hidden_Summary != nil
+func (x *DeleteFlowsResponse) SetCount(v int64) {
+	x.xxx_hidden_Count = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
 }
 
-func (x *FlowSummary) HasHttp() bool {
-	if x == nil {
-		return false
-	}
-	_, ok := x.xxx_hidden_Summary.(*flowSummary_Http)
-	return ok
+func (x *DeleteFlowsResponse) SetFlowIds(v []string) {
+	x.xxx_hidden_FlowIds = v
 }
 
-func (x *FlowSummary) HasDns() bool {
-	if x == nil {
-		return false
-	}
-	_, ok := x.xxx_hidden_Summary.(*flowSummary_Dns)
-	return ok
+func (x *DeleteFlowsResponse) SetRestorable(v bool) {
+	x.xxx_hidden_Restorable = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
 }
 
-func (x *FlowSummary) HasTcp() bool {
+func (x *DeleteFlowsResponse) HasCount() bool {
 	if x == nil {
 		return false
 	}
-	_, ok := x.xxx_hidden_Summary.(*flowSummary_Tcp)
-	return ok
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *FlowSummary) HasUdp() bool {
+func (x *DeleteFlowsResponse) HasRestorable() bool {
 	if x == nil {
 		return false
 	}
-	_, ok := x.xxx_hidden_Summary.(*flowSummary_Udp)
-	return ok
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
 }
 
-func (x *FlowSummary) ClearId() {
+func (x *DeleteFlowsResponse) ClearCount() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_Id = nil
+	x.xxx_hidden_Count = 0
 }
 
-func (x *FlowSummary) ClearType() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_Type = nil
+func (x *DeleteFlowsResponse) ClearRestorable() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Restorable = false
 }
 
-func (x *FlowSummary) ClearTimestampStart() {
-	x.xxx_hidden_TimestampStart = nil
+type DeleteFlowsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Count *int64
+	// The IDs of the deleted flows.
+	FlowIds []string
+	// Whether the deleted flows went into the trash and can be brought back
+	// with RestoreFlows.
+	Restorable *bool
 }
 
-func (x *FlowSummary) ClearPinned() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
-	x.xxx_hidden_Pinned = false
+func (b0 DeleteFlowsResponse_builder) Build() *DeleteFlowsResponse {
+	m0 := &DeleteFlowsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Count != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Count = *b.Count
+	}
+	x.xxx_hidden_FlowIds = b.FlowIds
+	if b.Restorable != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Restorable = *b.Restorable
+	}
+	return m0
 }
 
-func (x *FlowSummary) ClearNote() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
-	x.xxx_hidden_Note = nil
+type ExportFlowsRequest struct {
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_FlowIds         []string               `protobuf:"bytes,1,rep,name=flow_ids,json=flowIds"`
+	xxx_hidden_Format          ExportFormat           `protobuf:"varint,2,opt,name=format,enum=mitmflow.v1.ExportFormat"`
+	xxx_hidden_Anonymize       bool                   `protobuf:"varint,3,opt,name=anonymize"`
+	xxx_hidden_ShiftTimestamps bool                   `protobuf:"varint,4,opt,name=shift_timestamps,json=shiftTimestamps"`
+	xxx_hidden_Epoch           *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=epoch"`
+	xxx_hidden_Filter          *FlowFilter            `protobuf:"bytes,6,opt,name=filter"`
+	xxx_hidden_FilterExpr      *string                `protobuf:"bytes,7,opt,name=filter_expr,json=filterExpr"`
+	xxx_hidden_StartTime       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=start_time,json=startTime"`
+	xxx_hidden_EndTime         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=end_time,json=endTime"`
+	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
+	XXX_presence               [1]uint32
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
 }
 
-func (x *FlowSummary) ClearSummary() {
-	x.xxx_hidden_Summary = nil
+func (x *ExportFlowsRequest) Reset() {
+	*x = ExportFlowsRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *FlowSummary) ClearHttp() {
-	if _, ok := x.xxx_hidden_Summary.(*flowSummary_Http); ok {
-		x.xxx_hidden_Summary = nil
+func (x *ExportFlowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportFlowsRequest) ProtoMessage() {}
+
+func (x *ExportFlowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
+	return mi.MessageOf(x)
 }
 
-func (x *FlowSummary) ClearDns() {
-	if _, ok := x.xxx_hidden_Summary.(*flowSummary_Dns); ok {
-		x.xxx_hidden_Summary = nil
+func (x *ExportFlowsRequest) GetFlowIds() []string {
+	if x != nil {
+		return x.xxx_hidden_FlowIds
 	}
+	return nil
 }
 
-func (x *FlowSummary) ClearTcp() {
-	if _, ok := x.xxx_hidden_Summary.(*flowSummary_Tcp); ok {
-		x.xxx_hidden_Summary = nil
+func (x *ExportFlowsRequest) GetFormat() ExportFormat {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 1) {
+			return x.xxx_hidden_Format
+		}
 	}
+	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
 }
 
-func (x *FlowSummary) ClearUdp() {
-	if _, ok := x.xxx_hidden_Summary.(*flowSummary_Udp); ok {
-		x.xxx_hidden_Summary = nil
+func (x *ExportFlowsRequest) GetAnonymize() bool {
+	if x != nil {
+		return x.xxx_hidden_Anonymize
 	}
+	return false
 }
 
-const FlowSummary_Summary_not_set_case case_FlowSummary_Summary = 0
-const FlowSummary_Http_case case_FlowSummary_Summary = 6
-const FlowSummary_Dns_case case_FlowSummary_Summary = 7
-const FlowSummary_Tcp_case case_FlowSummary_Summary = 8
-const FlowSummary_Udp_case case_FlowSummary_Summary = 9
+func (x *ExportFlowsRequest) GetShiftTimestamps() bool {
+	if x != nil {
+		return x.xxx_hidden_ShiftTimestamps
+	}
+	return false
+}
 
-func (x *FlowSummary) WhichSummary() case_FlowSummary_Summary {
-	if x == nil {
-		return FlowSummary_Summary_not_set_case
+func (x *ExportFlowsRequest) GetEpoch() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Epoch
 	}
-	switch x.xxx_hidden_Summary.(type) {
-	case *flowSummary_Http:
-		return FlowSummary_Http_case
-	case *flowSummary_Dns:
-		return FlowSummary_Dns_case
-	case *flowSummary_Tcp:
-		return FlowSummary_Tcp_case
-	case *flowSummary_Udp:
-		return FlowSummary_Udp_case
-	default:
-		return FlowSummary_Summary_not_set_case
+	return nil
+}
+
+func (x *ExportFlowsRequest) GetFilter() *FlowFilter {
+	if x != nil {
+		return x.xxx_hidden_Filter
 	}
+	return nil
 }
 
-type FlowSummary_builder struct {
-	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+func (x *ExportFlowsRequest) GetFilterExpr() string {
+	if x != nil {
+		if x.xxx_hidden_FilterExpr != nil {
+			return *x.xxx_hidden_FilterExpr
+		}
+		return ""
+	}
+	return ""
+}
 
-	Id             *string
-	Type           *string
+func (x *ExportFlowsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_StartTime
+	}
+	return nil
+}
+
+func (x *ExportFlowsRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_EndTime
+	}
+	return nil
+}
+
+func (x *ExportFlowsRequest) SetFlowIds(v []string) {
+	x.xxx_hidden_FlowIds = v
+}
+
+func (x *ExportFlowsRequest) SetFormat(v ExportFormat) {
+	x.xxx_hidden_Format = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 9)
+}
+
+func (x *ExportFlowsRequest) SetAnonymize(v bool) {
+	x.xxx_hidden_Anonymize = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 9)
+}
+
+func (x *ExportFlowsRequest) SetShiftTimestamps(v bool) {
+	x.xxx_hidden_ShiftTimestamps = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 9)
+}
+
+func (x *ExportFlowsRequest) SetEpoch(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Epoch = v
+}
+
+func (x *ExportFlowsRequest) SetFilter(v *FlowFilter) {
+	x.xxx_hidden_Filter = v
+}
+
+func (x *ExportFlowsRequest) SetFilterExpr(v string) {
+	x.xxx_hidden_FilterExpr = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 9)
+}
+
+func (x *ExportFlowsRequest) SetStartTime(v *timestamppb.Timestamp) {
+	x.xxx_hidden_StartTime = v
+}
+
+func (x *ExportFlowsRequest) SetEndTime(v *timestamppb.Timestamp) {
+	x.xxx_hidden_EndTime = v
+}
+
+func (x *ExportFlowsRequest) HasFormat() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ExportFlowsRequest) HasAnonymize() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ExportFlowsRequest) HasShiftTimestamps() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *ExportFlowsRequest) HasEpoch() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Epoch != nil
+}
+
+func (x *ExportFlowsRequest) HasFilter() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Filter != nil
+}
+
+func (x *ExportFlowsRequest) HasFilterExpr() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *ExportFlowsRequest) HasStartTime() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_StartTime != nil
+}
+
+func (x *ExportFlowsRequest) HasEndTime() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_EndTime != nil
+}
+
+func (x *ExportFlowsRequest) ClearFormat() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Format = ExportFormat_EXPORT_FORMAT_UNSPECIFIED
+}
+
+func (x *ExportFlowsRequest) ClearAnonymize() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Anonymize = false
+}
+
+func (x *ExportFlowsRequest) ClearShiftTimestamps() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_ShiftTimestamps = false
+}
+
+func (x *ExportFlowsRequest) ClearEpoch() {
+	x.xxx_hidden_Epoch = nil
+}
+
+func (x *ExportFlowsRequest) ClearFilter() {
+	x.xxx_hidden_Filter = nil
+}
+
+func (x *ExportFlowsRequest) ClearFilterExpr() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_FilterExpr = nil
+}
+
+func (x *ExportFlowsRequest) ClearStartTime() {
+	x.xxx_hidden_StartTime = nil
+}
+
+func (x *ExportFlowsRequest) ClearEndTime() {
+	x.xxx_hidden_EndTime = nil
+}
+
+type ExportFlowsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	FlowIds []string
+	Format  *ExportFormat
+	// Replace hostnames, IP addresses, cookie values and credentials with
+	// pseudonyms before exporting. The same value always gets the same pseudonym
+	// from a server, which keeps the key that derives them to itself.
+	// Certificates and packed DNS messages are dropped; bodies are exported as
+	// they are.
+	Anonymize *bool
+	// Shift every timestamp by the same amount so the earliest one in the export
+	// lands on epoch (the Unix epoch when unset). Relative timing is kept.
+	ShiftTimestamps *bool
+	Epoch           *timestamppb.Timestamp
+	// Select flows by query instead of by ID. When any of the fields below is
+	// set, the flows matching all of them are exported, narrowed to flow_ids
+	// if those are given too. An empty filter matches every flow.
+	Filter *FlowFilter
+	// A filter expression such as "~d example.com & ~c 500".
+	FilterExpr *string
+	// Only flows that started in this range.
+	StartTime *timestamppb.Timestamp
+	EndTime   *timestamppb.Timestamp
+}
+
+func (b0 ExportFlowsRequest_builder) Build() *ExportFlowsRequest {
+	m0 := &ExportFlowsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_FlowIds = b.FlowIds
+	if b.Format != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 9)
+		x.xxx_hidden_Format = *b.Format
+	}
+	if b.Anonymize != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 9)
+		x.xxx_hidden_Anonymize = *b.Anonymize
+	}
+	if b.ShiftTimestamps != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 9)
+		x.xxx_hidden_ShiftTimestamps = *b.ShiftTimestamps
+	}
+	x.xxx_hidden_Epoch = b.Epoch
+	x.xxx_hidden_Filter = b.Filter
+	if b.FilterExpr != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 9)
+		x.xxx_hidden_FilterExpr = b.FilterExpr
+	}
+	x.xxx_hidden_StartTime = b.StartTime
+	x.xxx_hidden_EndTime = b.EndTime
+	return m0
+}
+
+type ExportFlowsResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Data        []byte                 `protobuf:"bytes,1,opt,name=data"`
+	xxx_hidden_Filename    *string                `protobuf:"bytes,2,opt,name=filename"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ExportFlowsResponse) Reset() {
+	*x = ExportFlowsResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportFlowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportFlowsResponse) ProtoMessage() {}
+
+func (x *ExportFlowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ExportFlowsResponse) GetData() []byte {
+	if x != nil {
+		return x.xxx_hidden_Data
+	}
+	return nil
+}
+
+func (x *ExportFlowsResponse) GetFilename() string {
+	if x != nil {
+		if x.xxx_hidden_Filename != nil {
+			return *x.xxx_hidden_Filename
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ExportFlowsResponse) SetData(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Data = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *ExportFlowsResponse) SetFilename(v string) {
+	x.xxx_hidden_Filename = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *ExportFlowsResponse) HasData() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ExportFlowsResponse) HasFilename() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ExportFlowsResponse) ClearData() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Data = nil
+}
+
+func (x *ExportFlowsResponse) ClearFilename() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Filename = nil
+}
+
+type ExportFlowsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Data     []byte
+	Filename *string
+}
+
+func (b0 ExportFlowsResponse_builder) Build() *ExportFlowsResponse {
+	m0 := &ExportFlowsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Data != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Data = b.Data
+	}
+	if b.Filename != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Filename = b.Filename
+	}
+	return m0
+}
+
+type ImportFlowsRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Data        []byte                 `protobuf:"bytes,1,opt,name=data"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ImportFlowsRequest) Reset() {
+	*x = ImportFlowsRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportFlowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportFlowsRequest) ProtoMessage() {}
+
+func (x *ImportFlowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ImportFlowsRequest) GetData() []byte {
+	if x != nil {
+		return x.xxx_hidden_Data
+	}
+	return nil
+}
+
+func (x *ImportFlowsRequest) SetData(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Data = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *ImportFlowsRequest) HasData() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ImportFlowsRequest) ClearData() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Data = nil
+}
+
+type ImportFlowsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// A serialized FlowSet, as produced by EXPORT_FORMAT_PROTO, or a bundle from
+	// CreateShareBundle.
+	Data []byte
+}
+
+func (b0 ImportFlowsRequest_builder) Build() *ImportFlowsRequest {
+	m0 := &ImportFlowsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Data != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Data = b.Data
+	}
+	return m0
+}
+
+type ImportFlowsResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Count       int64                  `protobuf:"varint,1,opt,name=count"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ImportFlowsResponse) Reset() {
+	*x = ImportFlowsResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportFlowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportFlowsResponse) ProtoMessage() {}
+
+func (x *ImportFlowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ImportFlowsResponse) GetCount() int64 {
+	if x != nil {
+		return x.xxx_hidden_Count
+	}
+	return 0
+}
+
+func (x *ImportFlowsResponse) SetCount(v int64) {
+	x.xxx_hidden_Count = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *ImportFlowsResponse) HasCount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ImportFlowsResponse) ClearCount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Count = 0
+}
+
+type ImportFlowsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Count *int64
+}
+
+func (b0 ImportFlowsResponse_builder) Build() *ImportFlowsResponse {
+	m0 := &ImportFlowsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Count != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Count = *b.Count
+	}
+	return m0
+}
+
+type CreateShareBundleRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_FlowId      *string                `protobuf:"bytes,1,opt,name=flow_id,json=flowId"`
+	xxx_hidden_Redact      bool                   `protobuf:"varint,2,opt,name=redact"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CreateShareBundleRequest) Reset() {
+	*x = CreateShareBundleRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareBundleRequest) ProtoMessage() {}
+
+func (x *CreateShareBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateShareBundleRequest) GetFlowId() string {
+	if x != nil {
+		if x.xxx_hidden_FlowId != nil {
+			return *x.xxx_hidden_FlowId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CreateShareBundleRequest) GetRedact() bool {
+	if x != nil {
+		return x.xxx_hidden_Redact
+	}
+	return false
+}
+
+func (x *CreateShareBundleRequest) SetFlowId(v string) {
+	x.xxx_hidden_FlowId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *CreateShareBundleRequest) SetRedact(v bool) {
+	x.xxx_hidden_Redact = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *CreateShareBundleRequest) HasFlowId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CreateShareBundleRequest) HasRedact() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *CreateShareBundleRequest) ClearFlowId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_FlowId = nil
+}
+
+func (x *CreateShareBundleRequest) ClearRedact() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Redact = false
+}
+
+type CreateShareBundleRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	FlowId *string
+	// Replace credentials with "REDACTED" before packaging: the values of
+	// Authorization, Cookie, Set-Cookie and other secret-looking headers, query
+	// parameters, form fields and JSON fields. Only HTTP flows can be redacted.
+	Redact *bool
+}
+
+func (b0 CreateShareBundleRequest_builder) Build() *CreateShareBundleRequest {
+	m0 := &CreateShareBundleRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.FlowId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_FlowId = b.FlowId
+	}
+	if b.Redact != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Redact = *b.Redact
+	}
+	return m0
+}
+
+type CreateShareBundleResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Bundle      *string                `protobuf:"bytes,1,opt,name=bundle"`
+	xxx_hidden_Filename    *string                `protobuf:"bytes,2,opt,name=filename"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CreateShareBundleResponse) Reset() {
+	*x = CreateShareBundleResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareBundleResponse) ProtoMessage() {}
+
+func (x *CreateShareBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateShareBundleResponse) GetBundle() string {
+	if x != nil {
+		if x.xxx_hidden_Bundle != nil {
+			return *x.xxx_hidden_Bundle
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CreateShareBundleResponse) GetFilename() string {
+	if x != nil {
+		if x.xxx_hidden_Filename != nil {
+			return *x.xxx_hidden_Filename
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CreateShareBundleResponse) SetBundle(v string) {
+	x.xxx_hidden_Bundle = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *CreateShareBundleResponse) SetFilename(v string) {
+	x.xxx_hidden_Filename = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *CreateShareBundleResponse) HasBundle() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CreateShareBundleResponse) HasFilename() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *CreateShareBundleResponse) ClearBundle() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Bundle = nil
+}
+
+func (x *CreateShareBundleResponse) ClearFilename() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Filename = nil
+}
+
+type CreateShareBundleResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// A single line of text that ImportFlows loads, to save as a file or paste
+	// into a bug report: "mitmflow-share:" followed by a gzipped FlowSet holding
+	// the flow, in unpadded base64url.
+	Bundle *string
+	// A file name for saving the bundle.
+	Filename *string
+}
+
+func (b0 CreateShareBundleResponse_builder) Build() *CreateShareBundleResponse {
+	m0 := &CreateShareBundleResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Bundle != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Bundle = b.Bundle
+	}
+	if b.Filename != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Filename = b.Filename
+	}
+	return m0
+}
+
+// SessionSelector picks the HTTP flows of a recorded session, e.g. a test run
+// whose flows were stamped with a build number.
+type SessionSelector struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Filter      *string                `protobuf:"bytes,1,opt,name=filter"`
+	xxx_hidden_StartTime   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime"`
+	xxx_hidden_EndTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SessionSelector) Reset() {
+	*x = SessionSelector{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionSelector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionSelector) ProtoMessage() {}
+
+func (x *SessionSelector) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SessionSelector) GetFilter() string {
+	if x != nil {
+		if x.xxx_hidden_Filter != nil {
+			return *x.xxx_hidden_Filter
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SessionSelector) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_StartTime
+	}
+	return nil
+}
+
+func (x *SessionSelector) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_EndTime
+	}
+	return nil
+}
+
+func (x *SessionSelector) SetFilter(v string) {
+	x.xxx_hidden_Filter = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *SessionSelector) SetStartTime(v *timestamppb.Timestamp) {
+	x.xxx_hidden_StartTime = v
+}
+
+func (x *SessionSelector) SetEndTime(v *timestamppb.Timestamp) {
+	x.xxx_hidden_EndTime = v
+}
+
+func (x *SessionSelector) HasFilter() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SessionSelector) HasStartTime() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_StartTime != nil
+}
+
+func (x *SessionSelector) HasEndTime() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_EndTime != nil
+}
+
+func (x *SessionSelector) ClearFilter() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Filter = nil
+}
+
+func (x *SessionSelector) ClearStartTime() {
+	x.xxx_hidden_StartTime = nil
+}
+
+func (x *SessionSelector) ClearEndTime() {
+	x.xxx_hidden_EndTime = nil
+}
+
+type SessionSelector_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// A filter expression such as "~meta ^build=12". Empty matches every flow.
+	Filter *string
+	// Only flows that started in this range, when set.
+	StartTime *timestamppb.Timestamp
+	EndTime   *timestamppb.Timestamp
+}
+
+func (b0 SessionSelector_builder) Build() *SessionSelector {
+	m0 := &SessionSelector{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Filter != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Filter = b.Filter
+	}
+	x.xxx_hidden_StartTime = b.StartTime
+	x.xxx_hidden_EndTime = b.EndTime
+	return m0
+}
+
+type SetBaselineRequest struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Session *SessionSelector       `protobuf:"bytes,1,opt,name=session"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SetBaselineRequest) Reset() {
+	*x = SetBaselineRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBaselineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBaselineRequest) ProtoMessage() {}
+
+func (x *SetBaselineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SetBaselineRequest) GetSession() *SessionSelector {
+	if x != nil {
+		return x.xxx_hidden_Session
+	}
+	return nil
+}
+
+func (x *SetBaselineRequest) SetSession(v *SessionSelector) {
+	x.xxx_hidden_Session = v
+}
+
+func (x *SetBaselineRequest) HasSession() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Session != nil
+}
+
+func (x *SetBaselineRequest) ClearSession() {
+	x.xxx_hidden_Session = nil
+}
+
+type SetBaselineRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The session whose responses new flows are compared with. Leave unset to
+	// clear the baseline.
+	Session *SessionSelector
+}
+
+func (b0 SetBaselineRequest_builder) Build() *SetBaselineRequest {
+	m0 := &SetBaselineRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Session = b.Session
+	return m0
+}
+
+type SetBaselineResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Count       int64                  `protobuf:"varint,1,opt,name=count"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SetBaselineResponse) Reset() {
+	*x = SetBaselineResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBaselineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBaselineResponse) ProtoMessage() {}
+
+func (x *SetBaselineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SetBaselineResponse) GetCount() int64 {
+	if x != nil {
+		return x.xxx_hidden_Count
+	}
+	return 0
+}
+
+func (x *SetBaselineResponse) SetCount(v int64) {
+	x.xxx_hidden_Count = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *SetBaselineResponse) HasCount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SetBaselineResponse) ClearCount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Count = 0
+}
+
+type SetBaselineResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// How many distinct method and path pairs the baseline holds.
+	Count *int64
+}
+
+func (b0 SetBaselineResponse_builder) Build() *SetBaselineResponse {
+	m0 := &SetBaselineResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Count != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Count = *b.Count
+	}
+	return m0
+}
+
+type CompareSessionsRequest struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Session *SessionSelector       `protobuf:"bytes,1,opt,name=session"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CompareSessionsRequest) Reset() {
+	*x = CompareSessionsRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareSessionsRequest) ProtoMessage() {}
+
+func (x *CompareSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CompareSessionsRequest) GetSession() *SessionSelector {
+	if x != nil {
+		return x.xxx_hidden_Session
+	}
+	return nil
+}
+
+func (x *CompareSessionsRequest) SetSession(v *SessionSelector) {
+	x.xxx_hidden_Session = v
+}
+
+func (x *CompareSessionsRequest) HasSession() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Session != nil
+}
+
+func (x *CompareSessionsRequest) ClearSession() {
+	x.xxx_hidden_Session = nil
+}
+
+type CompareSessionsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Session *SessionSelector
+}
+
+func (b0 CompareSessionsRequest_builder) Build() *CompareSessionsRequest {
+	m0 := &CompareSessionsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Session = b.Session
+	return m0
+}
+
+type CompareSessionsResponse struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Regressions    *[]*BaselineComparison `protobuf:"bytes,1,rep,name=regressions"`
+	xxx_hidden_MatchedCount   int64                  `protobuf:"varint,2,opt,name=matched_count,json=matchedCount"`
+	xxx_hidden_UnmatchedCount int64                  `protobuf:"varint,3,opt,name=unmatched_count,json=unmatchedCount"`
+	XXX_raceDetectHookData    protoimpl.RaceDetectHookData
+	XXX_presence              [1]uint32
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *CompareSessionsResponse) Reset() {
+	*x = CompareSessionsResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareSessionsResponse) ProtoMessage() {}
+
+func (x *CompareSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CompareSessionsResponse) GetRegressions() []*BaselineComparison {
+	if x != nil {
+		if x.xxx_hidden_Regressions != nil {
+			return *x.xxx_hidden_Regressions
+		}
+	}
+	return nil
+}
+
+func (x *CompareSessionsResponse) GetMatchedCount() int64 {
+	if x != nil {
+		return x.xxx_hidden_MatchedCount
+	}
+	return 0
+}
+
+func (x *CompareSessionsResponse) GetUnmatchedCount() int64 {
+	if x != nil {
+		return x.xxx_hidden_UnmatchedCount
+	}
+	return 0
+}
+
+func (x *CompareSessionsResponse) SetRegressions(v []*BaselineComparison) {
+	x.xxx_hidden_Regressions = &v
+}
+
+func (x *CompareSessionsResponse) SetMatchedCount(v int64) {
+	x.xxx_hidden_MatchedCount = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *CompareSessionsResponse) SetUnmatchedCount(v int64) {
+	x.xxx_hidden_UnmatchedCount = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *CompareSessionsResponse) HasMatchedCount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *CompareSessionsResponse) HasUnmatchedCount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *CompareSessionsResponse) ClearMatchedCount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_MatchedCount = 0
+}
+
+func (x *CompareSessionsResponse) ClearUnmatchedCount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_UnmatchedCount = 0
+}
+
+type CompareSessionsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The flows of the session that regressed from the baseline.
+	Regressions []*BaselineComparison
+	// How many flows of the session had a baseline response to compare with.
+	MatchedCount *int64
+	// How many flows of the session had a method and path the baseline lacks.
+	UnmatchedCount *int64
+}
+
+func (b0 CompareSessionsResponse_builder) Build() *CompareSessionsResponse {
+	m0 := &CompareSessionsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Regressions = &b.Regressions
+	if b.MatchedCount != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_MatchedCount = *b.MatchedCount
+	}
+	if b.UnmatchedCount != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_UnmatchedCount = *b.UnmatchedCount
+	}
+	return m0
+}
+
+type BaselineComparison struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_FlowId         *string                `protobuf:"bytes,1,opt,name=flow_id,json=flowId"`
+	xxx_hidden_BaselineFlowId *string                `protobuf:"bytes,2,opt,name=baseline_flow_id,json=baselineFlowId"`
+	xxx_hidden_Method         *string                `protobuf:"bytes,3,opt,name=method"`
+	xxx_hidden_Path           *string                `protobuf:"bytes,4,opt,name=path"`
+	xxx_hidden_Differences    *[]*BaselineDifference `protobuf:"bytes,5,rep,name=differences"`
+	XXX_raceDetectHookData    protoimpl.RaceDetectHookData
+	XXX_presence              [1]uint32
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *BaselineComparison) Reset() {
+	*x = BaselineComparison{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BaselineComparison) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BaselineComparison) ProtoMessage() {}
+
+func (x *BaselineComparison) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BaselineComparison) GetFlowId() string {
+	if x != nil {
+		if x.xxx_hidden_FlowId != nil {
+			return *x.xxx_hidden_FlowId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BaselineComparison) GetBaselineFlowId() string {
+	if x != nil {
+		if x.xxx_hidden_BaselineFlowId != nil {
+			return *x.xxx_hidden_BaselineFlowId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BaselineComparison) GetMethod() string {
+	if x != nil {
+		if x.xxx_hidden_Method != nil {
+			return *x.xxx_hidden_Method
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BaselineComparison) GetPath() string {
+	if x != nil {
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BaselineComparison) GetDifferences() []*BaselineDifference {
+	if x != nil {
+		if x.xxx_hidden_Differences != nil {
+			return *x.xxx_hidden_Differences
+		}
+	}
+	return nil
+}
+
+func (x *BaselineComparison) SetFlowId(v string) {
+	x.xxx_hidden_FlowId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *BaselineComparison) SetBaselineFlowId(v string) {
+	x.xxx_hidden_BaselineFlowId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+}
+
+func (x *BaselineComparison) SetMethod(v string) {
+	x.xxx_hidden_Method = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
+}
+
+func (x *BaselineComparison) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+}
+
+func (x *BaselineComparison) SetDifferences(v []*BaselineDifference) {
+	x.xxx_hidden_Differences = &v
+}
+
+func (x *BaselineComparison) HasFlowId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *BaselineComparison) HasBaselineFlowId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *BaselineComparison) HasMethod() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *BaselineComparison) HasPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *BaselineComparison) ClearFlowId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_FlowId = nil
+}
+
+func (x *BaselineComparison) ClearBaselineFlowId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_BaselineFlowId = nil
+}
+
+func (x *BaselineComparison) ClearMethod() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Method = nil
+}
+
+func (x *BaselineComparison) ClearPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Path = nil
+}
+
+type BaselineComparison_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	FlowId *string
+	// The baseline flow with the same method and path.
+	BaselineFlowId *string
+	Method         *string
+	Path           *string
+	// What changed for the worse. Empty when the response matches the baseline.
+	Differences []*BaselineDifference
+}
+
+func (b0 BaselineComparison_builder) Build() *BaselineComparison {
+	m0 := &BaselineComparison{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.FlowId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_FlowId = b.FlowId
+	}
+	if b.BaselineFlowId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		x.xxx_hidden_BaselineFlowId = b.BaselineFlowId
+	}
+	if b.Method != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
+		x.xxx_hidden_Method = b.Method
+	}
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
+		x.xxx_hidden_Path = b.Path
+	}
+	x.xxx_hidden_Differences = &b.Differences
+	return m0
+}
+
+// BaselineDifference is a status code that changed, a header that changed or
+// went missing, or a JSON body field that changed type or went missing.
+// Headers that differ on every response, like Date, are not compared.
+type BaselineDifference struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Kind        BaselineDifferenceKind `protobuf:"varint,1,opt,name=kind,enum=mitmflow.v1.BaselineDifferenceKind"`
+	xxx_hidden_Field       *string                `protobuf:"bytes,2,opt,name=field"`
+	xxx_hidden_Baseline    *string                `protobuf:"bytes,3,opt,name=baseline"`
+	xxx_hidden_Actual      *string                `protobuf:"bytes,4,opt,name=actual"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *BaselineDifference) Reset() {
+	*x = BaselineDifference{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BaselineDifference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BaselineDifference) ProtoMessage() {}
+
+func (x *BaselineDifference) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BaselineDifference) GetKind() BaselineDifferenceKind {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Kind
+		}
+	}
+	return BaselineDifferenceKind_BASELINE_DIFFERENCE_KIND_UNSPECIFIED
+}
+
+func (x *BaselineDifference) GetField() string {
+	if x != nil {
+		if x.xxx_hidden_Field != nil {
+			return *x.xxx_hidden_Field
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BaselineDifference) GetBaseline() string {
+	if x != nil {
+		if x.xxx_hidden_Baseline != nil {
+			return *x.xxx_hidden_Baseline
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BaselineDifference) GetActual() string {
+	if x != nil {
+		if x.xxx_hidden_Actual != nil {
+			return *x.xxx_hidden_Actual
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BaselineDifference) SetKind(v BaselineDifferenceKind) {
+	x.xxx_hidden_Kind = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *BaselineDifference) SetField(v string) {
+	x.xxx_hidden_Field = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *BaselineDifference) SetBaseline(v string) {
+	x.xxx_hidden_Baseline = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *BaselineDifference) SetActual(v string) {
+	x.xxx_hidden_Actual = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *BaselineDifference) HasKind() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *BaselineDifference) HasField() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *BaselineDifference) HasBaseline() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *BaselineDifference) HasActual() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *BaselineDifference) ClearKind() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Kind = BaselineDifferenceKind_BASELINE_DIFFERENCE_KIND_UNSPECIFIED
+}
+
+func (x *BaselineDifference) ClearField() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Field = nil
+}
+
+func (x *BaselineDifference) ClearBaseline() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Baseline = nil
+}
+
+func (x *BaselineDifference) ClearActual() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Actual = nil
+}
+
+type BaselineDifference_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Kind *BaselineDifferenceKind
+	// The header name, or the path of a body field such as "$.items[].id".
+	Field *string
+	// The status code, header value or JSON type. Empty when missing.
+	Baseline *string
+	Actual   *string
+}
+
+func (b0 BaselineDifference_builder) Build() *BaselineDifference {
+	m0 := &BaselineDifference{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Kind != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Kind = *b.Kind
+	}
+	if b.Field != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Field = b.Field
+	}
+	if b.Baseline != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Baseline = b.Baseline
+	}
+	if b.Actual != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Actual = b.Actual
+	}
+	return m0
+}
+
+type CreateBackupRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Path        *string                `protobuf:"bytes,1,opt,name=path"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CreateBackupRequest) Reset() {
+	*x = CreateBackupRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBackupRequest) ProtoMessage() {}
+
+func (x *CreateBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateBackupRequest) GetPath() string {
+	if x != nil {
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CreateBackupRequest) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *CreateBackupRequest) HasPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CreateBackupRequest) ClearPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Path = nil
+}
+
+type CreateBackupRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// When set, the backup is written to this file inside the server's backup
+	// directory instead of being streamed back.
+	Path *string
+}
+
+func (b0 CreateBackupRequest_builder) Build() *CreateBackupRequest {
+	m0 := &CreateBackupRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Path = b.Path
+	}
+	return m0
+}
+
+type CreateBackupResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Chunk       []byte                 `protobuf:"bytes,1,opt,name=chunk"`
+	xxx_hidden_Path        *string                `protobuf:"bytes,2,opt,name=path"`
+	xxx_hidden_FlowCount   int64                  `protobuf:"varint,3,opt,name=flow_count,json=flowCount"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CreateBackupResponse) Reset() {
+	*x = CreateBackupResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBackupResponse) ProtoMessage() {}
+
+func (x *CreateBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateBackupResponse) GetChunk() []byte {
+	if x != nil {
+		return x.xxx_hidden_Chunk
+	}
+	return nil
+}
+
+func (x *CreateBackupResponse) GetPath() string {
+	if x != nil {
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CreateBackupResponse) GetFlowCount() int64 {
+	if x != nil {
+		return x.xxx_hidden_FlowCount
+	}
+	return 0
+}
+
+func (x *CreateBackupResponse) SetChunk(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Chunk = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *CreateBackupResponse) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *CreateBackupResponse) SetFlowCount(v int64) {
+	x.xxx_hidden_FlowCount = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *CreateBackupResponse) HasChunk() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CreateBackupResponse) HasPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *CreateBackupResponse) HasFlowCount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *CreateBackupResponse) ClearChunk() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Chunk = nil
+}
+
+func (x *CreateBackupResponse) ClearPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Path = nil
+}
+
+func (x *CreateBackupResponse) ClearFlowCount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_FlowCount = 0
+}
+
+type CreateBackupResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// A piece of the gzipped tarball. Concatenate the chunks in order.
+	Chunk []byte
+	// Where the backup was written, when a path was requested.
+	Path *string
+	// Set on the final message.
+	FlowCount *int64
+}
+
+func (b0 CreateBackupResponse_builder) Build() *CreateBackupResponse {
+	m0 := &CreateBackupResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Chunk != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Chunk = b.Chunk
+	}
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Path = b.Path
+	}
+	if b.FlowCount != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_FlowCount = *b.FlowCount
+	}
+	return m0
+}
+
+type RestoreBackupRequest struct {
+	state                  protoimpl.MessageState        `protogen:"opaque.v1"`
+	xxx_hidden_Source      isRestoreBackupRequest_Source `protobuf_oneof:"source"`
+	xxx_hidden_Replace     bool                          `protobuf:"varint,3,opt,name=replace"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RestoreBackupRequest) Reset() {
+	*x = RestoreBackupRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreBackupRequest) ProtoMessage() {}
+
+func (x *RestoreBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RestoreBackupRequest) GetData() []byte {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Source.(*restoreBackupRequest_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *RestoreBackupRequest) GetPath() string {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Source.(*restoreBackupRequest_Path); ok {
+			return x.Path
+		}
+	}
+	return ""
+}
+
+func (x *RestoreBackupRequest) GetReplace() bool {
+	if x != nil {
+		return x.xxx_hidden_Replace
+	}
+	return false
+}
+
+func (x *RestoreBackupRequest) SetData(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Source = &restoreBackupRequest_Data{v}
+}
+
+func (x *RestoreBackupRequest) SetPath(v string) {
+	x.xxx_hidden_Source = &restoreBackupRequest_Path{v}
+}
+
+func (x *RestoreBackupRequest) SetReplace(v bool) {
+	x.xxx_hidden_Replace = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *RestoreBackupRequest) HasSource() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Source != nil
+}
+
+func (x *RestoreBackupRequest) HasData() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Source.(*restoreBackupRequest_Data)
+	return ok
+}
+
+func (x *RestoreBackupRequest) HasPath() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Source.(*restoreBackupRequest_Path)
+	return ok
+}
+
+func (x *RestoreBackupRequest) HasReplace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RestoreBackupRequest) ClearSource() {
+	x.xxx_hidden_Source = nil
+}
+
+func (x *RestoreBackupRequest) ClearData() {
+	if _, ok := x.xxx_hidden_Source.(*restoreBackupRequest_Data); ok {
+		x.xxx_hidden_Source = nil
+	}
+}
+
+func (x *RestoreBackupRequest) ClearPath() {
+	if _, ok := x.xxx_hidden_Source.(*restoreBackupRequest_Path); ok {
+		x.xxx_hidden_Source = nil
+	}
+}
+
+func (x *RestoreBackupRequest) ClearReplace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Replace = false
+}
+
+const RestoreBackupRequest_Source_not_set_case case_RestoreBackupRequest_Source = 0
+const RestoreBackupRequest_Data_case case_RestoreBackupRequest_Source = 1
+const RestoreBackupRequest_Path_case case_RestoreBackupRequest_Source = 2
+
+func (x *RestoreBackupRequest) WhichSource() case_RestoreBackupRequest_Source {
+	if x == nil {
+		return RestoreBackupRequest_Source_not_set_case
+	}
+	switch x.xxx_hidden_Source.(type) {
+	case *restoreBackupRequest_Data:
+		return RestoreBackupRequest_Data_case
+	case *restoreBackupRequest_Path:
+		return RestoreBackupRequest_Path_case
+	default:
+		return RestoreBackupRequest_Source_not_set_case
+	}
+}
+
+type RestoreBackupRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Fields of oneof xxx_hidden_Source:
+	// A backup as streamed by CreateBackup.
+	Data []byte
+	// A backup file inside the server's backup directory.
+	Path *string
+	// -- end of xxx_hidden_Source
+	// Delete every existing flow, including pinned flows, before restoring.
+	Replace *bool
+}
+
+func (b0 RestoreBackupRequest_builder) Build() *RestoreBackupRequest {
+	m0 := &RestoreBackupRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Data != nil {
+		x.xxx_hidden_Source = &restoreBackupRequest_Data{b.Data}
+	}
+	if b.Path != nil {
+		x.xxx_hidden_Source = &restoreBackupRequest_Path{*b.Path}
+	}
+	if b.Replace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Replace = *b.Replace
+	}
+	return m0
+}
+
+type case_RestoreBackupRequest_Source protoreflect.FieldNumber
+
+func (x case_RestoreBackupRequest_Source) String() string {
+	md := file_mitmflow_v1_mitmflow_proto_msgTypes[33].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isRestoreBackupRequest_Source interface {
+	isRestoreBackupRequest_Source()
+}
+
+type restoreBackupRequest_Data struct {
+	// A backup as streamed by CreateBackup.
+	Data []byte `protobuf:"bytes,1,opt,name=data,oneof"`
+}
+
+type restoreBackupRequest_Path struct {
+	// A backup file inside the server's backup directory.
+	Path string `protobuf:"bytes,2,opt,name=path,oneof"`
+}
+
+func (*restoreBackupRequest_Data) isRestoreBackupRequest_Source() {}
+
+func (*restoreBackupRequest_Path) isRestoreBackupRequest_Source() {}
+
+type RestoreBackupResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Count       int64                  `protobuf:"varint,1,opt,name=count"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RestoreBackupResponse) Reset() {
+	*x = RestoreBackupResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreBackupResponse) ProtoMessage() {}
+
+func (x *RestoreBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RestoreBackupResponse) GetCount() int64 {
+	if x != nil {
+		return x.xxx_hidden_Count
+	}
+	return 0
+}
+
+func (x *RestoreBackupResponse) SetCount(v int64) {
+	x.xxx_hidden_Count = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *RestoreBackupResponse) HasCount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RestoreBackupResponse) ClearCount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Count = 0
+}
+
+type RestoreBackupResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Count *int64
+}
+
+func (b0 RestoreBackupResponse_builder) Build() *RestoreBackupResponse {
+	m0 := &RestoreBackupResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Count != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Count = *b.Count
+	}
+	return m0
+}
+
+type SearchArchiveRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Filter      *FlowFilter            `protobuf:"bytes,1,opt,name=filter"`
+	xxx_hidden_Limit       int32                  `protobuf:"varint,2,opt,name=limit"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SearchArchiveRequest) Reset() {
+	*x = SearchArchiveRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchArchiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchArchiveRequest) ProtoMessage() {}
+
+func (x *SearchArchiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SearchArchiveRequest) GetFilter() *FlowFilter {
+	if x != nil {
+		return x.xxx_hidden_Filter
+	}
+	return nil
+}
+
+func (x *SearchArchiveRequest) GetLimit() int32 {
+	if x != nil {
+		return x.xxx_hidden_Limit
+	}
+	return 0
+}
+
+func (x *SearchArchiveRequest) SetFilter(v *FlowFilter) {
+	x.xxx_hidden_Filter = v
+}
+
+func (x *SearchArchiveRequest) SetLimit(v int32) {
+	x.xxx_hidden_Limit = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *SearchArchiveRequest) HasFilter() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Filter != nil
+}
+
+func (x *SearchArchiveRequest) HasLimit() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *SearchArchiveRequest) ClearFilter() {
+	x.xxx_hidden_Filter = nil
+}
+
+func (x *SearchArchiveRequest) ClearLimit() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Limit = 0
+}
+
+type SearchArchiveRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Filter *FlowFilter
+	Limit  *int32
+}
+
+func (b0 SearchArchiveRequest_builder) Build() *SearchArchiveRequest {
+	m0 := &SearchArchiveRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Filter = b.Filter
+	if b.Limit != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Limit = *b.Limit
+	}
+	return m0
+}
+
+type SearchArchiveResponse struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Flow *FlowSummary           `protobuf:"bytes,1,opt,name=flow"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SearchArchiveResponse) Reset() {
+	*x = SearchArchiveResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchArchiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchArchiveResponse) ProtoMessage() {}
+
+func (x *SearchArchiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SearchArchiveResponse) GetFlow() *FlowSummary {
+	if x != nil {
+		return x.xxx_hidden_Flow
+	}
+	return nil
+}
+
+func (x *SearchArchiveResponse) SetFlow(v *FlowSummary) {
+	x.xxx_hidden_Flow = v
+}
+
+func (x *SearchArchiveResponse) HasFlow() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Flow != nil
+}
+
+func (x *SearchArchiveResponse) ClearFlow() {
+	x.xxx_hidden_Flow = nil
+}
+
+type SearchArchiveResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Flow *FlowSummary
+}
+
+func (b0 SearchArchiveResponse_builder) Build() *SearchArchiveResponse {
+	m0 := &SearchArchiveResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Flow = b.Flow
+	return m0
+}
+
+type RestoreArchivedFlowsRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_FlowIds     []string               `protobuf:"bytes,1,rep,name=flow_ids,json=flowIds"`
+	xxx_hidden_Pin         bool                   `protobuf:"varint,2,opt,name=pin"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RestoreArchivedFlowsRequest) Reset() {
+	*x = RestoreArchivedFlowsRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreArchivedFlowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreArchivedFlowsRequest) ProtoMessage() {}
+
+func (x *RestoreArchivedFlowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RestoreArchivedFlowsRequest) GetFlowIds() []string {
+	if x != nil {
+		return x.xxx_hidden_FlowIds
+	}
+	return nil
+}
+
+func (x *RestoreArchivedFlowsRequest) GetPin() bool {
+	if x != nil {
+		return x.xxx_hidden_Pin
+	}
+	return false
+}
+
+func (x *RestoreArchivedFlowsRequest) SetFlowIds(v []string) {
+	x.xxx_hidden_FlowIds = v
+}
+
+func (x *RestoreArchivedFlowsRequest) SetPin(v bool) {
+	x.xxx_hidden_Pin = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *RestoreArchivedFlowsRequest) HasPin() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RestoreArchivedFlowsRequest) ClearPin() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Pin = false
+}
+
+type RestoreArchivedFlowsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	FlowIds []string
+	// Pin restored flows so the next prune doesn't archive them again.
+	Pin *bool
+}
+
+func (b0 RestoreArchivedFlowsRequest_builder) Build() *RestoreArchivedFlowsRequest {
+	m0 := &RestoreArchivedFlowsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_FlowIds = b.FlowIds
+	if b.Pin != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Pin = *b.Pin
+	}
+	return m0
+}
+
+type RestoreArchivedFlowsResponse struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Flows *[]*FlowSummary        `protobuf:"bytes,1,rep,name=flows"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RestoreArchivedFlowsResponse) Reset() {
+	*x = RestoreArchivedFlowsResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreArchivedFlowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreArchivedFlowsResponse) ProtoMessage() {}
+
+func (x *RestoreArchivedFlowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RestoreArchivedFlowsResponse) GetFlows() []*FlowSummary {
+	if x != nil {
+		if x.xxx_hidden_Flows != nil {
+			return *x.xxx_hidden_Flows
+		}
+	}
+	return nil
+}
+
+func (x *RestoreArchivedFlowsResponse) SetFlows(v []*FlowSummary) {
+	x.xxx_hidden_Flows = &v
+}
+
+type RestoreArchivedFlowsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Flows []*FlowSummary
+}
+
+func (b0 RestoreArchivedFlowsResponse_builder) Build() *RestoreArchivedFlowsResponse {
+	m0 := &RestoreArchivedFlowsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Flows = &b.Flows
+	return m0
+}
+
+// RestoreFlowsRequest brings deleted flows back out of the trash.
+type RestoreFlowsRequest struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_FlowIds []string               `protobuf:"bytes,1,rep,name=flow_ids,json=flowIds"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RestoreFlowsRequest) Reset() {
+	*x = RestoreFlowsRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFlowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFlowsRequest) ProtoMessage() {}
+
+func (x *RestoreFlowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RestoreFlowsRequest) GetFlowIds() []string {
+	if x != nil {
+		return x.xxx_hidden_FlowIds
+	}
+	return nil
+}
+
+func (x *RestoreFlowsRequest) SetFlowIds(v []string) {
+	x.xxx_hidden_FlowIds = v
+}
+
+type RestoreFlowsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	FlowIds []string
+}
+
+func (b0 RestoreFlowsRequest_builder) Build() *RestoreFlowsRequest {
+	m0 := &RestoreFlowsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_FlowIds = b.FlowIds
+	return m0
+}
+
+type RestoreFlowsResponse struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Flows *[]*FlowSummary        `protobuf:"bytes,1,rep,name=flows"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RestoreFlowsResponse) Reset() {
+	*x = RestoreFlowsResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFlowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFlowsResponse) ProtoMessage() {}
+
+func (x *RestoreFlowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RestoreFlowsResponse) GetFlows() []*FlowSummary {
+	if x != nil {
+		if x.xxx_hidden_Flows != nil {
+			return *x.xxx_hidden_Flows
+		}
+	}
+	return nil
+}
+
+func (x *RestoreFlowsResponse) SetFlows(v []*FlowSummary) {
+	x.xxx_hidden_Flows = &v
+}
+
+type RestoreFlowsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Flows []*FlowSummary
+}
+
+func (b0 RestoreFlowsResponse_builder) Build() *RestoreFlowsResponse {
+	m0 := &RestoreFlowsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Flows = &b.Flows
+	return m0
+}
+
+type UploadFlowBodyRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_FlowId      *string                `protobuf:"bytes,1,opt,name=flow_id,json=flowId"`
+	xxx_hidden_Response    bool                   `protobuf:"varint,2,opt,name=response"`
+	xxx_hidden_Chunk       []byte                 `protobuf:"bytes,3,opt,name=chunk"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *UploadFlowBodyRequest) Reset() {
+	*x = UploadFlowBodyRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadFlowBodyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFlowBodyRequest) ProtoMessage() {}
+
+func (x *UploadFlowBodyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UploadFlowBodyRequest) GetFlowId() string {
+	if x != nil {
+		if x.xxx_hidden_FlowId != nil {
+			return *x.xxx_hidden_FlowId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *UploadFlowBodyRequest) GetResponse() bool {
+	if x != nil {
+		return x.xxx_hidden_Response
+	}
+	return false
+}
+
+func (x *UploadFlowBodyRequest) GetChunk() []byte {
+	if x != nil {
+		return x.xxx_hidden_Chunk
+	}
+	return nil
+}
+
+func (x *UploadFlowBodyRequest) SetFlowId(v string) {
+	x.xxx_hidden_FlowId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *UploadFlowBodyRequest) SetResponse(v bool) {
+	x.xxx_hidden_Response = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *UploadFlowBodyRequest) SetChunk(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Chunk = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *UploadFlowBodyRequest) HasFlowId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *UploadFlowBodyRequest) HasResponse() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *UploadFlowBodyRequest) HasChunk() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *UploadFlowBodyRequest) ClearFlowId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_FlowId = nil
+}
+
+func (x *UploadFlowBodyRequest) ClearResponse() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Response = false
+}
+
+func (x *UploadFlowBodyRequest) ClearChunk() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Chunk = nil
+}
+
+type UploadFlowBodyRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The ID of an HTTP flow that has already been exported.
+	FlowId *string
+	// Whether the chunk belongs to the response body rather than the request
+	// body.
+	Response *bool
+	// The next part of the body.
+	Chunk []byte
+}
+
+func (b0 UploadFlowBodyRequest_builder) Build() *UploadFlowBodyRequest {
+	m0 := &UploadFlowBodyRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.FlowId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_FlowId = b.FlowId
+	}
+	if b.Response != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Response = *b.Response
+	}
+	if b.Chunk != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Chunk = b.Chunk
+	}
+	return m0
+}
+
+type UploadFlowBodyResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Bodies      int64                  `protobuf:"varint,1,opt,name=bodies"`
+	xxx_hidden_Bytes       int64                  `protobuf:"varint,2,opt,name=bytes"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *UploadFlowBodyResponse) Reset() {
+	*x = UploadFlowBodyResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadFlowBodyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFlowBodyResponse) ProtoMessage() {}
+
+func (x *UploadFlowBodyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UploadFlowBodyResponse) GetBodies() int64 {
+	if x != nil {
+		return x.xxx_hidden_Bodies
+	}
+	return 0
+}
+
+func (x *UploadFlowBodyResponse) GetBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_Bytes
+	}
+	return 0
+}
+
+func (x *UploadFlowBodyResponse) SetBodies(v int64) {
+	x.xxx_hidden_Bodies = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *UploadFlowBodyResponse) SetBytes(v int64) {
+	x.xxx_hidden_Bytes = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *UploadFlowBodyResponse) HasBodies() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *UploadFlowBodyResponse) HasBytes() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *UploadFlowBodyResponse) ClearBodies() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Bodies = 0
+}
+
+func (x *UploadFlowBodyResponse) ClearBytes() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Bytes = 0
+}
+
+type UploadFlowBodyResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// How many bodies were attached to their flows.
+	Bodies *int64
+	// The total size of the bodies.
+	Bytes *int64
+}
+
+func (b0 UploadFlowBodyResponse_builder) Build() *UploadFlowBodyResponse {
+	m0 := &UploadFlowBodyResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Bodies != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Bodies = *b.Bodies
+	}
+	if b.Bytes != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Bytes = *b.Bytes
+	}
+	return m0
+}
+
+// AuditEvent records who changed or exported flows, or changed the proxy
+// rules or the baseline, and when.
+type AuditEvent struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Time        *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time"`
+	xxx_hidden_Actor       *string                `protobuf:"bytes,2,opt,name=actor"`
+	xxx_hidden_Peer        *string                `protobuf:"bytes,3,opt,name=peer"`
+	xxx_hidden_Action      AuditAction            `protobuf:"varint,4,opt,name=action,enum=mitmflow.v1.AuditAction"`
+	xxx_hidden_FlowIds     []string               `protobuf:"bytes,5,rep,name=flow_ids,json=flowIds"`
+	xxx_hidden_Count       int64                  `protobuf:"varint,6,opt,name=count"`
+	xxx_hidden_Detail      *string                `protobuf:"bytes,7,opt,name=detail"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AuditEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Time
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetActor() string {
+	if x != nil {
+		if x.xxx_hidden_Actor != nil {
+			return *x.xxx_hidden_Actor
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetPeer() string {
+	if x != nil {
+		if x.xxx_hidden_Peer != nil {
+			return *x.xxx_hidden_Peer
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAction() AuditAction {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 3) {
+			return x.xxx_hidden_Action
+		}
+	}
+	return AuditAction_AUDIT_ACTION_UNSPECIFIED
+}
+
+func (x *AuditEvent) GetFlowIds() []string {
+	if x != nil {
+		return x.xxx_hidden_FlowIds
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetCount() int64 {
+	if x != nil {
+		return x.xxx_hidden_Count
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetDetail() string {
+	if x != nil {
+		if x.xxx_hidden_Detail != nil {
+			return *x.xxx_hidden_Detail
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AuditEvent) SetTime(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Time = v
+}
+
+func (x *AuditEvent) SetActor(v string) {
+	x.xxx_hidden_Actor = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+}
+
+func (x *AuditEvent) SetPeer(v string) {
+	x.xxx_hidden_Peer = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+}
+
+func (x *AuditEvent) SetAction(v AuditAction) {
+	x.xxx_hidden_Action = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
+}
+
+func (x *AuditEvent) SetFlowIds(v []string) {
+	x.xxx_hidden_FlowIds = v
+}
+
+func (x *AuditEvent) SetCount(v int64) {
+	x.xxx_hidden_Count = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *AuditEvent) SetDetail(v string) {
+	x.xxx_hidden_Detail = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+}
+
+func (x *AuditEvent) HasTime() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Time != nil
+}
+
+func (x *AuditEvent) HasActor() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *AuditEvent) HasPeer() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *AuditEvent) HasAction() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *AuditEvent) HasCount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *AuditEvent) HasDetail() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *AuditEvent) ClearTime() {
+	x.xxx_hidden_Time = nil
+}
+
+func (x *AuditEvent) ClearActor() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Actor = nil
+}
+
+func (x *AuditEvent) ClearPeer() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Peer = nil
+}
+
+func (x *AuditEvent) ClearAction() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Action = AuditAction_AUDIT_ACTION_UNSPECIFIED
+}
+
+func (x *AuditEvent) ClearCount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Count = 0
+}
+
+func (x *AuditEvent) ClearDetail() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Detail = nil
+}
+
+type AuditEvent_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Time *timestamppb.Timestamp
+	// The name of the token or OIDC user that made the change, or empty if
+	// the server doesn't require authentication.
+	Actor *string
+	// The address the request came from.
+	Peer   *string
+	Action *AuditAction
+	// The flows affected. Left empty when every flow was.
+	FlowIds []string
+	// How many flows were affected.
+	Count *int64
+	// More about the change, like the export format.
+	Detail *string
+}
+
+func (b0 AuditEvent_builder) Build() *AuditEvent {
+	m0 := &AuditEvent{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Time = b.Time
+	if b.Actor != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_Actor = b.Actor
+	}
+	if b.Peer != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		x.xxx_hidden_Peer = b.Peer
+	}
+	if b.Action != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		x.xxx_hidden_Action = *b.Action
+	}
+	x.xxx_hidden_FlowIds = b.FlowIds
+	if b.Count != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_Count = *b.Count
+	}
+	if b.Detail != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_Detail = b.Detail
+	}
+	return m0
+}
+
+type ListAuditEventsRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Limit       int32                  `protobuf:"varint,1,opt,name=limit"`
+	xxx_hidden_Actions     []AuditAction          `protobuf:"varint,2,rep,packed,name=actions,enum=mitmflow.v1.AuditAction"`
+	xxx_hidden_Actor       *string                `protobuf:"bytes,3,opt,name=actor"`
+	xxx_hidden_Since       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=since"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListAuditEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.xxx_hidden_Limit
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetActions() []AuditAction {
+	if x != nil {
+		return x.xxx_hidden_Actions
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetActor() string {
+	if x != nil {
+		if x.xxx_hidden_Actor != nil {
+			return *x.xxx_hidden_Actor
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Since
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) SetLimit(v int32) {
+	x.xxx_hidden_Limit = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *ListAuditEventsRequest) SetActions(v []AuditAction) {
+	x.xxx_hidden_Actions = v
+}
+
+func (x *ListAuditEventsRequest) SetActor(v string) {
+	x.xxx_hidden_Actor = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *ListAuditEventsRequest) SetSince(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Since = v
+}
+
+func (x *ListAuditEventsRequest) HasLimit() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ListAuditEventsRequest) HasActor() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ListAuditEventsRequest) HasSince() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Since != nil
+}
+
+func (x *ListAuditEventsRequest) ClearLimit() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Limit = 0
+}
+
+func (x *ListAuditEventsRequest) ClearActor() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Actor = nil
+}
+
+func (x *ListAuditEventsRequest) ClearSince() {
+	x.xxx_hidden_Since = nil
+}
+
+type ListAuditEventsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The most events to return. Defaults to 100.
+	Limit *int32
+	// Only return events with these actions.
+	Actions []AuditAction
+	// Only return events by this actor.
+	Actor *string
+	// Only return events at or after this time.
+	Since *timestamppb.Timestamp
+}
+
+func (b0 ListAuditEventsRequest_builder) Build() *ListAuditEventsRequest {
+	m0 := &ListAuditEventsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Limit != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Limit = *b.Limit
+	}
+	x.xxx_hidden_Actions = b.Actions
+	if b.Actor != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Actor = b.Actor
+	}
+	x.xxx_hidden_Since = b.Since
+	return m0
+}
+
+type ListAuditEventsResponse struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Events *[]*AuditEvent         `protobuf:"bytes,1,rep,name=events"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		if x.xxx_hidden_Events != nil {
+			return *x.xxx_hidden_Events
+		}
+	}
+	return nil
+}
+
+func (x *ListAuditEventsResponse) SetEvents(v []*AuditEvent) {
+	x.xxx_hidden_Events = &v
+}
+
+type ListAuditEventsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Events []*AuditEvent
+}
+
+func (b0 ListAuditEventsResponse_builder) Build() *ListAuditEventsResponse {
+	m0 := &ListAuditEventsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Events = &b.Events
+	return m0
+}
+
+type ListSubscribersRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscribersRequest) Reset() {
+	*x = ListSubscribersRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscribersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscribersRequest) ProtoMessage() {}
+
+func (x *ListSubscribersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type ListSubscribersRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 ListSubscribersRequest_builder) Build() *ListSubscribersRequest {
+	m0 := &ListSubscribersRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type ListSubscribersResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Subscribers *[]*Subscriber         `protobuf:"bytes,1,rep,name=subscribers"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ListSubscribersResponse) Reset() {
+	*x = ListSubscribersResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscribersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscribersResponse) ProtoMessage() {}
+
+func (x *ListSubscribersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListSubscribersResponse) GetSubscribers() []*Subscriber {
+	if x != nil {
+		if x.xxx_hidden_Subscribers != nil {
+			return *x.xxx_hidden_Subscribers
+		}
+	}
+	return nil
+}
+
+func (x *ListSubscribersResponse) SetSubscribers(v []*Subscriber) {
+	x.xxx_hidden_Subscribers = &v
+}
+
+type ListSubscribersResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Ordered by connect time, oldest first.
+	Subscribers []*Subscriber
+}
+
+func (b0 ListSubscribersResponse_builder) Build() *ListSubscribersResponse {
+	m0 := &ListSubscribersResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Subscribers = &b.Subscribers
+	return m0
+}
+
+// Subscriber is a client streaming flows with StreamFlows or over the
+// WebSocket endpoint.
+type Subscriber struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_ConnectTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=connect_time,json=connectTime"`
+	xxx_hidden_Peer        *string                `protobuf:"bytes,3,opt,name=peer"`
+	xxx_hidden_Filter      *FlowFilter            `protobuf:"bytes,4,opt,name=filter"`
+	xxx_hidden_Delivered   int64                  `protobuf:"varint,5,opt,name=delivered"`
+	xxx_hidden_Dropped     int64                  `protobuf:"varint,6,opt,name=dropped"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Subscriber) Reset() {
+	*x = Subscriber{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subscriber) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscriber) ProtoMessage() {}
+
+func (x *Subscriber) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Subscriber) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Subscriber) GetConnectTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ConnectTime
+	}
+	return nil
+}
+
+func (x *Subscriber) GetPeer() string {
+	if x != nil {
+		if x.xxx_hidden_Peer != nil {
+			return *x.xxx_hidden_Peer
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Subscriber) GetFilter() *FlowFilter {
+	if x != nil {
+		return x.xxx_hidden_Filter
+	}
+	return nil
+}
+
+func (x *Subscriber) GetDelivered() int64 {
+	if x != nil {
+		return x.xxx_hidden_Delivered
+	}
+	return 0
+}
+
+func (x *Subscriber) GetDropped() int64 {
+	if x != nil {
+		return x.xxx_hidden_Dropped
+	}
+	return 0
+}
+
+func (x *Subscriber) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+}
+
+func (x *Subscriber) SetConnectTime(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ConnectTime = v
+}
+
+func (x *Subscriber) SetPeer(v string) {
+	x.xxx_hidden_Peer = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
+}
+
+func (x *Subscriber) SetFilter(v *FlowFilter) {
+	x.xxx_hidden_Filter = v
+}
+
+func (x *Subscriber) SetDelivered(v int64) {
+	x.xxx_hidden_Delivered = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *Subscriber) SetDropped(v int64) {
+	x.xxx_hidden_Dropped = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
+func (x *Subscriber) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *Subscriber) HasConnectTime() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ConnectTime != nil
+}
+
+func (x *Subscriber) HasPeer() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *Subscriber) HasFilter() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Filter != nil
+}
+
+func (x *Subscriber) HasDelivered() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *Subscriber) HasDropped() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *Subscriber) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *Subscriber) ClearConnectTime() {
+	x.xxx_hidden_ConnectTime = nil
+}
+
+func (x *Subscriber) ClearPeer() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Peer = nil
+}
+
+func (x *Subscriber) ClearFilter() {
+	x.xxx_hidden_Filter = nil
+}
+
+func (x *Subscriber) ClearDelivered() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Delivered = 0
+}
+
+func (x *Subscriber) ClearDropped() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Dropped = 0
+}
+
+type Subscriber_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id          *string
+	ConnectTime *timestamppb.Timestamp
+	// The address the client connected from.
+	Peer *string
+	// The filter the client asked for, if any.
+	Filter *FlowFilter
+	// How many flows have been sent to the client.
+	Delivered *int64
+	// How many flows were dropped because the client wasn't keeping up.
+	Dropped *int64
+}
+
+func (b0 Subscriber_builder) Build() *Subscriber {
+	m0 := &Subscriber{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		x.xxx_hidden_Id = b.Id
+	}
+	x.xxx_hidden_ConnectTime = b.ConnectTime
+	if b.Peer != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
+		x.xxx_hidden_Peer = b.Peer
+	}
+	x.xxx_hidden_Filter = b.Filter
+	if b.Delivered != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_Delivered = *b.Delivered
+	}
+	if b.Dropped != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_Dropped = *b.Dropped
+	}
+	return m0
+}
+
+type GetServerInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerInfoRequest) Reset() {
+	*x = GetServerInfoRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoRequest) ProtoMessage() {}
+
+func (x *GetServerInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type GetServerInfoRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 GetServerInfoRequest_builder) Build() *GetServerInfoRequest {
+	m0 := &GetServerInfoRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type GetServerInfoResponse struct {
+	state                                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Version                   *string                `protobuf:"bytes,1,opt,name=version"`
+	xxx_hidden_GoVersion                 *string                `protobuf:"bytes,2,opt,name=go_version,json=goVersion"`
+	xxx_hidden_VcsRevision               *string                `protobuf:"bytes,3,opt,name=vcs_revision,json=vcsRevision"`
+	xxx_hidden_VcsTime                   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=vcs_time,json=vcsTime"`
+	xxx_hidden_StartTime                 *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime"`
+	xxx_hidden_UptimeMs                  int64                  `protobuf:"varint,6,opt,name=uptime_ms,json=uptimeMs"`
+	xxx_hidden_MaxFlows                  int32                  `protobuf:"varint,7,opt,name=max_flows,json=maxFlows"`
+	xxx_hidden_MaxBodyBytes              int64                  `protobuf:"varint,8,opt,name=max_body_bytes,json=maxBodyBytes"`
+	xxx_hidden_BlobThreshold             int64                  `protobuf:"varint,9,opt,name=blob_threshold,json=blobThreshold"`
+	xxx_hidden_DataDir                   *string                `protobuf:"bytes,10,opt,name=data_dir,json=dataDir"`
+	xxx_hidden_ArchiveDir                *string                `protobuf:"bytes,11,opt,name=archive_dir,json=archiveDir"`
+	xxx_hidden_BackupDir                 *string                `protobuf:"bytes,12,opt,name=backup_dir,json=backupDir"`
+	xxx_hidden_FlowCount                 int64                  `protobuf:"varint,13,opt,name=flow_count,json=flowCount"`
+	xxx_hidden_FlowCounts                map[string]int64       `protobuf:"bytes,14,rep,name=flow_counts,json=flowCounts" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	xxx_hidden_DescriptorFileCount       int32                  `protobuf:"varint,15,opt,name=descriptor_file_count,json=descriptorFileCount"`
+	xxx_hidden_SubscriberCount           int32                  `protobuf:"varint,16,opt,name=subscriber_count,json=subscriberCount"`
+	xxx_hidden_MaxIngestMessageBytes     int64                  `protobuf:"varint,17,opt,name=max_ingest_message_bytes,json=maxIngestMessageBytes"`
+	xxx_hidden_StreamKeepaliveIntervalMs int64                  `protobuf:"varint,18,opt,name=stream_keepalive_interval_ms,json=streamKeepaliveIntervalMs"`
+	XXX_raceDetectHookData               protoimpl.RaceDetectHookData
+	XXX_presence                         [1]uint32
+	unknownFields                        protoimpl.UnknownFields
+	sizeCache                            protoimpl.SizeCache
+}
+
+func (x *GetServerInfoResponse) Reset() {
+	*x = GetServerInfoResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoResponse) ProtoMessage() {}
+
+func (x *GetServerInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetServerInfoResponse) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetGoVersion() string {
+	if x != nil {
+		if x.xxx_hidden_GoVersion != nil {
+			return *x.xxx_hidden_GoVersion
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetVcsRevision() string {
+	if x != nil {
+		if x.xxx_hidden_VcsRevision != nil {
+			return *x.xxx_hidden_VcsRevision
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetVcsTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_VcsTime
+	}
+	return nil
+}
+
+func (x *GetServerInfoResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_StartTime
+	}
+	return nil
+}
+
+func (x *GetServerInfoResponse) GetUptimeMs() int64 {
+	if x != nil {
+		return x.xxx_hidden_UptimeMs
+	}
+	return 0
+}
+
+func (x *GetServerInfoResponse) GetMaxFlows() int32 {
+	if x != nil {
+		return x.xxx_hidden_MaxFlows
+	}
+	return 0
+}
+
+func (x *GetServerInfoResponse) GetMaxBodyBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_MaxBodyBytes
+	}
+	return 0
+}
+
+func (x *GetServerInfoResponse) GetBlobThreshold() int64 {
+	if x != nil {
+		return x.xxx_hidden_BlobThreshold
+	}
+	return 0
+}
+
+func (x *GetServerInfoResponse) GetDataDir() string {
+	if x != nil {
+		if x.xxx_hidden_DataDir != nil {
+			return *x.xxx_hidden_DataDir
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetArchiveDir() string {
+	if x != nil {
+		if x.xxx_hidden_ArchiveDir != nil {
+			return *x.xxx_hidden_ArchiveDir
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetBackupDir() string {
+	if x != nil {
+		if x.xxx_hidden_BackupDir != nil {
+			return *x.xxx_hidden_BackupDir
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetFlowCount() int64 {
+	if x != nil {
+		return x.xxx_hidden_FlowCount
+	}
+	return 0
+}
+
+func (x *GetServerInfoResponse) GetFlowCounts() map[string]int64 {
+	if x != nil {
+		return x.xxx_hidden_FlowCounts
+	}
+	return nil
+}
+
+func (x *GetServerInfoResponse) GetDescriptorFileCount() int32 {
+	if x != nil {
+		return x.xxx_hidden_DescriptorFileCount
+	}
+	return 0
+}
+
+func (x *GetServerInfoResponse) GetSubscriberCount() int32 {
+	if x != nil {
+		return x.xxx_hidden_SubscriberCount
+	}
+	return 0
+}
+
+func (x *GetServerInfoResponse) GetMaxIngestMessageBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_MaxIngestMessageBytes
+	}
+	return 0
+}
+
+func (x *GetServerInfoResponse) GetStreamKeepaliveIntervalMs() int64 {
+	if x != nil {
+		return x.xxx_hidden_StreamKeepaliveIntervalMs
+	}
+	return 0
+}
+
+func (x *GetServerInfoResponse) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 18)
+}
+
+func (x *GetServerInfoResponse) SetGoVersion(v string) {
+	x.xxx_hidden_GoVersion = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 18)
+}
+
+func (x *GetServerInfoResponse) SetVcsRevision(v string) {
+	x.xxx_hidden_VcsRevision = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 18)
+}
+
+func (x *GetServerInfoResponse) SetVcsTime(v *timestamppb.Timestamp) {
+	x.xxx_hidden_VcsTime = v
+}
+
+func (x *GetServerInfoResponse) SetStartTime(v *timestamppb.Timestamp) {
+	x.xxx_hidden_StartTime = v
+}
+
+func (x *GetServerInfoResponse) SetUptimeMs(v int64) {
+	x.xxx_hidden_UptimeMs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 18)
+}
+
+func (x *GetServerInfoResponse) SetMaxFlows(v int32) {
+	x.xxx_hidden_MaxFlows = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 18)
+}
+
+func (x *GetServerInfoResponse) SetMaxBodyBytes(v int64) {
+	x.xxx_hidden_MaxBodyBytes = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 18)
+}
+
+func (x *GetServerInfoResponse) SetBlobThreshold(v int64) {
+	x.xxx_hidden_BlobThreshold = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 18)
+}
+
+func (x *GetServerInfoResponse) SetDataDir(v string) {
+	x.xxx_hidden_DataDir = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 18)
+}
+
+func (x *GetServerInfoResponse) SetArchiveDir(v string) {
+	x.xxx_hidden_ArchiveDir = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 10, 18)
+}
+
+func (x *GetServerInfoResponse) SetBackupDir(v string) {
+	x.xxx_hidden_BackupDir = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 11, 18)
+}
+
+func (x *GetServerInfoResponse) SetFlowCount(v int64) {
+	x.xxx_hidden_FlowCount = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 12, 18)
+}
+
+func (x *GetServerInfoResponse) SetFlowCounts(v map[string]int64) {
+	x.xxx_hidden_FlowCounts = v
+}
+
+func (x *GetServerInfoResponse) SetDescriptorFileCount(v int32) {
+	x.xxx_hidden_DescriptorFileCount = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 14, 18)
+}
+
+func (x *GetServerInfoResponse) SetSubscriberCount(v int32) {
+	x.xxx_hidden_SubscriberCount = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 15, 18)
+}
+
+func (x *GetServerInfoResponse) SetMaxIngestMessageBytes(v int64) {
+	x.xxx_hidden_MaxIngestMessageBytes = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 16, 18)
+}
+
+func (x *GetServerInfoResponse) SetStreamKeepaliveIntervalMs(v int64) {
+	x.xxx_hidden_StreamKeepaliveIntervalMs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 17, 18)
+}
+
+func (x *GetServerInfoResponse) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *GetServerInfoResponse) HasGoVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *GetServerInfoResponse) HasVcsRevision() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *GetServerInfoResponse) HasVcsTime() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_VcsTime != nil
+}
+
+func (x *GetServerInfoResponse) HasStartTime() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_StartTime != nil
+}
+
+func (x *GetServerInfoResponse) HasUptimeMs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *GetServerInfoResponse) HasMaxFlows() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *GetServerInfoResponse) HasMaxBodyBytes() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *GetServerInfoResponse) HasBlobThreshold() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *GetServerInfoResponse) HasDataDir() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *GetServerInfoResponse) HasArchiveDir() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 10)
+}
+
+func (x *GetServerInfoResponse) HasBackupDir() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 11)
+}
+
+func (x *GetServerInfoResponse) HasFlowCount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 12)
+}
+
+func (x *GetServerInfoResponse) HasDescriptorFileCount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 14)
+}
+
+func (x *GetServerInfoResponse) HasSubscriberCount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 15)
+}
+
+func (x *GetServerInfoResponse) HasMaxIngestMessageBytes() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 16)
+}
+
+func (x *GetServerInfoResponse) HasStreamKeepaliveIntervalMs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 17)
+}
+
+func (x *GetServerInfoResponse) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *GetServerInfoResponse) ClearGoVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_GoVersion = nil
+}
+
+func (x *GetServerInfoResponse) ClearVcsRevision() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_VcsRevision = nil
+}
+
+func (x *GetServerInfoResponse) ClearVcsTime() {
+	x.xxx_hidden_VcsTime = nil
+}
+
+func (x *GetServerInfoResponse) ClearStartTime() {
+	x.xxx_hidden_StartTime = nil
+}
+
+func (x *GetServerInfoResponse) ClearUptimeMs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_UptimeMs = 0
+}
+
+func (x *GetServerInfoResponse) ClearMaxFlows() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_MaxFlows = 0
+}
+
+func (x *GetServerInfoResponse) ClearMaxBodyBytes() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_MaxBodyBytes = 0
+}
+
+func (x *GetServerInfoResponse) ClearBlobThreshold() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_BlobThreshold = 0
+}
+
+func (x *GetServerInfoResponse) ClearDataDir() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_DataDir = nil
+}
+
+func (x *GetServerInfoResponse) ClearArchiveDir() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 10)
+	x.xxx_hidden_ArchiveDir = nil
+}
+
+func (x *GetServerInfoResponse) ClearBackupDir() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 11)
+	x.xxx_hidden_BackupDir = nil
+}
+
+func (x *GetServerInfoResponse) ClearFlowCount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 12)
+	x.xxx_hidden_FlowCount = 0
+}
+
+func (x *GetServerInfoResponse) ClearDescriptorFileCount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 14)
+	x.xxx_hidden_DescriptorFileCount = 0
+}
+
+func (x *GetServerInfoResponse) ClearSubscriberCount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 15)
+	x.xxx_hidden_SubscriberCount = 0
+}
+
+func (x *GetServerInfoResponse) ClearMaxIngestMessageBytes() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 16)
+	x.xxx_hidden_MaxIngestMessageBytes = 0
+}
+
+func (x *GetServerInfoResponse) ClearStreamKeepaliveIntervalMs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 17)
+	x.xxx_hidden_StreamKeepaliveIntervalMs = 0
+}
+
+type GetServerInfoResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Version   *string
+	GoVersion *string
+	// VCS revision and commit time the binary was built from, when known.
+	VcsRevision   *string
+	VcsTime       *timestamppb.Timestamp
+	StartTime     *timestamppb.Timestamp
+	UptimeMs      *int64
+	MaxFlows      *int32
+	MaxBodyBytes  *int64
+	BlobThreshold *int64
+	DataDir       *string
+	ArchiveDir    *string
+	BackupDir     *string
+	FlowCount     *int64
+	// Number of stored flows keyed by type: "http", "dns", "tcp", "udp".
+	FlowCounts          map[string]int64
+	DescriptorFileCount *int32
+	SubscriberCount     *int32
+	// The largest ExportFlowRequest message accepted from mitmproxy, or zero
+	// for no limit.
+	MaxIngestMessageBytes *int64
+	// How often idle flow streams are sent a keepalive, or zero if they aren't.
+	StreamKeepaliveIntervalMs *int64
+}
+
+func (b0 GetServerInfoResponse_builder) Build() *GetServerInfoResponse {
+	m0 := &GetServerInfoResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 18)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.GoVersion != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 18)
+		x.xxx_hidden_GoVersion = b.GoVersion
+	}
+	if b.VcsRevision != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 18)
+		x.xxx_hidden_VcsRevision = b.VcsRevision
+	}
+	x.xxx_hidden_VcsTime = b.VcsTime
+	x.xxx_hidden_StartTime = b.StartTime
+	if b.UptimeMs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 18)
+		x.xxx_hidden_UptimeMs = *b.UptimeMs
+	}
+	if b.MaxFlows != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 18)
+		x.xxx_hidden_MaxFlows = *b.MaxFlows
+	}
+	if b.MaxBodyBytes != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 18)
+		x.xxx_hidden_MaxBodyBytes = *b.MaxBodyBytes
+	}
+	if b.BlobThreshold != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 18)
+		x.xxx_hidden_BlobThreshold = *b.BlobThreshold
+	}
+	if b.DataDir != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 18)
+		x.xxx_hidden_DataDir = b.DataDir
+	}
+	if b.ArchiveDir != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 10, 18)
+		x.xxx_hidden_ArchiveDir = b.ArchiveDir
+	}
+	if b.BackupDir != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 11, 18)
+		x.xxx_hidden_BackupDir = b.BackupDir
+	}
+	if b.FlowCount != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 12, 18)
+		x.xxx_hidden_FlowCount = *b.FlowCount
+	}
+	x.xxx_hidden_FlowCounts = b.FlowCounts
+	if b.DescriptorFileCount != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 14, 18)
+		x.xxx_hidden_DescriptorFileCount = *b.DescriptorFileCount
+	}
+	if b.SubscriberCount != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 15, 18)
+		x.xxx_hidden_SubscriberCount = *b.SubscriberCount
+	}
+	if b.MaxIngestMessageBytes != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 16, 18)
+		x.xxx_hidden_MaxIngestMessageBytes = *b.MaxIngestMessageBytes
+	}
+	if b.StreamKeepaliveIntervalMs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 17, 18)
+		x.xxx_hidden_StreamKeepaliveIntervalMs = *b.StreamKeepaliveIntervalMs
+	}
+	return m0
+}
+
+type SendRequestRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Method      *string                `protobuf:"bytes,1,opt,name=method"`
+	xxx_hidden_Url         *string                `protobuf:"bytes,2,opt,name=url"`
+	xxx_hidden_Headers     map[string]string      `protobuf:"bytes,3,rep,name=headers" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_Body        []byte                 `protobuf:"bytes,4,opt,name=body"`
+	xxx_hidden_Proxy       *string                `protobuf:"bytes,5,opt,name=proxy"`
+	xxx_hidden_TimeoutMs   int64                  `protobuf:"varint,6,opt,name=timeout_ms,json=timeoutMs"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SendRequestRequest) Reset() {
+	*x = SendRequestRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendRequestRequest) ProtoMessage() {}
+
+func (x *SendRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SendRequestRequest) GetMethod() string {
+	if x != nil {
+		if x.xxx_hidden_Method != nil {
+			return *x.xxx_hidden_Method
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SendRequestRequest) GetUrl() string {
+	if x != nil {
+		if x.xxx_hidden_Url != nil {
+			return *x.xxx_hidden_Url
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SendRequestRequest) GetHeaders() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Headers
+	}
+	return nil
+}
+
+func (x *SendRequestRequest) GetBody() []byte {
+	if x != nil {
+		return x.xxx_hidden_Body
+	}
+	return nil
+}
+
+func (x *SendRequestRequest) GetProxy() string {
+	if x != nil {
+		if x.xxx_hidden_Proxy != nil {
+			return *x.xxx_hidden_Proxy
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SendRequestRequest) GetTimeoutMs() int64 {
+	if x != nil {
+		return x.xxx_hidden_TimeoutMs
+	}
+	return 0
+}
+
+func (x *SendRequestRequest) SetMethod(v string) {
+	x.xxx_hidden_Method = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+}
+
+func (x *SendRequestRequest) SetUrl(v string) {
+	x.xxx_hidden_Url = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+}
+
+func (x *SendRequestRequest) SetHeaders(v map[string]string) {
+	x.xxx_hidden_Headers = v
+}
+
+func (x *SendRequestRequest) SetBody(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Body = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
+}
+
+func (x *SendRequestRequest) SetProxy(v string) {
+	x.xxx_hidden_Proxy = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *SendRequestRequest) SetTimeoutMs(v int64) {
+	x.xxx_hidden_TimeoutMs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
+func (x *SendRequestRequest) HasMethod() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SendRequestRequest) HasUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *SendRequestRequest) HasBody() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *SendRequestRequest) HasProxy() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *SendRequestRequest) HasTimeoutMs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *SendRequestRequest) ClearMethod() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Method = nil
+}
+
+func (x *SendRequestRequest) ClearUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Url = nil
+}
+
+func (x *SendRequestRequest) ClearBody() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Body = nil
+}
+
+func (x *SendRequestRequest) ClearProxy() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Proxy = nil
+}
+
+func (x *SendRequestRequest) ClearTimeoutMs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_TimeoutMs = 0
+}
+
+type SendRequestRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Defaults to GET.
+	Method  *string
+	Url     *string
+	Headers map[string]string
+	Body    []byte
+	// Optional HTTP proxy URL to send the request through, e.g. the mitmproxy
+	// instance feeding this server.
+	Proxy *string
+	// Defaults to 30 seconds.
+	TimeoutMs *int64
+}
+
+func (b0 SendRequestRequest_builder) Build() *SendRequestRequest {
+	m0 := &SendRequestRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Method != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		x.xxx_hidden_Method = b.Method
+	}
+	if b.Url != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		x.xxx_hidden_Url = b.Url
+	}
+	x.xxx_hidden_Headers = b.Headers
+	if b.Body != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 6)
+		x.xxx_hidden_Body = b.Body
+	}
+	if b.Proxy != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_Proxy = b.Proxy
+	}
+	if b.TimeoutMs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_TimeoutMs = *b.TimeoutMs
+	}
+	return m0
+}
+
+type SendRequestResponse struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Flow *Flow                  `protobuf:"bytes,1,opt,name=flow"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SendRequestResponse) Reset() {
+	*x = SendRequestResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendRequestResponse) ProtoMessage() {}
+
+func (x *SendRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SendRequestResponse) GetFlow() *Flow {
+	if x != nil {
+		return x.xxx_hidden_Flow
+	}
+	return nil
+}
+
+func (x *SendRequestResponse) SetFlow(v *Flow) {
+	x.xxx_hidden_Flow = v
+}
+
+func (x *SendRequestResponse) HasFlow() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Flow != nil
+}
+
+func (x *SendRequestResponse) ClearFlow() {
+	x.xxx_hidden_Flow = nil
+}
+
+type SendRequestResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The stored flow. Transport errors are recorded on the flow rather than
+	// returned as an RPC error.
+	Flow *Flow
+}
+
+func (b0 SendRequestResponse_builder) Build() *SendRequestResponse {
+	m0 := &SendRequestResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Flow = b.Flow
+	return m0
+}
+
+type GetCookieTimelineRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Domain      *string                `protobuf:"bytes,2,opt,name=domain"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GetCookieTimelineRequest) Reset() {
+	*x = GetCookieTimelineRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCookieTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCookieTimelineRequest) ProtoMessage() {}
+
+func (x *GetCookieTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetCookieTimelineRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetCookieTimelineRequest) GetDomain() string {
+	if x != nil {
+		if x.xxx_hidden_Domain != nil {
+			return *x.xxx_hidden_Domain
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetCookieTimelineRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *GetCookieTimelineRequest) SetDomain(v string) {
+	x.xxx_hidden_Domain = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *GetCookieTimelineRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *GetCookieTimelineRequest) HasDomain() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *GetCookieTimelineRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *GetCookieTimelineRequest) ClearDomain() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Domain = nil
+}
+
+type GetCookieTimelineRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name *string
+	// Only flows to this domain or its subdomains, e.g. "example.com". Empty
+	// for every domain.
+	Domain *string
+}
+
+func (b0 GetCookieTimelineRequest_builder) Build() *GetCookieTimelineRequest {
+	m0 := &GetCookieTimelineRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Domain != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Domain = b.Domain
+	}
+	return m0
+}
+
+type GetCookieTimelineResponse struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Events *[]*CookieEvent        `protobuf:"bytes,1,rep,name=events"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetCookieTimelineResponse) Reset() {
+	*x = GetCookieTimelineResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCookieTimelineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCookieTimelineResponse) ProtoMessage() {}
+
+func (x *GetCookieTimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetCookieTimelineResponse) GetEvents() []*CookieEvent {
+	if x != nil {
+		if x.xxx_hidden_Events != nil {
+			return *x.xxx_hidden_Events
+		}
+	}
+	return nil
+}
+
+func (x *GetCookieTimelineResponse) SetEvents(v []*CookieEvent) {
+	x.xxx_hidden_Events = &v
+}
+
+type GetCookieTimelineResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Oldest first.
+	Events []*CookieEvent
+}
+
+func (b0 GetCookieTimelineResponse_builder) Build() *GetCookieTimelineResponse {
+	m0 := &GetCookieTimelineResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Events = &b.Events
+	return m0
+}
+
+// CookieEvent is one flow that set, sent or deleted a cookie.
+type CookieEvent struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Type          CookieEventType        `protobuf:"varint,1,opt,name=type,enum=mitmflow.v1.CookieEventType"`
+	xxx_hidden_FlowId        *string                `protobuf:"bytes,2,opt,name=flow_id,json=flowId"`
+	xxx_hidden_Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp"`
+	xxx_hidden_Host          *string                `protobuf:"bytes,4,opt,name=host"`
+	xxx_hidden_Value         *string                `protobuf:"bytes,5,opt,name=value"`
+	xxx_hidden_Domain        *string                `protobuf:"bytes,6,opt,name=domain"`
+	xxx_hidden_Path          *string                `protobuf:"bytes,7,opt,name=path"`
+	xxx_hidden_Expires       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires"`
+	xxx_hidden_Secure        bool                   `protobuf:"varint,9,opt,name=secure"`
+	xxx_hidden_HttpOnly      bool                   `protobuf:"varint,10,opt,name=http_only,json=httpOnly"`
+	xxx_hidden_SameSite      *string                `protobuf:"bytes,11,opt,name=same_site,json=sameSite"`
+	xxx_hidden_ValueChanged  bool                   `protobuf:"varint,12,opt,name=value_changed,json=valueChanged"`
+	xxx_hidden_ExpiryChanged bool                   `protobuf:"varint,13,opt,name=expiry_changed,json=expiryChanged"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *CookieEvent) Reset() {
+	*x = CookieEvent{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CookieEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CookieEvent) ProtoMessage() {}
+
+func (x *CookieEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CookieEvent) GetType() CookieEventType {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Type
+		}
+	}
+	return CookieEventType_COOKIE_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *CookieEvent) GetFlowId() string {
+	if x != nil {
+		if x.xxx_hidden_FlowId != nil {
+			return *x.xxx_hidden_FlowId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CookieEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Timestamp
+	}
+	return nil
+}
+
+func (x *CookieEvent) GetHost() string {
+	if x != nil {
+		if x.xxx_hidden_Host != nil {
+			return *x.xxx_hidden_Host
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CookieEvent) GetValue() string {
+	if x != nil {
+		if x.xxx_hidden_Value != nil {
+			return *x.xxx_hidden_Value
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CookieEvent) GetDomain() string {
+	if x != nil {
+		if x.xxx_hidden_Domain != nil {
+			return *x.xxx_hidden_Domain
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CookieEvent) GetPath() string {
+	if x != nil {
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CookieEvent) GetExpires() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Expires
+	}
+	return nil
+}
+
+func (x *CookieEvent) GetSecure() bool {
+	if x != nil {
+		return x.xxx_hidden_Secure
+	}
+	return false
+}
+
+func (x *CookieEvent) GetHttpOnly() bool {
+	if x != nil {
+		return x.xxx_hidden_HttpOnly
+	}
+	return false
+}
+
+func (x *CookieEvent) GetSameSite() string {
+	if x != nil {
+		if x.xxx_hidden_SameSite != nil {
+			return *x.xxx_hidden_SameSite
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CookieEvent) GetValueChanged() bool {
+	if x != nil {
+		return x.xxx_hidden_ValueChanged
+	}
+	return false
+}
+
+func (x *CookieEvent) GetExpiryChanged() bool {
+	if x != nil {
+		return x.xxx_hidden_ExpiryChanged
+	}
+	return false
+}
+
+func (x *CookieEvent) SetType(v CookieEventType) {
+	x.xxx_hidden_Type = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 13)
+}
+
+func (x *CookieEvent) SetFlowId(v string) {
+	x.xxx_hidden_FlowId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 13)
+}
+
+func (x *CookieEvent) SetTimestamp(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Timestamp = v
+}
+
+func (x *CookieEvent) SetHost(v string) {
+	x.xxx_hidden_Host = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 13)
+}
+
+func (x *CookieEvent) SetValue(v string) {
+	x.xxx_hidden_Value = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 13)
+}
+
+func (x *CookieEvent) SetDomain(v string) {
+	x.xxx_hidden_Domain = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 13)
+}
+
+func (x *CookieEvent) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 13)
+}
+
+func (x *CookieEvent) SetExpires(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Expires = v
+}
+
+func (x *CookieEvent) SetSecure(v bool) {
+	x.xxx_hidden_Secure = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 13)
+}
+
+func (x *CookieEvent) SetHttpOnly(v bool) {
+	x.xxx_hidden_HttpOnly = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 13)
+}
+
+func (x *CookieEvent) SetSameSite(v string) {
+	x.xxx_hidden_SameSite = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 10, 13)
+}
+
+func (x *CookieEvent) SetValueChanged(v bool) {
+	x.xxx_hidden_ValueChanged = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 11, 13)
+}
+
+func (x *CookieEvent) SetExpiryChanged(v bool) {
+	x.xxx_hidden_ExpiryChanged = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 12, 13)
+}
+
+func (x *CookieEvent) HasType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CookieEvent) HasFlowId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *CookieEvent) HasTimestamp() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Timestamp != nil
+}
+
+func (x *CookieEvent) HasHost() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *CookieEvent) HasValue() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *CookieEvent) HasDomain() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *CookieEvent) HasPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *CookieEvent) HasExpires() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Expires != nil
+}
+
+func (x *CookieEvent) HasSecure() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *CookieEvent) HasHttpOnly() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *CookieEvent) HasSameSite() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 10)
+}
+
+func (x *CookieEvent) HasValueChanged() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 11)
+}
+
+func (x *CookieEvent) HasExpiryChanged() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 12)
+}
+
+func (x *CookieEvent) ClearType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Type = CookieEventType_COOKIE_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *CookieEvent) ClearFlowId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_FlowId = nil
+}
+
+func (x *CookieEvent) ClearTimestamp() {
+	x.xxx_hidden_Timestamp = nil
+}
+
+func (x *CookieEvent) ClearHost() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Host = nil
+}
+
+func (x *CookieEvent) ClearValue() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Value = nil
+}
+
+func (x *CookieEvent) ClearDomain() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Domain = nil
+}
+
+func (x *CookieEvent) ClearPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Path = nil
+}
+
+func (x *CookieEvent) ClearExpires() {
+	x.xxx_hidden_Expires = nil
+}
+
+func (x *CookieEvent) ClearSecure() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_Secure = false
+}
+
+func (x *CookieEvent) ClearHttpOnly() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_HttpOnly = false
+}
+
+func (x *CookieEvent) ClearSameSite() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 10)
+	x.xxx_hidden_SameSite = nil
+}
+
+func (x *CookieEvent) ClearValueChanged() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 11)
+	x.xxx_hidden_ValueChanged = false
+}
+
+func (x *CookieEvent) ClearExpiryChanged() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 12)
+	x.xxx_hidden_ExpiryChanged = false
+}
+
+type CookieEvent_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Type      *CookieEventType
+	FlowId    *string
+	Timestamp *timestamppb.Timestamp
+	// The host the request went to.
+	Host  *string
+	Value *string
+	// The attributes of SET and DELETED events.
+	Domain *string
+	Path   *string
+	// From Expires or Max-Age. Unset for session cookies.
+	Expires  *timestamppb.Timestamp
+	Secure   *bool
+	HttpOnly *bool
+	SameSite *string
+	// The value differs from the event before.
+	ValueChanged *bool
+	// A SET event whose expiry differs from the cookie's previous SET event.
+	ExpiryChanged *bool
+}
+
+func (b0 CookieEvent_builder) Build() *CookieEvent {
+	m0 := &CookieEvent{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Type != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 13)
+		x.xxx_hidden_Type = *b.Type
+	}
+	if b.FlowId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 13)
+		x.xxx_hidden_FlowId = b.FlowId
+	}
+	x.xxx_hidden_Timestamp = b.Timestamp
+	if b.Host != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 13)
+		x.xxx_hidden_Host = b.Host
+	}
+	if b.Value != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 13)
+		x.xxx_hidden_Value = b.Value
+	}
+	if b.Domain != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 13)
+		x.xxx_hidden_Domain = b.Domain
+	}
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 13)
+		x.xxx_hidden_Path = b.Path
+	}
+	x.xxx_hidden_Expires = b.Expires
+	if b.Secure != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 13)
+		x.xxx_hidden_Secure = *b.Secure
+	}
+	if b.HttpOnly != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 13)
+		x.xxx_hidden_HttpOnly = *b.HttpOnly
+	}
+	if b.SameSite != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 10, 13)
+		x.xxx_hidden_SameSite = b.SameSite
+	}
+	if b.ValueChanged != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 11, 13)
+		x.xxx_hidden_ValueChanged = *b.ValueChanged
+	}
+	if b.ExpiryChanged != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 12, 13)
+		x.xxx_hidden_ExpiryChanged = *b.ExpiryChanged
+	}
+	return m0
+}
+
+type GetRedirectChainRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_FlowId      *string                `protobuf:"bytes,1,opt,name=flow_id,json=flowId"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GetRedirectChainRequest) Reset() {
+	*x = GetRedirectChainRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRedirectChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRedirectChainRequest) ProtoMessage() {}
+
+func (x *GetRedirectChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetRedirectChainRequest) GetFlowId() string {
+	if x != nil {
+		if x.xxx_hidden_FlowId != nil {
+			return *x.xxx_hidden_FlowId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetRedirectChainRequest) SetFlowId(v string) {
+	x.xxx_hidden_FlowId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *GetRedirectChainRequest) HasFlowId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *GetRedirectChainRequest) ClearFlowId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_FlowId = nil
+}
+
+type GetRedirectChainRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	FlowId *string
+}
+
+func (b0 GetRedirectChainRequest_builder) Build() *GetRedirectChainRequest {
+	m0 := &GetRedirectChainRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.FlowId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_FlowId = b.FlowId
+	}
+	return m0
+}
+
+type GetRedirectChainResponse struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Hops *[]*RedirectHop        `protobuf:"bytes,1,rep,name=hops"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetRedirectChainResponse) Reset() {
+	*x = GetRedirectChainResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRedirectChainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRedirectChainResponse) ProtoMessage() {}
+
+func (x *GetRedirectChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetRedirectChainResponse) GetHops() []*RedirectHop {
+	if x != nil {
+		if x.xxx_hidden_Hops != nil {
+			return *x.xxx_hidden_Hops
+		}
+	}
+	return nil
+}
+
+func (x *GetRedirectChainResponse) SetHops(v []*RedirectHop) {
+	x.xxx_hidden_Hops = &v
+}
+
+type GetRedirectChainResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The chain the flow is part of, from the first request to the final
+	// response. Every hop but the last redirected to the next one. A flow
+	// that isn't part of a chain is its only hop.
+	Hops []*RedirectHop
+}
+
+func (b0 GetRedirectChainResponse_builder) Build() *GetRedirectChainResponse {
+	m0 := &GetRedirectChainResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Hops = &b.Hops
+	return m0
+}
+
+type RedirectHop struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_FlowId      *string                `protobuf:"bytes,1,opt,name=flow_id,json=flowId"`
+	xxx_hidden_Method      *string                `protobuf:"bytes,2,opt,name=method"`
+	xxx_hidden_Url         *string                `protobuf:"bytes,3,opt,name=url"`
+	xxx_hidden_StatusCode  int32                  `protobuf:"varint,4,opt,name=status_code,json=statusCode"`
+	xxx_hidden_Location    *string                `protobuf:"bytes,5,opt,name=location"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RedirectHop) Reset() {
+	*x = RedirectHop{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedirectHop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedirectHop) ProtoMessage() {}
+
+func (x *RedirectHop) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RedirectHop) GetFlowId() string {
+	if x != nil {
+		if x.xxx_hidden_FlowId != nil {
+			return *x.xxx_hidden_FlowId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RedirectHop) GetMethod() string {
+	if x != nil {
+		if x.xxx_hidden_Method != nil {
+			return *x.xxx_hidden_Method
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RedirectHop) GetUrl() string {
+	if x != nil {
+		if x.xxx_hidden_Url != nil {
+			return *x.xxx_hidden_Url
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RedirectHop) GetStatusCode() int32 {
+	if x != nil {
+		return x.xxx_hidden_StatusCode
+	}
+	return 0
+}
+
+func (x *RedirectHop) GetLocation() string {
+	if x != nil {
+		if x.xxx_hidden_Location != nil {
+			return *x.xxx_hidden_Location
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RedirectHop) SetFlowId(v string) {
+	x.xxx_hidden_FlowId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *RedirectHop) SetMethod(v string) {
+	x.xxx_hidden_Method = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+}
+
+func (x *RedirectHop) SetUrl(v string) {
+	x.xxx_hidden_Url = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
+}
+
+func (x *RedirectHop) SetStatusCode(v int32) {
+	x.xxx_hidden_StatusCode = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+}
+
+func (x *RedirectHop) SetLocation(v string) {
+	x.xxx_hidden_Location = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+}
+
+func (x *RedirectHop) HasFlowId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RedirectHop) HasMethod() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RedirectHop) HasUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *RedirectHop) HasStatusCode() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *RedirectHop) HasLocation() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *RedirectHop) ClearFlowId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_FlowId = nil
+}
+
+func (x *RedirectHop) ClearMethod() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Method = nil
+}
+
+func (x *RedirectHop) ClearUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Url = nil
+}
+
+func (x *RedirectHop) ClearStatusCode() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_StatusCode = 0
+}
+
+func (x *RedirectHop) ClearLocation() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Location = nil
+}
+
+type RedirectHop_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	FlowId *string
+	Method *string
+	Url    *string
+	// Unset while the flow has no response.
+	StatusCode *int32
+	// The Location header of redirects, resolved against the request URL.
+	Location *string
+}
+
+func (b0 RedirectHop_builder) Build() *RedirectHop {
+	m0 := &RedirectHop{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.FlowId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_FlowId = b.FlowId
+	}
+	if b.Method != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		x.xxx_hidden_Method = b.Method
+	}
+	if b.Url != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
+		x.xxx_hidden_Url = b.Url
+	}
+	if b.StatusCode != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
+		x.xxx_hidden_StatusCode = *b.StatusCode
+	}
+	if b.Location != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		x.xxx_hidden_Location = b.Location
+	}
+	return m0
+}
+
+type DiffWithPreviousRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_FlowId      *string                `protobuf:"bytes,1,opt,name=flow_id,json=flowId"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *DiffWithPreviousRequest) Reset() {
+	*x = DiffWithPreviousRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffWithPreviousRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffWithPreviousRequest) ProtoMessage() {}
+
+func (x *DiffWithPreviousRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DiffWithPreviousRequest) GetFlowId() string {
+	if x != nil {
+		if x.xxx_hidden_FlowId != nil {
+			return *x.xxx_hidden_FlowId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DiffWithPreviousRequest) SetFlowId(v string) {
+	x.xxx_hidden_FlowId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *DiffWithPreviousRequest) HasFlowId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *DiffWithPreviousRequest) ClearFlowId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_FlowId = nil
+}
+
+type DiffWithPreviousRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	FlowId *string
+}
+
+func (b0 DiffWithPreviousRequest_builder) Build() *DiffWithPreviousRequest {
+	m0 := &DiffWithPreviousRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.FlowId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_FlowId = b.FlowId
+	}
+	return m0
+}
+
+type DiffWithPreviousResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Previous *FlowSummary           `protobuf:"bytes,1,opt,name=previous"`
+	xxx_hidden_Request  *[]*FlowDifference     `protobuf:"bytes,2,rep,name=request"`
+	xxx_hidden_Response *[]*FlowDifference     `protobuf:"bytes,3,rep,name=response"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *DiffWithPreviousResponse) Reset() {
+	*x = DiffWithPreviousResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffWithPreviousResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffWithPreviousResponse) ProtoMessage() {}
+
+func (x *DiffWithPreviousResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DiffWithPreviousResponse) GetPrevious() *FlowSummary {
+	if x != nil {
+		return x.xxx_hidden_Previous
+	}
+	return nil
+}
+
+func (x *DiffWithPreviousResponse) GetRequest() []*FlowDifference {
+	if x != nil {
+		if x.xxx_hidden_Request != nil {
+			return *x.xxx_hidden_Request
+		}
+	}
+	return nil
+}
+
+func (x *DiffWithPreviousResponse) GetResponse() []*FlowDifference {
+	if x != nil {
+		if x.xxx_hidden_Response != nil {
+			return *x.xxx_hidden_Response
+		}
+	}
+	return nil
+}
+
+func (x *DiffWithPreviousResponse) SetPrevious(v *FlowSummary) {
+	x.xxx_hidden_Previous = v
+}
+
+func (x *DiffWithPreviousResponse) SetRequest(v []*FlowDifference) {
+	x.xxx_hidden_Request = &v
+}
+
+func (x *DiffWithPreviousResponse) SetResponse(v []*FlowDifference) {
+	x.xxx_hidden_Response = &v
+}
+
+func (x *DiffWithPreviousResponse) HasPrevious() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Previous != nil
+}
+
+func (x *DiffWithPreviousResponse) ClearPrevious() {
+	x.xxx_hidden_Previous = nil
+}
+
+type DiffWithPreviousResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The flow compared with. Unset when no earlier flow has the same method
+	// and path, and the differences are then empty.
+	Previous *FlowSummary
+	Request  []*FlowDifference
+	Response []*FlowDifference
+}
+
+func (b0 DiffWithPreviousResponse_builder) Build() *DiffWithPreviousResponse {
+	m0 := &DiffWithPreviousResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Previous = b.Previous
+	x.xxx_hidden_Request = &b.Request
+	x.xxx_hidden_Response = &b.Response
+	return m0
+}
+
+type TopFlowsRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Filter      *FlowFilter            `protobuf:"bytes,1,opt,name=filter"`
+	xxx_hidden_FilterExpr  *string                `protobuf:"bytes,2,opt,name=filter_expr,json=filterExpr"`
+	xxx_hidden_Order       TopFlowsOrder          `protobuf:"varint,3,opt,name=order,enum=mitmflow.v1.TopFlowsOrder"`
+	xxx_hidden_Limit       int32                  `protobuf:"varint,4,opt,name=limit"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *TopFlowsRequest) Reset() {
+	*x = TopFlowsRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopFlowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopFlowsRequest) ProtoMessage() {}
+
+func (x *TopFlowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TopFlowsRequest) GetFilter() *FlowFilter {
+	if x != nil {
+		return x.xxx_hidden_Filter
+	}
+	return nil
+}
+
+func (x *TopFlowsRequest) GetFilterExpr() string {
+	if x != nil {
+		if x.xxx_hidden_FilterExpr != nil {
+			return *x.xxx_hidden_FilterExpr
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TopFlowsRequest) GetOrder() TopFlowsOrder {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 2) {
+			return x.xxx_hidden_Order
+		}
+	}
+	return TopFlowsOrder_TOP_FLOWS_ORDER_UNSPECIFIED
+}
+
+func (x *TopFlowsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.xxx_hidden_Limit
+	}
+	return 0
+}
+
+func (x *TopFlowsRequest) SetFilter(v *FlowFilter) {
+	x.xxx_hidden_Filter = v
+}
+
+func (x *TopFlowsRequest) SetFilterExpr(v string) {
+	x.xxx_hidden_FilterExpr = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *TopFlowsRequest) SetOrder(v TopFlowsOrder) {
+	x.xxx_hidden_Order = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *TopFlowsRequest) SetLimit(v int32) {
+	x.xxx_hidden_Limit = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *TopFlowsRequest) HasFilter() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Filter != nil
+}
+
+func (x *TopFlowsRequest) HasFilterExpr() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *TopFlowsRequest) HasOrder() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *TopFlowsRequest) HasLimit() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *TopFlowsRequest) ClearFilter() {
+	x.xxx_hidden_Filter = nil
+}
+
+func (x *TopFlowsRequest) ClearFilterExpr() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_FilterExpr = nil
+}
+
+func (x *TopFlowsRequest) ClearOrder() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Order = TopFlowsOrder_TOP_FLOWS_ORDER_UNSPECIFIED
+}
+
+func (x *TopFlowsRequest) ClearLimit() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Limit = 0
+}
+
+type TopFlowsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Filter *FlowFilter
+	// A filter expression such as "~d example.com & ~c 500".
+	FilterExpr *string
+	Order      *TopFlowsOrder
+	// How many flows to return. Defaults to 10.
+	Limit *int32
+}
+
+func (b0 TopFlowsRequest_builder) Build() *TopFlowsRequest {
+	m0 := &TopFlowsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Filter = b.Filter
+	if b.FilterExpr != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_FilterExpr = b.FilterExpr
+	}
+	if b.Order != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Order = *b.Order
+	}
+	if b.Limit != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Limit = *b.Limit
+	}
+	return m0
+}
+
+type TopFlowsResponse struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Flows *[]*FlowSummary        `protobuf:"bytes,1,rep,name=flows"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TopFlowsResponse) Reset() {
+	*x = TopFlowsResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopFlowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopFlowsResponse) ProtoMessage() {}
+
+func (x *TopFlowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TopFlowsResponse) GetFlows() []*FlowSummary {
+	if x != nil {
+		if x.xxx_hidden_Flows != nil {
+			return *x.xxx_hidden_Flows
+		}
+	}
+	return nil
+}
+
+func (x *TopFlowsResponse) SetFlows(v []*FlowSummary) {
+	x.xxx_hidden_Flows = &v
+}
+
+type TopFlowsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Worst first. Flows that tie keep the newest first.
+	Flows []*FlowSummary
+}
+
+func (b0 TopFlowsResponse_builder) Build() *TopFlowsResponse {
+	m0 := &TopFlowsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Flows = &b.Flows
+	return m0
+}
+
+type GetBandwidthStatsRequest struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Filter        *FlowFilter            `protobuf:"bytes,1,opt,name=filter"`
+	xxx_hidden_FilterExpr    *string                `protobuf:"bytes,2,opt,name=filter_expr,json=filterExpr"`
+	xxx_hidden_StartTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime"`
+	xxx_hidden_EndTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime"`
+	xxx_hidden_BucketSeconds int32                  `protobuf:"varint,5,opt,name=bucket_seconds,json=bucketSeconds"`
+	xxx_hidden_Limit         int32                  `protobuf:"varint,6,opt,name=limit"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *GetBandwidthStatsRequest) Reset() {
+	*x = GetBandwidthStatsRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBandwidthStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBandwidthStatsRequest) ProtoMessage() {}
+
+func (x *GetBandwidthStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetBandwidthStatsRequest) GetFilter() *FlowFilter {
+	if x != nil {
+		return x.xxx_hidden_Filter
+	}
+	return nil
+}
+
+func (x *GetBandwidthStatsRequest) GetFilterExpr() string {
+	if x != nil {
+		if x.xxx_hidden_FilterExpr != nil {
+			return *x.xxx_hidden_FilterExpr
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetBandwidthStatsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_StartTime
+	}
+	return nil
+}
+
+func (x *GetBandwidthStatsRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_EndTime
+	}
+	return nil
+}
+
+func (x *GetBandwidthStatsRequest) GetBucketSeconds() int32 {
+	if x != nil {
+		return x.xxx_hidden_BucketSeconds
+	}
+	return 0
+}
+
+func (x *GetBandwidthStatsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.xxx_hidden_Limit
+	}
+	return 0
+}
+
+func (x *GetBandwidthStatsRequest) SetFilter(v *FlowFilter) {
+	x.xxx_hidden_Filter = v
+}
+
+func (x *GetBandwidthStatsRequest) SetFilterExpr(v string) {
+	x.xxx_hidden_FilterExpr = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+}
+
+func (x *GetBandwidthStatsRequest) SetStartTime(v *timestamppb.Timestamp) {
+	x.xxx_hidden_StartTime = v
+}
+
+func (x *GetBandwidthStatsRequest) SetEndTime(v *timestamppb.Timestamp) {
+	x.xxx_hidden_EndTime = v
+}
+
+func (x *GetBandwidthStatsRequest) SetBucketSeconds(v int32) {
+	x.xxx_hidden_BucketSeconds = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *GetBandwidthStatsRequest) SetLimit(v int32) {
+	x.xxx_hidden_Limit = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
+func (x *GetBandwidthStatsRequest) HasFilter() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Filter != nil
+}
+
+func (x *GetBandwidthStatsRequest) HasFilterExpr() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *GetBandwidthStatsRequest) HasStartTime() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_StartTime != nil
+}
+
+func (x *GetBandwidthStatsRequest) HasEndTime() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_EndTime != nil
+}
+
+func (x *GetBandwidthStatsRequest) HasBucketSeconds() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *GetBandwidthStatsRequest) HasLimit() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *GetBandwidthStatsRequest) ClearFilter() {
+	x.xxx_hidden_Filter = nil
+}
+
+func (x *GetBandwidthStatsRequest) ClearFilterExpr() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_FilterExpr = nil
+}
+
+func (x *GetBandwidthStatsRequest) ClearStartTime() {
+	x.xxx_hidden_StartTime = nil
+}
+
+func (x *GetBandwidthStatsRequest) ClearEndTime() {
+	x.xxx_hidden_EndTime = nil
+}
+
+func (x *GetBandwidthStatsRequest) ClearBucketSeconds() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_BucketSeconds = 0
+}
+
+func (x *GetBandwidthStatsRequest) ClearLimit() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Limit = 0
+}
+
+type GetBandwidthStatsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Filter *FlowFilter
+	// A filter expression such as "~d example.com & ~c 500".
+	FilterExpr *string
+	// Only flows that started in this range.
+	StartTime *timestamppb.Timestamp
+	EndTime   *timestamppb.Timestamp
+	// The width of the time buckets. Defaults to 60 seconds.
+	BucketSeconds *int32
+	// How many hosts and clients to return, the busiest first. Defaults to 50.
+	Limit *int32
+}
+
+func (b0 GetBandwidthStatsRequest_builder) Build() *GetBandwidthStatsRequest {
+	m0 := &GetBandwidthStatsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Filter = b.Filter
+	if b.FilterExpr != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		x.xxx_hidden_FilterExpr = b.FilterExpr
+	}
+	x.xxx_hidden_StartTime = b.StartTime
+	x.xxx_hidden_EndTime = b.EndTime
+	if b.BucketSeconds != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_BucketSeconds = *b.BucketSeconds
+	}
+	if b.Limit != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_Limit = *b.Limit
+	}
+	return m0
+}
+
+type GetBandwidthStatsResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Hosts   *[]*BandwidthUsage     `protobuf:"bytes,1,rep,name=hosts"`
+	xxx_hidden_Clients *[]*BandwidthUsage     `protobuf:"bytes,2,rep,name=clients"`
+	xxx_hidden_Total   *BandwidthUsage        `protobuf:"bytes,3,opt,name=total"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetBandwidthStatsResponse) Reset() {
+	*x = GetBandwidthStatsResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBandwidthStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBandwidthStatsResponse) ProtoMessage() {}
+
+func (x *GetBandwidthStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetBandwidthStatsResponse) GetHosts() []*BandwidthUsage {
+	if x != nil {
+		if x.xxx_hidden_Hosts != nil {
+			return *x.xxx_hidden_Hosts
+		}
+	}
+	return nil
+}
+
+func (x *GetBandwidthStatsResponse) GetClients() []*BandwidthUsage {
+	if x != nil {
+		if x.xxx_hidden_Clients != nil {
+			return *x.xxx_hidden_Clients
+		}
+	}
+	return nil
+}
+
+func (x *GetBandwidthStatsResponse) GetTotal() *BandwidthUsage {
+	if x != nil {
+		return x.xxx_hidden_Total
+	}
+	return nil
+}
+
+func (x *GetBandwidthStatsResponse) SetHosts(v []*BandwidthUsage) {
+	x.xxx_hidden_Hosts = &v
+}
+
+func (x *GetBandwidthStatsResponse) SetClients(v []*BandwidthUsage) {
+	x.xxx_hidden_Clients = &v
+}
+
+func (x *GetBandwidthStatsResponse) SetTotal(v *BandwidthUsage) {
+	x.xxx_hidden_Total = v
+}
+
+func (x *GetBandwidthStatsResponse) HasTotal() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Total != nil
+}
+
+func (x *GetBandwidthStatsResponse) ClearTotal() {
+	x.xxx_hidden_Total = nil
+}
+
+type GetBandwidthStatsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Per server host: the request's host for HTTP, otherwise the server's
+	// hostname when known, or its address.
+	Hosts []*BandwidthUsage
+	// Per client IP.
+	Clients []*BandwidthUsage
+	// All matching flows together, with an empty key.
+	Total *BandwidthUsage
+}
+
+func (b0 GetBandwidthStatsResponse_builder) Build() *GetBandwidthStatsResponse {
+	m0 := &GetBandwidthStatsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Hosts = &b.Hosts
+	x.xxx_hidden_Clients = &b.Clients
+	x.xxx_hidden_Total = b.Total
+	return m0
+}
+
+// BandwidthUsage counts bytes as FlowTotals does: sent by the client and
+// received from the server. Flows count towards the bucket they started in.
+type BandwidthUsage struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Key           *string                `protobuf:"bytes,1,opt,name=key"`
+	xxx_hidden_BytesSent     int64                  `protobuf:"varint,2,opt,name=bytes_sent,json=bytesSent"`
+	xxx_hidden_BytesReceived int64                  `protobuf:"varint,3,opt,name=bytes_received,json=bytesReceived"`
+	xxx_hidden_Flows         int64                  `protobuf:"varint,4,opt,name=flows"`
+	xxx_hidden_Buckets       *[]*BandwidthBucket    `protobuf:"bytes,5,rep,name=buckets"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *BandwidthUsage) Reset() {
+	*x = BandwidthUsage{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BandwidthUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BandwidthUsage) ProtoMessage() {}
+
+func (x *BandwidthUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BandwidthUsage) GetKey() string {
+	if x != nil {
+		if x.xxx_hidden_Key != nil {
+			return *x.xxx_hidden_Key
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BandwidthUsage) GetBytesSent() int64 {
+	if x != nil {
+		return x.xxx_hidden_BytesSent
+	}
+	return 0
+}
+
+func (x *BandwidthUsage) GetBytesReceived() int64 {
+	if x != nil {
+		return x.xxx_hidden_BytesReceived
+	}
+	return 0
+}
+
+func (x *BandwidthUsage) GetFlows() int64 {
+	if x != nil {
+		return x.xxx_hidden_Flows
+	}
+	return 0
+}
+
+func (x *BandwidthUsage) GetBuckets() []*BandwidthBucket {
+	if x != nil {
+		if x.xxx_hidden_Buckets != nil {
+			return *x.xxx_hidden_Buckets
+		}
+	}
+	return nil
+}
+
+func (x *BandwidthUsage) SetKey(v string) {
+	x.xxx_hidden_Key = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *BandwidthUsage) SetBytesSent(v int64) {
+	x.xxx_hidden_BytesSent = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+}
+
+func (x *BandwidthUsage) SetBytesReceived(v int64) {
+	x.xxx_hidden_BytesReceived = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
+}
+
+func (x *BandwidthUsage) SetFlows(v int64) {
+	x.xxx_hidden_Flows = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+}
+
+func (x *BandwidthUsage) SetBuckets(v []*BandwidthBucket) {
+	x.xxx_hidden_Buckets = &v
+}
+
+func (x *BandwidthUsage) HasKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *BandwidthUsage) HasBytesSent() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *BandwidthUsage) HasBytesReceived() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *BandwidthUsage) HasFlows() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *BandwidthUsage) ClearKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Key = nil
+}
+
+func (x *BandwidthUsage) ClearBytesSent() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_BytesSent = 0
+}
+
+func (x *BandwidthUsage) ClearBytesReceived() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_BytesReceived = 0
+}
+
+func (x *BandwidthUsage) ClearFlows() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Flows = 0
+}
+
+type BandwidthUsage_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Key           *string
+	BytesSent     *int64
+	BytesReceived *int64
+	Flows         *int64
+	// Oldest first, leaving out buckets without flows.
+	Buckets []*BandwidthBucket
+}
+
+func (b0 BandwidthUsage_builder) Build() *BandwidthUsage {
+	m0 := &BandwidthUsage{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Key != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_Key = b.Key
+	}
+	if b.BytesSent != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		x.xxx_hidden_BytesSent = *b.BytesSent
+	}
+	if b.BytesReceived != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
+		x.xxx_hidden_BytesReceived = *b.BytesReceived
+	}
+	if b.Flows != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
+		x.xxx_hidden_Flows = *b.Flows
+	}
+	x.xxx_hidden_Buckets = &b.Buckets
+	return m0
+}
+
+type BandwidthBucket struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start"`
+	xxx_hidden_BytesSent     int64                  `protobuf:"varint,2,opt,name=bytes_sent,json=bytesSent"`
+	xxx_hidden_BytesReceived int64                  `protobuf:"varint,3,opt,name=bytes_received,json=bytesReceived"`
+	xxx_hidden_Flows         int64                  `protobuf:"varint,4,opt,name=flows"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *BandwidthBucket) Reset() {
+	*x = BandwidthBucket{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BandwidthBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BandwidthBucket) ProtoMessage() {}
+
+func (x *BandwidthBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BandwidthBucket) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Start
+	}
+	return nil
+}
+
+func (x *BandwidthBucket) GetBytesSent() int64 {
+	if x != nil {
+		return x.xxx_hidden_BytesSent
+	}
+	return 0
+}
+
+func (x *BandwidthBucket) GetBytesReceived() int64 {
+	if x != nil {
+		return x.xxx_hidden_BytesReceived
+	}
+	return 0
+}
+
+func (x *BandwidthBucket) GetFlows() int64 {
+	if x != nil {
+		return x.xxx_hidden_Flows
+	}
+	return 0
+}
+
+func (x *BandwidthBucket) SetStart(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Start = v
+}
+
+func (x *BandwidthBucket) SetBytesSent(v int64) {
+	x.xxx_hidden_BytesSent = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *BandwidthBucket) SetBytesReceived(v int64) {
+	x.xxx_hidden_BytesReceived = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *BandwidthBucket) SetFlows(v int64) {
+	x.xxx_hidden_Flows = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *BandwidthBucket) HasStart() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Start != nil
+}
+
+func (x *BandwidthBucket) HasBytesSent() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *BandwidthBucket) HasBytesReceived() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *BandwidthBucket) HasFlows() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *BandwidthBucket) ClearStart() {
+	x.xxx_hidden_Start = nil
+}
+
+func (x *BandwidthBucket) ClearBytesSent() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_BytesSent = 0
+}
+
+func (x *BandwidthBucket) ClearBytesReceived() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_BytesReceived = 0
+}
+
+func (x *BandwidthBucket) ClearFlows() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Flows = 0
+}
+
+type BandwidthBucket_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Start         *timestamppb.Timestamp
+	BytesSent     *int64
+	BytesReceived *int64
+	Flows         *int64
+}
+
+func (b0 BandwidthBucket_builder) Build() *BandwidthBucket {
+	m0 := &BandwidthBucket{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Start = b.Start
+	if b.BytesSent != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_BytesSent = *b.BytesSent
+	}
+	if b.BytesReceived != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_BytesReceived = *b.BytesReceived
+	}
+	if b.Flows != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Flows = *b.Flows
+	}
+	return m0
+}
+
+// FlowDifference is a status code, query parameter, header or body that
+// differs between two flows. JSON bodies are compared field by field, up to
+// 1000 differences.
+type FlowDifference struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Kind        FlowDifferenceKind     `protobuf:"varint,1,opt,name=kind,enum=mitmflow.v1.FlowDifferenceKind"`
+	xxx_hidden_Field       *string                `protobuf:"bytes,2,opt,name=field"`
+	xxx_hidden_Previous    *string                `protobuf:"bytes,3,opt,name=previous"`
+	xxx_hidden_Current     *string                `protobuf:"bytes,4,opt,name=current"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *FlowDifference) Reset() {
+	*x = FlowDifference{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlowDifference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlowDifference) ProtoMessage() {}
+
+func (x *FlowDifference) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *FlowDifference) GetKind() FlowDifferenceKind {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Kind
+		}
+	}
+	return FlowDifferenceKind_FLOW_DIFFERENCE_KIND_UNSPECIFIED
+}
+
+func (x *FlowDifference) GetField() string {
+	if x != nil {
+		if x.xxx_hidden_Field != nil {
+			return *x.xxx_hidden_Field
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *FlowDifference) GetPrevious() string {
+	if x != nil {
+		if x.xxx_hidden_Previous != nil {
+			return *x.xxx_hidden_Previous
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *FlowDifference) GetCurrent() string {
+	if x != nil {
+		if x.xxx_hidden_Current != nil {
+			return *x.xxx_hidden_Current
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *FlowDifference) SetKind(v FlowDifferenceKind) {
+	x.xxx_hidden_Kind = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *FlowDifference) SetField(v string) {
+	x.xxx_hidden_Field = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *FlowDifference) SetPrevious(v string) {
+	x.xxx_hidden_Previous = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *FlowDifference) SetCurrent(v string) {
+	x.xxx_hidden_Current = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *FlowDifference) HasKind() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *FlowDifference) HasField() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *FlowDifference) HasPrevious() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *FlowDifference) HasCurrent() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *FlowDifference) ClearKind() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Kind = FlowDifferenceKind_FLOW_DIFFERENCE_KIND_UNSPECIFIED
+}
+
+func (x *FlowDifference) ClearField() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Field = nil
+}
+
+func (x *FlowDifference) ClearPrevious() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Previous = nil
+}
+
+func (x *FlowDifference) ClearCurrent() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Current = nil
+}
+
+type FlowDifference_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Kind *FlowDifferenceKind
+	// The query parameter or header name, or the path of a JSON body field such
+	// as "$.items[0].id". Empty for the status code and other bodies.
+	Field *string
+	// The values in the previous and the selected flow. Empty when missing.
+	// Bodies that aren't JSON are described by their size and SHA-256.
+	Previous *string
+	Current  *string
+}
+
+func (b0 FlowDifference_builder) Build() *FlowDifference {
+	m0 := &FlowDifference{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Kind != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Kind = *b.Kind
+	}
+	if b.Field != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Field = b.Field
+	}
+	if b.Previous != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Previous = b.Previous
+	}
+	if b.Current != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Current = b.Current
+	}
+	return m0
+}
+
+// FlowSet is the bundle format used to move flows between instances.
+type FlowSet struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Flows *[]*Flow               `protobuf:"bytes,1,rep,name=flows"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *FlowSet) Reset() {
+	*x = FlowSet{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlowSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlowSet) ProtoMessage() {}
+
+func (x *FlowSet) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *FlowSet) GetFlows() []*Flow {
+	if x != nil {
+		if x.xxx_hidden_Flows != nil {
+			return *x.xxx_hidden_Flows
+		}
+	}
+	return nil
+}
+
+func (x *FlowSet) SetFlows(v []*Flow) {
+	x.xxx_hidden_Flows = &v
+}
+
+type FlowSet_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Flows []*Flow
+}
+
+func (b0 FlowSet_builder) Build() *FlowSet {
+	m0 := &FlowSet{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Flows = &b.Flows
+	return m0
+}
+
+type FlowSummary struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id             *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Type           *string                `protobuf:"bytes,2,opt,name=type"`
+	xxx_hidden_TimestampStart *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp_start,json=timestampStart"`
+	xxx_hidden_Pinned         bool                   `protobuf:"varint,4,opt,name=pinned"`
+	xxx_hidden_Note           *string                `protobuf:"bytes,5,opt,name=note"`
+	xxx_hidden_Summary        isFlowSummary_Summary  `protobuf_oneof:"summary"`
+	xxx_hidden_Sequence       uint64                 `protobuf:"varint,10,opt,name=sequence"`
+	xxx_hidden_Owner          *string                `protobuf:"bytes,11,opt,name=owner"`
+	xxx_hidden_Private        bool                   `protobuf:"varint,12,opt,name=private"`
+	xxx_hidden_Protocol       *string                `protobuf:"bytes,13,opt,name=protocol"`
+	xxx_hidden_Totals         *FlowTotals            `protobuf:"bytes,14,opt,name=totals"`
+	XXX_raceDetectHookData    protoimpl.RaceDetectHookData
+	XXX_presence              [1]uint32
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *FlowSummary) Reset() {
+	*x = FlowSummary{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlowSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlowSummary) ProtoMessage() {}
+
+func (x *FlowSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *FlowSummary) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *FlowSummary) GetType() string {
+	if x != nil {
+		if x.xxx_hidden_Type != nil {
+			return *x.xxx_hidden_Type
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *FlowSummary) GetTimestampStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_TimestampStart
+	}
+	return nil
+}
+
+func (x *FlowSummary) GetPinned() bool {
+	if x != nil {
+		return x.xxx_hidden_Pinned
+	}
+	return false
+}
+
+func (x *FlowSummary) GetNote() string {
+	if x != nil {
+		if x.xxx_hidden_Note != nil {
+			return *x.xxx_hidden_Note
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *FlowSummary) GetHttp() *HttpFlowSummary {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Summary.(*flowSummary_Http); ok {
+			return x.Http
+		}
+	}
+	return nil
+}
+
+func (x *FlowSummary) GetDns() *DnsFlowSummary {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Summary.(*flowSummary_Dns); ok {
+			return x.Dns
+		}
+	}
+	return nil
+}
+
+func (x *FlowSummary) GetTcp() *TcpFlowSummary {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Summary.(*flowSummary_Tcp); ok {
+			return x.Tcp
+		}
+	}
+	return nil
+}
+
+func (x *FlowSummary) GetUdp() *UdpFlowSummary {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Summary.(*flowSummary_Udp); ok {
+			return x.Udp
+		}
+	}
+	return nil
+}
+
+func (x *FlowSummary) GetSequence() uint64 {
+	if x != nil {
+		return x.xxx_hidden_Sequence
+	}
+	return 0
+}
+
+func (x *FlowSummary) GetOwner() string {
+	if x != nil {
+		if x.xxx_hidden_Owner != nil {
+			return *x.xxx_hidden_Owner
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *FlowSummary) GetPrivate() bool {
+	if x != nil {
+		return x.xxx_hidden_Private
+	}
+	return false
+}
+
+func (x *FlowSummary) GetProtocol() string {
+	if x != nil {
+		if x.xxx_hidden_Protocol != nil {
+			return *x.xxx_hidden_Protocol
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *FlowSummary) GetTotals() *FlowTotals {
+	if x != nil {
+		return x.xxx_hidden_Totals
+	}
+	return nil
+}
+
+func (x *FlowSummary) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 11)
+}
+
+func (x *FlowSummary) SetType(v string) {
+	x.xxx_hidden_Type = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 11)
+}
+
+func (x *FlowSummary) SetTimestampStart(v *timestamppb.Timestamp) {
+	x.xxx_hidden_TimestampStart = v
+}
+
+func (x *FlowSummary) SetPinned(v bool) {
+	x.xxx_hidden_Pinned = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 11)
+}
+
+func (x *FlowSummary) SetNote(v string) {
+	x.xxx_hidden_Note = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 11)
+}
+
+func (x *FlowSummary) SetHttp(v *HttpFlowSummary) {
+	if v == nil {
+		x.xxx_hidden_Summary = nil
+		return
+	}
+	x.xxx_hidden_Summary = &flowSummary_Http{v}
+}
+
+func (x *FlowSummary) SetDns(v *DnsFlowSummary) {
+	if v == nil {
+		x.xxx_hidden_Summary = nil
+		return
+	}
+	x.xxx_hidden_Summary = &flowSummary_Dns{v}
+}
+
+func (x *FlowSummary) SetTcp(v *TcpFlowSummary) {
+	if v == nil {
+		x.xxx_hidden_Summary = nil
+		return
+	}
+	x.xxx_hidden_Summary = &flowSummary_Tcp{v}
+}
+
+func (x *FlowSummary) SetUdp(v *UdpFlowSummary) {
+	if v == nil {
+		x.xxx_hidden_Summary = nil
+		return
+	}
+	x.xxx_hidden_Summary = &flowSummary_Udp{v}
+}
+
+func (x *FlowSummary) SetSequence(v uint64) {
+	x.xxx_hidden_Sequence = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 11)
+}
+
+func (x *FlowSummary) SetOwner(v string) {
+	x.xxx_hidden_Owner = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 11)
+}
+
+func (x *FlowSummary) SetPrivate(v bool) {
+	x.xxx_hidden_Private = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 11)
+}
+
+func (x *FlowSummary) SetProtocol(v string) {
+	x.xxx_hidden_Protocol = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 11)
+}
+
+func (x *FlowSummary) SetTotals(v *FlowTotals) {
+	x.xxx_hidden_Totals = v
+}
+
+func (x *FlowSummary) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *FlowSummary) HasType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *FlowSummary) HasTimestampStart() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_TimestampStart != nil
+}
+
+func (x *FlowSummary) HasPinned() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *FlowSummary) HasNote() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *FlowSummary) HasSummary() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Summary != nil
+}
+
+func (x *FlowSummary) HasHttp() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Summary.(*flowSummary_Http)
+	return ok
+}
+
+func (x *FlowSummary) HasDns() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Summary.(*flowSummary_Dns)
+	return ok
+}
+
+func (x *FlowSummary) HasTcp() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Summary.(*flowSummary_Tcp)
+	return ok
+}
+
+func (x *FlowSummary) HasUdp() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Summary.(*flowSummary_Udp)
+	return ok
+}
+
+func (x *FlowSummary) HasSequence() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *FlowSummary) HasOwner() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *FlowSummary) HasPrivate() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *FlowSummary) HasProtocol() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *FlowSummary) HasTotals() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Totals != nil
+}
+
+func (x *FlowSummary) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *FlowSummary) ClearType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Type = nil
+}
+
+func (x *FlowSummary) ClearTimestampStart() {
+	x.xxx_hidden_TimestampStart = nil
+}
+
+func (x *FlowSummary) ClearPinned() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Pinned = false
+}
+
+func (x *FlowSummary) ClearNote() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Note = nil
+}
+
+func (x *FlowSummary) ClearSummary() {
+	x.xxx_hidden_Summary = nil
+}
+
+func (x *FlowSummary) ClearHttp() {
+	if _, ok := x.xxx_hidden_Summary.(*flowSummary_Http); ok {
+		x.xxx_hidden_Summary = nil
+	}
+}
+
+func (x *FlowSummary) ClearDns() {
+	if _, ok := x.xxx_hidden_Summary.(*flowSummary_Dns); ok {
+		x.xxx_hidden_Summary = nil
+	}
+}
+
+func (x *FlowSummary) ClearTcp() {
+	if _, ok := x.xxx_hidden_Summary.(*flowSummary_Tcp); ok {
+		x.xxx_hidden_Summary = nil
+	}
+}
+
+func (x *FlowSummary) ClearUdp() {
+	if _, ok := x.xxx_hidden_Summary.(*flowSummary_Udp); ok {
+		x.xxx_hidden_Summary = nil
+	}
+}
+
+func (x *FlowSummary) ClearSequence() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Sequence = 0
+}
+
+func (x *FlowSummary) ClearOwner() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_Owner = nil
+}
+
+func (x *FlowSummary) ClearPrivate() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_Private = false
+}
+
+func (x *FlowSummary) ClearProtocol() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_Protocol = nil
+}
+
+func (x *FlowSummary) ClearTotals() {
+	x.xxx_hidden_Totals = nil
+}
+
+const FlowSummary_Summary_not_set_case case_FlowSummary_Summary = 0
+const FlowSummary_Http_case case_FlowSummary_Summary = 6
+const FlowSummary_Dns_case case_FlowSummary_Summary = 7
+const FlowSummary_Tcp_case case_FlowSummary_Summary = 8
+const FlowSummary_Udp_case case_FlowSummary_Summary = 9
+
+func (x *FlowSummary) WhichSummary() case_FlowSummary_Summary {
+	if x == nil {
+		return FlowSummary_Summary_not_set_case
+	}
+	switch x.xxx_hidden_Summary.(type) {
+	case *flowSummary_Http:
+		return FlowSummary_Http_case
+	case *flowSummary_Dns:
+		return FlowSummary_Dns_case
+	case *flowSummary_Tcp:
+		return FlowSummary_Tcp_case
+	case *flowSummary_Udp:
+		return FlowSummary_Udp_case
+	default:
+		return FlowSummary_Summary_not_set_case
+	}
+}
+
+type FlowSummary_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id             *string
+	Type           *string
 	TimestampStart *timestamppb.Timestamp
 	Pinned         *bool
 	Note           *string
@@ -1814,115 +10203,4595 @@ type FlowSummary_builder struct {
 	Tcp  *TcpFlowSummary
 	Udp  *UdpFlowSummary
 	// -- end of xxx_hidden_Summary
+	// The change sequence of the flow's last change.
+	Sequence *uint64
+	Owner    *string
+	Private  *bool
+	// Normalized protocol label, see HTTPFlowExtra.protocol.
+	Protocol *string
+	Totals   *FlowTotals
+}
+
+func (b0 FlowSummary_builder) Build() *FlowSummary {
+	m0 := &FlowSummary{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 11)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.Type != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 11)
+		x.xxx_hidden_Type = b.Type
+	}
+	x.xxx_hidden_TimestampStart = b.TimestampStart
+	if b.Pinned != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 11)
+		x.xxx_hidden_Pinned = *b.Pinned
+	}
+	if b.Note != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 11)
+		x.xxx_hidden_Note = b.Note
+	}
+	if b.Http != nil {
+		x.xxx_hidden_Summary = &flowSummary_Http{b.Http}
+	}
+	if b.Dns != nil {
+		x.xxx_hidden_Summary = &flowSummary_Dns{b.Dns}
+	}
+	if b.Tcp != nil {
+		x.xxx_hidden_Summary = &flowSummary_Tcp{b.Tcp}
+	}
+	if b.Udp != nil {
+		x.xxx_hidden_Summary = &flowSummary_Udp{b.Udp}
+	}
+	if b.Sequence != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 11)
+		x.xxx_hidden_Sequence = *b.Sequence
+	}
+	if b.Owner != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 11)
+		x.xxx_hidden_Owner = b.Owner
+	}
+	if b.Private != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 11)
+		x.xxx_hidden_Private = *b.Private
+	}
+	if b.Protocol != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 11)
+		x.xxx_hidden_Protocol = b.Protocol
+	}
+	x.xxx_hidden_Totals = b.Totals
+	return m0
+}
+
+type case_FlowSummary_Summary protoreflect.FieldNumber
+
+func (x case_FlowSummary_Summary) String() string {
+	md := file_mitmflow_v1_mitmflow_proto_msgTypes[69].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isFlowSummary_Summary interface {
+	isFlowSummary_Summary()
+}
+
+type flowSummary_Http struct {
+	Http *HttpFlowSummary `protobuf:"bytes,6,opt,name=http,oneof"`
+}
+
+type flowSummary_Dns struct {
+	Dns *DnsFlowSummary `protobuf:"bytes,7,opt,name=dns,oneof"`
+}
+
+type flowSummary_Tcp struct {
+	Tcp *TcpFlowSummary `protobuf:"bytes,8,opt,name=tcp,oneof"`
+}
+
+type flowSummary_Udp struct {
+	Udp *UdpFlowSummary `protobuf:"bytes,9,opt,name=udp,oneof"`
+}
+
+func (*flowSummary_Http) isFlowSummary_Summary() {}
+
+func (*flowSummary_Dns) isFlowSummary_Summary() {}
+
+func (*flowSummary_Tcp) isFlowSummary_Summary() {}
+
+func (*flowSummary_Udp) isFlowSummary_Summary() {}
+
+type HttpFlowSummary struct {
+	state                            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Method                *string                `protobuf:"bytes,1,opt,name=method"`
+	xxx_hidden_Url                   *string                `protobuf:"bytes,2,opt,name=url"`
+	xxx_hidden_StatusCode            int32                  `protobuf:"varint,3,opt,name=status_code,json=statusCode"`
+	xxx_hidden_DurationMs            int64                  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs"`
+	xxx_hidden_RequestContentLength  int64                  `protobuf:"varint,5,opt,name=request_content_length,json=requestContentLength"`
+	xxx_hidden_ResponseContentLength int64                  `protobuf:"varint,6,opt,name=response_content_length,json=responseContentLength"`
+	xxx_hidden_ClientPeernameHost    *string                `protobuf:"bytes,7,opt,name=client_peername_host,json=clientPeernameHost"`
+	xxx_hidden_ServerAddressHost     *string                `protobuf:"bytes,8,opt,name=server_address_host,json=serverAddressHost"`
+	xxx_hidden_ServerAddressPort     uint32                 `protobuf:"varint,9,opt,name=server_address_port,json=serverAddressPort"`
+	xxx_hidden_ClientPeernamePort    uint32                 `protobuf:"varint,10,opt,name=client_peername_port,json=clientPeernamePort"`
+	xxx_hidden_ResponseSha256        *string                `protobuf:"bytes,11,opt,name=response_sha256,json=responseSha256"`
+	XXX_raceDetectHookData           protoimpl.RaceDetectHookData
+	XXX_presence                     [1]uint32
+	unknownFields                    protoimpl.UnknownFields
+	sizeCache                        protoimpl.SizeCache
+}
+
+func (x *HttpFlowSummary) Reset() {
+	*x = HttpFlowSummary{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HttpFlowSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HttpFlowSummary) ProtoMessage() {}
+
+func (x *HttpFlowSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *HttpFlowSummary) GetMethod() string {
+	if x != nil {
+		if x.xxx_hidden_Method != nil {
+			return *x.xxx_hidden_Method
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *HttpFlowSummary) GetUrl() string {
+	if x != nil {
+		if x.xxx_hidden_Url != nil {
+			return *x.xxx_hidden_Url
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *HttpFlowSummary) GetStatusCode() int32 {
+	if x != nil {
+		return x.xxx_hidden_StatusCode
+	}
+	return 0
+}
+
+func (x *HttpFlowSummary) GetDurationMs() int64 {
+	if x != nil {
+		return x.xxx_hidden_DurationMs
+	}
+	return 0
+}
+
+func (x *HttpFlowSummary) GetRequestContentLength() int64 {
+	if x != nil {
+		return x.xxx_hidden_RequestContentLength
+	}
+	return 0
+}
+
+func (x *HttpFlowSummary) GetResponseContentLength() int64 {
+	if x != nil {
+		return x.xxx_hidden_ResponseContentLength
+	}
+	return 0
+}
+
+func (x *HttpFlowSummary) GetClientPeernameHost() string {
+	if x != nil {
+		if x.xxx_hidden_ClientPeernameHost != nil {
+			return *x.xxx_hidden_ClientPeernameHost
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *HttpFlowSummary) GetServerAddressHost() string {
+	if x != nil {
+		if x.xxx_hidden_ServerAddressHost != nil {
+			return *x.xxx_hidden_ServerAddressHost
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *HttpFlowSummary) GetServerAddressPort() uint32 {
+	if x != nil {
+		return x.xxx_hidden_ServerAddressPort
+	}
+	return 0
+}
+
+func (x *HttpFlowSummary) GetClientPeernamePort() uint32 {
+	if x != nil {
+		return x.xxx_hidden_ClientPeernamePort
+	}
+	return 0
+}
+
+func (x *HttpFlowSummary) GetResponseSha256() string {
+	if x != nil {
+		if x.xxx_hidden_ResponseSha256 != nil {
+			return *x.xxx_hidden_ResponseSha256
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *HttpFlowSummary) SetMethod(v string) {
+	x.xxx_hidden_Method = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 11)
+}
+
+func (x *HttpFlowSummary) SetUrl(v string) {
+	x.xxx_hidden_Url = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 11)
+}
+
+func (x *HttpFlowSummary) SetStatusCode(v int32) {
+	x.xxx_hidden_StatusCode = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 11)
+}
+
+func (x *HttpFlowSummary) SetDurationMs(v int64) {
+	x.xxx_hidden_DurationMs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 11)
+}
+
+func (x *HttpFlowSummary) SetRequestContentLength(v int64) {
+	x.xxx_hidden_RequestContentLength = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 11)
+}
+
+func (x *HttpFlowSummary) SetResponseContentLength(v int64) {
+	x.xxx_hidden_ResponseContentLength = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 11)
+}
+
+func (x *HttpFlowSummary) SetClientPeernameHost(v string) {
+	x.xxx_hidden_ClientPeernameHost = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 11)
+}
+
+func (x *HttpFlowSummary) SetServerAddressHost(v string) {
+	x.xxx_hidden_ServerAddressHost = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 11)
+}
+
+func (x *HttpFlowSummary) SetServerAddressPort(v uint32) {
+	x.xxx_hidden_ServerAddressPort = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 11)
+}
+
+func (x *HttpFlowSummary) SetClientPeernamePort(v uint32) {
+	x.xxx_hidden_ClientPeernamePort = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 11)
+}
+
+func (x *HttpFlowSummary) SetResponseSha256(v string) {
+	x.xxx_hidden_ResponseSha256 = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 10, 11)
+}
+
+func (x *HttpFlowSummary) HasMethod() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *HttpFlowSummary) HasUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *HttpFlowSummary) HasStatusCode() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *HttpFlowSummary) HasDurationMs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *HttpFlowSummary) HasRequestContentLength() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *HttpFlowSummary) HasResponseContentLength() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *HttpFlowSummary) HasClientPeernameHost() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *HttpFlowSummary) HasServerAddressHost() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *HttpFlowSummary) HasServerAddressPort() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *HttpFlowSummary) HasClientPeernamePort() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *HttpFlowSummary) HasResponseSha256() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 10)
+}
+
+func (x *HttpFlowSummary) ClearMethod() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Method = nil
+}
+
+func (x *HttpFlowSummary) ClearUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Url = nil
+}
+
+func (x *HttpFlowSummary) ClearStatusCode() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_StatusCode = 0
+}
+
+func (x *HttpFlowSummary) ClearDurationMs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_DurationMs = 0
+}
+
+func (x *HttpFlowSummary) ClearRequestContentLength() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_RequestContentLength = 0
+}
+
+func (x *HttpFlowSummary) ClearResponseContentLength() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_ResponseContentLength = 0
+}
+
+func (x *HttpFlowSummary) ClearClientPeernameHost() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_ClientPeernameHost = nil
+}
+
+func (x *HttpFlowSummary) ClearServerAddressHost() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_ServerAddressHost = nil
+}
+
+func (x *HttpFlowSummary) ClearServerAddressPort() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_ServerAddressPort = 0
+}
+
+func (x *HttpFlowSummary) ClearClientPeernamePort() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_ClientPeernamePort = 0
+}
+
+func (x *HttpFlowSummary) ClearResponseSha256() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 10)
+	x.xxx_hidden_ResponseSha256 = nil
+}
+
+type HttpFlowSummary_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Method                *string
+	Url                   *string
+	StatusCode            *int32
+	DurationMs            *int64
+	RequestContentLength  *int64
+	ResponseContentLength *int64
+	ClientPeernameHost    *string
+	ServerAddressHost     *string
+	ServerAddressPort     *uint32
+	ClientPeernamePort    *uint32
+	// The SHA-256 of the response body, in hex.
+	ResponseSha256 *string
+}
+
+func (b0 HttpFlowSummary_builder) Build() *HttpFlowSummary {
+	m0 := &HttpFlowSummary{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Method != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 11)
+		x.xxx_hidden_Method = b.Method
+	}
+	if b.Url != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 11)
+		x.xxx_hidden_Url = b.Url
+	}
+	if b.StatusCode != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 11)
+		x.xxx_hidden_StatusCode = *b.StatusCode
+	}
+	if b.DurationMs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 11)
+		x.xxx_hidden_DurationMs = *b.DurationMs
+	}
+	if b.RequestContentLength != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 11)
+		x.xxx_hidden_RequestContentLength = *b.RequestContentLength
+	}
+	if b.ResponseContentLength != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 11)
+		x.xxx_hidden_ResponseContentLength = *b.ResponseContentLength
+	}
+	if b.ClientPeernameHost != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 11)
+		x.xxx_hidden_ClientPeernameHost = b.ClientPeernameHost
+	}
+	if b.ServerAddressHost != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 11)
+		x.xxx_hidden_ServerAddressHost = b.ServerAddressHost
+	}
+	if b.ServerAddressPort != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 11)
+		x.xxx_hidden_ServerAddressPort = *b.ServerAddressPort
+	}
+	if b.ClientPeernamePort != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 11)
+		x.xxx_hidden_ClientPeernamePort = *b.ClientPeernamePort
+	}
+	if b.ResponseSha256 != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 10, 11)
+		x.xxx_hidden_ResponseSha256 = b.ResponseSha256
+	}
+	return m0
+}
+
+type DnsFlowSummary struct {
+	state                         protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_QuestionName       *string                `protobuf:"bytes,1,opt,name=question_name,json=questionName"`
+	xxx_hidden_ClientPeernameHost *string                `protobuf:"bytes,2,opt,name=client_peername_host,json=clientPeernameHost"`
+	xxx_hidden_Error              *string                `protobuf:"bytes,3,opt,name=error"`
+	XXX_raceDetectHookData        protoimpl.RaceDetectHookData
+	XXX_presence                  [1]uint32
+	unknownFields                 protoimpl.UnknownFields
+	sizeCache                     protoimpl.SizeCache
+}
+
+func (x *DnsFlowSummary) Reset() {
+	*x = DnsFlowSummary{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DnsFlowSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DnsFlowSummary) ProtoMessage() {}
+
+func (x *DnsFlowSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DnsFlowSummary) GetQuestionName() string {
+	if x != nil {
+		if x.xxx_hidden_QuestionName != nil {
+			return *x.xxx_hidden_QuestionName
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DnsFlowSummary) GetClientPeernameHost() string {
+	if x != nil {
+		if x.xxx_hidden_ClientPeernameHost != nil {
+			return *x.xxx_hidden_ClientPeernameHost
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DnsFlowSummary) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DnsFlowSummary) SetQuestionName(v string) {
+	x.xxx_hidden_QuestionName = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *DnsFlowSummary) SetClientPeernameHost(v string) {
+	x.xxx_hidden_ClientPeernameHost = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *DnsFlowSummary) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *DnsFlowSummary) HasQuestionName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *DnsFlowSummary) HasClientPeernameHost() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *DnsFlowSummary) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *DnsFlowSummary) ClearQuestionName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_QuestionName = nil
+}
+
+func (x *DnsFlowSummary) ClearClientPeernameHost() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_ClientPeernameHost = nil
+}
+
+func (x *DnsFlowSummary) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Error = nil
+}
+
+type DnsFlowSummary_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	QuestionName       *string
+	ClientPeernameHost *string
+	Error              *string
+}
+
+func (b0 DnsFlowSummary_builder) Build() *DnsFlowSummary {
+	m0 := &DnsFlowSummary{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.QuestionName != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_QuestionName = b.QuestionName
+	}
+	if b.ClientPeernameHost != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_ClientPeernameHost = b.ClientPeernameHost
+	}
+	if b.Error != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Error = b.Error
+	}
+	return m0
+}
+
+type TcpFlowSummary struct {
+	state                         protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ServerAddressHost  *string                `protobuf:"bytes,1,opt,name=server_address_host,json=serverAddressHost"`
+	xxx_hidden_ServerAddressPort  uint32                 `protobuf:"varint,2,opt,name=server_address_port,json=serverAddressPort"`
+	xxx_hidden_ClientPeernameHost *string                `protobuf:"bytes,3,opt,name=client_peername_host,json=clientPeernameHost"`
+	xxx_hidden_ClientPeernamePort uint32                 `protobuf:"varint,4,opt,name=client_peername_port,json=clientPeernamePort"`
+	xxx_hidden_Error              *string                `protobuf:"bytes,5,opt,name=error"`
+	XXX_raceDetectHookData        protoimpl.RaceDetectHookData
+	XXX_presence                  [1]uint32
+	unknownFields                 protoimpl.UnknownFields
+	sizeCache                     protoimpl.SizeCache
+}
+
+func (x *TcpFlowSummary) Reset() {
+	*x = TcpFlowSummary{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TcpFlowSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TcpFlowSummary) ProtoMessage() {}
+
+func (x *TcpFlowSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TcpFlowSummary) GetServerAddressHost() string {
+	if x != nil {
+		if x.xxx_hidden_ServerAddressHost != nil {
+			return *x.xxx_hidden_ServerAddressHost
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TcpFlowSummary) GetServerAddressPort() uint32 {
+	if x != nil {
+		return x.xxx_hidden_ServerAddressPort
+	}
+	return 0
+}
+
+func (x *TcpFlowSummary) GetClientPeernameHost() string {
+	if x != nil {
+		if x.xxx_hidden_ClientPeernameHost != nil {
+			return *x.xxx_hidden_ClientPeernameHost
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TcpFlowSummary) GetClientPeernamePort() uint32 {
+	if x != nil {
+		return x.xxx_hidden_ClientPeernamePort
+	}
+	return 0
+}
+
+func (x *TcpFlowSummary) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TcpFlowSummary) SetServerAddressHost(v string) {
+	x.xxx_hidden_ServerAddressHost = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *TcpFlowSummary) SetServerAddressPort(v uint32) {
+	x.xxx_hidden_ServerAddressPort = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+}
+
+func (x *TcpFlowSummary) SetClientPeernameHost(v string) {
+	x.xxx_hidden_ClientPeernameHost = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
+}
+
+func (x *TcpFlowSummary) SetClientPeernamePort(v uint32) {
+	x.xxx_hidden_ClientPeernamePort = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+}
+
+func (x *TcpFlowSummary) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+}
+
+func (x *TcpFlowSummary) HasServerAddressHost() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *TcpFlowSummary) HasServerAddressPort() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *TcpFlowSummary) HasClientPeernameHost() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *TcpFlowSummary) HasClientPeernamePort() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *TcpFlowSummary) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *TcpFlowSummary) ClearServerAddressHost() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_ServerAddressHost = nil
+}
+
+func (x *TcpFlowSummary) ClearServerAddressPort() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_ServerAddressPort = 0
+}
+
+func (x *TcpFlowSummary) ClearClientPeernameHost() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_ClientPeernameHost = nil
+}
+
+func (x *TcpFlowSummary) ClearClientPeernamePort() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_ClientPeernamePort = 0
+}
+
+func (x *TcpFlowSummary) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Error = nil
+}
+
+type TcpFlowSummary_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	ServerAddressHost  *string
+	ServerAddressPort  *uint32
+	ClientPeernameHost *string
+	ClientPeernamePort *uint32
+	Error              *string
+}
+
+func (b0 TcpFlowSummary_builder) Build() *TcpFlowSummary {
+	m0 := &TcpFlowSummary{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.ServerAddressHost != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_ServerAddressHost = b.ServerAddressHost
+	}
+	if b.ServerAddressPort != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		x.xxx_hidden_ServerAddressPort = *b.ServerAddressPort
+	}
+	if b.ClientPeernameHost != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
+		x.xxx_hidden_ClientPeernameHost = b.ClientPeernameHost
+	}
+	if b.ClientPeernamePort != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
+		x.xxx_hidden_ClientPeernamePort = *b.ClientPeernamePort
+	}
+	if b.Error != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		x.xxx_hidden_Error = b.Error
+	}
+	return m0
+}
+
+type UdpFlowSummary struct {
+	state                         protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ServerAddressHost  *string                `protobuf:"bytes,1,opt,name=server_address_host,json=serverAddressHost"`
+	xxx_hidden_ServerAddressPort  uint32                 `protobuf:"varint,2,opt,name=server_address_port,json=serverAddressPort"`
+	xxx_hidden_ClientPeernameHost *string                `protobuf:"bytes,3,opt,name=client_peername_host,json=clientPeernameHost"`
+	xxx_hidden_ClientPeernamePort uint32                 `protobuf:"varint,4,opt,name=client_peername_port,json=clientPeernamePort"`
+	xxx_hidden_Error              *string                `protobuf:"bytes,5,opt,name=error"`
+	XXX_raceDetectHookData        protoimpl.RaceDetectHookData
+	XXX_presence                  [1]uint32
+	unknownFields                 protoimpl.UnknownFields
+	sizeCache                     protoimpl.SizeCache
+}
+
+func (x *UdpFlowSummary) Reset() {
+	*x = UdpFlowSummary{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UdpFlowSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UdpFlowSummary) ProtoMessage() {}
+
+func (x *UdpFlowSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UdpFlowSummary) GetServerAddressHost() string {
+	if x != nil {
+		if x.xxx_hidden_ServerAddressHost != nil {
+			return *x.xxx_hidden_ServerAddressHost
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *UdpFlowSummary) GetServerAddressPort() uint32 {
+	if x != nil {
+		return x.xxx_hidden_ServerAddressPort
+	}
+	return 0
+}
+
+func (x *UdpFlowSummary) GetClientPeernameHost() string {
+	if x != nil {
+		if x.xxx_hidden_ClientPeernameHost != nil {
+			return *x.xxx_hidden_ClientPeernameHost
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *UdpFlowSummary) GetClientPeernamePort() uint32 {
+	if x != nil {
+		return x.xxx_hidden_ClientPeernamePort
+	}
+	return 0
+}
+
+func (x *UdpFlowSummary) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *UdpFlowSummary) SetServerAddressHost(v string) {
+	x.xxx_hidden_ServerAddressHost = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *UdpFlowSummary) SetServerAddressPort(v uint32) {
+	x.xxx_hidden_ServerAddressPort = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+}
+
+func (x *UdpFlowSummary) SetClientPeernameHost(v string) {
+	x.xxx_hidden_ClientPeernameHost = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
+}
+
+func (x *UdpFlowSummary) SetClientPeernamePort(v uint32) {
+	x.xxx_hidden_ClientPeernamePort = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+}
+
+func (x *UdpFlowSummary) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+}
+
+func (x *UdpFlowSummary) HasServerAddressHost() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *UdpFlowSummary) HasServerAddressPort() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *UdpFlowSummary) HasClientPeernameHost() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *UdpFlowSummary) HasClientPeernamePort() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *UdpFlowSummary) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *UdpFlowSummary) ClearServerAddressHost() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_ServerAddressHost = nil
+}
+
+func (x *UdpFlowSummary) ClearServerAddressPort() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_ServerAddressPort = 0
+}
+
+func (x *UdpFlowSummary) ClearClientPeernameHost() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_ClientPeernameHost = nil
+}
+
+func (x *UdpFlowSummary) ClearClientPeernamePort() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_ClientPeernamePort = 0
+}
+
+func (x *UdpFlowSummary) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Error = nil
+}
+
+type UdpFlowSummary_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	ServerAddressHost  *string
+	ServerAddressPort  *uint32
+	ClientPeernameHost *string
+	ClientPeernamePort *uint32
+	Error              *string
+}
+
+func (b0 UdpFlowSummary_builder) Build() *UdpFlowSummary {
+	m0 := &UdpFlowSummary{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.ServerAddressHost != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_ServerAddressHost = b.ServerAddressHost
+	}
+	if b.ServerAddressPort != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		x.xxx_hidden_ServerAddressPort = *b.ServerAddressPort
+	}
+	if b.ClientPeernameHost != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
+		x.xxx_hidden_ClientPeernameHost = b.ClientPeernameHost
+	}
+	if b.ClientPeernamePort != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
+		x.xxx_hidden_ClientPeernamePort = *b.ClientPeernamePort
+	}
+	if b.Error != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		x.xxx_hidden_Error = b.Error
+	}
+	return m0
+}
+
+type Flow struct {
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Flow            isFlow_Flow            `protobuf_oneof:"flow"`
+	xxx_hidden_HttpFlowExtra   *HTTPFlowExtra         `protobuf:"bytes,5,opt,name=http_flow_extra,json=httpFlowExtra"`
+	xxx_hidden_Pinned          bool                   `protobuf:"varint,6,opt,name=pinned"`
+	xxx_hidden_Note            *string                `protobuf:"bytes,7,opt,name=note"`
+	xxx_hidden_StreamFlowExtra *StreamFlowExtra       `protobuf:"bytes,8,opt,name=stream_flow_extra,json=streamFlowExtra"`
+	xxx_hidden_Metadata        map[string]string      `protobuf:"bytes,9,rep,name=metadata" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_UserAnnotations map[string]*Annotation `protobuf:"bytes,10,rep,name=user_annotations,json=userAnnotations" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_Owner           *string                `protobuf:"bytes,11,opt,name=owner"`
+	xxx_hidden_Private         bool                   `protobuf:"varint,12,opt,name=private"`
+	xxx_hidden_DnsFlowExtra    *DnsFlowExtra          `protobuf:"bytes,13,opt,name=dns_flow_extra,json=dnsFlowExtra"`
+	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
+	XXX_presence               [1]uint32
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *Flow) Reset() {
+	*x = Flow{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Flow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Flow) ProtoMessage() {}
+
+func (x *Flow) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Flow) GetHttpFlow() *v1.HTTPFlow {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Flow.(*flow_HttpFlow); ok {
+			return x.HttpFlow
+		}
+	}
+	return nil
+}
+
+func (x *Flow) GetTcpFlow() *v1.TCPFlow {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Flow.(*flow_TcpFlow); ok {
+			return x.TcpFlow
+		}
+	}
+	return nil
+}
+
+func (x *Flow) GetUdpFlow() *v1.UDPFlow {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Flow.(*flow_UdpFlow); ok {
+			return x.UdpFlow
+		}
+	}
+	return nil
+}
+
+func (x *Flow) GetDnsFlow() *v1.DNSFlow {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Flow.(*flow_DnsFlow); ok {
+			return x.DnsFlow
+		}
+	}
+	return nil
+}
+
+func (x *Flow) GetHttpFlowExtra() *HTTPFlowExtra {
+	if x != nil {
+		return x.xxx_hidden_HttpFlowExtra
+	}
+	return nil
+}
+
+func (x *Flow) GetPinned() bool {
+	if x != nil {
+		return x.xxx_hidden_Pinned
+	}
+	return false
+}
+
+func (x *Flow) GetNote() string {
+	if x != nil {
+		if x.xxx_hidden_Note != nil {
+			return *x.xxx_hidden_Note
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Flow) GetStreamFlowExtra() *StreamFlowExtra {
+	if x != nil {
+		return x.xxx_hidden_StreamFlowExtra
+	}
+	return nil
+}
+
+func (x *Flow) GetMetadata() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Metadata
+	}
+	return nil
+}
+
+func (x *Flow) GetUserAnnotations() map[string]*Annotation {
+	if x != nil {
+		return x.xxx_hidden_UserAnnotations
+	}
+	return nil
+}
+
+func (x *Flow) GetOwner() string {
+	if x != nil {
+		if x.xxx_hidden_Owner != nil {
+			return *x.xxx_hidden_Owner
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Flow) GetPrivate() bool {
+	if x != nil {
+		return x.xxx_hidden_Private
+	}
+	return false
+}
+
+func (x *Flow) GetDnsFlowExtra() *DnsFlowExtra {
+	if x != nil {
+		return x.xxx_hidden_DnsFlowExtra
+	}
+	return nil
+}
+
+func (x *Flow) SetHttpFlow(v *v1.HTTPFlow) {
+	if v == nil {
+		x.xxx_hidden_Flow = nil
+		return
+	}
+	x.xxx_hidden_Flow = &flow_HttpFlow{v}
+}
+
+func (x *Flow) SetTcpFlow(v *v1.TCPFlow) {
+	if v == nil {
+		x.xxx_hidden_Flow = nil
+		return
+	}
+	x.xxx_hidden_Flow = &flow_TcpFlow{v}
+}
+
+func (x *Flow) SetUdpFlow(v *v1.UDPFlow) {
+	if v == nil {
+		x.xxx_hidden_Flow = nil
+		return
+	}
+	x.xxx_hidden_Flow = &flow_UdpFlow{v}
+}
+
+func (x *Flow) SetDnsFlow(v *v1.DNSFlow) {
+	if v == nil {
+		x.xxx_hidden_Flow = nil
+		return
+	}
+	x.xxx_hidden_Flow = &flow_DnsFlow{v}
+}
+
+func (x *Flow) SetHttpFlowExtra(v *HTTPFlowExtra) {
+	x.xxx_hidden_HttpFlowExtra = v
+}
+
+func (x *Flow) SetPinned(v bool) {
+	x.xxx_hidden_Pinned = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 10)
+}
+
+func (x *Flow) SetNote(v string) {
+	x.xxx_hidden_Note = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 10)
+}
+
+func (x *Flow) SetStreamFlowExtra(v *StreamFlowExtra) {
+	x.xxx_hidden_StreamFlowExtra = v
+}
+
+func (x *Flow) SetMetadata(v map[string]string) {
+	x.xxx_hidden_Metadata = v
+}
+
+func (x *Flow) SetUserAnnotations(v map[string]*Annotation) {
+	x.xxx_hidden_UserAnnotations = v
+}
+
+func (x *Flow) SetOwner(v string) {
+	x.xxx_hidden_Owner = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 10)
+}
+
+func (x *Flow) SetPrivate(v bool) {
+	x.xxx_hidden_Private = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 10)
+}
+
+func (x *Flow) SetDnsFlowExtra(v *DnsFlowExtra) {
+	x.xxx_hidden_DnsFlowExtra = v
+}
+
+func (x *Flow) HasFlow() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Flow != nil
+}
+
+func (x *Flow) HasHttpFlow() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Flow.(*flow_HttpFlow)
+	return ok
+}
+
+func (x *Flow) HasTcpFlow() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Flow.(*flow_TcpFlow)
+	return ok
+}
+
+func (x *Flow) HasUdpFlow() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Flow.(*flow_UdpFlow)
+	return ok
+}
+
+func (x *Flow) HasDnsFlow() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Flow.(*flow_DnsFlow)
+	return ok
+}
+
+func (x *Flow) HasHttpFlowExtra() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_HttpFlowExtra != nil
+}
+
+func (x *Flow) HasPinned() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *Flow) HasNote() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *Flow) HasStreamFlowExtra() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_StreamFlowExtra != nil
+}
+
+func (x *Flow) HasOwner() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *Flow) HasPrivate() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *Flow) HasDnsFlowExtra() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_DnsFlowExtra != nil
+}
+
+func (x *Flow) ClearFlow() {
+	x.xxx_hidden_Flow = nil
+}
+
+func (x *Flow) ClearHttpFlow() {
+	if _, ok := x.xxx_hidden_Flow.(*flow_HttpFlow); ok {
+		x.xxx_hidden_Flow = nil
+	}
+}
+
+func (x *Flow) ClearTcpFlow() {
+	if _, ok := x.xxx_hidden_Flow.(*flow_TcpFlow); ok {
+		x.xxx_hidden_Flow = nil
+	}
+}
+
+func (x *Flow) ClearUdpFlow() {
+	if _, ok := x.xxx_hidden_Flow.(*flow_UdpFlow); ok {
+		x.xxx_hidden_Flow = nil
+	}
+}
+
+func (x *Flow) ClearDnsFlow() {
+	if _, ok := x.xxx_hidden_Flow.(*flow_DnsFlow); ok {
+		x.xxx_hidden_Flow = nil
+	}
+}
+
+func (x *Flow) ClearHttpFlowExtra() {
+	x.xxx_hidden_HttpFlowExtra = nil
+}
+
+func (x *Flow) ClearPinned() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Pinned = false
+}
+
+func (x *Flow) ClearNote() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Note = nil
+}
+
+func (x *Flow) ClearStreamFlowExtra() {
+	x.xxx_hidden_StreamFlowExtra = nil
+}
+
+func (x *Flow) ClearOwner() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_Owner = nil
+}
+
+func (x *Flow) ClearPrivate() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_Private = false
+}
+
+func (x *Flow) ClearDnsFlowExtra() {
+	x.xxx_hidden_DnsFlowExtra = nil
+}
+
+const Flow_Flow_not_set_case case_Flow_Flow = 0
+const Flow_HttpFlow_case case_Flow_Flow = 1
+const Flow_TcpFlow_case case_Flow_Flow = 2
+const Flow_UdpFlow_case case_Flow_Flow = 3
+const Flow_DnsFlow_case case_Flow_Flow = 4
+
+func (x *Flow) WhichFlow() case_Flow_Flow {
+	if x == nil {
+		return Flow_Flow_not_set_case
+	}
+	switch x.xxx_hidden_Flow.(type) {
+	case *flow_HttpFlow:
+		return Flow_HttpFlow_case
+	case *flow_TcpFlow:
+		return Flow_TcpFlow_case
+	case *flow_UdpFlow:
+		return Flow_UdpFlow_case
+	case *flow_DnsFlow:
+		return Flow_DnsFlow_case
+	default:
+		return Flow_Flow_not_set_case
+	}
+}
+
+type Flow_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Fields of oneof xxx_hidden_Flow:
+	HttpFlow *v1.HTTPFlow
+	TcpFlow  *v1.TCPFlow
+	UdpFlow  *v1.UDPFlow
+	DnsFlow  *v1.DNSFlow
+	// -- end of xxx_hidden_Flow
+	HttpFlowExtra   *HTTPFlowExtra
+	Pinned          *bool
+	Note            *string
+	StreamFlowExtra *StreamFlowExtra
+	// Key/value annotations set through UpdateFlow, e.g. by tooling that stamps
+	// flows with a build number or test case ID.
+	Metadata map[string]string
+	// Each user's own pin and note, keyed by user name, when the server requires
+	// authentication. pinned and note above are shared by the whole team. Only
+	// the caller's annotation is returned, merged into pinned and note.
+	UserAnnotations map[string]*Annotation
+	// The user or token that captured, imported or sent the flow, when the
+	// server requires authentication.
+	Owner *string
+	// Only the owner and admins can see a private flow.
+	Private      *bool
+	DnsFlowExtra *DnsFlowExtra
+}
+
+func (b0 Flow_builder) Build() *Flow {
+	m0 := &Flow{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.HttpFlow != nil {
+		x.xxx_hidden_Flow = &flow_HttpFlow{b.HttpFlow}
+	}
+	if b.TcpFlow != nil {
+		x.xxx_hidden_Flow = &flow_TcpFlow{b.TcpFlow}
+	}
+	if b.UdpFlow != nil {
+		x.xxx_hidden_Flow = &flow_UdpFlow{b.UdpFlow}
+	}
+	if b.DnsFlow != nil {
+		x.xxx_hidden_Flow = &flow_DnsFlow{b.DnsFlow}
+	}
+	x.xxx_hidden_HttpFlowExtra = b.HttpFlowExtra
+	if b.Pinned != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 10)
+		x.xxx_hidden_Pinned = *b.Pinned
+	}
+	if b.Note != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 10)
+		x.xxx_hidden_Note = b.Note
+	}
+	x.xxx_hidden_StreamFlowExtra = b.StreamFlowExtra
+	x.xxx_hidden_Metadata = b.Metadata
+	x.xxx_hidden_UserAnnotations = b.UserAnnotations
+	if b.Owner != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 10)
+		x.xxx_hidden_Owner = b.Owner
+	}
+	if b.Private != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 10)
+		x.xxx_hidden_Private = *b.Private
+	}
+	x.xxx_hidden_DnsFlowExtra = b.DnsFlowExtra
+	return m0
+}
+
+type case_Flow_Flow protoreflect.FieldNumber
+
+func (x case_Flow_Flow) String() string {
+	md := file_mitmflow_v1_mitmflow_proto_msgTypes[74].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isFlow_Flow interface {
+	isFlow_Flow()
+}
+
+type flow_HttpFlow struct {
+	HttpFlow *v1.HTTPFlow `protobuf:"bytes,1,opt,name=http_flow,json=httpFlow,oneof"`
+}
+
+type flow_TcpFlow struct {
+	TcpFlow *v1.TCPFlow `protobuf:"bytes,2,opt,name=tcp_flow,json=tcpFlow,oneof"`
+}
+
+type flow_UdpFlow struct {
+	UdpFlow *v1.UDPFlow `protobuf:"bytes,3,opt,name=udp_flow,json=udpFlow,oneof"`
+}
+
+type flow_DnsFlow struct {
+	DnsFlow *v1.DNSFlow `protobuf:"bytes,4,opt,name=dns_flow,json=dnsFlow,oneof"`
+}
+
+func (*flow_HttpFlow) isFlow_Flow() {}
+
+func (*flow_TcpFlow) isFlow_Flow() {}
+
+func (*flow_UdpFlow) isFlow_Flow() {}
+
+func (*flow_DnsFlow) isFlow_Flow() {}
+
+type Annotation struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Pinned      bool                   `protobuf:"varint,1,opt,name=pinned"`
+	xxx_hidden_Note        *string                `protobuf:"bytes,2,opt,name=note"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Annotation) Reset() {
+	*x = Annotation{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Annotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Annotation) ProtoMessage() {}
+
+func (x *Annotation) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Annotation) GetPinned() bool {
+	if x != nil {
+		return x.xxx_hidden_Pinned
+	}
+	return false
+}
+
+func (x *Annotation) GetNote() string {
+	if x != nil {
+		if x.xxx_hidden_Note != nil {
+			return *x.xxx_hidden_Note
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Annotation) SetPinned(v bool) {
+	x.xxx_hidden_Pinned = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *Annotation) SetNote(v string) {
+	x.xxx_hidden_Note = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *Annotation) HasPinned() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *Annotation) HasNote() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *Annotation) ClearPinned() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Pinned = false
+}
+
+func (x *Annotation) ClearNote() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Note = nil
+}
+
+type Annotation_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Pinned *bool
+	Note   *string
+}
+
+func (b0 Annotation_builder) Build() *Annotation {
+	m0 := &Annotation{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Pinned != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Pinned = *b.Pinned
+	}
+	if b.Note != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Note = b.Note
+	}
+	return m0
+}
+
+type HTTPFlowExtra struct {
+	state                           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Request              *MessageDetails        `protobuf:"bytes,1,opt,name=request"`
+	xxx_hidden_Response             *MessageDetails        `protobuf:"bytes,2,opt,name=response"`
+	xxx_hidden_UserAgent            *UserAgent             `protobuf:"bytes,3,opt,name=user_agent,json=userAgent"`
+	xxx_hidden_ServerGeo            *GeoInfo               `protobuf:"bytes,4,opt,name=server_geo,json=serverGeo"`
+	xxx_hidden_ServerHostname       *string                `protobuf:"bytes,5,opt,name=server_hostname,json=serverHostname"`
+	xxx_hidden_ServerHostnameSource HostnameSource         `protobuf:"varint,6,opt,name=server_hostname_source,json=serverHostnameSource,enum=mitmflow.v1.HostnameSource"`
+	xxx_hidden_SecurityFindings     *[]*SecurityFinding    `protobuf:"bytes,7,rep,name=security_findings,json=securityFindings"`
+	xxx_hidden_Cors                 *CorsCheck             `protobuf:"bytes,8,opt,name=cors"`
+	xxx_hidden_Baseline             *BaselineComparison    `protobuf:"bytes,9,opt,name=baseline"`
+	xxx_hidden_ManifestFlowId       *string                `protobuf:"bytes,10,opt,name=manifest_flow_id,json=manifestFlowId"`
+	xxx_hidden_WebsocketMessages    *[]*MessageDetails     `protobuf:"bytes,11,rep,name=websocket_messages,json=websocketMessages"`
+	xxx_hidden_Http2                *HTTP2Details          `protobuf:"bytes,12,opt,name=http2"`
+	xxx_hidden_InterimResponses     *[]*InterimResponse    `protobuf:"bytes,13,rep,name=interim_responses,json=interimResponses"`
+	xxx_hidden_Protocol             *string                `protobuf:"bytes,14,opt,name=protocol"`
+	xxx_hidden_Totals               *FlowTotals            `protobuf:"bytes,15,opt,name=totals"`
+	XXX_raceDetectHookData          protoimpl.RaceDetectHookData
+	XXX_presence                    [1]uint32
+	unknownFields                   protoimpl.UnknownFields
+	sizeCache                       protoimpl.SizeCache
+}
+
+func (x *HTTPFlowExtra) Reset() {
+	*x = HTTPFlowExtra{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HTTPFlowExtra) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPFlowExtra) ProtoMessage() {}
+
+func (x *HTTPFlowExtra) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *HTTPFlowExtra) GetRequest() *MessageDetails {
+	if x != nil {
+		return x.xxx_hidden_Request
+	}
+	return nil
+}
+
+func (x *HTTPFlowExtra) GetResponse() *MessageDetails {
+	if x != nil {
+		return x.xxx_hidden_Response
+	}
+	return nil
+}
+
+func (x *HTTPFlowExtra) GetUserAgent() *UserAgent {
+	if x != nil {
+		return x.xxx_hidden_UserAgent
+	}
+	return nil
+}
+
+func (x *HTTPFlowExtra) GetServerGeo() *GeoInfo {
+	if x != nil {
+		return x.xxx_hidden_ServerGeo
+	}
+	return nil
+}
+
+func (x *HTTPFlowExtra) GetServerHostname() string {
+	if x != nil {
+		if x.xxx_hidden_ServerHostname != nil {
+			return *x.xxx_hidden_ServerHostname
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *HTTPFlowExtra) GetServerHostnameSource() HostnameSource {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 5) {
+			return x.xxx_hidden_ServerHostnameSource
+		}
+	}
+	return HostnameSource_HOSTNAME_SOURCE_UNSPECIFIED
+}
+
+func (x *HTTPFlowExtra) GetSecurityFindings() []*SecurityFinding {
+	if x != nil {
+		if x.xxx_hidden_SecurityFindings != nil {
+			return *x.xxx_hidden_SecurityFindings
+		}
+	}
+	return nil
+}
+
+func (x *HTTPFlowExtra) GetCors() *CorsCheck {
+	if x != nil {
+		return x.xxx_hidden_Cors
+	}
+	return nil
+}
+
+func (x *HTTPFlowExtra) GetBaseline() *BaselineComparison {
+	if x != nil {
+		return x.xxx_hidden_Baseline
+	}
+	return nil
+}
+
+func (x *HTTPFlowExtra) GetManifestFlowId() string {
+	if x != nil {
+		if x.xxx_hidden_ManifestFlowId != nil {
+			return *x.xxx_hidden_ManifestFlowId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *HTTPFlowExtra) GetWebsocketMessages() []*MessageDetails {
+	if x != nil {
+		if x.xxx_hidden_WebsocketMessages != nil {
+			return *x.xxx_hidden_WebsocketMessages
+		}
+	}
+	return nil
+}
+
+func (x *HTTPFlowExtra) GetHttp2() *HTTP2Details {
+	if x != nil {
+		return x.xxx_hidden_Http2
+	}
+	return nil
+}
+
+func (x *HTTPFlowExtra) GetInterimResponses() []*InterimResponse {
+	if x != nil {
+		if x.xxx_hidden_InterimResponses != nil {
+			return *x.xxx_hidden_InterimResponses
+		}
+	}
+	return nil
+}
+
+func (x *HTTPFlowExtra) GetProtocol() string {
+	if x != nil {
+		if x.xxx_hidden_Protocol != nil {
+			return *x.xxx_hidden_Protocol
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *HTTPFlowExtra) GetTotals() *FlowTotals {
+	if x != nil {
+		return x.xxx_hidden_Totals
+	}
+	return nil
+}
+
+func (x *HTTPFlowExtra) SetRequest(v *MessageDetails) {
+	x.xxx_hidden_Request = v
+}
+
+func (x *HTTPFlowExtra) SetResponse(v *MessageDetails) {
+	x.xxx_hidden_Response = v
+}
+
+func (x *HTTPFlowExtra) SetUserAgent(v *UserAgent) {
+	x.xxx_hidden_UserAgent = v
+}
+
+func (x *HTTPFlowExtra) SetServerGeo(v *GeoInfo) {
+	x.xxx_hidden_ServerGeo = v
+}
+
+func (x *HTTPFlowExtra) SetServerHostname(v string) {
+	x.xxx_hidden_ServerHostname = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 15)
+}
+
+func (x *HTTPFlowExtra) SetServerHostnameSource(v HostnameSource) {
+	x.xxx_hidden_ServerHostnameSource = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 15)
+}
+
+func (x *HTTPFlowExtra) SetSecurityFindings(v []*SecurityFinding) {
+	x.xxx_hidden_SecurityFindings = &v
+}
+
+func (x *HTTPFlowExtra) SetCors(v *CorsCheck) {
+	x.xxx_hidden_Cors = v
+}
+
+func (x *HTTPFlowExtra) SetBaseline(v *BaselineComparison) {
+	x.xxx_hidden_Baseline = v
+}
+
+func (x *HTTPFlowExtra) SetManifestFlowId(v string) {
+	x.xxx_hidden_ManifestFlowId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 15)
+}
+
+func (x *HTTPFlowExtra) SetWebsocketMessages(v []*MessageDetails) {
+	x.xxx_hidden_WebsocketMessages = &v
+}
+
+func (x *HTTPFlowExtra) SetHttp2(v *HTTP2Details) {
+	x.xxx_hidden_Http2 = v
+}
+
+func (x *HTTPFlowExtra) SetInterimResponses(v []*InterimResponse) {
+	x.xxx_hidden_InterimResponses = &v
+}
+
+func (x *HTTPFlowExtra) SetProtocol(v string) {
+	x.xxx_hidden_Protocol = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 13, 15)
+}
+
+func (x *HTTPFlowExtra) SetTotals(v *FlowTotals) {
+	x.xxx_hidden_Totals = v
+}
+
+func (x *HTTPFlowExtra) HasRequest() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Request != nil
+}
+
+func (x *HTTPFlowExtra) HasResponse() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Response != nil
+}
+
+func (x *HTTPFlowExtra) HasUserAgent() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_UserAgent != nil
+}
+
+func (x *HTTPFlowExtra) HasServerGeo() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ServerGeo != nil
+}
+
+func (x *HTTPFlowExtra) HasServerHostname() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *HTTPFlowExtra) HasServerHostnameSource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *HTTPFlowExtra) HasCors() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Cors != nil
+}
+
+func (x *HTTPFlowExtra) HasBaseline() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Baseline != nil
+}
+
+func (x *HTTPFlowExtra) HasManifestFlowId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *HTTPFlowExtra) HasHttp2() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Http2 != nil
+}
+
+func (x *HTTPFlowExtra) HasProtocol() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 13)
+}
+
+func (x *HTTPFlowExtra) HasTotals() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Totals != nil
+}
+
+func (x *HTTPFlowExtra) ClearRequest() {
+	x.xxx_hidden_Request = nil
+}
+
+func (x *HTTPFlowExtra) ClearResponse() {
+	x.xxx_hidden_Response = nil
+}
+
+func (x *HTTPFlowExtra) ClearUserAgent() {
+	x.xxx_hidden_UserAgent = nil
+}
+
+func (x *HTTPFlowExtra) ClearServerGeo() {
+	x.xxx_hidden_ServerGeo = nil
+}
+
+func (x *HTTPFlowExtra) ClearServerHostname() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_ServerHostname = nil
+}
+
+func (x *HTTPFlowExtra) ClearServerHostnameSource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_ServerHostnameSource = HostnameSource_HOSTNAME_SOURCE_UNSPECIFIED
+}
+
+func (x *HTTPFlowExtra) ClearCors() {
+	x.xxx_hidden_Cors = nil
+}
+
+func (x *HTTPFlowExtra) ClearBaseline() {
+	x.xxx_hidden_Baseline = nil
+}
+
+func (x *HTTPFlowExtra) ClearManifestFlowId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_ManifestFlowId = nil
+}
+
+func (x *HTTPFlowExtra) ClearHttp2() {
+	x.xxx_hidden_Http2 = nil
+}
+
+func (x *HTTPFlowExtra) ClearProtocol() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 13)
+	x.xxx_hidden_Protocol = nil
+}
+
+func (x *HTTPFlowExtra) ClearTotals() {
+	x.xxx_hidden_Totals = nil
+}
+
+type HTTPFlowExtra_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Request  *MessageDetails
+	Response *MessageDetails
+	// Parsed from the request's User-Agent header, when there is one.
+	UserAgent *UserAgent
+	ServerGeo *GeoInfo
+	// Best guess at the server's hostname when the request only addressed an
+	// IP, e.g. in transparent mode without a Host header.
+	ServerHostname       *string
+	ServerHostnameSource *HostnameSource
+	// Missing or weak security headers in the response.
+	SecurityFindings []*SecurityFinding
+	// Set on cross-origin requests and CORS preflights.
+	Cors *CorsCheck
+	// How the response compares with the baseline response for the same method
+	// and path, when a baseline is set and has one.
+	Baseline *BaselineComparison
+	// Set on requests for a playlist, segment, key or init segment that an
+	// earlier HLS or DASH manifest listed: the ID of that manifest's flow.
+	ManifestFlowId *string
+	// Decoded WebSocket messages, index for index, when the connection speaks
+	// a subprotocol mitmflow can decode, like MQTT.
+	WebsocketMessages []*MessageDetails
+	// Set on HTTP/2 and HTTP/3 flows.
+	Http2 *HTTP2Details
+	// 1xx responses that came before the final response, like 103 Early Hints,
+	// in the order they arrived. mitmproxy doesn't send them; they're recorded
+	// for requests sent by mitmflow.
+	InterimResponses []*InterimResponse
+	// Normalized protocol label computed at ingest: "http1", "http2", "http3",
+	// "grpc", "grpc-web", "connect", "websocket" or "dns-over-https".
+	Protocol *string
+	Totals   *FlowTotals
+}
+
+func (b0 HTTPFlowExtra_builder) Build() *HTTPFlowExtra {
+	m0 := &HTTPFlowExtra{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Request = b.Request
+	x.xxx_hidden_Response = b.Response
+	x.xxx_hidden_UserAgent = b.UserAgent
+	x.xxx_hidden_ServerGeo = b.ServerGeo
+	if b.ServerHostname != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 15)
+		x.xxx_hidden_ServerHostname = b.ServerHostname
+	}
+	if b.ServerHostnameSource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 15)
+		x.xxx_hidden_ServerHostnameSource = *b.ServerHostnameSource
+	}
+	x.xxx_hidden_SecurityFindings = &b.SecurityFindings
+	x.xxx_hidden_Cors = b.Cors
+	x.xxx_hidden_Baseline = b.Baseline
+	if b.ManifestFlowId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 15)
+		x.xxx_hidden_ManifestFlowId = b.ManifestFlowId
+	}
+	x.xxx_hidden_WebsocketMessages = &b.WebsocketMessages
+	x.xxx_hidden_Http2 = b.Http2
+	x.xxx_hidden_InterimResponses = &b.InterimResponses
+	if b.Protocol != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 13, 15)
+		x.xxx_hidden_Protocol = b.Protocol
+	}
+	x.xxx_hidden_Totals = b.Totals
+	return m0
+}
+
+// FlowTotals holds the sizes and duration of a flow, computed at ingest so
+// filters, stats and list views don't derive them from bodies and timestamps.
+type FlowTotals struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_RequestBytes  int64                  `protobuf:"varint,1,opt,name=request_bytes,json=requestBytes"`
+	xxx_hidden_ResponseBytes int64                  `protobuf:"varint,2,opt,name=response_bytes,json=responseBytes"`
+	xxx_hidden_DurationMs    int64                  `protobuf:"varint,3,opt,name=duration_ms,json=durationMs"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *FlowTotals) Reset() {
+	*x = FlowTotals{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlowTotals) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlowTotals) ProtoMessage() {}
+
+func (x *FlowTotals) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *FlowTotals) GetRequestBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_RequestBytes
+	}
+	return 0
+}
+
+func (x *FlowTotals) GetResponseBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_ResponseBytes
+	}
+	return 0
+}
+
+func (x *FlowTotals) GetDurationMs() int64 {
+	if x != nil {
+		return x.xxx_hidden_DurationMs
+	}
+	return 0
+}
+
+func (x *FlowTotals) SetRequestBytes(v int64) {
+	x.xxx_hidden_RequestBytes = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *FlowTotals) SetResponseBytes(v int64) {
+	x.xxx_hidden_ResponseBytes = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *FlowTotals) SetDurationMs(v int64) {
+	x.xxx_hidden_DurationMs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *FlowTotals) HasRequestBytes() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *FlowTotals) HasResponseBytes() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *FlowTotals) HasDurationMs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *FlowTotals) ClearRequestBytes() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_RequestBytes = 0
+}
+
+func (x *FlowTotals) ClearResponseBytes() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_ResponseBytes = 0
+}
+
+func (x *FlowTotals) ClearDurationMs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_DurationMs = 0
+}
+
+type FlowTotals_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Bytes sent by the client: for HTTP the request line, headers, body and
+	// trailers as HTTP/1 would send them, with the body at its original size;
+	// for TCP and UDP the client's messages; for DNS the query.
+	RequestBytes *int64
+	// Bytes sent by the server, counted the same way.
+	ResponseBytes *int64
+	// From the start of the flow to the end of its response or last message.
+	DurationMs *int64
+}
+
+func (b0 FlowTotals_builder) Build() *FlowTotals {
+	m0 := &FlowTotals{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.RequestBytes != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_RequestBytes = *b.RequestBytes
+	}
+	if b.ResponseBytes != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_ResponseBytes = *b.ResponseBytes
+	}
+	if b.DurationMs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_DurationMs = *b.DurationMs
+	}
+	return m0
+}
+
+type InterimResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_StatusCode  int32                  `protobuf:"varint,1,opt,name=status_code,json=statusCode"`
+	xxx_hidden_Headers     map[string]string      `protobuf:"bytes,2,rep,name=headers" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_Timestamp   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *InterimResponse) Reset() {
+	*x = InterimResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterimResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterimResponse) ProtoMessage() {}
+
+func (x *InterimResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *InterimResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.xxx_hidden_StatusCode
+	}
+	return 0
+}
+
+func (x *InterimResponse) GetHeaders() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Headers
+	}
+	return nil
+}
+
+func (x *InterimResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Timestamp
+	}
+	return nil
+}
+
+func (x *InterimResponse) SetStatusCode(v int32) {
+	x.xxx_hidden_StatusCode = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *InterimResponse) SetHeaders(v map[string]string) {
+	x.xxx_hidden_Headers = v
+}
+
+func (x *InterimResponse) SetTimestamp(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Timestamp = v
+}
+
+func (x *InterimResponse) HasStatusCode() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *InterimResponse) HasTimestamp() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Timestamp != nil
+}
+
+func (x *InterimResponse) ClearStatusCode() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_StatusCode = 0
+}
+
+func (x *InterimResponse) ClearTimestamp() {
+	x.xxx_hidden_Timestamp = nil
+}
+
+type InterimResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	StatusCode *int32
+	Headers    map[string]string
+	Timestamp  *timestamppb.Timestamp
+}
+
+func (b0 InterimResponse_builder) Build() *InterimResponse {
+	m0 := &InterimResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.StatusCode != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_StatusCode = *b.StatusCode
+	}
+	x.xxx_hidden_Headers = b.Headers
+	x.xxx_hidden_Timestamp = b.Timestamp
+	return m0
+}
+
+// HTTP2Details holds the parts of an HTTP/2 or HTTP/3 exchange that its
+// HTTP/1 style request and response don't show. mitmproxy doesn't send stream
+// IDs or whether a stream was pushed, so those aren't known.
+type HTTP2Details struct {
+	state                            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_RequestPseudoHeaders  *[]*HeaderField        `protobuf:"bytes,1,rep,name=request_pseudo_headers,json=requestPseudoHeaders"`
+	xxx_hidden_ResponsePseudoHeaders *[]*HeaderField        `protobuf:"bytes,2,rep,name=response_pseudo_headers,json=responsePseudoHeaders"`
+	xxx_hidden_RequestHeaderOrder    []string               `protobuf:"bytes,3,rep,name=request_header_order,json=requestHeaderOrder"`
+	xxx_hidden_ResponseHeaderOrder   []string               `protobuf:"bytes,4,rep,name=response_header_order,json=responseHeaderOrder"`
+	unknownFields                    protoimpl.UnknownFields
+	sizeCache                        protoimpl.SizeCache
+}
+
+func (x *HTTP2Details) Reset() {
+	*x = HTTP2Details{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HTTP2Details) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTP2Details) ProtoMessage() {}
+
+func (x *HTTP2Details) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *HTTP2Details) GetRequestPseudoHeaders() []*HeaderField {
+	if x != nil {
+		if x.xxx_hidden_RequestPseudoHeaders != nil {
+			return *x.xxx_hidden_RequestPseudoHeaders
+		}
+	}
+	return nil
+}
+
+func (x *HTTP2Details) GetResponsePseudoHeaders() []*HeaderField {
+	if x != nil {
+		if x.xxx_hidden_ResponsePseudoHeaders != nil {
+			return *x.xxx_hidden_ResponsePseudoHeaders
+		}
+	}
+	return nil
+}
+
+func (x *HTTP2Details) GetRequestHeaderOrder() []string {
+	if x != nil {
+		return x.xxx_hidden_RequestHeaderOrder
+	}
+	return nil
+}
+
+func (x *HTTP2Details) GetResponseHeaderOrder() []string {
+	if x != nil {
+		return x.xxx_hidden_ResponseHeaderOrder
+	}
+	return nil
+}
+
+func (x *HTTP2Details) SetRequestPseudoHeaders(v []*HeaderField) {
+	x.xxx_hidden_RequestPseudoHeaders = &v
+}
+
+func (x *HTTP2Details) SetResponsePseudoHeaders(v []*HeaderField) {
+	x.xxx_hidden_ResponsePseudoHeaders = &v
+}
+
+func (x *HTTP2Details) SetRequestHeaderOrder(v []string) {
+	x.xxx_hidden_RequestHeaderOrder = v
+}
+
+func (x *HTTP2Details) SetResponseHeaderOrder(v []string) {
+	x.xxx_hidden_ResponseHeaderOrder = v
+}
+
+type HTTP2Details_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// :method, :scheme, :authority and :path, in the order they were sent when
+	// the capture records them.
+	RequestPseudoHeaders []*HeaderField
+	// :status.
+	ResponsePseudoHeaders []*HeaderField
+	// Header names in the order HPACK or QPACK encoded them, repeats included.
+	// Only imported captures that keep headers as a list, like mitmproxy dumps
+	// and HAR files, record the order.
+	RequestHeaderOrder  []string
+	ResponseHeaderOrder []string
+}
+
+func (b0 HTTP2Details_builder) Build() *HTTP2Details {
+	m0 := &HTTP2Details{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_RequestPseudoHeaders = &b.RequestPseudoHeaders
+	x.xxx_hidden_ResponsePseudoHeaders = &b.ResponsePseudoHeaders
+	x.xxx_hidden_RequestHeaderOrder = b.RequestHeaderOrder
+	x.xxx_hidden_ResponseHeaderOrder = b.ResponseHeaderOrder
+	return m0
+}
+
+type HeaderField struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Value       *string                `protobuf:"bytes,2,opt,name=value"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *HeaderField) Reset() {
+	*x = HeaderField{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeaderField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeaderField) ProtoMessage() {}
+
+func (x *HeaderField) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *HeaderField) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *HeaderField) GetValue() string {
+	if x != nil {
+		if x.xxx_hidden_Value != nil {
+			return *x.xxx_hidden_Value
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *HeaderField) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *HeaderField) SetValue(v string) {
+	x.xxx_hidden_Value = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *HeaderField) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *HeaderField) HasValue() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *HeaderField) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *HeaderField) ClearValue() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Value = nil
+}
+
+type HeaderField_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name  *string
+	Value *string
+}
+
+func (b0 HeaderField_builder) Build() *HeaderField {
+	m0 := &HeaderField{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Value != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Value = b.Value
+	}
+	return m0
+}
+
+// CorsCheck compares a cross-origin request with the Access-Control-Allow-*
+// headers that the browser would check it against.
+type CorsCheck struct {
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Origin          *string                `protobuf:"bytes,1,opt,name=origin"`
+	xxx_hidden_Preflight       bool                   `protobuf:"varint,2,opt,name=preflight"`
+	xxx_hidden_PreflightFlowId *string                `protobuf:"bytes,3,opt,name=preflight_flow_id,json=preflightFlowId"`
+	xxx_hidden_Problems        []string               `protobuf:"bytes,4,rep,name=problems"`
+	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
+	XXX_presence               [1]uint32
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *CorsCheck) Reset() {
+	*x = CorsCheck{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CorsCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CorsCheck) ProtoMessage() {}
+
+func (x *CorsCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CorsCheck) GetOrigin() string {
+	if x != nil {
+		if x.xxx_hidden_Origin != nil {
+			return *x.xxx_hidden_Origin
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CorsCheck) GetPreflight() bool {
+	if x != nil {
+		return x.xxx_hidden_Preflight
+	}
+	return false
+}
+
+func (x *CorsCheck) GetPreflightFlowId() string {
+	if x != nil {
+		if x.xxx_hidden_PreflightFlowId != nil {
+			return *x.xxx_hidden_PreflightFlowId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CorsCheck) GetProblems() []string {
+	if x != nil {
+		return x.xxx_hidden_Problems
+	}
+	return nil
+}
+
+func (x *CorsCheck) SetOrigin(v string) {
+	x.xxx_hidden_Origin = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *CorsCheck) SetPreflight(v bool) {
+	x.xxx_hidden_Preflight = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *CorsCheck) SetPreflightFlowId(v string) {
+	x.xxx_hidden_PreflightFlowId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *CorsCheck) SetProblems(v []string) {
+	x.xxx_hidden_Problems = v
+}
+
+func (x *CorsCheck) HasOrigin() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CorsCheck) HasPreflight() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *CorsCheck) HasPreflightFlowId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *CorsCheck) ClearOrigin() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Origin = nil
+}
+
+func (x *CorsCheck) ClearPreflight() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Preflight = false
+}
+
+func (x *CorsCheck) ClearPreflightFlowId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_PreflightFlowId = nil
+}
+
+type CorsCheck_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The Origin header of the request.
+	Origin *string
+	// Whether the flow is an OPTIONS preflight rather than the actual request.
+	Preflight *bool
+	// For actual requests, the captured preflight that was sent for them.
+	PreflightFlowId *string
+	// Why the browser would block the request or its response, e.g.
+	// "Access-Control-Allow-Origin is missing". Empty when CORS allows it.
+	Problems []string
+}
+
+func (b0 CorsCheck_builder) Build() *CorsCheck {
+	m0 := &CorsCheck{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Origin != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Origin = b.Origin
+	}
+	if b.Preflight != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Preflight = *b.Preflight
+	}
+	if b.PreflightFlowId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_PreflightFlowId = b.PreflightFlowId
+	}
+	x.xxx_hidden_Problems = b.Problems
+	return m0
+}
+
+type SecurityFinding struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Header      *string                `protobuf:"bytes,1,opt,name=header"`
+	xxx_hidden_Severity    FindingSeverity        `protobuf:"varint,2,opt,name=severity,enum=mitmflow.v1.FindingSeverity"`
+	xxx_hidden_Message     *string                `protobuf:"bytes,3,opt,name=message"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SecurityFinding) Reset() {
+	*x = SecurityFinding{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecurityFinding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecurityFinding) ProtoMessage() {}
+
+func (x *SecurityFinding) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SecurityFinding) GetHeader() string {
+	if x != nil {
+		if x.xxx_hidden_Header != nil {
+			return *x.xxx_hidden_Header
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SecurityFinding) GetSeverity() FindingSeverity {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 1) {
+			return x.xxx_hidden_Severity
+		}
+	}
+	return FindingSeverity_FINDING_SEVERITY_UNSPECIFIED
+}
+
+func (x *SecurityFinding) GetMessage() string {
+	if x != nil {
+		if x.xxx_hidden_Message != nil {
+			return *x.xxx_hidden_Message
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SecurityFinding) SetHeader(v string) {
+	x.xxx_hidden_Header = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *SecurityFinding) SetSeverity(v FindingSeverity) {
+	x.xxx_hidden_Severity = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *SecurityFinding) SetMessage(v string) {
+	x.xxx_hidden_Message = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *SecurityFinding) HasHeader() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SecurityFinding) HasSeverity() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *SecurityFinding) HasMessage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *SecurityFinding) ClearHeader() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Header = nil
+}
+
+func (x *SecurityFinding) ClearSeverity() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Severity = FindingSeverity_FINDING_SEVERITY_UNSPECIFIED
+}
+
+func (x *SecurityFinding) ClearMessage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Message = nil
+}
+
+type SecurityFinding_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The header the finding is about, e.g. "Strict-Transport-Security" or
+	// "Set-Cookie".
+	Header   *string
+	Severity *FindingSeverity
+	// What is wrong, e.g. "missing" or "cookie sid: missing Secure".
+	Message *string
+}
+
+func (b0 SecurityFinding_builder) Build() *SecurityFinding {
+	m0 := &SecurityFinding{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Header != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Header = b.Header
+	}
+	if b.Severity != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Severity = *b.Severity
+	}
+	if b.Message != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Message = b.Message
+	}
+	return m0
+}
+
+// GeoInfo locates a public IP address using the GeoIP databases the server
+// was started with. Fields the databases don't cover are left empty.
+type GeoInfo struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_CountryCode    *string                `protobuf:"bytes,1,opt,name=country_code,json=countryCode"`
+	xxx_hidden_CountryName    *string                `protobuf:"bytes,2,opt,name=country_name,json=countryName"`
+	xxx_hidden_Asn            uint32                 `protobuf:"varint,3,opt,name=asn"`
+	xxx_hidden_AsOrganization *string                `protobuf:"bytes,4,opt,name=as_organization,json=asOrganization"`
+	XXX_raceDetectHookData    protoimpl.RaceDetectHookData
+	XXX_presence              [1]uint32
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *GeoInfo) Reset() {
+	*x = GeoInfo{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoInfo) ProtoMessage() {}
+
+func (x *GeoInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GeoInfo) GetCountryCode() string {
+	if x != nil {
+		if x.xxx_hidden_CountryCode != nil {
+			return *x.xxx_hidden_CountryCode
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GeoInfo) GetCountryName() string {
+	if x != nil {
+		if x.xxx_hidden_CountryName != nil {
+			return *x.xxx_hidden_CountryName
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GeoInfo) GetAsn() uint32 {
+	if x != nil {
+		return x.xxx_hidden_Asn
+	}
+	return 0
+}
+
+func (x *GeoInfo) GetAsOrganization() string {
+	if x != nil {
+		if x.xxx_hidden_AsOrganization != nil {
+			return *x.xxx_hidden_AsOrganization
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GeoInfo) SetCountryCode(v string) {
+	x.xxx_hidden_CountryCode = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *GeoInfo) SetCountryName(v string) {
+	x.xxx_hidden_CountryName = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *GeoInfo) SetAsn(v uint32) {
+	x.xxx_hidden_Asn = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *GeoInfo) SetAsOrganization(v string) {
+	x.xxx_hidden_AsOrganization = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *GeoInfo) HasCountryCode() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *GeoInfo) HasCountryName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *GeoInfo) HasAsn() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *GeoInfo) HasAsOrganization() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *GeoInfo) ClearCountryCode() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_CountryCode = nil
+}
+
+func (x *GeoInfo) ClearCountryName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_CountryName = nil
+}
+
+func (x *GeoInfo) ClearAsn() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Asn = 0
+}
+
+func (x *GeoInfo) ClearAsOrganization() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_AsOrganization = nil
+}
+
+type GeoInfo_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// ISO 3166-1 alpha-2 code, e.g. "DE".
+	CountryCode    *string
+	CountryName    *string
+	Asn            *uint32
+	AsOrganization *string
+}
+
+func (b0 GeoInfo_builder) Build() *GeoInfo {
+	m0 := &GeoInfo{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.CountryCode != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_CountryCode = b.CountryCode
+	}
+	if b.CountryName != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_CountryName = b.CountryName
+	}
+	if b.Asn != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Asn = *b.Asn
+	}
+	if b.AsOrganization != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_AsOrganization = b.AsOrganization
+	}
+	return m0
+}
+
+type UserAgent struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Browser        *string                `protobuf:"bytes,1,opt,name=browser"`
+	xxx_hidden_BrowserVersion *string                `protobuf:"bytes,2,opt,name=browser_version,json=browserVersion"`
+	xxx_hidden_Os             *string                `protobuf:"bytes,3,opt,name=os"`
+	xxx_hidden_OsVersion      *string                `protobuf:"bytes,4,opt,name=os_version,json=osVersion"`
+	xxx_hidden_Device         *string                `protobuf:"bytes,5,opt,name=device"`
+	xxx_hidden_DeviceType     DeviceType             `protobuf:"varint,6,opt,name=device_type,json=deviceType,enum=mitmflow.v1.DeviceType"`
+	XXX_raceDetectHookData    protoimpl.RaceDetectHookData
+	XXX_presence              [1]uint32
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *UserAgent) Reset() {
+	*x = UserAgent{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserAgent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserAgent) ProtoMessage() {}
+
+func (x *UserAgent) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UserAgent) GetBrowser() string {
+	if x != nil {
+		if x.xxx_hidden_Browser != nil {
+			return *x.xxx_hidden_Browser
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *UserAgent) GetBrowserVersion() string {
+	if x != nil {
+		if x.xxx_hidden_BrowserVersion != nil {
+			return *x.xxx_hidden_BrowserVersion
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *UserAgent) GetOs() string {
+	if x != nil {
+		if x.xxx_hidden_Os != nil {
+			return *x.xxx_hidden_Os
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *UserAgent) GetOsVersion() string {
+	if x != nil {
+		if x.xxx_hidden_OsVersion != nil {
+			return *x.xxx_hidden_OsVersion
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *UserAgent) GetDevice() string {
+	if x != nil {
+		if x.xxx_hidden_Device != nil {
+			return *x.xxx_hidden_Device
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *UserAgent) GetDeviceType() DeviceType {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 5) {
+			return x.xxx_hidden_DeviceType
+		}
+	}
+	return DeviceType_DEVICE_TYPE_UNSPECIFIED
+}
+
+func (x *UserAgent) SetBrowser(v string) {
+	x.xxx_hidden_Browser = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+}
+
+func (x *UserAgent) SetBrowserVersion(v string) {
+	x.xxx_hidden_BrowserVersion = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+}
+
+func (x *UserAgent) SetOs(v string) {
+	x.xxx_hidden_Os = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
+}
+
+func (x *UserAgent) SetOsVersion(v string) {
+	x.xxx_hidden_OsVersion = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
+}
+
+func (x *UserAgent) SetDevice(v string) {
+	x.xxx_hidden_Device = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *UserAgent) SetDeviceType(v DeviceType) {
+	x.xxx_hidden_DeviceType = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
+func (x *UserAgent) HasBrowser() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *UserAgent) HasBrowserVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *UserAgent) HasOs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *UserAgent) HasOsVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *UserAgent) HasDevice() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *UserAgent) HasDeviceType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *UserAgent) ClearBrowser() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Browser = nil
+}
+
+func (x *UserAgent) ClearBrowserVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_BrowserVersion = nil
+}
+
+func (x *UserAgent) ClearOs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Os = nil
+}
+
+func (x *UserAgent) ClearOsVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_OsVersion = nil
+}
+
+func (x *UserAgent) ClearDevice() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Device = nil
+}
+
+func (x *UserAgent) ClearDeviceType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_DeviceType = DeviceType_DEVICE_TYPE_UNSPECIFIED
+}
+
+type UserAgent_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Browser        *string
+	BrowserVersion *string
+	Os             *string
+	OsVersion      *string
+	// The device model, when the User-Agent names one, e.g. "iPhone".
+	Device     *string
+	DeviceType *DeviceType
+}
+
+func (b0 UserAgent_builder) Build() *UserAgent {
+	m0 := &UserAgent{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Browser != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		x.xxx_hidden_Browser = b.Browser
+	}
+	if b.BrowserVersion != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		x.xxx_hidden_BrowserVersion = b.BrowserVersion
+	}
+	if b.Os != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
+		x.xxx_hidden_Os = b.Os
+	}
+	if b.OsVersion != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 6)
+		x.xxx_hidden_OsVersion = b.OsVersion
+	}
+	if b.Device != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_Device = b.Device
+	}
+	if b.DeviceType != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_DeviceType = *b.DeviceType
+	}
+	return m0
+}
+
+// StreamFlowExtra holds details for the messages of a TCP or UDP flow, in the
+// same order as the flow's messages.
+type StreamFlowExtra struct {
+	state                           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Messages             *[]*MessageDetails     `protobuf:"bytes,1,rep,name=messages"`
+	xxx_hidden_ServerGeo            *GeoInfo               `protobuf:"bytes,2,opt,name=server_geo,json=serverGeo"`
+	xxx_hidden_ServerHostname       *string                `protobuf:"bytes,3,opt,name=server_hostname,json=serverHostname"`
+	xxx_hidden_ServerHostnameSource HostnameSource         `protobuf:"varint,4,opt,name=server_hostname_source,json=serverHostnameSource,enum=mitmflow.v1.HostnameSource"`
+	xxx_hidden_Protocol             *string                `protobuf:"bytes,5,opt,name=protocol"`
+	xxx_hidden_Totals               *FlowTotals            `protobuf:"bytes,6,opt,name=totals"`
+	XXX_raceDetectHookData          protoimpl.RaceDetectHookData
+	XXX_presence                    [1]uint32
+	unknownFields                   protoimpl.UnknownFields
+	sizeCache                       protoimpl.SizeCache
+}
+
+func (x *StreamFlowExtra) Reset() {
+	*x = StreamFlowExtra{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamFlowExtra) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamFlowExtra) ProtoMessage() {}
+
+func (x *StreamFlowExtra) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *StreamFlowExtra) GetMessages() []*MessageDetails {
+	if x != nil {
+		if x.xxx_hidden_Messages != nil {
+			return *x.xxx_hidden_Messages
+		}
+	}
+	return nil
+}
+
+func (x *StreamFlowExtra) GetServerGeo() *GeoInfo {
+	if x != nil {
+		return x.xxx_hidden_ServerGeo
+	}
+	return nil
+}
+
+func (x *StreamFlowExtra) GetServerHostname() string {
+	if x != nil {
+		if x.xxx_hidden_ServerHostname != nil {
+			return *x.xxx_hidden_ServerHostname
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *StreamFlowExtra) GetServerHostnameSource() HostnameSource {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 3) {
+			return x.xxx_hidden_ServerHostnameSource
+		}
+	}
+	return HostnameSource_HOSTNAME_SOURCE_UNSPECIFIED
+}
+
+func (x *StreamFlowExtra) GetProtocol() string {
+	if x != nil {
+		if x.xxx_hidden_Protocol != nil {
+			return *x.xxx_hidden_Protocol
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *StreamFlowExtra) GetTotals() *FlowTotals {
+	if x != nil {
+		return x.xxx_hidden_Totals
+	}
+	return nil
+}
+
+func (x *StreamFlowExtra) SetMessages(v []*MessageDetails) {
+	x.xxx_hidden_Messages = &v
+}
+
+func (x *StreamFlowExtra) SetServerGeo(v *GeoInfo) {
+	x.xxx_hidden_ServerGeo = v
+}
+
+func (x *StreamFlowExtra) SetServerHostname(v string) {
+	x.xxx_hidden_ServerHostname = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
+}
+
+func (x *StreamFlowExtra) SetServerHostnameSource(v HostnameSource) {
+	x.xxx_hidden_ServerHostnameSource = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
+}
+
+func (x *StreamFlowExtra) SetProtocol(v string) {
+	x.xxx_hidden_Protocol = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *StreamFlowExtra) SetTotals(v *FlowTotals) {
+	x.xxx_hidden_Totals = v
+}
+
+func (x *StreamFlowExtra) HasServerGeo() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ServerGeo != nil
+}
+
+func (x *StreamFlowExtra) HasServerHostname() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *StreamFlowExtra) HasServerHostnameSource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *StreamFlowExtra) HasProtocol() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *StreamFlowExtra) HasTotals() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Totals != nil
+}
+
+func (x *StreamFlowExtra) ClearServerGeo() {
+	x.xxx_hidden_ServerGeo = nil
+}
+
+func (x *StreamFlowExtra) ClearServerHostname() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_ServerHostname = nil
+}
+
+func (x *StreamFlowExtra) ClearServerHostnameSource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_ServerHostnameSource = HostnameSource_HOSTNAME_SOURCE_UNSPECIFIED
+}
+
+func (x *StreamFlowExtra) ClearProtocol() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Protocol = nil
+}
+
+func (x *StreamFlowExtra) ClearTotals() {
+	x.xxx_hidden_Totals = nil
+}
+
+type StreamFlowExtra_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Messages  []*MessageDetails
+	ServerGeo *GeoInfo
+	// Best guess at the server's hostname when the flow only addressed an IP.
+	ServerHostname       *string
+	ServerHostnameSource *HostnameSource
+	// Normalized protocol label, e.g. "tcp-postgresql", "dns-over-tcp", or
+	// plain "tcp" and "udp" when the protocol isn't recognized.
+	Protocol *string
+	Totals   *FlowTotals
+}
+
+func (b0 StreamFlowExtra_builder) Build() *StreamFlowExtra {
+	m0 := &StreamFlowExtra{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Messages = &b.Messages
+	x.xxx_hidden_ServerGeo = b.ServerGeo
+	if b.ServerHostname != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
+		x.xxx_hidden_ServerHostname = b.ServerHostname
+	}
+	if b.ServerHostnameSource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 6)
+		x.xxx_hidden_ServerHostnameSource = *b.ServerHostnameSource
+	}
+	if b.Protocol != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_Protocol = b.Protocol
+	}
+	x.xxx_hidden_Totals = b.Totals
+	return m0
+}
+
+// DnsFlowExtra holds what was found out about a DNS flow.
+type DnsFlowExtra struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Anomalies   *[]*DnsAnomaly         `protobuf:"bytes,1,rep,name=anomalies"`
+	xxx_hidden_Protocol    *string                `protobuf:"bytes,2,opt,name=protocol"`
+	xxx_hidden_Totals      *FlowTotals            `protobuf:"bytes,3,opt,name=totals"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *DnsFlowExtra) Reset() {
+	*x = DnsFlowExtra{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DnsFlowExtra) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DnsFlowExtra) ProtoMessage() {}
+
+func (x *DnsFlowExtra) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DnsFlowExtra) GetAnomalies() []*DnsAnomaly {
+	if x != nil {
+		if x.xxx_hidden_Anomalies != nil {
+			return *x.xxx_hidden_Anomalies
+		}
+	}
+	return nil
+}
+
+func (x *DnsFlowExtra) GetProtocol() string {
+	if x != nil {
+		if x.xxx_hidden_Protocol != nil {
+			return *x.xxx_hidden_Protocol
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DnsFlowExtra) GetTotals() *FlowTotals {
+	if x != nil {
+		return x.xxx_hidden_Totals
+	}
+	return nil
+}
+
+func (x *DnsFlowExtra) SetAnomalies(v []*DnsAnomaly) {
+	x.xxx_hidden_Anomalies = &v
+}
+
+func (x *DnsFlowExtra) SetProtocol(v string) {
+	x.xxx_hidden_Protocol = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *DnsFlowExtra) SetTotals(v *FlowTotals) {
+	x.xxx_hidden_Totals = v
+}
+
+func (x *DnsFlowExtra) HasProtocol() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *DnsFlowExtra) HasTotals() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Totals != nil
+}
+
+func (x *DnsFlowExtra) ClearProtocol() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Protocol = nil
+}
+
+func (x *DnsFlowExtra) ClearTotals() {
+	x.xxx_hidden_Totals = nil
+}
+
+type DnsFlowExtra_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Signs of tunneling, exfiltration or a misbehaving client, for triage.
+	Anomalies []*DnsAnomaly
+	// Normalized protocol label, always "dns".
+	Protocol *string
+	Totals   *FlowTotals
+}
+
+func (b0 DnsFlowExtra_builder) Build() *DnsFlowExtra {
+	m0 := &DnsFlowExtra{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Anomalies = &b.Anomalies
+	if b.Protocol != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Protocol = b.Protocol
+	}
+	x.xxx_hidden_Totals = b.Totals
+	return m0
+}
+
+type DnsAnomaly struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Kind        DnsAnomalyKind         `protobuf:"varint,1,opt,name=kind,enum=mitmflow.v1.DnsAnomalyKind"`
+	xxx_hidden_Detail      *string                `protobuf:"bytes,2,opt,name=detail"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *DnsAnomaly) Reset() {
+	*x = DnsAnomaly{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DnsAnomaly) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DnsAnomaly) ProtoMessage() {}
+
+func (x *DnsAnomaly) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DnsAnomaly) GetKind() DnsAnomalyKind {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Kind
+		}
+	}
+	return DnsAnomalyKind_DNS_ANOMALY_KIND_UNSPECIFIED
+}
+
+func (x *DnsAnomaly) GetDetail() string {
+	if x != nil {
+		if x.xxx_hidden_Detail != nil {
+			return *x.xxx_hidden_Detail
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DnsAnomaly) SetKind(v DnsAnomalyKind) {
+	x.xxx_hidden_Kind = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *DnsAnomaly) SetDetail(v string) {
+	x.xxx_hidden_Detail = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *DnsAnomaly) HasKind() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *DnsAnomaly) HasDetail() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *DnsAnomaly) ClearKind() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Kind = DnsAnomalyKind_DNS_ANOMALY_KIND_UNSPECIFIED
+}
+
+func (x *DnsAnomaly) ClearDetail() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Detail = nil
+}
+
+type DnsAnomaly_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Kind *DnsAnomalyKind
+	// What was seen, e.g. "label of 58 characters".
+	Detail *string
+}
+
+func (b0 DnsAnomaly_builder) Build() *DnsAnomaly {
+	m0 := &DnsAnomaly{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Kind != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Kind = *b.Kind
+	}
+	if b.Detail != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Detail = b.Detail
+	}
+	return m0
+}
+
+type MessageDetails struct {
+	state                           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_TextualFrames        []string               `protobuf:"bytes,1,rep,name=textual_frames,json=textualFrames"`
+	xxx_hidden_EffectiveContentType *string                `protobuf:"bytes,2,opt,name=effective_content_type,json=effectiveContentType"`
+	xxx_hidden_BodySize             int64                  `protobuf:"varint,3,opt,name=body_size,json=bodySize"`
+	xxx_hidden_BlobKey              *string                `protobuf:"bytes,4,opt,name=blob_key,json=blobKey"`
+	xxx_hidden_Truncated            bool                   `protobuf:"varint,5,opt,name=truncated"`
+	xxx_hidden_FrameTimestampsNs    []int64                `protobuf:"varint,6,rep,packed,name=frame_timestamps_ns,json=frameTimestampsNs"`
+	xxx_hidden_Sha256               *string                `protobuf:"bytes,7,opt,name=sha256"`
+	xxx_hidden_Soap                 *SoapMessage           `protobuf:"bytes,8,opt,name=soap"`
+	xxx_hidden_RecordCount          int32                  `protobuf:"varint,9,opt,name=record_count,json=recordCount"`
+	xxx_hidden_FormFields           *[]*FormField          `protobuf:"bytes,10,rep,name=form_fields,json=formFields"`
+	xxx_hidden_Media                *MediaInfo             `protobuf:"bytes,11,opt,name=media"`
+	xxx_hidden_DeclaredContentType  *string                `protobuf:"bytes,12,opt,name=declared_content_type,json=declaredContentType"`
+	xxx_hidden_DetectedContentType  *string                `protobuf:"bytes,13,opt,name=detected_content_type,json=detectedContentType"`
+	xxx_hidden_GrpcStatus           *GrpcStatus            `protobuf:"bytes,14,opt,name=grpc_status,json=grpcStatus"`
+	xxx_hidden_FrameCount           int32                  `protobuf:"varint,15,opt,name=frame_count,json=frameCount"`
+	xxx_hidden_BinaryMetadata       *[]*BinaryMetadata     `protobuf:"bytes,16,rep,name=binary_metadata,json=binaryMetadata"`
+	XXX_raceDetectHookData          protoimpl.RaceDetectHookData
+	XXX_presence                    [1]uint32
+	unknownFields                   protoimpl.UnknownFields
+	sizeCache                       protoimpl.SizeCache
+}
+
+func (x *MessageDetails) Reset() {
+	*x = MessageDetails{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageDetails) ProtoMessage() {}
+
+func (x *MessageDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *MessageDetails) GetTextualFrames() []string {
+	if x != nil {
+		return x.xxx_hidden_TextualFrames
+	}
+	return nil
+}
+
+func (x *MessageDetails) GetEffectiveContentType() string {
+	if x != nil {
+		if x.xxx_hidden_EffectiveContentType != nil {
+			return *x.xxx_hidden_EffectiveContentType
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *MessageDetails) GetBodySize() int64 {
+	if x != nil {
+		return x.xxx_hidden_BodySize
+	}
+	return 0
+}
+
+func (x *MessageDetails) GetBlobKey() string {
+	if x != nil {
+		if x.xxx_hidden_BlobKey != nil {
+			return *x.xxx_hidden_BlobKey
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *MessageDetails) GetTruncated() bool {
+	if x != nil {
+		return x.xxx_hidden_Truncated
+	}
+	return false
+}
+
+func (x *MessageDetails) GetFrameTimestampsNs() []int64 {
+	if x != nil {
+		return x.xxx_hidden_FrameTimestampsNs
+	}
+	return nil
+}
+
+func (x *MessageDetails) GetSha256() string {
+	if x != nil {
+		if x.xxx_hidden_Sha256 != nil {
+			return *x.xxx_hidden_Sha256
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *MessageDetails) GetSoap() *SoapMessage {
+	if x != nil {
+		return x.xxx_hidden_Soap
+	}
+	return nil
+}
+
+func (x *MessageDetails) GetRecordCount() int32 {
+	if x != nil {
+		return x.xxx_hidden_RecordCount
+	}
+	return 0
+}
+
+func (x *MessageDetails) GetFormFields() []*FormField {
+	if x != nil {
+		if x.xxx_hidden_FormFields != nil {
+			return *x.xxx_hidden_FormFields
+		}
+	}
+	return nil
+}
+
+func (x *MessageDetails) GetMedia() *MediaInfo {
+	if x != nil {
+		return x.xxx_hidden_Media
+	}
+	return nil
+}
+
+func (x *MessageDetails) GetDeclaredContentType() string {
+	if x != nil {
+		if x.xxx_hidden_DeclaredContentType != nil {
+			return *x.xxx_hidden_DeclaredContentType
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *MessageDetails) GetDetectedContentType() string {
+	if x != nil {
+		if x.xxx_hidden_DetectedContentType != nil {
+			return *x.xxx_hidden_DetectedContentType
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *MessageDetails) GetGrpcStatus() *GrpcStatus {
+	if x != nil {
+		return x.xxx_hidden_GrpcStatus
+	}
+	return nil
+}
+
+func (x *MessageDetails) GetFrameCount() int32 {
+	if x != nil {
+		return x.xxx_hidden_FrameCount
+	}
+	return 0
+}
+
+func (x *MessageDetails) GetBinaryMetadata() []*BinaryMetadata {
+	if x != nil {
+		if x.xxx_hidden_BinaryMetadata != nil {
+			return *x.xxx_hidden_BinaryMetadata
+		}
+	}
+	return nil
+}
+
+func (x *MessageDetails) SetTextualFrames(v []string) {
+	x.xxx_hidden_TextualFrames = v
+}
+
+func (x *MessageDetails) SetEffectiveContentType(v string) {
+	x.xxx_hidden_EffectiveContentType = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 16)
+}
+
+func (x *MessageDetails) SetBodySize(v int64) {
+	x.xxx_hidden_BodySize = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 16)
+}
+
+func (x *MessageDetails) SetBlobKey(v string) {
+	x.xxx_hidden_BlobKey = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 16)
+}
+
+func (x *MessageDetails) SetTruncated(v bool) {
+	x.xxx_hidden_Truncated = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 16)
+}
+
+func (x *MessageDetails) SetFrameTimestampsNs(v []int64) {
+	x.xxx_hidden_FrameTimestampsNs = v
+}
+
+func (x *MessageDetails) SetSha256(v string) {
+	x.xxx_hidden_Sha256 = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 16)
 }
 
-func (b0 FlowSummary_builder) Build() *FlowSummary {
-	m0 := &FlowSummary{}
+func (x *MessageDetails) SetSoap(v *SoapMessage) {
+	x.xxx_hidden_Soap = v
+}
+
+func (x *MessageDetails) SetRecordCount(v int32) {
+	x.xxx_hidden_RecordCount = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 16)
+}
+
+func (x *MessageDetails) SetFormFields(v []*FormField) {
+	x.xxx_hidden_FormFields = &v
+}
+
+func (x *MessageDetails) SetMedia(v *MediaInfo) {
+	x.xxx_hidden_Media = v
+}
+
+func (x *MessageDetails) SetDeclaredContentType(v string) {
+	x.xxx_hidden_DeclaredContentType = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 11, 16)
+}
+
+func (x *MessageDetails) SetDetectedContentType(v string) {
+	x.xxx_hidden_DetectedContentType = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 12, 16)
+}
+
+func (x *MessageDetails) SetGrpcStatus(v *GrpcStatus) {
+	x.xxx_hidden_GrpcStatus = v
+}
+
+func (x *MessageDetails) SetFrameCount(v int32) {
+	x.xxx_hidden_FrameCount = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 14, 16)
+}
+
+func (x *MessageDetails) SetBinaryMetadata(v []*BinaryMetadata) {
+	x.xxx_hidden_BinaryMetadata = &v
+}
+
+func (x *MessageDetails) HasEffectiveContentType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *MessageDetails) HasBodySize() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *MessageDetails) HasBlobKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *MessageDetails) HasTruncated() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *MessageDetails) HasSha256() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *MessageDetails) HasSoap() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Soap != nil
+}
+
+func (x *MessageDetails) HasRecordCount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *MessageDetails) HasMedia() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Media != nil
+}
+
+func (x *MessageDetails) HasDeclaredContentType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 11)
+}
+
+func (x *MessageDetails) HasDetectedContentType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 12)
+}
+
+func (x *MessageDetails) HasGrpcStatus() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_GrpcStatus != nil
+}
+
+func (x *MessageDetails) HasFrameCount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 14)
+}
+
+func (x *MessageDetails) ClearEffectiveContentType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_EffectiveContentType = nil
+}
+
+func (x *MessageDetails) ClearBodySize() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_BodySize = 0
+}
+
+func (x *MessageDetails) ClearBlobKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_BlobKey = nil
+}
+
+func (x *MessageDetails) ClearTruncated() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Truncated = false
+}
+
+func (x *MessageDetails) ClearSha256() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Sha256 = nil
+}
+
+func (x *MessageDetails) ClearSoap() {
+	x.xxx_hidden_Soap = nil
+}
+
+func (x *MessageDetails) ClearRecordCount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_RecordCount = 0
+}
+
+func (x *MessageDetails) ClearMedia() {
+	x.xxx_hidden_Media = nil
+}
+
+func (x *MessageDetails) ClearDeclaredContentType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 11)
+	x.xxx_hidden_DeclaredContentType = nil
+}
+
+func (x *MessageDetails) ClearDetectedContentType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 12)
+	x.xxx_hidden_DetectedContentType = nil
+}
+
+func (x *MessageDetails) ClearGrpcStatus() {
+	x.xxx_hidden_GrpcStatus = nil
+}
+
+func (x *MessageDetails) ClearFrameCount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 14)
+	x.xxx_hidden_FrameCount = 0
+}
+
+type MessageDetails_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	TextualFrames        []string
+	EffectiveContentType *string
+	// Size of the body as received, before any truncation or offloading.
+	BodySize *int64
+	// Set when the body was moved out of the flow into the blob store. This is
+	// the hex encoded SHA-256 of the body.
+	BlobKey *string
+	// Set when the body was cut down to the configured maximum body size at ingest.
+	Truncated *bool
+	// When each of textual_frames was received, in Unix nanoseconds, by index.
+	// Only known for streaming bodies that mitmproxy sent incrementally while
+	// the flow was live; frames whose arrival time is unknown are 0.
+	FrameTimestampsNs []int64
+	// The hex encoded SHA-256 of the body as received, before any truncation.
+	// Empty when there is no body.
+	Sha256 *string
+	// Set when the body is a SOAP envelope.
+	Soap *SoapMessage
+	// The number of records in an NDJSON body or a streamed JSON array, when
+	// textual_frames holds one record per frame. Records past the frame limit
+	// are counted but not rendered.
+	RecordCount *int32
+	// The decoded fields of an application/x-www-form-urlencoded body, in the
+	// order they were sent.
+	FormFields []*FormField
+	// Set for image, video and audio responses.
+	Media *MediaInfo
+	// The Content-Type header, lowercased. Empty when there is none.
+	DeclaredContentType *string
+	// The content type sniffed from the body. Which of the declared and
+	// detected types becomes effective_content_type depends on the server's
+	// content type rules.
+	DetectedContentType *string
+	// Set on gRPC and gRPC-Web responses that carry a grpc-status.
+	GrpcStatus *GrpcStatus
+	// The number of textual frames the message has. GetFlow only returns the
+	// first page of frames of a request or response; when this is larger than
+	// len(textual_frames), GetFlowFrames returns the rest.
+	FrameCount *int32
+	// The headers and trailers whose name ends in -bin, decoded, by key.
+	BinaryMetadata []*BinaryMetadata
+}
+
+func (b0 MessageDetails_builder) Build() *MessageDetails {
+	m0 := &MessageDetails{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.Id != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
-		x.xxx_hidden_Id = b.Id
+	x.xxx_hidden_TextualFrames = b.TextualFrames
+	if b.EffectiveContentType != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 16)
+		x.xxx_hidden_EffectiveContentType = b.EffectiveContentType
 	}
-	if b.Type != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
-		x.xxx_hidden_Type = b.Type
+	if b.BodySize != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 16)
+		x.xxx_hidden_BodySize = *b.BodySize
 	}
-	x.xxx_hidden_TimestampStart = b.TimestampStart
-	if b.Pinned != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 6)
-		x.xxx_hidden_Pinned = *b.Pinned
+	if b.BlobKey != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 16)
+		x.xxx_hidden_BlobKey = b.BlobKey
+	}
+	if b.Truncated != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 16)
+		x.xxx_hidden_Truncated = *b.Truncated
+	}
+	x.xxx_hidden_FrameTimestampsNs = b.FrameTimestampsNs
+	if b.Sha256 != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 16)
+		x.xxx_hidden_Sha256 = b.Sha256
+	}
+	x.xxx_hidden_Soap = b.Soap
+	if b.RecordCount != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 16)
+		x.xxx_hidden_RecordCount = *b.RecordCount
+	}
+	x.xxx_hidden_FormFields = &b.FormFields
+	x.xxx_hidden_Media = b.Media
+	if b.DeclaredContentType != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 11, 16)
+		x.xxx_hidden_DeclaredContentType = b.DeclaredContentType
+	}
+	if b.DetectedContentType != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 12, 16)
+		x.xxx_hidden_DetectedContentType = b.DetectedContentType
+	}
+	x.xxx_hidden_GrpcStatus = b.GrpcStatus
+	if b.FrameCount != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 14, 16)
+		x.xxx_hidden_FrameCount = *b.FrameCount
+	}
+	x.xxx_hidden_BinaryMetadata = &b.BinaryMetadata
+	return m0
+}
+
+// A header or trailer whose name ends in -bin. gRPC sends these base64
+// encoded; value holds the bytes whichever way they were captured.
+type BinaryMetadata struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Key         *string                `protobuf:"bytes,1,opt,name=key"`
+	xxx_hidden_Trailer     bool                   `protobuf:"varint,2,opt,name=trailer"`
+	xxx_hidden_Value       []byte                 `protobuf:"bytes,3,opt,name=value"`
+	xxx_hidden_Text        *string                `protobuf:"bytes,4,opt,name=text"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *BinaryMetadata) Reset() {
+	*x = BinaryMetadata{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BinaryMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BinaryMetadata) ProtoMessage() {}
+
+func (x *BinaryMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BinaryMetadata) GetKey() string {
+	if x != nil {
+		if x.xxx_hidden_Key != nil {
+			return *x.xxx_hidden_Key
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BinaryMetadata) GetTrailer() bool {
+	if x != nil {
+		return x.xxx_hidden_Trailer
+	}
+	return false
+}
+
+func (x *BinaryMetadata) GetValue() []byte {
+	if x != nil {
+		return x.xxx_hidden_Value
+	}
+	return nil
+}
+
+func (x *BinaryMetadata) GetText() string {
+	if x != nil {
+		if x.xxx_hidden_Text != nil {
+			return *x.xxx_hidden_Text
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BinaryMetadata) SetKey(v string) {
+	x.xxx_hidden_Key = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *BinaryMetadata) SetTrailer(v bool) {
+	x.xxx_hidden_Trailer = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *BinaryMetadata) SetValue(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Value = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *BinaryMetadata) SetText(v string) {
+	x.xxx_hidden_Text = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *BinaryMetadata) HasKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *BinaryMetadata) HasTrailer() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *BinaryMetadata) HasValue() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *BinaryMetadata) HasText() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *BinaryMetadata) ClearKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Key = nil
+}
+
+func (x *BinaryMetadata) ClearTrailer() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Trailer = false
+}
+
+func (x *BinaryMetadata) ClearValue() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Value = nil
+}
+
+func (x *BinaryMetadata) ClearText() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Text = nil
+}
+
+type BinaryMetadata_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The name, lowercased.
+	Key *string
+	// Set when it was a trailer, including those in the trailer frame of a
+	// gRPC-Web body.
+	Trailer *bool
+	Value   []byte
+	// A readable view of value: the google.rpc.Status as JSON for
+	// grpc-status-details-bin, protoscope when value looks like protobuf, or
+	// a hexdump.
+	Text *string
+}
+
+func (b0 BinaryMetadata_builder) Build() *BinaryMetadata {
+	m0 := &BinaryMetadata{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Key != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Key = b.Key
 	}
-	if b.Note != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
-		x.xxx_hidden_Note = b.Note
+	if b.Trailer != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Trailer = *b.Trailer
 	}
-	if b.Http != nil {
-		x.xxx_hidden_Summary = &flowSummary_Http{b.Http}
+	if b.Value != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Value = b.Value
 	}
-	if b.Dns != nil {
-		x.xxx_hidden_Summary = &flowSummary_Dns{b.Dns}
+	if b.Text != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Text = b.Text
 	}
-	if b.Tcp != nil {
-		x.xxx_hidden_Summary = &flowSummary_Tcp{b.Tcp}
+	return m0
+}
+
+type GrpcStatus struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Code        uint32                 `protobuf:"varint,1,opt,name=code"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,2,opt,name=name"`
+	xxx_hidden_Message     *string                `protobuf:"bytes,3,opt,name=message"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GrpcStatus) Reset() {
+	*x = GrpcStatus{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrpcStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrpcStatus) ProtoMessage() {}
+
+func (x *GrpcStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	if b.Udp != nil {
-		x.xxx_hidden_Summary = &flowSummary_Udp{b.Udp}
+	return mi.MessageOf(x)
+}
+
+func (x *GrpcStatus) GetCode() uint32 {
+	if x != nil {
+		return x.xxx_hidden_Code
 	}
-	return m0
+	return 0
 }
 
-type case_FlowSummary_Summary protoreflect.FieldNumber
+func (x *GrpcStatus) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
 
-func (x case_FlowSummary_Summary) String() string {
-	md := file_mitmflow_v1_mitmflow_proto_msgTypes[14].Descriptor()
-	if x == 0 {
-		return "not set"
+func (x *GrpcStatus) GetMessage() string {
+	if x != nil {
+		if x.xxx_hidden_Message != nil {
+			return *x.xxx_hidden_Message
+		}
+		return ""
 	}
-	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+	return ""
 }
 
-type isFlowSummary_Summary interface {
-	isFlowSummary_Summary()
+func (x *GrpcStatus) SetCode(v uint32) {
+	x.xxx_hidden_Code = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
 }
 
-type flowSummary_Http struct {
-	Http *HttpFlowSummary `protobuf:"bytes,6,opt,name=http,oneof"`
+func (x *GrpcStatus) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
 }
 
-type flowSummary_Dns struct {
-	Dns *DnsFlowSummary `protobuf:"bytes,7,opt,name=dns,oneof"`
+func (x *GrpcStatus) SetMessage(v string) {
+	x.xxx_hidden_Message = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
 }
 
-type flowSummary_Tcp struct {
-	Tcp *TcpFlowSummary `protobuf:"bytes,8,opt,name=tcp,oneof"`
+func (x *GrpcStatus) HasCode() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-type flowSummary_Udp struct {
-	Udp *UdpFlowSummary `protobuf:"bytes,9,opt,name=udp,oneof"`
+func (x *GrpcStatus) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (*flowSummary_Http) isFlowSummary_Summary() {}
+func (x *GrpcStatus) HasMessage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
 
-func (*flowSummary_Dns) isFlowSummary_Summary() {}
+func (x *GrpcStatus) ClearCode() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Code = 0
+}
 
-func (*flowSummary_Tcp) isFlowSummary_Summary() {}
+func (x *GrpcStatus) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Name = nil
+}
 
-func (*flowSummary_Udp) isFlowSummary_Summary() {}
+func (x *GrpcStatus) ClearMessage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Message = nil
+}
 
-type HttpFlowSummary struct {
-	state                            protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Method                *string                `protobuf:"bytes,1,opt,name=method"`
-	xxx_hidden_Url                   *string                `protobuf:"bytes,2,opt,name=url"`
-	xxx_hidden_StatusCode            int32                  `protobuf:"varint,3,opt,name=status_code,json=statusCode"`
-	xxx_hidden_DurationMs            int64                  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs"`
-	xxx_hidden_RequestContentLength  int64                  `protobuf:"varint,5,opt,name=request_content_length,json=requestContentLength"`
-	xxx_hidden_ResponseContentLength int64                  `protobuf:"varint,6,opt,name=response_content_length,json=responseContentLength"`
-	xxx_hidden_ClientPeernameHost    *string                `protobuf:"bytes,7,opt,name=client_peername_host,json=clientPeernameHost"`
-	xxx_hidden_ServerAddressHost     *string                `protobuf:"bytes,8,opt,name=server_address_host,json=serverAddressHost"`
-	xxx_hidden_ServerAddressPort     uint32                 `protobuf:"varint,9,opt,name=server_address_port,json=serverAddressPort"`
-	xxx_hidden_ClientPeernamePort    uint32                 `protobuf:"varint,10,opt,name=client_peername_port,json=clientPeernamePort"`
-	XXX_raceDetectHookData           protoimpl.RaceDetectHookData
-	XXX_presence                     [1]uint32
-	unknownFields                    protoimpl.UnknownFields
-	sizeCache                        protoimpl.SizeCache
+type GrpcStatus_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Code *uint32
+	// The canonical name of the code, e.g. "UNAVAILABLE" for 14.
+	Name *string
+	// The grpc-message, percent-decoded.
+	Message *string
 }
 
-func (x *HttpFlowSummary) Reset() {
-	*x = HttpFlowSummary{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[15]
+func (b0 GrpcStatus_builder) Build() *GrpcStatus {
+	m0 := &GrpcStatus{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Code != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Code = *b.Code
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Message != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Message = b.Message
+	}
+	return m0
+}
+
+// What could be read from an image, video or audio body without playing it.
+type MediaInfo struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Format      *string                `protobuf:"bytes,1,opt,name=format"`
+	xxx_hidden_Width       int32                  `protobuf:"varint,2,opt,name=width"`
+	xxx_hidden_Height      int32                  `protobuf:"varint,3,opt,name=height"`
+	xxx_hidden_DurationMs  int64                  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs"`
+	xxx_hidden_Exif        map[string]string      `protobuf:"bytes,5,rep,name=exif" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_Thumbnail   []byte                 `protobuf:"bytes,6,opt,name=thumbnail"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *MediaInfo) Reset() {
+	*x = MediaInfo{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HttpFlowSummary) String() string {
+func (x *MediaInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HttpFlowSummary) ProtoMessage() {}
+func (*MediaInfo) ProtoMessage() {}
 
-func (x *HttpFlowSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[15]
+func (x *MediaInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1933,346 +14802,501 @@ func (x *HttpFlowSummary) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *HttpFlowSummary) GetMethod() string {
+func (x *MediaInfo) GetFormat() string {
 	if x != nil {
-		if x.xxx_hidden_Method != nil {
-			return *x.xxx_hidden_Method
+		if x.xxx_hidden_Format != nil {
+			return *x.xxx_hidden_Format
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *HttpFlowSummary) GetUrl() string {
+func (x *MediaInfo) GetWidth() int32 {
 	if x != nil {
-		if x.xxx_hidden_Url != nil {
-			return *x.xxx_hidden_Url
-		}
-		return ""
+		return x.xxx_hidden_Width
 	}
-	return ""
+	return 0
 }
 
-func (x *HttpFlowSummary) GetStatusCode() int32 {
+func (x *MediaInfo) GetHeight() int32 {
 	if x != nil {
-		return x.xxx_hidden_StatusCode
+		return x.xxx_hidden_Height
 	}
 	return 0
 }
 
-func (x *HttpFlowSummary) GetDurationMs() int64 {
+func (x *MediaInfo) GetDurationMs() int64 {
 	if x != nil {
 		return x.xxx_hidden_DurationMs
 	}
 	return 0
 }
 
-func (x *HttpFlowSummary) GetRequestContentLength() int64 {
-	if x != nil {
-		return x.xxx_hidden_RequestContentLength
-	}
-	return 0
+func (x *MediaInfo) GetExif() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Exif
+	}
+	return nil
+}
+
+func (x *MediaInfo) GetThumbnail() []byte {
+	if x != nil {
+		return x.xxx_hidden_Thumbnail
+	}
+	return nil
+}
+
+func (x *MediaInfo) SetFormat(v string) {
+	x.xxx_hidden_Format = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+}
+
+func (x *MediaInfo) SetWidth(v int32) {
+	x.xxx_hidden_Width = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+}
+
+func (x *MediaInfo) SetHeight(v int32) {
+	x.xxx_hidden_Height = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
+}
+
+func (x *MediaInfo) SetDurationMs(v int64) {
+	x.xxx_hidden_DurationMs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
+}
+
+func (x *MediaInfo) SetExif(v map[string]string) {
+	x.xxx_hidden_Exif = v
+}
+
+func (x *MediaInfo) SetThumbnail(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Thumbnail = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
+func (x *MediaInfo) HasFormat() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *MediaInfo) HasWidth() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *MediaInfo) HasHeight() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *MediaInfo) HasDurationMs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *MediaInfo) HasThumbnail() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *MediaInfo) ClearFormat() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Format = nil
+}
+
+func (x *MediaInfo) ClearWidth() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Width = 0
+}
+
+func (x *MediaInfo) ClearHeight() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Height = 0
+}
+
+func (x *MediaInfo) ClearDurationMs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_DurationMs = 0
+}
+
+func (x *MediaInfo) ClearThumbnail() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Thumbnail = nil
+}
+
+type MediaInfo_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The image or container format, e.g. "jpeg", "png", "webp", "mp4" or "wav".
+	Format *string
+	// In pixels, when known. For video, the size of the first video track.
+	Width  *int32
+	Height *int32
+	// Of video and audio, when known.
+	DurationMs *int64
+	// Selected EXIF tags of a JPEG by name, e.g. "Model", "DateTimeOriginal" or
+	// "GPSLatitude". Left out when the server was started with -strip-exif.
+	Exif map[string]string
+	// A JPEG at most 128 pixels on its longest side, for JPEG, PNG and GIF
+	// images.
+	Thumbnail []byte
+}
+
+func (b0 MediaInfo_builder) Build() *MediaInfo {
+	m0 := &MediaInfo{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Format != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		x.xxx_hidden_Format = b.Format
+	}
+	if b.Width != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		x.xxx_hidden_Width = *b.Width
+	}
+	if b.Height != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
+		x.xxx_hidden_Height = *b.Height
+	}
+	if b.DurationMs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 6)
+		x.xxx_hidden_DurationMs = *b.DurationMs
+	}
+	x.xxx_hidden_Exif = b.Exif
+	if b.Thumbnail != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_Thumbnail = b.Thumbnail
+	}
+	return m0
+}
+
+type FormField struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Value       *string                `protobuf:"bytes,2,opt,name=value"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *FormField) Reset() {
+	*x = FormField{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FormField) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *HttpFlowSummary) GetResponseContentLength() int64 {
+func (*FormField) ProtoMessage() {}
+
+func (x *FormField) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[92]
 	if x != nil {
-		return x.xxx_hidden_ResponseContentLength
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *HttpFlowSummary) GetClientPeernameHost() string {
+func (x *FormField) GetName() string {
 	if x != nil {
-		if x.xxx_hidden_ClientPeernameHost != nil {
-			return *x.xxx_hidden_ClientPeernameHost
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *HttpFlowSummary) GetServerAddressHost() string {
+func (x *FormField) GetValue() string {
 	if x != nil {
-		if x.xxx_hidden_ServerAddressHost != nil {
-			return *x.xxx_hidden_ServerAddressHost
+		if x.xxx_hidden_Value != nil {
+			return *x.xxx_hidden_Value
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *HttpFlowSummary) GetServerAddressPort() uint32 {
-	if x != nil {
-		return x.xxx_hidden_ServerAddressPort
-	}
-	return 0
+func (x *FormField) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
 }
 
-func (x *HttpFlowSummary) GetClientPeernamePort() uint32 {
-	if x != nil {
-		return x.xxx_hidden_ClientPeernamePort
-	}
-	return 0
+func (x *FormField) SetValue(v string) {
+	x.xxx_hidden_Value = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
 }
 
-func (x *HttpFlowSummary) SetMethod(v string) {
-	x.xxx_hidden_Method = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 10)
+func (x *FormField) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *HttpFlowSummary) SetUrl(v string) {
-	x.xxx_hidden_Url = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 10)
+func (x *FormField) HasValue() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *HttpFlowSummary) SetStatusCode(v int32) {
-	x.xxx_hidden_StatusCode = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 10)
+func (x *FormField) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
 }
 
-func (x *HttpFlowSummary) SetDurationMs(v int64) {
-	x.xxx_hidden_DurationMs = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 10)
+func (x *FormField) ClearValue() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Value = nil
 }
 
-func (x *HttpFlowSummary) SetRequestContentLength(v int64) {
-	x.xxx_hidden_RequestContentLength = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 10)
-}
+type FormField_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-func (x *HttpFlowSummary) SetResponseContentLength(v int64) {
-	x.xxx_hidden_ResponseContentLength = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 10)
+	Name  *string
+	Value *string
 }
 
-func (x *HttpFlowSummary) SetClientPeernameHost(v string) {
-	x.xxx_hidden_ClientPeernameHost = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 10)
+func (b0 FormField_builder) Build() *FormField {
+	m0 := &FormField{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Value != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Value = b.Value
+	}
+	return m0
 }
 
-func (x *HttpFlowSummary) SetServerAddressHost(v string) {
-	x.xxx_hidden_ServerAddressHost = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 10)
+type SoapMessage struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Version     *string                `protobuf:"bytes,1,opt,name=version"`
+	xxx_hidden_Action      *string                `protobuf:"bytes,2,opt,name=action"`
+	xxx_hidden_Operation   *string                `protobuf:"bytes,3,opt,name=operation"`
+	xxx_hidden_Fault       *SoapFault             `protobuf:"bytes,4,opt,name=fault"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *HttpFlowSummary) SetServerAddressPort(v uint32) {
-	x.xxx_hidden_ServerAddressPort = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 10)
+func (x *SoapMessage) Reset() {
+	*x = SoapMessage{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *HttpFlowSummary) SetClientPeernamePort(v uint32) {
-	x.xxx_hidden_ClientPeernamePort = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 10)
+func (x *SoapMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *HttpFlowSummary) HasMethod() bool {
-	if x == nil {
-		return false
+func (*SoapMessage) ProtoMessage() {}
+
+func (x *SoapMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+	return mi.MessageOf(x)
 }
 
-func (x *HttpFlowSummary) HasUrl() bool {
-	if x == nil {
-		return false
+func (x *SoapMessage) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+	return ""
 }
 
-func (x *HttpFlowSummary) HasStatusCode() bool {
-	if x == nil {
-		return false
+func (x *SoapMessage) GetAction() string {
+	if x != nil {
+		if x.xxx_hidden_Action != nil {
+			return *x.xxx_hidden_Action
+		}
+		return ""
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+	return ""
 }
 
-func (x *HttpFlowSummary) HasDurationMs() bool {
-	if x == nil {
-		return false
+func (x *SoapMessage) GetOperation() string {
+	if x != nil {
+		if x.xxx_hidden_Operation != nil {
+			return *x.xxx_hidden_Operation
+		}
+		return ""
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+	return ""
 }
 
-func (x *HttpFlowSummary) HasRequestContentLength() bool {
-	if x == nil {
-		return false
+func (x *SoapMessage) GetFault() *SoapFault {
+	if x != nil {
+		return x.xxx_hidden_Fault
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+	return nil
 }
 
-func (x *HttpFlowSummary) HasResponseContentLength() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+func (x *SoapMessage) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
 }
 
-func (x *HttpFlowSummary) HasClientPeernameHost() bool {
+func (x *SoapMessage) SetAction(v string) {
+	x.xxx_hidden_Action = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *SoapMessage) SetOperation(v string) {
+	x.xxx_hidden_Operation = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *SoapMessage) SetFault(v *SoapFault) {
+	x.xxx_hidden_Fault = v
+}
+
+func (x *SoapMessage) HasVersion() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *HttpFlowSummary) HasServerAddressHost() bool {
+func (x *SoapMessage) HasAction() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *HttpFlowSummary) HasServerAddressPort() bool {
+func (x *SoapMessage) HasOperation() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
 }
 
-func (x *HttpFlowSummary) HasClientPeernamePort() bool {
+func (x *SoapMessage) HasFault() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+	return x.xxx_hidden_Fault != nil
 }
 
-func (x *HttpFlowSummary) ClearMethod() {
+func (x *SoapMessage) ClearVersion() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_Method = nil
+	x.xxx_hidden_Version = nil
 }
 
-func (x *HttpFlowSummary) ClearUrl() {
+func (x *SoapMessage) ClearAction() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_Url = nil
+	x.xxx_hidden_Action = nil
 }
 
-func (x *HttpFlowSummary) ClearStatusCode() {
+func (x *SoapMessage) ClearOperation() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
-	x.xxx_hidden_StatusCode = 0
-}
-
-func (x *HttpFlowSummary) ClearDurationMs() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
-	x.xxx_hidden_DurationMs = 0
-}
-
-func (x *HttpFlowSummary) ClearRequestContentLength() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
-	x.xxx_hidden_RequestContentLength = 0
-}
-
-func (x *HttpFlowSummary) ClearResponseContentLength() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
-	x.xxx_hidden_ResponseContentLength = 0
+	x.xxx_hidden_Operation = nil
 }
 
-func (x *HttpFlowSummary) ClearClientPeernameHost() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
-	x.xxx_hidden_ClientPeernameHost = nil
-}
-
-func (x *HttpFlowSummary) ClearServerAddressHost() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
-	x.xxx_hidden_ServerAddressHost = nil
-}
-
-func (x *HttpFlowSummary) ClearServerAddressPort() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
-	x.xxx_hidden_ServerAddressPort = 0
-}
-
-func (x *HttpFlowSummary) ClearClientPeernamePort() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
-	x.xxx_hidden_ClientPeernamePort = 0
+func (x *SoapMessage) ClearFault() {
+	x.xxx_hidden_Fault = nil
 }
 
-type HttpFlowSummary_builder struct {
+type SoapMessage_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Method                *string
-	Url                   *string
-	StatusCode            *int32
-	DurationMs            *int64
-	RequestContentLength  *int64
-	ResponseContentLength *int64
-	ClientPeernameHost    *string
-	ServerAddressHost     *string
-	ServerAddressPort     *uint32
-	ClientPeernamePort    *uint32
+	// "1.1" or "1.2".
+	Version *string
+	// From the SOAPAction header, or the action parameter of the Content-Type
+	// in SOAP 1.2. Only requests have one.
+	Action *string
+	// The name of the first element in the Body, e.g. "GetQuote", or "Fault".
+	Operation *string
+	// Set when the Body holds a Fault.
+	Fault *SoapFault
 }
 
-func (b0 HttpFlowSummary_builder) Build() *HttpFlowSummary {
-	m0 := &HttpFlowSummary{}
+func (b0 SoapMessage_builder) Build() *SoapMessage {
+	m0 := &SoapMessage{}
 	b, x := &b0, m0
-	_, _ = b, x
-	if b.Method != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 10)
-		x.xxx_hidden_Method = b.Method
-	}
-	if b.Url != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 10)
-		x.xxx_hidden_Url = b.Url
-	}
-	if b.StatusCode != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 10)
-		x.xxx_hidden_StatusCode = *b.StatusCode
-	}
-	if b.DurationMs != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 10)
-		x.xxx_hidden_DurationMs = *b.DurationMs
-	}
-	if b.RequestContentLength != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 10)
-		x.xxx_hidden_RequestContentLength = *b.RequestContentLength
-	}
-	if b.ResponseContentLength != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 10)
-		x.xxx_hidden_ResponseContentLength = *b.ResponseContentLength
-	}
-	if b.ClientPeernameHost != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 10)
-		x.xxx_hidden_ClientPeernameHost = b.ClientPeernameHost
-	}
-	if b.ServerAddressHost != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 10)
-		x.xxx_hidden_ServerAddressHost = b.ServerAddressHost
-	}
-	if b.ServerAddressPort != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 10)
-		x.xxx_hidden_ServerAddressPort = *b.ServerAddressPort
-	}
-	if b.ClientPeernamePort != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 10)
-		x.xxx_hidden_ClientPeernamePort = *b.ClientPeernamePort
-	}
-	return m0
-}
-
-type DnsFlowSummary struct {
-	state                         protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_QuestionName       *string                `protobuf:"bytes,1,opt,name=question_name,json=questionName"`
-	xxx_hidden_ClientPeernameHost *string                `protobuf:"bytes,2,opt,name=client_peername_host,json=clientPeernameHost"`
-	xxx_hidden_Error              *string                `protobuf:"bytes,3,opt,name=error"`
-	XXX_raceDetectHookData        protoimpl.RaceDetectHookData
-	XXX_presence                  [1]uint32
-	unknownFields                 protoimpl.UnknownFields
-	sizeCache                     protoimpl.SizeCache
+	_, _ = b, x
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.Action != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Action = b.Action
+	}
+	if b.Operation != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Operation = b.Operation
+	}
+	x.xxx_hidden_Fault = b.Fault
+	return m0
 }
 
-func (x *DnsFlowSummary) Reset() {
-	*x = DnsFlowSummary{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[16]
+type SoapFault struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Code        *string                `protobuf:"bytes,1,opt,name=code"`
+	xxx_hidden_Reason      *string                `protobuf:"bytes,2,opt,name=reason"`
+	xxx_hidden_Actor       *string                `protobuf:"bytes,3,opt,name=actor"`
+	xxx_hidden_Detail      *string                `protobuf:"bytes,4,opt,name=detail"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SoapFault) Reset() {
+	*x = SoapFault{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DnsFlowSummary) String() string {
+func (x *SoapFault) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DnsFlowSummary) ProtoMessage() {}
+func (*SoapFault) ProtoMessage() {}
 
-func (x *DnsFlowSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[16]
+func (x *SoapFault) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2283,142 +15307,171 @@ func (x *DnsFlowSummary) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *DnsFlowSummary) GetQuestionName() string {
+func (x *SoapFault) GetCode() string {
 	if x != nil {
-		if x.xxx_hidden_QuestionName != nil {
-			return *x.xxx_hidden_QuestionName
+		if x.xxx_hidden_Code != nil {
+			return *x.xxx_hidden_Code
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *DnsFlowSummary) GetClientPeernameHost() string {
+func (x *SoapFault) GetReason() string {
 	if x != nil {
-		if x.xxx_hidden_ClientPeernameHost != nil {
-			return *x.xxx_hidden_ClientPeernameHost
+		if x.xxx_hidden_Reason != nil {
+			return *x.xxx_hidden_Reason
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *DnsFlowSummary) GetError() string {
+func (x *SoapFault) GetActor() string {
 	if x != nil {
-		if x.xxx_hidden_Error != nil {
-			return *x.xxx_hidden_Error
+		if x.xxx_hidden_Actor != nil {
+			return *x.xxx_hidden_Actor
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *DnsFlowSummary) SetQuestionName(v string) {
-	x.xxx_hidden_QuestionName = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+func (x *SoapFault) GetDetail() string {
+	if x != nil {
+		if x.xxx_hidden_Detail != nil {
+			return *x.xxx_hidden_Detail
+		}
+		return ""
+	}
+	return ""
 }
 
-func (x *DnsFlowSummary) SetClientPeernameHost(v string) {
-	x.xxx_hidden_ClientPeernameHost = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+func (x *SoapFault) SetCode(v string) {
+	x.xxx_hidden_Code = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
 }
 
-func (x *DnsFlowSummary) SetError(v string) {
-	x.xxx_hidden_Error = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+func (x *SoapFault) SetReason(v string) {
+	x.xxx_hidden_Reason = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
 }
 
-func (x *DnsFlowSummary) HasQuestionName() bool {
+func (x *SoapFault) SetActor(v string) {
+	x.xxx_hidden_Actor = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *SoapFault) SetDetail(v string) {
+	x.xxx_hidden_Detail = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *SoapFault) HasCode() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *DnsFlowSummary) HasClientPeernameHost() bool {
+func (x *SoapFault) HasReason() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *DnsFlowSummary) HasError() bool {
+func (x *SoapFault) HasActor() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
 }
 
-func (x *DnsFlowSummary) ClearQuestionName() {
+func (x *SoapFault) HasDetail() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *SoapFault) ClearCode() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_QuestionName = nil
+	x.xxx_hidden_Code = nil
 }
 
-func (x *DnsFlowSummary) ClearClientPeernameHost() {
+func (x *SoapFault) ClearReason() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_ClientPeernameHost = nil
+	x.xxx_hidden_Reason = nil
 }
 
-func (x *DnsFlowSummary) ClearError() {
+func (x *SoapFault) ClearActor() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
-	x.xxx_hidden_Error = nil
+	x.xxx_hidden_Actor = nil
 }
 
-type DnsFlowSummary_builder struct {
+func (x *SoapFault) ClearDetail() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Detail = nil
+}
+
+type SoapFault_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	QuestionName       *string
-	ClientPeernameHost *string
-	Error              *string
+	// faultcode in SOAP 1.1, Code/Value in SOAP 1.2.
+	Code *string
+	// faultstring in SOAP 1.1, Reason/Text in SOAP 1.2.
+	Reason *string
+	// faultactor in SOAP 1.1, Node in SOAP 1.2.
+	Actor *string
+	// The text of the detail or Detail element.
+	Detail *string
 }
 
-func (b0 DnsFlowSummary_builder) Build() *DnsFlowSummary {
-	m0 := &DnsFlowSummary{}
+func (b0 SoapFault_builder) Build() *SoapFault {
+	m0 := &SoapFault{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.QuestionName != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
-		x.xxx_hidden_QuestionName = b.QuestionName
+	if b.Code != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Code = b.Code
 	}
-	if b.ClientPeernameHost != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
-		x.xxx_hidden_ClientPeernameHost = b.ClientPeernameHost
+	if b.Reason != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Reason = b.Reason
 	}
-	if b.Error != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
-		x.xxx_hidden_Error = b.Error
+	if b.Actor != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Actor = b.Actor
+	}
+	if b.Detail != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Detail = b.Detail
 	}
 	return m0
 }
 
-type TcpFlowSummary struct {
-	state                         protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_ServerAddressHost  *string                `protobuf:"bytes,1,opt,name=server_address_host,json=serverAddressHost"`
-	xxx_hidden_ServerAddressPort  uint32                 `protobuf:"varint,2,opt,name=server_address_port,json=serverAddressPort"`
-	xxx_hidden_ClientPeernameHost *string                `protobuf:"bytes,3,opt,name=client_peername_host,json=clientPeernameHost"`
-	xxx_hidden_ClientPeernamePort uint32                 `protobuf:"varint,4,opt,name=client_peername_port,json=clientPeernamePort"`
-	xxx_hidden_Error              *string                `protobuf:"bytes,5,opt,name=error"`
-	XXX_raceDetectHookData        protoimpl.RaceDetectHookData
-	XXX_presence                  [1]uint32
-	unknownFields                 protoimpl.UnknownFields
-	sizeCache                     protoimpl.SizeCache
+type GetProxyRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TcpFlowSummary) Reset() {
-	*x = TcpFlowSummary{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[17]
+func (x *GetProxyRulesRequest) Reset() {
+	*x = GetProxyRulesRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TcpFlowSummary) String() string {
+func (x *GetProxyRulesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TcpFlowSummary) ProtoMessage() {}
+func (*GetProxyRulesRequest) ProtoMessage() {}
 
-func (x *TcpFlowSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[17]
+func (x *GetProxyRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2429,200 +15482,385 @@ func (x *TcpFlowSummary) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *TcpFlowSummary) GetServerAddressHost() string {
+type GetProxyRulesRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 GetProxyRulesRequest_builder) Build() *GetProxyRulesRequest {
+	m0 := &GetProxyRulesRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type GetProxyRulesResponse struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Rules *ProxyRules            `protobuf:"bytes,1,opt,name=rules"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetProxyRulesResponse) Reset() {
+	*x = GetProxyRulesResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProxyRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProxyRulesResponse) ProtoMessage() {}
+
+func (x *GetProxyRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[96]
 	if x != nil {
-		if x.xxx_hidden_ServerAddressHost != nil {
-			return *x.xxx_hidden_ServerAddressHost
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		return ""
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *TcpFlowSummary) GetServerAddressPort() uint32 {
+func (x *GetProxyRulesResponse) GetRules() *ProxyRules {
 	if x != nil {
-		return x.xxx_hidden_ServerAddressPort
+		return x.xxx_hidden_Rules
 	}
-	return 0
+	return nil
 }
 
-func (x *TcpFlowSummary) GetClientPeernameHost() string {
+func (x *GetProxyRulesResponse) SetRules(v *ProxyRules) {
+	x.xxx_hidden_Rules = v
+}
+
+func (x *GetProxyRulesResponse) HasRules() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Rules != nil
+}
+
+func (x *GetProxyRulesResponse) ClearRules() {
+	x.xxx_hidden_Rules = nil
+}
+
+type GetProxyRulesResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Rules *ProxyRules
+}
+
+func (b0 GetProxyRulesResponse_builder) Build() *GetProxyRulesResponse {
+	m0 := &GetProxyRulesResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Rules = b.Rules
+	return m0
+}
+
+type SetProxyRulesRequest struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Rules *ProxyRules            `protobuf:"bytes,1,opt,name=rules"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetProxyRulesRequest) Reset() {
+	*x = SetProxyRulesRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProxyRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProxyRulesRequest) ProtoMessage() {}
+
+func (x *SetProxyRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[97]
 	if x != nil {
-		if x.xxx_hidden_ClientPeernameHost != nil {
-			return *x.xxx_hidden_ClientPeernameHost
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		return ""
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *TcpFlowSummary) GetClientPeernamePort() uint32 {
+func (x *SetProxyRulesRequest) GetRules() *ProxyRules {
 	if x != nil {
-		return x.xxx_hidden_ClientPeernamePort
+		return x.xxx_hidden_Rules
 	}
-	return 0
+	return nil
 }
 
-func (x *TcpFlowSummary) GetError() string {
+func (x *SetProxyRulesRequest) SetRules(v *ProxyRules) {
+	x.xxx_hidden_Rules = v
+}
+
+func (x *SetProxyRulesRequest) HasRules() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Rules != nil
+}
+
+func (x *SetProxyRulesRequest) ClearRules() {
+	x.xxx_hidden_Rules = nil
+}
+
+type SetProxyRulesRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Rules *ProxyRules
+}
+
+func (b0 SetProxyRulesRequest_builder) Build() *SetProxyRulesRequest {
+	m0 := &SetProxyRulesRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Rules = b.Rules
+	return m0
+}
+
+type SetProxyRulesResponse struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Rules *ProxyRules            `protobuf:"bytes,1,opt,name=rules"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetProxyRulesResponse) Reset() {
+	*x = SetProxyRulesResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProxyRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProxyRulesResponse) ProtoMessage() {}
+
+func (x *SetProxyRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[98]
 	if x != nil {
-		if x.xxx_hidden_Error != nil {
-			return *x.xxx_hidden_Error
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		return ""
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SetProxyRulesResponse) GetRules() *ProxyRules {
+	if x != nil {
+		return x.xxx_hidden_Rules
+	}
+	return nil
+}
+
+func (x *SetProxyRulesResponse) SetRules(v *ProxyRules) {
+	x.xxx_hidden_Rules = v
+}
+
+func (x *SetProxyRulesResponse) HasRules() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Rules != nil
+}
+
+func (x *SetProxyRulesResponse) ClearRules() {
+	x.xxx_hidden_Rules = nil
+}
+
+type SetProxyRulesResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Rules *ProxyRules
+}
+
+func (b0 SetProxyRulesResponse_builder) Build() *SetProxyRulesResponse {
+	m0 := &SetProxyRulesResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Rules = b.Rules
+	return m0
+}
+
+type WatchProxyRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchProxyRulesRequest) Reset() {
+	*x = WatchProxyRulesRequest{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchProxyRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchProxyRulesRequest) ProtoMessage() {}
+
+func (x *WatchProxyRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *TcpFlowSummary) SetServerAddressHost(v string) {
-	x.xxx_hidden_ServerAddressHost = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+type WatchProxyRulesRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
 }
 
-func (x *TcpFlowSummary) SetServerAddressPort(v uint32) {
-	x.xxx_hidden_ServerAddressPort = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+func (b0 WatchProxyRulesRequest_builder) Build() *WatchProxyRulesRequest {
+	m0 := &WatchProxyRulesRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
 }
 
-func (x *TcpFlowSummary) SetClientPeernameHost(v string) {
-	x.xxx_hidden_ClientPeernameHost = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
+type WatchProxyRulesResponse struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Rules     *ProxyRules            `protobuf:"bytes,1,opt,name=rules"`
+	xxx_hidden_Keepalive *Keepalive             `protobuf:"bytes,2,opt,name=keepalive"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
-func (x *TcpFlowSummary) SetClientPeernamePort(v uint32) {
-	x.xxx_hidden_ClientPeernamePort = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+func (x *WatchProxyRulesResponse) Reset() {
+	*x = WatchProxyRulesResponse{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *TcpFlowSummary) SetError(v string) {
-	x.xxx_hidden_Error = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+func (x *WatchProxyRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *TcpFlowSummary) HasServerAddressHost() bool {
-	if x == nil {
-		return false
+func (*WatchProxyRulesResponse) ProtoMessage() {}
+
+func (x *WatchProxyRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+	return mi.MessageOf(x)
 }
 
-func (x *TcpFlowSummary) HasServerAddressPort() bool {
-	if x == nil {
-		return false
+func (x *WatchProxyRulesResponse) GetRules() *ProxyRules {
+	if x != nil {
+		return x.xxx_hidden_Rules
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+	return nil
 }
 
-func (x *TcpFlowSummary) HasClientPeernameHost() bool {
-	if x == nil {
-		return false
+func (x *WatchProxyRulesResponse) GetKeepalive() *Keepalive {
+	if x != nil {
+		return x.xxx_hidden_Keepalive
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+	return nil
 }
 
-func (x *TcpFlowSummary) HasClientPeernamePort() bool {
+func (x *WatchProxyRulesResponse) SetRules(v *ProxyRules) {
+	x.xxx_hidden_Rules = v
+}
+
+func (x *WatchProxyRulesResponse) SetKeepalive(v *Keepalive) {
+	x.xxx_hidden_Keepalive = v
+}
+
+func (x *WatchProxyRulesResponse) HasRules() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+	return x.xxx_hidden_Rules != nil
 }
 
-func (x *TcpFlowSummary) HasError() bool {
+func (x *WatchProxyRulesResponse) HasKeepalive() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
-}
-
-func (x *TcpFlowSummary) ClearServerAddressHost() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_ServerAddressHost = nil
-}
-
-func (x *TcpFlowSummary) ClearServerAddressPort() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_ServerAddressPort = 0
-}
-
-func (x *TcpFlowSummary) ClearClientPeernameHost() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
-	x.xxx_hidden_ClientPeernameHost = nil
+	return x.xxx_hidden_Keepalive != nil
 }
 
-func (x *TcpFlowSummary) ClearClientPeernamePort() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
-	x.xxx_hidden_ClientPeernamePort = 0
+func (x *WatchProxyRulesResponse) ClearRules() {
+	x.xxx_hidden_Rules = nil
 }
 
-func (x *TcpFlowSummary) ClearError() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
-	x.xxx_hidden_Error = nil
+func (x *WatchProxyRulesResponse) ClearKeepalive() {
+	x.xxx_hidden_Keepalive = nil
 }
 
-type TcpFlowSummary_builder struct {
+type WatchProxyRulesResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	ServerAddressHost  *string
-	ServerAddressPort  *uint32
-	ClientPeernameHost *string
-	ClientPeernamePort *uint32
-	Error              *string
+	// The complete rules, replacing whatever the watcher applied before.
+	Rules *ProxyRules
+	// Sent instead of rules while they don't change.
+	Keepalive *Keepalive
 }
 
-func (b0 TcpFlowSummary_builder) Build() *TcpFlowSummary {
-	m0 := &TcpFlowSummary{}
+func (b0 WatchProxyRulesResponse_builder) Build() *WatchProxyRulesResponse {
+	m0 := &WatchProxyRulesResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.ServerAddressHost != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
-		x.xxx_hidden_ServerAddressHost = b.ServerAddressHost
-	}
-	if b.ServerAddressPort != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
-		x.xxx_hidden_ServerAddressPort = *b.ServerAddressPort
-	}
-	if b.ClientPeernameHost != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
-		x.xxx_hidden_ClientPeernameHost = b.ClientPeernameHost
-	}
-	if b.ClientPeernamePort != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
-		x.xxx_hidden_ClientPeernamePort = *b.ClientPeernamePort
-	}
-	if b.Error != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
-		x.xxx_hidden_Error = b.Error
-	}
+	x.xxx_hidden_Rules = b.Rules
+	x.xxx_hidden_Keepalive = b.Keepalive
 	return m0
 }
 
-type UdpFlowSummary struct {
-	state                         protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_ServerAddressHost  *string                `protobuf:"bytes,1,opt,name=server_address_host,json=serverAddressHost"`
-	xxx_hidden_ServerAddressPort  uint32                 `protobuf:"varint,2,opt,name=server_address_port,json=serverAddressPort"`
-	xxx_hidden_ClientPeernameHost *string                `protobuf:"bytes,3,opt,name=client_peername_host,json=clientPeernameHost"`
-	xxx_hidden_ClientPeernamePort uint32                 `protobuf:"varint,4,opt,name=client_peername_port,json=clientPeernamePort"`
-	xxx_hidden_Error              *string                `protobuf:"bytes,5,opt,name=error"`
-	XXX_raceDetectHookData        protoimpl.RaceDetectHookData
-	XXX_presence                  [1]uint32
-	unknownFields                 protoimpl.UnknownFields
-	sizeCache                     protoimpl.SizeCache
+// ProxyRules are the rules mitmproxy applies to the traffic it proxies. They
+// are kept in the data directory, so they survive restarts.
+type ProxyRules struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Overrides *[]*OverrideRule       `protobuf:"bytes,1,rep,name=overrides"`
+	xxx_hidden_Throttles *[]*ThrottleRule       `protobuf:"bytes,2,rep,name=throttles"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
-func (x *UdpFlowSummary) Reset() {
-	*x = UdpFlowSummary{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[18]
+func (x *ProxyRules) Reset() {
+	*x = ProxyRules{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UdpFlowSummary) String() string {
+func (x *ProxyRules) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UdpFlowSummary) ProtoMessage() {}
+func (*ProxyRules) ProtoMessage() {}
 
-func (x *UdpFlowSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[18]
+func (x *ProxyRules) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2633,199 +15871,214 @@ func (x *UdpFlowSummary) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *UdpFlowSummary) GetServerAddressHost() string {
+func (x *ProxyRules) GetOverrides() []*OverrideRule {
 	if x != nil {
-		if x.xxx_hidden_ServerAddressHost != nil {
-			return *x.xxx_hidden_ServerAddressHost
+		if x.xxx_hidden_Overrides != nil {
+			return *x.xxx_hidden_Overrides
 		}
-		return ""
 	}
-	return ""
+	return nil
 }
 
-func (x *UdpFlowSummary) GetServerAddressPort() uint32 {
+func (x *ProxyRules) GetThrottles() []*ThrottleRule {
 	if x != nil {
-		return x.xxx_hidden_ServerAddressPort
+		if x.xxx_hidden_Throttles != nil {
+			return *x.xxx_hidden_Throttles
+		}
 	}
-	return 0
+	return nil
 }
 
-func (x *UdpFlowSummary) GetClientPeernameHost() string {
+func (x *ProxyRules) SetOverrides(v []*OverrideRule) {
+	x.xxx_hidden_Overrides = &v
+}
+
+func (x *ProxyRules) SetThrottles(v []*ThrottleRule) {
+	x.xxx_hidden_Throttles = &v
+}
+
+type ProxyRules_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Checked in order; the first enabled rule matching a request wins.
+	Overrides []*OverrideRule
+	// Checked in order, separately from the overrides; the first enabled rule
+	// matching a request wins.
+	Throttles []*ThrottleRule
+}
+
+func (b0 ProxyRules_builder) Build() *ProxyRules {
+	m0 := &ProxyRules{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Overrides = &b.Overrides
+	x.xxx_hidden_Throttles = &b.Throttles
+	return m0
+}
+
+// ProxyRuleMatch selects the requests a rule applies to. Empty fields match
+// everything.
+type ProxyRuleMatch struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Host        *string                `protobuf:"bytes,1,opt,name=host"`
+	xxx_hidden_Path        *string                `protobuf:"bytes,2,opt,name=path"`
+	xxx_hidden_Methods     []string               `protobuf:"bytes,3,rep,name=methods"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ProxyRuleMatch) Reset() {
+	*x = ProxyRuleMatch{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProxyRuleMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProxyRuleMatch) ProtoMessage() {}
+
+func (x *ProxyRuleMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[102]
 	if x != nil {
-		if x.xxx_hidden_ClientPeernameHost != nil {
-			return *x.xxx_hidden_ClientPeernameHost
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		return ""
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *UdpFlowSummary) GetClientPeernamePort() uint32 {
+func (x *ProxyRuleMatch) GetHost() string {
 	if x != nil {
-		return x.xxx_hidden_ClientPeernamePort
+		if x.xxx_hidden_Host != nil {
+			return *x.xxx_hidden_Host
+		}
+		return ""
 	}
-	return 0
+	return ""
 }
 
-func (x *UdpFlowSummary) GetError() string {
+func (x *ProxyRuleMatch) GetPath() string {
 	if x != nil {
-		if x.xxx_hidden_Error != nil {
-			return *x.xxx_hidden_Error
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *UdpFlowSummary) SetServerAddressHost(v string) {
-	x.xxx_hidden_ServerAddressHost = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
-}
-
-func (x *UdpFlowSummary) SetServerAddressPort(v uint32) {
-	x.xxx_hidden_ServerAddressPort = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+func (x *ProxyRuleMatch) GetMethods() []string {
+	if x != nil {
+		return x.xxx_hidden_Methods
+	}
+	return nil
 }
 
-func (x *UdpFlowSummary) SetClientPeernameHost(v string) {
-	x.xxx_hidden_ClientPeernameHost = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
+func (x *ProxyRuleMatch) SetHost(v string) {
+	x.xxx_hidden_Host = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
 }
 
-func (x *UdpFlowSummary) SetClientPeernamePort(v uint32) {
-	x.xxx_hidden_ClientPeernamePort = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+func (x *ProxyRuleMatch) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
 }
 
-func (x *UdpFlowSummary) SetError(v string) {
-	x.xxx_hidden_Error = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+func (x *ProxyRuleMatch) SetMethods(v []string) {
+	x.xxx_hidden_Methods = v
 }
 
-func (x *UdpFlowSummary) HasServerAddressHost() bool {
+func (x *ProxyRuleMatch) HasHost() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *UdpFlowSummary) HasServerAddressPort() bool {
+func (x *ProxyRuleMatch) HasPath() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *UdpFlowSummary) HasClientPeernameHost() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
-}
-
-func (x *UdpFlowSummary) HasClientPeernamePort() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
-}
-
-func (x *UdpFlowSummary) HasError() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
-}
-
-func (x *UdpFlowSummary) ClearServerAddressHost() {
+func (x *ProxyRuleMatch) ClearHost() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_ServerAddressHost = nil
+	x.xxx_hidden_Host = nil
 }
 
-func (x *UdpFlowSummary) ClearServerAddressPort() {
+func (x *ProxyRuleMatch) ClearPath() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_ServerAddressPort = 0
-}
-
-func (x *UdpFlowSummary) ClearClientPeernameHost() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
-	x.xxx_hidden_ClientPeernameHost = nil
-}
-
-func (x *UdpFlowSummary) ClearClientPeernamePort() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
-	x.xxx_hidden_ClientPeernamePort = 0
-}
-
-func (x *UdpFlowSummary) ClearError() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
-	x.xxx_hidden_Error = nil
+	x.xxx_hidden_Path = nil
 }
 
-type UdpFlowSummary_builder struct {
+type ProxyRuleMatch_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	ServerAddressHost  *string
-	ServerAddressPort  *uint32
-	ClientPeernameHost *string
-	ClientPeernamePort *uint32
-	Error              *string
+	// The host of the request, e.g. "api.example.com" or "*.example.com".
+	// Matched case-insensitively; * matches any run of characters.
+	Host *string
+	// The path of the request, without the query, e.g. "/v1/users/*". *
+	// matches any run of characters, slashes included.
+	Path    *string
+	Methods []string
 }
 
-func (b0 UdpFlowSummary_builder) Build() *UdpFlowSummary {
-	m0 := &UdpFlowSummary{}
+func (b0 ProxyRuleMatch_builder) Build() *ProxyRuleMatch {
+	m0 := &ProxyRuleMatch{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.ServerAddressHost != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
-		x.xxx_hidden_ServerAddressHost = b.ServerAddressHost
-	}
-	if b.ServerAddressPort != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
-		x.xxx_hidden_ServerAddressPort = *b.ServerAddressPort
-	}
-	if b.ClientPeernameHost != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
-		x.xxx_hidden_ClientPeernameHost = b.ClientPeernameHost
-	}
-	if b.ClientPeernamePort != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
-		x.xxx_hidden_ClientPeernamePort = *b.ClientPeernamePort
+	if b.Host != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Host = b.Host
 	}
-	if b.Error != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
-		x.xxx_hidden_Error = b.Error
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Path = b.Path
 	}
+	x.xxx_hidden_Methods = b.Methods
 	return m0
 }
 
-type Flow struct {
-	state                    protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Flow          isFlow_Flow            `protobuf_oneof:"flow"`
-	xxx_hidden_HttpFlowExtra *HTTPFlowExtra         `protobuf:"bytes,5,opt,name=http_flow_extra,json=httpFlowExtra"`
-	xxx_hidden_Pinned        bool                   `protobuf:"varint,6,opt,name=pinned"`
-	xxx_hidden_Note          *string                `protobuf:"bytes,7,opt,name=note"`
-	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
-	XXX_presence             [1]uint32
-	unknownFields            protoimpl.UnknownFields
-	sizeCache                protoimpl.SizeCache
+// OverrideRule answers matching requests with a local response instead of
+// sending them to the server, e.g. to stub an endpoint that isn't built yet.
+type OverrideRule struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Disabled    bool                   `protobuf:"varint,2,opt,name=disabled"`
+	xxx_hidden_Match       *ProxyRuleMatch        `protobuf:"bytes,3,opt,name=match"`
+	xxx_hidden_StatusCode  int32                  `protobuf:"varint,4,opt,name=status_code,json=statusCode"`
+	xxx_hidden_Headers     map[string]string      `protobuf:"bytes,5,rep,name=headers" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_Body        []byte                 `protobuf:"bytes,6,opt,name=body"`
+	xxx_hidden_File        *string                `protobuf:"bytes,7,opt,name=file"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *Flow) Reset() {
-	*x = Flow{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[19]
+func (x *OverrideRule) Reset() {
+	*x = OverrideRule{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Flow) String() string {
+func (x *OverrideRule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Flow) ProtoMessage() {}
+func (*OverrideRule) ProtoMessage() {}
 
-func (x *Flow) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[19]
+func (x *OverrideRule) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2836,341 +16089,247 @@ func (x *Flow) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *Flow) GetHttpFlow() *v1.HTTPFlow {
+func (x *OverrideRule) GetName() string {
 	if x != nil {
-		if x, ok := x.xxx_hidden_Flow.(*flow_HttpFlow); ok {
-			return x.HttpFlow
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
 		}
+		return ""
 	}
-	return nil
+	return ""
 }
 
-func (x *Flow) GetTcpFlow() *v1.TCPFlow {
+func (x *OverrideRule) GetDisabled() bool {
 	if x != nil {
-		if x, ok := x.xxx_hidden_Flow.(*flow_TcpFlow); ok {
-			return x.TcpFlow
-		}
+		return x.xxx_hidden_Disabled
 	}
-	return nil
+	return false
 }
 
-func (x *Flow) GetUdpFlow() *v1.UDPFlow {
+func (x *OverrideRule) GetMatch() *ProxyRuleMatch {
 	if x != nil {
-		if x, ok := x.xxx_hidden_Flow.(*flow_UdpFlow); ok {
-			return x.UdpFlow
-		}
+		return x.xxx_hidden_Match
 	}
 	return nil
 }
 
-func (x *Flow) GetDnsFlow() *v1.DNSFlow {
+func (x *OverrideRule) GetStatusCode() int32 {
 	if x != nil {
-		if x, ok := x.xxx_hidden_Flow.(*flow_DnsFlow); ok {
-			return x.DnsFlow
-		}
+		return x.xxx_hidden_StatusCode
 	}
-	return nil
+	return 0
 }
 
-func (x *Flow) GetHttpFlowExtra() *HTTPFlowExtra {
+func (x *OverrideRule) GetHeaders() map[string]string {
 	if x != nil {
-		return x.xxx_hidden_HttpFlowExtra
+		return x.xxx_hidden_Headers
 	}
 	return nil
 }
 
-func (x *Flow) GetPinned() bool {
+func (x *OverrideRule) GetBody() []byte {
 	if x != nil {
-		return x.xxx_hidden_Pinned
+		return x.xxx_hidden_Body
 	}
-	return false
+	return nil
 }
 
-func (x *Flow) GetNote() string {
+func (x *OverrideRule) GetFile() string {
 	if x != nil {
-		if x.xxx_hidden_Note != nil {
-			return *x.xxx_hidden_Note
+		if x.xxx_hidden_File != nil {
+			return *x.xxx_hidden_File
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *Flow) SetHttpFlow(v *v1.HTTPFlow) {
-	if v == nil {
-		x.xxx_hidden_Flow = nil
-		return
-	}
-	x.xxx_hidden_Flow = &flow_HttpFlow{v}
-}
-
-func (x *Flow) SetTcpFlow(v *v1.TCPFlow) {
-	if v == nil {
-		x.xxx_hidden_Flow = nil
-		return
-	}
-	x.xxx_hidden_Flow = &flow_TcpFlow{v}
-}
-
-func (x *Flow) SetUdpFlow(v *v1.UDPFlow) {
-	if v == nil {
-		x.xxx_hidden_Flow = nil
-		return
-	}
-	x.xxx_hidden_Flow = &flow_UdpFlow{v}
-}
-
-func (x *Flow) SetDnsFlow(v *v1.DNSFlow) {
-	if v == nil {
-		x.xxx_hidden_Flow = nil
-		return
-	}
-	x.xxx_hidden_Flow = &flow_DnsFlow{v}
-}
-
-func (x *Flow) SetHttpFlowExtra(v *HTTPFlowExtra) {
-	x.xxx_hidden_HttpFlowExtra = v
+func (x *OverrideRule) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
 }
 
-func (x *Flow) SetPinned(v bool) {
-	x.xxx_hidden_Pinned = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+func (x *OverrideRule) SetDisabled(v bool) {
+	x.xxx_hidden_Disabled = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
 }
 
-func (x *Flow) SetNote(v string) {
-	x.xxx_hidden_Note = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+func (x *OverrideRule) SetMatch(v *ProxyRuleMatch) {
+	x.xxx_hidden_Match = v
 }
 
-func (x *Flow) HasFlow() bool {
-	if x == nil {
-		return false
-	}
-	return x.xxx_hidden_Flow != nil
+func (x *OverrideRule) SetStatusCode(v int32) {
+	x.xxx_hidden_StatusCode = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
 }
 
-func (x *Flow) HasHttpFlow() bool {
-	if x == nil {
-		return false
-	}
-	_, ok := x.xxx_hidden_Flow.(*flow_HttpFlow)
-	return ok
+func (x *OverrideRule) SetHeaders(v map[string]string) {
+	x.xxx_hidden_Headers = v
 }
 
-func (x *Flow) HasTcpFlow() bool {
-	if x == nil {
-		return false
+func (x *OverrideRule) SetBody(v []byte) {
+	if v == nil {
+		v = []byte{}
 	}
-	_, ok := x.xxx_hidden_Flow.(*flow_TcpFlow)
-	return ok
+	x.xxx_hidden_Body = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
 }
 
-func (x *Flow) HasUdpFlow() bool {
-	if x == nil {
-		return false
-	}
-	_, ok := x.xxx_hidden_Flow.(*flow_UdpFlow)
-	return ok
+func (x *OverrideRule) SetFile(v string) {
+	x.xxx_hidden_File = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
 }
 
-func (x *Flow) HasDnsFlow() bool {
+func (x *OverrideRule) HasName() bool {
 	if x == nil {
 		return false
 	}
-	_, ok := x.xxx_hidden_Flow.(*flow_DnsFlow)
-	return ok
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *Flow) HasHttpFlowExtra() bool {
+func (x *OverrideRule) HasDisabled() bool {
 	if x == nil {
 		return false
 	}
-	return x.xxx_hidden_HttpFlowExtra != nil
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *Flow) HasPinned() bool {
+func (x *OverrideRule) HasMatch() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+	return x.xxx_hidden_Match != nil
 }
 
-func (x *Flow) HasNote() bool {
+func (x *OverrideRule) HasStatusCode() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
-}
-
-func (x *Flow) ClearFlow() {
-	x.xxx_hidden_Flow = nil
-}
-
-func (x *Flow) ClearHttpFlow() {
-	if _, ok := x.xxx_hidden_Flow.(*flow_HttpFlow); ok {
-		x.xxx_hidden_Flow = nil
-	}
-}
-
-func (x *Flow) ClearTcpFlow() {
-	if _, ok := x.xxx_hidden_Flow.(*flow_TcpFlow); ok {
-		x.xxx_hidden_Flow = nil
-	}
-}
-
-func (x *Flow) ClearUdpFlow() {
-	if _, ok := x.xxx_hidden_Flow.(*flow_UdpFlow); ok {
-		x.xxx_hidden_Flow = nil
-	}
-}
-
-func (x *Flow) ClearDnsFlow() {
-	if _, ok := x.xxx_hidden_Flow.(*flow_DnsFlow); ok {
-		x.xxx_hidden_Flow = nil
-	}
-}
-
-func (x *Flow) ClearHttpFlowExtra() {
-	x.xxx_hidden_HttpFlowExtra = nil
-}
-
-func (x *Flow) ClearPinned() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
-	x.xxx_hidden_Pinned = false
-}
-
-func (x *Flow) ClearNote() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
-	x.xxx_hidden_Note = nil
-}
-
-const Flow_Flow_not_set_case case_Flow_Flow = 0
-const Flow_HttpFlow_case case_Flow_Flow = 1
-const Flow_TcpFlow_case case_Flow_Flow = 2
-const Flow_UdpFlow_case case_Flow_Flow = 3
-const Flow_DnsFlow_case case_Flow_Flow = 4
-
-func (x *Flow) WhichFlow() case_Flow_Flow {
-	if x == nil {
-		return Flow_Flow_not_set_case
-	}
-	switch x.xxx_hidden_Flow.(type) {
-	case *flow_HttpFlow:
-		return Flow_HttpFlow_case
-	case *flow_TcpFlow:
-		return Flow_TcpFlow_case
-	case *flow_UdpFlow:
-		return Flow_UdpFlow_case
-	case *flow_DnsFlow:
-		return Flow_DnsFlow_case
-	default:
-		return Flow_Flow_not_set_case
-	}
-}
-
-type Flow_builder struct {
-	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
-
-	// Fields of oneof xxx_hidden_Flow:
-	HttpFlow *v1.HTTPFlow
-	TcpFlow  *v1.TCPFlow
-	UdpFlow  *v1.UDPFlow
-	DnsFlow  *v1.DNSFlow
-	// -- end of xxx_hidden_Flow
-	HttpFlowExtra *HTTPFlowExtra
-	Pinned        *bool
-	Note          *string
-}
-
-func (b0 Flow_builder) Build() *Flow {
-	m0 := &Flow{}
-	b, x := &b0, m0
-	_, _ = b, x
-	if b.HttpFlow != nil {
-		x.xxx_hidden_Flow = &flow_HttpFlow{b.HttpFlow}
-	}
-	if b.TcpFlow != nil {
-		x.xxx_hidden_Flow = &flow_TcpFlow{b.TcpFlow}
-	}
-	if b.UdpFlow != nil {
-		x.xxx_hidden_Flow = &flow_UdpFlow{b.UdpFlow}
-	}
-	if b.DnsFlow != nil {
-		x.xxx_hidden_Flow = &flow_DnsFlow{b.DnsFlow}
-	}
-	x.xxx_hidden_HttpFlowExtra = b.HttpFlowExtra
-	if b.Pinned != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
-		x.xxx_hidden_Pinned = *b.Pinned
-	}
-	if b.Note != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
-		x.xxx_hidden_Note = b.Note
-	}
-	return m0
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
 }
 
-type case_Flow_Flow protoreflect.FieldNumber
-
-func (x case_Flow_Flow) String() string {
-	md := file_mitmflow_v1_mitmflow_proto_msgTypes[19].Descriptor()
-	if x == 0 {
-		return "not set"
+func (x *OverrideRule) HasBody() bool {
+	if x == nil {
+		return false
 	}
-	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
 }
 
-type isFlow_Flow interface {
-	isFlow_Flow()
+func (x *OverrideRule) HasFile() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
 }
 
-type flow_HttpFlow struct {
-	HttpFlow *v1.HTTPFlow `protobuf:"bytes,1,opt,name=http_flow,json=httpFlow,oneof"`
+func (x *OverrideRule) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
 }
 
-type flow_TcpFlow struct {
-	TcpFlow *v1.TCPFlow `protobuf:"bytes,2,opt,name=tcp_flow,json=tcpFlow,oneof"`
+func (x *OverrideRule) ClearDisabled() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Disabled = false
 }
 
-type flow_UdpFlow struct {
-	UdpFlow *v1.UDPFlow `protobuf:"bytes,3,opt,name=udp_flow,json=udpFlow,oneof"`
+func (x *OverrideRule) ClearMatch() {
+	x.xxx_hidden_Match = nil
 }
 
-type flow_DnsFlow struct {
-	DnsFlow *v1.DNSFlow `protobuf:"bytes,4,opt,name=dns_flow,json=dnsFlow,oneof"`
+func (x *OverrideRule) ClearStatusCode() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_StatusCode = 0
 }
 
-func (*flow_HttpFlow) isFlow_Flow() {}
-
-func (*flow_TcpFlow) isFlow_Flow() {}
+func (x *OverrideRule) ClearBody() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Body = nil
+}
 
-func (*flow_UdpFlow) isFlow_Flow() {}
+func (x *OverrideRule) ClearFile() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_File = nil
+}
 
-func (*flow_DnsFlow) isFlow_Flow() {}
+type OverrideRule_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-type HTTPFlowExtra struct {
-	state               protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Request  *MessageDetails        `protobuf:"bytes,1,opt,name=request"`
-	xxx_hidden_Response *MessageDetails        `protobuf:"bytes,2,opt,name=response"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	// Unique among the overrides.
+	Name *string
+	// Keeps the rule without applying it.
+	Disabled *bool
+	Match    *ProxyRuleMatch
+	// The status code of the response. Defaults to 200.
+	StatusCode *int32
+	Headers    map[string]string
+	// The body of the response. Mutually exclusive with file.
+	Body []byte
+	// A file on the host mitmproxy runs on to serve the body from, read on
+	// every request so edits show up right away.
+	File *string
+}
+
+func (b0 OverrideRule_builder) Build() *OverrideRule {
+	m0 := &OverrideRule{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Disabled != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_Disabled = *b.Disabled
+	}
+	x.xxx_hidden_Match = b.Match
+	if b.StatusCode != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		x.xxx_hidden_StatusCode = *b.StatusCode
+	}
+	x.xxx_hidden_Headers = b.Headers
+	if b.Body != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_Body = b.Body
+	}
+	if b.File != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_File = b.File
+	}
+	return m0
 }
 
-func (x *HTTPFlowExtra) Reset() {
-	*x = HTTPFlowExtra{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[20]
+// ThrottleRule slows matching flows down, e.g. to see how an app copes with a
+// bad mobile network.
+type ThrottleRule struct {
+	state                             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name                   *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Disabled               bool                   `protobuf:"varint,2,opt,name=disabled"`
+	xxx_hidden_Match                  *ProxyRuleMatch        `protobuf:"bytes,3,opt,name=match"`
+	xxx_hidden_RequestDelayMs         int32                  `protobuf:"varint,4,opt,name=request_delay_ms,json=requestDelayMs"`
+	xxx_hidden_ResponseDelayMs        int32                  `protobuf:"varint,5,opt,name=response_delay_ms,json=responseDelayMs"`
+	xxx_hidden_UploadBytesPerSecond   int64                  `protobuf:"varint,6,opt,name=upload_bytes_per_second,json=uploadBytesPerSecond"`
+	xxx_hidden_DownloadBytesPerSecond int64                  `protobuf:"varint,7,opt,name=download_bytes_per_second,json=downloadBytesPerSecond"`
+	XXX_raceDetectHookData            protoimpl.RaceDetectHookData
+	XXX_presence                      [1]uint32
+	unknownFields                     protoimpl.UnknownFields
+	sizeCache                         protoimpl.SizeCache
+}
+
+func (x *ThrottleRule) Reset() {
+	*x = ThrottleRule{}
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HTTPFlowExtra) String() string {
+func (x *ThrottleRule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HTTPFlowExtra) ProtoMessage() {}
+func (*ThrottleRule) ProtoMessage() {}
 
-func (x *HTTPFlowExtra) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[20]
+func (x *ThrottleRule) ProtoReflect() protoreflect.Message {
+	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3181,184 +16340,223 @@ func (x *HTTPFlowExtra) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *HTTPFlowExtra) GetRequest() *MessageDetails {
+func (x *ThrottleRule) GetName() string {
 	if x != nil {
-		return x.xxx_hidden_Request
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
 	}
-	return nil
+	return ""
 }
 
-func (x *HTTPFlowExtra) GetResponse() *MessageDetails {
+func (x *ThrottleRule) GetDisabled() bool {
 	if x != nil {
-		return x.xxx_hidden_Response
+		return x.xxx_hidden_Disabled
 	}
-	return nil
+	return false
 }
 
-func (x *HTTPFlowExtra) SetRequest(v *MessageDetails) {
-	x.xxx_hidden_Request = v
+func (x *ThrottleRule) GetMatch() *ProxyRuleMatch {
+	if x != nil {
+		return x.xxx_hidden_Match
+	}
+	return nil
 }
 
-func (x *HTTPFlowExtra) SetResponse(v *MessageDetails) {
-	x.xxx_hidden_Response = v
+func (x *ThrottleRule) GetRequestDelayMs() int32 {
+	if x != nil {
+		return x.xxx_hidden_RequestDelayMs
+	}
+	return 0
 }
 
-func (x *HTTPFlowExtra) HasRequest() bool {
-	if x == nil {
-		return false
+func (x *ThrottleRule) GetResponseDelayMs() int32 {
+	if x != nil {
+		return x.xxx_hidden_ResponseDelayMs
 	}
-	return x.xxx_hidden_Request != nil
+	return 0
 }
 
-func (x *HTTPFlowExtra) HasResponse() bool {
-	if x == nil {
-		return false
+func (x *ThrottleRule) GetUploadBytesPerSecond() int64 {
+	if x != nil {
+		return x.xxx_hidden_UploadBytesPerSecond
 	}
-	return x.xxx_hidden_Response != nil
+	return 0
 }
 
-func (x *HTTPFlowExtra) ClearRequest() {
-	x.xxx_hidden_Request = nil
+func (x *ThrottleRule) GetDownloadBytesPerSecond() int64 {
+	if x != nil {
+		return x.xxx_hidden_DownloadBytesPerSecond
+	}
+	return 0
 }
 
-func (x *HTTPFlowExtra) ClearResponse() {
-	x.xxx_hidden_Response = nil
+func (x *ThrottleRule) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
 }
 
-type HTTPFlowExtra_builder struct {
-	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
-
-	Request  *MessageDetails
-	Response *MessageDetails
+func (x *ThrottleRule) SetDisabled(v bool) {
+	x.xxx_hidden_Disabled = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
 }
 
-func (b0 HTTPFlowExtra_builder) Build() *HTTPFlowExtra {
-	m0 := &HTTPFlowExtra{}
-	b, x := &b0, m0
-	_, _ = b, x
-	x.xxx_hidden_Request = b.Request
-	x.xxx_hidden_Response = b.Response
-	return m0
+func (x *ThrottleRule) SetMatch(v *ProxyRuleMatch) {
+	x.xxx_hidden_Match = v
 }
 
-type MessageDetails struct {
-	state                           protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_TextualFrames        []string               `protobuf:"bytes,1,rep,name=textual_frames,json=textualFrames"`
-	xxx_hidden_EffectiveContentType *string                `protobuf:"bytes,2,opt,name=effective_content_type,json=effectiveContentType"`
-	xxx_hidden_BodySize             int64                  `protobuf:"varint,3,opt,name=body_size,json=bodySize"`
-	XXX_raceDetectHookData          protoimpl.RaceDetectHookData
-	XXX_presence                    [1]uint32
-	unknownFields                   protoimpl.UnknownFields
-	sizeCache                       protoimpl.SizeCache
+func (x *ThrottleRule) SetRequestDelayMs(v int32) {
+	x.xxx_hidden_RequestDelayMs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
 }
 
-func (x *MessageDetails) Reset() {
-	*x = MessageDetails{}
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[21]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ThrottleRule) SetResponseDelayMs(v int32) {
+	x.xxx_hidden_ResponseDelayMs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
 }
 
-func (x *MessageDetails) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ThrottleRule) SetUploadBytesPerSecond(v int64) {
+	x.xxx_hidden_UploadBytesPerSecond = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
 }
 
-func (*MessageDetails) ProtoMessage() {}
-
-func (x *MessageDetails) ProtoReflect() protoreflect.Message {
-	mi := &file_mitmflow_v1_mitmflow_proto_msgTypes[21]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+func (x *ThrottleRule) SetDownloadBytesPerSecond(v int64) {
+	x.xxx_hidden_DownloadBytesPerSecond = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
 }
 
-func (x *MessageDetails) GetTextualFrames() []string {
-	if x != nil {
-		return x.xxx_hidden_TextualFrames
+func (x *ThrottleRule) HasName() bool {
+	if x == nil {
+		return false
 	}
-	return nil
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *MessageDetails) GetEffectiveContentType() string {
-	if x != nil {
-		if x.xxx_hidden_EffectiveContentType != nil {
-			return *x.xxx_hidden_EffectiveContentType
-		}
-		return ""
+func (x *ThrottleRule) HasDisabled() bool {
+	if x == nil {
+		return false
 	}
-	return ""
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *MessageDetails) GetBodySize() int64 {
-	if x != nil {
-		return x.xxx_hidden_BodySize
+func (x *ThrottleRule) HasMatch() bool {
+	if x == nil {
+		return false
 	}
-	return 0
-}
-
-func (x *MessageDetails) SetTextualFrames(v []string) {
-	x.xxx_hidden_TextualFrames = v
+	return x.xxx_hidden_Match != nil
 }
 
-func (x *MessageDetails) SetEffectiveContentType(v string) {
-	x.xxx_hidden_EffectiveContentType = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+func (x *ThrottleRule) HasRequestDelayMs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
 }
 
-func (x *MessageDetails) SetBodySize(v int64) {
-	x.xxx_hidden_BodySize = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+func (x *ThrottleRule) HasResponseDelayMs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
 }
 
-func (x *MessageDetails) HasEffectiveContentType() bool {
+func (x *ThrottleRule) HasUploadBytesPerSecond() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
 }
 
-func (x *MessageDetails) HasBodySize() bool {
+func (x *ThrottleRule) HasDownloadBytesPerSecond() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
 }
 
-func (x *MessageDetails) ClearEffectiveContentType() {
+func (x *ThrottleRule) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *ThrottleRule) ClearDisabled() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_EffectiveContentType = nil
+	x.xxx_hidden_Disabled = false
 }
 
-func (x *MessageDetails) ClearBodySize() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
-	x.xxx_hidden_BodySize = 0
+func (x *ThrottleRule) ClearMatch() {
+	x.xxx_hidden_Match = nil
 }
 
-type MessageDetails_builder struct {
-	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+func (x *ThrottleRule) ClearRequestDelayMs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_RequestDelayMs = 0
+}
 
-	TextualFrames        []string
-	EffectiveContentType *string
-	BodySize             *int64
+func (x *ThrottleRule) ClearResponseDelayMs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_ResponseDelayMs = 0
 }
 
-func (b0 MessageDetails_builder) Build() *MessageDetails {
-	m0 := &MessageDetails{}
+func (x *ThrottleRule) ClearUploadBytesPerSecond() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_UploadBytesPerSecond = 0
+}
+
+func (x *ThrottleRule) ClearDownloadBytesPerSecond() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_DownloadBytesPerSecond = 0
+}
+
+type ThrottleRule_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Unique among the throttles.
+	Name *string
+	// Keeps the rule without applying it.
+	Disabled *bool
+	Match    *ProxyRuleMatch
+	// Added before the request is sent to the server, and again before the
+	// response is sent to the client.
+	RequestDelayMs  *int32
+	ResponseDelayMs *int32
+	// Caps how fast the request body is sent upstream, in bytes per second,
+	// or 0 for no limit.
+	UploadBytesPerSecond *int64
+	// Caps how fast the response body is sent to the client, in bytes per
+	// second, or 0 for no limit.
+	DownloadBytesPerSecond *int64
+}
+
+func (b0 ThrottleRule_builder) Build() *ThrottleRule {
+	m0 := &ThrottleRule{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_TextualFrames = b.TextualFrames
-	if b.EffectiveContentType != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
-		x.xxx_hidden_EffectiveContentType = b.EffectiveContentType
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		x.xxx_hidden_Name = b.Name
 	}
-	if b.BodySize != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
-		x.xxx_hidden_BodySize = *b.BodySize
+	if b.Disabled != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_Disabled = *b.Disabled
+	}
+	x.xxx_hidden_Match = b.Match
+	if b.RequestDelayMs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		x.xxx_hidden_RequestDelayMs = *b.RequestDelayMs
+	}
+	if b.ResponseDelayMs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
+		x.xxx_hidden_ResponseDelayMs = *b.ResponseDelayMs
+	}
+	if b.UploadBytesPerSecond != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_UploadBytesPerSecond = *b.UploadBytesPerSecond
+	}
+	if b.DownloadBytesPerSecond != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_DownloadBytesPerSecond = *b.DownloadBytesPerSecond
 	}
 	return m0
 }
@@ -3367,7 +16565,7 @@ var File_mitmflow_v1_mitmflow_proto protoreflect.FileDescriptor
 
 const file_mitmflow_v1_mitmflow_proto_rawDesc = "" +
 	"\n" +
-	"\x1amitmflow/v1/mitmflow.proto\x12\vmitmflow.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1emitmproxygrpc/v1/service.proto\"\xaa\x02\n" +
+	"\x1amitmflow/v1/mitmflow.proto\x12\vmitmflow.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1emitmproxygrpc/v1/service.proto\"\xcc\x03\n" +
 	"\n" +
 	"FlowFilter\x12&\n" +
 	"\vfilter_text\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x01R\n" +
@@ -3379,45 +16577,324 @@ const file_mitmflow_v1_mitmflow_proto_rawDesc = "" +
 	"\n" +
 	"client_ips\x18\x05 \x03(\tB\f\xbaH\t\x92\x01\x06\"\x04r\x02p\x01R\tclientIps\x12+\n" +
 	"\x04http\x18\x06 \x01(\v2\x17.mitmflow.v1.HttpFilterR\x04http\x12\x19\n" +
-	"\bflow_ids\x18\a \x03(\tR\aflowIds\"\x86\x01\n" +
+	"\bflow_ids\x18\a \x03(\tR\aflowIds\x12D\n" +
+	"\x10server_countries\x18\b \x03(\tB\x19\xbaH\x16\x92\x01\x13\"\x11r\x0f2\r^[A-Za-z]{2}$R\x0fserverCountries\x12<\n" +
+	"\vdns_anomaly\x18\t \x03(\x0e2\x1b.mitmflow.v1.DnsAnomalyKindR\n" +
+	"dnsAnomaly\x12\x1c\n" +
+	"\tprotocols\x18\n" +
+	" \x03(\tR\tprotocols\"\x9f\x03\n" +
 	"\n" +
 	"HttpFilter\x120\n" +
 	"\amethods\x18\x01 \x03(\tB\x16\xbaH\x13\x92\x01\x10\"\x0er\f\x18\x142\b^[A-Z]+$R\amethods\x12#\n" +
 	"\rcontent_types\x18\x02 \x03(\tR\fcontentTypes\x12!\n" +
-	"\fstatus_codes\x18\x03 \x03(\tR\vstatusCodes\")\n" +
+	"\fstatus_codes\x18\x03 \x03(\tR\vstatusCodes\x12'\n" +
+	"\x0fclient_families\x18\x04 \x03(\tR\x0eclientFamilies\x12P\n" +
+	"\x15min_security_severity\x18\x05 \x01(\x0e2\x1c.mitmflow.v1.FindingSeverityR\x13minSecuritySeverity\x12<\n" +
+	"\vbody_sha256\x18\x06 \x01(\tB\x1b\xbaH\x18r\x162\x14^([0-9a-fA-F]{64})?$R\n" +
+	"bodySha256\x129\n" +
+	"\fbody_queries\x18\a \x03(\v2\x16.mitmflow.v1.BodyQueryR\vbodyQueries\x12#\n" +
+	"\rgrpc_statuses\x18\b \x03(\tR\fgrpcStatuses\"f\n" +
+	"\tBodyQuery\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\xbaH\tr\a\x10\x012\x03^\\$R\x04path\x12\x1d\n" +
+	"\x06equals\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x01R\x06equals\x12\x18\n" +
+	"\arequest\x18\x03 \x01(\bR\arequest\")\n" +
 	"\x0eGetFlowRequest\x12\x17\n" +
 	"\aflow_id\x18\x01 \x01(\tR\x06flowId\"8\n" +
 	"\x0fGetFlowResponse\x12%\n" +
-	"\x04flow\x18\x01 \x01(\v2\x11.mitmflow.v1.FlowR\x04flow\"X\n" +
+	"\x04flow\x18\x01 \x01(\v2\x11.mitmflow.v1.FlowR\x04flow\"I\n" +
+	"\x12GetFlowBodyRequest\x12\x17\n" +
+	"\aflow_id\x18\x01 \x01(\tR\x06flowId\x12\x1a\n" +
+	"\bresponse\x18\x02 \x01(\bR\bresponse\"R\n" +
+	"\x13GetFlowBodyResponse\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\fR\acontent\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\"\x97\x01\n" +
+	"\x14GetFlowFramesRequest\x12 \n" +
+	"\aflow_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06flowId\x12\x1a\n" +
+	"\bresponse\x18\x02 \x01(\bR\bresponse\x12\x1f\n" +
+	"\x06offset\x18\x03 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x06offset\x12 \n" +
+	"\x05limit\x18\x04 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x00R\x05limit\"u\n" +
+	"\x15GetFlowFramesResponse\x12\x16\n" +
+	"\x06frames\x18\x01 \x03(\tR\x06frames\x12.\n" +
+	"\x13frame_timestamps_ns\x18\x02 \x03(\x03R\x11frameTimestampsNs\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\"X\n" +
 	"\x0fGetFlowsRequest\x12/\n" +
 	"\x06filter\x18\x01 \x01(\v2\x17.mitmflow.v1.FlowFilterR\x06filter\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\"@\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\\\n" +
 	"\x10GetFlowsResponse\x12,\n" +
-	"\x04flow\x18\x01 \x01(\v2\x18.mitmflow.v1.FlowSummaryR\x04flow\"s\n" +
+	"\x04flow\x18\x01 \x01(\v2\x18.mitmflow.v1.FlowSummaryR\x04flow\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x04R\bsequence\"\x9a\x01\n" +
 	"\x12StreamFlowsRequest\x12,\n" +
 	"\x12since_timestamp_ns\x18\x01 \x01(\x03R\x10sinceTimestampNs\x12/\n" +
-	"\x06filter\x18\x02 \x01(\v2\x17.mitmflow.v1.FlowFilterR\x06filter\"Q\n" +
+	"\x06filter\x18\x02 \x01(\v2\x17.mitmflow.v1.FlowFilterR\x06filter\x12%\n" +
+	"\x0esince_sequence\x18\x03 \x01(\x04R\rsinceSequence\"\x89\x01\n" +
 	"\x13StreamFlowsResponse\x12.\n" +
-	"\x04flow\x18\x01 \x01(\v2\x18.mitmflow.v1.FlowSummaryH\x00R\x04flowB\n" +
+	"\x04flow\x18\x01 \x01(\v2\x18.mitmflow.v1.FlowSummaryH\x00R\x04flow\x126\n" +
+	"\tkeepalive\x18\x02 \x01(\v2\x16.mitmflow.v1.KeepaliveH\x00R\tkeepaliveB\n" +
 	"\n" +
-	"\bresponse\"f\n" +
+	"\bresponse\",\n" +
+	"\tKeepalive\x12\x1f\n" +
+	"\vinterval_ms\x18\x01 \x01(\x03R\n" +
+	"intervalMs\"\xb7\x02\n" +
 	"\x11UpdateFlowRequest\x12\x17\n" +
 	"\aflow_id\x18\x01 \x01(\tR\x06flowId\x12\x1d\n" +
 	"\x06pinned\x18\x02 \x01(\bB\x05\xaa\x01\x02\b\x01R\x06pinned\x12\x19\n" +
-	"\x04note\x18\x03 \x01(\tB\x05\xaa\x01\x02\b\x01R\x04note\"B\n" +
+	"\x04note\x18\x03 \x01(\tB\x05\xaa\x01\x02\b\x01R\x04note\x12Y\n" +
+	"\bmetadata\x18\x04 \x03(\v2,.mitmflow.v1.UpdateFlowRequest.MetadataEntryB\x0f\xbaH\f\x9a\x01\t\"\ar\x05\x10\x01\x18\x80\x01R\bmetadata\x12\x16\n" +
+	"\x06shared\x18\x05 \x01(\bR\x06shared\x12\x1f\n" +
+	"\aprivate\x18\x06 \x01(\bB\x05\xaa\x01\x02\b\x01R\aprivate\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"B\n" +
 	"\x12UpdateFlowResponse\x12,\n" +
 	"\x04flow\x18\x01 \x01(\v2\x18.mitmflow.v1.FlowSummaryR\x04flow\"A\n" +
 	"\x12DeleteFlowsRequest\x12\x19\n" +
 	"\bflow_ids\x18\x01 \x03(\tR\aflowIds\x12\x10\n" +
-	"\x03all\x18\x02 \x01(\bR\x03all\"+\n" +
+	"\x03all\x18\x02 \x01(\bR\x03all\"f\n" +
 	"\x13DeleteFlowsResponse\x12\x14\n" +
-	"\x05count\x18\x01 \x01(\x03R\x05count\"b\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\x12\x19\n" +
+	"\bflow_ids\x18\x02 \x03(\tR\aflowIds\x12\x1e\n" +
+	"\n" +
+	"restorable\x18\x03 \x01(\bR\n" +
+	"restorable\"\xa1\x03\n" +
 	"\x12ExportFlowsRequest\x12\x19\n" +
 	"\bflow_ids\x18\x01 \x03(\tR\aflowIds\x121\n" +
-	"\x06format\x18\x02 \x01(\x0e2\x19.mitmflow.v1.ExportFormatR\x06format\"E\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x19.mitmflow.v1.ExportFormatR\x06format\x12\x1c\n" +
+	"\tanonymize\x18\x03 \x01(\bR\tanonymize\x12)\n" +
+	"\x10shift_timestamps\x18\x04 \x01(\bR\x0fshiftTimestamps\x120\n" +
+	"\x05epoch\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x05epoch\x12/\n" +
+	"\x06filter\x18\x06 \x01(\v2\x17.mitmflow.v1.FlowFilterR\x06filter\x12\x1f\n" +
+	"\vfilter_expr\x18\a \x01(\tR\n" +
+	"filterExpr\x129\n" +
+	"\n" +
+	"start_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"E\n" +
 	"\x13ExportFlowsResponse\x12\x12\n" +
 	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1a\n" +
-	"\bfilename\x18\x02 \x01(\tR\bfilename\"\xf4\x02\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\"(\n" +
+	"\x12ImportFlowsRequest\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"+\n" +
+	"\x13ImportFlowsResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\"T\n" +
+	"\x18CreateShareBundleRequest\x12 \n" +
+	"\aflow_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06flowId\x12\x16\n" +
+	"\x06redact\x18\x02 \x01(\bR\x06redact\"O\n" +
+	"\x19CreateShareBundleResponse\x12\x16\n" +
+	"\x06bundle\x18\x01 \x01(\tR\x06bundle\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\"\x9b\x01\n" +
+	"\x0fSessionSelector\x12\x16\n" +
+	"\x06filter\x18\x01 \x01(\tR\x06filter\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"L\n" +
+	"\x12SetBaselineRequest\x126\n" +
+	"\asession\x18\x01 \x01(\v2\x1c.mitmflow.v1.SessionSelectorR\asession\"+\n" +
+	"\x13SetBaselineResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\"P\n" +
+	"\x16CompareSessionsRequest\x126\n" +
+	"\asession\x18\x01 \x01(\v2\x1c.mitmflow.v1.SessionSelectorR\asession\"\xaa\x01\n" +
+	"\x17CompareSessionsResponse\x12A\n" +
+	"\vregressions\x18\x01 \x03(\v2\x1f.mitmflow.v1.BaselineComparisonR\vregressions\x12#\n" +
+	"\rmatched_count\x18\x02 \x01(\x03R\fmatchedCount\x12'\n" +
+	"\x0funmatched_count\x18\x03 \x01(\x03R\x0eunmatchedCount\"\xc6\x01\n" +
+	"\x12BaselineComparison\x12\x17\n" +
+	"\aflow_id\x18\x01 \x01(\tR\x06flowId\x12(\n" +
+	"\x10baseline_flow_id\x18\x02 \x01(\tR\x0ebaselineFlowId\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x12A\n" +
+	"\vdifferences\x18\x05 \x03(\v2\x1f.mitmflow.v1.BaselineDifferenceR\vdifferences\"\x97\x01\n" +
+	"\x12BaselineDifference\x127\n" +
+	"\x04kind\x18\x01 \x01(\x0e2#.mitmflow.v1.BaselineDifferenceKindR\x04kind\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x1a\n" +
+	"\bbaseline\x18\x03 \x01(\tR\bbaseline\x12\x16\n" +
+	"\x06actual\x18\x04 \x01(\tR\x06actual\")\n" +
+	"\x13CreateBackupRequest\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\"_\n" +
+	"\x14CreateBackupResponse\x12\x14\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1d\n" +
+	"\n" +
+	"flow_count\x18\x03 \x01(\x03R\tflowCount\"f\n" +
+	"\x14RestoreBackupRequest\x12\x14\n" +
+	"\x04data\x18\x01 \x01(\fH\x00R\x04data\x12\x14\n" +
+	"\x04path\x18\x02 \x01(\tH\x00R\x04path\x12\x18\n" +
+	"\areplace\x18\x03 \x01(\bR\areplaceB\b\n" +
+	"\x06source\"-\n" +
+	"\x15RestoreBackupResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\"]\n" +
+	"\x14SearchArchiveRequest\x12/\n" +
+	"\x06filter\x18\x01 \x01(\v2\x17.mitmflow.v1.FlowFilterR\x06filter\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"E\n" +
+	"\x15SearchArchiveResponse\x12,\n" +
+	"\x04flow\x18\x01 \x01(\v2\x18.mitmflow.v1.FlowSummaryR\x04flow\"J\n" +
+	"\x1bRestoreArchivedFlowsRequest\x12\x19\n" +
+	"\bflow_ids\x18\x01 \x03(\tR\aflowIds\x12\x10\n" +
+	"\x03pin\x18\x02 \x01(\bR\x03pin\"N\n" +
+	"\x1cRestoreArchivedFlowsResponse\x12.\n" +
+	"\x05flows\x18\x01 \x03(\v2\x18.mitmflow.v1.FlowSummaryR\x05flows\"0\n" +
+	"\x13RestoreFlowsRequest\x12\x19\n" +
+	"\bflow_ids\x18\x01 \x03(\tR\aflowIds\"F\n" +
+	"\x14RestoreFlowsResponse\x12.\n" +
+	"\x05flows\x18\x01 \x03(\v2\x18.mitmflow.v1.FlowSummaryR\x05flows\"k\n" +
+	"\x15UploadFlowBodyRequest\x12 \n" +
+	"\aflow_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06flowId\x12\x1a\n" +
+	"\bresponse\x18\x02 \x01(\bR\bresponse\x12\x14\n" +
+	"\x05chunk\x18\x03 \x01(\fR\x05chunk\"F\n" +
+	"\x16UploadFlowBodyResponse\x12\x16\n" +
+	"\x06bodies\x18\x01 \x01(\x03R\x06bodies\x12\x14\n" +
+	"\x05bytes\x18\x02 \x01(\x03R\x05bytes\"\xe1\x01\n" +
+	"\n" +
+	"AuditEvent\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\x12\x12\n" +
+	"\x04peer\x18\x03 \x01(\tR\x04peer\x120\n" +
+	"\x06action\x18\x04 \x01(\x0e2\x18.mitmflow.v1.AuditActionR\x06action\x12\x19\n" +
+	"\bflow_ids\x18\x05 \x03(\tR\aflowIds\x12\x14\n" +
+	"\x05count\x18\x06 \x01(\x03R\x05count\x12\x16\n" +
+	"\x06detail\x18\a \x01(\tR\x06detail\"\xb6\x01\n" +
+	"\x16ListAuditEventsRequest\x12 \n" +
+	"\x05limit\x18\x01 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\x90N(\x00R\x05limit\x122\n" +
+	"\aactions\x18\x02 \x03(\x0e2\x18.mitmflow.v1.AuditActionR\aactions\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x120\n" +
+	"\x05since\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"J\n" +
+	"\x17ListAuditEventsResponse\x12/\n" +
+	"\x06events\x18\x01 \x03(\v2\x17.mitmflow.v1.AuditEventR\x06events\"\x18\n" +
+	"\x16ListSubscribersRequest\"T\n" +
+	"\x17ListSubscribersResponse\x129\n" +
+	"\vsubscribers\x18\x01 \x03(\v2\x17.mitmflow.v1.SubscriberR\vsubscribers\"\xd8\x01\n" +
+	"\n" +
+	"Subscriber\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12=\n" +
+	"\fconnect_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vconnectTime\x12\x12\n" +
+	"\x04peer\x18\x03 \x01(\tR\x04peer\x12/\n" +
+	"\x06filter\x18\x04 \x01(\v2\x17.mitmflow.v1.FlowFilterR\x06filter\x12\x1c\n" +
+	"\tdelivered\x18\x05 \x01(\x03R\tdelivered\x12\x18\n" +
+	"\adropped\x18\x06 \x01(\x03R\adropped\"\x16\n" +
+	"\x14GetServerInfoRequest\"\xd3\x06\n" +
+	"\x15GetServerInfoResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x02 \x01(\tR\tgoVersion\x12!\n" +
+	"\fvcs_revision\x18\x03 \x01(\tR\vvcsRevision\x125\n" +
+	"\bvcs_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\avcsTime\x129\n" +
+	"\n" +
+	"start_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x12\x1b\n" +
+	"\tuptime_ms\x18\x06 \x01(\x03R\buptimeMs\x12\x1b\n" +
+	"\tmax_flows\x18\a \x01(\x05R\bmaxFlows\x12$\n" +
+	"\x0emax_body_bytes\x18\b \x01(\x03R\fmaxBodyBytes\x12%\n" +
+	"\x0eblob_threshold\x18\t \x01(\x03R\rblobThreshold\x12\x19\n" +
+	"\bdata_dir\x18\n" +
+	" \x01(\tR\adataDir\x12\x1f\n" +
+	"\varchive_dir\x18\v \x01(\tR\n" +
+	"archiveDir\x12\x1d\n" +
+	"\n" +
+	"backup_dir\x18\f \x01(\tR\tbackupDir\x12\x1d\n" +
+	"\n" +
+	"flow_count\x18\r \x01(\x03R\tflowCount\x12S\n" +
+	"\vflow_counts\x18\x0e \x03(\v22.mitmflow.v1.GetServerInfoResponse.FlowCountsEntryR\n" +
+	"flowCounts\x122\n" +
+	"\x15descriptor_file_count\x18\x0f \x01(\x05R\x13descriptorFileCount\x12)\n" +
+	"\x10subscriber_count\x18\x10 \x01(\x05R\x0fsubscriberCount\x127\n" +
+	"\x18max_ingest_message_bytes\x18\x11 \x01(\x03R\x15maxIngestMessageBytes\x12?\n" +
+	"\x1cstream_keepalive_interval_ms\x18\x12 \x01(\x03R\x19streamKeepaliveIntervalMs\x1a=\n" +
+	"\x0fFlowCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\xb1\x02\n" +
+	"\x12SendRequestRequest\x12)\n" +
+	"\x06method\x18\x01 \x01(\tB\x11\xbaH\x0er\f\x18\x142\b^[A-Z]*$R\x06method\x12\x1a\n" +
+	"\x03url\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x88\x01\x01R\x03url\x12F\n" +
+	"\aheaders\x18\x03 \x03(\v2,.mitmflow.v1.SendRequestRequest.HeadersEntryR\aheaders\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\fR\x04body\x12\x14\n" +
+	"\x05proxy\x18\x05 \x01(\tR\x05proxy\x12&\n" +
+	"\n" +
+	"timeout_ms\x18\x06 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\ttimeoutMs\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"<\n" +
+	"\x13SendRequestResponse\x12%\n" +
+	"\x04flow\x18\x01 \x01(\v2\x11.mitmflow.v1.FlowR\x04flow\"O\n" +
+	"\x18GetCookieTimelineRequest\x12\x1b\n" +
+	"\x04name\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04name\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\"M\n" +
+	"\x19GetCookieTimelineResponse\x120\n" +
+	"\x06events\x18\x01 \x03(\v2\x18.mitmflow.v1.CookieEventR\x06events\"\xbc\x03\n" +
+	"\vCookieEvent\x120\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1c.mitmflow.v1.CookieEventTypeR\x04type\x12\x17\n" +
+	"\aflow_id\x18\x02 \x01(\tR\x06flowId\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x12\n" +
+	"\x04host\x18\x04 \x01(\tR\x04host\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\tR\x05value\x12\x16\n" +
+	"\x06domain\x18\x06 \x01(\tR\x06domain\x12\x12\n" +
+	"\x04path\x18\a \x01(\tR\x04path\x124\n" +
+	"\aexpires\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\aexpires\x12\x16\n" +
+	"\x06secure\x18\t \x01(\bR\x06secure\x12\x1b\n" +
+	"\thttp_only\x18\n" +
+	" \x01(\bR\bhttpOnly\x12\x1b\n" +
+	"\tsame_site\x18\v \x01(\tR\bsameSite\x12#\n" +
+	"\rvalue_changed\x18\f \x01(\bR\fvalueChanged\x12%\n" +
+	"\x0eexpiry_changed\x18\r \x01(\bR\rexpiryChanged\";\n" +
+	"\x17GetRedirectChainRequest\x12 \n" +
+	"\aflow_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06flowId\"H\n" +
+	"\x18GetRedirectChainResponse\x12,\n" +
+	"\x04hops\x18\x01 \x03(\v2\x18.mitmflow.v1.RedirectHopR\x04hops\"\x8d\x01\n" +
+	"\vRedirectHop\x12\x17\n" +
+	"\aflow_id\x18\x01 \x01(\tR\x06flowId\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x1f\n" +
+	"\vstatus_code\x18\x04 \x01(\x05R\n" +
+	"statusCode\x12\x1a\n" +
+	"\blocation\x18\x05 \x01(\tR\blocation\";\n" +
+	"\x17DiffWithPreviousRequest\x12 \n" +
+	"\aflow_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06flowId\"\xc0\x01\n" +
+	"\x18DiffWithPreviousResponse\x124\n" +
+	"\bprevious\x18\x01 \x01(\v2\x18.mitmflow.v1.FlowSummaryR\bprevious\x125\n" +
+	"\arequest\x18\x02 \x03(\v2\x1b.mitmflow.v1.FlowDifferenceR\arequest\x127\n" +
+	"\bresponse\x18\x03 \x03(\v2\x1b.mitmflow.v1.FlowDifferenceR\bresponse\"\xc3\x01\n" +
+	"\x0fTopFlowsRequest\x12/\n" +
+	"\x06filter\x18\x01 \x01(\v2\x17.mitmflow.v1.FlowFilterR\x06filter\x12\x1f\n" +
+	"\vfilter_expr\x18\x02 \x01(\tR\n" +
+	"filterExpr\x12<\n" +
+	"\x05order\x18\x03 \x01(\x0e2\x1a.mitmflow.v1.TopFlowsOrderB\n" +
+	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\x05order\x12 \n" +
+	"\x05limit\x18\x04 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x00R\x05limit\"B\n" +
+	"\x10TopFlowsResponse\x12.\n" +
+	"\x05flows\x18\x01 \x03(\v2\x18.mitmflow.v1.FlowSummaryR\x05flows\"\xb4\x02\n" +
+	"\x18GetBandwidthStatsRequest\x12/\n" +
+	"\x06filter\x18\x01 \x01(\v2\x17.mitmflow.v1.FlowFilterR\x06filter\x12\x1f\n" +
+	"\vfilter_expr\x18\x02 \x01(\tR\n" +
+	"filterExpr\x129\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x122\n" +
+	"\x0ebucket_seconds\x18\x05 \x01(\x05B\v\xbaH\b\x1a\x06\x18\x80\xa3\x05(\x00R\rbucketSeconds\x12 \n" +
+	"\x05limit\x18\x06 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x00R\x05limit\"\xb8\x01\n" +
+	"\x19GetBandwidthStatsResponse\x121\n" +
+	"\x05hosts\x18\x01 \x03(\v2\x1b.mitmflow.v1.BandwidthUsageR\x05hosts\x125\n" +
+	"\aclients\x18\x02 \x03(\v2\x1b.mitmflow.v1.BandwidthUsageR\aclients\x121\n" +
+	"\x05total\x18\x03 \x01(\v2\x1b.mitmflow.v1.BandwidthUsageR\x05total\"\xb6\x01\n" +
+	"\x0eBandwidthUsage\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\x02 \x01(\x03R\tbytesSent\x12%\n" +
+	"\x0ebytes_received\x18\x03 \x01(\x03R\rbytesReceived\x12\x14\n" +
+	"\x05flows\x18\x04 \x01(\x03R\x05flows\x126\n" +
+	"\abuckets\x18\x05 \x03(\v2\x1c.mitmflow.v1.BandwidthBucketR\abuckets\"\x9f\x01\n" +
+	"\x0fBandwidthBucket\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\x02 \x01(\x03R\tbytesSent\x12%\n" +
+	"\x0ebytes_received\x18\x03 \x01(\x03R\rbytesReceived\x12\x14\n" +
+	"\x05flows\x18\x04 \x01(\x03R\x05flows\"\x91\x01\n" +
+	"\x0eFlowDifference\x123\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x1f.mitmflow.v1.FlowDifferenceKindR\x04kind\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x1a\n" +
+	"\bprevious\x18\x03 \x01(\tR\bprevious\x12\x18\n" +
+	"\acurrent\x18\x04 \x01(\tR\acurrent\"2\n" +
+	"\aFlowSet\x12'\n" +
+	"\x05flows\x18\x01 \x03(\v2\x11.mitmflow.v1.FlowR\x05flows\"\x8d\x04\n" +
 	"\vFlowSummary\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12C\n" +
@@ -3427,8 +16904,14 @@ const file_mitmflow_v1_mitmflow_proto_rawDesc = "" +
 	"\x04http\x18\x06 \x01(\v2\x1c.mitmflow.v1.HttpFlowSummaryH\x00R\x04http\x12/\n" +
 	"\x03dns\x18\a \x01(\v2\x1b.mitmflow.v1.DnsFlowSummaryH\x00R\x03dns\x12/\n" +
 	"\x03tcp\x18\b \x01(\v2\x1b.mitmflow.v1.TcpFlowSummaryH\x00R\x03tcp\x12/\n" +
-	"\x03udp\x18\t \x01(\v2\x1b.mitmflow.v1.UdpFlowSummaryH\x00R\x03udpB\t\n" +
-	"\asummary\"\xaf\x03\n" +
+	"\x03udp\x18\t \x01(\v2\x1b.mitmflow.v1.UdpFlowSummaryH\x00R\x03udp\x12\x1a\n" +
+	"\bsequence\x18\n" +
+	" \x01(\x04R\bsequence\x12\x14\n" +
+	"\x05owner\x18\v \x01(\tR\x05owner\x12\x18\n" +
+	"\aprivate\x18\f \x01(\bR\aprivate\x12\x1a\n" +
+	"\bprotocol\x18\r \x01(\tR\bprotocol\x12/\n" +
+	"\x06totals\x18\x0e \x01(\v2\x17.mitmflow.v1.FlowTotalsR\x06totalsB\t\n" +
+	"\asummary\"\xd8\x03\n" +
 	"\x0fHttpFlowSummary\x12\x16\n" +
 	"\x06method\x18\x01 \x01(\tR\x06method\x12\x10\n" +
 	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1f\n" +
@@ -3442,7 +16925,8 @@ const file_mitmflow_v1_mitmflow_proto_rawDesc = "" +
 	"\x13server_address_host\x18\b \x01(\tR\x11serverAddressHost\x12.\n" +
 	"\x13server_address_port\x18\t \x01(\rR\x11serverAddressPort\x120\n" +
 	"\x14client_peername_port\x18\n" +
-	" \x01(\rR\x12clientPeernamePort\"}\n" +
+	" \x01(\rR\x12clientPeernamePort\x12'\n" +
+	"\x0fresponse_sha256\x18\v \x01(\tR\x0eresponseSha256\"}\n" +
 	"\x0eDnsFlowSummary\x12#\n" +
 	"\rquestion_name\x18\x01 \x01(\tR\fquestionName\x120\n" +
 	"\x14client_peername_host\x18\x02 \x01(\tR\x12clientPeernameHost\x12\x14\n" +
@@ -3458,7 +16942,7 @@ const file_mitmflow_v1_mitmflow_proto_rawDesc = "" +
 	"\x13server_address_port\x18\x02 \x01(\rR\x11serverAddressPort\x120\n" +
 	"\x14client_peername_host\x18\x03 \x01(\tR\x12clientPeernameHost\x120\n" +
 	"\x14client_peername_port\x18\x04 \x01(\rR\x12clientPeernamePort\x12\x14\n" +
-	"\x05error\x18\x05 \x01(\tR\x05error\"\xd1\x02\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\xb6\x06\n" +
 	"\x04Flow\x125\n" +
 	"\thttp_flow\x18\x01 \x01(\v2\x16.mitmproxy.v1.HTTPFlowH\x00R\bhttpFlow\x122\n" +
 	"\btcp_flow\x18\x02 \x01(\v2\x15.mitmproxy.v1.TCPFlowH\x00R\atcpFlow\x122\n" +
@@ -3466,19 +16950,270 @@ const file_mitmflow_v1_mitmflow_proto_rawDesc = "" +
 	"\bdns_flow\x18\x04 \x01(\v2\x15.mitmproxy.v1.DNSFlowH\x00R\adnsFlow\x12B\n" +
 	"\x0fhttp_flow_extra\x18\x05 \x01(\v2\x1a.mitmflow.v1.HTTPFlowExtraR\rhttpFlowExtra\x12\x16\n" +
 	"\x06pinned\x18\x06 \x01(\bR\x06pinned\x12\x12\n" +
-	"\x04note\x18\a \x01(\tR\x04noteB\x06\n" +
-	"\x04flow\"\x7f\n" +
+	"\x04note\x18\a \x01(\tR\x04note\x12H\n" +
+	"\x11stream_flow_extra\x18\b \x01(\v2\x1c.mitmflow.v1.StreamFlowExtraR\x0fstreamFlowExtra\x12;\n" +
+	"\bmetadata\x18\t \x03(\v2\x1f.mitmflow.v1.Flow.MetadataEntryR\bmetadata\x12Q\n" +
+	"\x10user_annotations\x18\n" +
+	" \x03(\v2&.mitmflow.v1.Flow.UserAnnotationsEntryR\x0fuserAnnotations\x12\x14\n" +
+	"\x05owner\x18\v \x01(\tR\x05owner\x12\x18\n" +
+	"\aprivate\x18\f \x01(\bR\aprivate\x12?\n" +
+	"\x0edns_flow_extra\x18\r \x01(\v2\x19.mitmflow.v1.DnsFlowExtraR\fdnsFlowExtra\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a[\n" +
+	"\x14UserAnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12-\n" +
+	"\x05value\x18\x02 \x01(\v2\x17.mitmflow.v1.AnnotationR\x05value:\x028\x01B\x06\n" +
+	"\x04flow\"8\n" +
+	"\n" +
+	"Annotation\x12\x16\n" +
+	"\x06pinned\x18\x01 \x01(\bR\x06pinned\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\"\xda\x06\n" +
 	"\rHTTPFlowExtra\x125\n" +
 	"\arequest\x18\x01 \x01(\v2\x1b.mitmflow.v1.MessageDetailsR\arequest\x127\n" +
-	"\bresponse\x18\x02 \x01(\v2\x1b.mitmflow.v1.MessageDetailsR\bresponse\"\x8a\x01\n" +
+	"\bresponse\x18\x02 \x01(\v2\x1b.mitmflow.v1.MessageDetailsR\bresponse\x125\n" +
+	"\n" +
+	"user_agent\x18\x03 \x01(\v2\x16.mitmflow.v1.UserAgentR\tuserAgent\x123\n" +
+	"\n" +
+	"server_geo\x18\x04 \x01(\v2\x14.mitmflow.v1.GeoInfoR\tserverGeo\x12'\n" +
+	"\x0fserver_hostname\x18\x05 \x01(\tR\x0eserverHostname\x12Q\n" +
+	"\x16server_hostname_source\x18\x06 \x01(\x0e2\x1b.mitmflow.v1.HostnameSourceR\x14serverHostnameSource\x12I\n" +
+	"\x11security_findings\x18\a \x03(\v2\x1c.mitmflow.v1.SecurityFindingR\x10securityFindings\x12*\n" +
+	"\x04cors\x18\b \x01(\v2\x16.mitmflow.v1.CorsCheckR\x04cors\x12;\n" +
+	"\bbaseline\x18\t \x01(\v2\x1f.mitmflow.v1.BaselineComparisonR\bbaseline\x12(\n" +
+	"\x10manifest_flow_id\x18\n" +
+	" \x01(\tR\x0emanifestFlowId\x12J\n" +
+	"\x12websocket_messages\x18\v \x03(\v2\x1b.mitmflow.v1.MessageDetailsR\x11websocketMessages\x12/\n" +
+	"\x05http2\x18\f \x01(\v2\x19.mitmflow.v1.HTTP2DetailsR\x05http2\x12I\n" +
+	"\x11interim_responses\x18\r \x03(\v2\x1c.mitmflow.v1.InterimResponseR\x10interimResponses\x12\x1a\n" +
+	"\bprotocol\x18\x0e \x01(\tR\bprotocol\x12/\n" +
+	"\x06totals\x18\x0f \x01(\v2\x17.mitmflow.v1.FlowTotalsR\x06totals\"y\n" +
+	"\n" +
+	"FlowTotals\x12#\n" +
+	"\rrequest_bytes\x18\x01 \x01(\x03R\frequestBytes\x12%\n" +
+	"\x0eresponse_bytes\x18\x02 \x01(\x03R\rresponseBytes\x12\x1f\n" +
+	"\vduration_ms\x18\x03 \x01(\x03R\n" +
+	"durationMs\"\xed\x01\n" +
+	"\x0fInterimResponse\x12\x1f\n" +
+	"\vstatus_code\x18\x01 \x01(\x05R\n" +
+	"statusCode\x12C\n" +
+	"\aheaders\x18\x02 \x03(\v2).mitmflow.v1.InterimResponse.HeadersEntryR\aheaders\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x96\x02\n" +
+	"\fHTTP2Details\x12N\n" +
+	"\x16request_pseudo_headers\x18\x01 \x03(\v2\x18.mitmflow.v1.HeaderFieldR\x14requestPseudoHeaders\x12P\n" +
+	"\x17response_pseudo_headers\x18\x02 \x03(\v2\x18.mitmflow.v1.HeaderFieldR\x15responsePseudoHeaders\x120\n" +
+	"\x14request_header_order\x18\x03 \x03(\tR\x12requestHeaderOrder\x122\n" +
+	"\x15response_header_order\x18\x04 \x03(\tR\x13responseHeaderOrder\"7\n" +
+	"\vHeaderField\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\x89\x01\n" +
+	"\tCorsCheck\x12\x16\n" +
+	"\x06origin\x18\x01 \x01(\tR\x06origin\x12\x1c\n" +
+	"\tpreflight\x18\x02 \x01(\bR\tpreflight\x12*\n" +
+	"\x11preflight_flow_id\x18\x03 \x01(\tR\x0fpreflightFlowId\x12\x1a\n" +
+	"\bproblems\x18\x04 \x03(\tR\bproblems\"}\n" +
+	"\x0fSecurityFinding\x12\x16\n" +
+	"\x06header\x18\x01 \x01(\tR\x06header\x128\n" +
+	"\bseverity\x18\x02 \x01(\x0e2\x1c.mitmflow.v1.FindingSeverityR\bseverity\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x8a\x01\n" +
+	"\aGeoInfo\x12!\n" +
+	"\fcountry_code\x18\x01 \x01(\tR\vcountryCode\x12!\n" +
+	"\fcountry_name\x18\x02 \x01(\tR\vcountryName\x12\x10\n" +
+	"\x03asn\x18\x03 \x01(\rR\x03asn\x12'\n" +
+	"\x0fas_organization\x18\x04 \x01(\tR\x0easOrganization\"\xcf\x01\n" +
+	"\tUserAgent\x12\x18\n" +
+	"\abrowser\x18\x01 \x01(\tR\abrowser\x12'\n" +
+	"\x0fbrowser_version\x18\x02 \x01(\tR\x0ebrowserVersion\x12\x0e\n" +
+	"\x02os\x18\x03 \x01(\tR\x02os\x12\x1d\n" +
+	"\n" +
+	"os_version\x18\x04 \x01(\tR\tosVersion\x12\x16\n" +
+	"\x06device\x18\x05 \x01(\tR\x06device\x128\n" +
+	"\vdevice_type\x18\x06 \x01(\x0e2\x17.mitmflow.v1.DeviceTypeR\n" +
+	"deviceType\"\xc8\x02\n" +
+	"\x0fStreamFlowExtra\x127\n" +
+	"\bmessages\x18\x01 \x03(\v2\x1b.mitmflow.v1.MessageDetailsR\bmessages\x123\n" +
+	"\n" +
+	"server_geo\x18\x02 \x01(\v2\x14.mitmflow.v1.GeoInfoR\tserverGeo\x12'\n" +
+	"\x0fserver_hostname\x18\x03 \x01(\tR\x0eserverHostname\x12Q\n" +
+	"\x16server_hostname_source\x18\x04 \x01(\x0e2\x1b.mitmflow.v1.HostnameSourceR\x14serverHostnameSource\x12\x1a\n" +
+	"\bprotocol\x18\x05 \x01(\tR\bprotocol\x12/\n" +
+	"\x06totals\x18\x06 \x01(\v2\x17.mitmflow.v1.FlowTotalsR\x06totals\"\x92\x01\n" +
+	"\fDnsFlowExtra\x125\n" +
+	"\tanomalies\x18\x01 \x03(\v2\x17.mitmflow.v1.DnsAnomalyR\tanomalies\x12\x1a\n" +
+	"\bprotocol\x18\x02 \x01(\tR\bprotocol\x12/\n" +
+	"\x06totals\x18\x03 \x01(\v2\x17.mitmflow.v1.FlowTotalsR\x06totals\"U\n" +
+	"\n" +
+	"DnsAnomaly\x12/\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x1b.mitmflow.v1.DnsAnomalyKindR\x04kind\x12\x16\n" +
+	"\x06detail\x18\x02 \x01(\tR\x06detail\"\xcc\x05\n" +
 	"\x0eMessageDetails\x12%\n" +
 	"\x0etextual_frames\x18\x01 \x03(\tR\rtextualFrames\x124\n" +
 	"\x16effective_content_type\x18\x02 \x01(\tR\x14effectiveContentType\x12\x1b\n" +
-	"\tbody_size\x18\x03 \x01(\x03R\bbodySize*\\\n" +
+	"\tbody_size\x18\x03 \x01(\x03R\bbodySize\x12\x19\n" +
+	"\bblob_key\x18\x04 \x01(\tR\ablobKey\x12\x1c\n" +
+	"\ttruncated\x18\x05 \x01(\bR\ttruncated\x12.\n" +
+	"\x13frame_timestamps_ns\x18\x06 \x03(\x03R\x11frameTimestampsNs\x12\x16\n" +
+	"\x06sha256\x18\a \x01(\tR\x06sha256\x12,\n" +
+	"\x04soap\x18\b \x01(\v2\x18.mitmflow.v1.SoapMessageR\x04soap\x12!\n" +
+	"\frecord_count\x18\t \x01(\x05R\vrecordCount\x127\n" +
+	"\vform_fields\x18\n" +
+	" \x03(\v2\x16.mitmflow.v1.FormFieldR\n" +
+	"formFields\x12,\n" +
+	"\x05media\x18\v \x01(\v2\x16.mitmflow.v1.MediaInfoR\x05media\x122\n" +
+	"\x15declared_content_type\x18\f \x01(\tR\x13declaredContentType\x122\n" +
+	"\x15detected_content_type\x18\r \x01(\tR\x13detectedContentType\x128\n" +
+	"\vgrpc_status\x18\x0e \x01(\v2\x17.mitmflow.v1.GrpcStatusR\n" +
+	"grpcStatus\x12\x1f\n" +
+	"\vframe_count\x18\x0f \x01(\x05R\n" +
+	"frameCount\x12D\n" +
+	"\x0fbinary_metadata\x18\x10 \x03(\v2\x1b.mitmflow.v1.BinaryMetadataR\x0ebinaryMetadata\"f\n" +
+	"\x0eBinaryMetadata\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x18\n" +
+	"\atrailer\x18\x02 \x01(\bR\atrailer\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\fR\x05value\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\"N\n" +
+	"\n" +
+	"GrpcStatus\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\rR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xff\x01\n" +
+	"\tMediaInfo\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x03R\n" +
+	"durationMs\x124\n" +
+	"\x04exif\x18\x05 \x03(\v2 .mitmflow.v1.MediaInfo.ExifEntryR\x04exif\x12\x1c\n" +
+	"\tthumbnail\x18\x06 \x01(\fR\tthumbnail\x1a7\n" +
+	"\tExifEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"5\n" +
+	"\tFormField\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\x8b\x01\n" +
+	"\vSoapMessage\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x1c\n" +
+	"\toperation\x18\x03 \x01(\tR\toperation\x12,\n" +
+	"\x05fault\x18\x04 \x01(\v2\x16.mitmflow.v1.SoapFaultR\x05fault\"e\n" +
+	"\tSoapFault\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06detail\x18\x04 \x01(\tR\x06detail\"\x16\n" +
+	"\x14GetProxyRulesRequest\"F\n" +
+	"\x15GetProxyRulesResponse\x12-\n" +
+	"\x05rules\x18\x01 \x01(\v2\x17.mitmflow.v1.ProxyRulesR\x05rules\"E\n" +
+	"\x14SetProxyRulesRequest\x12-\n" +
+	"\x05rules\x18\x01 \x01(\v2\x17.mitmflow.v1.ProxyRulesR\x05rules\"F\n" +
+	"\x15SetProxyRulesResponse\x12-\n" +
+	"\x05rules\x18\x01 \x01(\v2\x17.mitmflow.v1.ProxyRulesR\x05rules\"\x18\n" +
+	"\x16WatchProxyRulesRequest\"~\n" +
+	"\x17WatchProxyRulesResponse\x12-\n" +
+	"\x05rules\x18\x01 \x01(\v2\x17.mitmflow.v1.ProxyRulesR\x05rules\x124\n" +
+	"\tkeepalive\x18\x02 \x01(\v2\x16.mitmflow.v1.KeepaliveR\tkeepalive\"~\n" +
+	"\n" +
+	"ProxyRules\x127\n" +
+	"\toverrides\x18\x01 \x03(\v2\x19.mitmflow.v1.OverrideRuleR\toverrides\x127\n" +
+	"\tthrottles\x18\x02 \x03(\v2\x19.mitmflow.v1.ThrottleRuleR\tthrottles\"j\n" +
+	"\x0eProxyRuleMatch\x12\x12\n" +
+	"\x04host\x18\x01 \x01(\tR\x04host\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x120\n" +
+	"\amethods\x18\x03 \x03(\tB\x16\xbaH\x13\x92\x01\x10\"\x0er\f\x18\x142\b^[A-Z]+$R\amethods\"\xcd\x02\n" +
+	"\fOverrideRule\x12\x1b\n" +
+	"\x04name\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04name\x12\x1a\n" +
+	"\bdisabled\x18\x02 \x01(\bR\bdisabled\x121\n" +
+	"\x05match\x18\x03 \x01(\v2\x1b.mitmflow.v1.ProxyRuleMatchR\x05match\x12+\n" +
+	"\vstatus_code\x18\x04 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xd7\x04(\x00R\n" +
+	"statusCode\x12@\n" +
+	"\aheaders\x18\x05 \x03(\v2&.mitmflow.v1.OverrideRule.HeadersEntryR\aheaders\x12\x12\n" +
+	"\x04body\x18\x06 \x01(\fR\x04body\x12\x12\n" +
+	"\x04file\x18\a \x01(\tR\x04file\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xee\x02\n" +
+	"\fThrottleRule\x12\x1b\n" +
+	"\x04name\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04name\x12\x1a\n" +
+	"\bdisabled\x18\x02 \x01(\bR\bdisabled\x121\n" +
+	"\x05match\x18\x03 \x01(\v2\x1b.mitmflow.v1.ProxyRuleMatchR\x05match\x125\n" +
+	"\x10request_delay_ms\x18\x04 \x01(\x05B\v\xbaH\b\x1a\x06\x18\xc0\xcf$(\x00R\x0erequestDelayMs\x127\n" +
+	"\x11response_delay_ms\x18\x05 \x01(\x05B\v\xbaH\b\x1a\x06\x18\xc0\xcf$(\x00R\x0fresponseDelayMs\x12>\n" +
+	"\x17upload_bytes_per_second\x18\x06 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x14uploadBytesPerSecond\x12B\n" +
+	"\x19download_bytes_per_second\x18\a \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x16downloadBytesPerSecond*\xc9\x02\n" +
 	"\fExportFormat\x12\x1d\n" +
 	"\x19EXPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11EXPORT_FORMAT_HAR\x10\x01\x12\x16\n" +
-	"\x12EXPORT_FORMAT_JSON\x10\x022\xed\x03\n" +
+	"\x12EXPORT_FORMAT_JSON\x10\x02\x12\x17\n" +
+	"\x13EXPORT_FORMAT_PROTO\x10\x03\x12\x15\n" +
+	"\x11EXPORT_FORMAT_SAZ\x10\x04\x12\x19\n" +
+	"\x15EXPORT_FORMAT_CHARLES\x10\x05\x12\x1d\n" +
+	"\x19EXPORT_FORMAT_GRPC_FRAMES\x10\x06\x12\x19\n" +
+	"\x15EXPORT_FORMAT_GRPCURL\x10\a\x12\x1a\n" +
+	"\x16EXPORT_FORMAT_BUF_CURL\x10\b\x12\x17\n" +
+	"\x13EXPORT_FORMAT_JSONL\x10\t\x12\x15\n" +
+	"\x11EXPORT_FORMAT_CSV\x10\n" +
+	"\x12\x1a\n" +
+	"\x16EXPORT_FORMAT_MARKDOWN\x10\v*\xaf\x01\n" +
+	"\x16BaselineDifferenceKind\x12(\n" +
+	"$BASELINE_DIFFERENCE_KIND_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fBASELINE_DIFFERENCE_KIND_STATUS\x10\x01\x12#\n" +
+	"\x1fBASELINE_DIFFERENCE_KIND_HEADER\x10\x02\x12!\n" +
+	"\x1dBASELINE_DIFFERENCE_KIND_BODY\x10\x03*\xd5\x02\n" +
+	"\vAuditAction\x12\x1c\n" +
+	"\x18AUDIT_ACTION_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13AUDIT_ACTION_DELETE\x10\x01\x12\x1b\n" +
+	"\x17AUDIT_ACTION_DELETE_ALL\x10\x02\x12\x14\n" +
+	"\x10AUDIT_ACTION_PIN\x10\x03\x12\x16\n" +
+	"\x12AUDIT_ACTION_UNPIN\x10\x04\x12\x15\n" +
+	"\x11AUDIT_ACTION_NOTE\x10\x05\x12 \n" +
+	"\x1cAUDIT_ACTION_UPDATE_METADATA\x10\x06\x12\x17\n" +
+	"\x13AUDIT_ACTION_EXPORT\x10\a\x12\x18\n" +
+	"\x14AUDIT_ACTION_RESTORE\x10\b\x12\x17\n" +
+	"\x13AUDIT_ACTION_IMPORT\x10\t\x12 \n" +
+	"\x1cAUDIT_ACTION_SET_PROXY_RULES\x10\n" +
+	"\x12\x1d\n" +
+	"\x19AUDIT_ACTION_SET_BASELINE\x10\v*\x8a\x01\n" +
+	"\x0fCookieEventType\x12!\n" +
+	"\x1dCOOKIE_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15COOKIE_EVENT_TYPE_SET\x10\x01\x12\x1a\n" +
+	"\x16COOKIE_EVENT_TYPE_SENT\x10\x02\x12\x1d\n" +
+	"\x19COOKIE_EVENT_TYPE_DELETED\x10\x03*j\n" +
+	"\rTopFlowsOrder\x12\x1f\n" +
+	"\x1bTOP_FLOWS_ORDER_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17TOP_FLOWS_ORDER_SLOWEST\x10\x01\x12\x1b\n" +
+	"\x17TOP_FLOWS_ORDER_LARGEST\x10\x02*\xbb\x01\n" +
+	"\x12FlowDifferenceKind\x12$\n" +
+	" FLOW_DIFFERENCE_KIND_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bFLOW_DIFFERENCE_KIND_STATUS\x10\x01\x12\x1e\n" +
+	"\x1aFLOW_DIFFERENCE_KIND_QUERY\x10\x02\x12\x1f\n" +
+	"\x1bFLOW_DIFFERENCE_KIND_HEADER\x10\x03\x12\x1d\n" +
+	"\x19FLOW_DIFFERENCE_KIND_BODY\x10\x04*\x85\x01\n" +
+	"\x0fFindingSeverity\x12 \n" +
+	"\x1cFINDING_SEVERITY_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15FINDING_SEVERITY_INFO\x10\x01\x12\x18\n" +
+	"\x14FINDING_SEVERITY_LOW\x10\x02\x12\x1b\n" +
+	"\x17FINDING_SEVERITY_MEDIUM\x10\x03*\x87\x01\n" +
+	"\n" +
+	"DeviceType\x12\x1b\n" +
+	"\x17DEVICE_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13DEVICE_TYPE_DESKTOP\x10\x01\x12\x16\n" +
+	"\x12DEVICE_TYPE_MOBILE\x10\x02\x12\x16\n" +
+	"\x12DEVICE_TYPE_TABLET\x10\x03\x12\x13\n" +
+	"\x0fDEVICE_TYPE_BOT\x10\x04*\xc4\x01\n" +
+	"\x0eDnsAnomalyKind\x12 \n" +
+	"\x1cDNS_ANOMALY_KIND_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fDNS_ANOMALY_KIND_NXDOMAIN_BURST\x10\x01\x12\x1f\n" +
+	"\x1bDNS_ANOMALY_KIND_LONG_LABEL\x10\x02\x12&\n" +
+	"\"DNS_ANOMALY_KIND_HIGH_ENTROPY_NAME\x10\x03\x12\"\n" +
+	"\x1eDNS_ANOMALY_KIND_UNUSUAL_QTYPE\x10\x04*p\n" +
+	"\x0eHostnameSource\x12\x1f\n" +
+	"\x1bHOSTNAME_SOURCE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18HOSTNAME_SOURCE_DNS_FLOW\x10\x01\x12\x1f\n" +
+	"\x1bHOSTNAME_SOURCE_REVERSE_DNS\x10\x022\x9e\x15\n" +
 	"\aService\x12K\n" +
 	"\bGetFlows\x12\x1c.mitmflow.v1.GetFlowsRequest\x1a\x1d.mitmflow.v1.GetFlowsResponse\"\x000\x01\x12T\n" +
 	"\vStreamFlows\x12\x1f.mitmflow.v1.StreamFlowsRequest\x1a .mitmflow.v1.StreamFlowsResponse\"\x000\x01\x12O\n" +
@@ -3486,79 +17221,351 @@ const file_mitmflow_v1_mitmflow_proto_rawDesc = "" +
 	"UpdateFlow\x12\x1e.mitmflow.v1.UpdateFlowRequest\x1a\x1f.mitmflow.v1.UpdateFlowResponse\"\x00\x12R\n" +
 	"\vDeleteFlows\x12\x1f.mitmflow.v1.DeleteFlowsRequest\x1a .mitmflow.v1.DeleteFlowsResponse\"\x00\x12R\n" +
 	"\vExportFlows\x12\x1f.mitmflow.v1.ExportFlowsRequest\x1a .mitmflow.v1.ExportFlowsResponse\"\x00\x12F\n" +
-	"\aGetFlow\x12\x1b.mitmflow.v1.GetFlowRequest\x1a\x1c.mitmflow.v1.GetFlowResponse\"\x00B\xab\x01\n" +
+	"\aGetFlow\x12\x1b.mitmflow.v1.GetFlowRequest\x1a\x1c.mitmflow.v1.GetFlowResponse\"\x00\x12R\n" +
+	"\vGetFlowBody\x12\x1f.mitmflow.v1.GetFlowBodyRequest\x1a .mitmflow.v1.GetFlowBodyResponse\"\x00\x12X\n" +
+	"\rGetFlowFrames\x12!.mitmflow.v1.GetFlowFramesRequest\x1a\".mitmflow.v1.GetFlowFramesResponse\"\x00\x12R\n" +
+	"\vImportFlows\x12\x1f.mitmflow.v1.ImportFlowsRequest\x1a .mitmflow.v1.ImportFlowsResponse\"\x00\x12W\n" +
+	"\fCreateBackup\x12 .mitmflow.v1.CreateBackupRequest\x1a!.mitmflow.v1.CreateBackupResponse\"\x000\x01\x12X\n" +
+	"\rRestoreBackup\x12!.mitmflow.v1.RestoreBackupRequest\x1a\".mitmflow.v1.RestoreBackupResponse\"\x00\x12Z\n" +
+	"\rSearchArchive\x12!.mitmflow.v1.SearchArchiveRequest\x1a\".mitmflow.v1.SearchArchiveResponse\"\x000\x01\x12m\n" +
+	"\x14RestoreArchivedFlows\x12(.mitmflow.v1.RestoreArchivedFlowsRequest\x1a).mitmflow.v1.RestoreArchivedFlowsResponse\"\x00\x12U\n" +
+	"\fRestoreFlows\x12 .mitmflow.v1.RestoreFlowsRequest\x1a!.mitmflow.v1.RestoreFlowsResponse\"\x00\x12X\n" +
+	"\rGetServerInfo\x12!.mitmflow.v1.GetServerInfoRequest\x1a\".mitmflow.v1.GetServerInfoResponse\"\x00\x12^\n" +
+	"\x0fListSubscribers\x12#.mitmflow.v1.ListSubscribersRequest\x1a$.mitmflow.v1.ListSubscribersResponse\"\x00\x12^\n" +
+	"\x0fListAuditEvents\x12#.mitmflow.v1.ListAuditEventsRequest\x1a$.mitmflow.v1.ListAuditEventsResponse\"\x00\x12R\n" +
+	"\vSendRequest\x12\x1f.mitmflow.v1.SendRequestRequest\x1a .mitmflow.v1.SendRequestResponse\"\x00\x12d\n" +
+	"\x11GetCookieTimeline\x12%.mitmflow.v1.GetCookieTimelineRequest\x1a&.mitmflow.v1.GetCookieTimelineResponse\"\x00\x12a\n" +
+	"\x10GetRedirectChain\x12$.mitmflow.v1.GetRedirectChainRequest\x1a%.mitmflow.v1.GetRedirectChainResponse\"\x00\x12d\n" +
+	"\x11CreateShareBundle\x12%.mitmflow.v1.CreateShareBundleRequest\x1a&.mitmflow.v1.CreateShareBundleResponse\"\x00\x12R\n" +
+	"\vSetBaseline\x12\x1f.mitmflow.v1.SetBaselineRequest\x1a .mitmflow.v1.SetBaselineResponse\"\x00\x12^\n" +
+	"\x0fCompareSessions\x12#.mitmflow.v1.CompareSessionsRequest\x1a$.mitmflow.v1.CompareSessionsResponse\"\x00\x12]\n" +
+	"\x0eUploadFlowBody\x12\".mitmflow.v1.UploadFlowBodyRequest\x1a#.mitmflow.v1.UploadFlowBodyResponse\"\x00(\x01\x12a\n" +
+	"\x10DiffWithPrevious\x12$.mitmflow.v1.DiffWithPreviousRequest\x1a%.mitmflow.v1.DiffWithPreviousResponse\"\x00\x12I\n" +
+	"\bTopFlows\x12\x1c.mitmflow.v1.TopFlowsRequest\x1a\x1d.mitmflow.v1.TopFlowsResponse\"\x00\x12d\n" +
+	"\x11GetBandwidthStats\x12%.mitmflow.v1.GetBandwidthStatsRequest\x1a&.mitmflow.v1.GetBandwidthStatsResponse\"\x00\x12X\n" +
+	"\rGetProxyRules\x12!.mitmflow.v1.GetProxyRulesRequest\x1a\".mitmflow.v1.GetProxyRulesResponse\"\x00\x12X\n" +
+	"\rSetProxyRules\x12!.mitmflow.v1.SetProxyRulesRequest\x1a\".mitmflow.v1.SetProxyRulesResponse\"\x00\x12`\n" +
+	"\x0fWatchProxyRules\x12#.mitmflow.v1.WatchProxyRulesRequest\x1a$.mitmflow.v1.WatchProxyRulesResponse\"\x000\x01B\xab\x01\n" +
 	"\x0fcom.mitmflow.v1B\rMitmflowProtoP\x01Z<github.com/sudorandom/mitmflow/gen/go/mitmflow/v1;mitmflowv1\xa2\x02\x03MXX\xaa\x02\vMitmflow.V1\xca\x02\vMitmflow\\V1\xe2\x02\x17Mitmflow\\V1\\GPBMetadata\xea\x02\fMitmflow::V1b\beditionsp\xe8\a"
 
-var file_mitmflow_v1_mitmflow_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_mitmflow_v1_mitmflow_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_mitmflow_v1_mitmflow_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
+var file_mitmflow_v1_mitmflow_proto_msgTypes = make([]protoimpl.MessageInfo, 113)
 var file_mitmflow_v1_mitmflow_proto_goTypes = []any{
-	(ExportFormat)(0),             // 0: mitmflow.v1.ExportFormat
-	(*FlowFilter)(nil),            // 1: mitmflow.v1.FlowFilter
-	(*HttpFilter)(nil),            // 2: mitmflow.v1.HttpFilter
-	(*GetFlowRequest)(nil),        // 3: mitmflow.v1.GetFlowRequest
-	(*GetFlowResponse)(nil),       // 4: mitmflow.v1.GetFlowResponse
-	(*GetFlowsRequest)(nil),       // 5: mitmflow.v1.GetFlowsRequest
-	(*GetFlowsResponse)(nil),      // 6: mitmflow.v1.GetFlowsResponse
-	(*StreamFlowsRequest)(nil),    // 7: mitmflow.v1.StreamFlowsRequest
-	(*StreamFlowsResponse)(nil),   // 8: mitmflow.v1.StreamFlowsResponse
-	(*UpdateFlowRequest)(nil),     // 9: mitmflow.v1.UpdateFlowRequest
-	(*UpdateFlowResponse)(nil),    // 10: mitmflow.v1.UpdateFlowResponse
-	(*DeleteFlowsRequest)(nil),    // 11: mitmflow.v1.DeleteFlowsRequest
-	(*DeleteFlowsResponse)(nil),   // 12: mitmflow.v1.DeleteFlowsResponse
-	(*ExportFlowsRequest)(nil),    // 13: mitmflow.v1.ExportFlowsRequest
-	(*ExportFlowsResponse)(nil),   // 14: mitmflow.v1.ExportFlowsResponse
-	(*FlowSummary)(nil),           // 15: mitmflow.v1.FlowSummary
-	(*HttpFlowSummary)(nil),       // 16: mitmflow.v1.HttpFlowSummary
-	(*DnsFlowSummary)(nil),        // 17: mitmflow.v1.DnsFlowSummary
-	(*TcpFlowSummary)(nil),        // 18: mitmflow.v1.TcpFlowSummary
-	(*UdpFlowSummary)(nil),        // 19: mitmflow.v1.UdpFlowSummary
-	(*Flow)(nil),                  // 20: mitmflow.v1.Flow
-	(*HTTPFlowExtra)(nil),         // 21: mitmflow.v1.HTTPFlowExtra
-	(*MessageDetails)(nil),        // 22: mitmflow.v1.MessageDetails
-	(*timestamppb.Timestamp)(nil), // 23: google.protobuf.Timestamp
-	(*v1.HTTPFlow)(nil),           // 24: mitmproxy.v1.HTTPFlow
-	(*v1.TCPFlow)(nil),            // 25: mitmproxy.v1.TCPFlow
-	(*v1.UDPFlow)(nil),            // 26: mitmproxy.v1.UDPFlow
-	(*v1.DNSFlow)(nil),            // 27: mitmproxy.v1.DNSFlow
+	(ExportFormat)(0),                    // 0: mitmflow.v1.ExportFormat
+	(BaselineDifferenceKind)(0),          // 1: mitmflow.v1.BaselineDifferenceKind
+	(AuditAction)(0),                     // 2: mitmflow.v1.AuditAction
+	(CookieEventType)(0),                 // 3: mitmflow.v1.CookieEventType
+	(TopFlowsOrder)(0),                   // 4: mitmflow.v1.TopFlowsOrder
+	(FlowDifferenceKind)(0),              // 5: mitmflow.v1.FlowDifferenceKind
+	(FindingSeverity)(0),                 // 6: mitmflow.v1.FindingSeverity
+	(DeviceType)(0),                      // 7: mitmflow.v1.DeviceType
+	(DnsAnomalyKind)(0),                  // 8: mitmflow.v1.DnsAnomalyKind
+	(HostnameSource)(0),                  // 9: mitmflow.v1.HostnameSource
+	(*FlowFilter)(nil),                   // 10: mitmflow.v1.FlowFilter
+	(*HttpFilter)(nil),                   // 11: mitmflow.v1.HttpFilter
+	(*BodyQuery)(nil),                    // 12: mitmflow.v1.BodyQuery
+	(*GetFlowRequest)(nil),               // 13: mitmflow.v1.GetFlowRequest
+	(*GetFlowResponse)(nil),              // 14: mitmflow.v1.GetFlowResponse
+	(*GetFlowBodyRequest)(nil),           // 15: mitmflow.v1.GetFlowBodyRequest
+	(*GetFlowBodyResponse)(nil),          // 16: mitmflow.v1.GetFlowBodyResponse
+	(*GetFlowFramesRequest)(nil),         // 17: mitmflow.v1.GetFlowFramesRequest
+	(*GetFlowFramesResponse)(nil),        // 18: mitmflow.v1.GetFlowFramesResponse
+	(*GetFlowsRequest)(nil),              // 19: mitmflow.v1.GetFlowsRequest
+	(*GetFlowsResponse)(nil),             // 20: mitmflow.v1.GetFlowsResponse
+	(*StreamFlowsRequest)(nil),           // 21: mitmflow.v1.StreamFlowsRequest
+	(*StreamFlowsResponse)(nil),          // 22: mitmflow.v1.StreamFlowsResponse
+	(*Keepalive)(nil),                    // 23: mitmflow.v1.Keepalive
+	(*UpdateFlowRequest)(nil),            // 24: mitmflow.v1.UpdateFlowRequest
+	(*UpdateFlowResponse)(nil),           // 25: mitmflow.v1.UpdateFlowResponse
+	(*DeleteFlowsRequest)(nil),           // 26: mitmflow.v1.DeleteFlowsRequest
+	(*DeleteFlowsResponse)(nil),          // 27: mitmflow.v1.DeleteFlowsResponse
+	(*ExportFlowsRequest)(nil),           // 28: mitmflow.v1.ExportFlowsRequest
+	(*ExportFlowsResponse)(nil),          // 29: mitmflow.v1.ExportFlowsResponse
+	(*ImportFlowsRequest)(nil),           // 30: mitmflow.v1.ImportFlowsRequest
+	(*ImportFlowsResponse)(nil),          // 31: mitmflow.v1.ImportFlowsResponse
+	(*CreateShareBundleRequest)(nil),     // 32: mitmflow.v1.CreateShareBundleRequest
+	(*CreateShareBundleResponse)(nil),    // 33: mitmflow.v1.CreateShareBundleResponse
+	(*SessionSelector)(nil),              // 34: mitmflow.v1.SessionSelector
+	(*SetBaselineRequest)(nil),           // 35: mitmflow.v1.SetBaselineRequest
+	(*SetBaselineResponse)(nil),          // 36: mitmflow.v1.SetBaselineResponse
+	(*CompareSessionsRequest)(nil),       // 37: mitmflow.v1.CompareSessionsRequest
+	(*CompareSessionsResponse)(nil),      // 38: mitmflow.v1.CompareSessionsResponse
+	(*BaselineComparison)(nil),           // 39: mitmflow.v1.BaselineComparison
+	(*BaselineDifference)(nil),           // 40: mitmflow.v1.BaselineDifference
+	(*CreateBackupRequest)(nil),          // 41: mitmflow.v1.CreateBackupRequest
+	(*CreateBackupResponse)(nil),         // 42: mitmflow.v1.CreateBackupResponse
+	(*RestoreBackupRequest)(nil),         // 43: mitmflow.v1.RestoreBackupRequest
+	(*RestoreBackupResponse)(nil),        // 44: mitmflow.v1.RestoreBackupResponse
+	(*SearchArchiveRequest)(nil),         // 45: mitmflow.v1.SearchArchiveRequest
+	(*SearchArchiveResponse)(nil),        // 46: mitmflow.v1.SearchArchiveResponse
+	(*RestoreArchivedFlowsRequest)(nil),  // 47: mitmflow.v1.RestoreArchivedFlowsRequest
+	(*RestoreArchivedFlowsResponse)(nil), // 48: mitmflow.v1.RestoreArchivedFlowsResponse
+	(*RestoreFlowsRequest)(nil),          // 49: mitmflow.v1.RestoreFlowsRequest
+	(*RestoreFlowsResponse)(nil),         // 50: mitmflow.v1.RestoreFlowsResponse
+	(*UploadFlowBodyRequest)(nil),        // 51: mitmflow.v1.UploadFlowBodyRequest
+	(*UploadFlowBodyResponse)(nil),       // 52: mitmflow.v1.UploadFlowBodyResponse
+	(*AuditEvent)(nil),                   // 53: mitmflow.v1.AuditEvent
+	(*ListAuditEventsRequest)(nil),       // 54: mitmflow.v1.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),      // 55: mitmflow.v1.ListAuditEventsResponse
+	(*ListSubscribersRequest)(nil),       // 56: mitmflow.v1.ListSubscribersRequest
+	(*ListSubscribersResponse)(nil),      // 57: mitmflow.v1.ListSubscribersResponse
+	(*Subscriber)(nil),                   // 58: mitmflow.v1.Subscriber
+	(*GetServerInfoRequest)(nil),         // 59: mitmflow.v1.GetServerInfoRequest
+	(*GetServerInfoResponse)(nil),        // 60: mitmflow.v1.GetServerInfoResponse
+	(*SendRequestRequest)(nil),           // 61: mitmflow.v1.SendRequestRequest
+	(*SendRequestResponse)(nil),          // 62: mitmflow.v1.SendRequestResponse
+	(*GetCookieTimelineRequest)(nil),     // 63: mitmflow.v1.GetCookieTimelineRequest
+	(*GetCookieTimelineResponse)(nil),    // 64: mitmflow.v1.GetCookieTimelineResponse
+	(*CookieEvent)(nil),                  // 65: mitmflow.v1.CookieEvent
+	(*GetRedirectChainRequest)(nil),      // 66: mitmflow.v1.GetRedirectChainRequest
+	(*GetRedirectChainResponse)(nil),     // 67: mitmflow.v1.GetRedirectChainResponse
+	(*RedirectHop)(nil),                  // 68: mitmflow.v1.RedirectHop
+	(*DiffWithPreviousRequest)(nil),      // 69: mitmflow.v1.DiffWithPreviousRequest
+	(*DiffWithPreviousResponse)(nil),     // 70: mitmflow.v1.DiffWithPreviousResponse
+	(*TopFlowsRequest)(nil),              // 71: mitmflow.v1.TopFlowsRequest
+	(*TopFlowsResponse)(nil),             // 72: mitmflow.v1.TopFlowsResponse
+	(*GetBandwidthStatsRequest)(nil),     // 73: mitmflow.v1.GetBandwidthStatsRequest
+	(*GetBandwidthStatsResponse)(nil),    // 74: mitmflow.v1.GetBandwidthStatsResponse
+	(*BandwidthUsage)(nil),               // 75: mitmflow.v1.BandwidthUsage
+	(*BandwidthBucket)(nil),              // 76: mitmflow.v1.BandwidthBucket
+	(*FlowDifference)(nil),               // 77: mitmflow.v1.FlowDifference
+	(*FlowSet)(nil),                      // 78: mitmflow.v1.FlowSet
+	(*FlowSummary)(nil),                  // 79: mitmflow.v1.FlowSummary
+	(*HttpFlowSummary)(nil),              // 80: mitmflow.v1.HttpFlowSummary
+	(*DnsFlowSummary)(nil),               // 81: mitmflow.v1.DnsFlowSummary
+	(*TcpFlowSummary)(nil),               // 82: mitmflow.v1.TcpFlowSummary
+	(*UdpFlowSummary)(nil),               // 83: mitmflow.v1.UdpFlowSummary
+	(*Flow)(nil),                         // 84: mitmflow.v1.Flow
+	(*Annotation)(nil),                   // 85: mitmflow.v1.Annotation
+	(*HTTPFlowExtra)(nil),                // 86: mitmflow.v1.HTTPFlowExtra
+	(*FlowTotals)(nil),                   // 87: mitmflow.v1.FlowTotals
+	(*InterimResponse)(nil),              // 88: mitmflow.v1.InterimResponse
+	(*HTTP2Details)(nil),                 // 89: mitmflow.v1.HTTP2Details
+	(*HeaderField)(nil),                  // 90: mitmflow.v1.HeaderField
+	(*CorsCheck)(nil),                    // 91: mitmflow.v1.CorsCheck
+	(*SecurityFinding)(nil),              // 92: mitmflow.v1.SecurityFinding
+	(*GeoInfo)(nil),                      // 93: mitmflow.v1.GeoInfo
+	(*UserAgent)(nil),                    // 94: mitmflow.v1.UserAgent
+	(*StreamFlowExtra)(nil),              // 95: mitmflow.v1.StreamFlowExtra
+	(*DnsFlowExtra)(nil),                 // 96: mitmflow.v1.DnsFlowExtra
+	(*DnsAnomaly)(nil),                   // 97: mitmflow.v1.DnsAnomaly
+	(*MessageDetails)(nil),               // 98: mitmflow.v1.MessageDetails
+	(*BinaryMetadata)(nil),               // 99: mitmflow.v1.BinaryMetadata
+	(*GrpcStatus)(nil),                   // 100: mitmflow.v1.GrpcStatus
+	(*MediaInfo)(nil),                    // 101: mitmflow.v1.MediaInfo
+	(*FormField)(nil),                    // 102: mitmflow.v1.FormField
+	(*SoapMessage)(nil),                  // 103: mitmflow.v1.SoapMessage
+	(*SoapFault)(nil),                    // 104: mitmflow.v1.SoapFault
+	(*GetProxyRulesRequest)(nil),         // 105: mitmflow.v1.GetProxyRulesRequest
+	(*GetProxyRulesResponse)(nil),        // 106: mitmflow.v1.GetProxyRulesResponse
+	(*SetProxyRulesRequest)(nil),         // 107: mitmflow.v1.SetProxyRulesRequest
+	(*SetProxyRulesResponse)(nil),        // 108: mitmflow.v1.SetProxyRulesResponse
+	(*WatchProxyRulesRequest)(nil),       // 109: mitmflow.v1.WatchProxyRulesRequest
+	(*WatchProxyRulesResponse)(nil),      // 110: mitmflow.v1.WatchProxyRulesResponse
+	(*ProxyRules)(nil),                   // 111: mitmflow.v1.ProxyRules
+	(*ProxyRuleMatch)(nil),               // 112: mitmflow.v1.ProxyRuleMatch
+	(*OverrideRule)(nil),                 // 113: mitmflow.v1.OverrideRule
+	(*ThrottleRule)(nil),                 // 114: mitmflow.v1.ThrottleRule
+	nil,                                  // 115: mitmflow.v1.UpdateFlowRequest.MetadataEntry
+	nil,                                  // 116: mitmflow.v1.GetServerInfoResponse.FlowCountsEntry
+	nil,                                  // 117: mitmflow.v1.SendRequestRequest.HeadersEntry
+	nil,                                  // 118: mitmflow.v1.Flow.MetadataEntry
+	nil,                                  // 119: mitmflow.v1.Flow.UserAnnotationsEntry
+	nil,                                  // 120: mitmflow.v1.InterimResponse.HeadersEntry
+	nil,                                  // 121: mitmflow.v1.MediaInfo.ExifEntry
+	nil,                                  // 122: mitmflow.v1.OverrideRule.HeadersEntry
+	(*timestamppb.Timestamp)(nil),        // 123: google.protobuf.Timestamp
+	(*v1.HTTPFlow)(nil),                  // 124: mitmproxy.v1.HTTPFlow
+	(*v1.TCPFlow)(nil),                   // 125: mitmproxy.v1.TCPFlow
+	(*v1.UDPFlow)(nil),                   // 126: mitmproxy.v1.UDPFlow
+	(*v1.DNSFlow)(nil),                   // 127: mitmproxy.v1.DNSFlow
 }
 var file_mitmflow_v1_mitmflow_proto_depIdxs = []int32{
-	2,  // 0: mitmflow.v1.FlowFilter.http:type_name -> mitmflow.v1.HttpFilter
-	20, // 1: mitmflow.v1.GetFlowResponse.flow:type_name -> mitmflow.v1.Flow
-	1,  // 2: mitmflow.v1.GetFlowsRequest.filter:type_name -> mitmflow.v1.FlowFilter
-	15, // 3: mitmflow.v1.GetFlowsResponse.flow:type_name -> mitmflow.v1.FlowSummary
-	1,  // 4: mitmflow.v1.StreamFlowsRequest.filter:type_name -> mitmflow.v1.FlowFilter
-	15, // 5: mitmflow.v1.StreamFlowsResponse.flow:type_name -> mitmflow.v1.FlowSummary
-	15, // 6: mitmflow.v1.UpdateFlowResponse.flow:type_name -> mitmflow.v1.FlowSummary
-	0,  // 7: mitmflow.v1.ExportFlowsRequest.format:type_name -> mitmflow.v1.ExportFormat
-	23, // 8: mitmflow.v1.FlowSummary.timestamp_start:type_name -> google.protobuf.Timestamp
-	16, // 9: mitmflow.v1.FlowSummary.http:type_name -> mitmflow.v1.HttpFlowSummary
-	17, // 10: mitmflow.v1.FlowSummary.dns:type_name -> mitmflow.v1.DnsFlowSummary
-	18, // 11: mitmflow.v1.FlowSummary.tcp:type_name -> mitmflow.v1.TcpFlowSummary
-	19, // 12: mitmflow.v1.FlowSummary.udp:type_name -> mitmflow.v1.UdpFlowSummary
-	24, // 13: mitmflow.v1.Flow.http_flow:type_name -> mitmproxy.v1.HTTPFlow
-	25, // 14: mitmflow.v1.Flow.tcp_flow:type_name -> mitmproxy.v1.TCPFlow
-	26, // 15: mitmflow.v1.Flow.udp_flow:type_name -> mitmproxy.v1.UDPFlow
-	27, // 16: mitmflow.v1.Flow.dns_flow:type_name -> mitmproxy.v1.DNSFlow
-	21, // 17: mitmflow.v1.Flow.http_flow_extra:type_name -> mitmflow.v1.HTTPFlowExtra
-	22, // 18: mitmflow.v1.HTTPFlowExtra.request:type_name -> mitmflow.v1.MessageDetails
-	22, // 19: mitmflow.v1.HTTPFlowExtra.response:type_name -> mitmflow.v1.MessageDetails
-	5,  // 20: mitmflow.v1.Service.GetFlows:input_type -> mitmflow.v1.GetFlowsRequest
-	7,  // 21: mitmflow.v1.Service.StreamFlows:input_type -> mitmflow.v1.StreamFlowsRequest
-	9,  // 22: mitmflow.v1.Service.UpdateFlow:input_type -> mitmflow.v1.UpdateFlowRequest
-	11, // 23: mitmflow.v1.Service.DeleteFlows:input_type -> mitmflow.v1.DeleteFlowsRequest
-	13, // 24: mitmflow.v1.Service.ExportFlows:input_type -> mitmflow.v1.ExportFlowsRequest
-	3,  // 25: mitmflow.v1.Service.GetFlow:input_type -> mitmflow.v1.GetFlowRequest
-	6,  // 26: mitmflow.v1.Service.GetFlows:output_type -> mitmflow.v1.GetFlowsResponse
-	8,  // 27: mitmflow.v1.Service.StreamFlows:output_type -> mitmflow.v1.StreamFlowsResponse
-	10, // 28: mitmflow.v1.Service.UpdateFlow:output_type -> mitmflow.v1.UpdateFlowResponse
-	12, // 29: mitmflow.v1.Service.DeleteFlows:output_type -> mitmflow.v1.DeleteFlowsResponse
-	14, // 30: mitmflow.v1.Service.ExportFlows:output_type -> mitmflow.v1.ExportFlowsResponse
-	4,  // 31: mitmflow.v1.Service.GetFlow:output_type -> mitmflow.v1.GetFlowResponse
-	26, // [26:32] is the sub-list for method output_type
-	20, // [20:26] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	11,  // 0: mitmflow.v1.FlowFilter.http:type_name -> mitmflow.v1.HttpFilter
+	8,   // 1: mitmflow.v1.FlowFilter.dns_anomaly:type_name -> mitmflow.v1.DnsAnomalyKind
+	6,   // 2: mitmflow.v1.HttpFilter.min_security_severity:type_name -> mitmflow.v1.FindingSeverity
+	12,  // 3: mitmflow.v1.HttpFilter.body_queries:type_name -> mitmflow.v1.BodyQuery
+	84,  // 4: mitmflow.v1.GetFlowResponse.flow:type_name -> mitmflow.v1.Flow
+	10,  // 5: mitmflow.v1.GetFlowsRequest.filter:type_name -> mitmflow.v1.FlowFilter
+	79,  // 6: mitmflow.v1.GetFlowsResponse.flow:type_name -> mitmflow.v1.FlowSummary
+	10,  // 7: mitmflow.v1.StreamFlowsRequest.filter:type_name -> mitmflow.v1.FlowFilter
+	79,  // 8: mitmflow.v1.StreamFlowsResponse.flow:type_name -> mitmflow.v1.FlowSummary
+	23,  // 9: mitmflow.v1.StreamFlowsResponse.keepalive:type_name -> mitmflow.v1.Keepalive
+	115, // 10: mitmflow.v1.UpdateFlowRequest.metadata:type_name -> mitmflow.v1.UpdateFlowRequest.MetadataEntry
+	79,  // 11: mitmflow.v1.UpdateFlowResponse.flow:type_name -> mitmflow.v1.FlowSummary
+	0,   // 12: mitmflow.v1.ExportFlowsRequest.format:type_name -> mitmflow.v1.ExportFormat
+	123, // 13: mitmflow.v1.ExportFlowsRequest.epoch:type_name -> google.protobuf.Timestamp
+	10,  // 14: mitmflow.v1.ExportFlowsRequest.filter:type_name -> mitmflow.v1.FlowFilter
+	123, // 15: mitmflow.v1.ExportFlowsRequest.start_time:type_name -> google.protobuf.Timestamp
+	123, // 16: mitmflow.v1.ExportFlowsRequest.end_time:type_name -> google.protobuf.Timestamp
+	123, // 17: mitmflow.v1.SessionSelector.start_time:type_name -> google.protobuf.Timestamp
+	123, // 18: mitmflow.v1.SessionSelector.end_time:type_name -> google.protobuf.Timestamp
+	34,  // 19: mitmflow.v1.SetBaselineRequest.session:type_name -> mitmflow.v1.SessionSelector
+	34,  // 20: mitmflow.v1.CompareSessionsRequest.session:type_name -> mitmflow.v1.SessionSelector
+	39,  // 21: mitmflow.v1.CompareSessionsResponse.regressions:type_name -> mitmflow.v1.BaselineComparison
+	40,  // 22: mitmflow.v1.BaselineComparison.differences:type_name -> mitmflow.v1.BaselineDifference
+	1,   // 23: mitmflow.v1.BaselineDifference.kind:type_name -> mitmflow.v1.BaselineDifferenceKind
+	10,  // 24: mitmflow.v1.SearchArchiveRequest.filter:type_name -> mitmflow.v1.FlowFilter
+	79,  // 25: mitmflow.v1.SearchArchiveResponse.flow:type_name -> mitmflow.v1.FlowSummary
+	79,  // 26: mitmflow.v1.RestoreArchivedFlowsResponse.flows:type_name -> mitmflow.v1.FlowSummary
+	79,  // 27: mitmflow.v1.RestoreFlowsResponse.flows:type_name -> mitmflow.v1.FlowSummary
+	123, // 28: mitmflow.v1.AuditEvent.time:type_name -> google.protobuf.Timestamp
+	2,   // 29: mitmflow.v1.AuditEvent.action:type_name -> mitmflow.v1.AuditAction
+	2,   // 30: mitmflow.v1.ListAuditEventsRequest.actions:type_name -> mitmflow.v1.AuditAction
+	123, // 31: mitmflow.v1.ListAuditEventsRequest.since:type_name -> google.protobuf.Timestamp
+	53,  // 32: mitmflow.v1.ListAuditEventsResponse.events:type_name -> mitmflow.v1.AuditEvent
+	58,  // 33: mitmflow.v1.ListSubscribersResponse.subscribers:type_name -> mitmflow.v1.Subscriber
+	123, // 34: mitmflow.v1.Subscriber.connect_time:type_name -> google.protobuf.Timestamp
+	10,  // 35: mitmflow.v1.Subscriber.filter:type_name -> mitmflow.v1.FlowFilter
+	123, // 36: mitmflow.v1.GetServerInfoResponse.vcs_time:type_name -> google.protobuf.Timestamp
+	123, // 37: mitmflow.v1.GetServerInfoResponse.start_time:type_name -> google.protobuf.Timestamp
+	116, // 38: mitmflow.v1.GetServerInfoResponse.flow_counts:type_name -> mitmflow.v1.GetServerInfoResponse.FlowCountsEntry
+	117, // 39: mitmflow.v1.SendRequestRequest.headers:type_name -> mitmflow.v1.SendRequestRequest.HeadersEntry
+	84,  // 40: mitmflow.v1.SendRequestResponse.flow:type_name -> mitmflow.v1.Flow
+	65,  // 41: mitmflow.v1.GetCookieTimelineResponse.events:type_name -> mitmflow.v1.CookieEvent
+	3,   // 42: mitmflow.v1.CookieEvent.type:type_name -> mitmflow.v1.CookieEventType
+	123, // 43: mitmflow.v1.CookieEvent.timestamp:type_name -> google.protobuf.Timestamp
+	123, // 44: mitmflow.v1.CookieEvent.expires:type_name -> google.protobuf.Timestamp
+	68,  // 45: mitmflow.v1.GetRedirectChainResponse.hops:type_name -> mitmflow.v1.RedirectHop
+	79,  // 46: mitmflow.v1.DiffWithPreviousResponse.previous:type_name -> mitmflow.v1.FlowSummary
+	77,  // 47: mitmflow.v1.DiffWithPreviousResponse.request:type_name -> mitmflow.v1.FlowDifference
+	77,  // 48: mitmflow.v1.DiffWithPreviousResponse.response:type_name -> mitmflow.v1.FlowDifference
+	10,  // 49: mitmflow.v1.TopFlowsRequest.filter:type_name -> mitmflow.v1.FlowFilter
+	4,   // 50: mitmflow.v1.TopFlowsRequest.order:type_name -> mitmflow.v1.TopFlowsOrder
+	79,  // 51: mitmflow.v1.TopFlowsResponse.flows:type_name -> mitmflow.v1.FlowSummary
+	10,  // 52: mitmflow.v1.GetBandwidthStatsRequest.filter:type_name -> mitmflow.v1.FlowFilter
+	123, // 53: mitmflow.v1.GetBandwidthStatsRequest.start_time:type_name -> google.protobuf.Timestamp
+	123, // 54: mitmflow.v1.GetBandwidthStatsRequest.end_time:type_name -> google.protobuf.Timestamp
+	75,  // 55: mitmflow.v1.GetBandwidthStatsResponse.hosts:type_name -> mitmflow.v1.BandwidthUsage
+	75,  // 56: mitmflow.v1.GetBandwidthStatsResponse.clients:type_name -> mitmflow.v1.BandwidthUsage
+	75,  // 57: mitmflow.v1.GetBandwidthStatsResponse.total:type_name -> mitmflow.v1.BandwidthUsage
+	76,  // 58: mitmflow.v1.BandwidthUsage.buckets:type_name -> mitmflow.v1.BandwidthBucket
+	123, // 59: mitmflow.v1.BandwidthBucket.start:type_name -> google.protobuf.Timestamp
+	5,   // 60: mitmflow.v1.FlowDifference.kind:type_name -> mitmflow.v1.FlowDifferenceKind
+	84,  // 61: mitmflow.v1.FlowSet.flows:type_name -> mitmflow.v1.Flow
+	123, // 62: mitmflow.v1.FlowSummary.timestamp_start:type_name -> google.protobuf.Timestamp
+	80,  // 63: mitmflow.v1.FlowSummary.http:type_name -> mitmflow.v1.HttpFlowSummary
+	81,  // 64: mitmflow.v1.FlowSummary.dns:type_name -> mitmflow.v1.DnsFlowSummary
+	82,  // 65: mitmflow.v1.FlowSummary.tcp:type_name -> mitmflow.v1.TcpFlowSummary
+	83,  // 66: mitmflow.v1.FlowSummary.udp:type_name -> mitmflow.v1.UdpFlowSummary
+	87,  // 67: mitmflow.v1.FlowSummary.totals:type_name -> mitmflow.v1.FlowTotals
+	124, // 68: mitmflow.v1.Flow.http_flow:type_name -> mitmproxy.v1.HTTPFlow
+	125, // 69: mitmflow.v1.Flow.tcp_flow:type_name -> mitmproxy.v1.TCPFlow
+	126, // 70: mitmflow.v1.Flow.udp_flow:type_name -> mitmproxy.v1.UDPFlow
+	127, // 71: mitmflow.v1.Flow.dns_flow:type_name -> mitmproxy.v1.DNSFlow
+	86,  // 72: mitmflow.v1.Flow.http_flow_extra:type_name -> mitmflow.v1.HTTPFlowExtra
+	95,  // 73: mitmflow.v1.Flow.stream_flow_extra:type_name -> mitmflow.v1.StreamFlowExtra
+	118, // 74: mitmflow.v1.Flow.metadata:type_name -> mitmflow.v1.Flow.MetadataEntry
+	119, // 75: mitmflow.v1.Flow.user_annotations:type_name -> mitmflow.v1.Flow.UserAnnotationsEntry
+	96,  // 76: mitmflow.v1.Flow.dns_flow_extra:type_name -> mitmflow.v1.DnsFlowExtra
+	98,  // 77: mitmflow.v1.HTTPFlowExtra.request:type_name -> mitmflow.v1.MessageDetails
+	98,  // 78: mitmflow.v1.HTTPFlowExtra.response:type_name -> mitmflow.v1.MessageDetails
+	94,  // 79: mitmflow.v1.HTTPFlowExtra.user_agent:type_name -> mitmflow.v1.UserAgent
+	93,  // 80: mitmflow.v1.HTTPFlowExtra.server_geo:type_name -> mitmflow.v1.GeoInfo
+	9,   // 81: mitmflow.v1.HTTPFlowExtra.server_hostname_source:type_name -> mitmflow.v1.HostnameSource
+	92,  // 82: mitmflow.v1.HTTPFlowExtra.security_findings:type_name -> mitmflow.v1.SecurityFinding
+	91,  // 83: mitmflow.v1.HTTPFlowExtra.cors:type_name -> mitmflow.v1.CorsCheck
+	39,  // 84: mitmflow.v1.HTTPFlowExtra.baseline:type_name -> mitmflow.v1.BaselineComparison
+	98,  // 85: mitmflow.v1.HTTPFlowExtra.websocket_messages:type_name -> mitmflow.v1.MessageDetails
+	89,  // 86: mitmflow.v1.HTTPFlowExtra.http2:type_name -> mitmflow.v1.HTTP2Details
+	88,  // 87: mitmflow.v1.HTTPFlowExtra.interim_responses:type_name -> mitmflow.v1.InterimResponse
+	87,  // 88: mitmflow.v1.HTTPFlowExtra.totals:type_name -> mitmflow.v1.FlowTotals
+	120, // 89: mitmflow.v1.InterimResponse.headers:type_name -> mitmflow.v1.InterimResponse.HeadersEntry
+	123, // 90: mitmflow.v1.InterimResponse.timestamp:type_name -> google.protobuf.Timestamp
+	90,  // 91: mitmflow.v1.HTTP2Details.request_pseudo_headers:type_name -> mitmflow.v1.HeaderField
+	90,  // 92: mitmflow.v1.HTTP2Details.response_pseudo_headers:type_name -> mitmflow.v1.HeaderField
+	6,   // 93: mitmflow.v1.SecurityFinding.severity:type_name -> mitmflow.v1.FindingSeverity
+	7,   // 94: mitmflow.v1.UserAgent.device_type:type_name -> mitmflow.v1.DeviceType
+	98,  // 95: mitmflow.v1.StreamFlowExtra.messages:type_name -> mitmflow.v1.MessageDetails
+	93,  // 96: mitmflow.v1.StreamFlowExtra.server_geo:type_name -> mitmflow.v1.GeoInfo
+	9,   // 97: mitmflow.v1.StreamFlowExtra.server_hostname_source:type_name -> mitmflow.v1.HostnameSource
+	87,  // 98: mitmflow.v1.StreamFlowExtra.totals:type_name -> mitmflow.v1.FlowTotals
+	97,  // 99: mitmflow.v1.DnsFlowExtra.anomalies:type_name -> mitmflow.v1.DnsAnomaly
+	87,  // 100: mitmflow.v1.DnsFlowExtra.totals:type_name -> mitmflow.v1.FlowTotals
+	8,   // 101: mitmflow.v1.DnsAnomaly.kind:type_name -> mitmflow.v1.DnsAnomalyKind
+	103, // 102: mitmflow.v1.MessageDetails.soap:type_name -> mitmflow.v1.SoapMessage
+	102, // 103: mitmflow.v1.MessageDetails.form_fields:type_name -> mitmflow.v1.FormField
+	101, // 104: mitmflow.v1.MessageDetails.media:type_name -> mitmflow.v1.MediaInfo
+	100, // 105: mitmflow.v1.MessageDetails.grpc_status:type_name -> mitmflow.v1.GrpcStatus
+	99,  // 106: mitmflow.v1.MessageDetails.binary_metadata:type_name -> mitmflow.v1.BinaryMetadata
+	121, // 107: mitmflow.v1.MediaInfo.exif:type_name -> mitmflow.v1.MediaInfo.ExifEntry
+	104, // 108: mitmflow.v1.SoapMessage.fault:type_name -> mitmflow.v1.SoapFault
+	111, // 109: mitmflow.v1.GetProxyRulesResponse.rules:type_name -> mitmflow.v1.ProxyRules
+	111, // 110: mitmflow.v1.SetProxyRulesRequest.rules:type_name -> mitmflow.v1.ProxyRules
+	111, // 111: mitmflow.v1.SetProxyRulesResponse.rules:type_name -> mitmflow.v1.ProxyRules
+	111, // 112: mitmflow.v1.WatchProxyRulesResponse.rules:type_name -> mitmflow.v1.ProxyRules
+	23,  // 113: mitmflow.v1.WatchProxyRulesResponse.keepalive:type_name -> mitmflow.v1.Keepalive
+	113, // 114: mitmflow.v1.ProxyRules.overrides:type_name -> mitmflow.v1.OverrideRule
+	114, // 115: mitmflow.v1.ProxyRules.throttles:type_name -> mitmflow.v1.ThrottleRule
+	112, // 116: mitmflow.v1.OverrideRule.match:type_name -> mitmflow.v1.ProxyRuleMatch
+	122, // 117: mitmflow.v1.OverrideRule.headers:type_name -> mitmflow.v1.OverrideRule.HeadersEntry
+	112, // 118: mitmflow.v1.ThrottleRule.match:type_name -> mitmflow.v1.ProxyRuleMatch
+	85,  // 119: mitmflow.v1.Flow.UserAnnotationsEntry.value:type_name -> mitmflow.v1.Annotation
+	19,  // 120: mitmflow.v1.Service.GetFlows:input_type -> mitmflow.v1.GetFlowsRequest
+	21,  // 121: mitmflow.v1.Service.StreamFlows:input_type -> mitmflow.v1.StreamFlowsRequest
+	24,  // 122: mitmflow.v1.Service.UpdateFlow:input_type -> mitmflow.v1.UpdateFlowRequest
+	26,  // 123: mitmflow.v1.Service.DeleteFlows:input_type -> mitmflow.v1.DeleteFlowsRequest
+	28,  // 124: mitmflow.v1.Service.ExportFlows:input_type -> mitmflow.v1.ExportFlowsRequest
+	13,  // 125: mitmflow.v1.Service.GetFlow:input_type -> mitmflow.v1.GetFlowRequest
+	15,  // 126: mitmflow.v1.Service.GetFlowBody:input_type -> mitmflow.v1.GetFlowBodyRequest
+	17,  // 127: mitmflow.v1.Service.GetFlowFrames:input_type -> mitmflow.v1.GetFlowFramesRequest
+	30,  // 128: mitmflow.v1.Service.ImportFlows:input_type -> mitmflow.v1.ImportFlowsRequest
+	41,  // 129: mitmflow.v1.Service.CreateBackup:input_type -> mitmflow.v1.CreateBackupRequest
+	43,  // 130: mitmflow.v1.Service.RestoreBackup:input_type -> mitmflow.v1.RestoreBackupRequest
+	45,  // 131: mitmflow.v1.Service.SearchArchive:input_type -> mitmflow.v1.SearchArchiveRequest
+	47,  // 132: mitmflow.v1.Service.RestoreArchivedFlows:input_type -> mitmflow.v1.RestoreArchivedFlowsRequest
+	49,  // 133: mitmflow.v1.Service.RestoreFlows:input_type -> mitmflow.v1.RestoreFlowsRequest
+	59,  // 134: mitmflow.v1.Service.GetServerInfo:input_type -> mitmflow.v1.GetServerInfoRequest
+	56,  // 135: mitmflow.v1.Service.ListSubscribers:input_type -> mitmflow.v1.ListSubscribersRequest
+	54,  // 136: mitmflow.v1.Service.ListAuditEvents:input_type -> mitmflow.v1.ListAuditEventsRequest
+	61,  // 137: mitmflow.v1.Service.SendRequest:input_type -> mitmflow.v1.SendRequestRequest
+	63,  // 138: mitmflow.v1.Service.GetCookieTimeline:input_type -> mitmflow.v1.GetCookieTimelineRequest
+	66,  // 139: mitmflow.v1.Service.GetRedirectChain:input_type -> mitmflow.v1.GetRedirectChainRequest
+	32,  // 140: mitmflow.v1.Service.CreateShareBundle:input_type -> mitmflow.v1.CreateShareBundleRequest
+	35,  // 141: mitmflow.v1.Service.SetBaseline:input_type -> mitmflow.v1.SetBaselineRequest
+	37,  // 142: mitmflow.v1.Service.CompareSessions:input_type -> mitmflow.v1.CompareSessionsRequest
+	51,  // 143: mitmflow.v1.Service.UploadFlowBody:input_type -> mitmflow.v1.UploadFlowBodyRequest
+	69,  // 144: mitmflow.v1.Service.DiffWithPrevious:input_type -> mitmflow.v1.DiffWithPreviousRequest
+	71,  // 145: mitmflow.v1.Service.TopFlows:input_type -> mitmflow.v1.TopFlowsRequest
+	73,  // 146: mitmflow.v1.Service.GetBandwidthStats:input_type -> mitmflow.v1.GetBandwidthStatsRequest
+	105, // 147: mitmflow.v1.Service.GetProxyRules:input_type -> mitmflow.v1.GetProxyRulesRequest
+	107, // 148: mitmflow.v1.Service.SetProxyRules:input_type -> mitmflow.v1.SetProxyRulesRequest
+	109, // 149: mitmflow.v1.Service.WatchProxyRules:input_type -> mitmflow.v1.WatchProxyRulesRequest
+	20,  // 150: mitmflow.v1.Service.GetFlows:output_type -> mitmflow.v1.GetFlowsResponse
+	22,  // 151: mitmflow.v1.Service.StreamFlows:output_type -> mitmflow.v1.StreamFlowsResponse
+	25,  // 152: mitmflow.v1.Service.UpdateFlow:output_type -> mitmflow.v1.UpdateFlowResponse
+	27,  // 153: mitmflow.v1.Service.DeleteFlows:output_type -> mitmflow.v1.DeleteFlowsResponse
+	29,  // 154: mitmflow.v1.Service.ExportFlows:output_type -> mitmflow.v1.ExportFlowsResponse
+	14,  // 155: mitmflow.v1.Service.GetFlow:output_type -> mitmflow.v1.GetFlowResponse
+	16,  // 156: mitmflow.v1.Service.GetFlowBody:output_type -> mitmflow.v1.GetFlowBodyResponse
+	18,  // 157: mitmflow.v1.Service.GetFlowFrames:output_type -> mitmflow.v1.GetFlowFramesResponse
+	31,  // 158: mitmflow.v1.Service.ImportFlows:output_type -> mitmflow.v1.ImportFlowsResponse
+	42,  // 159: mitmflow.v1.Service.CreateBackup:output_type -> mitmflow.v1.CreateBackupResponse
+	44,  // 160: mitmflow.v1.Service.RestoreBackup:output_type -> mitmflow.v1.RestoreBackupResponse
+	46,  // 161: mitmflow.v1.Service.SearchArchive:output_type -> mitmflow.v1.SearchArchiveResponse
+	48,  // 162: mitmflow.v1.Service.RestoreArchivedFlows:output_type -> mitmflow.v1.RestoreArchivedFlowsResponse
+	50,  // 163: mitmflow.v1.Service.RestoreFlows:output_type -> mitmflow.v1.RestoreFlowsResponse
+	60,  // 164: mitmflow.v1.Service.GetServerInfo:output_type -> mitmflow.v1.GetServerInfoResponse
+	57,  // 165: mitmflow.v1.Service.ListSubscribers:output_type -> mitmflow.v1.ListSubscribersResponse
+	55,  // 166: mitmflow.v1.Service.ListAuditEvents:output_type -> mitmflow.v1.ListAuditEventsResponse
+	62,  // 167: mitmflow.v1.Service.SendRequest:output_type -> mitmflow.v1.SendRequestResponse
+	64,  // 168: mitmflow.v1.Service.GetCookieTimeline:output_type -> mitmflow.v1.GetCookieTimelineResponse
+	67,  // 169: mitmflow.v1.Service.GetRedirectChain:output_type -> mitmflow.v1.GetRedirectChainResponse
+	33,  // 170: mitmflow.v1.Service.CreateShareBundle:output_type -> mitmflow.v1.CreateShareBundleResponse
+	36,  // 171: mitmflow.v1.Service.SetBaseline:output_type -> mitmflow.v1.SetBaselineResponse
+	38,  // 172: mitmflow.v1.Service.CompareSessions:output_type -> mitmflow.v1.CompareSessionsResponse
+	52,  // 173: mitmflow.v1.Service.UploadFlowBody:output_type -> mitmflow.v1.UploadFlowBodyResponse
+	70,  // 174: mitmflow.v1.Service.DiffWithPrevious:output_type -> mitmflow.v1.DiffWithPreviousResponse
+	72,  // 175: mitmflow.v1.Service.TopFlows:output_type -> mitmflow.v1.TopFlowsResponse
+	74,  // 176: mitmflow.v1.Service.GetBandwidthStats:output_type -> mitmflow.v1.GetBandwidthStatsResponse
+	106, // 177: mitmflow.v1.Service.GetProxyRules:output_type -> mitmflow.v1.GetProxyRulesResponse
+	108, // 178: mitmflow.v1.Service.SetProxyRules:output_type -> mitmflow.v1.SetProxyRulesResponse
+	110, // 179: mitmflow.v1.Service.WatchProxyRules:output_type -> mitmflow.v1.WatchProxyRulesResponse
+	150, // [150:180] is the sub-list for method output_type
+	120, // [120:150] is the sub-list for method input_type
+	120, // [120:120] is the sub-list for extension type_name
+	120, // [120:120] is the sub-list for extension extendee
+	0,   // [0:120] is the sub-list for field type_name
 }
 
 func init() { file_mitmflow_v1_mitmflow_proto_init() }
@@ -3566,16 +17573,21 @@ func file_mitmflow_v1_mitmflow_proto_init() {
 	if File_mitmflow_v1_mitmflow_proto != nil {
 		return
 	}
-	file_mitmflow_v1_mitmflow_proto_msgTypes[7].OneofWrappers = []any{
+	file_mitmflow_v1_mitmflow_proto_msgTypes[12].OneofWrappers = []any{
 		(*streamFlowsResponse_Flow)(nil),
+		(*streamFlowsResponse_Keepalive)(nil),
+	}
+	file_mitmflow_v1_mitmflow_proto_msgTypes[33].OneofWrappers = []any{
+		(*restoreBackupRequest_Data)(nil),
+		(*restoreBackupRequest_Path)(nil),
 	}
-	file_mitmflow_v1_mitmflow_proto_msgTypes[14].OneofWrappers = []any{
+	file_mitmflow_v1_mitmflow_proto_msgTypes[69].OneofWrappers = []any{
 		(*flowSummary_Http)(nil),
 		(*flowSummary_Dns)(nil),
 		(*flowSummary_Tcp)(nil),
 		(*flowSummary_Udp)(nil),
 	}
-	file_mitmflow_v1_mitmflow_proto_msgTypes[19].OneofWrappers = []any{
+	file_mitmflow_v1_mitmflow_proto_msgTypes[74].OneofWrappers = []any{
 		(*flow_HttpFlow)(nil),
 		(*flow_TcpFlow)(nil),
 		(*flow_UdpFlow)(nil),
@@ -3586,8 +17598,8 @@ func file_mitmflow_v1_mitmflow_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mitmflow_v1_mitmflow_proto_rawDesc), len(file_mitmflow_v1_mitmflow_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   22,
+			NumEnums:      10,
+			NumMessages:   113,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
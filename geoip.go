@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/oschwald/geoip2-golang"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// GeoIP looks up the country and ASN of IP addresses in MaxMind databases,
+// e.g. GeoLite2-Country and GeoLite2-ASN.
+type GeoIP struct {
+	readers []*geoip2.Reader
+}
+
+// OpenGeoIP opens the given MaxMind database files. Lookups combine what all
+// of them know about an address.
+func OpenGeoIP(paths []string) (*GeoIP, error) {
+	g := &GeoIP{}
+	for _, path := range paths {
+		r, err := geoip2.Open(path)
+		if err != nil {
+			g.Close() //nolint:errcheck
+			return nil, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		g.readers = append(g.readers, r)
+	}
+	return g, nil
+}
+
+func (g *GeoIP) Close() error {
+	var errs []error
+	for _, r := range g.readers {
+		errs = append(errs, r.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Lookup returns what the databases know about host, or nil when host isn't
+// a public IP address or isn't in any of them.
+func (g *GeoIP) Lookup(host string) *mitmflowv1.GeoInfo {
+	ip := net.ParseIP(host)
+	if g == nil || ip == nil || ip.IsPrivate() || ip.IsLoopback() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return nil
+	}
+	info := &mitmflowv1.GeoInfo{}
+	for _, r := range g.readers {
+		// Readers return an error for lookups their database type doesn't
+		// support, so every reader is simply asked for both.
+		if c, err := r.Country(ip); err == nil && c.Country.IsoCode != "" && info.GetCountryCode() == "" {
+			info.SetCountryCode(c.Country.IsoCode)
+			info.SetCountryName(c.Country.Names["en"])
+		}
+		if a, err := r.ASN(ip); err == nil && a.AutonomousSystemNumber != 0 && info.GetAsn() == 0 {
+			info.SetAsn(uint32(a.AutonomousSystemNumber))
+			info.SetAsOrganization(a.AutonomousSystemOrganization)
+		}
+	}
+	if proto.Size(info) == 0 {
+		return nil
+	}
+	return info
+}
+
+// flowServerIP returns the IP address of the server a flow talked to, which
+// is only known once mitmproxy connected or when the flow addressed an IP.
+func flowServerIP(flow *mitmflowv1.Flow) string {
+	type serverConn interface {
+		GetPeernameHost() string
+		GetAddressHost() string
+	}
+	var server serverConn
+	switch {
+	case flow.GetHttpFlow() != nil:
+		server = flow.GetHttpFlow().GetServer()
+	case flow.GetTcpFlow() != nil:
+		server = flow.GetTcpFlow().GetServer()
+	case flow.GetUdpFlow() != nil:
+		server = flow.GetUdpFlow().GetServer()
+	default:
+		return ""
+	}
+	for _, host := range []string{server.GetPeernameHost(), server.GetAddressHost()} {
+		if net.ParseIP(host) != nil {
+			return host
+		}
+	}
+	return ""
+}
+
+// flowServerGeo returns the GeoIP annotation of the flow's server, if any.
+func flowServerGeo(flow *mitmflowv1.Flow) *mitmflowv1.GeoInfo {
+	if geo := flow.GetHttpFlowExtra().GetServerGeo(); geo != nil {
+		return geo
+	}
+	return flow.GetStreamFlowExtra().GetServerGeo()
+}
+
+// matchGeo reports whether geo has the given country code or ASN, written
+// as "DE", "AS13335" or "13335".
+func matchGeo(geo *mitmflowv1.GeoInfo, value string) bool {
+	if geo == nil {
+		return false
+	}
+	if strings.EqualFold(geo.GetCountryCode(), value) {
+		return true
+	}
+	trimmed := strings.TrimPrefix(strings.ToUpper(value), "AS")
+	asn, err := strconv.ParseUint(trimmed, 10, 32)
+	return err == nil && geo.GetAsn() != 0 && uint32(asn) == geo.GetAsn()
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOpenGeoIP_InvalidFile(t *testing.T) {
+	dir, err := os.MkdirTemp("", "geoip-test")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "GeoLite2-Country.mmdb")
+	require.NoError(t, os.WriteFile(path, []byte("not a database"), 0o644))
+	_, err = OpenGeoIP([]string{path})
+	assert.ErrorContains(t, err, path)
+}
+
+func TestGeoIPLookup_SkipsNonPublicAddresses(t *testing.T) {
+	var disabled *GeoIP
+	assert.Nil(t, disabled.Lookup("8.8.8.8"))
+
+	g := &GeoIP{}
+	for _, host := range []string{"", "example.com", "10.0.0.1", "127.0.0.1", "::1", "fe80::1", "0.0.0.0"} {
+		assert.Nil(t, g.Lookup(host), host)
+	}
+	// Public, but none of the (zero) databases know it.
+	assert.Nil(t, g.Lookup("8.8.8.8"))
+}
+
+func TestFlowServerIP(t *testing.T) {
+	httpFlow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Server: mitmproxyv1.ServerConn_builder{
+				AddressHost:  proto.String("example.com"),
+				PeernameHost: proto.String("93.184.216.34"),
+			}.Build(),
+		}.Build(),
+	}.Build()
+	assert.Equal(t, "93.184.216.34", flowServerIP(httpFlow))
+
+	tcpFlow := mitmflowv1.Flow_builder{
+		TcpFlow: mitmproxyv1.TCPFlow_builder{
+			Server: mitmproxyv1.ServerConn_builder{
+				AddressHost: proto.String("2001:db8::1"),
+			}.Build(),
+		}.Build(),
+	}.Build()
+	assert.Equal(t, "2001:db8::1", flowServerIP(tcpFlow))
+
+	unresolved := mitmflowv1.Flow_builder{
+		UdpFlow: mitmproxyv1.UDPFlow_builder{
+			Server: mitmproxyv1.ServerConn_builder{
+				AddressHost: proto.String("example.com"),
+			}.Build(),
+		}.Build(),
+	}.Build()
+	assert.Empty(t, flowServerIP(unresolved))
+}
+
+func TestServerGeoFilters(t *testing.T) {
+	geo := mitmflowv1.GeoInfo_builder{
+		CountryCode:    proto.String("DE"),
+		CountryName:    proto.String("Germany"),
+		Asn:            proto.Uint32(3320),
+		AsOrganization: proto.String("Deutsche Telekom AG"),
+	}.Build()
+	httpFlow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Request: mitmproxyv1.Request_builder{
+				Method: proto.String("GET"),
+				Url:    proto.String("https://example.de/"),
+			}.Build(),
+		}.Build(),
+		HttpFlowExtra: mitmflowv1.HTTPFlowExtra_builder{ServerGeo: geo}.Build(),
+	}.Build()
+	tcpFlow := mitmflowv1.Flow_builder{
+		TcpFlow:         &mitmproxyv1.TCPFlow{},
+		StreamFlowExtra: mitmflowv1.StreamFlowExtra_builder{ServerGeo: geo}.Build(),
+	}.Build()
+	unknown := mitmflowv1.Flow_builder{TcpFlow: &mitmproxyv1.TCPFlow{}}.Build()
+
+	t.Run("flow filter", func(t *testing.T) {
+		filter := mitmflowv1.FlowFilter_builder{ServerCountries: []string{"us", "de"}}.Build()
+		assert.True(t, matchFlow(httpFlow, filter))
+		assert.True(t, matchFlow(tcpFlow, filter))
+		assert.False(t, matchFlow(unknown, filter))
+	})
+
+	t.Run("filter expression", func(t *testing.T) {
+		for expr, want := range map[string]bool{
+			"~geo de":     true,
+			"~geo AS3320": true,
+			"~geo 3320":   true,
+			"~geo US":     false,
+			"!~geo DE":    false,
+		} {
+			pred, err := parseFilterExpr(expr)
+			require.NoError(t, err)
+			assert.Equal(t, want, pred(httpFlow), expr)
+		}
+		pred, err := parseFilterExpr("!~geo DE")
+		require.NoError(t, err)
+		assert.True(t, pred(unknown))
+	})
+
+	t.Run("har", func(t *testing.T) {
+		entry := generateHAREntry(t, httpFlow)
+		assert.Equal(t, &HARServerGeo{
+			CountryCode:    "DE",
+			CountryName:    "Germany",
+			ASN:            3320,
+			ASOrganization: "Deutsche Telekom AG",
+		}, entry.ServerGeo)
+	})
+}
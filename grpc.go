@@ -3,7 +3,6 @@ package main
 import (
 	"bytes"
 	"compress/gzip"
-	"encoding/base64"
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
@@ -52,59 +51,66 @@ func processProtobufMessage(message []byte, msgDesc protoreflect.MessageDescript
 	return frames
 }
 
+// parseGrpcFrames decodes the length-prefixed messages of a gRPC body. A
+// body captured mid-stream can end in a partial frame: every complete frame
+// before it is still decoded, and the partial one is annotated with how many
+// of its bytes arrived. A message that fails to decompress is annotated the
+// same way rather than failing the whole body.
 func parseGrpcFrames(content []byte, trailers map[string]string, msgDesc protoreflect.MessageDescriptor) ([]string, error) {
 	// For grpc messages, if there is not enough content for a full frame, we should
 	// emit a ContentProtoscopeFrames with an empty string.
-	if len(content) < 5 {
-		return []string{""}, nil
-	}
 	var frames []string
-	buf := bytes.NewBuffer(content)
-	for buf.Len() >= 5 {
-		prefix := make([]byte, 5)
-		if _, err := io.ReadFull(buf, prefix); err != nil {
-			return nil, err
-		}
-		compressed := prefix[0] == 1
-		length := binary.BigEndian.Uint32(prefix[1:])
-
-		if buf.Len() < int(length) {
-			return nil, fmt.Errorf("incomplete grpc frame")
+	if len(content) == 0 {
+		frames = []string{""}
+	}
+	for len(content) > 0 {
+		if len(content) < 5 {
+			frames = append(frames, fmt.Sprintf("incomplete (%d bytes)", len(content)))
+			break
 		}
-
-		message := make([]byte, length)
-		if _, err := io.ReadFull(buf, message); err != nil {
-			return nil, err
+		compressed := content[0] == 1
+		length := binary.BigEndian.Uint32(content[1:5])
+		if uint64(len(content)-5) < uint64(length) {
+			frames = append(frames, fmt.Sprintf("incomplete (%d bytes)", len(content)))
+			break
 		}
+		message := content[5 : 5+length]
+		content = content[5+length:]
 
 		if compressed {
-			gr, err := gzip.NewReader(bytes.NewBuffer(message))
+			decompressed, err := gunzipGrpcMessage(message)
 			if err != nil {
-				return nil, fmt.Errorf("failed to create gzip reader: %w", err)
-			}
-			defer gr.Close() //nolint:errcheck
-			message, err = io.ReadAll(gr)
-			if err != nil {
-				return nil, fmt.Errorf("failed to decompress message: %w", err)
+				frames = append(frames, fmt.Sprintf("failed to decompress message (%d bytes): %v", len(message), err))
+				continue
 			}
+			message = decompressed
 		}
 
 		frames = append(frames, processProtobufMessage(message, msgDesc)...)
 	}
 
-	if statusFrame := parseErrorDetails(trailers["grpc-status-details-bin"]); statusFrame != nil {
+	if statusFrame := parseErrorDetails(getHeaderValue(trailers, "grpc-status-details-bin")); statusFrame != nil {
 		frames = append(frames, *statusFrame)
 	}
 
 	return frames, nil
 }
 
+func gunzipGrpcMessage(message []byte) ([]byte, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(message))
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close() //nolint:errcheck
+	return io.ReadAll(gr)
+}
+
 // parseGrpcWebFrames parses gRPC-Web frames from the content, utilizing headers and trailers for status details.
 func parseGrpcWebFrames(content []byte, headers map[string]string, trailers map[string]string, msgDesc protoreflect.MessageDescriptor) ([]string, error) {
+	var frames []string
 	if len(content) < 5 {
-		return []string{""}, nil
+		frames = []string{""}
 	}
-	var frames []string
 	buf := bytes.NewBuffer(content)
 	for buf.Len() >= 5 {
 		prefix := make([]byte, 1)
@@ -152,11 +158,12 @@ func parseGrpcWebFrames(content []byte, headers map[string]string, trailers map[
 		}
 	}
 
-	if statusFrame := parseErrorDetails(trailers["grpc-status-details-bin"]); statusFrame != nil {
-		frames = append(frames, *statusFrame)
-	}
-	if statusFrame := parseErrorDetails(headers["grpc-status-details-bin"]); statusFrame != nil {
-		frames = append(frames, *statusFrame)
+	// The status details can be in the trailers, in the headers of a
+	// trailers-only response or in the trailer frame of the body.
+	for _, fields := range []map[string]string{trailers, headers, grpcWebTrailers(content)} {
+		if statusFrame := parseErrorDetails(getHeaderValue(fields, "grpc-status-details-bin")); statusFrame != nil {
+			frames = append(frames, *statusFrame)
+		}
 	}
 
 	return frames, nil
@@ -221,15 +228,16 @@ func parseConnectStreamingFrames(content []byte, msgDesc protoreflect.MessageDes
 	return frames, nil
 }
 
+// parseErrorDetails decodes a grpc-status-details-bin value into a
+// google.rpc.Status, as JSON or else protoscope.
 func parseErrorDetails(errorDetailsBin string) *string {
 	if errorDetailsBin == "" {
 		return nil
 	}
+	return formatErrorDetails(decodeBinaryHeader(errorDetailsBin))
+}
 
-	decoded, err := base64.StdEncoding.DecodeString(errorDetailsBin)
-	if err != nil {
-		return nil
-	}
+func formatErrorDetails(decoded []byte) *string {
 
 	if statusJSON := parseErrorStatusAsJSON(decoded); statusJSON != nil {
 		return statusJSON
@@ -169,3 +169,25 @@ func TestParseGrpcFramesWithDescriptor_Streaming(t *testing.T) {
 	assert.Contains(t, frames[1], `"sentence"`)
 	assert.Contains(t, frames[1], `"World"`)
 }
+
+func TestParseGrpcFramesPartial(t *testing.T) {
+	frame := func(flag byte, payload []byte) []byte {
+		return append([]byte{flag, 0, 0, 0, byte(len(payload))}, payload...)
+	}
+	first := frame(0, []byte{0x08, 0x01})
+	second := frame(0, []byte{0x08, 0x02})
+
+	frames, err := parseGrpcFrames(append(append(first, second...), second[:4]...), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1: 1\n", "1: 2\n", "incomplete (4 bytes)"}, frames)
+
+	frames, err = parseGrpcFrames(append(first, second[:6]...), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1: 1\n", "incomplete (6 bytes)"}, frames)
+
+	frames, err = parseGrpcFrames(append(frame(1, []byte("not gzip")), second...), nil, nil)
+	require.NoError(t, err)
+	require.Len(t, frames, 2)
+	assert.Contains(t, frames[0], "failed to decompress message (8 bytes)")
+	assert.Equal(t, "1: 2\n", frames[1])
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+// rpcCommandTool selects the CLI that GenerateRPCCommands writes commands for.
+type rpcCommandTool int
+
+const (
+	toolGrpcurl rpcCommandTool = iota
+	toolBufCurl
+)
+
+// rpcTransportHeaders are set by the RPC protocol itself, so grpcurl and buf
+// curl add them on their own.
+var rpcTransportHeaders = map[string]bool{
+	"accept-encoding":          true,
+	"connect-accept-encoding":  true,
+	"connect-content-encoding": true,
+	"connect-protocol-version": true,
+	"connect-timeout-ms":       true,
+	"content-encoding":         true,
+	"content-length":           true,
+	"content-type":             true,
+	"grpc-accept-encoding":     true,
+	"grpc-encoding":            true,
+	"grpc-timeout":             true,
+	"host":                     true,
+	"te":                       true,
+	"transfer-encoding":        true,
+	"user-agent":               true,
+	"x-grpc-web":               true,
+	"x-user-agent":             true,
+}
+
+// rpcProtocol returns the protocol name buf curl uses for a content type, or
+// "" when the body isn't gRPC, gRPC-Web or Connect.
+func rpcProtocol(contentType string) string {
+	switch {
+	case strings.Contains(contentType, "application/grpc-web"):
+		return "grpcweb"
+	case strings.Contains(contentType, "application/grpc"):
+		return "grpc"
+	case strings.Contains(contentType, "application/connect+proto"),
+		strings.Contains(contentType, "application/proto"):
+		return "connect"
+	}
+	return ""
+}
+
+// GenerateRPCCommands writes a grpcurl or buf curl command that replays the
+// request of each gRPC, gRPC-Web or Connect flow. The request messages come
+// from the frames decoded with a schema, so flows without one are listed as
+// comments instead. Both tools resolve the schema with server reflection.
+func GenerateRPCCommands(flows []*mitmflowv1.Flow, tool rpcCommandTool) ([]byte, error) {
+	var commands []string
+	for _, flow := range flows {
+		h := flow.GetHttpFlow()
+		if h == nil {
+			continue
+		}
+		req := h.GetRequest()
+		contentType, _ := getContentType(req.GetHeaders())
+		protocol := rpcProtocol(contentType)
+		u, err := url.Parse(req.GetUrl())
+		if protocol == "" || err != nil {
+			continue
+		}
+		method := strings.Trim(u.Path, "/")
+		if !strings.Contains(method, "/") {
+			continue
+		}
+
+		data, ok := rpcRequestJSON(req.GetContent(), contentType, flow.GetHttpFlowExtra().GetRequest())
+		if !ok {
+			commands = append(commands, fmt.Sprintf("# %s: the request wasn't decoded with a schema", req.GetUrl()))
+			continue
+		}
+
+		var args []string
+		switch tool {
+		case toolGrpcurl:
+			args = []string{"grpcurl"}
+			if u.Scheme == "http" {
+				args = append(args, "-plaintext")
+			}
+			for _, header := range rpcRequestHeaders(req.GetHeaders()) {
+				args = append(args, "-H "+shellQuote(header))
+			}
+			host := u.Host
+			if u.Port() == "" {
+				host += map[string]string{"http": ":80", "https": ":443"}[u.Scheme]
+			}
+			args = append(args, "-d "+shellQuote(data), host, method)
+		case toolBufCurl:
+			args = []string{"buf curl", "--protocol " + protocol}
+			if u.Scheme == "http" && protocol == "grpc" {
+				args = append(args, "--http2-prior-knowledge")
+			}
+			for _, header := range rpcRequestHeaders(req.GetHeaders()) {
+				args = append(args, "-H "+shellQuote(header))
+			}
+			args = append(args, "-d "+shellQuote(data), shellQuote(u.Scheme+"://"+u.Host+"/"+method))
+		}
+		commands = append(commands, strings.Join(args, " \\\n  "))
+	}
+	if len(commands) == 0 {
+		return nil, nil
+	}
+	return []byte(strings.Join(commands, "\n\n") + "\n"), nil
+}
+
+// rpcRequestJSON joins the decoded request messages into the whitespace
+// separated JSON both tools accept for client streams.
+func rpcRequestJSON(content []byte, contentType string, details *mitmflowv1.MessageDetails) (string, bool) {
+	messages, err := splitRPCMessages(content, contentType)
+	if err != nil {
+		return "", false
+	}
+	if len(messages) == 0 {
+		return "{}", true
+	}
+	frames := details.GetTextualFrames()
+	if len(frames) < len(messages) {
+		return "", false
+	}
+	parts := make([]string, len(messages))
+	for i := range messages {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, []byte(frames[i])); err != nil {
+			return "", false
+		}
+		parts[i] = buf.String()
+	}
+	return strings.Join(parts, "\n"), true
+}
+
+// rpcRequestHeaders returns "Name: value" for the request metadata worth
+// replaying, sorted by name. -bin values are given base64 encoded, which is
+// what grpcurl and buf curl expect, however they were captured.
+func rpcRequestHeaders(headers map[string]string) []string {
+	var result []string
+	for name, value := range headers {
+		lower := strings.ToLower(name)
+		if rpcTransportHeaders[lower] || strings.HasPrefix(lower, ":") {
+			continue
+		}
+		if strings.HasSuffix(lower, "-bin") {
+			value = base64.StdEncoding.EncodeToString(decodeBinaryHeader(value))
+		}
+		result = append(result, name+": "+value)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func rpcTestFlow(rawURL, contentType string, content []byte, frames []string) *mitmflowv1.Flow {
+	return mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Request: mitmproxyv1.Request_builder{
+				Method: proto.String("POST"),
+				Url:    proto.String(rawURL),
+				Headers: map[string]string{
+					"content-type":  contentType,
+					"te":            "trailers",
+					"authorization": "Bearer it's-me",
+				},
+				Content: content,
+			}.Build(),
+		}.Build(),
+		HttpFlowExtra: mitmflowv1.HTTPFlowExtra_builder{
+			Request: mitmflowv1.MessageDetails_builder{TextualFrames: frames}.Build(),
+		}.Build(),
+	}.Build()
+}
+
+func TestGenerateRPCCommands(t *testing.T) {
+	grpcFlow := rpcTestFlow("https://api.example.com/greet.v1.GreetService/Greet", "application/grpc",
+		envelope(0, []byte("\x0a\x03bob")), []string{"{\n  \"name\": \"bob\"\n}"})
+	connectFlow := rpcTestFlow("http://localhost:8080/greet.v1.GreetService/Greet", "application/proto",
+		[]byte("\x0a\x03bob"), []string{`{"name": "bob"}`})
+	undecoded := rpcTestFlow("https://api.example.com/greet.v1.GreetService/Greet", "application/grpc",
+		envelope(0, []byte("\x0a\x03bob")), []string{`1: {"bob"}`})
+	notRPC := rpcTestFlow("https://example.com/api", "application/json", []byte("{}"), nil)
+
+	t.Run("grpcurl", func(t *testing.T) {
+		data, err := GenerateRPCCommands([]*mitmflowv1.Flow{grpcFlow, connectFlow, undecoded, notRPC}, toolGrpcurl)
+		require.NoError(t, err)
+		assert.Equal(t, `grpcurl \
+  -H 'authorization: Bearer it'\''s-me' \
+  -d '{"name":"bob"}' \
+  api.example.com:443 \
+  greet.v1.GreetService/Greet
+
+grpcurl \
+  -plaintext \
+  -H 'authorization: Bearer it'\''s-me' \
+  -d '{"name":"bob"}' \
+  localhost:8080 \
+  greet.v1.GreetService/Greet
+
+# https://api.example.com/greet.v1.GreetService/Greet: the request wasn't decoded with a schema
+`, string(data))
+	})
+
+	t.Run("buf curl", func(t *testing.T) {
+		data, err := GenerateRPCCommands([]*mitmflowv1.Flow{grpcFlow, connectFlow}, toolBufCurl)
+		require.NoError(t, err)
+		assert.Equal(t, `buf curl \
+  --protocol grpc \
+  -H 'authorization: Bearer it'\''s-me' \
+  -d '{"name":"bob"}' \
+  'https://api.example.com/greet.v1.GreetService/Greet'
+
+buf curl \
+  --protocol connect \
+  -H 'authorization: Bearer it'\''s-me' \
+  -d '{"name":"bob"}' \
+  'http://localhost:8080/greet.v1.GreetService/Greet'
+`, string(data))
+	})
+
+	t.Run("no rpc flows", func(t *testing.T) {
+		data, err := GenerateRPCCommands([]*mitmflowv1.Flow{notRPC}, toolGrpcurl)
+		require.NoError(t, err)
+		assert.Empty(t, data)
+	})
+}
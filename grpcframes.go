@@ -0,0 +1,157 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+// GenerateGRPCFrames writes every message of the gRPC, gRPC-Web and Connect
+// flows as its own file in a zip, so payloads can be fed to other protobuf
+// tooling. Messages are stored as
+// <service>/<method>/<flow id>/<request|response>_NNN.bin, with the
+// decoded message next to it as .json when the schema was known.
+func GenerateGRPCFrames(flows []*mitmflowv1.Flow) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, flow := range flows {
+		h := flow.GetHttpFlow()
+		if h == nil {
+			continue
+		}
+		u, err := url.Parse(h.GetRequest().GetUrl())
+		if err != nil {
+			continue
+		}
+		service, method, ok := strings.Cut(strings.Trim(u.Path, "/"), "/")
+		if !ok {
+			continue
+		}
+		dir := zipPathSegment(service) + "/" + zipPathSegment(method) + "/" + zipPathSegment(GetFlowID(flow))
+
+		extra := flow.GetHttpFlowExtra()
+		for _, side := range []struct {
+			name    string
+			headers map[string]string
+			content []byte
+			details *mitmflowv1.MessageDetails
+		}{
+			{"request", h.GetRequest().GetHeaders(), h.GetRequest().GetContent(), extra.GetRequest()},
+			{"response", h.GetResponse().GetHeaders(), h.GetResponse().GetContent(), extra.GetResponse()},
+		} {
+			contentType, _ := getContentType(side.headers)
+			// Truncated bodies end in a partial frame, so the messages before
+			// an error are still exported.
+			messages, _ := splitRPCMessages(side.content, contentType)
+			frames := side.details.GetTextualFrames()
+			for i, msg := range messages {
+				name := fmt.Sprintf("%s/%s_%03d", dir, side.name, i)
+				if err := writeZipFile(zw, name+".bin", msg); err != nil {
+					return nil, err
+				}
+				// Textual frames line up with the messages; anything after them
+				// is trailers or status details.
+				if i < len(frames) && json.Valid([]byte(frames[i])) {
+					if err := writeZipFile(zw, name+".json", []byte(frames[i])); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// zipPathSegment makes s safe to use as a single path element in a zip.
+func zipPathSegment(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, s)
+	if s == "" || s == "." || s == ".." {
+		return "_"
+	}
+	return s
+}
+
+// splitRPCMessages returns the serialized protobuf messages in a body, with
+// the envelope, compression and any trailer frames removed.
+func splitRPCMessages(content []byte, contentType string) ([][]byte, error) {
+	switch {
+	case strings.Contains(contentType, "application/grpc-web-text"):
+		decoded, err := base64.StdEncoding.DecodeString(string(content))
+		if err != nil {
+			return nil, err
+		}
+		return splitEnvelopedMessages(decoded, 0x80)
+	case strings.Contains(contentType, "application/grpc-web"):
+		return splitEnvelopedMessages(content, 0x80)
+	case strings.Contains(contentType, "application/grpc"):
+		return splitEnvelopedMessages(content, 0)
+	case strings.Contains(contentType, "application/connect+proto"):
+		return splitEnvelopedMessages(content, 0x02)
+	case strings.Contains(contentType, "application/proto"),
+		strings.Contains(contentType, "application/protobuf"),
+		strings.Contains(contentType, "application/x-protobuf"):
+		if len(content) == 0 {
+			return nil, nil
+		}
+		return [][]byte{content}, nil
+	}
+	return nil, nil
+}
+
+// splitEnvelopedMessages reads length-prefixed frames. Frames with any of the
+// trailerFlags bits set are skipped; bit 0 marks a gzip compressed message.
+func splitEnvelopedMessages(content []byte, trailerFlags byte) ([][]byte, error) {
+	var messages [][]byte
+	for len(content) >= 5 {
+		flags := content[0]
+		length := binary.BigEndian.Uint32(content[1:5])
+		if uint64(len(content)-5) < uint64(length) {
+			return messages, fmt.Errorf("incomplete frame")
+		}
+		message := content[5 : 5+length]
+		content = content[5+length:]
+		if flags&trailerFlags != 0 {
+			continue
+		}
+		if flags&0x01 != 0 {
+			gr, err := gzip.NewReader(bytes.NewReader(message))
+			if err != nil {
+				return messages, fmt.Errorf("failed to create gzip reader: %w", err)
+			}
+			message, err = io.ReadAll(gr)
+			gr.Close() //nolint:errcheck
+			if err != nil {
+				return messages, fmt.Errorf("failed to decompress message: %w", err)
+			}
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
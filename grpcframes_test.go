@@ -0,0 +1,123 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func envelope(flags byte, message []byte) []byte {
+	frame := make([]byte, 5, 5+len(message))
+	frame[0] = flags
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(message)))
+	return append(frame, message...)
+}
+
+func TestSplitRPCMessages(t *testing.T) {
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	_, err := gw.Write([]byte("compressed"))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	grpcWeb := append(envelope(0, []byte("one")), envelope(0x80, []byte("grpc-status: 0\r\n"))...)
+	connect := append(append(envelope(0, []byte("one")), envelope(1, gz.Bytes())...), envelope(0x02, []byte("{}"))...)
+	truncated := append(envelope(0, []byte("one")), envelope(0, []byte("two"))[:6]...)
+
+	tests := []struct {
+		name        string
+		contentType string
+		content     []byte
+		want        [][]byte
+		wantErr     bool
+	}{
+		{"grpc", "application/grpc", append(envelope(0, []byte("one")), envelope(1, gz.Bytes())...), [][]byte{[]byte("one"), []byte("compressed")}, false},
+		{"grpc-web skips trailers", "application/grpc-web+proto", grpcWeb, [][]byte{[]byte("one")}, false},
+		{"connect skips end of stream", "application/connect+proto", connect, [][]byte{[]byte("one"), []byte("compressed")}, false},
+		{"unary proto", "application/proto", []byte("whole"), [][]byte{[]byte("whole")}, false},
+		{"truncated", "application/grpc", truncated, [][]byte{[]byte("one")}, true},
+		{"not rpc", "application/json", []byte("{}"), nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitRPCMessages(tt.content, tt.contentType)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGenerateGRPCFrames(t *testing.T) {
+	flow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Id: proto.String("flow-1"),
+			Request: mitmproxyv1.Request_builder{
+				Method:  proto.String("POST"),
+				Url:     proto.String("https://api.example.com/greet.v1.GreetService/Greet"),
+				Headers: map[string]string{"Content-Type": "application/grpc"},
+				Content: envelope(0, []byte("\x0a\x03bob")),
+			}.Build(),
+			Response: mitmproxyv1.Response_builder{
+				StatusCode: proto.Int32(200),
+				Headers:    map[string]string{"Content-Type": "application/grpc"},
+				Content:    append(envelope(0, []byte("\x0a\x02hi")), envelope(0, []byte("\x0a\x03bye"))...),
+			}.Build(),
+		}.Build(),
+		HttpFlowExtra: mitmflowv1.HTTPFlowExtra_builder{
+			Request: mitmflowv1.MessageDetails_builder{
+				TextualFrames: []string{`{"name": "bob"}`},
+			}.Build(),
+			Response: mitmflowv1.MessageDetails_builder{
+				// Decoded without a schema, so there's no JSON to export.
+				TextualFrames: []string{`1: {"hi"}`, `1: {"bye"}`},
+			}.Build(),
+		}.Build(),
+	}.Build()
+	plain := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Request: mitmproxyv1.Request_builder{
+				Url:     proto.String("https://example.com/index.html"),
+				Content: []byte("hello"),
+			}.Build(),
+		}.Build(),
+	}.Build()
+
+	data, err := GenerateGRPCFrames([]*mitmflowv1.Flow{flow, plain})
+	require.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		files[f.Name] = string(b)
+	}
+	assert.Equal(t, map[string]string{
+		"greet.v1.GreetService/Greet/flow-1/request_000.bin":  "\x0a\x03bob",
+		"greet.v1.GreetService/Greet/flow-1/request_000.json": `{"name": "bob"}`,
+		"greet.v1.GreetService/Greet/flow-1/response_000.bin": "\x0a\x02hi",
+		"greet.v1.GreetService/Greet/flow-1/response_001.bin": "\x0a\x03bye",
+	}, files)
+}
+
+func TestZipPathSegment(t *testing.T) {
+	assert.Equal(t, "greet.v1.GreetService", zipPathSegment("greet.v1.GreetService"))
+	assert.Equal(t, "_", zipPathSegment(".."))
+	assert.Equal(t, "a_b", zipPathSegment("a/b"))
+}
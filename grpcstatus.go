@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/connect"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxygrpcv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// grpcStatusName returns the canonical name of a gRPC status code, e.g.
+// "UNAVAILABLE" for 14.
+func grpcStatusName(code uint32) string {
+	if code == 0 {
+		return "OK"
+	}
+	return strings.ToUpper(connect.Code(code).String())
+}
+
+// responseGrpcStatus returns the status of a gRPC or gRPC-Web response, or
+// nil when it has none. The status is in the trailers, in the headers of a
+// trailers-only response, or for gRPC-Web in the trailer frame of the body.
+func responseGrpcStatus(resp *mitmproxygrpcv1.Response, contentType string) *mitmflowv1.GrpcStatus {
+	if !strings.Contains(contentType, "application/grpc") {
+		return nil
+	}
+	fields := resp.GetTrailers()
+	if getHeaderValue(fields, "grpc-status") == "" {
+		fields = resp.GetHeaders()
+	}
+	if getHeaderValue(fields, "grpc-status") == "" && strings.Contains(contentType, "application/grpc-web") {
+		fields = grpcWebTrailers(resp.GetContent())
+	}
+	value := getHeaderValue(fields, "grpc-status")
+	if value == "" {
+		return nil
+	}
+	code, err := strconv.ParseUint(strings.TrimSpace(value), 10, 32)
+	if err != nil {
+		return nil
+	}
+	return mitmflowv1.GrpcStatus_builder{
+		Code:    proto.Uint32(uint32(code)),
+		Name:    proto.String(grpcStatusName(uint32(code))),
+		Message: proto.String(percentDecode(getHeaderValue(fields, "grpc-message"))),
+	}.Build()
+}
+
+// grpcWebTrailers reads the trailer frame of a gRPC-Web body, which holds
+// HTTP/1 style header lines.
+func grpcWebTrailers(content []byte) map[string]string {
+	for len(content) >= 5 {
+		flags, n := content[0], binary.BigEndian.Uint32(content[1:5])
+		if uint64(len(content)-5) < uint64(n) {
+			return nil
+		}
+		frame := content[5 : 5+n]
+		content = content[5+n:]
+		if flags&0x80 == 0 {
+			continue
+		}
+		trailers := make(map[string]string)
+		for _, line := range bytes.Split(frame, []byte("\r\n")) {
+			if name, value, ok := bytes.Cut(line, []byte(":")); ok {
+				trailers[strings.ToLower(string(bytes.TrimSpace(name)))] = string(bytes.TrimSpace(value))
+			}
+		}
+		return trailers
+	}
+	return nil
+}
+
+// percentDecode decodes a grpc-message, which is percent-encoded UTF-8.
+// Malformed escapes are kept as they are, as the gRPC spec asks.
+func percentDecode(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' && i+2 < len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+3], 16, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// matchGrpcStatus reports whether a flow's response has one of the named
+// gRPC statuses.
+func matchGrpcStatus(flow *mitmflowv1.Flow, names []string) bool {
+	status := flow.GetHttpFlowExtra().GetResponse().GetGrpcStatus()
+	if status == nil {
+		return false
+	}
+	for _, name := range names {
+		if strings.EqualFold(name, status.GetName()) {
+			return true
+		}
+	}
+	return false
+}
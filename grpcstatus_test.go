@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestResponseGrpcStatus(t *testing.T) {
+	resp := mitmproxyv1.Response_builder{
+		Headers:  map[string]string{"content-type": "application/grpc"},
+		Trailers: map[string]string{"grpc-status": "14", "grpc-message": "upstream%20connect%20error%3A%20%E2%9C%97 100%"},
+	}.Build()
+	status := responseGrpcStatus(resp, "application/grpc")
+	require.NotNil(t, status)
+	assert.Equal(t, uint32(14), status.GetCode())
+	assert.Equal(t, "UNAVAILABLE", status.GetName())
+	assert.Equal(t, "upstream connect error: ✗ 100%", status.GetMessage())
+
+	// Trailers-only responses carry the status in the headers.
+	resp = mitmproxyv1.Response_builder{
+		Headers: map[string]string{"content-type": "application/grpc", "grpc-status": "0"},
+	}.Build()
+	assert.Equal(t, "OK", responseGrpcStatus(resp, "application/grpc").GetName())
+
+	// gRPC-Web sends its trailers in the body.
+	trailer := "grpc-status: 5\r\ngrpc-message: no such user\r\n"
+	body := append([]byte{0, 0, 0, 0, 0}, 0x80, 0, 0, 0, byte(len(trailer)))
+	resp = mitmproxyv1.Response_builder{Content: append(body, trailer...)}.Build()
+	status = responseGrpcStatus(resp, "application/grpc-web+proto")
+	assert.Equal(t, "NOT_FOUND", status.GetName())
+	assert.Equal(t, "no such user", status.GetMessage())
+
+	assert.Nil(t, responseGrpcStatus(mitmproxyv1.Response_builder{
+		Headers: map[string]string{"grpc-status": "0"},
+	}.Build(), "application/json"))
+}
+
+func TestFilterGrpcStatus(t *testing.T) {
+	server, err := NewMITMFlowServer(nil, nil)
+	require.NoError(t, err)
+	flow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Request: mitmproxyv1.Request_builder{
+				Method: proto.String("POST"),
+				Url:    proto.String("https://example.com/greet.v1.GreetService/Greet"),
+			}.Build(),
+			Response: mitmproxyv1.Response_builder{
+				StatusCode: proto.Int32(200),
+				Headers:    map[string]string{"Content-Type": "application/grpc"},
+				Trailers:   map[string]string{"grpc-status": "4", "grpc-message": "deadline%20exceeded"},
+			}.Build(),
+		}.Build(),
+	}.Build()
+	server.preprocessFlow(flow)
+
+	filter := mitmflowv1.FlowFilter_builder{
+		Http: mitmflowv1.HttpFilter_builder{GrpcStatuses: []string{"deadline_exceeded"}}.Build(),
+	}.Build()
+	assert.True(t, matchFlow(flow, filter))
+	filter.GetHttp().SetGrpcStatuses([]string{"UNAVAILABLE"})
+	assert.False(t, matchFlow(flow, filter))
+
+	pred, err := parseFilterExpr("~grpc ^deadline")
+	require.NoError(t, err)
+	assert.True(t, pred(flow))
+}
@@ -4,7 +4,9 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -52,6 +54,31 @@ type HAREntry struct {
 	Timings         HARTimings  `json:"timings"`
 	ServerIPAddress string      `json:"serverIPAddress,omitempty"`
 	Connection      string      `json:"connection,omitempty"`
+
+	// Chrome DevTools extensions for WebSocket sessions.
+	ResourceType      string                `json:"_resourceType,omitempty"`
+	WebSocketMessages []HARWebSocketMessage `json:"_webSocketMessages,omitempty"`
+
+	ServerGeo *HARServerGeo `json:"_serverGeo,omitempty"`
+	// Metadata is the flow's key/value metadata.
+	Metadata map[string]string `json:"_metadata,omitempty"`
+}
+
+// HARServerGeo is the GeoIP annotation of the server address.
+type HARServerGeo struct {
+	CountryCode    string `json:"countryCode,omitempty"`
+	CountryName    string `json:"countryName,omitempty"`
+	ASN            uint32 `json:"asn,omitempty"`
+	ASOrganization string `json:"asOrganization,omitempty"`
+}
+
+// HARWebSocketMessage is a frame in Chrome's _webSocketMessages format. Time is
+// in seconds since the epoch; binary frames carry base64 data.
+type HARWebSocketMessage struct {
+	Type   string  `json:"type"` // "send" or "receive"
+	Time   float64 `json:"time"`
+	Opcode int     `json:"opcode"` // 1 for text, 2 for binary
+	Data   string  `json:"data"`
 }
 
 type HARRequest struct {
@@ -64,6 +91,9 @@ type HARRequest struct {
 	PostData    *HARPostData       `json:"postData,omitempty"`
 	HeadersSize int                `json:"headersSize"`
 	BodySize    int                `json:"bodySize"`
+	// Trailers is an extension for HTTP trailers, which gRPC sends its
+	// status in.
+	Trailers []HARNameValuePair `json:"_trailers,omitempty"`
 }
 
 type HARResponse struct {
@@ -76,6 +106,7 @@ type HARResponse struct {
 	RedirectURL string             `json:"redirectURL"`
 	HeadersSize int                `json:"headersSize"`
 	BodySize    int                `json:"bodySize"`
+	Trailers    []HARNameValuePair `json:"_trailers,omitempty"`
 }
 
 type HARCookie struct {
@@ -95,7 +126,7 @@ type HARNameValuePair struct {
 
 type HARPostData struct {
 	MimeType string             `json:"mimeType"`
-	Params   []HARPostDataParam `json:"params,omitempty"` // For multipart/form-data
+	Params   []HARPostDataParam `json:"params,omitempty"` // For form bodies
 	Text     string             `json:"text"`
 }
 
@@ -126,40 +157,18 @@ type HARTimings struct {
 
 // GenerateHAR creates a HAR file content from a slice of Flows.
 func GenerateHAR(flows []*mitmflowv1.Flow) ([]byte, error) {
-	// Find earliest timestamp
-	var earliestTime time.Time
+	var httpFlows []*mitmflowv1.Flow
 	for _, f := range flows {
-		httpFlow := f.GetHttpFlow()
-		if httpFlow == nil {
-			continue
-		}
-
-		ts := getFlowTime(httpFlow.GetRequest().GetTimestampStart())
-		if !ts.IsZero() {
-			if earliestTime.IsZero() || ts.Before(earliestTime) {
-				earliestTime = ts
-			}
+		if f.GetHttpFlow() != nil {
+			httpFlows = append(httpFlows, f)
 		}
 	}
 
-	pageID := "page_0"
-	pages := []HARPage{}
-	if !earliestTime.IsZero() {
-		pages = append(pages, HARPage{
-			ID:              pageID,
-			StartedDateTime: earliestTime.Format(time.RFC3339Nano),
-			Title:           "mitmflow capture",
-			PageTimings:     HARPageTimings{},
-		})
-	}
+	pages, pageRefs := groupHARPages(httpFlows)
 
 	entries := []HAREntry{}
-	for _, f := range flows {
-		httpFlow := f.GetHttpFlow()
-		if httpFlow == nil {
-			continue
-		}
-		entry := convertToHAREntry(f, httpFlow, pageID)
+	for i, f := range httpFlows {
+		entry := convertToHAREntry(f, f.GetHttpFlow(), pageRefs[i])
 		entries = append(entries, entry)
 	}
 
@@ -178,6 +187,143 @@ func GenerateHAR(flows []*mitmflowv1.Flow) ([]byte, error) {
 	return json.MarshalIndent(har, "", "  ")
 }
 
+// groupHARPages infers pages the way a browser would record them: every
+// top-level document request starts a page, redirects stay on the page that
+// was redirected, and subresources join the page named by their Referer,
+// following chains such as a font referenced from a stylesheet. It returns
+// the pages and the page ID for each flow. Captures without any document
+// requests get a single page holding everything.
+func groupHARPages(flows []*mitmflowv1.Flow) ([]HARPage, []string) {
+	pageRefs := make([]string, len(flows))
+	order := make([]int, len(flows))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return flowRequestTime(flows[order[a]]).Before(flowRequestTime(flows[order[b]]))
+	})
+
+	pages := []HARPage{}
+	pageOrigins := []string{}
+	pageByURL := make(map[string]string)
+	redirects := make(map[string]string)
+
+	pageForReferer := func(referer string) string {
+		if referer == "" {
+			return ""
+		}
+		if id, ok := pageByURL[stripFragment(referer)]; ok {
+			return id
+		}
+		// Cross-origin requests usually only send the origin as Referer.
+		origin := urlOrigin(referer)
+		for i := len(pages) - 1; i >= 0; i-- {
+			if pageOrigins[i] == origin {
+				return pages[i].ID
+			}
+		}
+		return ""
+	}
+
+	for _, i := range order {
+		httpFlow := flows[i].GetHttpFlow()
+		req := httpFlow.GetRequest()
+		u := stripFragment(getPrettyURL(req))
+
+		var id string
+		if isDocumentRequest(httpFlow) {
+			if redirected, ok := redirects[u]; ok {
+				id = redirected
+			} else {
+				id = fmt.Sprintf("page_%d", len(pages))
+				pages = append(pages, HARPage{
+					ID:              id,
+					StartedDateTime: flowRequestTime(flows[i]).Format(time.RFC3339Nano),
+					Title:           u,
+				})
+				pageOrigins = append(pageOrigins, urlOrigin(u))
+			}
+		} else {
+			id = pageForReferer(getHeaderValue(req.GetHeaders(), "Referer"))
+		}
+		if id == "" {
+			continue
+		}
+		pageRefs[i] = id
+		pageByURL[u] = id
+
+		res := httpFlow.GetResponse()
+		if code := res.GetStatusCode(); code >= 300 && code < 400 {
+			if location := getHeaderValue(res.GetHeaders(), "Location"); location != "" {
+				if base, err := url.Parse(u); err == nil {
+					if target, err := base.Parse(location); err == nil {
+						redirects[stripFragment(target.String())] = id
+					}
+				}
+			}
+		}
+	}
+
+	if len(pages) > 0 {
+		return pages, pageRefs
+	}
+
+	// No navigations were captured, so put everything on one page.
+	var earliestTime time.Time
+	for _, f := range flows {
+		ts := flowRequestTime(f)
+		if !ts.IsZero() && (earliestTime.IsZero() || ts.Before(earliestTime)) {
+			earliestTime = ts
+		}
+	}
+	if earliestTime.IsZero() {
+		return pages, pageRefs
+	}
+	for i := range pageRefs {
+		pageRefs[i] = "page_0"
+	}
+	return []HARPage{{
+		ID:              "page_0",
+		StartedDateTime: earliestTime.Format(time.RFC3339Nano),
+		Title:           "mitmflow capture",
+		PageTimings:     HARPageTimings{},
+	}}, pageRefs
+}
+
+// isDocumentRequest reports whether a request is a top-level navigation.
+// Sec-Fetch-Dest is authoritative when present; otherwise a GET that asked
+// for and received HTML counts.
+func isDocumentRequest(httpFlow *mitmproxyv1.HTTPFlow) bool {
+	req := httpFlow.GetRequest()
+	if !strings.EqualFold(req.GetMethod(), "GET") {
+		return false
+	}
+	if dest := getHeaderValue(req.GetHeaders(), "Sec-Fetch-Dest"); dest != "" {
+		return dest == "document"
+	}
+	return strings.Contains(getHeaderValue(req.GetHeaders(), "Accept"), "text/html") &&
+		strings.Contains(getHeaderValue(httpFlow.GetResponse().GetHeaders(), "Content-Type"), "text/html")
+}
+
+func flowRequestTime(flow *mitmflowv1.Flow) time.Time {
+	return getFlowTime(flow.GetHttpFlow().GetRequest().GetTimestampStart())
+}
+
+func stripFragment(u string) string {
+	if i := strings.IndexByte(u, '#'); i >= 0 {
+		return u[:i]
+	}
+	return u
+}
+
+func urlOrigin(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return ""
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
+
 func convertToHAREntry(flow *mitmflowv1.Flow, httpFlow *mitmproxyv1.HTTPFlow, pageRef string) HAREntry {
 	req := httpFlow.GetRequest()
 	res := httpFlow.GetResponse()
@@ -202,7 +348,14 @@ func convertToHAREntry(flow *mitmflowv1.Flow, httpFlow *mitmproxyv1.HTTPFlow, pa
 		receive = float64(resEnd.Sub(resStart).Milliseconds())
 	}
 
-	totalTime := send + wait + receive
+	timings := HARTimings{
+		Send:    send,
+		Wait:    wait,
+		Receive: receive,
+	}
+	setConnectionTimings(&timings, httpFlow.GetServer(), reqStart)
+
+	totalTime := timings.Connect + timings.Send + timings.Wait + timings.Receive
 	startedDateTime := time.Now().Format(time.RFC3339Nano)
 	if !reqStart.IsZero() {
 		startedDateTime = reqStart.Format(time.RFC3339Nano)
@@ -215,14 +368,24 @@ func convertToHAREntry(flow *mitmflowv1.Flow, httpFlow *mitmproxyv1.HTTPFlow, pa
 		HTTPVersion: req.GetHttpVersion(), // Placeholder, actual version might be needed from packet
 		Headers:     convertHeaders(req.GetHeaders()),
 		QueryString: parseQueryString(req.GetPrettyUrl()),
+		Cookies:     parseRequestCookies(req.GetHeaders()),
 		HeadersSize: -1,
 		BodySize:    len(req.GetContent()),
+		Trailers:    convertHeaders(req.GetTrailers()),
 	}
 
 	if len(req.GetContent()) > 0 && isBodyMethod(req.GetMethod()) {
+		body := req.GetContent()
+		if decoded, ok := decodeContentEncoding(body, getHeaderValue(req.GetHeaders(), "Content-Encoding")); ok {
+			body = decoded
+		}
+		if frames, ok := decodedFrames(flow.GetHttpFlowExtra().GetRequest()); ok {
+			body = []byte(frames)
+		}
 		harReq.PostData = &HARPostData{
 			MimeType: getHeaderValue(req.GetHeaders(), "Content-Type"),
-			Text:     string(req.GetContent()), // TODO: Handle binary content more gracefully if needed? HAR spec says text.
+			Params:   harFormParams(req.GetHeaders(), body),
+			Text:     string(body), // TODO: Handle binary content more gracefully if needed? HAR spec says text.
 		}
 	}
 
@@ -232,12 +395,14 @@ func convertToHAREntry(flow *mitmflowv1.Flow, httpFlow *mitmproxyv1.HTTPFlow, pa
 		StatusText:  res.GetReason(), // Or derive from status code
 		HTTPVersion: res.GetHttpVersion(),
 		Headers:     convertHeaders(res.GetHeaders()),
+		Cookies:     parseResponseCookies(res.GetHeaders()),
 		HeadersSize: -1,
 		BodySize:    len(res.GetContent()),
+		Trailers:    convertHeaders(res.GetTrailers()),
 	}
 	
 	// Content
-	harRes.Content = createHARContent(res.GetContent(), flow.GetHttpFlowExtra())
+	harRes.Content = createHARContent(res, flow.GetHttpFlowExtra().GetResponse())
 
 	serverIP := ""
 	if httpFlow.GetServer() != nil {
@@ -249,28 +414,90 @@ func convertToHAREntry(flow *mitmflowv1.Flow, httpFlow *mitmproxyv1.HTTPFlow, pa
 		connection = fmt.Sprintf("%d", httpFlow.GetServer().GetAddressPort())
 	}
 
-	return HAREntry{
+	entry := HAREntry{
 		Pageref:         pageRef,
 		StartedDateTime: startedDateTime,
 		Time:            totalTime,
 		Request:         harReq,
 		Response:        harRes,
-		Timings: HARTimings{
-			Send:    send,
-			Wait:    wait,
-			Receive: receive,
-		},
+		Timings:         timings,
 		ServerIPAddress: serverIP,
 		Connection:      connection,
 		Cache:           struct{}{},
 	}
+	if httpFlow.GetIsWebsocket() {
+		entry.ResourceType = "websocket"
+		entry.WebSocketMessages = convertWebSocketMessages(httpFlow.GetWebsocketMessages())
+	}
+	if geo := flow.GetHttpFlowExtra().GetServerGeo(); geo != nil {
+		entry.ServerGeo = &HARServerGeo{
+			CountryCode:    geo.GetCountryCode(),
+			CountryName:    geo.GetCountryName(),
+			ASN:            geo.GetAsn(),
+			ASOrganization: geo.GetAsOrganization(),
+		}
+	}
+	if len(flow.GetMetadata()) > 0 {
+		entry.Metadata = flow.GetMetadata()
+	}
+	return entry
+}
+
+// convertWebSocketMessages converts captured frames to Chrome's format.
+// mitmproxy doesn't export the frame opcode, so text and binary frames are
+// told apart by their content.
+func convertWebSocketMessages(messages []*mitmproxyv1.WebSocketMessage) []HARWebSocketMessage {
+	res := make([]HARWebSocketMessage, 0, len(messages))
+	for _, msg := range messages {
+		m := HARWebSocketMessage{
+			Type:   "receive",
+			Opcode: 1,
+			Data:   string(msg.GetContent()),
+		}
+		if msg.GetFromClient() {
+			m.Type = "send"
+		}
+		if ts := getFlowTime(msg.GetTimestamp()); !ts.IsZero() {
+			m.Time = float64(ts.UnixNano()) / float64(time.Second)
+		}
+		if isBinary(msg.GetContent()) {
+			m.Opcode = 2
+			m.Data = base64.StdEncoding.EncodeToString(msg.GetContent())
+		}
+		res = append(res, m)
+	}
+	return res
+}
+
+// setConnectionTimings fills in connect and ssl times when the server
+// connection was opened for this request rather than reused. mitmproxy
+// doesn't time name resolution separately, so it is part of connect and dns
+// is reported as not applicable.
+func setConnectionTimings(timings *HARTimings, server *mitmproxyv1.ServerConn, reqStart time.Time) {
+	connStart := getFlowTime(server.GetTimestampStart())
+	tcpSetup := getFlowTime(server.GetTimestampTcpSetup())
+	if connStart.IsZero() || tcpSetup.IsZero() || reqStart.IsZero() || connStart.Before(reqStart) {
+		return
+	}
+
+	connectEnd := tcpSetup
+	if tlsSetup := getFlowTime(server.GetTimestampTlsSetup()); tlsSetup.After(tcpSetup) {
+		// Per the HAR spec, ssl is also included in connect.
+		timings.Ssl = float64(tlsSetup.Sub(tcpSetup).Milliseconds())
+		connectEnd = tlsSetup
+	}
+	timings.DNS = -1
+	timings.Connect = float64(connectEnd.Sub(connStart).Milliseconds())
+	// The connection is opened after the request arrives, so it was counted
+	// as waiting for the response.
+	timings.Wait = max(timings.Wait-timings.Connect, 0)
 }
 
-func createHARContent(content []byte, extra *mitmflowv1.HTTPFlowExtra) HARContent {
+func createHARContent(res *mitmproxyv1.Response, details *mitmflowv1.MessageDetails) HARContent {
 	// Defaults
 	mimeType := "application/octet-stream"
-	if extra != nil && extra.GetResponse() != nil && extra.GetResponse().GetEffectiveContentType() != "" {
-		mimeType = extra.GetResponse().GetEffectiveContentType()
+	if details.GetEffectiveContentType() != "" {
+		mimeType = details.GetEffectiveContentType()
 	}
 
 	// Check for common text types
@@ -280,16 +507,27 @@ func createHARContent(content []byte, extra *mitmflowv1.HTTPFlowExtra) HARConten
 		strings.Contains(mimeType, "javascript") ||
 		strings.Contains(mimeType, "html")
 
+	content := res.GetContent()
 	harContent := HARContent{
 		Size:     len(content),
 		MimeType: mimeType,
 	}
 
+	// size is the decoded size; compression is how many bytes the
+	// Content-Encoding saved on the wire.
+	if decoded, ok := decodeContentEncoding(content, getHeaderValue(res.GetHeaders(), "Content-Encoding")); ok {
+		harContent.Size = len(decoded)
+		harContent.Compression = len(decoded) - len(content)
+		content = decoded
+	}
+
 	if len(content) == 0 {
 		return harContent
 	}
 
-	if isText {
+	if frames, ok := decodedFrames(details); ok {
+		harContent.Text = frames
+	} else if isText {
 		harContent.Text = string(content)
 	} else {
 		harContent.Text = base64.StdEncoding.EncodeToString(content)
@@ -299,6 +537,84 @@ func createHARContent(content []byte, extra *mitmflowv1.HTTPFlowExtra) HARConten
 	return harContent
 }
 
+// decodedFrames returns the decoded frames of a protobuf, gRPC or DNS body,
+// which are more useful to HAR consumers than the raw bytes. Hexdumps of
+// other binary bodies are left out in favor of base64.
+func decodedFrames(details *mitmflowv1.MessageDetails) (string, bool) {
+	frames := details.GetTextualFrames()
+	contentType := details.GetEffectiveContentType()
+	if len(frames) == 0 || !(strings.Contains(contentType, "proto") ||
+		strings.Contains(contentType, "grpc") ||
+		strings.Contains(contentType, "dns-message")) {
+		return "", false
+	}
+	return strings.Join(frames, "\n"), true
+}
+
+// parseRequestCookies turns the Cookie header into HAR cookies.
+func parseRequestCookies(headers map[string]string) []HARCookie {
+	cookies := []HARCookie{}
+	header := getHeaderValue(headers, "Cookie")
+	if header == "" {
+		return cookies
+	}
+	parsed, err := http.ParseCookie(header)
+	if err != nil {
+		return cookies
+	}
+	for _, c := range parsed {
+		cookies = append(cookies, HARCookie{Name: c.Name, Value: c.Value})
+	}
+	return cookies
+}
+
+// parseResponseCookies turns Set-Cookie headers into HAR cookies. Headers are
+// flattened to one value per name, so several Set-Cookie headers arrive joined
+// by commas or newlines.
+func parseResponseCookies(headers map[string]string) []HARCookie {
+	cookies := []HARCookie{}
+	for _, line := range splitSetCookie(getHeaderValue(headers, "Set-Cookie")) {
+		c, err := http.ParseSetCookie(line)
+		if err != nil {
+			continue
+		}
+		cookie := HARCookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Path:     c.Path,
+			Domain:   c.Domain,
+			HttpOnly: c.HttpOnly,
+			Secure:   c.Secure,
+		}
+		if !c.Expires.IsZero() {
+			cookie.Expires = c.Expires.UTC().Format(time.RFC3339)
+		}
+		cookies = append(cookies, cookie)
+	}
+	return cookies
+}
+
+// setCookieStart matches the start of a new cookie after a comma, which tells
+// a joining comma apart from the one in an Expires date.
+var setCookieStart = regexp.MustCompile(`^\s*[^=;,\s]+=`)
+
+func splitSetCookie(header string) []string {
+	var lines []string
+	for _, part := range strings.Split(header, "\n") {
+		start := 0
+		for i := 0; i < len(part); i++ {
+			if part[i] == ',' && setCookieStart.MatchString(part[i+1:]) {
+				lines = append(lines, strings.TrimSpace(part[start:i]))
+				start = i + 1
+			}
+		}
+		if rest := strings.TrimSpace(part[start:]); rest != "" {
+			lines = append(lines, rest)
+		}
+	}
+	return lines
+}
+
 func convertHeaders(headers map[string]string) []HARNameValuePair {
 	var res []HARNameValuePair
 	for k, v := range headers {
@@ -349,7 +665,7 @@ func getPrettyURL(req *mitmproxyv1.Request) string {
 // Helper to convert protobuf timestamp to time.Time
 // Assuming Timestamp has Seconds and Nanos
 func getFlowTime(ts interface{ GetSeconds() int64; GetNanos() int32 }) time.Time {
-	if ts == nil {
+	if ts == nil || (ts.GetSeconds() == 0 && ts.GetNanos() == 0) {
 		return time.Time{}
 	}
 	return time.Unix(ts.GetSeconds(), int64(ts.GetNanos()))
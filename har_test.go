@@ -0,0 +1,278 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// generateHAREntry exports a single flow and returns its HAR entry.
+func generateHAREntry(t *testing.T, flow *mitmflowv1.Flow) HAREntry {
+	t.Helper()
+	data, err := GenerateHAR([]*mitmflowv1.Flow{flow})
+	require.NoError(t, err)
+	var har HAR
+	require.NoError(t, json.Unmarshal(data, &har))
+	require.Len(t, har.Log.Entries, 1)
+	return har.Log.Entries[0]
+}
+
+func TestGenerateHAR_Cookies(t *testing.T) {
+	flow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Request: mitmproxyv1.Request_builder{
+				Method:  proto.String("GET"),
+				Url:     proto.String("https://example.com/"),
+				Headers: map[string]string{"cookie": "session=abc; theme=dark"},
+			}.Build(),
+			Response: mitmproxyv1.Response_builder{
+				StatusCode: proto.Int32(200),
+				Headers: map[string]string{
+					"Set-Cookie": "id=a3fWa; Expires=Wed, 21 Oct 2015 07:28:00 GMT; Secure; HttpOnly, lang=en; Path=/docs; Domain=example.com",
+				},
+			}.Build(),
+		}.Build(),
+	}.Build()
+
+	entry := generateHAREntry(t, flow)
+	assert.Equal(t, []HARCookie{
+		{Name: "session", Value: "abc"},
+		{Name: "theme", Value: "dark"},
+	}, entry.Request.Cookies)
+	assert.Equal(t, []HARCookie{
+		{Name: "id", Value: "a3fWa", Expires: "2015-10-21T07:28:00Z", HttpOnly: true, Secure: true},
+		{Name: "lang", Value: "en", Path: "/docs", Domain: "example.com"},
+	}, entry.Response.Cookies)
+}
+
+func TestGenerateHAR_ConnectionTimings(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(ms int) *timestamppb.Timestamp {
+		return timestamppb.New(start.Add(time.Duration(ms) * time.Millisecond))
+	}
+	newFlow := func(server *mitmproxyv1.ServerConn) *mitmflowv1.Flow {
+		return mitmflowv1.Flow_builder{
+			HttpFlow: mitmproxyv1.HTTPFlow_builder{
+				Request: mitmproxyv1.Request_builder{
+					Method:         proto.String("GET"),
+					Url:            proto.String("https://example.com/"),
+					TimestampStart: at(0),
+					TimestampEnd:   at(5),
+				}.Build(),
+				Response: mitmproxyv1.Response_builder{
+					StatusCode:     proto.Int32(200),
+					TimestampStart: at(100),
+					TimestampEnd:   at(120),
+				}.Build(),
+				Server: server,
+			}.Build(),
+		}.Build()
+	}
+
+	// A connection opened for this request.
+	entry := generateHAREntry(t, newFlow(mitmproxyv1.ServerConn_builder{
+		TimestampStart:    at(10),
+		TimestampTcpSetup: at(30),
+		TimestampTlsSetup: at(60),
+	}.Build()))
+	assert.Equal(t, HARTimings{DNS: -1, Connect: 50, Ssl: 30, Send: 5, Wait: 45, Receive: 20}, entry.Timings)
+	assert.Equal(t, float64(120), entry.Time)
+
+	// A reused connection has no connection timings.
+	entry = generateHAREntry(t, newFlow(mitmproxyv1.ServerConn_builder{
+		TimestampStart:    timestamppb.New(start.Add(-time.Minute)),
+		TimestampTcpSetup: timestamppb.New(start.Add(-time.Minute)),
+	}.Build()))
+	assert.Equal(t, HARTimings{Send: 5, Wait: 95, Receive: 20}, entry.Timings)
+	assert.Equal(t, float64(120), entry.Time)
+}
+
+func TestGenerateHAR_DecodedBodies(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write(bytes.Repeat([]byte(`{"hello":"world"}`), 10))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	flow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Request: mitmproxyv1.Request_builder{
+				Method: proto.String("GET"),
+				Url:    proto.String("https://example.com/"),
+			}.Build(),
+			Response: mitmproxyv1.Response_builder{
+				StatusCode: proto.Int32(200),
+				Headers:    map[string]string{"Content-Encoding": "gzip", "Content-Type": "application/json"},
+				Content:    buf.Bytes(),
+			}.Build(),
+		}.Build(),
+		HttpFlowExtra: mitmflowv1.HTTPFlowExtra_builder{
+			Response: mitmflowv1.MessageDetails_builder{
+				EffectiveContentType: proto.String("application/json"),
+			}.Build(),
+		}.Build(),
+	}.Build()
+
+	entry := generateHAREntry(t, flow)
+	assert.Equal(t, buf.Len(), entry.Response.BodySize)
+	assert.Equal(t, 170, entry.Response.Content.Size)
+	assert.Equal(t, 170-buf.Len(), entry.Response.Content.Compression)
+	assert.Equal(t, strings.Repeat(`{"hello":"world"}`, 10), entry.Response.Content.Text)
+	assert.Empty(t, entry.Response.Content.Encoding)
+
+	// gRPC bodies are exported as their decoded frames.
+	flow.GetHttpFlow().GetResponse().SetHeaders(map[string]string{"Content-Type": "application/grpc"})
+	flow.GetHttpFlow().GetResponse().SetContent([]byte{0, 0, 0, 0, 2, 0x08, 0x01})
+	flow.GetHttpFlowExtra().SetResponse(mitmflowv1.MessageDetails_builder{
+		EffectiveContentType: proto.String("application/grpc"),
+		TextualFrames:        []string{`{"id": 1}`},
+	}.Build())
+
+	entry = generateHAREntry(t, flow)
+	assert.Equal(t, `{"id": 1}`, entry.Response.Content.Text)
+	assert.Empty(t, entry.Response.Content.Encoding)
+	assert.Zero(t, entry.Response.Content.Compression)
+}
+
+func TestGenerateHAR_UndecodableRequestBody(t *testing.T) {
+	flow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Request: mitmproxyv1.Request_builder{
+				Method: proto.String("POST"),
+				Url:    proto.String("https://example.com/"),
+				// mitmproxy already decoded the body, or the encoding is
+				// one we don't know: the body is exported as it is.
+				Headers: map[string]string{"Content-Encoding": "gzip", "Content-Type": "application/json"},
+				Content: []byte(`{"hello":"world"}`),
+			}.Build(),
+			Response: mitmproxyv1.Response_builder{
+				StatusCode: proto.Int32(204),
+			}.Build(),
+		}.Build(),
+	}.Build()
+
+	entry := generateHAREntry(t, flow)
+	require.NotNil(t, entry.Request.PostData)
+	assert.Equal(t, `{"hello":"world"}`, entry.Request.PostData.Text)
+
+	flow.GetHttpFlow().GetRequest().SetHeaders(map[string]string{"Content-Encoding": "compress"})
+	entry = generateHAREntry(t, flow)
+	require.NotNil(t, entry.Request.PostData)
+	assert.Equal(t, `{"hello":"world"}`, entry.Request.PostData.Text)
+}
+
+func TestDecodeContentEncoding(t *testing.T) {
+	_, ok := decodeContentEncoding([]byte("plain"), "")
+	assert.False(t, ok)
+	// mitmproxy may already have decoded the body.
+	_, ok = decodeContentEncoding([]byte("plain"), "gzip")
+	assert.False(t, ok)
+	_, ok = decodeContentEncoding([]byte("plain"), "compress")
+	assert.False(t, ok)
+
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	_, err := zw.Write([]byte("deflated"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	decoded, ok := decodeContentEncoding(buf.Bytes(), "deflate")
+	require.True(t, ok)
+	assert.Equal(t, "deflated", string(decoded))
+}
+
+func TestGenerateHAR_WebSocketMessages(t *testing.T) {
+	ts := time.Date(2024, 1, 1, 0, 0, 1, 500_000_000, time.UTC)
+	flow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Request: mitmproxyv1.Request_builder{
+				Method: proto.String("GET"),
+				Url:    proto.String("wss://example.com/socket"),
+			}.Build(),
+			Response: mitmproxyv1.Response_builder{
+				StatusCode: proto.Int32(101),
+			}.Build(),
+			IsWebsocket: proto.Bool(true),
+			WebsocketMessages: []*mitmproxyv1.WebSocketMessage{
+				mitmproxyv1.WebSocketMessage_builder{
+					Content:    []byte("ping"),
+					FromClient: proto.Bool(true),
+					Timestamp:  timestamppb.New(ts),
+				}.Build(),
+				mitmproxyv1.WebSocketMessage_builder{
+					Content:   []byte{0x00, 0xff},
+					Timestamp: timestamppb.New(ts),
+				}.Build(),
+			},
+		}.Build(),
+	}.Build()
+
+	entry := generateHAREntry(t, flow)
+	assert.Equal(t, "websocket", entry.ResourceType)
+	assert.Equal(t, []HARWebSocketMessage{
+		{Type: "send", Time: 1704067201.5, Opcode: 1, Data: "ping"},
+		{Type: "receive", Time: 1704067201.5, Opcode: 2, Data: "AP8="},
+	}, entry.WebSocketMessages)
+}
+
+func TestGenerateHAR_Pages(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	n := 0
+	newFlow := func(u string, reqHeaders map[string]string, status int32, resHeaders map[string]string) *mitmflowv1.Flow {
+		n++
+		return mitmflowv1.Flow_builder{
+			HttpFlow: mitmproxyv1.HTTPFlow_builder{
+				Request: mitmproxyv1.Request_builder{
+					Method:         proto.String("GET"),
+					Url:            proto.String(u),
+					Headers:        reqHeaders,
+					TimestampStart: timestamppb.New(start.Add(time.Duration(n) * time.Second)),
+				}.Build(),
+				Response: mitmproxyv1.Response_builder{
+					StatusCode: proto.Int32(status),
+					Headers:    resHeaders,
+				}.Build(),
+			}.Build(),
+		}.Build()
+	}
+	nav := map[string]string{"Sec-Fetch-Dest": "document"}
+	html := map[string]string{"Content-Type": "text/html"}
+
+	flows := []*mitmflowv1.Flow{
+		newFlow("http://example.com/", nav, 301, map[string]string{"Location": "https://example.com/"}),
+		newFlow("https://example.com/", nav, 200, html),
+		newFlow("https://example.com/style.css", map[string]string{"Referer": "https://example.com/"}, 200, nil),
+		newFlow("https://fonts.example.net/font.woff2", map[string]string{"Referer": "https://example.com/style.css"}, 200, nil),
+		newFlow("https://cdn.example.net/app.js", map[string]string{"Referer": "https://example.com/"}, 200, nil),
+		// Without Sec-Fetch-Dest, a request for HTML that got HTML is a navigation.
+		newFlow("https://example.com/about#team", map[string]string{"Accept": "text/html,*/*"}, 200, html),
+		newFlow("https://api.example.org/track", map[string]string{"Referer": "https://example.com/about"}, 204, nil),
+		newFlow("https://unrelated.example/ping", nil, 200, nil),
+	}
+
+	data, err := GenerateHAR(flows)
+	require.NoError(t, err)
+	var har HAR
+	require.NoError(t, json.Unmarshal(data, &har))
+
+	// The redirect stays on the page it started.
+	require.Len(t, har.Log.Pages, 2)
+	assert.Equal(t, "http://example.com/", har.Log.Pages[0].Title)
+	assert.Equal(t, "https://example.com/about", har.Log.Pages[1].Title)
+
+	var refs []string
+	for _, entry := range har.Log.Entries {
+		refs = append(refs, entry.Pageref)
+	}
+	assert.Equal(t, []string{"page_0", "page_0", "page_0", "page_0", "page_0", "page_1", "page_1", ""}, refs)
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ParseHAR converts the entries of a HAR file into HTTP flows. HAR has no
+// flow IDs, so they are derived from the file's content and the entry's
+// position, which makes importing the same file twice replace the flows of
+// the first import instead of duplicating them.
+func ParseHAR(data []byte) ([]*mitmflowv1.Flow, error) {
+	var har HAR
+	if err := json.Unmarshal(data, &har); err != nil {
+		return nil, fmt.Errorf("invalid HAR: %w", err)
+	}
+	fileKey := blobKey(data)
+	flows := make([]*mitmflowv1.Flow, 0, len(har.Log.Entries))
+	for i, entry := range har.Log.Entries {
+		id := uuid.NewSHA1(uuid.NameSpaceURL, []byte(fileKey+"#"+strconv.Itoa(i))).String()
+		flow, err := convertHAREntry(id, entry)
+		if err != nil {
+			return nil, fmt.Errorf("HAR entry %d: %w", i, err)
+		}
+		flows = append(flows, flow)
+	}
+	return flows, nil
+}
+
+func convertHAREntry(id string, entry HAREntry) (*mitmflowv1.Flow, error) {
+	started, err := time.Parse(time.RFC3339Nano, entry.StartedDateTime)
+	if err != nil {
+		return nil, err
+	}
+	// Timings that don't apply are -1.
+	ms := func(v float64) time.Duration {
+		return time.Duration(max(v, 0) * float64(time.Millisecond))
+	}
+	t := entry.Timings
+	requestEnd := started.Add(ms(t.Blocked) + ms(t.DNS) + ms(t.Connect) + ms(t.Send))
+	responseStart := requestEnd.Add(ms(t.Wait))
+	end := started.Add(ms(entry.Time))
+
+	req := entry.Request
+	reqHeaders := harHeaders(req.Headers)
+	var reqContent []byte
+	if req.PostData != nil {
+		reqContent = []byte(req.PostData.Text)
+	}
+	httpFlow := mitmproxyv1.HTTPFlow_builder{
+		Id:             proto.String(id),
+		TimestampStart: timestamppb.New(started),
+		DurationMs:     proto.Float64(max(entry.Time, 0)),
+		Request: mitmproxyv1.Request_builder{
+			Method:         proto.String(req.Method),
+			Url:            proto.String(req.URL),
+			Headers:        reqHeaders,
+			Content:        reqContent,
+			HttpVersion:    proto.String(req.HTTPVersion),
+			Trailers:       harHeaders(req.Trailers),
+			TimestampStart: timestamppb.New(started),
+			TimestampEnd:   timestamppb.New(requestEnd),
+		}.Build(),
+	}
+
+	// Entries for requests that never got a response have status 0.
+	if res := entry.Response; res.Status > 0 {
+		content := []byte(res.Content.Text)
+		if res.Content.Encoding == "base64" {
+			if content, err = base64.StdEncoding.DecodeString(res.Content.Text); err != nil {
+				return nil, err
+			}
+		}
+		resHeaders := harHeaders(res.Headers)
+		// HAR content is already decoded.
+		for k := range resHeaders {
+			if strings.EqualFold(k, "content-encoding") {
+				delete(resHeaders, k)
+			}
+		}
+		httpFlow.Response = mitmproxyv1.Response_builder{
+			StatusCode:     proto.Int32(int32(res.Status)),
+			Reason:         proto.String(res.StatusText),
+			Headers:        resHeaders,
+			Content:        content,
+			HttpVersion:    proto.String(res.HTTPVersion),
+			Trailers:       harHeaders(res.Trailers),
+			TimestampStart: timestamppb.New(responseStart),
+			TimestampEnd:   timestamppb.New(end),
+		}.Build()
+	}
+
+	if u, err := url.Parse(req.URL); err == nil && u.Host != "" {
+		server := mitmproxyv1.ServerConn_builder{
+			AddressHost: proto.String(u.Hostname()),
+			Tls:         proto.Bool(u.Scheme == "https" || u.Scheme == "wss"),
+		}
+		if port, err := strconv.ParseUint(u.Port(), 10, 16); err == nil {
+			server.AddressPort = proto.Uint32(uint32(port))
+		} else if u.Scheme == "https" || u.Scheme == "wss" {
+			server.AddressPort = proto.Uint32(443)
+		} else {
+			server.AddressPort = proto.Uint32(80)
+		}
+		if ip := net.ParseIP(strings.Trim(entry.ServerIPAddress, "[]")); ip != nil {
+			server.PeernameHost = proto.String(ip.String())
+			server.PeernamePort = server.AddressPort
+		}
+		httpFlow.Server = server.Build()
+	}
+
+	if len(entry.WebSocketMessages) > 0 {
+		httpFlow.IsWebsocket = proto.Bool(true)
+		for _, m := range entry.WebSocketMessages {
+			content := []byte(m.Data)
+			if m.Opcode == 2 {
+				if content, err = base64.StdEncoding.DecodeString(m.Data); err != nil {
+					return nil, err
+				}
+			}
+			httpFlow.WebsocketMessages = append(httpFlow.WebsocketMessages, mitmproxyv1.WebSocketMessage_builder{
+				Content:    content,
+				Timestamp:  floatTimestamp(m.Time),
+				FromClient: proto.Bool(m.Type == "send"),
+			}.Build())
+		}
+	}
+
+	var extra *mitmflowv1.HTTPFlowExtra
+	if isHTTP2(req.HTTPVersion) {
+		// Browsers list the pseudo-headers along with the others.
+		if details := recordedHTTP2Details(harHeaderPairs(req.Headers), harHeaderPairs(entry.Response.Headers)); details != nil {
+			extra = mitmflowv1.HTTPFlowExtra_builder{Http2: details}.Build()
+		}
+	}
+
+	return mitmflowv1.Flow_builder{
+		HttpFlow:      httpFlow.Build(),
+		HttpFlowExtra: extra,
+		Metadata:      entry.Metadata,
+	}.Build(), nil
+}
+
+func harHeaderPairs(pairs []HARNameValuePair) []headerPair {
+	headers := make([]headerPair, len(pairs))
+	for i, pair := range pairs {
+		headers[i] = headerPair{name: pair.Name, value: pair.Value}
+	}
+	return headers
+}
+
+func harHeaders(pairs []HARNameValuePair) map[string]string {
+	if len(pairs) == 0 {
+		return nil
+	}
+	headers := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		// HTTP/2 pseudo-headers as listed by browsers.
+		if strings.HasPrefix(pair.Name, ":") {
+			continue
+		}
+		addHeader(headers, pair.Name, pair.Value)
+	}
+	return headers
+}
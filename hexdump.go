@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+// MaxHexdumpBytes caps how much of a body is rendered as a hexdump. A hexdump
+// is a little over four times the size of its input.
+const MaxHexdumpBytes = 8 * 1024
+
+// isBinary reports whether content looks like something other than text:
+// invalid UTF-8 or control characters that don't show up in text.
+func isBinary(content []byte) bool {
+	if len(content) == 0 {
+		return false
+	}
+	for _, b := range content {
+		switch {
+		case b == '\t', b == '\n', b == '\r', b == '\f', b == 0x1b:
+		case b < 0x20, b == 0x7f:
+			return true
+		}
+	}
+	return !utf8.Valid(content)
+}
+
+// hexdumpFrame renders content as a canonical hexdump: offset, hex bytes and
+// ASCII, sixteen bytes per line.
+func hexdumpFrame(content []byte) string {
+	if len(content) <= MaxHexdumpBytes {
+		return hex.Dump(content)
+	}
+	return hex.Dump(content[:MaxHexdumpBytes]) + fmt.Sprintf("... %d more bytes\n", len(content)-MaxHexdumpBytes)
+}
+
+// setHexdumpFrames fills in a hexdump for binary bodies that no decoder
+// produced frames for. Media types the UI renders natively are skipped.
+func setHexdumpFrames(content []byte, details *mitmflowv1.MessageDetails) {
+	if len(details.GetTextualFrames()) > 0 || !isBinary(content) {
+		return
+	}
+	contentType := details.GetEffectiveContentType()
+	for _, prefix := range []string{"image/", "audio/", "video/", "font/"} {
+		if strings.HasPrefix(contentType, prefix) {
+			return
+		}
+	}
+	details.SetTextualFrames([]string{hexdumpFrame(content)})
+}
+
+// streamMessageDetails builds the details for the messages of a TCP or UDP
+// flow. Only the first MaxTextualFrames binary messages get a hexdump.
+func streamMessageDetails[M interface{ GetContent() []byte }](messages []M) *mitmflowv1.StreamFlowExtra {
+	extra := &mitmflowv1.StreamFlowExtra{}
+	details := make([]*mitmflowv1.MessageDetails, len(messages))
+	dumped := 0
+	for i, msg := range messages {
+		d := &mitmflowv1.MessageDetails{}
+		d.SetBodySize(int64(len(msg.GetContent())))
+		if dumped < MaxTextualFrames && isBinary(msg.GetContent()) {
+			d.SetTextualFrames([]string{hexdumpFrame(msg.GetContent())})
+			dumped++
+		}
+		details[i] = d
+	}
+	extra.SetMessages(details)
+	return extra
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHexdumpFrame(t *testing.T) {
+	dump := hexdumpFrame([]byte("\x00\x01GET / HTTP/1.1"))
+	assert.Equal(t, "00000000  00 01 47 45 54 20 2f 20  48 54 54 50 2f 31 2e 31  |..GET / HTTP/1.1|\n", dump)
+
+	large := hexdumpFrame(bytes.Repeat([]byte{0xff}, MaxHexdumpBytes+10))
+	assert.True(t, strings.HasSuffix(large, "... 10 more bytes\n"))
+	assert.Equal(t, MaxHexdumpBytes/16+1, strings.Count(large, "\n"))
+}
+
+func TestPreprocessFlow_Hexdump(t *testing.T) {
+	server, err := NewMITMFlowServer(nil, nil)
+	require.NoError(t, err)
+
+	flow := createFlow("flow-1", time.Now())
+	req := &mitmproxyv1.Request{}
+	req.SetHeaders(map[string]string{"Content-Type": "application/json"})
+	req.SetContent([]byte(`{"ok":true}`))
+	flow.GetHttpFlow().SetRequest(req)
+	resp := &mitmproxyv1.Response{}
+	resp.SetHeaders(map[string]string{"Content-Type": "application/octet-stream"})
+	resp.SetContent([]byte{0xde, 0xad, 0xbe, 0xef, 0x00})
+	flow.GetHttpFlow().SetResponse(resp)
+
+	server.preprocessFlow(flow)
+	// Text bodies don't get a hexdump; JSON is formatted instead.
+	assert.Equal(t, []string{"{\n  \"ok\": true\n}"}, flow.GetHttpFlowExtra().GetRequest().GetTextualFrames())
+	frames := flow.GetHttpFlowExtra().GetResponse().GetTextualFrames()
+	require.Len(t, frames, 1)
+	assert.Contains(t, frames[0], "de ad be ef 00")
+
+	tcpFlow := mitmproxyv1.TCPFlow_builder{
+		Id: proto.String("flow-2"),
+		Messages: []*mitmproxyv1.TCPMessage{
+			mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(true), Content: []byte("hello\n")}.Build(),
+			mitmproxyv1.TCPMessage_builder{Content: []byte{0x16, 0x03, 0x01}}.Build(),
+		},
+	}.Build()
+	flow = mitmflowv1.Flow_builder{TcpFlow: tcpFlow}.Build()
+
+	server.preprocessFlow(flow)
+	messages := flow.GetStreamFlowExtra().GetMessages()
+	require.Len(t, messages, 2)
+	assert.Empty(t, messages[0].GetTextualFrames())
+	assert.Equal(t, int64(6), messages[0].GetBodySize())
+	require.Len(t, messages[1].GetTextualFrames(), 1)
+	assert.Contains(t, messages[1].GetTextualFrames()[0], "16 03 01")
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// dnsAnswerMaxAge is how long after a captured DNS flow its answers are
+	// used to name connections to their IPs.
+	dnsAnswerMaxAge = time.Hour
+	// maxNamesPerIP bounds how many names are remembered for one IP, which
+	// CDNs and shared hosting give many names.
+	maxNamesPerIP = 8
+	// reverseDNSCacheTTL is how long reverse lookups, including failed ones,
+	// are cached.
+	reverseDNSCacheTTL = time.Hour
+	reverseDNSTimeout  = 2 * time.Second
+	// reverseDNSRate limits reverse lookups per second, so a scan through the
+	// proxy doesn't turn into a burst of PTR queries.
+	reverseDNSRate  = 5
+	reverseDNSBurst = 10
+)
+
+type hostnameEntry struct {
+	name string
+	at   time.Time
+	// seen is when the entry was recorded, which for flows replayed from a
+	// file can be long after at.
+	seen time.Time
+}
+
+// hostnameResolver finds a best-guess hostname for IP addresses, first from
+// DNS flows that resolved them shortly before and then, when enabled, from
+// reverse DNS lookups.
+type hostnameResolver struct {
+	mu sync.Mutex
+	// answers indexes the names that captured DNS flows resolved to each IP,
+	// oldest first, stamped with when the DNS flow started.
+	answers map[string][]hostnameEntry
+	reverse map[string]hostnameEntry
+	pending map[string]bool
+
+	// lookupAddr does reverse lookups; nil disables them.
+	lookupAddr func(ctx context.Context, addr string) ([]string, error)
+	limiter    *rate.Limiter
+	now        func() time.Time
+}
+
+func newHostnameResolver() *hostnameResolver {
+	return &hostnameResolver{
+		answers: make(map[string][]hostnameEntry),
+		reverse: make(map[string]hostnameEntry),
+		pending: make(map[string]bool),
+		limiter: rate.NewLimiter(reverseDNSRate, reverseDNSBurst),
+		now:     time.Now,
+	}
+}
+
+// recordDNSFlow remembers the A and AAAA answers of a DNS flow under the name
+// the client asked for, which is more useful than the end of a CNAME chain.
+func (r *hostnameResolver) recordDNSFlow(flow *mitmflowv1.Flow) {
+	questions := flow.GetDnsFlow().GetRequest().GetQuestions()
+	if r == nil || len(questions) == 0 {
+		return
+	}
+	name := strings.TrimSuffix(questions[0].GetName(), ".")
+	now := r.now()
+	at := flowStartTime(flow, now)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, answer := range flow.GetDnsFlow().GetResponse().GetAnswers() {
+		if ip := dnsRecordIP(answer); ip != "" {
+			r.answers[ip] = addAnswer(r.answers[ip], hostnameEntry{name: name, at: at, seen: now})
+		}
+	}
+	r.expire(now)
+}
+
+// addAnswer adds e to the names of an IP, keeping them ordered by time. A
+// name that is already known is moved to its latest time.
+func addAnswer(entries []hostnameEntry, e hostnameEntry) []hostnameEntry {
+	entries = slices.DeleteFunc(entries, func(old hostnameEntry) bool {
+		if old.name == e.name && old.at.After(e.at) {
+			e.at = old.at
+		}
+		return old.name == e.name
+	})
+	i, _ := slices.BinarySearchFunc(entries, e.at, func(old hostnameEntry, at time.Time) int {
+		return old.at.Compare(at)
+	})
+	entries = slices.Insert(entries, i, e)
+	if len(entries) > maxNamesPerIP {
+		entries = entries[len(entries)-maxNamesPerIP:]
+	}
+	return entries
+}
+
+// flowStartTime is when flow started, or now when mitmproxy didn't say.
+func flowStartTime(flow *mitmflowv1.Flow, now time.Time) time.Time {
+	if ns := GetFlowStartTime(flow); ns != 0 {
+		return time.Unix(0, ns)
+	}
+	return now
+}
+
+// dnsRecordIP returns the address of an A or AAAA record, which mitmproxy
+// sends either packed or as text.
+func dnsRecordIP(record *mitmproxyv1.DNSResourceRecord) string {
+	switch record.GetType() {
+	case "A", "AAAA":
+	default:
+		return ""
+	}
+	data := record.GetData()
+	if len(data) == net.IPv4len || len(data) == net.IPv6len {
+		return net.IP(data).String()
+	}
+	if ip := net.ParseIP(string(data)); ip != nil {
+		return ip.String()
+	}
+	return ""
+}
+
+// expire drops entries that are too old to be used. r.mu must be held.
+func (r *hostnameResolver) expire(now time.Time) {
+	for ip, entries := range r.answers {
+		entries = slices.DeleteFunc(entries, func(e hostnameEntry) bool {
+			return now.Sub(e.seen) > dnsAnswerMaxAge
+		})
+		if len(entries) == 0 {
+			delete(r.answers, ip)
+		} else {
+			r.answers[ip] = entries
+		}
+	}
+	for ip, e := range r.reverse {
+		if now.Sub(e.at) > reverseDNSCacheTTL {
+			delete(r.reverse, ip)
+		}
+	}
+}
+
+// hostname returns what is known about the name of ip for a flow that
+// started at the given time: the name most recently resolved to it by a DNS
+// flow before then, or a cached reverse lookup. When nothing is known and
+// reverse lookups are enabled, it starts one in the background and calls
+// onResolved if it finds a name.
+func (r *hostnameResolver) hostname(ip string, at time.Time, onResolved func(name string)) (string, mitmflowv1.HostnameSource) {
+	canonical := net.ParseIP(ip)
+	if r == nil || canonical == nil {
+		return "", mitmflowv1.HostnameSource_HOSTNAME_SOURCE_UNSPECIFIED
+	}
+	ip = canonical.String()
+	now := r.now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range slices.Backward(r.answers[ip]) {
+		if !e.at.After(at) && at.Sub(e.at) <= dnsAnswerMaxAge {
+			return e.name, mitmflowv1.HostnameSource_HOSTNAME_SOURCE_DNS_FLOW
+		}
+	}
+	if e, ok := r.reverse[ip]; ok && now.Sub(e.at) <= reverseDNSCacheTTL {
+		if e.name == "" {
+			return "", mitmflowv1.HostnameSource_HOSTNAME_SOURCE_UNSPECIFIED
+		}
+		return e.name, mitmflowv1.HostnameSource_HOSTNAME_SOURCE_REVERSE_DNS
+	}
+	if r.lookupAddr != nil && !r.pending[ip] && r.limiter.Allow() {
+		r.pending[ip] = true
+		go r.lookup(ip, onResolved)
+	}
+	return "", mitmflowv1.HostnameSource_HOSTNAME_SOURCE_UNSPECIFIED
+}
+
+func (r *hostnameResolver) lookup(ip string, onResolved func(name string)) {
+	ctx, cancel := context.WithTimeout(context.Background(), reverseDNSTimeout)
+	defer cancel()
+	var name string
+	if names, err := r.lookupAddr(ctx, ip); err == nil && len(names) > 0 {
+		name = strings.TrimSuffix(names[0], ".")
+	}
+
+	r.mu.Lock()
+	delete(r.pending, ip)
+	r.reverse[ip] = hostnameEntry{name: name, at: r.now()}
+	r.mu.Unlock()
+
+	if name != "" && onResolved != nil {
+		onResolved(name)
+	}
+}
+
+// serverByIP returns the server IP of a flow that addressed its server by IP
+// alone: without a hostname from TLS SNI and, for HTTP, in the URL or Host
+// header.
+func serverByIP(flow *mitmflowv1.Flow) string {
+	var server *mitmproxyv1.ServerConn
+	switch {
+	case flow.GetHttpFlow() != nil:
+		h := flow.GetHttpFlow()
+		u, err := url.Parse(h.GetRequest().GetUrl())
+		if err != nil || net.ParseIP(u.Hostname()) == nil || h.GetServer().GetSni() != "" {
+			return ""
+		}
+		if host := getHeaderValue(h.GetRequest().GetHeaders(), "Host"); host != "" && net.ParseIP(hostWithoutPort(host)) == nil {
+			return ""
+		}
+		return u.Hostname()
+	case flow.GetTcpFlow() != nil:
+		server = flow.GetTcpFlow().GetServer()
+	case flow.GetUdpFlow() != nil:
+		server = flow.GetUdpFlow().GetServer()
+	}
+	if server.GetSni() != "" || net.ParseIP(server.GetAddressHost()) == nil {
+		return ""
+	}
+	return server.GetAddressHost()
+}
+
+func hostWithoutPort(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return strings.Trim(host, "[]")
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"golang.org/x/time/rate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var hostnameTestStart = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func dnsTestFlow(name string, answers ...*mitmproxyv1.DNSResourceRecord) *mitmflowv1.Flow {
+	return mitmflowv1.Flow_builder{
+		DnsFlow: mitmproxyv1.DNSFlow_builder{
+			Id:             proto.String("dns-" + name),
+			TimestampStart: timestamppb.New(hostnameTestStart),
+			Request: mitmproxyv1.DNSMessage_builder{
+				Questions: []*mitmproxyv1.DNSQuestion{
+					mitmproxyv1.DNSQuestion_builder{Name: proto.String(name), Type: proto.String("A")}.Build(),
+				},
+			}.Build(),
+			Response: mitmproxyv1.DNSMessage_builder{Answers: answers}.Build(),
+		}.Build(),
+	}.Build()
+}
+
+func tcpTestFlow(id, host, sni string) *mitmflowv1.Flow {
+	return tcpTestFlowAt(id, host, sni, hostnameTestStart.Add(time.Second))
+}
+
+func tcpTestFlowAt(id, host, sni string, start time.Time) *mitmflowv1.Flow {
+	return mitmflowv1.Flow_builder{
+		TcpFlow: mitmproxyv1.TCPFlow_builder{
+			Id:             proto.String(id),
+			TimestampStart: timestamppb.New(start),
+			Server: mitmproxyv1.ServerConn_builder{
+				AddressHost: proto.String(host),
+				Sni:         proto.String(sni),
+			}.Build(),
+		}.Build(),
+	}.Build()
+}
+
+func TestServerHostname_FromDNSFlow(t *testing.T) {
+	server, err := NewMITMFlowServer(nil, nil)
+	require.NoError(t, err)
+
+	server.preprocessFlow(dnsTestFlow("api.example.com.",
+		mitmproxyv1.DNSResourceRecord_builder{
+			Name: proto.String("api.example.com."),
+			Type: proto.String("CNAME"),
+			Data: []byte("edge.example.net."),
+		}.Build(),
+		mitmproxyv1.DNSResourceRecord_builder{
+			Name: proto.String("edge.example.net."),
+			Type: proto.String("A"),
+			Data: net.IPv4(93, 184, 216, 34).To4(),
+		}.Build(),
+		mitmproxyv1.DNSResourceRecord_builder{
+			Name: proto.String("edge.example.net."),
+			Type: proto.String("AAAA"),
+			Data: []byte("2001:db8::1"),
+		}.Build(),
+	))
+
+	flow := tcpTestFlow("tcp-1", "93.184.216.34", "")
+	server.preprocessFlow(flow)
+	assert.Equal(t, "api.example.com", flow.GetStreamFlowExtra().GetServerHostname())
+	assert.Equal(t, mitmflowv1.HostnameSource_HOSTNAME_SOURCE_DNS_FLOW, flow.GetStreamFlowExtra().GetServerHostnameSource())
+
+	flow = tcpTestFlow("tcp-2", "2001:db8:0::1", "")
+	server.preprocessFlow(flow)
+	assert.Equal(t, "api.example.com", flow.GetStreamFlowExtra().GetServerHostname())
+
+	pred, err := parseFilterExpr("~d api.example.com")
+	require.NoError(t, err)
+	assert.True(t, pred(flow))
+
+	// Flows with SNI already name their server.
+	flow = tcpTestFlow("tcp-3", "93.184.216.34", "www.example.com")
+	server.preprocessFlow(flow)
+	assert.Empty(t, flow.GetStreamFlowExtra().GetServerHostname())
+
+	// Only connections made shortly after the lookup are attributed to it.
+	flow = tcpTestFlowAt("tcp-4", "93.184.216.34", "", hostnameTestStart.Add(-time.Second))
+	server.preprocessFlow(flow)
+	assert.Empty(t, flow.GetStreamFlowExtra().GetServerHostname())
+	flow = tcpTestFlowAt("tcp-5", "93.184.216.34", "", hostnameTestStart.Add(2*dnsAnswerMaxAge))
+	server.preprocessFlow(flow)
+	assert.Empty(t, flow.GetStreamFlowExtra().GetServerHostname())
+
+	// A later answer for the same IP names later connections.
+	later := dnsTestFlow("cdn.example.com", mitmproxyv1.DNSResourceRecord_builder{
+		Type: proto.String("A"),
+		Data: net.IPv4(93, 184, 216, 34).To4(),
+	}.Build())
+	later.GetDnsFlow().SetTimestampStart(timestamppb.New(hostnameTestStart.Add(time.Minute)))
+	server.preprocessFlow(later)
+	flow = tcpTestFlow("tcp-6", "93.184.216.34", "")
+	server.preprocessFlow(flow)
+	assert.Equal(t, "api.example.com", flow.GetStreamFlowExtra().GetServerHostname())
+	flow = tcpTestFlowAt("tcp-7", "93.184.216.34", "", hostnameTestStart.Add(2*time.Minute))
+	server.preprocessFlow(flow)
+	assert.Equal(t, "cdn.example.com", flow.GetStreamFlowExtra().GetServerHostname())
+
+	// HTTP requests that only name an IP are attributed the same way.
+	httpFlow := func(rawURL string, headers map[string]string) *mitmflowv1.Flow {
+		return mitmflowv1.Flow_builder{
+			HttpFlow: mitmproxyv1.HTTPFlow_builder{
+				Id:             proto.String(rawURL),
+				TimestampStart: timestamppb.New(hostnameTestStart.Add(time.Second)),
+				Request: mitmproxyv1.Request_builder{
+					Method:  proto.String("GET"),
+					Url:     proto.String(rawURL),
+					Headers: headers,
+				}.Build(),
+			}.Build(),
+		}.Build()
+	}
+	flow = httpFlow("http://93.184.216.34/", map[string]string{"host": "93.184.216.34:80"})
+	server.preprocessFlow(flow)
+	assert.Equal(t, "api.example.com", flow.GetHttpFlowExtra().GetServerHostname())
+	assert.Equal(t, mitmflowv1.HostnameSource_HOSTNAME_SOURCE_DNS_FLOW, flow.GetHttpFlowExtra().GetServerHostnameSource())
+	flow = httpFlow("http://93.184.216.34/", map[string]string{"host": "www.example.com"})
+	server.preprocessFlow(flow)
+	assert.Empty(t, flow.GetHttpFlowExtra().GetServerHostname())
+
+	// Answers are forgotten a while after they were recorded, whatever the
+	// timestamp of their DNS flow.
+	server.hostnames.now = func() time.Time { return time.Now().Add(2 * dnsAnswerMaxAge) }
+	server.preprocessFlow(dnsTestFlow("late.example.com"))
+	assert.NotContains(t, server.hostnames.answers, "93.184.216.34")
+}
+
+func TestHostnameResolver_ReverseDNS(t *testing.T) {
+	r := newHostnameResolver()
+	lookups := make(chan string, 10)
+	r.lookupAddr = func(_ context.Context, addr string) ([]string, error) {
+		lookups <- addr
+		if addr == "192.0.2.1" {
+			return []string{"host.example.org."}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	resolved := make(chan string, 1)
+	name, source := r.hostname("192.0.2.1", time.Now(), func(name string) { resolved <- name })
+	assert.Empty(t, name)
+	assert.Equal(t, mitmflowv1.HostnameSource_HOSTNAME_SOURCE_UNSPECIFIED, source)
+	assert.Equal(t, "192.0.2.1", <-lookups)
+	assert.Equal(t, "host.example.org", <-resolved)
+
+	name, source = r.hostname("192.0.2.1", time.Now(), nil)
+	assert.Equal(t, "host.example.org", name)
+	assert.Equal(t, mitmflowv1.HostnameSource_HOSTNAME_SOURCE_REVERSE_DNS, source)
+
+	// Failed lookups are cached too.
+	r.hostname("192.0.2.2", time.Now(), nil)
+	assert.Equal(t, "192.0.2.2", <-lookups)
+	require.Eventually(t, func() bool {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		_, ok := r.reverse["192.0.2.2"]
+		return ok
+	}, time.Second, time.Millisecond)
+	name, _ = r.hostname("192.0.2.2", time.Now(), nil)
+	assert.Empty(t, name)
+	assert.Empty(t, lookups)
+}
+
+func TestHostnameResolver_RateLimit(t *testing.T) {
+	r := newHostnameResolver()
+	r.limiter = rate.NewLimiter(0, 1)
+	block := make(chan struct{})
+	lookups := make(chan string, 10)
+	r.lookupAddr = func(_ context.Context, addr string) ([]string, error) {
+		lookups <- addr
+		<-block
+		return nil, nil
+	}
+
+	r.hostname("192.0.2.1", time.Now(), nil)
+	// Already pending, and then over the limit.
+	r.hostname("192.0.2.1", time.Now(), nil)
+	r.hostname("192.0.2.2", time.Now(), nil)
+	close(block)
+	assert.Equal(t, "192.0.2.1", <-lookups)
+	assert.Empty(t, lookups)
+}
+
+func TestHostnameResolver_Disabled(t *testing.T) {
+	var r *hostnameResolver
+	r.recordDNSFlow(dnsTestFlow("example.com"))
+	name, _ := r.hostname("192.0.2.1", time.Now(), nil)
+	assert.Empty(t, name)
+
+	// Without WithReverseDNS nothing is looked up.
+	r = newHostnameResolver()
+	name, _ = r.hostname("192.0.2.1", time.Now(), nil)
+	assert.Empty(t, name)
+	assert.Empty(t, r.pending)
+}
+
+func TestStreamServerByIP(t *testing.T) {
+	assert.Equal(t, "10.0.0.1", serverByIP(tcpTestFlow("1", "10.0.0.1", "")))
+	assert.Empty(t, serverByIP(tcpTestFlow("2", "example.com", "")))
+	assert.Empty(t, serverByIP(tcpTestFlow("3", "10.0.0.1", "example.com")))
+	assert.Empty(t, serverByIP(dnsTestFlow("example.com")))
+
+	udp := mitmflowv1.Flow_builder{
+		UdpFlow: mitmproxyv1.UDPFlow_builder{
+			Server: mitmproxyv1.ServerConn_builder{AddressHost: proto.String("2001:db8::1")}.Build(),
+		}.Build(),
+	}.Build()
+	assert.Equal(t, "2001:db8::1", serverByIP(udp))
+}
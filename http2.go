@@ -0,0 +1,97 @@
+package main
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxygrpcv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// isHTTP2 reports whether an HTTP version is one that frames requests into
+// streams with pseudo-headers: HTTP/2 or HTTP/3.
+func isHTTP2(version string) bool {
+	version = strings.ToUpper(version)
+	return strings.HasPrefix(version, "HTTP/2") || strings.HasPrefix(version, "HTTP/3") || version == "H2" || version == "H3"
+}
+
+// http2Details returns the HTTP/2 details of a flow, or nil when it used an
+// older version. recorded holds what an importer read from the capture, like
+// the header order; pseudo-headers the capture didn't list are derived from
+// the request and response.
+func http2Details(f *mitmproxygrpcv1.HTTPFlow, recorded *mitmflowv1.HTTP2Details) *mitmflowv1.HTTP2Details {
+	if !isHTTP2(f.GetRequest().GetHttpVersion()) && !isHTTP2(f.GetResponse().GetHttpVersion()) {
+		return nil
+	}
+	details := &mitmflowv1.HTTP2Details{}
+	if recorded != nil {
+		details = proto.CloneOf(recorded)
+	}
+	if len(details.GetRequestPseudoHeaders()) == 0 && f.HasRequest() {
+		details.SetRequestPseudoHeaders(requestPseudoHeaders(f.GetRequest()))
+	}
+	if len(details.GetResponsePseudoHeaders()) == 0 && f.HasResponse() {
+		details.SetResponsePseudoHeaders([]*mitmflowv1.HeaderField{
+			headerField(":status", strconv.Itoa(int(f.GetResponse().GetStatusCode()))),
+		})
+	}
+	return details
+}
+
+// requestPseudoHeaders derives the pseudo-headers of a request. CONNECT
+// requests only have :method and :authority.
+func requestPseudoHeaders(req *mitmproxygrpcv1.Request) []*mitmflowv1.HeaderField {
+	fields := []*mitmflowv1.HeaderField{headerField(":method", req.GetMethod())}
+	u, err := url.Parse(req.GetUrl())
+	if err != nil {
+		return fields
+	}
+	if strings.EqualFold(req.GetMethod(), "CONNECT") {
+		return append(fields, headerField(":authority", u.Host))
+	}
+	return append(fields,
+		headerField(":scheme", u.Scheme),
+		headerField(":authority", u.Host),
+		headerField(":path", u.RequestURI()))
+}
+
+func headerField(name, value string) *mitmflowv1.HeaderField {
+	return mitmflowv1.HeaderField_builder{Name: proto.String(name), Value: proto.String(value)}.Build()
+}
+
+// headerPair is a header as a capture lists it.
+type headerPair struct {
+	name, value string
+}
+
+// recordedHTTP2Details returns what the header lists of a capture record
+// about an HTTP/2 exchange: the pseudo-headers and the order of the other
+// headers. It returns nil when the lists are empty.
+func recordedHTTP2Details(request, response []headerPair) *mitmflowv1.HTTP2Details {
+	if len(request) == 0 && len(response) == 0 {
+		return nil
+	}
+	details := &mitmflowv1.HTTP2Details{}
+	pseudo, order := splitPseudoHeaders(request)
+	details.SetRequestPseudoHeaders(pseudo)
+	details.SetRequestHeaderOrder(order)
+	pseudo, order = splitPseudoHeaders(response)
+	details.SetResponsePseudoHeaders(pseudo)
+	details.SetResponseHeaderOrder(order)
+	return details
+}
+
+func splitPseudoHeaders(pairs []headerPair) ([]*mitmflowv1.HeaderField, []string) {
+	var pseudo []*mitmflowv1.HeaderField
+	var order []string
+	for _, pair := range pairs {
+		if strings.HasPrefix(pair.name, ":") {
+			pseudo = append(pseudo, headerField(pair.name, pair.value))
+		} else {
+			order = append(order, pair.name)
+		}
+	}
+	return pseudo, order
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func headerFields(fields []*mitmflowv1.HeaderField) []string {
+	var out []string
+	for _, f := range fields {
+		out = append(out, f.GetName()+": "+f.GetValue())
+	}
+	return out
+}
+
+func TestHTTP2Details(t *testing.T) {
+	server, err := NewMITMFlowServer(nil, nil)
+	require.NoError(t, err)
+
+	flow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Request: mitmproxyv1.Request_builder{
+				Method:      proto.String("GET"),
+				Url:         proto.String("https://example.com:8443/api?q=1"),
+				HttpVersion: proto.String("HTTP/2.0"),
+			}.Build(),
+			Response: mitmproxyv1.Response_builder{
+				StatusCode:  proto.Int32(204),
+				HttpVersion: proto.String("HTTP/2.0"),
+			}.Build(),
+		}.Build(),
+	}.Build()
+	server.preprocessFlow(flow)
+	details := flow.GetHttpFlowExtra().GetHttp2()
+	require.NotNil(t, details)
+	assert.Equal(t, []string{":method: GET", ":scheme: https", ":authority: example.com:8443", ":path: /api?q=1"}, headerFields(details.GetRequestPseudoHeaders()))
+	assert.Equal(t, []string{":status: 204"}, headerFields(details.GetResponsePseudoHeaders()))
+	assert.Empty(t, details.GetRequestHeaderOrder(), "mitmproxy sends headers unordered")
+
+	http1 := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Request: mitmproxyv1.Request_builder{
+				Method:      proto.String("GET"),
+				Url:         proto.String("http://example.com/"),
+				HttpVersion: proto.String("HTTP/1.1"),
+			}.Build(),
+		}.Build(),
+	}.Build()
+	server.preprocessFlow(http1)
+	assert.Nil(t, http1.GetHttpFlowExtra().GetHttp2())
+}
+
+func TestHTTP2Details_FromHAR(t *testing.T) {
+	har := []byte(`{"log": {"entries": [{
+		"startedDateTime": "2024-05-01T12:00:00Z",
+		"time": 10,
+		"request": {"method": "GET", "url": "https://example.com/", "httpVersion": "h2", "headers": [
+			{"name": ":method", "value": "GET"},
+			{"name": ":authority", "value": "example.com"},
+			{"name": ":scheme", "value": "https"},
+			{"name": ":path", "value": "/"},
+			{"name": "user-agent", "value": "test"},
+			{"name": "accept", "value": "*/*"}
+		]},
+		"response": {"status": 200, "httpVersion": "h2", "headers": [
+			{"name": "content-type", "value": "text/html"},
+			{"name": "date", "value": "Wed, 01 May 2024 12:00:00 GMT"}
+		], "content": {"text": "hi"}},
+		"timings": {"send": 1, "wait": 8, "receive": 1}
+	}]}}`)
+	flows, err := ParseHAR(har)
+	require.NoError(t, err)
+	require.Len(t, flows, 1)
+
+	server, err := NewMITMFlowServer(nil, nil)
+	require.NoError(t, err)
+	server.preprocessFlow(flows[0])
+	details := flows[0].GetHttpFlowExtra().GetHttp2()
+	assert.Equal(t, []string{":method: GET", ":authority: example.com", ":scheme: https", ":path: /"}, headerFields(details.GetRequestPseudoHeaders()))
+	assert.Equal(t, []string{"user-agent", "accept"}, details.GetRequestHeaderOrder())
+	assert.Equal(t, []string{":status: 200"}, headerFields(details.GetResponsePseudoHeaders()))
+	assert.Equal(t, []string{"content-type", "date"}, details.GetResponseHeaderOrder())
+}
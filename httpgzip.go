@@ -0,0 +1,131 @@
+package main
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+var gzipWriterPool = sync.Pool{
+	New: func() any {
+		w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+		return w
+	},
+}
+
+// gzipHandler gzips responses for clients that accept it. Connect already
+// compresses unary responses itself, and gRPC and Connect clients that ask
+// for per-message compression get that instead; this covers the UI's assets
+// and the Connect streams the browser reads, such as GetFlows, which it
+// can't ask to be compressed any other way.
+func gzipHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !acceptsGzip(r) ||
+			r.Method == http.MethodHead ||
+			r.Header.Get("Upgrade") != "" ||
+			r.Header.Get("Range") != "" ||
+			r.Header.Get("Connect-Accept-Encoding") != "" ||
+			strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			h.ServeHTTP(w, r)
+			return
+		}
+		gw := &gzipResponseWriter{ResponseWriter: w}
+		defer gw.close()
+		h.ServeHTTP(gw, r)
+	})
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			return strings.ReplaceAll(params, " ", "") != "q=0"
+		}
+	}
+	return false
+}
+
+// compressibleType reports whether a response of the given content type is
+// worth compressing.
+func compressibleType(contentType string) bool {
+	switch {
+	case strings.HasPrefix(contentType, "image/svg"):
+		return true
+	case strings.HasPrefix(contentType, "image/"),
+		strings.HasPrefix(contentType, "audio/"),
+		strings.HasPrefix(contentType, "video/"),
+		strings.HasPrefix(contentType, "font/woff"),
+		strings.HasPrefix(contentType, "application/zip"),
+		strings.HasPrefix(contentType, "application/gzip"),
+		strings.HasPrefix(contentType, "application/zstd"):
+		return false
+	}
+	return true
+}
+
+// gzipResponseWriter decides whether to compress when the status is written:
+// responses that are already encoded, have no body or aren't compressible
+// pass through untouched.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz          *gzip.Writer
+	wroteHeader bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	header := w.Header()
+	if header.Get("Content-Encoding") == "" &&
+		status != http.StatusNoContent && status != http.StatusNotModified && status != http.StatusPartialContent &&
+		compressibleType(header.Get("Content-Type")) {
+		header.Set("Content-Encoding", "gzip")
+		header.Add("Vary", "Accept-Encoding")
+		header.Del("Content-Length")
+		w.gz = gzipWriterPool.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *gzipResponseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(p))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.gz != nil {
+		return w.gz.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush sends what has been compressed so far, so streamed messages aren't
+// held back.
+func (w *gzipResponseWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.gz != nil {
+		w.gz.Flush() //nolint:errcheck
+	}
+	http.NewResponseController(w.ResponseWriter).Flush() //nolint:errcheck
+}
+
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *gzipResponseWriter) close() {
+	if w.gz == nil {
+		return
+	}
+	w.gz.Close() //nolint:errcheck
+	gzipWriterPool.Put(w.gz)
+	w.gz = nil
+}
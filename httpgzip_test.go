@@ -0,0 +1,67 @@
+package main
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGzipHandler(t *testing.T) {
+	body := strings.Repeat("flow summary ", 100)
+	handler := gzipHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/encoded":
+			w.Header().Set("Content-Encoding", "br")
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+		}
+		// Streams are flushed part way through.
+		io.WriteString(w, body[:10])          //nolint:errcheck
+		http.NewResponseController(w).Flush() //nolint:errcheck
+		io.WriteString(w, body[10:])          //nolint:errcheck
+	}))
+
+	serve := func(path string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("/", map[string]string{"Accept-Encoding": "br, gzip"})
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+	assert.True(t, rec.Flushed)
+	zr, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	got, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(got))
+
+	for name, tc := range map[string]struct {
+		path   string
+		header map[string]string
+	}{
+		"not accepted":        {"/", nil},
+		"refused":             {"/", map[string]string{"Accept-Encoding": "gzip;q=0"}},
+		"already encoded":     {"/encoded", map[string]string{"Accept-Encoding": "gzip"}},
+		"incompressible":      {"/image", map[string]string{"Accept-Encoding": "gzip"}},
+		"per-message connect": {"/", map[string]string{"Accept-Encoding": "gzip", "Connect-Accept-Encoding": "gzip"}},
+		"grpc":                {"/", map[string]string{"Accept-Encoding": "gzip", "Content-Type": "application/grpc"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := serve(tc.path, tc.header)
+			assert.NotEqual(t, "gzip", rec.Header().Get("Content-Encoding"))
+			assert.Equal(t, body, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"connectrpc.com/connect"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// UploadFlowBody receives bodies that are too large to send inside an
+// ExportFlowRequest. The ingestion protocol is owned by mitmproxygrpc, so
+// rather than change its messages the addon exports the flow with the body
+// left out and then streams the body here in chunks. Each body is reassembled
+// and attached to its flow, which is then processed as if it had arrived
+// whole: its size and hash are recorded, it's truncated or moved to the blob
+// store as configured and subscribers see the update.
+func (s *MITMFlowServer) UploadFlowBody(
+	ctx context.Context,
+	stream *connect.ClientStream[mitmflowv1.UploadFlowBodyRequest],
+) (*connect.Response[mitmflowv1.UploadFlowBodyResponse], error) {
+	var (
+		id       string
+		response bool
+		body     []byte
+		started  bool
+		bodies   int64
+		total    int64
+	)
+	finish := func() error {
+		if !started {
+			return nil
+		}
+		if err := s.attachBody(ctx, id, response, body); err != nil {
+			return err
+		}
+		bodies++
+		total += int64(len(body))
+		return nil
+	}
+
+	for stream.Receive() {
+		msg := stream.Msg()
+		if !started || msg.GetFlowId() != id || msg.GetResponse() != response {
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			id, response, body, started = msg.GetFlowId(), msg.GetResponse(), nil, true
+		}
+		body = append(body, msg.GetChunk()...)
+	}
+	if err := stream.Err(); err != nil {
+		return nil, connect.NewError(connect.CodeCanceled, err)
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(mitmflowv1.UploadFlowBodyResponse_builder{
+		Bodies: proto.Int64(bodies),
+		Bytes:  proto.Int64(total),
+	}.Build()), nil
+}
+
+// attachBody sets the request or response body of a stored HTTP flow and
+// ingests the result again.
+func (s *MITMFlowServer) attachBody(ctx context.Context, id string, response bool, body []byte) error {
+	stored, ok := s.visibleFlow(ctx, id)
+	if !ok {
+		return connect.NewError(connect.CodeNotFound, fmt.Errorf("flow %s not found, export it before uploading its body", id))
+	}
+	if stored.GetHttpFlow() == nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("flow %s is not an HTTP flow", id))
+	}
+	// The other body may have been moved to the blob store, and preprocessing
+	// needs it inline to describe it again.
+	flow, err := s.storage.HydrateFlow(ctx, stored)
+	if err != nil {
+		log.Printf("failed to load flow %s: %v", id, err)
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if flow == stored {
+		flow = proto.Clone(stored).(*mitmflowv1.Flow)
+	}
+
+	httpFlow := flow.GetHttpFlow()
+	if response {
+		resp := httpFlow.GetResponse()
+		if resp == nil {
+			return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("flow %s has no response", id))
+		}
+		resp.SetContent(body)
+		resp.ClearContentTruncated()
+	} else {
+		req := httpFlow.GetRequest()
+		if req == nil {
+			return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("flow %s has no request", id))
+		}
+		req.SetContent(body)
+		req.ClearContentTruncated()
+	}
+	s.ingest(flow)
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strings"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+// setJSONFrames renders a JSON body as a single frame, indented and with
+// object keys sorted, so searches match the formatted text and the same
+// document always renders the same way.
+func setJSONFrames(content []byte, headers map[string]string, details *mitmflowv1.MessageDetails) {
+	if len(details.GetTextualFrames()) > 0 || len(content) == 0 {
+		return
+	}
+	mediaType, _, _ := mime.ParseMediaType(getHeaderValue(headers, "Content-Type"))
+	if !isJSONMediaType(mediaType) {
+		return
+	}
+	if decoded, ok := decodeContentEncoding(content, getHeaderValue(headers, "Content-Encoding")); ok {
+		content = decoded
+	}
+	frame, ok := canonicalJSON(content)
+	if !ok {
+		return
+	}
+	details.SetTextualFrames([]string{capFrame(frame)})
+}
+
+// capFrame cuts a rendered frame larger than MaxTextualFrameSize off at the
+// last whole line that fits.
+func capFrame(frame string) string {
+	if len(frame) <= MaxTextualFrameSize {
+		return frame
+	}
+	cut := strings.LastIndexByte(frame[:MaxTextualFrameSize], '\n')
+	if cut < 0 {
+		cut = MaxTextualFrameSize
+	}
+	return frame[:cut] + "\n..."
+}
+
+// canonicalJSON indents a JSON document with its object keys sorted. Numbers
+// are kept exactly as written.
+func canonicalJSON(data []byte) (string, bool) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return "", false
+	}
+	return renderJSONValue(v), true
+}
+
+// renderJSONValue indents a decoded JSON value. Maps are encoded with their
+// keys sorted.
+func renderJSONValue(v any) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
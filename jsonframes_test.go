@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+func TestSetJSONFrames(t *testing.T) {
+	frames := func(content []byte, headers map[string]string) []string {
+		details := &mitmflowv1.MessageDetails{}
+		setJSONFrames(content, headers, details)
+		return details.GetTextualFrames()
+	}
+	jsonHeaders := map[string]string{"Content-Type": "application/vnd.api+json; charset=utf-8"}
+
+	assert.Equal(t, []string{"{\n  \"a\": [\n    1.50,\n    \"<b>\"\n  ],\n  \"b\": {}\n}"},
+		frames([]byte(`{"b":{},"a":[1.50,"<b>"]}`), jsonHeaders))
+	// Key order doesn't change the rendering.
+	assert.Equal(t, frames([]byte(`{"x":1,"y":2}`), jsonHeaders), frames([]byte(`{"y":2, "x":1}`), jsonHeaders))
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	_, err := zw.Write([]byte(`{"ok":true}`))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	assert.Equal(t, []string{"{\n  \"ok\": true\n}"}, frames(gz.Bytes(), map[string]string{
+		"Content-Type":     "application/json",
+		"Content-Encoding": "gzip",
+	}))
+
+	assert.Empty(t, frames([]byte(`{"ok":true}`), map[string]string{"Content-Type": "text/plain"}))
+	assert.Empty(t, frames([]byte(`{"ok":`), jsonHeaders))
+	assert.Empty(t, frames([]byte(`{} {}`), jsonHeaders))
+
+	large := frames([]byte(`["`+strings.Repeat("x", MaxTextualFrameSize)+`", 1]`), jsonHeaders)
+	require.Len(t, large, 1)
+	assert.Equal(t, "[\n...", large[0])
+}
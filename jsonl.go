@@ -0,0 +1,27 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// GenerateJSONL writes one flow per line in the protobuf JSON mapping, which
+// streams well into log pipelines and line-oriented tools like jq.
+func GenerateJSONL(flows []*mitmflowv1.Flow) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, flow := range flows {
+		data, err := protojson.Marshal(flow)
+		if err != nil {
+			return nil, err
+		}
+		// protojson output is not guaranteed to be compact.
+		if err := json.Compact(&buf, data); err != nil {
+			return nil, err
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+// jsonPathStep is one step of a JSONPath: a member name, an array index, a
+// wildcard, or a recursive descent to a name or wildcard.
+type jsonPathStep struct {
+	name      string
+	index     int
+	isIndex   bool
+	wildcard  bool
+	recursive bool
+}
+
+type jsonPath []jsonPathStep
+
+// compileJSONPath parses the subset of JSONPath that BodyQuery documents:
+// $, .name, ['name'], [index], [*], .* and ..name.
+func compileJSONPath(path string) (jsonPath, error) {
+	rest, ok := strings.CutPrefix(path, "$")
+	if !ok {
+		return nil, fmt.Errorf("JSONPath %q must start with $", path)
+	}
+	var steps jsonPath
+	for rest != "" {
+		var step jsonPathStep
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			step.recursive = true
+			rest = rest[2:]
+			if strings.HasPrefix(rest, "[") {
+				return nil, fmt.Errorf("JSONPath %q: .. must be followed by a name or *", path)
+			}
+			rest = parseJSONPathName(rest, &step)
+		case strings.HasPrefix(rest, "."):
+			rest = parseJSONPathName(rest[1:], &step)
+		case strings.HasPrefix(rest, "["):
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("JSONPath %q: missing ]", path)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			switch {
+			case inner == "*":
+				step.wildcard = true
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				step.name = inner[1 : len(inner)-1]
+			default:
+				i, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("JSONPath %q: unsupported selector [%s]", path, inner)
+				}
+				step.index, step.isIndex = i, true
+			}
+		default:
+			return nil, fmt.Errorf("JSONPath %q: unexpected %q", path, rest)
+		}
+		if !step.isIndex && !step.wildcard && step.name == "" {
+			return nil, fmt.Errorf("JSONPath %q: missing name", path)
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// parseJSONPathName reads a name or * up to the next . or [.
+func parseJSONPathName(s string, step *jsonPathStep) string {
+	end := strings.IndexAny(s, ".[")
+	if end < 0 {
+		end = len(s)
+	}
+	if s[:end] == "*" {
+		step.wildcard = true
+	} else {
+		step.name = s[:end]
+	}
+	return s[end:]
+}
+
+// eval returns the values the path selects in a decoded JSON document.
+func (p jsonPath) eval(doc any) []any {
+	values := []any{doc}
+	for _, step := range p {
+		var next []any
+		for _, v := range values {
+			if step.recursive {
+				walkJSON(v, func(v any) { next = append(next, step.children(v)...) })
+			} else {
+				next = append(next, step.children(v)...)
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+func (s jsonPathStep) children(v any) []any {
+	switch v := v.(type) {
+	case map[string]any:
+		if s.wildcard {
+			out := make([]any, 0, len(v))
+			for _, child := range v {
+				out = append(out, child)
+			}
+			return out
+		}
+		if child, ok := v[s.name]; ok && !s.isIndex {
+			return []any{child}
+		}
+	case []any:
+		if s.wildcard {
+			return v
+		}
+		if s.isIndex {
+			i := s.index
+			if i < 0 {
+				i += len(v)
+			}
+			if i >= 0 && i < len(v) {
+				return []any{v[i]}
+			}
+		}
+	}
+	return nil
+}
+
+// walkJSON calls fn for v and every value nested in it.
+func walkJSON(v any, fn func(any)) {
+	fn(v)
+	switch v := v.(type) {
+	case map[string]any:
+		for _, child := range v {
+			walkJSON(child, fn)
+		}
+	case []any:
+		for _, child := range v {
+			walkJSON(child, fn)
+		}
+	}
+}
+
+// jsonValueEquals compares a decoded JSON value with the text of
+// BodyQuery.equals.
+func jsonValueEquals(v any, want string) bool {
+	switch v := v.(type) {
+	case string:
+		return v == want
+	case json.Number:
+		got, err1 := v.Float64()
+		expected, err2 := strconv.ParseFloat(want, 64)
+		if err1 == nil && err2 == nil {
+			return got == expected
+		}
+		return v.String() == want
+	}
+	data, err := json.Marshal(v)
+	return err == nil && string(data) == want
+}
+
+// matchBodyQuery reports whether the JSON request or response body of an HTTP
+// flow matches q. Bodies moved to the blob store aren't loaded, so they
+// never match.
+func matchBodyQuery(flow *mitmflowv1.Flow, q *mitmflowv1.BodyQuery) bool {
+	path, err := compileJSONPath(q.GetPath())
+	if err != nil {
+		return false
+	}
+	var m message = flow.GetHttpFlow().GetResponse()
+	if q.GetRequest() {
+		m = flow.GetHttpFlow().GetRequest()
+	}
+	mediaType, _, _ := mime.ParseMediaType(getHeaderValue(m.GetHeaders(), "Content-Type"))
+	if !isJSONMediaType(mediaType) {
+		return false
+	}
+	dec := json.NewDecoder(bytes.NewReader(decodedBody(m)))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return false
+	}
+	values := path.eval(doc)
+	if !q.HasEquals() {
+		return len(values) > 0
+	}
+	for _, v := range values {
+		if jsonValueEquals(v, q.GetEquals()) {
+			return true
+		}
+	}
+	return false
+}
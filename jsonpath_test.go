@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestJSONPath(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`{"items":[{"id":1,"status":"ok"},{"id":2,"status":"failed","tags":{"a-b":true}}],"total":2}`))
+	dec.UseNumber()
+	var doc any
+	require.NoError(t, dec.Decode(&doc))
+
+	for path, want := range map[string][]any{
+		"$":                       {doc},
+		"$.total":                 {json.Number("2")},
+		"$.items[*].status":       {"ok", "failed"},
+		"$.items[-1].id":          {json.Number("2")},
+		"$['items'][0]['status']": {"ok"},
+		"$..id":                   {json.Number("1"), json.Number("2")},
+		"$.items[1].tags.a-b":     {true},
+		"$.items[5].id":           nil,
+		"$.missing":               nil,
+	} {
+		p, err := compileJSONPath(path)
+		require.NoError(t, err, path)
+		assert.ElementsMatch(t, want, p.eval(doc), path)
+	}
+
+	for _, path := range []string{"items", "$.items[", "$.items[?(@.id)]", "$..", "$.", "$..[0]"} {
+		_, err := compileJSONPath(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestMatchBodyQuery(t *testing.T) {
+	flow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Request: mitmproxyv1.Request_builder{
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Content: []byte(`{"dryRun":true}`),
+			}.Build(),
+			Response: mitmproxyv1.Response_builder{
+				Headers: map[string]string{"Content-Type": "application/json; charset=utf-8"},
+				Content: []byte(`{"items":[{"status":"ok"},{"status":"failed","retries":3.0}]}`),
+			}.Build(),
+		}.Build(),
+	}.Build()
+	query := func(path string, equals *string, request bool) bool {
+		q := mitmflowv1.BodyQuery_builder{Path: proto.String(path), Equals: equals, Request: proto.Bool(request)}.Build()
+		return matchFlow(flow, mitmflowv1.FlowFilter_builder{
+			Http: mitmflowv1.HttpFilter_builder{BodyQueries: []*mitmflowv1.BodyQuery{q}}.Build(),
+		}.Build())
+	}
+
+	assert.True(t, query("$.items[*].status", proto.String("failed"), false))
+	assert.False(t, query("$.items[*].status", proto.String("pending"), false))
+	assert.True(t, query("$.items[*].retries", proto.String("3"), false))
+	assert.True(t, query("$.items[1].retries", nil, false))
+	assert.False(t, query("$.items[0].retries", nil, false))
+	assert.True(t, query("$.dryRun", proto.String("true"), true))
+	assert.False(t, query("$.dryRun", nil, false))
+
+	match, err := parseFilterExpr("~jp $.items[*].status=failed")
+	require.NoError(t, err)
+	assert.True(t, match(flow))
+	match, err = parseFilterExpr("~jp $.dryRun")
+	require.NoError(t, err)
+	assert.True(t, match(flow))
+	_, err = parseFilterExpr("~jp items")
+	assert.Error(t, err)
+}
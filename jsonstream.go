@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strings"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+// isNDJSONMediaType reports whether a body of mediaType holds one JSON value
+// per line, or per record separator for application/json-seq.
+func isNDJSONMediaType(mediaType string) bool {
+	switch mediaType {
+	case "application/x-ndjson", "application/ndjson", "application/jsonl",
+		"application/x-jsonlines", "application/jsonlines", "application/json-seq":
+		return true
+	}
+	return false
+}
+
+// setJSONStreamFrames splits a streamed JSON body into one frame per record:
+// the lines of an NDJSON body, the elements of a JSON array sent with chunked
+// transfer encoding, or a sequence of concatenated JSON values. Only the
+// first MaxTextualFrames records get a frame; RecordCount counts them all.
+func setJSONStreamFrames(content []byte, headers map[string]string, details *mitmflowv1.MessageDetails) {
+	if len(details.GetTextualFrames()) > 0 || len(content) == 0 {
+		return
+	}
+	mediaType, _, _ := mime.ParseMediaType(getHeaderValue(headers, "Content-Type"))
+	ndjson := isNDJSONMediaType(mediaType)
+	if !ndjson && !isJSONMediaType(mediaType) {
+		return
+	}
+	if decoded, ok := decodeContentEncoding(content, getHeaderValue(headers, "Content-Encoding")); ok {
+		content = decoded
+	}
+	var records []string
+	if ndjson {
+		records = ndjsonRecords(content)
+	} else {
+		chunked := strings.EqualFold(getHeaderValue(headers, "Transfer-Encoding"), "chunked")
+		records = jsonStreamRecords(content, chunked)
+	}
+	if len(records) == 0 {
+		return
+	}
+	details.SetRecordCount(int32(len(records)))
+	details.SetTextualFrames(recordFrames(records))
+}
+
+// ndjsonRecords renders each line of an NDJSON body. Lines that aren't valid
+// JSON are kept as they are, so a stream cut off mid-record still shows what
+// arrived.
+func ndjsonRecords(content []byte) []string {
+	var records []string
+	for _, line := range bytes.FieldsFunc(content, func(r rune) bool { return r == '\n' || r == '\x1e' }) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if frame, ok := canonicalJSON(line); ok {
+			records = append(records, frame)
+		} else {
+			records = append(records, string(line))
+		}
+	}
+	return records
+}
+
+// jsonStreamRecords renders the records of a JSON body that was streamed: the
+// values of a sequence of concatenated JSON values, or, when the body was
+// sent chunked, the elements of a top-level array. Anything else is a single
+// document and returns nil.
+func jsonStreamRecords(content []byte, chunked bool) []string {
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.UseNumber()
+	var values []any
+	for {
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			break
+		}
+		values = append(values, v)
+		if !dec.More() {
+			break
+		}
+	}
+	if len(values) == 1 && chunked {
+		if elements, ok := values[0].([]any); ok && len(elements) > 1 {
+			values = elements
+		}
+	}
+	if len(values) < 2 {
+		return nil
+	}
+	records := make([]string, 0, len(values))
+	for _, v := range values {
+		records = append(records, renderJSONValue(v))
+	}
+	return records
+}
+
+// recordFrames turns records into frames, keeping the frame count within
+// MaxTextualFrames. When there are more records, the last frame says how many
+// were left out.
+func recordFrames(records []string) []string {
+	if len(records) <= MaxTextualFrames {
+		frames := make([]string, len(records))
+		for i, r := range records {
+			frames[i] = capFrame(r)
+		}
+		return frames
+	}
+	shown := MaxTextualFrames - 1
+	frames := make([]string, 0, MaxTextualFrames)
+	for _, r := range records[:shown] {
+		frames = append(frames, capFrame(r))
+	}
+	return append(frames, fmt.Sprintf("... %d more records (%d in total)", len(records)-shown, len(records)))
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+func TestSetJSONStreamFrames_NDJSON(t *testing.T) {
+	details := &mitmflowv1.MessageDetails{}
+	setJSONStreamFrames([]byte("{\"b\":1,\"a\":2}\n\n[1,2]\n{\"cut\":"), map[string]string{"Content-Type": "application/x-ndjson"}, details)
+	assert.Equal(t, []string{"{\n  \"a\": 2,\n  \"b\": 1\n}", "[\n  1,\n  2\n]", `{"cut":`}, details.GetTextualFrames())
+	assert.Equal(t, int32(3), details.GetRecordCount())
+
+	details = &mitmflowv1.MessageDetails{}
+	setJSONStreamFrames([]byte("\x1e{\"a\":1}\n\x1e{\"a\":2}\n"), map[string]string{"Content-Type": "application/json-seq"}, details)
+	assert.Equal(t, []string{"{\n  \"a\": 1\n}", "{\n  \"a\": 2\n}"}, details.GetTextualFrames())
+}
+
+func TestSetJSONStreamFrames_JSON(t *testing.T) {
+	jsonHeaders := map[string]string{"Content-Type": "application/json"}
+	chunkedHeaders := map[string]string{"Content-Type": "application/json", "Transfer-Encoding": "chunked"}
+
+	// A whole array is one document unless it was streamed.
+	details := &mitmflowv1.MessageDetails{}
+	setJSONStreamFrames([]byte(`[{"id":1},{"id":2}]`), jsonHeaders, details)
+	assert.Empty(t, details.GetTextualFrames())
+	assert.False(t, details.HasRecordCount())
+
+	details = &mitmflowv1.MessageDetails{}
+	setJSONStreamFrames([]byte(`[{"id":1},{"id":2}]`), chunkedHeaders, details)
+	assert.Equal(t, []string{"{\n  \"id\": 1\n}", "{\n  \"id\": 2\n}"}, details.GetTextualFrames())
+	assert.Equal(t, int32(2), details.GetRecordCount())
+
+	details = &mitmflowv1.MessageDetails{}
+	setJSONStreamFrames([]byte(`{"id":1}{"id":2} {"id":3}`), jsonHeaders, details)
+	assert.Len(t, details.GetTextualFrames(), 3)
+	assert.Equal(t, int32(3), details.GetRecordCount())
+
+	details = &mitmflowv1.MessageDetails{}
+	setJSONStreamFrames([]byte(`{"id":1}`), chunkedHeaders, details)
+	assert.Empty(t, details.GetTextualFrames())
+}
+
+func TestSetJSONStreamFrames_Truncation(t *testing.T) {
+	var body strings.Builder
+	for i := range 120 {
+		fmt.Fprintf(&body, "{\"i\":%d}\n", i)
+	}
+	details := &mitmflowv1.MessageDetails{}
+	setJSONStreamFrames([]byte(body.String()), map[string]string{"Content-Type": "application/x-ndjson; charset=utf-8"}, details)
+	frames := details.GetTextualFrames()
+	require.Len(t, frames, MaxTextualFrames)
+	assert.Equal(t, "{\n  \"i\": 0\n}", frames[0])
+	assert.Equal(t, fmt.Sprintf("... %d more records (120 in total)", 120-MaxTextualFrames+1), frames[MaxTextualFrames-1])
+	assert.Equal(t, int32(120), details.GetRecordCount())
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type mailProtocol int
+
+const (
+	mailSMTP mailProtocol = iota
+	mailIMAP
+	mailPOP3
+)
+
+const (
+	// maxMailLine bounds how long a line may get before the stream is taken
+	// for something other than a mail protocol.
+	maxMailLine = 64 * 1024
+	// maxMIMEParts bounds how many parts of a message are summarized.
+	maxMIMEParts = 100
+	// maxMIMEDepth bounds how deeply multiparts may nest.
+	maxMIMEDepth = 10
+)
+
+// imapLiteral matches the {n} or {n+} at the end of an IMAP line that
+// announces n bytes of literal data right after it.
+var imapLiteral = regexp.MustCompile(`\{(\d+)\+?\}$`)
+
+func isSMTPGreeting(first []byte) bool {
+	return bytes.HasPrefix(first, []byte("220 ")) && bytes.Contains(bytes.ToUpper(firstLine(first)), []byte("SMTP"))
+}
+
+func isIMAPGreeting(first []byte) bool {
+	return bytes.HasPrefix(first, []byte("* OK")) || bytes.HasPrefix(first, []byte("* PREAUTH"))
+}
+
+func isPOP3Greeting(first []byte) bool {
+	return bytes.HasPrefix(first, []byte("+OK")) && bytes.HasSuffix(first, []byte("\r\n"))
+}
+
+func firstLine(b []byte) []byte {
+	line, _, _ := bytes.Cut(b, []byte("\n"))
+	return line
+}
+
+// mailDecoder renders the conversation of a mail protocol as a transcript,
+// one line per command or response. Credentials are redacted, and messages
+// sent or fetched are summarized by their headers and MIME parts rather
+// than shown in full.
+type mailDecoder struct {
+	protocol mailProtocol
+	// authenticating is set while the client answers SASL challenges.
+	authenticating bool
+	// requested is set after a command whose response carries a message:
+	// DATA for SMTP, RETR or TOP for POP3.
+	requested bool
+	// receiving is set, per direction, while the lines of a message arrive.
+	receiving [2]bool
+	message   [2][]byte
+	// literal counts the bytes of an IMAP literal still to come, per
+	// direction.
+	literal [2]int
+}
+
+func (d *mailDecoder) decode(data []byte, fromClient bool) ([]string, []byte, error) {
+	dir := 0
+	if fromClient {
+		dir = 1
+	}
+	var lines []string
+	for len(data) > 0 {
+		if n := d.literal[dir]; n > 0 {
+			if len(data) < n {
+				break
+			}
+			lines = append(lines, d.renderLiteral(data[:n], fromClient))
+			d.literal[dir] = 0
+			data = data[n:]
+			continue
+		}
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			if len(data) > maxMailLine {
+				return nil, nil, errors.New("mail line is too long")
+			}
+			break
+		}
+		raw := data[:i+1]
+		data = data[i+1:]
+		if isBinary(bytes.TrimRight(raw, "\r\n")) {
+			return nil, nil, errors.New("mail line isn't text")
+		}
+		if d.receiving[dir] {
+			if line := string(bytes.TrimRight(raw, "\r\n")); line == "." {
+				d.receiving[dir] = false
+				lines = append(lines, mimeSummary(d.message[dir]), ".")
+				d.message[dir] = nil
+			} else {
+				d.message[dir] = append(d.message[dir], bytes.TrimPrefix(raw, []byte("."))...)
+			}
+			continue
+		}
+		line := string(bytes.TrimRight(raw, "\r\n"))
+		if fromClient {
+			lines = append(lines, d.clientLine(line))
+		} else {
+			lines = append(lines, d.serverLine(line))
+		}
+		if m := imapLiteral.FindStringSubmatch(line); m != nil && d.protocol == mailIMAP {
+			if n, err := strconv.Atoi(m[1]); err == nil && n > 0 {
+				d.literal[dir] = n
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return nil, data, nil
+	}
+	return []string{strings.Join(lines, "\n")}, data, nil
+}
+
+// clientLine renders a line the client sent, with any credentials in it
+// redacted.
+func (d *mailDecoder) clientLine(line string) string {
+	if d.authenticating {
+		return "(redacted)"
+	}
+	fields := strings.Fields(line)
+	if d.protocol == mailIMAP && len(fields) > 1 {
+		// IMAP commands start with a tag.
+		tag := fields[0]
+		switch strings.ToUpper(fields[1]) {
+		case "LOGIN":
+			if len(fields) > 3 {
+				return strings.Join(append(fields[:3:3], "(redacted)"), " ")
+			}
+		case "AUTHENTICATE":
+			d.authenticating = true
+			if len(fields) > 3 {
+				return tag + " " + fields[1] + " " + fields[2] + " (redacted)"
+			}
+		}
+		return line
+	}
+	if len(fields) == 0 {
+		return line
+	}
+	switch strings.ToUpper(fields[0]) {
+	case "AUTH":
+		d.authenticating = true
+		if len(fields) > 2 {
+			return fields[0] + " " + fields[1] + " (redacted)"
+		}
+	case "PASS":
+		return fields[0] + " (redacted)"
+	case "APOP":
+		if len(fields) > 2 {
+			return fields[0] + " " + fields[1] + " (redacted)"
+		}
+	case "DATA":
+		d.requested = d.protocol == mailSMTP
+	case "RETR", "TOP":
+		d.requested = d.protocol == mailPOP3
+	}
+	return line
+}
+
+func (d *mailDecoder) serverLine(line string) string {
+	switch d.protocol {
+	case mailSMTP:
+		if d.authenticating && !strings.HasPrefix(line, "334") {
+			d.authenticating = false
+		}
+		// With pipelining, the replies to earlier commands can come between
+		// DATA and its 354.
+		if d.requested && strings.HasPrefix(line, "354") {
+			d.requested = false
+			d.receiving[1] = true
+		}
+	case mailIMAP:
+		if d.authenticating && !strings.HasPrefix(line, "+") {
+			d.authenticating = false
+		}
+	case mailPOP3:
+		if d.authenticating && !strings.HasPrefix(line, "+ ") {
+			d.authenticating = false
+		}
+		if d.requested {
+			d.requested = false
+			d.receiving[0] = strings.HasPrefix(line, "+OK")
+		}
+	}
+	return line
+}
+
+// renderLiteral renders an IMAP literal: a summary when it holds a message,
+// its text when it's short, and otherwise only its size. Literals from the
+// client can hold credentials, so only messages it appends are shown.
+func (d *mailDecoder) renderLiteral(literal []byte, fromClient bool) string {
+	if looksLikeMessage(literal) {
+		return mimeSummary(literal)
+	}
+	if !fromClient && !isBinary(literal) && len(literal) <= 1024 {
+		return string(literal)
+	}
+	return "(" + countOf(len(literal), "byte") + ")"
+}
+
+// looksLikeMessage reports whether data starts with an RFC 5322 header.
+func looksLikeMessage(data []byte) bool {
+	name, _, ok := bytes.Cut(firstLine(data), []byte(":"))
+	if !ok || len(name) == 0 || bytes.ContainsAny(name, " \t") {
+		return false
+	}
+	return bytes.Contains(data, []byte("\n\n")) || bytes.Contains(data, []byte("\r\n\r\n"))
+}
+
+// mimeSummary renders the headers of a message that say who sent it to whom
+// and what about, and a line for each of its MIME parts.
+func mimeSummary(raw []byte) string {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return "(message of " + countOf(len(raw), "byte") + ")"
+	}
+	var b strings.Builder
+	b.WriteString("message of " + formatBytes(int64(len(raw))))
+	dec := new(mime.WordDecoder)
+	for _, name := range []string{"From", "To", "Cc", "Subject", "Date"} {
+		v := msg.Header.Get(name)
+		if v == "" {
+			continue
+		}
+		if decoded, err := dec.DecodeHeader(v); err == nil {
+			v = decoded
+		}
+		fmt.Fprintf(&b, "\n  %s: %s", name, v)
+	}
+	parts := 0
+	summarizeMIMEPart(&b, msg.Header, msg.Body, 0, &parts)
+	return b.String()
+}
+
+type mimeHeader interface {
+	Get(string) string
+}
+
+func summarizeMIMEPart(b *strings.Builder, header mimeHeader, body io.Reader, depth int, parts *int) {
+	if *parts >= maxMIMEParts {
+		return
+	}
+	*parts++
+	indent := strings.Repeat("  ", depth+1)
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "text/plain"
+	}
+	if strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "" && depth < maxMIMEDepth {
+		fmt.Fprintf(b, "\n%s- %s", indent, mediaType)
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if err != nil {
+				break
+			}
+			summarizeMIMEPart(b, part.Header, part, depth+1, parts)
+			if *parts >= maxMIMEParts {
+				fmt.Fprintf(b, "\n%s  ...", indent)
+				return
+			}
+		}
+		return
+	}
+	content, _ := io.ReadAll(body)
+	if strings.EqualFold(strings.TrimSpace(header.Get("Content-Transfer-Encoding")), "base64") {
+		if decoded, err := base64.StdEncoding.DecodeString(string(content)); err == nil {
+			content = decoded
+		}
+	}
+	line := "- " + mediaType
+	_, disposition, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	filename := disposition["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	if filename != "" {
+		line += fmt.Sprintf(" %q", filename)
+	}
+	fmt.Fprintf(b, "\n%s%s, %s", indent, line, formatBytes(int64(len(content))))
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const testMailMessage = "From: Ann <ann@example.com>\r\n" +
+	"To: bob@example.com\r\n" +
+	"Subject: =?UTF-8?Q?Quarterly_r=C3=A9port?=\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=b1\r\n" +
+	"\r\n" +
+	"--b1\r\n" +
+	"Content-Type: text/plain\r\n" +
+	"\r\n" +
+	"See attached.\r\n" +
+	"--b1\r\n" +
+	"Content-Type: application/pdf; name=report.pdf\r\n" +
+	"Content-Disposition: attachment; filename=report.pdf\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"JVBERi0xLjQK\r\n" +
+	"--b1--\r\n"
+
+func mailMessages(lines ...string) []*mitmproxyv1.TCPMessage {
+	var messages []*mitmproxyv1.TCPMessage
+	for _, line := range lines {
+		fromClient := strings.HasPrefix(line, "C: ")
+		messages = append(messages, mitmproxyv1.TCPMessage_builder{
+			FromClient: proto.Bool(fromClient),
+			Content:    []byte(line[3:]),
+		}.Build())
+	}
+	return messages
+}
+
+func mailFrames(t *testing.T, details []*mitmflowv1.MessageDetails) []string {
+	t.Helper()
+	frames := make([]string, len(details))
+	for i, d := range details {
+		require.Len(t, d.GetTextualFrames(), 1, "message %d", i)
+		frames[i] = d.GetTextualFrames()[0]
+	}
+	return frames
+}
+
+func TestSMTPTranscript(t *testing.T) {
+	flow := mitmproxyv1.TCPFlow_builder{
+		Server: mitmproxyv1.ServerConn_builder{AddressPort: proto.Uint32(10025)}.Build(),
+		Messages: mailMessages(
+			"S: 220 mx.example.com ESMTP ready\r\n",
+			"C: EHLO client\r\n",
+			"S: 250-mx.example.com\r\n250 AUTH LOGIN PLAIN\r\n",
+			"C: AUTH LOGIN\r\n",
+			"S: 334 VXNlcm5hbWU6\r\n",
+			"C: YW5u\r\n",
+			"S: 235 2.7.0 Authentication successful\r\n",
+			"C: MAIL FROM:<ann@example.com>\r\nRCPT TO:<bob@example.com>\r\nDATA\r\n",
+			"S: 250 OK\r\n250 OK\r\n354 Go ahead\r\n",
+			"C: "+testMailMessage+".\r\n",
+			"S: 250 Queued\r\n",
+		),
+	}.Build()
+	details := tcpMessageDetails(flow, false).GetMessages()
+	assert.Equal(t, "application/x-smtp", details[0].GetEffectiveContentType())
+	frames := mailFrames(t, details)
+	assert.Equal(t, "(redacted)", frames[5])
+	assert.Equal(t, "MAIL FROM:<ann@example.com>\nRCPT TO:<bob@example.com>\nDATA", frames[7])
+	assert.Equal(t, "message of 375B\n"+
+		"  From: Ann <ann@example.com>\n"+
+		"  To: bob@example.com\n"+
+		"  Subject: Quarterly réport\n"+
+		"  - multipart/mixed\n"+
+		"    - text/plain, 13B\n"+
+		"    - application/pdf \"report.pdf\", 9B\n"+
+		".", frames[9])
+}
+
+func TestIMAPAndPOP3Redaction(t *testing.T) {
+	imap := mitmproxyv1.TCPFlow_builder{
+		Messages: mailMessages(
+			"S: * OK IMAP4rev1 ready\r\n",
+			"C: a1 LOGIN ann hunter2\r\n",
+			"S: a1 OK LOGIN completed\r\n",
+			"C: a2 FETCH 1 BODY[]\r\n",
+			"S: * 1 FETCH (BODY[] {375}\r\n"+testMailMessage+")\r\na2 OK FETCH completed\r\n",
+		),
+	}.Build()
+	frames := mailFrames(t, tcpMessageDetails(imap, false).GetMessages())
+	assert.Equal(t, "a1 LOGIN ann (redacted)", frames[1])
+	assert.True(t, strings.HasPrefix(frames[4], "* 1 FETCH (BODY[] {375}\nmessage of 375B\n  From: Ann"), frames[4])
+	assert.True(t, strings.HasSuffix(frames[4], ")\na2 OK FETCH completed"), frames[4])
+
+	pop3 := mitmproxyv1.TCPFlow_builder{
+		Messages: mailMessages(
+			"S: +OK POP3 server ready\r\n",
+			"C: USER ann\r\nPASS hunter2\r\n",
+		),
+	}.Build()
+	frames = mailFrames(t, tcpMessageDetails(pop3, false).GetMessages())
+	assert.Equal(t, "USER ann\nPASS (redacted)", frames[1])
+}
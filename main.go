@@ -1,25 +1,36 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"embed"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"io/fs"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"connectrpc.com/connect"
+	"connectrpc.com/grpcreflect"
 	"connectrpc.com/validate"
-	"github.com/gabriel-vasile/mimetype"
-	"github.com/google/uuid"
 	"github.com/rs/cors"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
@@ -45,30 +56,327 @@ func (i *stringArrayFlags) Set(value string) error {
 	return nil
 }
 
+// version is set at build time with -ldflags "-X main.version=...".
+var version = "dev"
+
 var (
-	addr            = flag.String("addr", "127.0.0.1:50051", "Address to listen on")
-	dataDir         = flag.String("data-dir", "mitmflow_data", "Directory to store flow data")
-	maxFlows        = flag.Int("max-flows", 500, "Maximum number of unpinned flows to keep")
-	descriptorFiles stringArrayFlags
+	addr             = flag.String("addr", "127.0.0.1:50051", "Address to listen on")
+	dataDir          = flag.String("data-dir", "mitmflow_data", "Directory to store flow data")
+	maxFlows         = flag.Int("max-flows", 500, "Maximum number of unpinned flows to keep")
+	maxAge           = flag.Duration("max-age", 0, "Prune unpinned flows older than this, e.g. 24h (0 keeps them until -max-flows is reached)")
+	retainTags       stringArrayFlags
+	compress         = flag.Bool("compress", true, "Compress stored flows with zstd")
+	flushInterval    = flag.Duration("flush-interval", 250*time.Millisecond, "Write saved flows to disk in batches this often, writing each flow once per batch (0 writes every save immediately)")
+	zstdDictFile     = flag.String("zstd-dict", "", "Path to a zstd dictionary used to compress stored flows")
+	blobThreshold    = flag.Int("blob-threshold", 0, "Store request/response bodies larger than this many bytes as separate blobs (0 disables)")
+	blobStore        = flag.String("blob-store", "", "Where to store body blobs: a directory or s3://bucket/prefix (defaults to <data-dir>/blobs)")
+	maxBodyBytes     = flag.Int("max-body-bytes", 0, "Truncate request/response bodies larger than this many bytes at ingest (0 disables)")
+	maxIngestBytes   = flag.Int("max-ingest-message-bytes", 0, "Reject flows from mitmproxy whose message is larger than this many bytes (0 means no limit)")
+	streamKeepalive  = flag.Duration("stream-keepalive", 15*time.Second, "Send a keepalive on flow streams idle this long, so proxies keep them open and the UI notices disconnects (0 disables)")
+	noUI             = flag.Bool("no-ui", false, "Don't serve the web UI, only the RPC services")
+	oidcIssuer       = flag.String("oidc-issuer", "", "Sign UI users in with this OpenID Connect provider, e.g. https://accounts.example.com")
+	oidcClientID     = flag.String("oidc-client-id", "", "OAuth client ID registered with the OIDC provider, with <public URL>/auth/callback as a redirect URL")
+	oidcSecretFile   = flag.String("oidc-client-secret-file", "", "File holding the OAuth client secret")
+	oidcScopes       = flag.String("oidc-scopes", "openid profile email", "Space-separated scopes to request from the OIDC provider")
+	oidcGroupsClaim  = flag.String("oidc-groups-claim", "groups", "ID token claim listing the user's groups")
+	oidcGroupRoles   stringArrayFlags
+	oidcDefaultRole  = flag.String("oidc-default-role", "", "Role of users in none of the -oidc-group-role groups: viewer, editor or admin (default deny them)")
+	oidcSessionTTL   = flag.Duration("oidc-session-ttl", 12*time.Hour, "How long UI users stay signed in")
+	tokenFile        = flag.String("token-file", "", "Require clients to send a bearer token listed in this file, one \"ROLE TOKEN [NAME [private]]\" per line, where ROLE is viewer, editor or admin and private hides the flows the token sends from everyone but it and admins")
+	auditLogFile     = flag.String("audit-log", "", "Append a record of every delete, pin, note and export, with who made it, to this file")
+	accessLog        = flag.String("access-log", "", "Write a JSON access log of RPCs and UI requests to this file, or - for stderr")
+	gzipResponses    = flag.Bool("gzip", true, "Gzip HTTP responses, including flow streams read by the UI, for clients that accept it")
+	basePath         = flag.String("base-path", "", "Path prefix to serve the UI and RPC services under, e.g. /mitmflow")
+	publicURL        = flag.String("public-url", "", "URL the UI uses to reach the server, e.g. https://example.com/mitmflow (derived from each request by default)")
+	uiDir            = flag.String("ui-dir", "", "Serve UI assets from this directory, falling back to the embedded UI")
+	archiveDir       = flag.String("archive-dir", "", "Move pruned flows into this directory instead of deleting them")
+	trashRetention   = flag.Duration("trash-retention", time.Hour, "Keep deleted flows this long so they can be restored (0 deletes them immediately)")
+	backupDir        = flag.String("backup-dir", "", "Directory for backups created or restored by path (defaults to <data-dir>/backups)")
+	descriptorFiles  stringArrayFlags
+	geoIPFiles       stringArrayFlags
+	autoExportRules  stringArrayFlags
+	alertRules       stringArrayFlags
+	alertWebhooks    stringArrayFlags
+	scriptFiles      stringArrayFlags
+	decoderHookSpecs stringArrayFlags
+	skipDecodeTypes  stringArrayFlags
+	contentTypeRules stringArrayFlags
+	autoExportEvery  = flag.Duration("auto-export-interval", 0, "Also write auto-exports of the running capture session this often (0 only exports when it ends)")
+	watchDir         = flag.String("watch-dir", "", "Import mitmproxy dumps and HAR files dropped into this directory, tagging their flows with source=<filename>")
+	reverseDNS       = flag.Bool("reverse-dns", false, "Look up the hostname of TCP/UDP servers reached by IP alone, when no captured DNS flow names them")
+	contentTypePrio  = flag.String("content-type-priority", "sniff", "Whether the type sniffed from a body (sniff) or its Content-Type header (header) decides how it is decoded, for types no -content-type-rule covers")
+	stripEXIF        = flag.Bool("strip-exif", false, "Don't record the EXIF tags of captured images, which can include where a photo was taken")
+	maxProtobufBytes = flag.Int("max-protobuf-bytes", 0, "Don't decode protobuf bodies, gRPC and Connect streams included, larger than this many bytes (0 disables)")
+	maxFramesBytes   = flag.Int("max-frames-bytes", 0, "Cap the decoded textual frames of a request, response or stream message at this many bytes in total (0 disables)")
+	pluginsDir       = flag.String("plugins-dir", "", "Load WebAssembly decoder plugins from the .wasm files in this directory, which decode bodies of the content types they register into textual frames")
+	redactDBValues   = flag.Bool("redact-db-values", false, "Leave query parameters, literals and row values out of decoded PostgreSQL and MySQL traffic")
 )
 
 func init() {
 	flag.Var(&descriptorFiles, "descriptor-set", "Path to a protobuf descriptor set file (can be repeated)")
+	flag.Var(&autoExportRules, "auto-export", "Write each capture session to a directory or S3 when mitmproxy disconnects, as FORMAT=LOCATION, e.g. har=./captures or jsonl=s3://bucket/ci (can be repeated)")
+	flag.Var(&retainTags, "retain-tag", "Never prune flows with this metadata key, or key=value, like pinned flows (can be repeated)")
+	flag.Var(&oidcGroupRoles, "oidc-group-role", "Give members of an OIDC group a role, as GROUP=ROLE, e.g. sre=admin (can be repeated)")
+	flag.Var(&contentTypeRules, "content-type-rule", "Decide how bodies declared as TYPE are decoded by their header or by sniffing, as TYPE=header or TYPE=sniff, where TYPE may be a wildcard like image/* (can be repeated)")
+	flag.Var(&alertRules, "alert", "Alert when flows matching a filter expression arrive, as name=NAME,count=N,window=DURATION,by=host|client,expr=EXPR, e.g. count=11,window=60s,by=host,expr=~c 5xx for more than 10 5xx responses from a host in a minute; only expr is required (can be repeated)")
+	flag.Var(&alertWebhooks, "alert-webhook", "POST alerts as JSON to this URL besides logging them, or post them as messages to a Slack or Discord incoming webhook given as slack=URL or discord=URL; set -public-url for links to the flows (can be repeated)")
+	flag.Var(&scriptFiles, "script", "Run ingested flows through the process(flow) function of this Starlark script, which can tag them, set their note, add textual frames or drop them (can be repeated)")
+	flag.Var(&skipDecodeTypes, "skip-decode", "Don't decode bodies of this content type, which may be a wildcard like video/* (can be repeated)")
+	flag.Var(&decoderHookSpecs, "decoder-hook", "Decode bodies of TYPE with a command, which gets the body on stdin, or an http(s) URL, which gets it POSTed, as TYPE=COMMAND or TYPE=URL, where TYPE may be a wildcard like application/*; the decoded text is split into frames at NUL bytes (can be repeated)")
+	flag.Var(&geoIPFiles, "geoip-db", "Path to a MaxMind country or ASN database used to annotate server addresses (can be repeated)")
+	flag.Usage = func() {
+		out := flag.CommandLine.Output()
+		fmt.Fprintf(out, "Usage: mitmflow [serve|export|import|tail] [flags]\n\n")
+		fmt.Fprintf(out, "Run a subcommand with -h for its flags. serve is the default and accepts:\n")
+		flag.PrintDefaults()
+	}
 }
 
 type MITMFlowServer struct {
-	subscribers map[string]chan *mitmflowv1.Flow
-	mu          sync.RWMutex
-	storage     *FlowStorage
-	registry    *Registry
+	subscribers  map[string]*subscriber
+	mu           sync.RWMutex
+	storage      *FlowStorage
+	registry     *Registry
+	maxBodyBytes int
+	// ingestMaxBytes is the largest message accepted from mitmproxy, or
+	// zero for no limit.
+	ingestMaxBytes int
+	// keepalive is how often idle flow streams are sent a Keepalive, or zero
+	// to send none.
+	keepalive    time.Duration
+	backupDir    string
+	geoIP        *GeoIP
+	hostnames    *hostnameResolver
+	cors         *corsTracker
+	manifests    *manifestTracker
+	dnsAnomalies *dnsAnomalyDetector
+	// contentTypes picks the effective content type of bodies.
+	contentTypes *contentTypePolicy
+	baseline     *baseline
+	proxyRules   *proxyRules
+	anonymizer   *anonymizer
+	autoExport   *autoExporter
+	alerts       *alerter
+	scripts      []*flowScript
+	plugins      *decoderPlugins
+	decoderHooks decoderHooks
+	decodeLimits decodeLimits
+	// stripEXIF leaves EXIF tags out of the media info of images.
+	stripEXIF bool
+	// redactDBValues leaves query parameters, literals and row values out of
+	// decoded database traffic.
+	redactDBValues bool
+	// auth is nil when clients don't need to authenticate.
+	auth *authenticator
+	// auditLog is nil unless changes to flows are audited.
+	auditLog  *auditLog
+	startTime time.Time
+
+	// ingestMu serializes saving flows from mitmproxy with late updates to
+	// them, like hostnames found by reverse DNS.
+	ingestMu sync.Mutex
+}
+
+// ServerOption configures optional MITMFlowServer behavior.
+type ServerOption func(*MITMFlowServer)
+
+// WithMaxBodyBytes truncates request and response bodies larger than n bytes
+// at ingest. Zero means bodies are kept in full.
+func WithMaxBodyBytes(n int) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.maxBodyBytes = n
+	}
+}
+
+// WithMaxIngestMessageBytes rejects ExportFlowRequest messages from mitmproxy
+// larger than n bytes. Zero means there is no limit.
+func WithMaxIngestMessageBytes(n int) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.ingestMaxBytes = n
+	}
+}
+
+// WithStreamKeepalive sends a Keepalive on flow streams that have been idle
+// for interval.
+func WithStreamKeepalive(interval time.Duration) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.keepalive = interval
+	}
+}
+
+// WithAuth requires clients to authenticate with one of auth's tokens or an
+// OIDC session, whose role must allow the RPC they call.
+func WithAuth(auth *authenticator) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.auth = auth
+	}
+}
+
+// WithBackupDir sets the directory CreateBackup and RestoreBackup read and
+// write backup files in when a path is given.
+func WithBackupDir(dir string) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.backupDir = dir
+	}
+}
+
+// WithGeoIP annotates flows with the country and ASN of their server.
+func WithGeoIP(g *GeoIP) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.geoIP = g
+	}
+}
+
+// WithReverseDNS enables rate-limited reverse DNS lookups for TCP and UDP
+// flows to IP addresses that no captured DNS flow has named.
+func WithReverseDNS() ServerOption {
+	return func(s *MITMFlowServer) {
+		s.hostnames.lookupAddr = net.DefaultResolver.LookupAddr
+	}
+}
+
+// WithContentTypePriority sets whether the Content-Type header or the type
+// sniffed from the body wins for declared types no rule covers.
+func WithContentTypePriority(source contentTypeSource) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.contentTypes.fallback = source
+	}
+}
+
+// WithContentTypeRule sets whether the Content-Type header or the sniffed
+// type wins for a declared media type, or for a whole type/* family.
+func WithContentTypeRule(mediaType string, source contentTypeSource) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.contentTypes.rules[mediaType] = source
+	}
+}
+
+// WithStripEXIF leaves EXIF tags, which can include where a photo was taken,
+// out of the media info recorded for images.
+func WithStripEXIF() ServerOption {
+	return func(s *MITMFlowServer) {
+		s.stripEXIF = true
+	}
+}
+
+// WithDBValueRedaction leaves query parameters, literals and row values out
+// of the frames decoded from PostgreSQL and MySQL traffic, keeping the
+// statements and row counts.
+func WithDBValueRedaction() ServerOption {
+	return func(s *MITMFlowServer) {
+		s.redactDBValues = true
+	}
+}
+
+// WithBaselineFile keeps the baseline set through SetBaseline in filename,
+// so it survives restarts.
+func WithBaselineFile(filename string) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.baseline.filename = filename
+	}
+}
+
+// WithProxyRulesFile keeps the rules set through SetProxyRules in filename,
+// so they survive restarts.
+func WithProxyRulesFile(filename string) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.proxyRules.filename = filename
+	}
+}
+
+// WithAnonymizationKey sets the HMAC key that anonymized exports derive
+// pseudonyms from. Without one, a random key is used, so pseudonyms only stay
+// the same until the server restarts.
+func WithAnonymizationKey(key []byte) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.anonymizer = newAnonymizer(key)
+	}
+}
+
+// WithAlerts checks ingested flows against rules and sends the alerts they
+// trip to notifiers. With a publicURL, alerts link to their flow in the UI.
+func WithAlerts(publicURL string, rules []alertRule, notifiers ...alertNotifier) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.alerts.rules = rules
+		s.alerts.notifiers = notifiers
+		s.alerts.publicURL = publicURL
+	}
+}
+
+// WithScripts runs each ingested flow through scripts, in order, before it
+// is stored.
+func WithScripts(scripts ...*flowScript) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.scripts = scripts
+	}
+}
+
+// WithDecoderPlugins decodes bodies of the content types plugins register
+// with them.
+func WithDecoderPlugins(plugins *decoderPlugins) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.plugins = plugins
+	}
+}
+
+// WithDecoderHooks decodes bodies of the content types hooks are given for
+// with them, over plugins.
+func WithDecoderHooks(hooks ...decoderHook) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.decoderHooks = hooks
+	}
+}
+
+// WithDecodeLimits bounds the work preprocessing does on bodies.
+func WithDecodeLimits(limits decodeLimits) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.decodeLimits = limits
+	}
+}
+
+// WithAutoExport writes every capture session to the locations of rules when
+// it ends and, when interval is positive, every interval while it lasts.
+func WithAutoExport(interval time.Duration, rules ...autoExportRule) ServerOption {
+	return func(s *MITMFlowServer) {
+		s.autoExport.rules = rules
+		s.autoExport.interval = interval
+	}
 }
 
-func NewMITMFlowServer(storage *FlowStorage, registry *Registry) (*MITMFlowServer, error) {
-	return &MITMFlowServer{
-		subscribers: make(map[string]chan *mitmflowv1.Flow),
-		storage:     storage,
-		registry:    registry,
-	}, nil
+func NewMITMFlowServer(storage *FlowStorage, registry *Registry, opts ...ServerOption) (*MITMFlowServer, error) {
+	s := &MITMFlowServer{
+		subscribers:  make(map[string]*subscriber),
+		storage:      storage,
+		registry:     registry,
+		hostnames:    newHostnameResolver(),
+		cors:         newCORSTracker(),
+		dnsAnomalies: newDNSAnomalyDetector(),
+		manifests:    newManifestTracker(),
+		contentTypes: newContentTypePolicy(),
+		baseline:     newBaseline(),
+		proxyRules:   newProxyRules(),
+		autoExport:   newAutoExporter(),
+		alerts:       newAlerter(),
+		startTime:    time.Now(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.autoExport.storage = storage
+	if err := s.baseline.load(); err != nil {
+		return nil, err
+	}
+	if err := s.proxyRules.load(); err != nil {
+		return nil, err
+	}
+	if s.anonymizer == nil {
+		key := make([]byte, sha256.Size)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		s.anonymizer = newAnonymizer(key)
+	}
+	return s, nil
 }
 
 func (s *MITMFlowServer) ExportFlow(
@@ -76,6 +384,8 @@ func (s *MITMFlowServer) ExportFlow(
 	stream *connect.ClientStream[mitmproxygrpcv1.ExportFlowRequest],
 ) (*connect.Response[mitmproxygrpcv1.ExportFlowResponse], error) {
 	var flowCount uint64
+	s.autoExport.begin()
+	defer s.autoExport.end(context.WithoutCancel(ctx))
 	for stream.Receive() {
 		flowCount++
 		req := stream.Msg()
@@ -94,21 +404,16 @@ func (s *MITMFlowServer) ExportFlow(
 			log.Printf("unknown flow type: %T", inFlow.WhichFlow())
 			continue
 		}
-		s.preprocessFlow(flow)
-		if err := s.storage.SaveFlow(flow); err != nil {
-			log.Printf("failed to save flow: %v", err)
-		}
-		s.mu.RLock()
-		for _, ch := range s.subscribers {
-			select {
-			case ch <- flow:
-			default:
-				// subscriber is not ready, drop the flow
-			}
-		}
-		s.mu.RUnlock()
+		claim(ctx, flow)
+		s.ingest(flow)
+		s.autoExport.add(GetFlowID(flow))
 	}
 	if err := stream.Err(); err != nil {
+		if connect.CodeOf(err) == connect.CodeResourceExhausted {
+			err = fmt.Errorf("flow %d is larger than the %d bytes mitmflow accepts, raise -max-ingest-message-bytes or send its body with UploadFlowBody: %w", flowCount+1, s.ingestMaxBytes, err)
+			log.Printf("ExportFlow: %v", err)
+			return nil, connect.NewError(connect.CodeResourceExhausted, err)
+		}
 		return nil, connect.NewError(connect.CodeCanceled, err)
 	}
 	log.Printf("Client disconnected gracefully. Received %d flows in total.", flowCount)
@@ -122,18 +427,305 @@ func (s *MITMFlowServer) ExportFlow(
 	return res, nil
 }
 
+// ingest preprocesses and saves a newly captured flow and sends it to
+// subscribers.
+func (s *MITMFlowServer) ingest(flow *mitmflowv1.Flow) {
+	s.ingestMu.Lock()
+	s.preprocessFlow(flow)
+	if s.runScripts(flow) {
+		s.ingestMu.Unlock()
+		return
+	}
+	if err := s.storage.SaveFlow(flow); err != nil {
+		log.Printf("failed to save flow: %v", err)
+	}
+	s.ingestMu.Unlock()
+	s.broadcast(flow)
+	if alerts := s.alerts.observe(flow, time.Now()); len(alerts) > 0 {
+		go s.alerts.notify(context.Background(), alerts)
+	}
+}
+
+// summarize converts a flow to a summary carrying its change sequence.
+func (s *MITMFlowServer) summarize(flow *mitmflowv1.Flow) *mitmflowv1.FlowSummary {
+	summary := convertToSummary(flow)
+	summary.SetSequence(s.storage.FlowSequence(GetFlowID(flow)))
+	return summary
+}
+
+// broadcast sends a new or updated flow to every StreamFlows subscriber.
+func (s *MITMFlowServer) broadcast(flow *mitmflowv1.Flow) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, sub := range s.subscribers {
+		select {
+		case sub.ch <- flow:
+		default:
+			// subscriber is not ready, drop the flow
+			sub.dropped.Add(1)
+		}
+	}
+}
+
+func (s *MITMFlowServer) ImportFlows(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.ImportFlowsRequest],
+) (*connect.Response[mitmflowv1.ImportFlowsResponse], error) {
+	set, err := decodeFlowSet(req.Msg.GetData())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid flow bundle: %w", err))
+	}
+
+	var imported []*mitmflowv1.Flow
+	for _, flow := range set.GetFlows() {
+		claim(ctx, flow)
+		if err := s.storage.SaveFlow(flow); err != nil {
+			log.Printf("failed to import flow: %v", err)
+			continue
+		}
+		s.broadcast(flow)
+		imported = append(imported, flow)
+	}
+	count := int64(len(imported))
+	log.Printf("Imported %d of %d flows", count, len(set.GetFlows()))
+	s.audit(ctx, req.Peer(), mitmflowv1.AuditAction_AUDIT_ACTION_IMPORT, flowIDs(imported), len(imported), "")
+
+	return connect.NewResponse(mitmflowv1.ImportFlowsResponse_builder{
+		Count: proto.Int64(count),
+	}.Build()), nil
+}
+
+func (s *MITMFlowServer) CreateBackup(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.CreateBackupRequest],
+	stream *connect.ServerStream[mitmflowv1.CreateBackupResponse],
+) error {
+	if req.Msg.GetPath() != "" {
+		filename, err := s.backupPath(req.Msg.GetPath())
+		if err != nil {
+			return err
+		}
+		var count int
+		err = writeFileAtomicFunc(filename, 0644, func(w io.Writer) error {
+			count, err = s.storage.WriteBackup(ctx, w)
+			return err
+		})
+		if err != nil {
+			log.Printf("Backup to %s failed: %v", filename, err)
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		log.Printf("Wrote backup of %d flows to %s", count, filename)
+		s.audit(ctx, req.Peer(), mitmflowv1.AuditAction_AUDIT_ACTION_EXPORT, nil, count, "backup to "+filename)
+		return stream.Send(mitmflowv1.CreateBackupResponse_builder{
+			Path:      proto.String(filename),
+			FlowCount: proto.Int64(int64(count)),
+		}.Build())
+	}
+
+	w := bufio.NewWriterSize(&backupChunkWriter{stream: stream}, backupChunkSize)
+	count, err := s.storage.WriteBackup(ctx, w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		log.Printf("Backup failed: %v", err)
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	s.audit(ctx, req.Peer(), mitmflowv1.AuditAction_AUDIT_ACTION_EXPORT, nil, count, "backup")
+	return stream.Send(mitmflowv1.CreateBackupResponse_builder{
+		FlowCount: proto.Int64(int64(count)),
+	}.Build())
+}
+
+func (s *MITMFlowServer) RestoreBackup(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.RestoreBackupRequest],
+) (*connect.Response[mitmflowv1.RestoreBackupResponse], error) {
+	var r io.Reader
+	switch req.Msg.WhichSource() {
+	case mitmflowv1.RestoreBackupRequest_Data_case:
+		r = bytes.NewReader(req.Msg.GetData())
+	case mitmflowv1.RestoreBackupRequest_Path_case:
+		filename, err := s.backupPath(req.Msg.GetPath())
+		if err != nil {
+			return nil, err
+		}
+		f, err := os.Open(filename)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("backup not found: %s", req.Msg.GetPath()))
+			}
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		defer f.Close() //nolint:errcheck
+		r = f
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("backup data or path is required"))
+	}
+
+	// The whole backup is read before anything is stored, so a corrupt
+	// backup doesn't leave the flows wiped or half restored.
+	var flows []*mitmflowv1.Flow
+	err := ReadBackup(r, func(flow *mitmflowv1.Flow) error {
+		flows = append(flows, flow)
+		return nil
+	})
+	if err != nil {
+		log.Printf("Restore failed after reading %d flows: %v", len(flows), err)
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if req.Msg.GetReplace() {
+		var ids []string
+		s.storage.Walk(func(flow *mitmflowv1.Flow) bool {
+			ids = append(ids, GetFlowID(flow))
+			return true
+		})
+		deleted, err := s.storage.DeleteFlows(ids)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		s.audit(ctx, req.Peer(), mitmflowv1.AuditAction_AUDIT_ACTION_DELETE_ALL, nil, len(deleted), "restore backup")
+	}
+
+	var count int64
+	for _, flow := range flows {
+		if err := s.storage.SaveFlow(flow); err != nil {
+			log.Printf("Restore failed after %d flows: %v", count, err)
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		s.broadcast(flow)
+		count++
+	}
+	s.audit(ctx, req.Peer(), mitmflowv1.AuditAction_AUDIT_ACTION_RESTORE, flowIDs(flows), len(flows), "backup")
+	log.Printf("Restored %d flows from backup", count)
+
+	return connect.NewResponse(mitmflowv1.RestoreBackupResponse_builder{
+		Count: proto.Int64(count),
+	}.Build()), nil
+}
+
+// backupPath resolves a client supplied backup name inside the backup
+// directory, refusing anything that would escape it.
+func (s *MITMFlowServer) backupPath(name string) (string, error) {
+	if s.backupDir == "" {
+		return "", connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("no backup directory configured"))
+	}
+	if !filepath.IsLocal(name) {
+		return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid backup path: %s", name))
+	}
+	if err := os.MkdirAll(s.backupDir, 0755); err != nil {
+		return "", connect.NewError(connect.CodeInternal, err)
+	}
+	return filepath.Join(s.backupDir, name), nil
+}
+
+const backupChunkSize = 64 * 1024
+
+// backupChunkWriter sends everything written to it as CreateBackupResponse
+// chunks.
+type backupChunkWriter struct {
+	stream *connect.ServerStream[mitmflowv1.CreateBackupResponse]
+}
+
+func (w *backupChunkWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(mitmflowv1.CreateBackupResponse_builder{
+		Chunk: bytes.Clone(p),
+	}.Build()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *MITMFlowServer) GetServerInfo(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.GetServerInfoRequest],
+) (*connect.Response[mitmflowv1.GetServerInfoResponse], error) {
+	counts := s.storage.CountByType()
+	var total int64
+	for _, n := range counts {
+		total += n
+	}
+
+	s.mu.RLock()
+	subscribers := len(s.subscribers)
+	s.mu.RUnlock()
+
+	builder := mitmflowv1.GetServerInfoResponse_builder{
+		Version:                   proto.String(version),
+		GoVersion:                 proto.String(runtime.Version()),
+		StartTime:                 timestamppb.New(s.startTime),
+		UptimeMs:                  proto.Int64(time.Since(s.startTime).Milliseconds()),
+		MaxFlows:                  proto.Int32(int32(s.storage.MaxFlows())),
+		MaxBodyBytes:              proto.Int64(int64(s.maxBodyBytes)),
+		MaxIngestMessageBytes:     proto.Int64(int64(s.ingestMaxBytes)),
+		StreamKeepaliveIntervalMs: proto.Int64(s.keepalive.Milliseconds()),
+		BlobThreshold:             proto.Int64(int64(s.storage.BlobThreshold())),
+		DataDir:                   proto.String(s.storage.Dir()),
+		ArchiveDir:                proto.String(s.storage.ArchiveDir()),
+		BackupDir:                 proto.String(s.backupDir),
+		FlowCount:                 proto.Int64(total),
+		FlowCounts:                counts,
+		DescriptorFileCount:       proto.Int32(int32(s.registry.NumFiles())),
+		SubscriberCount:           proto.Int32(int32(subscribers)),
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				builder.VcsRevision = proto.String(setting.Value)
+			case "vcs.time":
+				if t, err := time.Parse(time.RFC3339, setting.Value); err == nil {
+					builder.VcsTime = timestamppb.New(t)
+				}
+			}
+		}
+	}
+	return connect.NewResponse(builder.Build()), nil
+}
+
 func (s *MITMFlowServer) GetFlow(
 	ctx context.Context,
 	req *connect.Request[mitmflowv1.GetFlowRequest],
 ) (*connect.Response[mitmflowv1.GetFlowResponse], error) {
 	id := req.Msg.GetFlowId()
-	flow, ok := s.storage.GetFlow(id)
+	flow, ok := s.visibleFlow(ctx, id)
 	if !ok {
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("flow not found: %s", id))
 	}
+	flow, err := s.storage.HydrateFlow(ctx, flow)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	flow = pageFrames(viewAs(flow, annotationUser(ctx)))
 	return connect.NewResponse(mitmflowv1.GetFlowResponse_builder{Flow: flow}.Build()), nil
 }
 
+func (s *MITMFlowServer) GetFlowBody(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.GetFlowBodyRequest],
+) (*connect.Response[mitmflowv1.GetFlowBodyResponse], error) {
+	id := req.Msg.GetFlowId()
+	flow, ok := s.visibleFlow(ctx, id)
+	if !ok || flow.GetHttpFlow() == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("http flow not found: %s", id))
+	}
+
+	content, err := s.storage.GetBody(ctx, flow, req.Msg.GetResponse())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	details := flow.GetHttpFlowExtra().GetRequest()
+	if req.Msg.GetResponse() {
+		details = flow.GetHttpFlowExtra().GetResponse()
+	}
+	return connect.NewResponse(mitmflowv1.GetFlowBodyResponse_builder{
+		Content:     content,
+		ContentType: proto.String(details.GetEffectiveContentType()),
+	}.Build()), nil
+}
+
 func (s *MITMFlowServer) GetFlows(
 	ctx context.Context,
 	req *connect.Request[mitmflowv1.GetFlowsRequest],
@@ -147,18 +739,22 @@ func (s *MITMFlowServer) GetFlows(
 
 	count := 0
 	filter := req.Msg.GetFilter()
+	seq := s.storage.Sequence()
 
 	sendFlow := func(flow *mitmflowv1.Flow) error {
-		summary := convertToSummary(flow)
 		builder := mitmflowv1.GetFlowsResponse_builder{
-			Flow: summary,
+			Flow:     s.summarize(flow),
+			Sequence: proto.Uint64(seq),
 		}
 		return stream.Send(builder.Build())
 	}
 
+	user := annotationUser(ctx)
+	visible := visibleTo(ctx)
 	var iterErr error
 	s.storage.ReverseWalk(func(flow *mitmflowv1.Flow) bool {
-		if matchFlow(flow, filter) {
+		flow = viewAs(flow, user)
+		if visible(flow) && matchFlow(flow, filter) {
 			if err := sendFlow(flow); err != nil {
 				iterErr = err
 				return false
@@ -178,34 +774,114 @@ func (s *MITMFlowServer) GetFlows(
 	return nil
 }
 
+func (s *MITMFlowServer) SearchArchive(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.SearchArchiveRequest],
+	stream *connect.ServerStream[mitmflowv1.SearchArchiveResponse],
+) error {
+	limit := int(req.Msg.GetLimit())
+	if limit <= 0 {
+		limit = 500
+	}
+
+	count := 0
+	filter := req.Msg.GetFilter()
+	user := annotationUser(ctx)
+	visible := visibleTo(ctx)
+	var sendErr error
+	err := s.storage.WalkArchive(ctx, func(flow *mitmflowv1.Flow) bool {
+		flow = viewAs(flow, user)
+		if !visible(flow) || !matchFlow(flow, filter) {
+			return true
+		}
+		if sendErr = stream.Send(mitmflowv1.SearchArchiveResponse_builder{
+			Flow: convertToSummary(flow),
+		}.Build()); sendErr != nil {
+			return false
+		}
+		count++
+		return count < limit
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		log.Printf("failed to search archive: %v", err)
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	return nil
+}
+
+func (s *MITMFlowServer) RestoreArchivedFlows(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.RestoreArchivedFlowsRequest],
+) (*connect.Response[mitmflowv1.RestoreArchivedFlowsResponse], error) {
+	flows, err := s.storage.RestoreArchived(req.Msg.GetFlowIds(), req.Msg.GetPin())
+	summaries := make([]*mitmflowv1.FlowSummary, 0, len(flows))
+	for _, flow := range flows {
+		s.broadcast(flow)
+		summaries = append(summaries, convertToSummary(flow))
+	}
+	if len(flows) > 0 {
+		s.audit(ctx, req.Peer(), mitmflowv1.AuditAction_AUDIT_ACTION_RESTORE, flowIDs(flows), len(flows), "archive")
+	}
+	if err != nil {
+		log.Printf("failed to restore archived flows: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(mitmflowv1.RestoreArchivedFlowsResponse_builder{
+		Flows: summaries,
+	}.Build()), nil
+}
+
 func (s *MITMFlowServer) StreamFlows(
 	ctx context.Context,
 	req *connect.Request[mitmflowv1.StreamFlowsRequest],
 	stream *connect.ServerStream[mitmflowv1.StreamFlowsResponse],
 ) error {
-	// Increased buffer size to prevent blocking/dropping during heavy load or history iteration
-	ch := make(chan *mitmflowv1.Flow, 500)
-	id := uuid.New().String()
-	s.mu.RLock()
-	s.subscribers[id] = ch
-	s.mu.RUnlock()
+	return s.streamFlows(ctx, req.Peer().Addr, req.Msg, stream.Send)
+}
 
-	defer func() {
-		s.mu.Lock()
-		delete(s.subscribers, id)
-		s.mu.Unlock()
-		close(ch)
-	}()
+// streamFlows backfills flows newer than the requested timestamp and then
+// sends summaries of new and updated flows until ctx is done. It is shared
+// by StreamFlows and the WebSocket endpoint.
+func (s *MITMFlowServer) streamFlows(
+	ctx context.Context,
+	peer string,
+	req *mitmflowv1.StreamFlowsRequest,
+	send func(*mitmflowv1.StreamFlowsResponse) error,
+) error {
+	sinceNs := req.GetSinceTimestampNs()
+	filter := req.GetFilter()
+	user := annotationUser(ctx)
+	visible := visibleTo(ctx)
 
-	sinceNs := req.Msg.GetSinceTimestampNs()
-	filter := req.Msg.GetFilter()
+	sub := s.subscribe(peer, filter)
+	defer s.unsubscribe(sub)
+	ch := sub.ch
 
 	sendFlow := func(flow *mitmflowv1.Flow) error {
-		summary := convertToSummary(flow)
 		builder := mitmflowv1.StreamFlowsResponse_builder{
-			Flow: summary,
+			Flow: s.summarize(flow),
+		}
+		if err := send(builder.Build()); err != nil {
+			return err
+		}
+		sub.delivered.Add(1)
+		return nil
+	}
+	sendKeepalive := func() error {
+		return send(mitmflowv1.StreamFlowsResponse_builder{
+			Keepalive: mitmflowv1.Keepalive_builder{
+				IntervalMs: proto.Int64(s.keepalive.Milliseconds()),
+			}.Build(),
+		}.Build())
+	}
+	// The first keepalive tells the client how often to expect them.
+	if s.keepalive > 0 {
+		if err := sendKeepalive(); err != nil {
+			return err
 		}
-		return stream.Send(builder.Build())
 	}
 
 	// Helper to drain the channel of any new flows that arrived while we were processing history
@@ -213,7 +889,8 @@ func (s *MITMFlowServer) StreamFlows(
 		for {
 			select {
 			case flow := <-ch:
-				if !matchFlow(flow, filter) {
+				flow = viewAs(flow, user)
+				if !visible(flow) || !matchFlow(flow, filter) {
 					continue
 				}
 				if err := sendFlow(flow); err != nil {
@@ -225,9 +902,29 @@ func (s *MITMFlowServer) StreamFlows(
 		}
 	}
 
-	// Only backfill if sinceNs is provided (Resume scenario)
-	// If sinceNs is 0, we assume "start from now" (Live scenario)
-	if sinceNs > 0 {
+	// Resuming from a change sequence also picks up flows that were updated
+	// since, not just new ones.
+	if seq := req.GetSinceSequence(); seq > 0 {
+		for i, flow := range s.storage.ChangedSince(seq) {
+			if i%10 == 0 {
+				if ctx.Err() != nil {
+					return nil
+				}
+				if err := drainChannel(); err != nil {
+					return err
+				}
+			}
+			flow = viewAs(flow, user)
+			if !visible(flow) || !matchFlow(flow, filter) {
+				continue
+			}
+			if err := sendFlow(flow); err != nil {
+				return err
+			}
+		}
+	} else if sinceNs > 0 {
+		// Only backfill if sinceNs is provided (Resume scenario)
+		// If sinceNs is 0, we assume "start from now" (Live scenario)
 		var iterErr error
 		iterCount := 0
 		s.storage.ReverseWalk(func(flow *mitmflowv1.Flow) bool {
@@ -246,7 +943,8 @@ func (s *MITMFlowServer) StreamFlows(
 			if GetFlowStartTime(flow) <= sinceNs {
 				return false
 			}
-			if !matchFlow(flow, filter) {
+			flow = viewAs(flow, user)
+			if !visible(flow) || !matchFlow(flow, filter) {
 				return true
 			}
 			if err := sendFlow(flow); err != nil {
@@ -269,23 +967,35 @@ func (s *MITMFlowServer) StreamFlows(
 		return err
 	}
 
-	// Live streaming loop
-	ticker := time.NewTicker(100 * time.Millisecond)
-	defer ticker.Stop()
+	// Live streaming loop. Keepalives are only sent while no flows are.
+	var keepalive <-chan time.Time
+	var timer *time.Timer
+	if s.keepalive > 0 {
+		timer = time.NewTimer(s.keepalive)
+		defer timer.Stop()
+		keepalive = timer.C
+	}
 
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
 		case flow := <-ch:
-			if !matchFlow(flow, filter) {
+			flow = viewAs(flow, user)
+			if !visible(flow) || !matchFlow(flow, filter) {
 				continue
 			}
 			if err := sendFlow(flow); err != nil {
 				return err
 			}
-		case <-ticker.C:
-			// Just to ensure we check ctx.Done regularly if channel is empty, though select handles it.
+			if timer != nil {
+				timer.Reset(s.keepalive)
+			}
+		case <-keepalive:
+			if err := sendKeepalive(); err != nil {
+				return err
+			}
+			timer.Reset(s.keepalive)
 		}
 	}
 }
@@ -294,7 +1004,7 @@ func (s *MITMFlowServer) UpdateFlow(
 	ctx context.Context,
 	req *connect.Request[mitmflowv1.UpdateFlowRequest],
 ) (*connect.Response[mitmflowv1.UpdateFlowResponse], error) {
-	log.Printf("UpdateFlow: ID=%s Pinned=%v Note=%v", req.Msg.GetFlowId(), req.Msg.GetPinned(), req.Msg.GetNote())
+	log.Printf("UpdateFlow: ID=%s Pinned=%v Note=%v Metadata=%v", req.Msg.GetFlowId(), req.Msg.GetPinned(), req.Msg.GetNote(), req.Msg.GetMetadata())
 	var pinned *bool
 	if req.Msg.HasPinned() {
 		p := req.Msg.GetPinned()
@@ -306,22 +1016,47 @@ func (s *MITMFlowServer) UpdateFlow(
 		note = &n
 	}
 
-	flow, err := s.storage.UpdateFlow(req.Msg.GetFlowId(), pinned, note)
+	flow, ok := s.visibleFlow(ctx, req.Msg.GetFlowId())
+	if !ok {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("flow not found: %s", req.Msg.GetFlowId()))
+	}
+	if req.Msg.HasPrivate() {
+		if !canChangePrivacy(ctx, flow) {
+			return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("only the owner of flow %s or an admin can change whether it's private", req.Msg.GetFlowId()))
+		}
+		var err error
+		if flow, err = s.storage.SetFlowPrivate(req.Msg.GetFlowId(), req.Msg.GetPrivate()); err != nil {
+			log.Printf("UpdateFlow error: %v", err)
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+	}
+
+	user := annotationUser(ctx)
+	if req.Msg.GetShared() {
+		user = ""
+	}
+	flow, err := s.storage.UpdateFlow(req.Msg.GetFlowId(), user, pinned, note, req.Msg.GetMetadata())
 	if err != nil {
 		log.Printf("UpdateFlow error: %v", err)
 		return nil, connect.NewError(connect.CodeNotFound, err)
 	}
 
-	s.mu.RLock()
-	for _, ch := range s.subscribers {
-		select {
-		case ch <- flow:
-		default:
-		}
+	s.broadcast(flow)
+
+	ids := []string{req.Msg.GetFlowId()}
+	if pinned != nil && *pinned {
+		s.audit(ctx, req.Peer(), mitmflowv1.AuditAction_AUDIT_ACTION_PIN, ids, 1, "")
+	} else if pinned != nil {
+		s.audit(ctx, req.Peer(), mitmflowv1.AuditAction_AUDIT_ACTION_UNPIN, ids, 1, "")
+	}
+	if note != nil {
+		s.audit(ctx, req.Peer(), mitmflowv1.AuditAction_AUDIT_ACTION_NOTE, ids, 1, *note)
+	}
+	if len(req.Msg.GetMetadata()) > 0 {
+		s.audit(ctx, req.Peer(), mitmflowv1.AuditAction_AUDIT_ACTION_UPDATE_METADATA, ids, 1, formatMetadata(req.Msg.GetMetadata()))
 	}
-	s.mu.RUnlock()
 
-	summary := convertToSummary(flow)
+	summary := convertToSummary(viewAs(flow, annotationUser(ctx)))
 	return connect.NewResponse(mitmflowv1.UpdateFlowResponse_builder{Flow: summary}.Build()), nil
 }
 
@@ -342,6 +1077,10 @@ func convertToSummary(flow *mitmflowv1.Flow) *mitmflowv1.FlowSummary {
 		TimestampStart: ts,
 		Pinned:         proto.Bool(flow.GetPinned()),
 		Note:           proto.String(flow.GetNote()),
+		Owner:          proto.String(flow.GetOwner()),
+		Private:        proto.Bool(flow.GetPrivate()),
+		Protocol:       proto.String(flowProtocol(flow)),
+		Totals:         flowTotals(flow),
 	}
 
 	switch flow.WhichFlow() {
@@ -349,26 +1088,25 @@ func convertToSummary(flow *mitmflowv1.Flow) *mitmflowv1.FlowSummary {
 		f := flow.GetHttpFlow()
 		builder.Type = proto.String("http")
 
-		reqLen := int64(0)
-		if f.GetRequest() != nil {
-			reqLen = int64(len(f.GetRequest().GetContent()))
-		}
-		resLen := int64(0)
-		if f.GetResponse() != nil {
-			resLen = int64(len(f.GetResponse().GetContent()))
+		reqLen := getBodySize(f.GetRequest().GetContent(), flow.GetHttpFlowExtra().GetRequest())
+		resLen := getBodySize(f.GetResponse().GetContent(), flow.GetHttpFlowExtra().GetResponse())
+		durationMs := int64(f.GetDurationMs())
+		if totals := flowTotals(flow); totals != nil {
+			durationMs = totals.GetDurationMs()
 		}
 
 		builder.Http = mitmflowv1.HttpFlowSummary_builder{
 			Method:                proto.String(f.GetRequest().GetMethod()),
 			Url:                   proto.String(getPrettyURL(f.GetRequest())),
 			StatusCode:            proto.Int32(f.GetResponse().GetStatusCode()),
-			DurationMs:            proto.Int64(int64(f.GetDurationMs())),
+			DurationMs:            proto.Int64(durationMs),
 			RequestContentLength:  proto.Int64(reqLen),
 			ResponseContentLength: proto.Int64(resLen),
 			ClientPeernameHost:    proto.String(f.GetClient().GetPeernameHost()),
 			ClientPeernamePort:    proto.Uint32(f.GetClient().GetPeernamePort()),
 			ServerAddressHost:     proto.String(f.GetServer().GetAddressHost()),
 			ServerAddressPort:     proto.Uint32(f.GetServer().GetAddressPort()),
+			ResponseSha256:        proto.String(flow.GetHttpFlowExtra().GetResponse().GetSha256()),
 		}.Build()
 	case mitmflowv1.Flow_DnsFlow_case:
 		f := flow.GetDnsFlow()
@@ -405,33 +1143,129 @@ func convertToSummary(flow *mitmflowv1.Flow) *mitmflowv1.FlowSummary {
 	return builder.Build()
 }
 
+// getBodySize returns the original size of a body, taking into account bodies
+// that were truncated or moved out of the flow into the blob store.
+func getBodySize(content []byte, details *mitmflowv1.MessageDetails) int64 {
+	if details.HasBodySize() {
+		return details.GetBodySize()
+	}
+	return int64(len(content))
+}
+
 func (s *MITMFlowServer) DeleteFlows(
 	ctx context.Context,
 	req *connect.Request[mitmflowv1.DeleteFlowsRequest],
 ) (*connect.Response[mitmflowv1.DeleteFlowsResponse], error) {
-	var count int64
+	var ids []string
 	var err error
 
-	if req.Msg.GetAll() {
-		count, err = s.storage.DeleteAllFlows()
-	} else {
-		count, err = s.storage.DeleteFlows(req.Msg.GetFlowIds())
+	switch {
+	case req.Msg.GetAll() && seesEverything(ctx):
+		ids, err = s.storage.DeleteAllFlows()
+	case req.Msg.GetAll():
+		// Only delete the flows the client can see, unpinned like
+		// DeleteAllFlows.
+		visible := visibleTo(ctx)
+		var deletable []string
+		s.storage.Walk(func(flow *mitmflowv1.Flow) bool {
+			if visible(flow) && !pinnedByAnyone(flow) {
+				deletable = append(deletable, GetFlowID(flow))
+			}
+			return true
+		})
+		ids, err = s.storage.DeleteFlows(deletable)
+	default:
+		var deletable []string
+		for _, id := range req.Msg.GetFlowIds() {
+			if _, ok := s.visibleFlow(ctx, id); ok {
+				deletable = append(deletable, id)
+			}
+		}
+		ids, err = s.storage.DeleteFlows(deletable)
 	}
 
 	if err != nil {
 		log.Printf("DeleteFlows error: %v", err)
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+	if req.Msg.GetAll() {
+		s.audit(ctx, req.Peer(), mitmflowv1.AuditAction_AUDIT_ACTION_DELETE_ALL, nil, len(ids), "")
+	} else {
+		s.audit(ctx, req.Peer(), mitmflowv1.AuditAction_AUDIT_ACTION_DELETE, ids, len(ids), "")
+	}
 
-	return connect.NewResponse(mitmflowv1.DeleteFlowsResponse_builder{Count: proto.Int64(count)}.Build()), nil
+	return connect.NewResponse(mitmflowv1.DeleteFlowsResponse_builder{
+		Count:      proto.Int64(int64(len(ids))),
+		FlowIds:    ids,
+		Restorable: proto.Bool(s.storage.TrashEnabled()),
+	}.Build()), nil
+}
+
+// RestoreFlows undoes deleting flows that are still in the trash.
+func (s *MITMFlowServer) RestoreFlows(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.RestoreFlowsRequest],
+) (*connect.Response[mitmflowv1.RestoreFlowsResponse], error) {
+	if !s.storage.TrashEnabled() {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("deleted flows are not kept; start the server with -trash-retention"))
+	}
+	flows, err := s.storage.RestoreTrashed(req.Msg.GetFlowIds())
+	summaries := make([]*mitmflowv1.FlowSummary, 0, len(flows))
+	for _, flow := range flows {
+		s.broadcast(flow)
+		summaries = append(summaries, convertToSummary(flow))
+	}
+	if len(flows) > 0 {
+		s.audit(ctx, req.Peer(), mitmflowv1.AuditAction_AUDIT_ACTION_RESTORE, flowIDs(flows), len(flows), "trash")
+	}
+	if err != nil {
+		log.Printf("failed to restore deleted flows: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(mitmflowv1.RestoreFlowsResponse_builder{
+		Flows: summaries,
+	}.Build()), nil
 }
 
 func (s *MITMFlowServer) preprocessFlow(flow *mitmflowv1.Flow) {
+	var stream *mitmflowv1.StreamFlowExtra
+	switch {
+	case flow.GetDnsFlow() != nil:
+		s.hostnames.recordDNSFlow(flow)
+		flow.SetDnsFlowExtra(mitmflowv1.DnsFlowExtra_builder{
+			Anomalies: s.dnsAnomalies.check(flow),
+			Protocol:  proto.String("dns"),
+			Totals:    dnsTotals(flow.GetDnsFlow()),
+		}.Build())
+		return
+	case flow.GetTcpFlow() != nil:
+		f := flow.GetTcpFlow()
+		stream = tcpMessageDetails(f, s.redactDBValues)
+		stream.SetTotals(streamTotals(f.GetMessages(), f.GetDurationMs(), f.GetTimestampStart()))
+	case flow.GetUdpFlow() != nil:
+		f := flow.GetUdpFlow()
+		stream = streamMessageDetails(f.GetMessages())
+		stream.SetProtocol("udp")
+		stream.SetTotals(streamTotals(f.GetMessages(), f.GetDurationMs(), f.GetTimestampStart()))
+	}
+	if stream != nil {
+		for _, details := range stream.GetMessages() {
+			s.decodeLimits.capFrames(details)
+		}
+		stream.SetServerGeo(s.geoIP.Lookup(flowServerIP(flow)))
+		s.setServerHostname(flow, stream)
+		flow.SetStreamFlowExtra(stream)
+		return
+	}
 	httpFlow := flow.GetHttpFlow()
 	if httpFlow == nil {
 		return
 	}
 	extra := &mitmflowv1.HTTPFlowExtra{}
+	extra.SetHttp2(http2Details(httpFlow, flow.GetHttpFlowExtra().GetHttp2()))
+	extra.SetInterimResponses(flow.GetHttpFlowExtra().GetInterimResponses())
+	extra.SetServerGeo(s.geoIP.Lookup(flowServerIP(flow)))
+	s.setServerHostname(flow, extra)
 
 	var reqDesc, respDesc protoreflect.MessageDescriptor
 	if s.registry != nil && httpFlow.HasRequest() {
@@ -441,32 +1275,159 @@ func (s *MITMFlowServer) preprocessFlow(flow *mitmflowv1.Flow) {
 	}
 
 	if httpFlow.HasRequest() {
+		req := httpFlow.GetRequest()
 		details := &mitmflowv1.MessageDetails{}
-		s.preprocessRequest(httpFlow.GetRequest(), details, reqDesc)
+		details.SetBodySize(int64(len(req.GetContent())))
+		details.SetSha256(bodySHA256(req.GetContent()))
+		if content, truncated := s.truncateBody(req.GetContent()); truncated {
+			req.SetContent(content)
+			req.SetContentTruncated(true)
+			details.SetTruncated(true)
+		}
+		s.preprocessRequest(req, details, reqDesc)
 		extra.SetRequest(details)
+		extra.SetUserAgent(parseUserAgent(getHeaderValue(req.GetHeaders(), "User-Agent")))
 	}
 	if httpFlow.HasResponse() {
+		resp := httpFlow.GetResponse()
 		details := &mitmflowv1.MessageDetails{}
-		s.preprocessResponse(httpFlow.GetResponse(), details, respDesc)
+		details.SetBodySize(int64(len(resp.GetContent())))
+		details.SetSha256(bodySHA256(resp.GetContent()))
+		if content, truncated := s.truncateBody(resp.GetContent()); truncated {
+			resp.SetContent(content)
+			resp.SetContentTruncated(true)
+			details.SetTruncated(true)
+		}
+		s.preprocessResponse(resp, details, respDesc)
+		content := resp.GetContent()
+		if decoded, ok := decodeContentEncoding(content, getHeaderValue(resp.GetHeaders(), "Content-Encoding")); ok {
+			content = decoded
+		}
+		if !s.decodeLimits.skipsDecoding(details) {
+			details.SetMedia(mediaInfo(content, details.GetEffectiveContentType(), s.stripEXIF))
+		}
 		extra.SetResponse(details)
+		extra.SetSecurityFindings(auditSecurityHeaders(httpFlow, details.GetEffectiveContentType()))
+		extra.SetBaseline(s.baseline.compare(flow))
+	}
+	if httpFlow.GetIsWebsocket() && (isMQTTWebSocket(httpFlow.GetResponse().GetHeaders()) || isMQTTWebSocket(httpFlow.GetRequest().GetHeaders())) {
+		extra.SetWebsocketMessages(decodeStreamMessages(httpFlow.GetWebsocketMessages(), "application/mqtt", &mqttDecoder{}))
 	}
+	extra.SetProtocol(httpProtocol(httpFlow, extra))
+	extra.SetTotals(httpTotals(httpFlow, extra))
+	extra.SetCors(s.cors.check(flow))
+	extra.SetManifestFlowId(s.manifests.link(flow))
+	s.stampFrames(flow, extra, time.Now())
 	flow.SetHttpFlowExtra(extra)
 }
 
-func (s *MITMFlowServer) preprocessRequest(req *mitmproxygrpcv1.Request, details *mitmflowv1.MessageDetails, msgDesc protoreflect.MessageDescriptor) {
-	contentType, ok := getContentType(req.GetHeaders())
-	if ok {
-		details.SetEffectiveContentType(contentType)
-	}
-	if ct := mimetype.Detect(req.GetContent()); ct != nil {
-		detectedContentType := ct.String()
-		switch detectedContentType {
-		case "text/plain", "application/octet-stream":
-			// do not override common generic content types
-		default:
-			details.SetEffectiveContentType(detectedContentType)
+// hostnameExtra is the part of a flow's extra that names its server.
+type hostnameExtra interface {
+	GetServerHostname() string
+	SetServerHostname(string)
+	SetServerHostnameSource(mitmflowv1.HostnameSource)
+}
+
+func flowHostnameExtra(flow *mitmflowv1.Flow) hostnameExtra {
+	switch {
+	case flow.GetHttpFlowExtra() != nil:
+		return flow.GetHttpFlowExtra()
+	case flow.GetStreamFlowExtra() != nil:
+		return flow.GetStreamFlowExtra()
+	}
+	return nil
+}
+
+// setServerHostname names the server of a flow that only has an IP. When a
+// reverse lookup has to be made first, the stored flow is updated once it
+// finishes.
+func (s *MITMFlowServer) setServerHostname(flow *mitmflowv1.Flow, extra hostnameExtra) {
+	ip := serverByIP(flow)
+	if ip == "" {
+		return
+	}
+	id := GetFlowID(flow)
+	name, source := s.hostnames.hostname(ip, flowStartTime(flow, time.Now()), func(name string) {
+		s.ingestMu.Lock()
+		stored, ok := s.storage.GetFlow(id)
+		if !ok || flowHostnameExtra(stored) == nil || flowHostnameExtra(stored).GetServerHostname() != "" {
+			s.ingestMu.Unlock()
+			return
+		}
+		updated := proto.CloneOf(stored)
+		flowHostnameExtra(updated).SetServerHostname(name)
+		flowHostnameExtra(updated).SetServerHostnameSource(mitmflowv1.HostnameSource_HOSTNAME_SOURCE_REVERSE_DNS)
+		err := s.storage.SaveFlow(updated)
+		s.ingestMu.Unlock()
+		if err != nil {
+			log.Printf("failed to save hostname for flow %s: %v", id, err)
+			return
+		}
+		s.broadcast(updated)
+	})
+	if name != "" {
+		extra.SetServerHostname(name)
+		extra.SetServerHostnameSource(source)
+	}
+}
+
+// stampFrames records when each textual frame of a streaming body arrived.
+// mitmproxy sends a live flow again whenever more of it has been received, so
+// frames that weren't in the stored version of the flow arrived just now.
+func (s *MITMFlowServer) stampFrames(flow *mitmflowv1.Flow, extra *mitmflowv1.HTTPFlowExtra, now time.Time) {
+	if s.storage == nil {
+		return
+	}
+	prev, _ := s.storage.GetFlow(GetFlowID(flow))
+	live := flow.GetHttpFlow().GetLive()
+	stampMessageFrames(extra.GetRequest(), prev.GetHttpFlowExtra().GetRequest(), live, now.UnixNano())
+	stampMessageFrames(extra.GetResponse(), prev.GetHttpFlowExtra().GetResponse(), live, now.UnixNano())
+}
+
+func stampMessageFrames(details, prev *mitmflowv1.MessageDetails, live bool, nowNs int64) {
+	frames := details.GetTextualFrames()
+	prevTimestamps := prev.GetFrameTimestampsNs()
+	if len(frames) == 0 || (!live && len(prevTimestamps) == 0) {
+		return
+	}
+	seen := len(prev.GetTextualFrames())
+	timestamps := make([]int64, len(frames))
+	for i := range timestamps {
+		switch {
+		case i < len(prevTimestamps):
+			timestamps[i] = prevTimestamps[i]
+		case i >= seen:
+			timestamps[i] = nowNs
 		}
 	}
+	details.SetFrameTimestampsNs(timestamps)
+}
+
+// truncateBody cuts content down to the configured maximum body size. The
+// returned slice is a copy so the oversized backing array can be freed.
+func (s *MITMFlowServer) truncateBody(content []byte) ([]byte, bool) {
+	if s.maxBodyBytes <= 0 || len(content) <= s.maxBodyBytes {
+		return content, false
+	}
+	return bytes.Clone(content[:s.maxBodyBytes]), true
+}
+
+// bodySHA256 returns the hex encoded SHA-256 of a body, or "" when there is
+// none.
+func bodySHA256(content []byte) string {
+	if len(content) == 0 {
+		return ""
+	}
+	return blobKey(content)
+}
+
+func (s *MITMFlowServer) preprocessRequest(req *mitmproxygrpcv1.Request, details *mitmflowv1.MessageDetails, msgDesc protoreflect.MessageDescriptor) {
+	contentType, _ := getContentType(req.GetHeaders())
+	s.contentTypes.resolve(req.GetHeaders(), req.GetContent(), details)
+	details.SetBinaryMetadata(binaryMetadata(req.GetHeaders(), nil))
+	if s.decodeLimits.skipsDecoding(details) {
+		return
+	}
 
 	var dnsQuery string
 	if u, err := url.Parse(req.GetUrl()); err == nil {
@@ -476,6 +1437,8 @@ func (s *MITMFlowServer) preprocessRequest(req *mitmproxygrpcv1.Request, details
 	}
 
 	switch {
+	case isProtobufContentType(contentType) && s.decodeLimits.protobufTooLarge(len(req.GetContent())):
+		details.SetTextualFrames(s.decodeLimits.protobufTooLargeFrame(len(req.GetContent())))
 	case strings.Contains(contentType, "application/proto"),
 		strings.Contains(contentType, "application/protobuf"),
 		strings.Contains(contentType, "application/x-protobuf"):
@@ -519,6 +1482,17 @@ func (s *MITMFlowServer) preprocessRequest(req *mitmproxygrpcv1.Request, details
 			log.Printf("failed to parse grpc frames: %v", err)
 		}
 	}
+	setFormFields(req.GetContent(), req.GetHeaders(), details)
+	setJSONStreamFrames(req.GetContent(), req.GetHeaders(), details)
+	setJSONFrames(req.GetContent(), req.GetHeaders(), details)
+	setXMLFrames(req.GetContent(), req.GetHeaders(), details)
+	if !s.decodeLimits.protobufTooLarge(len(req.GetContent())) {
+		setSniffedProtobufFrames(req.GetContent(), req.GetHeaders(), details, msgDesc)
+	}
+	s.plugins.setFrames(req.GetContent(), details)
+	s.decoderHooks.setFrames(req.GetContent(), details)
+	setHexdumpFrames(req.GetContent(), details)
+	s.decodeLimits.capFrames(details)
 }
 
 func getContentType(headers map[string]string) (string, bool) {
@@ -531,21 +1505,21 @@ func getContentType(headers map[string]string) (string, bool) {
 }
 
 func (s *MITMFlowServer) preprocessResponse(resp *mitmproxygrpcv1.Response, details *mitmflowv1.MessageDetails, msgDesc protoreflect.MessageDescriptor) {
-	contentType, ok := getContentType(resp.GetHeaders())
-	if ok {
-		details.SetEffectiveContentType(contentType)
-	}
-	if ct := mimetype.Detect(resp.GetContent()); ct != nil {
-		detectedContentType := ct.String()
-		switch detectedContentType {
-		case "text/plain", "application/octet-stream":
-			// do not override common generic content types
-		default:
-			details.SetEffectiveContentType(detectedContentType)
-		}
+	contentType, _ := getContentType(resp.GetHeaders())
+	s.contentTypes.resolve(resp.GetHeaders(), resp.GetContent(), details)
+	details.SetGrpcStatus(responseGrpcStatus(resp, contentType))
+	trailers := resp.GetTrailers()
+	if len(trailers) == 0 && strings.Contains(contentType, "application/grpc-web") {
+		trailers = grpcWebTrailers(resp.GetContent())
+	}
+	details.SetBinaryMetadata(binaryMetadata(resp.GetHeaders(), trailers))
+	if s.decodeLimits.skipsDecoding(details) {
+		return
 	}
 
 	switch {
+	case isProtobufContentType(contentType) && s.decodeLimits.protobufTooLarge(len(resp.GetContent())):
+		details.SetTextualFrames(s.decodeLimits.protobufTooLargeFrame(len(resp.GetContent())))
 	case strings.Contains(contentType, "application/proto"),
 		strings.Contains(contentType, "application/protobuf"),
 		strings.Contains(contentType, "application/x-protobuf"):
@@ -578,56 +1552,149 @@ func (s *MITMFlowServer) preprocessResponse(resp *mitmproxygrpcv1.Response, deta
 			log.Printf("failed to parse grpc frames: %v", err)
 		}
 	}
+	setManifestFrames(resp.GetContent(), resp.GetHeaders(), details)
+	setFormFields(resp.GetContent(), resp.GetHeaders(), details)
+	setJSONStreamFrames(resp.GetContent(), resp.GetHeaders(), details)
+	setJSONFrames(resp.GetContent(), resp.GetHeaders(), details)
+	setXMLFrames(resp.GetContent(), resp.GetHeaders(), details)
+	if !s.decodeLimits.protobufTooLarge(len(resp.GetContent())) {
+		setSniffedProtobufFrames(resp.GetContent(), resp.GetHeaders(), details, msgDesc)
+	}
+	s.plugins.setFrames(resp.GetContent(), details)
+	s.decoderHooks.setFrames(resp.GetContent(), details)
+	setHexdumpFrames(resp.GetContent(), details)
+	s.decodeLimits.capFrames(details)
 }
 
-func (s *MITMFlowServer) ExportFlows(
-	ctx context.Context,
-	req *connect.Request[mitmflowv1.ExportFlowsRequest],
-) (*connect.Response[mitmflowv1.ExportFlowsResponse], error) {
-	log.Printf("ExportFlows called with %d flow IDs, format: %v", len(req.Msg.GetFlowIds()), req.Msg.GetFormat())
+var errUnsupportedFormat = errors.New("unsupported format")
+
+// encodeFlows serializes flows in the given export format and returns the
+// data along with a suggested file name.
+func encodeFlows(flows []*mitmflowv1.Flow, format mitmflowv1.ExportFormat) ([]byte, string, error) {
+	switch format {
+	case mitmflowv1.ExportFormat_EXPORT_FORMAT_HAR:
+		data, err := GenerateHAR(flows)
+		return data, "flows.har", err
+	case mitmflowv1.ExportFormat_EXPORT_FORMAT_JSON:
+		data, err := json.MarshalIndent(flows, "", "  ")
+		return data, "flows.json", err
+	case mitmflowv1.ExportFormat_EXPORT_FORMAT_PROTO:
+		data, err := proto.Marshal(mitmflowv1.FlowSet_builder{Flows: flows}.Build())
+		return data, "flows.binpb", err
+	case mitmflowv1.ExportFormat_EXPORT_FORMAT_SAZ:
+		data, err := GenerateSAZ(flows)
+		return data, "flows.saz", err
+	case mitmflowv1.ExportFormat_EXPORT_FORMAT_CHARLES:
+		data, err := GenerateCharles(flows)
+		return data, "flows.chlsj", err
+	case mitmflowv1.ExportFormat_EXPORT_FORMAT_GRPC_FRAMES:
+		data, err := GenerateGRPCFrames(flows)
+		return data, "grpc-frames.zip", err
+	case mitmflowv1.ExportFormat_EXPORT_FORMAT_GRPCURL:
+		data, err := GenerateRPCCommands(flows, toolGrpcurl)
+		return data, "grpcurl.sh", err
+	case mitmflowv1.ExportFormat_EXPORT_FORMAT_BUF_CURL:
+		data, err := GenerateRPCCommands(flows, toolBufCurl)
+		return data, "buf-curl.sh", err
+	case mitmflowv1.ExportFormat_EXPORT_FORMAT_JSONL:
+		data, err := GenerateJSONL(flows)
+		return data, "flows.jsonl", err
+	case mitmflowv1.ExportFormat_EXPORT_FORMAT_CSV:
+		data, err := GenerateCSV(flows)
+		return data, "flows.csv", err
+	case mitmflowv1.ExportFormat_EXPORT_FORMAT_MARKDOWN:
+		data, err := GenerateMarkdown(flows)
+		return data, "flows.md", err
+	}
+	return nil, "", fmt.Errorf("%w: %v", errUnsupportedFormat, format)
+}
 
-	var filteredFlows []*mitmflowv1.Flow
+// exportSelection returns the hydrated flows an export request selects, in
+// start time order. Without IDs or a query nothing is selected.
+func (s *MITMFlowServer) exportSelection(ctx context.Context, req *mitmflowv1.ExportFlowsRequest) ([]*mitmflowv1.Flow, error) {
+	query := req.HasFilter() || req.GetFilterExpr() != "" || req.HasStartTime() || req.HasEndTime()
+	var ids map[string]bool
+	if len(req.GetFlowIds()) > 0 {
+		ids = make(map[string]bool, len(req.GetFlowIds()))
+		for _, id := range req.GetFlowIds() {
+			ids[id] = true
+		}
+	}
 
-	// If specific IDs are requested, filter by them
-	if len(req.Msg.GetFlowIds()) > 0 {
-		seen := make(map[string]bool)
-		for _, id := range req.Msg.GetFlowIds() {
-			if seen[id] {
-				continue
+	user := annotationUser(ctx)
+	visible := visibleTo(ctx)
+	var selected []*mitmflowv1.Flow
+	switch {
+	case query:
+		match, err := parseFilterExpr(req.GetFilterExpr())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		var start, end int64
+		if req.HasStartTime() {
+			start = req.GetStartTime().AsTime().UnixNano()
+		}
+		if req.HasEndTime() {
+			end = req.GetEndTime().AsTime().UnixNano()
+		}
+		s.storage.Walk(func(flow *mitmflowv1.Flow) bool {
+			at := GetFlowStartTime(flow)
+			if (start != 0 && at < start) || (end != 0 && at > end) {
+				return true
 			}
-			if f, ok := s.storage.GetFlow(id); ok {
-				filteredFlows = append(filteredFlows, f)
-				seen[id] = true
+			flow = viewAs(flow, user)
+			if (ids == nil || ids[GetFlowID(flow)]) && visible(flow) && matchFlow(flow, req.GetFilter()) && match(flow) {
+				selected = append(selected, flow)
+			}
+			return true
+		})
+	case ids != nil:
+		for id := range ids {
+			if f, ok := s.storage.GetFlow(id); ok && visible(f) {
+				selected = append(selected, viewAs(f, user))
 			}
 		}
-		sort.Slice(filteredFlows, func(i, j int) bool {
-			return GetFlowStartTime(filteredFlows[i]) < GetFlowStartTime(filteredFlows[j])
+		sort.Slice(selected, func(i, j int) bool {
+			return GetFlowStartTime(selected[i]) < GetFlowStartTime(selected[j])
 		})
 	}
-	// If no IDs provided, return empty list or maybe error?
-	// For now, let's assume empty list.
-	// Or should we support "Export All" flag?
-	// The prompt said "explicit list". So empty list = empty export.
 
-	var data []byte
-	var filename string
-	var err error
+	for i, flow := range selected {
+		var err error
+		if selected[i], err = s.storage.HydrateFlow(ctx, flow); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	return selected, nil
+}
 
-	switch req.Msg.GetFormat() {
-	case mitmflowv1.ExportFormat_EXPORT_FORMAT_HAR:
-		data, err = GenerateHAR(filteredFlows)
-		filename = "flows.har"
-	case mitmflowv1.ExportFormat_EXPORT_FORMAT_JSON:
-		data, err = json.MarshalIndent(filteredFlows, "", "  ")
-		filename = "flows.json"
-	default:
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported format: %v", req.Msg.GetFormat()))
+func (s *MITMFlowServer) ExportFlows(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.ExportFlowsRequest],
+) (*connect.Response[mitmflowv1.ExportFlowsResponse], error) {
+	log.Printf("ExportFlows called with %d flow IDs, format: %v", len(req.Msg.GetFlowIds()), req.Msg.GetFormat())
+
+	filteredFlows, err := s.exportSelection(ctx, req.Msg)
+	if err != nil {
+		return nil, err
 	}
 
+	if req.Msg.GetAnonymize() {
+		filteredFlows = s.anonymizer.anonymizeFlows(filteredFlows)
+	}
+	if req.Msg.GetShiftTimestamps() {
+		filteredFlows = shiftFlows(filteredFlows, req.Msg.GetEpoch().AsTime())
+	}
+
+	data, filename, err := encodeFlows(filteredFlows, req.Msg.GetFormat())
+	if errors.Is(err, errUnsupportedFormat) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 	if err != nil {
 		log.Printf("Export generation failed: %v", err)
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+	s.audit(ctx, req.Peer(), mitmflowv1.AuditAction_AUDIT_ACTION_EXPORT, flowIDs(filteredFlows), len(filteredFlows), req.Msg.GetFormat().String())
 
 	return connect.NewResponse(mitmflowv1.ExportFlowsResponse_builder{
 		Data:     data,
@@ -636,9 +1703,53 @@ func (s *MITMFlowServer) ExportFlows(
 }
 
 func main() {
-	flag.Parse()
+	if len(os.Args) > 1 {
+		if cmd, ok := subcommands[os.Args[1]]; ok {
+			exitOnError(os.Args[1], cmd(os.Args[2:]))
+			return
+		}
+	}
+	runServe(os.Args[1:])
+}
 
-	storage, err := NewFlowStorage(*dataDir, *maxFlows)
+// runServe starts the server. It is the default when no subcommand is given.
+func runServe(args []string) {
+	flag.CommandLine.Parse(args) //nolint:errcheck
+
+	storageOpts := []StorageOption{WithCompression(*compress), WithFlushInterval(*flushInterval)}
+	if *zstdDictFile != "" {
+		dict, err := os.ReadFile(*zstdDictFile)
+		if err != nil {
+			log.Fatalf("failed to read zstd dictionary: %v", err)
+		}
+		storageOpts = append(storageOpts, WithZstdDictionary(dict))
+	}
+
+	if *blobThreshold > 0 {
+		location := *blobStore
+		if location == "" {
+			location = filepath.Join(*dataDir, "blobs")
+		}
+		blobs, err := NewBlobStore(context.Background(), location)
+		if err != nil {
+			log.Fatalf("failed to initialize blob store: %v", err)
+		}
+		storageOpts = append(storageOpts, WithBlobStore(blobs, *blobThreshold))
+	}
+	if *archiveDir != "" {
+		storageOpts = append(storageOpts, WithArchiveDir(*archiveDir))
+	}
+	if *trashRetention > 0 {
+		storageOpts = append(storageOpts, WithTrash(*trashRetention))
+	}
+	if *maxAge > 0 {
+		storageOpts = append(storageOpts, WithMaxAge(*maxAge))
+	}
+	if len(retainTags) > 0 {
+		storageOpts = append(storageOpts, WithRetainedTags(retainTags...))
+	}
+
+	storage, err := NewFlowStorage(*dataDir, *maxFlows, storageOpts...)
 	if err != nil {
 		log.Fatalf("failed to initialize storage: %v", err)
 	}
@@ -650,56 +1761,191 @@ func main() {
 		}
 	}
 
-	server, err := NewMITMFlowServer(storage, registry)
-	if err != nil {
-		log.Fatalf("failed to initialize server: %v", err)
+	if *backupDir == "" {
+		*backupDir = filepath.Join(*dataDir, "backups")
 	}
 
-	mux := http.NewServeMux()
-	opts := []connect.HandlerOption{
-		connect.WithInterceptors(validate.NewInterceptor()),
-		connect.WithCompressMinBytes(1024), // Compress response messages larger than 1KB
+	serverOpts := []ServerOption{
+		WithMaxBodyBytes(*maxBodyBytes),
+		WithMaxIngestMessageBytes(*maxIngestBytes),
+		WithStreamKeepalive(*streamKeepalive),
+		WithBackupDir(*backupDir),
+		WithBaselineFile(filepath.Join(*dataDir, "baseline.binpb")),
+		WithProxyRulesFile(filepath.Join(*dataDir, "proxy_rules.binpb")),
 	}
-	mux.Handle(mitmflowv1.NewServiceHandler(server, opts...))
-	mux.Handle(mitmproxygrpcv1.NewServiceHandler(server, opts...))
-
-	log.Printf("Starting server on %s", *addr)
-
-	fsys, err := fs.Sub(dist, "dist")
+	anonymizationKey, err := loadOrCreateKey(filepath.Join(*dataDir, anonymizationKeyFile))
+	if err != nil {
+		log.Fatalf("failed to load anonymization key: %v", err)
+	}
+	serverOpts = append(serverOpts, WithAnonymizationKey(anonymizationKey))
+	if len(geoIPFiles) > 0 {
+		geoIP, err := OpenGeoIP(geoIPFiles)
+		if err != nil {
+			log.Fatalf("failed to open GeoIP databases: %v", err)
+		}
+		defer geoIP.Close() //nolint:errcheck
+		serverOpts = append(serverOpts, WithGeoIP(geoIP))
+	}
+	if *reverseDNS {
+		serverOpts = append(serverOpts, WithReverseDNS())
+	}
+	if *stripEXIF {
+		serverOpts = append(serverOpts, WithStripEXIF())
+	}
+	if *redactDBValues {
+		serverOpts = append(serverOpts, WithDBValueRedaction())
+	}
+	priority, err := parseContentTypeSource(*contentTypePrio)
 	if err != nil {
 		log.Fatal(err)
 	}
-	staticHandler := http.FileServer(http.FS(fsys))
-
-	// Serve index.html for root and HTML requests
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == "/" || r.URL.Path == "/index.html" {
-			// Read the index.html file from the embedded filesystem
-			indexHTML, err := fs.ReadFile(fsys, "index.html")
+	serverOpts = append(serverOpts, WithContentTypePriority(priority))
+	for _, rule := range contentTypeRules {
+		mediaType, source, err := parseContentTypeRule(rule)
+		if err != nil {
+			log.Fatal(err)
+		}
+		serverOpts = append(serverOpts, WithContentTypeRule(mediaType, source))
+	}
+	var auth *authenticator
+	if *tokenFile != "" {
+		tokens, err := loadTokenFile(*tokenFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Requiring one of %d tokens from %s", len(tokens), *tokenFile)
+		auth = &authenticator{tokens: tokens}
+	}
+	uiCfg := uiConfig{BasePath: normalizeBasePath(*basePath), PublicURL: *publicURL}
+	if *oidcIssuer != "" {
+		oidc, err := newOIDCAuthFromFlags(context.Background(), uiCfg, filepath.Join(*dataDir, sessionKeyFile))
+		if err != nil {
+			log.Fatalf("failed to set up OIDC: %v", err)
+		}
+		log.Printf("Signing UI users in with %s", *oidcIssuer)
+		if auth == nil {
+			auth = &authenticator{}
+		}
+		auth.oidc = oidc
+		uiCfg.LoginPath = oidcLoginPath
+	}
+	if auth != nil {
+		serverOpts = append(serverOpts, WithAuth(auth))
+	}
+	if *auditLogFile != "" {
+		audit, err := openAuditLog(*auditLogFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer audit.Close() //nolint:errcheck
+		serverOpts = append(serverOpts, WithAuditLog(audit))
+	}
+	if len(autoExportRules) > 0 {
+		var rules []autoExportRule
+		for _, spec := range autoExportRules {
+			rule, err := parseAutoExportRule(context.Background(), spec)
+			if err != nil {
+				log.Fatalf("failed to parse -auto-export: %v", err)
+			}
+			rules = append(rules, rule)
+		}
+		serverOpts = append(serverOpts, WithAutoExport(*autoExportEvery, rules...))
+	}
+	if len(alertRules) > 0 {
+		var rules []alertRule
+		for _, spec := range alertRules {
+			rule, err := parseAlertRule(spec)
+			if err != nil {
+				log.Fatalf("failed to parse -alert: %v", err)
+			}
+			rules = append(rules, rule)
+		}
+		notifiers := []alertNotifier{logNotifier{}}
+		for _, spec := range alertWebhooks {
+			notifier, err := parseAlertNotifier(spec)
+			if err != nil {
+				log.Fatalf("failed to parse -alert-webhook: %v", err)
+			}
+			notifiers = append(notifiers, notifier)
+		}
+		serverOpts = append(serverOpts, WithAlerts(*publicURL, rules, notifiers...))
+	}
+	if len(scriptFiles) > 0 {
+		var scripts []*flowScript
+		for _, filename := range scriptFiles {
+			script, err := loadFlowScript(filename)
 			if err != nil {
-				http.Error(w, "index.html not found", http.StatusInternalServerError)
-				return
+				log.Fatalf("failed to load -script: %v", err)
 			}
+			scripts = append(scripts, script)
+		}
+		serverOpts = append(serverOpts, WithScripts(scripts...))
+	}
+	if *pluginsDir != "" {
+		plugins, err := loadDecoderPlugins(context.Background(), *pluginsDir)
+		if err != nil {
+			log.Fatalf("failed to load -plugins-dir: %v", err)
+		}
+		defer plugins.Close(context.Background())
+		serverOpts = append(serverOpts, WithDecoderPlugins(plugins))
+	}
+	var skipTypes []string
+	for _, t := range skipDecodeTypes {
+		skipTypes = append(skipTypes, strings.ToLower(strings.TrimSpace(t)))
+	}
+	serverOpts = append(serverOpts, WithDecodeLimits(decodeLimits{
+		maxProtobufBytes: *maxProtobufBytes,
+		skipTypes:        skipTypes,
+		maxFramesBytes:   *maxFramesBytes,
+	}))
+	if len(decoderHookSpecs) > 0 {
+		var hooks []decoderHook
+		for _, spec := range decoderHookSpecs {
+			hook, err := parseDecoderHook(spec)
+			if err != nil {
+				log.Fatalf("failed to parse -decoder-hook: %v", err)
+			}
+			hooks = append(hooks, hook)
+		}
+		serverOpts = append(serverOpts, WithDecoderHooks(hooks...))
+	}
 
-			// Inject configuration into the HTML
-			// Use "." for relative URL so requests go relative to current page path
-			config := `<script>window.MITMFLOW_GRPC_ADDR = ".";</script>`
-			modifiedHTML := strings.Replace(
-				string(indexHTML),
-				"<!-- MITMFLOW_CONFIG -->",
-				config,
-				1,
-			)
-
-			// Serve the modified HTML
-			w.Header().Set("Content-Type", "text/html")
-			w.Write([]byte(modifiedHTML)) //nolint:errcheck
-			return
+	server, err := NewMITMFlowServer(storage, registry, serverOpts...)
+	if err != nil {
+		log.Fatalf("failed to initialize server: %v", err)
+	}
+
+	go server.autoExport.run(context.Background())
+	if *watchDir != "" {
+		watcher, err := newDirWatcher(server, *watchDir)
+		if err != nil {
+			log.Fatalf("failed to watch %s: %v", *watchDir, err)
 		}
+		log.Printf("Watching %s for mitmproxy dumps and HAR files", *watchDir)
+		go watcher.run(context.Background(), watchDirInterval)
+	}
 
-		// For all other paths, serve static files
-		staticHandler.ServeHTTP(w, r)
-	})
+	prefix := normalizeBasePath(*basePath)
+	log.Printf("Starting server on %s%s", *addr, prefix)
+
+	var ui http.Handler
+	if *noUI {
+		log.Printf("UI disabled, only serving RPC services")
+	} else {
+		fsys, err := fs.Sub(dist, "dist")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if *uiDir != "" {
+			log.Printf("Serving UI from %s", *uiDir)
+			fsys = overlayFS{primary: os.DirFS(*uiDir), fallback: fsys}
+		}
+		ui = newUIHandler(fsys, uiCfg)
+		if auth != nil && auth.oidc != nil {
+			ui = auth.oidc.requireSession(ui)
+		}
+	}
+	mux := newServeMux(server, auth, ui)
 
 	c := cors.New(cors.Options{
 		AllowedOrigins: []string{"http://localhost:5173"},
@@ -707,7 +1953,19 @@ func main() {
 		AllowedHeaders: []string{"*"},
 	})
 
-	handlerWithCors := c.Handler(h2c.NewHandler(mux, &http2.Server{}))
+	var handler http.Handler = mux
+	if *gzipResponses {
+		handler = gzipHandler(handler)
+	}
+	if *accessLog != "" {
+		logger, closeLog, err := openAccessLog(*accessLog)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer closeLog() //nolint:errcheck
+		handler = accessLogHandler(handler, logger)
+	}
+	handlerWithCors := c.Handler(h2c.NewHandler(mountAt(prefix, handler), &http2.Server{}))
 
 	err = http.ListenAndServe(
 		*addr,
@@ -718,3 +1976,43 @@ func main() {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
+
+// newServeMux routes the RPC services, the WebSocket endpoint, reflection
+// and, unless ui is nil as with -no-ui, the UI. auth is nil when clients
+// aren't authenticated.
+func newServeMux(server *MITMFlowServer, auth *authenticator, ui http.Handler) *http.ServeMux {
+	mux := http.NewServeMux()
+	opts := []connect.HandlerOption{
+		connect.WithCompressMinBytes(1024), // Compress response messages larger than 1KB
+	}
+	if auth != nil {
+		opts = append(opts, connect.WithInterceptors(auth))
+	}
+	opts = append(opts, connect.WithInterceptors(validate.NewInterceptor()))
+	mux.Handle(mitmflowv1.NewServiceHandler(server, opts...))
+	ingestOpts := opts
+	if server.ingestMaxBytes > 0 {
+		ingestOpts = append(slices.Clip(opts), connect.WithReadMaxBytes(server.ingestMaxBytes))
+	}
+	mux.Handle(mitmproxygrpcv1.NewServiceHandler(server, ingestOpts...))
+	mux.HandleFunc(wsFlowsPath, server.handleFlowsWebSocket)
+	if auth != nil && auth.oidc != nil {
+		auth.oidc.register(mux)
+	}
+
+	// Reflection lets grpcurl and buf curl discover the services without
+	// needing the proto files. It takes the same credentials as the
+	// services it describes.
+	var reflectOpts []connect.HandlerOption
+	if auth != nil {
+		reflectOpts = append(reflectOpts, connect.WithInterceptors(auth))
+	}
+	reflector := grpcreflect.NewStaticReflector(mitmflowv1.ServiceName, mitmproxygrpcv1.ServiceName)
+	mux.Handle(grpcreflect.NewHandlerV1(reflector, reflectOpts...))
+	mux.Handle(grpcreflect.NewHandlerV1Alpha(reflector, reflectOpts...))
+
+	if ui != nil {
+		mux.Handle("/", ui)
+	}
+	return mux
+}
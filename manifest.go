@@ -0,0 +1,617 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+// manifestLinkTTL is how long after a manifest was captured requests for the
+// URLs it lists are still linked to it.
+const manifestLinkTTL = time.Hour
+
+func isHLSMediaType(mediaType string) bool {
+	switch mediaType {
+	case "application/vnd.apple.mpegurl", "application/x-mpegurl", "audio/mpegurl", "audio/x-mpegurl":
+		return true
+	}
+	return false
+}
+
+// manifestKind returns "hls" or "dash" for the body of an HLS playlist or a
+// DASH MPD, and "" for anything else.
+func manifestKind(content []byte, headers map[string]string) string {
+	mediaType, _, _ := mime.ParseMediaType(getHeaderValue(headers, "Content-Type"))
+	switch {
+	case isHLSMediaType(mediaType), bytes.HasPrefix(bytes.TrimPrefix(content, []byte("\ufeff")), []byte("#EXTM3U")):
+		return "hls"
+	case mediaType == "application/dash+xml":
+		return "dash"
+	}
+	return ""
+}
+
+// setManifestFrames describes an HLS playlist or DASH manifest in a frame
+// listing its variants or representations and segments.
+func setManifestFrames(content []byte, headers map[string]string, details *mitmflowv1.MessageDetails) {
+	if len(details.GetTextualFrames()) > 0 || len(content) == 0 {
+		return
+	}
+	if decoded, ok := decodeContentEncoding(content, getHeaderValue(headers, "Content-Encoding")); ok {
+		content = decoded
+	}
+	switch manifestKind(content, headers) {
+	case "hls":
+		if p, ok := parseHLS(content); ok {
+			details.SetTextualFrames([]string{capFrame(p.frame())})
+		}
+	case "dash":
+		if mpd, ok := parseDASH(content); ok {
+			details.SetTextualFrames([]string{capFrame(mpd.frame())})
+		}
+	}
+}
+
+// countOf formats n with noun, pluralized with an s when n isn't 1.
+func countOf(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return strconv.Itoa(n) + " " + noun + "s"
+}
+
+type hlsVariant struct {
+	uri    string
+	attrs  map[string]string
+	iframe bool
+}
+
+type hlsSegment struct {
+	uri      string
+	duration float64
+}
+
+// hlsPlaylist is an HLS master playlist, which has variants and renditions,
+// or a media playlist, which has segments.
+type hlsPlaylist struct {
+	variants       []hlsVariant
+	renditions     []map[string]string
+	segments       []hlsSegment
+	targetDuration string
+	mediaSequence  string
+	playlistType   string
+	ended          bool
+	keys           []string
+	maps           []string
+}
+
+func parseHLS(content []byte) (*hlsPlaylist, bool) {
+	text := strings.TrimPrefix(string(content), "\ufeff")
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if strings.TrimSpace(lines[0]) != "#EXTM3U" {
+		return nil, false
+	}
+	p := &hlsPlaylist{}
+	var variant map[string]string
+	var duration float64
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#"):
+			tag, value, _ := strings.Cut(line, ":")
+			switch tag {
+			case "#EXT-X-STREAM-INF":
+				variant = parseHLSAttributes(value)
+			case "#EXT-X-I-FRAME-STREAM-INF":
+				attrs := parseHLSAttributes(value)
+				p.variants = append(p.variants, hlsVariant{uri: attrs["URI"], attrs: attrs, iframe: true})
+			case "#EXT-X-MEDIA":
+				p.renditions = append(p.renditions, parseHLSAttributes(value))
+			case "#EXTINF":
+				d, _, _ := strings.Cut(value, ",")
+				duration, _ = strconv.ParseFloat(strings.TrimSpace(d), 64)
+			case "#EXT-X-TARGETDURATION":
+				p.targetDuration = value
+			case "#EXT-X-MEDIA-SEQUENCE":
+				p.mediaSequence = value
+			case "#EXT-X-PLAYLIST-TYPE":
+				p.playlistType = value
+			case "#EXT-X-ENDLIST":
+				p.ended = true
+			case "#EXT-X-KEY":
+				if uri := parseHLSAttributes(value)["URI"]; uri != "" && !slices.Contains(p.keys, uri) {
+					p.keys = append(p.keys, uri)
+				}
+			case "#EXT-X-MAP":
+				if uri := parseHLSAttributes(value)["URI"]; uri != "" && !slices.Contains(p.maps, uri) {
+					p.maps = append(p.maps, uri)
+				}
+			}
+		case variant != nil:
+			p.variants = append(p.variants, hlsVariant{uri: line, attrs: variant})
+			variant = nil
+		default:
+			p.segments = append(p.segments, hlsSegment{uri: line, duration: duration})
+			duration = 0
+		}
+	}
+	return p, true
+}
+
+// parseHLSAttributes parses an attribute list like
+// BANDWIDTH=1280000,CODECS="avc1.4d401f,mp4a.40.2".
+func parseHLSAttributes(s string) map[string]string {
+	attrs := map[string]string{}
+	for s != "" {
+		name, rest, ok := strings.Cut(s, "=")
+		if !ok {
+			break
+		}
+		var value string
+		if quoted, ok := strings.CutPrefix(rest, `"`); ok {
+			value, rest, _ = strings.Cut(quoted, `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		attrs[strings.TrimSpace(name)] = value
+		s = strings.TrimPrefix(strings.TrimSpace(rest), ",")
+	}
+	return attrs
+}
+
+// uris returns every URI the playlist lists.
+func (p *hlsPlaylist) uris() []string {
+	var uris []string
+	for _, v := range p.variants {
+		uris = append(uris, v.uri)
+	}
+	for _, r := range p.renditions {
+		uris = append(uris, r["URI"])
+	}
+	for _, s := range p.segments {
+		uris = append(uris, s.uri)
+	}
+	uris = append(uris, p.keys...)
+	uris = append(uris, p.maps...)
+	return slices.DeleteFunc(uris, func(uri string) bool { return uri == "" })
+}
+
+func (p *hlsPlaylist) frame() string {
+	var b strings.Builder
+	if len(p.variants) > 0 || len(p.renditions) > 0 {
+		fmt.Fprintf(&b, "HLS master playlist\n%s, %s\n", countOf(len(p.variants), "variant"), countOf(len(p.renditions), "rendition"))
+		if len(p.variants) > 0 {
+			b.WriteString("\nVariants:\n")
+		}
+		for i, v := range p.variants {
+			var parts []string
+			if v.iframe {
+				parts = append(parts, "I-frames")
+			}
+			if bandwidth := v.attrs["BANDWIDTH"]; bandwidth != "" {
+				parts = append(parts, bandwidth+" bps")
+			}
+			for _, name := range []string{"RESOLUTION", "CODECS"} {
+				if v.attrs[name] != "" {
+					parts = append(parts, v.attrs[name])
+				}
+			}
+			fmt.Fprintf(&b, "  %d. %s\n     %s\n", i+1, strings.Join(parts, ", "), v.uri)
+		}
+		if len(p.renditions) > 0 {
+			b.WriteString("\nRenditions:\n")
+		}
+		for _, r := range p.renditions {
+			fmt.Fprintf(&b, "  %s %q", r["TYPE"], r["NAME"])
+			if r["LANGUAGE"] != "" {
+				fmt.Fprintf(&b, " (%s)", r["LANGUAGE"])
+			}
+			if r["URI"] != "" {
+				b.WriteString(": " + r["URI"])
+			}
+			b.WriteString("\n")
+		}
+		return strings.TrimSuffix(b.String(), "\n")
+	}
+
+	kind := p.playlistType
+	if kind == "" {
+		kind = "live"
+		if p.ended {
+			kind = "VOD"
+		}
+	}
+	var total float64
+	for _, s := range p.segments {
+		total += s.duration
+	}
+	fmt.Fprintf(&b, "HLS media playlist (%s)\n%s, %.3fs", kind, countOf(len(p.segments), "segment"), total)
+	if p.targetDuration != "" {
+		fmt.Fprintf(&b, ", target duration %ss", p.targetDuration)
+	}
+	if p.mediaSequence != "" {
+		fmt.Fprintf(&b, ", media sequence %s", p.mediaSequence)
+	}
+	b.WriteString("\n")
+	for _, m := range p.maps {
+		fmt.Fprintf(&b, "Init segment: %s\n", m)
+	}
+	for _, k := range p.keys {
+		fmt.Fprintf(&b, "Key: %s\n", k)
+	}
+	if len(p.segments) > 0 {
+		b.WriteString("\nSegments:\n")
+	}
+	for i, s := range p.segments {
+		fmt.Fprintf(&b, "  %d. %.3fs %s\n", i+1, s.duration, s.uri)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+type dashMPD struct {
+	Type     string       `xml:"type,attr"`
+	Duration string       `xml:"mediaPresentationDuration,attr"`
+	BaseURL  string       `xml:"BaseURL"`
+	Periods  []dashPeriod `xml:"Period"`
+}
+
+type dashPeriod struct {
+	ID             string              `xml:"id,attr"`
+	BaseURL        string              `xml:"BaseURL"`
+	AdaptationSets []dashAdaptationSet `xml:"AdaptationSet"`
+}
+
+type dashAdaptationSet struct {
+	ContentType     string               `xml:"contentType,attr"`
+	MimeType        string               `xml:"mimeType,attr"`
+	Lang            string               `xml:"lang,attr"`
+	BaseURL         string               `xml:"BaseURL"`
+	SegmentTemplate *dashSegmentTemplate `xml:"SegmentTemplate"`
+	Representations []dashRepresentation `xml:"Representation"`
+}
+
+type dashRepresentation struct {
+	ID              string               `xml:"id,attr"`
+	Bandwidth       string               `xml:"bandwidth,attr"`
+	Width           string               `xml:"width,attr"`
+	Height          string               `xml:"height,attr"`
+	Codecs          string               `xml:"codecs,attr"`
+	BaseURL         string               `xml:"BaseURL"`
+	SegmentTemplate *dashSegmentTemplate `xml:"SegmentTemplate"`
+	SegmentList     *dashSegmentList     `xml:"SegmentList"`
+}
+
+type dashSegmentTemplate struct {
+	Media          string `xml:"media,attr"`
+	Initialization string `xml:"initialization,attr"`
+}
+
+type dashSegmentList struct {
+	Initialization struct {
+		SourceURL string `xml:"sourceURL,attr"`
+	} `xml:"Initialization"`
+	SegmentURLs []struct {
+		Media string `xml:"media,attr"`
+	} `xml:"SegmentURL"`
+}
+
+func parseDASH(content []byte) (*dashMPD, bool) {
+	var mpd dashMPD
+	if err := xml.Unmarshal(content, &mpd); err != nil {
+		return nil, false
+	}
+	return &mpd, true
+}
+
+// template returns the SegmentTemplate that applies to a representation of
+// the adaptation set.
+func (as *dashAdaptationSet) template(rep *dashRepresentation) *dashSegmentTemplate {
+	if rep.SegmentTemplate != nil {
+		return rep.SegmentTemplate
+	}
+	return as.SegmentTemplate
+}
+
+func (mpd *dashMPD) frame() string {
+	var sets, reps int
+	for _, period := range mpd.Periods {
+		sets += len(period.AdaptationSets)
+		for _, as := range period.AdaptationSets {
+			reps += len(as.Representations)
+		}
+	}
+	var b strings.Builder
+	kind := mpd.Type
+	if kind == "" {
+		kind = "static"
+	}
+	fmt.Fprintf(&b, "DASH manifest (%s)", kind)
+	if mpd.Duration != "" {
+		fmt.Fprintf(&b, ", duration %s", mpd.Duration)
+	}
+	fmt.Fprintf(&b, "\n%s, %s, %s\n", countOf(len(mpd.Periods), "period"), countOf(sets, "adaptation set"), countOf(reps, "representation"))
+	for i, period := range mpd.Periods {
+		id := period.ID
+		if id == "" {
+			id = strconv.Itoa(i + 1)
+		}
+		fmt.Fprintf(&b, "\nPeriod %s:\n", id)
+		for _, as := range period.AdaptationSets {
+			var parts []string
+			for _, part := range []string{as.MimeType, as.ContentType, as.Lang} {
+				if part != "" {
+					parts = append(parts, part)
+				}
+			}
+			fmt.Fprintf(&b, "  Adaptation set %s\n", strings.Join(parts, ", "))
+			for _, rep := range as.Representations {
+				parts = parts[:0]
+				if rep.Bandwidth != "" {
+					parts = append(parts, rep.Bandwidth+" bps")
+				}
+				if rep.Width != "" && rep.Height != "" {
+					parts = append(parts, rep.Width+"x"+rep.Height)
+				}
+				if rep.Codecs != "" {
+					parts = append(parts, rep.Codecs)
+				}
+				fmt.Fprintf(&b, "    %s: %s\n", rep.ID, strings.Join(parts, ", "))
+				if rep.BaseURL != "" {
+					fmt.Fprintf(&b, "      Base URL: %s\n", rep.BaseURL)
+				}
+				if t := as.template(&rep); t != nil {
+					if t.Initialization != "" {
+						fmt.Fprintf(&b, "      Init: %s\n", t.Initialization)
+					}
+					if t.Media != "" {
+						fmt.Fprintf(&b, "      Media: %s\n", t.Media)
+					}
+				}
+				if list := rep.SegmentList; list != nil {
+					if list.Initialization.SourceURL != "" {
+						fmt.Fprintf(&b, "      Init: %s\n", list.Initialization.SourceURL)
+					}
+					for j, seg := range list.SegmentURLs {
+						fmt.Fprintf(&b, "      %d. %s\n", j+1, seg.Media)
+					}
+				}
+			}
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// resolveManifestURL resolves ref against base, returning nil if ref isn't a
+// valid URL.
+func resolveManifestURL(base *url.URL, ref string) *url.URL {
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return nil
+	}
+	return base.ResolveReference(u)
+}
+
+// manifestURLKey identifies the resource a URL names for linking, ignoring
+// the query string, where CDNs often put per-request tokens.
+func manifestURLKey(u *url.URL) string {
+	return u.Host + u.Path
+}
+
+// urls returns the keys of the URLs a DASH manifest at base lists outright,
+// and patterns matching the keys of those its SegmentTemplates describe.
+func (mpd *dashMPD) urls(base *url.URL) ([]string, []*regexp.Regexp) {
+	var keys []string
+	var patterns []*regexp.Regexp
+	add := func(base *url.URL, ref string) {
+		if ref == "" {
+			return
+		}
+		if u := resolveManifestURL(base, ref); u != nil {
+			keys = append(keys, manifestURLKey(u))
+		}
+	}
+	within := func(base *url.URL, ref string) *url.URL {
+		if ref == "" {
+			return base
+		}
+		if u := resolveManifestURL(base, ref); u != nil {
+			return u
+		}
+		return base
+	}
+	base = within(base, mpd.BaseURL)
+	for _, period := range mpd.Periods {
+		periodBase := within(base, period.BaseURL)
+		for _, as := range period.AdaptationSets {
+			setBase := within(periodBase, as.BaseURL)
+			for _, rep := range as.Representations {
+				repBase := within(setBase, rep.BaseURL)
+				if rep.BaseURL != "" {
+					keys = append(keys, manifestURLKey(repBase))
+				}
+				if t := as.template(&rep); t != nil {
+					for _, template := range []string{t.Initialization, t.Media} {
+						if re := dashTemplatePattern(repBase, template, &rep); re != nil {
+							patterns = append(patterns, re)
+						}
+					}
+				}
+				if list := rep.SegmentList; list != nil {
+					add(repBase, list.Initialization.SourceURL)
+					for _, seg := range list.SegmentURLs {
+						add(repBase, seg.Media)
+					}
+				}
+			}
+		}
+	}
+	return keys, patterns
+}
+
+// dashTemplatePattern turns a SegmentTemplate URL into a pattern matching the
+// key of every URL it describes. $RepresentationID$ and $Bandwidth$ are
+// filled in; $Number$ and $Time$ match any number.
+func dashTemplatePattern(base *url.URL, template string, rep *dashRepresentation) *regexp.Regexp {
+	parts := strings.Split(template, "$")
+	if template == "" || len(parts)%2 == 0 {
+		return nil
+	}
+	// Numbers are written as a placeholder until the URL is resolved and
+	// quoted, then replaced by \d+.
+	const placeholder = "{n}"
+	var b strings.Builder
+	for i, part := range parts {
+		if i%2 == 0 {
+			b.WriteString(part)
+			continue
+		}
+		name, _, _ := strings.Cut(part, "%")
+		switch name {
+		case "":
+			b.WriteString("$")
+		case "RepresentationID":
+			b.WriteString(rep.ID)
+		case "Bandwidth":
+			b.WriteString(rep.Bandwidth)
+		default:
+			b.WriteString(placeholder)
+		}
+	}
+	u := resolveManifestURL(base, b.String())
+	if u == nil {
+		return nil
+	}
+	pattern := strings.ReplaceAll(regexp.QuoteMeta(manifestURLKey(u)), regexp.QuoteMeta(placeholder), `\d+`)
+	re, err := regexp.Compile("^" + pattern + "$")
+	if err != nil {
+		return nil
+	}
+	return re
+}
+
+type manifestRef struct {
+	flowID string
+	at     time.Time
+}
+
+type manifestPattern struct {
+	re *regexp.Regexp
+	manifestRef
+}
+
+// manifestTracker links requests for the playlists, segments and keys of
+// HLS and DASH streams to the manifest that listed them.
+type manifestTracker struct {
+	mu   sync.Mutex
+	urls map[string]manifestRef
+	// patterns holds the SegmentTemplate patterns of each DASH manifest by
+	// its own URL key, replaced whenever a live manifest is fetched again.
+	patterns map[string][]manifestPattern
+}
+
+func newManifestTracker() *manifestTracker {
+	return &manifestTracker{
+		urls:     make(map[string]manifestRef),
+		patterns: make(map[string][]manifestPattern),
+	}
+}
+
+// link returns the ID of the manifest flow that listed the URL an HTTP flow
+// requested, if any. When the flow's response is itself a manifest, the URLs
+// it lists are remembered so later requests can be linked to it.
+func (m *manifestTracker) link(flow *mitmflowv1.Flow) string {
+	if m == nil {
+		return ""
+	}
+	h := flow.GetHttpFlow()
+	u, err := url.Parse(h.GetRequest().GetUrl())
+	if err != nil {
+		return ""
+	}
+	id := GetFlowID(flow)
+	at := flowStartTime(flow, time.Now())
+	linked := m.lookup(manifestURLKey(u), id, at)
+
+	resp := h.GetResponse()
+	content := resp.GetContent()
+	if decoded, ok := decodeContentEncoding(content, getHeaderValue(resp.GetHeaders(), "Content-Encoding")); ok {
+		content = decoded
+	}
+	ref := manifestRef{flowID: id, at: at}
+	switch manifestKind(content, resp.GetHeaders()) {
+	case "hls":
+		if p, ok := parseHLS(content); ok {
+			var keys []string
+			for _, uri := range p.uris() {
+				if resolved := resolveManifestURL(u, uri); resolved != nil {
+					keys = append(keys, manifestURLKey(resolved))
+				}
+			}
+			m.record(manifestURLKey(u), ref, keys, nil)
+		}
+	case "dash":
+		if mpd, ok := parseDASH(content); ok {
+			keys, patterns := mpd.urls(u)
+			m.record(manifestURLKey(u), ref, keys, patterns)
+		}
+	}
+	return linked
+}
+
+func (m *manifestTracker) record(manifestKey string, ref manifestRef, keys []string, patterns []*regexp.Regexp) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for k, old := range m.urls {
+		if ref.at.Sub(old.at) > manifestLinkTTL {
+			delete(m.urls, k)
+		}
+	}
+	for k, old := range m.patterns {
+		if len(old) > 0 && ref.at.Sub(old[0].at) > manifestLinkTTL {
+			delete(m.patterns, k)
+		}
+	}
+	for _, k := range keys {
+		m.urls[k] = ref
+	}
+	if len(patterns) > 0 {
+		list := make([]manifestPattern, len(patterns))
+		for i, re := range patterns {
+			list[i] = manifestPattern{re: re, manifestRef: ref}
+		}
+		m.patterns[manifestKey] = list
+	}
+}
+
+// lookup returns the ID of the latest manifest before at that listed key,
+// other than the flow itself.
+func (m *manifestTracker) lookup(key, id string, at time.Time) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	usable := func(ref manifestRef) bool {
+		return ref.flowID != id && !at.Before(ref.at) && at.Sub(ref.at) <= manifestLinkTTL
+	}
+	if ref, ok := m.urls[key]; ok && usable(ref) {
+		return ref.flowID
+	}
+	var best manifestRef
+	for _, list := range m.patterns {
+		for _, p := range list {
+			if usable(p.manifestRef) && p.at.After(best.at) && p.re.MatchString(key) {
+				best = p.manifestRef
+			}
+		}
+	}
+	return best.flowID
+}
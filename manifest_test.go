@@ -0,0 +1,146 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const hlsMaster = `#EXTM3U
+#EXT-X-MEDIA:TYPE=AUDIO,GROUP-ID="aac",NAME="English",LANGUAGE="en",URI="audio/en.m3u8"
+#EXT-X-STREAM-INF:BANDWIDTH=2500000,RESOLUTION=1280x720,CODECS="avc1.4d401f,mp4a.40.2",AUDIO="aac"
+720p/index.m3u8
+#EXT-X-STREAM-INF:BANDWIDTH=800000,RESOLUTION=640x360
+https://cdn2.example.com/360p/index.m3u8
+`
+
+const hlsMedia = `#EXTM3U
+#EXT-X-VERSION:7
+#EXT-X-TARGETDURATION:6
+#EXT-X-MEDIA-SEQUENCE:0
+#EXT-X-MAP:URI="init.mp4"
+#EXT-X-KEY:METHOD=AES-128,URI="https://keys.example.com/k1?token=abc"
+#EXTINF:6.000,
+seg0.m4s
+#EXTINF:4.5,
+seg1.m4s?token=xyz
+#EXT-X-ENDLIST
+`
+
+const dashManifest = `<?xml version="1.0"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="static" mediaPresentationDuration="PT1M0S">
+  <BaseURL>media/</BaseURL>
+  <Period id="p0">
+    <AdaptationSet mimeType="video/mp4" contentType="video">
+      <SegmentTemplate initialization="$RepresentationID$/init.mp4" media="$RepresentationID$/seg-$Number%05d$.m4s"/>
+      <Representation id="720p" bandwidth="2500000" width="1280" height="720" codecs="avc1.4d401f"/>
+    </AdaptationSet>
+    <AdaptationSet mimeType="audio/mp4" lang="en">
+      <Representation id="audio" bandwidth="128000" codecs="mp4a.40.2">
+        <SegmentList>
+          <Initialization sourceURL="audio/init.mp4"/>
+          <SegmentURL media="audio/1.m4s"/>
+        </SegmentList>
+      </Representation>
+    </AdaptationSet>
+  </Period>
+</MPD>`
+
+func TestSetManifestFrames(t *testing.T) {
+	frame := func(body, contentType string) string {
+		details := &mitmflowv1.MessageDetails{}
+		setManifestFrames([]byte(body), map[string]string{"Content-Type": contentType}, details)
+		require.Len(t, details.GetTextualFrames(), 1)
+		return details.GetTextualFrames()[0]
+	}
+
+	assert.Equal(t, `HLS master playlist
+2 variants, 1 rendition
+
+Variants:
+  1. 2500000 bps, 1280x720, avc1.4d401f,mp4a.40.2
+     720p/index.m3u8
+  2. 800000 bps, 640x360
+     https://cdn2.example.com/360p/index.m3u8
+
+Renditions:
+  AUDIO "English" (en): audio/en.m3u8`, frame(hlsMaster, "application/vnd.apple.mpegurl"))
+
+	assert.Equal(t, `HLS media playlist (VOD)
+2 segments, 10.500s, target duration 6s, media sequence 0
+Init segment: init.mp4
+Key: https://keys.example.com/k1?token=abc
+
+Segments:
+  1. 6.000s seg0.m4s
+  2. 4.500s seg1.m4s?token=xyz`, frame(hlsMedia, "text/plain"))
+
+	assert.Equal(t, `DASH manifest (static), duration PT1M0S
+1 period, 2 adaptation sets, 2 representations
+
+Period p0:
+  Adaptation set video/mp4, video
+    720p: 2500000 bps, 1280x720, avc1.4d401f
+      Init: $RepresentationID$/init.mp4
+      Media: $RepresentationID$/seg-$Number%05d$.m4s
+  Adaptation set audio/mp4, en
+    audio: 128000 bps, mp4a.40.2
+      Init: audio/init.mp4
+      1. audio/1.m4s`, frame(dashManifest, "application/dash+xml"))
+}
+
+func TestManifestTracker_Link(t *testing.T) {
+	server, err := NewMITMFlowServer(nil, nil)
+	require.NoError(t, err)
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	n := 0
+	ingest := func(rawURL, contentType, body string) *mitmflowv1.Flow {
+		n++
+		flow := mitmflowv1.Flow_builder{
+			HttpFlow: mitmproxyv1.HTTPFlow_builder{
+				Id:             proto.String(rawURL),
+				TimestampStart: timestamppb.New(start.Add(time.Duration(n) * time.Second)),
+				Request: mitmproxyv1.Request_builder{
+					Method: proto.String("GET"),
+					Url:    proto.String(rawURL),
+				}.Build(),
+				Response: mitmproxyv1.Response_builder{
+					StatusCode: proto.Int32(200),
+					Headers:    map[string]string{"Content-Type": contentType},
+					Content:    []byte(body),
+				}.Build(),
+			}.Build(),
+		}.Build()
+		server.preprocessFlow(flow)
+		return flow
+	}
+	linked := func(flow *mitmflowv1.Flow) string {
+		return flow.GetHttpFlowExtra().GetManifestFlowId()
+	}
+
+	// Segments requested before their manifest aren't linked to it.
+	early := ingest("https://cdn.example.com/live/720p/seg0.m4s", "video/mp4", "")
+	assert.Empty(t, linked(early))
+
+	master := ingest("https://cdn.example.com/live/master.m3u8", "application/vnd.apple.mpegurl", hlsMaster)
+	assert.Empty(t, linked(master))
+	variant := ingest("https://cdn.example.com/live/720p/index.m3u8", "application/vnd.apple.mpegurl", hlsMedia)
+	assert.Equal(t, GetFlowID(master), linked(variant))
+	assert.Equal(t, GetFlowID(master), linked(ingest("https://cdn2.example.com/360p/index.m3u8", "application/vnd.apple.mpegurl", "#EXTM3U\n")))
+	assert.Equal(t, GetFlowID(variant), linked(ingest("https://cdn.example.com/live/720p/seg0.m4s", "video/mp4", "")))
+	assert.Equal(t, GetFlowID(variant), linked(ingest("https://cdn.example.com/live/720p/seg1.m4s?token=other", "video/mp4", "")))
+	assert.Equal(t, GetFlowID(variant), linked(ingest("https://keys.example.com/k1", "application/octet-stream", "")))
+	assert.Empty(t, linked(ingest("https://cdn.example.com/live/720p/seg2.m4s", "video/mp4", "")))
+
+	mpd := ingest("https://vod.example.com/movie/manifest.mpd", "application/dash+xml", dashManifest)
+	assert.Equal(t, GetFlowID(mpd), linked(ingest("https://vod.example.com/movie/media/720p/init.mp4", "video/mp4", "")))
+	assert.Equal(t, GetFlowID(mpd), linked(ingest("https://vod.example.com/movie/media/720p/seg-00042.m4s", "video/mp4", "")))
+	assert.Equal(t, GetFlowID(mpd), linked(ingest("https://vod.example.com/movie/media/audio/1.m4s", "audio/mp4", "")))
+	assert.Empty(t, linked(ingest("https://vod.example.com/movie/media/1080p/seg-00042.m4s", "video/mp4", "")))
+}
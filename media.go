@@ -0,0 +1,462 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"mime"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+const (
+	// thumbnailSize is the longest side of a thumbnail, in pixels.
+	thumbnailSize = 128
+	// maxThumbnailPixels bounds the size of images decoded for a thumbnail,
+	// so a small body claiming huge dimensions can't exhaust memory.
+	maxThumbnailPixels = 40_000_000
+)
+
+// mediaInfo describes an image, video or audio body, or returns nil for any
+// other content type. EXIF tags are left out when stripEXIF is set.
+func mediaInfo(content []byte, contentType string, stripEXIF bool) *mitmflowv1.MediaInfo {
+	if len(content) == 0 {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	kind, subtype, _ := strings.Cut(mediaType, "/")
+	switch kind {
+	case "image":
+		return imageInfo(content, subtype, stripEXIF)
+	case "video", "audio":
+		return avInfo(content, subtype)
+	}
+	return nil
+}
+
+func imageInfo(content []byte, subtype string, stripEXIF bool) *mitmflowv1.MediaInfo {
+	info := &mitmflowv1.MediaInfo{}
+	info.SetFormat(strings.TrimSuffix(subtype, "+xml"))
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if err == nil {
+		info.SetFormat(format)
+		info.SetWidth(int32(cfg.Width))
+		info.SetHeight(int32(cfg.Height))
+	} else if width, height, ok := webpSize(content); ok {
+		info.SetFormat("webp")
+		info.SetWidth(int32(width))
+		info.SetHeight(int32(height))
+	}
+	if format == "jpeg" && !stripEXIF {
+		if exif := jpegEXIF(content); len(exif) > 0 {
+			info.SetExif(exif)
+		}
+	}
+	if err == nil && cfg.Width*cfg.Height <= maxThumbnailPixels {
+		if thumb, ok := thumbnail(content); ok {
+			info.SetThumbnail(thumb)
+		}
+	}
+	return info
+}
+
+// thumbnail decodes an image and scales it down to fit thumbnailSize,
+// averaging a few source pixels for each thumbnail pixel. Transparent images
+// are drawn over white, since the thumbnail is a JPEG.
+func thumbnail(content []byte) ([]byte, bool) {
+	src, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, false
+	}
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w == 0 || h == 0 {
+		return nil, false
+	}
+	tw, th := w, h
+	if longest := max(w, h); longest > thumbnailSize {
+		tw = max(1, w*thumbnailSize/longest)
+		th = max(1, h*thumbnailSize/longest)
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := range th {
+		y0, y1 := bounds.Min.Y+y*h/th, bounds.Min.Y+max((y+1)*h/th, y*h/th+1)
+		for x := range tw {
+			x0, x1 := bounds.Min.X+x*w/tw, bounds.Min.X+max((x+1)*w/tw, x*w/tw+1)
+			dst.SetRGBA(x, y, averageOverWhite(src, x0, y0, x1, y1))
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 75}); err != nil {
+		return nil, false
+	}
+	return buf.Bytes(), true
+}
+
+// averageOverWhite averages up to 4x4 pixels sampled evenly from the
+// rectangle x0,y0-x1,y1 of src, drawn over white.
+func averageOverWhite(src image.Image, x0, y0, x1, y1 int) color.RGBA {
+	stepX, stepY := max(1, (x1-x0)/4), max(1, (y1-y0)/4)
+	var r, g, b, n uint64
+	for y := y0; y < y1; y += stepY {
+		for x := x0; x < x1; x += stepX {
+			cr, cg, cb, ca := src.At(x, y).RGBA()
+			// The colors are premultiplied, so white shows through
+			// by whatever alpha leaves uncovered.
+			r += uint64(cr + 0xffff - ca)
+			g += uint64(cg + 0xffff - ca)
+			b += uint64(cb + 0xffff - ca)
+			n++
+		}
+	}
+	return color.RGBA{R: uint8(r / n >> 8), G: uint8(g / n >> 8), B: uint8(b / n >> 8), A: 0xff}
+}
+
+// webpSize reads the dimensions of a WebP image, which the standard library
+// can't decode, from its first chunk.
+func webpSize(data []byte) (int, int, bool) {
+	if len(data) < 30 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return 0, 0, false
+	}
+	chunk := data[20:]
+	switch string(data[12:16]) {
+	case "VP8 ":
+		// A 3 byte frame tag and 3 byte start code come first.
+		return int(binary.LittleEndian.Uint16(chunk[6:]) & 0x3fff), int(binary.LittleEndian.Uint16(chunk[8:]) & 0x3fff), true
+	case "VP8L":
+		if chunk[0] != 0x2f {
+			return 0, 0, false
+		}
+		bits := binary.LittleEndian.Uint32(chunk[1:])
+		return int(bits&0x3fff) + 1, int(bits>>14&0x3fff) + 1, true
+	case "VP8X":
+		width := int(chunk[4]) | int(chunk[5])<<8 | int(chunk[6])<<16
+		height := int(chunk[7]) | int(chunk[8])<<8 | int(chunk[9])<<16
+		return width + 1, height + 1, true
+	}
+	return 0, 0, false
+}
+
+// avInfo describes a video or audio body. Durations and dimensions are read
+// from MP4 and WAV headers; other formats only get a format.
+func avInfo(content []byte, subtype string) *mitmflowv1.MediaInfo {
+	info := &mitmflowv1.MediaInfo{}
+	info.SetFormat(strings.TrimPrefix(subtype, "x-"))
+	switch {
+	case len(content) >= 12 && string(content[4:8]) == "ftyp":
+		if strings.HasPrefix(string(content[8:12]), "qt") {
+			info.SetFormat("quicktime")
+		} else {
+			info.SetFormat("mp4")
+		}
+		mp4Info(content, info)
+	case len(content) >= 12 && string(content[0:4]) == "RIFF" && string(content[8:12]) == "WAVE":
+		info.SetFormat("wav")
+		wavInfo(content[12:], info)
+	}
+	return info
+}
+
+// mp4Boxes calls fn with the type and contents of each box in data. A box
+// that runs past the end of data, as in a truncated body, ends the walk.
+func mp4Boxes(data []byte, fn func(typ string, body []byte)) {
+	for len(data) >= 8 {
+		size, header := uint64(binary.BigEndian.Uint32(data)), uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return
+			}
+			size, header = binary.BigEndian.Uint64(data[8:]), 16
+		}
+		if size < header || size > uint64(len(data)) {
+			return
+		}
+		fn(string(data[4:8]), data[header:size])
+		data = data[size:]
+	}
+}
+
+// mp4Info reads the duration from the movie header and the dimensions of
+// the first track that has any from its track header.
+func mp4Info(data []byte, info *mitmflowv1.MediaInfo) {
+	mp4Boxes(data, func(typ string, moov []byte) {
+		if typ != "moov" {
+			return
+		}
+		mp4Boxes(moov, func(typ string, body []byte) {
+			switch typ {
+			case "mvhd":
+				var timescale, duration uint64
+				switch {
+				case len(body) >= 32 && body[0] == 1:
+					timescale, duration = uint64(binary.BigEndian.Uint32(body[20:])), binary.BigEndian.Uint64(body[24:])
+				case len(body) >= 20:
+					timescale, duration = uint64(binary.BigEndian.Uint32(body[12:])), uint64(binary.BigEndian.Uint32(body[16:]))
+				}
+				if timescale > 0 {
+					info.SetDurationMs(int64(duration * 1000 / timescale))
+				}
+			case "trak":
+				if info.GetWidth() > 0 {
+					return
+				}
+				mp4Boxes(body, func(typ string, tkhd []byte) {
+					if typ != "tkhd" || len(tkhd) < 84 {
+						return
+					}
+					// Width and height are 16.16 fixed point and end the box.
+					info.SetWidth(int32(binary.BigEndian.Uint32(tkhd[len(tkhd)-8:]) >> 16))
+					info.SetHeight(int32(binary.BigEndian.Uint32(tkhd[len(tkhd)-4:]) >> 16))
+				})
+			}
+		})
+	})
+}
+
+// wavInfo works out the duration of a WAV file from the byte rate in its
+// fmt chunk and the size of its data chunk.
+func wavInfo(chunks []byte, info *mitmflowv1.MediaInfo) {
+	var byteRate, dataSize uint64
+	for len(chunks) >= 8 {
+		id, size := string(chunks[0:4]), uint64(binary.LittleEndian.Uint32(chunks[4:]))
+		body := chunks[8:]
+		if id == "fmt " && len(body) >= 12 {
+			byteRate = uint64(binary.LittleEndian.Uint32(body[8:]))
+		}
+		if id == "data" {
+			// The data chunk's size is declared up front, so this works
+			// for truncated bodies too.
+			dataSize = size
+			break
+		}
+		size += size & 1
+		if size > uint64(len(body)) {
+			break
+		}
+		chunks = body[size:]
+	}
+	if byteRate > 0 && dataSize > 0 {
+		info.SetDurationMs(int64(dataSize * 1000 / byteRate))
+	}
+}
+
+// jpegEXIF reads selected EXIF tags from the APP1 segment of a JPEG.
+func jpegEXIF(data []byte) map[string]string {
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return nil
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xff {
+			return nil
+		}
+		marker := data[i+1]
+		if marker == 0xff {
+			i++
+			continue
+		}
+		if marker == 0xda || marker == 0xd9 {
+			// Image data starts; metadata always comes before it.
+			return nil
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return nil
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xe1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return parseEXIF(segment[6:])
+		}
+		i += 2 + length
+	}
+	return nil
+}
+
+var (
+	exifIFD0Tags = map[uint16]string{
+		0x010f: "Make",
+		0x0110: "Model",
+		0x0112: "Orientation",
+		0x0131: "Software",
+		0x0132: "DateTime",
+		0x013b: "Artist",
+		0x8298: "Copyright",
+	}
+	exifSubIFDTags = map[uint16]string{
+		0x829a: "ExposureTime",
+		0x829d: "FNumber",
+		0x8827: "ISOSpeedRatings",
+		0x9003: "DateTimeOriginal",
+		0x920a: "FocalLength",
+		0xa434: "LensModel",
+	}
+)
+
+const (
+	exifSubIFDPointer = 0x8769
+	exifGPSIFDPointer = 0x8825
+)
+
+// tiffEntry is one entry of a TIFF image file directory, with its value
+// already read from wherever it is stored.
+type tiffEntry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	value []byte
+}
+
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+// tiffTypeSizes is the size in bytes of one value of each TIFF type used by
+// the tags read here.
+var tiffTypeSizes = map[uint16]uint64{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 7: 1, 9: 4, 10: 8}
+
+// ifd reads the entries of the image file directory at offset. Entries of
+// unknown types or whose values fall outside data are skipped.
+func (r tiffReader) ifd(offset uint32) []tiffEntry {
+	if uint64(offset)+2 > uint64(len(r.data)) {
+		return nil
+	}
+	n := int(r.order.Uint16(r.data[offset:]))
+	var entries []tiffEntry
+	for i := range n {
+		start := uint64(offset) + 2 + uint64(i)*12
+		if start+12 > uint64(len(r.data)) {
+			break
+		}
+		raw := r.data[start : start+12]
+		e := tiffEntry{tag: r.order.Uint16(raw), typ: r.order.Uint16(raw[2:]), count: r.order.Uint32(raw[4:])}
+		typeSize, ok := tiffTypeSizes[e.typ]
+		if !ok {
+			continue
+		}
+		size := uint64(e.count) * typeSize
+		if size <= 4 {
+			e.value = raw[8 : 8+size]
+		} else {
+			at := uint64(r.order.Uint32(raw[8:]))
+			if at+size > uint64(len(r.data)) {
+				continue
+			}
+			e.value = r.data[at : at+size]
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// rational returns the i'th RATIONAL of a value.
+func (r tiffReader) rational(value []byte, i int) (float64, bool) {
+	if len(value) < (i+1)*8 {
+		return 0, false
+	}
+	num, den := r.order.Uint32(value[i*8:]), r.order.Uint32(value[i*8+4:])
+	if den == 0 {
+		return 0, false
+	}
+	return float64(num) / float64(den), true
+}
+
+// format renders the first value of an ASCII, SHORT, LONG or RATIONAL entry.
+func (r tiffReader) format(e tiffEntry) (string, bool) {
+	switch e.typ {
+	case 2:
+		s := strings.TrimSpace(strings.TrimRight(string(e.value), "\x00"))
+		return strings.ToValidUTF8(s, string(utf8.RuneError)), s != ""
+	case 3:
+		if len(e.value) >= 2 {
+			return strconv.Itoa(int(r.order.Uint16(e.value))), true
+		}
+	case 4:
+		if len(e.value) >= 4 {
+			return strconv.FormatUint(uint64(r.order.Uint32(e.value)), 10), true
+		}
+	case 5:
+		if v, ok := r.rational(e.value, 0); ok {
+			return strconv.FormatFloat(v, 'f', -1, 64), true
+		}
+	}
+	return "", false
+}
+
+// parseEXIF reads the tags in exifIFD0Tags and exifSubIFDTags, and the GPS
+// position as signed decimal degrees, from a TIFF structured EXIF block.
+func parseEXIF(data []byte) map[string]string {
+	if len(data) < 8 {
+		return nil
+	}
+	r := tiffReader{data: data}
+	switch string(data[0:2]) {
+	case "II":
+		r.order = binary.LittleEndian
+	case "MM":
+		r.order = binary.BigEndian
+	default:
+		return nil
+	}
+	tags := map[string]string{}
+	add := func(names map[uint16]string, entries []tiffEntry) {
+		for _, e := range entries {
+			if name, ok := names[e.tag]; ok {
+				if v, ok := r.format(e); ok {
+					tags[name] = v
+				}
+			}
+		}
+	}
+	ifd0 := r.ifd(r.order.Uint32(data[4:]))
+	add(exifIFD0Tags, ifd0)
+	for _, e := range ifd0 {
+		if len(e.value) < 4 || e.typ != 4 {
+			continue
+		}
+		switch e.tag {
+		case exifSubIFDPointer:
+			add(exifSubIFDTags, r.ifd(r.order.Uint32(e.value)))
+		case exifGPSIFDPointer:
+			r.addGPS(r.ifd(r.order.Uint32(e.value)), tags)
+		}
+	}
+	return tags
+}
+
+// addGPS adds GPSLatitude and GPSLongitude from the entries of a GPS IFD.
+func (r tiffReader) addGPS(entries []tiffEntry, tags map[string]string) {
+	byTag := map[uint16]tiffEntry{}
+	for _, e := range entries {
+		byTag[e.tag] = e
+	}
+	coordinate := func(refTag, valueTag uint16, negative string, name string) {
+		value, ok := byTag[valueTag]
+		if !ok || value.typ != 5 {
+			return
+		}
+		var degrees float64
+		for i, unit := range []float64{1, 60, 3600} {
+			v, ok := r.rational(value.value, i)
+			if !ok {
+				return
+			}
+			degrees += v / unit
+		}
+		if ref, ok := byTag[refTag]; ok && strings.HasPrefix(string(ref.value), negative) {
+			degrees = -degrees
+		}
+		tags[name] = strconv.FormatFloat(degrees, 'f', 6, 64)
+	}
+	coordinate(1, 2, "S", "GPSLatitude")
+	coordinate(3, 4, "W", "GPSLongitude")
+}
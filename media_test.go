@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMediaInfo_PNGThumbnail(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 200, 100))
+	for y := range 100 {
+		for x := range 200 {
+			img.SetNRGBA(x, y, color.NRGBA{R: 0xff, A: 0xff})
+		}
+	}
+	img.SetNRGBA(0, 0, color.NRGBA{})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	info := mediaInfo(buf.Bytes(), "image/png", false)
+	assert.Equal(t, "png", info.GetFormat())
+	assert.Equal(t, int32(200), info.GetWidth())
+	assert.Equal(t, int32(100), info.GetHeight())
+	assert.Empty(t, info.GetExif())
+
+	thumb, err := jpeg.Decode(bytes.NewReader(info.GetThumbnail()))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 128, 64), thumb.Bounds())
+	r, g, _, _ := thumb.At(64, 32).RGBA()
+	assert.Greater(t, r, uint32(0xe000))
+	assert.Less(t, g, uint32(0x2000))
+
+	assert.Nil(t, mediaInfo(buf.Bytes(), "text/plain", false))
+}
+
+// jpegWithEXIF returns a small JPEG carrying an EXIF block with a camera
+// make, an orientation and a GPS position of 52°22'45"N 4°53'58.2"W.
+func jpegWithEXIF(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 16, 8)), nil))
+
+	be := binary.BigEndian
+	var tiff []byte
+	u16 := func(v uint16) { tiff = be.AppendUint16(tiff, v) }
+	u32 := func(v uint32) { tiff = be.AppendUint32(tiff, v) }
+	entry := func(tag, typ uint16, count, value uint32) {
+		u16(tag)
+		u16(typ)
+		u32(count)
+		u32(value)
+	}
+
+	tiff = append(tiff, "MM"...)
+	u16(42)
+	u32(8)
+	// IFD0 at 8: 3 entries, then the next IFD offset, then Make's value.
+	u16(3)
+	entry(0x010f, 2, 6, 8+2+3*12+4)
+	entry(0x0112, 3, 1, 6<<16)
+	gps := uint32(8 + 2 + 3*12 + 4 + 6)
+	entry(exifGPSIFDPointer, 4, 1, gps)
+	u32(0)
+	tiff = append(tiff, "Canon\x00"...)
+	// The GPS IFD: 4 entries, then the rationals of both coordinates.
+	rationals := gps + 2 + 4*12 + 4
+	u16(4)
+	entry(1, 2, 2, uint32('N')<<24)
+	entry(2, 5, 3, rationals)
+	entry(3, 2, 2, uint32('W')<<24)
+	entry(4, 5, 3, rationals+24)
+	u32(0)
+	for _, v := range []uint32{52, 1, 22, 1, 45, 1, 4, 1, 53, 1, 582, 10} {
+		u32(v)
+	}
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := append([]byte{0xff, 0xe1}, be.AppendUint16(nil, uint16(len(segment)+2))...)
+	app1 = append(app1, segment...)
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), app1...), data[2:]...)
+}
+
+func TestMediaInfo_EXIF(t *testing.T) {
+	data := jpegWithEXIF(t)
+	info := mediaInfo(data, "image/jpeg", false)
+	assert.Equal(t, "jpeg", info.GetFormat())
+	assert.Equal(t, int32(16), info.GetWidth())
+	assert.Equal(t, map[string]string{
+		"Make":         "Canon",
+		"Orientation":  "6",
+		"GPSLatitude":  "52.379167",
+		"GPSLongitude": "-4.899500",
+	}, info.GetExif())
+	assert.NotEmpty(t, info.GetThumbnail())
+
+	info = mediaInfo(data, "image/jpeg", true)
+	assert.Empty(t, info.GetExif())
+	assert.Equal(t, int32(8), info.GetHeight())
+}
+
+func TestMediaInfo_WebP(t *testing.T) {
+	data := []byte("RIFF\x00\x00\x00\x00WEBPVP8X\x0a\x00\x00\x00")
+	data = append(data, 0, 0, 0, 0, 0x7f, 0x07, 0x00, 0x37, 0x04, 0x00)
+	data = append(data, make([]byte, 10)...)
+	info := mediaInfo(data, "image/webp", false)
+	assert.Equal(t, "webp", info.GetFormat())
+	assert.Equal(t, int32(1920), info.GetWidth())
+	assert.Equal(t, int32(1080), info.GetHeight())
+	assert.Empty(t, info.GetThumbnail())
+}
+
+func TestMediaInfo_MP4(t *testing.T) {
+	box := func(typ string, body ...[]byte) []byte {
+		b := binary.BigEndian.AppendUint32(nil, uint32(8+len(bytes.Join(body, nil))))
+		return append(append(b, typ...), bytes.Join(body, nil)...)
+	}
+	mvhd := make([]byte, 100)
+	binary.BigEndian.PutUint32(mvhd[12:], 1000)
+	binary.BigEndian.PutUint32(mvhd[16:], 2500)
+	tkhd := make([]byte, 84)
+	binary.BigEndian.PutUint32(tkhd[76:], 640<<16)
+	binary.BigEndian.PutUint32(tkhd[80:], 360<<16)
+	data := append(box("ftyp", []byte("isom\x00\x00\x02\x00")), box("moov", box("mvhd", mvhd), box("trak", box("tkhd", tkhd)))...)
+
+	info := mediaInfo(data, "video/mp4", false)
+	assert.Equal(t, "mp4", info.GetFormat())
+	assert.Equal(t, int64(2500), info.GetDurationMs())
+	assert.Equal(t, int32(640), info.GetWidth())
+	assert.Equal(t, int32(360), info.GetHeight())
+
+	// A body cut off before the moov box still gets a format.
+	info = mediaInfo(data[:30], "video/mp4", false)
+	assert.Equal(t, "mp4", info.GetFormat())
+	assert.Zero(t, info.GetDurationMs())
+}
+
+func TestMediaInfo_WAV(t *testing.T) {
+	le := binary.LittleEndian
+	data := []byte("RIFF\x00\x00\x00\x00WAVEfmt ")
+	data = le.AppendUint32(data, 16)
+	data = le.AppendUint16(data, 1)      // PCM
+	data = le.AppendUint16(data, 2)      // channels
+	data = le.AppendUint32(data, 44100)  // sample rate
+	data = le.AppendUint32(data, 176400) // byte rate
+	data = le.AppendUint16(data, 4)      // block align
+	data = le.AppendUint16(data, 16)     // bits per sample
+	data = append(data, "data"...)
+	data = le.AppendUint32(data, 352800)
+	// The samples themselves were truncated away.
+
+	info := mediaInfo(data, "audio/x-wav", false)
+	assert.Equal(t, "wav", info.GetFormat())
+	assert.Equal(t, int64(2000), info.GetDurationMs())
+}
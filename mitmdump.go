@@ -0,0 +1,444 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// parseTNetString reads one value from a tnetstring, the serialization
+// mitmproxy writes flow dumps in (mitmdump -w). Byte strings are returned as
+// []byte, text as string, and dictionaries as map[string]any.
+func parseTNetString(data []byte) (any, []byte, error) {
+	colon := -1
+	for i := 0; i < len(data) && i < 12; i++ {
+		if data[i] == ':' {
+			colon = i
+			break
+		}
+	}
+	if colon <= 0 {
+		return nil, nil, errors.New("tnetstring: missing length")
+	}
+	n, err := strconv.Atoi(string(data[:colon]))
+	if err != nil || n < 0 || colon+1+n >= len(data) {
+		return nil, nil, errors.New("tnetstring: invalid length")
+	}
+	payload, kind, rest := data[colon+1:colon+1+n], data[colon+1+n], data[colon+2+n:]
+	switch kind {
+	case ',':
+		return payload, rest, nil
+	case ';':
+		return string(payload), rest, nil
+	case '#':
+		v, err := strconv.ParseInt(string(payload), 10, 64)
+		return v, rest, err
+	case '^':
+		v, err := strconv.ParseFloat(string(payload), 64)
+		return v, rest, err
+	case '!':
+		return string(payload) == "true", rest, nil
+	case '~':
+		return nil, rest, nil
+	case ']':
+		list := []any{}
+		for len(payload) > 0 {
+			var v any
+			if v, payload, err = parseTNetString(payload); err != nil {
+				return nil, nil, err
+			}
+			list = append(list, v)
+		}
+		return list, rest, nil
+	case '}':
+		dict := map[string]any{}
+		for len(payload) > 0 {
+			var k, v any
+			if k, payload, err = parseTNetString(payload); err != nil {
+				return nil, nil, err
+			}
+			if v, payload, err = parseTNetString(payload); err != nil {
+				return nil, nil, err
+			}
+			dict[tnetText(k)] = v
+		}
+		return dict, rest, nil
+	}
+	return nil, nil, fmt.Errorf("tnetstring: unknown type %q", kind)
+}
+
+// isMitmproxyDump reports whether data looks like a mitmproxy flow dump: a
+// tnetstring dictionary.
+func isMitmproxyDump(data []byte) bool {
+	colon := strings.IndexByte(string(data[:min(len(data), 12)]), ':')
+	if colon <= 0 {
+		return false
+	}
+	n, err := strconv.Atoi(string(data[:colon]))
+	return err == nil && n >= 0 && colon+1+n < len(data) && data[colon+1+n] == '}'
+}
+
+// ParseMitmproxyDump converts the HTTP, TCP and UDP flows of a mitmproxy flow
+// dump. Flows of other types are skipped.
+func ParseMitmproxyDump(data []byte) ([]*mitmflowv1.Flow, error) {
+	var flows []*mitmflowv1.Flow
+	for len(data) > 0 {
+		v, rest, err := parseTNetString(data)
+		if err != nil {
+			return nil, err
+		}
+		data = rest
+		state, ok := v.(map[string]any)
+		if !ok {
+			return nil, errors.New("mitmproxy dump: flow is not a dictionary")
+		}
+		if flow := convertMitmproxyFlow(tnetDict(state)); flow != nil {
+			flows = append(flows, flow)
+		}
+	}
+	return flows, nil
+}
+
+// tnetDict wraps a flow state dictionary with typed accessors. Missing or
+// mistyped values read as their zero value, so older and newer dump versions
+// convert as far as their fields match.
+type tnetDict map[string]any
+
+func (d tnetDict) dict(key string) tnetDict {
+	v, _ := d[key].(map[string]any)
+	return v
+}
+
+func (d tnetDict) list(key string) []any {
+	v, _ := d[key].([]any)
+	return v
+}
+
+func (d tnetDict) text(key string) string {
+	return tnetText(d[key])
+}
+
+func (d tnetDict) bytes(key string) []byte {
+	switch v := d[key].(type) {
+	case []byte:
+		return v
+	case string:
+		return []byte(v)
+	}
+	return nil
+}
+
+func (d tnetDict) int(key string) int64 {
+	switch v := d[key].(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
+
+func (d tnetDict) bool(key string) bool {
+	v, _ := d[key].(bool)
+	return v
+}
+
+// timestamp converts a float of seconds since the epoch.
+func (d tnetDict) timestamp(key string) *timestamppb.Timestamp {
+	switch v := d[key].(type) {
+	case float64:
+		return floatTimestamp(v)
+	case int64:
+		return timestamppb.New(time.Unix(v, 0))
+	}
+	return nil
+}
+
+func floatTimestamp(seconds float64) *timestamppb.Timestamp {
+	sec, frac := math.Modf(seconds)
+	return timestamppb.New(time.Unix(int64(sec), int64(frac*1e9)))
+}
+
+func tnetText(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return ""
+}
+
+// address splits a (host, port) pair.
+func (d tnetDict) address(key string) (string, uint32, bool) {
+	pair := d.list(key)
+	if len(pair) < 2 {
+		return "", 0, false
+	}
+	port, _ := pair[1].(int64)
+	return tnetText(pair[0]), uint32(port), true
+}
+
+// headers flattens a list of (name, value) pairs into one value per name.
+func (d tnetDict) headers(key string) map[string]string {
+	pairs := d.headerPairs(key)
+	if len(pairs) == 0 {
+		return nil
+	}
+	headers := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		addHeader(headers, pair.name, pair.value)
+	}
+	return headers
+}
+
+// headerPairs returns a list of (name, value) pairs in order.
+func (d tnetDict) headerPairs(key string) []headerPair {
+	var pairs []headerPair
+	for _, field := range d.list(key) {
+		pair, _ := field.([]any)
+		if len(pair) < 2 {
+			continue
+		}
+		pairs = append(pairs, headerPair{name: tnetText(pair[0]), value: tnetText(pair[1])})
+	}
+	return pairs
+}
+
+// addHeader adds a header to a flattened header map. Repeated headers are
+// joined by commas, except Set-Cookie, which is joined by newlines as
+// splitSetCookie expects.
+func addHeader(headers map[string]string, name, value string) {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			sep := ", "
+			if strings.EqualFold(name, "set-cookie") {
+				sep = "\n"
+			}
+			headers[k] = v + sep + value
+			return
+		}
+	}
+	headers[name] = value
+}
+
+func convertMitmproxyFlow(state tnetDict) *mitmflowv1.Flow {
+	id := state.text("id")
+	client := convertMitmproxyClient(state.dict("client_conn"))
+	server := convertMitmproxyServer(state.dict("server_conn"))
+	var flowError *string
+	if msg := state.dict("error").text("msg"); msg != "" {
+		flowError = proto.String(msg)
+	}
+
+	var flow *mitmflowv1.Flow
+	switch state.text("type") {
+	case "http":
+		req, res := state.dict("request"), state.dict("response")
+		start := req.timestamp("timestamp_start")
+		httpFlow := mitmproxyv1.HTTPFlow_builder{
+			Id:             proto.String(id),
+			TimestampStart: start,
+			Client:         client,
+			Server:         server,
+			Error:          flowError,
+			Request: mitmproxyv1.Request_builder{
+				Method:         proto.String(req.text("method")),
+				Url:            proto.String(mitmproxyRequestURL(req)),
+				Headers:        req.headers("headers"),
+				Content:        req.bytes("content"),
+				HttpVersion:    proto.String(req.text("http_version")),
+				Trailers:       req.headers("trailers"),
+				TimestampStart: start,
+				TimestampEnd:   req.timestamp("timestamp_end"),
+			}.Build(),
+		}
+		end := req.timestamp("timestamp_end")
+		if res != nil {
+			httpFlow.Response = mitmproxyv1.Response_builder{
+				StatusCode:     proto.Int32(int32(res.int("status_code"))),
+				Reason:         proto.String(res.text("reason")),
+				Headers:        res.headers("headers"),
+				Content:        res.bytes("content"),
+				HttpVersion:    proto.String(res.text("http_version")),
+				Trailers:       res.headers("trailers"),
+				TimestampStart: res.timestamp("timestamp_start"),
+				TimestampEnd:   res.timestamp("timestamp_end"),
+			}.Build()
+			if t := res.timestamp("timestamp_end"); t != nil {
+				end = t
+			}
+		}
+		if start != nil && end != nil {
+			httpFlow.DurationMs = proto.Float64(float64(end.AsTime().Sub(start.AsTime())) / float64(time.Millisecond))
+		}
+		if ws := state.dict("websocket"); ws != nil {
+			httpFlow.IsWebsocket = proto.Bool(true)
+			for _, m := range ws.list("messages") {
+				// (type, from_client, content, timestamp)
+				fields, _ := m.([]any)
+				if len(fields) < 4 {
+					continue
+				}
+				fromClient, _ := fields[1].(bool)
+				content, _ := fields[2].([]byte)
+				ts, _ := fields[3].(float64)
+				httpFlow.WebsocketMessages = append(httpFlow.WebsocketMessages, mitmproxyv1.WebSocketMessage_builder{
+					Content:    content,
+					Timestamp:  floatTimestamp(ts),
+					FromClient: proto.Bool(fromClient),
+				}.Build())
+			}
+		}
+		flow = mitmflowv1.Flow_builder{HttpFlow: httpFlow.Build()}.Build()
+		if isHTTP2(req.text("http_version")) {
+			if details := recordedHTTP2Details(req.headerPairs("headers"), res.headerPairs("headers")); details != nil {
+				flow.SetHttpFlowExtra(mitmflowv1.HTTPFlowExtra_builder{Http2: details}.Build())
+			}
+		}
+	case "tcp":
+		tcpFlow := mitmproxyv1.TCPFlow_builder{
+			Id:             proto.String(id),
+			Client:         client,
+			Server:         server,
+			Error:          flowError,
+			TimestampStart: client.GetTimestampStart(),
+		}
+		for _, m := range state.list("messages") {
+			fromClient, content, ts, ok := mitmproxyStreamMessage(m)
+			if !ok {
+				continue
+			}
+			tcpFlow.Messages = append(tcpFlow.Messages, mitmproxyv1.TCPMessage_builder{
+				Content:    content,
+				Timestamp:  ts,
+				FromClient: proto.Bool(fromClient),
+			}.Build())
+		}
+		flow = mitmflowv1.Flow_builder{TcpFlow: tcpFlow.Build()}.Build()
+	case "udp":
+		udpFlow := mitmproxyv1.UDPFlow_builder{
+			Id:             proto.String(id),
+			Client:         client,
+			Server:         server,
+			Error:          flowError,
+			TimestampStart: client.GetTimestampStart(),
+		}
+		for _, m := range state.list("messages") {
+			fromClient, content, ts, ok := mitmproxyStreamMessage(m)
+			if !ok {
+				continue
+			}
+			udpFlow.Messages = append(udpFlow.Messages, mitmproxyv1.UDPMessage_builder{
+				Content:    content,
+				Timestamp:  ts,
+				FromClient: proto.Bool(fromClient),
+			}.Build())
+		}
+		flow = mitmflowv1.Flow_builder{UdpFlow: udpFlow.Build()}.Build()
+	default:
+		return nil
+	}
+	if comment := state.text("comment"); comment != "" {
+		flow.SetNote(comment)
+	}
+	return flow
+}
+
+// mitmproxyStreamMessage reads a (from_client, content, timestamp) TCP or UDP
+// message.
+func mitmproxyStreamMessage(m any) (bool, []byte, *timestamppb.Timestamp, bool) {
+	fields, _ := m.([]any)
+	if len(fields) < 3 {
+		return false, nil, nil, false
+	}
+	fromClient, _ := fields[0].(bool)
+	content, _ := fields[1].([]byte)
+	ts, _ := fields[2].(float64)
+	return fromClient, content, floatTimestamp(ts), true
+}
+
+// mitmproxyRequestURL builds the URL of a request like mitmproxy's
+// Request.url, leaving out default ports.
+func mitmproxyRequestURL(req tnetDict) string {
+	scheme, host, port := req.text("scheme"), req.text("host"), req.int("port")
+	hostPort := host
+	if (scheme == "http" && port == 80) || (scheme == "https" && port == 443) || port == 0 {
+		if strings.Contains(host, ":") {
+			hostPort = "[" + host + "]"
+		}
+	} else {
+		hostPort = net.JoinHostPort(host, strconv.FormatInt(port, 10))
+	}
+	return scheme + "://" + hostPort + req.text("path")
+}
+
+func convertMitmproxyClient(c tnetDict) *mitmproxyv1.ClientConn {
+	if c == nil {
+		return nil
+	}
+	b := mitmproxyv1.ClientConn_builder{
+		Id:                proto.String(c.text("id")),
+		Tls:               proto.Bool(c.text("tls_version") != "" || c.bool("tls_established")),
+		Alpn:              c.bytes("alpn"),
+		TimestampStart:    c.timestamp("timestamp_start"),
+		TimestampEnd:      c.timestamp("timestamp_end"),
+		TimestampTlsSetup: c.timestamp("timestamp_tls_setup"),
+	}
+	if host, port, ok := c.address("peername"); ok {
+		b.PeernameHost, b.PeernamePort = proto.String(host), proto.Uint32(port)
+	}
+	if host, port, ok := c.address("sockname"); ok {
+		b.SocknameHost, b.SocknamePort = proto.String(host), proto.Uint32(port)
+	}
+	if sni := c.text("sni"); sni != "" {
+		b.Sni = proto.String(sni)
+	}
+	if cipher := c.text("cipher"); cipher != "" {
+		b.Cipher = proto.String(cipher)
+	}
+	if mode := c.text("proxy_mode"); mode != "" {
+		b.ProxyMode = proto.String(mode)
+	}
+	return b.Build()
+}
+
+func convertMitmproxyServer(s tnetDict) *mitmproxyv1.ServerConn {
+	if s == nil {
+		return nil
+	}
+	b := mitmproxyv1.ServerConn_builder{
+		Id:                proto.String(s.text("id")),
+		Tls:               proto.Bool(s.text("tls_version") != "" || s.bool("tls_established")),
+		Alpn:              s.bytes("alpn"),
+		TimestampStart:    s.timestamp("timestamp_start"),
+		TimestampEnd:      s.timestamp("timestamp_end"),
+		TimestampTcpSetup: s.timestamp("timestamp_tcp_setup"),
+		TimestampTlsSetup: s.timestamp("timestamp_tls_setup"),
+	}
+	if host, port, ok := s.address("address"); ok {
+		b.AddressHost, b.AddressPort = proto.String(host), proto.Uint32(port)
+	}
+	if host, port, ok := s.address("peername"); ok {
+		b.PeernameHost, b.PeernamePort = proto.String(host), proto.Uint32(port)
+	}
+	if host, port, ok := s.address("sockname"); ok {
+		b.SocknameHost, b.SocknamePort = proto.String(host), proto.Uint32(port)
+	}
+	if sni := s.text("sni"); sni != "" {
+		b.Sni = proto.String(sni)
+	}
+	if cipher := s.text("cipher"); cipher != "" {
+		b.Cipher = proto.String(cipher)
+	}
+	return b.Build()
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxMQTTPackets bounds how many packets of a single message are rendered.
+const maxMQTTPackets = 100
+
+var mqttPacketTypes = [...]string{
+	1: "CONNECT", 2: "CONNACK", 3: "PUBLISH", 4: "PUBACK", 5: "PUBREC",
+	6: "PUBREL", 7: "PUBCOMP", 8: "SUBSCRIBE", 9: "SUBACK", 10: "UNSUBSCRIBE",
+	11: "UNSUBACK", 12: "PINGREQ", 13: "PINGRESP", 14: "DISCONNECT", 15: "AUTH",
+}
+
+var errShortMQTTPacket = errors.New("mqtt packet is cut short")
+
+// isMQTTWebSocket reports whether a WebSocket handshake negotiated MQTT as its
+// subprotocol, e.g. "mqtt" or the older "mqttv3.1".
+func isMQTTWebSocket(headers map[string]string) bool {
+	for proto := range strings.SplitSeq(getHeaderValue(headers, "Sec-WebSocket-Protocol"), ",") {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(proto)), "mqtt") {
+			return true
+		}
+	}
+	return false
+}
+
+// mqttDecoder renders MQTT control packets. It remembers the protocol level
+// from CONNECT, since MQTT 5 adds properties to most packets.
+type mqttDecoder struct {
+	level byte
+}
+
+// decode renders the complete packets at the start of data and returns the
+// bytes of a trailing packet that isn't complete yet.
+func (d *mqttDecoder) decode(data []byte, _ bool) ([]string, []byte, error) {
+	var packets []string
+	for len(data) > 0 {
+		typ := data[0] >> 4
+		if typ == 0 {
+			return nil, nil, errors.New("mqtt packet type 0 is reserved")
+		}
+		length, n, err := mqttVarint(data[1:])
+		if errors.Is(err, errShortMQTTPacket) {
+			return packets, data, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		end := 1 + n + length
+		if end > len(data) {
+			return packets, data, nil
+		}
+		if len(packets) < maxMQTTPackets {
+			packet, err := d.packet(typ, data[0]&0x0f, data[1+n:end])
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", mqttPacketTypes[typ], err)
+			}
+			packets = append(packets, packet)
+		} else if len(packets) == maxMQTTPackets {
+			packets = append(packets, "...")
+		}
+		data = data[end:]
+	}
+	return packets, nil, nil
+}
+
+func (d *mqttDecoder) packet(typ, flags byte, body []byte) (string, error) {
+	r := &mqttReader{wireReader{data: body}}
+	var b strings.Builder
+	switch typ {
+	case 1:
+		name := r.string()
+		level := r.u8()
+		connectFlags := r.u8()
+		keepAlive := r.u16()
+		if r.err != nil {
+			return "", r.err
+		}
+		if name != "MQTT" && name != "MQIsdp" {
+			return "", fmt.Errorf("unknown protocol name %q", name)
+		}
+		d.level = level
+		r.properties(d.level)
+		fmt.Fprintf(&b, "CONNECT %s\n", mqttVersion(level))
+		fmt.Fprintf(&b, "  client id: %s\n", r.string())
+		fmt.Fprintf(&b, "  clean session: %t\n", connectFlags&0x02 != 0)
+		fmt.Fprintf(&b, "  keep alive: %ds", keepAlive)
+		if connectFlags&0x04 != 0 {
+			r.properties(d.level)
+			topic := r.string()
+			payload := r.binary()
+			fmt.Fprintf(&b, "\n  will: %s (QoS %d", topic, connectFlags>>3&0x03)
+			if connectFlags&0x20 != 0 {
+				b.WriteString(", retain")
+			}
+			fmt.Fprintf(&b, ")\n%s", indentLines(streamPayload(payload), "    "))
+		}
+		if connectFlags&0x80 != 0 {
+			fmt.Fprintf(&b, "\n  username: %s", r.string())
+		}
+		if connectFlags&0x40 != 0 {
+			r.binary()
+			b.WriteString("\n  password: (redacted)")
+		}
+	case 2:
+		ackFlags := r.u8()
+		code := r.u8()
+		fmt.Fprintf(&b, "CONNACK code %d", code)
+		if ackFlags&0x01 != 0 {
+			b.WriteString(", session present")
+		}
+	case 3:
+		qos := flags >> 1 & 0x03
+		topic := r.string()
+		b.WriteString("PUBLISH " + topic + " (QoS " + fmt.Sprint(qos))
+		if qos > 0 {
+			fmt.Fprintf(&b, ", packet %d", r.u16())
+		}
+		if flags&0x01 != 0 {
+			b.WriteString(", retain")
+		}
+		if flags&0x08 != 0 {
+			b.WriteString(", dup")
+		}
+		b.WriteString(")")
+		r.properties(d.level)
+		if payload := r.rest(); len(payload) > 0 {
+			b.WriteString("\n" + streamPayload(payload))
+		}
+	case 8, 10:
+		fmt.Fprintf(&b, "%s packet %d", mqttPacketTypes[typ], r.u16())
+		r.properties(d.level)
+		for r.err == nil && r.len() > 0 {
+			filter := r.string()
+			if typ == 8 {
+				fmt.Fprintf(&b, "\n  %s (QoS %d)", filter, r.u8()&0x03)
+			} else {
+				b.WriteString("\n  " + filter)
+			}
+		}
+	case 9, 11:
+		fmt.Fprintf(&b, "%s packet %d", mqttPacketTypes[typ], r.u16())
+		r.properties(d.level)
+		if codes := r.rest(); len(codes) > 0 {
+			strs := make([]string, len(codes))
+			for i, c := range codes {
+				strs[i] = fmt.Sprint(c)
+			}
+			b.WriteString(", codes " + strings.Join(strs, " "))
+		}
+	case 4, 5, 6, 7:
+		fmt.Fprintf(&b, "%s packet %d", mqttPacketTypes[typ], r.u16())
+	default:
+		b.WriteString(mqttPacketTypes[typ])
+	}
+	if r.err != nil {
+		return "", r.err
+	}
+	return b.String(), nil
+}
+
+func mqttVersion(level byte) string {
+	switch level {
+	case 3:
+		return "3.1"
+	case 4:
+		return "3.1.1"
+	case 5:
+		return "5.0"
+	}
+	return fmt.Sprintf("level %d", level)
+}
+
+// mqttVarint reads a variable byte integer: up to four bytes of seven bits,
+// least significant first.
+func mqttVarint(data []byte) (int, int, error) {
+	v, shift := 0, 0
+	for i := range 4 {
+		if i >= len(data) {
+			return 0, 0, errShortMQTTPacket
+		}
+		v |= int(data[i]&0x7f) << shift
+		if data[i]&0x80 == 0 {
+			return v, i + 1, nil
+		}
+		shift += 7
+	}
+	return 0, 0, errors.New("mqtt variable byte integer is too long")
+}
+
+// mqttReader reads the fields of an MQTT packet body.
+type mqttReader struct {
+	wireReader
+}
+
+func (r *mqttReader) binary() []byte {
+	return r.take(int(r.u16()))
+}
+
+func (r *mqttReader) string() string {
+	b := r.binary()
+	if r.err == nil && !utf8.Valid(b) {
+		r.err = errors.New("mqtt string is not valid UTF-8")
+	}
+	return string(b)
+}
+
+// properties skips the properties of an MQTT 5 packet.
+func (r *mqttReader) properties(level byte) {
+	if level < 5 || r.err != nil {
+		return
+	}
+	n, m, err := mqttVarint(r.data)
+	if err != nil {
+		r.err = err
+		return
+	}
+	r.take(m)
+	r.take(n)
+}
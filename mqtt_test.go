@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func mqttString(s string) []byte {
+	return append([]byte{byte(len(s) >> 8), byte(len(s))}, s...)
+}
+
+func mqttPacket(header byte, body ...[]byte) []byte {
+	var b []byte
+	for _, part := range body {
+		b = append(b, part...)
+	}
+	return append([]byte{header, byte(len(b))}, b...)
+}
+
+func TestMQTTMessageDetails(t *testing.T) {
+	connect := mqttPacket(0x10, mqttString("MQTT"), []byte{4, 0x82, 0, 60}, mqttString("sensor-1"), mqttString("ann"))
+	subscribe := mqttPacket(0x82, []byte{0, 1}, mqttString("home/+/temp"), []byte{1})
+	publish := mqttPacket(0x33, mqttString("home/kitchen/temp"), []byte{0, 7}, []byte(`{"c":21.5}`))
+
+	messages := []*mitmproxyv1.TCPMessage{
+		mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(true), Content: connect}.Build(),
+		mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(false), Content: mqttPacket(0x20, []byte{0, 0})}.Build(),
+		// A SUBSCRIBE and the first half of a PUBLISH, then the rest of it.
+		mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(true), Content: append(subscribe, publish[:5]...)}.Build(),
+		mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(true), Content: publish[5:]}.Build(),
+		mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(true), Content: []byte{0x00, 0xff, 0xfe}}.Build(),
+	}
+	details := decodeStreamMessages(messages, "application/mqtt", &mqttDecoder{})
+	require.Len(t, details, 5)
+
+	assert.Equal(t, []string{"CONNECT 3.1.1\n  client id: sensor-1\n  clean session: true\n  keep alive: 60s\n  username: ann"}, details[0].GetTextualFrames())
+	assert.Equal(t, "application/mqtt", details[0].GetEffectiveContentType())
+	assert.Equal(t, []string{"CONNACK code 0"}, details[1].GetTextualFrames())
+	assert.Equal(t, []string{"SUBSCRIBE packet 1\n  home/+/temp (QoS 1)"}, details[2].GetTextualFrames())
+	assert.Equal(t, []string{"PUBLISH home/kitchen/temp (QoS 1, packet 7, retain)\n{\n  \"c\": 21.5\n}"}, details[3].GetTextualFrames())
+	require.Len(t, details[4].GetTextualFrames(), 1)
+	assert.Contains(t, details[4].GetTextualFrames()[0], "00 ff fe")
+}
+
+func TestMQTTv5Publish(t *testing.T) {
+	dec := mqttDecoder{}
+	connect := mqttPacket(0x10, mqttString("MQTT"), []byte{5, 0x02, 0, 30, 0}, mqttString("c"))
+	publish := mqttPacket(0x30, mqttString("t"), []byte{2, 0x01, 0x01}, []byte("on"))
+	packets, rest, err := dec.decode(append(connect, publish...), true)
+	require.NoError(t, err)
+	assert.Empty(t, rest)
+	assert.Equal(t, []string{
+		"CONNECT 5.0\n  client id: c\n  clean session: true\n  keep alive: 30s",
+		"PUBLISH t (QoS 0)\non",
+	}, packets)
+}
+
+func TestIsMQTT(t *testing.T) {
+	assert.True(t, isMQTTWebSocket(map[string]string{"sec-websocket-protocol": "mqttv3.1, mqtt"}))
+	assert.False(t, isMQTTWebSocket(map[string]string{"Sec-WebSocket-Protocol": "graphql-ws"}))
+}
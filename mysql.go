@@ -0,0 +1,608 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// MySQL capability flags the decoder needs to know about.
+const (
+	mysqlClientConnectWithDB      = 0x00000008
+	mysqlClientSSL                = 0x00000800
+	mysqlClientSecureConnection   = 0x00008000
+	mysqlClientPluginAuth         = 0x00080000
+	mysqlClientPluginAuthLenenc   = 0x00200000
+	mysqlClientDeprecateEOF       = 0x01000000
+	mysqlClientQueryAttributes    = 0x08000000
+	mysqlColumnFlagUnsigned       = 0x0020
+	mysqlHandshakeProtocolVersion = 10
+	// maxMySQLPacket is the largest payload a single packet can carry.
+	maxMySQLPacket = 1<<24 - 1
+)
+
+// MySQL commands the decoder renders.
+const (
+	mysqlComQuit        = 0x01
+	mysqlComInitDB      = 0x02
+	mysqlComQuery       = 0x03
+	mysqlComFieldList   = 0x04
+	mysqlComStatistics  = 0x09
+	mysqlComPing        = 0x0e
+	mysqlComChangeUser  = 0x11
+	mysqlComStmtPrepare = 0x16
+	mysqlComStmtExecute = 0x17
+	mysqlComStmtSend    = 0x18
+	mysqlComStmtClose   = 0x19
+	mysqlComStmtReset   = 0x1a
+	mysqlComSetOption   = 0x1b
+	mysqlComResetConn   = 0x1f
+)
+
+// isMySQLHandshake recognizes the initial handshake packet a MySQL server
+// greets its clients with.
+func isMySQLHandshake(first []byte) bool {
+	if len(first) < 5 {
+		return false
+	}
+	length := int(first[0]) | int(first[1])<<8 | int(first[2])<<16
+	return first[3] == 0 && first[4] == mysqlHandshakeProtocolVersion && length > 40 && length < 1024
+}
+
+// mysqlReader reads the little-endian fields of a MySQL packet.
+type mysqlReader struct {
+	wireReader
+}
+
+func (r *mysqlReader) le16() uint16 {
+	if b := r.take(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *mysqlReader) le32() uint32 {
+	if b := r.take(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *mysqlReader) le64() uint64 {
+	if b := r.take(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+// lenenc reads a length-encoded integer. null is set for the 0xfb that
+// stands for NULL in text rows.
+func (r *mysqlReader) lenenc() (v uint64, null bool) {
+	switch b := r.u8(); b {
+	case 0xfb:
+		return 0, true
+	case 0xfc:
+		return uint64(r.le16()), false
+	case 0xfd:
+		if b := r.take(3); b != nil {
+			return uint64(b[0]) | uint64(b[1])<<8 | uint64(b[2])<<16, false
+		}
+		return 0, false
+	case 0xfe:
+		return r.le64(), false
+	default:
+		return uint64(b), false
+	}
+}
+
+// lenencBytes reads a length-encoded string.
+func (r *mysqlReader) lenencBytes() (v []byte, null bool) {
+	n, null := r.lenenc()
+	if null {
+		return nil, true
+	}
+	if n > uint64(r.len()) {
+		r.err = errFieldPastEnd
+		return nil, false
+	}
+	return r.take(int(n)), false
+}
+
+type mysqlColumn struct {
+	typ      byte
+	unsigned bool
+}
+
+type mysqlStatement struct {
+	params int
+	// types of the parameters, as last sent with an execute.
+	types []uint16
+}
+
+// mysqlResult is a result set being decoded.
+type mysqlResult struct {
+	dbResultSet
+	types []mysqlColumn
+	// columnsLeft counts the column definitions still to come.
+	columnsLeft int
+	// inRows is set once the column definitions ended.
+	inRows bool
+	// binary is set for the rows of a prepared statement.
+	binary bool
+}
+
+// mysqlDecoder renders the packets of the MySQL client/server protocol. The
+// server's responses only make sense knowing the command they answer, so it
+// follows the conversation across both directions.
+type mysqlDecoder struct {
+	redact bool
+	// caps are the capabilities the client asked for.
+	caps          uint32
+	loggedIn      bool
+	authenticated bool
+	command       byte
+	result        *mysqlResult
+	statements    map[uint32]*mysqlStatement
+	// skipDefinitions counts the parameter and column definitions, and their
+	// EOF packets, still to come after a statement was prepared.
+	skipDefinitions int
+}
+
+func (d *mysqlDecoder) decode(data []byte, fromClient bool) ([]string, []byte, error) {
+	var frames []string
+	for len(data) > 0 {
+		if len(data) < 4 {
+			return frames, data, nil
+		}
+		length := int(data[0]) | int(data[1])<<8 | int(data[2])<<16
+		if 4+length > len(data) {
+			return frames, data, nil
+		}
+		seq := data[3]
+		r := &mysqlReader{wireReader{data: data[4 : 4+length]}}
+		var frame string
+		var err error
+		if fromClient {
+			frame, err = d.clientPacket(r, seq)
+		} else {
+			frame, err = d.serverPacket(r)
+		}
+		if err == nil {
+			err = r.err
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("mysql packet: %w", err)
+		}
+		if frame != "" {
+			frames = append(frames, frame)
+		}
+		data = data[4+length:]
+	}
+	return frames, nil, nil
+}
+
+func (d *mysqlDecoder) deprecateEOF() bool {
+	return d.caps&mysqlClientDeprecateEOF != 0
+}
+
+func (d *mysqlDecoder) clientPacket(r *mysqlReader, seq byte) (string, error) {
+	switch {
+	case !d.loggedIn:
+		return d.login(r)
+	case !d.authenticated:
+		return "auth data: (redacted)", nil
+	case seq != 0:
+		return "data: " + countOf(r.len(), "byte"), nil
+	}
+	d.command = r.u8()
+	d.result = nil
+	switch d.command {
+	case mysqlComQuit:
+		return "quit", nil
+	case mysqlComInitDB:
+		return "use " + string(r.rest()), nil
+	case mysqlComQuery:
+		attrs := d.queryAttributes(r)
+		return "query: " + dbQuery(string(r.rest()), d.redact) + attrs, nil
+	case mysqlComFieldList:
+		return "field list " + r.cstring(), nil
+	case mysqlComStatistics:
+		return "statistics", nil
+	case mysqlComPing:
+		return "ping", nil
+	case mysqlComChangeUser:
+		return "change user " + r.cstring(), nil
+	case mysqlComStmtPrepare:
+		return "prepare: " + dbQuery(string(r.rest()), d.redact), nil
+	case mysqlComStmtExecute:
+		return d.execute(r)
+	case mysqlComStmtSend:
+		id := r.le32()
+		return fmt.Sprintf("send long data to statement %d parameter %d", id, r.le16()), nil
+	case mysqlComStmtClose:
+		return fmt.Sprintf("close statement %d", r.le32()), nil
+	case mysqlComStmtReset:
+		return fmt.Sprintf("reset statement %d", r.le32()), nil
+	case mysqlComSetOption:
+		return fmt.Sprintf("set option %d", r.le16()), nil
+	case mysqlComResetConn:
+		return "reset connection", nil
+	}
+	return fmt.Sprintf("command 0x%02x", d.command), nil
+}
+
+// login renders the handshake response a client logs in with, or the
+// shorter request to switch to TLS first.
+func (d *mysqlDecoder) login(r *mysqlReader) (string, error) {
+	d.caps = r.le32()
+	r.le32() // max packet size
+	r.u8()   // character set
+	r.take(23)
+	if r.err == nil && r.len() == 0 && d.caps&mysqlClientSSL != 0 {
+		return "SSL request", nil
+	}
+	d.loggedIn = true
+	user := r.cstring()
+	switch {
+	case d.caps&mysqlClientPluginAuthLenenc != 0:
+		r.lenencBytes()
+	case d.caps&mysqlClientSecureConnection != 0:
+		r.take(int(r.u8()))
+	default:
+		r.cstring()
+	}
+	frame := "login as " + user
+	if d.caps&mysqlClientConnectWithDB != 0 && r.len() > 0 {
+		if db := r.cstring(); db != "" {
+			frame += " to " + db
+		}
+	}
+	if d.caps&mysqlClientPluginAuth != 0 && r.len() > 0 {
+		// Some clients leave the NUL off a plugin name that ends the packet.
+		if bytes.IndexByte(r.data, 0) < 0 {
+			frame += " with " + string(r.rest())
+		} else {
+			frame += " with " + r.cstring()
+		}
+	}
+	return frame, nil
+}
+
+// queryAttributes renders the attributes sent along with a query, when the
+// client negotiated them.
+func (d *mysqlDecoder) queryAttributes(r *mysqlReader) string {
+	if d.caps&mysqlClientQueryAttributes == 0 {
+		return ""
+	}
+	count, _ := r.lenenc()
+	r.lenenc() // parameter sets, always 1
+	if count == 0 {
+		return ""
+	}
+	if count > uint64(r.len()) {
+		r.err = errFieldPastEnd
+		return ""
+	}
+	nulls := r.take((int(count) + 7) / 8)
+	r.u8() // new parameters bound, always 1
+	types := make([]uint16, count)
+	names := make([]string, count)
+	for i := range types {
+		types[i] = r.le16()
+		name, _ := r.lenencBytes()
+		names[i] = string(name)
+	}
+	values := d.binaryValues(r, types, nulls, 0)
+	attrs := make([]string, len(values))
+	for i, v := range values {
+		attrs[i] = names[i] + " = " + v
+	}
+	return "\n  attributes: " + strings.Join(attrs, ", ")
+}
+
+// execute renders the parameters a prepared statement is executed with.
+func (d *mysqlDecoder) execute(r *mysqlReader) (string, error) {
+	id := r.le32()
+	r.u8()   // cursor flags
+	r.le32() // iterations, always 1
+	frame := fmt.Sprintf("execute statement %d", id)
+	stmt := d.statements[id]
+	if stmt == nil || stmt.params == 0 {
+		return frame, nil
+	}
+	nulls := r.take((stmt.params + 7) / 8)
+	if r.u8() == 1 {
+		stmt.types = make([]uint16, stmt.params)
+		for i := range stmt.types {
+			stmt.types[i] = r.le16()
+		}
+	}
+	if len(stmt.types) != stmt.params {
+		return frame, nil
+	}
+	params := d.binaryValues(r, stmt.types, nulls, 0)
+	for i, v := range params {
+		params[i] = fmt.Sprintf("?%d = %s", i+1, v)
+	}
+	return frame + ": " + strings.Join(params, ", "), nil
+}
+
+// binaryValues reads values in the binary protocol of prepared statements.
+// The bits of nulls, from offset on, mark the values that are NULL. The high
+// bit of a type marks it unsigned.
+func (d *mysqlDecoder) binaryValues(r *mysqlReader, types []uint16, nulls []byte, offset int) []string {
+	values := make([]string, len(types))
+	for i, t := range types {
+		bit := i + offset
+		if bit/8 < len(nulls) && nulls[bit/8]&(1<<(bit%8)) != 0 {
+			values[i] = "NULL"
+			continue
+		}
+		values[i] = d.binaryValue(r, byte(t), t&0x8000 != 0)
+	}
+	return values
+}
+
+func (d *mysqlDecoder) binaryValue(r *mysqlReader, typ byte, unsigned bool) string {
+	var v string
+	switch typ {
+	case 1: // TINY
+		b := r.u8()
+		v = fmt.Sprint(int8(b))
+		if unsigned {
+			v = fmt.Sprint(b)
+		}
+	case 2, 13: // SHORT, YEAR
+		n := r.le16()
+		v = fmt.Sprint(int16(n))
+		if unsigned || typ == 13 {
+			v = fmt.Sprint(n)
+		}
+	case 3, 9: // LONG, INT24
+		n := r.le32()
+		v = fmt.Sprint(int32(n))
+		if unsigned {
+			v = fmt.Sprint(n)
+		}
+	case 8: // LONGLONG
+		n := r.le64()
+		v = fmt.Sprint(int64(n))
+		if unsigned {
+			v = fmt.Sprint(n)
+		}
+	case 4: // FLOAT
+		v = fmt.Sprint(math.Float32frombits(r.le32()))
+	case 5: // DOUBLE
+		v = fmt.Sprint(math.Float64frombits(r.le64()))
+	case 6: // NULL
+		return "NULL"
+	case 7, 10, 12: // TIMESTAMP, DATE, DATETIME
+		v = mysqlDateTime(r.take(int(r.u8())))
+	case 11: // TIME
+		v = mysqlTime(r.take(int(r.u8())))
+	default:
+		b, _ := r.lenencBytes()
+		return dbValue(b, false, d.redact)
+	}
+	if d.redact {
+		return "?"
+	}
+	return v
+}
+
+func mysqlDateTime(b []byte) string {
+	if len(b) < 4 {
+		return "0000-00-00"
+	}
+	s := fmt.Sprintf("%04d-%02d-%02d", binary.LittleEndian.Uint16(b), b[2], b[3])
+	if len(b) >= 7 {
+		s += fmt.Sprintf(" %02d:%02d:%02d", b[4], b[5], b[6])
+	}
+	if len(b) >= 11 {
+		s += fmt.Sprintf(".%06d", binary.LittleEndian.Uint32(b[7:]))
+	}
+	return s
+}
+
+func mysqlTime(b []byte) string {
+	if len(b) < 8 {
+		return "00:00:00"
+	}
+	sign := ""
+	if b[0] == 1 {
+		sign = "-"
+	}
+	hours := binary.LittleEndian.Uint32(b[1:])*24 + uint32(b[5])
+	s := fmt.Sprintf("%s%02d:%02d:%02d", sign, hours, b[6], b[7])
+	if len(b) >= 12 {
+		s += fmt.Sprintf(".%06d", binary.LittleEndian.Uint32(b[8:]))
+	}
+	return s
+}
+
+func (d *mysqlDecoder) serverPacket(r *mysqlReader) (string, error) {
+	if r.len() == 0 {
+		return "", errors.New("empty packet")
+	}
+	header := r.data[0]
+	switch {
+	case !d.loggedIn:
+		if header == 0xff {
+			return d.errPacket(r), nil
+		}
+		return d.handshake(r)
+	case !d.authenticated:
+		switch header {
+		case 0x00:
+			d.authenticated = true
+			return "authenticated", nil
+		case 0xff:
+			return d.errPacket(r), nil
+		case 0xfe:
+			r.u8()
+			return "auth switch to " + r.cstring(), nil
+		}
+		return "auth data", nil
+	case d.skipDefinitions > 0:
+		d.skipDefinitions--
+		return "", nil
+	case d.result != nil:
+		return d.resultPacket(r)
+	}
+
+	switch {
+	case header == 0x00 && d.command == mysqlComStmtPrepare:
+		return d.prepared(r), nil
+	case header == 0x00:
+		return d.okPacket(r), nil
+	case header == 0xff:
+		return d.errPacket(r), nil
+	case header == 0xfe && r.len() < 9:
+		return "", nil
+	case header == 0xfb:
+		r.u8()
+		return "local file requested: " + string(r.rest()), nil
+	case d.command == mysqlComStatistics:
+		return string(r.rest()), nil
+	case d.command == mysqlComFieldList:
+		col, _ := d.column(r)
+		return "column " + col, nil
+	}
+	count, _ := r.lenenc()
+	if count == 0 || count > 4096 {
+		return "", fmt.Errorf("result set of %d columns", count)
+	}
+	d.result = &mysqlResult{columnsLeft: int(count), binary: d.command == mysqlComStmtExecute}
+	return "", nil
+}
+
+// handshake renders the packet the server greets a client with.
+func (d *mysqlDecoder) handshake(r *mysqlReader) (string, error) {
+	if version := r.u8(); version != mysqlHandshakeProtocolVersion {
+		return "", fmt.Errorf("unknown handshake protocol version %d", version)
+	}
+	server := r.cstring()
+	return fmt.Sprintf("server %s, connection %d", server, r.le32()), nil
+}
+
+func (d *mysqlDecoder) okPacket(r *mysqlReader) string {
+	r.u8()
+	affected, _ := r.lenenc()
+	insertID, _ := r.lenenc()
+	frame := "OK: " + countOf(int(affected), "affected row")
+	if insertID != 0 {
+		frame += fmt.Sprintf(", last insert id %d", insertID)
+	}
+	return frame
+}
+
+func (d *mysqlDecoder) errPacket(r *mysqlReader) string {
+	r.u8()
+	code := r.le16()
+	state := ""
+	if r.len() > 0 && r.data[0] == '#' {
+		if b := r.take(6); b != nil {
+			state = " (" + string(b[1:]) + ")"
+		}
+	}
+	return fmt.Sprintf("error %d%s: %s", code, state, r.rest())
+}
+
+// prepared renders the response to a prepare and remembers the statement's
+// parameters for its executes.
+func (d *mysqlDecoder) prepared(r *mysqlReader) string {
+	r.u8()
+	id := r.le32()
+	columns := int(r.le16())
+	params := int(r.le16())
+	if d.statements == nil {
+		d.statements = make(map[uint32]*mysqlStatement)
+	}
+	d.statements[id] = &mysqlStatement{params: params}
+	d.skipDefinitions = params + columns
+	if !d.deprecateEOF() {
+		if params > 0 {
+			d.skipDefinitions++
+		}
+		if columns > 0 {
+			d.skipDefinitions++
+		}
+	}
+	return fmt.Sprintf("prepared statement %d: %s, %s", id, countOf(params, "parameter"), countOf(columns, "column"))
+}
+
+// column reads a column definition.
+func (d *mysqlDecoder) column(r *mysqlReader) (string, mysqlColumn) {
+	for range 4 { // catalog, schema, table and original table
+		r.lenencBytes()
+	}
+	name, _ := r.lenencBytes()
+	r.lenencBytes() // original name
+	r.lenenc()      // length of the fixed fields
+	r.le16()        // character set
+	r.le32()        // column length
+	typ := r.u8()
+	flags := r.le16()
+	return string(name), mysqlColumn{typ: typ, unsigned: flags&mysqlColumnFlagUnsigned != 0}
+}
+
+func (d *mysqlDecoder) resultPacket(r *mysqlReader) (string, error) {
+	res := d.result
+	header := r.data[0]
+	if res.columnsLeft > 0 {
+		name, col := d.column(r)
+		r.rest() // default values of a field list
+		res.columns = append(res.columns, name)
+		res.types = append(res.types, col)
+		res.columnsLeft--
+		if res.columnsLeft == 0 && d.deprecateEOF() {
+			res.inRows = true
+			return res.header(), nil
+		}
+		return "", nil
+	}
+	if !res.inRows {
+		if header != 0xfe {
+			return "", errors.New("column definitions aren't followed by EOF")
+		}
+		r.rest()
+		res.inRows = true
+		return res.header(), nil
+	}
+	switch {
+	case header == 0xff:
+		d.result = nil
+		return d.errPacket(r), nil
+	case header == 0xfe && (r.len() < 9 || d.deprecateEOF() && r.len() < maxMySQLPacket):
+		r.rest()
+		d.result = nil
+		frame := res.done()
+		if res.rows > maxDBRows {
+			frame = fmt.Sprintf("... %d more rows\n%s", res.rows-maxDBRows, frame)
+		}
+		return frame, nil
+	}
+	var values []string
+	if res.binary {
+		r.u8()
+		nulls := r.take((len(res.types) + 9) / 8)
+		types := make([]uint16, len(res.types))
+		for i, col := range res.types {
+			types[i] = uint16(col.typ)
+			if col.unsigned {
+				types[i] |= 0x8000
+			}
+		}
+		values = d.binaryValues(r, types, nulls, 2)
+	} else {
+		for range res.types {
+			v, null := r.lenencBytes()
+			values = append(values, dbValue(v, null, d.redact))
+		}
+	}
+	frame, _ := res.row(values)
+	return frame, nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func mysqlPacket(seq byte, body ...[]byte) []byte {
+	var b []byte
+	for _, part := range body {
+		b = append(b, part...)
+	}
+	return append([]byte{byte(len(b)), byte(len(b) >> 8), byte(len(b) >> 16), seq}, b...)
+}
+
+func mysqlLenenc(s string) []byte {
+	return append([]byte{byte(len(s))}, s...)
+}
+
+func mysqlColumnDef(name string, typ byte) []byte {
+	var b []byte
+	for _, s := range []string{"def", "shop", "users", "users", name, name} {
+		b = append(b, mysqlLenenc(s)...)
+	}
+	return append(b, 0x0c, 0x21, 0, 0, 0, 0, 0, typ, 0, 0, 0, 0, 0)
+}
+
+func TestMySQLMessageDetails(t *testing.T) {
+	handshake := mysqlPacket(0, []byte{10}, []byte("8.0.36\x00"), []byte{12, 0, 0, 0}, make([]byte, 40))
+	caps := binary.LittleEndian.AppendUint32(nil, mysqlClientConnectWithDB|mysqlClientSecureConnection|mysqlClientPluginAuth|mysqlClientDeprecateEOF)
+	login := mysqlPacket(1, caps, make([]byte, 28), []byte("ann\x00"), []byte{2, 0xaa, 0xbb}, []byte("shop\x00caching_sha2_password\x00"))
+
+	query := mysqlPacket(0, []byte{mysqlComQuery}, []byte("SELECT id, name FROM users WHERE id > 5"))
+	var results []byte
+	results = append(results, mysqlPacket(1, []byte{2})...)
+	results = append(results, mysqlPacket(2, mysqlColumnDef("id", 3))...)
+	results = append(results, mysqlPacket(3, mysqlColumnDef("name", 253))...)
+	results = append(results, mysqlPacket(4, mysqlLenenc("6"), mysqlLenenc("bob"))...)
+	results = append(results, mysqlPacket(5, mysqlLenenc("7"), []byte{0xfb})...)
+	results = append(results, mysqlPacket(6, []byte{0xfe, 0, 0, 0x22, 0, 0, 0})...)
+
+	prepare := mysqlPacket(0, []byte{mysqlComStmtPrepare}, []byte("UPDATE users SET name = ? WHERE id = ?"))
+	prepared := mysqlPacket(1, []byte{0, 1, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0})
+	paramDefs := append(mysqlPacket(2, mysqlColumnDef("?", 253)), mysqlPacket(3, mysqlColumnDef("?", 8))...)
+	execute := mysqlPacket(0, []byte{mysqlComStmtExecute, 1, 0, 0, 0, 0, 1, 0, 0, 0},
+		[]byte{0x00, 1, 253, 0, 8, 0}, mysqlLenenc("eve"), binary.LittleEndian.AppendUint64(nil, 7))
+	ok := mysqlPacket(1, []byte{0, 1, 0, 2, 0, 0, 0})
+
+	message := func(fromClient bool, content ...[]byte) *mitmproxyv1.TCPMessage {
+		var b []byte
+		for _, c := range content {
+			b = append(b, c...)
+		}
+		return mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(fromClient), Content: b}.Build()
+	}
+	flow := mitmproxyv1.TCPFlow_builder{
+		Server: mitmproxyv1.ServerConn_builder{AddressPort: proto.Uint32(13306)}.Build(),
+		Messages: []*mitmproxyv1.TCPMessage{
+			message(false, handshake),
+			message(true, login),
+			message(false, mysqlPacket(2, []byte{0, 0, 0, 2, 0, 0, 0})),
+			message(true, query),
+			message(false, results),
+			message(true, prepare),
+			message(false, prepared, paramDefs),
+			message(true, execute),
+			message(false, ok),
+		},
+	}.Build()
+
+	details := tcpMessageDetails(flow, false).GetMessages()
+	require.Len(t, details, 9)
+	frames := make([]string, len(details))
+	for i, d := range details {
+		require.Len(t, d.GetTextualFrames(), 1, "message %d", i)
+		frames[i] = d.GetTextualFrames()[0]
+	}
+	assert.Equal(t, []string{
+		"server 8.0.36, connection 12",
+		"login as ann to shop with caching_sha2_password",
+		"authenticated",
+		"query: SELECT id, name FROM users WHERE id > 5",
+		"2 columns: id, name\n\nrow 1: \"6\", \"bob\"\n\nrow 2: \"7\", NULL\n\n2 rows",
+		"prepare: UPDATE users SET name = ? WHERE id = ?",
+		"prepared statement 1: 2 parameters, 0 columns",
+		"execute statement 1: ?1 = \"eve\", ?2 = 7",
+		"OK: 1 affected row",
+	}, frames)
+
+	redacted := tcpMessageDetails(flow, true).GetMessages()
+	assert.Equal(t, "query: SELECT id, name FROM users WHERE id > ?", redacted[3].GetTextualFrames()[0])
+	assert.Equal(t, "execute statement 1: ?1 = ?, ?2 = ?", redacted[7].GetTextualFrames()[0])
+}
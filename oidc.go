@@ -0,0 +1,453 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	oidcLoginPath    = "/auth/login"
+	oidcCallbackPath = "/auth/callback"
+	oidcLogoutPath   = "/auth/logout"
+
+	sessionCookieName = "mitmflow_session"
+	loginCookieName   = "mitmflow_login"
+	loginTimeout      = 10 * time.Minute
+
+	// sessionKeyFile holds the key session cookies are signed with, so
+	// users stay signed in across restarts.
+	sessionKeyFile = "session.key"
+)
+
+// oidcAuth signs users in to the UI with an OpenID Connect provider using the
+// authorization code flow with PKCE, and keeps them signed in with a signed
+// session cookie. Their role comes from the groups in their ID token.
+//
+// The ID token is fetched from the provider's token endpoint over TLS with the
+// client secret, so, as the OIDC spec allows for this flow, its signature
+// isn't checked; its issuer, audience, expiry and nonce are.
+type oidcAuth struct {
+	issuer        string
+	clientID      string
+	clientSecret  string
+	scopes        []string
+	authEndpoint  string
+	tokenEndpoint string
+	groupsClaim   string
+	groupRoles    map[string]role
+	// defaultRole is given to users in none of groupRoles' groups. Zero
+	// turns them away.
+	defaultRole role
+	sessionTTL  time.Duration
+	// key signs the session and login cookies.
+	key    []byte
+	ui     uiConfig
+	client *http.Client
+}
+
+// newOIDCAuthFromFlags configures OIDC from the -oidc-* flags, discovering the
+// provider's endpoints.
+func newOIDCAuthFromFlags(ctx context.Context, ui uiConfig, keyFile string) (*oidcAuth, error) {
+	if *oidcClientID == "" {
+		return nil, errors.New("-oidc-client-id is required")
+	}
+	var secret string
+	if *oidcSecretFile != "" {
+		data, err := os.ReadFile(*oidcSecretFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client secret: %w", err)
+		}
+		secret = strings.TrimSpace(string(data))
+	}
+	groupRoles := make(map[string]role)
+	for _, spec := range oidcGroupRoles {
+		group, name, ok := strings.Cut(spec, "=")
+		if !ok || group == "" {
+			return nil, fmt.Errorf("invalid -oidc-group-role %q, expected GROUP=ROLE", spec)
+		}
+		r, err := parseRole(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -oidc-group-role %q: %w", spec, err)
+		}
+		groupRoles[group] = r
+	}
+	var defaultRole role
+	if *oidcDefaultRole != "" {
+		r, err := parseRole(*oidcDefaultRole)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -oidc-default-role: %w", err)
+		}
+		defaultRole = r
+	}
+	key, err := loadOrCreateKey(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session key: %w", err)
+	}
+
+	a := &oidcAuth{
+		issuer:       strings.TrimSuffix(*oidcIssuer, "/"),
+		clientID:     *oidcClientID,
+		clientSecret: secret,
+		scopes:       strings.Fields(*oidcScopes),
+		groupsClaim:  *oidcGroupsClaim,
+		groupRoles:   groupRoles,
+		defaultRole:  defaultRole,
+		sessionTTL:   *oidcSessionTTL,
+		key:          key,
+		ui:           ui,
+		client:       &http.Client{Timeout: 30 * time.Second},
+	}
+	if err := a.discover(ctx); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// discover looks up the provider's endpoints in its discovery document.
+func (a *oidcAuth) discover(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch OIDC discovery document: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch OIDC discovery document: %s", resp.Status)
+	}
+	var doc struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return fmt.Errorf("invalid OIDC discovery document: %w", err)
+	}
+	if strings.TrimSuffix(doc.Issuer, "/") != a.issuer {
+		return fmt.Errorf("OIDC provider's issuer is %q, not %q", doc.Issuer, a.issuer)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" {
+		return errors.New("OIDC discovery document is missing its endpoints")
+	}
+	a.authEndpoint = doc.AuthorizationEndpoint
+	a.tokenEndpoint = doc.TokenEndpoint
+	return nil
+}
+
+// register adds the login, callback and logout routes to mux.
+func (a *oidcAuth) register(mux *http.ServeMux) {
+	mux.HandleFunc(oidcLoginPath, a.handleLogin)
+	mux.HandleFunc(oidcCallbackPath, a.handleCallback)
+	mux.HandleFunc(oidcLogoutPath, a.handleLogout)
+}
+
+// requireSession sends users without a session to sign in before serving
+// them the UI.
+func (a *oidcAuth) requireSession(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := a.session(r.Header); !ok {
+			http.Redirect(w, r, a.ui.BasePath+oidcLoginPath+"?return="+url.QueryEscape(a.ui.BasePath+r.URL.RequestURI()), http.StatusFound)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// session returns the user whose session cookie is in header.
+func (a *oidcAuth) session(header http.Header) (principal, bool) {
+	if a == nil {
+		return principal{}, false
+	}
+	cookie, err := (&http.Request{Header: header}).Cookie(sessionCookieName)
+	if err != nil {
+		return principal{}, false
+	}
+	var s sessionCookie
+	if err := a.verify(cookie.Value, &s); err != nil || time.Now().Unix() >= s.Expires {
+		return principal{}, false
+	}
+	r, err := parseRole(s.Role)
+	if err != nil {
+		return principal{}, false
+	}
+	return principal{name: s.Name, role: r}, true
+}
+
+type sessionCookie struct {
+	Name    string `json:"name"`
+	Role    string `json:"role"`
+	Expires int64  `json:"exp"`
+}
+
+// loginCookie carries a sign-in's state from the login redirect to the
+// callback.
+type loginCookie struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	Return   string `json:"return"`
+	Expires  int64  `json:"exp"`
+}
+
+func (a *oidcAuth) redirectURL(r *http.Request) string {
+	return a.ui.grpcAddr(r) + oidcCallbackPath
+}
+
+func (a *oidcAuth) handleLogin(w http.ResponseWriter, r *http.Request) {
+	login := loginCookie{
+		State:    randomString(),
+		Nonce:    randomString(),
+		Verifier: randomString(),
+		Return:   a.ui.BasePath + "/",
+		Expires:  time.Now().Add(loginTimeout).Unix(),
+	}
+	// Only return to paths on this server.
+	if ret := r.URL.Query().Get("return"); strings.HasPrefix(ret, "/") && !strings.HasPrefix(ret, "//") && !strings.HasPrefix(ret, "/\\") {
+		login.Return = ret
+	}
+	a.setCookie(w, r, loginCookieName, a.sign(login), a.ui.BasePath+"/auth/", loginTimeout)
+
+	challenge := sha256.Sum256([]byte(login.Verifier))
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {a.clientID},
+		"redirect_uri":          {a.redirectURL(r)},
+		"scope":                 {strings.Join(a.scopes, " ")},
+		"state":                 {login.State},
+		"nonce":                 {login.Nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(a.authEndpoint, "?") {
+		sep = "&"
+	}
+	http.Redirect(w, r, a.authEndpoint+sep+query.Encode(), http.StatusFound)
+}
+
+func (a *oidcAuth) handleCallback(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie(loginCookieName)
+	if err != nil {
+		http.Error(w, "sign-in expired, please try again", http.StatusBadRequest)
+		return
+	}
+	var login loginCookie
+	if err := a.verify(cookie.Value, &login); err != nil || time.Now().Unix() >= login.Expires {
+		http.Error(w, "sign-in expired, please try again", http.StatusBadRequest)
+		return
+	}
+	a.setCookie(w, r, loginCookieName, "", a.ui.BasePath+"/auth/", -1)
+
+	query := r.URL.Query()
+	if query.Get("state") != login.State {
+		http.Error(w, "sign-in state mismatch", http.StatusBadRequest)
+		return
+	}
+	if e := query.Get("error"); e != "" {
+		log.Printf("OIDC sign-in failed: %s: %s", e, query.Get("error_description"))
+		http.Error(w, "sign-in failed: "+e, http.StatusForbidden)
+		return
+	}
+
+	claims, err := a.exchange(r.Context(), query.Get("code"), login.Verifier, a.redirectURL(r))
+	if err == nil {
+		err = a.checkClaims(claims, login.Nonce)
+	}
+	if err != nil {
+		log.Printf("OIDC sign-in failed: %v", err)
+		http.Error(w, "sign-in failed", http.StatusForbidden)
+		return
+	}
+	p, ok := a.principal(claims)
+	if !ok {
+		log.Printf("OIDC user %s is in no group with access", p.name)
+		http.Error(w, p.name+" is not allowed to use mitmflow", http.StatusForbidden)
+		return
+	}
+
+	log.Printf("OIDC user %s signed in as %s", p.name, p.role)
+	session := sessionCookie{Name: p.name, Role: p.role.String(), Expires: time.Now().Add(a.sessionTTL).Unix()}
+	a.setCookie(w, r, sessionCookieName, a.sign(session), a.cookiePath(), a.sessionTTL)
+	http.Redirect(w, r, login.Return, http.StatusFound)
+}
+
+func (a *oidcAuth) handleLogout(w http.ResponseWriter, r *http.Request) {
+	a.setCookie(w, r, sessionCookieName, "", a.cookiePath(), -1)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, `<p>Signed out of mitmflow. <a href="%s">Sign in again</a></p>`, a.ui.BasePath+oidcLoginPath) //nolint:errcheck
+}
+
+// exchange redeems an authorization code for the claims of the user's ID
+// token.
+func (a *oidcAuth) exchange(ctx context.Context, code, verifier, redirectURL string) (map[string]any, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURL},
+		"code_verifier": {verifier},
+		"client_id":     {a.clientID},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if a.clientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(a.clientID), url.QueryEscape(a.clientSecret))
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token request failed: %s: %s", resp.Status, body)
+	}
+	var token struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("invalid token response: %w", err)
+	}
+	parts := strings.Split(token.IDToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("token response has no ID token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+	return claims, nil
+}
+
+// checkClaims checks that an ID token was issued to us, for this sign-in, and
+// hasn't expired.
+func (a *oidcAuth) checkClaims(claims map[string]any, nonce string) error {
+	if iss, _ := claims["iss"].(string); strings.TrimSuffix(iss, "/") != a.issuer {
+		return fmt.Errorf("ID token issued by %q", iss)
+	}
+	if !slices.Contains(stringsClaim(claims["aud"]), a.clientID) {
+		return errors.New("ID token issued to another client")
+	}
+	if exp, _ := claims["exp"].(float64); time.Now().Unix() >= int64(exp) {
+		return errors.New("ID token expired")
+	}
+	if n, _ := claims["nonce"].(string); n != nonce {
+		return errors.New("ID token nonce mismatch")
+	}
+	return nil
+}
+
+// principal returns the user an ID token describes, with the highest role any
+// of their groups has. ok is false if they have no role.
+func (a *oidcAuth) principal(claims map[string]any) (p principal, ok bool) {
+	for _, claim := range []string{"email", "preferred_username", "sub"} {
+		if name, _ := claims[claim].(string); name != "" {
+			p.name = name
+			break
+		}
+	}
+	p.role = a.defaultRole
+	for _, group := range stringsClaim(claims[a.groupsClaim]) {
+		if r := a.groupRoles[group]; r > p.role {
+			p.role = r
+		}
+	}
+	return p, p.role != 0
+}
+
+// stringsClaim returns a claim that may be a string or a list of strings.
+func stringsClaim(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var out []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func (a *oidcAuth) cookiePath() string {
+	return a.ui.BasePath + "/"
+}
+
+func (a *oidcAuth) setCookie(w http.ResponseWriter, r *http.Request, name, value, path string, ttl time.Duration) {
+	secure := r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" || strings.HasPrefix(a.ui.PublicURL, "https://")
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		MaxAge:   int(ttl.Seconds()),
+		Secure:   secure,
+		HttpOnly: true,
+		// Lax keeps the cookie off cross-site POSTs, which is what RPCs are.
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// sign encodes v as a cookie value signed with an HMAC.
+func (a *oidcAuth) sign(v any) string {
+	payload, _ := json.Marshal(v)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(a.mac(encoded))
+}
+
+// verify decodes a cookie value made by sign into v.
+func (a *oidcAuth) verify(value string, v any) error {
+	encoded, sig, ok := strings.Cut(value, ".")
+	if !ok {
+		return errors.New("malformed cookie")
+	}
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, a.mac(encoded)) {
+		return errors.New("invalid cookie signature")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(payload, v)
+}
+
+func (a *oidcAuth) mac(s string) []byte {
+	h := hmac.New(sha256.New, a.key)
+	h.Write([]byte(s))
+	return h.Sum(nil)
+}
+
+func randomString() string {
+	b := make([]byte, 32)
+	rand.Read(b) //nolint:errcheck
+	return base64.RawURLEncoding.EncodeToString(b)
+}
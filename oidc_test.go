@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+// fakeOIDCProvider issues ID tokens for codes handed out by authorize.
+type fakeOIDCProvider struct {
+	*httptest.Server
+	t      *testing.T
+	groups []string
+	// codes maps an authorization code to its nonce and PKCE challenge.
+	codes map[string][2]string
+}
+
+func newFakeOIDCProvider(t *testing.T) *fakeOIDCProvider {
+	p := &fakeOIDCProvider{t: t, codes: make(map[string][2]string)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{ //nolint:errcheck
+			"issuer":                 p.URL,
+			"authorization_endpoint": p.URL + "/authorize",
+			"token_endpoint":         p.URL + "/token",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		require.NoError(t, r.ParseForm())
+		code, ok := p.codes[r.PostForm.Get("code")]
+		verifier := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if !ok || id != "mitmflow" || secret != "s3cret" || base64.RawURLEncoding.EncodeToString(verifier[:]) != code[1] {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		claims, _ := json.Marshal(map[string]any{
+			"iss":    p.URL,
+			"aud":    []string{"mitmflow"},
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"nonce":  code[0],
+			"email":  "ada@example.com",
+			"groups": p.groups,
+		})
+		json.NewEncoder(w).Encode(map[string]string{ //nolint:errcheck
+			"id_token": "e30." + base64.RawURLEncoding.EncodeToString(claims) + ".sig",
+		})
+	})
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+	return p
+}
+
+// authorize plays the provider's sign-in page, returning where it redirects
+// the browser back to.
+func (p *fakeOIDCProvider) authorize(location string) string {
+	u, err := url.Parse(location)
+	require.NoError(p.t, err)
+	require.Equal(p.t, p.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	q := u.Query()
+	require.Equal(p.t, "S256", q.Get("code_challenge_method"))
+	code := randomString()
+	p.codes[code] = [2]string{q.Get("nonce"), q.Get("code_challenge")}
+	return q.Get("redirect_uri") + "?" + url.Values{"code": {code}, "state": {q.Get("state")}}.Encode()
+}
+
+func TestOIDCAuth(t *testing.T) {
+	provider := newFakeOIDCProvider(t)
+	server, _ := newTestServer(t)
+
+	oidc := &oidcAuth{
+		issuer:       provider.URL,
+		clientID:     "mitmflow",
+		clientSecret: "s3cret",
+		scopes:       []string{"openid", "email"},
+		groupsClaim:  "groups",
+		groupRoles:   map[string]role{"sre": roleAdmin, "dev": roleViewer},
+		sessionTTL:   time.Hour,
+		key:          []byte("0123456789abcdef0123456789abcdef"),
+		ui:           uiConfig{BasePath: "/mitmflow"},
+		client:       http.DefaultClient,
+	}
+	require.NoError(t, oidc.discover(context.Background()))
+	auth := &authenticator{oidc: oidc}
+
+	mux := http.NewServeMux()
+	mux.Handle(mitmflowv1.NewServiceHandler(server, connect.WithInterceptors(auth)))
+	oidc.register(mux)
+	mux.Handle("/", oidc.requireSession(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ui")) //nolint:errcheck
+	})))
+	app := httptest.NewServer(mountAt("/mitmflow", mux))
+	defer app.Close()
+	oidc.ui.PublicURL = app.URL + "/mitmflow"
+
+	// signIn follows a browser through sign-in, returning its session cookie.
+	signIn := func(groups ...string) (*http.Response, *http.Cookie) {
+		provider.groups = groups
+		client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+
+		resp, err := client.Get(app.URL + "/mitmflow/flows?id=1")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+		resp, err = client.Get(app.URL + resp.Header.Get("Location"))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+		loginCookie := resp.Cookies()[0]
+
+		req, err := http.NewRequest(http.MethodGet, provider.authorize(resp.Header.Get("Location")), nil)
+		require.NoError(t, err)
+		req.AddCookie(loginCookie)
+		resp, err = client.Do(req)
+		require.NoError(t, err)
+		for _, c := range resp.Cookies() {
+			if c.Name == sessionCookieName {
+				return resp, c
+			}
+		}
+		return resp, nil
+	}
+
+	resp, session := signIn("dev", "sre")
+	require.NotNil(t, session)
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+	assert.Equal(t, "/mitmflow/flows?id=1", resp.Header.Get("Location"))
+	assert.Equal(t, "/mitmflow/", session.Path)
+	assert.True(t, session.HttpOnly)
+
+	header := http.Header{}
+	header.Set("Cookie", session.String())
+	p, ok := oidc.session(header)
+	require.True(t, ok)
+	assert.Equal(t, principal{name: "ada@example.com", role: roleAdmin}, p, "the highest role of the user's groups")
+
+	client := mitmflowv1.NewServiceClient(app.Client(), app.URL+"/mitmflow")
+	listSubscribers := func(cookie string) error {
+		req := connect.NewRequest(&mitmflowv1.ListSubscribersRequest{})
+		if cookie != "" {
+			req.Header().Set("Cookie", cookie)
+		}
+		_, err := client.ListSubscribers(context.Background(), req)
+		return err
+	}
+	assert.NoError(t, listSubscribers(session.String()))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(listSubscribers("")))
+	forged := *session
+	forged.Value = strings.Replace(forged.Value, ".", "x.", 1)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(listSubscribers(forged.String())))
+
+	_, session = signIn("dev")
+	require.NotNil(t, session)
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(listSubscribers(session.String())))
+
+	resp, session = signIn("marketing")
+	assert.Nil(t, session)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
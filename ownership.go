@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+// visibleTo returns whether the client making a request can see a flow.
+// Private flows are only visible to their owner and admins.
+func visibleTo(ctx context.Context) func(*mitmflowv1.Flow) bool {
+	p, ok := principalFromContext(ctx)
+	if !ok || p.role >= roleAdmin {
+		return func(*mitmflowv1.Flow) bool { return true }
+	}
+	return func(flow *mitmflowv1.Flow) bool {
+		return !flow.GetPrivate() || flow.GetOwner() == p.name
+	}
+}
+
+// seesEverything reports whether the client making a request can see every
+// flow, private or not.
+func seesEverything(ctx context.Context) bool {
+	p, ok := principalFromContext(ctx)
+	return !ok || p.role >= roleAdmin
+}
+
+// claim makes the client making a request the owner of a flow it captured,
+// imported or sent, and makes the flow private if the client's token is.
+func claim(ctx context.Context, flow *mitmflowv1.Flow) {
+	p, ok := principalFromContext(ctx)
+	if !ok {
+		return
+	}
+	flow.SetOwner(p.name)
+	if p.private {
+		flow.SetPrivate(true)
+	}
+}
+
+// canChangePrivacy reports whether the client making a request may make a
+// flow private or public again.
+func canChangePrivacy(ctx context.Context, flow *mitmflowv1.Flow) bool {
+	p, ok := principalFromContext(ctx)
+	return !ok || p.role >= roleAdmin || (flow.GetOwner() != "" && flow.GetOwner() == p.name)
+}
+
+// visibleFlow returns a stored flow if the client making a request can see
+// it.
+func (s *MITMFlowServer) visibleFlow(ctx context.Context, id string) (*mitmflowv1.Flow, bool) {
+	flow, ok := s.storage.GetFlow(id)
+	if !ok || !visibleTo(ctx)(flow) {
+		return nil, false
+	}
+	return flow, true
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPrivateFlows(t *testing.T) {
+	server, storage := newTestServer(t)
+	ada := context.WithValue(context.Background(), principalKey{}, principal{name: "ada", role: roleEditor, private: true})
+	bob := context.WithValue(context.Background(), principalKey{}, principal{name: "bob", role: roleEditor})
+	root := context.WithValue(context.Background(), principalKey{}, principal{name: "root", role: roleAdmin})
+
+	secret := createFlow("secret", time.Now())
+	claim(ada, secret)
+	require.NoError(t, storage.SaveFlow(secret))
+	public := createFlow("public", time.Now())
+	claim(bob, public)
+	require.NoError(t, storage.SaveFlow(public))
+
+	// mitmproxy sends a flow again as it progresses, and it stays private.
+	require.NoError(t, storage.SaveFlow(createFlow("secret", time.Now())))
+	stored, ok := storage.GetFlow("secret")
+	require.True(t, ok)
+	assert.Equal(t, "ada", stored.GetOwner())
+	assert.True(t, stored.GetPrivate())
+
+	getFlow := func(ctx context.Context, id string) error {
+		_, err := server.GetFlow(ctx, connect.NewRequest(mitmflowv1.GetFlowRequest_builder{FlowId: proto.String(id)}.Build()))
+		return err
+	}
+	require.NoError(t, getFlow(ada, "secret"))
+	require.NoError(t, getFlow(root, "secret"))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(getFlow(bob, "secret")))
+	require.NoError(t, getFlow(bob, "public"))
+
+	// Only the owner or an admin can make a flow public again.
+	setPrivate := func(ctx context.Context, id string, private bool) error {
+		_, err := server.UpdateFlow(ctx, connect.NewRequest(mitmflowv1.UpdateFlowRequest_builder{
+			FlowId:  proto.String(id),
+			Private: proto.Bool(private),
+		}.Build()))
+		return err
+	}
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(setPrivate(ada, "public", true)))
+	require.NoError(t, setPrivate(root, "secret", false))
+	require.NoError(t, getFlow(bob, "secret"))
+	require.NoError(t, setPrivate(ada, "secret", true))
+
+	// Deleting everything only deletes what the client can see.
+	res, err := server.DeleteFlows(bob, connect.NewRequest(mitmflowv1.DeleteFlowsRequest_builder{
+		All: proto.Bool(true),
+	}.Build()))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"public"}, res.Msg.GetFlowIds())
+	_, ok = storage.GetFlow("secret")
+	assert.True(t, ok)
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
+)
+
+// FlowBackend persists flows for FlowStorage. FlowStorage keeps the flows in
+// its Store, which owns ordering, pinning and pruning, and mirrors every change
+// to the backend, so a backend only needs to store flows by ID. Calls are made
+// from a single goroutine.
+type FlowBackend interface {
+	// Load returns every persisted flow, in any order.
+	Load() ([]*mitmflowv1.Flow, error)
+	// Save persists a serialized flow, replacing any previous version.
+	Save(id string, data []byte) error
+	// Read returns a persisted flow. The error matches fs.ErrNotExist when
+	// the flow isn't persisted.
+	Read(id string) (*mitmflowv1.Flow, error)
+	// Remove deletes a persisted flow. Removing a flow that isn't persisted
+	// is not an error.
+	Remove(id string) error
+	// Close releases the backend's resources.
+	Close() error
+}
+
+// WithBackend persists flows with backend instead of as files in the data
+// directory.
+func WithBackend(backend FlowBackend) StorageOption {
+	return func(s *FlowStorage) {
+		s.backend = backend
+	}
+}
+
+// WithStore keeps flows in store instead of the default sharded store.
+func WithStore(store Store) StorageOption {
+	return func(s *FlowStorage) {
+		s.store = store
+	}
+}
+
+// fileBackend stores each flow in its own file, <id>.bin, encoded with codec.
+type fileBackend struct {
+	dir   string
+	codec *flowCodec
+	// readOnly leaves temporary and corrupt files where they are on Load.
+	readOnly bool
+}
+
+func newFileBackend(dir string, codec *flowCodec) *fileBackend {
+	return &fileBackend{dir: dir, codec: codec}
+}
+
+func (b *fileBackend) filename(id string) string {
+	return filepath.Join(b.dir, id+".bin")
+}
+
+func (b *fileBackend) Load() ([]*mitmflowv1.Flow, error) {
+	entries, err := os.ReadDir(b.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data directory: %w", err)
+	}
+
+	// Files are read and unmarshaled by a bounded pool of workers. Each worker
+	// writes to its own slot so no locking is needed.
+	loaded := make([]*mitmflowv1.Flow, len(entries))
+	g := new(errgroup.Group)
+	g.SetLimit(runtime.GOMAXPROCS(0) * 4)
+
+	for i, entry := range entries {
+		i, entry := i, entry
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), tmpFileSuffix) {
+			// Leftover from a write that never completed, the previous
+			// version of the flow (if any) is still intact.
+			if !b.readOnly {
+				os.Remove(filepath.Join(b.dir, entry.Name())) //nolint:errcheck
+			}
+			continue
+		}
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".bin" {
+			continue
+		}
+
+		g.Go(func() error {
+			data, err := os.ReadFile(filepath.Join(b.dir, entry.Name()))
+			if err != nil {
+				log.Printf("failed to read flow file %s: %v", entry.Name(), err)
+				return nil
+			}
+			data, err = b.codec.decode(data)
+			if err != nil {
+				log.Printf("failed to decompress flow file %s: %v", entry.Name(), err)
+				b.quarantine(entry.Name())
+				return nil
+			}
+
+			flow := &mitmflowv1.Flow{}
+			if err := proto.Unmarshal(data, flow); err != nil {
+				log.Printf("failed to unmarshal flow file %s: %v", entry.Name(), err)
+				b.quarantine(entry.Name())
+				return nil
+			}
+
+			if GetFlowID(flow) == "" {
+				return nil
+			}
+
+			loaded[i] = flow
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	flows := loaded[:0]
+	for _, flow := range loaded {
+		if flow != nil {
+			flows = append(flows, flow)
+		}
+	}
+	return flows, nil
+}
+
+// Save compresses the data (if enabled) and writes it to a temporary file
+// which is fsynced and then renamed over the final path, so a crash mid-write
+// never leaves a partially written flow file behind.
+func (b *fileBackend) Save(id string, data []byte) error {
+	return writeFileAtomic(b.filename(id), b.codec.encode(data), 0644)
+}
+
+func (b *fileBackend) Read(id string) (*mitmflowv1.Flow, error) {
+	return readFlowFile(b.filename(id), b.codec)
+}
+
+func (b *fileBackend) Remove(id string) error {
+	if err := os.Remove(b.filename(id)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (b *fileBackend) Close() error {
+	return nil
+}
+
+// quarantine moves a flow file that could not be loaded into the corrupt/
+// subdirectory so it is kept for inspection but not retried on every startup.
+// A read-only backend leaves it in place.
+func (b *fileBackend) quarantine(name string) {
+	if b.readOnly {
+		return
+	}
+	corruptDir := filepath.Join(b.dir, corruptDirName)
+	if err := os.MkdirAll(corruptDir, 0755); err != nil {
+		log.Printf("failed to create corrupt directory: %v", err)
+		return
+	}
+	if err := os.Rename(filepath.Join(b.dir, name), filepath.Join(corruptDir, name)); err != nil {
+		log.Printf("failed to quarantine flow file %s: %v", name, err)
+		return
+	}
+	log.Printf("moved corrupt flow file %s to %s", name, corruptDir)
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+const (
+	// pluginDecodeTimeout bounds how long a plugin may take to decode one
+	// body.
+	pluginDecodeTimeout = 5 * time.Second
+	// pluginMemoryLimitPages caps the memory of a plugin at 256 MiB.
+	pluginMemoryLimitPages = 4096
+)
+
+// A decoder plugin is a WebAssembly module in the plugins directory that
+// decodes bodies of the content types it registers into textual frames. It
+// exports:
+//
+//   - memory
+//   - alloc(size i32) i32, which reserves size bytes for a body
+//   - content_types() i64, the content types it decodes, one per line, where
+//     a type may be a wildcard like application/*
+//   - decode(ptr i32, len i32) i64, which decodes a body into frames
+//     separated by NUL bytes
+//
+// Strings are returned packed as ptr<<32 | len and are copied out right
+// away. A module may also export free(ptr i32, len i32), which is called on
+// the body once it was decoded. WASI is available, and _initialize is
+// called when exported, so reactor modules built with TinyGo, Rust or Go
+// work.
+type decoderPlugin struct {
+	name         string
+	contentTypes []string
+	runtime      wazero.Runtime
+	compiled     wazero.CompiledModule
+
+	// mu serializes calls, because a module instance isn't safe for
+	// concurrent use.
+	mu  sync.Mutex
+	mod api.Module
+}
+
+// decoderPlugins holds the plugins loaded from a directory.
+type decoderPlugins struct {
+	runtime wazero.Runtime
+	plugins []*decoderPlugin
+}
+
+// loadDecoderPlugins loads every .wasm file in dir as a decoder plugin.
+func loadDecoderPlugins(ctx context.Context, dir string) (*decoderPlugins, error) {
+	filenames, err := filepath.Glob(filepath.Join(dir, "*.wasm"))
+	if err != nil {
+		return nil, err
+	}
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithCloseOnContextDone(true).
+		WithMemoryLimitPages(pluginMemoryLimitPages))
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		runtime.Close(ctx)
+		return nil, err
+	}
+	p := &decoderPlugins{runtime: runtime}
+	for _, filename := range filenames {
+		plugin, err := loadDecoderPlugin(ctx, runtime, filename)
+		if err != nil {
+			runtime.Close(ctx)
+			return nil, fmt.Errorf("failed to load plugin %s: %w", filename, err)
+		}
+		log.Printf("Loaded decoder plugin %s for %s", plugin.name, strings.Join(plugin.contentTypes, ", "))
+		p.plugins = append(p.plugins, plugin)
+	}
+	return p, nil
+}
+
+func loadDecoderPlugin(ctx context.Context, runtime wazero.Runtime, filename string) (*decoderPlugin, error) {
+	wasm, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	compiled, err := runtime.CompileModule(ctx, wasm)
+	if err != nil {
+		return nil, err
+	}
+	exports := compiled.ExportedFunctions()
+	for _, name := range []string{"alloc", "content_types", "decode"} {
+		if _, ok := exports[name]; !ok {
+			return nil, fmt.Errorf("module doesn't export %s", name)
+		}
+	}
+	plugin := &decoderPlugin{
+		name:     strings.TrimSuffix(filepath.Base(filename), ".wasm"),
+		runtime:  runtime,
+		compiled: compiled,
+	}
+	types, err := plugin.call(ctx, "content_types")
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(types), "\n") {
+		if t := strings.ToLower(strings.TrimSpace(line)); t != "" {
+			plugin.contentTypes = append(plugin.contentTypes, t)
+		}
+	}
+	if len(plugin.contentTypes) == 0 {
+		return nil, errors.New("module registers no content types")
+	}
+	return plugin, nil
+}
+
+// decoderFor returns the first plugin that decodes a content type.
+func (p *decoderPlugins) decoderFor(contentType string) *decoderPlugin {
+	if p == nil {
+		return nil
+	}
+	for _, plugin := range p.plugins {
+		if slices.ContainsFunc(plugin.contentTypes, func(pattern string) bool {
+			return matchMediaType(pattern, contentType)
+		}) {
+			return plugin
+		}
+	}
+	return nil
+}
+
+// matchMediaType reports whether a Content-Type has the media type of a
+// pattern, which may be a type/* wildcard.
+func matchMediaType(pattern, contentType string) bool {
+	if contentType == "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, _, _ = strings.Cut(strings.ToLower(contentType), ";")
+		mediaType = strings.TrimSpace(mediaType)
+	}
+	kind, _, _ := strings.Cut(mediaType, "/")
+	return pattern == mediaType || pattern == kind+"/*"
+}
+
+// splitFrames splits the output of a decoder into frames at NUL bytes.
+func splitFrames(out []byte) []string {
+	var frames []string
+	for _, frame := range strings.Split(string(out), "\x00") {
+		if frame != "" {
+			frames = append(frames, frame)
+		}
+	}
+	return frames
+}
+
+// setFrames decodes a body with the plugin for its effective, or else its
+// declared, content type.
+func (p *decoderPlugins) setFrames(content []byte, details *mitmflowv1.MessageDetails) {
+	if p == nil || len(content) == 0 {
+		return
+	}
+	plugin := p.decoderFor(details.GetEffectiveContentType())
+	if plugin == nil {
+		plugin = p.decoderFor(details.GetDeclaredContentType())
+	}
+	if plugin == nil {
+		return
+	}
+	frames, err := plugin.decodeBody(content)
+	if err != nil {
+		log.Printf("plugin %s failed to decode body: %v", plugin.name, err)
+		return
+	}
+	if len(frames) > 0 {
+		details.SetTextualFrames(frames)
+	}
+}
+
+func (p *decoderPlugins) Close(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+	return p.runtime.Close(ctx)
+}
+
+// decodeBody runs the plugin's decode function on a body.
+func (plugin *decoderPlugin) decodeBody(content []byte) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), pluginDecodeTimeout)
+	defer cancel()
+	out, err := plugin.call(ctx, "decode", content)
+	if err != nil {
+		return nil, err
+	}
+	return splitFrames(out), nil
+}
+
+// call copies arg, when given, into the module and calls fn with its
+// pointer and length, returning a copy of the string fn returns. The module
+// is instantiated again when an earlier call closed it, like on a timeout.
+func (plugin *decoderPlugin) call(ctx context.Context, fn string, arg ...[]byte) ([]byte, error) {
+	plugin.mu.Lock()
+	defer plugin.mu.Unlock()
+	if plugin.mod == nil || plugin.mod.IsClosed() {
+		mod, err := plugin.runtime.InstantiateModule(ctx, plugin.compiled, wazero.NewModuleConfig().
+			WithName("").
+			WithStartFunctions("_initialize"))
+		if err != nil {
+			return nil, err
+		}
+		plugin.mod = mod
+	}
+	mod := plugin.mod
+
+	var params []uint64
+	if len(arg) > 0 {
+		size := uint64(len(arg[0]))
+		res, err := mod.ExportedFunction("alloc").Call(ctx, size)
+		if err != nil {
+			return nil, err
+		}
+		ptr := res[0]
+		if !mod.Memory().Write(uint32(ptr), arg[0]) {
+			return nil, fmt.Errorf("alloc returned %d, out of memory bounds", ptr)
+		}
+		params = []uint64{ptr, size}
+		if free := mod.ExportedFunction("free"); free != nil {
+			defer free.Call(ctx, ptr, size)
+		}
+	}
+	res, err := mod.ExportedFunction(fn).Call(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	ptr, size := uint32(res[0]>>32), uint32(res[0])
+	if size == 0 {
+		return nil, nil
+	}
+	out, ok := mod.Memory().Read(ptr, size)
+	if !ok {
+		return nil, fmt.Errorf("%s returned %d bytes at %d, out of memory bounds", fn, size, ptr)
+	}
+	return slices.Clone(out), nil
+}
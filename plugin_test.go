@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecoderPlugins(t *testing.T) {
+	ctx := context.Background()
+	plugins, err := loadDecoderPlugins(ctx, "testdata/plugins")
+	require.NoError(t, err)
+	defer plugins.Close(ctx)
+	require.Len(t, plugins.plugins, 1)
+	assert.Equal(t, "upper", plugins.plugins[0].name)
+	assert.Equal(t, []string{"application/x-acme"}, plugins.plugins[0].contentTypes)
+
+	assert.NotNil(t, plugins.decoderFor("Application/X-Acme; version=2"))
+	assert.Nil(t, plugins.decoderFor("application/json"))
+
+	server, err := NewMITMFlowServer(nil, nil, WithDecoderPlugins(plugins))
+	require.NoError(t, err)
+	flow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Id: proto.String("f1"),
+			Request: mitmproxyv1.Request_builder{
+				Method:  proto.String("POST"),
+				Url:     proto.String("https://example.com/"),
+				Headers: map[string]string{"Content-Type": "application/x-acme"},
+				Content: []byte("hello\x00world"),
+			}.Build(),
+			Response: mitmproxyv1.Response_builder{
+				StatusCode: proto.Int32(200),
+				Headers:    map[string]string{"Content-Type": "application/json"},
+				Content:    []byte(`{"ok":true}`),
+			}.Build(),
+		}.Build(),
+	}.Build()
+	server.preprocessFlow(flow)
+	assert.Equal(t, []string{"HELLO", "WORLD"}, flow.GetHttpFlowExtra().GetRequest().GetTextualFrames())
+	assert.NotContains(t, flow.GetHttpFlowExtra().GetResponse().GetTextualFrames(), `{"OK":TRUE}`)
+
+	// The plugin keeps working across calls.
+	frames, err := plugins.plugins[0].decodeBody([]byte("again"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AGAIN"}, frames)
+}
+
+func TestLoadDecoderPluginsErrors(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.wasm"), []byte("not wasm"), 0o644))
+	_, err := loadDecoderPlugins(context.Background(), dir)
+	assert.ErrorContains(t, err, "bad.wasm")
+
+	plugins, err := loadDecoderPlugins(context.Background(), t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, plugins.plugins)
+	assert.NoError(t, plugins.Close(context.Background()))
+}
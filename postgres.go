@@ -0,0 +1,337 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	postgresProtocol3   = 196608
+	postgresSSLRequest  = 80877103
+	postgresGSSRequest  = 80877104
+	postgresCancel      = 80877102
+	maxPostgresStartup  = 10000
+	maxPostgresMessage  = 64 << 20
+	postgresMessageHead = 5
+)
+
+var errShortPostgresMessage = errors.New("postgres message is cut short")
+
+// isPostgresStartup recognizes the untyped message a client opens a
+// PostgreSQL connection with: a startup message or a request for TLS or GSS
+// encryption.
+func isPostgresStartup(first []byte) bool {
+	if len(first) < 8 {
+		return false
+	}
+	length := binary.BigEndian.Uint32(first)
+	switch binary.BigEndian.Uint32(first[4:]) {
+	case postgresSSLRequest, postgresGSSRequest:
+		return length == 8
+	case postgresProtocol3:
+		return length <= maxPostgresStartup
+	}
+	return false
+}
+
+// postgresDecoder renders the messages of the PostgreSQL frontend/backend
+// protocol. Most message types mean different things from the client and the
+// server, so the decoder tells them apart by direction.
+type postgresDecoder struct {
+	redact bool
+	// started is set once the client sent its startup message, after which
+	// its messages are typed.
+	started bool
+	// encryptionRequested is set while the client waits for the single byte
+	// answer to a TLS or GSS encryption request.
+	encryptionRequested bool
+	rows                *dbResultSet
+}
+
+func (d *postgresDecoder) decode(data []byte, fromClient bool) ([]string, []byte, error) {
+	var frames []string
+	for len(data) > 0 {
+		if !fromClient && d.encryptionRequested {
+			d.encryptionRequested = false
+			switch data[0] {
+			case 'S':
+				frames = append(frames, "encryption accepted")
+			case 'N':
+				frames = append(frames, "encryption refused")
+			default:
+				return nil, nil, fmt.Errorf("unexpected answer %q to an encryption request", data[0])
+			}
+			data = data[1:]
+			continue
+		}
+		var frame string
+		var n int
+		var err error
+		if fromClient && !d.started {
+			frame, n, err = d.startup(data)
+		} else {
+			frame, n, err = d.message(data, fromClient)
+		}
+		if errors.Is(err, errShortPostgresMessage) {
+			return frames, data, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if frame != "" {
+			frames = append(frames, frame)
+		}
+		data = data[n:]
+	}
+	return frames, nil, nil
+}
+
+func (d *postgresDecoder) startup(data []byte) (string, int, error) {
+	if len(data) < 8 {
+		return "", 0, errShortPostgresMessage
+	}
+	length := int(binary.BigEndian.Uint32(data))
+	if length < 8 || length > maxPostgresStartup {
+		return "", 0, fmt.Errorf("invalid postgres startup message of %d bytes", length)
+	}
+	if length > len(data) {
+		return "", 0, errShortPostgresMessage
+	}
+	r := &wireReader{data: data[4:length]}
+	switch code := r.u32(); code {
+	case postgresSSLRequest:
+		d.encryptionRequested = true
+		return "SSL request", length, nil
+	case postgresGSSRequest:
+		d.encryptionRequested = true
+		return "GSS encryption request", length, nil
+	case postgresCancel:
+		return fmt.Sprintf("cancel request for process %d", r.u32()), length, nil
+	case postgresProtocol3:
+		d.started = true
+		var params []string
+		for r.err == nil && r.len() > 1 {
+			name := r.cstring()
+			params = append(params, name+"="+r.cstring())
+		}
+		if r.err != nil {
+			return "", 0, fmt.Errorf("postgres startup message: %w", r.err)
+		}
+		return "startup: " + strings.Join(params, ", "), length, nil
+	default:
+		return "", 0, fmt.Errorf("unknown postgres protocol version %d", code)
+	}
+}
+
+func (d *postgresDecoder) message(data []byte, fromClient bool) (string, int, error) {
+	if len(data) < postgresMessageHead {
+		return "", 0, errShortPostgresMessage
+	}
+	typ := data[0]
+	length := int(binary.BigEndian.Uint32(data[1:]))
+	if length < 4 || length > maxPostgresMessage {
+		return "", 0, fmt.Errorf("invalid postgres message of %d bytes", length)
+	}
+	if 1+length > len(data) {
+		return "", 0, errShortPostgresMessage
+	}
+	r := &wireReader{data: data[postgresMessageHead : 1+length]}
+	var frame string
+	var err error
+	if fromClient {
+		frame, err = d.clientMessage(typ, r)
+	} else {
+		frame, err = d.serverMessage(typ, r)
+	}
+	if err == nil {
+		err = r.err
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("postgres %q message: %w", typ, err)
+	}
+	return frame, 1 + length, nil
+}
+
+func (d *postgresDecoder) clientMessage(typ byte, r *wireReader) (string, error) {
+	switch typ {
+	case 'Q':
+		return "query: " + dbQuery(r.cstring(), d.redact), nil
+	case 'P':
+		name := r.cstring()
+		query := dbQuery(r.cstring(), d.redact)
+		if name != "" {
+			return fmt.Sprintf("parse %s: %s", name, query), nil
+		}
+		return "parse: " + query, nil
+	case 'B':
+		return d.bind(r), nil
+	case 'E':
+		if portal := r.cstring(); portal != "" {
+			return "execute " + portal, nil
+		}
+		return "execute", nil
+	case 'D', 'C':
+		kind := "statement"
+		if r.u8() == 'P' {
+			kind = "portal"
+		}
+		verb := "describe"
+		if typ == 'C' {
+			verb = "close"
+		}
+		return strings.TrimSpace(fmt.Sprintf("%s %s %s", verb, kind, r.cstring())), nil
+	case 'S':
+		return "sync", nil
+	case 'H':
+		return "flush", nil
+	case 'X':
+		return "terminate", nil
+	case 'p':
+		return "password: (redacted)", nil
+	case 'd':
+		return "copy data: " + countOf(r.len(), "byte"), nil
+	case 'c':
+		return "copy done", nil
+	case 'f':
+		return "copy failed: " + r.cstring(), nil
+	case 'F':
+		return "function call", nil
+	}
+	return "", errors.New("unknown client message type")
+}
+
+// bind renders the parameters a prepared statement is executed with.
+func (d *postgresDecoder) bind(r *wireReader) string {
+	r.cstring() // portal
+	stmt := r.cstring()
+	formats := int(r.u16())
+	r.take(2 * formats)
+	var params []string
+	for i := range int(r.u16()) {
+		n := int32(r.u32())
+		v := r.take(max(int(n), 0))
+		params = append(params, fmt.Sprintf("$%d = %s", i+1, dbValue(v, n < 0, d.redact)))
+	}
+	head := "bind"
+	if stmt != "" {
+		head += " " + stmt
+	}
+	if len(params) == 0 {
+		return head
+	}
+	return head + ": " + strings.Join(params, ", ")
+}
+
+func (d *postgresDecoder) serverMessage(typ byte, r *wireReader) (string, error) {
+	switch typ {
+	case 'R':
+		switch code := r.u32(); code {
+		case 0:
+			return "authentication ok", nil
+		case 3:
+			return "password requested", nil
+		case 5:
+			return "MD5 password requested", nil
+		case 10:
+			var mechanisms []string
+			for r.err == nil && r.len() > 1 {
+				mechanisms = append(mechanisms, r.cstring())
+			}
+			return "SASL authentication: " + strings.Join(mechanisms, ", "), nil
+		case 11, 12:
+			return "SASL exchange", nil
+		default:
+			return fmt.Sprintf("authentication request %d", code), nil
+		}
+	case 'S':
+		name := r.cstring()
+		return fmt.Sprintf("parameter %s = %s", name, r.cstring()), nil
+	case 'K':
+		return fmt.Sprintf("backend process %d", r.u32()), nil
+	case 'Z':
+		switch r.u8() {
+		case 'T':
+			return "ready (in transaction)", nil
+		case 'E':
+			return "ready (failed transaction)", nil
+		}
+		return "ready", nil
+	case 'T':
+		d.rows = &dbResultSet{}
+		for range int(r.u16()) {
+			d.rows.columns = append(d.rows.columns, r.cstring())
+			r.take(18) // table, column, type, size, modifier and format
+		}
+		return d.rows.header(), nil
+	case 'D':
+		if d.rows == nil {
+			d.rows = &dbResultSet{}
+		}
+		var values []string
+		for range int(r.u16()) {
+			n := int32(r.u32())
+			values = append(values, dbValue(r.take(max(int(n), 0)), n < 0, d.redact))
+		}
+		frame, _ := d.rows.row(values)
+		return frame, nil
+	case 'C':
+		tag := r.cstring()
+		if d.rows != nil && d.rows.rows > maxDBRows {
+			tag = fmt.Sprintf("... %d more rows\n%s", d.rows.rows-maxDBRows, tag)
+		}
+		d.rows = nil
+		return "complete: " + tag, nil
+	case 'E', 'N':
+		var severity, code, message string
+		for r.err == nil && r.len() > 1 {
+			field := r.u8()
+			value := r.cstring()
+			switch field {
+			case 'V':
+				severity = value
+			case 'S':
+				if severity == "" {
+					severity = value
+				}
+			case 'C':
+				code = value
+			case 'M':
+				message = value
+			}
+		}
+		return fmt.Sprintf("%s %s: %s", severity, code, message), nil
+	case '1':
+		return "parse complete", nil
+	case '2':
+		return "bind complete", nil
+	case '3':
+		return "close complete", nil
+	case 'n':
+		return "no data", nil
+	case 's':
+		return "portal suspended", nil
+	case 'I':
+		return "empty query", nil
+	case 't':
+		return "parameters: " + fmt.Sprint(r.u16()), nil
+	case 'A':
+		r.u32() // process
+		channel := r.cstring()
+		payload := r.cstring()
+		if d.redact {
+			payload = "?"
+		}
+		return fmt.Sprintf("notification on %s: %s", channel, payload), nil
+	case 'G', 'H', 'W':
+		return "copy started", nil
+	case 'd':
+		return "copy data: " + countOf(r.len(), "byte"), nil
+	case 'c':
+		return "copy done", nil
+	case 'v':
+		return "protocol version negotiated", nil
+	}
+	return "", errors.New("unknown server message type")
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func pgMessage(typ byte, body ...[]byte) []byte {
+	var b []byte
+	for _, part := range body {
+		b = append(b, part...)
+	}
+	return append(binary.BigEndian.AppendUint32([]byte{typ}, uint32(4+len(b))), b...)
+}
+
+func pgString(s string) []byte {
+	return append([]byte(s), 0)
+}
+
+func pgValue(s string) []byte {
+	return append(binary.BigEndian.AppendUint32(nil, uint32(len(s))), s...)
+}
+
+func pgStartup() []byte {
+	body := binary.BigEndian.AppendUint32(nil, postgresProtocol3)
+	body = append(body, "user\x00ann\x00database\x00shop\x00\x00"...)
+	return append(binary.BigEndian.AppendUint32(nil, uint32(4+len(body))), body...)
+}
+
+func TestPostgresMessageDetails(t *testing.T) {
+	query := pgMessage('Q', pgString("SELECT id, name FROM users WHERE name = 'ann' LIMIT 10"))
+	rowDescription := pgMessage('T', []byte{0, 2},
+		pgString("id"), make([]byte, 18),
+		pgString("name"), make([]byte, 18))
+	var server []byte
+	server = append(server, rowDescription...)
+	server = append(server, pgMessage('D', []byte{0, 2}, pgValue("1"), pgValue("ann"))...)
+	server = append(server, pgMessage('D', []byte{0, 2}, pgValue("2"), []byte{0xff, 0xff, 0xff, 0xff})...)
+	server = append(server, pgMessage('C', pgString("SELECT 2"))...)
+	server = append(server, pgMessage('Z', []byte{'I'})...)
+
+	bind := pgMessage('B', pgString(""), pgString("s1"), []byte{0, 0}, []byte{0, 1}, pgValue("secret"), []byte{0, 0})
+
+	flow := mitmproxyv1.TCPFlow_builder{
+		Server: mitmproxyv1.ServerConn_builder{AddressPort: proto.Uint32(6543)}.Build(),
+		Messages: []*mitmproxyv1.TCPMessage{
+			mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(true), Content: append(pgStartup(), query...)}.Build(),
+			mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(false), Content: server}.Build(),
+			mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(true), Content: append(bind, pgMessage('S')...)}.Build(),
+		},
+	}.Build()
+
+	details := tcpMessageDetails(flow, false).GetMessages()
+	require.Len(t, details, 3)
+	assert.Equal(t, "application/x-postgresql", details[0].GetEffectiveContentType())
+	assert.Equal(t, []string{"startup: user=ann, database=shop\n\nquery: SELECT id, name FROM users WHERE name = 'ann' LIMIT 10"}, details[0].GetTextualFrames())
+	assert.Equal(t, []string{`2 columns: id, name
+
+row 1: "1", "ann"
+
+row 2: "2", NULL
+
+complete: SELECT 2
+
+ready`}, details[1].GetTextualFrames())
+	assert.Equal(t, []string{"bind s1: $1 = \"secret\"\n\nsync"}, details[2].GetTextualFrames())
+
+	redacted := tcpMessageDetails(flow, true).GetMessages()
+	assert.Equal(t, []string{"startup: user=ann, database=shop\n\nquery: SELECT id, name FROM users WHERE name = ? LIMIT ?"}, redacted[0].GetTextualFrames())
+	assert.Contains(t, redacted[1].GetTextualFrames()[0], "row 2: ?, NULL")
+	assert.Equal(t, []string{"bind s1: $1 = ?\n\nsync"}, redacted[2].GetTextualFrames())
+}
+
+func TestPostgresEncryptionRequest(t *testing.T) {
+	dec := postgresDecoder{}
+	frames, rest, err := dec.decode([]byte{0, 0, 0, 8, 0x04, 0xd2, 0x16, 0x2f}, true)
+	require.NoError(t, err)
+	assert.Empty(t, rest)
+	assert.Equal(t, []string{"SSL request"}, frames)
+
+	frames, _, err = dec.decode([]byte{'N'}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"encryption refused"}, frames)
+
+	frames, _, err = dec.decode(pgStartup(), true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"startup: user=ann, database=shop"}, frames)
+}
+
+func TestRedactSQL(t *testing.T) {
+	assert.Equal(t,
+		"INSERT INTO t1 (a, b) VALUES (?, ?), ($1, -?)",
+		redactSQL("INSERT INTO t1 (a, b) VALUES ('it''s', 4.5), ($1, -7)"))
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxygrpcv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+)
+
+// httpProtocol returns the protocol label of an HTTP flow, from the most
+// specific protocol it carries down to its HTTP version. It needs the
+// effective content types in extra, so it runs after the messages were
+// preprocessed.
+func httpProtocol(f *mitmproxygrpcv1.HTTPFlow, extra *mitmflowv1.HTTPFlowExtra) string {
+	req, resp := f.GetRequest(), f.GetResponse()
+	contentTypes := []string{
+		extra.GetRequest().GetEffectiveContentType(),
+		extra.GetResponse().GetEffectiveContentType(),
+	}
+	if ct, _ := getContentType(req.GetHeaders()); ct != "" {
+		contentTypes = append(contentTypes, ct)
+	}
+	if ct, _ := getContentType(resp.GetHeaders()); ct != "" {
+		contentTypes = append(contentTypes, ct)
+	}
+	carries := func(prefix string) bool {
+		for _, ct := range contentTypes {
+			if strings.HasPrefix(ct, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case f.GetIsWebsocket():
+		return "websocket"
+	case carries("application/grpc-web"):
+		return "grpc-web"
+	case carries("application/grpc"):
+		return "grpc"
+	case carries("application/connect+") || getHeaderValue(req.GetHeaders(), "Connect-Protocol-Version") != "":
+		return "connect"
+	case carries("application/dns-message") || isDoHGet(req):
+		return "dns-over-https"
+	}
+	version := strings.ToUpper(req.GetHttpVersion())
+	if version == "" {
+		version = strings.ToUpper(resp.GetHttpVersion())
+	}
+	switch {
+	case strings.HasPrefix(version, "HTTP/3"), version == "H3":
+		return "http3"
+	case isHTTP2(version):
+		return "http2"
+	}
+	return "http1"
+}
+
+// isDoHGet reports whether a request is a DNS over HTTPS query sent with
+// GET, which carries the query in the dns parameter and no body.
+func isDoHGet(req *mitmproxygrpcv1.Request) bool {
+	if !strings.EqualFold(req.GetMethod(), "GET") {
+		return false
+	}
+	u, err := url.Parse(req.GetUrl())
+	return err == nil && u.Query().Has("dns")
+}
+
+// isRESPCommand recognizes the first message of a Redis connection: a
+// command sent as a RESP array of bulk strings, e.g. "*1\r\n$4\r\nPING\r\n".
+func isRESPCommand(first []byte) bool {
+	if len(first) < 4 || first[0] != '*' {
+		return false
+	}
+	count, rest, ok := bytes.Cut(first[1:], []byte("\r\n"))
+	if !ok || len(count) == 0 || len(rest) == 0 || rest[0] != '$' {
+		return false
+	}
+	for _, c := range count {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// flowProtocol returns the protocol label computed for a flow at ingest.
+func flowProtocol(flow *mitmflowv1.Flow) string {
+	switch {
+	case flow.HasHttpFlowExtra():
+		return flow.GetHttpFlowExtra().GetProtocol()
+	case flow.HasStreamFlowExtra():
+		return flow.GetStreamFlowExtra().GetProtocol()
+	case flow.HasDnsFlowExtra():
+		return flow.GetDnsFlowExtra().GetProtocol()
+	}
+	return ""
+}
+
+// matchProtocols reports whether a flow has one of the protocol labels.
+func matchProtocols(flow *mitmflowv1.Flow, protocols []string) bool {
+	label := flowProtocol(flow)
+	for _, p := range protocols {
+		if strings.EqualFold(p, label) {
+			return true
+		}
+	}
+	return false
+}
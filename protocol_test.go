@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestFlowProtocol(t *testing.T) {
+	server, err := NewMITMFlowServer(nil, nil)
+	require.NoError(t, err)
+
+	httpFlow := func(version string, reqHeaders, respHeaders map[string]string) *mitmflowv1.Flow {
+		return mitmflowv1.Flow_builder{
+			HttpFlow: mitmproxyv1.HTTPFlow_builder{
+				Request: mitmproxyv1.Request_builder{
+					Method:      proto.String("POST"),
+					Url:         proto.String("https://example.com/svc/Method"),
+					HttpVersion: proto.String(version),
+					Headers:     reqHeaders,
+				}.Build(),
+				Response: mitmproxyv1.Response_builder{
+					StatusCode:  proto.Int32(200),
+					HttpVersion: proto.String(version),
+					Headers:     respHeaders,
+				}.Build(),
+			}.Build(),
+		}.Build()
+	}
+	tests := []struct {
+		name string
+		flow *mitmflowv1.Flow
+		want string
+	}{
+		{"http1", httpFlow("HTTP/1.1", nil, map[string]string{"Content-Type": "text/html"}), "http1"},
+		{"http2", httpFlow("HTTP/2.0", nil, nil), "http2"},
+		{"http3", httpFlow("HTTP/3", nil, nil), "http3"},
+		{"grpc", httpFlow("HTTP/2.0", map[string]string{"content-type": "application/grpc+proto"}, nil), "grpc"},
+		{"grpc-web", httpFlow("HTTP/1.1", nil, map[string]string{"Content-Type": "application/grpc-web-text"}), "grpc-web"},
+		{"connect unary", httpFlow("HTTP/1.1", map[string]string{"Content-Type": "application/json", "Connect-Protocol-Version": "1"}, nil), "connect"},
+		{"connect streaming", httpFlow("HTTP/2.0", map[string]string{"Content-Type": "application/connect+proto"}, nil), "connect"},
+		{"dns-over-https", httpFlow("HTTP/2.0", map[string]string{"Content-Type": "application/dns-message"}, nil), "dns-over-https"},
+		{"websocket", mitmflowv1.Flow_builder{
+			HttpFlow: mitmproxyv1.HTTPFlow_builder{
+				Request:     mitmproxyv1.Request_builder{Url: proto.String("https://example.com/ws")}.Build(),
+				IsWebsocket: proto.Bool(true),
+			}.Build(),
+		}.Build(), "websocket"},
+		{"dns", mitmflowv1.Flow_builder{DnsFlow: mitmproxyv1.DNSFlow_builder{Id: proto.String("dns")}.Build()}.Build(), "dns"},
+		{"udp", mitmflowv1.Flow_builder{UdpFlow: mitmproxyv1.UDPFlow_builder{Id: proto.String("udp")}.Build()}.Build(), "udp"},
+		{"tcp", tcpFlow(4000, []byte("hello")), "tcp"},
+		{"tcp-redis", tcpFlow(7000, []byte("*1\r\n$4\r\nPING\r\n")), "tcp-redis"},
+		{"tcp-postgresql", tcpFlow(5432, nil), "tcp-postgresql"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server.preprocessFlow(tt.flow)
+			assert.Equal(t, tt.want, flowProtocol(tt.flow))
+			assert.Equal(t, tt.want, convertToSummary(tt.flow).GetProtocol())
+		})
+	}
+}
+
+func tcpFlow(port uint32, first []byte) *mitmflowv1.Flow {
+	var messages []*mitmproxyv1.TCPMessage
+	if first != nil {
+		messages = append(messages, mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(true), Content: first}.Build())
+	}
+	return mitmflowv1.Flow_builder{
+		TcpFlow: mitmproxyv1.TCPFlow_builder{
+			Id:       proto.String("tcp"),
+			Server:   mitmproxyv1.ServerConn_builder{AddressPort: proto.Uint32(port)}.Build(),
+			Messages: messages,
+		}.Build(),
+	}.Build()
+}
+
+func TestFilterProtocol(t *testing.T) {
+	server, err := NewMITMFlowServer(nil, nil)
+	require.NoError(t, err)
+	flow := tcpFlow(6379, nil)
+	server.preprocessFlow(flow)
+
+	assert.True(t, matchFlow(flow, mitmflowv1.FlowFilter_builder{Protocols: []string{"http1", "TCP-Redis"}}.Build()))
+	assert.False(t, matchFlow(flow, mitmflowv1.FlowFilter_builder{Protocols: []string{"tcp"}}.Build()))
+
+	pred, err := parseFilterExpr("~proto ^tcp-")
+	require.NoError(t, err)
+	assert.True(t, pred(flow))
+	pred, err = parseFilterExpr("~proto grpc")
+	require.NoError(t, err)
+	assert.False(t, pred(flow))
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"github.com/gabriel-vasile/mimetype"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxSniffedFieldNumber is the largest field number a body may use and still
+// be taken for protobuf. Real schemas rarely go this high, while the tags
+// read from random bytes usually do.
+const maxSniffedFieldNumber = 1 << 16
+
+// looksLikeProtobuf reports whether content parses cleanly as a protobuf
+// message: every field has a plausible number and a wire type other than the
+// deprecated groups, and the last one ends exactly at the end of content.
+func looksLikeProtobuf(content []byte) bool {
+	if len(content) < 2 {
+		return false
+	}
+	for len(content) > 0 {
+		num, typ, n := protowire.ConsumeTag(content)
+		if n < 0 || num > maxSniffedFieldNumber {
+			return false
+		}
+		switch typ {
+		case protowire.VarintType, protowire.Fixed32Type, protowire.Fixed64Type, protowire.BytesType:
+		default:
+			return false
+		}
+		m := protowire.ConsumeFieldValue(num, typ, content[n:])
+		if m < 0 {
+			return false
+		}
+		content = content[n+m:]
+	}
+	return true
+}
+
+// setSniffedProtobufFrames decodes a binary body that no content type
+// explained as protobuf, when it looks like it is. Bodies mimetype recognizes,
+// like images or archives, are left alone.
+func setSniffedProtobufFrames(content []byte, headers map[string]string, details *mitmflowv1.MessageDetails, msgDesc protoreflect.MessageDescriptor) {
+	if len(details.GetTextualFrames()) > 0 {
+		return
+	}
+	if decoded, ok := decodeContentEncoding(content, getHeaderValue(headers, "Content-Encoding")); ok {
+		content = decoded
+	}
+	if !isBinary(content) || !mimetype.Detect(content).Is("application/octet-stream") || !looksLikeProtobuf(content) {
+		return
+	}
+	details.SetEffectiveContentType("application/x-protobuf")
+	details.SetTextualFrames(processProtobufMessage(content, msgDesc))
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestLooksLikeProtobuf(t *testing.T) {
+	msg, err := proto.Marshal(mitmproxyv1.Request_builder{
+		Method:  proto.String("GET"),
+		Url:     proto.String("https://example.com/"),
+		Content: []byte{0xff, 0x00},
+	}.Build())
+	require.NoError(t, err)
+	assert.True(t, looksLikeProtobuf(msg))
+
+	assert.False(t, looksLikeProtobuf(msg[:len(msg)-1]), "truncated")
+	assert.False(t, looksLikeProtobuf([]byte{0x08}), "too short")
+	assert.False(t, looksLikeProtobuf([]byte{0x0b, 0x0c}), "group")
+	assert.False(t, looksLikeProtobuf([]byte{0xf8, 0xff, 0xff, 0x7f, 0x01}), "huge field number")
+	assert.False(t, looksLikeProtobuf([]byte("\x89PNG\r\n\x1a\n")))
+}
+
+func TestSetSniffedProtobufFrames(t *testing.T) {
+	msg, err := proto.Marshal(mitmproxyv1.Request_builder{
+		Method:           proto.String("GET"),
+		ContentTruncated: proto.Bool(true),
+	}.Build())
+	require.NoError(t, err)
+	headers := map[string]string{"Content-Type": "application/octet-stream"}
+
+	details := &mitmflowv1.MessageDetails{}
+	setSniffedProtobufFrames(msg, headers, details, nil)
+	assert.Equal(t, "application/x-protobuf", details.GetEffectiveContentType())
+	require.Len(t, details.GetTextualFrames(), 1)
+	assert.Contains(t, details.GetTextualFrames()[0], `"GET"`)
+
+	details = &mitmflowv1.MessageDetails{}
+	setSniffedProtobufFrames(msg, map[string]string{"Content-Type": "application/vnd.example.v2"}, details, (&mitmproxyv1.Request{}).ProtoReflect().Descriptor())
+	require.Len(t, details.GetTextualFrames(), 1)
+	assert.Contains(t, details.GetTextualFrames()[0], `"contentTruncated"`)
+
+	// Text and bodies that already have frames are left alone.
+	details = &mitmflowv1.MessageDetails{}
+	setSniffedProtobufFrames([]byte("hello"), headers, details, nil)
+	assert.Empty(t, details.GetTextualFrames())
+	details = mitmflowv1.MessageDetails_builder{TextualFrames: []string{"decoded"}}.Build()
+	setSniffedProtobufFrames(msg, headers, details, nil)
+	assert.Equal(t, []string{"decoded"}, details.GetTextualFrames())
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// proxyRules holds the rules mitmproxy is asked to apply to the traffic it
+// proxies. mitmflow doesn't apply them itself: the mitmproxy addon watches
+// them through WatchProxyRules and applies the latest ones it got. They are
+// kept in filename, when set, so they survive restarts.
+type proxyRules struct {
+	mu       sync.RWMutex
+	filename string
+	rules    *mitmflowv1.ProxyRules
+	// changed is closed, and replaced, whenever the rules change, which
+	// wakes up the watchers.
+	changed chan struct{}
+}
+
+func newProxyRules() *proxyRules {
+	return &proxyRules{
+		rules:   &mitmflowv1.ProxyRules{},
+		changed: make(chan struct{}),
+	}
+}
+
+// load reads the rules saved by an earlier run, if any.
+func (r *proxyRules) load() error {
+	if r == nil || r.filename == "" {
+		return nil
+	}
+	data, err := os.ReadFile(r.filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	rules := &mitmflowv1.ProxyRules{}
+	if err := proto.Unmarshal(data, rules); err != nil {
+		return fmt.Errorf("invalid proxy rules %s: %w", r.filename, err)
+	}
+	if err := validateProxyRules(rules); err != nil {
+		return fmt.Errorf("invalid proxy rules %s: %w", r.filename, err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules = rules
+	return nil
+}
+
+// get returns the current rules, which must not be modified, and a channel
+// that is closed once they change.
+func (r *proxyRules) get() (*mitmflowv1.ProxyRules, <-chan struct{}) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.rules, r.changed
+}
+
+// set validates and replaces the rules, saves them and wakes up the
+// watchers.
+func (r *proxyRules) set(rules *mitmflowv1.ProxyRules) error {
+	if rules == nil {
+		rules = &mitmflowv1.ProxyRules{}
+	}
+	if err := validateProxyRules(rules); err != nil {
+		return err
+	}
+	rules = proto.Clone(rules).(*mitmflowv1.ProxyRules)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.filename != "" {
+		data, err := proto.Marshal(rules)
+		if err != nil {
+			return err
+		}
+		if err := writeFileAtomic(r.filename, data, 0644); err != nil {
+			return err
+		}
+	}
+	r.rules = rules
+	close(r.changed)
+	r.changed = make(chan struct{})
+	return nil
+}
+
+// errInvalidProxyRules wraps the problems validateProxyRules finds, so they
+// can be told apart from failures to save the rules.
+var errInvalidProxyRules = errors.New("invalid proxy rules")
+
+// validateProxyRules checks what the protovalidate constraints can't: that
+// rule names are unique, status codes are real and an override has at most
+// one body. The ranges are checked again because rules are also loaded from
+// disk.
+func validateProxyRules(rules *mitmflowv1.ProxyRules) error {
+	names := make(map[string]bool)
+	for _, rule := range rules.GetOverrides() {
+		name := rule.GetName()
+		if err := checkProxyRuleName("override", name, names); err != nil {
+			return err
+		}
+		if code := rule.GetStatusCode(); code != 0 && (code < 100 || code > 599) {
+			return fmt.Errorf("%w: override %q has status code %d", errInvalidProxyRules, name, code)
+		}
+		if len(rule.GetBody()) > 0 && rule.GetFile() != "" {
+			return fmt.Errorf("%w: override %q has both a body and a file", errInvalidProxyRules, name)
+		}
+	}
+	names = make(map[string]bool)
+	for _, rule := range rules.GetThrottles() {
+		name := rule.GetName()
+		if err := checkProxyRuleName("throttle", name, names); err != nil {
+			return err
+		}
+		if rule.GetRequestDelayMs() < 0 || rule.GetResponseDelayMs() < 0 {
+			return fmt.Errorf("%w: throttle %q has a negative delay", errInvalidProxyRules, name)
+		}
+		if rule.GetUploadBytesPerSecond() < 0 || rule.GetDownloadBytesPerSecond() < 0 {
+			return fmt.Errorf("%w: throttle %q has a negative bandwidth limit", errInvalidProxyRules, name)
+		}
+	}
+	return nil
+}
+
+// checkProxyRuleName checks that a rule has a name that isn't in names yet,
+// and adds it.
+func checkProxyRuleName(kind, name string, names map[string]bool) error {
+	if name == "" {
+		return fmt.Errorf("%w: %s without a name", errInvalidProxyRules, kind)
+	}
+	if names[name] {
+		return fmt.Errorf("%w: more than one %s named %q", errInvalidProxyRules, kind, name)
+	}
+	names[name] = true
+	return nil
+}
+
+// GetProxyRules returns the current proxy rules.
+func (s *MITMFlowServer) GetProxyRules(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.GetProxyRulesRequest],
+) (*connect.Response[mitmflowv1.GetProxyRulesResponse], error) {
+	rules, _ := s.proxyRules.get()
+	return connect.NewResponse(mitmflowv1.GetProxyRulesResponse_builder{
+		Rules: rules,
+	}.Build()), nil
+}
+
+// SetProxyRules replaces the proxy rules and relays them to the watchers.
+func (s *MITMFlowServer) SetProxyRules(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.SetProxyRulesRequest],
+) (*connect.Response[mitmflowv1.SetProxyRulesResponse], error) {
+	if err := s.proxyRules.set(req.Msg.GetRules()); err != nil {
+		if errors.Is(err, errInvalidProxyRules) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		log.Printf("Failed to save proxy rules: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	rules, _ := s.proxyRules.get()
+	detail := fmt.Sprintf("%d overrides and %d throttles", len(rules.GetOverrides()), len(rules.GetThrottles()))
+	log.Printf("Proxy rules set with %s", detail)
+	s.audit(ctx, req.Peer(), mitmflowv1.AuditAction_AUDIT_ACTION_SET_PROXY_RULES, nil, 0, detail)
+	return connect.NewResponse(mitmflowv1.SetProxyRulesResponse_builder{
+		Rules: rules,
+	}.Build()), nil
+}
+
+// WatchProxyRules sends the proxy rules, and then sends them again whenever
+// they change, until the client goes away. Keepalives are sent in between.
+func (s *MITMFlowServer) WatchProxyRules(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.WatchProxyRulesRequest],
+	stream *connect.ServerStream[mitmflowv1.WatchProxyRulesResponse],
+) error {
+	log.Printf("Proxy rules watched by %s", req.Peer().Addr)
+	var keepalive <-chan time.Time
+	var timer *time.Timer
+	if s.keepalive > 0 {
+		timer = time.NewTimer(s.keepalive)
+		defer timer.Stop()
+		keepalive = timer.C
+	}
+	for {
+		rules, changed := s.proxyRules.get()
+		if err := stream.Send(mitmflowv1.WatchProxyRulesResponse_builder{
+			Rules: rules,
+		}.Build()); err != nil {
+			return err
+		}
+		if timer != nil {
+			timer.Reset(s.keepalive)
+		}
+	wait:
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-changed:
+				break wait
+			case <-keepalive:
+				if err := stream.Send(mitmflowv1.WatchProxyRulesResponse_builder{
+					Keepalive: mitmflowv1.Keepalive_builder{
+						IntervalMs: proto.Int64(s.keepalive.Milliseconds()),
+					}.Build(),
+				}.Build()); err != nil {
+					return err
+				}
+				timer.Reset(s.keepalive)
+			}
+		}
+	}
+}
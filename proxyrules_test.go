@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestProxyRules(t *testing.T) {
+	dir, err := os.MkdirTemp("", "mitmflow_proxy_rules")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(dir)) })
+	storage, err := NewFlowStorage(dir, 100)
+	require.NoError(t, err)
+	t.Cleanup(storage.Close)
+	rulesFile := filepath.Join(dir, "proxy_rules.binpb")
+	server, err := NewMITMFlowServer(storage, nil, WithProxyRulesFile(rulesFile))
+	require.NoError(t, err)
+
+	resp, err := server.GetProxyRules(context.Background(), connect.NewRequest(&mitmflowv1.GetProxyRulesRequest{}))
+	require.NoError(t, err)
+	assert.Empty(t, resp.Msg.GetRules().GetOverrides())
+
+	rules := mitmflowv1.ProxyRules_builder{
+		Overrides: []*mitmflowv1.OverrideRule{
+			mitmflowv1.OverrideRule_builder{
+				Name: proto.String("stub users"),
+				Match: mitmflowv1.ProxyRuleMatch_builder{
+					Host:    proto.String("api.example.com"),
+					Path:    proto.String("/v1/users/*"),
+					Methods: []string{"GET"},
+				}.Build(),
+				StatusCode: proto.Int32(200),
+				Headers:    map[string]string{"Content-Type": "application/json"},
+				Body:       []byte(`{"name":"stub"}`),
+			}.Build(),
+			mitmflowv1.OverrideRule_builder{
+				Name:     proto.String("local bundle"),
+				Disabled: proto.Bool(true),
+				Match:    mitmflowv1.ProxyRuleMatch_builder{Path: proto.String("/static/app.js")}.Build(),
+				File:     proto.String("/home/dev/app/dist/app.js"),
+			}.Build(),
+		},
+		Throttles: []*mitmflowv1.ThrottleRule{
+			mitmflowv1.ThrottleRule_builder{
+				Name:                   proto.String("3G"),
+				Match:                  mitmflowv1.ProxyRuleMatch_builder{Host: proto.String("*.example.com")}.Build(),
+				RequestDelayMs:         proto.Int32(100),
+				ResponseDelayMs:        proto.Int32(300),
+				UploadBytesPerSecond:   proto.Int64(96_000),
+				DownloadBytesPerSecond: proto.Int64(200_000),
+			}.Build(),
+		},
+	}.Build()
+	_, err = server.SetProxyRules(context.Background(), connect.NewRequest(mitmflowv1.SetProxyRulesRequest_builder{Rules: rules}.Build()))
+	require.NoError(t, err)
+	require.FileExists(t, rulesFile)
+
+	// A restarted server picks up the saved rules.
+	server, err = NewMITMFlowServer(storage, nil, WithProxyRulesFile(rulesFile))
+	require.NoError(t, err)
+	resp, err = server.GetProxyRules(context.Background(), connect.NewRequest(&mitmflowv1.GetProxyRulesRequest{}))
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(rules, resp.Msg.GetRules()))
+
+	for name, override := range map[string]*mitmflowv1.OverrideRule{
+		"no name":      mitmflowv1.OverrideRule_builder{}.Build(),
+		"bad status":   mitmflowv1.OverrideRule_builder{Name: proto.String("x"), StatusCode: proto.Int32(42)}.Build(),
+		"body or file": mitmflowv1.OverrideRule_builder{Name: proto.String("x"), Body: []byte("a"), File: proto.String("a.txt")}.Build(),
+	} {
+		_, err := server.SetProxyRules(context.Background(), connect.NewRequest(mitmflowv1.SetProxyRulesRequest_builder{
+			Rules: mitmflowv1.ProxyRules_builder{Overrides: []*mitmflowv1.OverrideRule{override}}.Build(),
+		}.Build()))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), name)
+	}
+	for name, throttle := range map[string]*mitmflowv1.ThrottleRule{
+		"no name":        mitmflowv1.ThrottleRule_builder{}.Build(),
+		"negative delay": mitmflowv1.ThrottleRule_builder{Name: proto.String("x"), ResponseDelayMs: proto.Int32(-1)}.Build(),
+		"negative limit": mitmflowv1.ThrottleRule_builder{Name: proto.String("x"), DownloadBytesPerSecond: proto.Int64(-1)}.Build(),
+	} {
+		_, err := server.SetProxyRules(context.Background(), connect.NewRequest(mitmflowv1.SetProxyRulesRequest_builder{
+			Rules: mitmflowv1.ProxyRules_builder{Throttles: []*mitmflowv1.ThrottleRule{throttle}}.Build(),
+		}.Build()))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), name)
+	}
+	duplicate := mitmflowv1.OverrideRule_builder{Name: proto.String("x")}.Build()
+	_, err = server.SetProxyRules(context.Background(), connect.NewRequest(mitmflowv1.SetProxyRulesRequest_builder{
+		Rules: mitmflowv1.ProxyRules_builder{Overrides: []*mitmflowv1.OverrideRule{duplicate, duplicate}}.Build(),
+	}.Build()))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	resp, err = server.GetProxyRules(context.Background(), connect.NewRequest(&mitmflowv1.GetProxyRulesRequest{}))
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(rules, resp.Msg.GetRules()), "invalid rules are rejected as a whole")
+
+	// Overrides and throttles may share names.
+	_, err = server.SetProxyRules(context.Background(), connect.NewRequest(mitmflowv1.SetProxyRulesRequest_builder{
+		Rules: mitmflowv1.ProxyRules_builder{
+			Overrides: []*mitmflowv1.OverrideRule{duplicate},
+			Throttles: []*mitmflowv1.ThrottleRule{mitmflowv1.ThrottleRule_builder{Name: proto.String("x")}.Build()},
+		}.Build(),
+	}.Build()))
+	require.NoError(t, err)
+}
+
+func TestWatchProxyRules(t *testing.T) {
+	server, _ := newTestServer(t)
+	server.keepalive = 50 * time.Millisecond
+	mux := http.NewServeMux()
+	mux.Handle(mitmflowv1.NewServiceHandler(server))
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := mitmflowv1.NewServiceClient(ts.Client(), ts.URL).WatchProxyRules(ctx, connect.NewRequest(&mitmflowv1.WatchProxyRulesRequest{}))
+	require.NoError(t, err)
+	defer stream.Close()
+
+	require.True(t, stream.Receive(), stream.Err())
+	require.True(t, stream.Msg().HasRules(), "the current rules are sent right away")
+	assert.Empty(t, stream.Msg().GetRules().GetOverrides())
+
+	require.True(t, stream.Receive(), stream.Err())
+	assert.True(t, stream.Msg().HasKeepalive(), "idle watchers get keepalives")
+
+	_, err = server.SetProxyRules(ctx, connect.NewRequest(mitmflowv1.SetProxyRulesRequest_builder{
+		Rules: mitmflowv1.ProxyRules_builder{
+			Overrides: []*mitmflowv1.OverrideRule{
+				mitmflowv1.OverrideRule_builder{Name: proto.String("teapot"), StatusCode: proto.Int32(418)}.Build(),
+			},
+		}.Build(),
+	}.Build()))
+	require.NoError(t, err)
+	for stream.Receive() && !stream.Msg().HasRules() {
+	}
+	require.NoError(t, stream.Err())
+	require.Len(t, stream.Msg().GetRules().GetOverrides(), 1)
+	assert.Equal(t, "teapot", stream.Msg().GetRules().GetOverrides()[0].GetName())
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// maxRedirectHops is how many redirects browsers follow before giving up.
+	maxRedirectHops = 20
+	// redirectMaxGap is how long after a redirect the request following it
+	// may start and still be linked to it.
+	redirectMaxGap = 30 * time.Second
+)
+
+// GetRedirectChain links a flow with the redirects that led to it and the
+// ones it led to, by matching each Location header with the next request from
+// the same client.
+func (s *MITMFlowServer) GetRedirectChain(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.GetRedirectChainRequest],
+) (*connect.Response[mitmflowv1.GetRedirectChainResponse], error) {
+	id := req.Msg.GetFlowId()
+	var flows []*mitmflowv1.Flow
+	idx := -1
+	visible := visibleTo(ctx)
+	s.storage.Walk(func(flow *mitmflowv1.Flow) bool {
+		if flow.GetHttpFlow() == nil || !visible(flow) {
+			return true
+		}
+		if GetFlowID(flow) == id {
+			idx = len(flows)
+		}
+		flows = append(flows, flow)
+		return true
+	})
+	if idx < 0 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("http flow not found: %s", id))
+	}
+
+	var hops []*mitmflowv1.RedirectHop
+	for _, i := range redirectChain(flows, idx) {
+		hops = append(hops, redirectHop(flows[i]))
+	}
+	return connect.NewResponse(mitmflowv1.GetRedirectChainResponse_builder{Hops: hops}.Build()), nil
+}
+
+// redirectChain returns the indexes of the flows in the chain through
+// flows[idx], oldest first. flows must be HTTP flows in start time order.
+func redirectChain(flows []*mitmflowv1.Flow, idx int) []int {
+	chain := []int{idx}
+	seen := map[int]bool{idx: true}
+	for first := idx; len(chain) < maxRedirectHops; {
+		prev := -1
+		for i := first - 1; i >= 0; i-- {
+			if redirectsTo(flows[i], flows[first]) {
+				prev = i
+				break
+			}
+		}
+		if prev < 0 || seen[prev] {
+			break
+		}
+		seen[prev] = true
+		chain = append([]int{prev}, chain...)
+		first = prev
+	}
+	for last := idx; len(chain) < maxRedirectHops; {
+		next := -1
+		for i := last + 1; i < len(flows); i++ {
+			if redirectsTo(flows[last], flows[i]) {
+				next = i
+				break
+			}
+		}
+		if next < 0 || seen[next] {
+			break
+		}
+		seen[next] = true
+		chain = append(chain, next)
+		last = next
+	}
+	return chain
+}
+
+// redirectsTo reports whether from is a redirect whose Location is the URL
+// that to requested, from the same client shortly afterwards.
+func redirectsTo(from, to *mitmflowv1.Flow) bool {
+	location := redirectLocation(from)
+	if location == "" || !sameRedirectURL(location, to.GetHttpFlow().GetRequest().GetUrl()) {
+		return false
+	}
+	fromClient := from.GetHttpFlow().GetClient().GetPeernameHost()
+	toClient := to.GetHttpFlow().GetClient().GetPeernameHost()
+	if fromClient != "" && toClient != "" && fromClient != toClient {
+		return false
+	}
+	gap := time.Duration(GetFlowStartTime(to) - GetFlowStartTime(from))
+	return gap >= 0 && gap <= redirectMaxGap
+}
+
+// redirectLocation returns the absolute Location of a 3xx response, or ""
+// when the flow isn't a redirect.
+func redirectLocation(flow *mitmflowv1.Flow) string {
+	h := flow.GetHttpFlow()
+	if code := h.GetResponse().GetStatusCode(); code < 300 || code > 399 {
+		return ""
+	}
+	location := getHeaderValue(h.GetResponse().GetHeaders(), "Location")
+	if location == "" {
+		return ""
+	}
+	base, err := url.Parse(h.GetRequest().GetUrl())
+	if err != nil {
+		return ""
+	}
+	ref, err := url.Parse(location)
+	if err != nil {
+		return ""
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// sameRedirectURL compares URLs the way a followed redirect changes them:
+// the fragment stays in the browser and scheme and host are case-insensitive.
+func sameRedirectURL(a, b string) bool {
+	ua, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+	ub, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(ua.Scheme, ub.Scheme) && strings.EqualFold(ua.Hostname(), ub.Hostname()) &&
+		originPort(ua) == originPort(ub) && ua.EscapedPath() == ub.EscapedPath() && ua.RawQuery == ub.RawQuery
+}
+
+func redirectHop(flow *mitmflowv1.Flow) *mitmflowv1.RedirectHop {
+	h := flow.GetHttpFlow()
+	hop := mitmflowv1.RedirectHop_builder{
+		FlowId:   proto.String(GetFlowID(flow)),
+		Method:   proto.String(h.GetRequest().GetMethod()),
+		Url:      proto.String(h.GetRequest().GetUrl()),
+		Location: proto.String(redirectLocation(flow)),
+	}.Build()
+	if h.HasResponse() {
+		hop.SetStatusCode(h.GetResponse().GetStatusCode())
+	}
+	return hop
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetRedirectChain(t *testing.T) {
+	dir, err := os.MkdirTemp("", "mitmflow_redirects")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(dir)) })
+	storage, err := NewFlowStorage(dir, 100)
+	require.NoError(t, err)
+	defer storage.Close()
+	server, err := NewMITMFlowServer(storage, nil)
+	require.NoError(t, err)
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+	client := withClientIP("10.0.0.1")
+	for _, flow := range []*mitmflowv1.Flow{
+		createHTTPFlow("short", "http://sho.rt/abc", at(0), client, withStatus(301),
+			withResponseHeaders(map[string]string{"location": "https://sho.rt/abc"})),
+		createHTTPFlow("https", "https://sho.rt/abc", at(100), client, withStatus(302),
+			withResponseHeaders(map[string]string{"location": "https://auth.example.com/authorize?client_id=1#frag"})),
+		// Another client opening the same link isn't part of the chain.
+		createHTTPFlow("other-client", "https://auth.example.com/authorize?client_id=1", at(150), withClientIP("10.0.0.2")),
+		createHTTPFlow("unrelated", "https://cdn.example.com/app.js", at(180), client),
+		createHTTPFlow("authorize", "https://AUTH.example.com:443/authorize?client_id=1", at(200), client, withStatus(302),
+			withResponseHeaders(map[string]string{"location": "/callback?code=xyz"})),
+		createHTTPFlow("callback", "https://auth.example.com/callback?code=xyz", at(300), client),
+		// Too late to be the request that followed the redirect.
+		createHTTPFlow("reload", "https://auth.example.com/callback?code=xyz", at(60000), client),
+	} {
+		require.NoError(t, storage.SaveFlow(flow))
+	}
+
+	chain := func(id string) []string {
+		resp, err := server.GetRedirectChain(context.Background(), connect.NewRequest(
+			mitmflowv1.GetRedirectChainRequest_builder{FlowId: proto.String(id)}.Build()))
+		require.NoError(t, err)
+		var ids []string
+		for _, hop := range resp.Msg.GetHops() {
+			ids = append(ids, hop.GetFlowId())
+		}
+		return ids
+	}
+	want := []string{"short", "https", "authorize", "callback"}
+	assert.Equal(t, want, chain("short"))
+	assert.Equal(t, want, chain("authorize"))
+	assert.Equal(t, want, chain("callback"))
+	assert.Equal(t, []string{"unrelated"}, chain("unrelated"))
+	assert.Equal(t, []string{"reload"}, chain("reload"))
+
+	resp, err := server.GetRedirectChain(context.Background(), connect.NewRequest(
+		mitmflowv1.GetRedirectChainRequest_builder{FlowId: proto.String("authorize")}.Build()))
+	require.NoError(t, err)
+	hop := resp.Msg.GetHops()[2]
+	assert.Equal(t, int32(302), hop.GetStatusCode())
+	assert.Equal(t, "https://AUTH.example.com:443/callback?code=xyz", hop.GetLocation())
+
+	_, err = server.GetRedirectChain(context.Background(), connect.NewRequest(
+		mitmflowv1.GetRedirectChainRequest_builder{FlowId: proto.String("missing")}.Build()))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
@@ -51,6 +51,14 @@ func (r *Registry) LoadFromFiles(paths []string) error {
 	return nil
 }
 
+// NumFiles returns the number of loaded descriptor files.
+func (r *Registry) NumFiles() int {
+	if r == nil || r.files == nil {
+		return 0
+	}
+	return r.files.NumFiles()
+}
+
 // LookupMethod resolves a gRPC path (e.g. "/package.Service/Method") to input and output message descriptors.
 func (r *Registry) LookupMethod(path string) (protoreflect.MessageDescriptor, protoreflect.MessageDescriptor, error) {
 	if r.files == nil {
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+)
+
+// GenerateSAZ writes HTTP flows as a Fiddler session archive: a zip holding
+// the raw request (raw/NN_c.txt), raw response (raw/NN_s.txt) and session
+// metadata (raw/NN_m.xml) of each session.
+func GenerateSAZ(flows []*mitmflowv1.Flow) ([]byte, error) {
+	var httpFlows []*mitmflowv1.Flow
+	for _, f := range flows {
+		if f.GetHttpFlow() != nil {
+			httpFlows = append(httpFlows, f)
+		}
+	}
+	width := max(2, len(strconv.Itoa(len(httpFlows))))
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for i, f := range httpFlows {
+		h := f.GetHttpFlow()
+		prefix := fmt.Sprintf("raw/%0*d", width, i+1)
+		meta, err := sazMetadata(i+1, f)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range []struct {
+			name string
+			data []byte
+		}{
+			{prefix + "_c.txt", rawHTTPRequest(h.GetRequest())},
+			{prefix + "_s.txt", rawHTTPResponse(h.GetResponse())},
+			{prefix + "_m.xml", meta},
+		} {
+			w, err := zw.Create(file.name)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := w.Write(file.data); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// rawHTTPRequest renders a request as it would appear on the wire to a proxy,
+// with an absolute URL. Bodies are stored whole, so any Transfer-Encoding is
+// replaced with a Content-Length.
+func rawHTTPRequest(req *mitmproxyv1.Request) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %s %s\r\n", req.GetMethod(), getPrettyURL(req), httpVersionOrDefault(req.GetHttpVersion()))
+	writeRawHeaders(&buf, req.GetHeaders(), req.GetContent())
+	buf.Write(req.GetContent())
+	return buf.Bytes()
+}
+
+func rawHTTPResponse(res *mitmproxyv1.Response) []byte {
+	if res == nil {
+		return nil
+	}
+	var buf bytes.Buffer
+	reason := res.GetReason()
+	if reason == "" {
+		reason = http.StatusText(int(res.GetStatusCode()))
+	}
+	fmt.Fprintf(&buf, "%s %d %s\r\n", httpVersionOrDefault(res.GetHttpVersion()), res.GetStatusCode(), reason)
+	writeRawHeaders(&buf, res.GetHeaders(), res.GetContent())
+	buf.Write(res.GetContent())
+	return buf.Bytes()
+}
+
+func writeRawHeaders(buf *bytes.Buffer, headers map[string]string, body []byte) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		switch strings.ToLower(name) {
+		case "transfer-encoding", "content-length":
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(buf, "%s: %s\r\n", name, headers[name])
+	}
+	if len(body) > 0 || getHeaderValue(headers, "Content-Length") != "" {
+		fmt.Fprintf(buf, "Content-Length: %d\r\n", len(body))
+	}
+	buf.WriteString("\r\n")
+}
+
+// httpVersionOrDefault maps mitmproxy's HTTP/2 and HTTP/3 versions to
+// HTTP/1.1, which is the only framing a raw session file can describe.
+func httpVersionOrDefault(version string) string {
+	if strings.HasPrefix(version, "HTTP/1.") {
+		return version
+	}
+	return "HTTP/1.1"
+}
+
+type sazSession struct {
+	XMLName xml.Name        `xml:"Session"`
+	SID     int             `xml:"SID,attr"`
+	Flags   int             `xml:"BitFlags,attr"`
+	Timers  sazTimers       `xml:"SessionTimers"`
+	Vars    []sazSessionVar `xml:"SessionFlags>SessionFlag"`
+}
+
+type sazTimers struct {
+	ClientConnected     string `xml:"ClientConnected,attr"`
+	ClientBeginRequest  string `xml:"ClientBeginRequest,attr"`
+	GotRequestHeaders   string `xml:"GotRequestHeaders,attr"`
+	ClientDoneRequest   string `xml:"ClientDoneRequest,attr"`
+	ServerConnected     string `xml:"ServerConnected,attr"`
+	FiddlerBeginRequest string `xml:"FiddlerBeginRequest,attr"`
+	ServerGotRequest    string `xml:"ServerGotRequest,attr"`
+	ServerBeginResponse string `xml:"ServerBeginResponse,attr"`
+	GotResponseHeaders  string `xml:"GotResponseHeaders,attr"`
+	ServerDoneResponse  string `xml:"ServerDoneResponse,attr"`
+	ClientBeginResponse string `xml:"ClientBeginResponse,attr"`
+	ClientDoneResponse  string `xml:"ClientDoneResponse,attr"`
+	DNSTime             int64  `xml:"DNSTime,attr"`
+	TCPConnectTime      int64  `xml:"TCPConnectTime,attr"`
+	HTTPSHandshakeTime  int64  `xml:"HTTPSHandshakeTime,attr"`
+}
+
+type sazSessionVar struct {
+	Name  string `xml:"N,attr"`
+	Value string `xml:"V,attr"`
+}
+
+func sazMetadata(sid int, flow *mitmflowv1.Flow) ([]byte, error) {
+	h := flow.GetHttpFlow()
+	req, res := h.GetRequest(), h.GetResponse()
+	format := func(ts interface {
+		GetSeconds() int64
+		GetNanos() int32
+	}) string {
+		t := getFlowTime(ts)
+		if t.IsZero() {
+			return "0001-01-01T00:00:00"
+		}
+		return t.Format("2006-01-02T15:04:05.0000000-07:00")
+	}
+
+	var connectMs, tlsMs int64
+	server := h.GetServer()
+	connStart := getFlowTime(server.GetTimestampStart())
+	tcpSetup := getFlowTime(server.GetTimestampTcpSetup())
+	tlsSetup := getFlowTime(server.GetTimestampTlsSetup())
+	if !connStart.IsZero() && tcpSetup.After(connStart) {
+		connectMs = tcpSetup.Sub(connStart).Milliseconds()
+	}
+	if !tcpSetup.IsZero() && tlsSetup.After(tcpSetup) {
+		tlsMs = tlsSetup.Sub(tcpSetup).Milliseconds()
+	}
+
+	session := sazSession{
+		SID: sid,
+		Timers: sazTimers{
+			ClientConnected:     format(h.GetClient().GetTimestampStart()),
+			ClientBeginRequest:  format(req.GetTimestampStart()),
+			GotRequestHeaders:   format(req.GetTimestampStart()),
+			ClientDoneRequest:   format(req.GetTimestampEnd()),
+			ServerConnected:     format(server.GetTimestampStart()),
+			FiddlerBeginRequest: format(req.GetTimestampEnd()),
+			ServerGotRequest:    format(req.GetTimestampEnd()),
+			ServerBeginResponse: format(res.GetTimestampStart()),
+			GotResponseHeaders:  format(res.GetTimestampStart()),
+			ServerDoneResponse:  format(res.GetTimestampEnd()),
+			ClientBeginResponse: format(res.GetTimestampStart()),
+			ClientDoneResponse:  format(res.GetTimestampEnd()),
+			TCPConnectTime:      connectMs,
+			HTTPSHandshakeTime:  tlsMs,
+		},
+		Vars: []sazSessionVar{
+			{Name: "x-clientip", Value: h.GetClient().GetPeernameHost()},
+			{Name: "x-clientport", Value: strconv.Itoa(int(h.GetClient().GetPeernamePort()))},
+			{Name: "x-hostip", Value: server.GetAddressHost()},
+		},
+	}
+	if flow.GetNote() != "" {
+		session.Vars = append(session.Vars, sazSessionVar{Name: "ui-comments", Value: flow.GetNote()})
+	}
+	if flow.GetPinned() {
+		session.Vars = append(session.Vars, sazSessionVar{Name: "ui-bold", Value: "true"})
+	}
+	// Fiddler keeps custom session flags in the same list, sorted so the
+	// output is stable.
+	metadata := flow.GetMetadata()
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		session.Vars = append(session.Vars, sazSessionVar{Name: "x-meta-" + k, Value: metadata[k]})
+	}
+
+	data, err := xml.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
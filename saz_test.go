@@ -0,0 +1,107 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func exportTestFlow() *mitmflowv1.Flow {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return mitmflowv1.Flow_builder{
+		Note:     proto.String("checkout"),
+		Metadata: map[string]string{"ticket": "OPS-12"},
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Request: mitmproxyv1.Request_builder{
+				Method:         proto.String("POST"),
+				Url:            proto.String("https://example.com/api?q=1"),
+				HttpVersion:    proto.String("HTTP/2.0"),
+				Headers:        map[string]string{"content-type": "application/json", "transfer-encoding": "chunked"},
+				Content:        []byte(`{"a":1}`),
+				TimestampStart: timestamppb.New(start),
+				TimestampEnd:   timestamppb.New(start.Add(10 * time.Millisecond)),
+			}.Build(),
+			Response: mitmproxyv1.Response_builder{
+				StatusCode:     proto.Int32(201),
+				HttpVersion:    proto.String("HTTP/2.0"),
+				Headers:        map[string]string{"content-type": "application/octet-stream"},
+				Content:        []byte{0x00, 0x01, 0x02},
+				TimestampStart: timestamppb.New(start.Add(50 * time.Millisecond)),
+				TimestampEnd:   timestamppb.New(start.Add(60 * time.Millisecond)),
+			}.Build(),
+		}.Build(),
+	}.Build()
+}
+
+func TestGenerateSAZ(t *testing.T) {
+	tcp := mitmflowv1.Flow_builder{TcpFlow: &mitmproxyv1.TCPFlow{}}.Build()
+	data, err := GenerateSAZ([]*mitmflowv1.Flow{tcp, exportTestFlow()})
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		files[f.Name] = string(b)
+	}
+	require.Len(t, files, 3)
+
+	assert.Equal(t, "POST https://example.com/api?q=1 HTTP/1.1\r\n"+
+		"content-type: application/json\r\n"+
+		"Content-Length: 7\r\n"+
+		"\r\n"+
+		`{"a":1}`, files["raw/01_c.txt"])
+	assert.Equal(t, "HTTP/1.1 201 Created\r\n"+
+		"content-type: application/octet-stream\r\n"+
+		"Content-Length: 3\r\n"+
+		"\r\n"+
+		"\x00\x01\x02", files["raw/01_s.txt"])
+	assert.Contains(t, files["raw/01_m.xml"], `<Session SID="1" BitFlags="0">`)
+	assert.Contains(t, files["raw/01_m.xml"], `ClientBeginRequest="2024-01-01T00:00:00.0000000+00:00"`)
+	assert.Contains(t, files["raw/01_m.xml"], `<SessionFlag N="ui-comments" V="checkout"></SessionFlag>`)
+	assert.Contains(t, files["raw/01_m.xml"], `<SessionFlag N="x-meta-ticket" V="OPS-12"></SessionFlag>`)
+}
+
+func TestGenerateCharles(t *testing.T) {
+	data, err := GenerateCharles([]*mitmflowv1.Flow{exportTestFlow()})
+	require.NoError(t, err)
+	var sessions []CharlesSession
+	require.NoError(t, json.Unmarshal(data, &sessions))
+	require.Len(t, sessions, 1)
+
+	s := sessions[0]
+	assert.Equal(t, "COMPLETE", s.Status)
+	assert.Equal(t, "https", s.Scheme)
+	assert.Equal(t, "example.com", s.Host)
+	assert.Equal(t, 443, s.ActualPort)
+	assert.Equal(t, "/api", s.Path)
+	require.NotNil(t, s.Query)
+	assert.Equal(t, "q=1", *s.Query)
+	assert.Equal(t, "checkout", s.Notes)
+	require.NotNil(t, s.Durations.Total)
+	assert.Equal(t, int64(60), *s.Durations.Total)
+	require.NotNil(t, s.Durations.Latency)
+	assert.Equal(t, int64(40), *s.Durations.Latency)
+	assert.Nil(t, s.Durations.Connect)
+
+	assert.Equal(t, "application/json", s.Request.MimeType)
+	assert.Equal(t, &CharlesBody{Text: `{"a":1}`}, s.Request.Body)
+	require.NotNil(t, s.Response)
+	assert.Equal(t, 201, s.Response.Status)
+	assert.Equal(t, &CharlesBody{Encoded: "AAEC"}, s.Response.Body)
+}
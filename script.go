@@ -0,0 +1,323 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+)
+
+// scriptMaxSteps bounds how much work a script may do for one flow, so a
+// script stuck in a loop can't stall ingestion.
+const scriptMaxSteps = 10_000_000
+
+// flowScript is a Starlark script that processes ingested flows. It defines
+// process(flow), which is called with each flow once it was preprocessed.
+// Through the flow it can read the flow, tag it, set its note, add textual
+// frames to its messages or drop it.
+type flowScript struct {
+	name    string
+	process starlark.Callable
+}
+
+// loadFlowScript runs a script file and picks up its process function.
+func loadFlowScript(filename string) (*flowScript, error) {
+	thread := newScriptThread(filename)
+	globals, err := starlark.ExecFileOptions(&syntax.FileOptions{}, thread, filename, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	process, ok := globals["process"].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("%s doesn't define process(flow)", filename)
+	}
+	return &flowScript{name: filename, process: process}, nil
+}
+
+func newScriptThread(name string) *starlark.Thread {
+	return &starlark.Thread{
+		Name:  name,
+		Print: func(thread *starlark.Thread, msg string) { log.Printf("%s: %s", thread.Name, msg) },
+	}
+}
+
+// run calls the script with a flow and applies what it did, reporting
+// whether it dropped the flow. When the script fails, the flow is left as
+// it was.
+func (sc *flowScript) run(flow *mitmflowv1.Flow) (bool, error) {
+	thread := newScriptThread(sc.name)
+	thread.SetMaxExecutionSteps(scriptMaxSteps)
+	v := newScriptFlow(flow)
+	if _, err := starlark.Call(thread, sc.process, starlark.Tuple{v}, nil); err != nil {
+		return false, err
+	}
+	if v.dropped {
+		return true, nil
+	}
+	v.apply(flow)
+	return false, nil
+}
+
+// runScripts runs the scripts on a flow in order and reports whether one of
+// them dropped it. A dropped flow that an earlier update of it stored is
+// deleted.
+func (s *MITMFlowServer) runScripts(flow *mitmflowv1.Flow) bool {
+	id := GetFlowID(flow)
+	for _, sc := range s.scripts {
+		dropped, err := sc.run(flow)
+		if err != nil {
+			log.Printf("script %s failed on flow %s: %v", sc.name, id, err)
+			continue
+		}
+		if dropped {
+			if _, ok := s.storage.GetFlow(id); ok {
+				if _, err := s.storage.DeleteFlows([]string{id}); err != nil {
+					log.Printf("failed to delete flow %s dropped by script %s: %v", id, sc.name, err)
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// scriptFrame is a textual frame a script added. message is the index of a
+// TCP or UDP message, or -1 for the request and -2 for the response of an
+// HTTP flow.
+type scriptFrame struct {
+	message int
+	text    string
+}
+
+const (
+	scriptFrameRequest  = -1
+	scriptFrameResponse = -2
+)
+
+// scriptFlow is the flow a script sees. Its attributes are read-only
+// snapshots; the changes made through its methods are collected and only
+// applied once the script returns.
+type scriptFlow struct {
+	attrs   starlark.StringDict
+	kind    string
+	streams int
+
+	tags    map[string]string
+	note    *string
+	frames  []scriptFrame
+	dropped bool
+}
+
+var _ starlark.HasAttrs = (*scriptFlow)(nil)
+
+func newScriptFlow(flow *mitmflowv1.Flow) *scriptFlow {
+	v := &scriptFlow{tags: make(map[string]string)}
+	attrs := starlark.StringDict{
+		"id":       starlark.String(GetFlowID(flow)),
+		"protocol": starlark.String(flowProtocol(flow)),
+		"host":     starlark.String(flowServerHost(flow)),
+		"client":   starlark.String(flowClientHost(flow)),
+		"note":     starlark.String(flow.GetNote()),
+		"metadata": scriptDict(flow.GetMetadata()),
+	}
+	var messages []streamMessage
+	switch {
+	case flow.GetHttpFlow() != nil:
+		v.kind = "http"
+		h := flow.GetHttpFlow()
+		attrs["method"] = starlark.String(h.GetRequest().GetMethod())
+		attrs["url"] = starlark.String(h.GetRequest().GetUrl())
+		attrs["status"] = starlark.MakeInt(int(h.GetResponse().GetStatusCode()))
+		attrs["request_headers"] = scriptDict(h.GetRequest().GetHeaders())
+		attrs["response_headers"] = scriptDict(h.GetResponse().GetHeaders())
+		attrs["request_body"] = starlark.String(h.GetRequest().GetContent())
+		attrs["response_body"] = starlark.String(h.GetResponse().GetContent())
+	case flow.GetTcpFlow() != nil:
+		v.kind = "tcp"
+		for _, m := range flow.GetTcpFlow().GetMessages() {
+			messages = append(messages, m)
+		}
+	case flow.GetUdpFlow() != nil:
+		v.kind = "udp"
+		for _, m := range flow.GetUdpFlow().GetMessages() {
+			messages = append(messages, m)
+		}
+	case flow.GetDnsFlow() != nil:
+		v.kind = "dns"
+		var names []starlark.Value
+		for _, q := range flow.GetDnsFlow().GetRequest().GetQuestions() {
+			names = append(names, starlark.String(q.GetName()))
+		}
+		attrs["questions"] = starlark.NewList(names)
+	}
+	if v.kind == "tcp" || v.kind == "udp" {
+		list := make([]starlark.Value, len(messages))
+		for i, m := range messages {
+			list[i] = starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+				"from_client": starlark.Bool(m.GetFromClient()),
+				"content":     starlark.String(m.GetContent()),
+			})
+		}
+		attrs["messages"] = starlark.NewList(list)
+		v.streams = len(messages)
+	}
+	attrs["type"] = starlark.String(v.kind)
+	attrs.Freeze()
+	v.attrs = attrs
+	return v
+}
+
+func scriptDict(m map[string]string) *starlark.Dict {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	d := starlark.NewDict(len(m))
+	for _, k := range keys {
+		_ = d.SetKey(starlark.String(k), starlark.String(m[k]))
+	}
+	return d
+}
+
+func (v *scriptFlow) String() string {
+	return fmt.Sprintf("<flow %s>", v.attrs["id"].(starlark.String).GoString())
+}
+func (v *scriptFlow) Type() string          { return "flow" }
+func (v *scriptFlow) Freeze()               {}
+func (v *scriptFlow) Truth() starlark.Bool  { return starlark.True }
+func (v *scriptFlow) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable type: flow") }
+
+func (v *scriptFlow) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "tag":
+		return starlark.NewBuiltin("tag", v.tag).BindReceiver(v), nil
+	case "set_note":
+		return starlark.NewBuiltin("set_note", v.setNote).BindReceiver(v), nil
+	case "add_frame":
+		return starlark.NewBuiltin("add_frame", v.addFrame).BindReceiver(v), nil
+	case "drop":
+		return starlark.NewBuiltin("drop", v.drop).BindReceiver(v), nil
+	}
+	if value, ok := v.attrs[name]; ok {
+		return value, nil
+	}
+	return starlark.None, nil
+}
+
+func (v *scriptFlow) AttrNames() []string {
+	names := append(v.attrs.Keys(), "tag", "set_note", "add_frame", "drop")
+	slices.Sort(names)
+	return names
+}
+
+// tag(key, value="") sets a metadata key of the flow.
+func (v *scriptFlow) tag(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var key, value string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "key", &key, "value?", &value); err != nil {
+		return nil, err
+	}
+	if key == "" {
+		return nil, fmt.Errorf("%s: key is empty", b.Name())
+	}
+	v.tags[key] = value
+	return starlark.None, nil
+}
+
+// set_note(text) replaces the flow's note.
+func (v *scriptFlow) setNote(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var text string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "text", &text); err != nil {
+		return nil, err
+	}
+	v.note = &text
+	return starlark.None, nil
+}
+
+// add_frame(text, request=False, message=None) adds a textual frame to the
+// response of an HTTP flow, or its request, or to a message of a TCP or UDP
+// flow by index.
+func (v *scriptFlow) addFrame(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var text string
+	var request bool
+	message := -1
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "text", &text, "request?", &request, "message?", &message); err != nil {
+		return nil, err
+	}
+	switch v.kind {
+	case "http":
+		frame := scriptFrame{message: scriptFrameResponse, text: text}
+		if request {
+			frame.message = scriptFrameRequest
+		}
+		v.frames = append(v.frames, frame)
+	case "tcp", "udp":
+		if message < 0 || message >= v.streams {
+			return nil, fmt.Errorf("%s: message %d out of range, the flow has %d messages", b.Name(), message, v.streams)
+		}
+		v.frames = append(v.frames, scriptFrame{message: message, text: text})
+	default:
+		return nil, fmt.Errorf("%s: %s flows have no frames", b.Name(), v.kind)
+	}
+	return starlark.None, nil
+}
+
+// drop() keeps the flow from being stored.
+func (v *scriptFlow) drop(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+		return nil, err
+	}
+	v.dropped = true
+	return starlark.None, nil
+}
+
+// apply makes the changes the script asked for to the flow.
+func (v *scriptFlow) apply(flow *mitmflowv1.Flow) {
+	if len(v.tags) > 0 {
+		metadata := flow.GetMetadata()
+		if metadata == nil {
+			metadata = make(map[string]string, len(v.tags))
+		}
+		for k, value := range v.tags {
+			metadata[k] = value
+		}
+		flow.SetMetadata(metadata)
+	}
+	if v.note != nil {
+		flow.SetNote(*v.note)
+	}
+	for _, frame := range v.frames {
+		var details *mitmflowv1.MessageDetails
+		switch frame.message {
+		case scriptFrameRequest, scriptFrameResponse:
+			extra := flow.GetHttpFlowExtra()
+			if extra == nil {
+				extra = &mitmflowv1.HTTPFlowExtra{}
+				flow.SetHttpFlowExtra(extra)
+			}
+			if frame.message == scriptFrameRequest {
+				if !extra.HasRequest() {
+					extra.SetRequest(&mitmflowv1.MessageDetails{})
+				}
+				details = extra.GetRequest()
+			} else {
+				if !extra.HasResponse() {
+					extra.SetResponse(&mitmflowv1.MessageDetails{})
+				}
+				details = extra.GetResponse()
+			}
+		default:
+			messages := flow.GetStreamFlowExtra().GetMessages()
+			if frame.message >= len(messages) {
+				continue
+			}
+			details = messages[frame.message]
+		}
+		details.SetTextualFrames(append(details.GetTextualFrames(), frame.text))
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func writeScript(t *testing.T, src string) *flowScript {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), "script.star")
+	require.NoError(t, os.WriteFile(filename, []byte(src), 0o644))
+	script, err := loadFlowScript(filename)
+	require.NoError(t, err)
+	return script
+}
+
+func TestFlowScript(t *testing.T) {
+	server, storage := newTestServer(t)
+	server.scripts = []*flowScript{writeScript(t, `
+def process(flow):
+    if flow.type == "http" and flow.url.endswith("/health"):
+        flow.drop()
+        return
+    if flow.type == "http" and flow.status >= 500:
+        flow.tag("triage", "server-error")
+        flow.set_note("failed with %d" % flow.status)
+        flow.add_frame("body: " + flow.response_body)
+    if flow.type == "tcp":
+        for i, m in enumerate(flow.messages):
+            if m.content.startswith("HELLO"):
+                flow.add_frame("greeting from client" if m.from_client else "greeting from server", message=i)
+`)}
+
+	http := func(id, url string, status int32) *mitmflowv1.Flow {
+		return mitmflowv1.Flow_builder{
+			HttpFlow: mitmproxyv1.HTTPFlow_builder{
+				Id:       proto.String(id),
+				Request:  mitmproxyv1.Request_builder{Method: proto.String("GET"), Url: proto.String(url)}.Build(),
+				Response: mitmproxyv1.Response_builder{StatusCode: proto.Int32(status), Content: []byte("oops")}.Build(),
+			}.Build(),
+		}.Build()
+	}
+
+	server.ingest(http("ok", "https://example.com/", 200))
+	server.ingest(http("err", "https://example.com/", 503))
+	server.ingest(http("health", "https://example.com/health", 200))
+	server.ingest(mitmflowv1.Flow_builder{
+		TcpFlow: mitmproxyv1.TCPFlow_builder{
+			Id: proto.String("tcp"),
+			Messages: []*mitmproxyv1.TCPMessage{
+				mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(true), Content: []byte("HELLO server")}.Build(),
+				mitmproxyv1.TCPMessage_builder{Content: []byte("bye")}.Build(),
+			},
+		}.Build(),
+	}.Build())
+
+	flow, ok := storage.GetFlow("ok")
+	require.True(t, ok)
+	assert.Empty(t, flow.GetMetadata())
+	assert.Empty(t, flow.GetNote())
+
+	flow, ok = storage.GetFlow("err")
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"triage": "server-error"}, flow.GetMetadata())
+	assert.Equal(t, "failed with 503", flow.GetNote())
+	assert.Equal(t, []string{"body: oops"}, flow.GetHttpFlowExtra().GetResponse().GetTextualFrames())
+
+	_, ok = storage.GetFlow("health")
+	assert.False(t, ok, "dropped flows aren't stored")
+
+	flow, ok = storage.GetFlow("tcp")
+	require.True(t, ok)
+	messages := flow.GetStreamFlowExtra().GetMessages()
+	require.Len(t, messages, 2)
+	assert.Contains(t, messages[0].GetTextualFrames(), "greeting from client")
+	assert.NotContains(t, messages[1].GetTextualFrames(), "greeting from client")
+}
+
+func TestFlowScriptDropsStoredFlow(t *testing.T) {
+	server, storage := newTestServer(t)
+	flow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Id:      proto.String("f1"),
+			Request: mitmproxyv1.Request_builder{Method: proto.String("GET"), Url: proto.String("https://example.com/")}.Build(),
+		}.Build(),
+	}.Build()
+	server.ingest(flow)
+	_, ok := storage.GetFlow("f1")
+	require.True(t, ok)
+
+	server.scripts = []*flowScript{writeScript(t, `
+def process(flow):
+    if flow.status == 404:
+        flow.drop()
+`)}
+	flow = proto.Clone(flow).(*mitmflowv1.Flow)
+	flow.GetHttpFlow().SetResponse(mitmproxyv1.Response_builder{StatusCode: proto.Int32(404)}.Build())
+	server.ingest(flow)
+	_, ok = storage.GetFlow("f1")
+	assert.False(t, ok, "the stored flow is deleted once a script drops its update")
+}
+
+func TestFlowScriptErrors(t *testing.T) {
+	script := writeScript(t, `
+def process(flow):
+    flow.tag("seen")
+    fail("broken")
+`)
+	flow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{Id: proto.String("f1")}.Build(),
+	}.Build()
+	dropped, err := script.run(flow)
+	assert.ErrorContains(t, err, "broken")
+	assert.False(t, dropped)
+	assert.Empty(t, flow.GetMetadata(), "a failing script leaves the flow as it was")
+
+	script = writeScript(t, `
+def process(flow):
+    for i in range(1000000000):
+        pass
+`)
+	_, err = script.run(flow)
+	assert.ErrorContains(t, err, "too many steps")
+
+	script = writeScript(t, `
+def process(flow):
+    flow.add_frame("x", message=3)
+`)
+	_, err = script.run(mitmflowv1.Flow_builder{TcpFlow: mitmproxyv1.TCPFlow_builder{Id: proto.String("t")}.Build()}.Build())
+	assert.ErrorContains(t, err, "out of range")
+
+	filename := filepath.Join(t.TempDir(), "empty.star")
+	require.NoError(t, os.WriteFile(filename, []byte("x = 1\n"), 0o644))
+	_, err = loadFlowScript(filename)
+	assert.ErrorContains(t, err, "doesn't define process(flow)")
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// hstsMinMaxAge is the shortest HSTS max-age that isn't reported as weak,
+// the 180 days browsers' preload lists ask for at the least.
+const hstsMinMaxAge = 180 * 24 * 60 * 60
+
+const (
+	severityInfo   = mitmflowv1.FindingSeverity_FINDING_SEVERITY_INFO
+	severityLow    = mitmflowv1.FindingSeverity_FINDING_SEVERITY_LOW
+	severityMedium = mitmflowv1.FindingSeverity_FINDING_SEVERITY_MEDIUM
+)
+
+// auditSecurityHeaders checks the response of flow for missing or weak
+// security headers. Headers that only matter for documents, like CSP, are
+// only checked on HTML responses, and HSTS and cookie Secure flags only over
+// HTTPS.
+func auditSecurityHeaders(flow *mitmproxyv1.HTTPFlow, contentType string) []*mitmflowv1.SecurityFinding {
+	if !flow.HasResponse() {
+		return nil
+	}
+	headers := flow.GetResponse().GetHeaders()
+	u, _ := url.Parse(flow.GetRequest().GetUrl())
+	https := u != nil && u.Scheme == "https"
+	html := strings.Contains(contentType, "text/html")
+
+	var findings []*mitmflowv1.SecurityFinding
+	add := func(header string, severity mitmflowv1.FindingSeverity, format string, args ...any) {
+		findings = append(findings, mitmflowv1.SecurityFinding_builder{
+			Header:   proto.String(header),
+			Severity: severity.Enum(),
+			Message:  proto.String(fmt.Sprintf(format, args...)),
+		}.Build())
+	}
+
+	if https {
+		hsts := getHeaderValue(headers, "Strict-Transport-Security")
+		if hsts == "" {
+			add("Strict-Transport-Security", severityMedium, "missing")
+		} else if maxAge, ok := hstsMaxAge(hsts); !ok {
+			add("Strict-Transport-Security", severityLow, "no valid max-age")
+		} else if maxAge < hstsMinMaxAge {
+			add("Strict-Transport-Security", severityLow, "max-age of %d seconds is shorter than 180 days", maxAge)
+		}
+	}
+
+	switch nosniff := getHeaderValue(headers, "X-Content-Type-Options"); {
+	case nosniff == "":
+		add("X-Content-Type-Options", severityLow, "missing")
+	case !strings.EqualFold(strings.TrimSpace(nosniff), "nosniff"):
+		add("X-Content-Type-Options", severityLow, "%q is not nosniff", nosniff)
+	}
+
+	if html {
+		csp := getHeaderValue(headers, "Content-Security-Policy")
+		switch {
+		case csp == "" && getHeaderValue(headers, "Content-Security-Policy-Report-Only") != "":
+			add("Content-Security-Policy", severityLow, "only report-only, nothing is enforced")
+		case csp == "":
+			add("Content-Security-Policy", severityMedium, "missing")
+		default:
+			for _, source := range []string{"'unsafe-inline'", "'unsafe-eval'"} {
+				if cspScriptSourcesContain(csp, source) {
+					add("Content-Security-Policy", severityLow, "scripts allow %s", source)
+				}
+			}
+		}
+
+		switch policy := strings.ToLower(strings.TrimSpace(getHeaderValue(headers, "Referrer-Policy"))); policy {
+		case "":
+			add("Referrer-Policy", severityInfo, "missing, browsers default to strict-origin-when-cross-origin")
+		case "unsafe-url", "no-referrer-when-downgrade":
+			add("Referrer-Policy", severityLow, "%s sends full URLs to other sites", policy)
+		}
+	}
+
+	for _, line := range splitSetCookie(getHeaderValue(headers, "Set-Cookie")) {
+		c, err := http.ParseSetCookie(line)
+		if err != nil {
+			continue
+		}
+		if https && !c.Secure {
+			add("Set-Cookie", severityMedium, "cookie %s: missing Secure", c.Name)
+		}
+		if !c.HttpOnly {
+			add("Set-Cookie", severityLow, "cookie %s: missing HttpOnly", c.Name)
+		}
+		switch {
+		case c.SameSite == http.SameSiteNoneMode && !c.Secure:
+			add("Set-Cookie", severityMedium, "cookie %s: SameSite=None without Secure is rejected by browsers", c.Name)
+		case c.SameSite == 0:
+			add("Set-Cookie", severityLow, "cookie %s: missing SameSite", c.Name)
+		}
+	}
+	return findings
+}
+
+// hstsMaxAge returns the max-age directive of a Strict-Transport-Security
+// header.
+func hstsMaxAge(header string) (int64, bool) {
+	for _, directive := range strings.Split(header, ";") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		if strings.EqualFold(name, "max-age") {
+			maxAge, err := strconv.ParseInt(strings.Trim(value, `"`), 10, 64)
+			return maxAge, err == nil
+		}
+	}
+	return 0, false
+}
+
+// cspScriptSourcesContain reports whether the sources scripts may load from,
+// script-src or else default-src, include source.
+func cspScriptSourcesContain(csp, source string) bool {
+	directives := map[string][]string{}
+	for _, directive := range strings.Split(csp, ";") {
+		fields := strings.Fields(strings.ToLower(directive))
+		if len(fields) > 0 {
+			directives[fields[0]] = fields[1:]
+		}
+	}
+	sources, ok := directives["script-src"]
+	if !ok {
+		sources = directives["default-src"]
+	}
+	for _, s := range sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+// maxFindingSeverity is the severity of the worst security finding of a
+// flow, or unspecified when there are none.
+func maxFindingSeverity(flow *mitmflowv1.Flow) mitmflowv1.FindingSeverity {
+	severity := mitmflowv1.FindingSeverity_FINDING_SEVERITY_UNSPECIFIED
+	for _, f := range flow.GetHttpFlowExtra().GetSecurityFindings() {
+		severity = max(severity, f.GetSeverity())
+	}
+	return severity
+}
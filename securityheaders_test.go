@@ -0,0 +1,110 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+func findingMessages(flow *mitmflowv1.Flow) map[string]mitmflowv1.FindingSeverity {
+	messages := map[string]mitmflowv1.FindingSeverity{}
+	for _, f := range flow.GetHttpFlowExtra().GetSecurityFindings() {
+		messages[f.GetHeader()+": "+f.GetMessage()] = f.GetSeverity()
+	}
+	return messages
+}
+
+func TestAuditSecurityHeaders(t *testing.T) {
+	s := &MITMFlowServer{}
+
+	t.Run("bare html page", func(t *testing.T) {
+		flow := createHTTPFlow("", "https://example.com/", time.Time{}, withResponseHeaders(map[string]string{
+			"content-type": "text/html; charset=utf-8",
+			"set-cookie":   "sid=abc; Path=/",
+		}))
+		s.preprocessFlow(flow)
+		assert.Equal(t, map[string]mitmflowv1.FindingSeverity{
+			"Strict-Transport-Security: missing":                                            severityMedium,
+			"X-Content-Type-Options: missing":                                               severityLow,
+			"Content-Security-Policy: missing":                                              severityMedium,
+			"Referrer-Policy: missing, browsers default to strict-origin-when-cross-origin": severityInfo,
+			"Set-Cookie: cookie sid: missing Secure":                                        severityMedium,
+			"Set-Cookie: cookie sid: missing HttpOnly":                                      severityLow,
+			"Set-Cookie: cookie sid: missing SameSite":                                      severityLow,
+		}, findingMessages(flow))
+	})
+
+	t.Run("hardened html page", func(t *testing.T) {
+		flow := createHTTPFlow("", "https://example.com/", time.Time{}, withResponseHeaders(map[string]string{
+			"content-type":              "text/html",
+			"strict-transport-security": "max-age=63072000; includeSubDomains; preload",
+			"x-content-type-options":    "nosniff",
+			"content-security-policy":   "default-src 'self'; style-src 'self' 'unsafe-inline'",
+			"referrer-policy":           "strict-origin-when-cross-origin",
+			"set-cookie":                "sid=abc; Secure; HttpOnly; SameSite=Lax",
+		}))
+		s.preprocessFlow(flow)
+		assert.Empty(t, findingMessages(flow))
+	})
+
+	t.Run("weak values", func(t *testing.T) {
+		flow := createHTTPFlow("", "https://example.com/", time.Time{}, withResponseHeaders(map[string]string{
+			"content-type":              "text/html",
+			"strict-transport-security": "max-age=3600",
+			"x-content-type-options":    "sniff",
+			"content-security-policy":   "default-src 'self' 'unsafe-inline'; object-src 'none'",
+			"referrer-policy":           "unsafe-url",
+			"set-cookie":                "a=1; HttpOnly; SameSite=None, b=2; Secure; HttpOnly; SameSite=Strict",
+		}))
+		s.preprocessFlow(flow)
+		assert.Equal(t, map[string]mitmflowv1.FindingSeverity{
+			"Strict-Transport-Security: max-age of 3600 seconds is shorter than 180 days": severityLow,
+			`X-Content-Type-Options: "sniff" is not nosniff`:                              severityLow,
+			"Content-Security-Policy: scripts allow 'unsafe-inline'":                      severityLow,
+			"Referrer-Policy: unsafe-url sends full URLs to other sites":                  severityLow,
+			"Set-Cookie: cookie a: missing Secure":                                        severityMedium,
+			"Set-Cookie: cookie a: SameSite=None without Secure is rejected by browsers":  severityMedium,
+		}, findingMessages(flow))
+	})
+
+	t.Run("plain http api", func(t *testing.T) {
+		flow := createHTTPFlow("", "http://example.com/api", time.Time{}, withResponseHeaders(map[string]string{
+			"content-type":           "application/json",
+			"x-content-type-options": "nosniff",
+		}))
+		s.preprocessFlow(flow)
+		assert.Empty(t, findingMessages(flow))
+	})
+}
+
+func TestSecurityFindingFilters(t *testing.T) {
+	s := &MITMFlowServer{}
+	weak := createHTTPFlow("", "https://example.com/", time.Time{}, withResponseHeaders(map[string]string{"content-type": "text/html"}))
+	s.preprocessFlow(weak)
+	informational := createHTTPFlow("", "http://example.com/", time.Time{}, withResponseHeaders(map[string]string{
+		"content-type":            "text/html",
+		"x-content-type-options":  "nosniff",
+		"content-security-policy": "default-src 'self'",
+	}))
+	s.preprocessFlow(informational)
+
+	filter := func(severity mitmflowv1.FindingSeverity) *mitmflowv1.FlowFilter {
+		return mitmflowv1.FlowFilter_builder{
+			Http: mitmflowv1.HttpFilter_builder{MinSecuritySeverity: severity.Enum()}.Build(),
+		}.Build()
+	}
+	assert.True(t, matchFlow(weak, filter(severityMedium)))
+	assert.False(t, matchFlow(informational, filter(severityLow)))
+	assert.True(t, matchFlow(informational, filter(severityInfo)))
+
+	pred, err := parseFilterExpr("~sec medium")
+	require.NoError(t, err)
+	assert.True(t, pred(weak))
+	assert.False(t, pred(informational))
+
+	_, err = parseFilterExpr("~sec critical")
+	assert.ErrorContains(t, err, "~sec expects")
+}
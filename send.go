@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxygrpcv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultSendTimeout = 30 * time.Second
+
+// SendRequest issues an HTTP request from the server and stores the exchange
+// as a new flow, so requests can be composed and replayed from the UI.
+func (s *MITMFlowServer) SendRequest(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.SendRequestRequest],
+) (*connect.Response[mitmflowv1.SendRequestResponse], error) {
+	method := req.Msg.GetMethod()
+	if method == "" {
+		method = http.MethodGet
+	}
+	target, err := url.Parse(req.Msg.GetUrl())
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("url must be an absolute http or https URL"))
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	if req.Msg.GetProxy() != "" {
+		proxyURL, err := url.Parse(req.Msg.GetProxy())
+		if err != nil || proxyURL.Host == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid proxy URL %q", req.Msg.GetProxy()))
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	defer transport.CloseIdleConnections()
+
+	timeout := defaultSendTimeout
+	if ms := req.Msg.GetTimeoutMs(); ms > 0 {
+		timeout = time.Duration(ms) * time.Millisecond
+	}
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+		// Show the response as sent rather than following redirects.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, target.String(), bytes.NewReader(req.Msg.GetBody()))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	for k, v := range req.Msg.GetHeaders() {
+		if strings.EqualFold(k, "Host") {
+			httpReq.Host = v
+			continue
+		}
+		httpReq.Header.Set(k, v)
+	}
+
+	// Interim responses don't end up in resp, so they're recorded as they
+	// arrive.
+	var interim []*mitmflowv1.InterimResponse
+	httpReq = httpReq.WithContext(httptrace.WithClientTrace(httpReq.Context(), &httptrace.ClientTrace{
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			interim = append(interim, mitmflowv1.InterimResponse_builder{
+				StatusCode: proto.Int32(int32(code)),
+				Headers:    flattenHeader(http.Header(header)),
+				Timestamp:  timestamppb.Now(),
+			}.Build())
+			return nil
+		},
+	}))
+
+	start := time.Now()
+	httpFlow := mitmproxygrpcv1.HTTPFlow_builder{
+		Id: proto.String(uuid.New().String()),
+		Request: mitmproxygrpcv1.Request_builder{
+			Method:         proto.String(method),
+			Url:            proto.String(target.String()),
+			Headers:        req.Msg.GetHeaders(),
+			Content:        req.Msg.GetBody(),
+			HttpVersion:    proto.String("HTTP/1.1"),
+			TimestampStart: timestamppb.New(start),
+		}.Build(),
+		TimestampStart: timestamppb.New(start),
+		Server:         sendServerConn(target),
+	}.Build()
+
+	// Responses are read up to the body limit, and the rest is dropped, as
+	// ingest would, so a huge download can't exhaust memory.
+	limit := s.sendResponseLimit()
+	var truncated bool
+	var bodySize int64
+	resp, err := client.Do(httpReq)
+	if err == nil {
+		httpFlow.GetRequest().SetTimestampEnd(timestamppb.Now())
+		var content []byte
+		content, err = io.ReadAll(io.LimitReader(resp.Body, int64(limit)+1))
+		resp.Body.Close() //nolint:errcheck
+		if len(content) > limit {
+			content, truncated = content[:limit], true
+			bodySize = resp.ContentLength
+		}
+		end := time.Now()
+		httpFlow.SetResponse(mitmproxygrpcv1.Response_builder{
+			StatusCode:     proto.Int32(int32(resp.StatusCode)),
+			Reason:         proto.String(http.StatusText(resp.StatusCode)),
+			Headers:        flattenHeader(resp.Header),
+			Content:        content,
+			HttpVersion:    proto.String(resp.Proto),
+			Trailers:       flattenHeader(resp.Trailer),
+			TimestampStart: timestamppb.New(start),
+			TimestampEnd:   timestamppb.New(end),
+		}.Build())
+		if truncated {
+			httpFlow.GetResponse().SetContentTruncated(true)
+		}
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, connect.NewError(connect.CodeCanceled, ctx.Err())
+		}
+		httpFlow.SetError(err.Error())
+	}
+	httpFlow.SetDurationMs(float64(time.Since(start)) / float64(time.Millisecond))
+
+	flow := &mitmflowv1.Flow{}
+	flow.SetHttpFlow(httpFlow)
+	if len(interim) > 0 {
+		flow.SetHttpFlowExtra(mitmflowv1.HTTPFlowExtra_builder{InterimResponses: interim}.Build())
+	}
+	claim(ctx, flow)
+	s.preprocessFlow(flow)
+	if truncated {
+		details := flow.GetHttpFlowExtra().GetResponse()
+		details.SetTruncated(true)
+		// The full size is only known when the server sent a Content-Length.
+		if bodySize > int64(limit) {
+			details.SetBodySize(bodySize)
+		}
+	}
+	if err := s.storage.SaveFlow(flow); err != nil {
+		log.Printf("failed to save sent request: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	s.broadcast(flow)
+
+	return connect.NewResponse(mitmflowv1.SendRequestResponse_builder{
+		Flow: flow,
+	}.Build()), nil
+}
+
+// sendResponseLimit is how many bytes of a response SendRequest keeps: the
+// configured body limit or, without one, as much as a Content-Encoding is
+// ever expanded to.
+func (s *MITMFlowServer) sendResponseLimit() int {
+	if s.maxBodyBytes > 0 {
+		return s.maxBodyBytes
+	}
+	return maxDecodedBodySize
+}
+
+// sendServerConn describes the server a composed request is sent to.
+func sendServerConn(target *url.URL) *mitmproxygrpcv1.ServerConn {
+	port := target.Port()
+	if port == "" {
+		port = "80"
+		if target.Scheme == "https" {
+			port = "443"
+		}
+	}
+	p, _ := strconv.ParseUint(port, 10, 32)
+	conn := mitmproxygrpcv1.ServerConn_builder{
+		AddressHost: proto.String(target.Hostname()),
+		AddressPort: proto.Uint32(uint32(p)),
+		Tls:         proto.Bool(target.Scheme == "https"),
+	}.Build()
+	if target.Scheme == "https" && net.ParseIP(target.Hostname()) == nil {
+		conn.SetSni(target.Hostname())
+	}
+	return conn
+}
+
+// flattenHeader joins repeated header values the way they would appear on a
+// single header line.
+func flattenHeader(h http.Header) map[string]string {
+	if len(h) == 0 {
+		return nil
+	}
+	headers := make(map[string]string, len(h))
+	for k, v := range h {
+		headers[k] = strings.Join(v, ", ")
+	}
+	return headers
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSendRequest(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Method", r.Method)
+		w.Header().Set("X-Token", r.Header.Get("X-Token"))
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body) //nolint:errcheck
+	}))
+	defer upstream.Close()
+
+	tmpDir, err := os.MkdirTemp("", "mitmflow_send_test")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(tmpDir)) })
+	storage, err := NewFlowStorage(tmpDir, 100)
+	require.NoError(t, err)
+	defer storage.Close()
+	server, err := NewMITMFlowServer(storage, NewRegistry())
+	require.NoError(t, err)
+
+	resp, err := server.SendRequest(context.Background(), connect.NewRequest(mitmflowv1.SendRequestRequest_builder{
+		Method:  proto.String("POST"),
+		Url:     proto.String(upstream.URL + "/items"),
+		Headers: map[string]string{"X-Token": "secret"},
+		Body:    []byte(`{"name":"gopher"}`),
+	}.Build()))
+	require.NoError(t, err)
+
+	flow := resp.Msg.GetFlow()
+	httpFlow := flow.GetHttpFlow()
+	assert.Empty(t, httpFlow.GetError())
+	assert.Equal(t, "POST", httpFlow.GetRequest().GetMethod())
+	assert.Equal(t, int32(http.StatusCreated), httpFlow.GetResponse().GetStatusCode())
+	assert.Equal(t, "POST", httpFlow.GetResponse().GetHeaders()["X-Method"])
+	assert.Equal(t, "secret", httpFlow.GetResponse().GetHeaders()["X-Token"])
+	assert.Equal(t, `{"name":"gopher"}`, string(httpFlow.GetResponse().GetContent()))
+	assert.Equal(t, "application/json", flow.GetHttpFlowExtra().GetResponse().GetEffectiveContentType())
+
+	stored, ok := storage.GetFlow(httpFlow.GetId())
+	require.True(t, ok)
+	assert.Equal(t, int32(http.StatusCreated), stored.GetHttpFlow().GetResponse().GetStatusCode())
+
+	// Transport failures are recorded on the flow.
+	upstream.Close()
+	resp, err = server.SendRequest(context.Background(), connect.NewRequest(mitmflowv1.SendRequestRequest_builder{
+		Url: proto.String(upstream.URL),
+	}.Build()))
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.Msg.GetFlow().GetHttpFlow().GetError())
+	assert.False(t, resp.Msg.GetFlow().GetHttpFlow().HasResponse())
+
+	_, err = server.SendRequest(context.Background(), connect.NewRequest(mitmflowv1.SendRequestRequest_builder{
+		Url: proto.String("ftp://example.com"),
+	}.Build()))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestSendRequest_TruncatesLargeResponses(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(strings.Repeat("x", 100))) //nolint:errcheck
+	}))
+	defer upstream.Close()
+
+	server, _ := newTestServer(t, WithMaxBodyBytes(16))
+	resp, err := server.SendRequest(context.Background(), connect.NewRequest(mitmflowv1.SendRequestRequest_builder{
+		Url: proto.String(upstream.URL),
+	}.Build()))
+	require.NoError(t, err)
+
+	flow := resp.Msg.GetFlow()
+	assert.Equal(t, strings.Repeat("x", 16), string(flow.GetHttpFlow().GetResponse().GetContent()))
+	assert.True(t, flow.GetHttpFlow().GetResponse().GetContentTruncated())
+	assert.True(t, flow.GetHttpFlowExtra().GetResponse().GetTruncated())
+	assert.Equal(t, int64(100), flow.GetHttpFlowExtra().GetResponse().GetBodySize())
+}
+
+func TestSendRequest_InterimResponses(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", "</style.css>; rel=preload; as=style")
+		w.WriteHeader(http.StatusEarlyHints)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer upstream.Close()
+
+	server, err := NewMITMFlowServer(nil, nil)
+	require.NoError(t, err)
+	server.storage, err = NewFlowStorage(t.TempDir(), 100)
+	require.NoError(t, err)
+	defer server.storage.Close()
+
+	resp, err := server.SendRequest(context.Background(), connect.NewRequest(mitmflowv1.SendRequestRequest_builder{
+		Url: proto.String(upstream.URL),
+	}.Build()))
+	require.NoError(t, err)
+	interim := resp.Msg.GetFlow().GetHttpFlowExtra().GetInterimResponses()
+	require.Len(t, interim, 1)
+	assert.Equal(t, int32(http.StatusEarlyHints), interim[0].GetStatusCode())
+	assert.Equal(t, "</style.css>; rel=preload; as=style", interim[0].GetHeaders()["Link"])
+	assert.True(t, interim[0].HasTimestamp())
+	assert.Equal(t, int32(http.StatusOK), resp.Msg.GetFlow().GetHttpFlow().GetResponse().GetStatusCode())
+}
@@ -0,0 +1,429 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newTestServer returns a server, without a registry, over a storage in a
+// temporary directory that is removed when the test ends.
+func newTestServer(t *testing.T, opts ...ServerOption) (*MITMFlowServer, *FlowStorage) {
+	dir, err := os.MkdirTemp("", "mitmflow_server")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(dir)) })
+	storage, err := NewFlowStorage(dir, 100)
+	require.NoError(t, err)
+	t.Cleanup(storage.Close)
+	server, err := NewMITMFlowServer(storage, nil, opts...)
+	require.NoError(t, err)
+	return server, storage
+}
+
+func TestPreprocessFlow_MaxBodyBytes(t *testing.T) {
+	server, err := NewMITMFlowServer(nil, nil, WithMaxBodyBytes(10))
+	require.NoError(t, err)
+
+	flow := createFlow("flow-1", time.Now())
+	req := &mitmproxyv1.Request{}
+	req.SetUrl("http://example.com/upload")
+	req.SetContent([]byte("small"))
+	flow.GetHttpFlow().SetRequest(req)
+	resp := &mitmproxyv1.Response{}
+	resp.SetContent(bytes.Repeat([]byte("x"), 100))
+	flow.GetHttpFlow().SetResponse(resp)
+
+	server.preprocessFlow(flow)
+
+	reqDetails := flow.GetHttpFlowExtra().GetRequest()
+	assert.Equal(t, []byte("small"), flow.GetHttpFlow().GetRequest().GetContent())
+	assert.False(t, reqDetails.GetTruncated())
+	assert.Equal(t, int64(5), reqDetails.GetBodySize())
+
+	respDetails := flow.GetHttpFlowExtra().GetResponse()
+	assert.Len(t, flow.GetHttpFlow().GetResponse().GetContent(), 10)
+	assert.True(t, flow.GetHttpFlow().GetResponse().GetContentTruncated())
+	assert.True(t, respDetails.GetTruncated())
+	assert.Equal(t, int64(100), respDetails.GetBodySize())
+	// The hash is of the whole body, as received.
+	assert.Equal(t, blobKey(bytes.Repeat([]byte("x"), 100)), respDetails.GetSha256())
+	assert.Equal(t, blobKey([]byte("small")), reqDetails.GetSha256())
+
+	summary := convertToSummary(flow)
+	assert.Equal(t, int64(100), summary.GetHttp().GetResponseContentLength())
+	assert.Equal(t, respDetails.GetSha256(), summary.GetHttp().GetResponseSha256())
+}
+
+func TestExportImportFlows_Proto(t *testing.T) {
+	newServer := func() (*MITMFlowServer, *FlowStorage) {
+		tmpDir, err := os.MkdirTemp("", "mitmflow_bundle_test")
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, os.RemoveAll(tmpDir)) })
+		storage, err := NewFlowStorage(tmpDir, 100)
+		require.NoError(t, err)
+		t.Cleanup(storage.Close)
+		server, err := NewMITMFlowServer(storage, NewRegistry())
+		require.NoError(t, err)
+		return server, storage
+	}
+
+	src, srcStorage := newServer()
+	flow := createFlow("flow-1", time.Now())
+	flow.SetPinned(true)
+	flow.SetNote("keep me")
+	// Unknown fields, such as extensions, must survive the round trip.
+	flow.GetHttpFlow().ProtoReflect().SetUnknown(protowire.AppendVarint(protowire.AppendTag(nil, 9999, protowire.VarintType), 42))
+	require.NoError(t, srcStorage.SaveFlow(flow))
+
+	format := mitmflowv1.ExportFormat_EXPORT_FORMAT_PROTO
+	exported, err := src.ExportFlows(context.Background(), connect.NewRequest(mitmflowv1.ExportFlowsRequest_builder{
+		FlowIds: []string{"flow-1"},
+		Format:  &format,
+	}.Build()))
+	require.NoError(t, err)
+	assert.Equal(t, "flows.binpb", exported.Msg.GetFilename())
+
+	dst, dstStorage := newServer()
+	imported, err := dst.ImportFlows(context.Background(), connect.NewRequest(mitmflowv1.ImportFlowsRequest_builder{
+		Data: exported.Msg.GetData(),
+	}.Build()))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), imported.Msg.GetCount())
+
+	got, ok := dstStorage.GetFlow("flow-1")
+	require.True(t, ok)
+	assert.True(t, proto.Equal(flow, got))
+	assert.True(t, got.GetPinned())
+	assert.Equal(t, "keep me", got.GetNote())
+}
+
+func TestExportFlows_Query(t *testing.T) {
+	server, storage := newTestServer(t)
+	base := time.Now().Add(-3 * time.Hour)
+	for i, host := range []string{"example.com", "other.com", "example.com"} {
+		flow := createFlow(fmt.Sprint(i), base.Add(time.Duration(i)*time.Hour))
+		flow.GetHttpFlow().SetRequest(mitmproxyv1.Request_builder{Url: proto.String("https://" + host + "/")}.Build())
+		flow.SetPinned(i == 1)
+		require.NoError(t, storage.SaveFlow(flow))
+	}
+
+	export := func(req *mitmflowv1.ExportFlowsRequest) []string {
+		req.SetFormat(mitmflowv1.ExportFormat_EXPORT_FORMAT_PROTO)
+		resp, err := server.ExportFlows(context.Background(), connect.NewRequest(req))
+		require.NoError(t, err)
+		set := &mitmflowv1.FlowSet{}
+		require.NoError(t, proto.Unmarshal(resp.Msg.GetData(), set))
+		var ids []string
+		for _, flow := range set.GetFlows() {
+			ids = append(ids, GetFlowID(flow))
+		}
+		return ids
+	}
+
+	assert.Empty(t, export(&mitmflowv1.ExportFlowsRequest{}))
+	assert.Equal(t, []string{"0", "1", "2"}, export(mitmflowv1.ExportFlowsRequest_builder{
+		Filter: &mitmflowv1.FlowFilter{},
+	}.Build()))
+	assert.Equal(t, []string{"2"}, export(mitmflowv1.ExportFlowsRequest_builder{
+		FilterExpr: proto.String("~d example.com"),
+		StartTime:  timestamppb.New(base.Add(30 * time.Minute)),
+	}.Build()))
+	assert.Equal(t, []string{"1"}, export(mitmflowv1.ExportFlowsRequest_builder{
+		FlowIds: []string{"0", "1"},
+		Filter:  mitmflowv1.FlowFilter_builder{Pinned: proto.Bool(true)}.Build(),
+	}.Build()))
+
+	_, err := server.ExportFlows(context.Background(), connect.NewRequest(mitmflowv1.ExportFlowsRequest_builder{
+		FilterExpr: proto.String("~c"),
+	}.Build()))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestGetServerInfo(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_info_test")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(tmpDir)) })
+	storage, err := NewFlowStorage(tmpDir, 100)
+	require.NoError(t, err)
+	defer storage.Close()
+
+	require.NoError(t, storage.SaveFlow(createFlow("flow-1", time.Now())))
+	dnsFlow := &mitmproxyv1.DNSFlow{}
+	dnsFlow.SetId("flow-2")
+	flow := &mitmflowv1.Flow{}
+	flow.SetDnsFlow(dnsFlow)
+	require.NoError(t, storage.SaveFlow(flow))
+
+	server, err := NewMITMFlowServer(storage, NewRegistry(), WithMaxBodyBytes(1024))
+	require.NoError(t, err)
+
+	resp, err := server.GetServerInfo(context.Background(), connect.NewRequest(&mitmflowv1.GetServerInfoRequest{}))
+	require.NoError(t, err)
+	info := resp.Msg
+	assert.Equal(t, "dev", info.GetVersion())
+	assert.Equal(t, tmpDir, info.GetDataDir())
+	assert.Equal(t, int32(100), info.GetMaxFlows())
+	assert.Equal(t, int64(1024), info.GetMaxBodyBytes())
+	assert.Equal(t, int64(2), info.GetFlowCount())
+	assert.Equal(t, map[string]int64{"http": 1, "dns": 1}, info.GetFlowCounts())
+	assert.Zero(t, info.GetDescriptorFileCount())
+}
+
+func TestStampMessageFrames(t *testing.T) {
+	details := func(frames int, timestamps ...int64) *mitmflowv1.MessageDetails {
+		d := &mitmflowv1.MessageDetails{}
+		d.SetTextualFrames(make([]string, frames))
+		d.SetFrameTimestampsNs(timestamps)
+		return d
+	}
+
+	// A flow that is only sent once has no frame timing.
+	d := details(2)
+	stampMessageFrames(d, nil, false, 100)
+	assert.Empty(t, d.GetFrameTimestampsNs())
+
+	// The first live update stamps every frame seen so far.
+	first := details(1)
+	stampMessageFrames(first, nil, true, 100)
+	assert.Equal(t, []int64{100}, first.GetFrameTimestampsNs())
+
+	// Later updates keep earlier times and stamp the new frames, including the
+	// final update once the flow is no longer live.
+	second := details(3)
+	stampMessageFrames(second, first, true, 200)
+	assert.Equal(t, []int64{100, 200, 200}, second.GetFrameTimestampsNs())
+	final := details(4)
+	stampMessageFrames(final, second, false, 300)
+	assert.Equal(t, []int64{100, 200, 200, 300}, final.GetFrameTimestampsNs())
+
+	// Frames stored before timing started are unknown.
+	late := details(3)
+	stampMessageFrames(late, details(2), true, 400)
+	assert.Equal(t, []int64{0, 0, 400}, late.GetFrameTimestampsNs())
+}
+
+func TestStreamFlows_SinceSequence(t *testing.T) {
+	server, storage := newTestServer(t)
+
+	base := time.Now()
+	require.NoError(t, storage.SaveFlow(createFlow("a", base)))
+	require.NoError(t, storage.SaveFlow(createFlow("b", base.Add(time.Second))))
+	seq := storage.Sequence()
+	require.NoError(t, storage.SaveFlow(createFlow("c", base.Add(2*time.Second))))
+	note := "changed"
+	_, err := storage.UpdateFlow("a", "", nil, &note, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var got []*mitmflowv1.FlowSummary
+	err = server.streamFlows(ctx, "test", mitmflowv1.StreamFlowsRequest_builder{
+		SinceSequence: proto.Uint64(seq),
+	}.Build(), func(resp *mitmflowv1.StreamFlowsResponse) error {
+		got = append(got, resp.GetFlow())
+		if len(got) == 2 {
+			cancel()
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "c", got[0].GetId())
+	assert.Equal(t, "a", got[1].GetId(), "updated flows are resent")
+	assert.Equal(t, "changed", got[1].GetNote())
+	assert.Greater(t, got[0].GetSequence(), seq)
+	assert.Equal(t, storage.Sequence(), got[1].GetSequence())
+}
+
+func TestStreamFlows_Keepalive(t *testing.T) {
+	server, storage := newTestServer(t)
+	server.keepalive = 20 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var got []*mitmflowv1.StreamFlowsResponse
+	err := server.streamFlows(ctx, "test", &mitmflowv1.StreamFlowsRequest{}, func(resp *mitmflowv1.StreamFlowsResponse) error {
+		got = append(got, resp)
+		switch len(got) {
+		case 2:
+			flow := createFlow("a", time.Now())
+			require.NoError(t, storage.SaveFlow(flow))
+			server.broadcast(flow)
+		case 4:
+			cancel()
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 4)
+	assert.Equal(t, int64(20), got[0].GetKeepalive().GetIntervalMs(), "the first keepalive is sent straight away")
+	assert.True(t, got[1].HasKeepalive(), "idle streams get keepalives")
+	assert.Equal(t, "a", got[2].GetFlow().GetId())
+	assert.True(t, got[3].HasKeepalive())
+}
+
+func TestExportFlow_MaxIngestMessageBytes(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_ingest_test")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(tmpDir)) })
+	storage, err := NewFlowStorage(tmpDir, 100)
+	require.NoError(t, err)
+	t.Cleanup(storage.Close)
+	server, err := NewMITMFlowServer(storage, NewRegistry(), WithMaxIngestMessageBytes(1024))
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle(mitmproxyv1.NewServiceHandler(server, connect.WithReadMaxBytes(1024)))
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	send := func(id string, size int) error {
+		stream := mitmproxyv1.NewServiceClient(ts.Client(), ts.URL).ExportFlow(context.Background())
+		flow := createFlow(id, time.Now())
+		flow.GetHttpFlow().SetResponse(mitmproxyv1.Response_builder{Content: bytes.Repeat([]byte("x"), size)}.Build())
+		if err := stream.Send(mitmproxyv1.ExportFlowRequest_builder{
+			Flow: mitmproxyv1.Flow_builder{HttpFlow: flow.GetHttpFlow()}.Build(),
+		}.Build()); err != nil {
+			return err
+		}
+		_, err := stream.CloseAndReceive()
+		return err
+	}
+
+	require.NoError(t, send("small", 10))
+	_, ok := storage.GetFlow("small")
+	assert.True(t, ok)
+
+	err = send("large", 4096)
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+	assert.ErrorContains(t, err, "-max-ingest-message-bytes")
+	_, ok = storage.GetFlow("large")
+	assert.False(t, ok)
+}
+
+func TestUploadFlowBody(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_upload_test")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(tmpDir)) })
+	storage, err := NewFlowStorage(tmpDir, 100)
+	require.NoError(t, err)
+	t.Cleanup(storage.Close)
+	server, err := NewMITMFlowServer(storage, NewRegistry())
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle(mitmflowv1.NewServiceHandler(server))
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := mitmflowv1.NewServiceClient(ts.Client(), ts.URL)
+
+	// The flow arrives without its bodies.
+	flow := createFlow("large", time.Now())
+	flow.GetHttpFlow().SetRequest(mitmproxyv1.Request_builder{Url: proto.String("https://example.com/upload")}.Build())
+	flow.GetHttpFlow().SetResponse(mitmproxyv1.Response_builder{StatusCode: proto.Int32(200), ContentTruncated: proto.Bool(true)}.Build())
+	server.ingest(flow)
+
+	requestBody := bytes.Repeat([]byte("q"), 3000)
+	responseBody := bytes.Repeat([]byte("r"), 5000)
+	stream := client.UploadFlowBody(context.Background())
+	for _, part := range []struct {
+		response bool
+		chunk    []byte
+	}{
+		{false, requestBody[:1000]},
+		{false, requestBody[1000:]},
+		{true, responseBody[:2048]},
+		{true, responseBody[2048:4096]},
+		{true, responseBody[4096:]},
+	} {
+		require.NoError(t, stream.Send(mitmflowv1.UploadFlowBodyRequest_builder{
+			FlowId:   proto.String("large"),
+			Response: proto.Bool(part.response),
+			Chunk:    part.chunk,
+		}.Build()))
+	}
+	res, err := stream.CloseAndReceive()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.Msg.GetBodies())
+	assert.Equal(t, int64(8000), res.Msg.GetBytes())
+
+	stored, ok := storage.GetFlow("large")
+	require.True(t, ok)
+	assert.Equal(t, requestBody, stored.GetHttpFlow().GetRequest().GetContent())
+	assert.Equal(t, responseBody, stored.GetHttpFlow().GetResponse().GetContent())
+	assert.False(t, stored.GetHttpFlow().GetResponse().GetContentTruncated())
+	assert.Equal(t, int64(5000), stored.GetHttpFlowExtra().GetResponse().GetBodySize())
+	assert.Equal(t, bodySHA256(responseBody), stored.GetHttpFlowExtra().GetResponse().GetSha256())
+
+	stream = client.UploadFlowBody(context.Background())
+	require.NoError(t, stream.Send(mitmflowv1.UploadFlowBodyRequest_builder{
+		FlowId: proto.String("missing"),
+		Chunk:  []byte("x"),
+	}.Build()))
+	_, err = stream.CloseAndReceive()
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestListSubscribers(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	delivered := make(chan string, 10)
+	go server.streamFlows(ctx, "10.0.0.1:1234", mitmflowv1.StreamFlowsRequest_builder{ //nolint:errcheck
+		Filter: mitmflowv1.FlowFilter_builder{FilterText: proto.String("example")}.Build(),
+	}.Build(), func(resp *mitmflowv1.StreamFlowsResponse) error {
+		delivered <- resp.GetFlow().GetId()
+		return nil
+	})
+	require.Eventually(t, func() bool {
+		server.mu.RLock()
+		defer server.mu.RUnlock()
+		return len(server.subscribers) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// A subscriber that never reads has flows dropped once its buffer fills.
+	stalled := server.subscribe("10.0.0.2:1234", nil)
+	defer server.unsubscribe(stalled)
+
+	for range cap(stalled.ch) {
+		stalled.ch <- nil
+	}
+
+	flow := createFlow("a", time.Now())
+	flow.GetHttpFlow().SetRequest(mitmproxyv1.Request_builder{Url: proto.String("https://example.com/")}.Build())
+	server.broadcast(flow)
+	server.broadcast(flow)
+	server.broadcast(createFlow("unmatched", time.Now()))
+	assert.Equal(t, "a", <-delivered)
+	assert.Equal(t, "a", <-delivered)
+
+	var subs []*mitmflowv1.Subscriber
+	// Deliveries are counted once the send returns.
+	require.Eventually(t, func() bool {
+		res, err := server.ListSubscribers(context.Background(), connect.NewRequest(&mitmflowv1.ListSubscribersRequest{}))
+		require.NoError(t, err)
+		subs = res.Msg.GetSubscribers()
+		return len(subs) == 2 && subs[0].GetDelivered() == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "10.0.0.1:1234", subs[0].GetPeer())
+	assert.Equal(t, "example", subs[0].GetFilter().GetFilterText())
+	assert.Equal(t, int64(0), subs[0].GetDropped())
+	assert.Equal(t, stalled.id, subs[1].GetId())
+	assert.Equal(t, int64(0), subs[1].GetDelivered())
+	assert.Equal(t, int64(3), subs[1].GetDropped())
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+const storeShards = 64
+
+// flowEntry holds the current version of a flow. The sorted index points at
+// entries rather than flows so that updating a flow without changing its start
+// time, which is what happens when a response arrives, doesn't touch the index.
+type flowEntry struct {
+	flow  atomic.Pointer[mitmflowv1.Flow]
+	start int64
+}
+
+type storeShard struct {
+	mu    sync.RWMutex
+	flows map[string]*flowEntry
+}
+
+// shardedStore is a Store that spreads flows over shards keyed by flow ID so
+// lookups and in-place updates only contend with flows in the same shard. The
+// sorted index is a copy-on-write snapshot guarded by its own mutex, which is
+// only taken when flows are added, removed, or change their start time.
+//
+// Lock order is indexMu before any shard lock.
+type shardedStore struct {
+	shards  [storeShards]storeShard
+	indexMu sync.Mutex
+	sorted  atomic.Pointer[[]*flowEntry]
+	count   atomic.Int64
+}
+
+// NewShardedStore creates a new in-memory flow store tuned for concurrent
+// ingest and reads.
+func NewShardedStore() Store {
+	s := &shardedStore{}
+	for i := range s.shards {
+		s.shards[i].flows = make(map[string]*flowEntry)
+	}
+	s.setIndex(make([]*flowEntry, 0))
+	return s
+}
+
+func (s *shardedStore) shard(id string) *storeShard {
+	// FNV-1a, inlined to avoid allocating a hasher per lookup.
+	h := uint32(2166136261)
+	for i := 0; i < len(id); i++ {
+		h ^= uint32(id[i])
+		h *= 16777619
+	}
+	return &s.shards[h%storeShards]
+}
+
+func (s *shardedStore) index() []*flowEntry {
+	return *s.sorted.Load()
+}
+
+func (s *shardedStore) setIndex(entries []*flowEntry) {
+	s.sorted.Store(&entries)
+}
+
+func (s *shardedStore) Upsert(flow *mitmflowv1.Flow) {
+	id := GetFlowID(flow)
+	if id == "" {
+		return
+	}
+	start := GetFlowStartTime(flow)
+	shard := s.shard(id)
+
+	// Fast path: the flow exists and keeps its position in the index.
+	shard.mu.Lock()
+	if entry, ok := shard.flows[id]; ok && entry.start == start {
+		entry.flow.Store(flow)
+		shard.mu.Unlock()
+		return
+	}
+	shard.mu.Unlock()
+
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+
+	entry := &flowEntry{start: start}
+	entry.flow.Store(flow)
+
+	shard.mu.Lock()
+	previous, isUpdate := shard.flows[id]
+	shard.flows[id] = entry
+	shard.mu.Unlock()
+
+	index := s.index()
+	if isUpdate {
+		index = withoutEntries(index, map[*flowEntry]bool{previous: true})
+	} else {
+		s.count.Add(1)
+	}
+	s.setIndex(insertEntry(index, entry))
+}
+
+func (s *shardedStore) UpsertBatch(flows []*mitmflowv1.Flow) {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+
+	replaced := make(map[*flowEntry]bool)
+	added := make([]*flowEntry, 0, len(flows))
+	for _, flow := range flows {
+		id := GetFlowID(flow)
+		if id == "" {
+			continue
+		}
+		entry := &flowEntry{start: GetFlowStartTime(flow)}
+		entry.flow.Store(flow)
+
+		shard := s.shard(id)
+		shard.mu.Lock()
+		if previous, ok := shard.flows[id]; ok {
+			replaced[previous] = true
+		} else {
+			s.count.Add(1)
+		}
+		shard.flows[id] = entry
+		shard.mu.Unlock()
+		added = append(added, entry)
+	}
+
+	// Later duplicates within the batch replace earlier ones, so only keep
+	// entries that are still current.
+	stale := s.staleEntries(added)
+	old := s.index()
+	index := make([]*flowEntry, 0, len(old)+len(added))
+	for _, entry := range old {
+		if !replaced[entry] {
+			index = append(index, entry)
+		}
+	}
+	for _, entry := range added {
+		if !stale[entry] {
+			index = append(index, entry)
+		}
+	}
+	sort.SliceStable(index, func(i, j int) bool {
+		return index[i].start < index[j].start
+	})
+	s.setIndex(index)
+}
+
+// staleEntries returns the entries in entries that are no longer the current
+// entry for their flow ID.
+func (s *shardedStore) staleEntries(entries []*flowEntry) map[*flowEntry]bool {
+	stale := make(map[*flowEntry]bool)
+	for _, entry := range entries {
+		id := GetFlowID(entry.flow.Load())
+		shard := s.shard(id)
+		shard.mu.RLock()
+		if shard.flows[id] != entry {
+			stale[entry] = true
+		}
+		shard.mu.RUnlock()
+	}
+	return stale
+}
+
+func (s *shardedStore) Get(id string) (*mitmflowv1.Flow, bool) {
+	shard := s.shard(id)
+	shard.mu.RLock()
+	entry, ok := shard.flows[id]
+	shard.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return entry.flow.Load(), true
+}
+
+func (s *shardedStore) Delete(ids ...string) []string {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+
+	var deleted []string
+	toDelete := make(map[*flowEntry]bool)
+
+	for _, id := range ids {
+		shard := s.shard(id)
+		shard.mu.Lock()
+		if entry, ok := shard.flows[id]; ok {
+			delete(shard.flows, id)
+			toDelete[entry] = true
+			deleted = append(deleted, id)
+		}
+		shard.mu.Unlock()
+	}
+
+	if len(deleted) > 0 {
+		s.count.Add(-int64(len(deleted)))
+		s.setIndex(withoutEntries(s.index(), toDelete))
+	}
+
+	return deleted
+}
+
+func (s *shardedStore) DeleteAllUnpinned() []string {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+
+	var deleted []string
+	toDelete := make(map[*flowEntry]bool)
+
+	for i := range s.shards {
+		shard := &s.shards[i]
+		shard.mu.Lock()
+		for id, entry := range shard.flows {
+			if !pinnedByAnyone(entry.flow.Load()) {
+				delete(shard.flows, id)
+				toDelete[entry] = true
+				deleted = append(deleted, id)
+			}
+		}
+		shard.mu.Unlock()
+	}
+
+	if len(deleted) > 0 {
+		s.count.Add(-int64(len(deleted)))
+		s.setIndex(withoutEntries(s.index(), toDelete))
+	}
+
+	return deleted
+}
+
+func (s *shardedStore) Prune(maxSize int, minStart int64, keep func(*mitmflowv1.Flow) bool) []string {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+
+	index := s.index()
+	if len(index) <= maxSize && (len(index) == 0 || index[0].start >= minStart) {
+		return nil
+	}
+
+	toRemove := len(index) - maxSize
+	kept := make([]*flowEntry, 0, min(len(index), maxSize))
+	var deleted []string
+
+	for _, entry := range index {
+		flow := entry.flow.Load()
+		if (len(deleted) < toRemove || entry.start < minStart) && !keep(flow) {
+			id := GetFlowID(flow)
+			shard := s.shard(id)
+			shard.mu.Lock()
+			delete(shard.flows, id)
+			shard.mu.Unlock()
+			deleted = append(deleted, id)
+			continue
+		}
+		kept = append(kept, entry)
+	}
+
+	s.count.Add(-int64(len(deleted)))
+	s.setIndex(kept)
+	return deleted
+}
+
+func (s *shardedStore) Len() int {
+	return int(s.count.Load())
+}
+
+func (s *shardedStore) Walk(fn func(*mitmflowv1.Flow) bool) {
+	for _, entry := range s.index() {
+		if !fn(entry.flow.Load()) {
+			break
+		}
+	}
+}
+
+func (s *shardedStore) ReverseWalk(fn func(*mitmflowv1.Flow) bool) {
+	index := s.index()
+	for i := len(index) - 1; i >= 0; i-- {
+		if !fn(index[i].flow.Load()) {
+			break
+		}
+	}
+}
+
+// insertEntry returns index with entry inserted in start time order. Like
+// upsertSorted, appending to the end reuses the backing array.
+func insertEntry(index []*flowEntry, entry *flowEntry) []*flowEntry {
+	if len(index) == 0 || entry.start >= index[len(index)-1].start {
+		return append(index, entry)
+	}
+
+	i := sort.Search(len(index), func(i int) bool {
+		return index[i].start > entry.start
+	})
+
+	result := make([]*flowEntry, 0, len(index)+1)
+	result = append(result, index[:i]...)
+	result = append(result, entry)
+	return append(result, index[i:]...)
+}
+
+// withoutEntries returns a new slice holding the entries of index that are not
+// in toDelete.
+func withoutEntries(index []*flowEntry, toDelete map[*flowEntry]bool) []*flowEntry {
+	result := make([]*flowEntry, 0, max(len(index)-len(toDelete), 0))
+	for _, entry := range index {
+		if !toDelete[entry] {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/url"
+	"strings"
+
+	"connectrpc.com/connect"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// shareBundlePrefix starts a share bundle, so one can be told apart from a
+	// FlowSet on import.
+	shareBundlePrefix = "mitmflow-share:"
+	// redacted replaces the values that a redacted bundle leaves out.
+	redacted = "REDACTED"
+	// maxShareBundleSize bounds how much a share bundle may inflate to on
+	// import.
+	maxShareBundleSize = 256 << 20
+)
+
+// CreateShareBundle packages a single flow into a line of text that another
+// instance can load with ImportFlows, e.g. to attach a failing request to a
+// bug report.
+func (s *MITMFlowServer) CreateShareBundle(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.CreateShareBundleRequest],
+) (*connect.Response[mitmflowv1.CreateShareBundleResponse], error) {
+	id := req.Msg.GetFlowId()
+	flow, ok := s.visibleFlow(ctx, id)
+	if !ok {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("flow not found: %s", id))
+	}
+	if req.Msg.GetRedact() && flow.GetHttpFlow() == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("only http flows can be redacted: %s", id))
+	}
+	flow, err := s.storage.HydrateFlow(ctx, flow)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	flow = proto.Clone(flow).(*mitmflowv1.Flow)
+	if req.Msg.GetRedact() {
+		redactHTTPFlow(flow.GetHttpFlow())
+	}
+
+	bundle, err := encodeShareBundle(flow)
+	if err != nil {
+		log.Printf("Share bundle for %s failed: %v", id, err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	s.audit(ctx, req.Peer(), mitmflowv1.AuditAction_AUDIT_ACTION_EXPORT, []string{id}, 1, "share bundle")
+	return connect.NewResponse(mitmflowv1.CreateShareBundleResponse_builder{
+		Bundle:   proto.String(bundle),
+		Filename: proto.String("flow-" + id + ".mitmflow"),
+	}.Build()), nil
+}
+
+func encodeShareBundle(flow *mitmflowv1.Flow) (string, error) {
+	data, err := proto.Marshal(mitmflowv1.FlowSet_builder{Flows: []*mitmflowv1.Flow{flow}}.Build())
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return "", err
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+	return shareBundlePrefix + base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// decodeFlowSet reads a FlowSet or a share bundle.
+func decodeFlowSet(data []byte) (*mitmflowv1.FlowSet, error) {
+	if text := bytes.TrimSpace(data); bytes.HasPrefix(text, []byte(shareBundlePrefix)) {
+		decoded, err := base64.RawURLEncoding.DecodeString(string(text[len(shareBundlePrefix):]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid share bundle: %w", err)
+		}
+		zr, err := gzip.NewReader(bytes.NewReader(decoded))
+		if err != nil {
+			return nil, err
+		}
+		data, err = io.ReadAll(io.LimitReader(zr, maxShareBundleSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxShareBundleSize {
+			return nil, fmt.Errorf("share bundle is larger than %d bytes", maxShareBundleSize)
+		}
+	}
+	set := &mitmflowv1.FlowSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// redactHTTPFlow replaces credentials in a flow, which must not be shared,
+// with "REDACTED". Header, parameter and field names are kept so the flow
+// still shows what was sent.
+func redactHTTPFlow(h *mitmproxyv1.HTTPFlow) {
+	if req := h.GetRequest(); req != nil {
+		req.SetUrl(redactURL(req.GetUrl()))
+		if req.HasPrettyUrl() {
+			req.SetPrettyUrl(redactURL(req.GetPrettyUrl()))
+		}
+		req.SetHeaders(redactHeaders(req.GetHeaders()))
+		req.SetTrailers(redactHeaders(req.GetTrailers()))
+		if content, ok := redactBody(req.GetContent(), req.GetHeaders()); ok {
+			req.SetContent(content)
+		}
+	}
+	if res := h.GetResponse(); res != nil {
+		res.SetHeaders(redactHeaders(res.GetHeaders()))
+		res.SetTrailers(redactHeaders(res.GetTrailers()))
+		if content, ok := redactBody(res.GetContent(), res.GetHeaders()); ok {
+			res.SetContent(content)
+		}
+	}
+}
+
+// isSecretName reports whether a header, parameter or field name looks like
+// it holds a credential.
+func isSecretName(name string) bool {
+	n := strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(name))
+	switch n {
+	case "authorization", "proxyauthorization", "key", "code", "sig":
+		return true
+	}
+	for _, word := range []string{"token", "secret", "password", "passwd", "apikey", "session", "signature", "csrf", "xsrf", "credential"} {
+		if strings.Contains(n, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactHeaders(headers map[string]string) map[string]string {
+	if len(headers) == 0 {
+		return headers
+	}
+	res := make(map[string]string, len(headers))
+	for k, v := range headers {
+		switch strings.ToLower(k) {
+		case "cookie":
+			v = redactCookie(v)
+		case "set-cookie":
+			var lines []string
+			for _, line := range splitSetCookie(v) {
+				name, attrs, _ := strings.Cut(line, ";")
+				name, _, _ = strings.Cut(name, "=")
+				if attrs != "" {
+					attrs = ";" + attrs
+				}
+				lines = append(lines, strings.TrimSpace(name)+"="+redacted+attrs)
+			}
+			v = strings.Join(lines, "\n")
+		default:
+			if isSecretName(k) {
+				v = redacted
+			}
+		}
+		res[k] = v
+	}
+	return res
+}
+
+// redactCookie keeps the names of the cookies in a Cookie header.
+func redactCookie(header string) string {
+	pairs := strings.Split(header, ";")
+	for i, pair := range pairs {
+		name, _, _ := strings.Cut(pair, "=")
+		pairs[i] = strings.TrimSpace(name) + "=" + redacted
+	}
+	return strings.Join(pairs, "; ")
+}
+
+// redactURL replaces the password and secret query parameters of a URL,
+// leaving the rest of it as it was written.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+	u.RawQuery = redactQuery(u.RawQuery)
+	return u.String()
+}
+
+// redactQuery redacts the values of secret parameters in a query string or
+// form body without reordering or re-encoding the others.
+func redactQuery(query string) string {
+	if query == "" {
+		return query
+	}
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		name, _, hasValue := strings.Cut(param, "=")
+		if unescaped, err := url.QueryUnescape(name); err == nil && hasValue && isSecretName(unescaped) {
+			params[i] = name + "=" + redacted
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// redactBody redacts form and JSON bodies, decoding them first when they
+// were sent compressed. A JSON body that doesn't parse, e.g. because it was
+// truncated, is replaced as a whole. It returns false when there is no body.
+func redactBody(content []byte, headers map[string]string) ([]byte, bool) {
+	if len(content) == 0 {
+		return nil, false
+	}
+	mediaType, _, _ := mime.ParseMediaType(getHeaderValue(headers, "Content-Type"))
+	if decoded, ok := decodeContentEncoding(content, getHeaderValue(headers, "Content-Encoding")); ok {
+		// The decoded body can't be re-encoded the same way, so the header no
+		// longer applies.
+		for k := range headers {
+			if strings.EqualFold(k, "Content-Encoding") {
+				delete(headers, k)
+			}
+		}
+		content = decoded
+	}
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		return []byte(redactQuery(string(content))), true
+	case isJSONMediaType(mediaType):
+		dec := json.NewDecoder(bytes.NewReader(content))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return []byte(redacted), true
+		}
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(redactJSON(v)); err != nil {
+			return []byte(redacted), true
+		}
+		return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), true
+	}
+	return content, true
+}
+
+func redactJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			if isSecretName(k) {
+				v[k] = redacted
+			} else {
+				v[k] = redactJSON(item)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactJSON(item)
+		}
+	}
+	return v
+}
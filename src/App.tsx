@@ -1,8 +1,8 @@
 import React, { useState, useEffect, useMemo, useRef, useCallback } from 'react';
-import { Search, Pause, Play, Download, Braces, HardDriveDownload, Menu, Filter, X, Settings, Trash, ChevronDown } from 'lucide-react';
+import { Search, Pause, Play, Download, Braces, HardDriveDownload, Menu, Filter, X, Settings, Trash, ChevronDown, Package, Send, EyeOff, Table, FileText, SlidersHorizontal } from 'lucide-react';
 import { createConnectTransport } from "@connectrpc/connect-web";
-import { createClient } from "@connectrpc/connect";
-import { Flow, FlowSummary, FlowSchema, ExportFormat, Service, FlowFilterSchema, GetFlowsRequestSchema, StreamFlowsRequestSchema } from "./gen/mitmflow/v1/mitmflow_pb";
+import { Code, ConnectError, createClient } from "@connectrpc/connect";
+import { Flow, FlowSummary, FlowSchema, ExportFormat, FindingSeverity, ProxyRules, Service, FlowFilterSchema, GetFlowsRequestSchema, StreamFlowsRequestSchema } from "./gen/mitmflow/v1/mitmflow_pb";
 import { toJson, create } from "@bufbuild/protobuf";
 import { DnsFlowDetails } from './components/DnsFlowDetails';
 import { HttpFlowDetails } from './components/HttpFlowDetails';
@@ -12,12 +12,21 @@ import { ContentFormat, getFlowId, getFlowTimestampNs } from './utils';
 import { DetailsPanel } from './components/DetailsPanel';
 import FilterModal from './components/FilterModal';
 import NoteModal from './components/NoteModal';
+import ComposeRequestModal, { ComposedRequest } from './components/ComposeRequestModal';
 import SettingsModal from './components/SettingsModal';
-import useFilterStore, { FlowType } from './store';
+import ProxyRulesModal from './components/ProxyRulesModal';
+import useFilterStore, { FlowType, SecuritySeverity, SECURITY_SEVERITIES } from './store';
 import useSettingsStore from './settingsStore';
 import FlowTable from './components/FlowTable';
 import { Toast } from './components/Toast';
 import { useDebounce } from './hooks/useDebounce';
+import { streamFlowsWebSocket } from './wsStream';
+
+const SECURITY_SEVERITY_VALUES: Record<SecuritySeverity, FindingSeverity> = {
+  info: FindingSeverity.INFO,
+  low: FindingSeverity.LOW,
+  medium: FindingSeverity.MEDIUM,
+};
 
 
 // --- MAIN APP COMPONENT ---
@@ -25,14 +34,38 @@ import { useDebounce } from './hooks/useDebounce';
 declare global {
   interface Window {
     MITMFLOW_GRPC_ADDR?: string;
+    MITMFLOW_BASE_PATH?: string;
+    MITMFLOW_LOGIN_URL?: string;
   }
 }
 
+// signInAgainIfExpired sends users signed in with OIDC back to sign in when
+// their session has expired, returning them to the current page afterwards.
+const signInAgainIfExpired = (err: unknown) => {
+  if (!window.MITMFLOW_LOGIN_URL || useSettingsStore.getState().accessToken) return;
+  if (ConnectError.from(err).code === Code.Unauthenticated) {
+    window.location.href = `${window.MITMFLOW_LOGIN_URL}?return=${encodeURIComponent(window.location.pathname + window.location.search)}`;
+  }
+};
+
 type ConnectionStatus = 'connecting' | 'live' | 'paused' | 'failed' | 'reconnecting';
 
 const App: React.FC = () => {
   // Use relative URL - in dev mode Vite proxies to backend, in production both are served from same origin
-  const client = useMemo(() => createClient(Service, createConnectTransport({ baseUrl: window.MITMFLOW_GRPC_ADDR || "." })), []);
+  const client = useMemo(() => createClient(Service, createConnectTransport({
+    baseUrl: window.MITMFLOW_GRPC_ADDR || ".",
+    // The token is read on every call so a new one from the settings applies straight away.
+    interceptors: [(next) => async (req) => {
+      const { accessToken } = useSettingsStore.getState();
+      if (accessToken) req.header.set('Authorization', `Bearer ${accessToken}`);
+      try {
+        return await next(req);
+      } catch (err) {
+        signInAgainIfExpired(err);
+        throw err;
+      }
+    }],
+  })), []);
   // --- State ---
   const [flowState, setFlowState] = useState<{ all: FlowSummary[]; filtered: FlowSummary[]; newIds: Set<string> }>({ all: [], filtered: [], newIds: new Set() });
   const [detailsFlow, setDetailsFlow] = useState<Flow | null>(null);
@@ -43,6 +76,9 @@ const App: React.FC = () => {
   const [connectionStatus, setConnectionStatus] = useState<ConnectionStatus>('connecting');
   const [isBackfilling, setIsBackfilling] = useState(false);
   const latestTimestampNs = useRef<bigint>(BigInt(0));
+  // The server's change sequence as of the newest flow seen, used to resume
+  // the live stream without missing updates to older flows.
+  const latestSequence = useRef<bigint>(BigInt(0));
   const {
     text: filterText,
     setText: setFilterText,
@@ -54,10 +90,14 @@ const App: React.FC = () => {
     setFlowTypes,
     clientIps,
     setClientIps,
+    serverCountries,
+    setServerCountries,
     http,
     setHttpMethods,
     setHttpContentTypes,
     setHttpStatusCodes,
+    setHttpClientFamilies,
+    setHttpMinSecuritySeverity,
     clearFilters
   } = useFilterStore();
 
@@ -98,6 +138,9 @@ const App: React.FC = () => {
     if (params.has('client_ip')) {
       setClientIps(params.get('client_ip')?.split(',') || []);
     }
+    if (params.has('country')) {
+      setServerCountries(params.get('country')?.split(',') || []);
+    }
     if (params.has('method')) {
       setHttpMethods(params.get('method')?.split(',') || []);
     }
@@ -107,6 +150,13 @@ const App: React.FC = () => {
     if (params.has('content')) {
       setHttpContentTypes(params.get('content')?.split(',') || []);
     }
+    if (params.has('client')) {
+      setHttpClientFamilies(params.get('client')?.split(',') || []);
+    }
+    const security = params.get('security');
+    if (security && SECURITY_SEVERITIES.some(s => s.value === security)) {
+      setHttpMinSecuritySeverity(security as SecuritySeverity);
+    }
   }, []);
 
   // Update URL
@@ -125,16 +175,21 @@ const App: React.FC = () => {
     }
     if (flowTypes.length > 0) params.set('type', flowTypes.join(','));
     if (clientIps.length > 0) params.set('client_ip', clientIps.join(','));
+    if (serverCountries.length > 0) params.set('country', serverCountries.join(','));
     if (http.methods.length > 0) params.set('method', http.methods.join(','));
     if (http.statusCodes.length > 0) params.set('status', http.statusCodes.join(','));
     if (http.contentTypes.length > 0) params.set('content', http.contentTypes.join(','));
+    if (http.clientFamilies.length > 0) params.set('client', http.clientFamilies.join(','));
+    if (http.minSecuritySeverity) params.set('security', http.minSecuritySeverity);
 
     const newUrl = params.toString() ? `?${params.toString()}` : window.location.pathname;
     window.history.replaceState(null, '', newUrl);
-  }, [filterText, pinned, hasNote, flowTypes, clientIps, http]);
+  }, [filterText, pinned, hasNote, flowTypes, clientIps, serverCountries, http]);
 
   const [isFilterModalOpen, setIsFilterModalOpen] = useState(false);
   const [isNoteModalOpen, setIsNoteModalOpen] = useState(false);
+  const [isComposeModalOpen, setIsComposeModalOpen] = useState(false);
+  const [isProxyRulesModalOpen, setIsProxyRulesModalOpen] = useState(false);
   const handleCloseFilterModal = useCallback(() => setIsFilterModalOpen(false), []);
   const handleCloseSettingsModal = useCallback(() => setIsSettingsModalOpen(false), []);
   const [isPanelMinimized, setIsPanelMinimized] = useState(false);
@@ -147,11 +202,11 @@ const App: React.FC = () => {
   const [requestFormats, setRequestFormats] = useState<Map<string, ContentFormat>>(new Map());
   const [responseFormats, setResponseFormats] = useState<Map<string, ContentFormat>>(new Map());
   const [isSettingsModalOpen, setIsSettingsModalOpen] = useState(false);
-  const [toast, setToast] = useState<{ message: string; visible: boolean } | null>(null);
+  const [toast, setToast] = useState<{ message: string; visible: boolean; action?: { label: string; onClick: () => void } } | null>(null);
   const [lastSelectedTabs, setLastSelectedTabs] = useState<Record<string, string>>({});
 
-  const showToast = useCallback((message: string) => {
-    setToast({ message, visible: true });
+  const showToast = useCallback((message: string, action?: { label: string; onClick: () => void }) => {
+    setToast({ message, visible: true, action });
   }, []);
 
   const hideToast = useCallback(() => {
@@ -269,6 +324,72 @@ const App: React.FC = () => {
     URL.revokeObjectURL(url);
   }, [client, showToast]);
 
+  const getCookieTimeline = useCallback(async (name: string, domain: string) => {
+    const response = await client.getCookieTimeline({ name, domain });
+    return response.events;
+  }, [client]);
+
+  const getRedirectChain = useCallback(async (flowId: string) => {
+    const response = await client.getRedirectChain({ flowId });
+    return response.hops;
+  }, [client]);
+
+  const loadProxyRules = useCallback(async () => (await client.getProxyRules({})).rules, [client]);
+  const saveProxyRules = useCallback(async (rules: ProxyRules) => {
+    await client.setProxyRules({ rules });
+    setIsProxyRulesModalOpen(false);
+  }, [client]);
+  const handleCloseProxyRulesModal = useCallback(() => setIsProxyRulesModalOpen(false), []);
+
+  const diffWithPrevious = useCallback((flowId: string) => client.diffWithPrevious({ flowId }), [client]);
+
+  const getFlowFrames = useCallback((flowId: string, response: boolean, offset: number) => client.getFlowFrames({ flowId, response, offset }), [client]);
+
+  const copyRPCCommand = useCallback(async (flow: Flow, tool: 'grpcurl' | 'buf-curl') => {
+    const flowId = getFlowId(flow);
+    if (!flowId) return;
+    try {
+      const response = await client.exportFlows({
+        flowIds: [flowId],
+        format: tool === 'grpcurl' ? ExportFormat.GRPCURL : ExportFormat.BUF_CURL,
+      });
+      if (response.data.length === 0) {
+        showToast('Not a gRPC, gRPC-Web or Connect request');
+        return;
+      }
+      await navigator.clipboard.writeText(new TextDecoder().decode(response.data));
+      showToast(`Copied ${tool === 'grpcurl' ? 'grpcurl' : 'buf curl'} command`);
+    } catch (err) {
+      console.error(`Failed to generate ${tool} command`, err);
+      showToast(`Failed to generate ${tool} command`);
+    }
+  }, [client, showToast]);
+
+  const shareFlow = useCallback(async (flow: Flow, action: 'copy' | 'download') => {
+    const flowId = getFlowId(flow);
+    if (!flowId) return;
+    try {
+      // Only HTTP flows can be redacted; other flows are shared as captured.
+      const response = await client.createShareBundle({ flowId, redact: flow.flow.case === 'httpFlow' });
+      if (action === 'copy') {
+        await navigator.clipboard.writeText(response.bundle);
+        showToast('Copied share bundle');
+        return;
+      }
+      const url = URL.createObjectURL(new Blob([response.bundle + '\n'], { type: 'text/plain;charset=utf-8' }));
+      const a = document.createElement('a');
+      a.href = url;
+      a.download = response.filename || `${flowId}.mitmflow`;
+      document.body.appendChild(a);
+      a.click();
+      document.body.removeChild(a);
+      URL.revokeObjectURL(url);
+    } catch (err) {
+      console.error("Failed to create share bundle", err);
+      showToast("Failed to create share bundle");
+    }
+  }, [client, showToast]);
+
   const flowBuffer = useRef<FlowSummary[]>([]);
 
   const processIncomingFlow = useCallback((incomingFlow: FlowSummary) => {
@@ -314,6 +435,9 @@ const App: React.FC = () => {
              if (flowTs > latestTimestampNs.current) {
                latestTimestampNs.current = flowTs;
              }
+             if (incomingFlow.sequence > latestSequence.current) {
+               latestSequence.current = incomingFlow.sequence;
+             }
 
              if (incomingFlowId && flowIdMap.has(incomingFlowId)) {
                const idx = flowIdMap.get(incomingFlowId)!;
@@ -379,12 +503,15 @@ const App: React.FC = () => {
       hasNote,
       flowTypes,
       clientIps,
+      serverCountries,
       http: {
         methods: http.methods,
         contentTypes: http.contentTypes,
         statusCodes: http.statusCodes,
+        clientFamilies: http.clientFamilies,
+        minSecuritySeverity: http.minSecuritySeverity ? SECURITY_SEVERITY_VALUES[http.minSecuritySeverity] : FindingSeverity.UNSPECIFIED,
       },
-  }), [debouncedFilterText, pinned, hasNote, flowTypes, clientIps, http]);
+  }), [debouncedFilterText, pinned, hasNote, flowTypes, clientIps, serverCountries, http]);
 
   const processHistoryFlow = useCallback((incomingFlow: FlowSummary) => {
     setFlowState(prevState => {
@@ -411,6 +538,7 @@ const App: React.FC = () => {
   useEffect(() => {
     // Reset state on filter change
     latestTimestampNs.current = BigInt(0);
+    latestSequence.current = BigInt(0);
     setFlowState({ all: [], filtered: [], newIds: new Set() });
     newFlowsMap.current.clear();
     setIsFlowsTruncated(false);
@@ -430,6 +558,9 @@ const App: React.FC = () => {
          });
          const stream = client.getFlows(req, { signal });
          for await (const res of stream) {
+             if (res.sequence > latestSequence.current) {
+                 latestSequence.current = res.sequence;
+             }
              if (res.flow) {
                  processHistoryFlow(res.flow); // Use synchronous processor
              }
@@ -440,6 +571,7 @@ const App: React.FC = () => {
        } catch (err) {
          if (!signal.aborted) {
             console.error("History fetch error:", err);
+            signInAgainIfExpired(err);
             if (retryCount < 5) {
                 if (retryCount > 0) setConnectionStatus('reconnecting');
                 setTimeout(() => fetchHistory(retryCount + 1), 2000);
@@ -458,6 +590,7 @@ const App: React.FC = () => {
     };
   }, [filter, maxFlows, client, processHistoryFlow]);
 
+  const useWebSocketStream = useRef(false);
   useEffect(() => {
       if (isPaused) {
           setConnectionStatus('paused');
@@ -473,15 +606,46 @@ const App: React.FC = () => {
           try {
               const req = create(StreamFlowsRequestSchema, {
                   sinceTimestampNs: latestTimestampNs.current,
+                  sinceSequence: latestSequence.current,
                   filter,
               });
-              const stream = client.streamFlows(req, { signal });
-              setConnectionStatus('live');
-
-              for await (const res of stream) {
-                  if (res.response.case === 'flow') {
-                      processIncomingFlow(res.response.value); // Use batched processor
+              // Some proxies cut long-lived HTTP/2 streams; after repeated
+              // failures switch to the WebSocket transport for the session.
+              if (retryCount >= 2) useWebSocketStream.current = true;
+              // The server sends keepalives on idle streams. If they stop
+              // arriving the connection is gone even though the browser
+              // hasn't noticed, so give up on it and reconnect.
+              const attempt = new AbortController();
+              const abortAttempt = () => attempt.abort();
+              signal.addEventListener('abort', abortAttempt);
+              let keepaliveMs = 0;
+              let watchdog: ReturnType<typeof setTimeout> | undefined;
+              const armWatchdog = () => {
+                  clearTimeout(watchdog);
+                  if (keepaliveMs > 0) {
+                      watchdog = setTimeout(() => {
+                          setConnectionStatus('reconnecting');
+                          attempt.abort();
+                      }, keepaliveMs * 3);
+                  }
+              };
+              try {
+                  const stream = useWebSocketStream.current
+                      ? streamFlowsWebSocket(window.MITMFLOW_GRPC_ADDR || ".", req, attempt.signal, useSettingsStore.getState().accessToken)
+                      : client.streamFlows(req, { signal: attempt.signal });
+                  setConnectionStatus('live');
+
+                  for await (const res of stream) {
+                      if (res.response.case === 'flow') {
+                          processIncomingFlow(res.response.value); // Use batched processor
+                      } else if (res.response.case === 'keepalive') {
+                          keepaliveMs = Number(res.response.value.intervalMs);
+                      }
+                      armWatchdog();
                   }
+              } finally {
+                  clearTimeout(watchdog);
+                  signal.removeEventListener('abort', abortAttempt);
               }
               if (!signal.aborted) {
                   retryTimeout = setTimeout(() => subscribeLive(0), 2000);
@@ -489,6 +653,7 @@ const App: React.FC = () => {
           } catch (err) {
               if (signal.aborted) return;
               console.error("Live stream error:", err);
+              signInAgainIfExpired(err);
               if (retryCount > 0) setConnectionStatus('reconnecting');
               retryTimeout = setTimeout(() => subscribeLive(retryCount + 1), 2000);
           }
@@ -511,9 +676,12 @@ const App: React.FC = () => {
     (hasNote !== undefined ? 1 : 0) +
     (flowTypes.length > 0 ? 1 : 0) +
     (clientIps.length > 0 ? 1 : 0) +
+    (serverCountries.length > 0 ? 1 : 0) +
     (http.methods.length > 0 ? 1 : 0) +
     (http.contentTypes.length > 0 ? 1 : 0) +
-    (http.statusCodes.length > 0 ? 1 : 0);
+    (http.statusCodes.length > 0 ? 1 : 0) +
+    (http.clientFamilies.length > 0 ? 1 : 0) +
+    (http.minSecuritySeverity ? 1 : 0);
 
   const uniqueClientIps = useMemo(() => {
     const ips = new Set<string>();
@@ -539,23 +707,31 @@ const App: React.FC = () => {
   }
 
   // --- Event Handlers ---
-  const handleDownloadSelectedFlows = async (format: 'har' | 'json') => {
+  const handleDownloadSelectedFlows = async (format: 'har' | 'json' | 'proto' | 'saz' | 'chlsj' | 'grpc-frames' | 'csv' | 'md', anonymize = false) => {
     const ids = Array.from(selectedFlowIds);
     if (ids.length === 0) return;
 
     try {
-      const exportFormat = format === 'har' 
-        ? ExportFormat.HAR 
-        : ExportFormat.JSON;
+      const exportFormat = {
+        har: ExportFormat.HAR,
+        json: ExportFormat.JSON,
+        proto: ExportFormat.PROTO,
+        saz: ExportFormat.SAZ,
+        chlsj: ExportFormat.CHARLES,
+        'grpc-frames': ExportFormat.GRPC_FRAMES,
+        csv: ExportFormat.CSV,
+        md: ExportFormat.MARKDOWN,
+      }[format];
       
       const response = await client.exportFlows({
         flowIds: ids,
         format: exportFormat,
+        anonymize,
       });
 
       if (response.data) {
         // eslint-disable-next-line @typescript-eslint/no-explicit-any
-        const blob = new Blob([response.data as any], { type: format === 'har' ? 'application/json' : 'application/json' });
+        const blob = new Blob([response.data as any], { type: format === 'har' || format === 'json' || format === 'chlsj' ? 'application/json' : format === 'csv' ? 'text/csv' : format === 'md' ? 'text/markdown' : 'application/octet-stream' });
         const url = URL.createObjectURL(blob);
         const a = document.createElement('a');
         a.href = url;
@@ -585,11 +761,30 @@ const App: React.FC = () => {
     setIsDeleteMenuOpen(false);
   };
 
+  // Restored flows come back through the flow stream.
+  const offerUndoDelete = useCallback((flowIds: string[], restorable: boolean, message?: string) => {
+    if (!restorable || flowIds.length === 0) {
+      if (message) showToast(message);
+      return;
+    }
+    const noun = flowIds.length === 1 ? 'flow' : 'flows';
+    showToast(message ?? `Deleted ${flowIds.length} ${noun}`, {
+      label: 'Undo',
+      onClick: () => {
+        client.restoreFlows({ flowIds }).catch(err => {
+          console.error("Failed to restore flows", err);
+          showToast(`Failed to restore ${noun}`);
+        });
+      },
+    });
+  }, [client, showToast]);
+
   const handleDeleteSelectedFlows = async () => {
     const ids = Array.from(selectedFlowIds);
     if (ids.length === 0) return;
     try {
-      await client.deleteFlows({ flowIds: ids });
+      const res = await client.deleteFlows({ flowIds: ids });
+      offerUndoDelete(res.flowIds, res.restorable);
       setFlowState(prev => ({
         all: prev.all.filter(f => !ids.includes(f.id)),
         filtered: prev.filtered.filter(f => !ids.includes(f.id)),
@@ -607,12 +802,12 @@ const App: React.FC = () => {
   };
 
   const handleDeleteAllFlows = async () => {
-    if (!window.confirm("Are you sure you want to delete all flows? This cannot be undone.")) {
+    if (!window.confirm("Are you sure you want to delete all flows?")) {
       setIsDeleteMenuOpen(false);
       return;
     }
     try {
-      await client.deleteFlows({ all: true });
+      const res = await client.deleteFlows({ all: true });
       const pinnedFlows = flowState.all.filter(f => f.pinned);
       if (pinnedFlows.length > 0) {
         setFlowState({
@@ -620,9 +815,10 @@ const App: React.FC = () => {
           filtered: pinnedFlows,
           newIds: new Set()
         });
-        showToast("Pinned flows were not deleted. Select and delete them explicitly to remove.");
+        offerUndoDelete(res.flowIds, res.restorable, "Pinned flows were not deleted. Select and delete them explicitly to remove.");
       } else {
         handleClearFlows();
+        offerUndoDelete(res.flowIds, res.restorable);
       }
     } catch (err) {
       console.error("Failed to delete all flows", err);
@@ -634,7 +830,8 @@ const App: React.FC = () => {
     const flowId = getFlowId(flow);
     if (!flowId) return;
     try {
-      await client.deleteFlows({ flowIds: [flowId] });
+      const res = await client.deleteFlows({ flowIds: [flowId] });
+      offerUndoDelete(res.flowIds, res.restorable);
       setFlowState(prev => ({
         all: prev.all.filter(f => f.id !== flowId),
         filtered: prev.filtered.filter(f => f.id !== flowId),
@@ -652,7 +849,7 @@ const App: React.FC = () => {
     } catch (err) {
       console.error("Failed to delete flow", err);
     }
-  }, [client, selectedFlowId]);
+  }, [client, selectedFlowId, offerUndoDelete]);
 
   const handleSetRequestFormat = useCallback((flowId: string, format: ContentFormat) => {
     setRequestFormats(prev => {
@@ -749,6 +946,21 @@ const App: React.FC = () => {
         }
     }, 200);
   }, [client, showToast]);
+
+  const selectFlowById = useCallback((flowId: string) => {
+    const summary = flowState.all.find(f => f.id === flowId);
+    if (summary) handleFlowSelection(summary);
+  }, [flowState.all, handleFlowSelection]);
+
+  // Open the flow a ?flow=ID link points at, like the ones in alerts, once it
+  // has loaded.
+  const linkedFlowId = useRef(new URLSearchParams(window.location.search).get('flow'));
+  useEffect(() => {
+    const flowId = linkedFlowId.current;
+    if (!flowId || !flowState.all.some(f => f.id === flowId)) return;
+    linkedFlowId.current = null;
+    selectFlowById(flowId);
+  }, [flowState.all, selectFlowById]);
   useEffect(() => {
     return () => {
         if (detailsAbortController.current) {
@@ -856,6 +1068,55 @@ const App: React.FC = () => {
                       >
                         <Braces size={16} /> Download Flows (JSON)
                       </a>
+                      <a
+                        href="#"
+                        onClick={(e) => { e.preventDefault(); handleDownloadSelectedFlows('proto'); setIsBulkDownloadOpen(false); setIsMenuOpen(false); }}
+                        className="flex items-center gap-3 px-4 py-2.5 text-sm text-gray-700 dark:text-zinc-200 hover:bg-gray-100 dark:hover:bg-zinc-700"
+                      >
+                        <Package size={16} /> Download Bundle
+                      </a>
+                      <a
+                        href="#"
+                        onClick={(e) => { e.preventDefault(); handleDownloadSelectedFlows('saz'); setIsBulkDownloadOpen(false); setIsMenuOpen(false); }}
+                        className="flex items-center gap-3 px-4 py-2.5 text-sm text-gray-700 dark:text-zinc-200 hover:bg-gray-100 dark:hover:bg-zinc-700"
+                      >
+                        <HardDriveDownload size={16} /> Download Fiddler (SAZ)
+                      </a>
+                      <a
+                        href="#"
+                        onClick={(e) => { e.preventDefault(); handleDownloadSelectedFlows('chlsj'); setIsBulkDownloadOpen(false); setIsMenuOpen(false); }}
+                        className="flex items-center gap-3 px-4 py-2.5 text-sm text-gray-700 dark:text-zinc-200 hover:bg-gray-100 dark:hover:bg-zinc-700"
+                      >
+                        <HardDriveDownload size={16} /> Download Charles (CHLSJ)
+                      </a>
+                      <a
+                        href="#"
+                        onClick={(e) => { e.preventDefault(); handleDownloadSelectedFlows('grpc-frames'); setIsBulkDownloadOpen(false); setIsMenuOpen(false); }}
+                        className="flex items-center gap-3 px-4 py-2.5 text-sm text-gray-700 dark:text-zinc-200 hover:bg-gray-100 dark:hover:bg-zinc-700"
+                      >
+                        <Package size={16} /> Download gRPC Frames
+                      </a>
+                      <a
+                        href="#"
+                        onClick={(e) => { e.preventDefault(); handleDownloadSelectedFlows('csv'); setIsBulkDownloadOpen(false); setIsMenuOpen(false); }}
+                        className="flex items-center gap-3 px-4 py-2.5 text-sm text-gray-700 dark:text-zinc-200 hover:bg-gray-100 dark:hover:bg-zinc-700"
+                      >
+                        <Table size={16} /> Download Summary (CSV)
+                      </a>
+                      <a
+                        href="#"
+                        onClick={(e) => { e.preventDefault(); handleDownloadSelectedFlows('md'); setIsBulkDownloadOpen(false); setIsMenuOpen(false); }}
+                        className="flex items-center gap-3 px-4 py-2.5 text-sm text-gray-700 dark:text-zinc-200 hover:bg-gray-100 dark:hover:bg-zinc-700"
+                      >
+                        <FileText size={16} /> Download Summary (Markdown)
+                      </a>
+                      <a
+                        href="#"
+                        onClick={(e) => { e.preventDefault(); handleDownloadSelectedFlows('har', true); setIsBulkDownloadOpen(false); setIsMenuOpen(false); }}
+                        className="flex items-center gap-3 px-4 py-2.5 text-sm text-gray-700 dark:text-zinc-200 hover:bg-gray-100 dark:hover:bg-zinc-700"
+                      >
+                        <EyeOff size={16} /> Download Anonymized HAR
+                      </a>
                     </div>
                   )}
                 </div>
@@ -866,6 +1127,12 @@ const App: React.FC = () => {
                     <Settings size={20} /> Settings
                   </button>
                 <button
+                    onClick={() => { setIsProxyRulesModalOpen(true); setIsMenuOpen(false); }}
+                    className="block w-full text-left px-4 py-2 text-sm text-gray-700 dark:text-zinc-200 hover:bg-gray-100 dark:hover:bg-zinc-700 flex items-center gap-1.5"
+                  >
+                    <SlidersHorizontal size={20} /> Proxy Rules
+                  </button>
+                <button
                   
                   className="bg-gray-100 dark:bg-zinc-800 border border-gray-200 dark:border-zinc-700 text-gray-700 dark:text-zinc-200 px-3 py-1 rounded-full text-sm font-medium flex items-center gap-1.5 hover:bg-gray-200 dark:hover:bg-zinc-700"
                 >
@@ -917,6 +1184,22 @@ const App: React.FC = () => {
               )}
             </div>
 
+            <button
+                onClick={() => setIsComposeModalOpen(true)}
+                aria-label="Compose request"
+                className="bg-gray-100 dark:bg-zinc-800 border border-gray-200 dark:border-zinc-700 text-gray-700 dark:text-zinc-200 px-3 py-1 rounded-full text-sm font-medium flex items-center gap-1.5 hover:bg-gray-200 dark:hover:bg-zinc-700"
+              >
+                <Send size={20} />
+              </button>
+
+            <button
+                onClick={() => setIsProxyRulesModalOpen(true)}
+                aria-label="Proxy rules"
+                className="bg-gray-100 dark:bg-zinc-800 border border-gray-200 dark:border-zinc-700 text-gray-700 dark:text-zinc-200 px-3 py-1 rounded-full text-sm font-medium flex items-center gap-1.5 hover:bg-gray-200 dark:hover:bg-zinc-700"
+              >
+                <SlidersHorizontal size={20} />
+              </button>
+
             <button
                 onClick={() => setIsSettingsModalOpen(true)}
                 aria-label="Settings"
@@ -950,6 +1233,55 @@ const App: React.FC = () => {
                   >
                     <Braces size={20} /> Download Flows (JSON)
                   </a>
+                  <a
+                    href="#"
+                    onClick={(e) => { e.preventDefault(); handleDownloadSelectedFlows('proto'); setIsBulkDownloadOpen(false); }}
+                    className="flex items-center gap-3 px-4 py-2.5 text-sm text-gray-700 dark:text-zinc-400 hover:bg-gray-100 dark:hover:bg-zinc-700 hover:text-gray-900 dark:hover:text-zinc-200"
+                  >
+                    <Package size={20} /> Download Bundle
+                  </a>
+                  <a
+                    href="#"
+                    onClick={(e) => { e.preventDefault(); handleDownloadSelectedFlows('saz'); setIsBulkDownloadOpen(false); }}
+                    className="flex items-center gap-3 px-4 py-2.5 text-sm text-gray-700 dark:text-zinc-400 hover:bg-gray-100 dark:hover:bg-zinc-700 hover:text-gray-900 dark:hover:text-zinc-200"
+                  >
+                    <HardDriveDownload size={20} /> Download Fiddler (SAZ)
+                  </a>
+                  <a
+                    href="#"
+                    onClick={(e) => { e.preventDefault(); handleDownloadSelectedFlows('chlsj'); setIsBulkDownloadOpen(false); }}
+                    className="flex items-center gap-3 px-4 py-2.5 text-sm text-gray-700 dark:text-zinc-400 hover:bg-gray-100 dark:hover:bg-zinc-700 hover:text-gray-900 dark:hover:text-zinc-200"
+                  >
+                    <HardDriveDownload size={20} /> Download Charles (CHLSJ)
+                  </a>
+                  <a
+                    href="#"
+                    onClick={(e) => { e.preventDefault(); handleDownloadSelectedFlows('grpc-frames'); setIsBulkDownloadOpen(false); }}
+                    className="flex items-center gap-3 px-4 py-2.5 text-sm text-gray-700 dark:text-zinc-400 hover:bg-gray-100 dark:hover:bg-zinc-700 hover:text-gray-900 dark:hover:text-zinc-200"
+                  >
+                    <Package size={20} /> Download gRPC Frames
+                  </a>
+                  <a
+                    href="#"
+                    onClick={(e) => { e.preventDefault(); handleDownloadSelectedFlows('csv'); setIsBulkDownloadOpen(false); }}
+                    className="flex items-center gap-3 px-4 py-2.5 text-sm text-gray-700 dark:text-zinc-400 hover:bg-gray-100 dark:hover:bg-zinc-700 hover:text-gray-900 dark:hover:text-zinc-200"
+                  >
+                    <Table size={20} /> Download Summary (CSV)
+                  </a>
+                  <a
+                    href="#"
+                    onClick={(e) => { e.preventDefault(); handleDownloadSelectedFlows('md'); setIsBulkDownloadOpen(false); }}
+                    className="flex items-center gap-3 px-4 py-2.5 text-sm text-gray-700 dark:text-zinc-400 hover:bg-gray-100 dark:hover:bg-zinc-700 hover:text-gray-900 dark:hover:text-zinc-200"
+                  >
+                    <FileText size={20} /> Download Summary (Markdown)
+                  </a>
+                  <a
+                    href="#"
+                    onClick={(e) => { e.preventDefault(); handleDownloadSelectedFlows('har', true); setIsBulkDownloadOpen(false); }}
+                    className="flex items-center gap-3 px-4 py-2.5 text-sm text-gray-700 dark:text-zinc-400 hover:bg-gray-100 dark:hover:bg-zinc-700 hover:text-gray-900 dark:hover:text-zinc-200"
+                  >
+                    <EyeOff size={20} /> Download Anonymized HAR
+                  </a>
                 </div>
               )}
             </div>
@@ -1010,6 +1342,26 @@ const App: React.FC = () => {
         onClose={handleCloseSettingsModal}
       />
 
+      <ComposeRequestModal
+        isOpen={isComposeModalOpen}
+        onClose={() => setIsComposeModalOpen(false)}
+        onSend={async (req: ComposedRequest) => {
+            const res = await client.sendRequest(req);
+            const flowId = getFlowId(res.flow);
+            if (flowId) {
+                setSelectedFlowId(flowId);
+            }
+            setIsComposeModalOpen(false);
+        }}
+      />
+
+      <ProxyRulesModal
+        isOpen={isProxyRulesModalOpen}
+        onClose={handleCloseProxyRulesModal}
+        onLoad={loadProxyRules}
+        onSave={saveProxyRules}
+      />
+
       <NoteModal
         isOpen={isNoteModalOpen}
         initialNote={detailsFlow?.note || ''}
@@ -1071,6 +1423,8 @@ const App: React.FC = () => {
         message={toast?.message || ''}
         isVisible={!!toast?.visible}
         onClose={hideToast}
+        action={toast?.action}
+        duration={toast?.action ? 8000 : undefined}
       />
       <DetailsPanel
         flow={detailsFlow}
@@ -1080,6 +1434,8 @@ const App: React.FC = () => {
         panelHeight={detailsPanelHeight}
         setPanelHeight={setDetailsPanelHeight}
         downloadFlowContent={downloadFlowContent}
+        copyRPCCommand={copyRPCCommand}
+        shareFlow={shareFlow}
         onTogglePin={handleTogglePin}
         onDeleteFlow={handleDeleteFlow}
         onEditNote={() => setIsNoteModalOpen(true)}
@@ -1097,6 +1453,11 @@ const App: React.FC = () => {
             onUpdateFlow={handleUpdateFlow}
             selectedTab={lastSelectedTabs[getFlowType(detailsFlow)] || 'summary'}
             onTabChange={(tab) => setLastSelectedTabs(prev => ({ ...prev, [getFlowType(detailsFlow)]: tab }))}
+            getCookieTimeline={getCookieTimeline}
+            getRedirectChain={getRedirectChain}
+            diffWithPrevious={diffWithPrevious}
+            getFlowFrames={getFlowFrames}
+            onSelectFlow={selectFlowById}
           />
         )}
         {detailsFlow?.flow?.case === 'dnsFlow' && (
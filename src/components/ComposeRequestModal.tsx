@@ -0,0 +1,141 @@
+import React, { useState, useEffect } from 'react';
+import { X } from 'lucide-react';
+
+export interface ComposedRequest {
+  method: string;
+  url: string;
+  headers: { [key: string]: string };
+  body: Uint8Array;
+  proxy: string;
+}
+
+interface ComposeRequestModalProps {
+  isOpen: boolean;
+  onClose: () => void;
+  onSend: (req: ComposedRequest) => Promise<void>;
+}
+
+const METHODS = ['GET', 'POST', 'PUT', 'PATCH', 'DELETE', 'HEAD', 'OPTIONS'];
+
+// parseHeaders reads one "Name: value" pair per line, skipping blank lines.
+export const parseHeaders = (text: string): { [key: string]: string } => {
+  const headers: { [key: string]: string } = {};
+  for (const line of text.split('\n')) {
+    const idx = line.indexOf(':');
+    if (idx <= 0) continue;
+    headers[line.slice(0, idx).trim()] = line.slice(idx + 1).trim();
+  }
+  return headers;
+};
+
+const ComposeRequestModal: React.FC<ComposeRequestModalProps> = ({ isOpen, onClose, onSend }) => {
+  const [method, setMethod] = useState('GET');
+  const [url, setUrl] = useState('');
+  const [headers, setHeaders] = useState('');
+  const [body, setBody] = useState('');
+  const [proxy, setProxy] = useState('');
+  const [isSending, setIsSending] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    if (!isOpen) return;
+    setError(null);
+    const handleKeyDown = (e: KeyboardEvent) => {
+      if (e.key === 'Escape') {
+        e.stopImmediatePropagation();
+        onClose();
+      }
+    };
+    window.addEventListener('keydown', handleKeyDown, true);
+    return () => window.removeEventListener('keydown', handleKeyDown, true);
+  }, [isOpen, onClose]);
+
+  if (!isOpen) return null;
+
+  const handleSend = async () => {
+    setIsSending(true);
+    setError(null);
+    try {
+      await onSend({
+        method,
+        url: url.trim(),
+        headers: parseHeaders(headers),
+        body: new TextEncoder().encode(body),
+        proxy: proxy.trim(),
+      });
+    } catch (err) {
+      setError(err instanceof Error ? err.message : String(err));
+    } finally {
+      setIsSending(false);
+    }
+  };
+
+  const inputClass = "w-full text-sm p-2 bg-gray-50 dark:bg-zinc-900 border border-gray-200 dark:border-zinc-700 rounded focus:ring-2 focus:ring-orange-500 focus:outline-none dark:text-zinc-200";
+
+  return (
+    <div className="fixed inset-0 bg-black/50 z-[100] flex items-center justify-center backdrop-blur-sm">
+      <div className="bg-white dark:bg-zinc-800 rounded-lg shadow-xl w-full max-w-2xl text-gray-900 dark:text-white border border-gray-200 dark:border-zinc-700 flex flex-col">
+        <div className="flex justify-between items-center p-4 border-b border-gray-200 dark:border-zinc-700">
+          <h2 className="text-lg font-semibold">Compose Request</h2>
+          <button onClick={onClose} className="text-gray-500 dark:text-zinc-400 hover:text-gray-900 dark:hover:text-white">
+            <X size={20} />
+          </button>
+        </div>
+
+        <div className="p-4 flex flex-col gap-3">
+          <div className="flex gap-2">
+            <select value={method} onChange={(e) => setMethod(e.target.value)} className={`${inputClass} w-32`}>
+              {METHODS.map(m => <option key={m} value={m}>{m}</option>)}
+            </select>
+            <input
+              type="text"
+              value={url}
+              onChange={(e) => setUrl(e.target.value)}
+              placeholder="https://example.com/api"
+              className={inputClass}
+              autoFocus
+            />
+          </div>
+          <textarea
+            value={headers}
+            onChange={(e) => setHeaders(e.target.value)}
+            placeholder={"Content-Type: application/json\nAuthorization: Bearer ..."}
+            className={`${inputClass} font-mono resize-none h-24`}
+          />
+          <textarea
+            value={body}
+            onChange={(e) => setBody(e.target.value)}
+            placeholder="Request body"
+            className={`${inputClass} font-mono resize-none h-40`}
+          />
+          <input
+            type="text"
+            value={proxy}
+            onChange={(e) => setProxy(e.target.value)}
+            placeholder="Proxy (optional), e.g. http://127.0.0.1:8080"
+            className={inputClass}
+          />
+          {error && <p className="text-sm text-red-500">{error}</p>}
+        </div>
+
+        <div className="flex justify-end items-center p-4 border-t border-gray-200 dark:border-zinc-700 bg-gray-50 dark:bg-zinc-900 rounded-b-lg">
+          <button
+            onClick={onClose}
+            className="text-gray-500 dark:text-zinc-400 hover:text-gray-900 dark:hover:text-white px-4 py-2 rounded-md transition-colors text-sm"
+          >
+            Cancel
+          </button>
+          <button
+            onClick={handleSend}
+            disabled={isSending || url.trim() === ''}
+            className="bg-orange-500 hover:bg-orange-600 text-white font-bold py-2 px-4 rounded-md ml-2 transition-colors text-sm disabled:opacity-50 disabled:cursor-not-allowed"
+          >
+            {isSending ? 'Sending...' : 'Send'}
+          </button>
+        </div>
+      </div>
+    </div>
+  );
+};
+
+export default ComposeRequestModal;
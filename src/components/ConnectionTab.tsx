@@ -6,6 +6,7 @@ import {
     TLSVersion,
     TransportProtocol
 } from "../gen/mitmproxygrpc/v1/service_pb";
+import { DeviceType, GeoInfo, HostnameSource, UserAgent } from "../gen/mitmflow/v1/mitmflow_pb";
 import { getTimestamp } from '../utils';
 import { TimingRow } from './TimingRow';
 import { CertificateDetails } from "./CertificateDetails";
@@ -13,9 +14,31 @@ import { CertificateDetails } from "./CertificateDetails";
 interface ConnectionTabProps {
     client?: ClientConn;
     server?: ServerConn;
+    userAgent?: UserAgent;
+    serverGeo?: GeoInfo;
+    serverHostname?: string;
+    serverHostnameSource?: HostnameSource;
 }
 
-export const ConnectionTab: React.FC<ConnectionTabProps> = ({ client, server }) => {
+const HOSTNAME_SOURCE_LABELS: Partial<Record<HostnameSource, string>> = {
+    [HostnameSource.DNS_FLOW]: 'from a captured DNS flow',
+    [HostnameSource.REVERSE_DNS]: 'from reverse DNS',
+};
+
+const formatGeo = (geo: GeoInfo): string => {
+    const country = geo.countryCode ? `${geo.countryName || geo.countryCode} (${geo.countryCode})` : '';
+    const asn = geo.asn ? `AS${geo.asn} ${geo.asOrganization}`.trim() : '';
+    return [country, asn].filter(Boolean).join(' / ');
+};
+
+const formatUserAgent = (ua: UserAgent): string => {
+    const browser = [ua.browser, ua.browserVersion].filter(Boolean).join(' ');
+    const os = [ua.os, ua.osVersion].filter(Boolean).join(' ');
+    const device = ua.deviceType ? DeviceType[ua.deviceType].toLowerCase() : '';
+    return [browser, os, ua.device, device].filter(Boolean).join(' / ') || 'N/A';
+};
+
+export const ConnectionTab: React.FC<ConnectionTabProps> = ({ client, server, userAgent, serverGeo, serverHostname, serverHostnameSource }) => {
     const firstTimestamp = getTimestamp(client?.timestampStart);
 
     return (
@@ -25,6 +48,7 @@ export const ConnectionTab: React.FC<ConnectionTabProps> = ({ client, server })
                 <div className="grid grid-cols-2 gap-x-4 gap-y-2 text-gray-900 dark:text-zinc-300">
                     <div className="text-gray-500 dark:text-zinc-500">ID:</div> <div>{client?.id}</div>
                     <div className="text-gray-500 dark:text-zinc-500">Address:</div> <div>{client?.peernameHost}:{client?.peernamePort}</div>
+                    {userAgent && <><div className="text-gray-500 dark:text-zinc-500">User-Agent:</div> <div>{formatUserAgent(userAgent)}</div></>}
                     <div className="text-gray-500 dark:text-zinc-500">State:</div> <div>{client ? ConnectionState[client.state] : 'N/A'}</div>
                     <div className="text-gray-500 dark:text-zinc-500">Protocol:</div> <div>{client ? TransportProtocol[client.transportProtocol] : 'N/A'}</div>
                     <div className="text-gray-500 dark:text-zinc-500">Error:</div> <div>{client?.error ?? 'N/A'}</div>
@@ -43,6 +67,8 @@ export const ConnectionTab: React.FC<ConnectionTabProps> = ({ client, server })
                 <div className="grid grid-cols-2 gap-x-4 gap-y-2 text-gray-900 dark:text-zinc-300">
                     <div className="text-gray-500 dark:text-zinc-500">ID:</div> <div>{server?.id}</div>
                     <div className="text-gray-500 dark:text-zinc-500">Address:</div> <div>{server?.addressHost}:{server?.addressPort}</div>
+                    {serverGeo && <><div className="text-gray-500 dark:text-zinc-500">Location:</div> <div>{formatGeo(serverGeo)}</div></>}
+                    {serverHostname && <><div className="text-gray-500 dark:text-zinc-500">Hostname:</div> <div title={serverHostnameSource ? HOSTNAME_SOURCE_LABELS[serverHostnameSource] : undefined}>{serverHostname} (best guess)</div></>}
                     <div className="text-gray-500 dark:text-zinc-500">State:</div> <div>{server ? ConnectionState[server.state] : 'N/A'}</div>
                     <div className="text-gray-500 dark:text-zinc-500">Protocol:</div> <div>{server ? TransportProtocol[server.transportProtocol] : 'N/A'}</div>
                     <div className="text-gray-500 dark:text-zinc-500">Error:</div> <div>{server?.error ?? 'N/A'}</div>
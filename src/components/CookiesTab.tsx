@@ -0,0 +1,130 @@
+import React, { useEffect, useMemo, useState } from 'react';
+import { CookieEvent, CookieEventType, Flow } from "../gen/mitmflow/v1/mitmflow_pb";
+import { getFlowId, getTimestamp } from '../utils';
+import { useDebounce } from '../hooks/useDebounce';
+
+const EVENT_LABELS: Record<CookieEventType, { label: string; className: string }> = {
+    [CookieEventType.UNSPECIFIED]: { label: '', className: '' },
+    [CookieEventType.SET]: { label: 'Set', className: 'text-green-500' },
+    [CookieEventType.SENT]: { label: 'Sent', className: 'text-blue-500' },
+    [CookieEventType.DELETED]: { label: 'Deleted', className: 'text-red-500' },
+};
+
+const getHeader = (headers: { [key: string]: string } | undefined, name: string): string => {
+    const entry = Object.entries(headers || {}).find(([key]) => key.toLowerCase() === name);
+    return entry ? entry[1] : '';
+};
+
+// cookieNames lists the cookies a flow sent or set. Several Set-Cookie
+// headers arrive joined by commas or newlines, like the server splits them.
+const cookieNames = (flow: Flow): string[] => {
+    const httpFlow = flow.flow.case === 'httpFlow' ? flow.flow.value : null;
+    const names = new Set<string>();
+    getHeader(httpFlow?.request?.headers, 'cookie').split(';').forEach(pair => {
+        const name = pair.split('=')[0].trim();
+        if (name) names.add(name);
+    });
+    getHeader(httpFlow?.response?.headers, 'set-cookie').split(/\n|,(?=\s*[^=;,\s]+=)/).forEach(line => {
+        const name = line.split('=')[0].trim();
+        if (name) names.add(name);
+    });
+    return [...names].sort();
+};
+
+const formatTime = (ts: number | undefined): string =>
+    ts ? new Date(ts).toLocaleString() : '';
+
+export const CookiesTab: React.FC<{
+    flow: Flow;
+    getCookieTimeline: (name: string, domain: string) => Promise<CookieEvent[]>;
+}> = ({ flow, getCookieTimeline }) => {
+    const names = useMemo(() => cookieNames(flow), [flow]);
+    const host = useMemo(() => {
+        const httpFlow = flow.flow.case === 'httpFlow' ? flow.flow.value : null;
+        try {
+            return new URL(httpFlow?.request?.url || '').hostname;
+        } catch {
+            return '';
+        }
+    }, [flow]);
+    const [selected, setSelected] = useState<string | null>(names[0] ?? null);
+    const [domain, setDomain] = useState(host);
+    const debouncedDomain = useDebounce(domain, 300);
+    const [events, setEvents] = useState<CookieEvent[] | null>(null);
+    const [error, setError] = useState<string | null>(null);
+
+    useEffect(() => {
+        if (!selected) return;
+        let cancelled = false;
+        setEvents(null);
+        setError(null);
+        getCookieTimeline(selected, debouncedDomain)
+            .then(result => { if (!cancelled) setEvents(result); })
+            .catch(err => { if (!cancelled) setError(String(err)); });
+        return () => { cancelled = true; };
+    }, [selected, debouncedDomain, getCookieTimeline]);
+
+    if (names.length === 0) {
+        return <div className="text-sm text-gray-500 dark:text-zinc-500">This flow didn't send or set any cookies.</div>;
+    }
+
+    return (
+        <div className="text-sm space-y-4">
+            <div className="flex flex-wrap items-center gap-2">
+                {names.map(name => (
+                    <button
+                        key={name}
+                        onClick={() => setSelected(name)}
+                        className={`px-2 py-1 rounded font-mono ${selected === name ? 'bg-orange-500 text-white' : 'bg-gray-200 dark:bg-zinc-700 hover:bg-gray-300 dark:hover:bg-zinc-600'}`}
+                    >
+                        {name}
+                    </button>
+                ))}
+                <label className="ml-auto flex items-center gap-2 text-gray-500 dark:text-zinc-500">
+                    Domain:
+                    <input
+                        value={domain}
+                        onChange={(e) => setDomain(e.target.value)}
+                        placeholder="any"
+                        className="px-2 py-1 rounded border border-gray-300 dark:border-zinc-600 bg-white dark:bg-zinc-800 text-gray-900 dark:text-zinc-300"
+                    />
+                </label>
+            </div>
+            {error && <div className="text-red-500">{error}</div>}
+            {!events && !error && <div className="text-gray-500 dark:text-zinc-500">Loading...</div>}
+            {events && events.length === 0 && <div className="text-gray-500 dark:text-zinc-500">No stored flows used this cookie.</div>}
+            {events && events.length > 0 && (
+                <table className="w-full font-mono text-xs">
+                    <thead>
+                        <tr className="text-left text-gray-500 dark:text-zinc-500 border-b border-gray-200 dark:border-zinc-700">
+                            <th className="py-1 pr-4">Time</th>
+                            <th className="py-1 pr-4">Event</th>
+                            <th className="py-1 pr-4">Host</th>
+                            <th className="py-1 pr-4">Value</th>
+                            <th className="py-1 pr-4">Expires</th>
+                            <th className="py-1">Flow</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {events.map((event, i) => (
+                            <tr key={i} className={`border-b border-gray-100 dark:border-zinc-800 ${event.flowId === getFlowId(flow) ? 'bg-orange-50 dark:bg-zinc-800' : ''}`}>
+                                <td className="py-1 pr-4 whitespace-nowrap">{formatTime(getTimestamp(event.timestamp))}</td>
+                                <td className={`py-1 pr-4 ${EVENT_LABELS[event.type].className}`}>{EVENT_LABELS[event.type].label}</td>
+                                <td className="py-1 pr-4">{event.host}</td>
+                                <td className="py-1 pr-4 break-all">
+                                    {event.value}
+                                    {event.valueChanged && <span className="ml-2 text-yellow-500">(changed)</span>}
+                                </td>
+                                <td className="py-1 pr-4 whitespace-nowrap">
+                                    {event.expires ? formatTime(getTimestamp(event.expires)) : (event.type === CookieEventType.SET ? 'session' : '')}
+                                    {event.expiryChanged && <span className="ml-2 text-yellow-500">(changed)</span>}
+                                </td>
+                                <td className="py-1 break-all">{event.flowId}</td>
+                            </tr>
+                        ))}
+                    </tbody>
+                </table>
+            )}
+        </div>
+    );
+};
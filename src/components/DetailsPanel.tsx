@@ -16,6 +16,8 @@ interface DetailsPanelProps {
   setPanelHeight: (height: number) => void;
   children: React.ReactNode;
   downloadFlowContent: (flow: Flow, type: 'har' | 'flow-json' | 'request' | 'response') => void;
+  copyRPCCommand: (flow: Flow, tool: 'grpcurl' | 'buf-curl') => void;
+  shareFlow: (flow: Flow, action: 'copy' | 'download') => void;
   onTogglePin: (flow: Flow | FlowSummary) => void;
   onDeleteFlow: (flow: Flow | FlowSummary) => void;
   onEditNote: () => void;
@@ -31,6 +33,8 @@ export const DetailsPanel = forwardRef<HTMLDivElement, DetailsPanelProps>(({
   setPanelHeight,
   children,
   downloadFlowContent,
+  copyRPCCommand,
+  shareFlow,
   onTogglePin,
   onDeleteFlow,
   onEditNote,
@@ -271,6 +275,52 @@ export const DetailsPanel = forwardRef<HTMLDivElement, DetailsPanelProps>(({
                 >
                   JSON
                 </a>
+                <a
+                  href="#"
+                  onClick={(e) => {
+                    e.preventDefault();
+                    if (flow) copyRPCCommand(flow, 'grpcurl');
+                    setDownloadOpen(false);
+                  }}
+                  className={`block px-4 py-2 text-sm ${isHttp ? 'text-gray-700 dark:text-zinc-200 hover:bg-gray-100 dark:hover:bg-zinc-700' : 'text-gray-400 dark:text-zinc-500 cursor-not-allowed'}`}
+                >
+                  Copy as grpcurl
+                </a>
+                <a
+                  href="#"
+                  onClick={(e) => {
+                    e.preventDefault();
+                    if (flow) copyRPCCommand(flow, 'buf-curl');
+                    setDownloadOpen(false);
+                  }}
+                  className={`block px-4 py-2 text-sm ${isHttp ? 'text-gray-700 dark:text-zinc-200 hover:bg-gray-100 dark:hover:bg-zinc-700' : 'text-gray-400 dark:text-zinc-500 cursor-not-allowed'}`}
+                >
+                  Copy as buf curl
+                </a>
+                <a
+                  href="#"
+                  onClick={(e) => {
+                    e.preventDefault();
+                    if (flow) shareFlow(flow, 'download');
+                    setDownloadOpen(false);
+                  }}
+                  className="block px-4 py-2 text-sm text-gray-700 dark:text-zinc-200 hover:bg-gray-100 dark:hover:bg-zinc-700"
+                  title={isHttp ? "Credentials are redacted" : undefined}
+                >
+                  Share bundle
+                </a>
+                <a
+                  href="#"
+                  onClick={(e) => {
+                    e.preventDefault();
+                    if (flow) shareFlow(flow, 'copy');
+                    setDownloadOpen(false);
+                  }}
+                  className="block px-4 py-2 text-sm text-gray-700 dark:text-zinc-200 hover:bg-gray-100 dark:hover:bg-zinc-700"
+                  title={isHttp ? "Credentials are redacted" : undefined}
+                >
+                  Copy share bundle
+                </a>
               </div>
             )}
           </div>
@@ -0,0 +1,92 @@
+import React, { useEffect, useState } from 'react';
+import { DiffWithPreviousResponse, FlowDifference, FlowDifferenceKind } from "../gen/mitmflow/v1/mitmflow_pb";
+import { getTimestamp } from '../utils';
+
+const KIND_LABELS: Record<FlowDifferenceKind, string> = {
+    [FlowDifferenceKind.UNSPECIFIED]: '',
+    [FlowDifferenceKind.STATUS]: 'Status',
+    [FlowDifferenceKind.QUERY]: 'Query',
+    [FlowDifferenceKind.HEADER]: 'Header',
+    [FlowDifferenceKind.BODY]: 'Body',
+};
+
+const DifferenceTable: React.FC<{ title: string; differences: FlowDifference[] }> = ({ title, differences }) => (
+    <div className="break-inside-avoid bg-gray-50 dark:bg-zinc-800 p-4 rounded border border-gray-200 dark:border-zinc-700">
+        <h5 className="font-semibold text-gray-700 dark:text-zinc-400 mb-3 border-b border-gray-200 dark:border-zinc-700 pb-2">{title}</h5>
+        {differences.length === 0 ? (
+            <p className="text-gray-500 dark:text-zinc-500">No differences.</p>
+        ) : (
+            <table className="w-full text-left">
+                <thead>
+                    <tr className="text-gray-500 dark:text-zinc-500">
+                        <th className="pr-4 font-normal">Kind</th>
+                        <th className="pr-4 font-normal">Field</th>
+                        <th className="pr-4 font-normal">Previous</th>
+                        <th className="font-normal">This flow</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {differences.map((d, i) => (
+                        <tr key={i} className="align-top">
+                            <td className="pr-4 whitespace-nowrap">{KIND_LABELS[d.kind]}</td>
+                            <td className="pr-4 break-all">{d.field}</td>
+                            <td className="pr-4 break-all text-red-500">{d.previous || <span className="text-gray-500 dark:text-zinc-500">(missing)</span>}</td>
+                            <td className="break-all text-green-500">{d.current || <span className="text-gray-500 dark:text-zinc-500">(missing)</span>}</td>
+                        </tr>
+                    ))}
+                </tbody>
+            </table>
+        )}
+    </div>
+);
+
+// DiffTab compares a flow with the latest one before it with the same method
+// and path.
+export const DiffTab: React.FC<{
+    flowId: string;
+    diffWithPrevious: (flowId: string) => Promise<DiffWithPreviousResponse>;
+    onSelectFlow?: (flowId: string) => void;
+}> = ({ flowId, diffWithPrevious, onSelectFlow }) => {
+    const [diff, setDiff] = useState<DiffWithPreviousResponse | null>(null);
+    const [error, setError] = useState<string | null>(null);
+
+    useEffect(() => {
+        let cancelled = false;
+        setDiff(null);
+        setError(null);
+        diffWithPrevious(flowId)
+            .then(result => { if (!cancelled) setDiff(result); })
+            .catch(err => { if (!cancelled) setError(err instanceof Error ? err.message : String(err)); });
+        return () => { cancelled = true; };
+    }, [flowId, diffWithPrevious]);
+
+    if (error) {
+        return <p className="text-red-500">Failed to compare flows: {error}</p>;
+    }
+    if (!diff) {
+        return <p className="text-gray-500 dark:text-zinc-500">Comparing...</p>;
+    }
+    const previous = diff.previous;
+    if (!previous) {
+        return <p className="text-gray-500 dark:text-zinc-500">No earlier flow has the same method and path.</p>;
+    }
+    const url = previous.summary.case === 'http' ? previous.summary.value.url : previous.id;
+
+    return (
+        <div className="space-y-4">
+            <p>
+                Compared with{' '}
+                {onSelectFlow ? (
+                    <button className="break-all text-left text-orange-500 hover:underline" onClick={() => onSelectFlow(previous.id)}>{url}</button>
+                ) : (
+                    <span className="break-all">{url}</span>
+                )}
+                {previous.timestampStart && (
+                    <span className="text-gray-500 dark:text-zinc-500"> at {new Date(getTimestamp(previous.timestampStart)).toLocaleString()}</span>
+                )}
+            </p>
+            <DifferenceTable title="Request" differences={diff.request} />
+            <DifferenceTable title="Response" differences={diff.response} />
+        </div>
+    );
+};
@@ -2,6 +2,7 @@ import React from 'react';
 import { Flow } from "../gen/mitmflow/v1/mitmflow_pb";
 import { ConnectionTab } from './ConnectionTab';
 import { NoteDisplay } from './NoteDisplay';
+import { MetadataDisplay } from './MetadataDisplay';
 import { getFlowId } from '../utils';
 
 export const DnsFlowDetails: React.FC<{
@@ -50,6 +51,17 @@ export const DnsFlowDetails: React.FC<{
                                 }
                             }}
                         />
+                        <MetadataDisplay metadata={flow.metadata} />
+                        {flow.dnsFlowExtra && flow.dnsFlowExtra.anomalies.length > 0 && (
+                            <div className="break-inside-avoid bg-yellow-50 dark:bg-zinc-800 p-4 rounded border border-yellow-200 dark:border-zinc-700">
+                                <h5 className="font-semibold text-yellow-700 dark:text-yellow-400 mb-3 border-b border-yellow-200 dark:border-zinc-700 pb-2">Anomalies</h5>
+                                <ul className="space-y-1">
+                                    {flow.dnsFlowExtra.anomalies.map((a, i) => (
+                                        <li key={i} className="break-all">{a.detail}</li>
+                                    ))}
+                                </ul>
+                            </div>
+                        )}
                         <div className="break-inside-avoid bg-gray-50 dark:bg-zinc-800 p-4 rounded border border-gray-200 dark:border-zinc-700">
                             <h5 className="font-semibold text-gray-700 dark:text-zinc-400 mb-3 border-b border-gray-200 dark:border-zinc-700 pb-2">DNS Query</h5>
                             <pre className="whitespace-pre-wrap break-all text-gray-800 dark:text-zinc-300">{dnsFlow.request?.questions.map(q => `${q.name} ${q.type} ${q.class}`).join('\n')}</pre>
@@ -7,9 +7,12 @@ const mockSetPinned = vi.fn();
 const mockSetHasNote = vi.fn();
 const mockSetFlowTypes = vi.fn();
 const mockSetClientIps = vi.fn();
+const mockSetServerCountries = vi.fn();
 const mockSetHttpMethods = vi.fn();
 const mockSetHttpStatusCodes = vi.fn();
 const mockSetHttpContentTypes = vi.fn();
+const mockSetHttpClientFamilies = vi.fn();
+const mockSetHttpMinSecuritySeverity = vi.fn();
 
 // Mock the store hook
 vi.mock('../store', () => ({
@@ -22,14 +25,20 @@ vi.mock('../store', () => ({
     setFlowTypes: mockSetFlowTypes,
     clientIps: [],
     setClientIps: mockSetClientIps,
+    serverCountries: [],
+    setServerCountries: mockSetServerCountries,
     http: {
         methods: [],
         statusCodes: [],
-        contentTypes: []
+        contentTypes: [],
+        clientFamilies: [],
+        minSecuritySeverity: undefined
     },
     setHttpMethods: mockSetHttpMethods,
     setHttpStatusCodes: mockSetHttpStatusCodes,
     setHttpContentTypes: mockSetHttpContentTypes,
+    setHttpClientFamilies: mockSetHttpClientFamilies,
+    setHttpMinSecuritySeverity: mockSetHttpMinSecuritySeverity,
   }),
   FLOW_TYPES: [
     { value: 'http', label: 'HTTP' },
@@ -37,6 +46,11 @@ vi.mock('../store', () => ({
     { value: 'tcp', label: 'TCP' },
     { value: 'udp', label: 'UDP' },
   ],
+  SECURITY_SEVERITIES: [
+    { value: 'info', label: 'Info' },
+    { value: 'low', label: 'Low' },
+    { value: 'medium', label: 'Medium' },
+  ],
 }));
 
 describe('FilterModal', () => {
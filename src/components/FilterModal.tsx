@@ -1,5 +1,5 @@
 import React, { useState, useEffect, useRef } from 'react';
-import useFilterStore, { FlowType, FLOW_TYPES } from '../store';
+import useFilterStore, { FlowType, FLOW_TYPES, SecuritySeverity, SECURITY_SEVERITIES } from '../store';
 import { X } from 'lucide-react';
 import CreatableSelect from 'react-select/creatable';
 import Select, { CSSObjectWithLabel } from 'react-select';
@@ -38,6 +38,10 @@ const CONTENT_TYPE_OPTIONS = [
     { value: 'application/octet-stream', label: 'application/octet-stream' },
 ];
 
+const CLIENT_FAMILY_OPTIONS = [
+    'Chrome', 'Firefox', 'Safari', 'Edge', 'Windows', 'macOS', 'Linux', 'Android', 'iOS', 'desktop', 'mobile', 'tablet', 'bot',
+].map(f => ({ value: f, label: f }));
+
 const YES_NO_OPTIONS: { value: 'true' | 'false'; label: string }[] = [
   { value: 'true', label: 'Yes' },
   { value: 'false', label: 'No' },
@@ -79,9 +83,12 @@ const FilterModal: React.FC<FilterModalProps> = ({ isOpen, onClose, uniqueClient
   const [hasNote, setHasNote] = useState(store.hasNote);
   const [flowTypes, setFlowTypes] = useState<FlowType[]>(store.flowTypes);
   const [clientIps, setClientIps] = useState<string[]>(store.clientIps);
+  const [serverCountries, setServerCountries] = useState<string[]>(store.serverCountries);
   const [methods, setMethods] = useState<string[]>(store.http.methods);
   const [statusCodes, setStatusCodes] = useState<string[]>(store.http.statusCodes);
   const [contentTypes, setContentTypes] = useState<string[]>(store.http.contentTypes);
+  const [clientFamilies, setClientFamilies] = useState<string[]>(store.http.clientFamilies);
+  const [minSecuritySeverity, setMinSecuritySeverity] = useState<SecuritySeverity | undefined>(store.http.minSecuritySeverity);
 
   const modalRef = useRef<HTMLDivElement>(null);
 
@@ -92,9 +99,12 @@ const FilterModal: React.FC<FilterModalProps> = ({ isOpen, onClose, uniqueClient
       setHasNote(store.hasNote);
       setFlowTypes(store.flowTypes);
       setClientIps(store.clientIps);
+      setServerCountries(store.serverCountries);
       setMethods(store.http.methods);
       setStatusCodes(store.http.statusCodes);
       setContentTypes(store.http.contentTypes);
+      setClientFamilies(store.http.clientFamilies);
+      setMinSecuritySeverity(store.http.minSecuritySeverity);
     }
   }, [isOpen]);
 
@@ -121,9 +131,12 @@ const FilterModal: React.FC<FilterModalProps> = ({ isOpen, onClose, uniqueClient
     store.setHasNote(hasNote);
     store.setFlowTypes(flowTypes);
     store.setClientIps(clientIps);
+    store.setServerCountries(serverCountries);
     store.setHttpMethods(methods);
     store.setHttpStatusCodes(statusCodes);
     store.setHttpContentTypes(contentTypes);
+    store.setHttpClientFamilies(clientFamilies);
+    store.setHttpMinSecuritySeverity(minSecuritySeverity);
     onClose();
   };
 
@@ -132,9 +145,12 @@ const FilterModal: React.FC<FilterModalProps> = ({ isOpen, onClose, uniqueClient
     setHasNote(undefined);
     setFlowTypes([]);
     setClientIps([]);
+    setServerCountries([]);
     setMethods([]);
     setStatusCodes([]);
     setContentTypes([]);
+    setClientFamilies([]);
+    setMinSecuritySeverity(undefined);
   };
 
   // Filter out HTTP-specific options if HTTP is not selected (if flowTypes.length > 0)
@@ -202,6 +218,20 @@ const FilterModal: React.FC<FilterModalProps> = ({ isOpen, onClose, uniqueClient
                 />
             </FilterRow>
 
+            {/* Server Country */}
+            <FilterRow label="Server Country" isEven={rowIndex++ % 2 !== 0}>
+                <CreatableSelect
+                    isMulti
+                    value={serverCountries.map(cc => ({ value: cc, label: cc }))}
+                    onChange={(selected) => setServerCountries(selected.map(s => s.value.toUpperCase()))}
+                    isValidNewOption={(input) => /^[A-Za-z]{2}$/.test(input)}
+                    className="text-black text-sm"
+                    placeholder="e.g., US, DE (needs a GeoIP database)"
+                    menuPortalTarget={document.body}
+                    styles={selectStyles}
+                />
+            </FilterRow>
+
             {showHttpFilters && (
                 <>
                     {/* HTTP Method */}
@@ -245,6 +275,29 @@ const FilterModal: React.FC<FilterModalProps> = ({ isOpen, onClose, uniqueClient
                             styles={selectStyles}
                         />
                     </FilterRow>
+
+                    {/* HTTP Client Family */}
+                    <FilterRow label="Client (User-Agent)" isEven={rowIndex++ % 2 !== 0}>
+                        <CreatableSelect
+                            isMulti
+                            options={CLIENT_FAMILY_OPTIONS}
+                            value={clientFamilies.map(f => ({ value: f, label: f }))}
+                            onChange={(selected) => setClientFamilies(selected.map(s => s.value))}
+                            className="text-black text-sm"
+                            placeholder="e.g., Chrome, iOS, mobile"
+                            menuPortalTarget={document.body}
+                            styles={selectStyles}
+                        />
+                    </FilterRow>
+
+                    {/* Security Header Findings */}
+                    <FilterRow label="Security Findings (at least)" isEven={rowIndex++ % 2 !== 0}>
+                        <SegmentedControl
+                            options={SECURITY_SEVERITIES}
+                            value={minSecuritySeverity}
+                            onChange={setMinSecuritySeverity}
+                        />
+                    </FilterRow>
                 </>
             )}
         </div>
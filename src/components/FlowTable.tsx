@@ -282,6 +282,23 @@ const FlowTable = forwardRef<HTMLDivElement, FlowTableProps>(
                 },
                 sortable: true,
             },
+            {
+                headerName: "SHA-256",
+                width: 110,
+                cellClass: 'nowrap-cell font-mono',
+                cellRenderer: (params: { data: FlowSummary }) => {
+                    const summary = getSummary(params.data);
+                    const hash = summary.case === 'http' ? summary.value.responseSha256 : '';
+                    return hash ? <span title={`Response body SHA-256: ${hash}`}>{hash.slice(0, 10)}</span> : null;
+                },
+                valueGetter: (params: ValueGetterParams<FlowSummary>) => {
+                    const flow = params.data;
+                    if (!flow) return null;
+                    const summary = getSummary(flow);
+                    return summary.case === 'http' ? summary.value.responseSha256 : null;
+                },
+                sortable: true,
+            },
         ];
 
         // Compute sorted flows based on current sort configuration
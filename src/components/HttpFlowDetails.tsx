@@ -1,13 +1,32 @@
-import React, { useState, useMemo } from 'react';
+import React, { useState, useMemo, useEffect } from 'react';
 import { Request, Response } from "../gen/mitmproxygrpc/v1/service_pb";
-import { Flow, MessageDetails } from "../gen/mitmflow/v1/mitmflow_pb";
+import { CookieEvent, DiffWithPreviousResponse, Flow, FindingSeverity, GetFlowFramesResponse, MessageDetails, RedirectHop } from "../gen/mitmflow/v1/mitmflow_pb";
 import { Light as SyntaxHighlighter } from 'react-syntax-highlighter';
 import { atomOneDark } from 'react-syntax-highlighter/dist/esm/styles/hljs'; // A simple, light theme
 import HexViewer from '../HexViewer';
 import { ContentFormat, FormattedContent, formatContent, getContentType, getTimestamp, formatSize, formatBytes, getFlowId } from '../utils';
 import { ConnectionTab } from './ConnectionTab';
+import { CookiesTab } from './CookiesTab';
+import { DiffTab } from './DiffTab';
+import { RedirectChain } from './RedirectChain';
 import { TimingRow } from './TimingRow';
 import { NoteDisplay } from './NoteDisplay';
+import { MetadataDisplay } from './MetadataDisplay';
+
+const SEVERITY_STYLES: Record<FindingSeverity, { label: string; className: string }> = {
+    [FindingSeverity.UNSPECIFIED]: { label: '', className: '' },
+    [FindingSeverity.INFO]: { label: 'Info', className: 'text-blue-500' },
+    [FindingSeverity.LOW]: { label: 'Low', className: 'text-yellow-500' },
+    [FindingSeverity.MEDIUM]: { label: 'Medium', className: 'text-orange-500' },
+};
+
+// bytesToBase64 encodes small binary data, like thumbnails, for data URLs.
+const bytesToBase64 = (bytes: Uint8Array): string => btoa(Array.from(bytes, b => String.fromCharCode(b)).join(''));
+
+const formatDuration = (ms: bigint): string => {
+    const seconds = Number(ms) / 1000;
+    return seconds < 60 ? `${seconds.toFixed(1)}s` : `${Math.floor(seconds / 60)}m ${Math.round(seconds % 60)}s`;
+};
 
 const formatHeaders = (headers: { [key: string]: string }): string => {
     return Object.entries(headers)
@@ -15,6 +34,16 @@ const formatHeaders = (headers: { [key: string]: string }): string => {
         .join('\n');
 };
 
+// frameOffsetMs returns when a frame arrived relative to the start of the
+// request or response, when the server recorded it.
+const frameOffsetMs = (timestamps: bigint[], index: number, flowPart?: Request | Response): number | undefined => {
+    const ts = timestamps[index];
+    const start = flowPart?.timestampStart;
+    if (!ts || !start) return undefined;
+    const startNs = start.seconds * 1_000_000_000n + BigInt(start.nanos);
+    return Number((ts - startNs) / 1_000_000n);
+};
+
 type RequestResponseViewProps = {
     title: string;
     fullContent?: string;
@@ -24,10 +53,30 @@ type RequestResponseViewProps = {
     headers?: { [key: string]: string }; // To help with auto-selection
     flowPart?: Request | Response;
     details?: MessageDetails;
+    // Fetches the frames GetFlow left out of details, from offset on.
+    getMoreFrames?: (offset: number) => Promise<GetFlowFramesResponse>;
 };
 
-export const RequestResponseView: React.FC<RequestResponseViewProps> = ({ fullContent, bodyContent, format, setFormat, headers, flowPart, details }) => {
+export const RequestResponseView: React.FC<RequestResponseViewProps> = ({ fullContent, bodyContent, format, setFormat, headers, flowPart, details, getMoreFrames }) => {
     const [isBodyExpanded, setIsBodyExpanded] = useState(false);
+    const [moreFrames, setMoreFrames] = useState<{ frames: string[]; timestamps: bigint[] }>({ frames: [], timestamps: [] });
+    const [isLoadingFrames, setIsLoadingFrames] = useState(false);
+    useEffect(() => {
+        setMoreFrames({ frames: [], timestamps: [] });
+    }, [details]);
+    const frames = useMemo(() => [...(details?.textualFrames ?? []), ...moreFrames.frames], [details, moreFrames]);
+    const frameTimestamps = useMemo(() => [...(details?.frameTimestampsNs ?? []), ...moreFrames.timestamps], [details, moreFrames]);
+    const frameCount = Math.max(details?.frameCount ?? 0, frames.length);
+    const loadMoreFrames = async () => {
+        if (!getMoreFrames) return;
+        setIsLoadingFrames(true);
+        try {
+            const page = await getMoreFrames(frames.length);
+            setMoreFrames(prev => ({ frames: [...prev.frames, ...page.frames], timestamps: [...prev.timestamps, ...page.frameTimestampsNs] }));
+        } finally {
+            setIsLoadingFrames(false);
+        }
+    };
     const headerText = useMemo(() => {
         if (!fullContent) return 'No content captured.';
         const parts = fullContent.split('\n\n');
@@ -71,18 +120,98 @@ export const RequestResponseView: React.FC<RequestResponseViewProps> = ({ fullCo
                     </pre>
                 </>
             )}
-            {details?.textualFrames && details.textualFrames.length > 0 && ['protobuf', 'grpc', 'grpc-web', 'dns', 'text'].includes(effectiveFormat) ? (
-                // Render protoscope frames if they exist
+            {details?.grpcStatus && (
+                <div className={`mt-2 text-xs ${details.grpcStatus.code === 0 ? 'text-green-600 dark:text-green-400' : 'text-red-500'}`}>
+                    <span className="font-semibold">gRPC {details.grpcStatus.name} ({details.grpcStatus.code})</span>
+                    {details.grpcStatus.message && <span className="ml-2 break-all">{details.grpcStatus.message}</span>}
+                </div>
+            )}
+            {details?.soap && (
+                <div className="mt-2 text-xs">
+                    <span className="font-semibold">SOAP {details.soap.version}</span>
+                    {details.soap.operation && <span className="ml-2">{details.soap.operation}</span>}
+                    {details.soap.action && <span className="ml-2 text-gray-500 dark:text-zinc-400 break-all">{details.soap.action}</span>}
+                    {details.soap.fault && (
+                        <div className="mt-1 text-red-500">Fault {details.soap.fault.code}: {details.soap.fault.reason}</div>
+                    )}
+                </div>
+            )}
+            {details?.media && (
+                <div className="mt-2 flex items-start gap-3 text-xs">
+                    {details.media.thumbnail.length > 0 && (
+                        <img src={`data:image/jpeg;base64,${bytesToBase64(details.media.thumbnail)}`} alt="Thumbnail" className="rounded border border-gray-200 dark:border-zinc-700" />
+                    )}
+                    <div>
+                        <div>
+                            <span className="font-semibold">{details.media.format}</span>
+                            {details.media.width > 0 && <span className="ml-2">{details.media.width}×{details.media.height}</span>}
+                            {details.media.durationMs > 0n && <span className="ml-2">{formatDuration(details.media.durationMs)}</span>}
+                        </div>
+                        {Object.keys(details.media.exif).length > 0 && (
+                            <table className="mt-1 text-left">
+                                <tbody>
+                                    {Object.entries(details.media.exif).sort(([a], [b]) => a.localeCompare(b)).map(([name, value]) => (
+                                        <tr key={name}>
+                                            <td className="pr-4 text-gray-500 dark:text-zinc-400">{name}</td>
+                                            <td className="break-all">{value}</td>
+                                        </tr>
+                                    ))}
+                                </tbody>
+                            </table>
+                        )}
+                    </div>
+                </div>
+            )}
+            {details?.binaryMetadata && details.binaryMetadata.length > 0 && (
+                <table className="mt-2 w-full text-left text-xs font-mono">
+                    <tbody>
+                        {details.binaryMetadata.map((entry, index) => (
+                            <tr key={index} className="align-top border-b border-gray-200 dark:border-zinc-700">
+                                <td className="pr-4 py-1 font-semibold whitespace-nowrap">
+                                    {entry.key}
+                                    {entry.trailer && <span className="ml-1 font-normal text-gray-500 dark:text-zinc-400">(trailer)</span>}
+                                </td>
+                                <td className="py-1"><pre className="whitespace-pre-wrap break-all">{entry.text}</pre></td>
+                            </tr>
+                        ))}
+                    </tbody>
+                </table>
+            )}
+            {details?.formFields && details.formFields.length > 0 && (
+                <table className="mt-2 w-full text-left text-xs font-mono">
+                    <tbody>
+                        {details.formFields.map((field, index) => (
+                            <tr key={index} className="align-top border-b border-gray-200 dark:border-zinc-700">
+                                <td className="pr-4 py-1 font-semibold whitespace-nowrap">{field.name}</td>
+                                <td className="py-1 break-all whitespace-pre-wrap">{field.value}</td>
+                            </tr>
+                        ))}
+                    </tbody>
+                </table>
+            )}
+            {details && frames.length > 0 && (details.recordCount > 0 || ['protobuf', 'grpc', 'grpc-web', 'dns', 'text', 'binary'].includes(effectiveFormat)) ? (
+                // Render protoscope frames, or the records of a JSON stream, if they exist
                 <div>
-                    {details.textualFrames.map((frame, index) => (
+                    {details.recordCount > 0 && (
+                        <p className="mt-2 text-xs text-gray-500 dark:text-zinc-400">
+                            {details.recordCount} {details.recordCount === 1 ? 'record' : 'records'}
+                        </p>
+                    )}
+                    {frames.map((frame, index) => (
                         <div key={index} className="border-b border-gray-200 dark:border-zinc-700 py-2">
-                            {details.textualFrames.length > 1 && (
-                                <h4 className="text-sm font-semibold mb-1">Frame {index + 1}</h4>
+                            {frameCount > 1 && (
+                                <h4 className="text-sm font-semibold mb-1">
+                                    {details.recordCount > 0 ? 'Record' : 'Frame'} {index + 1}
+                                    {frameOffsetMs(frameTimestamps, index, flowPart) !== undefined && (
+                                        <span className="ml-2 font-normal text-gray-500 dark:text-zinc-400">+{frameOffsetMs(frameTimestamps, index, flowPart)}ms</span>
+                                    )}
+                                </h4>
                             )}
                             <SyntaxHighlighter
                                 language={(() => {
                                     if (effectiveFormat === 'dns') return 'json';
-                                    if (effectiveFormat === 'text') return 'text';
+                                    if (details.recordCount > 0) return 'json';
+                                    if (effectiveFormat === 'text' || effectiveFormat === 'binary') return 'text';
                                     const trimmed = frame.trim();
                                     if (trimmed.startsWith('{') || trimmed.startsWith('[')) return 'json';
                                     return 'protobuf';
@@ -104,6 +233,13 @@ export const RequestResponseView: React.FC<RequestResponseViewProps> = ({ fullCo
                             </SyntaxHighlighter>
                         </div>
                     ))}
+                    {frameCount > frames.length && getMoreFrames && (
+                        <div className="mt-2 text-sm">
+                            <a href="#" onClick={(e) => { e.preventDefault(); if (!isLoadingFrames) loadMoreFrames(); }} className="text-orange-500 hover:underline">
+                                {isLoadingFrames ? 'Loading…' : `Load more frames (${frames.length} of ${frameCount} shown)`}
+                            </a>
+                        </div>
+                    )}
                 </div>
             ) : (
                 // Otherwise, render the regular body content (expandable)
@@ -157,7 +293,12 @@ export const HttpFlowDetails: React.FC<{
     onUpdateFlow: (flowId: string, updates: { pinned?: boolean; note?: string }) => void;
     selectedTab: string;
     onTabChange: (tab: string) => void;
-}> = ({ flow, requestFormat, setRequestFormat, responseFormat, setResponseFormat, contentRef, onEditNote, onUpdateFlow, selectedTab, onTabChange }) => {
+    getCookieTimeline?: (name: string, domain: string) => Promise<CookieEvent[]>;
+    getRedirectChain?: (flowId: string) => Promise<RedirectHop[]>;
+    diffWithPrevious?: (flowId: string) => Promise<DiffWithPreviousResponse>;
+    getFlowFrames?: (flowId: string, response: boolean, offset: number) => Promise<GetFlowFramesResponse>;
+    onSelectFlow?: (flowId: string) => void;
+}> = ({ flow, requestFormat, setRequestFormat, responseFormat, setResponseFormat, contentRef, onEditNote, onUpdateFlow, selectedTab, onTabChange, getCookieTimeline, getRedirectChain, diffWithPrevious, getFlowFrames, onSelectFlow }) => {
     const httpFlow = flow.flow.case === 'httpFlow' ? flow.flow.value : null;
 
     const queryParams = useMemo(() => {
@@ -254,6 +395,22 @@ export const HttpFlowDetails: React.FC<{
                             WebSocket
                         </button>
                     )}
+                    {getCookieTimeline && (
+                        <button
+                            className={`px-3 py-2 text-sm font-medium border-b-2 ${selectedTab === 'cookies' ? 'border-orange-500 text-orange-500' : 'border-transparent text-gray-500 dark:text-zinc-400 hover:text-gray-900 dark:hover:text-white'}`}
+                            onClick={() => onTabChange('cookies')}
+                        >
+                            Cookies
+                        </button>
+                    )}
+                    {diffWithPrevious && (
+                        <button
+                            className={`px-3 py-2 text-sm font-medium border-b-2 ${selectedTab === 'changes' ? 'border-orange-500 text-orange-500' : 'border-transparent text-gray-500 dark:text-zinc-400 hover:text-gray-900 dark:hover:text-white'}`}
+                            onClick={() => onTabChange('changes')}
+                        >
+                            Changes
+                        </button>
+                    )}
                     <button
                         className={`px-3 py-2 text-sm font-medium border-b-2 ${selectedTab === 'connection' ? 'border-orange-500 text-orange-500' : 'border-transparent text-gray-500 dark:text-zinc-400 hover:text-gray-900 dark:hover:text-white'}`}
                         onClick={() => onTabChange('connection')}
@@ -277,6 +434,7 @@ export const HttpFlowDetails: React.FC<{
                                 }
                             }}
                         />
+                        <MetadataDisplay metadata={flow.metadata} />
                         <div className="break-inside-avoid bg-gray-50 dark:bg-zinc-800 p-4 rounded border border-gray-200 dark:border-zinc-700">
                             <h5 className="font-semibold text-gray-700 dark:text-zinc-400 mb-3 border-b border-gray-200 dark:border-zinc-700 pb-2">Flow Details</h5>
                             <div className="grid grid-cols-2 gap-x-4 gap-y-2">
@@ -284,27 +442,42 @@ export const HttpFlowDetails: React.FC<{
                                 <div className="text-gray-500 dark:text-zinc-500">Method:</div> <div>{httpFlow.request?.method}</div>
                                 <div className="text-gray-500 dark:text-zinc-500">Status:</div> <div className={statusClass}>{httpFlow.response?.statusCode}</div>
                                 {httpFlow.isWebsocket && <><div className="text-gray-500 dark:text-zinc-500">WebSocket:</div> <div>Yes</div></>}
+                                {flow.httpFlowExtra?.protocol && <><div className="text-gray-500 dark:text-zinc-500">Protocol:</div> <div>{flow.httpFlowExtra.protocol}</div></>}
                                 <div className="text-gray-500 dark:text-zinc-500">URL:</div> <div className="col-span-2 break-all">{httpFlow.request?.prettyUrl || httpFlow.request?.url}</div>
                                 <div className="text-gray-500 dark:text-zinc-500">Transfer:</div>
                                 <div>
                                     <div>Out: {formatBytes(httpFlow.request?.content?.length)} {httpFlow.request?.contentTruncated && <span className="text-yellow-500">(truncated)</span>}</div>
                                     <div>In: {formatBytes(httpFlow.response?.content?.length)} {httpFlow.response?.contentTruncated && <span className="text-yellow-500">(truncated)</span>}</div>
                                 </div>
+                                {flow.httpFlowExtra?.request?.sha256 && <><div className="text-gray-500 dark:text-zinc-500">Request SHA-256:</div> <div className="break-all">{flow.httpFlowExtra.request.sha256}</div></>}
+                                {flow.httpFlowExtra?.response?.sha256 && <><div className="text-gray-500 dark:text-zinc-500">Response SHA-256:</div> <div className="break-all">{flow.httpFlowExtra.response.sha256}</div></>}
 
                                 <div className="text-gray-500 dark:text-zinc-500">Request Content-Type:</div> <div className="break-all">{getContentType(httpFlow.request?.headers) || 'N/A'}</div>
                                 {flow.httpFlowExtra?.request?.effectiveContentType && getContentType(httpFlow.request?.headers) !== flow.httpFlowExtra?.request?.effectiveContentType && (
                                     <>
-                                        <div className="text-gray-500 dark:text-zinc-500">Detected Request Content-Type:</div>
+                                        <div className="text-gray-500 dark:text-zinc-500">Effective Request Content-Type:</div>
                                         <div className="break-all">{flow.httpFlowExtra?.request?.effectiveContentType}</div>
                                     </>
                                 )}
+                                {flow.httpFlowExtra?.request?.detectedContentType && flow.httpFlowExtra.request.detectedContentType !== flow.httpFlowExtra.request.effectiveContentType && (
+                                    <>
+                                        <div className="text-gray-500 dark:text-zinc-500">Sniffed Request Content-Type:</div>
+                                        <div className="break-all">{flow.httpFlowExtra.request.detectedContentType}</div>
+                                    </>
+                                )}
                                 <div className="text-gray-500 dark:text-zinc-500">Response Content-Type:</div> <div className="break-all">{getContentType(httpFlow.response?.headers) || 'N/A'}</div>
                                 {flow.httpFlowExtra?.response?.effectiveContentType && getContentType(httpFlow.response?.headers) !== flow.httpFlowExtra?.response?.effectiveContentType && (
                                     <>
-                                        <div className="text-gray-500 dark:text-zinc-500">Detected Response Content-Type:</div>
+                                        <div className="text-gray-500 dark:text-zinc-500">Effective Response Content-Type:</div>
                                         <div className="break-all">{flow.httpFlowExtra?.response?.effectiveContentType}</div>
                                     </>
                                 )}
+                                {flow.httpFlowExtra?.response?.detectedContentType && flow.httpFlowExtra.response.detectedContentType !== flow.httpFlowExtra.response.effectiveContentType && (
+                                    <>
+                                        <div className="text-gray-500 dark:text-zinc-500">Sniffed Response Content-Type:</div>
+                                        <div className="break-all">{flow.httpFlowExtra.response.detectedContentType}</div>
+                                    </>
+                                )}
                             </div>
                         </div>
                         {httpFlow.error && (
@@ -313,6 +486,96 @@ export const HttpFlowDetails: React.FC<{
                                 <div className="text-red-600 dark:text-red-400">{httpFlow.error}</div>
                             </div>
                         )}
+                        {getRedirectChain && httpFlow.id && (
+                            <RedirectChain flowId={httpFlow.id} getRedirectChain={getRedirectChain} onSelectFlow={onSelectFlow} />
+                        )}
+                        {flow.httpFlowExtra?.cors && (
+                            <div className="break-inside-avoid bg-gray-50 dark:bg-zinc-800 p-4 rounded border border-gray-200 dark:border-zinc-700">
+                                <h5 className="font-semibold text-gray-700 dark:text-zinc-400 mb-3 border-b border-gray-200 dark:border-zinc-700 pb-2">CORS {flow.httpFlowExtra.cors.preflight ? 'Preflight' : ''}</h5>
+                                <div className="grid grid-cols-2 gap-x-4 gap-y-2">
+                                    <div className="text-gray-500 dark:text-zinc-500">Origin:</div> <div className="break-all">{flow.httpFlowExtra.cors.origin}</div>
+                                    {flow.httpFlowExtra.cors.preflightFlowId && <><div className="text-gray-500 dark:text-zinc-500">Preflight:</div> <div className="break-all">{flow.httpFlowExtra.cors.preflightFlowId}</div></>}
+                                    <div className="text-gray-500 dark:text-zinc-500">Result:</div>
+                                    {flow.httpFlowExtra.cors.problems.length === 0 ? (
+                                        <div className="text-green-500">{httpFlow.response ? 'Allowed' : 'Waiting for response'}</div>
+                                    ) : (
+                                        <ul className="text-red-500 list-disc pl-4">
+                                            {flow.httpFlowExtra.cors.problems.map((problem, i) => <li key={i}>{problem}</li>)}
+                                        </ul>
+                                    )}
+                                </div>
+                            </div>
+                        )}
+                        {flow.httpFlowExtra?.http2 && (
+                            <div className="break-inside-avoid bg-gray-50 dark:bg-zinc-800 p-4 rounded border border-gray-200 dark:border-zinc-700">
+                                <h5 className="font-semibold text-gray-700 dark:text-zinc-400 mb-3 border-b border-gray-200 dark:border-zinc-700 pb-2">HTTP/2</h5>
+                                <pre className="whitespace-pre-wrap break-all text-gray-800 dark:text-zinc-300">{[...flow.httpFlowExtra.http2.requestPseudoHeaders, ...flow.httpFlowExtra.http2.responsePseudoHeaders].map(h => `${h.name}: ${h.value}`).join('\n')}</pre>
+                                {flow.httpFlowExtra.http2.requestHeaderOrder.length > 0 && (
+                                    <div className="mt-2"><span className="text-gray-500 dark:text-zinc-500">Request header order:</span> <span className="break-all">{flow.httpFlowExtra.http2.requestHeaderOrder.join(', ')}</span></div>
+                                )}
+                                {flow.httpFlowExtra.http2.responseHeaderOrder.length > 0 && (
+                                    <div className="mt-2"><span className="text-gray-500 dark:text-zinc-500">Response header order:</span> <span className="break-all">{flow.httpFlowExtra.http2.responseHeaderOrder.join(', ')}</span></div>
+                                )}
+                            </div>
+                        )}
+                        {flow.httpFlowExtra?.manifestFlowId && (
+                            <div className="break-inside-avoid bg-gray-50 dark:bg-zinc-800 p-4 rounded border border-gray-200 dark:border-zinc-700">
+                                <h5 className="font-semibold text-gray-700 dark:text-zinc-400 mb-3 border-b border-gray-200 dark:border-zinc-700 pb-2">Streaming</h5>
+                                <div className="grid grid-cols-2 gap-x-4 gap-y-2">
+                                    <div className="text-gray-500 dark:text-zinc-500">Listed by manifest:</div>
+                                    {onSelectFlow ? (
+                                        <button className="break-all text-left text-orange-500 hover:underline" onClick={() => onSelectFlow(flow.httpFlowExtra!.manifestFlowId)}>{flow.httpFlowExtra.manifestFlowId}</button>
+                                    ) : (
+                                        <div className="break-all">{flow.httpFlowExtra.manifestFlowId}</div>
+                                    )}
+                                </div>
+                            </div>
+                        )}
+                        {flow.httpFlowExtra?.baseline && (
+                            <div className="break-inside-avoid bg-gray-50 dark:bg-zinc-800 p-4 rounded border border-gray-200 dark:border-zinc-700">
+                                <h5 className="font-semibold text-gray-700 dark:text-zinc-400 mb-3 border-b border-gray-200 dark:border-zinc-700 pb-2">Baseline</h5>
+                                <div className="grid grid-cols-2 gap-x-4 gap-y-2 mb-2">
+                                    <div className="text-gray-500 dark:text-zinc-500">Baseline Flow:</div>
+                                    {onSelectFlow ? (
+                                        <button className="break-all text-left text-orange-500 hover:underline" onClick={() => onSelectFlow(flow.httpFlowExtra!.baseline!.baselineFlowId)}>{flow.httpFlowExtra.baseline.baselineFlowId}</button>
+                                    ) : (
+                                        <div className="break-all">{flow.httpFlowExtra.baseline.baselineFlowId}</div>
+                                    )}
+                                </div>
+                                {flow.httpFlowExtra.baseline.differences.length === 0 ? (
+                                    <div className="text-green-500">Matches the baseline</div>
+                                ) : (
+                                    <div className="grid grid-cols-[max-content,1fr,1fr] gap-x-4 gap-y-2">
+                                        <div className="text-gray-500 dark:text-zinc-500">Field</div>
+                                        <div className="text-gray-500 dark:text-zinc-500">Baseline</div>
+                                        <div className="text-gray-500 dark:text-zinc-500">Now</div>
+                                        {flow.httpFlowExtra.baseline.differences.map((diff, i) => (
+                                            <React.Fragment key={i}>
+                                                <div className="break-all">{diff.field}</div>
+                                                <div className="break-all">{diff.baseline || <span className="text-gray-500 dark:text-zinc-500">missing</span>}</div>
+                                                <div className="break-all text-red-500">{diff.actual || 'missing'}</div>
+                                            </React.Fragment>
+                                        ))}
+                                    </div>
+                                )}
+                            </div>
+                        )}
+                        {!!flow.httpFlowExtra?.securityFindings.length && (
+                            <div className="break-inside-avoid bg-gray-50 dark:bg-zinc-800 p-4 rounded border border-gray-200 dark:border-zinc-700">
+                                <h5 className="font-semibold text-gray-700 dark:text-zinc-400 mb-3 border-b border-gray-200 dark:border-zinc-700 pb-2">Security Headers</h5>
+                                <div className="grid grid-cols-[max-content,max-content,1fr] gap-x-4 gap-y-2">
+                                    {[...flow.httpFlowExtra.securityFindings]
+                                        .sort((a, b) => b.severity - a.severity)
+                                        .map((finding, i) => (
+                                            <React.Fragment key={i}>
+                                                <div className={SEVERITY_STYLES[finding.severity].className}>{SEVERITY_STYLES[finding.severity].label}</div>
+                                                <div className="text-gray-500 dark:text-zinc-500">{finding.header}</div>
+                                                <div className="break-all">{finding.message}</div>
+                                            </React.Fragment>
+                                        ))}
+                                </div>
+                            </div>
+                        )}
                         <div className="break-inside-avoid bg-gray-50 dark:bg-zinc-800 p-4 rounded border border-gray-200 dark:border-zinc-700">
                             <h5 className="font-semibold text-gray-700 dark:text-zinc-400 mb-3 border-b border-gray-200 dark:border-zinc-700 pb-2">Timing</h5>
                             <div className="grid grid-cols-[max-content,1fr] gap-x-4 gap-y-2">
@@ -339,8 +602,19 @@ export const HttpFlowDetails: React.FC<{
                         headers={httpFlow.request?.headers}
                         flowPart={httpFlow.request}
                         details={flow.httpFlowExtra?.request}
+                        getMoreFrames={getFlowFrames && httpFlow.id ? (offset) => getFlowFrames(httpFlow.id, false, offset) : undefined}
                     />
                 )}
+                {selectedTab === 'response' && flow.httpFlowExtra && flow.httpFlowExtra.interimResponses.length > 0 && (
+                    <div className="mb-4 space-y-2">
+                        {flow.httpFlowExtra.interimResponses.map((interim, i) => (
+                            <div key={i} className="bg-blue-50 dark:bg-zinc-800 p-3 rounded border border-blue-200 dark:border-zinc-700 text-sm font-mono">
+                                <div className="font-semibold text-blue-700 dark:text-blue-400">Interim response {interim.statusCode}</div>
+                                <pre className="whitespace-pre-wrap break-all text-gray-800 dark:text-zinc-300">{formatHeaders(interim.headers)}</pre>
+                            </div>
+                        ))}
+                    </div>
+                )}
                 {selectedTab === 'response' && (
                     <RequestResponseView
                         title="Response"
@@ -351,6 +625,7 @@ export const HttpFlowDetails: React.FC<{
                         headers={httpFlow.response?.headers}
                         flowPart={httpFlow.response}
                         details={flow.httpFlowExtra?.response}
+                        getMoreFrames={getFlowFrames && httpFlow.id ? (offset) => getFlowFrames(httpFlow.id, true, offset) : undefined}
                     />
                 )}
                 {selectedTab === 'websocket' && (
@@ -359,13 +634,23 @@ export const HttpFlowDetails: React.FC<{
                         {httpFlow.websocketMessages.map((msg, index) => (
                             <div key={index} className="mt-2">
                                 <p className="font-semibold text-gray-800 dark:text-zinc-200">{msg.fromClient ? 'Client -> Server' : 'Server -> Client'}</p>
-                                <HexViewer data={msg.content} />
+                                {flow.httpFlowExtra?.websocketMessages[index]?.effectiveContentType ? (
+                                    <pre className="bg-zinc-800 text-zinc-200 p-4 rounded text-xs font-mono whitespace-pre-wrap">{flow.httpFlowExtra.websocketMessages[index].textualFrames[0]}</pre>
+                                ) : (
+                                    <HexViewer data={msg.content} />
+                                )}
                             </div>
                         ))}
                     </div>
                 )}
+                {selectedTab === 'cookies' && getCookieTimeline && (
+                    <CookiesTab flow={flow} getCookieTimeline={getCookieTimeline} />
+                )}
+                {selectedTab === 'changes' && diffWithPrevious && httpFlow.id && (
+                    <DiffTab flowId={httpFlow.id} diffWithPrevious={diffWithPrevious} onSelectFlow={onSelectFlow} />
+                )}
                 {selectedTab === 'connection' && (
-                    <ConnectionTab client={httpFlow.client} server={httpFlow.server} />
+                    <ConnectionTab client={httpFlow.client} server={httpFlow.server} userAgent={flow.httpFlowExtra?.userAgent} serverGeo={flow.httpFlowExtra?.serverGeo} serverHostname={flow.httpFlowExtra?.serverHostname} serverHostnameSource={flow.httpFlowExtra?.serverHostnameSource} />
                 )}
             </div>
         </>
@@ -0,0 +1,24 @@
+import React from 'react';
+import { Tags } from 'lucide-react';
+
+// MetadataDisplay lists the key/value metadata tooling attached to a flow.
+export const MetadataDisplay: React.FC<{ metadata: { [key: string]: string } }> = ({ metadata }) => {
+  const entries = Object.entries(metadata || {}).sort(([a], [b]) => a.localeCompare(b));
+  if (entries.length === 0) return null;
+
+  return (
+    <div className="break-inside-avoid bg-gray-50 dark:bg-zinc-800 p-4 rounded border border-gray-200 dark:border-zinc-700">
+      <h5 className="font-semibold text-gray-700 dark:text-zinc-400 mb-3 border-b border-gray-200 dark:border-zinc-700 pb-2 flex items-center gap-2">
+        <Tags size={16} /> Metadata
+      </h5>
+      <div className="grid grid-cols-2 gap-x-4 gap-y-2">
+        {entries.map(([key, value]) => (
+          <React.Fragment key={key}>
+            <div className="text-gray-500 dark:text-zinc-500 break-all">{key}:</div>
+            <div className="break-all">{value}</div>
+          </React.Fragment>
+        ))}
+      </div>
+    </div>
+  );
+};
@@ -0,0 +1,83 @@
+import { render, screen, fireEvent, waitFor } from '@testing-library/react';
+import { vi, test, expect, describe } from 'vitest';
+import { create } from '@bufbuild/protobuf';
+import ProxyRulesModal from './ProxyRulesModal';
+import { ProxyRules, ProxyRulesSchema } from '../gen/mitmflow/v1/mitmflow_pb';
+
+const loadedRules = () => create(ProxyRulesSchema, {
+  overrides: [{
+    name: 'stub users',
+    match: { host: 'api.example.com', path: '/v1/users/*', methods: ['GET'] },
+    statusCode: 200,
+    headers: { 'Content-Type': 'application/json' },
+    body: new TextEncoder().encode('{"name":"stub"}'),
+  }],
+  throttles: [{
+    name: '3G',
+    match: { host: '*.example.com' },
+    responseDelayMs: 300,
+    downloadBytesPerSecond: 204800n,
+  }],
+});
+
+describe('ProxyRulesModal', () => {
+  test('shows the current overrides', async () => {
+    render(<ProxyRulesModal isOpen={true} onClose={() => {}} onLoad={async () => loadedRules()} onSave={async () => {}} />);
+
+    expect(await screen.findByDisplayValue('stub users')).toBeInTheDocument();
+    expect(screen.getByDisplayValue('api.example.com')).toBeInTheDocument();
+    expect(screen.getByDisplayValue('/v1/users/*')).toBeInTheDocument();
+    expect(screen.getByDisplayValue('Content-Type: application/json')).toBeInTheDocument();
+    expect(screen.getByDisplayValue('{"name":"stub"}')).toBeInTheDocument();
+  });
+
+  test('saves edited and added overrides', async () => {
+    const onSave = vi.fn<(rules: ProxyRules) => Promise<void>>(async () => {});
+    render(<ProxyRulesModal isOpen={true} onClose={() => {}} onLoad={async () => loadedRules()} onSave={onSave} />);
+
+    fireEvent.change(await screen.findByDisplayValue('stub users'), { target: { value: 'stub all users' } });
+    fireEvent.click(screen.getByText(/Add override/i));
+    fireEvent.change(screen.getAllByLabelText('Override name')[1], { target: { value: 'teapot' } });
+    fireEvent.change(screen.getAllByLabelText('Status code')[1], { target: { value: '418' } });
+    fireEvent.click(screen.getByText('Save'));
+
+    await waitFor(() => expect(onSave).toHaveBeenCalledTimes(1));
+    const saved = onSave.mock.calls[0][0];
+    expect(saved.overrides.map(o => o.name)).toEqual(['stub all users', 'teapot']);
+    expect(saved.overrides[0].match?.methods).toEqual(['GET']);
+    expect(saved.overrides[1].statusCode).toBe(418);
+  });
+
+  test('edits throttle delays and bandwidth limits', async () => {
+    const onSave = vi.fn<(rules: ProxyRules) => Promise<void>>(async () => {});
+    render(<ProxyRulesModal isOpen={true} onClose={() => {}} onLoad={async () => loadedRules()} onSave={onSave} />);
+
+    expect(await screen.findByDisplayValue('3G')).toBeInTheDocument();
+    expect(screen.getByLabelText('Response delay (ms)')).toHaveValue(300);
+    expect(screen.getByLabelText('Download limit (KB/s)')).toHaveValue(200);
+
+    fireEvent.change(screen.getByLabelText('Request delay (ms)'), { target: { value: '100' } });
+    fireEvent.change(screen.getByLabelText('Upload limit (KB/s)'), { target: { value: '64' } });
+    fireEvent.change(screen.getByLabelText('Download limit (KB/s)'), { target: { value: '' } });
+    fireEvent.click(screen.getByText('Save'));
+
+    await waitFor(() => expect(onSave).toHaveBeenCalledTimes(1));
+    const [throttle] = onSave.mock.calls[0][0].throttles;
+    expect(throttle.name).toBe('3G');
+    expect(throttle.match?.host).toBe('*.example.com');
+    expect(throttle.requestDelayMs).toBe(100);
+    expect(throttle.responseDelayMs).toBe(300);
+    expect(throttle.uploadBytesPerSecond).toBe(65536n);
+    expect(throttle.downloadBytesPerSecond).toBe(0n);
+  });
+
+  test('shows save errors', async () => {
+    const onSave = async () => { throw new Error('override "x" has status code 42'); };
+    render(<ProxyRulesModal isOpen={true} onClose={() => {}} onLoad={async () => loadedRules()} onSave={onSave} />);
+
+    await screen.findByDisplayValue('stub users');
+    fireEvent.click(screen.getByText('Save'));
+
+    expect(await screen.findByText(/status code 42/)).toBeInTheDocument();
+  });
+});
@@ -0,0 +1,418 @@
+import React, { useState, useEffect } from 'react';
+import { X, Plus, Trash } from 'lucide-react';
+import { create } from '@bufbuild/protobuf';
+import { ProxyRules, ProxyRulesSchema, OverrideRule, OverrideRuleSchema, ThrottleRule, ThrottleRuleSchema, ProxyRuleMatch, ProxyRuleMatchSchema } from '../gen/mitmflow/v1/mitmflow_pb';
+import { parseHeaders } from './ComposeRequestModal';
+
+interface ProxyRulesModalProps {
+  isOpen: boolean;
+  onClose: () => void;
+  onLoad: () => Promise<ProxyRules | undefined>;
+  onSave: (rules: ProxyRules) => Promise<void>;
+}
+
+// The match fields are edited as text: methods as a comma separated list.
+interface MatchDraft {
+  host: string;
+  path: string;
+  methods: string;
+}
+
+interface OverrideDraft extends MatchDraft {
+  name: string;
+  disabled: boolean;
+  statusCode: string;
+  headers: string;
+  body: string;
+  file: string;
+}
+
+// Bandwidth limits are edited in KB/s, where empty means no limit.
+interface ThrottleDraft extends MatchDraft {
+  name: string;
+  disabled: boolean;
+  requestDelayMs: string;
+  responseDelayMs: string;
+  uploadKBps: string;
+  downloadKBps: string;
+}
+
+const matchToDraft = (match?: ProxyRuleMatch): MatchDraft => ({
+  host: match?.host ?? '',
+  path: match?.path ?? '',
+  methods: match?.methods.join(', ') ?? '',
+});
+
+const draftToMatch = (draft: MatchDraft): ProxyRuleMatch => create(ProxyRuleMatchSchema, {
+  host: draft.host.trim(),
+  path: draft.path.trim(),
+  methods: draft.methods.split(',').map(m => m.trim().toUpperCase()).filter(m => m !== ''),
+});
+
+const overrideToDraft = (rule: OverrideRule): OverrideDraft => ({
+  ...matchToDraft(rule.match),
+  name: rule.name,
+  disabled: rule.disabled,
+  statusCode: rule.statusCode ? String(rule.statusCode) : '',
+  headers: Object.entries(rule.headers).map(([k, v]) => `${k}: ${v}`).join('\n'),
+  body: new TextDecoder().decode(rule.body),
+  file: rule.file,
+});
+
+const draftToOverride = (draft: OverrideDraft): OverrideRule => create(OverrideRuleSchema, {
+  name: draft.name.trim(),
+  disabled: draft.disabled,
+  match: draftToMatch(draft),
+  statusCode: Number(draft.statusCode) || 0,
+  headers: parseHeaders(draft.headers),
+  // The body field is disabled, but kept, while a file is set.
+  body: draft.file.trim() === '' ? new TextEncoder().encode(draft.body) : new Uint8Array(),
+  file: draft.file.trim(),
+});
+
+const newOverride = (): OverrideDraft => ({
+  name: '', disabled: false, host: '', path: '', methods: '', statusCode: '200', headers: '', body: '', file: '',
+});
+
+const bytesToKB = (bytes: bigint): string => bytes > 0n ? String(Number(bytes) / 1024) : '';
+const kbToBytes = (kb: string): bigint => BigInt(Math.round((Number(kb) || 0) * 1024));
+
+const throttleToDraft = (rule: ThrottleRule): ThrottleDraft => ({
+  ...matchToDraft(rule.match),
+  name: rule.name,
+  disabled: rule.disabled,
+  requestDelayMs: rule.requestDelayMs ? String(rule.requestDelayMs) : '',
+  responseDelayMs: rule.responseDelayMs ? String(rule.responseDelayMs) : '',
+  uploadKBps: bytesToKB(rule.uploadBytesPerSecond),
+  downloadKBps: bytesToKB(rule.downloadBytesPerSecond),
+});
+
+const draftToThrottle = (draft: ThrottleDraft): ThrottleRule => create(ThrottleRuleSchema, {
+  name: draft.name.trim(),
+  disabled: draft.disabled,
+  match: draftToMatch(draft),
+  requestDelayMs: Math.round(Number(draft.requestDelayMs) || 0),
+  responseDelayMs: Math.round(Number(draft.responseDelayMs) || 0),
+  uploadBytesPerSecond: kbToBytes(draft.uploadKBps),
+  downloadBytesPerSecond: kbToBytes(draft.downloadKBps),
+});
+
+const newThrottle = (): ThrottleDraft => ({
+  name: '', disabled: false, host: '', path: '', methods: '', requestDelayMs: '', responseDelayMs: '', uploadKBps: '', downloadKBps: '',
+});
+
+const ProxyRulesModal: React.FC<ProxyRulesModalProps> = ({ isOpen, onClose, onLoad, onSave }) => {
+  const [overrides, setOverrides] = useState<OverrideDraft[]>([]);
+  const [throttles, setThrottles] = useState<ThrottleDraft[]>([]);
+  const [isLoading, setIsLoading] = useState(false);
+  const [isSaving, setIsSaving] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+
+  // Fetch the current rules whenever the modal opens, so edits made
+  // elsewhere aren't overwritten with stale ones.
+  useEffect(() => {
+    if (!isOpen) return;
+    let cancelled = false;
+    setError(null);
+    setIsLoading(true);
+    onLoad()
+      .then(loaded => {
+        if (cancelled) return;
+        setOverrides((loaded?.overrides ?? []).map(overrideToDraft));
+        setThrottles((loaded?.throttles ?? []).map(throttleToDraft));
+      })
+      .catch(err => {
+        if (!cancelled) setError(err instanceof Error ? err.message : String(err));
+      })
+      .finally(() => {
+        if (!cancelled) setIsLoading(false);
+      });
+    return () => { cancelled = true; };
+  }, [isOpen, onLoad]);
+
+  useEffect(() => {
+    if (!isOpen) return;
+    const handleKeyDown = (e: KeyboardEvent) => {
+      if (e.key === 'Escape') {
+        e.stopImmediatePropagation();
+        onClose();
+      }
+    };
+    window.addEventListener('keydown', handleKeyDown, true);
+    return () => window.removeEventListener('keydown', handleKeyDown, true);
+  }, [isOpen, onClose]);
+
+  if (!isOpen) return null;
+
+  const updateOverride = (index: number, changes: Partial<OverrideDraft>) => {
+    setOverrides(prev => prev.map((o, i) => i === index ? { ...o, ...changes } : o));
+  };
+
+  const updateThrottle = (index: number, changes: Partial<ThrottleDraft>) => {
+    setThrottles(prev => prev.map((t, i) => i === index ? { ...t, ...changes } : t));
+  };
+
+  const handleSave = async () => {
+    setIsSaving(true);
+    setError(null);
+    try {
+      await onSave(create(ProxyRulesSchema, {
+        overrides: overrides.map(draftToOverride),
+        throttles: throttles.map(draftToThrottle),
+      }));
+    } catch (err) {
+      setError(err instanceof Error ? err.message : String(err));
+    } finally {
+      setIsSaving(false);
+    }
+  };
+
+  const inputClass = "w-full text-sm p-2 bg-gray-50 dark:bg-zinc-900 border border-gray-200 dark:border-zinc-700 rounded focus:ring-2 focus:ring-orange-500 focus:outline-none dark:text-zinc-200";
+
+  return (
+    <div className="fixed inset-0 bg-black/50 z-[100] flex items-center justify-center backdrop-blur-sm">
+      <div className="bg-white dark:bg-zinc-800 rounded-lg shadow-xl w-full max-w-3xl max-h-[90vh] text-gray-900 dark:text-white border border-gray-200 dark:border-zinc-700 flex flex-col">
+        <div className="flex justify-between items-center p-4 border-b border-gray-200 dark:border-zinc-700">
+          <h2 className="text-lg font-semibold">Proxy Rules</h2>
+          <button onClick={onClose} className="text-gray-500 dark:text-zinc-400 hover:text-gray-900 dark:hover:text-white">
+            <X size={20} />
+          </button>
+        </div>
+
+        <div className="p-4 flex flex-col gap-3 overflow-y-auto">
+          <div className="flex justify-between items-center">
+            <div>
+              <h3 className="font-semibold">Overrides</h3>
+              <p className="text-xs text-gray-500 dark:text-zinc-500">Answer matching requests with a local response. Host and path accept * wildcards.</p>
+            </div>
+            <button
+              onClick={() => setOverrides(prev => [...prev, newOverride()])}
+              className="text-sm text-orange-500 hover:text-orange-600 flex items-center gap-1"
+            >
+              <Plus size={16} /> Add override
+            </button>
+          </div>
+          {isLoading && <p className="text-sm text-gray-500 dark:text-zinc-400">Loading...</p>}
+          {!isLoading && overrides.length === 0 && (
+            <p className="text-sm text-gray-500 dark:text-zinc-400">No overrides.</p>
+          )}
+          {overrides.map((override, i) => (
+            <div key={i} className="flex flex-col gap-2 p-3 border border-gray-200 dark:border-zinc-700 rounded">
+              <div className="flex gap-2 items-center">
+                <input
+                  type="text"
+                  aria-label="Override name"
+                  value={override.name}
+                  onChange={(e) => updateOverride(i, { name: e.target.value })}
+                  placeholder="Name"
+                  className={inputClass}
+                />
+                <label className="flex items-center gap-1 text-sm whitespace-nowrap">
+                  <input
+                    type="checkbox"
+                    checked={!override.disabled}
+                    onChange={(e) => updateOverride(i, { disabled: !e.target.checked })}
+                  />
+                  Enabled
+                </label>
+                <button
+                  onClick={() => setOverrides(prev => prev.filter((_, j) => j !== i))}
+                  aria-label="Remove override"
+                  className="text-gray-500 dark:text-zinc-400 hover:text-red-500"
+                >
+                  <Trash size={16} />
+                </button>
+              </div>
+              <div className="flex gap-2">
+                <input
+                  type="text"
+                  value={override.host}
+                  onChange={(e) => updateOverride(i, { host: e.target.value })}
+                  placeholder="Host, e.g. *.example.com"
+                  className={inputClass}
+                />
+                <input
+                  type="text"
+                  value={override.path}
+                  onChange={(e) => updateOverride(i, { path: e.target.value })}
+                  placeholder="Path, e.g. /v1/users/*"
+                  className={inputClass}
+                />
+                <input
+                  type="text"
+                  value={override.methods}
+                  onChange={(e) => updateOverride(i, { methods: e.target.value })}
+                  placeholder="Methods, e.g. GET, POST"
+                  className={`${inputClass} w-48`}
+                />
+              </div>
+              <div className="flex gap-2">
+                <input
+                  type="number"
+                  min={100}
+                  max={599}
+                  aria-label="Status code"
+                  value={override.statusCode}
+                  onChange={(e) => updateOverride(i, { statusCode: e.target.value })}
+                  placeholder="Status"
+                  className={`${inputClass} w-28`}
+                />
+                <input
+                  type="text"
+                  value={override.file}
+                  onChange={(e) => updateOverride(i, { file: e.target.value })}
+                  placeholder="Serve the body from a file on the mitmproxy host (optional)"
+                  className={inputClass}
+                />
+              </div>
+              <textarea
+                value={override.headers}
+                onChange={(e) => updateOverride(i, { headers: e.target.value })}
+                placeholder={"Content-Type: application/json"}
+                className={`${inputClass} font-mono resize-none h-16`}
+              />
+              <textarea
+                value={override.body}
+                onChange={(e) => updateOverride(i, { body: e.target.value })}
+                disabled={override.file.trim() !== ''}
+                placeholder="Response body"
+                className={`${inputClass} font-mono resize-none h-24 disabled:opacity-50`}
+              />
+            </div>
+          ))}
+
+          <div className="flex justify-between items-center mt-3">
+            <div>
+              <h3 className="font-semibold">Throttles</h3>
+              <p className="text-xs text-gray-500 dark:text-zinc-500">Slow matching flows down with added delays and bandwidth limits. Leave a field empty for no limit.</p>
+            </div>
+            <button
+              onClick={() => setThrottles(prev => [...prev, newThrottle()])}
+              className="text-sm text-orange-500 hover:text-orange-600 flex items-center gap-1"
+            >
+              <Plus size={16} /> Add throttle
+            </button>
+          </div>
+          {!isLoading && throttles.length === 0 && (
+            <p className="text-sm text-gray-500 dark:text-zinc-400">No throttles.</p>
+          )}
+          {throttles.map((throttle, i) => (
+            <div key={i} className="flex flex-col gap-2 p-3 border border-gray-200 dark:border-zinc-700 rounded">
+              <div className="flex gap-2 items-center">
+                <input
+                  type="text"
+                  aria-label="Throttle name"
+                  value={throttle.name}
+                  onChange={(e) => updateThrottle(i, { name: e.target.value })}
+                  placeholder="Name, e.g. 3G"
+                  className={inputClass}
+                />
+                <label className="flex items-center gap-1 text-sm whitespace-nowrap">
+                  <input
+                    type="checkbox"
+                    checked={!throttle.disabled}
+                    onChange={(e) => updateThrottle(i, { disabled: !e.target.checked })}
+                  />
+                  Enabled
+                </label>
+                <button
+                  onClick={() => setThrottles(prev => prev.filter((_, j) => j !== i))}
+                  aria-label="Remove throttle"
+                  className="text-gray-500 dark:text-zinc-400 hover:text-red-500"
+                >
+                  <Trash size={16} />
+                </button>
+              </div>
+              <div className="flex gap-2">
+                <input
+                  type="text"
+                  value={throttle.host}
+                  onChange={(e) => updateThrottle(i, { host: e.target.value })}
+                  placeholder="Host, e.g. *.example.com"
+                  className={inputClass}
+                />
+                <input
+                  type="text"
+                  value={throttle.path}
+                  onChange={(e) => updateThrottle(i, { path: e.target.value })}
+                  placeholder="Path, e.g. /v1/users/*"
+                  className={inputClass}
+                />
+                <input
+                  type="text"
+                  value={throttle.methods}
+                  onChange={(e) => updateThrottle(i, { methods: e.target.value })}
+                  placeholder="Methods, e.g. GET, POST"
+                  className={`${inputClass} w-48`}
+                />
+              </div>
+              <div className="grid grid-cols-4 gap-2">
+                <label className="text-xs text-gray-500 dark:text-zinc-400">
+                  Request delay (ms)
+                  <input
+                    type="number"
+                    min={0}
+                    max={600000}
+                    value={throttle.requestDelayMs}
+                    onChange={(e) => updateThrottle(i, { requestDelayMs: e.target.value })}
+                    className={`${inputClass} mt-1`}
+                  />
+                </label>
+                <label className="text-xs text-gray-500 dark:text-zinc-400">
+                  Response delay (ms)
+                  <input
+                    type="number"
+                    min={0}
+                    max={600000}
+                    value={throttle.responseDelayMs}
+                    onChange={(e) => updateThrottle(i, { responseDelayMs: e.target.value })}
+                    className={`${inputClass} mt-1`}
+                  />
+                </label>
+                <label className="text-xs text-gray-500 dark:text-zinc-400">
+                  Upload limit (KB/s)
+                  <input
+                    type="number"
+                    min={0}
+                    value={throttle.uploadKBps}
+                    onChange={(e) => updateThrottle(i, { uploadKBps: e.target.value })}
+                    className={`${inputClass} mt-1`}
+                  />
+                </label>
+                <label className="text-xs text-gray-500 dark:text-zinc-400">
+                  Download limit (KB/s)
+                  <input
+                    type="number"
+                    min={0}
+                    value={throttle.downloadKBps}
+                    onChange={(e) => updateThrottle(i, { downloadKBps: e.target.value })}
+                    className={`${inputClass} mt-1`}
+                  />
+                </label>
+              </div>
+            </div>
+          ))}
+          {error && <p className="text-sm text-red-500">{error}</p>}
+        </div>
+
+        <div className="flex justify-end items-center p-4 border-t border-gray-200 dark:border-zinc-700 bg-gray-50 dark:bg-zinc-900 rounded-b-lg">
+          <button
+            onClick={onClose}
+            className="text-gray-500 dark:text-zinc-400 hover:text-gray-900 dark:hover:text-white px-4 py-2 rounded-md transition-colors text-sm"
+          >
+            Cancel
+          </button>
+          <button
+            onClick={handleSave}
+            disabled={isLoading || isSaving}
+            className="bg-orange-500 hover:bg-orange-600 text-white font-bold py-2 px-4 rounded-md ml-2 transition-colors text-sm disabled:opacity-50 disabled:cursor-not-allowed"
+          >
+            {isSaving ? 'Saving...' : 'Save'}
+          </button>
+        </div>
+      </div>
+    </div>
+  );
+};
+
+export default ProxyRulesModal;
@@ -0,0 +1,45 @@
+import React, { useEffect, useState } from 'react';
+import { RedirectHop } from "../gen/mitmflow/v1/mitmflow_pb";
+
+// RedirectChain shows the redirects that led to a flow and the ones it led
+// to. It renders nothing for flows that aren't part of a chain.
+export const RedirectChain: React.FC<{
+    flowId: string;
+    getRedirectChain: (flowId: string) => Promise<RedirectHop[]>;
+    onSelectFlow?: (flowId: string) => void;
+}> = ({ flowId, getRedirectChain, onSelectFlow }) => {
+    const [hops, setHops] = useState<RedirectHop[]>([]);
+
+    useEffect(() => {
+        let cancelled = false;
+        setHops([]);
+        getRedirectChain(flowId)
+            .then(result => { if (!cancelled) setHops(result); })
+            .catch(err => console.error("Failed to load redirect chain", err));
+        return () => { cancelled = true; };
+    }, [flowId, getRedirectChain]);
+
+    if (hops.length < 2) {
+        return null;
+    }
+
+    return (
+        <div className="break-inside-avoid bg-gray-50 dark:bg-zinc-800 p-4 rounded border border-gray-200 dark:border-zinc-700">
+            <h5 className="font-semibold text-gray-700 dark:text-zinc-400 mb-3 border-b border-gray-200 dark:border-zinc-700 pb-2">Redirect Chain</h5>
+            <ol className="space-y-2">
+                {hops.map((hop, i) => (
+                    <li key={hop.flowId} className="flex gap-2">
+                        <span className="text-gray-500 dark:text-zinc-500">{i + 1}.</span>
+                        <span className={hop.statusCode >= 300 && hop.statusCode < 400 ? 'text-yellow-500' : ''}>{hop.statusCode || '...'}</span>
+                        <span>{hop.method}</span>
+                        {hop.flowId === flowId || !onSelectFlow ? (
+                            <span className={`break-all ${hop.flowId === flowId ? 'font-semibold' : ''}`}>{hop.url}</span>
+                        ) : (
+                            <button className="break-all text-left text-orange-500 hover:underline" onClick={() => onSelectFlow(hop.flowId)}>{hop.url}</button>
+                        )}
+                    </li>
+                ))}
+            </ol>
+        </div>
+    );
+};
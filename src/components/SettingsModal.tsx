@@ -8,20 +8,22 @@ interface SettingsModalProps {
 }
 
 const SettingsModal: React.FC<SettingsModalProps> = ({ isOpen, onClose }) => {
-  const { theme, setTheme, maxFlows, setMaxFlows, maxBodySize, setMaxBodySize } = useSettingsStore();
+  const { theme, setTheme, maxFlows, setMaxFlows, maxBodySize, setMaxBodySize, accessToken, setAccessToken } = useSettingsStore();
   const modalRef = React.useRef<HTMLDivElement>(null);
 
   // Local state for buffered settings
   const [localMaxFlows, setLocalMaxFlows] = React.useState(maxFlows);
   const [localMaxBodySize, setLocalMaxBodySize] = React.useState(maxBodySize);
+  const [localAccessToken, setLocalAccessToken] = React.useState(accessToken);
 
   // Sync local state with store when modal opens
   React.useEffect(() => {
     if (isOpen) {
       setLocalMaxFlows(maxFlows);
       setLocalMaxBodySize(maxBodySize);
+      setLocalAccessToken(accessToken);
     }
-  }, [isOpen, maxFlows, maxBodySize]);
+  }, [isOpen, maxFlows, maxBodySize, accessToken]);
 
   // Only close on Escape if modal is open and focused
   React.useEffect(() => {
@@ -44,6 +46,7 @@ const SettingsModal: React.FC<SettingsModalProps> = ({ isOpen, onClose }) => {
   const handleSave = () => {
     setMaxFlows(localMaxFlows);
     setMaxBodySize(localMaxBodySize);
+    setAccessToken(localAccessToken.trim());
     onClose();
   };
 
@@ -108,6 +111,20 @@ const SettingsModal: React.FC<SettingsModalProps> = ({ isOpen, onClose }) => {
               className="bg-gray-50 dark:bg-zinc-900 border border-gray-300 dark:border-zinc-700 rounded-md text-gray-900 dark:text-zinc-200 px-3 py-2 w-full focus:outline-none focus:ring-2 focus:ring-orange-500"
             />
           </div>
+          <div>
+            <label htmlFor="access-token" className="block text-sm font-medium text-gray-700 dark:text-zinc-300 mb-2">
+              Access token
+            </label>
+            <input
+              type="password"
+              id="access-token"
+              autoComplete="off"
+              placeholder="Only needed if the server requires one"
+              value={localAccessToken}
+              onChange={(e) => setLocalAccessToken(e.target.value)}
+              className="bg-gray-50 dark:bg-zinc-900 border border-gray-300 dark:border-zinc-700 rounded-md text-gray-900 dark:text-zinc-200 px-3 py-2 w-full focus:outline-none focus:ring-2 focus:ring-orange-500"
+            />
+          </div>
         </div>
 
         <div className="flex items-center justify-end gap-3 mt-8">
@@ -3,6 +3,7 @@ import { Flow } from '../gen/mitmflow/v1/mitmflow_pb';
 import HexViewer from '../HexViewer';
 import { ConnectionTab } from './ConnectionTab';
 import { NoteDisplay } from './NoteDisplay';
+import { MetadataDisplay } from './MetadataDisplay';
 import { getFlowId } from '../utils';
 
 export const TcpFlowDetails: React.FC<{
@@ -50,17 +51,22 @@ export const TcpFlowDetails: React.FC<{
                                 }
                             }}
                         />
+                        <MetadataDisplay metadata={flow.metadata} />
                         <h3 className="font-semibold text-gray-900 dark:text-white">Messages</h3>
                         {tcpFlow.messages.map((msg, index) => (
                             <div key={index} className="mt-2">
                                 <p className="font-semibold text-gray-800 dark:text-zinc-200">{msg.fromClient ? 'Client -> Server' : 'Server -> Client'}</p>
-                                <HexViewer data={msg.content} />
+                                {flow.streamFlowExtra?.messages[index]?.effectiveContentType ? (
+                                    <pre className="bg-zinc-800 text-zinc-200 p-4 rounded text-xs font-mono whitespace-pre-wrap">{flow.streamFlowExtra.messages[index].textualFrames[0]}</pre>
+                                ) : (
+                                    <HexViewer data={msg.content} />
+                                )}
                             </div>
                         ))}
                     </div>
                 )}
                 {selectedTab === 'connection' && (
-                    <ConnectionTab client={tcpFlow.client} server={tcpFlow.server} />
+                    <ConnectionTab client={tcpFlow.client} server={tcpFlow.server} serverGeo={flow.streamFlowExtra?.serverGeo} serverHostname={flow.streamFlowExtra?.serverHostname} serverHostnameSource={flow.streamFlowExtra?.serverHostnameSource} />
                 )}
             </div>
         </>
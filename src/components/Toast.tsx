@@ -6,9 +6,10 @@ interface ToastProps {
   isVisible: boolean;
   onClose: () => void;
   duration?: number;
+  action?: { label: string; onClick: () => void };
 }
 
-export const Toast: React.FC<ToastProps> = ({ message, isVisible, onClose, duration = 3000 }) => {
+export const Toast: React.FC<ToastProps> = ({ message, isVisible, onClose, duration = 3000, action }) => {
   useEffect(() => {
     if (isVisible) {
       const timer = setTimeout(onClose, duration);
@@ -21,6 +22,14 @@ export const Toast: React.FC<ToastProps> = ({ message, isVisible, onClose, durat
   return (
     <div className="fixed bottom-4 right-4 bg-zinc-800 text-white px-4 py-3 rounded shadow-lg flex items-center gap-3 z-50">
       <span>{message}</span>
+      {action && (
+        <button
+          onClick={() => { action.onClick(); onClose(); }}
+          className="font-semibold text-orange-400 hover:text-orange-300"
+        >
+          {action.label}
+        </button>
+      )}
       <button onClick={onClose} className="text-zinc-400 hover:text-white">
         <X size={16} />
       </button>
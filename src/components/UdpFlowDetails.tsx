@@ -3,6 +3,7 @@ import { Flow } from '../gen/mitmflow/v1/mitmflow_pb';
 import HexViewer from '../HexViewer';
 import { ConnectionTab } from './ConnectionTab';
 import { NoteDisplay } from './NoteDisplay';
+import { MetadataDisplay } from './MetadataDisplay';
 import { getFlowId } from '../utils';
 
 export const UdpFlowDetails: React.FC<{
@@ -51,6 +52,7 @@ export const UdpFlowDetails: React.FC<{
                                 }
                             }}
                         />
+                        <MetadataDisplay metadata={flow.metadata} />
                         <h3 className="font-semibold text-gray-900 dark:text-white">Messages</h3>
                         {udpFlow.messages.map((msg, index) => (
                             <div key={index} className="mt-2">
@@ -61,7 +63,7 @@ export const UdpFlowDetails: React.FC<{
                     </div>
                 )}
                 {selectedTab === 'connection' && (
-                    <ConnectionTab client={udpFlow.client} server={udpFlow.server} />
+                    <ConnectionTab client={udpFlow.client} server={udpFlow.server} serverGeo={flow.streamFlowExtra?.serverGeo} serverHostname={flow.streamFlowExtra?.serverHostname} serverHostnameSource={flow.streamFlowExtra?.serverHostnameSource} />
                 )}
             </div>
         </>
@@ -50,6 +50,28 @@ export declare type FlowFilter = Message<"mitmflow.v1.FlowFilter"> & {
    * @generated from field: repeated string flow_ids = 7;
    */
   flowIds: string[];
+
+  /**
+   * ISO 3166-1 country codes of the server, e.g. "DE". Needs a GeoIP database.
+   *
+   * @generated from field: repeated string server_countries = 8;
+   */
+  serverCountries: string[];
+
+  /**
+   * Only DNS flows flagged with any of these anomalies.
+   *
+   * @generated from field: repeated mitmflow.v1.DnsAnomalyKind dns_anomaly = 9;
+   */
+  dnsAnomaly: DnsAnomalyKind[];
+
+  /**
+   * Only flows with any of these protocol labels, e.g. "grpc" or "tcp-redis".
+   * See HTTPFlowExtra.protocol.
+   *
+   * @generated from field: repeated string protocols = 10;
+   */
+  protocols: string[];
 };
 
 /**
@@ -80,6 +102,43 @@ export declare type HttpFilter = Message<"mitmflow.v1.HttpFilter"> & {
    * @generated from field: repeated string status_codes = 3;
    */
   statusCodes: string[];
+
+  /**
+   * Browser, OS or device type parsed from the User-Agent, e.g. "Chrome",
+   * "iOS", "mobile". Matched case-insensitively.
+   *
+   * @generated from field: repeated string client_families = 4;
+   */
+  clientFamilies: string[];
+
+  /**
+   * Only flows with a security finding at least this severe.
+   *
+   * @generated from field: mitmflow.v1.FindingSeverity min_security_severity = 5;
+   */
+  minSecuritySeverity: FindingSeverity;
+
+  /**
+   * Only flows whose request or response body has this SHA-256, in hex.
+   *
+   * @generated from field: string body_sha256 = 6;
+   */
+  bodySha256: string;
+
+  /**
+   * Only flows with JSON bodies that every query matches.
+   *
+   * @generated from field: repeated mitmflow.v1.BodyQuery body_queries = 7;
+   */
+  bodyQueries: BodyQuery[];
+
+  /**
+   * Only gRPC responses with one of these statuses, by name, e.g.
+   * "UNAVAILABLE". Matched case-insensitively.
+   *
+   * @generated from field: repeated string grpc_statuses = 8;
+   */
+  grpcStatuses: string[];
 };
 
 /**
@@ -88,6 +147,42 @@ export declare type HttpFilter = Message<"mitmflow.v1.HttpFilter"> & {
  */
 export declare const HttpFilterSchema: GenMessage<HttpFilter>;
 
+/**
+ * BodyQuery matches a JSON body by the values a JSONPath expression selects,
+ * e.g. "$.items[*].status" equal to "failed". Paths support .name, ['name'],
+ * [index] (negative counts from the end), [*], .* and ..name.
+ *
+ * @generated from message mitmflow.v1.BodyQuery
+ */
+export declare type BodyQuery = Message<"mitmflow.v1.BodyQuery"> & {
+  /**
+   * @generated from field: string path = 1;
+   */
+  path: string;
+
+  /**
+   * Matches when any selected value equals this: strings as is, numbers
+   * numerically and anything else as JSON, e.g. "true" or "null". Without it,
+   * the path only has to select something.
+   *
+   * @generated from field: string equals = 2 [features.field_presence = EXPLICIT];
+   */
+  equals: string;
+
+  /**
+   * Query the request body instead of the response body.
+   *
+   * @generated from field: bool request = 3;
+   */
+  request: boolean;
+};
+
+/**
+ * Describes the message mitmflow.v1.BodyQuery.
+ * Use `create(BodyQuerySchema)` to create a new message.
+ */
+export declare const BodyQuerySchema: GenMessage<BodyQuery>;
+
 /**
  * @generated from message mitmflow.v1.GetFlowRequest
  */
@@ -120,6 +215,118 @@ export declare type GetFlowResponse = Message<"mitmflow.v1.GetFlowResponse"> & {
  */
 export declare const GetFlowResponseSchema: GenMessage<GetFlowResponse>;
 
+/**
+ * @generated from message mitmflow.v1.GetFlowBodyRequest
+ */
+export declare type GetFlowBodyRequest = Message<"mitmflow.v1.GetFlowBodyRequest"> & {
+  /**
+   * @generated from field: string flow_id = 1;
+   */
+  flowId: string;
+
+  /**
+   * Return the response body instead of the request body.
+   *
+   * @generated from field: bool response = 2;
+   */
+  response: boolean;
+};
+
+/**
+ * Describes the message mitmflow.v1.GetFlowBodyRequest.
+ * Use `create(GetFlowBodyRequestSchema)` to create a new message.
+ */
+export declare const GetFlowBodyRequestSchema: GenMessage<GetFlowBodyRequest>;
+
+/**
+ * @generated from message mitmflow.v1.GetFlowBodyResponse
+ */
+export declare type GetFlowBodyResponse = Message<"mitmflow.v1.GetFlowBodyResponse"> & {
+  /**
+   * @generated from field: bytes content = 1;
+   */
+  content: Uint8Array;
+
+  /**
+   * @generated from field: string content_type = 2;
+   */
+  contentType: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.GetFlowBodyResponse.
+ * Use `create(GetFlowBodyResponseSchema)` to create a new message.
+ */
+export declare const GetFlowBodyResponseSchema: GenMessage<GetFlowBodyResponse>;
+
+/**
+ * @generated from message mitmflow.v1.GetFlowFramesRequest
+ */
+export declare type GetFlowFramesRequest = Message<"mitmflow.v1.GetFlowFramesRequest"> & {
+  /**
+   * @generated from field: string flow_id = 1;
+   */
+  flowId: string;
+
+  /**
+   * Page through the response's frames instead of the request's.
+   *
+   * @generated from field: bool response = 2;
+   */
+  response: boolean;
+
+  /**
+   * The index of the first frame to return.
+   *
+   * @generated from field: int32 offset = 3;
+   */
+  offset: number;
+
+  /**
+   * How many frames to return. Defaults to 100.
+   *
+   * @generated from field: int32 limit = 4;
+   */
+  limit: number;
+};
+
+/**
+ * Describes the message mitmflow.v1.GetFlowFramesRequest.
+ * Use `create(GetFlowFramesRequestSchema)` to create a new message.
+ */
+export declare const GetFlowFramesRequestSchema: GenMessage<GetFlowFramesRequest>;
+
+/**
+ * @generated from message mitmflow.v1.GetFlowFramesResponse
+ */
+export declare type GetFlowFramesResponse = Message<"mitmflow.v1.GetFlowFramesResponse"> & {
+  /**
+   * @generated from field: repeated string frames = 1;
+   */
+  frames: string[];
+
+  /**
+   * When each of frames was received, like
+   * MessageDetails.frame_timestamps_ns.
+   *
+   * @generated from field: repeated int64 frame_timestamps_ns = 2;
+   */
+  frameTimestampsNs: bigint[];
+
+  /**
+   * The number of frames the message has in all.
+   *
+   * @generated from field: int32 total = 3;
+   */
+  total: number;
+};
+
+/**
+ * Describes the message mitmflow.v1.GetFlowFramesResponse.
+ * Use `create(GetFlowFramesResponseSchema)` to create a new message.
+ */
+export declare const GetFlowFramesResponseSchema: GenMessage<GetFlowFramesResponse>;
+
 /**
  * @generated from message mitmflow.v1.GetFlowsRequest
  */
@@ -149,6 +356,14 @@ export declare type GetFlowsResponse = Message<"mitmflow.v1.GetFlowsResponse"> &
    * @generated from field: mitmflow.v1.FlowSummary flow = 1;
    */
   flow?: FlowSummary;
+
+  /**
+   * The change sequence when the listing started. Streaming with it as
+   * since_sequence returns only flows changed afterwards.
+   *
+   * @generated from field: uint64 sequence = 2;
+   */
+  sequence: bigint;
 };
 
 /**
@@ -170,6 +385,16 @@ export declare type StreamFlowsRequest = Message<"mitmflow.v1.StreamFlowsRequest
    * @generated from field: mitmflow.v1.FlowFilter filter = 2;
    */
   filter?: FlowFilter;
+
+  /**
+   * Send flows changed after this change sequence before streaming live
+   * flows. Takes precedence over since_timestamp_ns. Sequences carry over
+   * server restarts; flows kept from before a restart count as changed at
+   * the last sequence of the previous run.
+   *
+   * @generated from field: uint64 since_sequence = 3;
+   */
+  sinceSequence: bigint;
 };
 
 /**
@@ -191,6 +416,12 @@ export declare type StreamFlowsResponse = Message<"mitmflow.v1.StreamFlowsRespon
      */
     value: FlowSummary;
     case: "flow";
+  } | {
+    /**
+     * @generated from field: mitmflow.v1.Keepalive keepalive = 2;
+     */
+    value: Keepalive;
+    case: "keepalive";
   } | { case: undefined; value?: undefined };
 };
 
@@ -200,6 +431,28 @@ export declare type StreamFlowsResponse = Message<"mitmflow.v1.StreamFlowsRespon
  */
 export declare const StreamFlowsResponseSchema: GenMessage<StreamFlowsResponse>;
 
+/**
+ * Keepalive is sent on an otherwise idle flow stream so intermediaries don't
+ * close the connection, and so clients notice when the server goes away.
+ *
+ * @generated from message mitmflow.v1.Keepalive
+ */
+export declare type Keepalive = Message<"mitmflow.v1.Keepalive"> & {
+  /**
+   * How often keepalives are sent. The first one is sent as soon as the
+   * stream starts, so a client knows how long to wait before giving up on it.
+   *
+   * @generated from field: int64 interval_ms = 1;
+   */
+  intervalMs: bigint;
+};
+
+/**
+ * Describes the message mitmflow.v1.Keepalive.
+ * Use `create(KeepaliveSchema)` to create a new message.
+ */
+export declare const KeepaliveSchema: GenMessage<Keepalive>;
+
 /**
  * @generated from message mitmflow.v1.UpdateFlowRequest
  */
@@ -218,6 +471,30 @@ export declare type UpdateFlowRequest = Message<"mitmflow.v1.UpdateFlowRequest">
    * @generated from field: string note = 3 [features.field_presence = EXPLICIT];
    */
   note: string;
+
+  /**
+   * Metadata to merge into the flow's. Keys with an empty value are removed.
+   *
+   * @generated from field: map<string, string> metadata = 4;
+   */
+  metadata: { [key: string]: string };
+
+  /**
+   * Set the shared pin and note, seen by every user, instead of the caller's
+   * own. Ignored when the server doesn't require authentication, where
+   * everything is shared.
+   *
+   * @generated from field: bool shared = 5;
+   */
+  shared: boolean;
+
+  /**
+   * Hide the flow from everyone but its owner and admins. Only they can
+   * change it.
+   *
+   * @generated from field: bool private = 6 [features.field_presence = EXPLICIT];
+   */
+  private: boolean;
 };
 
 /**
@@ -271,6 +548,21 @@ export declare type DeleteFlowsResponse = Message<"mitmflow.v1.DeleteFlowsRespon
    * @generated from field: int64 count = 1;
    */
   count: bigint;
+
+  /**
+   * The IDs of the deleted flows.
+   *
+   * @generated from field: repeated string flow_ids = 2;
+   */
+  flowIds: string[];
+
+  /**
+   * Whether the deleted flows went into the trash and can be brought back
+   * with RestoreFlows.
+   *
+   * @generated from field: bool restorable = 3;
+   */
+  restorable: boolean;
 };
 
 /**
@@ -292,6 +584,58 @@ export declare type ExportFlowsRequest = Message<"mitmflow.v1.ExportFlowsRequest
    * @generated from field: mitmflow.v1.ExportFormat format = 2;
    */
   format: ExportFormat;
+
+  /**
+   * Replace hostnames, IP addresses, cookie values and credentials with
+   * pseudonyms before exporting. The same value always gets the same pseudonym
+   * from a server, which keeps the key that derives them to itself.
+   * Certificates and packed DNS messages are dropped; bodies are exported as
+   * they are.
+   *
+   * @generated from field: bool anonymize = 3;
+   */
+  anonymize: boolean;
+
+  /**
+   * Shift every timestamp by the same amount so the earliest one in the export
+   * lands on epoch (the Unix epoch when unset). Relative timing is kept.
+   *
+   * @generated from field: bool shift_timestamps = 4;
+   */
+  shiftTimestamps: boolean;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp epoch = 5;
+   */
+  epoch?: Timestamp;
+
+  /**
+   * Select flows by query instead of by ID. When any of the fields below is
+   * set, the flows matching all of them are exported, narrowed to flow_ids
+   * if those are given too. An empty filter matches every flow.
+   *
+   * @generated from field: mitmflow.v1.FlowFilter filter = 6;
+   */
+  filter?: FlowFilter;
+
+  /**
+   * A filter expression such as "~d example.com & ~c 500".
+   *
+   * @generated from field: string filter_expr = 7;
+   */
+  filterExpr: string;
+
+  /**
+   * Only flows that started in this range.
+   *
+   * @generated from field: google.protobuf.Timestamp start_time = 8;
+   */
+  startTime?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp end_time = 9;
+   */
+  endTime?: Timestamp;
 };
 
 /**
@@ -322,357 +666,3425 @@ export declare type ExportFlowsResponse = Message<"mitmflow.v1.ExportFlowsRespon
 export declare const ExportFlowsResponseSchema: GenMessage<ExportFlowsResponse>;
 
 /**
- * @generated from message mitmflow.v1.FlowSummary
+ * @generated from message mitmflow.v1.ImportFlowsRequest
  */
-export declare type FlowSummary = Message<"mitmflow.v1.FlowSummary"> & {
-  /**
-   * @generated from field: string id = 1;
-   */
-  id: string;
-
+export declare type ImportFlowsRequest = Message<"mitmflow.v1.ImportFlowsRequest"> & {
   /**
-   * "http", "dns", "tcp", "udp"
+   * A serialized FlowSet, as produced by EXPORT_FORMAT_PROTO, or a bundle from
+   * CreateShareBundle.
    *
-   * @generated from field: string type = 2;
+   * @generated from field: bytes data = 1;
    */
-  type: string;
+  data: Uint8Array;
+};
 
-  /**
-   * @generated from field: google.protobuf.Timestamp timestamp_start = 3;
-   */
-  timestampStart?: Timestamp;
+/**
+ * Describes the message mitmflow.v1.ImportFlowsRequest.
+ * Use `create(ImportFlowsRequestSchema)` to create a new message.
+ */
+export declare const ImportFlowsRequestSchema: GenMessage<ImportFlowsRequest>;
 
+/**
+ * @generated from message mitmflow.v1.ImportFlowsResponse
+ */
+export declare type ImportFlowsResponse = Message<"mitmflow.v1.ImportFlowsResponse"> & {
   /**
-   * @generated from field: bool pinned = 4;
+   * @generated from field: int64 count = 1;
    */
-  pinned: boolean;
+  count: bigint;
+};
 
+/**
+ * Describes the message mitmflow.v1.ImportFlowsResponse.
+ * Use `create(ImportFlowsResponseSchema)` to create a new message.
+ */
+export declare const ImportFlowsResponseSchema: GenMessage<ImportFlowsResponse>;
+
+/**
+ * @generated from message mitmflow.v1.CreateShareBundleRequest
+ */
+export declare type CreateShareBundleRequest = Message<"mitmflow.v1.CreateShareBundleRequest"> & {
   /**
-   * @generated from field: string note = 5;
+   * @generated from field: string flow_id = 1;
    */
-  note: string;
+  flowId: string;
 
   /**
-   * @generated from oneof mitmflow.v1.FlowSummary.summary
+   * Replace credentials with "REDACTED" before packaging: the values of
+   * Authorization, Cookie, Set-Cookie and other secret-looking headers, query
+   * parameters, form fields and JSON fields. Only HTTP flows can be redacted.
+   *
+   * @generated from field: bool redact = 2;
    */
-  summary: {
-    /**
-     * @generated from field: mitmflow.v1.HttpFlowSummary http = 6;
-     */
-    value: HttpFlowSummary;
-    case: "http";
-  } | {
-    /**
-     * @generated from field: mitmflow.v1.DnsFlowSummary dns = 7;
-     */
-    value: DnsFlowSummary;
-    case: "dns";
-  } | {
-    /**
-     * @generated from field: mitmflow.v1.TcpFlowSummary tcp = 8;
-     */
-    value: TcpFlowSummary;
-    case: "tcp";
-  } | {
-    /**
-     * @generated from field: mitmflow.v1.UdpFlowSummary udp = 9;
-     */
-    value: UdpFlowSummary;
-    case: "udp";
-  } | { case: undefined; value?: undefined };
+  redact: boolean;
 };
 
 /**
- * Describes the message mitmflow.v1.FlowSummary.
- * Use `create(FlowSummarySchema)` to create a new message.
+ * Describes the message mitmflow.v1.CreateShareBundleRequest.
+ * Use `create(CreateShareBundleRequestSchema)` to create a new message.
  */
-export declare const FlowSummarySchema: GenMessage<FlowSummary>;
+export declare const CreateShareBundleRequestSchema: GenMessage<CreateShareBundleRequest>;
 
 /**
- * @generated from message mitmflow.v1.HttpFlowSummary
+ * @generated from message mitmflow.v1.CreateShareBundleResponse
  */
-export declare type HttpFlowSummary = Message<"mitmflow.v1.HttpFlowSummary"> & {
+export declare type CreateShareBundleResponse = Message<"mitmflow.v1.CreateShareBundleResponse"> & {
   /**
-   * @generated from field: string method = 1;
+   * A single line of text that ImportFlows loads, to save as a file or paste
+   * into a bug report: "mitmflow-share:" followed by a gzipped FlowSet holding
+   * the flow, in unpadded base64url.
+   *
+   * @generated from field: string bundle = 1;
    */
-  method: string;
+  bundle: string;
+
+  /**
+   * A file name for saving the bundle.
+   *
+   * @generated from field: string filename = 2;
+   */
+  filename: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.CreateShareBundleResponse.
+ * Use `create(CreateShareBundleResponseSchema)` to create a new message.
+ */
+export declare const CreateShareBundleResponseSchema: GenMessage<CreateShareBundleResponse>;
+
+/**
+ * SessionSelector picks the HTTP flows of a recorded session, e.g. a test run
+ * whose flows were stamped with a build number.
+ *
+ * @generated from message mitmflow.v1.SessionSelector
+ */
+export declare type SessionSelector = Message<"mitmflow.v1.SessionSelector"> & {
+  /**
+   * A filter expression such as "~meta ^build=12". Empty matches every flow.
+   *
+   * @generated from field: string filter = 1;
+   */
+  filter: string;
+
+  /**
+   * Only flows that started in this range, when set.
+   *
+   * @generated from field: google.protobuf.Timestamp start_time = 2;
+   */
+  startTime?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp end_time = 3;
+   */
+  endTime?: Timestamp;
+};
+
+/**
+ * Describes the message mitmflow.v1.SessionSelector.
+ * Use `create(SessionSelectorSchema)` to create a new message.
+ */
+export declare const SessionSelectorSchema: GenMessage<SessionSelector>;
+
+/**
+ * @generated from message mitmflow.v1.SetBaselineRequest
+ */
+export declare type SetBaselineRequest = Message<"mitmflow.v1.SetBaselineRequest"> & {
+  /**
+   * The session whose responses new flows are compared with. Leave unset to
+   * clear the baseline.
+   *
+   * @generated from field: mitmflow.v1.SessionSelector session = 1;
+   */
+  session?: SessionSelector;
+};
+
+/**
+ * Describes the message mitmflow.v1.SetBaselineRequest.
+ * Use `create(SetBaselineRequestSchema)` to create a new message.
+ */
+export declare const SetBaselineRequestSchema: GenMessage<SetBaselineRequest>;
+
+/**
+ * @generated from message mitmflow.v1.SetBaselineResponse
+ */
+export declare type SetBaselineResponse = Message<"mitmflow.v1.SetBaselineResponse"> & {
+  /**
+   * How many distinct method and path pairs the baseline holds.
+   *
+   * @generated from field: int64 count = 1;
+   */
+  count: bigint;
+};
+
+/**
+ * Describes the message mitmflow.v1.SetBaselineResponse.
+ * Use `create(SetBaselineResponseSchema)` to create a new message.
+ */
+export declare const SetBaselineResponseSchema: GenMessage<SetBaselineResponse>;
+
+/**
+ * @generated from message mitmflow.v1.CompareSessionsRequest
+ */
+export declare type CompareSessionsRequest = Message<"mitmflow.v1.CompareSessionsRequest"> & {
+  /**
+   * @generated from field: mitmflow.v1.SessionSelector session = 1;
+   */
+  session?: SessionSelector;
+};
+
+/**
+ * Describes the message mitmflow.v1.CompareSessionsRequest.
+ * Use `create(CompareSessionsRequestSchema)` to create a new message.
+ */
+export declare const CompareSessionsRequestSchema: GenMessage<CompareSessionsRequest>;
+
+/**
+ * @generated from message mitmflow.v1.CompareSessionsResponse
+ */
+export declare type CompareSessionsResponse = Message<"mitmflow.v1.CompareSessionsResponse"> & {
+  /**
+   * The flows of the session that regressed from the baseline.
+   *
+   * @generated from field: repeated mitmflow.v1.BaselineComparison regressions = 1;
+   */
+  regressions: BaselineComparison[];
+
+  /**
+   * How many flows of the session had a baseline response to compare with.
+   *
+   * @generated from field: int64 matched_count = 2;
+   */
+  matchedCount: bigint;
+
+  /**
+   * How many flows of the session had a method and path the baseline lacks.
+   *
+   * @generated from field: int64 unmatched_count = 3;
+   */
+  unmatchedCount: bigint;
+};
+
+/**
+ * Describes the message mitmflow.v1.CompareSessionsResponse.
+ * Use `create(CompareSessionsResponseSchema)` to create a new message.
+ */
+export declare const CompareSessionsResponseSchema: GenMessage<CompareSessionsResponse>;
+
+/**
+ * @generated from message mitmflow.v1.BaselineComparison
+ */
+export declare type BaselineComparison = Message<"mitmflow.v1.BaselineComparison"> & {
+  /**
+   * @generated from field: string flow_id = 1;
+   */
+  flowId: string;
+
+  /**
+   * The baseline flow with the same method and path.
+   *
+   * @generated from field: string baseline_flow_id = 2;
+   */
+  baselineFlowId: string;
+
+  /**
+   * @generated from field: string method = 3;
+   */
+  method: string;
+
+  /**
+   * @generated from field: string path = 4;
+   */
+  path: string;
+
+  /**
+   * What changed for the worse. Empty when the response matches the baseline.
+   *
+   * @generated from field: repeated mitmflow.v1.BaselineDifference differences = 5;
+   */
+  differences: BaselineDifference[];
+};
+
+/**
+ * Describes the message mitmflow.v1.BaselineComparison.
+ * Use `create(BaselineComparisonSchema)` to create a new message.
+ */
+export declare const BaselineComparisonSchema: GenMessage<BaselineComparison>;
+
+/**
+ * BaselineDifference is a status code that changed, a header that changed or
+ * went missing, or a JSON body field that changed type or went missing.
+ * Headers that differ on every response, like Date, are not compared.
+ *
+ * @generated from message mitmflow.v1.BaselineDifference
+ */
+export declare type BaselineDifference = Message<"mitmflow.v1.BaselineDifference"> & {
+  /**
+   * @generated from field: mitmflow.v1.BaselineDifferenceKind kind = 1;
+   */
+  kind: BaselineDifferenceKind;
+
+  /**
+   * The header name, or the path of a body field such as "$.items[].id".
+   *
+   * @generated from field: string field = 2;
+   */
+  field: string;
+
+  /**
+   * The status code, header value or JSON type. Empty when missing.
+   *
+   * @generated from field: string baseline = 3;
+   */
+  baseline: string;
+
+  /**
+   * @generated from field: string actual = 4;
+   */
+  actual: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.BaselineDifference.
+ * Use `create(BaselineDifferenceSchema)` to create a new message.
+ */
+export declare const BaselineDifferenceSchema: GenMessage<BaselineDifference>;
+
+/**
+ * @generated from message mitmflow.v1.CreateBackupRequest
+ */
+export declare type CreateBackupRequest = Message<"mitmflow.v1.CreateBackupRequest"> & {
+  /**
+   * When set, the backup is written to this file inside the server's backup
+   * directory instead of being streamed back.
+   *
+   * @generated from field: string path = 1;
+   */
+  path: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.CreateBackupRequest.
+ * Use `create(CreateBackupRequestSchema)` to create a new message.
+ */
+export declare const CreateBackupRequestSchema: GenMessage<CreateBackupRequest>;
+
+/**
+ * @generated from message mitmflow.v1.CreateBackupResponse
+ */
+export declare type CreateBackupResponse = Message<"mitmflow.v1.CreateBackupResponse"> & {
+  /**
+   * A piece of the gzipped tarball. Concatenate the chunks in order.
+   *
+   * @generated from field: bytes chunk = 1;
+   */
+  chunk: Uint8Array;
+
+  /**
+   * Where the backup was written, when a path was requested.
+   *
+   * @generated from field: string path = 2;
+   */
+  path: string;
+
+  /**
+   * Set on the final message.
+   *
+   * @generated from field: int64 flow_count = 3;
+   */
+  flowCount: bigint;
+};
+
+/**
+ * Describes the message mitmflow.v1.CreateBackupResponse.
+ * Use `create(CreateBackupResponseSchema)` to create a new message.
+ */
+export declare const CreateBackupResponseSchema: GenMessage<CreateBackupResponse>;
+
+/**
+ * @generated from message mitmflow.v1.RestoreBackupRequest
+ */
+export declare type RestoreBackupRequest = Message<"mitmflow.v1.RestoreBackupRequest"> & {
+  /**
+   * @generated from oneof mitmflow.v1.RestoreBackupRequest.source
+   */
+  source: {
+    /**
+     * A backup as streamed by CreateBackup.
+     *
+     * @generated from field: bytes data = 1;
+     */
+    value: Uint8Array;
+    case: "data";
+  } | {
+    /**
+     * A backup file inside the server's backup directory.
+     *
+     * @generated from field: string path = 2;
+     */
+    value: string;
+    case: "path";
+  } | { case: undefined; value?: undefined };
+
+  /**
+   * Delete every existing flow, including pinned flows, before restoring.
+   *
+   * @generated from field: bool replace = 3;
+   */
+  replace: boolean;
+};
+
+/**
+ * Describes the message mitmflow.v1.RestoreBackupRequest.
+ * Use `create(RestoreBackupRequestSchema)` to create a new message.
+ */
+export declare const RestoreBackupRequestSchema: GenMessage<RestoreBackupRequest>;
+
+/**
+ * @generated from message mitmflow.v1.RestoreBackupResponse
+ */
+export declare type RestoreBackupResponse = Message<"mitmflow.v1.RestoreBackupResponse"> & {
+  /**
+   * @generated from field: int64 count = 1;
+   */
+  count: bigint;
+};
+
+/**
+ * Describes the message mitmflow.v1.RestoreBackupResponse.
+ * Use `create(RestoreBackupResponseSchema)` to create a new message.
+ */
+export declare const RestoreBackupResponseSchema: GenMessage<RestoreBackupResponse>;
+
+/**
+ * @generated from message mitmflow.v1.SearchArchiveRequest
+ */
+export declare type SearchArchiveRequest = Message<"mitmflow.v1.SearchArchiveRequest"> & {
+  /**
+   * @generated from field: mitmflow.v1.FlowFilter filter = 1;
+   */
+  filter?: FlowFilter;
+
+  /**
+   * @generated from field: int32 limit = 2;
+   */
+  limit: number;
+};
+
+/**
+ * Describes the message mitmflow.v1.SearchArchiveRequest.
+ * Use `create(SearchArchiveRequestSchema)` to create a new message.
+ */
+export declare const SearchArchiveRequestSchema: GenMessage<SearchArchiveRequest>;
+
+/**
+ * @generated from message mitmflow.v1.SearchArchiveResponse
+ */
+export declare type SearchArchiveResponse = Message<"mitmflow.v1.SearchArchiveResponse"> & {
+  /**
+   * @generated from field: mitmflow.v1.FlowSummary flow = 1;
+   */
+  flow?: FlowSummary;
+};
+
+/**
+ * Describes the message mitmflow.v1.SearchArchiveResponse.
+ * Use `create(SearchArchiveResponseSchema)` to create a new message.
+ */
+export declare const SearchArchiveResponseSchema: GenMessage<SearchArchiveResponse>;
+
+/**
+ * @generated from message mitmflow.v1.RestoreArchivedFlowsRequest
+ */
+export declare type RestoreArchivedFlowsRequest = Message<"mitmflow.v1.RestoreArchivedFlowsRequest"> & {
+  /**
+   * @generated from field: repeated string flow_ids = 1;
+   */
+  flowIds: string[];
+
+  /**
+   * Pin restored flows so the next prune doesn't archive them again.
+   *
+   * @generated from field: bool pin = 2;
+   */
+  pin: boolean;
+};
+
+/**
+ * Describes the message mitmflow.v1.RestoreArchivedFlowsRequest.
+ * Use `create(RestoreArchivedFlowsRequestSchema)` to create a new message.
+ */
+export declare const RestoreArchivedFlowsRequestSchema: GenMessage<RestoreArchivedFlowsRequest>;
+
+/**
+ * @generated from message mitmflow.v1.RestoreArchivedFlowsResponse
+ */
+export declare type RestoreArchivedFlowsResponse = Message<"mitmflow.v1.RestoreArchivedFlowsResponse"> & {
+  /**
+   * @generated from field: repeated mitmflow.v1.FlowSummary flows = 1;
+   */
+  flows: FlowSummary[];
+};
+
+/**
+ * Describes the message mitmflow.v1.RestoreArchivedFlowsResponse.
+ * Use `create(RestoreArchivedFlowsResponseSchema)` to create a new message.
+ */
+export declare const RestoreArchivedFlowsResponseSchema: GenMessage<RestoreArchivedFlowsResponse>;
+
+/**
+ * RestoreFlowsRequest brings deleted flows back out of the trash.
+ *
+ * @generated from message mitmflow.v1.RestoreFlowsRequest
+ */
+export declare type RestoreFlowsRequest = Message<"mitmflow.v1.RestoreFlowsRequest"> & {
+  /**
+   * @generated from field: repeated string flow_ids = 1;
+   */
+  flowIds: string[];
+};
+
+/**
+ * Describes the message mitmflow.v1.RestoreFlowsRequest.
+ * Use `create(RestoreFlowsRequestSchema)` to create a new message.
+ */
+export declare const RestoreFlowsRequestSchema: GenMessage<RestoreFlowsRequest>;
+
+/**
+ * @generated from message mitmflow.v1.RestoreFlowsResponse
+ */
+export declare type RestoreFlowsResponse = Message<"mitmflow.v1.RestoreFlowsResponse"> & {
+  /**
+   * @generated from field: repeated mitmflow.v1.FlowSummary flows = 1;
+   */
+  flows: FlowSummary[];
+};
+
+/**
+ * Describes the message mitmflow.v1.RestoreFlowsResponse.
+ * Use `create(RestoreFlowsResponseSchema)` to create a new message.
+ */
+export declare const RestoreFlowsResponseSchema: GenMessage<RestoreFlowsResponse>;
+
+/**
+ * @generated from message mitmflow.v1.UploadFlowBodyRequest
+ */
+export declare type UploadFlowBodyRequest = Message<"mitmflow.v1.UploadFlowBodyRequest"> & {
+  /**
+   * The ID of an HTTP flow that has already been exported.
+   *
+   * @generated from field: string flow_id = 1;
+   */
+  flowId: string;
+
+  /**
+   * Whether the chunk belongs to the response body rather than the request
+   * body.
+   *
+   * @generated from field: bool response = 2;
+   */
+  response: boolean;
+
+  /**
+   * The next part of the body.
+   *
+   * @generated from field: bytes chunk = 3;
+   */
+  chunk: Uint8Array;
+};
+
+/**
+ * Describes the message mitmflow.v1.UploadFlowBodyRequest.
+ * Use `create(UploadFlowBodyRequestSchema)` to create a new message.
+ */
+export declare const UploadFlowBodyRequestSchema: GenMessage<UploadFlowBodyRequest>;
+
+/**
+ * @generated from message mitmflow.v1.UploadFlowBodyResponse
+ */
+export declare type UploadFlowBodyResponse = Message<"mitmflow.v1.UploadFlowBodyResponse"> & {
+  /**
+   * How many bodies were attached to their flows.
+   *
+   * @generated from field: int64 bodies = 1;
+   */
+  bodies: bigint;
+
+  /**
+   * The total size of the bodies.
+   *
+   * @generated from field: int64 bytes = 2;
+   */
+  bytes: bigint;
+};
+
+/**
+ * Describes the message mitmflow.v1.UploadFlowBodyResponse.
+ * Use `create(UploadFlowBodyResponseSchema)` to create a new message.
+ */
+export declare const UploadFlowBodyResponseSchema: GenMessage<UploadFlowBodyResponse>;
+
+/**
+ * AuditEvent records who changed or exported flows, or changed the proxy
+ * rules or the baseline, and when.
+ *
+ * @generated from message mitmflow.v1.AuditEvent
+ */
+export declare type AuditEvent = Message<"mitmflow.v1.AuditEvent"> & {
+  /**
+   * @generated from field: google.protobuf.Timestamp time = 1;
+   */
+  time?: Timestamp;
+
+  /**
+   * The name of the token or OIDC user that made the change, or empty if
+   * the server doesn't require authentication.
+   *
+   * @generated from field: string actor = 2;
+   */
+  actor: string;
+
+  /**
+   * The address the request came from.
+   *
+   * @generated from field: string peer = 3;
+   */
+  peer: string;
+
+  /**
+   * @generated from field: mitmflow.v1.AuditAction action = 4;
+   */
+  action: AuditAction;
+
+  /**
+   * The flows affected. Left empty when every flow was.
+   *
+   * @generated from field: repeated string flow_ids = 5;
+   */
+  flowIds: string[];
+
+  /**
+   * How many flows were affected.
+   *
+   * @generated from field: int64 count = 6;
+   */
+  count: bigint;
+
+  /**
+   * More about the change, like the export format.
+   *
+   * @generated from field: string detail = 7;
+   */
+  detail: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.AuditEvent.
+ * Use `create(AuditEventSchema)` to create a new message.
+ */
+export declare const AuditEventSchema: GenMessage<AuditEvent>;
+
+/**
+ * @generated from message mitmflow.v1.ListAuditEventsRequest
+ */
+export declare type ListAuditEventsRequest = Message<"mitmflow.v1.ListAuditEventsRequest"> & {
+  /**
+   * The most events to return. Defaults to 100.
+   *
+   * @generated from field: int32 limit = 1;
+   */
+  limit: number;
+
+  /**
+   * Only return events with these actions.
+   *
+   * @generated from field: repeated mitmflow.v1.AuditAction actions = 2;
+   */
+  actions: AuditAction[];
+
+  /**
+   * Only return events by this actor.
+   *
+   * @generated from field: string actor = 3;
+   */
+  actor: string;
+
+  /**
+   * Only return events at or after this time.
+   *
+   * @generated from field: google.protobuf.Timestamp since = 4;
+   */
+  since?: Timestamp;
+};
+
+/**
+ * Describes the message mitmflow.v1.ListAuditEventsRequest.
+ * Use `create(ListAuditEventsRequestSchema)` to create a new message.
+ */
+export declare const ListAuditEventsRequestSchema: GenMessage<ListAuditEventsRequest>;
+
+/**
+ * @generated from message mitmflow.v1.ListAuditEventsResponse
+ */
+export declare type ListAuditEventsResponse = Message<"mitmflow.v1.ListAuditEventsResponse"> & {
+  /**
+   * @generated from field: repeated mitmflow.v1.AuditEvent events = 1;
+   */
+  events: AuditEvent[];
+};
+
+/**
+ * Describes the message mitmflow.v1.ListAuditEventsResponse.
+ * Use `create(ListAuditEventsResponseSchema)` to create a new message.
+ */
+export declare const ListAuditEventsResponseSchema: GenMessage<ListAuditEventsResponse>;
+
+/**
+ * @generated from message mitmflow.v1.ListSubscribersRequest
+ */
+export declare type ListSubscribersRequest = Message<"mitmflow.v1.ListSubscribersRequest"> & {
+};
+
+/**
+ * Describes the message mitmflow.v1.ListSubscribersRequest.
+ * Use `create(ListSubscribersRequestSchema)` to create a new message.
+ */
+export declare const ListSubscribersRequestSchema: GenMessage<ListSubscribersRequest>;
+
+/**
+ * @generated from message mitmflow.v1.ListSubscribersResponse
+ */
+export declare type ListSubscribersResponse = Message<"mitmflow.v1.ListSubscribersResponse"> & {
+  /**
+   * Ordered by connect time, oldest first.
+   *
+   * @generated from field: repeated mitmflow.v1.Subscriber subscribers = 1;
+   */
+  subscribers: Subscriber[];
+};
+
+/**
+ * Describes the message mitmflow.v1.ListSubscribersResponse.
+ * Use `create(ListSubscribersResponseSchema)` to create a new message.
+ */
+export declare const ListSubscribersResponseSchema: GenMessage<ListSubscribersResponse>;
+
+/**
+ * Subscriber is a client streaming flows with StreamFlows or over the
+ * WebSocket endpoint.
+ *
+ * @generated from message mitmflow.v1.Subscriber
+ */
+export declare type Subscriber = Message<"mitmflow.v1.Subscriber"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp connect_time = 2;
+   */
+  connectTime?: Timestamp;
+
+  /**
+   * The address the client connected from.
+   *
+   * @generated from field: string peer = 3;
+   */
+  peer: string;
+
+  /**
+   * The filter the client asked for, if any.
+   *
+   * @generated from field: mitmflow.v1.FlowFilter filter = 4;
+   */
+  filter?: FlowFilter;
+
+  /**
+   * How many flows have been sent to the client.
+   *
+   * @generated from field: int64 delivered = 5;
+   */
+  delivered: bigint;
+
+  /**
+   * How many flows were dropped because the client wasn't keeping up.
+   *
+   * @generated from field: int64 dropped = 6;
+   */
+  dropped: bigint;
+};
+
+/**
+ * Describes the message mitmflow.v1.Subscriber.
+ * Use `create(SubscriberSchema)` to create a new message.
+ */
+export declare const SubscriberSchema: GenMessage<Subscriber>;
+
+/**
+ * @generated from message mitmflow.v1.GetServerInfoRequest
+ */
+export declare type GetServerInfoRequest = Message<"mitmflow.v1.GetServerInfoRequest"> & {
+};
+
+/**
+ * Describes the message mitmflow.v1.GetServerInfoRequest.
+ * Use `create(GetServerInfoRequestSchema)` to create a new message.
+ */
+export declare const GetServerInfoRequestSchema: GenMessage<GetServerInfoRequest>;
+
+/**
+ * @generated from message mitmflow.v1.GetServerInfoResponse
+ */
+export declare type GetServerInfoResponse = Message<"mitmflow.v1.GetServerInfoResponse"> & {
+  /**
+   * @generated from field: string version = 1;
+   */
+  version: string;
+
+  /**
+   * @generated from field: string go_version = 2;
+   */
+  goVersion: string;
+
+  /**
+   * VCS revision and commit time the binary was built from, when known.
+   *
+   * @generated from field: string vcs_revision = 3;
+   */
+  vcsRevision: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp vcs_time = 4;
+   */
+  vcsTime?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp start_time = 5;
+   */
+  startTime?: Timestamp;
+
+  /**
+   * @generated from field: int64 uptime_ms = 6;
+   */
+  uptimeMs: bigint;
+
+  /**
+   * @generated from field: int32 max_flows = 7;
+   */
+  maxFlows: number;
+
+  /**
+   * @generated from field: int64 max_body_bytes = 8;
+   */
+  maxBodyBytes: bigint;
+
+  /**
+   * @generated from field: int64 blob_threshold = 9;
+   */
+  blobThreshold: bigint;
+
+  /**
+   * @generated from field: string data_dir = 10;
+   */
+  dataDir: string;
+
+  /**
+   * @generated from field: string archive_dir = 11;
+   */
+  archiveDir: string;
+
+  /**
+   * @generated from field: string backup_dir = 12;
+   */
+  backupDir: string;
+
+  /**
+   * @generated from field: int64 flow_count = 13;
+   */
+  flowCount: bigint;
+
+  /**
+   * Number of stored flows keyed by type: "http", "dns", "tcp", "udp".
+   *
+   * @generated from field: map<string, int64> flow_counts = 14;
+   */
+  flowCounts: { [key: string]: bigint };
+
+  /**
+   * @generated from field: int32 descriptor_file_count = 15;
+   */
+  descriptorFileCount: number;
+
+  /**
+   * @generated from field: int32 subscriber_count = 16;
+   */
+  subscriberCount: number;
+
+  /**
+   * The largest ExportFlowRequest message accepted from mitmproxy, or zero
+   * for no limit.
+   *
+   * @generated from field: int64 max_ingest_message_bytes = 17;
+   */
+  maxIngestMessageBytes: bigint;
+
+  /**
+   * How often idle flow streams are sent a keepalive, or zero if they aren't.
+   *
+   * @generated from field: int64 stream_keepalive_interval_ms = 18;
+   */
+  streamKeepaliveIntervalMs: bigint;
+};
+
+/**
+ * Describes the message mitmflow.v1.GetServerInfoResponse.
+ * Use `create(GetServerInfoResponseSchema)` to create a new message.
+ */
+export declare const GetServerInfoResponseSchema: GenMessage<GetServerInfoResponse>;
+
+/**
+ * @generated from message mitmflow.v1.SendRequestRequest
+ */
+export declare type SendRequestRequest = Message<"mitmflow.v1.SendRequestRequest"> & {
+  /**
+   * Defaults to GET.
+   *
+   * @generated from field: string method = 1;
+   */
+  method: string;
+
+  /**
+   * @generated from field: string url = 2;
+   */
+  url: string;
+
+  /**
+   * @generated from field: map<string, string> headers = 3;
+   */
+  headers: { [key: string]: string };
+
+  /**
+   * @generated from field: bytes body = 4;
+   */
+  body: Uint8Array;
+
+  /**
+   * Optional HTTP proxy URL to send the request through, e.g. the mitmproxy
+   * instance feeding this server.
+   *
+   * @generated from field: string proxy = 5;
+   */
+  proxy: string;
+
+  /**
+   * Defaults to 30 seconds.
+   *
+   * @generated from field: int64 timeout_ms = 6;
+   */
+  timeoutMs: bigint;
+};
+
+/**
+ * Describes the message mitmflow.v1.SendRequestRequest.
+ * Use `create(SendRequestRequestSchema)` to create a new message.
+ */
+export declare const SendRequestRequestSchema: GenMessage<SendRequestRequest>;
+
+/**
+ * @generated from message mitmflow.v1.SendRequestResponse
+ */
+export declare type SendRequestResponse = Message<"mitmflow.v1.SendRequestResponse"> & {
+  /**
+   * The stored flow. Transport errors are recorded on the flow rather than
+   * returned as an RPC error.
+   *
+   * @generated from field: mitmflow.v1.Flow flow = 1;
+   */
+  flow?: Flow;
+};
+
+/**
+ * Describes the message mitmflow.v1.SendRequestResponse.
+ * Use `create(SendRequestResponseSchema)` to create a new message.
+ */
+export declare const SendRequestResponseSchema: GenMessage<SendRequestResponse>;
+
+/**
+ * @generated from message mitmflow.v1.GetCookieTimelineRequest
+ */
+export declare type GetCookieTimelineRequest = Message<"mitmflow.v1.GetCookieTimelineRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * Only flows to this domain or its subdomains, e.g. "example.com". Empty
+   * for every domain.
+   *
+   * @generated from field: string domain = 2;
+   */
+  domain: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.GetCookieTimelineRequest.
+ * Use `create(GetCookieTimelineRequestSchema)` to create a new message.
+ */
+export declare const GetCookieTimelineRequestSchema: GenMessage<GetCookieTimelineRequest>;
+
+/**
+ * @generated from message mitmflow.v1.GetCookieTimelineResponse
+ */
+export declare type GetCookieTimelineResponse = Message<"mitmflow.v1.GetCookieTimelineResponse"> & {
+  /**
+   * Oldest first.
+   *
+   * @generated from field: repeated mitmflow.v1.CookieEvent events = 1;
+   */
+  events: CookieEvent[];
+};
+
+/**
+ * Describes the message mitmflow.v1.GetCookieTimelineResponse.
+ * Use `create(GetCookieTimelineResponseSchema)` to create a new message.
+ */
+export declare const GetCookieTimelineResponseSchema: GenMessage<GetCookieTimelineResponse>;
+
+/**
+ * CookieEvent is one flow that set, sent or deleted a cookie.
+ *
+ * @generated from message mitmflow.v1.CookieEvent
+ */
+export declare type CookieEvent = Message<"mitmflow.v1.CookieEvent"> & {
+  /**
+   * @generated from field: mitmflow.v1.CookieEventType type = 1;
+   */
+  type: CookieEventType;
+
+  /**
+   * @generated from field: string flow_id = 2;
+   */
+  flowId: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp timestamp = 3;
+   */
+  timestamp?: Timestamp;
+
+  /**
+   * The host the request went to.
+   *
+   * @generated from field: string host = 4;
+   */
+  host: string;
+
+  /**
+   * @generated from field: string value = 5;
+   */
+  value: string;
+
+  /**
+   * The attributes of SET and DELETED events.
+   *
+   * @generated from field: string domain = 6;
+   */
+  domain: string;
+
+  /**
+   * @generated from field: string path = 7;
+   */
+  path: string;
+
+  /**
+   * From Expires or Max-Age. Unset for session cookies.
+   *
+   * @generated from field: google.protobuf.Timestamp expires = 8;
+   */
+  expires?: Timestamp;
+
+  /**
+   * @generated from field: bool secure = 9;
+   */
+  secure: boolean;
+
+  /**
+   * @generated from field: bool http_only = 10;
+   */
+  httpOnly: boolean;
+
+  /**
+   * @generated from field: string same_site = 11;
+   */
+  sameSite: string;
+
+  /**
+   * The value differs from the event before.
+   *
+   * @generated from field: bool value_changed = 12;
+   */
+  valueChanged: boolean;
+
+  /**
+   * A SET event whose expiry differs from the cookie's previous SET event.
+   *
+   * @generated from field: bool expiry_changed = 13;
+   */
+  expiryChanged: boolean;
+};
+
+/**
+ * Describes the message mitmflow.v1.CookieEvent.
+ * Use `create(CookieEventSchema)` to create a new message.
+ */
+export declare const CookieEventSchema: GenMessage<CookieEvent>;
+
+/**
+ * @generated from message mitmflow.v1.GetRedirectChainRequest
+ */
+export declare type GetRedirectChainRequest = Message<"mitmflow.v1.GetRedirectChainRequest"> & {
+  /**
+   * @generated from field: string flow_id = 1;
+   */
+  flowId: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.GetRedirectChainRequest.
+ * Use `create(GetRedirectChainRequestSchema)` to create a new message.
+ */
+export declare const GetRedirectChainRequestSchema: GenMessage<GetRedirectChainRequest>;
+
+/**
+ * @generated from message mitmflow.v1.GetRedirectChainResponse
+ */
+export declare type GetRedirectChainResponse = Message<"mitmflow.v1.GetRedirectChainResponse"> & {
+  /**
+   * The chain the flow is part of, from the first request to the final
+   * response. Every hop but the last redirected to the next one. A flow
+   * that isn't part of a chain is its only hop.
+   *
+   * @generated from field: repeated mitmflow.v1.RedirectHop hops = 1;
+   */
+  hops: RedirectHop[];
+};
+
+/**
+ * Describes the message mitmflow.v1.GetRedirectChainResponse.
+ * Use `create(GetRedirectChainResponseSchema)` to create a new message.
+ */
+export declare const GetRedirectChainResponseSchema: GenMessage<GetRedirectChainResponse>;
+
+/**
+ * @generated from message mitmflow.v1.RedirectHop
+ */
+export declare type RedirectHop = Message<"mitmflow.v1.RedirectHop"> & {
+  /**
+   * @generated from field: string flow_id = 1;
+   */
+  flowId: string;
+
+  /**
+   * @generated from field: string method = 2;
+   */
+  method: string;
+
+  /**
+   * @generated from field: string url = 3;
+   */
+  url: string;
+
+  /**
+   * Unset while the flow has no response.
+   *
+   * @generated from field: int32 status_code = 4;
+   */
+  statusCode: number;
+
+  /**
+   * The Location header of redirects, resolved against the request URL.
+   *
+   * @generated from field: string location = 5;
+   */
+  location: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.RedirectHop.
+ * Use `create(RedirectHopSchema)` to create a new message.
+ */
+export declare const RedirectHopSchema: GenMessage<RedirectHop>;
+
+/**
+ * @generated from message mitmflow.v1.DiffWithPreviousRequest
+ */
+export declare type DiffWithPreviousRequest = Message<"mitmflow.v1.DiffWithPreviousRequest"> & {
+  /**
+   * @generated from field: string flow_id = 1;
+   */
+  flowId: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.DiffWithPreviousRequest.
+ * Use `create(DiffWithPreviousRequestSchema)` to create a new message.
+ */
+export declare const DiffWithPreviousRequestSchema: GenMessage<DiffWithPreviousRequest>;
+
+/**
+ * @generated from message mitmflow.v1.DiffWithPreviousResponse
+ */
+export declare type DiffWithPreviousResponse = Message<"mitmflow.v1.DiffWithPreviousResponse"> & {
+  /**
+   * The flow compared with. Unset when no earlier flow has the same method
+   * and path, and the differences are then empty.
+   *
+   * @generated from field: mitmflow.v1.FlowSummary previous = 1;
+   */
+  previous?: FlowSummary;
+
+  /**
+   * @generated from field: repeated mitmflow.v1.FlowDifference request = 2;
+   */
+  request: FlowDifference[];
+
+  /**
+   * @generated from field: repeated mitmflow.v1.FlowDifference response = 3;
+   */
+  response: FlowDifference[];
+};
+
+/**
+ * Describes the message mitmflow.v1.DiffWithPreviousResponse.
+ * Use `create(DiffWithPreviousResponseSchema)` to create a new message.
+ */
+export declare const DiffWithPreviousResponseSchema: GenMessage<DiffWithPreviousResponse>;
+
+/**
+ * @generated from message mitmflow.v1.TopFlowsRequest
+ */
+export declare type TopFlowsRequest = Message<"mitmflow.v1.TopFlowsRequest"> & {
+  /**
+   * @generated from field: mitmflow.v1.FlowFilter filter = 1;
+   */
+  filter?: FlowFilter;
+
+  /**
+   * A filter expression such as "~d example.com & ~c 500".
+   *
+   * @generated from field: string filter_expr = 2;
+   */
+  filterExpr: string;
+
+  /**
+   * @generated from field: mitmflow.v1.TopFlowsOrder order = 3;
+   */
+  order: TopFlowsOrder;
+
+  /**
+   * How many flows to return. Defaults to 10.
+   *
+   * @generated from field: int32 limit = 4;
+   */
+  limit: number;
+};
+
+/**
+ * Describes the message mitmflow.v1.TopFlowsRequest.
+ * Use `create(TopFlowsRequestSchema)` to create a new message.
+ */
+export declare const TopFlowsRequestSchema: GenMessage<TopFlowsRequest>;
+
+/**
+ * @generated from message mitmflow.v1.TopFlowsResponse
+ */
+export declare type TopFlowsResponse = Message<"mitmflow.v1.TopFlowsResponse"> & {
+  /**
+   * Worst first. Flows that tie keep the newest first.
+   *
+   * @generated from field: repeated mitmflow.v1.FlowSummary flows = 1;
+   */
+  flows: FlowSummary[];
+};
+
+/**
+ * Describes the message mitmflow.v1.TopFlowsResponse.
+ * Use `create(TopFlowsResponseSchema)` to create a new message.
+ */
+export declare const TopFlowsResponseSchema: GenMessage<TopFlowsResponse>;
+
+/**
+ * @generated from message mitmflow.v1.GetBandwidthStatsRequest
+ */
+export declare type GetBandwidthStatsRequest = Message<"mitmflow.v1.GetBandwidthStatsRequest"> & {
+  /**
+   * @generated from field: mitmflow.v1.FlowFilter filter = 1;
+   */
+  filter?: FlowFilter;
+
+  /**
+   * A filter expression such as "~d example.com & ~c 500".
+   *
+   * @generated from field: string filter_expr = 2;
+   */
+  filterExpr: string;
+
+  /**
+   * Only flows that started in this range.
+   *
+   * @generated from field: google.protobuf.Timestamp start_time = 3;
+   */
+  startTime?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp end_time = 4;
+   */
+  endTime?: Timestamp;
+
+  /**
+   * The width of the time buckets. Defaults to 60 seconds.
+   *
+   * @generated from field: int32 bucket_seconds = 5;
+   */
+  bucketSeconds: number;
+
+  /**
+   * How many hosts and clients to return, the busiest first. Defaults to 50.
+   *
+   * @generated from field: int32 limit = 6;
+   */
+  limit: number;
+};
+
+/**
+ * Describes the message mitmflow.v1.GetBandwidthStatsRequest.
+ * Use `create(GetBandwidthStatsRequestSchema)` to create a new message.
+ */
+export declare const GetBandwidthStatsRequestSchema: GenMessage<GetBandwidthStatsRequest>;
+
+/**
+ * @generated from message mitmflow.v1.GetBandwidthStatsResponse
+ */
+export declare type GetBandwidthStatsResponse = Message<"mitmflow.v1.GetBandwidthStatsResponse"> & {
+  /**
+   * Per server host: the request's host for HTTP, otherwise the server's
+   * hostname when known, or its address.
+   *
+   * @generated from field: repeated mitmflow.v1.BandwidthUsage hosts = 1;
+   */
+  hosts: BandwidthUsage[];
+
+  /**
+   * Per client IP.
+   *
+   * @generated from field: repeated mitmflow.v1.BandwidthUsage clients = 2;
+   */
+  clients: BandwidthUsage[];
+
+  /**
+   * All matching flows together, with an empty key.
+   *
+   * @generated from field: mitmflow.v1.BandwidthUsage total = 3;
+   */
+  total?: BandwidthUsage;
+};
+
+/**
+ * Describes the message mitmflow.v1.GetBandwidthStatsResponse.
+ * Use `create(GetBandwidthStatsResponseSchema)` to create a new message.
+ */
+export declare const GetBandwidthStatsResponseSchema: GenMessage<GetBandwidthStatsResponse>;
+
+/**
+ * BandwidthUsage counts bytes as FlowTotals does: sent by the client and
+ * received from the server. Flows count towards the bucket they started in.
+ *
+ * @generated from message mitmflow.v1.BandwidthUsage
+ */
+export declare type BandwidthUsage = Message<"mitmflow.v1.BandwidthUsage"> & {
+  /**
+   * @generated from field: string key = 1;
+   */
+  key: string;
+
+  /**
+   * @generated from field: int64 bytes_sent = 2;
+   */
+  bytesSent: bigint;
+
+  /**
+   * @generated from field: int64 bytes_received = 3;
+   */
+  bytesReceived: bigint;
+
+  /**
+   * @generated from field: int64 flows = 4;
+   */
+  flows: bigint;
+
+  /**
+   * Oldest first, leaving out buckets without flows.
+   *
+   * @generated from field: repeated mitmflow.v1.BandwidthBucket buckets = 5;
+   */
+  buckets: BandwidthBucket[];
+};
+
+/**
+ * Describes the message mitmflow.v1.BandwidthUsage.
+ * Use `create(BandwidthUsageSchema)` to create a new message.
+ */
+export declare const BandwidthUsageSchema: GenMessage<BandwidthUsage>;
+
+/**
+ * @generated from message mitmflow.v1.BandwidthBucket
+ */
+export declare type BandwidthBucket = Message<"mitmflow.v1.BandwidthBucket"> & {
+  /**
+   * @generated from field: google.protobuf.Timestamp start = 1;
+   */
+  start?: Timestamp;
+
+  /**
+   * @generated from field: int64 bytes_sent = 2;
+   */
+  bytesSent: bigint;
+
+  /**
+   * @generated from field: int64 bytes_received = 3;
+   */
+  bytesReceived: bigint;
+
+  /**
+   * @generated from field: int64 flows = 4;
+   */
+  flows: bigint;
+};
+
+/**
+ * Describes the message mitmflow.v1.BandwidthBucket.
+ * Use `create(BandwidthBucketSchema)` to create a new message.
+ */
+export declare const BandwidthBucketSchema: GenMessage<BandwidthBucket>;
+
+/**
+ * FlowDifference is a status code, query parameter, header or body that
+ * differs between two flows. JSON bodies are compared field by field, up to
+ * 1000 differences.
+ *
+ * @generated from message mitmflow.v1.FlowDifference
+ */
+export declare type FlowDifference = Message<"mitmflow.v1.FlowDifference"> & {
+  /**
+   * @generated from field: mitmflow.v1.FlowDifferenceKind kind = 1;
+   */
+  kind: FlowDifferenceKind;
+
+  /**
+   * The query parameter or header name, or the path of a JSON body field such
+   * as "$.items[0].id". Empty for the status code and other bodies.
+   *
+   * @generated from field: string field = 2;
+   */
+  field: string;
+
+  /**
+   * The values in the previous and the selected flow. Empty when missing.
+   * Bodies that aren't JSON are described by their size and SHA-256.
+   *
+   * @generated from field: string previous = 3;
+   */
+  previous: string;
+
+  /**
+   * @generated from field: string current = 4;
+   */
+  current: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.FlowDifference.
+ * Use `create(FlowDifferenceSchema)` to create a new message.
+ */
+export declare const FlowDifferenceSchema: GenMessage<FlowDifference>;
+
+/**
+ * FlowSet is the bundle format used to move flows between instances.
+ *
+ * @generated from message mitmflow.v1.FlowSet
+ */
+export declare type FlowSet = Message<"mitmflow.v1.FlowSet"> & {
+  /**
+   * @generated from field: repeated mitmflow.v1.Flow flows = 1;
+   */
+  flows: Flow[];
+};
+
+/**
+ * Describes the message mitmflow.v1.FlowSet.
+ * Use `create(FlowSetSchema)` to create a new message.
+ */
+export declare const FlowSetSchema: GenMessage<FlowSet>;
+
+/**
+ * @generated from message mitmflow.v1.FlowSummary
+ */
+export declare type FlowSummary = Message<"mitmflow.v1.FlowSummary"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * "http", "dns", "tcp", "udp"
+   *
+   * @generated from field: string type = 2;
+   */
+  type: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp timestamp_start = 3;
+   */
+  timestampStart?: Timestamp;
+
+  /**
+   * @generated from field: bool pinned = 4;
+   */
+  pinned: boolean;
+
+  /**
+   * @generated from field: string note = 5;
+   */
+  note: string;
+
+  /**
+   * @generated from oneof mitmflow.v1.FlowSummary.summary
+   */
+  summary: {
+    /**
+     * @generated from field: mitmflow.v1.HttpFlowSummary http = 6;
+     */
+    value: HttpFlowSummary;
+    case: "http";
+  } | {
+    /**
+     * @generated from field: mitmflow.v1.DnsFlowSummary dns = 7;
+     */
+    value: DnsFlowSummary;
+    case: "dns";
+  } | {
+    /**
+     * @generated from field: mitmflow.v1.TcpFlowSummary tcp = 8;
+     */
+    value: TcpFlowSummary;
+    case: "tcp";
+  } | {
+    /**
+     * @generated from field: mitmflow.v1.UdpFlowSummary udp = 9;
+     */
+    value: UdpFlowSummary;
+    case: "udp";
+  } | { case: undefined; value?: undefined };
+
+  /**
+   * The change sequence of the flow's last change.
+   *
+   * @generated from field: uint64 sequence = 10;
+   */
+  sequence: bigint;
+
+  /**
+   * @generated from field: string owner = 11;
+   */
+  owner: string;
+
+  /**
+   * @generated from field: bool private = 12;
+   */
+  private: boolean;
+
+  /**
+   * Normalized protocol label, see HTTPFlowExtra.protocol.
+   *
+   * @generated from field: string protocol = 13;
+   */
+  protocol: string;
+
+  /**
+   * @generated from field: mitmflow.v1.FlowTotals totals = 14;
+   */
+  totals?: FlowTotals;
+};
+
+/**
+ * Describes the message mitmflow.v1.FlowSummary.
+ * Use `create(FlowSummarySchema)` to create a new message.
+ */
+export declare const FlowSummarySchema: GenMessage<FlowSummary>;
+
+/**
+ * @generated from message mitmflow.v1.HttpFlowSummary
+ */
+export declare type HttpFlowSummary = Message<"mitmflow.v1.HttpFlowSummary"> & {
+  /**
+   * @generated from field: string method = 1;
+   */
+  method: string;
 
   /**
    * @generated from field: string url = 2;
    */
-  url: string;
+  url: string;
+
+  /**
+   * @generated from field: int32 status_code = 3;
+   */
+  statusCode: number;
+
+  /**
+   * @generated from field: int64 duration_ms = 4;
+   */
+  durationMs: bigint;
+
+  /**
+   * @generated from field: int64 request_content_length = 5;
+   */
+  requestContentLength: bigint;
+
+  /**
+   * @generated from field: int64 response_content_length = 6;
+   */
+  responseContentLength: bigint;
+
+  /**
+   * @generated from field: string client_peername_host = 7;
+   */
+  clientPeernameHost: string;
+
+  /**
+   * @generated from field: string server_address_host = 8;
+   */
+  serverAddressHost: string;
+
+  /**
+   * @generated from field: uint32 server_address_port = 9;
+   */
+  serverAddressPort: number;
+
+  /**
+   * @generated from field: uint32 client_peername_port = 10;
+   */
+  clientPeernamePort: number;
+
+  /**
+   * The SHA-256 of the response body, in hex.
+   *
+   * @generated from field: string response_sha256 = 11;
+   */
+  responseSha256: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.HttpFlowSummary.
+ * Use `create(HttpFlowSummarySchema)` to create a new message.
+ */
+export declare const HttpFlowSummarySchema: GenMessage<HttpFlowSummary>;
+
+/**
+ * @generated from message mitmflow.v1.DnsFlowSummary
+ */
+export declare type DnsFlowSummary = Message<"mitmflow.v1.DnsFlowSummary"> & {
+  /**
+   * @generated from field: string question_name = 1;
+   */
+  questionName: string;
+
+  /**
+   * @generated from field: string client_peername_host = 2;
+   */
+  clientPeernameHost: string;
+
+  /**
+   * @generated from field: string error = 3;
+   */
+  error: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.DnsFlowSummary.
+ * Use `create(DnsFlowSummarySchema)` to create a new message.
+ */
+export declare const DnsFlowSummarySchema: GenMessage<DnsFlowSummary>;
+
+/**
+ * @generated from message mitmflow.v1.TcpFlowSummary
+ */
+export declare type TcpFlowSummary = Message<"mitmflow.v1.TcpFlowSummary"> & {
+  /**
+   * @generated from field: string server_address_host = 1;
+   */
+  serverAddressHost: string;
+
+  /**
+   * @generated from field: uint32 server_address_port = 2;
+   */
+  serverAddressPort: number;
+
+  /**
+   * @generated from field: string client_peername_host = 3;
+   */
+  clientPeernameHost: string;
+
+  /**
+   * @generated from field: uint32 client_peername_port = 4;
+   */
+  clientPeernamePort: number;
+
+  /**
+   * @generated from field: string error = 5;
+   */
+  error: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.TcpFlowSummary.
+ * Use `create(TcpFlowSummarySchema)` to create a new message.
+ */
+export declare const TcpFlowSummarySchema: GenMessage<TcpFlowSummary>;
+
+/**
+ * @generated from message mitmflow.v1.UdpFlowSummary
+ */
+export declare type UdpFlowSummary = Message<"mitmflow.v1.UdpFlowSummary"> & {
+  /**
+   * @generated from field: string server_address_host = 1;
+   */
+  serverAddressHost: string;
+
+  /**
+   * @generated from field: uint32 server_address_port = 2;
+   */
+  serverAddressPort: number;
+
+  /**
+   * @generated from field: string client_peername_host = 3;
+   */
+  clientPeernameHost: string;
+
+  /**
+   * @generated from field: uint32 client_peername_port = 4;
+   */
+  clientPeernamePort: number;
+
+  /**
+   * @generated from field: string error = 5;
+   */
+  error: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.UdpFlowSummary.
+ * Use `create(UdpFlowSummarySchema)` to create a new message.
+ */
+export declare const UdpFlowSummarySchema: GenMessage<UdpFlowSummary>;
+
+/**
+ * @generated from message mitmflow.v1.Flow
+ */
+export declare type Flow = Message<"mitmflow.v1.Flow"> & {
+  /**
+   * @generated from oneof mitmflow.v1.Flow.flow
+   */
+  flow: {
+    /**
+     * @generated from field: mitmproxy.v1.HTTPFlow http_flow = 1;
+     */
+    value: HTTPFlow;
+    case: "httpFlow";
+  } | {
+    /**
+     * @generated from field: mitmproxy.v1.TCPFlow tcp_flow = 2;
+     */
+    value: TCPFlow;
+    case: "tcpFlow";
+  } | {
+    /**
+     * @generated from field: mitmproxy.v1.UDPFlow udp_flow = 3;
+     */
+    value: UDPFlow;
+    case: "udpFlow";
+  } | {
+    /**
+     * @generated from field: mitmproxy.v1.DNSFlow dns_flow = 4;
+     */
+    value: DNSFlow;
+    case: "dnsFlow";
+  } | { case: undefined; value?: undefined };
+
+  /**
+   * @generated from field: mitmflow.v1.HTTPFlowExtra http_flow_extra = 5;
+   */
+  httpFlowExtra?: HTTPFlowExtra;
+
+  /**
+   * @generated from field: bool pinned = 6;
+   */
+  pinned: boolean;
+
+  /**
+   * @generated from field: string note = 7;
+   */
+  note: string;
+
+  /**
+   * @generated from field: mitmflow.v1.StreamFlowExtra stream_flow_extra = 8;
+   */
+  streamFlowExtra?: StreamFlowExtra;
+
+  /**
+   * Key/value annotations set through UpdateFlow, e.g. by tooling that stamps
+   * flows with a build number or test case ID.
+   *
+   * @generated from field: map<string, string> metadata = 9;
+   */
+  metadata: { [key: string]: string };
+
+  /**
+   * Each user's own pin and note, keyed by user name, when the server requires
+   * authentication. pinned and note above are shared by the whole team. Only
+   * the caller's annotation is returned, merged into pinned and note.
+   *
+   * @generated from field: map<string, mitmflow.v1.Annotation> user_annotations = 10;
+   */
+  userAnnotations: { [key: string]: Annotation };
+
+  /**
+   * The user or token that captured, imported or sent the flow, when the
+   * server requires authentication.
+   *
+   * @generated from field: string owner = 11;
+   */
+  owner: string;
+
+  /**
+   * Only the owner and admins can see a private flow.
+   *
+   * @generated from field: bool private = 12;
+   */
+  private: boolean;
+
+  /**
+   * @generated from field: mitmflow.v1.DnsFlowExtra dns_flow_extra = 13;
+   */
+  dnsFlowExtra?: DnsFlowExtra;
+};
+
+/**
+ * Describes the message mitmflow.v1.Flow.
+ * Use `create(FlowSchema)` to create a new message.
+ */
+export declare const FlowSchema: GenMessage<Flow>;
+
+/**
+ * @generated from message mitmflow.v1.Annotation
+ */
+export declare type Annotation = Message<"mitmflow.v1.Annotation"> & {
+  /**
+   * @generated from field: bool pinned = 1;
+   */
+  pinned: boolean;
+
+  /**
+   * @generated from field: string note = 2;
+   */
+  note: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.Annotation.
+ * Use `create(AnnotationSchema)` to create a new message.
+ */
+export declare const AnnotationSchema: GenMessage<Annotation>;
+
+/**
+ * @generated from message mitmflow.v1.HTTPFlowExtra
+ */
+export declare type HTTPFlowExtra = Message<"mitmflow.v1.HTTPFlowExtra"> & {
+  /**
+   * @generated from field: mitmflow.v1.MessageDetails request = 1;
+   */
+  request?: MessageDetails;
+
+  /**
+   * @generated from field: mitmflow.v1.MessageDetails response = 2;
+   */
+  response?: MessageDetails;
+
+  /**
+   * Parsed from the request's User-Agent header, when there is one.
+   *
+   * @generated from field: mitmflow.v1.UserAgent user_agent = 3;
+   */
+  userAgent?: UserAgent;
+
+  /**
+   * @generated from field: mitmflow.v1.GeoInfo server_geo = 4;
+   */
+  serverGeo?: GeoInfo;
+
+  /**
+   * Best guess at the server's hostname when the request only addressed an
+   * IP, e.g. in transparent mode without a Host header.
+   *
+   * @generated from field: string server_hostname = 5;
+   */
+  serverHostname: string;
+
+  /**
+   * @generated from field: mitmflow.v1.HostnameSource server_hostname_source = 6;
+   */
+  serverHostnameSource: HostnameSource;
+
+  /**
+   * Missing or weak security headers in the response.
+   *
+   * @generated from field: repeated mitmflow.v1.SecurityFinding security_findings = 7;
+   */
+  securityFindings: SecurityFinding[];
+
+  /**
+   * Set on cross-origin requests and CORS preflights.
+   *
+   * @generated from field: mitmflow.v1.CorsCheck cors = 8;
+   */
+  cors?: CorsCheck;
+
+  /**
+   * How the response compares with the baseline response for the same method
+   * and path, when a baseline is set and has one.
+   *
+   * @generated from field: mitmflow.v1.BaselineComparison baseline = 9;
+   */
+  baseline?: BaselineComparison;
+
+  /**
+   * Set on requests for a playlist, segment, key or init segment that an
+   * earlier HLS or DASH manifest listed: the ID of that manifest's flow.
+   *
+   * @generated from field: string manifest_flow_id = 10;
+   */
+  manifestFlowId: string;
+
+  /**
+   * Decoded WebSocket messages, index for index, when the connection speaks
+   * a subprotocol mitmflow can decode, like MQTT.
+   *
+   * @generated from field: repeated mitmflow.v1.MessageDetails websocket_messages = 11;
+   */
+  websocketMessages: MessageDetails[];
+
+  /**
+   * Set on HTTP/2 and HTTP/3 flows.
+   *
+   * @generated from field: mitmflow.v1.HTTP2Details http2 = 12;
+   */
+  http2?: HTTP2Details;
+
+  /**
+   * 1xx responses that came before the final response, like 103 Early Hints,
+   * in the order they arrived. mitmproxy doesn't send them; they're recorded
+   * for requests sent by mitmflow.
+   *
+   * @generated from field: repeated mitmflow.v1.InterimResponse interim_responses = 13;
+   */
+  interimResponses: InterimResponse[];
+
+  /**
+   * Normalized protocol label computed at ingest: "http1", "http2", "http3",
+   * "grpc", "grpc-web", "connect", "websocket" or "dns-over-https".
+   *
+   * @generated from field: string protocol = 14;
+   */
+  protocol: string;
+
+  /**
+   * @generated from field: mitmflow.v1.FlowTotals totals = 15;
+   */
+  totals?: FlowTotals;
+};
+
+/**
+ * Describes the message mitmflow.v1.HTTPFlowExtra.
+ * Use `create(HTTPFlowExtraSchema)` to create a new message.
+ */
+export declare const HTTPFlowExtraSchema: GenMessage<HTTPFlowExtra>;
+
+/**
+ * FlowTotals holds the sizes and duration of a flow, computed at ingest so
+ * filters, stats and list views don't derive them from bodies and timestamps.
+ *
+ * @generated from message mitmflow.v1.FlowTotals
+ */
+export declare type FlowTotals = Message<"mitmflow.v1.FlowTotals"> & {
+  /**
+   * Bytes sent by the client: for HTTP the request line, headers, body and
+   * trailers as HTTP/1 would send them, with the body at its original size;
+   * for TCP and UDP the client's messages; for DNS the query.
+   *
+   * @generated from field: int64 request_bytes = 1;
+   */
+  requestBytes: bigint;
+
+  /**
+   * Bytes sent by the server, counted the same way.
+   *
+   * @generated from field: int64 response_bytes = 2;
+   */
+  responseBytes: bigint;
+
+  /**
+   * From the start of the flow to the end of its response or last message.
+   *
+   * @generated from field: int64 duration_ms = 3;
+   */
+  durationMs: bigint;
+};
+
+/**
+ * Describes the message mitmflow.v1.FlowTotals.
+ * Use `create(FlowTotalsSchema)` to create a new message.
+ */
+export declare const FlowTotalsSchema: GenMessage<FlowTotals>;
+
+/**
+ * @generated from message mitmflow.v1.InterimResponse
+ */
+export declare type InterimResponse = Message<"mitmflow.v1.InterimResponse"> & {
+  /**
+   * @generated from field: int32 status_code = 1;
+   */
+  statusCode: number;
+
+  /**
+   * @generated from field: map<string, string> headers = 2;
+   */
+  headers: { [key: string]: string };
+
+  /**
+   * @generated from field: google.protobuf.Timestamp timestamp = 3;
+   */
+  timestamp?: Timestamp;
+};
+
+/**
+ * Describes the message mitmflow.v1.InterimResponse.
+ * Use `create(InterimResponseSchema)` to create a new message.
+ */
+export declare const InterimResponseSchema: GenMessage<InterimResponse>;
+
+/**
+ * HTTP2Details holds the parts of an HTTP/2 or HTTP/3 exchange that its
+ * HTTP/1 style request and response don't show. mitmproxy doesn't send stream
+ * IDs or whether a stream was pushed, so those aren't known.
+ *
+ * @generated from message mitmflow.v1.HTTP2Details
+ */
+export declare type HTTP2Details = Message<"mitmflow.v1.HTTP2Details"> & {
+  /**
+   * :method, :scheme, :authority and :path, in the order they were sent when
+   * the capture records them.
+   *
+   * @generated from field: repeated mitmflow.v1.HeaderField request_pseudo_headers = 1;
+   */
+  requestPseudoHeaders: HeaderField[];
+
+  /**
+   * :status.
+   *
+   * @generated from field: repeated mitmflow.v1.HeaderField response_pseudo_headers = 2;
+   */
+  responsePseudoHeaders: HeaderField[];
+
+  /**
+   * Header names in the order HPACK or QPACK encoded them, repeats included.
+   * Only imported captures that keep headers as a list, like mitmproxy dumps
+   * and HAR files, record the order.
+   *
+   * @generated from field: repeated string request_header_order = 3;
+   */
+  requestHeaderOrder: string[];
+
+  /**
+   * @generated from field: repeated string response_header_order = 4;
+   */
+  responseHeaderOrder: string[];
+};
+
+/**
+ * Describes the message mitmflow.v1.HTTP2Details.
+ * Use `create(HTTP2DetailsSchema)` to create a new message.
+ */
+export declare const HTTP2DetailsSchema: GenMessage<HTTP2Details>;
+
+/**
+ * @generated from message mitmflow.v1.HeaderField
+ */
+export declare type HeaderField = Message<"mitmflow.v1.HeaderField"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string value = 2;
+   */
+  value: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.HeaderField.
+ * Use `create(HeaderFieldSchema)` to create a new message.
+ */
+export declare const HeaderFieldSchema: GenMessage<HeaderField>;
+
+/**
+ * CorsCheck compares a cross-origin request with the Access-Control-Allow-*
+ * headers that the browser would check it against.
+ *
+ * @generated from message mitmflow.v1.CorsCheck
+ */
+export declare type CorsCheck = Message<"mitmflow.v1.CorsCheck"> & {
+  /**
+   * The Origin header of the request.
+   *
+   * @generated from field: string origin = 1;
+   */
+  origin: string;
+
+  /**
+   * Whether the flow is an OPTIONS preflight rather than the actual request.
+   *
+   * @generated from field: bool preflight = 2;
+   */
+  preflight: boolean;
+
+  /**
+   * For actual requests, the captured preflight that was sent for them.
+   *
+   * @generated from field: string preflight_flow_id = 3;
+   */
+  preflightFlowId: string;
+
+  /**
+   * Why the browser would block the request or its response, e.g.
+   * "Access-Control-Allow-Origin is missing". Empty when CORS allows it.
+   *
+   * @generated from field: repeated string problems = 4;
+   */
+  problems: string[];
+};
+
+/**
+ * Describes the message mitmflow.v1.CorsCheck.
+ * Use `create(CorsCheckSchema)` to create a new message.
+ */
+export declare const CorsCheckSchema: GenMessage<CorsCheck>;
+
+/**
+ * @generated from message mitmflow.v1.SecurityFinding
+ */
+export declare type SecurityFinding = Message<"mitmflow.v1.SecurityFinding"> & {
+  /**
+   * The header the finding is about, e.g. "Strict-Transport-Security" or
+   * "Set-Cookie".
+   *
+   * @generated from field: string header = 1;
+   */
+  header: string;
+
+  /**
+   * @generated from field: mitmflow.v1.FindingSeverity severity = 2;
+   */
+  severity: FindingSeverity;
+
+  /**
+   * What is wrong, e.g. "missing" or "cookie sid: missing Secure".
+   *
+   * @generated from field: string message = 3;
+   */
+  message: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.SecurityFinding.
+ * Use `create(SecurityFindingSchema)` to create a new message.
+ */
+export declare const SecurityFindingSchema: GenMessage<SecurityFinding>;
+
+/**
+ * GeoInfo locates a public IP address using the GeoIP databases the server
+ * was started with. Fields the databases don't cover are left empty.
+ *
+ * @generated from message mitmflow.v1.GeoInfo
+ */
+export declare type GeoInfo = Message<"mitmflow.v1.GeoInfo"> & {
+  /**
+   * ISO 3166-1 alpha-2 code, e.g. "DE".
+   *
+   * @generated from field: string country_code = 1;
+   */
+  countryCode: string;
+
+  /**
+   * @generated from field: string country_name = 2;
+   */
+  countryName: string;
+
+  /**
+   * @generated from field: uint32 asn = 3;
+   */
+  asn: number;
+
+  /**
+   * @generated from field: string as_organization = 4;
+   */
+  asOrganization: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.GeoInfo.
+ * Use `create(GeoInfoSchema)` to create a new message.
+ */
+export declare const GeoInfoSchema: GenMessage<GeoInfo>;
+
+/**
+ * @generated from message mitmflow.v1.UserAgent
+ */
+export declare type UserAgent = Message<"mitmflow.v1.UserAgent"> & {
+  /**
+   * @generated from field: string browser = 1;
+   */
+  browser: string;
+
+  /**
+   * @generated from field: string browser_version = 2;
+   */
+  browserVersion: string;
+
+  /**
+   * @generated from field: string os = 3;
+   */
+  os: string;
+
+  /**
+   * @generated from field: string os_version = 4;
+   */
+  osVersion: string;
+
+  /**
+   * The device model, when the User-Agent names one, e.g. "iPhone".
+   *
+   * @generated from field: string device = 5;
+   */
+  device: string;
+
+  /**
+   * @generated from field: mitmflow.v1.DeviceType device_type = 6;
+   */
+  deviceType: DeviceType;
+};
+
+/**
+ * Describes the message mitmflow.v1.UserAgent.
+ * Use `create(UserAgentSchema)` to create a new message.
+ */
+export declare const UserAgentSchema: GenMessage<UserAgent>;
+
+/**
+ * StreamFlowExtra holds details for the messages of a TCP or UDP flow, in the
+ * same order as the flow's messages.
+ *
+ * @generated from message mitmflow.v1.StreamFlowExtra
+ */
+export declare type StreamFlowExtra = Message<"mitmflow.v1.StreamFlowExtra"> & {
+  /**
+   * @generated from field: repeated mitmflow.v1.MessageDetails messages = 1;
+   */
+  messages: MessageDetails[];
+
+  /**
+   * @generated from field: mitmflow.v1.GeoInfo server_geo = 2;
+   */
+  serverGeo?: GeoInfo;
+
+  /**
+   * Best guess at the server's hostname when the flow only addressed an IP.
+   *
+   * @generated from field: string server_hostname = 3;
+   */
+  serverHostname: string;
+
+  /**
+   * @generated from field: mitmflow.v1.HostnameSource server_hostname_source = 4;
+   */
+  serverHostnameSource: HostnameSource;
+
+  /**
+   * Normalized protocol label, e.g. "tcp-postgresql", "dns-over-tcp", or
+   * plain "tcp" and "udp" when the protocol isn't recognized.
+   *
+   * @generated from field: string protocol = 5;
+   */
+  protocol: string;
+
+  /**
+   * @generated from field: mitmflow.v1.FlowTotals totals = 6;
+   */
+  totals?: FlowTotals;
+};
+
+/**
+ * Describes the message mitmflow.v1.StreamFlowExtra.
+ * Use `create(StreamFlowExtraSchema)` to create a new message.
+ */
+export declare const StreamFlowExtraSchema: GenMessage<StreamFlowExtra>;
+
+/**
+ * DnsFlowExtra holds what was found out about a DNS flow.
+ *
+ * @generated from message mitmflow.v1.DnsFlowExtra
+ */
+export declare type DnsFlowExtra = Message<"mitmflow.v1.DnsFlowExtra"> & {
+  /**
+   * Signs of tunneling, exfiltration or a misbehaving client, for triage.
+   *
+   * @generated from field: repeated mitmflow.v1.DnsAnomaly anomalies = 1;
+   */
+  anomalies: DnsAnomaly[];
+
+  /**
+   * Normalized protocol label, always "dns".
+   *
+   * @generated from field: string protocol = 2;
+   */
+  protocol: string;
+
+  /**
+   * @generated from field: mitmflow.v1.FlowTotals totals = 3;
+   */
+  totals?: FlowTotals;
+};
+
+/**
+ * Describes the message mitmflow.v1.DnsFlowExtra.
+ * Use `create(DnsFlowExtraSchema)` to create a new message.
+ */
+export declare const DnsFlowExtraSchema: GenMessage<DnsFlowExtra>;
+
+/**
+ * @generated from message mitmflow.v1.DnsAnomaly
+ */
+export declare type DnsAnomaly = Message<"mitmflow.v1.DnsAnomaly"> & {
+  /**
+   * @generated from field: mitmflow.v1.DnsAnomalyKind kind = 1;
+   */
+  kind: DnsAnomalyKind;
+
+  /**
+   * What was seen, e.g. "label of 58 characters".
+   *
+   * @generated from field: string detail = 2;
+   */
+  detail: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.DnsAnomaly.
+ * Use `create(DnsAnomalySchema)` to create a new message.
+ */
+export declare const DnsAnomalySchema: GenMessage<DnsAnomaly>;
+
+/**
+ * @generated from message mitmflow.v1.MessageDetails
+ */
+export declare type MessageDetails = Message<"mitmflow.v1.MessageDetails"> & {
+  /**
+   * @generated from field: repeated string textual_frames = 1;
+   */
+  textualFrames: string[];
+
+  /**
+   * @generated from field: string effective_content_type = 2;
+   */
+  effectiveContentType: string;
+
+  /**
+   * Size of the body as received, before any truncation or offloading.
+   *
+   * @generated from field: int64 body_size = 3;
+   */
+  bodySize: bigint;
+
+  /**
+   * Set when the body was moved out of the flow into the blob store. This is
+   * the hex encoded SHA-256 of the body.
+   *
+   * @generated from field: string blob_key = 4;
+   */
+  blobKey: string;
+
+  /**
+   * Set when the body was cut down to the configured maximum body size at ingest.
+   *
+   * @generated from field: bool truncated = 5;
+   */
+  truncated: boolean;
+
+  /**
+   * When each of textual_frames was received, in Unix nanoseconds, by index.
+   * Only known for streaming bodies that mitmproxy sent incrementally while
+   * the flow was live; frames whose arrival time is unknown are 0.
+   *
+   * @generated from field: repeated int64 frame_timestamps_ns = 6;
+   */
+  frameTimestampsNs: bigint[];
+
+  /**
+   * The hex encoded SHA-256 of the body as received, before any truncation.
+   * Empty when there is no body.
+   *
+   * @generated from field: string sha256 = 7;
+   */
+  sha256: string;
+
+  /**
+   * Set when the body is a SOAP envelope.
+   *
+   * @generated from field: mitmflow.v1.SoapMessage soap = 8;
+   */
+  soap?: SoapMessage;
+
+  /**
+   * The number of records in an NDJSON body or a streamed JSON array, when
+   * textual_frames holds one record per frame. Records past the frame limit
+   * are counted but not rendered.
+   *
+   * @generated from field: int32 record_count = 9;
+   */
+  recordCount: number;
+
+  /**
+   * The decoded fields of an application/x-www-form-urlencoded body, in the
+   * order they were sent.
+   *
+   * @generated from field: repeated mitmflow.v1.FormField form_fields = 10;
+   */
+  formFields: FormField[];
+
+  /**
+   * Set for image, video and audio responses.
+   *
+   * @generated from field: mitmflow.v1.MediaInfo media = 11;
+   */
+  media?: MediaInfo;
+
+  /**
+   * The Content-Type header, lowercased. Empty when there is none.
+   *
+   * @generated from field: string declared_content_type = 12;
+   */
+  declaredContentType: string;
+
+  /**
+   * The content type sniffed from the body. Which of the declared and
+   * detected types becomes effective_content_type depends on the server's
+   * content type rules.
+   *
+   * @generated from field: string detected_content_type = 13;
+   */
+  detectedContentType: string;
+
+  /**
+   * Set on gRPC and gRPC-Web responses that carry a grpc-status.
+   *
+   * @generated from field: mitmflow.v1.GrpcStatus grpc_status = 14;
+   */
+  grpcStatus?: GrpcStatus;
+
+  /**
+   * The number of textual frames the message has. GetFlow only returns the
+   * first page of frames of a request or response; when this is larger than
+   * len(textual_frames), GetFlowFrames returns the rest.
+   *
+   * @generated from field: int32 frame_count = 15;
+   */
+  frameCount: number;
+
+  /**
+   * The headers and trailers whose name ends in -bin, decoded, by key.
+   *
+   * @generated from field: repeated mitmflow.v1.BinaryMetadata binary_metadata = 16;
+   */
+  binaryMetadata: BinaryMetadata[];
+};
+
+/**
+ * Describes the message mitmflow.v1.MessageDetails.
+ * Use `create(MessageDetailsSchema)` to create a new message.
+ */
+export declare const MessageDetailsSchema: GenMessage<MessageDetails>;
+
+/**
+ * A header or trailer whose name ends in -bin. gRPC sends these base64
+ * encoded; value holds the bytes whichever way they were captured.
+ *
+ * @generated from message mitmflow.v1.BinaryMetadata
+ */
+export declare type BinaryMetadata = Message<"mitmflow.v1.BinaryMetadata"> & {
+  /**
+   * The name, lowercased.
+   *
+   * @generated from field: string key = 1;
+   */
+  key: string;
+
+  /**
+   * Set when it was a trailer, including those in the trailer frame of a
+   * gRPC-Web body.
+   *
+   * @generated from field: bool trailer = 2;
+   */
+  trailer: boolean;
+
+  /**
+   * @generated from field: bytes value = 3;
+   */
+  value: Uint8Array;
+
+  /**
+   * A readable view of value: the google.rpc.Status as JSON for
+   * grpc-status-details-bin, protoscope when value looks like protobuf, or
+   * a hexdump.
+   *
+   * @generated from field: string text = 4;
+   */
+  text: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.BinaryMetadata.
+ * Use `create(BinaryMetadataSchema)` to create a new message.
+ */
+export declare const BinaryMetadataSchema: GenMessage<BinaryMetadata>;
+
+/**
+ * @generated from message mitmflow.v1.GrpcStatus
+ */
+export declare type GrpcStatus = Message<"mitmflow.v1.GrpcStatus"> & {
+  /**
+   * @generated from field: uint32 code = 1;
+   */
+  code: number;
+
+  /**
+   * The canonical name of the code, e.g. "UNAVAILABLE" for 14.
+   *
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * The grpc-message, percent-decoded.
+   *
+   * @generated from field: string message = 3;
+   */
+  message: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.GrpcStatus.
+ * Use `create(GrpcStatusSchema)` to create a new message.
+ */
+export declare const GrpcStatusSchema: GenMessage<GrpcStatus>;
+
+/**
+ * What could be read from an image, video or audio body without playing it.
+ *
+ * @generated from message mitmflow.v1.MediaInfo
+ */
+export declare type MediaInfo = Message<"mitmflow.v1.MediaInfo"> & {
+  /**
+   * The image or container format, e.g. "jpeg", "png", "webp", "mp4" or "wav".
+   *
+   * @generated from field: string format = 1;
+   */
+  format: string;
+
+  /**
+   * In pixels, when known. For video, the size of the first video track.
+   *
+   * @generated from field: int32 width = 2;
+   */
+  width: number;
+
+  /**
+   * @generated from field: int32 height = 3;
+   */
+  height: number;
+
+  /**
+   * Of video and audio, when known.
+   *
+   * @generated from field: int64 duration_ms = 4;
+   */
+  durationMs: bigint;
+
+  /**
+   * Selected EXIF tags of a JPEG by name, e.g. "Model", "DateTimeOriginal" or
+   * "GPSLatitude". Left out when the server was started with -strip-exif.
+   *
+   * @generated from field: map<string, string> exif = 5;
+   */
+  exif: { [key: string]: string };
+
+  /**
+   * A JPEG at most 128 pixels on its longest side, for JPEG, PNG and GIF
+   * images.
+   *
+   * @generated from field: bytes thumbnail = 6;
+   */
+  thumbnail: Uint8Array;
+};
+
+/**
+ * Describes the message mitmflow.v1.MediaInfo.
+ * Use `create(MediaInfoSchema)` to create a new message.
+ */
+export declare const MediaInfoSchema: GenMessage<MediaInfo>;
+
+/**
+ * @generated from message mitmflow.v1.FormField
+ */
+export declare type FormField = Message<"mitmflow.v1.FormField"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string value = 2;
+   */
+  value: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.FormField.
+ * Use `create(FormFieldSchema)` to create a new message.
+ */
+export declare const FormFieldSchema: GenMessage<FormField>;
+
+/**
+ * @generated from message mitmflow.v1.SoapMessage
+ */
+export declare type SoapMessage = Message<"mitmflow.v1.SoapMessage"> & {
+  /**
+   * "1.1" or "1.2".
+   *
+   * @generated from field: string version = 1;
+   */
+  version: string;
+
+  /**
+   * From the SOAPAction header, or the action parameter of the Content-Type
+   * in SOAP 1.2. Only requests have one.
+   *
+   * @generated from field: string action = 2;
+   */
+  action: string;
+
+  /**
+   * The name of the first element in the Body, e.g. "GetQuote", or "Fault".
+   *
+   * @generated from field: string operation = 3;
+   */
+  operation: string;
+
+  /**
+   * Set when the Body holds a Fault.
+   *
+   * @generated from field: mitmflow.v1.SoapFault fault = 4;
+   */
+  fault?: SoapFault;
+};
+
+/**
+ * Describes the message mitmflow.v1.SoapMessage.
+ * Use `create(SoapMessageSchema)` to create a new message.
+ */
+export declare const SoapMessageSchema: GenMessage<SoapMessage>;
+
+/**
+ * @generated from message mitmflow.v1.SoapFault
+ */
+export declare type SoapFault = Message<"mitmflow.v1.SoapFault"> & {
+  /**
+   * faultcode in SOAP 1.1, Code/Value in SOAP 1.2.
+   *
+   * @generated from field: string code = 1;
+   */
+  code: string;
+
+  /**
+   * faultstring in SOAP 1.1, Reason/Text in SOAP 1.2.
+   *
+   * @generated from field: string reason = 2;
+   */
+  reason: string;
+
+  /**
+   * faultactor in SOAP 1.1, Node in SOAP 1.2.
+   *
+   * @generated from field: string actor = 3;
+   */
+  actor: string;
+
+  /**
+   * The text of the detail or Detail element.
+   *
+   * @generated from field: string detail = 4;
+   */
+  detail: string;
+};
+
+/**
+ * Describes the message mitmflow.v1.SoapFault.
+ * Use `create(SoapFaultSchema)` to create a new message.
+ */
+export declare const SoapFaultSchema: GenMessage<SoapFault>;
+
+/**
+ * @generated from message mitmflow.v1.GetProxyRulesRequest
+ */
+export declare type GetProxyRulesRequest = Message<"mitmflow.v1.GetProxyRulesRequest"> & {
+};
+
+/**
+ * Describes the message mitmflow.v1.GetProxyRulesRequest.
+ * Use `create(GetProxyRulesRequestSchema)` to create a new message.
+ */
+export declare const GetProxyRulesRequestSchema: GenMessage<GetProxyRulesRequest>;
+
+/**
+ * @generated from message mitmflow.v1.GetProxyRulesResponse
+ */
+export declare type GetProxyRulesResponse = Message<"mitmflow.v1.GetProxyRulesResponse"> & {
+  /**
+   * @generated from field: mitmflow.v1.ProxyRules rules = 1;
+   */
+  rules?: ProxyRules;
+};
+
+/**
+ * Describes the message mitmflow.v1.GetProxyRulesResponse.
+ * Use `create(GetProxyRulesResponseSchema)` to create a new message.
+ */
+export declare const GetProxyRulesResponseSchema: GenMessage<GetProxyRulesResponse>;
+
+/**
+ * @generated from message mitmflow.v1.SetProxyRulesRequest
+ */
+export declare type SetProxyRulesRequest = Message<"mitmflow.v1.SetProxyRulesRequest"> & {
+  /**
+   * @generated from field: mitmflow.v1.ProxyRules rules = 1;
+   */
+  rules?: ProxyRules;
+};
+
+/**
+ * Describes the message mitmflow.v1.SetProxyRulesRequest.
+ * Use `create(SetProxyRulesRequestSchema)` to create a new message.
+ */
+export declare const SetProxyRulesRequestSchema: GenMessage<SetProxyRulesRequest>;
+
+/**
+ * @generated from message mitmflow.v1.SetProxyRulesResponse
+ */
+export declare type SetProxyRulesResponse = Message<"mitmflow.v1.SetProxyRulesResponse"> & {
+  /**
+   * @generated from field: mitmflow.v1.ProxyRules rules = 1;
+   */
+  rules?: ProxyRules;
+};
+
+/**
+ * Describes the message mitmflow.v1.SetProxyRulesResponse.
+ * Use `create(SetProxyRulesResponseSchema)` to create a new message.
+ */
+export declare const SetProxyRulesResponseSchema: GenMessage<SetProxyRulesResponse>;
+
+/**
+ * @generated from message mitmflow.v1.WatchProxyRulesRequest
+ */
+export declare type WatchProxyRulesRequest = Message<"mitmflow.v1.WatchProxyRulesRequest"> & {
+};
+
+/**
+ * Describes the message mitmflow.v1.WatchProxyRulesRequest.
+ * Use `create(WatchProxyRulesRequestSchema)` to create a new message.
+ */
+export declare const WatchProxyRulesRequestSchema: GenMessage<WatchProxyRulesRequest>;
+
+/**
+ * @generated from message mitmflow.v1.WatchProxyRulesResponse
+ */
+export declare type WatchProxyRulesResponse = Message<"mitmflow.v1.WatchProxyRulesResponse"> & {
+  /**
+   * The complete rules, replacing whatever the watcher applied before.
+   *
+   * @generated from field: mitmflow.v1.ProxyRules rules = 1;
+   */
+  rules?: ProxyRules;
 
   /**
-   * @generated from field: int32 status_code = 3;
+   * Sent instead of rules while they don't change.
+   *
+   * @generated from field: mitmflow.v1.Keepalive keepalive = 2;
+   */
+  keepalive?: Keepalive;
+};
+
+/**
+ * Describes the message mitmflow.v1.WatchProxyRulesResponse.
+ * Use `create(WatchProxyRulesResponseSchema)` to create a new message.
+ */
+export declare const WatchProxyRulesResponseSchema: GenMessage<WatchProxyRulesResponse>;
+
+/**
+ * ProxyRules are the rules mitmproxy applies to the traffic it proxies. They
+ * are kept in the data directory, so they survive restarts.
+ *
+ * @generated from message mitmflow.v1.ProxyRules
+ */
+export declare type ProxyRules = Message<"mitmflow.v1.ProxyRules"> & {
+  /**
+   * Checked in order; the first enabled rule matching a request wins.
+   *
+   * @generated from field: repeated mitmflow.v1.OverrideRule overrides = 1;
+   */
+  overrides: OverrideRule[];
+
+  /**
+   * Checked in order, separately from the overrides; the first enabled rule
+   * matching a request wins.
+   *
+   * @generated from field: repeated mitmflow.v1.ThrottleRule throttles = 2;
+   */
+  throttles: ThrottleRule[];
+};
+
+/**
+ * Describes the message mitmflow.v1.ProxyRules.
+ * Use `create(ProxyRulesSchema)` to create a new message.
+ */
+export declare const ProxyRulesSchema: GenMessage<ProxyRules>;
+
+/**
+ * ProxyRuleMatch selects the requests a rule applies to. Empty fields match
+ * everything.
+ *
+ * @generated from message mitmflow.v1.ProxyRuleMatch
+ */
+export declare type ProxyRuleMatch = Message<"mitmflow.v1.ProxyRuleMatch"> & {
+  /**
+   * The host of the request, e.g. "api.example.com" or "*.example.com".
+   * Matched case-insensitively; * matches any run of characters.
+   *
+   * @generated from field: string host = 1;
+   */
+  host: string;
+
+  /**
+   * The path of the request, without the query, e.g. "/v1/users/*". *
+   * matches any run of characters, slashes included.
+   *
+   * @generated from field: string path = 2;
+   */
+  path: string;
+
+  /**
+   * @generated from field: repeated string methods = 3;
+   */
+  methods: string[];
+};
+
+/**
+ * Describes the message mitmflow.v1.ProxyRuleMatch.
+ * Use `create(ProxyRuleMatchSchema)` to create a new message.
+ */
+export declare const ProxyRuleMatchSchema: GenMessage<ProxyRuleMatch>;
+
+/**
+ * OverrideRule answers matching requests with a local response instead of
+ * sending them to the server, e.g. to stub an endpoint that isn't built yet.
+ *
+ * @generated from message mitmflow.v1.OverrideRule
+ */
+export declare type OverrideRule = Message<"mitmflow.v1.OverrideRule"> & {
+  /**
+   * Unique among the overrides.
+   *
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * Keeps the rule without applying it.
+   *
+   * @generated from field: bool disabled = 2;
+   */
+  disabled: boolean;
+
+  /**
+   * @generated from field: mitmflow.v1.ProxyRuleMatch match = 3;
+   */
+  match?: ProxyRuleMatch;
+
+  /**
+   * The status code of the response. Defaults to 200.
+   *
+   * @generated from field: int32 status_code = 4;
    */
   statusCode: number;
 
   /**
-   * @generated from field: int64 duration_ms = 4;
+   * @generated from field: map<string, string> headers = 5;
    */
-  durationMs: bigint;
+  headers: { [key: string]: string };
 
   /**
-   * @generated from field: int64 request_content_length = 5;
+   * The body of the response. Mutually exclusive with file.
+   *
+   * @generated from field: bytes body = 6;
    */
-  requestContentLength: bigint;
+  body: Uint8Array;
 
   /**
-   * @generated from field: int64 response_content_length = 6;
+   * A file on the host mitmproxy runs on to serve the body from, read on
+   * every request so edits show up right away.
+   *
+   * @generated from field: string file = 7;
    */
-  responseContentLength: bigint;
+  file: string;
+};
 
+/**
+ * Describes the message mitmflow.v1.OverrideRule.
+ * Use `create(OverrideRuleSchema)` to create a new message.
+ */
+export declare const OverrideRuleSchema: GenMessage<OverrideRule>;
+
+/**
+ * ThrottleRule slows matching flows down, e.g. to see how an app copes with a
+ * bad mobile network.
+ *
+ * @generated from message mitmflow.v1.ThrottleRule
+ */
+export declare type ThrottleRule = Message<"mitmflow.v1.ThrottleRule"> & {
   /**
-   * @generated from field: string client_peername_host = 7;
+   * Unique among the throttles.
+   *
+   * @generated from field: string name = 1;
    */
-  clientPeernameHost: string;
+  name: string;
 
   /**
-   * @generated from field: string server_address_host = 8;
+   * Keeps the rule without applying it.
+   *
+   * @generated from field: bool disabled = 2;
    */
-  serverAddressHost: string;
+  disabled: boolean;
 
   /**
-   * @generated from field: uint32 server_address_port = 9;
+   * @generated from field: mitmflow.v1.ProxyRuleMatch match = 3;
+   */
+  match?: ProxyRuleMatch;
+
+  /**
+   * Added before the request is sent to the server, and again before the
+   * response is sent to the client.
+   *
+   * @generated from field: int32 request_delay_ms = 4;
+   */
+  requestDelayMs: number;
+
+  /**
+   * @generated from field: int32 response_delay_ms = 5;
+   */
+  responseDelayMs: number;
+
+  /**
+   * Caps how fast the request body is sent upstream, in bytes per second,
+   * or 0 for no limit.
+   *
+   * @generated from field: int64 upload_bytes_per_second = 6;
+   */
+  uploadBytesPerSecond: bigint;
+
+  /**
+   * Caps how fast the response body is sent to the client, in bytes per
+   * second, or 0 for no limit.
+   *
+   * @generated from field: int64 download_bytes_per_second = 7;
+   */
+  downloadBytesPerSecond: bigint;
+};
+
+/**
+ * Describes the message mitmflow.v1.ThrottleRule.
+ * Use `create(ThrottleRuleSchema)` to create a new message.
+ */
+export declare const ThrottleRuleSchema: GenMessage<ThrottleRule>;
+
+/**
+ * @generated from enum mitmflow.v1.ExportFormat
+ */
+export enum ExportFormat {
+  /**
+   * @generated from enum value: EXPORT_FORMAT_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: EXPORT_FORMAT_HAR = 1;
+   */
+  HAR = 1,
+
+  /**
+   * @generated from enum value: EXPORT_FORMAT_JSON = 2;
+   */
+  JSON = 2,
+
+  /**
+   * A serialized FlowSet, which can be loaded back with ImportFlows.
+   *
+   * @generated from enum value: EXPORT_FORMAT_PROTO = 3;
+   */
+  PROTO = 3,
+
+  /**
+   * A Fiddler session archive.
+   *
+   * @generated from enum value: EXPORT_FORMAT_SAZ = 4;
+   */
+  SAZ = 4,
+
+  /**
+   * A Charles JSON session (.chlsj), which Charles opens like a .chls file.
+   *
+   * @generated from enum value: EXPORT_FORMAT_CHARLES = 5;
+   */
+  CHARLES = 5,
+
+  /**
+   * A zip with each gRPC, gRPC-Web or Connect message as a .bin file of the
+   * serialized message, plus a .json file when the message could be decoded.
+   *
+   * @generated from enum value: EXPORT_FORMAT_GRPC_FRAMES = 6;
+   */
+  GRPC_FRAMES = 6,
+
+  /**
+   * grpcurl commands replaying the requests of gRPC flows that were decoded
+   * with a schema.
+   *
+   * @generated from enum value: EXPORT_FORMAT_GRPCURL = 7;
+   */
+  GRPCURL = 7,
+
+  /**
+   * Like EXPORT_FORMAT_GRPCURL, but for buf curl, which also speaks gRPC-Web
+   * and Connect.
+   *
+   * @generated from enum value: EXPORT_FORMAT_BUF_CURL = 8;
+   */
+  BUF_CURL = 8,
+
+  /**
+   * One flow per line in the protobuf JSON mapping.
+   *
+   * @generated from enum value: EXPORT_FORMAT_JSONL = 9;
+   */
+  JSONL = 9,
+
+  /**
+   * A summary table with the time, method, URL, status, duration, response
+   * size and content type of each flow, as CSV or as a Markdown table.
+   *
+   * @generated from enum value: EXPORT_FORMAT_CSV = 10;
+   */
+  CSV = 10,
+
+  /**
+   * @generated from enum value: EXPORT_FORMAT_MARKDOWN = 11;
+   */
+  MARKDOWN = 11,
+}
+
+/**
+ * Describes the enum mitmflow.v1.ExportFormat.
+ */
+export declare const ExportFormatSchema: GenEnum<ExportFormat>;
+
+/**
+ * @generated from enum mitmflow.v1.BaselineDifferenceKind
+ */
+export enum BaselineDifferenceKind {
+  /**
+   * @generated from enum value: BASELINE_DIFFERENCE_KIND_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: BASELINE_DIFFERENCE_KIND_STATUS = 1;
+   */
+  STATUS = 1,
+
+  /**
+   * @generated from enum value: BASELINE_DIFFERENCE_KIND_HEADER = 2;
+   */
+  HEADER = 2,
+
+  /**
+   * @generated from enum value: BASELINE_DIFFERENCE_KIND_BODY = 3;
+   */
+  BODY = 3,
+}
+
+/**
+ * Describes the enum mitmflow.v1.BaselineDifferenceKind.
+ */
+export declare const BaselineDifferenceKindSchema: GenEnum<BaselineDifferenceKind>;
+
+/**
+ * @generated from enum mitmflow.v1.AuditAction
+ */
+export enum AuditAction {
+  /**
+   * @generated from enum value: AUDIT_ACTION_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: AUDIT_ACTION_DELETE = 1;
+   */
+  DELETE = 1,
+
+  /**
+   * Every flow was deleted at once.
+   *
+   * @generated from enum value: AUDIT_ACTION_DELETE_ALL = 2;
+   */
+  DELETE_ALL = 2,
+
+  /**
+   * @generated from enum value: AUDIT_ACTION_PIN = 3;
+   */
+  PIN = 3,
+
+  /**
+   * @generated from enum value: AUDIT_ACTION_UNPIN = 4;
+   */
+  UNPIN = 4,
+
+  /**
+   * @generated from enum value: AUDIT_ACTION_NOTE = 5;
+   */
+  NOTE = 5,
+
+  /**
+   * @generated from enum value: AUDIT_ACTION_UPDATE_METADATA = 6;
+   */
+  UPDATE_METADATA = 6,
+
+  /**
+   * Flows were exported with ExportFlows, shared with CreateShareBundle or
+   * backed up with CreateBackup. The detail says which.
+   *
+   * @generated from enum value: AUDIT_ACTION_EXPORT = 7;
+   */
+  EXPORT = 7,
+
+  /**
+   * @generated from enum value: AUDIT_ACTION_RESTORE = 8;
+   */
+  RESTORE = 8,
+
+  /**
+   * Flows were added with ImportFlows.
+   *
+   * @generated from enum value: AUDIT_ACTION_IMPORT = 9;
+   */
+  IMPORT = 9,
+
+  /**
+   * The proxy rules were replaced. The detail counts the overrides and
+   * throttles.
+   *
+   * @generated from enum value: AUDIT_ACTION_SET_PROXY_RULES = 10;
+   */
+  SET_PROXY_RULES = 10,
+
+  /**
+   * The baseline was set from a session, whose filter is the detail, or
+   * cleared, when the detail is "cleared".
+   *
+   * @generated from enum value: AUDIT_ACTION_SET_BASELINE = 11;
+   */
+  SET_BASELINE = 11,
+}
+
+/**
+ * Describes the enum mitmflow.v1.AuditAction.
+ */
+export declare const AuditActionSchema: GenEnum<AuditAction>;
+
+/**
+ * @generated from enum mitmflow.v1.CookieEventType
+ */
+export enum CookieEventType {
+  /**
+   * @generated from enum value: COOKIE_EVENT_TYPE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * A response set the cookie.
+   *
+   * @generated from enum value: COOKIE_EVENT_TYPE_SET = 1;
+   */
+  SET = 1,
+
+  /**
+   * A request sent the cookie.
+   *
+   * @generated from enum value: COOKIE_EVENT_TYPE_SENT = 2;
    */
-  serverAddressPort: number;
+  SENT = 2,
 
   /**
-   * @generated from field: uint32 client_peername_port = 10;
+   * A response expired the cookie.
+   *
+   * @generated from enum value: COOKIE_EVENT_TYPE_DELETED = 3;
    */
-  clientPeernamePort: number;
-};
+  DELETED = 3,
+}
 
 /**
- * Describes the message mitmflow.v1.HttpFlowSummary.
- * Use `create(HttpFlowSummarySchema)` to create a new message.
+ * Describes the enum mitmflow.v1.CookieEventType.
  */
-export declare const HttpFlowSummarySchema: GenMessage<HttpFlowSummary>;
+export declare const CookieEventTypeSchema: GenEnum<CookieEventType>;
 
 /**
- * @generated from message mitmflow.v1.DnsFlowSummary
+ * @generated from enum mitmflow.v1.TopFlowsOrder
  */
-export declare type DnsFlowSummary = Message<"mitmflow.v1.DnsFlowSummary"> & {
+export enum TopFlowsOrder {
   /**
-   * @generated from field: string question_name = 1;
+   * @generated from enum value: TOP_FLOWS_ORDER_UNSPECIFIED = 0;
    */
-  questionName: string;
+  UNSPECIFIED = 0,
 
   /**
-   * @generated from field: string client_peername_host = 2;
+   * By duration.
+   *
+   * @generated from enum value: TOP_FLOWS_ORDER_SLOWEST = 1;
    */
-  clientPeernameHost: string;
+  SLOWEST = 1,
 
   /**
-   * @generated from field: string error = 3;
+   * By the bytes sent and received together.
+   *
+   * @generated from enum value: TOP_FLOWS_ORDER_LARGEST = 2;
    */
-  error: string;
-};
+  LARGEST = 2,
+}
 
 /**
- * Describes the message mitmflow.v1.DnsFlowSummary.
- * Use `create(DnsFlowSummarySchema)` to create a new message.
+ * Describes the enum mitmflow.v1.TopFlowsOrder.
  */
-export declare const DnsFlowSummarySchema: GenMessage<DnsFlowSummary>;
+export declare const TopFlowsOrderSchema: GenEnum<TopFlowsOrder>;
 
 /**
- * @generated from message mitmflow.v1.TcpFlowSummary
+ * @generated from enum mitmflow.v1.FlowDifferenceKind
  */
-export declare type TcpFlowSummary = Message<"mitmflow.v1.TcpFlowSummary"> & {
+export enum FlowDifferenceKind {
   /**
-   * @generated from field: string server_address_host = 1;
+   * @generated from enum value: FLOW_DIFFERENCE_KIND_UNSPECIFIED = 0;
    */
-  serverAddressHost: string;
+  UNSPECIFIED = 0,
 
   /**
-   * @generated from field: uint32 server_address_port = 2;
+   * @generated from enum value: FLOW_DIFFERENCE_KIND_STATUS = 1;
    */
-  serverAddressPort: number;
+  STATUS = 1,
 
   /**
-   * @generated from field: string client_peername_host = 3;
+   * @generated from enum value: FLOW_DIFFERENCE_KIND_QUERY = 2;
    */
-  clientPeernameHost: string;
+  QUERY = 2,
 
   /**
-   * @generated from field: uint32 client_peername_port = 4;
+   * @generated from enum value: FLOW_DIFFERENCE_KIND_HEADER = 3;
    */
-  clientPeernamePort: number;
+  HEADER = 3,
 
   /**
-   * @generated from field: string error = 5;
+   * @generated from enum value: FLOW_DIFFERENCE_KIND_BODY = 4;
    */
-  error: string;
-};
+  BODY = 4,
+}
 
 /**
- * Describes the message mitmflow.v1.TcpFlowSummary.
- * Use `create(TcpFlowSummarySchema)` to create a new message.
+ * Describes the enum mitmflow.v1.FlowDifferenceKind.
  */
-export declare const TcpFlowSummarySchema: GenMessage<TcpFlowSummary>;
+export declare const FlowDifferenceKindSchema: GenEnum<FlowDifferenceKind>;
 
 /**
- * @generated from message mitmflow.v1.UdpFlowSummary
+ * @generated from enum mitmflow.v1.FindingSeverity
  */
-export declare type UdpFlowSummary = Message<"mitmflow.v1.UdpFlowSummary"> & {
-  /**
-   * @generated from field: string server_address_host = 1;
-   */
-  serverAddressHost: string;
-
+export enum FindingSeverity {
   /**
-   * @generated from field: uint32 server_address_port = 2;
+   * @generated from enum value: FINDING_SEVERITY_UNSPECIFIED = 0;
    */
-  serverAddressPort: number;
+  UNSPECIFIED = 0,
 
   /**
-   * @generated from field: string client_peername_host = 3;
+   * @generated from enum value: FINDING_SEVERITY_INFO = 1;
    */
-  clientPeernameHost: string;
+  INFO = 1,
 
   /**
-   * @generated from field: uint32 client_peername_port = 4;
+   * @generated from enum value: FINDING_SEVERITY_LOW = 2;
    */
-  clientPeernamePort: number;
+  LOW = 2,
 
   /**
-   * @generated from field: string error = 5;
+   * @generated from enum value: FINDING_SEVERITY_MEDIUM = 3;
    */
-  error: string;
-};
+  MEDIUM = 3,
+}
 
 /**
- * Describes the message mitmflow.v1.UdpFlowSummary.
- * Use `create(UdpFlowSummarySchema)` to create a new message.
+ * Describes the enum mitmflow.v1.FindingSeverity.
  */
-export declare const UdpFlowSummarySchema: GenMessage<UdpFlowSummary>;
+export declare const FindingSeveritySchema: GenEnum<FindingSeverity>;
 
 /**
- * @generated from message mitmflow.v1.Flow
+ * @generated from enum mitmflow.v1.DeviceType
  */
-export declare type Flow = Message<"mitmflow.v1.Flow"> & {
+export enum DeviceType {
   /**
-   * @generated from oneof mitmflow.v1.Flow.flow
+   * @generated from enum value: DEVICE_TYPE_UNSPECIFIED = 0;
    */
-  flow: {
-    /**
-     * @generated from field: mitmproxy.v1.HTTPFlow http_flow = 1;
-     */
-    value: HTTPFlow;
-    case: "httpFlow";
-  } | {
-    /**
-     * @generated from field: mitmproxy.v1.TCPFlow tcp_flow = 2;
-     */
-    value: TCPFlow;
-    case: "tcpFlow";
-  } | {
-    /**
-     * @generated from field: mitmproxy.v1.UDPFlow udp_flow = 3;
-     */
-    value: UDPFlow;
-    case: "udpFlow";
-  } | {
-    /**
-     * @generated from field: mitmproxy.v1.DNSFlow dns_flow = 4;
-     */
-    value: DNSFlow;
-    case: "dnsFlow";
-  } | { case: undefined; value?: undefined };
+  UNSPECIFIED = 0,
 
   /**
-   * @generated from field: mitmflow.v1.HTTPFlowExtra http_flow_extra = 5;
+   * @generated from enum value: DEVICE_TYPE_DESKTOP = 1;
    */
-  httpFlowExtra?: HTTPFlowExtra;
+  DESKTOP = 1,
 
   /**
-   * @generated from field: bool pinned = 6;
+   * @generated from enum value: DEVICE_TYPE_MOBILE = 2;
    */
-  pinned: boolean;
+  MOBILE = 2,
 
   /**
-   * @generated from field: string note = 7;
+   * @generated from enum value: DEVICE_TYPE_TABLET = 3;
    */
-  note: string;
-};
+  TABLET = 3,
+
+  /**
+   * @generated from enum value: DEVICE_TYPE_BOT = 4;
+   */
+  BOT = 4,
+}
 
 /**
- * Describes the message mitmflow.v1.Flow.
- * Use `create(FlowSchema)` to create a new message.
+ * Describes the enum mitmflow.v1.DeviceType.
  */
-export declare const FlowSchema: GenMessage<Flow>;
+export declare const DeviceTypeSchema: GenEnum<DeviceType>;
 
 /**
- * @generated from message mitmflow.v1.HTTPFlowExtra
+ * @generated from enum mitmflow.v1.DnsAnomalyKind
  */
-export declare type HTTPFlowExtra = Message<"mitmflow.v1.HTTPFlowExtra"> & {
+export enum DnsAnomalyKind {
   /**
-   * @generated from field: mitmflow.v1.MessageDetails request = 1;
+   * @generated from enum value: DNS_ANOMALY_KIND_UNSPECIFIED = 0;
    */
-  request?: MessageDetails;
+  UNSPECIFIED = 0,
 
   /**
-   * @generated from field: mitmflow.v1.MessageDetails response = 2;
+   * Many NXDOMAIN answers to the same client in a short time, as from a
+   * domain generation algorithm or a scan.
+   *
+   * @generated from enum value: DNS_ANOMALY_KIND_NXDOMAIN_BURST = 1;
    */
-  response?: MessageDetails;
-};
-
-/**
- * Describes the message mitmflow.v1.HTTPFlowExtra.
- * Use `create(HTTPFlowExtraSchema)` to create a new message.
- */
-export declare const HTTPFlowExtraSchema: GenMessage<HTTPFlowExtra>;
+  NXDOMAIN_BURST = 1,
 
-/**
- * @generated from message mitmflow.v1.MessageDetails
- */
-export declare type MessageDetails = Message<"mitmflow.v1.MessageDetails"> & {
   /**
-   * @generated from field: repeated string textual_frames = 1;
+   * A label or name close to the limits DNS allows.
+   *
+   * @generated from enum value: DNS_ANOMALY_KIND_LONG_LABEL = 2;
    */
-  textualFrames: string[];
+  LONG_LABEL = 2,
 
   /**
-   * @generated from field: string effective_content_type = 2;
+   * A label that looks random, like data encoded into a name to tunnel it.
+   *
+   * @generated from enum value: DNS_ANOMALY_KIND_HIGH_ENTROPY_NAME = 3;
    */
-  effectiveContentType: string;
+  HIGH_ENTROPY_NAME = 3,
 
   /**
-   * @generated from field: int64 body_size = 3;
+   * A query type that ordinary clients rarely ask for, like NULL or ANY.
+   *
+   * @generated from enum value: DNS_ANOMALY_KIND_UNUSUAL_QTYPE = 4;
    */
-  bodySize: bigint;
-};
+  UNUSUAL_QTYPE = 4,
+}
 
 /**
- * Describes the message mitmflow.v1.MessageDetails.
- * Use `create(MessageDetailsSchema)` to create a new message.
+ * Describes the enum mitmflow.v1.DnsAnomalyKind.
  */
-export declare const MessageDetailsSchema: GenMessage<MessageDetails>;
+export declare const DnsAnomalyKindSchema: GenEnum<DnsAnomalyKind>;
 
 /**
- * @generated from enum mitmflow.v1.ExportFormat
+ * @generated from enum mitmflow.v1.HostnameSource
  */
-export enum ExportFormat {
+export enum HostnameSource {
   /**
-   * @generated from enum value: EXPORT_FORMAT_UNSPECIFIED = 0;
+   * @generated from enum value: HOSTNAME_SOURCE_UNSPECIFIED = 0;
    */
   UNSPECIFIED = 0,
 
   /**
-   * @generated from enum value: EXPORT_FORMAT_HAR = 1;
+   * An answer for the IP in a DNS flow captured shortly before the flow.
+   *
+   * @generated from enum value: HOSTNAME_SOURCE_DNS_FLOW = 1;
    */
-  HAR = 1,
+  DNS_FLOW = 1,
 
   /**
-   * @generated from enum value: EXPORT_FORMAT_JSON = 2;
+   * A reverse DNS (PTR) lookup of the IP.
+   *
+   * @generated from enum value: HOSTNAME_SOURCE_REVERSE_DNS = 2;
    */
-  JSON = 2,
+  REVERSE_DNS = 2,
 }
 
 /**
- * Describes the enum mitmflow.v1.ExportFormat.
+ * Describes the enum mitmflow.v1.HostnameSource.
  */
-export declare const ExportFormatSchema: GenEnum<ExportFormat>;
+export declare const HostnameSourceSchema: GenEnum<HostnameSource>;
 
 /**
  * @generated from service mitmflow.v1.Service
@@ -726,5 +4138,231 @@ export declare const Service: GenService<{
     input: typeof GetFlowRequestSchema;
     output: typeof GetFlowResponseSchema;
   },
+  /**
+   * @generated from rpc mitmflow.v1.Service.GetFlowBody
+   */
+  getFlowBody: {
+    methodKind: "unary";
+    input: typeof GetFlowBodyRequestSchema;
+    output: typeof GetFlowBodyResponseSchema;
+  },
+  /**
+   * Pages through the textual frames of a request or response, for
+   * streaming bodies with more frames than GetFlow returns.
+   *
+   * @generated from rpc mitmflow.v1.Service.GetFlowFrames
+   */
+  getFlowFrames: {
+    methodKind: "unary";
+    input: typeof GetFlowFramesRequestSchema;
+    output: typeof GetFlowFramesResponseSchema;
+  },
+  /**
+   * @generated from rpc mitmflow.v1.Service.ImportFlows
+   */
+  importFlows: {
+    methodKind: "unary";
+    input: typeof ImportFlowsRequestSchema;
+    output: typeof ImportFlowsResponseSchema;
+  },
+  /**
+   * @generated from rpc mitmflow.v1.Service.CreateBackup
+   */
+  createBackup: {
+    methodKind: "server_streaming";
+    input: typeof CreateBackupRequestSchema;
+    output: typeof CreateBackupResponseSchema;
+  },
+  /**
+   * @generated from rpc mitmflow.v1.Service.RestoreBackup
+   */
+  restoreBackup: {
+    methodKind: "unary";
+    input: typeof RestoreBackupRequestSchema;
+    output: typeof RestoreBackupResponseSchema;
+  },
+  /**
+   * @generated from rpc mitmflow.v1.Service.SearchArchive
+   */
+  searchArchive: {
+    methodKind: "server_streaming";
+    input: typeof SearchArchiveRequestSchema;
+    output: typeof SearchArchiveResponseSchema;
+  },
+  /**
+   * @generated from rpc mitmflow.v1.Service.RestoreArchivedFlows
+   */
+  restoreArchivedFlows: {
+    methodKind: "unary";
+    input: typeof RestoreArchivedFlowsRequestSchema;
+    output: typeof RestoreArchivedFlowsResponseSchema;
+  },
+  /**
+   * @generated from rpc mitmflow.v1.Service.RestoreFlows
+   */
+  restoreFlows: {
+    methodKind: "unary";
+    input: typeof RestoreFlowsRequestSchema;
+    output: typeof RestoreFlowsResponseSchema;
+  },
+  /**
+   * @generated from rpc mitmflow.v1.Service.GetServerInfo
+   */
+  getServerInfo: {
+    methodKind: "unary";
+    input: typeof GetServerInfoRequestSchema;
+    output: typeof GetServerInfoResponseSchema;
+  },
+  /**
+   * ListSubscribers describes the clients currently streaming flows, for
+   * diagnosing why one of them is missing flows.
+   *
+   * @generated from rpc mitmflow.v1.Service.ListSubscribers
+   */
+  listSubscribers: {
+    methodKind: "unary";
+    input: typeof ListSubscribersRequestSchema;
+    output: typeof ListSubscribersResponseSchema;
+  },
+  /**
+   * ListAuditEvents returns the audit log of changes to and exports of
+   * flows, newest first. It requires the server to be started with -audit-log.
+   *
+   * @generated from rpc mitmflow.v1.Service.ListAuditEvents
+   */
+  listAuditEvents: {
+    methodKind: "unary";
+    input: typeof ListAuditEventsRequestSchema;
+    output: typeof ListAuditEventsResponseSchema;
+  },
+  /**
+   * @generated from rpc mitmflow.v1.Service.SendRequest
+   */
+  sendRequest: {
+    methodKind: "unary";
+    input: typeof SendRequestRequestSchema;
+    output: typeof SendRequestResponseSchema;
+  },
+  /**
+   * @generated from rpc mitmflow.v1.Service.GetCookieTimeline
+   */
+  getCookieTimeline: {
+    methodKind: "unary";
+    input: typeof GetCookieTimelineRequestSchema;
+    output: typeof GetCookieTimelineResponseSchema;
+  },
+  /**
+   * @generated from rpc mitmflow.v1.Service.GetRedirectChain
+   */
+  getRedirectChain: {
+    methodKind: "unary";
+    input: typeof GetRedirectChainRequestSchema;
+    output: typeof GetRedirectChainResponseSchema;
+  },
+  /**
+   * @generated from rpc mitmflow.v1.Service.CreateShareBundle
+   */
+  createShareBundle: {
+    methodKind: "unary";
+    input: typeof CreateShareBundleRequestSchema;
+    output: typeof CreateShareBundleResponseSchema;
+  },
+  /**
+   * @generated from rpc mitmflow.v1.Service.SetBaseline
+   */
+  setBaseline: {
+    methodKind: "unary";
+    input: typeof SetBaselineRequestSchema;
+    output: typeof SetBaselineResponseSchema;
+  },
+  /**
+   * @generated from rpc mitmflow.v1.Service.CompareSessions
+   */
+  compareSessions: {
+    methodKind: "unary";
+    input: typeof CompareSessionsRequestSchema;
+    output: typeof CompareSessionsResponseSchema;
+  },
+  /**
+   * UploadFlowBody lets mitmproxy send bodies too large for a single
+   * ExportFlowRequest in chunks. The flow is exported first without the body
+   * (or with it truncated), then the chunks of each body are sent in order;
+   * a body is complete when the stream moves on to another one or ends.
+   *
+   * @generated from rpc mitmflow.v1.Service.UploadFlowBody
+   */
+  uploadFlowBody: {
+    methodKind: "client_streaming";
+    input: typeof UploadFlowBodyRequestSchema;
+    output: typeof UploadFlowBodyResponseSchema;
+  },
+  /**
+   * DiffWithPrevious compares an HTTP flow with the latest one before it with
+   * the same method and path, e.g. to see what changed between a passing and
+   * a failing call.
+   *
+   * @generated from rpc mitmflow.v1.Service.DiffWithPrevious
+   */
+  diffWithPrevious: {
+    methodKind: "unary";
+    input: typeof DiffWithPreviousRequestSchema;
+    output: typeof DiffWithPreviousResponseSchema;
+  },
+  /**
+   * TopFlows returns the slowest or largest flows matching a filter, worst
+   * first, to start a performance hunt from the worst offenders.
+   *
+   * @generated from rpc mitmflow.v1.Service.TopFlows
+   */
+  topFlows: {
+    methodKind: "unary";
+    input: typeof TopFlowsRequestSchema;
+    output: typeof TopFlowsResponseSchema;
+  },
+  /**
+   * GetBandwidthStats adds up the bytes flows sent and received per server
+   * host and per client over time, e.g. to find what is eating a data plan.
+   *
+   * @generated from rpc mitmflow.v1.Service.GetBandwidthStats
+   */
+  getBandwidthStats: {
+    methodKind: "unary";
+    input: typeof GetBandwidthStatsRequestSchema;
+    output: typeof GetBandwidthStatsResponseSchema;
+  },
+  /**
+   * GetProxyRules returns the rules mitmproxy is asked to apply to the
+   * traffic it proxies, like serving a local response instead of the
+   * server's or slowing flows down.
+   *
+   * @generated from rpc mitmflow.v1.Service.GetProxyRules
+   */
+  getProxyRules: {
+    methodKind: "unary";
+    input: typeof GetProxyRulesRequestSchema;
+    output: typeof GetProxyRulesResponseSchema;
+  },
+  /**
+   * SetProxyRules replaces the proxy rules and relays them to every watcher.
+   *
+   * @generated from rpc mitmflow.v1.Service.SetProxyRules
+   */
+  setProxyRules: {
+    methodKind: "unary";
+    input: typeof SetProxyRulesRequestSchema;
+    output: typeof SetProxyRulesResponseSchema;
+  },
+  /**
+   * WatchProxyRules is the control channel of the mitmproxy addon. It sends
+   * the current rules right away and again whenever they change; the addon
+   * applies the latest rules it got.
+   *
+   * @generated from rpc mitmflow.v1.Service.WatchProxyRules
+   */
+  watchProxyRules: {
+    methodKind: "server_streaming";
+    input: typeof WatchProxyRulesRequestSchema;
+    output: typeof WatchProxyRulesResponseSchema;
+  },
 }>;
 
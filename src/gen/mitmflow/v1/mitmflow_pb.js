@@ -11,7 +11,7 @@ import { file_mitmproxygrpc_v1_service } from "../../mitmproxygrpc/v1/service_pb
  * Describes the file mitmflow/v1/mitmflow.proto.
  */
 export const file_mitmflow_v1_mitmflow = /*@__PURE__*/
-  fileDesc("ChptaXRtZmxvdy92MS9taXRtZmxvdy5wcm90bxILbWl0bWZsb3cudjEi6AEKCkZsb3dGaWx0ZXISGgoLZmlsdGVyX3RleHQYASABKAlCBaoBAggBEhUKBnBpbm5lZBgCIAEoCEIFqgECCAESFwoIaGFzX25vdGUYAyABKAhCBaoBAggBEjMKCmZsb3dfdHlwZXMYBCADKAlCH7pIHJIBGSIXchVSBGh0dHBSA2Ruc1IDdGNwUgN1ZHASIAoKY2xpZW50X2lwcxgFIAMoCUIMukgJkgEGIgRyAnABEiUKBGh0dHAYBiABKAsyFy5taXRtZmxvdy52MS5IdHRwRmlsdGVyEhAKCGZsb3dfaWRzGAcgAygJImIKCkh0dHBGaWx0ZXISJwoHbWV0aG9kcxgBIAMoCUIWukgTkgEQIg5yDBgUMgheW0EtWl0rJBIVCg1jb250ZW50X3R5cGVzGAIgAygJEhQKDHN0YXR1c19jb2RlcxgDIAMoCSIhCg5HZXRGbG93UmVxdWVzdBIPCgdmbG93X2lkGAEgASgJIjIKD0dldEZsb3dSZXNwb25zZRIfCgRmbG93GAEgASgLMhEubWl0bWZsb3cudjEuRmxvdyJJCg9HZXRGbG93c1JlcXVlc3QSJwoGZmlsdGVyGAEgASgLMhcubWl0bWZsb3cudjEuRmxvd0ZpbHRlchINCgVsaW1pdBgCIAEoBSI6ChBHZXRGbG93c1Jlc3BvbnNlEiYKBGZsb3cYASABKAsyGC5taXRtZmxvdy52MS5GbG93U3VtbWFyeSJZChJTdHJlYW1GbG93c1JlcXVlc3QSGgoSc2luY2VfdGltZXN0YW1wX25zGAEgASgDEicKBmZpbHRlchgCIAEoCzIXLm1pdG1mbG93LnYxLkZsb3dGaWx0ZXIiSwoTU3RyZWFtRmxvd3NSZXNwb25zZRIoCgRmbG93GAEgASgLMhgubWl0bWZsb3cudjEuRmxvd1N1bW1hcnlIAEIKCghyZXNwb25zZSJQChFVcGRhdGVGbG93UmVxdWVzdBIPCgdmbG93X2lkGAEgASgJEhUKBnBpbm5lZBgCIAEoCEIFqgECCAESEwoEbm90ZRgDIAEoCUIFqgECCAEiPAoSVXBkYXRlRmxvd1Jlc3BvbnNlEiYKBGZsb3cYASABKAsyGC5taXRtZmxvdy52MS5GbG93U3VtbWFyeSIzChJEZWxldGVGbG93c1JlcXVlc3QSEAoIZmxvd19pZHMYASADKAkSCwoDYWxsGAIgASgIIiQKE0RlbGV0ZUZsb3dzUmVzcG9uc2USDQoFY291bnQYASABKAMiUQoSRXhwb3J0Rmxvd3NSZXF1ZXN0EhAKCGZsb3dfaWRzGAEgAygJEikKBmZvcm1hdBgCIAEoDjIZLm1pdG1mbG93LnYxLkV4cG9ydEZvcm1hdCI1ChNFeHBvcnRGbG93c1Jlc3BvbnNlEgwKBGRhdGEYASABKAwSEAoIZmlsZW5hbWUYAiABKAkitwIKC0Zsb3dTdW1tYXJ5EgoKAmlkGAEgASgJEgwKBHR5cGUYAiABKAkSMwoPdGltZXN0YW1wX3N0YXJ0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIOCgZwaW5uZWQYBCABKAgSDAoEbm90ZRgFIAEoCRIsCgRodHRwGAYgASgLMhwubWl0bWZsb3cudjEuSHR0cEZsb3dTdW1tYXJ5SAASKgoDZG5zGAcgASgLMhsubWl0bWZsb3cudjEuRG5zRmxvd1N1bW1hcnlIABIqCgN0Y3AYCCABKAsyGy5taXRtZmxvdy52MS5UY3BGbG93U3VtbWFyeUgAEioKA3VkcBgJIAEoCzIbLm1pdG1mbG93LnYxLlVkcEZsb3dTdW1tYXJ5SABCCQoHc3VtbWFyeSKPAgoPSHR0cEZsb3dTdW1tYXJ5Eg4KBm1ldGhvZBgBIAEoCRILCgN1cmwYAiABKAkSEwoLc3RhdHVzX2NvZGUYAyABKAUSEwoLZHVyYXRpb25fbXMYBCABKAMSHgoWcmVxdWVzdF9jb250ZW50X2xlbmd0aBgFIAEoAxIfChdyZXNwb25zZV9jb250ZW50X2xlbmd0aBgGIAEoAxIcChRjbGllbnRfcGVlcm5hbWVfaG9zdBgHIAEoCRIbChNzZXJ2ZXJfYWRkcmVzc19ob3N0GAggASgJEhsKE3NlcnZlcl9hZGRyZXNzX3BvcnQYCSABKA0SHAoUY2xpZW50X3BlZXJuYW1lX3BvcnQYCiABKA0iVAoORG5zRmxvd1N1bW1hcnkSFQoNcXVlc3Rpb25fbmFtZRgBIAEoCRIcChRjbGllbnRfcGVlcm5hbWVfaG9zdBgCIAEoCRINCgVlcnJvchgDIAEoCSKVAQoOVGNwRmxvd1N1bW1hcnkSGwoTc2VydmVyX2FkZHJlc3NfaG9zdBgBIAEoCRIbChNzZXJ2ZXJfYWRkcmVzc19wb3J0GAIgASgNEhwKFGNsaWVudF9wZWVybmFtZV9ob3N0GAMgASgJEhwKFGNsaWVudF9wZWVybmFtZV9wb3J0GAQgASgNEg0KBWVycm9yGAUgASgJIpUBCg5VZHBGbG93U3VtbWFyeRIbChNzZXJ2ZXJfYWRkcmVzc19ob3N0GAEgASgJEhsKE3NlcnZlcl9hZGRyZXNzX3BvcnQYAiABKA0SHAoUY2xpZW50X3BlZXJuYW1lX2hvc3QYAyABKAkSHAoUY2xpZW50X3BlZXJuYW1lX3BvcnQYBCABKA0SDQoFZXJyb3IYBSABKAkijwIKBEZsb3cSKwoJaHR0cF9mbG93GAEgASgLMhYubWl0bXByb3h5LnYxLkhUVFBGbG93SAASKQoIdGNwX2Zsb3cYAiABKAsyFS5taXRtcHJveHkudjEuVENQRmxvd0gAEikKCHVkcF9mbG93GAMgASgLMhUubWl0bXByb3h5LnYxLlVEUEZsb3dIABIpCghkbnNfZmxvdxgEIAEoCzIVLm1pdG1wcm94eS52MS5ETlNGbG93SAASMwoPaHR0cF9mbG93X2V4dHJhGAUgASgLMhoubWl0bWZsb3cudjEuSFRUUEZsb3dFeHRyYRIOCgZwaW5uZWQYBiABKAgSDAoEbm90ZRgHIAEoCUIGCgRmbG93ImwKDUhUVFBGbG93RXh0cmESLAoHcmVxdWVzdBgBIAEoCzIbLm1pdG1mbG93LnYxLk1lc3NhZ2VEZXRhaWxzEi0KCHJlc3BvbnNlGAIgASgLMhsubWl0bWZsb3cudjEuTWVzc2FnZURldGFpbHMiWwoOTWVzc2FnZURldGFpbHMSFgoOdGV4dHVhbF9mcmFtZXMYASADKAkSHgoWZWZmZWN0aXZlX2NvbnRlbnRfdHlwZRgCIAEoCRIRCglib2R5X3NpemUYAyABKAMqXAoMRXhwb3J0Rm9ybWF0Eh0KGUVYUE9SVF9GT1JNQVRfVU5TUEVDSUZJRUQQABIVChFFWFBPUlRfRk9STUFUX0hBUhABEhYKEkVYUE9SVF9GT1JNQVRfSlNPThACMu0DCgdTZXJ2aWNlEksKCEdldEZsb3dzEhwubWl0bWZsb3cudjEuR2V0Rmxvd3NSZXF1ZXN0Gh0ubWl0bWZsb3cudjEuR2V0Rmxvd3NSZXNwb25zZSIAMAESVAoLU3RyZWFtRmxvd3MSHy5taXRtZmxvdy52MS5TdHJlYW1GbG93c1JlcXVlc3QaIC5taXRtZmxvdy52MS5TdHJlYW1GbG93c1Jlc3BvbnNlIgAwARJPCgpVcGRhdGVGbG93Eh4ubWl0bWZsb3cudjEuVXBkYXRlRmxvd1JlcXVlc3QaHy5taXRtZmxvdy52MS5VcGRhdGVGbG93UmVzcG9uc2UiABJSCgtEZWxldGVGbG93cxIfLm1pdG1mbG93LnYxLkRlbGV0ZUZsb3dzUmVxdWVzdBogLm1pdG1mbG93LnYxLkRlbGV0ZUZsb3dzUmVzcG9uc2UiABJSCgtFeHBvcnRGbG93cxIfLm1pdG1mbG93LnYxLkV4cG9ydEZsb3dzUmVxdWVzdBogLm1pdG1mbG93LnYxLkV4cG9ydEZsb3dzUmVzcG9uc2UiABJGCgdHZXRGbG93EhsubWl0bWZsb3cudjEuR2V0Rmxvd1JlcXVlc3QaHC5taXRtZmxvdy52MS5HZXRGbG93UmVzcG9uc2UiAGIIZWRpdGlvbnNw6Ac", [file_buf_validate_validate, file_google_protobuf_timestamp, file_mitmproxygrpc_v1_service]);
+  fileDesc("ChptaXRtZmxvdy92MS9taXRtZmxvdy5wcm90bxILbWl0bWZsb3cudjEi4gIKCkZsb3dGaWx0ZXISGgoLZmlsdGVyX3RleHQYASABKAlCBaoBAggBEhUKBnBpbm5lZBgCIAEoCEIFqgECCAESFwoIaGFzX25vdGUYAyABKAhCBaoBAggBEjMKCmZsb3dfdHlwZXMYBCADKAlCH7pIHJIBGSIXchVSBGh0dHBSA2Ruc1IDdGNwUgN1ZHASIAoKY2xpZW50X2lwcxgFIAMoCUIMukgJkgEGIgRyAnABEiUKBGh0dHAYBiABKAsyFy5taXRtZmxvdy52MS5IdHRwRmlsdGVyEhAKCGZsb3dfaWRzGAcgAygJEjMKEHNlcnZlcl9jb3VudHJpZXMYCCADKAlCGbpIFpIBEyIRcg8yDV5bQS1aYS16XXsyfSQSMAoLZG5zX2Fub21hbHkYCSADKA4yGy5taXRtZmxvdy52MS5EbnNBbm9tYWx5S2luZBIRCglwcm90b2NvbHMYCiADKAkirwIKCkh0dHBGaWx0ZXISJwoHbWV0aG9kcxgBIAMoCUIWukgTkgEQIg5yDBgUMgheW0EtWl0rJBIVCg1jb250ZW50X3R5cGVzGAIgAygJEhQKDHN0YXR1c19jb2RlcxgDIAMoCRIXCg9jbGllbnRfZmFtaWxpZXMYBCADKAkSOwoVbWluX3NlY3VyaXR5X3NldmVyaXR5GAUgASgOMhwubWl0bWZsb3cudjEuRmluZGluZ1NldmVyaXR5EjAKC2JvZHlfc2hhMjU2GAYgASgJQhu6SBhyFjIUXihbMC05YS1mQS1GXXs2NH0pPyQSLAoMYm9keV9xdWVyaWVzGAcgAygLMhYubWl0bWZsb3cudjEuQm9keVF1ZXJ5EhUKDWdycGNfc3RhdHVzZXMYCCADKAkiTwoJQm9keVF1ZXJ5EhoKBHBhdGgYASABKAlCDLpICXIHEAEyA15cJBIVCgZlcXVhbHMYAiABKAlCBaoBAggBEg8KB3JlcXVlc3QYAyABKAgiIQoOR2V0Rmxvd1JlcXVlc3QSDwoHZmxvd19pZBgBIAEoCSIyCg9HZXRGbG93UmVzcG9uc2USHwoEZmxvdxgBIAEoCzIRLm1pdG1mbG93LnYxLkZsb3ciNwoSR2V0Rmxvd0JvZHlSZXF1ZXN0Eg8KB2Zsb3dfaWQYASABKAkSEAoIcmVzcG9uc2UYAiABKAgiPAoTR2V0Rmxvd0JvZHlSZXNwb25zZRIPCgdjb250ZW50GAEgASgMEhQKDGNvbnRlbnRfdHlwZRgCIAEoCSJ2ChRHZXRGbG93RnJhbWVzUmVxdWVzdBIYCgdmbG93X2lkGAEgASgJQge6SARyAhABEhAKCHJlc3BvbnNlGAIgASgIEhcKBm9mZnNldBgDIAEoBUIHukgEGgIoABIZCgVsaW1pdBgEIAEoBUIKukgHGgUY6AcoACJTChVHZXRGbG93RnJhbWVzUmVzcG9uc2USDgoGZnJhbWVzGAEgAygJEhsKE2ZyYW1lX3RpbWVzdGFtcHNfbnMYAiADKAMSDQoFdG90YWwYAyABKAUiSQoPR2V0Rmxvd3NSZXF1ZXN0EicKBmZpbHRlchgBIAEoCzIXLm1pdG1mbG93LnYxLkZsb3dGaWx0ZXISDQoFbGltaXQYAiABKAUiTAoQR2V0Rmxvd3NSZXNwb25zZRImCgRmbG93GAEgASgLMhgubWl0bWZsb3cudjEuRmxvd1N1bW1hcnkSEAoIc2VxdWVuY2UYAiABKAQicQoSU3RyZWFtRmxvd3NSZXF1ZXN0EhoKEnNpbmNlX3RpbWVzdGFtcF9ucxgBIAEoAxInCgZmaWx0ZXIYAiABKAsyFy5taXRtZmxvdy52MS5GbG93RmlsdGVyEhYKDnNpbmNlX3NlcXVlbmNlGAMgASgEIngKE1N0cmVhbUZsb3dzUmVzcG9uc2USKAoEZmxvdxgBIAEoCzIYLm1pdG1mbG93LnYxLkZsb3dTdW1tYXJ5SAASKwoJa2VlcGFsaXZlGAIgASgLMhYubWl0bWZsb3cudjEuS2VlcGFsaXZlSABCCgoIcmVzcG9uc2UiIAoJS2VlcGFsaXZlEhMKC2ludGVydmFsX21zGAEgASgDIvoBChFVcGRhdGVGbG93UmVxdWVzdBIPCgdmbG93X2lkGAEgASgJEhUKBnBpbm5lZBgCIAEoCEIFqgECCAESEwoEbm90ZRgDIAEoCUIFqgECCAESTwoIbWV0YWRhdGEYBCADKAsyLC5taXRtZmxvdy52MS5VcGRhdGVGbG93UmVxdWVzdC5NZXRhZGF0YUVudHJ5Qg+6SAyaAQkiB3IFEAEYgAESDgoGc2hhcmVkGAUgASgIEhYKB3ByaXZhdGUYBiABKAhCBaoBAggBGi8KDU1ldGFkYXRhRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASI8ChJVcGRhdGVGbG93UmVzcG9uc2USJgoEZmxvdxgBIAEoCzIYLm1pdG1mbG93LnYxLkZsb3dTdW1tYXJ5IjMKEkRlbGV0ZUZsb3dzUmVxdWVzdBIQCghmbG93X2lkcxgBIAMoCRILCgNhbGwYAiABKAgiSgoTRGVsZXRlRmxvd3NSZXNwb25zZRINCgVjb3VudBgBIAEoAxIQCghmbG93X2lkcxgCIAMoCRISCgpyZXN0b3JhYmxlGAMgASgIIsUCChJFeHBvcnRGbG93c1JlcXVlc3QSEAoIZmxvd19pZHMYASADKAkSKQoGZm9ybWF0GAIgASgOMhkubWl0bWZsb3cudjEuRXhwb3J0Rm9ybWF0EhEKCWFub255bWl6ZRgDIAEoCBIYChBzaGlmdF90aW1lc3RhbXBzGAQgASgIEikKBWVwb2NoGAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBInCgZmaWx0ZXIYBiABKAsyFy5taXRtZmxvdy52MS5GbG93RmlsdGVyEhMKC2ZpbHRlcl9leHByGAcgASgJEi4KCnN0YXJ0X3RpbWUYCCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEiwKCGVuZF90aW1lGAkgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCI1ChNFeHBvcnRGbG93c1Jlc3BvbnNlEgwKBGRhdGEYASABKAwSEAoIZmlsZW5hbWUYAiABKAkiIgoSSW1wb3J0Rmxvd3NSZXF1ZXN0EgwKBGRhdGEYASABKAwiJAoTSW1wb3J0Rmxvd3NSZXNwb25zZRINCgVjb3VudBgBIAEoAyJEChhDcmVhdGVTaGFyZUJ1bmRsZVJlcXVlc3QSGAoHZmxvd19pZBgBIAEoCUIHukgEcgIQARIOCgZyZWRhY3QYAiABKAgiPQoZQ3JlYXRlU2hhcmVCdW5kbGVSZXNwb25zZRIOCgZidW5kbGUYASABKAkSEAoIZmlsZW5hbWUYAiABKAkifwoPU2Vzc2lvblNlbGVjdG9yEg4KBmZpbHRlchgBIAEoCRIuCgpzdGFydF90aW1lGAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIsCghlbmRfdGltZRgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiQwoSU2V0QmFzZWxpbmVSZXF1ZXN0Ei0KB3Nlc3Npb24YASABKAsyHC5taXRtZmxvdy52MS5TZXNzaW9uU2VsZWN0b3IiJAoTU2V0QmFzZWxpbmVSZXNwb25zZRINCgVjb3VudBgBIAEoAyJHChZDb21wYXJlU2Vzc2lvbnNSZXF1ZXN0Ei0KB3Nlc3Npb24YASABKAsyHC5taXRtZmxvdy52MS5TZXNzaW9uU2VsZWN0b3IifwoXQ29tcGFyZVNlc3Npb25zUmVzcG9uc2USNAoLcmVncmVzc2lvbnMYASADKAsyHy5taXRtZmxvdy52MS5CYXNlbGluZUNvbXBhcmlzb24SFQoNbWF0Y2hlZF9jb3VudBgCIAEoAxIXCg91bm1hdGNoZWRfY291bnQYAyABKAMikwEKEkJhc2VsaW5lQ29tcGFyaXNvbhIPCgdmbG93X2lkGAEgASgJEhgKEGJhc2VsaW5lX2Zsb3dfaWQYAiABKAkSDgoGbWV0aG9kGAMgASgJEgwKBHBhdGgYBCABKAkSNAoLZGlmZmVyZW5jZXMYBSADKAsyHy5taXRtZmxvdy52MS5CYXNlbGluZURpZmZlcmVuY2UieAoSQmFzZWxpbmVEaWZmZXJlbmNlEjEKBGtpbmQYASABKA4yIy5taXRtZmxvdy52MS5CYXNlbGluZURpZmZlcmVuY2VLaW5kEg0KBWZpZWxkGAIgASgJEhAKCGJhc2VsaW5lGAMgASgJEg4KBmFjdHVhbBgEIAEoCSIjChNDcmVhdGVCYWNrdXBSZXF1ZXN0EgwKBHBhdGgYASABKAkiRwoUQ3JlYXRlQmFja3VwUmVzcG9uc2USDQoFY2h1bmsYASABKAwSDAoEcGF0aBgCIAEoCRISCgpmbG93X2NvdW50GAMgASgDIlEKFFJlc3RvcmVCYWNrdXBSZXF1ZXN0Eg4KBGRhdGEYASABKAxIABIOCgRwYXRoGAIgASgJSAASDwoHcmVwbGFjZRgDIAEoCEIICgZzb3VyY2UiJgoVUmVzdG9yZUJhY2t1cFJlc3BvbnNlEg0KBWNvdW50GAEgASgDIk4KFFNlYXJjaEFyY2hpdmVSZXF1ZXN0EicKBmZpbHRlchgBIAEoCzIXLm1pdG1mbG93LnYxLkZsb3dGaWx0ZXISDQoFbGltaXQYAiABKAUiPwoVU2VhcmNoQXJjaGl2ZVJlc3BvbnNlEiYKBGZsb3cYASABKAsyGC5taXRtZmxvdy52MS5GbG93U3VtbWFyeSI8ChtSZXN0b3JlQXJjaGl2ZWRGbG93c1JlcXVlc3QSEAoIZmxvd19pZHMYASADKAkSCwoDcGluGAIgASgIIkcKHFJlc3RvcmVBcmNoaXZlZEZsb3dzUmVzcG9uc2USJwoFZmxvd3MYASADKAsyGC5taXRtZmxvdy52MS5GbG93U3VtbWFyeSInChNSZXN0b3JlRmxvd3NSZXF1ZXN0EhAKCGZsb3dfaWRzGAEgAygJIj8KFFJlc3RvcmVGbG93c1Jlc3BvbnNlEicKBWZsb3dzGAEgAygLMhgubWl0bWZsb3cudjEuRmxvd1N1bW1hcnkiUgoVVXBsb2FkRmxvd0JvZHlSZXF1ZXN0EhgKB2Zsb3dfaWQYASABKAlCB7pIBHICEAESEAoIcmVzcG9uc2UYAiABKAgSDQoFY2h1bmsYAyABKAwiNwoWVXBsb2FkRmxvd0JvZHlSZXNwb25zZRIOCgZib2RpZXMYASABKAMSDQoFYnl0ZXMYAiABKAMirgEKCkF1ZGl0RXZlbnQSKAoEdGltZRgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASDQoFYWN0b3IYAiABKAkSDAoEcGVlchgDIAEoCRIoCgZhY3Rpb24YBCABKA4yGC5taXRtZmxvdy52MS5BdWRpdEFjdGlvbhIQCghmbG93X2lkcxgFIAMoCRINCgVjb3VudBgGIAEoAxIOCgZkZXRhaWwYByABKAkimAEKFkxpc3RBdWRpdEV2ZW50c1JlcXVlc3QSGQoFbGltaXQYASABKAVCCrpIBxoFGJBOKAASKQoHYWN0aW9ucxgCIAMoDjIYLm1pdG1mbG93LnYxLkF1ZGl0QWN0aW9uEg0KBWFjdG9yGAMgASgJEikKBXNpbmNlGAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCJCChdMaXN0QXVkaXRFdmVudHNSZXNwb25zZRInCgZldmVudHMYASADKAsyFy5taXRtZmxvdy52MS5BdWRpdEV2ZW50IhgKFkxpc3RTdWJzY3JpYmVyc1JlcXVlc3QiRwoXTGlzdFN1YnNjcmliZXJzUmVzcG9uc2USLAoLc3Vic2NyaWJlcnMYASADKAsyFy5taXRtZmxvdy52MS5TdWJzY3JpYmVyIqUBCgpTdWJzY3JpYmVyEgoKAmlkGAEgASgJEjAKDGNvbm5lY3RfdGltZRgCIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASDAoEcGVlchgDIAEoCRInCgZmaWx0ZXIYBCABKAsyFy5taXRtZmxvdy52MS5GbG93RmlsdGVyEhEKCWRlbGl2ZXJlZBgFIAEoAxIPCgdkcm9wcGVkGAYgASgDIhYKFEdldFNlcnZlckluZm9SZXF1ZXN0ItIEChVHZXRTZXJ2ZXJJbmZvUmVzcG9uc2USDwoHdmVyc2lvbhgBIAEoCRISCgpnb192ZXJzaW9uGAIgASgJEhQKDHZjc19yZXZpc2lvbhgDIAEoCRIsCgh2Y3NfdGltZRgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLgoKc3RhcnRfdGltZRgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASEQoJdXB0aW1lX21zGAYgASgDEhEKCW1heF9mbG93cxgHIAEoBRIWCg5tYXhfYm9keV9ieXRlcxgIIAEoAxIWCg5ibG9iX3RocmVzaG9sZBgJIAEoAxIQCghkYXRhX2RpchgKIAEoCRITCgthcmNoaXZlX2RpchgLIAEoCRISCgpiYWNrdXBfZGlyGAwgASgJEhIKCmZsb3dfY291bnQYDSABKAMSRwoLZmxvd19jb3VudHMYDiADKAsyMi5taXRtZmxvdy52MS5HZXRTZXJ2ZXJJbmZvUmVzcG9uc2UuRmxvd0NvdW50c0VudHJ5Eh0KFWRlc2NyaXB0b3JfZmlsZV9jb3VudBgPIAEoBRIYChBzdWJzY3JpYmVyX2NvdW50GBAgASgFEiAKGG1heF9pbmdlc3RfbWVzc2FnZV9ieXRlcxgRIAEoAxIkChxzdHJlYW1fa2VlcGFsaXZlX2ludGVydmFsX21zGBIgASgDGjEKD0Zsb3dDb3VudHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAM6AjgBIvcBChJTZW5kUmVxdWVzdFJlcXVlc3QSIQoGbWV0aG9kGAEgASgJQhG6SA5yDBgUMgheW0EtWl0qJBIVCgN1cmwYAiABKAlCCLpIBXIDiAEBEj0KB2hlYWRlcnMYAyADKAsyLC5taXRtZmxvdy52MS5TZW5kUmVxdWVzdFJlcXVlc3QuSGVhZGVyc0VudHJ5EgwKBGJvZHkYBCABKAwSDQoFcHJveHkYBSABKAkSGwoKdGltZW91dF9tcxgGIAEoA0IHukgEIgIoABouCgxIZWFkZXJzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASI2ChNTZW5kUmVxdWVzdFJlc3BvbnNlEh8KBGZsb3cYASABKAsyES5taXRtZmxvdy52MS5GbG93IkEKGEdldENvb2tpZVRpbWVsaW5lUmVxdWVzdBIVCgRuYW1lGAEgASgJQge6SARyAhABEg4KBmRvbWFpbhgCIAEoCSJFChlHZXRDb29raWVUaW1lbGluZVJlc3BvbnNlEigKBmV2ZW50cxgBIAMoCzIYLm1pdG1mbG93LnYxLkNvb2tpZUV2ZW50IsYCCgtDb29raWVFdmVudBIqCgR0eXBlGAEgASgOMhwubWl0bWZsb3cudjEuQ29va2llRXZlbnRUeXBlEg8KB2Zsb3dfaWQYAiABKAkSLQoJdGltZXN0YW1wGAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIMCgRob3N0GAQgASgJEg0KBXZhbHVlGAUgASgJEg4KBmRvbWFpbhgGIAEoCRIMCgRwYXRoGAcgASgJEisKB2V4cGlyZXMYCCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEg4KBnNlY3VyZRgJIAEoCBIRCglodHRwX29ubHkYCiABKAgSEQoJc2FtZV9zaXRlGAsgASgJEhUKDXZhbHVlX2NoYW5nZWQYDCABKAgSFgoOZXhwaXJ5X2NoYW5nZWQYDSABKAgiMwoXR2V0UmVkaXJlY3RDaGFpblJlcXVlc3QSGAoHZmxvd19pZBgBIAEoCUIHukgEcgIQASJCChhHZXRSZWRpcmVjdENoYWluUmVzcG9uc2USJgoEaG9wcxgBIAMoCzIYLm1pdG1mbG93LnYxLlJlZGlyZWN0SG9wImIKC1JlZGlyZWN0SG9wEg8KB2Zsb3dfaWQYASABKAkSDgoGbWV0aG9kGAIgASgJEgsKA3VybBgDIAEoCRITCgtzdGF0dXNfY29kZRgEIAEoBRIQCghsb2NhdGlvbhgFIAEoCSIzChdEaWZmV2l0aFByZXZpb3VzUmVxdWVzdBIYCgdmbG93X2lkGAEgASgJQge6SARyAhABIqMBChhEaWZmV2l0aFByZXZpb3VzUmVzcG9uc2USKgoIcHJldmlvdXMYASABKAsyGC5taXRtZmxvdy52MS5GbG93U3VtbWFyeRIsCgdyZXF1ZXN0GAIgAygLMhsubWl0bWZsb3cudjEuRmxvd0RpZmZlcmVuY2USLQoIcmVzcG9uc2UYAyADKAsyGy5taXRtZmxvdy52MS5GbG93RGlmZmVyZW5jZSKhAQoPVG9wRmxvd3NSZXF1ZXN0EicKBmZpbHRlchgBIAEoCzIXLm1pdG1mbG93LnYxLkZsb3dGaWx0ZXISEwoLZmlsdGVyX2V4cHIYAiABKAkSNQoFb3JkZXIYAyABKA4yGi5taXRtZmxvdy52MS5Ub3BGbG93c09yZGVyQgq6SAeCAQQQASAAEhkKBWxpbWl0GAQgASgFQgq6SAcaBRjoBygAIjsKEFRvcEZsb3dzUmVzcG9uc2USJwoFZmxvd3MYASADKAsyGC5taXRtZmxvdy52MS5GbG93U3VtbWFyeSL2AQoYR2V0QmFuZHdpZHRoU3RhdHNSZXF1ZXN0EicKBmZpbHRlchgBIAEoCzIXLm1pdG1mbG93LnYxLkZsb3dGaWx0ZXISEwoLZmlsdGVyX2V4cHIYAiABKAkSLgoKc3RhcnRfdGltZRgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLAoIZW5kX3RpbWUYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEiMKDmJ1Y2tldF9zZWNvbmRzGAUgASgFQgu6SAgaBhiAowUoABIZCgVsaW1pdBgGIAEoBUIKukgHGgUY6AcoACKhAQoZR2V0QmFuZHdpZHRoU3RhdHNSZXNwb25zZRIqCgVob3N0cxgBIAMoCzIbLm1pdG1mbG93LnYxLkJhbmR3aWR0aFVzYWdlEiwKB2NsaWVudHMYAiADKAsyGy5taXRtZmxvdy52MS5CYW5kd2lkdGhVc2FnZRIqCgV0b3RhbBgDIAEoCzIbLm1pdG1mbG93LnYxLkJhbmR3aWR0aFVzYWdlIocBCg5CYW5kd2lkdGhVc2FnZRILCgNrZXkYASABKAkSEgoKYnl0ZXNfc2VudBgCIAEoAxIWCg5ieXRlc19yZWNlaXZlZBgDIAEoAxINCgVmbG93cxgEIAEoAxItCgdidWNrZXRzGAUgAygLMhwubWl0bWZsb3cudjEuQmFuZHdpZHRoQnVja2V0IncKD0JhbmR3aWR0aEJ1Y2tldBIpCgVzdGFydBgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASEgoKYnl0ZXNfc2VudBgCIAEoAxIWCg5ieXRlc19yZWNlaXZlZBgDIAEoAxINCgVmbG93cxgEIAEoAyJxCg5GbG93RGlmZmVyZW5jZRItCgRraW5kGAEgASgOMh8ubWl0bWZsb3cudjEuRmxvd0RpZmZlcmVuY2VLaW5kEg0KBWZpZWxkGAIgASgJEhAKCHByZXZpb3VzGAMgASgJEg8KB2N1cnJlbnQYBCABKAkiKwoHRmxvd1NldBIgCgVmbG93cxgBIAMoCzIRLm1pdG1mbG93LnYxLkZsb3cipAMKC0Zsb3dTdW1tYXJ5EgoKAmlkGAEgASgJEgwKBHR5cGUYAiABKAkSMwoPdGltZXN0YW1wX3N0YXJ0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIOCgZwaW5uZWQYBCABKAgSDAoEbm90ZRgFIAEoCRIsCgRodHRwGAYgASgLMhwubWl0bWZsb3cudjEuSHR0cEZsb3dTdW1tYXJ5SAASKgoDZG5zGAcgASgLMhsubWl0bWZsb3cudjEuRG5zRmxvd1N1bW1hcnlIABIqCgN0Y3AYCCABKAsyGy5taXRtZmxvdy52MS5UY3BGbG93U3VtbWFyeUgAEioKA3VkcBgJIAEoCzIbLm1pdG1mbG93LnYxLlVkcEZsb3dTdW1tYXJ5SAASEAoIc2VxdWVuY2UYCiABKAQSDQoFb3duZXIYCyABKAkSDwoHcHJpdmF0ZRgMIAEoCBIQCghwcm90b2NvbBgNIAEoCRInCgZ0b3RhbHMYDiABKAsyFy5taXRtZmxvdy52MS5GbG93VG90YWxzQgkKB3N1bW1hcnkiqAIKD0h0dHBGbG93U3VtbWFyeRIOCgZtZXRob2QYASABKAkSCwoDdXJsGAIgASgJEhMKC3N0YXR1c19jb2RlGAMgASgFEhMKC2R1cmF0aW9uX21zGAQgASgDEh4KFnJlcXVlc3RfY29udGVudF9sZW5ndGgYBSABKAMSHwoXcmVzcG9uc2VfY29udGVudF9sZW5ndGgYBiABKAMSHAoUY2xpZW50X3BlZXJuYW1lX2hvc3QYByABKAkSGwoTc2VydmVyX2FkZHJlc3NfaG9zdBgIIAEoCRIbChNzZXJ2ZXJfYWRkcmVzc19wb3J0GAkgASgNEhwKFGNsaWVudF9wZWVybmFtZV9wb3J0GAogASgNEhcKD3Jlc3BvbnNlX3NoYTI1NhgLIAEoCSJUCg5EbnNGbG93U3VtbWFyeRIVCg1xdWVzdGlvbl9uYW1lGAEgASgJEhwKFGNsaWVudF9wZWVybmFtZV9ob3N0GAIgASgJEg0KBWVycm9yGAMgASgJIpUBCg5UY3BGbG93U3VtbWFyeRIbChNzZXJ2ZXJfYWRkcmVzc19ob3N0GAEgASgJEhsKE3NlcnZlcl9hZGRyZXNzX3BvcnQYAiABKA0SHAoUY2xpZW50X3BlZXJuYW1lX2hvc3QYAyABKAkSHAoUY2xpZW50X3BlZXJuYW1lX3BvcnQYBCABKA0SDQoFZXJyb3IYBSABKAkilQEKDlVkcEZsb3dTdW1tYXJ5EhsKE3NlcnZlcl9hZGRyZXNzX2hvc3QYASABKAkSGwoTc2VydmVyX2FkZHJlc3NfcG9ydBgCIAEoDRIcChRjbGllbnRfcGVlcm5hbWVfaG9zdBgDIAEoCRIcChRjbGllbnRfcGVlcm5hbWVfcG9ydBgEIAEoDRINCgVlcnJvchgFIAEoCSKSBQoERmxvdxIrCglodHRwX2Zsb3cYASABKAsyFi5taXRtcHJveHkudjEuSFRUUEZsb3dIABIpCgh0Y3BfZmxvdxgCIAEoCzIVLm1pdG1wcm94eS52MS5UQ1BGbG93SAASKQoIdWRwX2Zsb3cYAyABKAsyFS5taXRtcHJveHkudjEuVURQRmxvd0gAEikKCGRuc19mbG93GAQgASgLMhUubWl0bXByb3h5LnYxLkROU0Zsb3dIABIzCg9odHRwX2Zsb3dfZXh0cmEYBSABKAsyGi5taXRtZmxvdy52MS5IVFRQRmxvd0V4dHJhEg4KBnBpbm5lZBgGIAEoCBIMCgRub3RlGAcgASgJEjcKEXN0cmVhbV9mbG93X2V4dHJhGAggASgLMhwubWl0bWZsb3cudjEuU3RyZWFtRmxvd0V4dHJhEjEKCG1ldGFkYXRhGAkgAygLMh8ubWl0bWZsb3cudjEuRmxvdy5NZXRhZGF0YUVudHJ5EkAKEHVzZXJfYW5ub3RhdGlvbnMYCiADKAsyJi5taXRtZmxvdy52MS5GbG93LlVzZXJBbm5vdGF0aW9uc0VudHJ5Eg0KBW93bmVyGAsgASgJEg8KB3ByaXZhdGUYDCABKAgSMQoOZG5zX2Zsb3dfZXh0cmEYDSABKAsyGS5taXRtZmxvdy52MS5EbnNGbG93RXh0cmEaLwoNTWV0YWRhdGFFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBGk8KFFVzZXJBbm5vdGF0aW9uc0VudHJ5EgsKA2tleRgBIAEoCRImCgV2YWx1ZRgCIAEoCzIXLm1pdG1mbG93LnYxLkFubm90YXRpb246AjgBQgYKBGZsb3ciKgoKQW5ub3RhdGlvbhIOCgZwaW5uZWQYASABKAgSDAoEbm90ZRgCIAEoCSKbBQoNSFRUUEZsb3dFeHRyYRIsCgdyZXF1ZXN0GAEgASgLMhsubWl0bWZsb3cudjEuTWVzc2FnZURldGFpbHMSLQoIcmVzcG9uc2UYAiABKAsyGy5taXRtZmxvdy52MS5NZXNzYWdlRGV0YWlscxIqCgp1c2VyX2FnZW50GAMgASgLMhYubWl0bWZsb3cudjEuVXNlckFnZW50EigKCnNlcnZlcl9nZW8YBCABKAsyFC5taXRtZmxvdy52MS5HZW9JbmZvEhcKD3NlcnZlcl9ob3N0bmFtZRgFIAEoCRI7ChZzZXJ2ZXJfaG9zdG5hbWVfc291cmNlGAYgASgOMhsubWl0bWZsb3cudjEuSG9zdG5hbWVTb3VyY2USNwoRc2VjdXJpdHlfZmluZGluZ3MYByADKAsyHC5taXRtZmxvdy52MS5TZWN1cml0eUZpbmRpbmcSJAoEY29ycxgIIAEoCzIWLm1pdG1mbG93LnYxLkNvcnNDaGVjaxIxCghiYXNlbGluZRgJIAEoCzIfLm1pdG1mbG93LnYxLkJhc2VsaW5lQ29tcGFyaXNvbhIYChBtYW5pZmVzdF9mbG93X2lkGAogASgJEjcKEndlYnNvY2tldF9tZXNzYWdlcxgLIAMoCzIbLm1pdG1mbG93LnYxLk1lc3NhZ2VEZXRhaWxzEigKBWh0dHAyGAwgASgLMhkubWl0bWZsb3cudjEuSFRUUDJEZXRhaWxzEjcKEWludGVyaW1fcmVzcG9uc2VzGA0gAygLMhwubWl0bWZsb3cudjEuSW50ZXJpbVJlc3BvbnNlEhAKCHByb3RvY29sGA4gASgJEicKBnRvdGFscxgPIAEoCzIXLm1pdG1mbG93LnYxLkZsb3dUb3RhbHMiUAoKRmxvd1RvdGFscxIVCg1yZXF1ZXN0X2J5dGVzGAEgASgDEhYKDnJlc3BvbnNlX2J5dGVzGAIgASgDEhMKC2R1cmF0aW9uX21zGAMgASgDIsEBCg9JbnRlcmltUmVzcG9uc2USEwoLc3RhdHVzX2NvZGUYASABKAUSOgoHaGVhZGVycxgCIAMoCzIpLm1pdG1mbG93LnYxLkludGVyaW1SZXNwb25zZS5IZWFkZXJzRW50cnkSLQoJdGltZXN0YW1wGAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBouCgxIZWFkZXJzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASLAAQoMSFRUUDJEZXRhaWxzEjgKFnJlcXVlc3RfcHNldWRvX2hlYWRlcnMYASADKAsyGC5taXRtZmxvdy52MS5IZWFkZXJGaWVsZBI5ChdyZXNwb25zZV9wc2V1ZG9faGVhZGVycxgCIAMoCzIYLm1pdG1mbG93LnYxLkhlYWRlckZpZWxkEhwKFHJlcXVlc3RfaGVhZGVyX29yZGVyGAMgAygJEh0KFXJlc3BvbnNlX2hlYWRlcl9vcmRlchgEIAMoCSIqCgtIZWFkZXJGaWVsZBIMCgRuYW1lGAEgASgJEg0KBXZhbHVlGAIgASgJIlsKCUNvcnNDaGVjaxIOCgZvcmlnaW4YASABKAkSEQoJcHJlZmxpZ2h0GAIgASgIEhkKEXByZWZsaWdodF9mbG93X2lkGAMgASgJEhAKCHByb2JsZW1zGAQgAygJImIKD1NlY3VyaXR5RmluZGluZxIOCgZoZWFkZXIYASABKAkSLgoIc2V2ZXJpdHkYAiABKA4yHC5taXRtZmxvdy52MS5GaW5kaW5nU2V2ZXJpdHkSDwoHbWVzc2FnZRgDIAEoCSJbCgdHZW9JbmZvEhQKDGNvdW50cnlfY29kZRgBIAEoCRIUCgxjb3VudHJ5X25hbWUYAiABKAkSCwoDYXNuGAMgASgNEhcKD2FzX29yZ2FuaXphdGlvbhgEIAEoCSKTAQoJVXNlckFnZW50Eg8KB2Jyb3dzZXIYASABKAkSFwoPYnJvd3Nlcl92ZXJzaW9uGAIgASgJEgoKAm9zGAMgASgJEhIKCm9zX3ZlcnNpb24YBCABKAkSDgoGZGV2aWNlGAUgASgJEiwKC2RldmljZV90eXBlGAYgASgOMhcubWl0bWZsb3cudjEuRGV2aWNlVHlwZSL7AQoPU3RyZWFtRmxvd0V4dHJhEi0KCG1lc3NhZ2VzGAEgAygLMhsubWl0bWZsb3cudjEuTWVzc2FnZURldGFpbHMSKAoKc2VydmVyX2dlbxgCIAEoCzIULm1pdG1mbG93LnYxLkdlb0luZm8SFwoPc2VydmVyX2hvc3RuYW1lGAMgASgJEjsKFnNlcnZlcl9ob3N0bmFtZV9zb3VyY2UYBCABKA4yGy5taXRtZmxvdy52MS5Ib3N0bmFtZVNvdXJjZRIQCghwcm90b2NvbBgFIAEoCRInCgZ0b3RhbHMYBiABKAsyFy5taXRtZmxvdy52MS5GbG93VG90YWxzInUKDERuc0Zsb3dFeHRyYRIqCglhbm9tYWxpZXMYASADKAsyFy5taXRtZmxvdy52MS5EbnNBbm9tYWx5EhAKCHByb3RvY29sGAIgASgJEicKBnRvdGFscxgDIAEoCzIXLm1pdG1mbG93LnYxLkZsb3dUb3RhbHMiRwoKRG5zQW5vbWFseRIpCgRraW5kGAEgASgOMhsubWl0bWZsb3cudjEuRG5zQW5vbWFseUtpbmQSDgoGZGV0YWlsGAIgASgJIvYDCg5NZXNzYWdlRGV0YWlscxIWCg50ZXh0dWFsX2ZyYW1lcxgBIAMoCRIeChZlZmZlY3RpdmVfY29udGVudF90eXBlGAIgASgJEhEKCWJvZHlfc2l6ZRgDIAEoAxIQCghibG9iX2tleRgEIAEoCRIRCgl0cnVuY2F0ZWQYBSABKAgSGwoTZnJhbWVfdGltZXN0YW1wc19ucxgGIAMoAxIOCgZzaGEyNTYYByABKAkSJgoEc29hcBgIIAEoCzIYLm1pdG1mbG93LnYxLlNvYXBNZXNzYWdlEhQKDHJlY29yZF9jb3VudBgJIAEoBRIrCgtmb3JtX2ZpZWxkcxgKIAMoCzIWLm1pdG1mbG93LnYxLkZvcm1GaWVsZBIlCgVtZWRpYRgLIAEoCzIWLm1pdG1mbG93LnYxLk1lZGlhSW5mbxIdChVkZWNsYXJlZF9jb250ZW50X3R5cGUYDCABKAkSHQoVZGV0ZWN0ZWRfY29udGVudF90eXBlGA0gASgJEiwKC2dycGNfc3RhdHVzGA4gASgLMhcubWl0bWZsb3cudjEuR3JwY1N0YXR1cxITCgtmcmFtZV9jb3VudBgPIAEoBRI0Cg9iaW5hcnlfbWV0YWRhdGEYECADKAsyGy5taXRtZmxvdy52MS5CaW5hcnlNZXRhZGF0YSJLCg5CaW5hcnlNZXRhZGF0YRILCgNrZXkYASABKAkSDwoHdHJhaWxlchgCIAEoCBINCgV2YWx1ZRgDIAEoDBIMCgR0ZXh0GAQgASgJIjkKCkdycGNTdGF0dXMSDAoEY29kZRgBIAEoDRIMCgRuYW1lGAIgASgJEg8KB21lc3NhZ2UYAyABKAkivwEKCU1lZGlhSW5mbxIOCgZmb3JtYXQYASABKAkSDQoFd2lkdGgYAiABKAUSDgoGaGVpZ2h0GAMgASgFEhMKC2R1cmF0aW9uX21zGAQgASgDEi4KBGV4aWYYBSADKAsyIC5taXRtZmxvdy52MS5NZWRpYUluZm8uRXhpZkVudHJ5EhEKCXRodW1ibmFpbBgGIAEoDBorCglFeGlmRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASIoCglGb3JtRmllbGQSDAoEbmFtZRgBIAEoCRINCgV2YWx1ZRgCIAEoCSJoCgtTb2FwTWVzc2FnZRIPCgd2ZXJzaW9uGAEgASgJEg4KBmFjdGlvbhgCIAEoCRIRCglvcGVyYXRpb24YAyABKAkSJQoFZmF1bHQYBCABKAsyFi5taXRtZmxvdy52MS5Tb2FwRmF1bHQiSAoJU29hcEZhdWx0EgwKBGNvZGUYASABKAkSDgoGcmVhc29uGAIgASgJEg0KBWFjdG9yGAMgASgJEg4KBmRldGFpbBgEIAEoCSIWChRHZXRQcm94eVJ1bGVzUmVxdWVzdCI/ChVHZXRQcm94eVJ1bGVzUmVzcG9uc2USJgoFcnVsZXMYASABKAsyFy5taXRtZmxvdy52MS5Qcm94eVJ1bGVzIj4KFFNldFByb3h5UnVsZXNSZXF1ZXN0EiYKBXJ1bGVzGAEgASgLMhcubWl0bWZsb3cudjEuUHJveHlSdWxlcyI/ChVTZXRQcm94eVJ1bGVzUmVzcG9uc2USJgoFcnVsZXMYASABKAsyFy5taXRtZmxvdy52MS5Qcm94eVJ1bGVzIhgKFldhdGNoUHJveHlSdWxlc1JlcXVlc3QibAoXV2F0Y2hQcm94eVJ1bGVzUmVzcG9uc2USJgoFcnVsZXMYASABKAsyFy5taXRtZmxvdy52MS5Qcm94eVJ1bGVzEikKCWtlZXBhbGl2ZRgCIAEoCzIWLm1pdG1mbG93LnYxLktlZXBhbGl2ZSJoCgpQcm94eVJ1bGVzEiwKCW92ZXJyaWRlcxgBIAMoCzIZLm1pdG1mbG93LnYxLk92ZXJyaWRlUnVsZRIsCgl0aHJvdHRsZXMYAiADKAsyGS5taXRtZmxvdy52MS5UaHJvdHRsZVJ1bGUiVQoOUHJveHlSdWxlTWF0Y2gSDAoEaG9zdBgBIAEoCRIMCgRwYXRoGAIgASgJEicKB21ldGhvZHMYAyADKAlCFrpIE5IBECIOcgwYFDIIXltBLVpdKyQiiQIKDE92ZXJyaWRlUnVsZRIVCgRuYW1lGAEgASgJQge6SARyAhABEhAKCGRpc2FibGVkGAIgASgIEioKBW1hdGNoGAMgASgLMhsubWl0bWZsb3cudjEuUHJveHlSdWxlTWF0Y2gSHwoLc3RhdHVzX2NvZGUYBCABKAVCCrpIBxoFGNcEKAASNwoHaGVhZGVycxgFIAMoCzImLm1pdG1mbG93LnYxLk92ZXJyaWRlUnVsZS5IZWFkZXJzRW50cnkSDAoEYm9keRgGIAEoDBIMCgRmaWxlGAcgASgJGi4KDEhlYWRlcnNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIogCCgxUaHJvdHRsZVJ1bGUSFQoEbmFtZRgBIAEoCUIHukgEcgIQARIQCghkaXNhYmxlZBgCIAEoCBIqCgVtYXRjaBgDIAEoCzIbLm1pdG1mbG93LnYxLlByb3h5UnVsZU1hdGNoEiUKEHJlcXVlc3RfZGVsYXlfbXMYBCABKAVCC7pICBoGGMDPJCgAEiYKEXJlc3BvbnNlX2RlbGF5X21zGAUgASgFQgu6SAgaBhjAzyQoABIoChd1cGxvYWRfYnl0ZXNfcGVyX3NlY29uZBgGIAEoA0IHukgEIgIoABIqChlkb3dubG9hZF9ieXRlc19wZXJfc2Vjb25kGAcgASgDQge6SAQiAigAKskCCgxFeHBvcnRGb3JtYXQSHQoZRVhQT1JUX0ZPUk1BVF9VTlNQRUNJRklFRBAAEhUKEUVYUE9SVF9GT1JNQVRfSEFSEAESFgoSRVhQT1JUX0ZPUk1BVF9KU09OEAISFwoTRVhQT1JUX0ZPUk1BVF9QUk9UTxADEhUKEUVYUE9SVF9GT1JNQVRfU0FaEAQSGQoVRVhQT1JUX0ZPUk1BVF9DSEFSTEVTEAUSHQoZRVhQT1JUX0ZPUk1BVF9HUlBDX0ZSQU1FUxAGEhkKFUVYUE9SVF9GT1JNQVRfR1JQQ1VSTBAHEhoKFkVYUE9SVF9GT1JNQVRfQlVGX0NVUkwQCBIXChNFWFBPUlRfRk9STUFUX0pTT05MEAkSFQoRRVhQT1JUX0ZPUk1BVF9DU1YQChIaChZFWFBPUlRfRk9STUFUX01BUktET1dOEAsqrwEKFkJhc2VsaW5lRGlmZmVyZW5jZUtpbmQSKAokQkFTRUxJTkVfRElGRkVSRU5DRV9LSU5EX1VOU1BFQ0lGSUVEEAASIwofQkFTRUxJTkVfRElGRkVSRU5DRV9LSU5EX1NUQVRVUxABEiMKH0JBU0VMSU5FX0RJRkZFUkVOQ0VfS0lORF9IRUFERVIQAhIhCh1CQVNFTElORV9ESUZGRVJFTkNFX0tJTkRfQk9EWRADKtUCCgtBdWRpdEFjdGlvbhIcChhBVURJVF9BQ1RJT05fVU5TUEVDSUZJRUQQABIXChNBVURJVF9BQ1RJT05fREVMRVRFEAESGwoXQVVESVRfQUNUSU9OX0RFTEVURV9BTEwQAhIUChBBVURJVF9BQ1RJT05fUElOEAMSFgoSQVVESVRfQUNUSU9OX1VOUElOEAQSFQoRQVVESVRfQUNUSU9OX05PVEUQBRIgChxBVURJVF9BQ1RJT05fVVBEQVRFX01FVEFEQVRBEAYSFwoTQVVESVRfQUNUSU9OX0VYUE9SVBAHEhgKFEFVRElUX0FDVElPTl9SRVNUT1JFEAgSFwoTQVVESVRfQUNUSU9OX0lNUE9SVBAJEiAKHEFVRElUX0FDVElPTl9TRVRfUFJPWFlfUlVMRVMQChIdChlBVURJVF9BQ1RJT05fU0VUX0JBU0VMSU5FEAsqigEKD0Nvb2tpZUV2ZW50VHlwZRIhCh1DT09LSUVfRVZFTlRfVFlQRV9VTlNQRUNJRklFRBAAEhkKFUNPT0tJRV9FVkVOVF9UWVBFX1NFVBABEhoKFkNPT0tJRV9FVkVOVF9UWVBFX1NFTlQQAhIdChlDT09LSUVfRVZFTlRfVFlQRV9ERUxFVEVEEAMqagoNVG9wRmxvd3NPcmRlchIfChtUT1BfRkxPV1NfT1JERVJfVU5TUEVDSUZJRUQQABIbChdUT1BfRkxPV1NfT1JERVJfU0xPV0VTVBABEhsKF1RPUF9GTE9XU19PUkRFUl9MQVJHRVNUEAIquwEKEkZsb3dEaWZmZXJlbmNlS2luZBIkCiBGTE9XX0RJRkZFUkVOQ0VfS0lORF9VTlNQRUNJRklFRBAAEh8KG0ZMT1dfRElGRkVSRU5DRV9LSU5EX1NUQVRVUxABEh4KGkZMT1dfRElGRkVSRU5DRV9LSU5EX1FVRVJZEAISHwobRkxPV19ESUZGRVJFTkNFX0tJTkRfSEVBREVSEAMSHQoZRkxPV19ESUZGRVJFTkNFX0tJTkRfQk9EWRAEKoUBCg9GaW5kaW5nU2V2ZXJpdHkSIAocRklORElOR19TRVZFUklUWV9VTlNQRUNJRklFRBAAEhkKFUZJTkRJTkdfU0VWRVJJVFlfSU5GTxABEhgKFEZJTkRJTkdfU0VWRVJJVFlfTE9XEAISGwoXRklORElOR19TRVZFUklUWV9NRURJVU0QAyqHAQoKRGV2aWNlVHlwZRIbChdERVZJQ0VfVFlQRV9VTlNQRUNJRklFRBAAEhcKE0RFVklDRV9UWVBFX0RFU0tUT1AQARIWChJERVZJQ0VfVFlQRV9NT0JJTEUQAhIWChJERVZJQ0VfVFlQRV9UQUJMRVQQAxITCg9ERVZJQ0VfVFlQRV9CT1QQBCrEAQoORG5zQW5vbWFseUtpbmQSIAocRE5TX0FOT01BTFlfS0lORF9VTlNQRUNJRklFRBAAEiMKH0ROU19BTk9NQUxZX0tJTkRfTlhET01BSU5fQlVSU1QQARIfChtETlNfQU5PTUFMWV9LSU5EX0xPTkdfTEFCRUwQAhImCiJETlNfQU5PTUFMWV9LSU5EX0hJR0hfRU5UUk9QWV9OQU1FEAMSIgoeRE5TX0FOT01BTFlfS0lORF9VTlVTVUFMX1FUWVBFEAQqcAoOSG9zdG5hbWVTb3VyY2USHwobSE9TVE5BTUVfU09VUkNFX1VOU1BFQ0lGSUVEEAASHAoYSE9TVE5BTUVfU09VUkNFX0ROU19GTE9XEAESHwobSE9TVE5BTUVfU09VUkNFX1JFVkVSU0VfRE5TEAIynhUKB1NlcnZpY2USSwoIR2V0Rmxvd3MSHC5taXRtZmxvdy52MS5HZXRGbG93c1JlcXVlc3QaHS5taXRtZmxvdy52MS5HZXRGbG93c1Jlc3BvbnNlIgAwARJUCgtTdHJlYW1GbG93cxIfLm1pdG1mbG93LnYxLlN0cmVhbUZsb3dzUmVxdWVzdBogLm1pdG1mbG93LnYxLlN0cmVhbUZsb3dzUmVzcG9uc2UiADABEk8KClVwZGF0ZUZsb3cSHi5taXRtZmxvdy52MS5VcGRhdGVGbG93UmVxdWVzdBofLm1pdG1mbG93LnYxLlVwZGF0ZUZsb3dSZXNwb25zZSIAElIKC0RlbGV0ZUZsb3dzEh8ubWl0bWZsb3cudjEuRGVsZXRlRmxvd3NSZXF1ZXN0GiAubWl0bWZsb3cudjEuRGVsZXRlRmxvd3NSZXNwb25zZSIAElIKC0V4cG9ydEZsb3dzEh8ubWl0bWZsb3cudjEuRXhwb3J0Rmxvd3NSZXF1ZXN0GiAubWl0bWZsb3cudjEuRXhwb3J0Rmxvd3NSZXNwb25zZSIAEkYKB0dldEZsb3cSGy5taXRtZmxvdy52MS5HZXRGbG93UmVxdWVzdBocLm1pdG1mbG93LnYxLkdldEZsb3dSZXNwb25zZSIAElIKC0dldEZsb3dCb2R5Eh8ubWl0bWZsb3cudjEuR2V0Rmxvd0JvZHlSZXF1ZXN0GiAubWl0bWZsb3cudjEuR2V0Rmxvd0JvZHlSZXNwb25zZSIAElgKDUdldEZsb3dGcmFtZXMSIS5taXRtZmxvdy52MS5HZXRGbG93RnJhbWVzUmVxdWVzdBoiLm1pdG1mbG93LnYxLkdldEZsb3dGcmFtZXNSZXNwb25zZSIAElIKC0ltcG9ydEZsb3dzEh8ubWl0bWZsb3cudjEuSW1wb3J0Rmxvd3NSZXF1ZXN0GiAubWl0bWZsb3cudjEuSW1wb3J0Rmxvd3NSZXNwb25zZSIAElcKDENyZWF0ZUJhY2t1cBIgLm1pdG1mbG93LnYxLkNyZWF0ZUJhY2t1cFJlcXVlc3QaIS5taXRtZmxvdy52MS5DcmVhdGVCYWNrdXBSZXNwb25zZSIAMAESWAoNUmVzdG9yZUJhY2t1cBIhLm1pdG1mbG93LnYxLlJlc3RvcmVCYWNrdXBSZXF1ZXN0GiIubWl0bWZsb3cudjEuUmVzdG9yZUJhY2t1cFJlc3BvbnNlIgASWgoNU2VhcmNoQXJjaGl2ZRIhLm1pdG1mbG93LnYxLlNlYXJjaEFyY2hpdmVSZXF1ZXN0GiIubWl0bWZsb3cudjEuU2VhcmNoQXJjaGl2ZVJlc3BvbnNlIgAwARJtChRSZXN0b3JlQXJjaGl2ZWRGbG93cxIoLm1pdG1mbG93LnYxLlJlc3RvcmVBcmNoaXZlZEZsb3dzUmVxdWVzdBopLm1pdG1mbG93LnYxLlJlc3RvcmVBcmNoaXZlZEZsb3dzUmVzcG9uc2UiABJVCgxSZXN0b3JlRmxvd3MSIC5taXRtZmxvdy52MS5SZXN0b3JlRmxvd3NSZXF1ZXN0GiEubWl0bWZsb3cudjEuUmVzdG9yZUZsb3dzUmVzcG9uc2UiABJYCg1HZXRTZXJ2ZXJJbmZvEiEubWl0bWZsb3cudjEuR2V0U2VydmVySW5mb1JlcXVlc3QaIi5taXRtZmxvdy52MS5HZXRTZXJ2ZXJJbmZvUmVzcG9uc2UiABJeCg9MaXN0U3Vic2NyaWJlcnMSIy5taXRtZmxvdy52MS5MaXN0U3Vic2NyaWJlcnNSZXF1ZXN0GiQubWl0bWZsb3cudjEuTGlzdFN1YnNjcmliZXJzUmVzcG9uc2UiABJeCg9MaXN0QXVkaXRFdmVudHMSIy5taXRtZmxvdy52MS5MaXN0QXVkaXRFdmVudHNSZXF1ZXN0GiQubWl0bWZsb3cudjEuTGlzdEF1ZGl0RXZlbnRzUmVzcG9uc2UiABJSCgtTZW5kUmVxdWVzdBIfLm1pdG1mbG93LnYxLlNlbmRSZXF1ZXN0UmVxdWVzdBogLm1pdG1mbG93LnYxLlNlbmRSZXF1ZXN0UmVzcG9uc2UiABJkChFHZXRDb29raWVUaW1lbGluZRIlLm1pdG1mbG93LnYxLkdldENvb2tpZVRpbWVsaW5lUmVxdWVzdBomLm1pdG1mbG93LnYxLkdldENvb2tpZVRpbWVsaW5lUmVzcG9uc2UiABJhChBHZXRSZWRpcmVjdENoYWluEiQubWl0bWZsb3cudjEuR2V0UmVkaXJlY3RDaGFpblJlcXVlc3QaJS5taXRtZmxvdy52MS5HZXRSZWRpcmVjdENoYWluUmVzcG9uc2UiABJkChFDcmVhdGVTaGFyZUJ1bmRsZRIlLm1pdG1mbG93LnYxLkNyZWF0ZVNoYXJlQnVuZGxlUmVxdWVzdBomLm1pdG1mbG93LnYxLkNyZWF0ZVNoYXJlQnVuZGxlUmVzcG9uc2UiABJSCgtTZXRCYXNlbGluZRIfLm1pdG1mbG93LnYxLlNldEJhc2VsaW5lUmVxdWVzdBogLm1pdG1mbG93LnYxLlNldEJhc2VsaW5lUmVzcG9uc2UiABJeCg9Db21wYXJlU2Vzc2lvbnMSIy5taXRtZmxvdy52MS5Db21wYXJlU2Vzc2lvbnNSZXF1ZXN0GiQubWl0bWZsb3cudjEuQ29tcGFyZVNlc3Npb25zUmVzcG9uc2UiABJdCg5VcGxvYWRGbG93Qm9keRIiLm1pdG1mbG93LnYxLlVwbG9hZEZsb3dCb2R5UmVxdWVzdBojLm1pdG1mbG93LnYxLlVwbG9hZEZsb3dCb2R5UmVzcG9uc2UiACgBEmEKEERpZmZXaXRoUHJldmlvdXMSJC5taXRtZmxvdy52MS5EaWZmV2l0aFByZXZpb3VzUmVxdWVzdBolLm1pdG1mbG93LnYxLkRpZmZXaXRoUHJldmlvdXNSZXNwb25zZSIAEkkKCFRvcEZsb3dzEhwubWl0bWZsb3cudjEuVG9wRmxvd3NSZXF1ZXN0Gh0ubWl0bWZsb3cudjEuVG9wRmxvd3NSZXNwb25zZSIAEmQKEUdldEJhbmR3aWR0aFN0YXRzEiUubWl0bWZsb3cudjEuR2V0QmFuZHdpZHRoU3RhdHNSZXF1ZXN0GiYubWl0bWZsb3cudjEuR2V0QmFuZHdpZHRoU3RhdHNSZXNwb25zZSIAElgKDUdldFByb3h5UnVsZXMSIS5taXRtZmxvdy52MS5HZXRQcm94eVJ1bGVzUmVxdWVzdBoiLm1pdG1mbG93LnYxLkdldFByb3h5UnVsZXNSZXNwb25zZSIAElgKDVNldFByb3h5UnVsZXMSIS5taXRtZmxvdy52MS5TZXRQcm94eVJ1bGVzUmVxdWVzdBoiLm1pdG1mbG93LnYxLlNldFByb3h5UnVsZXNSZXNwb25zZSIAEmAKD1dhdGNoUHJveHlSdWxlcxIjLm1pdG1mbG93LnYxLldhdGNoUHJveHlSdWxlc1JlcXVlc3QaJC5taXRtZmxvdy52MS5XYXRjaFByb3h5UnVsZXNSZXNwb25zZSIAMAFiCGVkaXRpb25zcOgH", [file_buf_validate_validate, file_google_protobuf_timestamp, file_mitmproxygrpc_v1_service]);
 
 /**
  * Describes the message mitmflow.v1.FlowFilter.
@@ -27,145 +27,726 @@ export const FlowFilterSchema = /*@__PURE__*/
 export const HttpFilterSchema = /*@__PURE__*/
   messageDesc(file_mitmflow_v1_mitmflow, 1);
 
+/**
+ * Describes the message mitmflow.v1.BodyQuery.
+ * Use `create(BodyQuerySchema)` to create a new message.
+ */
+export const BodyQuerySchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 2);
+
 /**
  * Describes the message mitmflow.v1.GetFlowRequest.
  * Use `create(GetFlowRequestSchema)` to create a new message.
  */
 export const GetFlowRequestSchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 2);
+  messageDesc(file_mitmflow_v1_mitmflow, 3);
 
 /**
  * Describes the message mitmflow.v1.GetFlowResponse.
  * Use `create(GetFlowResponseSchema)` to create a new message.
  */
 export const GetFlowResponseSchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 3);
+  messageDesc(file_mitmflow_v1_mitmflow, 4);
+
+/**
+ * Describes the message mitmflow.v1.GetFlowBodyRequest.
+ * Use `create(GetFlowBodyRequestSchema)` to create a new message.
+ */
+export const GetFlowBodyRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 5);
+
+/**
+ * Describes the message mitmflow.v1.GetFlowBodyResponse.
+ * Use `create(GetFlowBodyResponseSchema)` to create a new message.
+ */
+export const GetFlowBodyResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 6);
+
+/**
+ * Describes the message mitmflow.v1.GetFlowFramesRequest.
+ * Use `create(GetFlowFramesRequestSchema)` to create a new message.
+ */
+export const GetFlowFramesRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 7);
+
+/**
+ * Describes the message mitmflow.v1.GetFlowFramesResponse.
+ * Use `create(GetFlowFramesResponseSchema)` to create a new message.
+ */
+export const GetFlowFramesResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 8);
 
 /**
  * Describes the message mitmflow.v1.GetFlowsRequest.
  * Use `create(GetFlowsRequestSchema)` to create a new message.
  */
 export const GetFlowsRequestSchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 4);
+  messageDesc(file_mitmflow_v1_mitmflow, 9);
 
 /**
  * Describes the message mitmflow.v1.GetFlowsResponse.
  * Use `create(GetFlowsResponseSchema)` to create a new message.
  */
 export const GetFlowsResponseSchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 5);
+  messageDesc(file_mitmflow_v1_mitmflow, 10);
 
 /**
  * Describes the message mitmflow.v1.StreamFlowsRequest.
  * Use `create(StreamFlowsRequestSchema)` to create a new message.
  */
 export const StreamFlowsRequestSchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 6);
+  messageDesc(file_mitmflow_v1_mitmflow, 11);
 
 /**
  * Describes the message mitmflow.v1.StreamFlowsResponse.
  * Use `create(StreamFlowsResponseSchema)` to create a new message.
  */
 export const StreamFlowsResponseSchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 7);
+  messageDesc(file_mitmflow_v1_mitmflow, 12);
+
+/**
+ * Describes the message mitmflow.v1.Keepalive.
+ * Use `create(KeepaliveSchema)` to create a new message.
+ */
+export const KeepaliveSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 13);
 
 /**
  * Describes the message mitmflow.v1.UpdateFlowRequest.
  * Use `create(UpdateFlowRequestSchema)` to create a new message.
  */
 export const UpdateFlowRequestSchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 8);
+  messageDesc(file_mitmflow_v1_mitmflow, 14);
 
 /**
  * Describes the message mitmflow.v1.UpdateFlowResponse.
  * Use `create(UpdateFlowResponseSchema)` to create a new message.
  */
 export const UpdateFlowResponseSchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 9);
+  messageDesc(file_mitmflow_v1_mitmflow, 15);
 
 /**
  * Describes the message mitmflow.v1.DeleteFlowsRequest.
  * Use `create(DeleteFlowsRequestSchema)` to create a new message.
  */
 export const DeleteFlowsRequestSchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 10);
+  messageDesc(file_mitmflow_v1_mitmflow, 16);
 
 /**
  * Describes the message mitmflow.v1.DeleteFlowsResponse.
  * Use `create(DeleteFlowsResponseSchema)` to create a new message.
  */
 export const DeleteFlowsResponseSchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 11);
+  messageDesc(file_mitmflow_v1_mitmflow, 17);
 
 /**
  * Describes the message mitmflow.v1.ExportFlowsRequest.
  * Use `create(ExportFlowsRequestSchema)` to create a new message.
  */
 export const ExportFlowsRequestSchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 12);
+  messageDesc(file_mitmflow_v1_mitmflow, 18);
 
 /**
  * Describes the message mitmflow.v1.ExportFlowsResponse.
  * Use `create(ExportFlowsResponseSchema)` to create a new message.
  */
 export const ExportFlowsResponseSchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 13);
+  messageDesc(file_mitmflow_v1_mitmflow, 19);
+
+/**
+ * Describes the message mitmflow.v1.ImportFlowsRequest.
+ * Use `create(ImportFlowsRequestSchema)` to create a new message.
+ */
+export const ImportFlowsRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 20);
+
+/**
+ * Describes the message mitmflow.v1.ImportFlowsResponse.
+ * Use `create(ImportFlowsResponseSchema)` to create a new message.
+ */
+export const ImportFlowsResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 21);
+
+/**
+ * Describes the message mitmflow.v1.CreateShareBundleRequest.
+ * Use `create(CreateShareBundleRequestSchema)` to create a new message.
+ */
+export const CreateShareBundleRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 22);
+
+/**
+ * Describes the message mitmflow.v1.CreateShareBundleResponse.
+ * Use `create(CreateShareBundleResponseSchema)` to create a new message.
+ */
+export const CreateShareBundleResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 23);
+
+/**
+ * Describes the message mitmflow.v1.SessionSelector.
+ * Use `create(SessionSelectorSchema)` to create a new message.
+ */
+export const SessionSelectorSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 24);
+
+/**
+ * Describes the message mitmflow.v1.SetBaselineRequest.
+ * Use `create(SetBaselineRequestSchema)` to create a new message.
+ */
+export const SetBaselineRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 25);
+
+/**
+ * Describes the message mitmflow.v1.SetBaselineResponse.
+ * Use `create(SetBaselineResponseSchema)` to create a new message.
+ */
+export const SetBaselineResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 26);
+
+/**
+ * Describes the message mitmflow.v1.CompareSessionsRequest.
+ * Use `create(CompareSessionsRequestSchema)` to create a new message.
+ */
+export const CompareSessionsRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 27);
+
+/**
+ * Describes the message mitmflow.v1.CompareSessionsResponse.
+ * Use `create(CompareSessionsResponseSchema)` to create a new message.
+ */
+export const CompareSessionsResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 28);
+
+/**
+ * Describes the message mitmflow.v1.BaselineComparison.
+ * Use `create(BaselineComparisonSchema)` to create a new message.
+ */
+export const BaselineComparisonSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 29);
+
+/**
+ * Describes the message mitmflow.v1.BaselineDifference.
+ * Use `create(BaselineDifferenceSchema)` to create a new message.
+ */
+export const BaselineDifferenceSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 30);
+
+/**
+ * Describes the message mitmflow.v1.CreateBackupRequest.
+ * Use `create(CreateBackupRequestSchema)` to create a new message.
+ */
+export const CreateBackupRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 31);
+
+/**
+ * Describes the message mitmflow.v1.CreateBackupResponse.
+ * Use `create(CreateBackupResponseSchema)` to create a new message.
+ */
+export const CreateBackupResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 32);
+
+/**
+ * Describes the message mitmflow.v1.RestoreBackupRequest.
+ * Use `create(RestoreBackupRequestSchema)` to create a new message.
+ */
+export const RestoreBackupRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 33);
+
+/**
+ * Describes the message mitmflow.v1.RestoreBackupResponse.
+ * Use `create(RestoreBackupResponseSchema)` to create a new message.
+ */
+export const RestoreBackupResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 34);
+
+/**
+ * Describes the message mitmflow.v1.SearchArchiveRequest.
+ * Use `create(SearchArchiveRequestSchema)` to create a new message.
+ */
+export const SearchArchiveRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 35);
+
+/**
+ * Describes the message mitmflow.v1.SearchArchiveResponse.
+ * Use `create(SearchArchiveResponseSchema)` to create a new message.
+ */
+export const SearchArchiveResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 36);
+
+/**
+ * Describes the message mitmflow.v1.RestoreArchivedFlowsRequest.
+ * Use `create(RestoreArchivedFlowsRequestSchema)` to create a new message.
+ */
+export const RestoreArchivedFlowsRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 37);
+
+/**
+ * Describes the message mitmflow.v1.RestoreArchivedFlowsResponse.
+ * Use `create(RestoreArchivedFlowsResponseSchema)` to create a new message.
+ */
+export const RestoreArchivedFlowsResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 38);
+
+/**
+ * Describes the message mitmflow.v1.RestoreFlowsRequest.
+ * Use `create(RestoreFlowsRequestSchema)` to create a new message.
+ */
+export const RestoreFlowsRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 39);
+
+/**
+ * Describes the message mitmflow.v1.RestoreFlowsResponse.
+ * Use `create(RestoreFlowsResponseSchema)` to create a new message.
+ */
+export const RestoreFlowsResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 40);
+
+/**
+ * Describes the message mitmflow.v1.UploadFlowBodyRequest.
+ * Use `create(UploadFlowBodyRequestSchema)` to create a new message.
+ */
+export const UploadFlowBodyRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 41);
+
+/**
+ * Describes the message mitmflow.v1.UploadFlowBodyResponse.
+ * Use `create(UploadFlowBodyResponseSchema)` to create a new message.
+ */
+export const UploadFlowBodyResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 42);
+
+/**
+ * Describes the message mitmflow.v1.AuditEvent.
+ * Use `create(AuditEventSchema)` to create a new message.
+ */
+export const AuditEventSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 43);
+
+/**
+ * Describes the message mitmflow.v1.ListAuditEventsRequest.
+ * Use `create(ListAuditEventsRequestSchema)` to create a new message.
+ */
+export const ListAuditEventsRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 44);
+
+/**
+ * Describes the message mitmflow.v1.ListAuditEventsResponse.
+ * Use `create(ListAuditEventsResponseSchema)` to create a new message.
+ */
+export const ListAuditEventsResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 45);
+
+/**
+ * Describes the message mitmflow.v1.ListSubscribersRequest.
+ * Use `create(ListSubscribersRequestSchema)` to create a new message.
+ */
+export const ListSubscribersRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 46);
+
+/**
+ * Describes the message mitmflow.v1.ListSubscribersResponse.
+ * Use `create(ListSubscribersResponseSchema)` to create a new message.
+ */
+export const ListSubscribersResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 47);
+
+/**
+ * Describes the message mitmflow.v1.Subscriber.
+ * Use `create(SubscriberSchema)` to create a new message.
+ */
+export const SubscriberSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 48);
+
+/**
+ * Describes the message mitmflow.v1.GetServerInfoRequest.
+ * Use `create(GetServerInfoRequestSchema)` to create a new message.
+ */
+export const GetServerInfoRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 49);
+
+/**
+ * Describes the message mitmflow.v1.GetServerInfoResponse.
+ * Use `create(GetServerInfoResponseSchema)` to create a new message.
+ */
+export const GetServerInfoResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 50);
+
+/**
+ * Describes the message mitmflow.v1.SendRequestRequest.
+ * Use `create(SendRequestRequestSchema)` to create a new message.
+ */
+export const SendRequestRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 51);
+
+/**
+ * Describes the message mitmflow.v1.SendRequestResponse.
+ * Use `create(SendRequestResponseSchema)` to create a new message.
+ */
+export const SendRequestResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 52);
+
+/**
+ * Describes the message mitmflow.v1.GetCookieTimelineRequest.
+ * Use `create(GetCookieTimelineRequestSchema)` to create a new message.
+ */
+export const GetCookieTimelineRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 53);
+
+/**
+ * Describes the message mitmflow.v1.GetCookieTimelineResponse.
+ * Use `create(GetCookieTimelineResponseSchema)` to create a new message.
+ */
+export const GetCookieTimelineResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 54);
+
+/**
+ * Describes the message mitmflow.v1.CookieEvent.
+ * Use `create(CookieEventSchema)` to create a new message.
+ */
+export const CookieEventSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 55);
+
+/**
+ * Describes the message mitmflow.v1.GetRedirectChainRequest.
+ * Use `create(GetRedirectChainRequestSchema)` to create a new message.
+ */
+export const GetRedirectChainRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 56);
+
+/**
+ * Describes the message mitmflow.v1.GetRedirectChainResponse.
+ * Use `create(GetRedirectChainResponseSchema)` to create a new message.
+ */
+export const GetRedirectChainResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 57);
+
+/**
+ * Describes the message mitmflow.v1.RedirectHop.
+ * Use `create(RedirectHopSchema)` to create a new message.
+ */
+export const RedirectHopSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 58);
+
+/**
+ * Describes the message mitmflow.v1.DiffWithPreviousRequest.
+ * Use `create(DiffWithPreviousRequestSchema)` to create a new message.
+ */
+export const DiffWithPreviousRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 59);
+
+/**
+ * Describes the message mitmflow.v1.DiffWithPreviousResponse.
+ * Use `create(DiffWithPreviousResponseSchema)` to create a new message.
+ */
+export const DiffWithPreviousResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 60);
+
+/**
+ * Describes the message mitmflow.v1.TopFlowsRequest.
+ * Use `create(TopFlowsRequestSchema)` to create a new message.
+ */
+export const TopFlowsRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 61);
+
+/**
+ * Describes the message mitmflow.v1.TopFlowsResponse.
+ * Use `create(TopFlowsResponseSchema)` to create a new message.
+ */
+export const TopFlowsResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 62);
+
+/**
+ * Describes the message mitmflow.v1.GetBandwidthStatsRequest.
+ * Use `create(GetBandwidthStatsRequestSchema)` to create a new message.
+ */
+export const GetBandwidthStatsRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 63);
+
+/**
+ * Describes the message mitmflow.v1.GetBandwidthStatsResponse.
+ * Use `create(GetBandwidthStatsResponseSchema)` to create a new message.
+ */
+export const GetBandwidthStatsResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 64);
+
+/**
+ * Describes the message mitmflow.v1.BandwidthUsage.
+ * Use `create(BandwidthUsageSchema)` to create a new message.
+ */
+export const BandwidthUsageSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 65);
+
+/**
+ * Describes the message mitmflow.v1.BandwidthBucket.
+ * Use `create(BandwidthBucketSchema)` to create a new message.
+ */
+export const BandwidthBucketSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 66);
+
+/**
+ * Describes the message mitmflow.v1.FlowDifference.
+ * Use `create(FlowDifferenceSchema)` to create a new message.
+ */
+export const FlowDifferenceSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 67);
+
+/**
+ * Describes the message mitmflow.v1.FlowSet.
+ * Use `create(FlowSetSchema)` to create a new message.
+ */
+export const FlowSetSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 68);
 
 /**
  * Describes the message mitmflow.v1.FlowSummary.
  * Use `create(FlowSummarySchema)` to create a new message.
  */
 export const FlowSummarySchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 14);
+  messageDesc(file_mitmflow_v1_mitmflow, 69);
 
 /**
  * Describes the message mitmflow.v1.HttpFlowSummary.
  * Use `create(HttpFlowSummarySchema)` to create a new message.
  */
 export const HttpFlowSummarySchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 15);
+  messageDesc(file_mitmflow_v1_mitmflow, 70);
 
 /**
  * Describes the message mitmflow.v1.DnsFlowSummary.
  * Use `create(DnsFlowSummarySchema)` to create a new message.
  */
 export const DnsFlowSummarySchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 16);
+  messageDesc(file_mitmflow_v1_mitmflow, 71);
 
 /**
  * Describes the message mitmflow.v1.TcpFlowSummary.
  * Use `create(TcpFlowSummarySchema)` to create a new message.
  */
 export const TcpFlowSummarySchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 17);
+  messageDesc(file_mitmflow_v1_mitmflow, 72);
 
 /**
  * Describes the message mitmflow.v1.UdpFlowSummary.
  * Use `create(UdpFlowSummarySchema)` to create a new message.
  */
 export const UdpFlowSummarySchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 18);
+  messageDesc(file_mitmflow_v1_mitmflow, 73);
 
 /**
  * Describes the message mitmflow.v1.Flow.
  * Use `create(FlowSchema)` to create a new message.
  */
 export const FlowSchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 19);
+  messageDesc(file_mitmflow_v1_mitmflow, 74);
+
+/**
+ * Describes the message mitmflow.v1.Annotation.
+ * Use `create(AnnotationSchema)` to create a new message.
+ */
+export const AnnotationSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 75);
 
 /**
  * Describes the message mitmflow.v1.HTTPFlowExtra.
  * Use `create(HTTPFlowExtraSchema)` to create a new message.
  */
 export const HTTPFlowExtraSchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 20);
+  messageDesc(file_mitmflow_v1_mitmflow, 76);
+
+/**
+ * Describes the message mitmflow.v1.FlowTotals.
+ * Use `create(FlowTotalsSchema)` to create a new message.
+ */
+export const FlowTotalsSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 77);
+
+/**
+ * Describes the message mitmflow.v1.InterimResponse.
+ * Use `create(InterimResponseSchema)` to create a new message.
+ */
+export const InterimResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 78);
+
+/**
+ * Describes the message mitmflow.v1.HTTP2Details.
+ * Use `create(HTTP2DetailsSchema)` to create a new message.
+ */
+export const HTTP2DetailsSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 79);
+
+/**
+ * Describes the message mitmflow.v1.HeaderField.
+ * Use `create(HeaderFieldSchema)` to create a new message.
+ */
+export const HeaderFieldSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 80);
+
+/**
+ * Describes the message mitmflow.v1.CorsCheck.
+ * Use `create(CorsCheckSchema)` to create a new message.
+ */
+export const CorsCheckSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 81);
+
+/**
+ * Describes the message mitmflow.v1.SecurityFinding.
+ * Use `create(SecurityFindingSchema)` to create a new message.
+ */
+export const SecurityFindingSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 82);
+
+/**
+ * Describes the message mitmflow.v1.GeoInfo.
+ * Use `create(GeoInfoSchema)` to create a new message.
+ */
+export const GeoInfoSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 83);
+
+/**
+ * Describes the message mitmflow.v1.UserAgent.
+ * Use `create(UserAgentSchema)` to create a new message.
+ */
+export const UserAgentSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 84);
+
+/**
+ * Describes the message mitmflow.v1.StreamFlowExtra.
+ * Use `create(StreamFlowExtraSchema)` to create a new message.
+ */
+export const StreamFlowExtraSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 85);
+
+/**
+ * Describes the message mitmflow.v1.DnsFlowExtra.
+ * Use `create(DnsFlowExtraSchema)` to create a new message.
+ */
+export const DnsFlowExtraSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 86);
+
+/**
+ * Describes the message mitmflow.v1.DnsAnomaly.
+ * Use `create(DnsAnomalySchema)` to create a new message.
+ */
+export const DnsAnomalySchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 87);
 
 /**
  * Describes the message mitmflow.v1.MessageDetails.
  * Use `create(MessageDetailsSchema)` to create a new message.
  */
 export const MessageDetailsSchema = /*@__PURE__*/
-  messageDesc(file_mitmflow_v1_mitmflow, 21);
+  messageDesc(file_mitmflow_v1_mitmflow, 88);
+
+/**
+ * Describes the message mitmflow.v1.BinaryMetadata.
+ * Use `create(BinaryMetadataSchema)` to create a new message.
+ */
+export const BinaryMetadataSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 89);
+
+/**
+ * Describes the message mitmflow.v1.GrpcStatus.
+ * Use `create(GrpcStatusSchema)` to create a new message.
+ */
+export const GrpcStatusSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 90);
+
+/**
+ * Describes the message mitmflow.v1.MediaInfo.
+ * Use `create(MediaInfoSchema)` to create a new message.
+ */
+export const MediaInfoSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 91);
+
+/**
+ * Describes the message mitmflow.v1.FormField.
+ * Use `create(FormFieldSchema)` to create a new message.
+ */
+export const FormFieldSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 92);
+
+/**
+ * Describes the message mitmflow.v1.SoapMessage.
+ * Use `create(SoapMessageSchema)` to create a new message.
+ */
+export const SoapMessageSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 93);
+
+/**
+ * Describes the message mitmflow.v1.SoapFault.
+ * Use `create(SoapFaultSchema)` to create a new message.
+ */
+export const SoapFaultSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 94);
+
+/**
+ * Describes the message mitmflow.v1.GetProxyRulesRequest.
+ * Use `create(GetProxyRulesRequestSchema)` to create a new message.
+ */
+export const GetProxyRulesRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 95);
+
+/**
+ * Describes the message mitmflow.v1.GetProxyRulesResponse.
+ * Use `create(GetProxyRulesResponseSchema)` to create a new message.
+ */
+export const GetProxyRulesResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 96);
+
+/**
+ * Describes the message mitmflow.v1.SetProxyRulesRequest.
+ * Use `create(SetProxyRulesRequestSchema)` to create a new message.
+ */
+export const SetProxyRulesRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 97);
+
+/**
+ * Describes the message mitmflow.v1.SetProxyRulesResponse.
+ * Use `create(SetProxyRulesResponseSchema)` to create a new message.
+ */
+export const SetProxyRulesResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 98);
+
+/**
+ * Describes the message mitmflow.v1.WatchProxyRulesRequest.
+ * Use `create(WatchProxyRulesRequestSchema)` to create a new message.
+ */
+export const WatchProxyRulesRequestSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 99);
+
+/**
+ * Describes the message mitmflow.v1.WatchProxyRulesResponse.
+ * Use `create(WatchProxyRulesResponseSchema)` to create a new message.
+ */
+export const WatchProxyRulesResponseSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 100);
+
+/**
+ * Describes the message mitmflow.v1.ProxyRules.
+ * Use `create(ProxyRulesSchema)` to create a new message.
+ */
+export const ProxyRulesSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 101);
+
+/**
+ * Describes the message mitmflow.v1.ProxyRuleMatch.
+ * Use `create(ProxyRuleMatchSchema)` to create a new message.
+ */
+export const ProxyRuleMatchSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 102);
+
+/**
+ * Describes the message mitmflow.v1.OverrideRule.
+ * Use `create(OverrideRuleSchema)` to create a new message.
+ */
+export const OverrideRuleSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 103);
+
+/**
+ * Describes the message mitmflow.v1.ThrottleRule.
+ * Use `create(ThrottleRuleSchema)` to create a new message.
+ */
+export const ThrottleRuleSchema = /*@__PURE__*/
+  messageDesc(file_mitmflow_v1_mitmflow, 104);
 
 /**
  * Describes the enum mitmflow.v1.ExportFormat.
@@ -179,6 +760,114 @@ export const ExportFormatSchema = /*@__PURE__*/
 export const ExportFormat = /*@__PURE__*/
   tsEnum(ExportFormatSchema);
 
+/**
+ * Describes the enum mitmflow.v1.BaselineDifferenceKind.
+ */
+export const BaselineDifferenceKindSchema = /*@__PURE__*/
+  enumDesc(file_mitmflow_v1_mitmflow, 1);
+
+/**
+ * @generated from enum mitmflow.v1.BaselineDifferenceKind
+ */
+export const BaselineDifferenceKind = /*@__PURE__*/
+  tsEnum(BaselineDifferenceKindSchema);
+
+/**
+ * Describes the enum mitmflow.v1.AuditAction.
+ */
+export const AuditActionSchema = /*@__PURE__*/
+  enumDesc(file_mitmflow_v1_mitmflow, 2);
+
+/**
+ * @generated from enum mitmflow.v1.AuditAction
+ */
+export const AuditAction = /*@__PURE__*/
+  tsEnum(AuditActionSchema);
+
+/**
+ * Describes the enum mitmflow.v1.CookieEventType.
+ */
+export const CookieEventTypeSchema = /*@__PURE__*/
+  enumDesc(file_mitmflow_v1_mitmflow, 3);
+
+/**
+ * @generated from enum mitmflow.v1.CookieEventType
+ */
+export const CookieEventType = /*@__PURE__*/
+  tsEnum(CookieEventTypeSchema);
+
+/**
+ * Describes the enum mitmflow.v1.TopFlowsOrder.
+ */
+export const TopFlowsOrderSchema = /*@__PURE__*/
+  enumDesc(file_mitmflow_v1_mitmflow, 4);
+
+/**
+ * @generated from enum mitmflow.v1.TopFlowsOrder
+ */
+export const TopFlowsOrder = /*@__PURE__*/
+  tsEnum(TopFlowsOrderSchema);
+
+/**
+ * Describes the enum mitmflow.v1.FlowDifferenceKind.
+ */
+export const FlowDifferenceKindSchema = /*@__PURE__*/
+  enumDesc(file_mitmflow_v1_mitmflow, 5);
+
+/**
+ * @generated from enum mitmflow.v1.FlowDifferenceKind
+ */
+export const FlowDifferenceKind = /*@__PURE__*/
+  tsEnum(FlowDifferenceKindSchema);
+
+/**
+ * Describes the enum mitmflow.v1.FindingSeverity.
+ */
+export const FindingSeveritySchema = /*@__PURE__*/
+  enumDesc(file_mitmflow_v1_mitmflow, 6);
+
+/**
+ * @generated from enum mitmflow.v1.FindingSeverity
+ */
+export const FindingSeverity = /*@__PURE__*/
+  tsEnum(FindingSeveritySchema);
+
+/**
+ * Describes the enum mitmflow.v1.DeviceType.
+ */
+export const DeviceTypeSchema = /*@__PURE__*/
+  enumDesc(file_mitmflow_v1_mitmflow, 7);
+
+/**
+ * @generated from enum mitmflow.v1.DeviceType
+ */
+export const DeviceType = /*@__PURE__*/
+  tsEnum(DeviceTypeSchema);
+
+/**
+ * Describes the enum mitmflow.v1.DnsAnomalyKind.
+ */
+export const DnsAnomalyKindSchema = /*@__PURE__*/
+  enumDesc(file_mitmflow_v1_mitmflow, 8);
+
+/**
+ * @generated from enum mitmflow.v1.DnsAnomalyKind
+ */
+export const DnsAnomalyKind = /*@__PURE__*/
+  tsEnum(DnsAnomalyKindSchema);
+
+/**
+ * Describes the enum mitmflow.v1.HostnameSource.
+ */
+export const HostnameSourceSchema = /*@__PURE__*/
+  enumDesc(file_mitmflow_v1_mitmflow, 9);
+
+/**
+ * @generated from enum mitmflow.v1.HostnameSource
+ */
+export const HostnameSource = /*@__PURE__*/
+  tsEnum(HostnameSourceSchema);
+
 /**
  * @generated from service mitmflow.v1.Service
  */
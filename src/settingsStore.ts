@@ -10,6 +10,9 @@ interface SettingsState {
   setMaxFlows: (maxFlows: number) => void;
   maxBodySize: number;
   setMaxBodySize: (maxBodySize: number) => void;
+  // Bearer token sent to servers started with -token-file.
+  accessToken: string;
+  setAccessToken: (accessToken: string) => void;
 }
 
 const useSettingsStore = create<SettingsState>()(
@@ -21,6 +24,8 @@ const useSettingsStore = create<SettingsState>()(
       setMaxFlows: (maxFlows) => set({ maxFlows }),
       maxBodySize: 1024,
       setMaxBodySize: (maxBodySize) => set({ maxBodySize }),
+      accessToken: '',
+      setAccessToken: (accessToken) => set({ accessToken }),
     }),
     {
       name: 'settings-storage',
@@ -10,10 +10,21 @@ export const FLOW_TYPES: { value: FlowType; label: string }[] = [
   { value: 'udp', label: 'UDP' },
 ];
 
+export type SecuritySeverity = 'info' | 'low' | 'medium';
+
+export const SECURITY_SEVERITIES: { value: SecuritySeverity; label: string }[] = [
+  { value: 'info', label: 'Info' },
+  { value: 'low', label: 'Low' },
+  { value: 'medium', label: 'Medium' },
+];
+
 interface HttpFilterState {
   methods: string[];
   contentTypes: string[];
   statusCodes: string[];
+  clientFamilies: string[];
+  // Only flows with a security header finding at least this severe.
+  minSecuritySeverity: SecuritySeverity | undefined;
 }
 
 interface FilterState {
@@ -34,11 +45,16 @@ interface FilterState {
   clientIps: string[];
   setClientIps: (ips: string[]) => void;
 
+  serverCountries: string[];
+  setServerCountries: (countries: string[]) => void;
+
   // HTTP Specific Filters
   http: HttpFilterState;
   setHttpMethods: (methods: string[]) => void;
   setHttpContentTypes: (contentTypes: string[]) => void;
   setHttpStatusCodes: (statusCodes: string[]) => void;
+  setHttpClientFamilies: (clientFamilies: string[]) => void;
+  setHttpMinSecuritySeverity: (minSecuritySeverity: SecuritySeverity | undefined) => void;
 
 
   // Actions
@@ -58,10 +74,14 @@ const useFilterStore = create<FilterState>()(
       setFlowTypes: (flowTypes) => set({ flowTypes }),
       clientIps: [],
       setClientIps: (clientIps) => set({ clientIps }),
+      serverCountries: [],
+      setServerCountries: (serverCountries) => set({ serverCountries }),
       http: {
         methods: [],
         contentTypes: [],
         statusCodes: [],
+        clientFamilies: [],
+        minSecuritySeverity: undefined,
       },
       setHttpMethods: (methods) =>
         set((state) => ({ http: { ...state.http, methods } })),
@@ -69,6 +89,10 @@ const useFilterStore = create<FilterState>()(
         set((state) => ({ http: { ...state.http, contentTypes } })),
       setHttpStatusCodes: (statusCodes) =>
         set((state) => ({ http: { ...state.http, statusCodes } })),
+      setHttpClientFamilies: (clientFamilies) =>
+        set((state) => ({ http: { ...state.http, clientFamilies } })),
+      setHttpMinSecuritySeverity: (minSecuritySeverity) =>
+        set((state) => ({ http: { ...state.http, minSecuritySeverity } })),
       clearFilters: () =>
         set((state) => ({
           text: '',
@@ -76,22 +100,33 @@ const useFilterStore = create<FilterState>()(
           hasNote: undefined,
           flowTypes: [],
           clientIps: [],
+          serverCountries: [],
           http: {
             ...state.http,
             methods: [],
             contentTypes: [],
             statusCodes: [],
+            clientFamilies: [],
+            minSecuritySeverity: undefined,
           },
         })),
     }),
     {
       name: 'filter-storage', // name of the item in the storage (must be unique)
-      version: 1, // bump version to migrate old state
+      version: 3, // bump version to migrate old state
       migrate: (persistedState: unknown, version: number) => {
         if (version < 1 && persistedState && typeof persistedState === 'object') {
           // If old version, clear flowTypes to default to none selected
           (persistedState as FilterState).flowTypes = [];
         }
+        if (version < 2 && persistedState && typeof persistedState === 'object') {
+          const state = persistedState as FilterState;
+          state.http = { ...state.http, clientFamilies: [] };
+        }
+        if (version < 3 && persistedState && typeof persistedState === 'object') {
+          const state = persistedState as FilterState;
+          state.http = { ...state.http, minSecuritySeverity: undefined };
+        }
         return persistedState as FilterState;
       },
       partialize: (state) => ({
@@ -100,6 +135,7 @@ const useFilterStore = create<FilterState>()(
         hasNote: state.hasNote,
         flowTypes: state.flowTypes,
         clientIps: state.clientIps,
+        serverCountries: state.serverCountries,
         http: state.http,
       }),
     }
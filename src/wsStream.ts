@@ -0,0 +1,69 @@
+import { fromJsonString, toJsonString } from "@bufbuild/protobuf";
+import { StreamFlowsRequest, StreamFlowsRequestSchema, StreamFlowsResponse, StreamFlowsResponseSchema } from "./gen/mitmflow/v1/mitmflow_pb";
+
+// flowsWebSocketUrl resolves the /ws/flows endpoint relative to the RPC base
+// URL. Browsers can't set headers on WebSockets, so a token goes in the query.
+export const flowsWebSocketUrl = (baseUrl: string, accessToken = ''): string => {
+  const url = new URL(baseUrl.replace(/\/?$/, '/') + 'ws/flows', window.location.href);
+  url.protocol = url.protocol === 'https:' ? 'wss:' : 'ws:';
+  if (accessToken) url.searchParams.set('access_token', accessToken);
+  return url.toString();
+};
+
+// streamFlowsWebSocket is a fallback for client.streamFlows for networks where
+// long-lived HTTP/2 streams get cut off. It yields the same responses.
+export async function* streamFlowsWebSocket(
+  baseUrl: string,
+  req: StreamFlowsRequest,
+  signal: AbortSignal,
+  accessToken = '',
+): AsyncGenerator<StreamFlowsResponse> {
+  const ws = new WebSocket(flowsWebSocketUrl(baseUrl, accessToken));
+  const queue: StreamFlowsResponse[] = [];
+  let done = false;
+  let error: Error | null = null;
+  let wake: (() => void) | null = null;
+  const notify = () => {
+    wake?.();
+    wake = null;
+  };
+
+  ws.onopen = () => ws.send(toJsonString(StreamFlowsRequestSchema, req));
+  ws.onmessage = (event) => {
+    try {
+      queue.push(fromJsonString(StreamFlowsResponseSchema, event.data as string));
+    } catch (err) {
+      error = err instanceof Error ? err : new Error(String(err));
+    }
+    notify();
+  };
+  ws.onerror = () => {
+    error = new Error('WebSocket error');
+    notify();
+  };
+  ws.onclose = (event) => {
+    if (event.code !== 1000 && !error) {
+      error = new Error(`WebSocket closed: ${event.code} ${event.reason}`);
+    }
+    done = true;
+    notify();
+  };
+  const onAbort = () => ws.close(1000);
+  signal.addEventListener('abort', onAbort);
+
+  try {
+    while (true) {
+      while (queue.length > 0) {
+        yield queue.shift()!;
+      }
+      if (error) throw error;
+      if (done || signal.aborted) return;
+      await new Promise<void>(resolve => { wake = resolve; });
+    }
+  } finally {
+    signal.removeEventListener('abort', onAbort);
+    if (ws.readyState === WebSocket.CONNECTING || ws.readyState === WebSocket.OPEN) {
+      ws.close(1000);
+    }
+  }
+}
@@ -1,45 +1,208 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
-	"runtime"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
-	"golang.org/x/sync/errgroup"
 	"google.golang.org/protobuf/proto"
 )
 
+const (
+	corruptDirName = "corrupt"
+	tmpFileSuffix  = ".tmp"
+)
+
 type FlowStorage struct {
 	mu        sync.RWMutex
 	dir       string
 	maxFlows  int
 	store     Store
+	backend   FlowBackend
 	persistCh chan func()
 	wg        sync.WaitGroup
+
+	// With a positive flushInterval, saved flows are collected in pending
+	// and written in batches, so a flow updated several times between
+	// flushes is only written once.
+	flushInterval time.Duration
+	pending       map[string][]byte
+	stopFlusher   chan struct{}
+
+	compress bool
+	zstdDict []byte
+	codec    *flowCodec
+
+	// Bodies larger than blobThreshold are moved into blobs. blobRefs counts
+	// how many flows, and saves still in progress, reference each blob and
+	// flowBlobs remembers which blobs a flow references so they can be
+	// released when it is removed. blobMu guards blobRefs, and is held while
+	// deciding to delete a blob, so a blob can't be deleted under a save
+	// that is about to reference it.
+	blobs         BlobStore
+	blobThreshold int
+	blobMu        sync.Mutex
+	blobRefs      map[string]int
+	flowBlobs     map[string][]string
+
+	// Pruned flows are moved into archiveDir, compressed with archiveCodec,
+	// instead of being deleted.
+	archiveDir   string
+	archiveCodec *flowCodec
+
+	// Flows older than maxAge are pruned regardless of maxFlows. Pinned flows
+	// and flows with a retainTags tag are never pruned.
+	maxAge     time.Duration
+	retainTags []string
+
+	// Deleted flows stay in the trash for trashRetention, when positive.
+	trashRetention time.Duration
+
+	// seq is the change sequence, flowSeqs the sequence of each flow's last
+	// change. seqLimit is the sequence saved in the sequence file, which
+	// seq may reach before the file is written again.
+	seq      uint64
+	seqLimit uint64
+	flowSeqs map[string]uint64
+
+	// readOnly storage never writes to dir, see WithReadOnly.
+	readOnly bool
+}
+
+// StorageOption configures optional FlowStorage behavior.
+type StorageOption func(*FlowStorage)
+
+// WithCompression enables or disables zstd compression of flow files written
+// to disk. Existing files are read regardless of this setting.
+func WithCompression(enabled bool) StorageOption {
+	return func(s *FlowStorage) {
+		s.compress = enabled
+	}
+}
+
+// WithZstdDictionary sets a zstd dictionary used to compress and decompress
+// flow files. Files written with a dictionary can only be read with it.
+func WithZstdDictionary(dict []byte) StorageOption {
+	return func(s *FlowStorage) {
+		s.zstdDict = dict
+	}
+}
+
+// WithBlobStore moves request and response bodies larger than threshold bytes
+// out of the flow records and into blobs, which keeps the in-memory store small.
+// Bodies are loaded back on demand with HydrateFlow and GetBody.
+func WithBlobStore(blobs BlobStore, threshold int) StorageOption {
+	return func(s *FlowStorage) {
+		s.blobs = blobs
+		s.blobThreshold = threshold
+	}
+}
+
+// WithFlushInterval batches writes of saved flows, writing them at most once
+// per interval instead of on every save. Flows saved within the interval
+// before a crash are lost.
+func WithFlushInterval(interval time.Duration) StorageOption {
+	return func(s *FlowStorage) {
+		s.flushInterval = interval
+	}
 }
 
-func NewFlowStorage(dir string, maxFlows int) (*FlowStorage, error) {
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create data directory: %w", err)
+// WithReadOnly loads the data directory without changing it: leftover
+// temporary files and corrupt flow files are left in place, nothing is
+// pruned and the change sequence isn't saved. It is for reading the data
+// directory of a server that may be running, as the CLI does. Flows must not
+// be saved to or deleted from read-only storage.
+func WithReadOnly() StorageOption {
+	return func(s *FlowStorage) {
+		s.readOnly = true
 	}
+}
+
+// WithMaxAge prunes unpinned flows once they are older than maxAge, in
+// addition to pruning the oldest ones beyond the maximum number of flows.
+func WithMaxAge(maxAge time.Duration) StorageOption {
+	return func(s *FlowStorage) {
+		s.maxAge = maxAge
+	}
+}
 
+// WithRetainedTags exempts flows carrying any of tags from pruning, like
+// pinned flows. A tag is either a metadata key, matching any value, or
+// key=value, matching that value only.
+func WithRetainedTags(tags ...string) StorageOption {
+	return func(s *FlowStorage) {
+		s.retainTags = tags
+	}
+}
+
+func NewFlowStorage(dir string, maxFlows int, opts ...StorageOption) (*FlowStorage, error) {
 	s := &FlowStorage{
 		dir:       dir,
 		maxFlows:  maxFlows,
-		store:     NewMemoryStore(),
+		store:     NewShardedStore(),
 		persistCh: make(chan func(), 64), // Reduced buffer to provide backpressure and save memory
+		compress:  true,
+		blobRefs:  make(map[string]int),
+		flowBlobs: make(map[string][]string),
+		pending:   make(map[string][]byte),
+		flowSeqs:  make(map[string]uint64),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if !s.readOnly {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create data directory: %w", err)
+		}
+	}
+
+	codec, err := newFlowCodec(s.compress, s.zstdDict)
+	if err != nil {
+		return nil, err
+	}
+	s.codec = codec
+	if s.backend == nil {
+		backend := newFileBackend(dir, codec)
+		backend.readOnly = s.readOnly
+		s.backend = backend
+	}
+
+	if s.archiveDir != "" {
+		archiveCodec, err := newFlowCodec(true, s.zstdDict)
+		if err != nil {
+			return nil, err
+		}
+		s.archiveCodec = archiveCodec
 	}
 
 	s.wg.Add(1)
 	go s.persistWorker(s.persistCh)
+	if s.flushInterval > 0 {
+		s.stopFlusher = make(chan struct{})
+		s.wg.Add(1)
+		go s.flusher(s.flushInterval, s.stopFlusher)
+	}
 
+	if err := s.loadSequence(); err != nil {
+		return nil, err
+	}
 	if err := s.loadFlows(); err != nil {
 		return nil, err
 	}
+	if s.TrashEnabled() {
+		s.purgeTrash()
+	}
 
 	return s, nil
 }
@@ -51,72 +214,198 @@ func (s *FlowStorage) persistWorker(ch chan func()) {
 	}
 }
 
+// flusher writes pending flows every interval until stop is closed.
+func (s *FlowStorage) flusher(interval time.Duration, stop chan struct{}) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			s.flushPendingLocked()
+			s.mu.Unlock()
+		}
+	}
+}
+
+// persist queues writing a saved flow, or collects it to be written with the
+// next batch when writes are batched. Must be called with s.mu held.
+func (s *FlowStorage) persist(id string, data []byte) {
+	if s.flushInterval > 0 {
+		s.pending[id] = data
+		return
+	}
+	s.persistCh <- func() {
+		s.saveToDisk(id, data)
+	}
+}
+
+// flushPendingLocked queues writing all pending flows. Must be called with
+// s.mu held.
+func (s *FlowStorage) flushPendingLocked() {
+	if len(s.pending) == 0 || s.persistCh == nil {
+		return
+	}
+	batch := s.pending
+	s.pending = make(map[string][]byte)
+	s.persistCh <- func() {
+		for id, data := range batch {
+			s.saveToDisk(id, data)
+		}
+	}
+}
+
+// settlePending drops the pending writes of flows that are being removed.
+// When write is set they are queued first, for tasks that still need to read
+// the flow files. Must be called with s.mu held, before queuing the tasks
+// that remove the files.
+func (s *FlowStorage) settlePending(ids []string, write bool) {
+	if len(s.pending) == 0 {
+		return
+	}
+	batch := make(map[string][]byte)
+	for _, id := range ids {
+		if data, ok := s.pending[id]; ok {
+			batch[id] = data
+			delete(s.pending, id)
+		}
+	}
+	if !write || len(batch) == 0 || s.persistCh == nil {
+		return
+	}
+	s.persistCh <- func() {
+		for id, data := range batch {
+			s.saveToDisk(id, data)
+		}
+	}
+}
+
+// flush waits for pending flows to be written and for the persist worker to
+// finish the tasks queued so far.
+func (s *FlowStorage) flush() {
+	done := make(chan struct{})
+	s.mu.Lock()
+	if s.persistCh == nil {
+		s.mu.Unlock()
+		return
+	}
+	s.flushPendingLocked()
+	s.persistCh <- func() { close(done) }
+	s.mu.Unlock()
+	<-done
+}
+
 func (s *FlowStorage) Close() {
 	s.mu.Lock()
+	if s.stopFlusher != nil {
+		close(s.stopFlusher)
+		s.stopFlusher = nil
+	}
 	if s.persistCh != nil {
+		s.flushPendingLocked()
 		close(s.persistCh)
 		s.persistCh = nil
 	}
+	// A clean shutdown saves the exact sequence, so clients that were up
+	// to date don't get every flow again after a restart.
+	s.saveSequence(s.seq)
 	s.mu.Unlock()
 	s.wg.Wait()
+	if err := s.backend.Close(); err != nil {
+		log.Printf("failed to close storage backend: %v", err)
+	}
+	s.codec.Close()
+	if s.archiveCodec != nil {
+		s.archiveCodec.Close()
+	}
 }
 
 func (s *FlowStorage) loadFlows() error {
-	entries, err := os.ReadDir(s.dir)
+	flows, err := s.backend.Load()
 	if err != nil {
-		return fmt.Errorf("failed to read data directory: %w", err)
+		return err
+	}
+	// The store is sorted once at the end instead of on every insert.
+	s.store.UpsertBatch(flows)
+	for _, flow := range flows {
+		s.setFlowBlobs(GetFlowID(flow), flowBlobKeys(flow))
+		s.flowSeqs[GetFlowID(flow)] = s.seq
 	}
 
-	g := new(errgroup.Group)
-	g.SetLimit(runtime.GOMAXPROCS(0) * 4)
+	s.prune()
 
-	for _, entry := range entries {
-		entry := entry
-		if entry.IsDir() || filepath.Ext(entry.Name()) != ".bin" {
-			continue
-		}
+	return nil
+}
 
-		g.Go(func() error {
-			data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
-			if err != nil {
-				log.Printf("failed to read flow file %s: %v", entry.Name(), err)
-				return nil
-			}
+// saveToDisk persists the serialized flow with the backend.
+func (s *FlowStorage) saveToDisk(id string, data []byte) {
+	if err := s.backend.Save(id, data); err != nil {
+		log.Printf("failed to save flow %s: %v", id, err)
+	}
+}
 
-			flow := &mitmflowv1.Flow{}
-			if err := proto.Unmarshal(data, flow); err != nil {
-				log.Printf("failed to unmarshal flow file %s: %v", entry.Name(), err)
-				return nil
-			}
+// removeFromDisk removes a flow from the backend.
+func (s *FlowStorage) removeFromDisk(id string) {
+	if err := s.backend.Remove(id); err != nil {
+		log.Printf("failed to remove flow %s: %v", id, err)
+	}
+}
 
-			if GetFlowID(flow) == "" {
-				return nil
-			}
+func writeFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	return writeFileAtomicFunc(filename, perm, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
 
-			s.store.Upsert(flow)
-			return nil
-		})
+// writeFileAtomicFunc is like writeFileAtomic but streams the contents from
+// write, so large files don't need to be held in memory.
+func writeFileAtomicFunc(filename string, perm os.FileMode, write func(io.Writer) error) error {
+	f, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".*"+tmpFileSuffix)
+	if err != nil {
+		return err
 	}
+	tmpName := f.Name()
+	defer os.Remove(tmpName) //nolint:errcheck
 
-	if err := g.Wait(); err != nil {
+	if err := write(f); err != nil {
+		f.Close() //nolint:errcheck
 		return err
 	}
-
-	s.prune()
-
-	return nil
+	if err := f.Sync(); err != nil {
+		f.Close() //nolint:errcheck
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, filename)
 }
 
 func (s *FlowStorage) SaveFlow(flow *mitmflowv1.Flow) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	id := GetFlowID(flow)
 	if id == "" {
 		return fmt.Errorf("flow has no ID")
 	}
 
-	// Preserve pinned status and note if updating existing flow
+	// Blobs are written before taking the lock so slow blob stores don't
+	// block readers. They stay referenced by the save until the flow
+	// references them.
+	retained, err := s.offloadBodies(flow)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.releaseBlobs(retained)
+	if err != nil {
+		return fmt.Errorf("failed to store body blob: %w", err)
+	}
+
+	// Preserve pinned status, note and metadata if updating existing flow
 	if existing, ok := s.store.Get(id); ok {
 		if !flow.GetPinned() && existing.GetPinned() {
 			flow.SetPinned(true)
@@ -124,9 +413,23 @@ func (s *FlowStorage) SaveFlow(flow *mitmflowv1.Flow) error {
 		if flow.GetNote() == "" && existing.GetNote() != "" {
 			flow.SetNote(existing.GetNote())
 		}
+		if len(flow.GetMetadata()) == 0 && len(existing.GetMetadata()) > 0 {
+			flow.SetMetadata(existing.GetMetadata())
+		}
+		if len(flow.GetUserAnnotations()) == 0 && len(existing.GetUserAnnotations()) > 0 {
+			flow.SetUserAnnotations(existing.GetUserAnnotations())
+		}
+		if flow.GetOwner() == "" && existing.GetOwner() != "" {
+			flow.SetOwner(existing.GetOwner())
+		}
+		if !flow.GetPrivate() && existing.GetPrivate() {
+			flow.SetPrivate(true)
+		}
 	}
 
 	s.store.Upsert(flow)
+	s.setFlowBlobs(id, flowBlobKeys(flow))
+	s.touch(id)
 
 	if s.persistCh == nil {
 		return fmt.Errorf("storage closed")
@@ -138,18 +441,16 @@ func (s *FlowStorage) SaveFlow(flow *mitmflowv1.Flow) error {
 		return fmt.Errorf("failed to marshal flow: %w", err)
 	}
 
-	filename := filepath.Join(s.dir, id+".bin")
-	s.persistCh <- func() {
-		if err := os.WriteFile(filename, data, 0644); err != nil {
-			log.Printf("failed to save flow %s: %v", id, err)
-		}
-	}
+	s.persist(id, data)
 
 	s.prune()
 	return nil
 }
 
-func (s *FlowStorage) UpdateFlow(id string, pinned *bool, note *string) (*mitmflowv1.Flow, error) {
+// UpdateFlow sets the pinned status and note of a flow when they are non-nil
+// and merges metadata into its metadata, removing keys with an empty value.
+// The pin and note are user's own unless user is "", when they're shared.
+func (s *FlowStorage) UpdateFlow(id, user string, pinned *bool, note *string, metadata map[string]string) (*mitmflowv1.Flow, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -158,15 +459,36 @@ func (s *FlowStorage) UpdateFlow(id string, pinned *bool, note *string) (*mitmfl
 		return nil, fmt.Errorf("flow not found: %s", id)
 	}
 
-	if pinned != nil {
-		flow.SetPinned(*pinned)
+	if user != "" {
+		if pinned != nil || note != nil {
+			annotate(flow, user, pinned, note)
+		}
+	} else {
+		if pinned != nil {
+			flow.SetPinned(*pinned)
+		}
+		if note != nil {
+			flow.SetNote(*note)
+		}
 	}
-	if note != nil {
-		flow.SetNote(*note)
+	if len(metadata) > 0 {
+		merged := maps.Clone(flow.GetMetadata())
+		if merged == nil {
+			merged = make(map[string]string, len(metadata))
+		}
+		for k, v := range metadata {
+			if v == "" {
+				delete(merged, k)
+			} else {
+				merged[k] = v
+			}
+		}
+		flow.SetMetadata(merged)
 	}
 
 	// Upsert to ensure store state is consistent
 	s.store.Upsert(flow)
+	s.touch(id)
 
 	if s.persistCh == nil {
 		return nil, fmt.Errorf("storage closed")
@@ -177,69 +499,106 @@ func (s *FlowStorage) UpdateFlow(id string, pinned *bool, note *string) (*mitmfl
 		return nil, fmt.Errorf("failed to marshal flow: %w", err)
 	}
 
-	filename := filepath.Join(s.dir, id+".bin")
-	s.persistCh <- func() {
-		if err := os.WriteFile(filename, data, 0644); err != nil {
-			log.Printf("failed to save flow %s: %v", id, err)
-		}
-	}
+	s.persist(id, data)
 
 	s.prune()
 
 	return flow, nil
 }
 
-func (s *FlowStorage) DeleteFlows(ids []string) (int64, error) {
+// SetFlowPrivate makes a flow private to its owner, or public again.
+func (s *FlowStorage) SetFlowPrivate(id string, private bool) (*mitmflowv1.Flow, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	deletedIDs := s.store.Delete(ids...)
-	if len(deletedIDs) > 0 {
-		if s.persistCh == nil {
-			return int64(len(deletedIDs)), nil
-		}
-		// Copy IDs for the closure
-		idsToDelete := make([]string, len(deletedIDs))
-		copy(idsToDelete, deletedIDs)
+	flow, ok := s.store.Get(id)
+	if !ok {
+		return nil, fmt.Errorf("flow not found: %s", id)
+	}
+	flow.SetPrivate(private)
+	s.store.Upsert(flow)
+	s.touch(id)
 
-		s.persistCh <- func() {
-			for _, id := range idsToDelete {
-				if err := os.Remove(filepath.Join(s.dir, id+".bin")); err != nil && !os.IsNotExist(err) {
-					log.Printf("failed to remove flow file %s: %v", id, err)
-				}
-			}
-		}
+	if s.persistCh == nil {
+		return nil, fmt.Errorf("storage closed")
+	}
+	data, err := proto.Marshal(flow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal flow: %w", err)
 	}
+	s.persist(id, data)
+	return flow, nil
+}
 
-	return int64(len(deletedIDs)), nil
+// DeleteFlows deletes flows by ID and returns the IDs that were deleted.
+func (s *FlowStorage) DeleteFlows(ids []string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deletedIDs := s.store.Delete(ids...)
+	s.removeFlowFiles(deletedIDs)
+	for _, id := range deletedIDs {
+		s.forgetFlow(id)
+	}
+	return deletedIDs, nil
 }
 
-func (s *FlowStorage) DeleteAllFlows() (int64, error) {
+// DeleteAllFlows deletes every unpinned flow and returns their IDs.
+func (s *FlowStorage) DeleteAllFlows() ([]string, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	deletedIDs := s.store.DeleteAllUnpinned()
-	if len(deletedIDs) > 0 {
-		if s.persistCh == nil {
-			return int64(len(deletedIDs)), nil
-		}
-		idsToDelete := make([]string, len(deletedIDs))
-		copy(idsToDelete, deletedIDs)
+	s.removeFlowFiles(deletedIDs)
+	for _, id := range deletedIDs {
+		s.forgetFlow(id)
+	}
+	return deletedIDs, nil
+}
 
-		s.persistCh <- func() {
-			for _, id := range idsToDelete {
-				if err := os.Remove(filepath.Join(s.dir, id+".bin")); err != nil && !os.IsNotExist(err) {
-					log.Printf("failed to remove flow file %s: %v", id, err)
-				}
-			}
-		}
+// Dir returns the directory flows are persisted in.
+func (s *FlowStorage) Dir() string {
+	return s.dir
+}
+
+// MaxFlows returns the number of flows kept before pruning.
+func (s *FlowStorage) MaxFlows() int {
+	return s.maxFlows
+}
+
+// BlobThreshold returns the body size above which bodies are stored as
+// blobs, or zero when blobs are disabled.
+func (s *FlowStorage) BlobThreshold() int {
+	if s.blobs == nil {
+		return 0
 	}
+	return s.blobThreshold
+}
+
+// ArchiveDir returns the directory pruned flows are archived in, if any.
+func (s *FlowStorage) ArchiveDir() string {
+	return s.archiveDir
+}
 
-	return int64(len(deletedIDs)), nil
+// CountByType returns the number of stored flows of each type.
+func (s *FlowStorage) CountByType() map[string]int64 {
+	counts := make(map[string]int64)
+	s.store.Walk(func(flow *mitmflowv1.Flow) bool {
+		counts[GetFlowType(flow)]++
+		return true
+	})
+	return counts
 }
 
+// GetFlows returns every stored flow, oldest first. It copies the whole
+// store, so Walk is preferred where the flows are only iterated over.
 func (s *FlowStorage) GetFlows() []*mitmflowv1.Flow {
-	return s.store.List()
+	flows := make([]*mitmflowv1.Flow, 0, s.store.Len())
+	s.store.Walk(func(flow *mitmflowv1.Flow) bool {
+		flows = append(flows, flow)
+		return true
+	})
+	return flows
 }
 
 func (s *FlowStorage) Walk(fn func(*mitmflowv1.Flow) bool) {
@@ -254,20 +613,328 @@ func (s *FlowStorage) GetFlow(id string) (*mitmflowv1.Flow, bool) {
 	return s.store.Get(id)
 }
 
-func (s *FlowStorage) prune() {
-	deletedIDs := s.store.Prune(s.maxFlows)
-	if len(deletedIDs) > 0 {
-		if s.persistCh == nil {
-			return
+// retained reports whether a flow is exempt from pruning.
+func (s *FlowStorage) retained(flow *mitmflowv1.Flow) bool {
+	if pinnedByAnyone(flow) {
+		return true
+	}
+	metadata := flow.GetMetadata()
+	for _, tag := range s.retainTags {
+		key, value, hasValue := strings.Cut(tag, "=")
+		if v, ok := metadata[key]; ok && (!hasValue || v == value) {
+			return true
 		}
+	}
+	return false
+}
+
+func (s *FlowStorage) prune() {
+	var minStart int64
+	if s.maxAge > 0 {
+		minStart = time.Now().Add(-s.maxAge).UnixNano()
+	}
+	if s.readOnly {
+		return
+	}
+	deletedIDs := s.store.Prune(s.maxFlows, minStart, s.retained)
+	if len(deletedIDs) > 0 && s.persistCh != nil {
 		// Copy IDs for closure
 		idsToDelete := make([]string, len(deletedIDs))
 		copy(idsToDelete, deletedIDs)
 
-		s.persistCh <- func() {
-			for _, id := range idsToDelete {
-				os.Remove(filepath.Join(s.dir, id+".bin")) //nolint:errcheck
+		s.settlePending(idsToDelete, s.archiveDir != "")
+
+		// Archiving is queued before the blobs are released so that the
+		// archive task can still read them.
+		if s.archiveDir != "" {
+			s.persistCh <- func() {
+				s.archiveFlows(idsToDelete)
+			}
+		} else {
+			s.persistCh <- func() {
+				for _, id := range idsToDelete {
+					s.removeFromDisk(id)
+				}
+			}
+		}
+	}
+	for _, id := range deletedIDs {
+		s.forgetFlow(id)
+	}
+}
+
+// offloadBodies moves request and response bodies above the blob threshold
+// into the blob store, recording the blob key and original size in the flow's
+// MessageDetails. It returns the keys of the blobs it stored, which are
+// retained until the caller releases them with releaseBlobs, even when it
+// fails.
+func (s *FlowStorage) offloadBodies(flow *mitmflowv1.Flow) ([]string, error) {
+	httpFlow := flow.GetHttpFlow()
+	if s.blobs == nil || s.blobThreshold <= 0 || httpFlow == nil {
+		return nil, nil
+	}
+
+	var retained []string
+	offload := func(content []byte, details *mitmflowv1.MessageDetails) error {
+		key := blobKey(content)
+		// The blob is retained before it is written, so a delete queued
+		// when it was last released can't remove it afterwards.
+		s.blobMu.Lock()
+		s.blobRefs[key]++
+		s.blobMu.Unlock()
+		retained = append(retained, key)
+		if err := s.blobs.Put(context.Background(), key, content); err != nil {
+			return err
+		}
+		details.SetBlobKey(key)
+		details.SetBodySize(int64(len(content)))
+		return nil
+	}
+
+	if req := httpFlow.GetRequest(); req != nil && len(req.GetContent()) > s.blobThreshold {
+		if err := offload(req.GetContent(), ensureRequestDetails(flow)); err != nil {
+			return retained, err
+		}
+		req.SetContent(nil)
+	}
+	if res := httpFlow.GetResponse(); res != nil && len(res.GetContent()) > s.blobThreshold {
+		if err := offload(res.GetContent(), ensureResponseDetails(flow)); err != nil {
+			return retained, err
+		}
+		res.SetContent(nil)
+	}
+	return retained, nil
+}
+
+// HydrateFlow returns the flow with any bodies that were moved into the blob
+// store loaded back in, making it self-contained. The stored flow is never
+// modified; if there is nothing to load the flow itself is returned.
+func (s *FlowStorage) HydrateFlow(ctx context.Context, flow *mitmflowv1.Flow) (*mitmflowv1.Flow, error) {
+	if s.blobs == nil || len(flowBlobKeys(flow)) == 0 {
+		return flow, nil
+	}
+
+	s.mu.RLock()
+	hydrated := proto.Clone(flow).(*mitmflowv1.Flow)
+	s.mu.RUnlock()
+
+	if err := s.loadBlobs(ctx, hydrated); err != nil {
+		return nil, err
+	}
+	return hydrated, nil
+}
+
+// loadBlobs inlines offloaded bodies into flow, which must not be shared.
+func (s *FlowStorage) loadBlobs(ctx context.Context, flow *mitmflowv1.Flow) error {
+	if s.blobs == nil {
+		return nil
+	}
+	httpFlow := flow.GetHttpFlow()
+	if key := flow.GetHttpFlowExtra().GetRequest().GetBlobKey(); key != "" && httpFlow.HasRequest() {
+		data, err := s.blobs.Get(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to load request body: %w", err)
+		}
+		httpFlow.GetRequest().SetContent(data)
+		flow.GetHttpFlowExtra().GetRequest().ClearBlobKey()
+	}
+	if key := flow.GetHttpFlowExtra().GetResponse().GetBlobKey(); key != "" && httpFlow.HasResponse() {
+		data, err := s.blobs.Get(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to load response body: %w", err)
+		}
+		httpFlow.GetResponse().SetContent(data)
+		flow.GetHttpFlowExtra().GetResponse().ClearBlobKey()
+	}
+	return nil
+}
+
+// GetBody returns the request or response body of a flow, loading it from the
+// blob store if it was offloaded.
+func (s *FlowStorage) GetBody(ctx context.Context, flow *mitmflowv1.Flow, response bool) ([]byte, error) {
+	details := flow.GetHttpFlowExtra().GetRequest()
+	content := flow.GetHttpFlow().GetRequest().GetContent()
+	if response {
+		details = flow.GetHttpFlowExtra().GetResponse()
+		content = flow.GetHttpFlow().GetResponse().GetContent()
+	}
+	if key := details.GetBlobKey(); key != "" && s.blobs != nil {
+		return s.blobs.Get(ctx, key)
+	}
+	return content, nil
+}
+
+// setFlowBlobs records that the flow references keys, releasing whatever it
+// referenced before. Blobs that are no longer referenced by any flow are
+// deleted. Must be called with s.mu held.
+func (s *FlowStorage) setFlowBlobs(id string, keys []string) {
+	previous := s.flowBlobs[id]
+	if len(keys) > 0 {
+		s.flowBlobs[id] = keys
+	} else {
+		delete(s.flowBlobs, id)
+	}
+	s.blobMu.Lock()
+	for _, key := range keys {
+		s.blobRefs[key]++
+	}
+	s.blobMu.Unlock()
+	s.releaseBlobs(previous)
+}
+
+// releaseBlobs drops a reference to each of keys and queues the blobs no
+// longer referenced for deletion. Must be called with s.mu held.
+func (s *FlowStorage) releaseBlobs(keys []string) {
+	var unused []string
+	s.blobMu.Lock()
+	for _, key := range keys {
+		s.blobRefs[key]--
+		if s.blobRefs[key] <= 0 {
+			delete(s.blobRefs, key)
+			unused = append(unused, key)
+		}
+	}
+	s.blobMu.Unlock()
+	if len(unused) == 0 || s.persistCh == nil || s.blobs == nil {
+		return
+	}
+	s.persistCh <- func() {
+		s.blobMu.Lock()
+		defer s.blobMu.Unlock()
+		for _, key := range unused {
+			// A flow saved since may have taken the blob up again.
+			if s.blobRefs[key] > 0 {
+				continue
 			}
+			if err := s.blobs.Delete(context.Background(), key); err != nil {
+				log.Printf("failed to delete blob %s: %v", key, err)
+			}
+		}
+	}
+}
+
+// forgetFlow drops the blob references and change sequence of a removed
+// flow. Must be called with s.mu held.
+func (s *FlowStorage) forgetFlow(id string) {
+	s.setFlowBlobs(id, nil)
+	delete(s.flowSeqs, id)
+}
+
+// touch records a change to a flow. Must be called with s.mu held.
+func (s *FlowStorage) touch(id string) {
+	s.seq++
+	s.flowSeqs[id] = s.seq
+	if s.seq > s.seqLimit {
+		s.saveSequence(s.seq + sequenceReserve)
+	}
+}
+
+const (
+	// sequenceFile keeps the change sequence across restarts.
+	sequenceFile = "sequence"
+	// sequenceReserve is how many sequences are handed out before the
+	// sequence file is written again. After a crash the sequence carries on
+	// from the reserved one, so no sequence is handed out twice.
+	sequenceReserve = 1024
+)
+
+// loadSequence picks up the change sequence where the last run stopped.
+// Flows loaded from disk count as changed at that sequence. A store without
+// a sequence file starts at the current time in Unix nanoseconds, which is
+// above any sequence a client got from a data directory without one, so
+// resuming clients don't miss anything.
+func (s *FlowStorage) loadSequence() error {
+	data, err := os.ReadFile(filepath.Join(s.dir, sequenceFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		s.seq = uint64(time.Now().UnixNano())
+	case err != nil:
+		return fmt.Errorf("failed to read change sequence: %w", err)
+	default:
+		if s.seq, err = strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64); err != nil {
+			return fmt.Errorf("invalid change sequence in %s: %w", sequenceFile, err)
+		}
+	}
+	s.saveSequence(s.seq + sequenceReserve)
+	return nil
+}
+
+// saveSequence writes seq to the sequence file and makes it the limit seq
+// may reach before it is written again. Must be called with s.mu held, or
+// before the storage is in use.
+func (s *FlowStorage) saveSequence(seq uint64) {
+	if s.readOnly {
+		return
+	}
+	if err := writeFileAtomic(filepath.Join(s.dir, sequenceFile), []byte(strconv.FormatUint(seq, 10)+"\n"), 0644); err != nil {
+		log.Printf("failed to save change sequence: %v", err)
+		return
+	}
+	s.seqLimit = seq
+}
+
+// Sequence returns the change sequence, which increases with every saved or
+// updated flow. It is kept in the data directory, so it keeps increasing
+// across restarts, whatever the clock does.
+func (s *FlowStorage) Sequence() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.seq
+}
+
+// FlowSequence returns the change sequence of a flow's last change, or zero
+// if the flow isn't stored.
+func (s *FlowStorage) FlowSequence(id string) uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.flowSeqs[id]
+}
+
+// ChangedSince returns the flows changed after the change sequence seq,
+// ordered by their last change.
+func (s *FlowStorage) ChangedSince(seq uint64) []*mitmflowv1.Flow {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var changed []*mitmflowv1.Flow
+	s.store.Walk(func(flow *mitmflowv1.Flow) bool {
+		if s.flowSeqs[GetFlowID(flow)] > seq {
+			changed = append(changed, flow)
 		}
+		return true
+	})
+	sort.Slice(changed, func(i, j int) bool {
+		return s.flowSeqs[GetFlowID(changed[i])] < s.flowSeqs[GetFlowID(changed[j])]
+	})
+	return changed
+}
+
+func flowBlobKeys(flow *mitmflowv1.Flow) []string {
+	var keys []string
+	if key := flow.GetHttpFlowExtra().GetRequest().GetBlobKey(); key != "" {
+		keys = append(keys, key)
+	}
+	if key := flow.GetHttpFlowExtra().GetResponse().GetBlobKey(); key != "" {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func ensureRequestDetails(flow *mitmflowv1.Flow) *mitmflowv1.MessageDetails {
+	if !flow.HasHttpFlowExtra() {
+		flow.SetHttpFlowExtra(&mitmflowv1.HTTPFlowExtra{})
+	}
+	if !flow.GetHttpFlowExtra().HasRequest() {
+		flow.GetHttpFlowExtra().SetRequest(&mitmflowv1.MessageDetails{})
+	}
+	return flow.GetHttpFlowExtra().GetRequest()
+}
+
+func ensureResponseDetails(flow *mitmflowv1.Flow) *mitmflowv1.MessageDetails {
+	if !flow.HasHttpFlowExtra() {
+		flow.SetHttpFlowExtra(&mitmflowv1.HTTPFlowExtra{})
+	}
+	if !flow.GetHttpFlowExtra().HasResponse() {
+		flow.GetHttpFlowExtra().SetResponse(&mitmflowv1.MessageDetails{})
 	}
+	return flow.GetHttpFlowExtra().GetResponse()
 }
@@ -1,7 +1,15 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"io/fs"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
 	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -23,6 +32,68 @@ func createFlow(id string, ts time.Time) *mitmflowv1.Flow {
 	return flow
 }
 
+// flowOption changes a flow built by createHTTPFlow.
+type flowOption func(*mitmflowv1.Flow)
+
+// createHTTPFlow returns a flow like createFlow's with a GET request for
+// rawURL answered by a bare 200 response, changed by opts.
+func createHTTPFlow(id, rawURL string, start time.Time, opts ...flowOption) *mitmflowv1.Flow {
+	flow := createFlow(id, start)
+	flow.GetHttpFlow().SetRequest(mitmproxyv1.Request_builder{
+		Method: proto.String("GET"),
+		Url:    proto.String(rawURL),
+	}.Build())
+	flow.GetHttpFlow().SetResponse(mitmproxyv1.Response_builder{
+		StatusCode: proto.Int32(200),
+	}.Build())
+	for _, opt := range opts {
+		opt(flow)
+	}
+	return flow
+}
+
+func withMethod(method string) flowOption {
+	return func(flow *mitmflowv1.Flow) {
+		flow.GetHttpFlow().GetRequest().SetMethod(method)
+	}
+}
+
+func withRequestHeaders(headers map[string]string) flowOption {
+	return func(flow *mitmflowv1.Flow) {
+		flow.GetHttpFlow().GetRequest().SetHeaders(headers)
+	}
+}
+
+func withStatus(code int32) flowOption {
+	return func(flow *mitmflowv1.Flow) {
+		flow.GetHttpFlow().GetResponse().SetStatusCode(code)
+	}
+}
+
+func withResponseHeaders(headers map[string]string) flowOption {
+	return func(flow *mitmflowv1.Flow) {
+		flow.GetHttpFlow().GetResponse().SetHeaders(headers)
+	}
+}
+
+func withResponseBody(body string) flowOption {
+	return func(flow *mitmflowv1.Flow) {
+		flow.GetHttpFlow().GetResponse().SetContent([]byte(body))
+	}
+}
+
+func withMetadata(metadata map[string]string) flowOption {
+	return func(flow *mitmflowv1.Flow) {
+		flow.SetMetadata(metadata)
+	}
+}
+
+func withClientIP(ip string) flowOption {
+	return func(flow *mitmflowv1.Flow) {
+		flow.GetHttpFlow().SetClient(mitmproxyv1.ClientConn_builder{PeernameHost: proto.String(ip)}.Build())
+	}
+}
+
 func TestFlowStorage_SortOrder(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "mitmflow_test_sort")
 	require.NoError(t, err)
@@ -129,6 +200,42 @@ func TestFlowStorage_PrunePinned(t *testing.T) {
 	assert.Equal(t, []string{"1", "3", "4"}, ids)
 }
 
+func TestFlowStorage_PruneRetention(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_test_prune_retention")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	})
+
+	s, err := NewFlowStorage(tmpDir, 100, WithMaxAge(24*time.Hour), WithRetainedTags("incident-1234", "keep=yes"))
+	require.NoError(t, err)
+	defer s.Close()
+
+	now := time.Now()
+	old := func(id string, metadata map[string]string) *mitmflowv1.Flow {
+		f := createFlow(id, now.Add(-48*time.Hour))
+		f.SetMetadata(metadata)
+		return f
+	}
+	require.NoError(t, s.SaveFlow(old("tagged", map[string]string{"incident-1234": "true"})))
+	require.NoError(t, s.SaveFlow(old("keep-yes", map[string]string{"keep": "yes"})))
+	require.NoError(t, s.SaveFlow(old("keep-no", map[string]string{"keep": "no"})))
+	require.NoError(t, s.SaveFlow(old("untagged", nil)))
+	require.NoError(t, s.SaveFlow(createFlow("recent", now.Add(-time.Hour))))
+
+	ids := make([]string, 0)
+	for _, f := range s.GetFlows() {
+		ids = append(ids, GetFlowID(f))
+	}
+	assert.ElementsMatch(t, []string{"tagged", "keep-yes", "recent"}, ids)
+
+	// Removing the tag makes the flow prunable again.
+	_, err = s.UpdateFlow("tagged", "", nil, nil, map[string]string{"incident-1234": ""})
+	require.NoError(t, err)
+	_, ok := s.GetFlow("tagged")
+	assert.False(t, ok)
+}
+
 func TestFlowStorage_UpdateFlow(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "mitmflow_test_update")
 	require.NoError(t, err)
@@ -145,7 +252,7 @@ func TestFlowStorage_UpdateFlow(t *testing.T) {
 
 	// Update pinned
 	pinned := true
-	_, err = s.UpdateFlow("1", &pinned, nil)
+	_, err = s.UpdateFlow("1", "", &pinned, nil, nil)
 	require.NoError(t, err)
 
 	flows := s.GetFlows()
@@ -153,11 +260,26 @@ func TestFlowStorage_UpdateFlow(t *testing.T) {
 
 	// Update note
 	note := "my note"
-	_, err = s.UpdateFlow("1", nil, &note)
+	_, err = s.UpdateFlow("1", "", nil, &note, nil)
 	require.NoError(t, err)
 
 	flows = s.GetFlows()
 	assert.Equal(t, "my note", flows[0].GetNote())
+
+	// Merge metadata, removing keys set to ""
+	_, err = s.UpdateFlow("1", "", nil, nil, map[string]string{"build": "1234", "test": "login"})
+	require.NoError(t, err)
+	_, err = s.UpdateFlow("1", "", nil, nil, map[string]string{"test": "", "ticket": "BUG-7"})
+	require.NoError(t, err)
+
+	flows = s.GetFlows()
+	assert.Equal(t, map[string]string{"build": "1234", "ticket": "BUG-7"}, flows[0].GetMetadata())
+	assert.Equal(t, "my note", flows[0].GetNote())
+
+	// Metadata survives mitmproxy sending the flow again
+	require.NoError(t, s.SaveFlow(createFlow("1", time.Now())))
+	flows = s.GetFlows()
+	assert.Equal(t, map[string]string{"build": "1234", "ticket": "BUG-7"}, flows[0].GetMetadata())
 }
 
 func TestFlowStorage_DeleteFlows(t *testing.T) {
@@ -174,11 +296,423 @@ func TestFlowStorage_DeleteFlows(t *testing.T) {
 	require.NoError(t, s.SaveFlow(createFlow("1", time.Now())))
 	require.NoError(t, s.SaveFlow(createFlow("2", time.Now().Add(time.Second))))
 
-	count, err := s.DeleteFlows([]string{"1"})
+	deleted, err := s.DeleteFlows([]string{"1"})
 	require.NoError(t, err)
-	assert.Equal(t, int64(1), count)
+	assert.Equal(t, []string{"1"}, deleted)
 
 	flows := s.GetFlows()
 	assert.Equal(t, 1, len(flows))
 	assert.Equal(t, "2", GetFlowID(flows[0]))
 }
+
+func TestFlowStorage_QuarantineCorrupt(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_test_corrupt")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	})
+
+	// A truncated protobuf: field 1 (http_flow) claims 100 bytes but has none.
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "broken.bin"), []byte{0x0a, 0x64}, 0644))
+	// A leftover temp file from an interrupted write.
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".good.bin.123"+tmpFileSuffix), []byte{0x0a}, 0644))
+
+	s, err := NewFlowStorage(tmpDir, 10)
+	require.NoError(t, err)
+	require.NoError(t, s.SaveFlow(createFlow("good", time.Now())))
+	s.Close()
+
+	assert.NoFileExists(t, filepath.Join(tmpDir, "broken.bin"))
+	assert.FileExists(t, filepath.Join(tmpDir, corruptDirName, "broken.bin"))
+	assert.FileExists(t, filepath.Join(tmpDir, "good.bin"))
+
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		assert.False(t, strings.HasSuffix(entry.Name(), tmpFileSuffix), "unexpected temp file %s", entry.Name())
+	}
+
+	// Reloading should pick up the flow written atomically.
+	s, err = NewFlowStorage(tmpDir, 10)
+	require.NoError(t, err)
+	defer s.Close()
+	_, ok := s.GetFlow("good")
+	assert.True(t, ok)
+}
+
+func TestFlowStorage_QuarantineTruncatedZstd(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_test_corrupt")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	})
+
+	s, err := NewFlowStorage(tmpDir, 10)
+	require.NoError(t, err)
+	require.NoError(t, s.SaveFlow(createFlow("broken", time.Now())))
+	s.Close()
+
+	// Cut the compressed file short, as a crash or a full disk could.
+	filename := filepath.Join(tmpDir, "broken.bin")
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(data, zstdMagic))
+	require.NoError(t, os.WriteFile(filename, data[:len(data)/2], 0644))
+
+	s, err = NewFlowStorage(tmpDir, 10)
+	require.NoError(t, err)
+	defer s.Close()
+	_, ok := s.GetFlow("broken")
+	assert.False(t, ok)
+	assert.NoFileExists(t, filename)
+	assert.FileExists(t, filepath.Join(tmpDir, corruptDirName, "broken.bin"))
+}
+
+func TestFlowStorage_ReadOnly(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_test_readonly")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	})
+
+	s, err := NewFlowStorage(tmpDir, 10)
+	require.NoError(t, err)
+	require.NoError(t, s.SaveFlow(createFlow("good", time.Now())))
+	s.Close()
+	sequence, err := os.ReadFile(filepath.Join(tmpDir, sequenceFile))
+	require.NoError(t, err)
+
+	// What a running server may be in the middle of writing, or hasn't
+	// cleaned up yet, is left alone.
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "broken.bin"), []byte{0x0a, 0x64}, 0644))
+	tmpFile := filepath.Join(tmpDir, ".next.bin.123"+tmpFileSuffix)
+	require.NoError(t, os.WriteFile(tmpFile, []byte{0x0a}, 0644))
+
+	s, err = NewFlowStorage(tmpDir, 10, WithReadOnly())
+	require.NoError(t, err)
+	_, ok := s.GetFlow("good")
+	assert.True(t, ok)
+	s.Close()
+
+	assert.FileExists(t, filepath.Join(tmpDir, "broken.bin"))
+	assert.NoDirExists(t, filepath.Join(tmpDir, corruptDirName))
+	assert.FileExists(t, tmpFile)
+	after, err := os.ReadFile(filepath.Join(tmpDir, sequenceFile))
+	require.NoError(t, err)
+	assert.Equal(t, sequence, after)
+
+	_, err = NewFlowStorage(filepath.Join(tmpDir, "missing"), 10, WithReadOnly())
+	require.Error(t, err)
+	assert.NoDirExists(t, filepath.Join(tmpDir, "missing"))
+}
+
+func TestFlowStorage_Compression(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_test_compression")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	})
+
+	// Write one flow uncompressed, as older versions did.
+	s, err := NewFlowStorage(tmpDir, 10, WithCompression(false))
+	require.NoError(t, err)
+	require.NoError(t, s.SaveFlow(createFlow("plain", time.Now())))
+	s.Close()
+
+	s, err = NewFlowStorage(tmpDir, 10)
+	require.NoError(t, err)
+	flow := createFlow("compressed", time.Now().Add(time.Second))
+	flow.GetHttpFlow().SetRequest(mitmproxyv1.Request_builder{
+		Content: []byte(strings.Repeat("compressible ", 1000)),
+	}.Build())
+	require.NoError(t, s.SaveFlow(flow))
+	s.Close()
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "compressed.bin"))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, zstdMagic))
+	assert.Less(t, len(data), 1000)
+
+	s, err = NewFlowStorage(tmpDir, 10)
+	require.NoError(t, err)
+	defer s.Close()
+	_, ok := s.GetFlow("plain")
+	assert.True(t, ok)
+	loaded, ok := s.GetFlow("compressed")
+	require.True(t, ok)
+	assert.Equal(t, flow.GetHttpFlow().GetRequest().GetContent(), loaded.GetHttpFlow().GetRequest().GetContent())
+}
+
+func TestFlowStorage_LoadSorted(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_test_load")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	})
+
+	s, err := NewFlowStorage(tmpDir, 1000)
+	require.NoError(t, err)
+	baseTime := time.Now()
+	// Random IDs so directory order doesn't match timestamp order.
+	for i := range 200 {
+		require.NoError(t, s.SaveFlow(createFlow(uuid.New().String(), baseTime.Add(time.Duration(i)*time.Millisecond))))
+	}
+	s.Close()
+
+	s, err = NewFlowStorage(tmpDir, 1000)
+	require.NoError(t, err)
+	defer s.Close()
+
+	flows := s.GetFlows()
+	require.Len(t, flows, 200)
+	for i := 1; i < len(flows); i++ {
+		assert.LessOrEqual(t, GetFlowStartTime(flows[i-1]), GetFlowStartTime(flows[i]))
+	}
+}
+
+func BenchmarkFlowStorage_Load(b *testing.B) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_bench_load")
+	require.NoError(b, err)
+	b.Cleanup(func() {
+		require.NoError(b, os.RemoveAll(tmpDir))
+	})
+
+	s, err := NewFlowStorage(tmpDir, 10000)
+	require.NoError(b, err)
+	baseTime := time.Now()
+	for i := range 5000 {
+		require.NoError(b, s.SaveFlow(createFlow(uuid.New().String(), baseTime.Add(time.Duration(i)*time.Millisecond))))
+	}
+	s.Close()
+
+	for b.Loop() {
+		s, err := NewFlowStorage(tmpDir, 10000)
+		if err != nil {
+			b.Fatal(err)
+		}
+		s.Close()
+	}
+}
+
+func TestFlowStorage_BlobOffload(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_test_blobs")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	})
+
+	blobs, err := NewLocalBlobStore(filepath.Join(tmpDir, "blobs"))
+	require.NoError(t, err)
+	s, err := NewFlowStorage(tmpDir, 10, WithBlobStore(blobs, 16))
+	require.NoError(t, err)
+	defer s.Close()
+
+	body := []byte(strings.Repeat("large body ", 10))
+	flow := createFlow("1", time.Now())
+	flow.GetHttpFlow().SetRequest(mitmproxyv1.Request_builder{Content: []byte("small")}.Build())
+	flow.GetHttpFlow().SetResponse(mitmproxyv1.Response_builder{Content: body}.Build())
+	require.NoError(t, s.SaveFlow(flow))
+
+	stored, ok := s.GetFlow("1")
+	require.True(t, ok)
+	assert.Equal(t, []byte("small"), stored.GetHttpFlow().GetRequest().GetContent())
+	assert.Empty(t, stored.GetHttpFlow().GetResponse().GetContent())
+	assert.Equal(t, blobKey(body), stored.GetHttpFlowExtra().GetResponse().GetBlobKey())
+	assert.Equal(t, int64(len(body)), stored.GetHttpFlowExtra().GetResponse().GetBodySize())
+
+	hydrated, err := s.HydrateFlow(context.Background(), stored)
+	require.NoError(t, err)
+	assert.Equal(t, body, hydrated.GetHttpFlow().GetResponse().GetContent())
+	assert.Empty(t, stored.GetHttpFlow().GetResponse().GetContent(), "stored flow must not be modified")
+
+	got, err := s.GetBody(context.Background(), stored, true)
+	require.NoError(t, err)
+	assert.Equal(t, body, got)
+
+	_, err = s.DeleteFlows([]string{"1"})
+	require.NoError(t, err)
+	s.Close()
+	_, err = blobs.Get(context.Background(), blobKey(body))
+	assert.ErrorIs(t, err, ErrBlobNotFound)
+}
+
+func TestFlowStorage_BlobReusedAfterDelete(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_test_blobs")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	})
+
+	blobs, err := NewLocalBlobStore(filepath.Join(tmpDir, "blobs"))
+	require.NoError(t, err)
+	s, err := NewFlowStorage(tmpDir, 10, WithBlobStore(blobs, 16))
+	require.NoError(t, err)
+	defer s.Close()
+
+	body := []byte(strings.Repeat("large body ", 10))
+	save := func(id string) {
+		flow := createFlow(id, time.Now())
+		flow.GetHttpFlow().SetResponse(mitmproxyv1.Response_builder{Content: slices.Clone(body)}.Build())
+		require.NoError(t, s.SaveFlow(flow))
+	}
+
+	// Hold the persist worker, so the blob's delete is still queued when the
+	// second flow with the same body is saved.
+	release := make(chan struct{})
+	s.mu.Lock()
+	s.persistCh <- func() { <-release }
+	s.mu.Unlock()
+
+	save("1")
+	_, err = s.DeleteFlows([]string{"1"})
+	require.NoError(t, err)
+	save("2")
+	close(release)
+	s.flush()
+
+	stored, ok := s.GetFlow("2")
+	require.True(t, ok)
+	hydrated, err := s.HydrateFlow(context.Background(), stored)
+	require.NoError(t, err)
+	assert.Equal(t, body, hydrated.GetHttpFlow().GetResponse().GetContent())
+}
+
+func TestFlowStorage_FlushInterval(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_test_flush")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	})
+
+	s, err := NewFlowStorage(tmpDir, 10, WithFlushInterval(time.Hour), WithTrash(time.Hour))
+	require.NoError(t, err)
+	defer s.Close()
+
+	for _, id := range []string{"1", "2", "3"} {
+		require.NoError(t, s.SaveFlow(createFlow(id, time.Now())))
+	}
+	note := "latest"
+	_, err = s.UpdateFlow("1", "", nil, &note, nil)
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(tmpDir, "1.bin"), "writes are batched")
+
+	// Deleting a flow that was never written still moves it to the trash.
+	_, err = s.DeleteFlows([]string{"2"})
+	require.NoError(t, err)
+	restored, err := s.RestoreTrashed([]string{"2"})
+	require.NoError(t, err)
+	assert.Len(t, restored, 1)
+
+	s.Close()
+	s2, err := NewFlowStorage(tmpDir, 10)
+	require.NoError(t, err)
+	defer s2.Close()
+	assert.Len(t, s2.GetFlows(), 3)
+	flow, ok := s2.GetFlow("1")
+	require.True(t, ok)
+	assert.Equal(t, "latest", flow.GetNote())
+}
+
+// mapBackend is a FlowBackend that keeps serialized flows in memory.
+type mapBackend struct {
+	flows map[string][]byte
+}
+
+func (b *mapBackend) Load() ([]*mitmflowv1.Flow, error) {
+	var flows []*mitmflowv1.Flow
+	for id := range b.flows {
+		flow, err := b.Read(id)
+		if err != nil {
+			return nil, err
+		}
+		flows = append(flows, flow)
+	}
+	return flows, nil
+}
+
+func (b *mapBackend) Save(id string, data []byte) error {
+	b.flows[id] = data
+	return nil
+}
+
+func (b *mapBackend) Read(id string) (*mitmflowv1.Flow, error) {
+	data, ok := b.flows[id]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	flow := &mitmflowv1.Flow{}
+	return flow, proto.Unmarshal(data, flow)
+}
+
+func (b *mapBackend) Remove(id string) error {
+	delete(b.flows, id)
+	return nil
+}
+
+func (b *mapBackend) Close() error { return nil }
+
+func TestFlowStorage_Backend(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_test_backend")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	})
+
+	backend := &mapBackend{flows: make(map[string][]byte)}
+	s, err := NewFlowStorage(tmpDir, 2, WithBackend(backend), WithStore(NewMemoryStore()), WithTrash(time.Hour))
+	require.NoError(t, err)
+
+	now := time.Now()
+	for i, id := range []string{"1", "2", "3"} {
+		require.NoError(t, s.SaveFlow(createFlow(id, now.Add(time.Duration(i)*time.Second))))
+	}
+	_, err = s.DeleteFlows([]string{"2"})
+	require.NoError(t, err)
+	restored, err := s.RestoreTrashed([]string{"2"})
+	require.NoError(t, err)
+	assert.Len(t, restored, 1)
+	s.Close()
+
+	assert.ElementsMatch(t, []string{"2", "3"}, slices.Collect(maps.Keys(backend.flows)), "pruned flows are removed from the backend")
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		assert.True(t, entry.IsDir() || entry.Name() == sequenceFile, "no flow files are written to the data directory")
+	}
+
+	s, err = NewFlowStorage(tmpDir, 2, WithBackend(backend))
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Len(t, s.GetFlows(), 2)
+}
+
+func TestFlowStorage_SequenceAcrossRestarts(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_test_sequence")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(tmpDir)) })
+
+	s, err := NewFlowStorage(tmpDir, 100)
+	require.NoError(t, err)
+	require.NoError(t, s.SaveFlow(createFlow("a", time.Now())))
+	require.NoError(t, s.SaveFlow(createFlow("b", time.Now())))
+	seq := s.Sequence()
+	// Until the storage is closed, the file holds a reserved sequence ahead
+	// of the current one, which a crashed run carries on from.
+	data, err := os.ReadFile(filepath.Join(tmpDir, sequenceFile))
+	require.NoError(t, err)
+	reserved, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	require.NoError(t, err)
+	assert.Greater(t, reserved, seq)
+	s.Close()
+
+	s, err = NewFlowStorage(tmpDir, 100)
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Equal(t, seq, s.Sequence(), "the sequence carries on where it stopped")
+	assert.Empty(t, s.ChangedSince(seq), "an up to date client gets nothing again")
+	assert.Len(t, s.ChangedSince(seq-1), 2)
+
+	require.NoError(t, s.SaveFlow(createFlow("c", time.Now())))
+	assert.Greater(t, s.FlowSequence("c"), seq)
+	assert.Equal(t, []string{"c"}, flowIDs(s.ChangedSince(seq)))
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"slices"
+	"strings"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxygrpcv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+)
+
+// streamMessage is a message of a TCP flow or a WebSocket connection.
+type streamMessage interface {
+	GetContent() []byte
+	GetFromClient() bool
+}
+
+// streamDecoder renders the packets of a protocol carried over a stream.
+type streamDecoder interface {
+	// decode renders the complete packets at the start of data, which holds
+	// the bytes sent in one direction that weren't decoded yet, and returns
+	// the bytes of a trailing packet that isn't complete yet.
+	decode(data []byte, fromClient bool) ([]string, []byte, error)
+}
+
+// streamProtocol is a protocol that TCP flows are decoded as.
+type streamProtocol struct {
+	// label is the flow's protocol label, see HTTPFlowExtra.protocol.
+	label string
+	// contentType is set as the effective content type of decoded messages.
+	contentType string
+	// ports are the server ports the protocol is usually served on.
+	ports []uint32
+	// opens, when set, recognizes the first message the client sends on a
+	// connection of the protocol, whatever its port.
+	opens func(first []byte) bool
+	// greets does the same with the first message the server sends, for
+	// protocols where the server speaks first.
+	greets func(first []byte) bool
+	// newDecoder returns a decoder for a connection. With redactValues set,
+	// decoders of protocols that carry data values, like database queries,
+	// leave the values out. It is nil for protocols that are only labeled.
+	newDecoder func(redactValues bool) streamDecoder
+}
+
+var streamProtocols = []streamProtocol{
+	{
+		label:       "tcp-mqtt",
+		contentType: "application/mqtt",
+		ports:       []uint32{1883, 8883},
+		newDecoder:  func(bool) streamDecoder { return &mqttDecoder{} },
+	},
+	{
+		label:       "tcp-amqp",
+		contentType: "application/amqp",
+		ports:       []uint32{5672, 5671},
+		opens:       isAMQPHeader,
+		newDecoder:  func(bool) streamDecoder { return &amqpDecoder{} },
+	},
+	{
+		label:       "tcp-postgresql",
+		contentType: "application/x-postgresql",
+		ports:       []uint32{5432},
+		opens:       isPostgresStartup,
+		newDecoder:  func(redact bool) streamDecoder { return &postgresDecoder{redact: redact} },
+	},
+	{
+		label:       "tcp-mysql",
+		contentType: "application/x-mysql",
+		ports:       []uint32{3306},
+		greets:      isMySQLHandshake,
+		newDecoder:  func(redact bool) streamDecoder { return &mysqlDecoder{redact: redact} },
+	},
+	{
+		label:       "dns-over-tcp",
+		contentType: "application/dns-message",
+		ports:       []uint32{53, 853},
+		opens:       isDNSOverTCP,
+		newDecoder:  func(bool) streamDecoder { return dnsStreamDecoder{} },
+	},
+	{
+		label:       "tcp-smtp",
+		contentType: "application/x-smtp",
+		ports:       []uint32{25, 465, 587, 2525},
+		greets:      isSMTPGreeting,
+		newDecoder:  func(bool) streamDecoder { return &mailDecoder{protocol: mailSMTP} },
+	},
+	{
+		label:       "tcp-imap",
+		contentType: "application/x-imap",
+		ports:       []uint32{143, 993},
+		greets:      isIMAPGreeting,
+		newDecoder:  func(bool) streamDecoder { return &mailDecoder{protocol: mailIMAP} },
+	},
+	{
+		label:       "tcp-pop3",
+		contentType: "application/x-pop3",
+		ports:       []uint32{110, 995},
+		greets:      isPOP3Greeting,
+		newDecoder:  func(bool) streamDecoder { return &mailDecoder{protocol: mailPOP3} },
+	},
+	{
+		label: "tcp-redis",
+		ports: []uint32{6379},
+		opens: isRESPCommand,
+	},
+}
+
+// detectStreamProtocol picks the protocol a TCP flow speaks, if it's one
+// mitmflow decodes.
+func detectStreamProtocol(flow *mitmproxygrpcv1.TCPFlow) *streamProtocol {
+	var fromClient, fromServer []byte
+	for _, msg := range flow.GetMessages() {
+		switch {
+		case msg.GetFromClient() && fromClient == nil:
+			fromClient = msg.GetContent()
+		case !msg.GetFromClient() && fromServer == nil:
+			fromServer = msg.GetContent()
+		}
+	}
+	for i, p := range streamProtocols {
+		if p.opens != nil && fromClient != nil && p.opens(fromClient) {
+			return &streamProtocols[i]
+		}
+		if p.greets != nil && fromServer != nil && p.greets(fromServer) {
+			return &streamProtocols[i]
+		}
+	}
+	server := flow.GetServer()
+	for i, p := range streamProtocols {
+		if slices.Contains(p.ports, server.GetPeernamePort()) || slices.Contains(p.ports, server.GetAddressPort()) {
+			return &streamProtocols[i]
+		}
+	}
+	return nil
+}
+
+// tcpMessageDetails builds the details for the messages of a TCP flow,
+// decoding them when the flow speaks a known protocol.
+func tcpMessageDetails(flow *mitmproxygrpcv1.TCPFlow, redactValues bool) *mitmflowv1.StreamFlowExtra {
+	p := detectStreamProtocol(flow)
+	if p == nil || p.newDecoder == nil {
+		extra := streamMessageDetails(flow.GetMessages())
+		extra.SetProtocol("tcp")
+		if p != nil {
+			extra.SetProtocol(p.label)
+		}
+		return extra
+	}
+	extra := &mitmflowv1.StreamFlowExtra{}
+	extra.SetMessages(decodeStreamMessages(flow.GetMessages(), p.contentType, p.newDecoder(redactValues)))
+	extra.SetProtocol(p.label)
+	return extra
+}
+
+// decodeStreamMessages decodes messages with dec, one frame per message
+// listing its packets. A packet split across messages is decoded with the
+// message it ends in. Binary messages that don't decode get a hexdump
+// instead.
+func decodeStreamMessages[M streamMessage](messages []M, contentType string, dec streamDecoder) []*mitmflowv1.MessageDetails {
+	var pending [2][]byte
+	details := make([]*mitmflowv1.MessageDetails, len(messages))
+	framed := 0
+	for i, msg := range messages {
+		d := &mitmflowv1.MessageDetails{}
+		d.SetBodySize(int64(len(msg.GetContent())))
+		details[i] = d
+
+		dir := 0
+		if msg.GetFromClient() {
+			dir = 1
+		}
+		data := append(pending[dir], msg.GetContent()...)
+		packets, rest, err := dec.decode(data, msg.GetFromClient())
+		pending[dir] = rest
+		if framed >= MaxTextualFrames {
+			continue
+		}
+		switch {
+		case err != nil:
+			pending[dir] = nil
+			if isBinary(msg.GetContent()) {
+				d.SetTextualFrames([]string{hexdumpFrame(msg.GetContent())})
+				framed++
+			}
+		case len(packets) > 0:
+			d.SetEffectiveContentType(contentType)
+			d.SetTextualFrames([]string{capFrame(strings.Join(packets, "\n\n"))})
+			framed++
+		}
+	}
+	return details
+}
+
+// streamPayload renders a message payload as indented JSON, text or, when
+// it's neither, a hexdump.
+func streamPayload(payload []byte) string {
+	if s, ok := canonicalJSON(payload); ok {
+		return s
+	}
+	if !isBinary(payload) {
+		return string(payload)
+	}
+	return strings.TrimSuffix(hexdumpFrame(payload), "\n")
+}
+
+func indentLines(s, indent string) string {
+	return indent + strings.ReplaceAll(s, "\n", "\n"+indent)
+}
+
+var errFieldPastEnd = errors.New("field runs past the end of the packet")
+
+// wireReader reads the big-endian fields of a packet. The first error sticks
+// and makes later reads return zero values.
+type wireReader struct {
+	data []byte
+	err  error
+}
+
+func (r *wireReader) len() int { return len(r.data) }
+
+func (r *wireReader) take(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(r.data) {
+		r.err = errFieldPastEnd
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *wireReader) rest() []byte {
+	return r.take(len(r.data))
+}
+
+func (r *wireReader) u8() byte {
+	if b := r.take(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *wireReader) u16() uint16 {
+	if b := r.take(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *wireReader) u32() uint32 {
+	if b := r.take(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *wireReader) u64() uint64 {
+	if b := r.take(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+// cstring reads a string ended by a NUL byte.
+func (r *wireReader) cstring() string {
+	if r.err != nil {
+		return ""
+	}
+	i := slices.Index(r.data, 0)
+	if i < 0 {
+		r.err = errFieldPastEnd
+		return ""
+	}
+	s := string(r.data[:i])
+	r.data = r.data[i+1:]
+	return s
+}
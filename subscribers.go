@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// subscriber is a client streaming new and updated flows. Flows are sent to
+// it through ch, which is dropped from rather than blocked on when full.
+type subscriber struct {
+	id          string
+	ch          chan *mitmflowv1.Flow
+	connectTime time.Time
+	peer        string
+	filter      *mitmflowv1.FlowFilter
+	delivered   atomic.Int64
+	dropped     atomic.Int64
+}
+
+// subscribe registers a subscriber that is sent every flow broadcast until
+// unsubscribe is called.
+func (s *MITMFlowServer) subscribe(peer string, filter *mitmflowv1.FlowFilter) *subscriber {
+	sub := &subscriber{
+		id: uuid.New().String(),
+		// Increased buffer size to prevent blocking/dropping during heavy load or history iteration
+		ch:          make(chan *mitmflowv1.Flow, 500),
+		connectTime: time.Now(),
+		peer:        peer,
+		filter:      filter,
+	}
+	s.mu.Lock()
+	s.subscribers[sub.id] = sub
+	s.mu.Unlock()
+	return sub
+}
+
+func (s *MITMFlowServer) unsubscribe(sub *subscriber) {
+	s.mu.Lock()
+	delete(s.subscribers, sub.id)
+	s.mu.Unlock()
+	close(sub.ch)
+}
+
+func (s *MITMFlowServer) ListSubscribers(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.ListSubscribersRequest],
+) (*connect.Response[mitmflowv1.ListSubscribersResponse], error) {
+	s.mu.RLock()
+	subs := make([]*subscriber, 0, len(s.subscribers))
+	for _, sub := range s.subscribers {
+		subs = append(subs, sub)
+	}
+	s.mu.RUnlock()
+	slices.SortFunc(subs, func(a, b *subscriber) int {
+		return a.connectTime.Compare(b.connectTime)
+	})
+
+	list := make([]*mitmflowv1.Subscriber, 0, len(subs))
+	for _, sub := range subs {
+		list = append(list, mitmflowv1.Subscriber_builder{
+			Id:          proto.String(sub.id),
+			ConnectTime: timestamppb.New(sub.connectTime),
+			Peer:        proto.String(sub.peer),
+			Filter:      sub.filter,
+			Delivered:   proto.Int64(sub.delivered.Load()),
+			Dropped:     proto.Int64(sub.dropped.Load()),
+		}.Build())
+	}
+	return connect.NewResponse(mitmflowv1.ListSubscribersResponse_builder{
+		Subscribers: list,
+	}.Build()), nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+var summaryTableHeader = []string{"Time", "Method", "URL", "Status", "Duration (ms)", "Size", "Content Type"}
+
+// summaryTableRows returns a row of summaryTableHeader columns per flow.
+// Flows that aren't HTTP use their type as the method and their server
+// address, or DNS question, as the URL.
+func summaryTableRows(flows []*mitmflowv1.Flow) [][]string {
+	rows := make([][]string, 0, len(flows))
+	for _, flow := range flows {
+		summary := convertToSummary(flow)
+		row := make([]string, len(summaryTableHeader))
+		if summary.HasTimestampStart() {
+			row[0] = summary.GetTimestampStart().AsTime().UTC().Format(time.RFC3339Nano)
+		}
+		switch {
+		case summary.HasHttp():
+			h := summary.GetHttp()
+			row[1] = h.GetMethod()
+			row[2] = h.GetUrl()
+			if h.GetStatusCode() != 0 {
+				row[3] = strconv.Itoa(int(h.GetStatusCode()))
+				row[5] = strconv.FormatInt(h.GetResponseContentLength(), 10)
+				row[6] = getHeaderValue(flow.GetHttpFlow().GetResponse().GetHeaders(), "Content-Type")
+			}
+			row[4] = strconv.FormatInt(h.GetDurationMs(), 10)
+		case summary.HasDns():
+			row[1] = "DNS"
+			row[2] = summary.GetDns().GetQuestionName()
+		case summary.HasTcp():
+			row[1] = "TCP"
+			row[2] = net.JoinHostPort(summary.GetTcp().GetServerAddressHost(), strconv.Itoa(int(summary.GetTcp().GetServerAddressPort())))
+		case summary.HasUdp():
+			row[1] = "UDP"
+			row[2] = net.JoinHostPort(summary.GetUdp().GetServerAddressHost(), strconv.Itoa(int(summary.GetUdp().GetServerAddressPort())))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// GenerateCSV writes a summary table of flows as CSV, for spreadsheets.
+func GenerateCSV(flows []*mitmflowv1.Flow) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(summaryTableHeader); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(summaryTableRows(flows)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GenerateMarkdown writes a summary table of flows as a Markdown table, for
+// pasting into bug reports.
+func GenerateMarkdown(flows []*mitmflowv1.Flow) ([]byte, error) {
+	var buf bytes.Buffer
+	writeRow := func(cells []string) {
+		buf.WriteString("|")
+		for _, cell := range cells {
+			fmt.Fprintf(&buf, " %s |", markdownCell(cell))
+		}
+		buf.WriteString("\n")
+	}
+	writeRow(summaryTableHeader)
+	buf.WriteString("|")
+	for range summaryTableHeader {
+		buf.WriteString(" --- |")
+	}
+	buf.WriteString("\n")
+	for _, row := range summaryTableRows(flows) {
+		writeRow(row)
+	}
+	return buf.Bytes(), nil
+}
+
+// markdownCell escapes the characters that would break a table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
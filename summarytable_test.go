@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func summaryTableTestFlows() []*mitmflowv1.Flow {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	return []*mitmflowv1.Flow{
+		mitmflowv1.Flow_builder{
+			HttpFlow: mitmproxyv1.HTTPFlow_builder{
+				Id:             proto.String("1"),
+				TimestampStart: timestamppb.New(start),
+				DurationMs:     proto.Float64(42.7),
+				Request: mitmproxyv1.Request_builder{
+					Method: proto.String("GET"),
+					Url:    proto.String("https://example.com/a|b"),
+				}.Build(),
+				Response: mitmproxyv1.Response_builder{
+					StatusCode: proto.Int32(200),
+					Headers:    map[string]string{"content-type": "text/plain, charset=utf-8"},
+					Content:    []byte("hello"),
+				}.Build(),
+			}.Build(),
+		}.Build(),
+		mitmflowv1.Flow_builder{
+			TcpFlow: mitmproxyv1.TCPFlow_builder{
+				Id:             proto.String("2"),
+				TimestampStart: timestamppb.New(start.Add(time.Second)),
+				Server: mitmproxyv1.ServerConn_builder{
+					AddressHost: proto.String("10.0.0.1"),
+					AddressPort: proto.Uint32(5432),
+				}.Build(),
+			}.Build(),
+		}.Build(),
+	}
+}
+
+func TestGenerateCSV(t *testing.T) {
+	data, err := GenerateCSV(summaryTableTestFlows())
+	require.NoError(t, err)
+	assert.Equal(t, "Time,Method,URL,Status,Duration (ms),Size,Content Type\n"+
+		"2024-05-01T12:00:00Z,GET,https://example.com/a|b,200,42,5,\"text/plain, charset=utf-8\"\n"+
+		"2024-05-01T12:00:01Z,TCP,10.0.0.1:5432,,,,\n", string(data))
+}
+
+func TestGenerateMarkdown(t *testing.T) {
+	data, err := GenerateMarkdown(summaryTableTestFlows())
+	require.NoError(t, err)
+	assert.Equal(t, "| Time | Method | URL | Status | Duration (ms) | Size | Content Type |\n"+
+		"| --- | --- | --- | --- | --- | --- | --- |\n"+
+		"| 2024-05-01T12:00:00Z | GET | https://example.com/a\\|b | 200 | 42 | 5 | text/plain, charset=utf-8 |\n"+
+		"| 2024-05-01T12:00:01Z | TCP | 10.0.0.1:5432 |  |  |  |  |\n", string(data))
+}
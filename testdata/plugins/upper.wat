@@ -0,0 +1,56 @@
+;; A decoder plugin for application/x-acme bodies that upper-cases them.
+;; NUL bytes in the body split it into frames.
+;;
+;; upper.wasm is built from this file with: wat2wasm upper.wat
+(module
+  (memory (export "memory") 1)
+  (data (i32.const 0) "application/x-acme")
+
+  ;; The body is always written at 1024.
+  (func (export "alloc") (param $size i32) (result i32)
+    i32.const 1024)
+
+  (func (export "content_types") (result i64)
+    ;; ptr 0, len 18
+    i64.const 18)
+
+  (func (export "decode") (param $ptr i32) (param $len i32) (result i64)
+    (local $i i32) (local $a i32) (local $c i32)
+    block
+      loop
+        local.get $i
+        local.get $len
+        i32.ge_u
+        br_if 1
+        local.get $ptr
+        local.get $i
+        i32.add
+        local.set $a
+        local.get $a
+        local.get $a
+        i32.load8_u
+        local.tee $c
+        ;; c - ((c - 'a') < 26) << 5
+        local.get $c
+        i32.const 97
+        i32.sub
+        i32.const 26
+        i32.lt_u
+        i32.const 5
+        i32.shl
+        i32.sub
+        i32.store8
+        local.get $i
+        i32.const 1
+        i32.add
+        local.set $i
+        br 0
+      end
+    end
+    local.get $ptr
+    i64.extend_i32_u
+    i64.const 32
+    i64.shl
+    local.get $len
+    i64.extend_i32_u
+    i64.or))
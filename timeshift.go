@@ -0,0 +1,117 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	timestampName = (&timestamppb.Timestamp{}).ProtoReflect().Descriptor().FullName()
+	certName      = (&mitmproxyv1.Cert{}).ProtoReflect().Descriptor().FullName()
+)
+
+// shiftFlows returns copies of flows with every timestamp moved by the same
+// amount, so that the earliest one lands on epoch. Relative timing is kept
+// while hiding when the capture was taken. Certificate validity periods
+// describe the certificate rather than the capture and are left alone; Date
+// headers are shifted along with the flows.
+func shiftFlows(flows []*mitmflowv1.Flow, epoch time.Time) []*mitmflowv1.Flow {
+	var earliest time.Time
+	for _, flow := range flows {
+		walkTimestamps(flow.ProtoReflect(), func(ts *timestamppb.Timestamp) {
+			if t := ts.AsTime(); earliest.IsZero() || t.Before(earliest) {
+				earliest = t
+			}
+		})
+	}
+	offset := epoch.Sub(earliest)
+
+	res := make([]*mitmflowv1.Flow, len(flows))
+	for i, flow := range flows {
+		flow = proto.Clone(flow).(*mitmflowv1.Flow)
+		if !earliest.IsZero() {
+			shiftFlow(flow, offset)
+		}
+		res[i] = flow
+	}
+	return res
+}
+
+func shiftFlow(flow *mitmflowv1.Flow, offset time.Duration) {
+	walkTimestamps(flow.ProtoReflect(), func(ts *timestamppb.Timestamp) {
+		t := ts.AsTime().Add(offset)
+		ts.Seconds = t.Unix()
+		ts.Nanos = int32(t.Nanosecond())
+	})
+	if h := flow.GetHttpFlow(); h != nil {
+		if req := h.GetRequest(); req != nil {
+			req.SetHeaders(shiftDateHeader(req.GetHeaders(), offset))
+		}
+		if res := h.GetResponse(); res != nil {
+			res.SetHeaders(shiftDateHeader(res.GetHeaders(), offset))
+		}
+	}
+	if extra := flow.GetHttpFlowExtra(); extra != nil {
+		for _, details := range []*mitmflowv1.MessageDetails{extra.GetRequest(), extra.GetResponse()} {
+			timestamps := details.GetFrameTimestampsNs()
+			for i, ns := range timestamps {
+				// 0 means the arrival time isn't known.
+				if ns != 0 {
+					timestamps[i] = ns + offset.Nanoseconds()
+				}
+			}
+		}
+	}
+}
+
+// walkTimestamps calls fn for every non-zero timestamp in m outside
+// certificates.
+func walkTimestamps(m protoreflect.Message, fn func(*timestamppb.Timestamp)) {
+	if m.Descriptor().FullName() == certName {
+		return
+	}
+	if m.Descriptor().FullName() == timestampName {
+		if ts := m.Interface().(*timestamppb.Timestamp); ts.GetSeconds() != 0 || ts.GetNanos() != 0 {
+			fn(ts)
+		}
+		return
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					walkTimestamps(v.Message(), fn)
+					return true
+				})
+			}
+		case fd.Message() == nil:
+		case fd.IsList():
+			for i := 0; i < v.List().Len(); i++ {
+				walkTimestamps(v.List().Get(i).Message(), fn)
+			}
+		default:
+			walkTimestamps(v.Message(), fn)
+		}
+		return true
+	})
+}
+
+func shiftDateHeader(headers map[string]string, offset time.Duration) map[string]string {
+	for k, v := range headers {
+		if !strings.EqualFold(k, "date") {
+			continue
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			headers[k] = t.Add(offset).UTC().Format(http.TimeFormat)
+		}
+	}
+	return headers
+}
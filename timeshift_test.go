@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestShiftFlows(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	flows := []*mitmflowv1.Flow{
+		mitmflowv1.Flow_builder{
+			HttpFlow: mitmproxyv1.HTTPFlow_builder{
+				Id:             proto.String("1"),
+				TimestampStart: timestamppb.New(start.Add(time.Second)),
+				Client: mitmproxyv1.ClientConn_builder{
+					TimestampStart: timestamppb.New(start),
+				}.Build(),
+				Server: mitmproxyv1.ServerConn_builder{
+					CertificateList: []*mitmproxyv1.Cert{mitmproxyv1.Cert_builder{
+						Notafter: timestamppb.New(notAfter),
+					}.Build()},
+				}.Build(),
+				Request: mitmproxyv1.Request_builder{
+					TimestampStart: timestamppb.New(start.Add(time.Second)),
+				}.Build(),
+				Response: mitmproxyv1.Response_builder{
+					TimestampStart: timestamppb.New(start.Add(1500 * time.Millisecond)),
+					Headers:        map[string]string{"Date": "Wed, 01 May 2024 12:00:01 GMT"},
+				}.Build(),
+			}.Build(),
+			HttpFlowExtra: mitmflowv1.HTTPFlowExtra_builder{
+				Response: mitmflowv1.MessageDetails_builder{
+					FrameTimestampsNs: []int64{0, start.Add(2 * time.Second).UnixNano()},
+				}.Build(),
+			}.Build(),
+		}.Build(),
+		mitmflowv1.Flow_builder{
+			DnsFlow: mitmproxyv1.DNSFlow_builder{
+				Id:             proto.String("2"),
+				TimestampStart: timestamppb.New(start.Add(time.Minute)),
+			}.Build(),
+		}.Build(),
+	}
+
+	epoch := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	shifted := shiftFlows(flows, epoch)
+	h := shifted[0].GetHttpFlow()
+	assert.Equal(t, epoch, h.GetClient().GetTimestampStart().AsTime())
+	assert.Equal(t, epoch.Add(time.Second), h.GetTimestampStart().AsTime())
+	assert.Equal(t, epoch.Add(time.Second), h.GetRequest().GetTimestampStart().AsTime())
+	assert.Equal(t, epoch.Add(1500*time.Millisecond), h.GetResponse().GetTimestampStart().AsTime())
+	assert.Equal(t, "Sat, 01 Jan 2000 00:00:01 GMT", h.GetResponse().GetHeaders()["Date"])
+	assert.Equal(t, notAfter, h.GetServer().GetCertificateList()[0].GetNotafter().AsTime())
+	assert.Equal(t, []int64{0, epoch.Add(2 * time.Second).UnixNano()},
+		shifted[0].GetHttpFlowExtra().GetResponse().GetFrameTimestampsNs())
+	assert.Equal(t, epoch.Add(time.Minute), shifted[1].GetDnsFlow().GetTimestampStart().AsTime())
+
+	// The originals are left alone.
+	assert.Equal(t, start, flows[0].GetHttpFlow().GetClient().GetTimestampStart().AsTime())
+	assert.Equal(t, "Wed, 01 May 2024 12:00:01 GMT", flows[0].GetHttpFlow().GetResponse().GetHeaders()["Date"])
+}
+
+func TestExportFlowsShiftTimestamps(t *testing.T) {
+	server, storage := newTestServer(t)
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i, id := range []string{"1", "2"} {
+		require.NoError(t, storage.SaveFlow(mitmflowv1.Flow_builder{
+			TcpFlow: mitmproxyv1.TCPFlow_builder{
+				Id:             proto.String(id),
+				TimestampStart: timestamppb.New(start.Add(time.Duration(i) * time.Second)),
+			}.Build(),
+		}.Build()))
+	}
+
+	format := mitmflowv1.ExportFormat_EXPORT_FORMAT_PROTO
+	resp, err := server.ExportFlows(context.Background(), connect.NewRequest(mitmflowv1.ExportFlowsRequest_builder{
+		FlowIds:         []string{"1", "2"},
+		Format:          &format,
+		ShiftTimestamps: proto.Bool(true),
+	}.Build()))
+	require.NoError(t, err)
+	set := &mitmflowv1.FlowSet{}
+	require.NoError(t, proto.Unmarshal(resp.Msg.GetData(), set))
+	require.Len(t, set.GetFlows(), 2)
+	assert.Equal(t, time.Unix(0, 0).UTC(), set.GetFlows()[0].GetTcpFlow().GetTimestampStart().AsTime())
+	assert.Equal(t, time.Unix(1, 0).UTC(), set.GetFlows()[1].GetTcpFlow().GetTimestampStart().AsTime())
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"container/heap"
+	"context"
+
+	"connectrpc.com/connect"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+// defaultTopFlows is how many flows TopFlows returns without a limit.
+const defaultTopFlows = 10
+
+// TopFlows returns the slowest or largest visible flows matching a filter.
+// It keeps the worst flows seen so far in a heap instead of sorting every
+// match.
+func (s *MITMFlowServer) TopFlows(
+	ctx context.Context,
+	req *connect.Request[mitmflowv1.TopFlowsRequest],
+) (*connect.Response[mitmflowv1.TopFlowsResponse], error) {
+	match, err := parseFilterExpr(req.Msg.GetFilterExpr())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	value := topFlowsValue(req.Msg.GetOrder())
+	limit := int(req.Msg.GetLimit())
+	if limit <= 0 {
+		limit = defaultTopFlows
+	}
+
+	user := annotationUser(ctx)
+	visible := visibleTo(ctx)
+	filter := req.Msg.GetFilter()
+	top := &topFlowsHeap{}
+	seen := 0
+	s.storage.ReverseWalk(func(flow *mitmflowv1.Flow) bool {
+		flow = viewAs(flow, user)
+		if !visible(flow) || !matchFlow(flow, filter) || !match(flow) {
+			return true
+		}
+		entry := topFlow{flow: flow, value: value(flowTotals(flow)), seen: seen}
+		seen++
+		switch {
+		case top.Len() < limit:
+			heap.Push(top, entry)
+		case top.less(top.entries[0], entry):
+			top.entries[0] = entry
+			heap.Fix(top, 0)
+		}
+		return true
+	})
+
+	flows := make([]*mitmflowv1.FlowSummary, top.Len())
+	for i := len(flows) - 1; i >= 0; i-- {
+		flows[i] = s.summarize(heap.Pop(top).(topFlow).flow)
+	}
+	return connect.NewResponse(mitmflowv1.TopFlowsResponse_builder{Flows: flows}.Build()), nil
+}
+
+// topFlowsValue returns what flows are ranked by for an order.
+func topFlowsValue(order mitmflowv1.TopFlowsOrder) func(*mitmflowv1.FlowTotals) int64 {
+	if order == mitmflowv1.TopFlowsOrder_TOP_FLOWS_ORDER_LARGEST {
+		return func(t *mitmflowv1.FlowTotals) int64 { return t.GetRequestBytes() + t.GetResponseBytes() }
+	}
+	return (*mitmflowv1.FlowTotals).GetDurationMs
+}
+
+// topFlow is a flow ranked by TopFlows. seen counts the matches before it;
+// as flows are walked newest first, a lower count is a newer flow.
+type topFlow struct {
+	flow  *mitmflowv1.Flow
+	value int64
+	seen  int
+}
+
+// topFlowsHeap is a min-heap of the worst flows seen so far, with the least
+// bad one on top so it can be replaced by a worse one. Of two flows with the
+// same value, the older one is less bad.
+type topFlowsHeap struct {
+	entries []topFlow
+}
+
+func (h *topFlowsHeap) less(a, b topFlow) bool {
+	if a.value != b.value {
+		return a.value < b.value
+	}
+	return a.seen > b.seen
+}
+
+func (h *topFlowsHeap) Len() int           { return len(h.entries) }
+func (h *topFlowsHeap) Less(i, j int) bool { return h.less(h.entries[i], h.entries[j]) }
+func (h *topFlowsHeap) Swap(i, j int)      { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+func (h *topFlowsHeap) Push(x any)         { h.entries = append(h.entries, x.(topFlow)) }
+func (h *topFlowsHeap) Pop() any {
+	last := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return last
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestTopFlows(t *testing.T) {
+	server, storage := newTestServer(t)
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	save := func(id, host string, at time.Duration, durationMs float64, bodySize int) {
+		flow := mitmflowv1.Flow_builder{
+			HttpFlow: mitmproxyv1.HTTPFlow_builder{
+				Id:             proto.String(id),
+				TimestampStart: timestamppb.New(start.Add(at)),
+				DurationMs:     proto.Float64(durationMs),
+				Request: mitmproxyv1.Request_builder{
+					Method: proto.String("GET"),
+					Url:    proto.String("https://" + host + "/"),
+				}.Build(),
+				Response: mitmproxyv1.Response_builder{
+					StatusCode: proto.Int32(200),
+					Content:    []byte(strings.Repeat("x", bodySize)),
+				}.Build(),
+			}.Build(),
+		}.Build()
+		server.preprocessFlow(flow)
+		require.NoError(t, storage.SaveFlow(flow))
+	}
+	save("a", "api.example.com", 0, 120, 10)
+	save("b", "api.example.com", time.Second, 900, 5)
+	save("c", "cdn.example.com", 2*time.Second, 50, 5000)
+	save("d", "api.example.com", 3*time.Second, 120, 20)
+	save("e", "api.example.com", 4*time.Second, 30, 1000)
+
+	top := func(req *mitmflowv1.TopFlowsRequest) []string {
+		res, err := server.TopFlows(context.Background(), connect.NewRequest(req))
+		require.NoError(t, err)
+		var ids []string
+		for _, f := range res.Msg.GetFlows() {
+			ids = append(ids, f.GetId())
+		}
+		return ids
+	}
+	slowest := mitmflowv1.TopFlowsOrder_TOP_FLOWS_ORDER_SLOWEST.Enum()
+	assert.Equal(t, []string{"b", "d", "a"}, top(mitmflowv1.TopFlowsRequest_builder{Order: slowest, Limit: proto.Int32(3)}.Build()),
+		"ties keep the newest first")
+	assert.Equal(t, []string{"e", "d", "a"}, top(mitmflowv1.TopFlowsRequest_builder{
+		Order:      mitmflowv1.TopFlowsOrder_TOP_FLOWS_ORDER_LARGEST.Enum(),
+		FilterExpr: proto.String("~d ^api"),
+		Limit:      proto.Int32(3),
+	}.Build()))
+	assert.Len(t, top(mitmflowv1.TopFlowsRequest_builder{Order: slowest}.Build()), 5)
+
+	_, err := server.TopFlows(context.Background(), connect.NewRequest(mitmflowv1.TopFlowsRequest_builder{
+		Order:      slowest,
+		FilterExpr: proto.String("~bogus"),
+	}.Build()))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
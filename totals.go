@@ -0,0 +1,106 @@
+package main
+
+import (
+	"net/url"
+	"strconv"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxygrpcv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// httpTotals computes the totals of an HTTP flow. extra holds the original
+// body sizes, as the bodies may have been truncated.
+func httpTotals(f *mitmproxygrpcv1.HTTPFlow, extra *mitmflowv1.HTTPFlowExtra) *mitmflowv1.FlowTotals {
+	totals := &mitmflowv1.FlowTotals{}
+	if req := f.GetRequest(); req != nil {
+		target := req.GetUrl()
+		if u, err := url.Parse(target); err == nil {
+			target = u.RequestURI()
+		}
+		line := req.GetMethod() + " " + target + " " + req.GetHttpVersion()
+		totals.SetRequestBytes(httpMessageBytes(line, req.GetHeaders(), req.GetTrailers(), getBodySize(req.GetContent(), extra.GetRequest())))
+	}
+	if resp := f.GetResponse(); resp != nil {
+		line := resp.GetHttpVersion() + " " + strconv.Itoa(int(resp.GetStatusCode())) + " " + resp.GetReason()
+		totals.SetResponseBytes(httpMessageBytes(line, resp.GetHeaders(), resp.GetTrailers(), getBodySize(resp.GetContent(), extra.GetResponse())))
+	}
+	end := f.GetResponse().GetTimestampEnd()
+	if end == nil {
+		end = f.GetRequest().GetTimestampEnd()
+	}
+	totals.SetDurationMs(durationMs(f.GetDurationMs(), f.GetTimestampStart(), end))
+	return totals
+}
+
+// httpMessageBytes is the size of an HTTP message as HTTP/1 sends it: the
+// start line, the headers, a blank line, the body and the trailers.
+func httpMessageBytes(startLine string, headers, trailers map[string]string, bodySize int64) int64 {
+	n := int64(len(startLine)) + 2 + 2 + bodySize
+	for _, fields := range []map[string]string{headers, trailers} {
+		for k, v := range fields {
+			n += int64(len(k) + len(": ") + len(v) + len("\r\n"))
+		}
+	}
+	return n
+}
+
+// streamTotals computes the totals of a TCP or UDP flow from its messages.
+func streamTotals[M interface {
+	streamMessage
+	GetTimestamp() *timestamppb.Timestamp
+}](messages []M, flowDurationMs float64, start *timestamppb.Timestamp) *mitmflowv1.FlowTotals {
+	totals := &mitmflowv1.FlowTotals{}
+	var fromClient, fromServer int64
+	var last *timestamppb.Timestamp
+	for _, msg := range messages {
+		if msg.GetFromClient() {
+			fromClient += int64(len(msg.GetContent()))
+		} else {
+			fromServer += int64(len(msg.GetContent()))
+		}
+		if ts := msg.GetTimestamp(); ts != nil {
+			last = ts
+		}
+	}
+	totals.SetRequestBytes(fromClient)
+	totals.SetResponseBytes(fromServer)
+	totals.SetDurationMs(durationMs(flowDurationMs, start, last))
+	return totals
+}
+
+// dnsTotals computes the totals of a DNS flow from its packed messages.
+func dnsTotals(f *mitmproxygrpcv1.DNSFlow) *mitmflowv1.FlowTotals {
+	return mitmflowv1.FlowTotals_builder{
+		RequestBytes:  proto.Int64(int64(len(f.GetRequest().GetPacked()))),
+		ResponseBytes: proto.Int64(int64(len(f.GetResponse().GetPacked()))),
+		DurationMs:    proto.Int64(int64(f.GetDurationMs())),
+	}.Build()
+}
+
+// durationMs returns the duration mitmproxy reported, or else the time from
+// start to end when both are known.
+func durationMs(reported float64, start, end *timestamppb.Timestamp) int64 {
+	if reported > 0 || start == nil || end == nil {
+		return int64(reported)
+	}
+	d := end.AsTime().Sub(start.AsTime())
+	if d < 0 {
+		return 0
+	}
+	return d.Milliseconds()
+}
+
+// flowTotals returns the totals computed for a flow at ingest.
+func flowTotals(flow *mitmflowv1.Flow) *mitmflowv1.FlowTotals {
+	switch {
+	case flow.HasHttpFlowExtra():
+		return flow.GetHttpFlowExtra().GetTotals()
+	case flow.HasStreamFlowExtra():
+		return flow.GetStreamFlowExtra().GetTotals()
+	case flow.HasDnsFlowExtra():
+		return flow.GetDnsFlowExtra().GetTotals()
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestFlowTotals_HTTP(t *testing.T) {
+	server, err := NewMITMFlowServer(nil, nil, WithMaxBodyBytes(4))
+	require.NoError(t, err)
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	flow := mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			TimestampStart: timestamppb.New(start),
+			Request: mitmproxyv1.Request_builder{
+				Method:      proto.String("POST"),
+				Url:         proto.String("https://example.com/a?b=1"),
+				HttpVersion: proto.String("HTTP/1.1"),
+				Headers:     map[string]string{"Host": "example.com"},
+				Content:     []byte("hello"),
+			}.Build(),
+			Response: mitmproxyv1.Response_builder{
+				StatusCode:   proto.Int32(200),
+				Reason:       proto.String("OK"),
+				HttpVersion:  proto.String("HTTP/1.1"),
+				Content:      []byte("hi"),
+				TimestampEnd: timestamppb.New(start.Add(1500 * time.Millisecond)),
+			}.Build(),
+		}.Build(),
+	}.Build()
+	server.preprocessFlow(flow)
+
+	totals := flowTotals(flow)
+	// "POST /a?b=1 HTTP/1.1\r\n" + "Host: example.com\r\n" + "\r\n" + body at
+	// its original size, though it was truncated.
+	assert.Equal(t, int64(22+19+2+5), totals.GetRequestBytes())
+	// "HTTP/1.1 200 OK\r\n" + "\r\n" + "hi"
+	assert.Equal(t, int64(17+2+2), totals.GetResponseBytes())
+	assert.Equal(t, int64(1500), totals.GetDurationMs())
+	assert.Equal(t, int64(1500), convertToSummary(flow).GetHttp().GetDurationMs())
+}
+
+func TestFlowTotals_Stream(t *testing.T) {
+	server, err := NewMITMFlowServer(nil, nil)
+	require.NoError(t, err)
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	flow := mitmflowv1.Flow_builder{
+		TcpFlow: mitmproxyv1.TCPFlow_builder{
+			Id:             proto.String("tcp"),
+			TimestampStart: timestamppb.New(start),
+			Messages: []*mitmproxyv1.TCPMessage{
+				mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(true), Content: []byte("ping"), Timestamp: timestamppb.New(start)}.Build(),
+				mitmproxyv1.TCPMessage_builder{FromClient: proto.Bool(false), Content: []byte("pong!"), Timestamp: timestamppb.New(start.Add(250 * time.Millisecond))}.Build(),
+			},
+		}.Build(),
+	}.Build()
+	server.preprocessFlow(flow)
+
+	totals := convertToSummary(flow).GetTotals()
+	assert.Equal(t, int64(4), totals.GetRequestBytes())
+	assert.Equal(t, int64(5), totals.GetResponseBytes())
+	assert.Equal(t, int64(250), totals.GetDurationMs())
+
+	dns := mitmflowv1.Flow_builder{
+		DnsFlow: mitmproxyv1.DNSFlow_builder{
+			Id:         proto.String("dns"),
+			DurationMs: proto.Float64(12.7),
+			Request:    mitmproxyv1.DNSMessage_builder{Packed: make([]byte, 29)}.Build(),
+		}.Build(),
+	}.Build()
+	server.preprocessFlow(dns)
+	assert.Equal(t, int64(29), flowTotals(dns).GetRequestBytes())
+	assert.Equal(t, int64(12), flowTotals(dns).GetDurationMs())
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// trashDirName is the directory inside the data directory deleted flows are
+// kept in until they are purged.
+const trashDirName = "trash"
+
+// WithTrash makes deleting flows move them into the trash, where they can be
+// restored with RestoreTrashed until they have been there for retention.
+// Trashed flows have their blobs inlined, so purging them needs no
+// bookkeeping.
+func WithTrash(retention time.Duration) StorageOption {
+	return func(s *FlowStorage) {
+		s.trashRetention = retention
+	}
+}
+
+// TrashEnabled reports whether deleted flows can be restored.
+func (s *FlowStorage) TrashEnabled() bool {
+	return s.trashRetention > 0
+}
+
+func (s *FlowStorage) trashDir() string {
+	return filepath.Join(s.dir, trashDirName)
+}
+
+// removeFlowFiles queues removing the files of deleted flows, moving them into
+// the trash when it is enabled. Must be called with s.mu held and before the
+// flows' blobs are released, so the trash task can still read them.
+func (s *FlowStorage) removeFlowFiles(ids []string) {
+	if len(ids) == 0 || s.persistCh == nil {
+		return
+	}
+	ids = append([]string(nil), ids...)
+	// Flows that were never written can only be trashed once they are.
+	s.settlePending(ids, s.TrashEnabled())
+	s.persistCh <- func() {
+		for _, id := range ids {
+			if s.TrashEnabled() {
+				if err := s.trashFlow(id); err == nil {
+					continue
+				} else if !errors.Is(err, fs.ErrNotExist) {
+					log.Printf("failed to move flow %s to the trash: %v", id, err)
+				}
+			}
+			s.removeFromDisk(id)
+		}
+		s.purgeTrash()
+	}
+}
+
+// trashFlow moves a flow from the backend into the trash. The file's
+// modification time records when the flow was deleted.
+func (s *FlowStorage) trashFlow(id string) error {
+	flow, err := s.backend.Read(id)
+	if err != nil {
+		return err
+	}
+	if err := s.loadBlobs(context.Background(), flow); err != nil {
+		return err
+	}
+	data, err := proto.Marshal(flow)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.trashDir(), 0755); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(s.trashDir(), id+".bin"), s.codec.encode(data), 0644); err != nil {
+		return err
+	}
+	return s.backend.Remove(id)
+}
+
+// purgeTrash removes flows that have been in the trash for longer than the
+// retention period.
+func (s *FlowStorage) purgeTrash() {
+	entries, err := os.ReadDir(s.trashDir())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("failed to read trash: %v", err)
+		}
+		return
+	}
+	cutoff := time.Now().Add(-s.trashRetention)
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || entry.IsDir() || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.trashDir(), entry.Name())); err != nil {
+			log.Printf("failed to purge %s from the trash: %v", entry.Name(), err)
+		}
+	}
+}
+
+// RestoreTrashed moves flows out of the trash and back into the store and
+// returns them, oldest first. IDs that aren't in the trash are skipped.
+func (s *FlowStorage) RestoreTrashed(ids []string) ([]*mitmflowv1.Flow, error) {
+	if !s.TrashEnabled() {
+		return nil, fmt.Errorf("the trash is not enabled")
+	}
+	// Deletions are moved into the trash by the persist worker.
+	s.flush()
+
+	var restored []*mitmflowv1.Flow
+	for _, id := range ids {
+		if !validFlowID(id) {
+			continue
+		}
+		filename := filepath.Join(s.trashDir(), id+".bin")
+		flow, err := readFlowFile(filename, s.codec)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return restored, fmt.Errorf("failed to read trashed flow %s: %w", id, err)
+		}
+		if err := s.SaveFlow(flow); err != nil {
+			return restored, err
+		}
+		if err := os.Remove(filename); err != nil {
+			return restored, err
+		}
+		restored = append(restored, flow)
+	}
+	sort.Slice(restored, func(i, j int) bool {
+		return GetFlowStartTime(restored[i]) < GetFlowStartTime(restored[j])
+	})
+	return restored, nil
+}
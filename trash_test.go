@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+)
+
+func TestFlowStorage_Trash(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_test")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(tmpDir)) })
+
+	blobs, err := NewLocalBlobStore(filepath.Join(tmpDir, "blobs"))
+	require.NoError(t, err)
+	s, err := NewFlowStorage(tmpDir, 10, WithTrash(time.Hour), WithBlobStore(blobs, 16))
+	require.NoError(t, err)
+	defer s.Close()
+
+	body := []byte(strings.Repeat("large body ", 10))
+	now := time.Now()
+	for _, id := range []string{"1", "2", "3"} {
+		flow := createFlow(id, now)
+		flow.GetHttpFlow().SetResponse(mitmproxyv1.Response_builder{Content: body}.Build())
+		flow.SetPinned(id == "3")
+		require.NoError(t, s.SaveFlow(flow))
+	}
+
+	deleted, err := s.DeleteAllFlows()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"1", "2"}, deleted)
+	assert.Len(t, s.GetFlows(), 1)
+
+	restored, err := s.RestoreTrashed([]string{"1", "missing", "../3"})
+	require.NoError(t, err)
+	require.Len(t, restored, 1)
+	assert.Equal(t, "1", GetFlowID(restored[0]))
+	flow, ok := s.GetFlow("1")
+	require.True(t, ok)
+	hydrated, err := s.HydrateFlow(context.Background(), flow)
+	require.NoError(t, err)
+	assert.Equal(t, body, hydrated.GetHttpFlow().GetResponse().GetContent(), "blobs must survive the trash")
+	assert.NoFileExists(t, filepath.Join(tmpDir, trashDirName, "1.bin"))
+
+	// Flows that have been in the trash past the retention are purged.
+	old := now.Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(tmpDir, trashDirName, "2.bin"), old, old))
+	s.purgeTrash()
+	restored, err = s.RestoreTrashed([]string{"2"})
+	require.NoError(t, err)
+	assert.Empty(t, restored)
+}
+
+func TestFlowStorage_TrashDisabled(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_test")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(tmpDir)) })
+
+	s, err := NewFlowStorage(tmpDir, 10)
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.NoError(t, s.SaveFlow(createFlow("1", time.Now())))
+	_, err = s.DeleteFlows([]string{"1"})
+	require.NoError(t, err)
+	_, err = s.RestoreTrashed([]string{"1"})
+	assert.Error(t, err)
+	s.flush()
+	assert.NoDirExists(t, filepath.Join(tmpDir, trashDirName))
+	assert.NoFileExists(t, filepath.Join(tmpDir, "1.bin"))
+}
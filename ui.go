@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strings"
+)
+
+// uiConfig is the configuration injected into index.html for the frontend.
+type uiConfig struct {
+	// BasePath is the path prefix the server is mounted under, if any.
+	BasePath string
+	// PublicURL overrides the address the UI sends RPCs to. When empty it
+	// is derived from each request.
+	PublicURL string
+	// LoginPath is where the UI sends users whose session has expired, when
+	// they sign in with OIDC.
+	LoginPath string
+}
+
+// grpcAddr returns the address the UI should send RPCs to. It is based on
+// how the client reached us rather than the bind address, so it works when
+// listening on 0.0.0.0, behind port forwarding, or behind a reverse proxy
+// that sets the X-Forwarded-Proto and X-Forwarded-Host headers.
+func (c uiConfig) grpcAddr(r *http.Request) string {
+	if c.PublicURL != "" {
+		return strings.TrimSuffix(c.PublicURL, "/")
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	host := r.Host
+	if fwd := r.Header.Get("X-Forwarded-Host"); fwd != "" {
+		host = strings.TrimSpace(strings.Split(fwd, ",")[0])
+	}
+	if host == "" {
+		// Use "." for relative URL so requests go relative to current page path
+		return "."
+	}
+	return scheme + "://" + host + c.BasePath
+}
+
+// script renders the config as a script tag defining the window globals the
+// frontend reads.
+func (c uiConfig) script(r *http.Request) string {
+	addr, _ := json.Marshal(c.grpcAddr(r))
+	basePath, _ := json.Marshal(c.BasePath)
+	if c.LoginPath != "" {
+		loginURL, _ := json.Marshal(c.BasePath + c.LoginPath)
+		return fmt.Sprintf(`<script>window.MITMFLOW_GRPC_ADDR = %s; window.MITMFLOW_BASE_PATH = %s; window.MITMFLOW_LOGIN_URL = %s;</script>`, addr, basePath, loginURL)
+	}
+	return fmt.Sprintf(`<script>window.MITMFLOW_GRPC_ADDR = %s; window.MITMFLOW_BASE_PATH = %s;</script>`, addr, basePath)
+}
+
+// newUIHandler serves the web UI from fsys, injecting the client
+// configuration into index.html.
+func newUIHandler(fsys fs.FS, cfg uiConfig) http.Handler {
+	staticHandler := http.FileServer(http.FS(fsys))
+
+	// Serve index.html for root and HTML requests
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" || r.URL.Path == "/index.html" {
+			// Read the index.html file from the embedded filesystem
+			indexHTML, err := fs.ReadFile(fsys, "index.html")
+			if err != nil {
+				http.Error(w, "index.html not found", http.StatusInternalServerError)
+				return
+			}
+
+			// Inject configuration into the HTML
+			modifiedHTML := strings.Replace(
+				string(indexHTML),
+				"<!-- MITMFLOW_CONFIG -->",
+				cfg.script(r),
+				1,
+			)
+
+			// Serve the modified HTML
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(modifiedHTML)) //nolint:errcheck
+			return
+		}
+
+		// For all other paths, serve static files
+		staticHandler.ServeHTTP(w, r)
+	})
+}
+
+// normalizeBasePath turns a -base-path value into the form "/prefix", or ""
+// when the server is mounted at the root.
+func normalizeBasePath(p string) string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return ""
+	}
+	return "/" + p
+}
+
+// mountAt serves h under basePath. Requests for the bare prefix are redirected
+// to "prefix/" so relative asset and RPC URLs in the UI resolve correctly.
+func mountAt(basePath string, h http.Handler) http.Handler {
+	if basePath == "" {
+		return h
+	}
+	mux := http.NewServeMux()
+	mux.Handle(basePath+"/", http.StripPrefix(basePath, h))
+	mux.Handle(basePath, http.RedirectHandler(basePath+"/", http.StatusMovedPermanently))
+	return mux
+}
+
+// overlayFS serves files from primary, falling back to fallback for files
+// primary doesn't have. It lets a directory on disk override individual
+// assets of the embedded UI.
+type overlayFS struct {
+	primary  fs.FS
+	fallback fs.FS
+}
+
+func (o overlayFS) Open(name string) (fs.File, error) {
+	f, err := o.primary.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return o.fallback.Open(name)
+	}
+	return f, err
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+func TestUIHandler_Overlay(t *testing.T) {
+	embedded := fstest.MapFS{
+		"index.html":     {Data: []byte("<html><!-- MITMFLOW_CONFIG --></html>")},
+		"assets/app.js":  {Data: []byte("embedded js")},
+		"assets/app.css": {Data: []byte("embedded css")},
+	}
+	disk := fstest.MapFS{
+		"assets/app.css": {Data: []byte("custom css")},
+	}
+	handler := newUIHandler(overlayFS{primary: disk, fallback: embedded}, uiConfig{})
+
+	get := func(path string) string {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		require.Equal(t, 200, rec.Code, path)
+		body, err := io.ReadAll(rec.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	assert.Equal(t, "custom css", get("/assets/app.css"))
+	assert.Equal(t, "embedded js", get("/assets/app.js"))
+	assert.Contains(t, get("/"), `window.MITMFLOW_GRPC_ADDR = "http://example.com"`)
+}
+
+func TestMountAt_BasePath(t *testing.T) {
+	basePath := normalizeBasePath("mitmflow/")
+	assert.Equal(t, "/mitmflow", basePath)
+	assert.Equal(t, "", normalizeBasePath("/"))
+
+	ui := newUIHandler(fstest.MapFS{
+		"index.html": {Data: []byte("<html><!-- MITMFLOW_CONFIG --></html>")},
+	}, uiConfig{BasePath: basePath})
+	handler := mountAt(basePath, ui)
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		return rec
+	}
+
+	rec := serve("/mitmflow")
+	assert.Equal(t, 301, rec.Code)
+	assert.Equal(t, "/mitmflow/", rec.Header().Get("Location"))
+
+	rec = serve("/mitmflow/")
+	assert.Equal(t, 200, rec.Code)
+	assert.Contains(t, rec.Body.String(), `window.MITMFLOW_GRPC_ADDR = "http://example.com/mitmflow"; window.MITMFLOW_BASE_PATH = "/mitmflow";`)
+
+	assert.Equal(t, 404, serve("/index.html").Code)
+}
+
+func TestUIConfig_GRPCAddr(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Host = "0.0.0.0:50051"
+	assert.Equal(t, "http://0.0.0.0:50051", uiConfig{}.grpcAddr(req))
+
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req.Header.Set("X-Forwarded-Host", "flows.example.com, proxy.internal")
+	assert.Equal(t, "https://flows.example.com/mitmflow", uiConfig{BasePath: "/mitmflow"}.grpcAddr(req))
+
+	cfg := uiConfig{BasePath: "/mitmflow", PublicURL: "https://public.example.com/mitmflow/"}
+	assert.Equal(t, "https://public.example.com/mitmflow", cfg.grpcAddr(req))
+}
+
+func TestNoUI(t *testing.T) {
+	server, _ := newTestServer(t)
+	// A nil UI handler is what -no-ui gives newServeMux.
+	ts := httptest.NewServer(newServeMux(server, nil, nil))
+	defer ts.Close()
+
+	for _, path := range []string{"/", "/index.html"} {
+		resp, err := ts.Client().Get(ts.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close() //nolint:errcheck
+		assert.Equal(t, 404, resp.StatusCode, path)
+	}
+
+	_, err := mitmflowv1.NewServiceClient(ts.Client(), ts.URL).GetServerInfo(context.Background(), connect.NewRequest(&mitmflowv1.GetServerInfoRequest{}))
+	assert.NoError(t, err, "the RPCs are still served")
+}
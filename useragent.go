@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/mileusna/useragent"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// parseUserAgent splits a User-Agent header into browser, OS and device
+// fields. It returns nil for an empty header.
+func parseUserAgent(header string) *mitmflowv1.UserAgent {
+	if header == "" {
+		return nil
+	}
+	ua := useragent.Parse(header)
+	deviceType := mitmflowv1.DeviceType_DEVICE_TYPE_UNSPECIFIED
+	switch {
+	case ua.Bot:
+		deviceType = mitmflowv1.DeviceType_DEVICE_TYPE_BOT
+	case ua.Tablet:
+		deviceType = mitmflowv1.DeviceType_DEVICE_TYPE_TABLET
+	case ua.Mobile:
+		deviceType = mitmflowv1.DeviceType_DEVICE_TYPE_MOBILE
+	case ua.Desktop:
+		deviceType = mitmflowv1.DeviceType_DEVICE_TYPE_DESKTOP
+	}
+	return mitmflowv1.UserAgent_builder{
+		Browser:        proto.String(ua.Name),
+		BrowserVersion: proto.String(ua.Version),
+		Os:             proto.String(ua.OS),
+		OsVersion:      proto.String(ua.OSVersion),
+		Device:         proto.String(ua.Device),
+		DeviceType:     deviceType.Enum(),
+	}.Build()
+}
+
+// deviceTypeName is the lowercase name of a device type, e.g. "mobile".
+func deviceTypeName(t mitmflowv1.DeviceType) string {
+	if t == mitmflowv1.DeviceType_DEVICE_TYPE_UNSPECIFIED {
+		return ""
+	}
+	return strings.ToLower(strings.TrimPrefix(t.String(), "DEVICE_TYPE_"))
+}
+
+// matchClientFamily reports whether the browser, OS or device type of ua is
+// one of families.
+func matchClientFamily(ua *mitmflowv1.UserAgent, families []string) bool {
+	if ua == nil {
+		return false
+	}
+	for _, family := range families {
+		for _, value := range []string{ua.GetBrowser(), ua.GetOs(), deviceTypeName(ua.GetDeviceType())} {
+			if value != "" && strings.EqualFold(family, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
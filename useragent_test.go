@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	iPhoneSafariUA  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1"
+	windowsChromeUA = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	assert.Nil(t, parseUserAgent(""))
+
+	ua := parseUserAgent(iPhoneSafariUA)
+	require.NotNil(t, ua)
+	assert.Equal(t, "Safari", ua.GetBrowser())
+	assert.Equal(t, "17.1", ua.GetBrowserVersion())
+	assert.Equal(t, "iOS", ua.GetOs())
+	assert.Equal(t, "iPhone", ua.GetDevice())
+	assert.Equal(t, mitmflowv1.DeviceType_DEVICE_TYPE_MOBILE, ua.GetDeviceType())
+
+	ua = parseUserAgent(windowsChromeUA)
+	assert.Equal(t, "Chrome", ua.GetBrowser())
+	assert.Equal(t, "Windows", ua.GetOs())
+	assert.Equal(t, mitmflowv1.DeviceType_DEVICE_TYPE_DESKTOP, ua.GetDeviceType())
+}
+
+func TestMatchFlow_ClientFamilies(t *testing.T) {
+	s := &MITMFlowServer{}
+	newFlow := func(userAgent string) *mitmflowv1.Flow {
+		flow := mitmflowv1.Flow_builder{
+			HttpFlow: mitmproxyv1.HTTPFlow_builder{
+				Request: mitmproxyv1.Request_builder{
+					Method:  proto.String("GET"),
+					Url:     proto.String("https://example.com/"),
+					Headers: map[string]string{"user-agent": userAgent},
+				}.Build(),
+			}.Build(),
+		}.Build()
+		s.preprocessFlow(flow)
+		return flow
+	}
+	filter := func(families ...string) *mitmflowv1.FlowFilter {
+		return mitmflowv1.FlowFilter_builder{
+			Http: mitmflowv1.HttpFilter_builder{ClientFamilies: families}.Build(),
+		}.Build()
+	}
+
+	iphone, desktop, none := newFlow(iPhoneSafariUA), newFlow(windowsChromeUA), newFlow("")
+	assert.True(t, matchFlow(iphone, filter("ios")))
+	assert.True(t, matchFlow(iphone, filter("Mobile")))
+	assert.False(t, matchFlow(desktop, filter("iOS", "mobile")))
+	assert.True(t, matchFlow(desktop, filter("iOS", "chrome")))
+	assert.False(t, matchFlow(none, filter("chrome")))
+	assert.True(t, matchFlow(none, filter()))
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+const (
+	watchDirInterval = 2 * time.Second
+	// Imported files are moved into these subdirectories of the watched
+	// directory, so they aren't imported again after a restart.
+	watchDirImported = "imported"
+	watchDirFailed   = "failed"
+	// sourceMetadataKey names the file a flow was imported from.
+	sourceMetadataKey = "source"
+)
+
+// dirWatcher imports mitmproxy dumps and HAR files that appear in a
+// directory. The directory is polled; a file is imported once its size and
+// modification time stayed the same between two polls, so files that are
+// still being copied in are left alone.
+type dirWatcher struct {
+	server *MITMFlowServer
+	dir    string
+	seen   map[string]os.FileInfo
+}
+
+func newDirWatcher(server *MITMFlowServer, dir string) (*dirWatcher, error) {
+	for _, sub := range []string{watchDirImported, watchDirFailed} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return nil, err
+		}
+	}
+	return &dirWatcher{server: server, dir: dir, seen: make(map[string]os.FileInfo)}, nil
+}
+
+// run polls the directory every interval until ctx is done.
+func (w *dirWatcher) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		w.poll()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *dirWatcher) poll() {
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		log.Printf("watch-dir: %v", err)
+		return
+	}
+	present := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || strings.HasPrefix(name, ".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		present[name] = true
+		prev, ok := w.seen[name]
+		w.seen[name] = info
+		if ok && info.Size() > 0 && prev.Size() == info.Size() && prev.ModTime().Equal(info.ModTime()) {
+			delete(w.seen, name)
+			w.importFile(name)
+		}
+	}
+	for name := range w.seen {
+		if !present[name] {
+			delete(w.seen, name)
+		}
+	}
+}
+
+func (w *dirWatcher) importFile(name string) {
+	filename := filepath.Join(w.dir, name)
+	dest := watchDirImported
+	data, err := os.ReadFile(filename)
+	var flows []*mitmflowv1.Flow
+	if err == nil {
+		flows, err = parseImportFile(name, data)
+	}
+	if err != nil {
+		log.Printf("watch-dir: failed to import %s: %v", name, err)
+		dest = watchDirFailed
+	}
+	for _, flow := range flows {
+		metadata := flow.GetMetadata()
+		if metadata == nil {
+			metadata = make(map[string]string)
+		}
+		metadata[sourceMetadataKey] = name
+		flow.SetMetadata(metadata)
+		w.server.ingest(flow)
+	}
+	if err == nil {
+		log.Printf("watch-dir: imported %d flows from %s", len(flows), name)
+	}
+	if err := os.Rename(filename, filepath.Join(w.dir, dest, name)); err != nil {
+		log.Printf("watch-dir: failed to move %s: %v", name, err)
+	}
+}
+
+// parseImportFile converts a HAR file or mitmproxy flow dump.
+func parseImportFile(name string, data []byte) ([]*mitmflowv1.Flow, error) {
+	switch {
+	case strings.EqualFold(filepath.Ext(name), ".har"), bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")):
+		return ParseHAR(data)
+	case isMitmproxyDump(data):
+		return ParseMitmproxyDump(data)
+	}
+	return nil, errors.New("not a HAR file or mitmproxy dump")
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	mitmproxyv1 "github.com/sudorandom/mitmflow/gen/go/mitmproxygrpc/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// tnet encodes a value as a tnetstring, the way mitmproxy writes dumps.
+func tnet(v any) string {
+	wrap := func(payload string, kind byte) string {
+		return fmt.Sprintf("%d:%s%c", len(payload), payload, kind)
+	}
+	switch v := v.(type) {
+	case []byte:
+		return wrap(string(v), ',')
+	case string:
+		return wrap(v, ';')
+	case int:
+		return wrap(fmt.Sprint(v), '#')
+	case float64:
+		return wrap(fmt.Sprint(v), '^')
+	case bool:
+		return wrap(fmt.Sprint(v), '!')
+	case nil:
+		return wrap("", '~')
+	case []any:
+		var b strings.Builder
+		for _, item := range v {
+			b.WriteString(tnet(item))
+		}
+		return wrap(b.String(), ']')
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var b strings.Builder
+		for _, k := range keys {
+			b.WriteString(tnet(k) + tnet(v[k]))
+		}
+		return wrap(b.String(), '}')
+	}
+	panic(fmt.Sprintf("tnet: unsupported type %T", v))
+}
+
+func testMitmproxyDump() []byte {
+	httpFlow := map[string]any{
+		"type": "http",
+		"id":   "http-1",
+		"client_conn": map[string]any{
+			"id":              "c1",
+			"peername":        []any{"127.0.0.1", 50000},
+			"timestamp_start": 1714564800.0,
+		},
+		"server_conn": map[string]any{
+			"id":          "s1",
+			"address":     []any{"example.com", 8443},
+			"sni":         "example.com",
+			"tls_version": "TLSv1.3",
+		},
+		"request": map[string]any{
+			"host":            "example.com",
+			"port":            8443,
+			"scheme":          []byte("https"),
+			"method":          []byte("POST"),
+			"path":            []byte("/api?q=1"),
+			"http_version":    []byte("HTTP/1.1"),
+			"headers":         []any{[]any{[]byte("Content-Type"), []byte("text/plain")}, []any{[]byte("Accept"), []byte("a")}, []any{[]byte("accept"), []byte("b")}},
+			"content":         []byte("hello"),
+			"timestamp_start": 1714564800.25,
+			"timestamp_end":   1714564800.5,
+		},
+		"response": map[string]any{
+			"status_code":     201,
+			"reason":          []byte("Created"),
+			"http_version":    []byte("HTTP/1.1"),
+			"headers":         []any{[]any{[]byte("Set-Cookie"), []byte("a=1")}, []any{[]byte("Set-Cookie"), []byte("b=2")}},
+			"content":         []byte("world"),
+			"timestamp_start": 1714564800.75,
+			"timestamp_end":   1714564801.25,
+		},
+		"comment": "from the dump",
+		"error":   nil,
+	}
+	tcpFlow := map[string]any{
+		"type":        "tcp",
+		"id":          "tcp-1",
+		"client_conn": map[string]any{"timestamp_start": 1714564802.0},
+		"server_conn": map[string]any{"address": []any{"10.0.0.1", 5432}},
+		"messages":    []any{[]any{true, []byte("ping"), 1714564802.5}, []any{false, []byte("pong"), 1714564803.0}},
+		"error":       map[string]any{"msg": "connection reset", "timestamp": 1714564803.0},
+	}
+	dnsFlow := map[string]any{"type": "dns", "id": "dns-1"}
+	return []byte(tnet(httpFlow) + tnet(tcpFlow) + tnet(dnsFlow))
+}
+
+func TestParseMitmproxyDump(t *testing.T) {
+	data := testMitmproxyDump()
+	require.True(t, isMitmproxyDump(data))
+	flows, err := ParseMitmproxyDump(data)
+	require.NoError(t, err)
+	require.Len(t, flows, 2, "DNS flows are skipped")
+
+	h := flows[0].GetHttpFlow()
+	assert.Equal(t, "http-1", h.GetId())
+	assert.Equal(t, "from the dump", flows[0].GetNote())
+	assert.Equal(t, "https://example.com:8443/api?q=1", h.GetRequest().GetUrl())
+	assert.Equal(t, "POST", h.GetRequest().GetMethod())
+	assert.Equal(t, map[string]string{"Content-Type": "text/plain", "Accept": "a, b"}, h.GetRequest().GetHeaders())
+	assert.Equal(t, "hello", string(h.GetRequest().GetContent()))
+	assert.Equal(t, int32(201), h.GetResponse().GetStatusCode())
+	assert.Equal(t, "Created", h.GetResponse().GetReason())
+	assert.Equal(t, "a=1\nb=2", h.GetResponse().GetHeaders()["Set-Cookie"])
+	assert.Equal(t, time.Unix(1714564800, 250e6).UTC(), h.GetTimestampStart().AsTime())
+	assert.InDelta(t, 1000, h.GetDurationMs(), 0.001)
+	assert.Equal(t, "127.0.0.1", h.GetClient().GetPeernameHost())
+	assert.Equal(t, "example.com", h.GetServer().GetAddressHost())
+	assert.Equal(t, uint32(8443), h.GetServer().GetAddressPort())
+	assert.True(t, h.GetServer().GetTls())
+	assert.False(t, h.HasError())
+
+	tcp := flows[1].GetTcpFlow()
+	assert.Equal(t, "connection reset", tcp.GetError())
+	require.Len(t, tcp.GetMessages(), 2)
+	assert.True(t, tcp.GetMessages()[0].GetFromClient())
+	assert.Equal(t, "pong", string(tcp.GetMessages()[1].GetContent()))
+
+	_, err = ParseMitmproxyDump([]byte("5:hello,"))
+	assert.Error(t, err)
+	_, err = ParseMitmproxyDump([]byte("10:hello}"))
+	assert.Error(t, err)
+}
+
+func TestParseHAR(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	har, err := GenerateHAR([]*mitmflowv1.Flow{mitmflowv1.Flow_builder{
+		HttpFlow: mitmproxyv1.HTTPFlow_builder{
+			Id:             proto.String("original"),
+			TimestampStart: timestamppb.New(start),
+			DurationMs:     proto.Float64(250),
+			Request: mitmproxyv1.Request_builder{
+				Method:         proto.String("POST"),
+				Url:            proto.String("https://example.com/api"),
+				HttpVersion:    proto.String("HTTP/2.0"),
+				Headers:        map[string]string{"Content-Type": "application/json"},
+				Content:        []byte(`{"a":1}`),
+				TimestampStart: timestamppb.New(start),
+			}.Build(),
+			Response: mitmproxyv1.Response_builder{
+				StatusCode:  proto.Int32(200),
+				HttpVersion: proto.String("HTTP/2.0"),
+				Headers:     map[string]string{"Content-Type": "image/png"},
+				Content:     []byte{0x89, 'P', 'N', 'G', 0},
+				Trailers:    map[string]string{"grpc-status": "0"},
+			}.Build(),
+		}.Build(),
+		Metadata: map[string]string{"build": "7"},
+	}.Build()})
+	require.NoError(t, err)
+
+	flows, err := ParseHAR(har)
+	require.NoError(t, err)
+	require.Len(t, flows, 1)
+	again, err := ParseHAR(har)
+	require.NoError(t, err)
+	assert.Equal(t, GetFlowID(flows[0]), GetFlowID(again[0]), "IDs should be stable")
+
+	h := flows[0].GetHttpFlow()
+	assert.Equal(t, start, h.GetTimestampStart().AsTime())
+	assert.Equal(t, "POST", h.GetRequest().GetMethod())
+	assert.Equal(t, "https://example.com/api", h.GetRequest().GetUrl())
+	assert.Equal(t, `{"a":1}`, string(h.GetRequest().GetContent()))
+	assert.Equal(t, "application/json", getHeaderValue(h.GetRequest().GetHeaders(), "Content-Type"))
+	assert.Equal(t, int32(200), h.GetResponse().GetStatusCode())
+	assert.Equal(t, []byte{0x89, 'P', 'N', 'G', 0}, h.GetResponse().GetContent())
+	assert.Equal(t, map[string]string{"grpc-status": "0"}, h.GetResponse().GetTrailers())
+	assert.Equal(t, "example.com", h.GetServer().GetAddressHost())
+	assert.Equal(t, uint32(443), h.GetServer().GetAddressPort())
+	assert.Equal(t, map[string]string{"build": "7"}, flows[0].GetMetadata())
+
+	_, err = ParseHAR([]byte("{"))
+	assert.Error(t, err)
+}
+
+func TestDirWatcher(t *testing.T) {
+	server, storage := newTestServer(t)
+	dir, err := os.MkdirTemp("", "mitmflow_watch")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(dir)) })
+	watcher, err := newDirWatcher(server, dir)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "laptop.mitm"), testMitmproxyDump(), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a capture"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".partial"), testMitmproxyDump(), 0644))
+
+	// Files are only picked up once they stop changing.
+	watcher.poll()
+	assert.Empty(t, storage.GetFlows())
+	watcher.poll()
+
+	flow, ok := storage.GetFlow("http-1")
+	require.True(t, ok)
+	assert.Equal(t, "laptop.mitm", flow.GetMetadata()[sourceMetadataKey])
+	flow, ok = storage.GetFlow("tcp-1")
+	require.True(t, ok)
+	assert.Equal(t, "laptop.mitm", flow.GetMetadata()[sourceMetadataKey])
+	assert.NotNil(t, flow.GetStreamFlowExtra(), "imported flows are preprocessed")
+
+	assert.FileExists(t, filepath.Join(dir, watchDirImported, "laptop.mitm"))
+	assert.FileExists(t, filepath.Join(dir, watchDirFailed, "notes.txt"))
+	assert.FileExists(t, filepath.Join(dir, ".partial"))
+	assert.NoFileExists(t, filepath.Join(dir, "laptop.mitm"))
+}
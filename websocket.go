@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"buf.build/go/protovalidate"
+	"connectrpc.com/connect"
+	"github.com/coder/websocket"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// wsFlowsPath serves StreamFlows over a WebSocket for networks where proxies
+// break long-lived HTTP/2 streams. The client sends a StreamFlowsRequest as
+// JSON in the first message and then receives one StreamFlowsResponse, also
+// JSON, per text message.
+const wsFlowsPath = "/ws/flows"
+
+const (
+	wsRequestTimeout = 10 * time.Second
+	wsWriteTimeout   = 10 * time.Second
+)
+
+func (s *MITMFlowServer) handleFlowsWebSocket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if s.auth != nil {
+		p, err := s.auth.authorizeRequest(r, requiredRole(mitmflowv1.ServiceStreamFlowsProcedure))
+		if err != nil {
+			status := http.StatusUnauthorized
+			if connect.CodeOf(err) == connect.CodePermissionDenied {
+				status = http.StatusForbidden
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		ctx = context.WithValue(ctx, principalKey{}, p)
+	}
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		// Matches the CORS policy for the Vite dev server.
+		OriginPatterns: []string{"localhost:5173"},
+		// Summaries are JSON and compress well.
+		CompressionMode: websocket.CompressionContextTakeover,
+	})
+	if err != nil {
+		log.Printf("websocket accept failed: %v", err)
+		return
+	}
+	defer conn.CloseNow() //nolint:errcheck
+
+	readCtx, cancel := context.WithTimeout(ctx, wsRequestTimeout)
+	_, data, err := conn.Read(readCtx)
+	cancel()
+	if err != nil {
+		return
+	}
+	req := &mitmflowv1.StreamFlowsRequest{}
+	if err := protojson.Unmarshal(data, req); err != nil {
+		conn.Close(websocket.StatusUnsupportedData, "invalid StreamFlowsRequest") //nolint:errcheck
+		return
+	}
+	if err := protovalidate.Validate(req); err != nil {
+		conn.Close(websocket.StatusPolicyViolation, err.Error()) //nolint:errcheck
+		return
+	}
+
+	// The client doesn't send anything else; CloseRead handles control frames
+	// and cancels ctx when the client goes away.
+	ctx = conn.CloseRead(ctx)
+	err = s.streamFlows(ctx, r.RemoteAddr, req, func(resp *mitmflowv1.StreamFlowsResponse) error {
+		data, err := protojson.Marshal(resp)
+		if err != nil {
+			return err
+		}
+		writeCtx, cancel := context.WithTimeout(ctx, wsWriteTimeout)
+		defer cancel()
+		return conn.Write(writeCtx, websocket.MessageText, data)
+	})
+	if err != nil && ctx.Err() == nil {
+		log.Printf("websocket stream failed: %v", err)
+		conn.Close(websocket.StatusInternalError, "stream failed") //nolint:errcheck
+	}
+}
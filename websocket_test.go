@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestFlowsWebSocket(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mitmflow_ws_test")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(tmpDir)) })
+	storage, err := NewFlowStorage(tmpDir, 100)
+	require.NoError(t, err)
+	defer storage.Close()
+	server, err := NewMITMFlowServer(storage, NewRegistry())
+	require.NoError(t, err)
+
+	base := time.Now()
+	require.NoError(t, storage.SaveFlow(createFlow("old", base)))
+	require.NoError(t, storage.SaveFlow(createFlow("backfilled", base.Add(time.Second))))
+
+	ts := httptest.NewServer(http.HandlerFunc(server.handleFlowsWebSocket))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(ts.URL, "http")+wsFlowsPath, nil)
+	require.NoError(t, err)
+	defer conn.CloseNow() //nolint:errcheck
+
+	req, err := protojson.Marshal(mitmflowv1.StreamFlowsRequest_builder{
+		SinceTimestampNs: proto.Int64(base.UnixNano()),
+	}.Build())
+	require.NoError(t, err)
+	require.NoError(t, conn.Write(ctx, websocket.MessageText, req))
+
+	read := func() *mitmflowv1.StreamFlowsResponse {
+		typ, data, err := conn.Read(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, websocket.MessageText, typ)
+		resp := &mitmflowv1.StreamFlowsResponse{}
+		require.NoError(t, protojson.Unmarshal(data, resp))
+		return resp
+	}
+	assert.Equal(t, "backfilled", read().GetFlow().GetId())
+
+	live := createFlow("live", base.Add(2*time.Second))
+	require.Eventually(t, func() bool {
+		server.mu.RLock()
+		defer server.mu.RUnlock()
+		return len(server.subscribers) == 1
+	}, time.Second, 10*time.Millisecond)
+	server.broadcast(live)
+	assert.Equal(t, "live", read().GetFlow().GetId())
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"mime"
+	"strings"
+
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	soap11Namespace = "http://schemas.xmlsoap.org/soap/envelope/"
+	soap12Namespace = "http://www.w3.org/2003/05/soap-envelope"
+)
+
+func isXMLMediaType(mediaType string) bool {
+	return mediaType == "text/xml" || mediaType == "application/xml" || strings.HasSuffix(mediaType, "+xml")
+}
+
+// setXMLFrames renders an XML body as an indented frame. SOAP envelopes are
+// also described in details, and a Fault gets a frame of its own after the
+// envelope.
+func setXMLFrames(content []byte, headers map[string]string, details *mitmflowv1.MessageDetails) {
+	if len(details.GetTextualFrames()) > 0 || len(content) == 0 {
+		return
+	}
+	mediaType, params, _ := mime.ParseMediaType(getHeaderValue(headers, "Content-Type"))
+	if !isXMLMediaType(mediaType) {
+		return
+	}
+	if decoded, ok := decodeContentEncoding(content, getHeaderValue(headers, "Content-Encoding")); ok {
+		content = decoded
+	}
+	pretty, ok := prettyXML(content)
+	if !ok {
+		return
+	}
+	frames := []string{capFrame(pretty)}
+	if soap := parseSOAP(content); soap != nil {
+		action := strings.Trim(getHeaderValue(headers, "SOAPAction"), `"`)
+		if action == "" {
+			action = params["action"]
+		}
+		soap.SetAction(action)
+		details.SetSoap(soap)
+		if soap.HasFault() {
+			frames = append(frames, capFrame(soapFaultFrame(soap.GetFault())))
+		}
+	}
+	details.SetTextualFrames(frames)
+}
+
+var (
+	xmlTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	xmlAttrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+)
+
+// prettyXML indents an XML document, or a sequence of elements, two spaces
+// per level. Elements holding only text stay on one line and empty ones are
+// self-closed. Namespace prefixes are kept as written.
+func prettyXML(data []byte) (string, bool) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	var b strings.Builder
+	// stack holds the names of the elements being written, so mismatched end
+	// tags are caught even though RawToken doesn't check them.
+	var stack []string
+	elements := 0
+	// open is set while the last start tag is waiting for its '>', and inline
+	// while the current element's text is on the start tag's line.
+	open, inline := false, false
+	closeStart := func() {
+		if open {
+			b.WriteByte('>')
+			open = false
+		}
+	}
+	line := func() {
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(strings.Repeat("  ", len(stack)))
+	}
+	for {
+		tok, err := dec.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", false
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			closeStart()
+			line()
+			b.WriteString("<" + rawXMLName(t.Name))
+			for _, a := range t.Attr {
+				b.WriteString(" " + rawXMLName(a.Name) + `="` + xmlAttrEscaper.Replace(a.Value) + `"`)
+			}
+			open, inline = true, false
+			stack = append(stack, rawXMLName(t.Name))
+			elements++
+		case xml.EndElement:
+			if len(stack) == 0 || stack[len(stack)-1] != rawXMLName(t.Name) {
+				return "", false
+			}
+			stack = stack[:len(stack)-1]
+			switch {
+			case open:
+				b.WriteString("/>")
+			case inline:
+				b.WriteString("</" + rawXMLName(t.Name) + ">")
+			default:
+				line()
+				b.WriteString("</" + rawXMLName(t.Name) + ">")
+			}
+			open, inline = false, false
+		case xml.CharData:
+			text := strings.TrimSpace(string(t))
+			if text == "" {
+				continue
+			}
+			if open {
+				closeStart()
+				inline = true
+			} else {
+				line()
+			}
+			b.WriteString(xmlTextEscaper.Replace(text))
+		case xml.Comment:
+			closeStart()
+			line()
+			b.WriteString("<!--" + string(t) + "-->")
+		case xml.ProcInst:
+			closeStart()
+			line()
+			b.WriteString("<?" + t.Target + " " + string(t.Inst) + "?>")
+		case xml.Directive:
+			closeStart()
+			line()
+			b.WriteString("<!" + string(t) + ">")
+		}
+	}
+	if len(stack) != 0 || elements == 0 {
+		return "", false
+	}
+	return b.String(), true
+}
+
+func rawXMLName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// xmlElement is any element, for walking SOAP envelopes.
+type xmlElement struct {
+	XMLName  xml.Name
+	Children []xmlElement `xml:",any"`
+	Text     string       `xml:",chardata"`
+	Inner    string       `xml:",innerxml"`
+}
+
+// child returns the first child element with the given local name.
+func (e *xmlElement) child(local string) *xmlElement {
+	for i := range e.Children {
+		if e.Children[i].XMLName.Local == local {
+			return &e.Children[i]
+		}
+	}
+	return nil
+}
+
+func (e *xmlElement) text() string {
+	if e == nil {
+		return ""
+	}
+	return strings.TrimSpace(e.Text)
+}
+
+// parseSOAP describes a SOAP 1.1 or 1.2 envelope, or returns nil when the
+// document isn't one.
+func parseSOAP(data []byte) *mitmflowv1.SoapMessage {
+	var env xmlElement
+	if err := xml.Unmarshal(data, &env); err != nil || env.XMLName.Local != "Envelope" {
+		return nil
+	}
+	var version string
+	switch env.XMLName.Space {
+	case soap11Namespace:
+		version = "1.1"
+	case soap12Namespace:
+		version = "1.2"
+	default:
+		return nil
+	}
+	msg := mitmflowv1.SoapMessage_builder{Version: proto.String(version)}.Build()
+	body := env.child("Body")
+	if body == nil || len(body.Children) == 0 {
+		return msg
+	}
+	op := &body.Children[0]
+	msg.SetOperation(op.XMLName.Local)
+	if op.XMLName.Local != "Fault" || op.XMLName.Space != env.XMLName.Space {
+		return msg
+	}
+
+	fault := &mitmflowv1.SoapFault{}
+	detail := op.child("detail")
+	if version == "1.1" {
+		fault.SetCode(op.child("faultcode").text())
+		fault.SetReason(op.child("faultstring").text())
+		fault.SetActor(op.child("faultactor").text())
+	} else {
+		if code := op.child("Code"); code != nil {
+			fault.SetCode(code.child("Value").text())
+		}
+		if reason := op.child("Reason"); reason != nil {
+			fault.SetReason(reason.child("Text").text())
+		}
+		fault.SetActor(op.child("Node").text())
+		detail = op.child("Detail")
+	}
+	if detail != nil {
+		inner := strings.TrimSpace(detail.Inner)
+		if strings.HasPrefix(inner, "<") {
+			if pretty, ok := prettyXML([]byte(inner)); ok {
+				inner = pretty
+			}
+		}
+		fault.SetDetail(inner)
+	}
+	msg.SetFault(fault)
+	return msg
+}
+
+// soapFaultFrame is the frame a SOAP Fault is shown in.
+func soapFaultFrame(fault *mitmflowv1.SoapFault) string {
+	var b strings.Builder
+	b.WriteString("SOAP Fault\n")
+	b.WriteString("Code: " + fault.GetCode() + "\n")
+	b.WriteString("Reason: " + fault.GetReason() + "\n")
+	if fault.GetActor() != "" {
+		b.WriteString("Actor: " + fault.GetActor() + "\n")
+	}
+	if fault.GetDetail() != "" {
+		b.WriteString("Detail:\n" + fault.GetDetail() + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mitmflowv1 "github.com/sudorandom/mitmflow/gen/go/mitmflow/v1"
+)
+
+func TestPrettyXML(t *testing.T) {
+	pretty, ok := prettyXML([]byte(`<?xml version="1.0"?><a x="1&amp;2"><b>hi &lt;there&gt;</b><c/><d>text<e/></d><!-- note --></a>`))
+	require.True(t, ok)
+	assert.Equal(t, `<?xml version="1.0"?>
+<a x="1&amp;2">
+  <b>hi &lt;there&gt;</b>
+  <c/>
+  <d>text
+    <e/>
+  </d>
+  <!-- note -->
+</a>`, pretty)
+
+	_, ok = prettyXML([]byte(`<a><b></a></b>`))
+	assert.False(t, ok)
+	_, ok = prettyXML([]byte(`not xml`))
+	assert.False(t, ok)
+}
+
+func TestSetXMLFrames_SOAP(t *testing.T) {
+	details := &mitmflowv1.MessageDetails{}
+	setXMLFrames([]byte(`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><m:GetQuote xmlns:m="urn:stocks"><m:Symbol>ACME</m:Symbol></m:GetQuote></soap:Body></soap:Envelope>`),
+		map[string]string{"Content-Type": "text/xml; charset=utf-8", "SOAPAction": `"urn:stocks#GetQuote"`}, details)
+	require.Len(t, details.GetTextualFrames(), 1)
+	assert.Contains(t, details.GetTextualFrames()[0], "    <m:GetQuote xmlns:m=\"urn:stocks\">\n      <m:Symbol>ACME</m:Symbol>")
+	assert.Equal(t, "1.1", details.GetSoap().GetVersion())
+	assert.Equal(t, "urn:stocks#GetQuote", details.GetSoap().GetAction())
+	assert.Equal(t, "GetQuote", details.GetSoap().GetOperation())
+	assert.False(t, details.GetSoap().HasFault())
+
+	details = &mitmflowv1.MessageDetails{}
+	setXMLFrames([]byte(`<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope">
+  <env:Body>
+    <env:Fault>
+      <env:Code><env:Value>env:Sender</env:Value></env:Code>
+      <env:Reason><env:Text xml:lang="en">Unknown symbol</env:Text></env:Reason>
+      <env:Detail><e:symbol xmlns:e="urn:stocks">XYZ</e:symbol></env:Detail>
+    </env:Fault>
+  </env:Body>
+</env:Envelope>`), map[string]string{"Content-Type": `application/soap+xml; action="urn:stocks#GetQuote"`}, details)
+	soap := details.GetSoap()
+	assert.Equal(t, "1.2", soap.GetVersion())
+	assert.Equal(t, "urn:stocks#GetQuote", soap.GetAction())
+	assert.Equal(t, "Fault", soap.GetOperation())
+	assert.Equal(t, "env:Sender", soap.GetFault().GetCode())
+	assert.Equal(t, "Unknown symbol", soap.GetFault().GetReason())
+	assert.Equal(t, `<e:symbol xmlns:e="urn:stocks">XYZ</e:symbol>`, soap.GetFault().GetDetail())
+	require.Len(t, details.GetTextualFrames(), 2)
+	assert.Equal(t, "SOAP Fault\nCode: env:Sender\nReason: Unknown symbol\nDetail:\n<e:symbol xmlns:e=\"urn:stocks\">XYZ</e:symbol>", details.GetTextualFrames()[1])
+
+	details = &mitmflowv1.MessageDetails{}
+	setXMLFrames([]byte(`<feed/>`), map[string]string{"Content-Type": "application/atom+xml"}, details)
+	assert.Equal(t, []string{"<feed/>"}, details.GetTextualFrames())
+	assert.False(t, details.HasSoap())
+}